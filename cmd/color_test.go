@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+)
+
+func TestColorMode(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	mode := func(args ...string) (string, error) {
+		root := NewRootCmdForTesting()
+		require.NoError(t, root.ParseFlags(args))
+		return colorMode(root)
+	}
+
+	got, err := mode()
+	require.NoError(t, err)
+	assert.Equal(t, printer.ColorAuto, got)
+
+	got, err = mode("--color", "always")
+	require.NoError(t, err)
+	assert.Equal(t, printer.ColorAlways, got)
+
+	got, err = mode("--no-color")
+	require.NoError(t, err)
+	assert.Equal(t, printer.ColorNever, got, "--no-color is --color never")
+
+	_, err = mode("--color", "sometimes")
+	assert.ErrorContains(t, err, "invalid color mode: sometimes")
+
+	_, err = mode("--no-color", "--color", "always")
+	assert.ErrorContains(t, err, "--no-color conflicts with --color always")
+
+	root := NewRootCmdForTesting()
+	root.SetOut(io.Discard)
+	root.SetArgs([]string{"config", "set", "color_output", "false"})
+	require.NoError(t, root.Execute())
+	got, err = mode()
+	require.NoError(t, err)
+	assert.Equal(t, printer.ColorNever, got, "the color_output preference turns auto off")
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/dashboard"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/pager"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/spf13/cobra"
+)
+
+// stdioIsTerminal reports whether the dashboard can take over the terminal;
+// replaced in tests
+var stdioIsTerminal = func() bool {
+	return printer.IsTerminal(os.Stdin) && printer.IsTerminal(os.Stdout)
+}
+
+// newDashboardCommand creates the dashboard command
+func newDashboardCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dashboard",
+		Short: "Show a live view of deliverability, failing integrations and recent messages",
+		Long: `Open a live terminal view of the account for on-call use. The screen has three
+panes, each refreshed on its own interval:
+
+  1. Deliverability   totals and rates over the last --stats-window
+  2. Failing webhooks and routes
+                      integrations whose latest deliveries failed, longest
+                      error streak first
+  3. Recent messages  the newest messages within the last --stats-window
+
+The panes use the same API calls as 'ahasend stats deliverability
+--summary-only', 'ahasend webhooks list --include-stats', 'ahasend routes list'
+and 'ahasend messages list'. A pane that fails to refresh keeps its last data
+and shows the error in its title.
+
+KEYS:
+  tab, →, ↓, j, l     focus the next pane
+  shift+tab, ←, ↑, k, h
+                      focus the previous pane
+  1-3                 focus a pane directly
+  r                   refresh the focused pane now
+  q, esc, ctrl+c      quit
+
+The focused pane gets the most rows. The dashboard needs an interactive
+terminal; in scripts, use the commands above instead.`,
+		Example: `  # Open the dashboard for the active profile
+  ahasend dashboard
+
+  # Refresh recent messages every 5 seconds and look at the last hour
+  ahasend dashboard --messages-interval 5s --stats-window 1h
+
+  # Watch another account
+  ahasend dashboard --profile production`,
+		Args:         cobra.NoArgs,
+		RunE:         runDashboard,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().Duration("stats-interval", time.Minute, "How often the deliverability pane refreshes")
+	cmd.Flags().Duration("integrations-interval", 2*time.Minute, "How often the failing webhooks and routes pane refreshes")
+	cmd.Flags().Duration("messages-interval", 15*time.Second, "How often the recent messages pane refreshes")
+	cmd.Flags().Duration("stats-window", 24*time.Hour, "Time window covered by the deliverability and messages panes")
+	cmd.Flags().Int("messages-limit", 20, "Maximum number of recent messages to fetch (1-100)")
+
+	return cmd
+}
+
+func runDashboard(cmd *cobra.Command, args []string) error {
+	statsInterval, _ := cmd.Flags().GetDuration("stats-interval")
+	integrationsInterval, _ := cmd.Flags().GetDuration("integrations-interval")
+	messagesInterval, _ := cmd.Flags().GetDuration("messages-interval")
+	window, _ := cmd.Flags().GetDuration("stats-window")
+	messagesLimit, _ := cmd.Flags().GetInt("messages-limit")
+
+	for _, duration := range []struct {
+		flag  string
+		value time.Duration
+	}{
+		{"--stats-interval", statsInterval},
+		{"--integrations-interval", integrationsInterval},
+		{"--messages-interval", messagesInterval},
+		{"--stats-window", window},
+	} {
+		if duration.value <= 0 {
+			return errors.NewValidationError(duration.flag+" must be greater than zero", nil)
+		}
+	}
+	if messagesLimit < 1 || messagesLimit > 100 {
+		return errors.NewValidationError("--messages-limit must be between 1 and 100", nil)
+	}
+
+	if !stdioIsTerminal() {
+		return errors.NewValidationError("the dashboard needs an interactive terminal; in scripts use 'ahasend stats deliverability --summary-only', 'ahasend webhooks list --include-stats' or 'ahasend messages list'", nil)
+	}
+
+	apiClient, err := auth.GetAuthenticatedClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	logger.Get().WithFields(map[string]interface{}{
+		"stats_interval":        statsInterval,
+		"integrations_interval": integrationsInterval,
+		"messages_interval":     messagesInterval,
+		"stats_window":          window,
+		"messages_limit":        messagesLimit,
+	}).Debug("Executing dashboard command")
+
+	// The dashboard draws on the terminal directly, so it cannot be paged
+	if err := pager.StopBuffering(cmd); err != nil {
+		return err
+	}
+
+	model := dashboard.NewModel("AhaSend dashboard · account "+apiClient.GetAccountID(),
+		dashboard.NewDeliverabilityPane(window, statsInterval),
+		dashboard.NewIntegrationsPane(integrationsInterval),
+		dashboard.NewMessagesPane(window, messagesLimit, messagesInterval),
+	)
+
+	parent := cmd.Context()
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, stop := signal.NotifyContext(parent, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return dashboard.Run(ctx, os.Stdin, os.Stdout, apiClient, model)
+}
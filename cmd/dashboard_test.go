@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+)
+
+func TestDashboard_Validation(t *testing.T) {
+	prev := stdioIsTerminal
+	stdioIsTerminal = func() bool { return false }
+	t.Cleanup(func() { stdioIsTerminal = prev })
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "not a terminal", wantErr: "the dashboard needs an interactive terminal"},
+		{name: "zero interval", args: []string{"--messages-interval", "0s"}, wantErr: "--messages-interval must be greater than zero"},
+		{name: "limit out of range", args: []string{"--messages-limit", "500"}, wantErr: "--messages-limit must be between 1 and 100"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			cmd := newDashboardCommand()
+			cmd.SetOut(&out)
+			cmd.SetErr(&out)
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Equal(t, errors.ErrCodeValidation, err.(*errors.CLIError).Code)
+		})
+	}
+}
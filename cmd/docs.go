@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	authn "github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/docs"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/spf13/cobra"
+)
+
+// newDocsCommand creates the hidden docs command, which generates the
+// command reference for the developer portal and docs/reference
+func newDocsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:         "docs",
+		Short:       "Generate the command reference",
+		Hidden:      true,
+		Annotations: map[string]string{authn.NoAuthAnnotation: "true"},
+	}
+	cmd.AddCommand(newDocsGenerateCommand())
+	return cmd
+}
+
+func newDocsGenerateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Generate the command reference as man pages, markdown or reStructuredText",
+		Long: `Generate one reference page per command from the command tree.
+
+Besides usage, flags and examples, each page lists the output formats the
+command supports and the API key scopes it needs. The output contains no
+timestamps, so regenerating an unchanged CLI produces identical files.
+
+The references in docs/reference are generated with this command (make docs);
+the tests fail when they are out of date.`,
+		Example: `  # Regenerate the markdown reference
+  ahasend docs generate --format markdown --out docs/reference/markdown
+
+  # Generate man pages
+  ahasend docs generate --format man --out /usr/local/share/man/man1`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			handler := printer.GetResponseHandlerFromCommand(cmd)
+
+			format, _ := cmd.Flags().GetString("format")
+			out, _ := cmd.Flags().GetString("out")
+
+			pages, err := docs.Generate(cmd.Root(), format, out)
+			if err != nil {
+				return err
+			}
+			return handler.HandleSimpleSuccess(fmt.Sprintf("Wrote %d %s pages to %s", pages, format, out))
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().String("format", docs.FormatMarkdown,
+		fmt.Sprintf("Reference format (%s)", strings.Join(docs.GetSupportedFormats(), ", ")))
+	cmd.Flags().String("out", "", "Directory to write the pages to (required)")
+	cmd.MarkFlagRequired("out")
+
+	return cmd
+}
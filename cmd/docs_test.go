@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/docs"
+)
+
+// TestDocsReferenceUpToDate regenerates the reference and compares it with
+// the copy in docs/reference. Run `make docs` after changing commands.
+func TestDocsReferenceUpToDate(t *testing.T) {
+	for _, format := range docs.GetSupportedFormats() {
+		t.Run(format, func(t *testing.T) {
+			dir := t.TempDir()
+			_, err := docs.Generate(GetRootCmd(), format, dir)
+			require.NoError(t, err)
+
+			golden := filepath.Join("..", "docs", "reference", format)
+			generated := readDir(t, dir)
+			committed := readDir(t, golden)
+
+			for name, content := range generated {
+				want, ok := committed[name]
+				if !assert.True(t, ok, "%s is missing from %s; run make docs", name, golden) {
+					continue
+				}
+				assert.Equal(t, want, content, "%s is out of date; run make docs", name)
+			}
+			for name := range committed {
+				_, ok := generated[name]
+				assert.True(t, ok, "%s no longer matches a command; run make docs", name)
+			}
+		})
+	}
+}
+
+func TestDocsScopesCoverEveryCommand(t *testing.T) {
+	var walk func(cmd *cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		for _, child := range cmd.Commands() {
+			if !child.IsAvailableCommand() || child.Name() == "help" || child.Name() == "completion" {
+				continue
+			}
+			if child.Runnable() {
+				_, ok := docs.Scopes(child)
+				assert.True(t, ok, "no scopes recorded for %q", child.CommandPath())
+			}
+			walk(child)
+		}
+	}
+	walk(GetRootCmd())
+}
+
+func TestDocsGenerateCommand(t *testing.T) {
+	t.Run("writes pages", func(t *testing.T) {
+		dir := t.TempDir()
+		root := NewRootCmdForTesting()
+		root.SetArgs([]string{"docs", "generate", "--format", "man", "--out", dir})
+		var out bytes.Buffer
+		root.SetOut(&out)
+
+		require.NoError(t, root.Execute())
+		assert.Contains(t, out.String(), "man pages to "+dir)
+		assert.FileExists(t, filepath.Join(dir, "ahasend-messages-send.1"))
+	})
+
+	t.Run("hidden from help", func(t *testing.T) {
+		root := NewRootCmdForTesting()
+		docsCmd, _, err := root.Find([]string{"docs"})
+		require.NoError(t, err)
+		assert.True(t, docsCmd.Hidden)
+	})
+}
+
+func readDir(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	files := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		require.NoError(t, err)
+		files[entry.Name()] = string(content)
+	}
+	return files
+}
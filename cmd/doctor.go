@@ -0,0 +1,385 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	authn "github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/clockskew"
+	"github.com/AhaSend/ahasend-cli/internal/config"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/fetch"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/webhooks"
+	"github.com/AhaSend/ahasend-go/models/responses"
+)
+
+// smtpRelayAddress is the SMTP relay whose reachability doctor checks
+const smtpRelayAddress = "send.ahasend.com:587"
+
+// smtpDialTimeout bounds the connection attempt to the SMTP relay
+const smtpDialTimeout = 5 * time.Second
+
+// doctorScopes are the scopes of the common operations doctor checks the
+// API key for, with what each one allows
+var doctorScopes = []struct {
+	scope string
+	use   string
+}{
+	{"messages:send:all", "send messages"},
+	{"messages:read:all", "read messages"},
+	{"domains:read", "list domains"},
+	{"webhooks:read:all", "list webhooks"},
+	{"suppressions:read", "list suppressions"},
+	{"statistics-transactional:read:all", "view statistics"},
+}
+
+var (
+	// dialSMTP and observeClockSkew are replaced in tests
+	dialSMTP = func(address string, timeout time.Duration) error {
+		conn, err := net.DialTimeout("tcp", address, timeout)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+	observeClockSkew = client.ObservedClockSkew
+)
+
+// newDoctorCommand creates the doctor command. Each root command gets its
+// own instance, since a cobra command can only have one parent.
+func newDoctorCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "doctor",
+		Short: "Check the CLI setup, API key, domains and connectivity",
+		Long: `Run a series of checks on the CLI setup and print pass, warn or fail for each:
+
+- config: ~/.ahasend/config.yaml can be read and parsed
+- profile: the active profile (--profile or the default) exists
+- api_key: the profile has an API key and the API accepts it (ping)
+- scopes: the key has the scopes of common operations, such as
+  messages:send:all and domains:read
+- domains: at least one domain of the account has valid DNS
+- clock_skew: the local clock is within a minute of the API server's,
+  compared with the Date header of the API responses
+- smtp: send.ahasend.com:587 can be reached, for SMTP sending
+
+Checks that depend on a failed check are skipped. The scopes check needs the
+ID of the key in use, recorded with 'ahasend auth login --api-key-id', and
+the api-keys:read scope; otherwise it warns.
+
+The command works without credentials, so it can diagnose a broken setup.
+It exits 0 when every check passed, 12 when the worst result is a warning
+and 13 when a check failed. --output json prints the results as a
+doctor_report object.`,
+		Example: `  # Check the default profile
+  ahasend doctor
+
+  # Check another profile
+  ahasend doctor --profile production
+
+  # Machine-readable results for a CI job
+  ahasend doctor --output json`,
+		Annotations:  map[string]string{authn.NoAuthAnnotation: "true"},
+		RunE:         runDoctor,
+		SilenceUsage: true,
+	}
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+
+	logger.Get().Debug("Executing doctor command")
+
+	d := &doctor{cmd: cmd}
+	report := d.run()
+	if err := handler.HandleDoctorReport(report, printer.SingleConfig{
+		EmptyMessage: "No checks were run",
+	}); err != nil {
+		return err
+	}
+
+	// The exit code reflects the worst result
+	total := len(report.Checks)
+	switch report.Status() {
+	case printer.DoctorStatusFail:
+		return errors.NewChecksFailedError(fmt.Sprintf("%d of %d checks failed", report.Count(printer.DoctorStatusFail), total), nil)
+	case printer.DoctorStatusWarn:
+		return errors.NewChecksWarnedError(fmt.Sprintf("%d of %d checks passed with warnings", report.Count(printer.DoctorStatusWarn), total), nil)
+	}
+	return nil
+}
+
+// doctor runs the checks in order, each one seeing what the earlier ones
+// found
+type doctor struct {
+	cmd *cobra.Command
+
+	configMgr   *config.Manager
+	credentials string // where the API key comes from: "--api-key", the environment or "" for a profile
+	profileName string
+	profile     *config.Profile
+	apiClient   client.AhaSendClient
+}
+
+func (d *doctor) run() *printer.DoctorReport {
+	report := &printer.DoctorReport{}
+	for _, check := range []func() printer.DoctorCheck{
+		d.checkConfig,
+		d.checkProfile,
+		d.checkAPIKey,
+		d.checkScopes,
+		d.checkDomains,
+		d.checkClockSkew,
+		d.checkSMTP,
+	} {
+		result := check()
+		logger.Get().WithFields(map[string]interface{}{
+			"check":  result.ID,
+			"status": result.Status,
+		}).Debug(result.Message)
+		report.Checks = append(report.Checks, result)
+	}
+	return report
+}
+
+func doctorPass(id, name, message string) printer.DoctorCheck {
+	return printer.DoctorCheck{ID: id, Name: name, Status: printer.DoctorStatusPass, Message: message}
+}
+
+func doctorWarn(id, name, message, hint string) printer.DoctorCheck {
+	return printer.DoctorCheck{ID: id, Name: name, Status: printer.DoctorStatusWarn, Message: message, Hint: hint}
+}
+
+func doctorFail(id, name, message, hint string) printer.DoctorCheck {
+	return printer.DoctorCheck{ID: id, Name: name, Status: printer.DoctorStatusFail, Message: message, Hint: hint}
+}
+
+func doctorSkip(id, name, message string) printer.DoctorCheck {
+	return printer.DoctorCheck{ID: id, Name: name, Status: printer.DoctorStatusSkip, Message: message}
+}
+
+func (d *doctor) checkConfig() printer.DoctorCheck {
+	const id, name = "config", "Config file"
+	configMgr, err := config.NewManager()
+	if err != nil {
+		return doctorFail(id, name, fmt.Sprintf("cannot open the configuration directory: %v", err),
+			"Check that the home directory is set and ~/.ahasend is writable")
+	}
+	if err := configMgr.Load(); err != nil {
+		return doctorFail(id, name, fmt.Sprintf("~/.ahasend/config.yaml cannot be parsed: %v", err),
+			"Fix the YAML syntax, or move the file aside and run 'ahasend auth login' to create a new one")
+	}
+	d.configMgr = configMgr
+
+	profiles := len(configMgr.GetConfig().Profiles)
+	return doctorPass(id, name, fmt.Sprintf("~/.ahasend/config.yaml was read (%d %s)", profiles, profileNoun(profiles)))
+}
+
+func profileNoun(count int) string {
+	if count == 1 {
+		return "profile"
+	}
+	return "profiles"
+}
+
+func (d *doctor) checkProfile() printer.DoctorCheck {
+	const id, name = "profile", "Active profile"
+	flagKey, _ := d.cmd.Flags().GetString("api-key")
+	profileName, _ := d.cmd.Flags().GetString("profile")
+
+	// Credentials from flags or the environment need no profile
+	if flagKey != "" {
+		d.credentials = "--api-key"
+		return doctorPass(id, name, "not used: the API key comes from --api-key")
+	}
+	if envKey, _ := authn.EnvCredentials(); envKey != "" && profileName == "" {
+		d.credentials = authn.APIKeyEnvVar
+		return doctorPass(id, name, fmt.Sprintf("not used: the API key comes from %s", authn.APIKeyEnvVar))
+	}
+
+	if d.configMgr == nil {
+		return doctorSkip(id, name, "the config file could not be read")
+	}
+	cfg := d.configMgr.GetConfig()
+	if len(cfg.Profiles) == 0 {
+		return doctorFail(id, name, "no profile is set up",
+			fmt.Sprintf("Run 'ahasend auth login', or set %s and %s", authn.APIKeyEnvVar, authn.AccountIDEnvVar))
+	}
+	if profileName == "" {
+		profileName = cfg.DefaultProfile
+		if _, ok := cfg.Profiles[profileName]; !ok {
+			return doctorFail(id, name, fmt.Sprintf("the default profile '%s' does not exist", profileName),
+				fmt.Sprintf("Run 'ahasend auth switch <profile>' to pick one of: %s", strings.Join(d.configMgr.ListProfiles(), ", ")))
+		}
+	}
+	profile, ok := cfg.Profiles[profileName]
+	if !ok {
+		return doctorFail(id, name, fmt.Sprintf("profile '%s' does not exist", profileName),
+			fmt.Sprintf("Run 'ahasend auth login --profile %s'", profileName))
+	}
+	d.profileName, d.profile = profileName, &profile
+
+	account := profile.AccountID
+	if profile.AccountName != "" {
+		account = fmt.Sprintf("%s (%s)", profile.AccountName, profile.AccountID)
+	}
+	return doctorPass(id, name, fmt.Sprintf("'%s', account %s", profileName, account))
+}
+
+func (d *doctor) checkAPIKey() printer.DoctorCheck {
+	const id, name = "api_key", "API key"
+	if d.credentials == "" && d.profile == nil {
+		return doctorSkip(id, name, "there is no active profile")
+	}
+
+	if d.profile != nil && !authn.HasProvidedClient(d.cmd) {
+		loginHint := fmt.Sprintf("Run 'ahasend auth login --profile %s' with a valid key", d.profileName)
+		if !d.profile.KeyDeletedAt.IsZero() {
+			return doctorFail(id, name, fmt.Sprintf("the key of profile '%s' was deleted on %s",
+				d.profileName, d.profile.KeyDeletedAt.Local().Format("2006-01-02 15:04")), loginHint)
+		}
+		key, err := authn.ProfileAPIKey(*d.profile)
+		if err != nil {
+			return doctorFail(id, name, fmt.Sprintf("cannot read the key of profile '%s': %v", d.profileName, err), loginHint)
+		}
+		if key == "" {
+			return doctorFail(id, name, fmt.Sprintf("profile '%s' has no API key", d.profileName), loginHint)
+		}
+	}
+
+	apiClient, err := authn.GetAuthenticatedClient(d.cmd)
+	if err != nil {
+		return doctorFail(id, name, err.Error(), "Check the --api-key, --account-id and --api-url values, or run 'ahasend auth login'")
+	}
+	if err := apiClient.Ping(); err != nil {
+		message, hint := splitGuidance(err)
+		if hint == "" {
+			hint = "Check the key, the account ID and the connection to the API"
+		}
+		return doctorFail(id, name, "ping failed: "+message, hint)
+	}
+	d.apiClient = apiClient
+	return doctorPass(id, name, fmt.Sprintf("accepted by %s", apiClient.GetAPIURL()))
+}
+
+func (d *doctor) checkScopes() printer.DoctorCheck {
+	const id, name = "scopes", "Key scopes"
+	if d.apiClient == nil {
+		return doctorSkip(id, name, "the API key check failed")
+	}
+	// Only a key logged in with --api-key-id can be identified
+	if d.profile == nil || d.profile.APIKeyID == "" {
+		return doctorWarn(id, name, "cannot tell which API key is in use, so its scopes were not checked",
+			"Log in with 'ahasend auth login --api-key-id <key-id>' so the key can be identified")
+	}
+
+	key, err := d.apiClient.GetAPIKey(d.profile.APIKeyID)
+	if err != nil {
+		message, _ := splitGuidance(err)
+		return doctorWarn(id, name, fmt.Sprintf("cannot read API key %s: %s", d.profile.APIKeyID, message),
+			"The scopes check needs the api-keys:read scope")
+	}
+
+	var missing, uses []string
+	for _, required := range doctorScopes {
+		if !hasScope(key.Scopes, required.scope) {
+			missing = append(missing, required.scope)
+			uses = append(uses, required.use)
+		}
+	}
+	if len(missing) > 0 {
+		return doctorWarn(id, name, fmt.Sprintf("the key cannot %s (missing %s)", strings.Join(uses, ", "), strings.Join(missing, ", ")),
+			fmt.Sprintf("Create a key with the missing scopes: ahasend apikeys clone %s --add-scope %s",
+				key.ID, strings.Join(missing, " --add-scope ")))
+	}
+	return doctorPass(id, name, fmt.Sprintf("key '%s' has the scopes of common operations", key.Label))
+}
+
+// splitGuidance translates an API error and splits it into the error and
+// the guidance on how to fix it, which Translate puts on the lines after it
+func splitGuidance(err error) (message, guidance string) {
+	message, guidance, _ = strings.Cut(errors.Translate(err).Error(), "\n")
+	return message, strings.ReplaceAll(guidance, "\n", " ")
+}
+
+// hasScope reports whether scopes grant required. A scope restricted to
+// domains, e.g. messages:send:{example.com}, counts for the :all scope.
+func hasScope(scopes []responses.APIKeyScope, required string) bool {
+	restricted := strings.TrimSuffix(required, "all") + "{"
+	for _, granted := range scopes {
+		if granted.Scope == required || (strings.HasSuffix(required, ":all") && strings.HasPrefix(granted.Scope, restricted)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *doctor) checkDomains() printer.DoctorCheck {
+	const id, name = "domains", "Verified domain"
+	if d.apiClient == nil {
+		return doctorSkip(id, name, "the API key check failed")
+	}
+
+	domains, err := fetch.AllDomains(d.apiClient)
+	if err != nil {
+		message, hint := splitGuidance(err)
+		if cliErr, ok := errors.Translate(err).(*errors.CLIError); ok && cliErr.Code == errors.ErrCodePermission {
+			return doctorWarn(id, name, "cannot list domains: "+message, "The domains check needs the domains:read scope")
+		}
+		return doctorFail(id, name, "cannot list domains: "+message, hint)
+	}
+	if len(domains) == 0 {
+		return doctorFail(id, name, "the account has no domains, so it cannot send",
+			"Add one with 'ahasend domains create <domain>' and publish its DNS records")
+	}
+
+	var valid []string
+	for _, domain := range domains {
+		if domain.DNSValid {
+			valid = append(valid, domain.Domain)
+		}
+	}
+	if len(valid) == 0 {
+		return doctorFail(id, name, fmt.Sprintf("none of the %d domains has valid DNS, so the account cannot send", len(domains)),
+			fmt.Sprintf("Run 'ahasend domains verify %s' to see which records are missing", domains[0].Domain))
+	}
+	return doctorPass(id, name, fmt.Sprintf("%d of %d domains have valid DNS: %s", len(valid), len(domains), strings.Join(valid, ", ")))
+}
+
+func (d *doctor) checkClockSkew() printer.DoctorCheck {
+	const id, name = "clock_skew", "Clock skew"
+	if d.apiClient == nil {
+		return doctorSkip(id, name, "no API response to compare the clock with")
+	}
+
+	skew, ok := observeClockSkew()
+	if !ok {
+		return doctorWarn(id, name, "the API responses carried no Date header to compare the clock with", "")
+	}
+	// The server being ahead means the local clock is behind
+	described := fmt.Sprintf("the local clock is %s the API server's", webhooks.DescribeSkew(-skew))
+	switch {
+	case skew.Abs() > webhooks.DefaultTolerance:
+		return doctorFail(id, name, described+"; webhook signatures are rejected beyond "+webhooks.DefaultTolerance.String(),
+			"Synchronize the clock (e.g. enable NTP)")
+	case skew.Abs() > clockskew.Threshold:
+		return doctorWarn(id, name, described, "Synchronize the clock (e.g. enable NTP)")
+	}
+	return doctorPass(id, name, described)
+}
+
+func (d *doctor) checkSMTP() printer.DoctorCheck {
+	const id, name = "smtp", "SMTP connectivity"
+	if err := dialSMTP(smtpRelayAddress, smtpDialTimeout); err != nil {
+		return doctorFail(id, name, fmt.Sprintf("cannot connect to %s: %v", smtpRelayAddress, err),
+			"Outbound port 587 may be blocked by a firewall; the HTTP API still works, but SMTP sending from this network does not")
+	}
+	return doctorPass(id, name, fmt.Sprintf("connected to %s", smtpRelayAddress))
+}
@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/models/common"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+)
+
+const doctorKeyID = "7d1c8f3a-2b4e-4f6a-9c8d-0e1f2a3b4c5d"
+
+var allDoctorScopes = []responses.APIKeyScope{
+	{Scope: "messages:send:{example.com}"},
+	{Scope: "messages:read:all"},
+	{Scope: "domains:read"},
+	{Scope: "webhooks:read:all"},
+	{Scope: "suppressions:read"},
+	{Scope: "statistics-transactional:read:all"},
+}
+
+type doctorResult struct {
+	Status string `json:"status"`
+	Checks []struct {
+		ID      string `json:"id"`
+		Status  string `json:"status"`
+		Message string `json:"message"`
+		Hint    string `json:"hint"`
+	} `json:"checks"`
+}
+
+func (r doctorResult) statuses() map[string]string {
+	statuses := make(map[string]string, len(r.Checks))
+	for _, check := range r.Checks {
+		statuses[check.ID] = check.Status
+	}
+	return statuses
+}
+
+// setupDoctor writes config, if any, to a temporary home directory and
+// stubs the clock skew observation and the SMTP dial
+func setupDoctor(t *testing.T, config string, skew time.Duration, dialErr error) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(auth.APIKeyEnvVar, "")
+	t.Setenv(auth.AccountIDEnvVar, "")
+	if config != "" {
+		require.NoError(t, os.MkdirAll(filepath.Join(home, ".ahasend"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(home, ".ahasend", "config.yaml"), []byte(config), 0o600))
+	}
+
+	prevObserve, prevDial := observeClockSkew, dialSMTP
+	observeClockSkew = func() (time.Duration, bool) { return skew, true }
+	dialSMTP = func(string, time.Duration) error { return dialErr }
+	globalExitCode = 0
+	t.Cleanup(func() {
+		observeClockSkew, dialSMTP = prevObserve, prevDial
+		globalExitCode = 0
+	})
+}
+
+const doctorConfig = `default_profile: default
+profiles:
+  default:
+    name: AhaSend default
+    api_key: aha-sk-test
+    account_id: 4f9c2b1e-8d3a-4c55-9e0f-1a2b3c4d5e6f
+    api_key_id: ` + doctorKeyID + `
+`
+
+func mockDoctorClient(t *testing.T, scopes []responses.APIKeyScope, domains []responses.Domain) {
+	t.Helper()
+	mockClient := &mocks.MockClient{}
+	mockClient.On("Ping").Return(nil)
+	mockClient.On("GetAPIURL").Return("https://api.ahasend.com")
+	mockClient.On("GetAPIKey", doctorKeyID).Return(&responses.APIKey{
+		ID:     uuid.MustParse(doctorKeyID),
+		Label:  "laptop",
+		Scopes: scopes,
+	}, nil)
+	mockClient.On("ListDomains", mock.Anything, mock.Anything).Return(&responses.PaginatedDomainsResponse{
+		Object:     "list",
+		Data:       domains,
+		Pagination: common.PaginationInfo{HasMore: false},
+	}, nil)
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+}
+
+func runDoctorJSON(t *testing.T, args ...string) doctorResult {
+	t.Helper()
+	root := NewRootCmdForTesting()
+	var out, errOut bytes.Buffer
+	root.SetOut(&out)
+	root.SetErr(&errOut)
+	root.SetArgs(append([]string{"doctor", "--output", "json"}, args...))
+	require.NoError(t, root.Execute())
+
+	var result doctorResult
+	decoder := json.NewDecoder(&out)
+	require.NoError(t, decoder.Decode(&result), out.String())
+	return result
+}
+
+func TestDoctor_AllPass(t *testing.T) {
+	setupDoctor(t, doctorConfig, 2*time.Second, nil)
+	mockDoctorClient(t, allDoctorScopes, []responses.Domain{
+		{Domain: "example.com", DNSValid: true},
+		{Domain: "mail.example.org", DNSValid: false},
+	})
+
+	result := runDoctorJSON(t)
+	assert.Equal(t, "pass", result.Status)
+	assert.Equal(t, map[string]string{
+		"config": "pass", "profile": "pass", "api_key": "pass", "scopes": "pass",
+		"domains": "pass", "clock_skew": "pass", "smtp": "pass",
+	}, result.statuses())
+	assert.Equal(t, 0, globalExitCode)
+	assert.Equal(t, "1 of 2 domains have valid DNS: example.com", result.Checks[4].Message)
+}
+
+func TestDoctor_WorstSeverity(t *testing.T) {
+	t.Run("warnings", func(t *testing.T) {
+		setupDoctor(t, doctorConfig, 2*time.Minute, nil)
+		mockDoctorClient(t, allDoctorScopes[1:], []responses.Domain{{Domain: "example.com", DNSValid: true}})
+
+		result := runDoctorJSON(t)
+		assert.Equal(t, "warn", result.Status)
+		statuses := result.statuses()
+		assert.Equal(t, "warn", statuses["scopes"])
+		assert.Equal(t, "warn", statuses["clock_skew"])
+		assert.Equal(t, 12, globalExitCode)
+
+		scopes := result.Checks[3]
+		assert.Equal(t, "the key cannot send messages (missing messages:send:all)", scopes.Message)
+		assert.Equal(t, fmt.Sprintf("Create a key with the missing scopes: ahasend apikeys clone %s --add-scope messages:send:all", doctorKeyID), scopes.Hint)
+		assert.Equal(t, "the local clock is 2m0s behind the API server's", result.Checks[5].Message)
+	})
+
+	t.Run("failures", func(t *testing.T) {
+		setupDoctor(t, doctorConfig, 10*time.Minute, fmt.Errorf("i/o timeout"))
+		mockDoctorClient(t, allDoctorScopes, []responses.Domain{{Domain: "example.com", DNSValid: false}})
+
+		result := runDoctorJSON(t)
+		assert.Equal(t, "fail", result.Status)
+		statuses := result.statuses()
+		assert.Equal(t, "fail", statuses["domains"])
+		assert.Equal(t, "fail", statuses["clock_skew"])
+		assert.Equal(t, "fail", statuses["smtp"])
+		assert.Equal(t, 13, globalExitCode)
+		assert.Equal(t, "Run 'ahasend domains verify example.com' to see which records are missing", result.Checks[4].Hint)
+	})
+}
+
+func TestDoctor_WithoutProfile(t *testing.T) {
+	setupDoctor(t, "", 0, nil)
+
+	result := runDoctorJSON(t)
+	assert.Equal(t, map[string]string{
+		"config": "pass", "profile": "fail", "api_key": "skip", "scopes": "skip",
+		"domains": "skip", "clock_skew": "skip", "smtp": "pass",
+	}, result.statuses())
+	assert.Equal(t, 13, globalExitCode)
+}
+
+func TestDoctor_UnidentifiedKey(t *testing.T) {
+	setupDoctor(t, doctorConfig, 0, nil)
+	mockDoctorClient(t, nil, []responses.Domain{{Domain: "example.com", DNSValid: true}})
+
+	result := runDoctorJSON(t, "--api-key", "aha-sk-other", "--account-id", "4f9c2b1e-8d3a-4c55-9e0f-1a2b3c4d5e6f")
+	assert.Equal(t, "warn", result.statuses()["scopes"])
+	assert.Contains(t, result.Checks[3].Hint, "ahasend auth login --api-key-id <key-id>")
+}
+
+func TestDoctor_PlainOutput(t *testing.T) {
+	setupDoctor(t, "", 0, nil)
+
+	root := NewRootCmdForTesting()
+	var out bytes.Buffer
+	root.SetOut(&out)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"doctor", "--output", "plain"})
+	require.NoError(t, root.Execute())
+
+	assert.Contains(t, out.String(), "[FAIL] Active profile: no profile is set up\n  Hint: Run 'ahasend auth login'")
+	assert.Contains(t, out.String(), "[SKIP] API key: there is no active profile\n")
+	assert.Contains(t, out.String(), "\n2 passed, 1 failed, 4 skipped\n")
+}
+
+func TestHasScope(t *testing.T) {
+	scopes := []responses.APIKeyScope{{Scope: "messages:send:{example.com}"}, {Scope: "domains:read"}}
+	assert.True(t, hasScope(scopes, "messages:send:all"), "a domain-restricted scope counts")
+	assert.True(t, hasScope(scopes, "domains:read"))
+	assert.False(t, hasScope(scopes, "messages:read:all"))
+	assert.False(t, hasScope(scopes, "domains:write"))
+}
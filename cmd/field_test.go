@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func executeWithDomains(t *testing.T, args ...string) (string, string, error) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"object":"list","data":[` +
+			`{"id":"4f9c2b1e-8d3a-4c55-9e0f-1a2b3c4d5e6f","domain":"example.com","dns_valid":true},` +
+			`{"id":"9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d","domain":"mail.example.org","dns_valid":false}` +
+			`],"pagination":{"has_more":false}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	root := NewRootCmdForTesting()
+	var out, errOut bytes.Buffer
+	root.SetOut(&out)
+	root.SetErr(&errOut)
+	root.SetArgs(append(args, "--api-key", "aha-sk-test", "--account-id", "4f9c2b1e-8d3a-4c55-9e0f-1a2b3c4d5e6f", "--api-url", server.URL))
+	err := root.Execute()
+	return out.String(), errOut.String(), err
+}
+
+func TestField_PrintsOneValuePerItem(t *testing.T) {
+	out, _, err := executeWithDomains(t, "domains", "list", "--field", "domain")
+	require.NoError(t, err)
+	assert.Equal(t, "example.com\nmail.example.org\n", out)
+
+	out, _, err = executeWithDomains(t, "domains", "list", "--field", "dns_valid", "--output", "plain")
+	require.NoError(t, err)
+	assert.Equal(t, "true\nfalse\n", out)
+}
+
+func TestField_UnknownField(t *testing.T) {
+	globalExitCode = 0
+	t.Cleanup(func() { globalExitCode = 0 })
+
+	out, errOut, err := executeWithDomains(t, "domains", "list", "--field", "status")
+	require.NoError(t, err, "the error is printed by the handler")
+	assert.Equal(t, 4, globalExitCode)
+	assert.Contains(t, errOut, `unknown field "status"; valid fields: `)
+	assert.Contains(t, errOut, "domain, ")
+	assert.Empty(t, out, "errors stay out of the captured value")
+}
+
+func TestField_Conflicts(t *testing.T) {
+	_, _, err := executeWithDomains(t, "domains", "list", "--field", "domain", "--output", "json")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--field works with --output plain, not json")
+
+	_, _, err = executeWithDomains(t, "domains", "list", "--field", "domain", "--ids-only")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--field cannot be used with --ids-only")
+}
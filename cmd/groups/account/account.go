@@ -0,0 +1,25 @@
+package account
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates the account command group
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "account",
+		Short: "View and update your AhaSend account settings",
+		Long: `View and update the account-level email settings of the account the CLI is
+authenticated with: open and click tracking, rejection of bad and mistyped
+recipients, and how long message metadata and content are kept.
+
+Common workflow:
+  1. Review the settings: ahasend account get
+  2. Change some of them: ahasend account update --track-clicks=false`,
+	}
+
+	cmd.AddCommand(NewGetCommand())
+	cmd.AddCommand(NewUpdateCommand())
+
+	return cmd
+}
@@ -0,0 +1,130 @@
+package account
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+)
+
+// execCommand runs a leaf command with a response handler of format
+// installed in context and captures its output
+func execCommand(cmd *cobra.Command, format string, args ...string) (string, error) {
+	var buf bytes.Buffer
+	handler := printer.GetResponseHandler(format, false, &buf)
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+// trackingResolver installs a test auth resolver returning c and reports
+// whether it was called
+func trackingResolver(t *testing.T, c client.AhaSendClient) *bool {
+	t.Helper()
+	called := false
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		called = true
+		return c, nil
+	})
+	t.Cleanup(restore)
+	return &called
+}
+
+func newTestAccount() *responses.Account {
+	trackOpens, trackClicks, rejectBad := true, false, true
+	metadataRetention, dataRetention := int32(30), int32(7)
+	return &responses.Account{
+		Object:                   "account",
+		ID:                       uuid.MustParse("11111111-1111-1111-1111-111111111111"),
+		OwnerID:                  uuid.MustParse("22222222-2222-2222-2222-222222222222"),
+		CreatedAt:                time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:                time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC),
+		Name:                     "Acme",
+		TrackOpens:               &trackOpens,
+		TrackClicks:              &trackClicks,
+		RejectBadRecipients:      &rejectBad,
+		MessageMetadataRetention: &metadataRetention,
+		MessageDataRetention:     &dataRetention,
+	}
+}
+
+func TestAccountGet_Plain(t *testing.T) {
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetAccountID").Return("11111111-1111-1111-1111-111111111111")
+	mockClient.On("GetAccount").Return(newTestAccount(), nil)
+	trackingResolver(t, mockClient)
+
+	output, err := execCommand(NewGetCommand(), "plain")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Name: Acme\n")
+	assert.Contains(t, output, "Track Opens: Yes\n")
+	assert.Contains(t, output, "Track Clicks: No\n")
+	assert.Contains(t, output, "Message Data Retention (days): 7\n")
+	assert.NotContains(t, output, "Reject Mistyped Recipients", "settings the API did not return are left out")
+}
+
+func TestAccountGet_CSV(t *testing.T) {
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetAccountID").Return("11111111-1111-1111-1111-111111111111")
+	mockClient.On("GetAccount").Return(newTestAccount(), nil)
+	trackingResolver(t, mockClient)
+
+	output, err := execCommand(NewGetCommand(), "csv")
+	require.NoError(t, err)
+	assert.Contains(t, output, "id,name,parent_account_id,website,about,track_opens,track_clicks,reject_bad_recipients,reject_mistyped_recipients,message_metadata_retention,message_data_retention,owner_id,created_at,updated_at\n")
+	assert.Contains(t, output, "11111111-1111-1111-1111-111111111111,Acme,,,,Yes,No,Yes,,30,7,")
+}
+
+func TestAccountUpdate_SendsOnlyChangedFlags(t *testing.T) {
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetAccountID").Return("11111111-1111-1111-1111-111111111111")
+	mockClient.On("UpdateAccount", mock.MatchedBy(func(req requests.UpdateAccountRequest) bool {
+		return req.TrackOpens != nil && !*req.TrackOpens &&
+			req.MessageDataRetention != nil && *req.MessageDataRetention == 0 &&
+			req.TrackClicks == nil && req.RejectBadRecipients == nil && req.RejectMistypedRecipients == nil &&
+			req.MessageMetadataRetention == nil && req.Name == nil
+	})).Return(newTestAccount(), nil).Once()
+	trackingResolver(t, mockClient)
+
+	output, err := execCommand(NewUpdateCommand(), "json", "--track-opens=false", "--message-data-retention", "0")
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+
+	var account map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(output), &account))
+	assert.Equal(t, "Acme", account["name"])
+}
+
+func TestAccountUpdate_ValidatesBeforeAuth(t *testing.T) {
+	for name, args := range map[string][]string{
+		"no flags":                 {},
+		"metadata retention below": {"--message-metadata-retention", "0"},
+		"metadata retention above": {"--message-metadata-retention", "31"},
+		"data retention negative":  {"--message-data-retention", "-1"},
+		"data retention above":     {"--message-data-retention", "90"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			called := trackingResolver(t, &mocks.MockClient{})
+			_, err := execCommand(NewUpdateCommand(), "plain", args...)
+			require.Error(t, err)
+			assert.False(t, *called, "the API client must not be requested for invalid input")
+		})
+	}
+}
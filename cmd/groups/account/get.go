@@ -0,0 +1,53 @@
+package account
+
+import (
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/spf13/cobra"
+)
+
+// NewGetCommand creates the get command
+func NewGetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get",
+		Short: "Show the account and its email settings",
+		Long: `Show the account the CLI is authenticated with, including its tracking,
+recipient rejection and data retention settings.`,
+		Example: `  # Show the account settings
+  ahasend account get
+
+  # Check whether click tracking is on
+  ahasend account get --output json | jq .track_clicks`,
+		Args:         cobra.NoArgs,
+		RunE:         runAccountGet,
+		SilenceUsage: true,
+	}
+
+	return cmd
+}
+
+func runAccountGet(cmd *cobra.Command, args []string) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+
+	client, err := auth.GetAuthenticatedClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	logger.Get().WithField("account_id", client.GetAccountID()).Debug("Executing account get command")
+
+	account, err := client.GetAccount()
+	if err != nil {
+		return err
+	}
+	if account == nil {
+		return errors.NewNotFoundError("account not found", nil)
+	}
+
+	return handler.HandleAccount(account, printer.SingleConfig{
+		SuccessMessage: "Account details",
+		EmptyMessage:   "Account not found",
+	})
+}
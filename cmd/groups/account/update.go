@@ -0,0 +1,133 @@
+package account
+
+import (
+	"fmt"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/spf13/cobra"
+)
+
+// Retention ranges accepted by the API, in days
+const (
+	metadataRetentionMin = 1
+	metadataRetentionMax = 30
+	dataRetentionMin     = 0
+	dataRetentionMax     = 30
+)
+
+// NewUpdateCommand creates the update command
+func NewUpdateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "Update the account's email settings",
+		Long: `Update the email settings of the account the CLI is authenticated with.
+
+Only the flags you provide are changed; omitted settings remain unchanged, and
+at least one flag must be provided. Boolean settings take an explicit value,
+e.g. --track-opens=false. Message metadata is kept for 1 to 30 days and
+message content for 0 to 30 days; values outside these ranges are rejected
+before the API is called. The updated account is printed afterwards.`,
+		Example: `  # Turn off open and click tracking
+  ahasend account update --track-opens=false --track-clicks=false
+
+  # Keep message content for 30 days and metadata for 14
+  ahasend account update --message-data-retention 30 --message-metadata-retention 14
+
+  # Stop sending to addresses that look mistyped
+  ahasend account update --reject-mistyped-recipients`,
+		Args:         cobra.NoArgs,
+		RunE:         runAccountUpdate,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().Bool("track-opens", false, "Track opens of sent messages")
+	cmd.Flags().Bool("track-clicks", false, "Track clicks on links in sent messages")
+	cmd.Flags().Bool("reject-bad-recipients", false, "Reject recipients known to be undeliverable")
+	cmd.Flags().Bool("reject-mistyped-recipients", false, "Reject recipients whose address looks mistyped")
+	cmd.Flags().Int32("message-metadata-retention", 0, fmt.Sprintf("Days to keep message metadata (%d-%d)", metadataRetentionMin, metadataRetentionMax))
+	cmd.Flags().Int32("message-data-retention", 0, fmt.Sprintf("Days to keep message content (%d-%d)", dataRetentionMin, dataRetentionMax))
+
+	return cmd
+}
+
+func runAccountUpdate(cmd *cobra.Command, args []string) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+
+	req, err := buildUpdateAccountRequest(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Only authenticate after local validation passes
+	client, err := auth.GetAuthenticatedClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	logger.Get().WithFields(map[string]interface{}{
+		"account_id": client.GetAccountID(),
+		"request":    fmt.Sprintf("%+v", req),
+	}).Debug("Executing account update command")
+
+	account, err := client.UpdateAccount(req)
+	if err != nil {
+		return err
+	}
+	if account == nil {
+		return errors.NewNotFoundError("account not found", nil)
+	}
+
+	return handler.HandleAccount(account, printer.SingleConfig{
+		SuccessMessage: "Account settings updated successfully",
+		EmptyMessage:   "Account not found",
+	})
+}
+
+// buildUpdateAccountRequest builds the request from the flags that were
+// set, so an explicit false or 0 is sent and an omitted flag is not
+func buildUpdateAccountRequest(cmd *cobra.Command) (requests.UpdateAccountRequest, error) {
+	req := requests.UpdateAccountRequest{}
+	changed := false
+
+	for flag, field := range map[string]**bool{
+		"track-opens":                &req.TrackOpens,
+		"track-clicks":               &req.TrackClicks,
+		"reject-bad-recipients":      &req.RejectBadRecipients,
+		"reject-mistyped-recipients": &req.RejectMistypedRecipients,
+	} {
+		if cmd.Flags().Changed(flag) {
+			v, _ := cmd.Flags().GetBool(flag)
+			*field = &v
+			changed = true
+		}
+	}
+
+	for _, retention := range []struct {
+		flag     string
+		field    **int32
+		min, max int32
+	}{
+		{"message-metadata-retention", &req.MessageMetadataRetention, metadataRetentionMin, metadataRetentionMax},
+		{"message-data-retention", &req.MessageDataRetention, dataRetentionMin, dataRetentionMax},
+	} {
+		if !cmd.Flags().Changed(retention.flag) {
+			continue
+		}
+		v, _ := cmd.Flags().GetInt32(retention.flag)
+		if v < retention.min || v > retention.max {
+			return req, errors.NewValidationError(fmt.Sprintf("invalid --%s: %d (must be between %d and %d days)",
+				retention.flag, v, retention.min, retention.max), nil)
+		}
+		*retention.field = &v
+		changed = true
+	}
+
+	if !changed {
+		return req, errors.NewValidationError("at least one setting must be provided, e.g. --track-opens=false", nil)
+	}
+	return req, nil
+}
@@ -49,6 +49,13 @@ Use these commands to create, list, update, and delete API keys as needed.`,
     --scope messages:send:all \
     --scope webhooks:read:all
 
+  # Rotate a key: create a new one with the same scopes and delete the old one
+  ahasend apikeys rotate ak_1234567890abcdef
+
+  # Create a new key with the same scopes, keeping the old one for now
+  ahasend apikeys clone ak_1234567890abcdef --label "rotated 2024-06" \
+    --revoke-source-after 7d
+
   # Delete an API key
   ahasend apikeys delete ak_1234567890abcdef`,
 	}
@@ -57,6 +64,8 @@ Use these commands to create, list, update, and delete API keys as needed.`,
 	cmd.AddCommand(NewListCommand())
 	cmd.AddCommand(NewGetCommand())
 	cmd.AddCommand(NewCreateCommand())
+	cmd.AddCommand(NewCloneCommand())
+	cmd.AddCommand(NewRotateCommand())
 	cmd.AddCommand(NewUpdateCommand())
 	cmd.AddCommand(NewDeleteCommand())
 
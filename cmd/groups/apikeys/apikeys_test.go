@@ -53,6 +53,7 @@ func TestAPIKeysCommand_Help(t *testing.T) {
 	assert.Contains(t, helpOutput, "create")
 	assert.Contains(t, helpOutput, "update")
 	assert.Contains(t, helpOutput, "delete")
+	assert.Contains(t, helpOutput, "clone")
 	assert.Contains(t, helpOutput, "authentication and access control")
 }
 
@@ -60,8 +61,8 @@ func TestAPIKeysCommand_SubcommandCount(t *testing.T) {
 	cmd := NewCommand()
 	subcommands := cmd.Commands()
 
-	// Should have exactly 5 subcommands
-	assert.Equal(t, 5, len(subcommands), "apikeys command should have exactly 5 subcommands")
+	// Should have exactly 7 subcommands
+	assert.Equal(t, 7, len(subcommands), "apikeys command should have exactly 7 subcommands")
 }
 
 // Test list command structure and flags
@@ -0,0 +1,310 @@
+package apikeys
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/output"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/state"
+	"github.com/AhaSend/ahasend-cli/internal/validation"
+)
+
+// stdinIsTerminal is replaced in tests to exercise the interactive prompts
+var stdinIsTerminal = func() bool { return printer.IsTerminal(os.Stdin) }
+
+// NewCloneCommand creates the apikeys clone command
+func NewCloneCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "clone <source-key-id>",
+		Short: "Create a new API key with the same scopes as an existing one",
+		Long: `Create a new API key with the exact scope set of an existing key, for
+rotating keys without recreating scopes by hand.
+
+--add-scope and --remove-scope adjust the copied scopes before the new key is
+created. The new secret is displayed once and cannot be retrieved again.
+
+Domain-restricted scopes are checked against the domains in your account. For
+scopes that reference a domain that no longer exists you are asked whether to
+drop them; without a terminal the clone stops so you can pass --remove-scope.
+
+--revoke-source-after records a reminder to revoke the source key, e.g. once
+all clients use the new one. The source key is never deleted automatically;
+see 'ahasend reminders list'.`,
+		Example: `  # Rotate a key
+  ahasend apikeys clone fcb3f3bc-4ac8-4330-948d-1671fcf9a768 --label "rotated 2024-06"
+
+  # Rotate and drop a scope the new key no longer needs
+  ahasend apikeys clone fcb3f3bc-4ac8-4330-948d-1671fcf9a768 \
+    --label "rotated 2024-06" \
+    --remove-scope webhooks:write:all \
+    --add-scope messages:read:all
+
+  # Remind me to revoke the old key in a week
+  ahasend apikeys clone fcb3f3bc-4ac8-4330-948d-1671fcf9a768 \
+    --label "rotated 2024-06" --revoke-source-after 7d`,
+		Args:         cobra.ExactArgs(1),
+		RunE:         runAPIKeyClone,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().String("label", "", "Label for the new API key (required)")
+	cmd.Flags().StringSlice("add-scope", []string{}, "Scope to grant in addition to the source key's scopes (can be used multiple times)")
+	cmd.Flags().StringSlice("remove-scope", []string{}, "Source key scope to leave out (can be used multiple times)")
+	cmd.Flags().String("revoke-source-after", "", "Record a reminder to revoke the source key after this long (e.g. 7d, 48h) or at an RFC3339 time")
+
+	cmd.MarkFlagRequired("label")
+
+	return cmd
+}
+
+func runAPIKeyClone(cmd *cobra.Command, args []string) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+
+	sourceID := args[0]
+	if _, err := uuid.Parse(sourceID); err != nil {
+		return errors.NewValidationError(fmt.Sprintf("invalid API key ID format: %s", sourceID), err)
+	}
+
+	label, _ := cmd.Flags().GetString("label")
+	addScopes, _ := cmd.Flags().GetStringSlice("add-scope")
+	removeScopes, _ := cmd.Flags().GetStringSlice("remove-scope")
+	revokeAfter, _ := cmd.Flags().GetString("revoke-source-after")
+
+	for _, scope := range addScopes {
+		if err := validation.ValidateScope(scope); err != nil {
+			return errors.NewValidationError(err.Error(), nil)
+		}
+	}
+
+	var revokeAt *time.Time
+	if revokeAfter != "" {
+		at, err := output.ParseTimeFuture(revokeAfter)
+		if err != nil {
+			return errors.NewValidationError(fmt.Sprintf("invalid revoke-source-after: %v", err), nil)
+		}
+		revokeAt = &at
+	}
+
+	apiClient, err := auth.GetAuthenticatedClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	source, err := apiClient.GetAPIKey(sourceID)
+	if err != nil {
+		return err
+	}
+	if source == nil {
+		return errors.NewNotFoundError(fmt.Sprintf("API key %s not found", sourceID), nil)
+	}
+
+	scopes, err := cloneScopes(source.Scopes, addScopes, removeScopes)
+	if err != nil {
+		return err
+	}
+
+	scopes, err = dropDeletedDomainScopes(cmd, apiClient, source.Scopes, scopes)
+	if err != nil {
+		return err
+	}
+	if len(scopes) == 0 {
+		return errors.NewValidationError("the new API key would have no scopes; add one with --add-scope", nil)
+	}
+
+	logger.Get().WithFields(map[string]interface{}{
+		"source_key_id": sourceID,
+		"label":         label,
+		"scopes":        scopes,
+	}).Debug("Cloning API key")
+
+	apiKey, err := apiClient.CreateAPIKey(requests.CreateAPIKeyRequest{
+		Label:  label,
+		Scopes: scopes,
+	})
+	if err != nil {
+		return err
+	}
+
+	// The key exists at this point and its secret must still be shown, so a
+	// failure to record the reminder is reported without failing the command
+	if revokeAt != nil {
+		recordRevokeReminder(cmd.ErrOrStderr(), source, apiKey, *revokeAt)
+	}
+
+	return handler.HandleCreateAPIKey(apiKey, printer.CreateConfig{
+		SuccessMessage: fmt.Sprintf("✅ API Key Cloned Successfully from %s", sourceID),
+		ItemName:       "API key",
+		FieldOrder:     []string{"id", "label", "public_key", "secret_key", "scopes", "created_at"},
+	})
+}
+
+// cloneScopes copies the source key's scopes, leaving out removeScopes and
+// appending addScopes, without duplicates and in source order
+func cloneScopes(source []responses.APIKeyScope, addScopes, removeScopes []string) ([]string, error) {
+	remove := make(map[string]bool, len(removeScopes))
+	for _, scope := range removeScopes {
+		remove[scope] = true
+	}
+
+	seen := make(map[string]bool)
+	var scopes []string
+	for _, scope := range source {
+		if seen[scope.Scope] {
+			continue
+		}
+		seen[scope.Scope] = true
+		if !remove[scope.Scope] {
+			scopes = append(scopes, scope.Scope)
+		}
+	}
+
+	for _, scope := range removeScopes {
+		if !seen[scope] {
+			return nil, errors.NewValidationError(fmt.Sprintf("cannot remove scope '%s': the source key does not have it", scope), nil)
+		}
+	}
+
+	for _, scope := range addScopes {
+		if !seen[scope] || remove[scope] {
+			seen[scope] = true
+			remove[scope] = false
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes, nil
+}
+
+// dropDeletedDomainScopes finds scopes restricted to domains that no longer
+// exist in the account and asks whether to drop each one. Without a terminal
+// to ask on, it fails and names the scopes to pass to --remove-scope.
+func dropDeletedDomainScopes(cmd *cobra.Command, apiClient client.AhaSendClient, source []responses.APIKeyScope, scopes []string) ([]string, error) {
+	stale, err := deletedDomainScopes(apiClient, source, scopes)
+	if err != nil {
+		return nil, err
+	}
+	if len(stale) == 0 {
+		return scopes, nil
+	}
+
+	if !stdinIsTerminal() {
+		return nil, errors.NewValidationError(fmt.Sprintf(
+			"the source key has scopes for domains that no longer exist: %s; pass --remove-scope for each to clone without them",
+			strings.Join(stale, ", ")), nil)
+	}
+
+	out := cmd.ErrOrStderr()
+	reader := bufio.NewReader(cmd.InOrStdin())
+	drop := make(map[string]bool)
+	for _, scope := range stale {
+		fmt.Fprintf(out, "⚠️  Scope '%s' references a domain that no longer exists in this account.\n", scope)
+		fmt.Fprint(out, "Drop this scope from the new key? (Y/n): ")
+		answer, _ := reader.ReadString('\n')
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		drop[scope] = answer == "" || answer == "y" || answer == "yes"
+	}
+
+	kept := scopes[:0:0]
+	for _, scope := range scopes {
+		if !drop[scope] {
+			kept = append(kept, scope)
+		}
+	}
+	return kept, nil
+}
+
+// deletedDomainScopes returns the scopes restricted to domains that no
+// longer exist in the account, looking the domains up only when a scope is
+// restricted
+func deletedDomainScopes(apiClient client.AhaSendClient, source []responses.APIKeyScope, scopes []string) ([]string, error) {
+	// Source scopes may carry the restriction as a domain ID only
+	domainIDs := make(map[string]*uuid.UUID)
+	for _, scope := range source {
+		if scope.DomainID != nil {
+			domainIDs[scope.Scope] = scope.DomainID
+		}
+	}
+
+	restricted := false
+	for _, scope := range scopes {
+		_, hasDomain := validation.ScopeDomain(scope)
+		restricted = restricted || hasDomain || domainIDs[scope] != nil
+	}
+	if !restricted {
+		return nil, nil
+	}
+
+	names, ids, err := listAllDomains(apiClient)
+	if err != nil {
+		return nil, err
+	}
+
+	var stale []string
+	for _, scope := range scopes {
+		if domain, ok := validation.ScopeDomain(scope); ok {
+			if !names[strings.ToLower(domain)] {
+				stale = append(stale, scope)
+			}
+		} else if id := domainIDs[scope]; id != nil && !ids[*id] {
+			stale = append(stale, scope)
+		}
+	}
+	return stale, nil
+}
+
+// listAllDomains returns the lowercased names and the IDs of every domain in the account
+func listAllDomains(apiClient client.AhaSendClient) (map[string]bool, map[uuid.UUID]bool, error) {
+	names := make(map[string]bool)
+	ids := make(map[uuid.UUID]bool)
+	limit := int32(100)
+	var cursor *string
+	for {
+		page, err := apiClient.ListDomains(&limit, cursor)
+		if err != nil {
+			return nil, nil, err
+		}
+		if page == nil {
+			break
+		}
+		for _, domain := range page.Data {
+			names[strings.ToLower(domain.Domain)] = true
+			ids[domain.ID] = true
+		}
+		if !page.Pagination.HasMore || page.Pagination.NextCursor == nil || *page.Pagination.NextCursor == "" {
+			break
+		}
+		cursor = page.Pagination.NextCursor
+	}
+	return names, ids, nil
+}
+
+// recordRevokeReminder stores a reminder to revoke the source key once the
+// clone has replaced it
+func recordRevokeReminder(out io.Writer, source, clone *responses.APIKey, revokeAt time.Time) {
+	reminder, err := state.AddReminder(state.Reminder{
+		Kind:    state.ReminderRevokeAPIKey,
+		Subject: source.ID.String(),
+		Message: fmt.Sprintf("Revoke API key '%s' (%s), replaced by '%s' (%s): ahasend apikeys delete %s",
+			source.Label, source.ID, clone.Label, clone.ID, source.ID),
+		DueAt: revokeAt.UTC(),
+	})
+	if err != nil {
+		fmt.Fprintf(out, "⚠️  The key was created, but the revocation reminder could not be saved: %v\n", err)
+		return
+	}
+	fmt.Fprintf(out, "📅 Reminder %s: revoke the source key after %s (see 'ahasend reminders list')\n",
+		reminder.ID, reminder.DueAt.Local().Format("2006-01-02 15:04"))
+}
@@ -0,0 +1,170 @@
+package apikeys
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/state"
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func testScopes(scopes ...string) []responses.APIKeyScope {
+	result := make([]responses.APIKeyScope, len(scopes))
+	for i, scope := range scopes {
+		result[i] = responses.APIKeyScope{ID: uuid.New(), Scope: scope}
+	}
+	return result
+}
+
+func TestCloneScopes(t *testing.T) {
+	source := testScopes("messages:send:all", "domains:read", "webhooks:write:all", "domains:read")
+
+	scopes, err := cloneScopes(source, []string{"messages:read:all", "domains:read"}, []string{"webhooks:write:all"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"messages:send:all", "domains:read", "messages:read:all"}, scopes)
+
+	// Removing and re-adding a scope keeps it, at the end
+	scopes, err = cloneScopes(source, []string{"messages:send:all"}, []string{"messages:send:all"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"domains:read", "webhooks:write:all", "messages:send:all"}, scopes)
+
+	_, err = cloneScopes(source, nil, []string{"routes:read:all"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "the source key does not have it")
+}
+
+type cloneRun struct {
+	stdout     string
+	stderr     string
+	err        error
+	mockClient *mocks.MockClient
+}
+
+func executeClone(t *testing.T, interactive bool, stdin string, setup func(*mocks.MockClient), args ...string) cloneRun {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	prev := stdinIsTerminal
+	stdinIsTerminal = func() bool { return interactive }
+	t.Cleanup(func() { stdinIsTerminal = prev })
+
+	mockClient := &mocks.MockClient{}
+	setup(mockClient)
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var stdout, stderr bytes.Buffer
+	handler := printer.GetResponseHandler("table", false, &stdout)
+	cmd := NewCloneCommand()
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	return cloneRun{stdout: stdout.String(), stderr: stderr.String(), err: err, mockClient: mockClient}
+}
+
+func TestCloneCommand(t *testing.T) {
+	sourceID := uuid.New()
+	deletedDomainID := uuid.New()
+	source := &responses.APIKey{
+		ID:    sourceID,
+		Label: "old key",
+		Scopes: append(testScopes("messages:send:all", "messages:send:{gone.com}", "webhooks:read:{example.com}"),
+			responses.APIKeyScope{Scope: "routes:read:all", DomainID: &deletedDomainID}),
+	}
+	secret := "sk_new"
+
+	setup := func(m *mocks.MockClient) {
+		m.On("GetAPIKey", sourceID.String()).Return(source, nil).Once()
+		m.On("ListDomains", mock.Anything, (*string)(nil)).Return(&responses.PaginatedDomainsResponse{
+			Data: []responses.Domain{{ID: uuid.New(), Domain: "example.com"}},
+		}, nil).Once()
+	}
+
+	t.Run("drops stale scopes interactively and records a reminder", func(t *testing.T) {
+		run := executeClone(t, true, "y\nn\n", func(m *mocks.MockClient) {
+			setup(m)
+			m.On("CreateAPIKey", requests.CreateAPIKeyRequest{
+				Label:  "rotated",
+				Scopes: []string{"messages:send:all", "webhooks:read:{example.com}", "routes:read:all", "domains:read"},
+			}).Return(&responses.APIKey{ID: uuid.New(), Label: "rotated", SecretKey: &secret}, nil).Once()
+		}, sourceID.String(), "--label", "rotated", "--add-scope", "domains:read", "--revoke-source-after", "7d")
+
+		require.NoError(t, run.err)
+		run.mockClient.AssertExpectations(t)
+		assert.Contains(t, run.stderr, "Scope 'messages:send:{gone.com}' references a domain that no longer exists")
+		assert.Contains(t, run.stderr, "Scope 'routes:read:all' references a domain that no longer exists")
+		assert.Contains(t, run.stdout, "sk_new")
+		assert.Contains(t, run.stdout, "It won't be displayed again")
+
+		s, err := state.Load()
+		require.NoError(t, err)
+		require.Len(t, s.Reminders, 1)
+		reminder := s.Reminders[0]
+		assert.Equal(t, state.ReminderRevokeAPIKey, reminder.Kind)
+		assert.Equal(t, sourceID.String(), reminder.Subject)
+		assert.Contains(t, reminder.Message, "ahasend apikeys delete "+sourceID.String())
+		assert.WithinDuration(t, time.Now().AddDate(0, 0, 7), reminder.DueAt, time.Minute)
+		assert.Contains(t, run.stderr, "Reminder "+reminder.ID)
+	})
+
+	t.Run("fails without a terminal to ask on", func(t *testing.T) {
+		run := executeClone(t, false, "", setup, sourceID.String(), "--label", "rotated")
+
+		require.Error(t, run.err)
+		assert.Contains(t, run.err.Error(), "messages:send:{gone.com}, routes:read:all")
+		assert.Contains(t, run.err.Error(), "--remove-scope")
+		run.mockClient.AssertNotCalled(t, "CreateAPIKey", mock.Anything)
+	})
+
+	t.Run("removed stale scopes need no prompt", func(t *testing.T) {
+		run := executeClone(t, false, "", func(m *mocks.MockClient) {
+			setup(m)
+			m.On("CreateAPIKey", mock.MatchedBy(func(req requests.CreateAPIKeyRequest) bool {
+				return len(req.Scopes) == 2
+			})).Return(&responses.APIKey{ID: uuid.New(), Label: "rotated", SecretKey: &secret}, nil).Once()
+		}, sourceID.String(), "--label", "rotated",
+			"--remove-scope", "messages:send:{gone.com}", "--remove-scope", "routes:read:all")
+
+		require.NoError(t, run.err)
+		run.mockClient.AssertExpectations(t)
+	})
+}
+
+func TestCloneCommand_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"invalid id", []string{"not-a-uuid", "--label", "x"}, "invalid API key ID format"},
+		{"invalid added scope", []string{uuid.NewString(), "--label", "x", "--add-scope", "bogus"}, "invalid scope: bogus"},
+		{"invalid revoke time", []string{uuid.NewString(), "--label", "x", "--revoke-source-after", "soon"}, "invalid revoke-source-after"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run := executeClone(t, false, "", func(*mocks.MockClient) {}, tt.args...)
+			require.Error(t, run.err)
+			assert.Contains(t, run.err.Error(), tt.want)
+		})
+	}
+}
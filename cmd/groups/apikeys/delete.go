@@ -5,11 +5,15 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/config"
 	"github.com/AhaSend/ahasend-cli/internal/errors"
 	"github.com/AhaSend/ahasend-cli/internal/logger"
 	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-go/api"
+	"github.com/AhaSend/ahasend-go/models/responses"
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 )
@@ -31,7 +35,19 @@ Before deleting an API key, ensure:
 - You have alternative authentication methods configured
 - You have documented any systems that might be affected
 
-Use the --force flag to skip the confirmation prompt for automation.`,
+Use the --force flag to skip the confirmation prompt for automation.
+
+Deleting the key the CLI is authenticated with ends the session at once. It
+is refused unless --allow-self-delete is given, and then asks you to type the
+key's label (or its ID when it has no label) to confirm; --force skips the
+typed confirmation only when stdin is not a terminal. Afterwards the profile
+is marked as having a deleted key until 'ahasend auth login' replaces it.
+
+The key in use is recognized by the key ID recorded with 'ahasend auth login
+--api-key-id'. When it was not recorded and the API does not return the key's
+secret, the CLI cannot tell whether the key is the one in use: it warns and
+asks for confirmation even with --force, and without a terminal it needs
+--allow-self-delete as well.`,
 		Example: `  # Delete an API key (with confirmation)
   ahasend apikeys delete fcb3f3bc-4ac8-4330-948d-1671fcf9a768
 
@@ -39,13 +55,17 @@ Use the --force flag to skip the confirmation prompt for automation.`,
   ahasend apikeys delete fcb3f3bc-4ac8-4330-948d-1671fcf9a768 --force
 
   # JSON output for automation
-  ahasend apikeys delete fcb3f3bc-4ac8-4330-948d-1671fcf9a768 --force --output json`,
+  ahasend apikeys delete fcb3f3bc-4ac8-4330-948d-1671fcf9a768 --force --output json
+
+  # Delete the key this profile authenticates with
+  ahasend apikeys delete fcb3f3bc-4ac8-4330-948d-1671fcf9a768 --allow-self-delete`,
 		Args: cobra.ExactArgs(1),
 		RunE: runAPIKeyDelete,
 	}
 
 	// Delete flags
 	cmd.Flags().Bool("force", false, "Skip confirmation prompt")
+	cmd.Flags().Bool("allow-self-delete", false, "Allow deleting the API key the CLI is authenticated with")
 
 	return cmd
 }
@@ -68,9 +88,28 @@ func runAPIKeyDelete(cmd *cobra.Command, args []string) error {
 
 	// Get flag values
 	force, _ := cmd.Flags().GetBool("force")
+	allowSelfDelete, _ := cmd.Flags().GetBool("allow-self-delete")
 
-	// If not force mode, show confirmation
-	if !force {
+	// Deleting the key in use needs its own opt-in and confirmation
+	key, err := client.GetAPIKey(keyID)
+	if err != nil {
+		return err
+	}
+	apiKey, _ := client.GetAuthContext().Value(api.ContextAccessToken).(string)
+	identity := identifyKey(key, apiKey, profileKeyID(cmd, apiKey))
+	self := identity == keyInUse
+
+	switch {
+	case self:
+		if err := confirmSelfDeletion(cmd, key, allowSelfDelete, force); err != nil {
+			return err
+		}
+	case identity == keyUnidentified:
+		if err := confirmUnidentifiedDeletion(cmd, key, allowSelfDelete, force); err != nil {
+			return err
+		}
+	case !force:
+		// If not force mode, show confirmation
 		if err := confirmDeletion(keyID); err != nil {
 			return err
 		}
@@ -80,6 +119,7 @@ func runAPIKeyDelete(cmd *cobra.Command, args []string) error {
 	logger.Get().WithFields(map[string]interface{}{
 		"key_id": keyID,
 		"force":  force,
+		"self":   identity.String(),
 	}).Debug("Deleting API key")
 
 	// Delete the API key
@@ -88,6 +128,10 @@ func runAPIKeyDelete(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if self {
+		markProfileKeyDeleted(cmd)
+	}
+
 	// Handle successful deletion
 	return handler.HandleDeleteAPIKey(true, printer.DeleteConfig{
 		SuccessMessage: "✅ API Key Deleted Successfully",
@@ -110,3 +154,191 @@ func confirmDeletion(keyID string) error {
 
 	return errors.NewValidationError("operation cancelled", nil)
 }
+
+// keyIdentity says whether a key is the API key the CLI is authenticated with
+type keyIdentity int
+
+const (
+	keyUnidentified keyIdentity = iota
+	keyInUse
+	keyNotInUse
+)
+
+func (k keyIdentity) String() string {
+	switch k {
+	case keyInUse:
+		return "in use"
+	case keyNotInUse:
+		return "not in use"
+	default:
+		return "unidentified"
+	}
+}
+
+// identifyKey tells whether key is the API key the CLI is authenticated
+// with. The API does not say which key made a request, so the secret in use
+// is compared with the secret returned for the key, if any, and otherwise
+// the key ID recorded at login is compared with the key's ID. Without
+// either, the key cannot be identified.
+func identifyKey(key *responses.APIKey, apiKey, activeKeyID string) keyIdentity {
+	if key == nil || apiKey == "" {
+		return keyUnidentified
+	}
+	if key.SecretKey != nil {
+		if *key.SecretKey == apiKey {
+			return keyInUse
+		}
+		return keyNotInUse
+	}
+	if activeKeyID != "" {
+		if strings.EqualFold(activeKeyID, key.ID.String()) {
+			return keyInUse
+		}
+		return keyNotInUse
+	}
+	return keyUnidentified
+}
+
+// profileKeyID returns the key ID recorded at login for the active profile,
+// or "" when none was recorded or the command authenticates with another
+// key, such as one passed with --api-key
+func profileKeyID(cmd *cobra.Command, apiKey string) string {
+	profile, ok := activeProfile(cmd)
+	if !ok {
+		return ""
+	}
+	if profileKey, err := auth.ProfileAPIKey(profile); err != nil || profileKey != apiKey {
+		return ""
+	}
+	return profile.APIKeyID
+}
+
+// confirmUnidentifiedDeletion warns that key may be the key in use and asks
+// for confirmation, even with --force. Without a terminal the delete needs
+// --allow-self-delete and --force.
+func confirmUnidentifiedDeletion(cmd *cobra.Command, key *responses.APIKey, allowSelfDelete, force bool) error {
+	name := key.ID.String()
+	if key.Label != "" {
+		name = fmt.Sprintf("%s (%s)", key.Label, key.ID)
+	}
+	out := cmd.ErrOrStderr()
+	fmt.Fprintf(out, "⚠️  Cannot tell whether API key %s is the key this CLI is authenticated with.\n", name)
+	fmt.Fprintln(out, "If it is, deleting it ends this session immediately. Log in with 'ahasend auth login --api-key-id <key-id>' so the key in use can be recognized.")
+
+	if !stdinIsTerminal() {
+		if allowSelfDelete && force {
+			return nil
+		}
+		return errors.NewValidationError(fmt.Sprintf(
+			"cannot tell whether API key %s is the key this CLI is authenticated with; "+
+				"pass --allow-self-delete and --force to delete it anyway", name), nil)
+	}
+
+	fmt.Fprint(out, "Are you sure you want to continue? (y/N): ")
+	response, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	if err != nil && response == "" {
+		return errors.NewValidationError("failed to read confirmation", err)
+	}
+	response = strings.ToLower(strings.TrimSpace(response))
+	if response != "y" && response != "yes" {
+		return errors.NewValidationError("operation cancelled", nil)
+	}
+	return nil
+}
+
+// confirmSelfDeletion warns about deleting the key in use and asks the user
+// to type the key's label, or its ID when it has none. --allow-self-delete
+// is required; --force skips the typed confirmation only without a terminal.
+func confirmSelfDeletion(cmd *cobra.Command, key *responses.APIKey, allowSelfDelete, force bool) error {
+	name := key.ID.String()
+	if key.Label != "" {
+		name = fmt.Sprintf("%s (%s)", key.Label, key.ID)
+	}
+	if !allowSelfDelete {
+		return errors.NewValidationError(fmt.Sprintf(
+			"API key %s is the key this CLI is authenticated with; deleting it ends this session and breaks any automation using it. "+
+				"Pass --allow-self-delete to delete it anyway", name), nil)
+	}
+
+	out := cmd.ErrOrStderr()
+	fmt.Fprintf(out, "⚠️  API key %s is the key this CLI is authenticated with.\n", name)
+	fmt.Fprintln(out, "Deleting it ends this session immediately, and every script or application using it loses access.")
+
+	if !stdinIsTerminal() {
+		if force {
+			return nil
+		}
+		return errors.NewValidationError("deleting the API key in use needs a typed confirmation; run it in a terminal, or pass --force as well", nil)
+	}
+
+	expected := key.Label
+	if expected == "" {
+		expected = key.ID.String()
+	}
+	fmt.Fprintf(out, "Type %q to confirm: ", expected)
+	response, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	if err != nil && response == "" {
+		return errors.NewValidationError("failed to read confirmation", err)
+	}
+	if strings.TrimSpace(response) != expected {
+		return errors.NewValidationError("operation cancelled", nil)
+	}
+	return nil
+}
+
+// markProfileKeyDeleted records on the active profile that its key was
+// deleted, so the next command explains why it cannot authenticate. Keys
+// passed with --api-key belong to no profile.
+func markProfileKeyDeleted(cmd *cobra.Command) {
+	if apiKey, _ := cmd.Flags().GetString("api-key"); apiKey != "" {
+		return
+	}
+
+	configMgr, err := config.NewManager()
+	if err == nil {
+		err = configMgr.Load()
+	}
+	if err != nil {
+		logger.Get().WithError(err).Warn("Failed to load configuration to mark the profile's key as deleted")
+		return
+	}
+
+	profileName := profileFlagOrDefault(cmd, configMgr)
+	profile, ok := configMgr.GetConfig().Profiles[profileName]
+	if !ok {
+		return
+	}
+	profile.KeyDeletedAt = time.Now()
+	if err := configMgr.SetProfile(profileName, profile); err != nil {
+		logger.Get().WithError(err).Warn("Failed to mark the profile's key as deleted")
+		return
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Profile '%s' can no longer authenticate; run 'ahasend auth login --profile %s' with a new key.\n", profileName, profileName)
+}
+
+// activeProfile returns the profile the command authenticates with. Keys
+// passed with --api-key belong to no profile.
+func activeProfile(cmd *cobra.Command) (config.Profile, bool) {
+	if apiKey, _ := cmd.Flags().GetString("api-key"); apiKey != "" {
+		return config.Profile{}, false
+	}
+	configMgr, err := config.NewManager()
+	if err == nil {
+		err = configMgr.Load()
+	}
+	if err != nil {
+		logger.Get().WithError(err).Debug("Failed to load configuration to find the active profile")
+		return config.Profile{}, false
+	}
+	profile, ok := configMgr.GetConfig().Profiles[profileFlagOrDefault(cmd, configMgr)]
+	return profile, ok
+}
+
+// profileFlagOrDefault returns the --profile flag, or the default profile
+func profileFlagOrDefault(cmd *cobra.Command, configMgr *config.Manager) string {
+	profileName, _ := cmd.Flags().GetString("profile")
+	if profileName == "" {
+		profileName = configMgr.GetConfig().DefaultProfile
+	}
+	return profileName
+}
@@ -0,0 +1,192 @@
+package apikeys
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/AhaSend/ahasend-go/api"
+	"github.com/AhaSend/ahasend-go/models/common"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/config"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+)
+
+const activeSecret = "aha-sk-abc123def456"
+
+type deleteRun struct {
+	stdout     string
+	stderr     string
+	err        error
+	mockClient *mocks.MockClient
+}
+
+// executeDelete runs apikeys delete for key, authenticated with the
+// activeSecret of the default profile, whose key ID is activeKeyID
+func executeDelete(t *testing.T, interactive bool, stdin string, key *responses.APIKey, activeKeyID string, args ...string) deleteRun {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	configMgr, err := config.NewManager()
+	require.NoError(t, err)
+	require.NoError(t, configMgr.Load())
+	require.NoError(t, configMgr.SetProfile("default", config.Profile{APIKey: activeSecret, APIKeyID: activeKeyID, AccountID: uuid.NewString()}))
+
+	prev := stdinIsTerminal
+	stdinIsTerminal = func() bool { return interactive }
+	t.Cleanup(func() { stdinIsTerminal = prev })
+
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetAPIKey", key.ID.String()).Return(key, nil)
+	mockClient.On("GetAuthContext").Return(context.WithValue(context.Background(), api.ContextAccessToken, activeSecret))
+	mockClient.On("DeleteAPIKey", key.ID.String()).Return(&common.SuccessResponse{}, nil)
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var stdout, stderr bytes.Buffer
+	handler := printer.GetResponseHandler("table", false, &stdout)
+	cmd := NewDeleteCommand()
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetArgs(append([]string{key.ID.String()}, args...))
+
+	err = cmd.Execute()
+	return deleteRun{stdout: stdout.String(), stderr: stderr.String(), err: err, mockClient: mockClient}
+}
+
+// profileKeyDeleted reports whether the default profile is marked as having
+// a deleted key
+func profileKeyDeleted(t *testing.T) bool {
+	t.Helper()
+	configMgr, err := config.NewManager()
+	require.NoError(t, err)
+	require.NoError(t, configMgr.Load())
+	return !configMgr.GetConfig().Profiles["default"].KeyDeletedAt.IsZero()
+}
+
+func TestIdentifyKey(t *testing.T) {
+	secret := activeSecret
+	other := "aha-sk-other"
+	keyID := uuid.New()
+	tests := []struct {
+		name        string
+		key         *responses.APIKey
+		activeKeyID string
+		want        keyIdentity
+	}{
+		{"recorded key ID", &responses.APIKey{ID: keyID}, keyID.String(), keyInUse},
+		{"other recorded key ID", &responses.APIKey{ID: keyID}, uuid.NewString(), keyNotInUse},
+		{"matching secret", &responses.APIKey{ID: keyID, SecretKey: &secret}, "", keyInUse},
+		{"other secret", &responses.APIKey{ID: keyID, SecretKey: &other}, keyID.String(), keyNotInUse},
+		{"public key only", &responses.APIKey{ID: keyID, PublicKey: "aha-pk-abc123"}, "", keyUnidentified},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, identifyKey(tt.key, activeSecret, tt.activeKeyID))
+		})
+	}
+	assert.Equal(t, keyUnidentified, identifyKey(&responses.APIKey{ID: keyID}, "", keyID.String()))
+}
+
+func TestDeleteCommand_OtherKey(t *testing.T) {
+	key := &responses.APIKey{ID: uuid.New(), Label: "ci", PublicKey: "aha-pk-xyz789"}
+	run := executeDelete(t, false, "", key, uuid.NewString(), "--force")
+
+	require.NoError(t, run.err)
+	run.mockClient.AssertCalled(t, "DeleteAPIKey", key.ID.String())
+	assert.NotContains(t, run.stderr, "authenticated with")
+	assert.False(t, profileKeyDeleted(t))
+}
+
+func TestDeleteCommand_UnidentifiedKey(t *testing.T) {
+	// The public key looks like the secret's, but that proves nothing
+	key := &responses.APIKey{ID: uuid.New(), Label: "ci", PublicKey: "aha-pk-abc123"}
+
+	t.Run("refused without a terminal", func(t *testing.T) {
+		run := executeDelete(t, false, "", key, "", "--force")
+
+		require.Error(t, run.err)
+		assert.Contains(t, run.err.Error(), "cannot tell whether API key ci")
+		assert.Contains(t, run.stderr, "auth login --api-key-id")
+		run.mockClient.AssertNotCalled(t, "DeleteAPIKey", mock.Anything)
+	})
+
+	t.Run("allowed without a terminal with --allow-self-delete", func(t *testing.T) {
+		run := executeDelete(t, false, "", key, "", "--force", "--allow-self-delete")
+
+		require.NoError(t, run.err)
+		run.mockClient.AssertCalled(t, "DeleteAPIKey", key.ID.String())
+		assert.False(t, profileKeyDeleted(t))
+	})
+
+	t.Run("confirmed in a terminal even with --force", func(t *testing.T) {
+		run := executeDelete(t, true, "n\n", key, "", "--force")
+		require.Error(t, run.err)
+		assert.Contains(t, run.err.Error(), "operation cancelled")
+		run.mockClient.AssertNotCalled(t, "DeleteAPIKey", mock.Anything)
+
+		run = executeDelete(t, true, "y\n", key, "", "--force")
+		require.NoError(t, run.err)
+		run.mockClient.AssertCalled(t, "DeleteAPIKey", key.ID.String())
+	})
+}
+
+func TestDeleteCommand_SelfDelete(t *testing.T) {
+	key := &responses.APIKey{ID: uuid.New(), Label: "laptop", PublicKey: "aha-pk-xyz789"}
+	keyID := key.ID.String()
+
+	t.Run("refused without --allow-self-delete", func(t *testing.T) {
+		run := executeDelete(t, true, "laptop\n", key, keyID, "--force")
+
+		require.Error(t, run.err)
+		assert.Contains(t, run.err.Error(), "is the key this CLI is authenticated with")
+		assert.Contains(t, run.err.Error(), "--allow-self-delete")
+		run.mockClient.AssertNotCalled(t, "DeleteAPIKey", mock.Anything)
+		assert.False(t, profileKeyDeleted(t))
+	})
+
+	t.Run("cancelled when the label is mistyped", func(t *testing.T) {
+		run := executeDelete(t, true, "y\n", key, keyID, "--allow-self-delete")
+
+		require.Error(t, run.err)
+		assert.Contains(t, run.err.Error(), "operation cancelled")
+		assert.Contains(t, run.stderr, "ends this session immediately")
+		assert.Contains(t, run.stderr, `Type "laptop" to confirm`)
+		run.mockClient.AssertNotCalled(t, "DeleteAPIKey", mock.Anything)
+	})
+
+	t.Run("typed confirmation deletes and invalidates the profile", func(t *testing.T) {
+		run := executeDelete(t, true, "laptop\n", key, keyID, "--allow-self-delete")
+
+		require.NoError(t, run.err)
+		run.mockClient.AssertCalled(t, "DeleteAPIKey", key.ID.String())
+		assert.Contains(t, run.stderr, "Profile 'default' can no longer authenticate")
+		assert.True(t, profileKeyDeleted(t))
+	})
+
+	t.Run("needs --force without a terminal", func(t *testing.T) {
+		run := executeDelete(t, false, "", key, keyID, "--allow-self-delete")
+		require.Error(t, run.err)
+		assert.Contains(t, run.err.Error(), "needs a typed confirmation")
+		run.mockClient.AssertNotCalled(t, "DeleteAPIKey", mock.Anything)
+
+		run = executeDelete(t, false, "", key, keyID, "--allow-self-delete", "--force")
+		require.NoError(t, run.err)
+		run.mockClient.AssertCalled(t, "DeleteAPIKey", key.ID.String())
+		assert.True(t, profileKeyDeleted(t))
+	})
+}
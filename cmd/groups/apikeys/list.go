@@ -34,6 +34,7 @@ The secret value of API keys is never displayed for security reasons.`,
 	// Pagination flags
 	cmd.Flags().Int32("limit", 20, "Maximum number of API keys to return (1-100)")
 	cmd.Flags().String("cursor", "", "Pagination cursor for next page")
+	printer.AddIDsOnlyFlag(cmd)
 
 	return cmd
 }
@@ -0,0 +1,173 @@
+package apikeys
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/api"
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+)
+
+// now is replaced in tests to date the generated labels
+var now = time.Now
+
+// rotatedSuffix matches the suffix added by a previous rotation, so that
+// rotating a rotated key does not stack dates
+var rotatedSuffix = regexp.MustCompile(`-rotated-\d{4}-\d{2}-\d{2}$`)
+
+// NewRotateCommand creates the apikeys rotate command
+func NewRotateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rotate <key-id>",
+		Short: "Replace an API key with a new one with the same scopes",
+		Long: `Replace an API key in one step: create a new key with exactly the same
+scopes, show its secret and delete the old key.
+
+The new key is labelled after the old one with a "-rotated-<date>" suffix;
+--label sets another label. The old key is deleted only once the new key
+has been created, and --keep-old leaves it in place, e.g. to delete it once
+every deployment uses the new key. The new secret is displayed once and
+cannot be retrieved again.
+
+Domain-restricted scopes are checked against the domains in your account
+first. When a scope references a domain that no longer exists, nothing is
+created or deleted; use 'ahasend apikeys clone --remove-scope' to create a
+replacement without it.
+
+The key this CLI is authenticated with can only be rotated with --keep-old,
+as deleting it would end the session; log in with the new key, then delete
+the old one.`,
+		Example: `  # Rotate a key
+  ahasend apikeys rotate fcb3f3bc-4ac8-4330-948d-1671fcf9a768
+
+  # Rotate with a new label, keeping the old key until deployments are updated
+  ahasend apikeys rotate fcb3f3bc-4ac8-4330-948d-1671fcf9a768 \
+    --label "Production API 2024-06" --keep-old
+
+  # Rotate and capture the new secret
+  ahasend apikeys rotate fcb3f3bc-4ac8-4330-948d-1671fcf9a768 --output json | jq -r .secret_key`,
+		Args:         cobra.ExactArgs(1),
+		RunE:         runAPIKeyRotate,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().String("label", "", `Label for the new API key (default: the old label with a "-rotated-<date>" suffix)`)
+	cmd.Flags().Bool("keep-old", false, "Keep the old API key instead of deleting it")
+
+	return cmd
+}
+
+func runAPIKeyRotate(cmd *cobra.Command, args []string) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+
+	keyID := args[0]
+	if _, err := uuid.Parse(keyID); err != nil {
+		return errors.NewValidationError(fmt.Sprintf("invalid API key ID format: %s", keyID), err)
+	}
+
+	label, _ := cmd.Flags().GetString("label")
+	keepOld, _ := cmd.Flags().GetBool("keep-old")
+
+	apiClient, err := auth.GetAuthenticatedClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	old, err := apiClient.GetAPIKey(keyID)
+	if err != nil {
+		return err
+	}
+	if old == nil {
+		return errors.NewNotFoundError(fmt.Sprintf("API key %s not found", keyID), nil)
+	}
+
+	if !keepOld {
+		apiKey, _ := apiClient.GetAuthContext().Value(api.ContextAccessToken).(string)
+		if identifyKey(old, apiKey, profileKeyID(cmd, apiKey)) == keyInUse {
+			return errors.NewValidationError(fmt.Sprintf(
+				"API key %s is the key this CLI is authenticated with; deleting it would end this session. "+
+					"Rotate it with --keep-old, log in with the new key, then delete the old one", keyID), nil)
+		}
+	}
+
+	scopes, err := cloneScopes(old.Scopes, nil, nil)
+	if err != nil {
+		return err
+	}
+	if len(scopes) == 0 {
+		return errors.NewValidationError(fmt.Sprintf("API key %s has no scopes to copy", keyID), nil)
+	}
+
+	stale, err := deletedDomainScopes(apiClient, old.Scopes, scopes)
+	if err != nil {
+		return err
+	}
+	if len(stale) > 0 {
+		return errors.NewValidationError(fmt.Sprintf(
+			"cannot copy the scopes of API key %s, nothing was created or deleted: these scopes reference domains that no longer exist: %s; "+
+				"use 'ahasend apikeys clone --remove-scope' to replace the key without them",
+			keyID, strings.Join(stale, ", ")), nil)
+	}
+
+	if label == "" {
+		label = rotatedLabel(old.Label, now())
+	}
+
+	logger.Get().WithFields(map[string]interface{}{
+		"key_id":   keyID,
+		"label":    label,
+		"scopes":   scopes,
+		"keep_old": keepOld,
+	}).Debug("Rotating API key")
+
+	newKey, err := apiClient.CreateAPIKey(requests.CreateAPIKeyRequest{
+		Label:  label,
+		Scopes: scopes,
+	})
+	if err != nil {
+		return err
+	}
+
+	// The new key exists at this point and its secret must still be shown,
+	// so a failed delete is reported after it
+	message := fmt.Sprintf("✅ API Key Rotated Successfully; old key %s deleted", keyID)
+	var deleteErr error
+	if keepOld {
+		message = fmt.Sprintf("✅ API Key Rotated Successfully; old key %s kept", keyID)
+	} else if _, deleteErr = apiClient.DeleteAPIKey(keyID); deleteErr != nil {
+		message = fmt.Sprintf("✅ New API Key Created; old key %s could not be deleted", keyID)
+	}
+
+	if err := handler.HandleCreateAPIKey(newKey, printer.CreateConfig{
+		SuccessMessage: message,
+		ItemName:       "API key",
+		FieldOrder:     []string{"id", "label", "public_key", "secret_key", "scopes", "created_at"},
+	}); err != nil {
+		return err
+	}
+	if deleteErr != nil {
+		return errors.NewAPIError(fmt.Sprintf(
+			"the new API key was created, but deleting the old key %s failed; delete it with 'ahasend apikeys delete %s'", keyID, keyID), deleteErr)
+	}
+	return nil
+}
+
+// rotatedLabel labels a replacement key after the key it replaces, dated
+// with the day of the rotation
+func rotatedLabel(label string, at time.Time) string {
+	suffix := "rotated-" + at.Format("2006-01-02")
+	label = rotatedSuffix.ReplaceAllString(label, "")
+	if label == "" {
+		return suffix
+	}
+	return label + "-" + suffix
+}
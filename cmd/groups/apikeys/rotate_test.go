@@ -0,0 +1,143 @@
+package apikeys
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/api"
+	"github.com/AhaSend/ahasend-go/models/common"
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/config"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+)
+
+// executeRotate runs apikeys rotate for key, authenticated with the
+// activeSecret of the default profile, whose key ID is activeKeyID
+func executeRotate(t *testing.T, key *responses.APIKey, activeKeyID string, setup func(*mocks.MockClient), args ...string) deleteRun {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	configMgr, err := config.NewManager()
+	require.NoError(t, err)
+	require.NoError(t, configMgr.Load())
+	require.NoError(t, configMgr.SetProfile("default", config.Profile{APIKey: activeSecret, APIKeyID: activeKeyID, AccountID: uuid.NewString()}))
+
+	prevNow := now
+	now = func() time.Time { return time.Date(2024, 6, 3, 12, 0, 0, 0, time.UTC) }
+	t.Cleanup(func() { now = prevNow })
+
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetAPIKey", key.ID.String()).Return(key, nil)
+	mockClient.On("GetAuthContext").Return(context.WithValue(context.Background(), api.ContextAccessToken, activeSecret)).Maybe()
+	setup(mockClient)
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var stdout, stderr bytes.Buffer
+	handler := printer.GetResponseHandler("table", false, &stdout)
+	cmd := NewRotateCommand()
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs(append([]string{key.ID.String()}, args...))
+
+	err = cmd.Execute()
+	return deleteRun{stdout: stdout.String(), stderr: stderr.String(), err: err, mockClient: mockClient}
+}
+
+func TestRotateCommand(t *testing.T) {
+	oldKey := &responses.APIKey{
+		ID:     uuid.New(),
+		Label:  "Production",
+		Scopes: testScopes("messages:send:all", "webhooks:read:{example.com}"),
+	}
+	secret := "sk_new"
+	created := &responses.APIKey{ID: uuid.New(), Label: "Production-rotated-2024-06-03", SecretKey: &secret}
+	domains := func(m *mocks.MockClient) {
+		m.On("ListDomains", mock.Anything, (*string)(nil)).Return(&responses.PaginatedDomainsResponse{
+			Data: []responses.Domain{{ID: uuid.New(), Domain: "example.com"}},
+		}, nil).Once()
+	}
+	createsCopy := func(label string) func(*mocks.MockClient) {
+		return func(m *mocks.MockClient) {
+			domains(m)
+			m.On("CreateAPIKey", requests.CreateAPIKeyRequest{
+				Label:  label,
+				Scopes: []string{"messages:send:all", "webhooks:read:{example.com}"},
+			}).Return(created, nil).Once()
+		}
+	}
+
+	t.Run("creates a copy, then deletes the old key", func(t *testing.T) {
+		run := executeRotate(t, oldKey, "", func(m *mocks.MockClient) {
+			createsCopy("Production-rotated-2024-06-03")(m)
+			m.On("DeleteAPIKey", oldKey.ID.String()).Return(&common.SuccessResponse{}, nil).Once()
+		})
+
+		require.NoError(t, run.err)
+		run.mockClient.AssertExpectations(t)
+		assert.Contains(t, run.stdout, "sk_new")
+		assert.Contains(t, run.stdout, fmt.Sprintf("old key %s deleted", oldKey.ID))
+	})
+
+	t.Run("keep old with a label", func(t *testing.T) {
+		run := executeRotate(t, oldKey, "", createsCopy("Production v2"), "--label", "Production v2", "--keep-old")
+
+		require.NoError(t, run.err)
+		run.mockClient.AssertExpectations(t)
+		run.mockClient.AssertNotCalled(t, "DeleteAPIKey", mock.Anything)
+		assert.Contains(t, run.stdout, fmt.Sprintf("old key %s kept", oldKey.ID))
+	})
+
+	t.Run("shows the new secret when the delete fails", func(t *testing.T) {
+		run := executeRotate(t, oldKey, "", func(m *mocks.MockClient) {
+			createsCopy("Production-rotated-2024-06-03")(m)
+			m.On("DeleteAPIKey", oldKey.ID.String()).Return(nil, fmt.Errorf("server error")).Once()
+		})
+
+		require.Error(t, run.err)
+		assert.Contains(t, run.err.Error(), "ahasend apikeys delete "+oldKey.ID.String())
+		assert.Contains(t, run.stdout, "sk_new")
+	})
+
+	t.Run("aborts on a scope for a deleted domain", func(t *testing.T) {
+		stale := &responses.APIKey{ID: uuid.New(), Label: "Old", Scopes: testScopes("messages:send:all", "messages:send:{gone.com}")}
+		run := executeRotate(t, stale, "", domains)
+
+		require.Error(t, run.err)
+		assert.Contains(t, run.err.Error(), "messages:send:{gone.com}")
+		assert.Contains(t, run.err.Error(), "nothing was created or deleted")
+		run.mockClient.AssertNotCalled(t, "CreateAPIKey", mock.Anything)
+		run.mockClient.AssertNotCalled(t, "DeleteAPIKey", mock.Anything)
+	})
+
+	t.Run("refuses to delete the key in use", func(t *testing.T) {
+		run := executeRotate(t, oldKey, oldKey.ID.String(), func(*mocks.MockClient) {})
+
+		require.Error(t, run.err)
+		assert.Contains(t, run.err.Error(), "--keep-old")
+		run.mockClient.AssertNotCalled(t, "CreateAPIKey", mock.Anything)
+	})
+}
+
+func TestRotatedLabel(t *testing.T) {
+	at := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, "Production-rotated-2024-06-03", rotatedLabel("Production", at))
+	assert.Equal(t, "Production-rotated-2024-06-03", rotatedLabel("Production-rotated-2024-01-15", at))
+	assert.Equal(t, "rotated-2024-06-03", rotatedLabel("", at))
+}
@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/spf13/cobra"
+)
+
+// These are replaced in tests to use mock clients and exercise the prompts
+var (
+	stdinIsTerminal = func() bool { return printer.IsTerminal(os.Stdin) }
+
+	newKeyClient = func(apiKey, apiURL string) (client.AhaSendClient, error) {
+		return client.NewKeyClient(apiKey, apiURL)
+	}
+	newAccountClient = func(apiKey, accountID, apiURL string) (client.AhaSendClient, error) {
+		return client.NewClient(apiKey, accountID, apiURL)
+	}
+)
+
+// chooseAccount picks the account to bind a profile to. A requested account
+// ID must be one of the accounts; otherwise a single account is used as is,
+// and with several the user chooses in a terminal. Without a terminal the
+// error lists the available account IDs.
+func chooseAccount(cmd *cobra.Command, accounts []responses.Account, requested string) (*responses.Account, error) {
+	if requested != "" {
+		for i := range accounts {
+			if strings.EqualFold(accounts[i].ID.String(), requested) {
+				return &accounts[i], nil
+			}
+		}
+		return nil, errors.NewValidationError(fmt.Sprintf("account %s is not accessible with this API key; available accounts:\n%s",
+			requested, formatAccountList(accounts)), nil)
+	}
+
+	switch {
+	case len(accounts) == 0:
+		return nil, errors.NewValidationError("the API key does not have access to any account", nil)
+	case len(accounts) == 1:
+		return &accounts[0], nil
+	case !stdinIsTerminal():
+		return nil, errors.NewValidationError(fmt.Sprintf("the API key has access to %d accounts; pass --account-id with one of:\n%s",
+			len(accounts), formatAccountList(accounts)), nil)
+	}
+
+	return promptAccountSelection(cmd.InOrStdin(), cmd.ErrOrStderr(), accounts)
+}
+
+// promptAccountSelection shows a numbered list of accounts and reads the
+// choice, asking again until it is valid
+func promptAccountSelection(in io.Reader, out io.Writer, accounts []responses.Account) (*responses.Account, error) {
+	fmt.Fprintf(out, "\nThis API key has access to %d accounts:\n\n", len(accounts))
+	for i, account := range accounts {
+		fmt.Fprintf(out, "  %d. %s (%s, created %s)\n", i+1, account.Name, account.ID, account.CreatedAt.Format("2006-01-02"))
+	}
+	fmt.Fprintln(out)
+
+	reader := bufio.NewReader(in)
+	for {
+		fmt.Fprintf(out, "Select an account [1-%d]: ", len(accounts))
+		answer, err := reader.ReadString('\n')
+		choice, convErr := strconv.Atoi(strings.TrimSpace(answer))
+		if convErr == nil && choice >= 1 && choice <= len(accounts) {
+			return &accounts[choice-1], nil
+		}
+		if err != nil {
+			return nil, errors.NewValidationError("no account selected", err)
+		}
+		fmt.Fprintf(out, "Please enter a number between 1 and %d.\n", len(accounts))
+	}
+}
+
+// formatAccountList lists account IDs and names, one per line
+func formatAccountList(accounts []responses.Account) string {
+	lines := make([]string, len(accounts))
+	for i, account := range accounts {
+		lines[i] = fmt.Sprintf("  %s  %s", account.ID, account.Name)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// resolveLoginAccount returns the account ID to log in with. --account-id is
+// used as given; otherwise the accounts the key can access are listed and
+// one is chosen. When the accounts cannot be listed, an interactive login
+// falls back to asking for the account ID.
+func resolveLoginAccount(cmd *cobra.Command, apiKey, apiURL, accountID string) (string, error) {
+	if accountID != "" {
+		return accountID, nil
+	}
+
+	keyClient, err := newKeyClient(apiKey, apiURL)
+	if err != nil {
+		return "", errors.NewAuthError("failed to create API client", err)
+	}
+	if err := keyClient.Ping(); err != nil {
+		return "", errors.Translate(err)
+	}
+
+	accounts, err := keyClient.ListAccounts()
+	if err != nil {
+		logger.Get().WithError(err).Debug("Failed to list accounts for the API key")
+		if !stdinIsTerminal() {
+			return "", errors.NewValidationError("could not list the accounts for this API key; pass --account-id", err)
+		}
+		return promptAccountID()
+	}
+
+	account, err := chooseAccount(cmd, accounts, "")
+	if err != nil {
+		return "", err
+	}
+	return account.ID.String(), nil
+}
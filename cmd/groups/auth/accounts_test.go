@@ -0,0 +1,313 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/config"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	primaryAccount = responses.Account{
+		ID:        uuid.MustParse("11111111-1111-4111-8111-111111111111"),
+		Name:      "Acme",
+		CreatedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+	stagingAccount = responses.Account{
+		ID:        uuid.MustParse("22222222-2222-4222-8222-222222222222"),
+		Name:      "Acme Staging",
+		CreatedAt: time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC),
+	}
+)
+
+// loginKeyID is the only API key ID the account clients know
+var loginKeyID = uuid.MustParse("33333333-3333-4333-8333-333333333333")
+
+// accountClients replaces the API clients used by login and switch-account.
+// The key client lists accounts; the account client validates the chosen one.
+type accountClients struct {
+	key      *mocks.MockClient
+	boundTo  []string
+	terminal bool
+}
+
+func useAccountClients(t *testing.T, accounts []responses.Account, terminal bool) *accountClients {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	// The config manager keeps saved values in the global viper instance,
+	// where they would override the config files written by later tests
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	clients := &accountClients{key: &mocks.MockClient{}, terminal: terminal}
+	clients.key.On("Ping").Return(nil)
+	if accounts != nil {
+		clients.key.On("ListAccounts").Return(accounts, nil)
+	}
+
+	prevKey, prevAccount, prevTerminal := newKeyClient, newAccountClient, stdinIsTerminal
+	newKeyClient = func(apiKey, apiURL string) (client.AhaSendClient, error) {
+		return clients.key, nil
+	}
+	newAccountClient = func(apiKey, accountID, apiURL string) (client.AhaSendClient, error) {
+		clients.boundTo = append(clients.boundTo, accountID)
+		account := &mocks.MockClient{}
+		account.On("Ping").Return(nil)
+		account.On("GetAPIKey", loginKeyID.String()).Return(&responses.APIKey{ID: loginKeyID}, nil)
+		account.On("GetAPIKey", mock.Anything).Return((*responses.APIKey)(nil), assert.AnError)
+		for _, candidate := range []responses.Account{primaryAccount, stagingAccount} {
+			if candidate.ID.String() == accountID {
+				account.On("GetAccount").Return(&candidate, nil)
+				return account, nil
+			}
+		}
+		account.On("GetAccount").Return(nil, assert.AnError)
+		return account, nil
+	}
+	stdinIsTerminal = func() bool { return clients.terminal }
+	t.Cleanup(func() {
+		newKeyClient, newAccountClient, stdinIsTerminal = prevKey, prevAccount, prevTerminal
+	})
+	return clients
+}
+
+func executeAuthCommand(t *testing.T, cmd *cobra.Command, stdin string, args ...string) (string, string, error) {
+	t.Helper()
+	var out, errOut bytes.Buffer
+	handler := printer.GetResponseHandler("plain", false, &out)
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetOut(&out)
+	cmd.SetErr(&errOut)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), errOut.String(), err
+}
+
+func loadProfile(t *testing.T, name string) (config.Profile, bool) {
+	t.Helper()
+	configMgr, err := config.NewManager()
+	require.NoError(t, err)
+	require.NoError(t, configMgr.Load())
+	profile, ok := configMgr.GetConfig().Profiles[name]
+	return profile, ok
+}
+
+func TestLogin_SingleAccountIsDetected(t *testing.T) {
+	clients := useAccountClients(t, []responses.Account{primaryAccount}, false)
+
+	out, _, err := executeAuthCommand(t, NewLoginCommand(), "", "--api-key", "test-key")
+	require.NoError(t, err)
+	assert.Contains(t, out, "for account 'Acme' ("+primaryAccount.ID.String()+")")
+	assert.Equal(t, []string{primaryAccount.ID.String()}, clients.boundTo)
+
+	profile, ok := loadProfile(t, "default")
+	require.True(t, ok)
+	assert.Equal(t, primaryAccount.ID.String(), profile.AccountID)
+	assert.Equal(t, "Acme", profile.AccountName)
+}
+
+func TestLogin_MultipleAccountsPrompt(t *testing.T) {
+	useAccountClients(t, []responses.Account{primaryAccount, stagingAccount}, true)
+
+	_, prompt, err := executeAuthCommand(t, NewLoginCommand(), "7\n2\n", "--api-key", "test-key")
+	require.NoError(t, err)
+	assert.Contains(t, prompt, "1. Acme ("+primaryAccount.ID.String()+", created 2024-01-02)")
+	assert.Contains(t, prompt, "2. Acme Staging ("+stagingAccount.ID.String()+", created 2024-05-06)")
+	assert.Contains(t, prompt, "Please enter a number between 1 and 2")
+
+	profile, ok := loadProfile(t, "default")
+	require.True(t, ok)
+	assert.Equal(t, stagingAccount.ID.String(), profile.AccountID)
+	assert.Equal(t, "Acme Staging", profile.AccountName)
+}
+
+func TestLogin_MultipleAccountsWithoutTerminal(t *testing.T) {
+	useAccountClients(t, []responses.Account{primaryAccount, stagingAccount}, false)
+
+	_, _, err := executeAuthCommand(t, NewLoginCommand(), "", "--api-key", "test-key")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "the API key has access to 2 accounts; pass --account-id")
+	assert.Contains(t, err.Error(), primaryAccount.ID.String())
+	assert.Contains(t, err.Error(), stagingAccount.ID.String())
+
+	_, ok := loadProfile(t, "default")
+	assert.False(t, ok, "no profile is saved")
+}
+
+func TestLogin_AccountIDSkipsSelection(t *testing.T) {
+	clients := useAccountClients(t, nil, false)
+
+	_, _, err := executeAuthCommand(t, NewLoginCommand(), "",
+		"--api-key", "test-key", "--account-id", stagingAccount.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, []string{stagingAccount.ID.String()}, clients.boundTo)
+	clients.key.AssertNotCalled(t, "ListAccounts")
+}
+
+func TestLogin_ListingFailsWithoutTerminal(t *testing.T) {
+	clients := useAccountClients(t, nil, false)
+	clients.key.On("ListAccounts").Return(nil, assert.AnError)
+
+	_, _, err := executeAuthCommand(t, NewLoginCommand(), "", "--api-key", "test-key")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "could not list the accounts for this API key; pass --account-id")
+}
+
+func TestLogin_KeepsProfileSettings(t *testing.T) {
+	useAccountClients(t, []responses.Account{primaryAccount}, false)
+	configDir := filepath.Join(os.Getenv("HOME"), ".ahasend")
+	require.NoError(t, os.MkdirAll(configDir, 0o755))
+	content := `default_profile: default
+profiles:
+  default:
+    name: Production
+    api_key: deleted-key
+    account_id: ` + stagingAccount.ID.String() + `
+    confirm_threshold: 25
+    default_test_recipient: me@example.com
+    test_tag: smoke
+    default_from: hello@example.com
+    key_deleted_at: 2026-01-02T00:00:00Z
+`
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte(content), 0o600))
+
+	_, _, err := executeAuthCommand(t, NewLoginCommand(), "", "--api-key", "new-key")
+	require.NoError(t, err)
+
+	profile, ok := loadProfile(t, "default")
+	require.True(t, ok)
+	assert.Equal(t, "new-key", profile.APIKey)
+	assert.Equal(t, primaryAccount.ID.String(), profile.AccountID)
+	assert.Equal(t, "Acme", profile.AccountName)
+	assert.Equal(t, "Production", profile.Name)
+	require.NotNil(t, profile.ConfirmThreshold)
+	assert.Equal(t, 25, *profile.ConfirmThreshold)
+	assert.Equal(t, "me@example.com", profile.DefaultTestRecipient)
+	assert.Equal(t, "smoke", profile.TestTag)
+	assert.Equal(t, "hello@example.com", profile.DefaultFrom)
+	assert.True(t, profile.KeyDeletedAt.IsZero(), "the new key makes the profile usable again")
+}
+
+func TestLogin_RecordsAPIKeyID(t *testing.T) {
+	useAccountClients(t, []responses.Account{primaryAccount}, false)
+
+	_, _, err := executeAuthCommand(t, NewLoginCommand(), "", "--api-key", "test-key", "--api-key-id", loginKeyID.String())
+	require.NoError(t, err)
+	profile, ok := loadProfile(t, "default")
+	require.True(t, ok)
+	assert.Equal(t, loginKeyID.String(), profile.APIKeyID)
+
+	// Logging in again with the same key keeps the ID; another key drops it
+	_, _, err = executeAuthCommand(t, NewLoginCommand(), "", "--api-key", "test-key")
+	require.NoError(t, err)
+	profile, _ = loadProfile(t, "default")
+	assert.Equal(t, loginKeyID.String(), profile.APIKeyID)
+
+	_, _, err = executeAuthCommand(t, NewLoginCommand(), "", "--api-key", "other-key")
+	require.NoError(t, err)
+	profile, _ = loadProfile(t, "default")
+	assert.Empty(t, profile.APIKeyID)
+}
+
+func TestLogin_RejectsUnknownAPIKeyID(t *testing.T) {
+	useAccountClients(t, []responses.Account{primaryAccount}, false)
+
+	_, _, err := executeAuthCommand(t, NewLoginCommand(), "", "--api-key", "test-key", "--api-key-id", uuid.NewString())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "was not found in account")
+
+	_, ok := loadProfile(t, "default")
+	assert.False(t, ok, "no profile is saved")
+}
+
+func writeBoundProfile(t *testing.T, accountID string) {
+	t.Helper()
+	configDir := filepath.Join(os.Getenv("HOME"), ".ahasend")
+	require.NoError(t, os.MkdirAll(configDir, 0o755))
+	content := "default_profile: default\nprofiles:\n  default:\n    name: default\n    api_key: test-key\n    account_id: " + accountID + "\n"
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte(content), 0o600))
+}
+
+func TestSwitchAccount(t *testing.T) {
+	accounts := []responses.Account{primaryAccount, stagingAccount}
+
+	t.Run("by account ID", func(t *testing.T) {
+		useAccountClients(t, accounts, false)
+		writeBoundProfile(t, primaryAccount.ID.String())
+
+		out, _, err := executeAuthCommand(t, NewSwitchAccountCommand(), "", stagingAccount.ID.String())
+		require.NoError(t, err)
+		assert.Contains(t, out, "Profile 'default' now uses account 'Acme Staging'")
+
+		profile, ok := loadProfile(t, "default")
+		require.True(t, ok)
+		assert.Equal(t, stagingAccount.ID.String(), profile.AccountID)
+		assert.Equal(t, "Acme Staging", profile.AccountName)
+		assert.Equal(t, "test-key", profile.APIKey, "the API key is kept")
+	})
+
+	t.Run("interactive", func(t *testing.T) {
+		useAccountClients(t, accounts, true)
+		writeBoundProfile(t, primaryAccount.ID.String())
+
+		_, _, err := executeAuthCommand(t, NewSwitchAccountCommand(), "2\n")
+		require.NoError(t, err)
+		profile, _ := loadProfile(t, "default")
+		assert.Equal(t, stagingAccount.ID.String(), profile.AccountID)
+	})
+
+	t.Run("already bound", func(t *testing.T) {
+		useAccountClients(t, accounts, false)
+		writeBoundProfile(t, primaryAccount.ID.String())
+
+		out, _, err := executeAuthCommand(t, NewSwitchAccountCommand(), "", primaryAccount.ID.String())
+		require.NoError(t, err)
+		assert.Contains(t, out, "already uses account 'Acme'")
+	})
+
+	t.Run("without terminal lists the accounts", func(t *testing.T) {
+		useAccountClients(t, accounts, false)
+		writeBoundProfile(t, primaryAccount.ID.String())
+
+		_, _, err := executeAuthCommand(t, NewSwitchAccountCommand(), "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), stagingAccount.ID.String())
+	})
+
+	t.Run("inaccessible account", func(t *testing.T) {
+		useAccountClients(t, accounts, false)
+		writeBoundProfile(t, primaryAccount.ID.String())
+
+		_, _, err := executeAuthCommand(t, NewSwitchAccountCommand(), "", uuid.NewString())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "is not accessible with this API key")
+
+		profile, _ := loadProfile(t, "default")
+		assert.Equal(t, primaryAccount.ID.String(), profile.AccountID, "the profile is unchanged")
+	})
+
+	t.Run("unknown profile", func(t *testing.T) {
+		useAccountClients(t, accounts, false)
+		writeBoundProfile(t, primaryAccount.ID.String())
+
+		_, _, err := executeAuthCommand(t, NewSwitchAccountCommand(), "", "--profile", "staging")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "profile 'staging' not found")
+	})
+}
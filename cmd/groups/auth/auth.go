@@ -1,6 +1,7 @@
 package auth
 
 import (
+	authn "github.com/AhaSend/ahasend-cli/internal/auth"
 	"github.com/spf13/cobra"
 )
 
@@ -16,7 +17,13 @@ Common workflow:
   1. Login with your API key: ahasend auth login
   2. Check your status: ahasend auth status
   3. Switch between profiles: ahasend auth switch <profile>
-  4. Logout when done: ahasend auth logout`,
+  4. Bind a profile to another account: ahasend auth switch-account
+  5. Logout when done: ahasend auth logout
+
+API keys are kept in the configuration file unless you log in with
+--use-keyring or --encrypt-config; 'ahasend auth migrate-keys' moves
+existing keys.`,
+		Annotations: map[string]string{authn.NoAuthAnnotation: "true"},
 	}
 
 	// Add subcommands
@@ -24,6 +31,8 @@ Common workflow:
 	cmd.AddCommand(NewLogoutCommand())
 	cmd.AddCommand(NewStatusCommand())
 	cmd.AddCommand(NewSwitchCommand())
+	cmd.AddCommand(NewSwitchAccountCommand())
+	cmd.AddCommand(NewMigrateKeysCommand())
 
 	return cmd
 }
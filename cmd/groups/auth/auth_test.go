@@ -9,7 +9,7 @@ import (
 func TestAuthCommandStructure(t *testing.T) {
 	// Create a fresh auth command and verify it has expected subcommands
 	authCmd := NewCommand()
-	expectedSubcommands := []string{"login", "logout", "status", "switch"}
+	expectedSubcommands := []string{"login", "logout", "status", "switch", "switch-account"}
 
 	subcommands := make([]string, 0)
 	for _, cmd := range authCmd.Commands() {
@@ -8,11 +8,14 @@ import (
 	"syscall"
 	"time"
 
+	authn "github.com/AhaSend/ahasend-cli/internal/auth"
 	"github.com/AhaSend/ahasend-cli/internal/client"
 	"github.com/AhaSend/ahasend-cli/internal/config"
+	"github.com/AhaSend/ahasend-cli/internal/credentials"
 	"github.com/AhaSend/ahasend-cli/internal/errors"
 	"github.com/AhaSend/ahasend-cli/internal/logger"
 	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
 )
@@ -25,6 +28,27 @@ func NewLoginCommand() *cobra.Command {
 		Long: `Authenticate with AhaSend by providing your API key and account ID.
 This command will validate your credentials and store them securely for future use.
 
+Without --account-id, the accounts the API key can access are listed. A key
+with a single account is bound to it; with several you choose one from a
+list. Non-interactive logins with a multi-account key must pass --account-id;
+the error lists the available account IDs. Use 'ahasend auth switch-account'
+to bind the profile to another account later.
+
+Pass --api-key-id with the ID of the API key to record which key the profile
+uses. 'ahasend apikeys delete' needs it to recognize the key in use; without
+it, deleting any key asks for confirmation.
+
+By default the API key is saved in plain text in ~/.ahasend/config.yaml. With
+--use-keyring it goes to the OS keychain (macOS Keychain, Windows Credential
+Manager or the Secret Service on Linux) and the configuration file keeps only
+a reference; when no keychain is available, it goes to the encrypted
+credentials file instead. With --encrypt-config it goes to
+~/.ahasend/credentials.enc, encrypted with a passphrase that is prompted for
+or read from AHASEND_CONFIG_PASSPHRASE. Logging in again keeps the key where
+the profile kept it unless one of these flags is given. In a terminal, you
+are offered to move the plain text keys of other profiles too; see
+'ahasend auth migrate-keys' to move keys later or back.
+
 You can create API keys in your AhaSend dashboard at https://app.ahasend.com`,
 		Example: `  # Interactive login
   ahasend auth login
@@ -33,7 +57,19 @@ You can create API keys in your AhaSend dashboard at https://app.ahasend.com`,
   ahasend auth login --profile production
 
   # Login with API key directly (not recommended for production)
-  ahasend auth login --api-key your-api-key --account-id your-account-id`,
+  ahasend auth login --api-key your-api-key --account-id your-account-id
+
+  # Login with a single-account API key; the account is detected
+  ahasend auth login --api-key your-api-key
+
+  # Record the key's ID so apikeys delete can recognize it
+  ahasend auth login --api-key your-api-key --api-key-id fcb3f3bc-4ac8-4330-948d-1671fcf9a768
+
+  # Keep the API key in the OS keychain
+  ahasend auth login --use-keyring
+
+  # Keep the API key in the encrypted credentials file, non-interactively
+  AHASEND_CONFIG_PASSPHRASE=... ahasend auth login --encrypt-config --api-key your-api-key`,
 		RunE:         runLogin,
 		SilenceUsage: true,
 	}
@@ -41,7 +77,11 @@ You can create API keys in your AhaSend dashboard at https://app.ahasend.com`,
 	cmd.Flags().String("profile", "", "Profile name to save credentials under")
 	cmd.Flags().String("api-key", "", "AhaSend API key (not recommended, use interactive prompt)")
 	cmd.Flags().String("account-id", "", "AhaSend Account ID")
-	cmd.Flags().String("api-url", "https://api.ahasend.com", "AhaSend API URL")
+	cmd.Flags().String("api-key-id", "", "ID of the API key, used to recognize it in 'apikeys delete'")
+	cmd.Flags().String("api-url", client.DefaultAPIURL, "AhaSend API URL (defaults to AHASEND_API_URL when set)")
+	cmd.Flags().Bool("use-keyring", false, "Store the API key in the OS keychain instead of the configuration file")
+	cmd.Flags().Bool("encrypt-config", false, "Store the API key in the passphrase-encrypted credentials file")
+	cmd.MarkFlagsMutuallyExclusive("use-keyring", "encrypt-config")
 
 	return cmd
 }
@@ -54,6 +94,9 @@ func runLogin(cmd *cobra.Command, args []string) error {
 	apiKey, _ := cmd.Flags().GetString("api-key")
 	accountID, _ := cmd.Flags().GetString("account-id")
 	apiURL, _ := cmd.Flags().GetString("api-url")
+	apiKeyID, _ := cmd.Flags().GetString("api-key-id")
+	useKeyring, _ := cmd.Flags().GetBool("use-keyring")
+	encryptConfig, _ := cmd.Flags().GetBool("encrypt-config")
 
 	// Create configuration manager
 	configMgr, err := config.NewManager()
@@ -71,9 +114,13 @@ func runLogin(cmd *cobra.Command, args []string) error {
 		profileName = "default"
 	}
 
-	// Default API URL
-	if apiURL == "" {
-		apiURL = "https://api.ahasend.com"
+	// Resolve the API URL: an explicit flag wins, then AHASEND_API_URL
+	if !cmd.Flags().Changed("api-url") {
+		apiURL = ""
+	}
+	apiURL = client.ResolveAPIURL(apiURL, "")
+	if err := client.ValidateAPIURL(apiURL); err != nil {
+		return errors.NewValidationError("invalid API URL "+apiURL, err)
 	}
 
 	// Log login attempt
@@ -82,16 +129,19 @@ func runLogin(cmd *cobra.Command, args []string) error {
 		"api_url":     apiURL,
 	})
 
-	// Validate partial flag usage - if one credential flag is provided, both must be provided
+	// An account ID without an API key is not useful; the key alone is
+	// enough to find its accounts
 	apiKeyProvided := cmd.Flags().Changed("api-key")
 	accountIDProvided := cmd.Flags().Changed("account-id")
 
-	if apiKeyProvided && !accountIDProvided {
-		return errors.NewValidationError("account ID is required when API key is provided", nil)
-	}
 	if accountIDProvided && !apiKeyProvided {
 		return errors.NewValidationError("API key is required when account ID is provided", nil)
 	}
+	if apiKeyID != "" {
+		if _, err := uuid.Parse(apiKeyID); err != nil {
+			return errors.NewValidationError(fmt.Sprintf("invalid API key ID format: %s", apiKeyID), err)
+		}
+	}
 
 	// Interactive prompts if values not provided
 	// Note: The handler will manage whether to show prompts based on format
@@ -103,30 +153,40 @@ func runLogin(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	if accountID == "" {
-		// TODO: Handler should manage interactive mode, but for now keep prompts
-		accountID, err = promptAccountID()
-		if err != nil {
-			return errors.NewValidationError("failed to read account ID", err)
-		}
-	}
-
 	// Validate inputs
 	if apiKey == "" {
 		return errors.NewValidationError("API key is required", nil)
 	}
+
+	// Pick the account: --account-id, the key's only account, or the user's choice
+	accountID, err = resolveLoginAccount(cmd, apiKey, apiURL, accountID)
+	if err != nil {
+		return err
+	}
 	if accountID == "" {
 		return errors.NewValidationError("account ID is required", nil)
 	}
 
 	// Test the credentials
-	testClient, err := client.NewClient(apiKey, accountID)
+	testClient, err := newAccountClient(apiKey, accountID, apiURL)
 	if err != nil {
 		return errors.NewAuthError("failed to create API client", err)
 	}
 
 	if err := testClient.Ping(); err != nil {
-		return err
+		return errors.Translate(err)
+	}
+
+	// The key ID must belong to the account, and to this key when the API
+	// returns its secret
+	if apiKeyID != "" {
+		key, err := testClient.GetAPIKey(apiKeyID)
+		if err != nil {
+			return errors.NewValidationError(fmt.Sprintf("API key %s was not found in account %s", apiKeyID, accountID), err)
+		}
+		if key.SecretKey != nil && *key.SecretKey != apiKey {
+			return errors.NewValidationError(fmt.Sprintf("API key %s is not the key being logged in with", apiKeyID), nil)
+		}
 	}
 
 	// Fetch account information
@@ -144,18 +204,35 @@ func runLogin(cmd *cobra.Command, args []string) error {
 		accountUpdated = time.Now()
 	}
 
-	// Save the profile
-	profile := config.Profile{
-		APIKey:         apiKey,
-		APIURL:         apiURL,
-		AccountID:      accountID,
-		Name:           fmt.Sprintf("AhaSend %s", profileName),
-		AccountName:    accountName,
-		AccountUpdated: accountUpdated,
+	// Save the profile. Logging in again replaces the credentials and
+	// account of an existing profile but keeps its other settings, such as
+	// default_from and confirm_threshold.
+	profile := configMgr.GetConfig().Profiles[profileName]
+	if profile.Name == "" {
+		profile.Name = fmt.Sprintf("AhaSend %s", profileName)
 	}
-
-	if err := configMgr.SetProfile(profileName, profile); err != nil {
-		return errors.NewConfigError("failed to save profile", err)
+	// A stored key ID only describes the key it was recorded with
+	if previousKey, err := authn.ProfileAPIKey(profile); apiKeyID != "" || err != nil || previousKey != apiKey {
+		profile.APIKeyID = apiKeyID
+	}
+	profile.APIURL = apiURL
+	profile.AccountID = accountID
+	profile.AccountName = accountName
+	profile.AccountUpdated = accountUpdated
+	// The key just authenticated, so the profile is usable again
+	profile.KeyDeletedAt = time.Time{}
+
+	// The key stays where the profile kept it unless a store is requested
+	store := authn.ProfileKeyStore(profile)
+	switch {
+	case useKeyring:
+		store = credentials.StoreKeyring
+	case encryptConfig:
+		store = credentials.StoreEncrypted
+	}
+	usedStore, err := authn.SetProfileAPIKey(configMgr, profileName, profile, apiKey, store)
+	if err != nil {
+		return err
 	}
 
 	// Set as default profile if it's the first one or explicitly requested
@@ -167,8 +244,19 @@ func runLogin(cmd *cobra.Command, args []string) error {
 	}
 
 	// Handle successful login
+	message := fmt.Sprintf("Successfully authenticated and saved profile '%s'", profileName)
+	if accountName != "" {
+		message += fmt.Sprintf(" for account '%s' (%s)", accountName, accountID)
+	}
+	if usedStore != credentials.StorePlaintext {
+		message += fmt.Sprintf("; the API key is in %s", describeKeyStore(usedStore))
+		if usedStore != store {
+			message += " because the OS keychain is unavailable"
+		}
+		offerMigration(cmd, configMgr, profileName, usedStore)
+	}
 	return handler.HandleAuthLogin(true, profileName, printer.AuthConfig{
-		SuccessMessage: fmt.Sprintf("Successfully authenticated and saved profile '%s'", profileName),
+		SuccessMessage: message,
 	})
 }
 
@@ -191,7 +279,7 @@ func promptAccountID() (string, error) {
 
 	accountID, err := reader.ReadString('\n')
 	if err != nil {
-		return "", err
+		return "", errors.NewValidationError("failed to read account ID", err)
 	}
 
 	return strings.TrimSpace(accountID), nil
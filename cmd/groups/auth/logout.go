@@ -3,6 +3,7 @@ package auth
 import (
 	"fmt"
 
+	authn "github.com/AhaSend/ahasend-cli/internal/auth"
 	"github.com/AhaSend/ahasend-cli/internal/config"
 	"github.com/AhaSend/ahasend-cli/internal/errors"
 	"github.com/AhaSend/ahasend-cli/internal/logger"
@@ -16,7 +17,8 @@ func NewLogoutCommand() *cobra.Command {
 		Use:   "logout [profile]",
 		Short: "Log out and remove stored credentials",
 		Long: `Remove stored API credentials for the current profile or a specific profile.
-This will delete the profile from your local configuration.`,
+This will delete the profile from your local configuration, and its API key
+from the OS keychain or the encrypted credentials file when it is kept there.`,
 		Example: `  # Logout from current default profile
   ahasend auth logout
 
@@ -66,7 +68,9 @@ func runLogout(cmd *cobra.Command, args []string) error {
 		}
 
 		// Remove all profiles
+		removed := make([]config.Profile, 0, len(profiles))
 		for _, profileName := range profiles {
+			removed = append(removed, configMgr.GetConfig().Profiles[profileName])
 			logger.Get().WithField("profile", profileName).Debug("Removing profile")
 			// Can't use RemoveProfile as it prevents removing default profile
 			delete(configMgr.GetConfig().Profiles, profileName)
@@ -78,6 +82,9 @@ func runLogout(cmd *cobra.Command, args []string) error {
 		if err := configMgr.Save(); err != nil {
 			return errors.NewConfigError("failed to save configuration", err)
 		}
+		for _, profile := range removed {
+			deleteStoredKey(profile)
+		}
 
 		logger.ConfigOperation("logout_complete", "", map[string]interface{}{
 			"operation": "all_profiles",
@@ -118,6 +125,8 @@ func runLogout(cmd *cobra.Command, args []string) error {
 		return errors.NewNotFoundError(fmt.Sprintf("profile '%s' not found", profileName), nil)
 	}
 
+	removed := configMgr.GetConfig().Profiles[profileName]
+
 	// If this is the default profile, we need to handle it specially
 	if profileName == configMgr.GetConfig().DefaultProfile {
 		// Remove the profile manually since RemoveProfile prevents removing default
@@ -142,6 +151,7 @@ func runLogout(cmd *cobra.Command, args []string) error {
 			return errors.NewConfigError("failed to remove profile", err)
 		}
 	}
+	deleteStoredKey(removed)
 
 	logger.ConfigOperation("logout_complete", profileName, map[string]interface{}{
 		"operation":   "single_profile",
@@ -153,3 +163,12 @@ func runLogout(cmd *cobra.Command, args []string) error {
 		SuccessMessage: fmt.Sprintf("Successfully logged out from profile '%s'", profileName),
 	})
 }
+
+// deleteStoredKey removes the API key of a removed profile from the OS
+// keychain or the encrypted credentials file. The profile is already gone,
+// so failing to remove the key is only logged.
+func deleteStoredKey(profile config.Profile) {
+	if err := authn.DeleteProfileAPIKey(profile); err != nil {
+		logger.Get().WithError(err).Warn("Failed to remove the stored API key of the profile")
+	}
+}
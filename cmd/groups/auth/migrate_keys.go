@@ -0,0 +1,225 @@
+package auth
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	authn "github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/config"
+	"github.com/AhaSend/ahasend-cli/internal/credentials"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/spf13/cobra"
+)
+
+// NewMigrateKeysCommand creates the migrate-keys command
+func NewMigrateKeysCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate-keys",
+		Short: "Move stored API keys to the OS keychain or an encrypted file",
+		Long: `Move the API keys of your profiles between the places the CLI can keep them:
+
+  plaintext  the configuration file, ~/.ahasend/config.yaml (the default)
+  keyring    the OS keychain: macOS Keychain, Windows Credential Manager or
+             the Secret Service (libsecret) on Linux
+  encrypted  ~/.ahasend/credentials.enc, encrypted with a passphrase that is
+             prompted for or read from AHASEND_CONFIG_PASSPHRASE
+
+When a key moves out of the configuration file, the profile keeps only a
+reference to it. When no keychain is available, keys moved to the keyring go
+to the encrypted file instead.
+
+Each key is written to its new store before the profile is saved, and removed
+from its old store only after that, so an interrupted migration leaves every
+profile working. To undo a migration, move the keys back with
+--to plaintext.`,
+		Example: `  # Move every plain text API key to the OS keychain
+  ahasend auth migrate-keys --to keyring
+
+  # Encrypt the key of one profile
+  ahasend auth migrate-keys --to encrypted --profile production
+
+  # Undo: put every key back in the configuration file
+  ahasend auth migrate-keys --to plaintext`,
+		Args:         cobra.NoArgs,
+		RunE:         runMigrateKeys,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().String("to", "", "Where to keep the API keys: keyring, encrypted or plaintext")
+	cmd.Flags().StringSlice("profile", nil, "Profiles to migrate (default: all profiles)")
+	cmd.Flags().Bool("force", false, "Skip the confirmation prompt")
+	_ = cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+func runMigrateKeys(cmd *cobra.Command, args []string) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+
+	target, _ := cmd.Flags().GetString("to")
+	selected, _ := cmd.Flags().GetStringSlice("profile")
+	force, _ := cmd.Flags().GetBool("force")
+
+	store, err := credentials.ParseStore(target)
+	if err != nil {
+		return errors.NewValidationError(err.Error(), nil)
+	}
+
+	configMgr, err := config.NewManager()
+	if err != nil {
+		return errors.NewConfigError("failed to initialize configuration", err)
+	}
+	if err := configMgr.Load(); err != nil {
+		return errors.NewConfigError("failed to load configuration", err)
+	}
+
+	profiles := configMgr.GetConfig().Profiles
+	if len(selected) == 0 {
+		selected = configMgr.ListProfiles()
+	}
+	var names []string
+	for _, name := range selected {
+		profile, exists := profiles[name]
+		if !exists {
+			return errors.NewNotFoundError(fmt.Sprintf("profile '%s' not found", name), nil)
+		}
+		if authn.ProfileKeyStore(profile) != store {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	if len(names) == 0 {
+		return handler.HandleSimpleSuccess(fmt.Sprintf("All selected API keys are already in %s", describeKeyStore(store)))
+	}
+
+	if !force {
+		if !stdinIsTerminal() {
+			return errors.NewValidationError(fmt.Sprintf(
+				"moving %d API keys to %s needs confirmation; re-run with --force", len(names), describeKeyStore(store)), nil)
+		}
+		if !confirmMigration(cmd.InOrStdin(), cmd.ErrOrStderr(), profiles, names, store) {
+			return errors.NewValidationError("migration cancelled", nil)
+		}
+	}
+
+	moved, used, err := migrateProfileKeys(configMgr, names, store)
+	if err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("Moved %d API %s to %s", moved, pluralKeys(moved), describeKeyStore(used))
+	if used != store {
+		message += " because the OS keychain is unavailable"
+	}
+	if used != credentials.StorePlaintext {
+		message += ". To undo, run 'ahasend auth migrate-keys --to plaintext'"
+	}
+	return handler.HandleSimpleSuccess(message)
+}
+
+// migrateProfileKeys moves the API keys of the named profiles to store and
+// returns how many moved and the store they went to. Once the keychain turns
+// out to be unavailable, the remaining keys go straight to the encrypted file.
+func migrateProfileKeys(configMgr *config.Manager, names []string, store credentials.Store) (int, credentials.Store, error) {
+	moved := 0
+	for _, name := range names {
+		profile := configMgr.GetConfig().Profiles[name]
+		apiKey, err := authn.ProfileAPIKey(profile)
+		if err != nil {
+			return moved, store, err
+		}
+
+		used, err := authn.SetProfileAPIKey(configMgr, name, profile, apiKey, store)
+		if err != nil {
+			return moved, store, err
+		}
+		store = used
+		moved++
+
+		logger.ConfigOperation("migrate_key", name, map[string]interface{}{
+			"store": string(used),
+		})
+	}
+	return moved, store, nil
+}
+
+// confirmMigration lists the keys to move and asks for confirmation
+func confirmMigration(in io.Reader, out io.Writer, profiles map[string]config.Profile, names []string, store credentials.Store) bool {
+	fmt.Fprintf(out, "The API keys of these profiles will move to %s:\n", describeKeyStore(store))
+	for _, name := range names {
+		fmt.Fprintf(out, "  %s (now in %s)\n", name, describeKeyStore(authn.ProfileKeyStore(profiles[name])))
+	}
+	fmt.Fprintf(out, "\nMove %d API %s? (y/N): ", len(names), pluralKeys(len(names)))
+	return readYes(in)
+}
+
+// offerMigration asks, after a login that stored its key outside the
+// configuration file, whether to move the plain text keys of the other
+// profiles there too
+func offerMigration(cmd *cobra.Command, configMgr *config.Manager, loggedIn string, store credentials.Store) {
+	var names []string
+	for name, profile := range configMgr.GetConfig().Profiles {
+		if name != loggedIn && authn.ProfileKeyStore(profile) == credentials.StorePlaintext && profile.APIKey != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 || !stdinIsTerminal() {
+		return
+	}
+	sort.Strings(names)
+
+	out := cmd.ErrOrStderr()
+	fmt.Fprintf(out, "\n%d other %s keep their API key in plain text in the configuration file: %s\n",
+		len(names), pluralProfiles(len(names)), strings.Join(names, ", "))
+	fmt.Fprintf(out, "Move them to %s too? (y/N): ", describeKeyStore(store))
+	if !readYes(cmd.InOrStdin()) {
+		fmt.Fprintf(out, "Left as is. Run 'ahasend auth migrate-keys --to %s' to move them later.\n", store)
+		return
+	}
+
+	moved, used, err := migrateProfileKeys(configMgr, names, store)
+	if err != nil {
+		fmt.Fprintf(out, "Moved %d of %d API keys: %v\n", moved, len(names), err)
+		return
+	}
+	fmt.Fprintf(out, "Moved %d API %s to %s. To undo, run 'ahasend auth migrate-keys --to plaintext'.\n",
+		moved, pluralKeys(moved), describeKeyStore(used))
+}
+
+// readYes reads a y/N answer
+func readYes(in io.Reader) bool {
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// describeKeyStore names where a store keeps API keys
+func describeKeyStore(store credentials.Store) string {
+	switch store {
+	case credentials.StoreKeyring:
+		return "the OS keychain"
+	case credentials.StoreEncrypted:
+		return "the encrypted credentials file"
+	}
+	return "the configuration file (plain text)"
+}
+
+func pluralKeys(n int) string {
+	if n == 1 {
+		return "key"
+	}
+	return "keys"
+}
+
+func pluralProfiles(n int) string {
+	if n == 1 {
+		return "profile"
+	}
+	return "profiles"
+}
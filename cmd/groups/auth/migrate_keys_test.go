@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	authn "github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/credentials"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readConfigFile(t *testing.T) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(os.Getenv("HOME"), ".ahasend", "config.yaml"))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestLogin_UseKeyring(t *testing.T) {
+	useAccountClients(t, []responses.Account{primaryAccount}, false)
+	credentials.MockKeyring(nil)
+
+	stdout, _, err := executeAuthCommand(t, NewLoginCommand(), "", "--api-key", "secret-key", "--use-keyring")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "the API key is in the OS keychain")
+
+	profile, ok := loadProfile(t, "default")
+	require.True(t, ok)
+	assert.Empty(t, profile.APIKey)
+	assert.Equal(t, "keyring:default", profile.APIKeyRef)
+	assert.NotContains(t, readConfigFile(t), "secret-key")
+
+	key, err := authn.ProfileAPIKey(profile)
+	require.NoError(t, err)
+	assert.Equal(t, "secret-key", key)
+
+	// Logging in again without a store flag keeps the key in the keychain
+	_, _, err = executeAuthCommand(t, NewLoginCommand(), "", "--api-key", "new-key")
+	require.NoError(t, err)
+	profile, _ = loadProfile(t, "default")
+	assert.Equal(t, "keyring:default", profile.APIKeyRef)
+	key, err = authn.ProfileAPIKey(profile)
+	require.NoError(t, err)
+	assert.Equal(t, "new-key", key)
+}
+
+func TestLogin_EncryptConfig(t *testing.T) {
+	useAccountClients(t, []responses.Account{primaryAccount}, false)
+	t.Setenv(authn.PassphraseEnv, "correct horse")
+
+	_, _, err := executeAuthCommand(t, NewLoginCommand(), "", "--api-key", "secret-key", "--encrypt-config")
+	require.NoError(t, err)
+
+	profile, _ := loadProfile(t, "default")
+	assert.Equal(t, "encrypted:default", profile.APIKeyRef)
+	assert.NotContains(t, readConfigFile(t), "secret-key")
+
+	data, err := os.ReadFile(filepath.Join(os.Getenv("HOME"), ".ahasend", credentials.EncryptedFileName))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "secret-key")
+}
+
+func TestLogin_StoreFlagsAreExclusive(t *testing.T) {
+	useAccountClients(t, []responses.Account{primaryAccount}, false)
+
+	_, _, err := executeAuthCommand(t, NewLoginCommand(), "", "--api-key", "secret-key", "--use-keyring", "--encrypt-config")
+	require.Error(t, err)
+}
+
+func TestLogin_OffersMigration(t *testing.T) {
+	useAccountClients(t, []responses.Account{primaryAccount}, false)
+	credentials.MockKeyring(nil)
+
+	_, _, err := executeAuthCommand(t, NewLoginCommand(), "", "--api-key", "staging-key", "--profile", "staging")
+	require.NoError(t, err)
+
+	stdinIsTerminal = func() bool { return true }
+	_, stderr, err := executeAuthCommand(t, NewLoginCommand(), "y\n", "--api-key", "secret-key", "--use-keyring")
+	require.NoError(t, err)
+	assert.Contains(t, stderr, "1 other profile keep their API key in plain text")
+	assert.Contains(t, stderr, "Moved 1 API key to the OS keychain")
+
+	staging, _ := loadProfile(t, "staging")
+	assert.Equal(t, "keyring:staging", staging.APIKeyRef)
+	assert.NotContains(t, readConfigFile(t), "staging-key")
+}
+
+func TestMigrateKeys_AndBack(t *testing.T) {
+	useAccountClients(t, []responses.Account{primaryAccount}, false)
+	credentials.MockKeyring(nil)
+	writeBoundProfile(t, primaryAccount.ID.String())
+
+	// Without a terminal the migration needs --force
+	_, _, err := executeAuthCommand(t, NewMigrateKeysCommand(), "", "--to", "keyring")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "re-run with --force")
+
+	stdout, _, err := executeAuthCommand(t, NewMigrateKeysCommand(), "", "--to", "keyring", "--force")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "Moved 1 API key to the OS keychain")
+	assert.Contains(t, stdout, "ahasend auth migrate-keys --to plaintext")
+	assert.NotContains(t, readConfigFile(t), "test-key")
+
+	stdout, _, err = executeAuthCommand(t, NewMigrateKeysCommand(), "", "--to", "keyring", "--force")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "already in the OS keychain")
+
+	_, _, err = executeAuthCommand(t, NewMigrateKeysCommand(), "", "--to", "plaintext", "--force")
+	require.NoError(t, err)
+	profile, _ := loadProfile(t, "default")
+	assert.Equal(t, "test-key", profile.APIKey)
+	assert.Empty(t, profile.APIKeyRef)
+	_, err = credentials.KeyringGet("default")
+	assert.ErrorIs(t, err, credentials.ErrNotFound)
+}
+
+func TestMigrateKeys_Confirmation(t *testing.T) {
+	useAccountClients(t, []responses.Account{primaryAccount}, true)
+	credentials.MockKeyring(nil)
+	writeBoundProfile(t, primaryAccount.ID.String())
+
+	_, stderr, err := executeAuthCommand(t, NewMigrateKeysCommand(), "n\n", "--to", "keyring")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "migration cancelled")
+	assert.Contains(t, stderr, "default (now in the configuration file (plain text))")
+
+	profile, _ := loadProfile(t, "default")
+	assert.Equal(t, "test-key", profile.APIKey)
+}
+
+func TestMigrateKeys_UnknownStoreOrProfile(t *testing.T) {
+	useAccountClients(t, nil, false)
+	writeBoundProfile(t, primaryAccount.ID.String())
+
+	_, _, err := executeAuthCommand(t, NewMigrateKeysCommand(), "", "--to", "vault", "--force")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown credential store")
+
+	_, _, err = executeAuthCommand(t, NewMigrateKeysCommand(), "", "--to", "keyring", "--profile", "missing", "--force")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "profile 'missing' not found")
+}
+
+func TestLogout_RemovesKeyringKey(t *testing.T) {
+	useAccountClients(t, []responses.Account{primaryAccount}, false)
+	credentials.MockKeyring(nil)
+
+	_, _, err := executeAuthCommand(t, NewLoginCommand(), "", "--api-key", "secret-key", "--use-keyring")
+	require.NoError(t, err)
+	_, _, err = executeAuthCommand(t, NewLogoutCommand(), "", "default")
+	require.NoError(t, err)
+
+	_, err = credentials.KeyringGet("default")
+	assert.ErrorIs(t, err, credentials.ErrNotFound)
+}
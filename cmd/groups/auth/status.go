@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	authn "github.com/AhaSend/ahasend-cli/internal/auth"
 	"github.com/AhaSend/ahasend-cli/internal/client"
 	"github.com/AhaSend/ahasend-cli/internal/config"
 	"github.com/AhaSend/ahasend-cli/internal/errors"
@@ -20,6 +21,7 @@ func NewStatusCommand() *cobra.Command {
 		Short: "Show authentication status and current profile information",
 		Long: `Display information about the current authentication status, including:
 - Current active profile
+- Effective API endpoint
 - API key validity
 - Account information
 - Available profiles`,
@@ -88,7 +90,8 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create AuthStatus for the specific profile
-	status, err := createAuthStatus(configMgr, profileName)
+	apiURLFlag, _ := cmd.Flags().GetString("api-url")
+	status, err := createAuthStatus(configMgr, profileName, apiURLFlag)
 	if err != nil {
 		return err
 	}
@@ -99,7 +102,7 @@ func runStatus(cmd *cobra.Command, args []string) error {
 }
 
 // createAuthStatus creates an AuthStatus struct for the given profile
-func createAuthStatus(configMgr *config.Manager, profileName string) (*printer.AuthStatus, error) {
+func createAuthStatus(configMgr *config.Manager, profileName, apiURLFlag string) (*printer.AuthStatus, error) {
 	// Get the specific profile by name
 	profiles := configMgr.GetConfig().Profiles
 	profile, exists := profiles[profileName]
@@ -109,17 +112,33 @@ func createAuthStatus(configMgr *config.Manager, profileName string) (*printer.A
 
 	// Create a copy of the profile to work with
 	profileCopy := profile
+	apiURL := client.ResolveAPIURL(apiURLFlag, profile.APIURL)
+
+	// A key that cannot be read, e.g. without the credentials file
+	// passphrase, makes the profile invalid rather than failing the status
+	apiKey, err := authn.ProfileAPIKey(profile)
+	if err != nil {
+		logger.Get().WithError(err).Debug("Failed to read the API key of the profile")
+		return &printer.AuthStatus{
+			Profile:     profileName,
+			APIKey:      "(unavailable)",
+			APIURL:      apiURL,
+			Valid:       false,
+			AccountID:   profile.AccountID,
+			AccountName: profile.AccountName,
+		}, nil
+	}
 
 	// Check if account info needs refreshing
 	if shouldRefreshAccountInfo(&profileCopy) {
 		logger.Get().WithField("profile", profileName).Debug("Refreshing account information")
-		if err := refreshAccountInfo(configMgr, profileName, &profileCopy); err != nil {
+		if err := refreshAccountInfo(configMgr, profileName, &profileCopy, apiKey, apiURL); err != nil {
 			logger.Get().WithError(err).Debug("Failed to refresh account info, continuing with existing data")
 		}
 	}
 
 	// Test if the credentials are valid
-	testClient, err := client.NewClient(profile.APIKey, profile.AccountID)
+	testClient, err := client.NewClient(apiKey, profile.AccountID, apiURL)
 	isValid := true
 	var account *responses.Account
 
@@ -140,14 +159,17 @@ func createAuthStatus(configMgr *config.Manager, profileName string) (*printer.A
 
 	// Create masked API key
 	maskedAPIKey := fmt.Sprintf("%s...%s",
-		profile.APIKey[:min(10, len(profile.APIKey))],
-		profile.APIKey[max(0, len(profile.APIKey)-4):])
+		apiKey[:min(10, len(apiKey))],
+		apiKey[max(0, len(apiKey)-4):])
 
 	return &printer.AuthStatus{
-		Profile: profileName,
-		APIKey:  maskedAPIKey,
-		Account: account,
-		Valid:   isValid,
+		Profile:     profileName,
+		APIKey:      maskedAPIKey,
+		APIURL:      apiURL,
+		Account:     account,
+		Valid:       isValid,
+		AccountID:   profile.AccountID,
+		AccountName: profileCopy.AccountName,
 	}, nil
 }
 
@@ -168,8 +190,8 @@ func shouldRefreshAccountInfo(profile *config.Profile) bool {
 }
 
 // refreshAccountInfo fetches fresh account information and updates the profile
-func refreshAccountInfo(configMgr *config.Manager, profileName string, profile *config.Profile) error {
-	client, err := client.NewClient(profile.APIKey, profile.AccountID)
+func refreshAccountInfo(configMgr *config.Manager, profileName string, profile *config.Profile, apiKey, apiURL string) error {
+	client, err := client.NewClient(apiKey, profile.AccountID, apiURL)
 	if err != nil {
 		return err
 	}
@@ -244,6 +244,26 @@ func TestAuthStatus_ParentAccountIDRendering(t *testing.T) {
 	})
 }
 
+func TestAuthStatus_ProfileAccountRendering(t *testing.T) {
+	status := &printer.AuthStatus{
+		Profile:     "default",
+		APIKey:      "test-key...abcd",
+		AccountID:   "11111111-1111-4111-8111-111111111111",
+		AccountName: "Acme",
+	}
+
+	// The profile's account is shown even when the key cannot be validated
+	for _, format := range []string{"table", "plain", "csv"} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			handler := printer.GetResponseHandler(format, false, &buf)
+			require.NoError(t, handler.HandleAuthStatus(status, printer.AuthConfig{}))
+			assert.Contains(t, buf.String(), "11111111-1111-4111-8111-111111111111")
+			assert.Contains(t, buf.String(), "Acme")
+		})
+	}
+}
+
 // Benchmark tests
 func BenchmarkStatusCommand_Creation(b *testing.B) {
 	for i := 0; i < b.N; i++ {
@@ -3,6 +3,7 @@ package auth
 import (
 	"fmt"
 
+	authn "github.com/AhaSend/ahasend-cli/internal/auth"
 	"github.com/AhaSend/ahasend-cli/internal/client"
 	"github.com/AhaSend/ahasend-cli/internal/config"
 	"github.com/AhaSend/ahasend-cli/internal/errors"
@@ -85,7 +86,11 @@ func runSwitch(cmd *cobra.Command, args []string) error {
 		"account_id": profile.AccountID,
 	}).Debug("Validating profile credentials")
 
-	testClient, err := client.NewClient(profile.APIKey, profile.AccountID)
+	apiKey, err := authn.ProfileAPIKey(profile)
+	if err != nil {
+		return err
+	}
+	testClient, err := client.NewClient(apiKey, profile.AccountID)
 	if err != nil {
 		return errors.NewAuthError("failed to create API client for profile", err)
 	}
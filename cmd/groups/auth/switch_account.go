@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"fmt"
+	"time"
+
+	authn "github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/config"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/spf13/cobra"
+)
+
+// NewSwitchAccountCommand creates the switch-account command
+func NewSwitchAccountCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "switch-account [account-id]",
+		Short: "Bind a profile to another account its API key can access",
+		Long: `Bind an existing profile to a different account without re-entering the API key.
+The accounts the profile's API key can access are listed; pass an account ID
+to pick one directly, or choose from the list in a terminal.
+
+Use 'ahasend auth switch' to change the active profile instead.`,
+		Example: `  # Choose from the accounts the default profile's key can access
+  ahasend auth switch-account
+
+  # Bind the production profile to a specific account
+  ahasend auth switch-account abcd1234-5678-90ef-abcd-1234567890ab --profile production`,
+		Args:         cobra.MaximumNArgs(1),
+		RunE:         runSwitchAccount,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().String("profile", "", "Profile to rebind (defaults to the active profile)")
+
+	return cmd
+}
+
+func runSwitchAccount(cmd *cobra.Command, args []string) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+
+	profileName, _ := cmd.Flags().GetString("profile")
+	var requested string
+	if len(args) > 0 {
+		requested = args[0]
+	}
+
+	configMgr, err := config.NewManager()
+	if err != nil {
+		return errors.NewConfigError("failed to initialize configuration", err)
+	}
+	if err := configMgr.Load(); err != nil {
+		return errors.NewConfigError("failed to load configuration", err)
+	}
+
+	if profileName == "" {
+		profileName = configMgr.GetConfig().DefaultProfile
+		if profileName == "" {
+			return errors.NewValidationError("no default profile set and no profile specified", nil)
+		}
+	}
+	profile, exists := configMgr.GetConfig().Profiles[profileName]
+	if !exists {
+		return errors.NewNotFoundError(fmt.Sprintf("profile '%s' not found", profileName), nil)
+	}
+
+	logger.ConfigOperation("switch_account_start", profileName, map[string]interface{}{
+		"from_account": profile.AccountID,
+		"to_account":   requested,
+	})
+
+	apiURLFlag, _ := cmd.Flags().GetString("api-url")
+	apiKey, err := authn.ProfileAPIKey(profile)
+	if err != nil {
+		return err
+	}
+	keyClient, err := newKeyClient(apiKey, client.ResolveAPIURL(apiURLFlag, profile.APIURL))
+	if err != nil {
+		return errors.NewAuthError("failed to create API client for profile", err)
+	}
+	accounts, err := keyClient.ListAccounts()
+	if err != nil {
+		return errors.Translate(err)
+	}
+
+	account, err := chooseAccount(cmd, accounts, requested)
+	if err != nil {
+		return err
+	}
+
+	accountID := account.ID.String()
+	if accountID == profile.AccountID {
+		return handler.HandleSimpleSuccess(fmt.Sprintf("Profile '%s' already uses account '%s' (%s)", profileName, account.Name, accountID))
+	}
+
+	profile.AccountID = accountID
+	profile.AccountName = account.Name
+	profile.AccountUpdated = time.Now()
+	if err := configMgr.SetProfile(profileName, profile); err != nil {
+		return errors.NewConfigError("failed to save profile", err)
+	}
+
+	logger.ConfigOperation("switch_account_complete", profileName, map[string]interface{}{
+		"account_id": accountID,
+	})
+
+	return handler.HandleSimpleSuccess(fmt.Sprintf("Profile '%s' now uses account '%s' (%s)", profileName, account.Name, accountID))
+}
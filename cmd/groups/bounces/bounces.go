@@ -0,0 +1,27 @@
+package bounces
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates the bounces command group
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bounces",
+		Short: "Explain bounce classifications",
+		Long: `Explain the bounce classifications AhaSend reports in 'stats bounces' and
+'messages get', and what to do about each of them.
+
+The explanations are built into the CLI, so these commands work without
+logging in.`,
+		Example: `  # List every classification with its explanation
+  ahasend bounces explain
+
+  # Explain a single classification
+  ahasend bounces explain PolicyRelated`,
+	}
+
+	cmd.AddCommand(NewExplainCommand())
+
+	return cmd
+}
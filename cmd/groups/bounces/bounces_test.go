@@ -0,0 +1,56 @@
+package bounces
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/AhaSend/ahasend-cli/internal/bounces"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func executeBounces(t *testing.T, format string, args ...string) (string, error) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	handler := printer.GetResponseHandler(format, false, &buf)
+	cmd := NewCommand()
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+func TestBouncesCommand_Structure(t *testing.T) {
+	cmd := NewCommand()
+	assert.Equal(t, "bounces", cmd.Name())
+	assert.Len(t, cmd.Commands(), 1)
+}
+
+func TestBouncesExplain(t *testing.T) {
+	out, err := executeBounces(t, "json", "explain")
+	require.NoError(t, err)
+	var decoded struct {
+		Data []bounces.Explanation `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &decoded))
+	assert.Len(t, decoded.Data, len(bounces.Classifications))
+
+	out, err = executeBounces(t, "plain", "explain", "policy_related")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Classification: PolicyRelated\n")
+	assert.NotContains(t, out, "QuotaIssues")
+}
+
+func TestBouncesExplain_UnknownClassification(t *testing.T) {
+	_, err := executeBounces(t, "plain", "explain", "bad_mailbox")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown bounce classification 'bad_mailbox'")
+	assert.Contains(t, err.Error(), "InvalidRecipient")
+}
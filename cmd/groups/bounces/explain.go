@@ -0,0 +1,58 @@
+package bounces
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/bounces"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/spf13/cobra"
+)
+
+// NewExplainCommand creates the bounces explain command
+func NewExplainCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "explain [classification]",
+		Short: "Explain a bounce classification and what to do about it",
+		Long: `Explain what a bounce classification means and the recommended action.
+
+Without an argument every classification is listed. Classification names are
+matched ignoring case, underscores and hyphens, so 'policy_related' finds
+PolicyRelated.`,
+		Example: `  # List every classification
+  ahasend bounces explain
+
+  # Explain a single classification
+  ahasend bounces explain PolicyRelated
+
+  # Matching ignores case and separators
+  ahasend bounces explain invalid_recipient --output json`,
+		Args:         cobra.MaximumNArgs(1),
+		RunE:         runBouncesExplain,
+		SilenceUsage: true,
+		Annotations:  map[string]string{auth.NoAuthAnnotation: "true"},
+	}
+
+	return cmd
+}
+
+func runBouncesExplain(cmd *cobra.Command, args []string) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+
+	explanations := bounces.All()
+	if len(args) == 1 {
+		explanation, ok := bounces.Explain(args[0])
+		if !ok {
+			return handler.HandleError(errors.NewNotFoundError(
+				fmt.Sprintf("unknown bounce classification '%s', must be one of: %s",
+					args[0], strings.Join(bounces.Classifications, ", ")), nil))
+		}
+		explanations = []bounces.Explanation{explanation}
+	}
+
+	return handler.HandleBounceExplanations(explanations, printer.ListConfig{
+		EmptyMessage: "No bounce classifications",
+	})
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates the config command group
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "View and change CLI settings",
+		Long: `View and change settings stored in ~/.ahasend/config.yaml.
+
+Per-profile settings apply to the profile selected with --profile (or the
+default profile):
+  default-test-recipient  Address used by 'messages send --to-me'
+  test-tag                Tag added to 'messages send --to-me' sends (default: test)
+  confirm-threshold       Recipient count above which 'messages send' asks for confirmation
+  default-from            Sender used by 'messages send' and 'smtp send' when --from is omitted
+
+Global preferences:
+  output-format, color-output, webhook-timeout, log-level, default-domain,
+  batch-concurrency
+
+Per-command output formats:
+  output-overrides.<command>  Output format of one command, e.g.
+                              output-overrides.messages.list
+
+Per-domain send defaults (see 'config set-domain-default'):
+  domain-defaults.<domain>    Tracking and tags applied to 'messages send'
+                              from the domain, e.g. domain-defaults.mail.acme.com
+
+The output format is --output when given, else the command's output override,
+else output-format, else table.`,
+		Annotations: map[string]string{auth.NoAuthAnnotation: "true"},
+	}
+
+	cmd.AddCommand(NewSetCommand())
+	cmd.AddCommand(NewGetCommand())
+	cmd.AddCommand(NewSetDomainDefaultCommand())
+
+	return cmd
+}
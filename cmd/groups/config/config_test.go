@@ -0,0 +1,163 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	cliconfig "github.com/AhaSend/ahasend-cli/internal/config"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func executeConfigCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	handler := printer.GetResponseHandler("plain", false, &buf)
+	cmd := NewCommand()
+	cmd.PersistentFlags().String("profile", "", "")
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+func TestConfigCommand_Structure(t *testing.T) {
+	cmd := NewCommand()
+	assert.Equal(t, "config", cmd.Name())
+	assert.Len(t, cmd.Commands(), 3)
+}
+
+func TestConfigSetAndGet_ProfileSetting(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	mgr, err := cliconfig.NewManager()
+	require.NoError(t, err)
+	require.NoError(t, mgr.Load())
+	require.NoError(t, mgr.SetProfile("default", cliconfig.Profile{APIKey: "key", AccountID: "id"}))
+	require.NoError(t, mgr.SetProfile("staging", cliconfig.Profile{APIKey: "key", AccountID: "id"}))
+
+	out, err := executeConfigCommand(t, "set", "default-test-recipient", "me@example.com")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Set default-test-recipient for profile 'default'")
+
+	out, err = executeConfigCommand(t, "get", "default-test-recipient")
+	require.NoError(t, err)
+	assert.Contains(t, out, "default-test-recipient = me@example.com")
+
+	_, err = executeConfigCommand(t, "set", "test-tag", "qa", "--profile", "staging")
+	require.NoError(t, err)
+
+	require.NoError(t, mgr.Load())
+	assert.Equal(t, "me@example.com", mgr.GetConfig().Profiles["default"].DefaultTestRecipient)
+	assert.Equal(t, "qa", mgr.GetConfig().Profiles["staging"].TestTag)
+	assert.Empty(t, mgr.GetConfig().Profiles["default"].TestTag)
+}
+
+func TestConfigSet_Errors(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	mgr, err := cliconfig.NewManager()
+	require.NoError(t, err)
+	require.NoError(t, mgr.Load())
+	require.NoError(t, mgr.SetProfile("default", cliconfig.Profile{APIKey: "key", AccountID: "id"}))
+
+	_, err = executeConfigCommand(t, "set", "default-test-recipient", "not-an-email")
+	assert.Error(t, err)
+
+	_, err = executeConfigCommand(t, "set", "default-from", "noreply@@example.com")
+	assert.Error(t, err)
+
+	_, err = executeConfigCommand(t, "set", "no-such-key", "x")
+	assert.Error(t, err)
+
+	_, err = executeConfigCommand(t, "set", "test-tag", "qa", "--profile", "missing")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "profile 'missing' not found")
+}
+
+func TestConfigSet_Preference(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	_, err := executeConfigCommand(t, "set", "output-format", "json")
+	require.NoError(t, err)
+
+	out, err := executeConfigCommand(t, "get", "output-format")
+	require.NoError(t, err)
+	assert.Contains(t, out, "output-format = json")
+}
+
+func TestConfigSetAndGet_OutputOverride(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// The config group is the root command here, so its own subcommands are
+	// the commands that can be overridden
+	out, err := executeConfigCommand(t, "set", "output-overrides.get", "csv")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Set output-overrides.get")
+
+	out, err = executeConfigCommand(t, "get", "output-overrides.get")
+	require.NoError(t, err)
+	assert.Contains(t, out, "output-overrides.get = csv")
+
+	_, err = executeConfigCommand(t, "set", "output-overrides.get", "xml")
+	assert.Error(t, err)
+
+	_, err = executeConfigCommand(t, "set", "output-overrides.nope", "csv")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown command 'nope'")
+
+	out, err = executeConfigCommand(t, "set", "output-overrides.get", "")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Removed output-overrides.get")
+
+	out, err = executeConfigCommand(t, "get", "output-overrides.get")
+	require.NoError(t, err)
+	assert.Contains(t, out, "output-overrides.get is not set")
+}
+
+func TestConfigSetDomainDefault(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	out, err := executeConfigCommand(t, "set-domain-default", "Mail.Acme.com", "track_opens=false", "track-clicks=false", "tags=transactional,receipts")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Send defaults for mail.acme.com: track_opens=false track_clicks=false tags=transactional,receipts")
+
+	out, err = executeConfigCommand(t, "get", "domain-defaults.mail.acme.com")
+	require.NoError(t, err)
+	assert.Contains(t, out, "domain-defaults.mail.acme.com = track_opens=false track_clicks=false tags=transactional,receipts")
+
+	// An invalid setting changes nothing
+	_, err = executeConfigCommand(t, "set-domain-default", "mail.acme.com", "tags=", "track_opens=maybe")
+	require.Error(t, err)
+	out, err = executeConfigCommand(t, "get", "domain-defaults.mail.acme.com")
+	require.NoError(t, err)
+	assert.Contains(t, out, "tags=transactional,receipts")
+	_, err = executeConfigCommand(t, "set-domain-default", "mail.acme.com", "subject=hi")
+	assert.ErrorContains(t, err, `unknown domain setting "subject"`)
+	_, err = executeConfigCommand(t, "set-domain-default", "mail.acme.com", "tags")
+	assert.ErrorContains(t, err, "expected key=value")
+	_, err = executeConfigCommand(t, "set-domain-default", "not a domain", "tags=x")
+	assert.ErrorContains(t, err, `invalid domain "not a domain"`)
+
+	// The Unicode and punycode forms are the same domain
+	out, err = executeConfigCommand(t, "set-domain-default", "bücher.example", "tags=de")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Send defaults for bücher.example (xn--bcher-kva.example): tags=de")
+	out, err = executeConfigCommand(t, "get", "domain-defaults.xn--bcher-kva.example")
+	require.NoError(t, err)
+	assert.Contains(t, out, "tags=de")
+
+	out, err = executeConfigCommand(t, "set-domain-default", "mail.acme.com", "tags=", "track_opens=", "track_clicks=")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Removed the send defaults of mail.acme.com")
+
+	out, err = executeConfigCommand(t, "get", "domain-defaults.mail.acme.com")
+	require.NoError(t, err)
+	assert.Contains(t, out, "is not set")
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"fmt"
+
+	cliconfig "github.com/AhaSend/ahasend-cli/internal/config"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/spf13/cobra"
+)
+
+// NewGetCommand creates the config get command
+func NewGetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get <key>",
+		Short: "Show a configuration value",
+		Long:  `Show a per-profile setting or a global preference.`,
+		Example: `  # Show the address used by 'messages send --to-me'
+  ahasend config get default-test-recipient
+
+  # Show a setting for another profile
+  ahasend config get test-tag --profile staging
+
+  # Show the output format override of messages list
+  ahasend config get output-overrides.messages.list
+
+  # Show the send defaults of a sending domain
+  ahasend config get domain-defaults.mail.acme.com`,
+		Args:         cobra.ExactArgs(1),
+		RunE:         runConfigGet,
+		SilenceUsage: true,
+	}
+
+	return cmd
+}
+
+func runConfigGet(cmd *cobra.Command, args []string) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+	key := normalizeKey(args[0])
+
+	configMgr, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	var value string
+	if command, ok := outputOverrideCommand(args[0]); ok {
+		value = configMgr.GetOutputOverride(command)
+	} else if domain, ok := domainDefaultKey(args[0]); ok {
+		defaults, _ := configMgr.GetDomainDefault(domain)
+		value = defaults.String()
+	} else if cliconfig.IsProfileSetting(key) {
+		profileName, err := resolveProfileName(cmd, configMgr)
+		if err != nil {
+			return err
+		}
+		value, err = configMgr.GetProfileValue(profileName, key)
+		if err != nil {
+			return errors.NewValidationError(fmt.Sprintf("failed to get %s", args[0]), err)
+		}
+	} else {
+		value, err = configMgr.GetPreference(key)
+		if err != nil {
+			return errors.NewValidationError(fmt.Sprintf("failed to get %s", args[0]), err)
+		}
+	}
+
+	if value == "" {
+		return handler.HandleEmpty(fmt.Sprintf("%s is not set", args[0]))
+	}
+	return handler.HandleSimpleSuccess(fmt.Sprintf("%s = %s", args[0], value))
+}
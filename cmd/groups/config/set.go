@@ -0,0 +1,146 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	cliconfig "github.com/AhaSend/ahasend-cli/internal/config"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/spf13/cobra"
+)
+
+// NewSetCommand creates the config set command
+func NewSetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Set a configuration value",
+		Long: `Set a per-profile setting or a global preference.
+
+Per-profile settings are stored on the profile selected with --profile, or the
+default profile when --profile is not given. Pass an empty string to clear a
+per-profile setting.
+
+output-overrides.<command> sets the output format of one command, named by its
+path with dots (e.g. messages.list or subaccounts.api-keys.get). --output
+still wins over it. Pass an empty string to remove the override.`,
+		Example: `  # Address for 'messages send --to-me'
+  ahasend config set default-test-recipient me@example.com
+
+  # Sender used when --from is omitted
+  ahasend config set default-from noreply@mycompany.com
+
+  # Tag test sends for the staging profile
+  ahasend config set test-tag qa --profile staging
+
+  # Change the default output format
+  ahasend config set output-format json
+
+  # Always list messages as CSV unless --output says otherwise
+  ahasend config set output-overrides.messages.list csv
+
+  # Never page long tables
+  ahasend config set pager never`,
+		Args:         cobra.ExactArgs(2),
+		RunE:         runConfigSet,
+		SilenceUsage: true,
+	}
+
+	return cmd
+}
+
+func runConfigSet(cmd *cobra.Command, args []string) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+	key := normalizeKey(args[0])
+	value := args[1]
+
+	configMgr, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	if command, ok := outputOverrideCommand(args[0]); ok {
+		if !isCommand(cmd.Root(), command) {
+			return errors.NewValidationError(fmt.Sprintf("unknown command '%s' in %s", command, args[0]), nil)
+		}
+		if err := configMgr.SetOutputOverride(command, value); err != nil {
+			return errors.NewValidationError(fmt.Sprintf("failed to set %s", args[0]), err)
+		}
+		if value == "" {
+			return handler.HandleSimpleSuccess(fmt.Sprintf("Removed %s", args[0]))
+		}
+		return handler.HandleSimpleSuccess(fmt.Sprintf("Set %s", args[0]))
+	}
+
+	if cliconfig.IsProfileSetting(key) {
+		profileName, err := resolveProfileName(cmd, configMgr)
+		if err != nil {
+			return err
+		}
+
+		logger.ConfigOperation("set_profile_value", profileName, map[string]interface{}{
+			"key": key,
+		})
+
+		if err := configMgr.SetProfileValue(profileName, key, value); err != nil {
+			return errors.NewValidationError(fmt.Sprintf("failed to set %s", args[0]), err)
+		}
+		return handler.HandleSimpleSuccess(fmt.Sprintf("Set %s for profile '%s'", args[0], profileName))
+	}
+
+	if err := configMgr.SetPreference(key, value); err != nil {
+		return errors.NewValidationError(fmt.Sprintf("failed to set %s", args[0]), err)
+	}
+	return handler.HandleSimpleSuccess(fmt.Sprintf("Set %s", args[0]))
+}
+
+// normalizeKey accepts kebab-case keys on the command line and maps them to
+// the snake_case names used in the configuration file
+func normalizeKey(key string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(key)), "-", "_")
+}
+
+// outputOverrideCommand returns the command of an output-overrides.<command>
+// key, e.g. "messages.list". Command names keep their hyphens.
+func outputOverrideCommand(key string) (string, bool) {
+	prefix, command, found := strings.Cut(strings.ToLower(strings.TrimSpace(key)), ".")
+	if !found || normalizeKey(prefix) != "output_overrides" {
+		return "", false
+	}
+	return command, true
+}
+
+// isCommand reports whether command, a dotted path like "messages.list",
+// names a runnable command under root
+func isCommand(root *cobra.Command, command string) bool {
+	found, rest, err := root.Find(strings.Split(command, "."))
+	return err == nil && len(rest) == 0 && found.Runnable() && printer.CommandKey(found) == command
+}
+
+// loadConfig creates and loads the configuration manager
+func loadConfig() (*cliconfig.Manager, error) {
+	configMgr, err := cliconfig.NewManager()
+	if err != nil {
+		return nil, errors.NewConfigError("failed to initialize configuration", err)
+	}
+	if err := configMgr.Load(); err != nil {
+		return nil, errors.NewConfigError("failed to load configuration", err)
+	}
+	return configMgr, nil
+}
+
+// resolveProfileName returns --profile or the default profile
+func resolveProfileName(cmd *cobra.Command, configMgr *cliconfig.Manager) (string, error) {
+	profileName, _ := cmd.Flags().GetString("profile")
+	if profileName == "" {
+		profileName = configMgr.GetConfig().DefaultProfile
+	}
+	if profileName == "" {
+		return "", errors.NewConfigError("no default profile set. Run 'ahasend auth login' or pass --profile", nil)
+	}
+	if _, exists := configMgr.GetConfig().Profiles[profileName]; !exists {
+		return "", errors.NewNotFoundError(fmt.Sprintf("profile '%s' not found", profileName), nil)
+	}
+	return profileName, nil
+}
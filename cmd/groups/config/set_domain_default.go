@@ -0,0 +1,104 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	cliconfig "github.com/AhaSend/ahasend-cli/internal/config"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/validation"
+)
+
+// NewSetDomainDefaultCommand creates the config set-domain-default command
+func NewSetDomainDefaultCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-domain-default <domain> <key=value>...",
+		Short: "Set send defaults for a sending domain",
+		Long: `Set the send settings 'messages send' applies to messages from a domain.
+
+When the sender's domain has defaults, they are applied beneath the command
+line: a flag given explicitly always wins over the domain default, and the
+defaults of other domains never apply. Domains match exactly, so
+mail.acme.com does not inherit the defaults of acme.com. An internationalized
+domain matches in its Unicode and punycode forms alike.
+
+Settings:
+  track_opens   true or false
+  track_clicks  true or false
+  tags          Comma-separated tags, replaced by --tags when it is given
+
+Pass an empty value (e.g. tags=) to remove a setting. The defaults are stored
+under domain_defaults in ~/.ahasend/config.yaml and apply to every profile.`,
+		Example: `  # Transactional mail is never tracked
+  ahasend config set-domain-default mail.acme.com track_opens=false track_clicks=false tags=transactional
+
+  # Campaign tag and tracking for the marketing domain
+  ahasend config set-domain-default news.acme.com track_opens=true tags=campaign
+
+  # Stop tagging the marketing domain
+  ahasend config set-domain-default news.acme.com tags=
+
+  # Show the defaults of a domain
+  ahasend config get domain-defaults.mail.acme.com`,
+		Args:         cobra.MinimumNArgs(2),
+		RunE:         runConfigSetDomainDefault,
+		SilenceUsage: true,
+	}
+
+	return cmd
+}
+
+func runConfigSetDomainDefault(cmd *cobra.Command, args []string) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+	domain, err := cliconfig.NormalizeDomain(args[0])
+	if err != nil {
+		return errors.NewValidationError(fmt.Sprintf("invalid domain %q", args[0]), err)
+	}
+	display := validation.FormatDomainForDisplay(domain)
+
+	settings := make(map[string]string, len(args)-1)
+	for _, arg := range args[1:] {
+		key, value, found := strings.Cut(arg, "=")
+		if !found {
+			return errors.NewValidationError(fmt.Sprintf("invalid setting %q: expected key=value, e.g. track_opens=false", arg), nil)
+		}
+		key = normalizeKey(key)
+		if !cliconfig.IsDomainDefaultSetting(key) {
+			return errors.NewValidationError(fmt.Sprintf("unknown domain setting %q: expected track_opens, track_clicks or tags", key), nil)
+		}
+		settings[key] = value
+	}
+
+	configMgr, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	logger.ConfigOperation("set_domain_default", "", map[string]interface{}{
+		"domain":   domain,
+		"settings": len(settings),
+	})
+
+	if err := configMgr.SetDomainDefault(domain, settings); err != nil {
+		return errors.NewValidationError(fmt.Sprintf("failed to set the send defaults of %s", display), err)
+	}
+
+	defaults, ok := configMgr.GetDomainDefault(domain)
+	if !ok {
+		return handler.HandleSimpleSuccess(fmt.Sprintf("Removed the send defaults of %s", display))
+	}
+	return handler.HandleSimpleSuccess(fmt.Sprintf("Send defaults for %s: %s", display, defaults))
+}
+
+// domainDefaultKey returns the domain of a domain-defaults.<domain> key
+func domainDefaultKey(key string) (string, bool) {
+	prefix, domain, found := strings.Cut(strings.ToLower(strings.TrimSpace(key)), ".")
+	if !found || normalizeKey(prefix) != "domain_defaults" {
+		return "", false
+	}
+	return domain, true
+}
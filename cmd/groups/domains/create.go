@@ -74,6 +74,13 @@ func runDomainsCreate(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// Internationalized domains are sent to the API in punycode form
+	displayDomain := validation.FormatDomainForDisplay(domain)
+	domain, err = validation.ToASCIIDomain(domain)
+	if err != nil {
+		return err
+	}
+
 	logger.Get().WithFields(map[string]interface{}{
 		"domain":      domain,
 		"format":      format,
@@ -91,7 +98,7 @@ func runDomainsCreate(cmd *cobra.Command, args []string) error {
 	// The specific format and no-dns-help flags will need to be handled differently
 	// For now, we'll use the SingleConfig to pass the domain
 	config := printer.SingleConfig{
-		SuccessMessage: fmt.Sprintf("Domain '%s' added successfully", domain),
+		SuccessMessage: fmt.Sprintf("Domain '%s' added successfully", displayDomain),
 		EmptyMessage:   "No domain created",
 		FieldOrder:     []string{"domain", "id", "dns_valid", "created_at", "updated_at"},
 	}
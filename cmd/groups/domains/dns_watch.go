@@ -0,0 +1,250 @@
+package domains
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/dns"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/pager"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/spf13/cobra"
+)
+
+// maxPendingShown caps the pending checks named on a status line
+const maxPendingShown = 3
+
+// newLookuper creates the DNS query layer; replaced in tests
+var newLookuper = func() dns.Lookuper {
+	return dns.NetLookuper{}
+}
+
+// NewDNSWatchCommand creates the dns-watch command
+func NewDNSWatchCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dns-watch <domain>",
+		Short: "Watch DNS records propagate across public resolvers",
+		Long: `Repeatedly query each DNS record AhaSend expects for a domain against a set of
+public resolvers until every required record is visible on all of them.
+
+Each record is reported per resolver as:
+  found       the resolver serves the expected value
+  missing     the record does not exist on the resolver yet
+  mismatched  the record exists but with a different value
+  error       the resolver could not be queried
+
+When writing a table to a terminal the matrix is redrawn in place after every
+check. Otherwise a one-line status is written to stderr after every check, and
+the final matrix is printed in the selected output format.
+
+The command exits 0 as soon as all required records are visible everywhere,
+and with a timeout error (exit code 7) if they are not within --timeout. When
+the API marks no record as required, all records are waited for.`,
+		Example: `  # Watch propagation on Google, Cloudflare and Quad9
+  ahasend domains dns-watch example.com
+
+  # Check every 10 seconds for up to an hour
+  ahasend domains dns-watch example.com --interval 10s --timeout 1h
+
+  # Use specific resolvers
+  ahasend domains dns-watch example.com --resolvers 8.8.8.8,208.67.222.222
+
+  # Wait for propagation in a script
+  ahasend domains dns-watch example.com --output json > propagation.json`,
+		Args:         cobra.ExactArgs(1),
+		RunE:         runDomainsDNSWatch,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringSlice("resolvers", dns.DefaultResolvers, "DNS resolvers to query (comma-separated)")
+	cmd.Flags().Duration("interval", 30*time.Second, "Time between checks")
+	cmd.Flags().Duration("timeout", 30*time.Minute, "Give up if records have not propagated within this time")
+
+	return cmd
+}
+
+func runDomainsDNSWatch(cmd *cobra.Command, args []string) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+
+	resolvers, _ := cmd.Flags().GetStringSlice("resolvers")
+	interval, _ := cmd.Flags().GetDuration("interval")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+
+	resolvers = cleanResolvers(resolvers)
+	if len(resolvers) == 0 {
+		return errors.NewValidationError("at least one resolver is required", nil)
+	}
+	if interval <= 0 {
+		return errors.NewValidationError("--interval must be greater than zero", nil)
+	}
+	if timeout <= 0 {
+		return errors.NewValidationError("--timeout must be greater than zero", nil)
+	}
+
+	client, err := auth.GetAuthenticatedClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	domainName := args[0]
+	logger.Get().WithFields(map[string]interface{}{
+		"domain":    domainName,
+		"resolvers": resolvers,
+		"interval":  interval,
+		"timeout":   timeout,
+	}).Debug("Executing domain dns-watch command")
+
+	domain, err := client.GetDomain(domainName)
+	if err != nil {
+		return err
+	}
+	if domain == nil {
+		return errors.NewNotFoundError(fmt.Sprintf("domain '%s' not found", domainName), nil)
+	}
+	if len(domain.DNSRecords) == 0 {
+		return errors.NewValidationError(fmt.Sprintf("domain '%s' has no DNS records to check", domainName), nil)
+	}
+
+	parent := cmd.Context()
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, stop := signal.NotifyContext(parent, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// The live view redraws the terminal in place, so it cannot be paged
+	if err := pager.StopBuffering(cmd); err != nil {
+		return err
+	}
+
+	live := handler.GetFormat() == "table" && printer.IsTerminal(cmd.OutOrStdout())
+	display := &watchDisplay{out: cmd.OutOrStdout(), errOut: cmd.ErrOrStderr(), live: live, color: handler.GetCapabilities().Color}
+
+	lookuper := newLookuper()
+	deadline := time.Now().Add(timeout)
+	var matrix *dns.PropagationMatrix
+	for {
+		matrix = dns.CheckPropagation(ctx, lookuper, domain, resolvers)
+		if ctx.Err() != nil {
+			break
+		}
+		if matrix.Complete() {
+			break
+		}
+		display.update(matrix)
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			break
+		}
+		wait := interval
+		if remaining < wait {
+			wait = remaining
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(wait):
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	display.clear()
+
+	visible, total := matrix.RequiredCounts()
+	config := printer.SingleConfig{EmptyMessage: "No DNS records to check"}
+	switch {
+	case matrix.Complete():
+		config.SuccessMessage = fmt.Sprintf("✅ All required DNS records for %s are visible on %d resolvers", domain.Domain, len(resolvers))
+		return handler.HandleDNSPropagation(matrix, config)
+	case ctx.Err() != nil && parent.Err() == nil:
+		config.SuccessMessage = fmt.Sprintf("⚠️ Stopped watching %s", domain.Domain)
+		if err := handler.HandleDNSPropagation(matrix, config); err != nil {
+			return err
+		}
+		return errors.NewInterruptedError(fmt.Sprintf("DNS watch interrupted with %d/%d required records visible", visible, total), nil)
+	default:
+		config.SuccessMessage = fmt.Sprintf("⏱️ DNS records for %s have not fully propagated after %s", domain.Domain, timeout)
+		if err := handler.HandleDNSPropagation(matrix, config); err != nil {
+			return err
+		}
+		return errors.NewTimeoutError(fmt.Sprintf("DNS propagation for %s incomplete after %s: %d/%d required records visible", domain.Domain, timeout, visible, total), nil)
+	}
+}
+
+// cleanResolvers trims resolver addresses and drops empty entries
+func cleanResolvers(resolvers []string) []string {
+	cleaned := make([]string, 0, len(resolvers))
+	for _, resolver := range resolvers {
+		if resolver = strings.TrimSpace(resolver); resolver != "" {
+			cleaned = append(cleaned, resolver)
+		}
+	}
+	return cleaned
+}
+
+// watchDisplay reports progress between checks, either by redrawing the
+// matrix in place on a terminal or with one status line per check
+type watchDisplay struct {
+	out    io.Writer
+	errOut io.Writer
+	live   bool
+	color  bool
+	lines  int // lines of the last live frame
+}
+
+func (d *watchDisplay) update(matrix *dns.PropagationMatrix) {
+	if !d.live {
+		fmt.Fprintln(d.errOut, formatWatchStatus(matrix))
+		return
+	}
+
+	var frame bytes.Buffer
+	frameHandler := printer.GetResponseHandler("table", d.color, &frame)
+	if err := frameHandler.HandleDNSPropagation(matrix, printer.SingleConfig{
+		SuccessMessage: fmt.Sprintf("Watching DNS propagation for %s (Ctrl+C to stop)", matrix.Domain),
+	}); err != nil {
+		return
+	}
+
+	d.clear()
+	d.out.Write(frame.Bytes())
+	d.lines = bytes.Count(frame.Bytes(), []byte("\n"))
+}
+
+// clear erases the last live frame so the final output replaces it
+func (d *watchDisplay) clear() {
+	if d.lines > 0 {
+		fmt.Fprintf(d.out, "\033[%dA\033[J", d.lines)
+		d.lines = 0
+	}
+}
+
+// formatWatchStatus summarizes one check on a single line
+func formatWatchStatus(matrix *dns.PropagationMatrix) string {
+	visible, total := matrix.RequiredCounts()
+	status := fmt.Sprintf("[%s] %d/%d required records visible", matrix.CheckedAt.Local().Format("15:04:05"), visible, total)
+
+	pending := matrix.Pending()
+	if len(pending) == 0 {
+		return status
+	}
+	shown := pending
+	if len(shown) > maxPendingShown {
+		shown = shown[:maxPendingShown]
+	}
+	status += "; waiting on " + strings.Join(shown, ", ")
+	if more := len(pending) - len(shown); more > 0 {
+		status += fmt.Sprintf(" and %d more", more)
+	}
+	return status
+}
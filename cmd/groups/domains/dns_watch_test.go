@@ -0,0 +1,149 @@
+package domains
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/dns"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// propagatingLookuper serves the DMARC record on a resolver only once it has
+// been queried the given number of times
+type propagatingLookuper struct {
+	mu      sync.Mutex
+	visible map[string]int // server -> lookups before the record appears
+	calls   map[string]int
+}
+
+func (p *propagatingLookuper) Lookup(ctx context.Context, server, recordType, name string) ([]string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls[server]++
+	if p.calls[server] < p.visible[server] {
+		return nil, nil
+	}
+	return []string{"v=DMARC1; p=none;"}, nil
+}
+
+func executeDNSWatch(t *testing.T, format string, lookuper dns.Lookuper, args ...string) (string, string, error) {
+	t.Helper()
+
+	mockClient := &mocks.MockClient{}
+	domain := mockClient.NewMockDomain("example.com", false)
+	domain.DNSRecords = []responses.DNSRecord{
+		{Type: "TXT", Host: "_dmarc.example.com", Content: "v=DMARC1; p=none;", Required: true},
+	}
+	mockClient.On("GetDomain", "example.com").Return(domain, nil)
+
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	originalLookuper := newLookuper
+	newLookuper = func() dns.Lookuper { return lookuper }
+	t.Cleanup(func() { newLookuper = originalLookuper })
+
+	var stdout, stderr bytes.Buffer
+	cmd := NewDNSWatchCommand()
+	handler := printer.GetResponseHandler(format, false, &stdout)
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs(append([]string{"example.com"}, args...))
+
+	err := cmd.Execute()
+	return stdout.String(), stderr.String(), err
+}
+
+func TestDNSWatchCommand_Structure(t *testing.T) {
+	cmd := NewDNSWatchCommand()
+	assert.Equal(t, "dns-watch", cmd.Name())
+	assert.NotEmpty(t, cmd.Long)
+	assert.NotEmpty(t, cmd.Example)
+
+	for _, flag := range []string{"resolvers", "interval", "timeout"} {
+		assert.NotNil(t, cmd.Flags().Lookup(flag), "missing --%s flag", flag)
+	}
+	assert.Equal(t, "[8.8.8.8,1.1.1.1,9.9.9.9]", cmd.Flags().Lookup("resolvers").DefValue)
+}
+
+func TestDNSWatch_WaitsUntilPropagated(t *testing.T) {
+	lookuper := &propagatingLookuper{
+		visible: map[string]int{"8.8.8.8": 1, "1.1.1.1": 3},
+		calls:   map[string]int{},
+	}
+
+	stdout, stderr, err := executeDNSWatch(t, "plain", lookuper,
+		"--resolvers", "8.8.8.8,1.1.1.1", "--interval", "1ms", "--timeout", "5s")
+	require.NoError(t, err)
+
+	// One status line per incomplete check
+	lines := strings.Split(strings.TrimSpace(stderr), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], "1/2 required records visible; waiting on TXT _dmarc.example.com @ 1.1.1.1 (missing)")
+
+	assert.Contains(t, stdout, "All required DNS records for example.com are visible on 2 resolvers")
+	assert.Contains(t, stdout, "TXT _dmarc.example.com: 8.8.8.8=found 1.1.1.1=found")
+	assert.Equal(t, 3, lookuper.calls["1.1.1.1"])
+}
+
+func TestDNSWatch_Timeout(t *testing.T) {
+	lookuper := &propagatingLookuper{
+		visible: map[string]int{"8.8.8.8": 1, "1.1.1.1": 1 << 30},
+		calls:   map[string]int{},
+	}
+
+	stdout, _, err := executeDNSWatch(t, "json", lookuper,
+		"--resolvers", "8.8.8.8,1.1.1.1", "--interval", "1ms", "--timeout", "20ms")
+	require.Error(t, err)
+	assert.Equal(t, 7, errors.GetExitCode(err))
+	assert.Contains(t, err.Error(), "1/2 required records visible")
+
+	var result struct {
+		Object   string `json:"object"`
+		Complete bool   `json:"complete"`
+		Records  []struct {
+			Host     string            `json:"host"`
+			Statuses map[string]string `json:"statuses"`
+		} `json:"records"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &result))
+	assert.Equal(t, "dns_propagation", result.Object)
+	assert.False(t, result.Complete)
+	require.Len(t, result.Records, 1)
+	assert.Equal(t, map[string]string{"8.8.8.8": "found", "1.1.1.1": "missing"}, result.Records[0].Statuses)
+}
+
+func TestDNSWatch_InvalidFlags(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"no resolvers", []string{"--resolvers", " "}, "at least one resolver is required"},
+		{"zero interval", []string{"--interval", "0s"}, "--interval must be greater than zero"},
+		{"zero timeout", []string{"--timeout", "0s"}, "--timeout must be greater than zero"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := executeDNSWatch(t, "table", &propagatingLookuper{}, tt.args...)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
@@ -25,6 +25,7 @@ Common workflow:
 	cmd.AddCommand(NewGetCommand())
 	cmd.AddCommand(NewEditCommand())
 	cmd.AddCommand(NewCheckDNSCommand())
+	cmd.AddCommand(NewDNSWatchCommand())
 	cmd.AddCommand(NewVerifyCommand())
 	cmd.AddCommand(NewDeleteCommand())
 
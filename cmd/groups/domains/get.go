@@ -1,12 +1,20 @@
 package domains
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
 	"github.com/AhaSend/ahasend-cli/internal/errors"
 	"github.com/AhaSend/ahasend-cli/internal/logger"
 	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/validation"
+	"github.com/AhaSend/ahasend-go/models/responses"
 	"github.com/spf13/cobra"
 )
 
@@ -18,17 +26,35 @@ func NewGetCommand() *cobra.Command {
 		Long: `Get detailed information about a specific domain including DNS records,
 verification status, and last verification check time.
 
-This command shows complete domain configuration and status.`,
+This command shows complete domain configuration and status.
+
+Waiting for DNS:
+  --wait polls the domain every --interval (default: 15s) until AhaSend marks
+  its DNS as valid, then shows the domain. Table and plain output print a
+  status line to stderr after every poll, with the propagation of each DNS
+  record; other formats only print the final state. If the DNS is not valid
+  within --timeout (default: 10m), the final state is printed and the command
+  exits with a timeout error (exit code 7). Ctrl+C stops waiting.`,
 		Example: `  # Get domain details
   ahasend domains get example.com
 
   # Get domain details with JSON output
-  ahasend domains get example.com --output json`,
+  ahasend domains get example.com --output json
+
+  # Wait until the DNS records have propagated
+  ahasend domains get example.com --wait
+
+  # Wait up to 30 minutes in CI, checking every minute
+  ahasend domains get example.com --wait --timeout 30m --interval 1m --output json`,
 		Args:         cobra.ExactArgs(1),
 		RunE:         runDomainsGet,
 		SilenceUsage: true,
 	}
 
+	cmd.Flags().Bool("wait", false, "Poll until the domain's DNS is valid")
+	cmd.Flags().Duration("timeout", 10*time.Minute, "Give up waiting after this long (with --wait)")
+	cmd.Flags().Duration("interval", 15*time.Second, "Time between polls (with --wait)")
+
 	return cmd
 }
 
@@ -36,33 +62,115 @@ func runDomainsGet(cmd *cobra.Command, args []string) error {
 	// Get response handler instance
 	handler := printer.GetResponseHandlerFromCommand(cmd)
 
-	client, err := auth.GetAuthenticatedClient(cmd)
+	wait, _ := cmd.Flags().GetBool("wait")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	interval, _ := cmd.Flags().GetDuration("interval")
+	if !wait && (cmd.Flags().Changed("timeout") || cmd.Flags().Changed("interval")) {
+		return errors.NewValidationError("--timeout and --interval require --wait", nil)
+	}
+	if wait && interval <= 0 {
+		return errors.NewValidationError("--interval must be greater than zero", nil)
+	}
+	if wait && timeout <= 0 {
+		return errors.NewValidationError("--timeout must be greater than zero", nil)
+	}
+
+	apiClient, err := auth.GetAuthenticatedClient(cmd)
 	if err != nil {
 		return err
 	}
 
-	domain := args[0]
+	// Internationalized domains are looked up by their punycode form
+	domain, err := validation.ToASCIIDomain(args[0])
+	if err != nil {
+		return err
+	}
 
 	logger.Get().WithFields(map[string]interface{}{
-		"domain": domain,
+		"domain":   domain,
+		"wait":     wait,
+		"timeout":  timeout,
+		"interval": interval,
 	}).Debug("Executing domain get command")
 
 	// Get domain details
-	response, err := client.GetDomain(domain)
+	response, err := getDomain(apiClient, domain)
 	if err != nil {
 		return err
 	}
 
-	if response == nil {
-		return errors.NewNotFoundError(fmt.Sprintf("domain '%s' not found", domain), nil)
-	}
-
 	// Handle successful domain response
 	config := printer.SingleConfig{
-		SuccessMessage: fmt.Sprintf("Domain details for '%s'", domain),
+		SuccessMessage: fmt.Sprintf("Domain details for '%s'", validation.FormatDomainForDisplay(domain)),
 		EmptyMessage:   "Domain not found",
 		FieldOrder:     []string{"domain", "id", "dns_valid", "created_at", "updated_at", "last_dns_check_at"},
 	}
+	if !wait {
+		return handler.HandleSingleDomain(response, config)
+	}
 
-	return handler.HandleSingleDomain(response, config)
+	parent := cmd.Context()
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, stop := signal.NotifyContext(parent, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Status lines are only for people watching; structured formats get the final state
+	format := handler.GetFormat()
+	showStatus := format == "table" || format == "plain"
+
+	deadline := time.Now().Add(timeout)
+	for !response.DNSValid {
+		if showStatus {
+			fmt.Fprintln(cmd.ErrOrStderr(), printer.FormatDomainWaitStatus(response, time.Now()))
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(min(interval, remaining)):
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		if response, err = getDomain(apiClient, domain); err != nil {
+			return err
+		}
+	}
+
+	display := validation.FormatDomainForDisplay(domain)
+	switch {
+	case response.DNSValid:
+		config.SuccessMessage = fmt.Sprintf("✅ DNS for '%s' is valid", display)
+		return handler.HandleSingleDomain(response, config)
+	case ctx.Err() != nil && parent.Err() == nil:
+		config.SuccessMessage = fmt.Sprintf("⚠️ Stopped waiting for DNS of '%s'", display)
+		if err := handler.HandleSingleDomain(response, config); err != nil {
+			return err
+		}
+		return errors.NewInterruptedError(fmt.Sprintf("stopped waiting for DNS of '%s' before it was valid", display), nil)
+	default:
+		config.SuccessMessage = fmt.Sprintf("⏱️ DNS for '%s' is still not valid after %s", display, timeout)
+		if err := handler.HandleSingleDomain(response, config); err != nil {
+			return err
+		}
+		return errors.NewTimeoutError(fmt.Sprintf("DNS for '%s' not valid after %s", display, timeout), nil)
+	}
+}
+
+// getDomain fetches a domain, treating an empty response as not found
+func getDomain(apiClient client.AhaSendClient, domain string) (*responses.Domain, error) {
+	response, err := apiClient.GetDomain(domain)
+	if err != nil {
+		return nil, err
+	}
+	if response == nil {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("domain '%s' not found", domain), nil)
+	}
+	return response, nil
 }
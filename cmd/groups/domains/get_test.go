@@ -9,6 +9,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
 	"github.com/AhaSend/ahasend-cli/internal/mocks"
 	"github.com/AhaSend/ahasend-cli/internal/printer"
 	"github.com/AhaSend/ahasend-go/models/responses"
@@ -350,3 +353,119 @@ func TestGetCommand_AllFormatsSupported(t *testing.T) {
 		})
 	}
 }
+
+// waitDomain returns example.com with a DMARC record propagated or not
+func waitDomain(valid bool) *responses.Domain {
+	domain := (&mocks.MockClient{}).NewMockDomain("example.com", valid)
+	domain.DNSRecords = []responses.DNSRecord{
+		{Type: "CNAME", Host: "em.example.com", Required: true, Propagated: true},
+		{Type: "TXT", Host: "_dmarc.example.com", Required: true, Propagated: valid},
+	}
+	return domain
+}
+
+func executeGetWait(t *testing.T, format string, polls []*responses.Domain, args ...string) (string, string, error) {
+	t.Helper()
+
+	mockClient := &mocks.MockClient{}
+	for i, domain := range polls {
+		call := mockClient.On("GetDomain", "example.com").Return(domain, nil)
+		if i < len(polls)-1 {
+			call.Once()
+		}
+	}
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var stdout, stderr bytes.Buffer
+	cmd := NewGetCommand()
+	handler := printer.GetResponseHandler(format, false, &stdout)
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs(append([]string{"example.com"}, args...))
+
+	err := cmd.Execute()
+	return stdout.String(), stderr.String(), err
+}
+
+func TestGetCommand_WaitUntilValid(t *testing.T) {
+	stdout, stderr, err := executeGetWait(t, "plain",
+		[]*responses.Domain{waitDomain(false), waitDomain(false), waitDomain(true)},
+		"--wait", "--interval", "1ms", "--timeout", "5s")
+	require.NoError(t, err)
+
+	// One status line per poll before the DNS is valid
+	lines := strings.Split(strings.TrimSpace(stderr), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], "DNS Invalid, 1/2 records propagated: CNAME em.example.com Yes, TXT _dmarc.example.com No")
+
+	assert.Contains(t, stdout, "DNS for 'example.com' is valid")
+	assert.Contains(t, stdout, "DNS Status: Valid")
+}
+
+func TestGetCommand_WaitTimeout(t *testing.T) {
+	stdout, stderr, err := executeGetWait(t, "json", []*responses.Domain{waitDomain(false)},
+		"--wait", "--interval", "1ms", "--timeout", "20ms")
+	require.Error(t, err)
+	assert.Equal(t, 7, errors.GetExitCode(err))
+	assert.Contains(t, err.Error(), "DNS for 'example.com' not valid after 20ms")
+
+	// JSON output has only the final state
+	assert.NotContains(t, stderr, "records propagated")
+	var domain struct {
+		Domain   string `json:"domain"`
+		DNSValid bool   `json:"dns_valid"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &domain))
+	assert.Equal(t, "example.com", domain.Domain)
+	assert.False(t, domain.DNSValid)
+}
+
+func TestGetCommand_WaitInterrupted(t *testing.T) {
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetDomain", "example.com").Return(waitDomain(false), nil)
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var stdout, stderr bytes.Buffer
+	cmd := NewGetCommand()
+	handler := printer.GetResponseHandler("table", false, &stdout)
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cancel()
+	cmd.SetContext(ctx)
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs([]string{"example.com", "--wait"})
+
+	// A cancelled parent context stops the wait without the interrupted error
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Equal(t, 7, errors.GetExitCode(err))
+	mockClient.AssertNumberOfCalls(t, "GetDomain", 1)
+}
+
+func TestGetCommand_WaitInvalidFlags(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"timeout without wait", []string{"--timeout", "1m"}, "--timeout and --interval require --wait"},
+		{"interval without wait", []string{"--interval", "1s"}, "--timeout and --interval require --wait"},
+		{"zero interval", []string{"--wait", "--interval", "0s"}, "--interval must be greater than zero"},
+		{"zero timeout", []string{"--wait", "--timeout", "0s"}, "--timeout must be greater than zero"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := executeGetWait(t, "table", nil, tt.args...)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
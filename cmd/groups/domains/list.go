@@ -37,6 +37,7 @@ The list can be filtered and paginated for large numbers of domains.`,
 	cmd.Flags().Int32("limit", 0, "Maximum number of domains to return")
 	cmd.Flags().String("cursor", "", "Pagination cursor for next page")
 	cmd.Flags().String("status", "", "Filter by DNS status (verified, pending, failed)")
+	printer.AddIDsOnlyFlag(cmd)
 
 	return cmd
 }
@@ -2,8 +2,15 @@ package domains
 
 import (
 	"bytes"
+	"context"
 	"testing"
 
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -182,6 +189,38 @@ func TestListCommand_StatusValidation(t *testing.T) {
 }
 
 // Benchmark tests
+func TestListCommand_IDsOnly(t *testing.T) {
+	mockClient := &mocks.MockClient{}
+	mockClient.On("ListDomains", (*int32)(nil), (*string)(nil)).Return(&responses.PaginatedDomainsResponse{
+		Data: []responses.Domain{
+			{Domain: "example.com", DNSValid: true},
+			{Domain: "pending.example.com", DNSValid: false},
+			{Domain: "mail.example.org", DNSValid: true},
+		},
+	}, nil)
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	run := func(args ...string) string {
+		var buf bytes.Buffer
+		cmd := NewListCommand()
+		cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, printer.GetResponseHandler("table", false, &buf)))
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		cmd.SetArgs(args)
+		require.NoError(t, cmd.Execute())
+		return buf.String()
+	}
+
+	assert.Equal(t, "example.com\nmail.example.org\n", run("--ids-only", "--status", "verified"))
+
+	mockClient.ExpectedCalls = nil
+	mockClient.On("ListDomains", (*int32)(nil), (*string)(nil)).Return(&responses.PaginatedDomainsResponse{}, nil)
+	assert.Empty(t, run("--ids-only"), "an empty list prints nothing")
+}
+
 func BenchmarkListCommand_Creation(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		_ = NewListCommand()
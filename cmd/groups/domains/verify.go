@@ -1,13 +1,16 @@
 package domains
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
 	"github.com/AhaSend/ahasend-cli/internal/auth"
 	"github.com/AhaSend/ahasend-cli/internal/dns"
 	"github.com/AhaSend/ahasend-cli/internal/errors"
 	"github.com/AhaSend/ahasend-cli/internal/logger"
 	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-go/models/responses"
 	"github.com/spf13/cobra"
 )
 
@@ -16,21 +19,62 @@ func NewVerifyCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "verify <domain>",
 		Short: "Check domain DNS configuration",
-		Long: `Check the DNS configuration status for a domain and provide troubleshooting information.
-
-This command shows whether DNS records are properly configured and provides
-helpful guidance for fixing DNS issues.`,
+		Long: `Check the DNS configuration status for a domain and explain every record that
+failed AhaSend's DNS check.
+
+For each failed record the command shows its type and host, the expected
+value, the values public resolvers serve for it, and why it failed:
+  missing         no resolver serves the record
+  wrong value     the record exists with a different value
+  conflicting     several records compete where only one may exist, such as
+                  two SPF records or a CNAME next to other records
+  not propagated  the expected value is served, but not by every resolver
+                  or not yet seen by AhaSend
+  unknown         no resolver could be queried
+
+The API reports only whether each record passed, so the served values are
+looked up by the CLI on --resolvers and labelled "observed locally".
+
+With --local the command leaves AhaSend's check aside and looks up every
+expected record itself, on the system resolver or on the DNS server given
+with --resolver (which implies --local). Each record is reported as:
+  found       the expected value is served
+  missing     the record does not exist
+  mismatched  the record exists with a different value
+  error       the resolver could not be queried
+along with the values actually served. Required records count towards the
+exit code; optional ones are reported only.
+
+Exit codes:
+  0   every required record passed (with --local: was found)
+  9   some required records passed (partially verified)
+  10  no required record passed (unverified)`,
 		Example: `  # Check domain DNS status
   ahasend domains verify example.com
 
-  # Show detailed DNS information
-  ahasend domains verify example.com --verbose`,
+  # Also list the records that passed
+  ahasend domains verify example.com --verbose
+
+  # Compare against specific resolvers
+  ahasend domains verify example.com --resolvers 8.8.8.8,208.67.222.222
+
+  # Gate a deployment on a fully verified domain
+  ahasend domains verify example.com --output json > verification.json
+
+  # Look every record up from this machine with the system resolver
+  ahasend domains verify example.com --local
+
+  # Look every record up on a specific DNS server
+  ahasend domains verify example.com --resolver 1.1.1.1:53 --output json`,
 		Args:         cobra.ExactArgs(1),
 		RunE:         runDomainsVerify,
 		SilenceUsage: true,
 	}
 
 	cmd.Flags().Bool("verbose", false, "Show detailed DNS information")
+	cmd.Flags().StringSlice("resolvers", dns.DefaultResolvers, "DNS resolvers to look failed records up on (comma-separated)")
+	cmd.Flags().Bool("local", false, "Look every record up from this machine instead of explaining AhaSend's check")
+	cmd.Flags().String("resolver", "", "DNS server for --local, as host or host:port (default: the system resolver)")
 
 	return cmd
 }
@@ -39,17 +83,39 @@ func runDomainsVerify(cmd *cobra.Command, args []string) error {
 	// Get response handler instance
 	handler := printer.GetResponseHandlerFromCommand(cmd)
 
+	domain := args[0]
+	verbose, _ := cmd.Flags().GetBool("verbose")
+	resolvers, _ := cmd.Flags().GetStringSlice("resolvers")
+	local, _ := cmd.Flags().GetBool("local")
+	resolver, _ := cmd.Flags().GetString("resolver")
+
+	resolver = strings.TrimSpace(resolver)
+	if cmd.Flags().Changed("resolver") {
+		if resolver == "" {
+			return errors.NewValidationError("--resolver requires a DNS server, such as 1.1.1.1 or 1.1.1.1:53", nil)
+		}
+		local = true
+	}
+	if local && cmd.Flags().Changed("resolvers") {
+		return errors.NewValidationError("--resolvers applies to AhaSend's check; use --resolver with --local", nil)
+	}
+
+	resolvers = cleanResolvers(resolvers)
+	if len(resolvers) == 0 {
+		return errors.NewValidationError("at least one resolver is required", nil)
+	}
+
 	client, err := auth.GetAuthenticatedClient(cmd)
 	if err != nil {
 		return err
 	}
 
-	domain := args[0]
-	verbose, _ := cmd.Flags().GetBool("verbose")
-
 	logger.Get().WithFields(map[string]interface{}{
-		"domain":  domain,
-		"verbose": verbose,
+		"domain":    domain,
+		"verbose":   verbose,
+		"resolvers": resolvers,
+		"local":     local,
+		"resolver":  resolver,
 	}).Debug("Executing domain verify command")
 
 	// Get current domain status
@@ -62,44 +128,74 @@ func runDomainsVerify(cmd *cobra.Command, args []string) error {
 		return errors.NewNotFoundError(fmt.Sprintf("domain '%s' not found", domain), nil)
 	}
 
-	// Show troubleshooting tips and DNS configuration instructions
-	// TODO: This logic should ideally be moved to the printer implementations
-	// to maintain format consistency, but for now keeping it here
-	if !response.DNSValid {
-		fmt.Println("💡 Troubleshooting tips:")
-		fmt.Println("• DNS propagation can take up to 48 hours")
-		fmt.Println("• Check that DNS records are configured exactly as shown")
-		fmt.Println("• Use 'dig' or online DNS lookup tools to verify record propagation")
-		fmt.Printf("• Run 'ahasend domains get %s --show-dns-records' to see required records\n", domain)
-
-		// Show DNS configuration instructions
-		recordSet := dns.FormatDNSRecords(response)
-		dns.PrintDNSInstructions(recordSet)
-		fmt.Println()
-	}
-
-	// Show DNS records if verbose mode is enabled
-	if verbose && response != nil {
-		recordSet := dns.FormatDNSRecords(response)
-		if len(recordSet.Records) > 0 {
-			fmt.Println("📋 DNS Records Details:")
-			dns.PrintDNSInstructions(recordSet)
-		}
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if local {
+		return verifyLocally(ctx, handler, response, resolver)
 	}
+	verification := dns.Verify(ctx, newLookuper(), response, resolvers)
 
-	// Handle successful domain verification response
 	var successMessage string
-	if response.DNSValid {
+	switch verification.State {
+	case dns.StateVerified:
 		successMessage = fmt.Sprintf("✅ Domain '%s' DNS is properly configured", domain)
-	} else {
-		successMessage = fmt.Sprintf("⚠️ Domain '%s' DNS configuration needs attention", domain)
+	case dns.StatePartiallyVerified:
+		successMessage = fmt.Sprintf("⚠️ Domain '%s' DNS is partially configured", domain)
+	default:
+		successMessage = fmt.Sprintf("❌ Domain '%s' DNS is not configured", domain)
 	}
 
 	config := printer.SingleConfig{
 		SuccessMessage: successMessage,
 		EmptyMessage:   "Domain not found",
-		FieldOrder:     []string{"domain", "dns_valid", "created_at", "updated_at", "last_dns_check_at"},
+		ShowRecords:    verbose,
+	}
+	if err := handler.HandleDNSVerification(response, verification, config); err != nil {
+		return err
+	}
+
+	summary := fmt.Sprintf("%d/%d required DNS records for %s passed", verification.RequiredPassed, verification.RequiredTotal, domain)
+	switch verification.State {
+	case dns.StatePartiallyVerified:
+		return errors.NewDNSPartiallyVerifiedError(summary, nil)
+	case dns.StateUnverified:
+		return errors.NewDNSUnverifiedError(summary, nil)
+	}
+	return nil
+}
+
+// verifyLocally looks every expected record of the domain up on resolver,
+// or on the system resolver when it is empty, and reports what is served
+func verifyLocally(ctx context.Context, handler printer.ResponseHandler, domain *responses.Domain, resolver string) error {
+	verification := dns.VerifyLocally(ctx, newLookuper(), domain, resolver)
+
+	var successMessage string
+	switch verification.State() {
+	case dns.StateVerified:
+		successMessage = fmt.Sprintf("✅ Every required DNS record of '%s' is served as expected", domain.Domain)
+	case dns.StatePartiallyVerified:
+		successMessage = fmt.Sprintf("⚠️ Some required DNS records of '%s' are missing or differ", domain.Domain)
+	default:
+		successMessage = fmt.Sprintf("❌ No required DNS record of '%s' is served as expected", domain.Domain)
 	}
 
-	return handler.HandleSingleDomain(response, config)
+	config := printer.SingleConfig{
+		SuccessMessage: successMessage,
+		EmptyMessage:   "Domain not found",
+	}
+	if err := handler.HandleDomainVerification(verification, config); err != nil {
+		return err
+	}
+
+	found, total := verification.RequiredCounts()
+	summary := fmt.Sprintf("%d/%d required DNS records for %s found on resolver %s", found, total, domain.Domain, verification.Resolver)
+	switch verification.State() {
+	case dns.StatePartiallyVerified:
+		return errors.NewDNSPartiallyVerifiedError(summary, nil)
+	case dns.StateUnverified:
+		return errors.NewDNSUnverifiedError(summary, nil)
+	}
+	return nil
 }
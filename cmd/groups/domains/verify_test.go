@@ -1,9 +1,21 @@
 package domains
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"testing"
 
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/dns"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestVerifyCommand_Flags(t *testing.T) {
@@ -14,6 +26,10 @@ func TestVerifyCommand_Flags(t *testing.T) {
 	verboseFlag := flags.Lookup("verbose")
 	assert.NotNil(t, verboseFlag)
 	assert.Equal(t, "bool", verboseFlag.Value.Type())
+
+	resolversFlag := flags.Lookup("resolvers")
+	assert.NotNil(t, resolversFlag)
+	assert.Equal(t, "[8.8.8.8,1.1.1.1,9.9.9.9]", resolversFlag.DefValue)
 }
 
 func TestVerifyCommand_Args(t *testing.T) {
@@ -29,3 +45,177 @@ func TestVerifyCommand_Structure(t *testing.T) {
 	assert.NotEmpty(t, verifyCmd.Long)
 	assert.NotEmpty(t, verifyCmd.Example)
 }
+
+// staticLookuper serves the same values for a record on every resolver
+type staticLookuper map[string][]string // "type|name" -> values
+
+func (s staticLookuper) Lookup(ctx context.Context, server, recordType, name string) ([]string, error) {
+	return s[recordType+"|"+name], nil
+}
+
+func executeVerify(t *testing.T, format string, domain *responses.Domain, lookuper dns.Lookuper, args ...string) (string, error) {
+	t.Helper()
+
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetDomain", domain.Domain).Return(domain, nil)
+
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	originalLookuper := newLookuper
+	newLookuper = func() dns.Lookuper { return lookuper }
+	t.Cleanup(func() { newLookuper = originalLookuper })
+
+	var stdout bytes.Buffer
+	cmd := NewVerifyCommand()
+	handler := printer.GetResponseHandler(format, false, &stdout)
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(append([]string{domain.Domain}, args...))
+
+	err := cmd.Execute()
+	return stdout.String(), err
+}
+
+func verifyTestDomain(propagated ...bool) *responses.Domain {
+	domain := (&mocks.MockClient{}).NewMockDomain("example.com", false)
+	domain.DNSRecords = []responses.DNSRecord{
+		{Type: "TXT", Host: "_dmarc.example.com", Content: "v=DMARC1; p=none;", Required: true, Propagated: propagated[0]},
+		{Type: "CNAME", Host: "ahasend._domainkey.example.com", Content: "dkim.ahasend.com", Required: true, Propagated: propagated[1]},
+	}
+	domain.DNSValid = propagated[0] && propagated[1]
+	return domain
+}
+
+func TestVerify_Verified(t *testing.T) {
+	stdout, err := executeVerify(t, "plain", verifyTestDomain(true, true), staticLookuper{})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "DNS is properly configured")
+	assert.Contains(t, stdout, "State: verified")
+	assert.NotContains(t, stdout, "Failed:")
+	assert.NotContains(t, stdout, "Troubleshooting tips")
+}
+
+func TestVerify_PartiallyVerified(t *testing.T) {
+	lookuper := staticLookuper{"TXT|_dmarc.example.com": {"v=DMARC1; p=reject;"}}
+
+	stdout, err := executeVerify(t, "plain", verifyTestDomain(false, true), lookuper, "--resolvers", "8.8.8.8")
+	require.Error(t, err)
+	assert.Equal(t, 9, errors.GetExitCode(err))
+	assert.Contains(t, err.Error(), "1/2 required DNS records for example.com passed")
+
+	assert.Contains(t, stdout, "State: partially verified")
+	assert.Contains(t, stdout, "Failed: TXT _dmarc.example.com (required: Yes)")
+	assert.Contains(t, stdout, "Reason: wrong value")
+	assert.Contains(t, stdout, "Expected: v=DMARC1; p=none;")
+	assert.Contains(t, stdout, "Observed (observed locally): v=DMARC1; p=reject;")
+	assert.Contains(t, stdout, "Troubleshooting tips")
+}
+
+func TestVerify_UnverifiedJSON(t *testing.T) {
+	stdout, err := executeVerify(t, "json", verifyTestDomain(false, false), staticLookuper{}, "--resolvers", "8.8.8.8,1.1.1.1")
+	require.Error(t, err)
+	assert.Equal(t, 10, errors.GetExitCode(err))
+
+	var result struct {
+		Object         string   `json:"object"`
+		State          string   `json:"state"`
+		RequiredPassed int      `json:"required_passed"`
+		RequiredTotal  int      `json:"required_total"`
+		Resolvers      []string `json:"resolvers"`
+		Failures       []struct {
+			Host           string   `json:"host"`
+			Reason         string   `json:"reason"`
+			Observed       []string `json:"observed"`
+			ObservedSource string   `json:"observed_source"`
+		} `json:"failures"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &result))
+	assert.Equal(t, "dns_verification", result.Object)
+	assert.Equal(t, "unverified", result.State)
+	assert.Equal(t, 0, result.RequiredPassed)
+	assert.Equal(t, 2, result.RequiredTotal)
+	assert.Equal(t, []string{"8.8.8.8", "1.1.1.1"}, result.Resolvers)
+	require.Len(t, result.Failures, 2)
+	assert.Equal(t, "missing", result.Failures[0].Reason)
+	assert.Equal(t, []string{}, result.Failures[0].Observed)
+	assert.Equal(t, "observed locally", result.Failures[0].ObservedSource)
+}
+
+func TestVerify_RequiresResolver(t *testing.T) {
+	_, err := executeVerify(t, "plain", verifyTestDomain(true, true), staticLookuper{}, "--resolvers", " ")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "at least one resolver is required")
+}
+
+// serverLookuper serves values only on one server
+type serverLookuper struct {
+	server string
+	staticLookuper
+}
+
+func (s serverLookuper) Lookup(ctx context.Context, server, recordType, name string) ([]string, error) {
+	if server != s.server {
+		return nil, nil
+	}
+	return s.staticLookuper.Lookup(ctx, server, recordType, name)
+}
+
+func TestVerify_LocalChecksEveryRecord(t *testing.T) {
+	lookuper := serverLookuper{server: "1.1.1.1:53", staticLookuper: staticLookuper{
+		"TXT|_dmarc.example.com": {"v=DMARC1; p=reject;"},
+	}}
+
+	// AhaSend reports both records as passed; the local check does not rely on it
+	stdout, err := executeVerify(t, "plain", verifyTestDomain(true, true), lookuper, "--resolver", "1.1.1.1:53")
+	require.Error(t, err)
+	assert.Equal(t, 10, errors.GetExitCode(err))
+	assert.Contains(t, err.Error(), "0/2 required DNS records for example.com found on resolver 1.1.1.1:53")
+
+	assert.Contains(t, stdout, "Resolver: 1.1.1.1:53")
+	assert.Contains(t, stdout, "TXT _dmarc.example.com (required: Yes): mismatched")
+	assert.Contains(t, stdout, "Observed: v=DMARC1; p=reject;")
+	assert.Contains(t, stdout, "CNAME ahasend._domainkey.example.com (required: Yes): missing")
+	assert.Contains(t, stdout, "Observed: (none)")
+}
+
+func TestVerify_LocalSystemResolverJSON(t *testing.T) {
+	lookuper := serverLookuper{server: "", staticLookuper: staticLookuper{
+		"TXT|_dmarc.example.com":               {"v=DMARC1; p=none;"},
+		"CNAME|ahasend._domainkey.example.com": {"dkim.ahasend.com."},
+	}}
+
+	stdout, err := executeVerify(t, "json", verifyTestDomain(false, false), lookuper, "--local")
+	require.NoError(t, err)
+
+	var result struct {
+		Object        string `json:"object"`
+		Resolver      string `json:"resolver"`
+		State         string `json:"state"`
+		RequiredFound int    `json:"required_found"`
+		Records       []struct {
+			Host     string   `json:"host"`
+			Status   string   `json:"status"`
+			Observed []string `json:"observed"`
+		} `json:"records"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &result))
+	assert.Equal(t, "domain_verification", result.Object)
+	assert.Equal(t, "system", result.Resolver)
+	assert.Equal(t, "verified", result.State)
+	assert.Equal(t, 2, result.RequiredFound)
+	require.Len(t, result.Records, 2)
+	assert.Equal(t, "found", result.Records[1].Status)
+	assert.Equal(t, []string{"dkim.ahasend.com."}, result.Records[1].Observed)
+}
+
+func TestVerify_LocalFlagConflicts(t *testing.T) {
+	_, err := executeVerify(t, "plain", verifyTestDomain(true, true), staticLookuper{}, "--local", "--resolvers", "8.8.8.8")
+	assert.ErrorContains(t, err, "use --resolver with --local")
+
+	_, err = executeVerify(t, "plain", verifyTestDomain(true, true), staticLookuper{}, "--resolver", " ")
+	assert.ErrorContains(t, err, "--resolver requires a DNS server")
+}
@@ -0,0 +1,85 @@
+package inbound
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/AhaSend/ahasend-go/models/responses"
+
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+)
+
+// saveAttachments decodes and writes attachments into dir, returning the
+// paths written. Existing files are never overwritten.
+func saveAttachments(dir string, attachments []responses.ContentAttachment) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.NewFileError("failed to create attachment directory", err)
+	}
+
+	var saved []string
+	for i, attachment := range attachments {
+		data, err := decodeAttachment(attachment.Content)
+		if err != nil {
+			return saved, errors.NewValidationError(fmt.Sprintf("failed to decode attachment %q", attachment.Filename), err)
+		}
+
+		name := sanitizeAttachmentName(attachment.Filename, i)
+		path, err := writeUniqueFile(dir, name, data)
+		if err != nil {
+			return saved, errors.NewFileError(fmt.Sprintf("failed to save attachment %q", name), err)
+		}
+		saved = append(saved, path)
+	}
+	return saved, nil
+}
+
+// decodeAttachment decodes base64 attachment content, tolerating line
+// breaks and missing padding
+func decodeAttachment(content string) ([]byte, error) {
+	cleaned := strings.NewReplacer("\r", "", "\n", "", " ", "").Replace(content)
+	if data, err := base64.StdEncoding.DecodeString(cleaned); err == nil {
+		return data, nil
+	}
+	return base64.RawStdEncoding.DecodeString(strings.TrimRight(cleaned, "="))
+}
+
+// sanitizeAttachmentName strips any directory components so attachments
+// cannot be written outside the target directory
+func sanitizeAttachmentName(filename string, index int) string {
+	name := filepath.Base(strings.ReplaceAll(filename, "\\", "/"))
+	if name == "" || name == "." || name == ".." || name == "/" {
+		return fmt.Sprintf("attachment-%d", index+1)
+	}
+	return name
+}
+
+// writeUniqueFile writes data to dir/name, adding a numeric suffix before the
+// extension when the name is already taken
+func writeUniqueFile(dir, name string, data []byte) (string, error) {
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+
+	for n := 0; ; n++ {
+		candidate := name
+		if n > 0 {
+			candidate = fmt.Sprintf("%s-%d%s", base, n, ext)
+		}
+		path := filepath.Join(dir, candidate)
+
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if os.IsExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if _, err := f.Write(data); err != nil {
+			f.Close()
+			return "", err
+		}
+		return path, f.Close()
+	}
+}
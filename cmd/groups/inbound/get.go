@@ -0,0 +1,96 @@
+package inbound
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/spf13/cobra"
+)
+
+// NewGetCommand creates the inbound get command
+func NewGetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get <message-id>",
+		Short: "Get details of an inbound message",
+		Long: `Get details of an inbound message, including its attachment count.
+
+With --download-attachments, attachments from the stored message are written
+to the given directory. Files are written directly to disk and never pass
+through the output formatter. If a file with the same name already exists, a
+numeric suffix is added (e.g. invoice-1.pdf) rather than overwriting it.
+Attachments are only available while the message is within its retention
+period.`,
+		Example: `  # Show an inbound message
+  ahasend inbound get 8c5e3f2a-1b4d-4e6f-9a8b-7c6d5e4f3a2b
+
+  # Save its attachments
+  ahasend inbound get 8c5e3f2a-1b4d-4e6f-9a8b-7c6d5e4f3a2b --download-attachments ./attachments`,
+		Args:         cobra.ExactArgs(1),
+		RunE:         runInboundGet,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().String("download-attachments", "", "Directory to save the message's attachments to")
+
+	return cmd
+}
+
+func runInboundGet(cmd *cobra.Command, args []string) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+
+	client, err := auth.GetAuthenticatedClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	messageID := args[0]
+	downloadDir, _ := cmd.Flags().GetString("download-attachments")
+
+	logger.Get().WithFields(map[string]interface{}{
+		"message_id":           messageID,
+		"download_attachments": downloadDir,
+	}).Debug("Executing inbound get command")
+
+	message, err := client.GetMessage(messageID)
+	if err != nil {
+		return err
+	}
+	if message == nil {
+		return errors.NewNotFoundError(fmt.Sprintf("message '%s' not found", messageID), nil)
+	}
+	if message.Direction != directionInbound {
+		return errors.NewValidationError(fmt.Sprintf("message '%s' is not an inbound message (direction: %s); use 'ahasend messages get' instead", messageID, message.Direction), nil)
+	}
+
+	if downloadDir != "" {
+		if !message.RetainUntil.IsZero() && time.Now().After(message.RetainUntil) {
+			return errors.NewValidationError(fmt.Sprintf("message content was retained until %s and is no longer available", message.RetainUntil.Format(time.RFC3339)), nil)
+		}
+		if message.ContentParsed == nil {
+			return errors.NewNotFoundError("message content is not available; attachments cannot be downloaded", nil)
+		}
+
+		saved, err := saveAttachments(downloadDir, message.ContentParsed.Attachments)
+		if err != nil {
+			return err
+		}
+
+		// Report saved files on stderr so structured stdout stays parseable
+		if len(saved) == 0 {
+			fmt.Fprintln(cmd.ErrOrStderr(), "Message has no attachments")
+		}
+		for _, path := range saved {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Saved attachment: %s\n", path)
+		}
+	}
+
+	return handler.HandleSingleMessage(message, printer.SingleConfig{
+		SuccessMessage: fmt.Sprintf("Inbound message details for '%s'", messageID),
+		EmptyMessage:   "Message not found",
+		FieldOrder:     []string{"id", "sender", "recipient", "subject", "received", "attachments"},
+	})
+}
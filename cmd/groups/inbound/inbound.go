@@ -0,0 +1,35 @@
+package inbound
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates the inbound command group
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "inbound",
+		Short: "Browse inbound messages received through routes",
+		Long: `Browse inbound email received by your routes.
+
+These commands are conveniences over the messages API that only return
+inbound messages and show inbound-relevant details such as the attachment
+count. Use 'inbound get --download-attachments' to save attachments from a
+stored message while it is still within its retention period.
+
+Common workflow:
+  1. Configure a route: ahasend routes create
+  2. Browse received mail: ahasend inbound list
+  3. Inspect a message: ahasend inbound get <message-id>`,
+		Example: `  # List recent inbound messages
+  ahasend inbound list --from-time 24h
+
+  # Show an inbound message and save its attachments
+  ahasend inbound get <message-id> --download-attachments ./attachments`,
+	}
+
+	// Add subcommands
+	cmd.AddCommand(NewListCommand())
+	cmd.AddCommand(NewGetCommand())
+
+	return cmd
+}
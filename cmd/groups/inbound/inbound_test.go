@@ -0,0 +1,164 @@
+package inbound
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+)
+
+func executeInbound(t *testing.T, mockClient *mocks.MockClient, args ...string) (string, string, error) {
+	t.Helper()
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var stdout, stderr bytes.Buffer
+	cmd := NewCommand()
+	handler := printer.GetResponseHandler("plain", false, &stdout)
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return stdout.String(), stderr.String(), err
+}
+
+func newInboundMessage(direction string, attachments ...responses.ContentAttachment) *responses.Message {
+	return &responses.Message{
+		ID:          uuid.New(),
+		Sender:      "alice@example.com",
+		Recipient:   "support@inbound.example.com",
+		Subject:     "Invoice attached",
+		Direction:   direction,
+		CreatedAt:   time.Now().Add(-time.Hour),
+		RetainUntil: time.Now().Add(24 * time.Hour),
+		ContentParsed: &responses.ContentParsed{
+			Attachments: attachments,
+		},
+	}
+}
+
+func TestInboundCommand_Structure(t *testing.T) {
+	cmd := NewCommand()
+	assert.Equal(t, "inbound", cmd.Name())
+	assert.NotEmpty(t, cmd.Long)
+
+	names := []string{}
+	for _, sub := range cmd.Commands() {
+		names = append(names, sub.Name())
+	}
+	assert.ElementsMatch(t, []string{"list", "get"}, names)
+
+	get := NewGetCommand()
+	assert.NotNil(t, get.Flags().Lookup("download-attachments"))
+}
+
+func TestFilterInbound(t *testing.T) {
+	cursor := "next"
+	page := &responses.PaginatedMessagesResponse{
+		Object: "list",
+		Data: []responses.Message{
+			*newInboundMessage("inbound"),
+			*newInboundMessage("outbound"),
+		},
+	}
+	page.Pagination.HasMore = true
+	page.Pagination.NextCursor = &cursor
+
+	filtered := filterInbound(page)
+	require.Len(t, filtered.Data, 1)
+	assert.Equal(t, "inbound", filtered.Data[0].Direction)
+	assert.True(t, filtered.Pagination.HasMore)
+	assert.Equal(t, &cursor, filtered.Pagination.NextCursor)
+}
+
+func TestInboundList_ShowsOnlyInbound(t *testing.T) {
+	inbound := newInboundMessage("inbound")
+	outbound := newInboundMessage("outbound")
+	outbound.Recipient = "customer@example.net"
+
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetMessages", mock.Anything).Return(&responses.PaginatedMessagesResponse{
+		Object: "list",
+		Data:   []responses.Message{*inbound, *outbound},
+	}, nil)
+
+	stdout, _, err := executeInbound(t, mockClient, "list")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "support@inbound.example.com")
+	assert.NotContains(t, stdout, "Route:")
+	assert.NotContains(t, stdout, "customer@example.net")
+}
+
+func TestInboundGet_RejectsOutbound(t *testing.T) {
+	message := newInboundMessage("outbound")
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetMessage", message.ID.String()).Return(message, nil)
+
+	_, _, err := executeInbound(t, mockClient, "get", message.ID.String())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not an inbound message")
+}
+
+func TestInboundGet_DownloadAttachments(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "invoice.pdf"), []byte("existing"), 0644))
+
+	message := newInboundMessage("inbound",
+		responses.ContentAttachment{Filename: "invoice.pdf", Content: base64.StdEncoding.EncodeToString([]byte("%PDF-1"))},
+		responses.ContentAttachment{Filename: "invoice.pdf", Content: base64.StdEncoding.EncodeToString([]byte("%PDF-2"))},
+		responses.ContentAttachment{Filename: "../../etc/passwd", Content: base64.StdEncoding.EncodeToString([]byte("x"))},
+	)
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetMessage", message.ID.String()).Return(message, nil)
+
+	stdout, stderr, err := executeInbound(t, mockClient, "get", message.ID.String(), "--download-attachments", dir)
+	require.NoError(t, err)
+
+	existing, _ := os.ReadFile(filepath.Join(dir, "invoice.pdf"))
+	assert.Equal(t, "existing", string(existing), "existing files must not be overwritten")
+	first, _ := os.ReadFile(filepath.Join(dir, "invoice-1.pdf"))
+	assert.Equal(t, "%PDF-1", string(first))
+	second, _ := os.ReadFile(filepath.Join(dir, "invoice-2.pdf"))
+	assert.Equal(t, "%PDF-2", string(second))
+	assert.FileExists(t, filepath.Join(dir, "passwd"))
+
+	assert.Contains(t, stderr, "Saved attachment")
+	assert.NotContains(t, stdout, "%PDF", "attachment bytes must not reach the printer")
+	assert.Contains(t, stdout, "Attachments: 3")
+}
+
+func TestInboundGet_DownloadAfterRetention(t *testing.T) {
+	message := newInboundMessage("inbound")
+	message.RetainUntil = time.Now().Add(-time.Hour)
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetMessage", message.ID.String()).Return(message, nil)
+
+	_, _, err := executeInbound(t, mockClient, "get", message.ID.String(), "--download-attachments", t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no longer available")
+}
+
+func TestSanitizeAttachmentName(t *testing.T) {
+	assert.Equal(t, "report.csv", sanitizeAttachmentName("report.csv", 0))
+	assert.Equal(t, "evil.sh", sanitizeAttachmentName("../../evil.sh", 0))
+	assert.Equal(t, "evil.sh", sanitizeAttachmentName(`..\..\evil.sh`, 0))
+	assert.Equal(t, "attachment-2", sanitizeAttachmentName("", 1))
+}
@@ -0,0 +1,139 @@
+package inbound
+
+import (
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/output"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	ahasend "github.com/AhaSend/ahasend-go"
+	"github.com/AhaSend/ahasend-go/models/common"
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/spf13/cobra"
+)
+
+// directionInbound is the message direction reported for routed email
+const directionInbound = "inbound"
+
+// NewListCommand creates the inbound list command
+func NewListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List inbound messages",
+		Long: `List inbound messages received through your routes.
+
+Only messages with direction "inbound" are shown. Filtering by direction is
+applied to each page returned by the API, so a page may contain fewer
+messages than --limit; use --cursor to continue.
+
+Date/time values should be in RFC3339 format (e.g., 2024-01-15T10:30:00Z),
+a date like 2024-01-15 (read in --timezone; the whole day for --to-time and
+--on) or relative like "24h" or "7d".`,
+		Example: `  # List inbound messages
+  ahasend inbound list
+
+  # Inbound messages from a specific sender in the last day
+  ahasend inbound list --sender alice@example.com --from-time 24h
+
+  # Inbound messages sent to a route address
+  ahasend inbound list --recipient support@inbound.example.com
+
+  # Export to JSON
+  ahasend inbound list --output json`,
+		RunE:         runInboundList,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().String("sender", "", "Filter by sender email address")
+	cmd.Flags().String("recipient", "", "Filter by recipient email address")
+	cmd.Flags().String("subject", "", "Filter by subject text (partial match)")
+	cmd.Flags().String("from-time", "", "Filter messages received after this time (RFC3339, YYYY-MM-DD or relative like '24h', '7d')")
+	cmd.Flags().String("to-time", "", "Filter messages received before this time (RFC3339, YYYY-MM-DD for the whole day, or relative)")
+	cmd.Flags().String("on", "", "Filter to a single day (YYYY-MM-DD); replaces --from-time and --to-time")
+	cmd.Flags().String("timezone", "", "Timezone for date-only values, e.g. 'Europe/Berlin' or 'UTC' (default: local)")
+	cmd.Flags().Int("limit", 100, "Maximum number of messages to fetch per page (1-100)")
+	cmd.Flags().String("cursor", "", "Pagination cursor for next page")
+	printer.AddIDsOnlyFlag(cmd)
+
+	return cmd
+}
+
+func runInboundList(cmd *cobra.Command, args []string) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+
+	client, err := auth.GetAuthenticatedClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	sender, _ := cmd.Flags().GetString("sender")
+	recipient, _ := cmd.Flags().GetString("recipient")
+	subject, _ := cmd.Flags().GetString("subject")
+	fromTimeStr, _ := cmd.Flags().GetString("from-time")
+	toTimeStr, _ := cmd.Flags().GetString("to-time")
+	on, _ := cmd.Flags().GetString("on")
+	timezone, _ := cmd.Flags().GetString("timezone")
+	limit, _ := cmd.Flags().GetInt("limit")
+	cursor, _ := cmd.Flags().GetString("cursor")
+
+	if limit < 1 || limit > 100 {
+		return errors.NewValidationError("limit must be between 1 and 100", nil)
+	}
+
+	fromTime, toTime, err := output.ParseTimeRange(fromTimeStr, toTimeStr, on, timezone)
+	if err != nil {
+		return err
+	}
+
+	logger.Get().WithFields(map[string]interface{}{
+		"sender":    sender,
+		"recipient": recipient,
+		"subject":   subject,
+		"from_time": fromTime,
+		"to_time":   toTime,
+		"limit":     limit,
+		"cursor":    cursor,
+	}).Debug("Listing inbound messages")
+
+	response, err := client.GetMessages(requests.GetMessagesParams{
+		Sender:    ahasend.String(sender),
+		Recipient: ahasend.String(recipient),
+		Subject:   ahasend.String(subject),
+		FromTime:  fromTime,
+		ToTime:    toTime,
+		PaginationParams: common.PaginationParams{
+			Limit:  ahasend.Int32(int32(limit)),
+			Cursor: ahasend.String(cursor),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if response == nil {
+		return errors.NewAPIError("received nil response from API", nil)
+	}
+
+	return handler.HandleMessageList(filterInbound(response), printer.ListConfig{
+		SuccessMessage: "Inbound messages retrieved successfully",
+		EmptyMessage:   "No inbound messages found matching criteria",
+		ShowPagination: true,
+		FieldOrder:     []string{"id", "sender", "recipient", "subject", "received"},
+	})
+}
+
+// filterInbound returns a copy of the page containing only inbound messages,
+// keeping the pagination cursor so callers can continue scanning
+func filterInbound(response *responses.PaginatedMessagesResponse) *responses.PaginatedMessagesResponse {
+	filtered := &responses.PaginatedMessagesResponse{
+		Object:     response.Object,
+		Data:       []responses.Message{},
+		Pagination: response.Pagination,
+	}
+	for _, message := range response.Data {
+		if message.Direction == directionInbound {
+			filtered.Data = append(filtered.Data, message)
+		}
+	}
+	return filtered
+}
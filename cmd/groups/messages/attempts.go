@@ -0,0 +1,152 @@
+package messages
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/spf13/cobra"
+)
+
+// NewAttemptsCommand creates the attempts command
+func NewAttemptsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "attempts <api-id>",
+		Short: "Show the SMTP delivery attempts of a message",
+		Long: `Show each SMTP delivery attempt of an outbound message in chronological
+order: attempt number, timestamp, remote host (destination MX), status
+(delivered, deferred or failed), SMTP code and the server's response.
+
+The interval column shows the time since the previous attempt (or since the
+message was created, for the first attempt), and the total time from creation
+to the final state is shown below the table. While delivery is still being
+retried, the time elapsed so far is shown instead.
+
+Table output truncates long SMTP responses; use --output json for the full text.
+
+Attempts are not recorded for inbound messages and are no longer available
+once the message is past its retention period.`,
+		Example: `  # Show why a message is being deferred
+  ahasend messages attempts abcd1234-5678-90ef-abcd-1234567890ab
+
+  # Full SMTP responses as JSON
+  ahasend messages attempts abcd1234-5678-90ef-abcd-1234567890ab --output json`,
+		Args:         cobra.ExactArgs(1),
+		RunE:         runMessagesAttempts,
+		SilenceUsage: true,
+	}
+
+	return cmd
+}
+
+func runMessagesAttempts(cmd *cobra.Command, args []string) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+
+	apiClient, err := auth.GetAuthenticatedClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	messageID := args[0]
+
+	logger.Get().WithFields(map[string]interface{}{
+		"message_id": messageID,
+	}).Debug("Executing messages attempts command")
+
+	message, err := apiClient.GetMessage(messageID)
+	if err != nil {
+		return err
+	}
+	if message == nil {
+		return errors.NewNotFoundError(fmt.Sprintf("message '%s' not found", messageID), nil)
+	}
+	if message.Direction == "inbound" {
+		return handler.HandleEmpty(fmt.Sprintf("Delivery attempts are not recorded for inbound message '%s'", messageID))
+	}
+
+	response, err := apiClient.GetMessageAttempts(messageID)
+	if err != nil && !errors.IsNotFoundError(err) {
+		return err
+	}
+
+	now := time.Now()
+	if response == nil || len(response.Data) == 0 {
+		return handler.HandleEmpty(attemptsUnavailableMessage(messageID, message, now))
+	}
+
+	return handler.HandleMessageAttempts(buildAttemptsReport(messageID, message, response.Data, now), printer.SingleConfig{
+		SuccessMessage: fmt.Sprintf("Delivery attempts for message '%s'", messageID),
+		EmptyMessage:   attemptsUnavailableMessage(messageID, message, now),
+	})
+}
+
+// buildAttemptsReport orders the attempts chronologically and computes the
+// interval before each attempt and the total delivery time
+func buildAttemptsReport(messageID string, message *responses.Message, attempts []client.MessageAttempt, now time.Time) *printer.MessageAttemptsReport {
+	sorted := append([]client.MessageAttempt(nil), attempts...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Time.Before(sorted[j].Time)
+	})
+
+	report := &printer.MessageAttemptsReport{
+		MessageID: messageID,
+		Recipient: message.Recipient,
+		Status:    message.Status,
+		CreatedAt: message.CreatedAt,
+		Attempts:  make([]printer.MessageAttemptEntry, len(sorted)),
+	}
+
+	previous := message.CreatedAt
+	for i, attempt := range sorted {
+		number := attempt.Attempt
+		if number == 0 {
+			number = i + 1
+		}
+		report.Attempts[i] = printer.MessageAttemptEntry{
+			Attempt:       number,
+			Time:          attempt.Time,
+			RemoteHost:    attempt.RemoteHost,
+			Status:        attempt.Status,
+			SMTPCode:      attempt.SMTPCode,
+			SMTPResponse:  attempt.SMTPResponse,
+			SincePrevious: nonNegative(attempt.Time.Sub(previous)),
+		}
+		previous = attempt.Time
+	}
+
+	last := sorted[len(sorted)-1]
+	report.Final = last.Status == client.AttemptStatusDelivered || last.Status == client.AttemptStatusFailed
+	if report.Final {
+		report.TotalDuration = nonNegative(last.Time.Sub(message.CreatedAt))
+	} else {
+		report.TotalDuration = nonNegative(now.Sub(message.CreatedAt))
+	}
+	return report
+}
+
+// attemptsUnavailableMessage explains why a message has no attempt details
+func attemptsUnavailableMessage(messageID string, message *responses.Message, now time.Time) string {
+	if !message.RetainUntil.IsZero() && now.After(message.RetainUntil) {
+		return fmt.Sprintf("Delivery attempts for message '%s' are no longer available: message data was retained until %s",
+			messageID, message.RetainUntil.Local().Format("2006-01-02 15:04:05"))
+	}
+	if message.NumAttempts == 0 {
+		return fmt.Sprintf("No delivery attempts yet for message '%s' (status: %s)", messageID, message.Status)
+	}
+	return fmt.Sprintf("Delivery attempt details are not available for message '%s' (%d attempts recorded, status: %s)",
+		messageID, message.NumAttempts, message.Status)
+}
+
+// nonNegative clamps clock skew between timestamps to zero
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
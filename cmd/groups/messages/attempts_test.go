@@ -0,0 +1,178 @@
+package messages
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+)
+
+var attemptsCreatedAt = time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+func attemptsMessage(status string) *responses.Message {
+	return &responses.Message{
+		ID:          uuid.New(),
+		CreatedAt:   attemptsCreatedAt,
+		RetainUntil: time.Now().Add(24 * time.Hour),
+		Recipient:   "user@example.com",
+		Direction:   "outbound",
+		Status:      status,
+		NumAttempts: 3,
+	}
+}
+
+func deferredThenDelivered() []client.MessageAttempt {
+	longResponse := "451 4.7.1 Greylisted, please try again later " + strings.Repeat("x", 80)
+	return []client.MessageAttempt{
+		// Deliberately out of order; the report sorts by time
+		{Attempt: 3, Time: attemptsCreatedAt.Add(2 * time.Hour), RemoteHost: "mx1.example.com", Status: "delivered", SMTPCode: 250, SMTPResponse: "250 2.0.0 OK"},
+		{Attempt: 1, Time: attemptsCreatedAt.Add(5 * time.Second), RemoteHost: "mx1.example.com", Status: "deferred", SMTPCode: 451, SMTPResponse: longResponse},
+		{Attempt: 2, Time: attemptsCreatedAt.Add(35 * time.Minute), RemoteHost: "mx2.example.com", Status: "deferred", SMTPCode: 421, SMTPResponse: "421 Try again"},
+	}
+}
+
+func executeAttempts(t *testing.T, mockClient *mocks.MockClient, format string, args ...string) (string, error) {
+	t.Helper()
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var stdout bytes.Buffer
+	cmd := NewAttemptsCommand()
+	handler := printer.GetResponseHandler(format, false, &stdout)
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stdout)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return stdout.String(), err
+}
+
+func TestBuildAttemptsReport(t *testing.T) {
+	t.Run("final state", func(t *testing.T) {
+		report := buildAttemptsReport("msg-1", attemptsMessage("Delivered"), deferredThenDelivered(), time.Now())
+
+		require.Len(t, report.Attempts, 3)
+		assert.Equal(t, []int{1, 2, 3}, []int{report.Attempts[0].Attempt, report.Attempts[1].Attempt, report.Attempts[2].Attempt})
+		assert.Equal(t, 5*time.Second, report.Attempts[0].SincePrevious)
+		assert.Equal(t, 35*time.Minute-5*time.Second, report.Attempts[1].SincePrevious)
+		assert.Equal(t, 85*time.Minute, report.Attempts[2].SincePrevious)
+		assert.True(t, report.Final)
+		assert.Equal(t, 2*time.Hour, report.TotalDuration)
+	})
+
+	t.Run("still deferred", func(t *testing.T) {
+		attempts := deferredThenDelivered()[1:]
+		now := attemptsCreatedAt.Add(3 * time.Hour)
+		report := buildAttemptsReport("msg-1", attemptsMessage("Deferred"), attempts, now)
+
+		assert.False(t, report.Final)
+		assert.Equal(t, 3*time.Hour, report.TotalDuration)
+	})
+}
+
+func TestAttemptsCommand_Output(t *testing.T) {
+	t.Run("table truncates responses", func(t *testing.T) {
+		mockClient := &mocks.MockClient{}
+		mockClient.On("GetMessage", "msg-1").Return(attemptsMessage("Delivered"), nil)
+		mockClient.On("GetMessageAttempts", "msg-1").Return(&client.MessageAttemptsResponse{Data: deferredThenDelivered()}, nil)
+
+		out, err := executeAttempts(t, mockClient, "table", "msg-1")
+		require.NoError(t, err)
+		assert.Contains(t, out, "mx2.example.com")
+		assert.Contains(t, out, "Greylisted")
+		assert.NotContains(t, out, strings.Repeat("x", 80))
+		assert.Contains(t, out, "+34m 55s")
+		assert.Contains(t, out, "Total time: 2h 0m from creation to Delivered")
+	})
+
+	t.Run("json keeps full responses", func(t *testing.T) {
+		mockClient := &mocks.MockClient{}
+		mockClient.On("GetMessage", "msg-1").Return(attemptsMessage("Delivered"), nil)
+		mockClient.On("GetMessageAttempts", "msg-1").Return(&client.MessageAttemptsResponse{Data: deferredThenDelivered()}, nil)
+
+		out, err := executeAttempts(t, mockClient, "json", "msg-1")
+		require.NoError(t, err)
+
+		var decoded struct {
+			Object               string  `json:"object"`
+			Final                bool    `json:"final"`
+			TotalDurationSeconds float64 `json:"total_duration_seconds"`
+			Attempts             []struct {
+				SMTPCode        int     `json:"smtp_code"`
+				SMTPResponse    string  `json:"smtp_response"`
+				IntervalSeconds float64 `json:"interval_seconds"`
+			} `json:"attempts"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(out), &decoded))
+		assert.Equal(t, "message_attempts", decoded.Object)
+		assert.True(t, decoded.Final)
+		assert.Equal(t, 7200.0, decoded.TotalDurationSeconds)
+		require.Len(t, decoded.Attempts, 3)
+		assert.Equal(t, 451, decoded.Attempts[0].SMTPCode)
+		assert.Contains(t, decoded.Attempts[0].SMTPResponse, strings.Repeat("x", 80))
+		assert.Equal(t, 5.0, decoded.Attempts[0].IntervalSeconds)
+	})
+}
+
+func TestAttemptsCommand_Unavailable(t *testing.T) {
+	t.Run("inbound message", func(t *testing.T) {
+		message := attemptsMessage("Received")
+		message.Direction = "inbound"
+		mockClient := &mocks.MockClient{}
+		mockClient.On("GetMessage", "msg-1").Return(message, nil)
+
+		out, err := executeAttempts(t, mockClient, "plain", "msg-1")
+		require.NoError(t, err)
+		assert.Contains(t, out, "not recorded for inbound message")
+		mockClient.AssertNotCalled(t, "GetMessageAttempts", "msg-1")
+	})
+
+	t.Run("past retention", func(t *testing.T) {
+		message := attemptsMessage("Delivered")
+		message.RetainUntil = time.Now().Add(-time.Hour)
+		mockClient := &mocks.MockClient{}
+		mockClient.On("GetMessage", "msg-1").Return(message, nil)
+		mockClient.On("GetMessageAttempts", "msg-1").Return(nil, errors.NewNotFoundError("not found", nil))
+
+		out, err := executeAttempts(t, mockClient, "plain", "msg-1")
+		require.NoError(t, err)
+		assert.Contains(t, out, "no longer available")
+	})
+
+	t.Run("not attempted yet", func(t *testing.T) {
+		message := attemptsMessage("Queued")
+		message.NumAttempts = 0
+		mockClient := &mocks.MockClient{}
+		mockClient.On("GetMessage", "msg-1").Return(message, nil)
+		mockClient.On("GetMessageAttempts", "msg-1").Return(&client.MessageAttemptsResponse{}, nil)
+
+		out, err := executeAttempts(t, mockClient, "plain", "msg-1")
+		require.NoError(t, err)
+		assert.Contains(t, out, "No delivery attempts yet")
+	})
+
+	t.Run("other errors are returned", func(t *testing.T) {
+		mockClient := &mocks.MockClient{}
+		mockClient.On("GetMessage", "msg-1").Return(attemptsMessage("Deferred"), nil)
+		mockClient.On("GetMessageAttempts", "msg-1").Return(nil, errors.NewAPIError("server error", nil))
+
+		_, err := executeAttempts(t, mockClient, "plain", "msg-1")
+		require.Error(t, err)
+	})
+}
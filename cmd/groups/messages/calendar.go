@@ -0,0 +1,118 @@
+package messages
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-go/models/common"
+)
+
+// maxCalendarInviteSize is the largest --calendar-invite file accepted
+const maxCalendarInviteSize = 1024 * 1024
+
+// calendarInviteContentType marks a calendar part as a meeting request, which
+// is what makes mail clients show Accept and Decline buttons
+const calendarInviteContentType = "text/calendar; method=REQUEST; charset=UTF-8"
+
+// processCalendarInvite reads a --calendar-invite file as the two parts mail
+// clients need for an invitation: an inline text/calendar part, which the
+// message places next to the text and HTML bodies as an alternative, and the
+// same data as an .ics attachment for clients that only offer a download.
+// Files over 1MB, or that are not an iCalendar object with an event, are
+// rejected.
+func processCalendarInvite(path string) ([]common.Attachment, error) {
+	if path == "" {
+		return nil, nil
+	}
+	path = normalizeInputPath(path)
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.NewFileError(fmt.Sprintf("cannot access calendar invite %s", path), err)
+	}
+	if info.Size() > maxCalendarInviteSize {
+		return nil, errors.NewValidationError(fmt.Sprintf("calendar invite %s is too large (%.2f MB > 1 MB)",
+			path, float64(info.Size())/(1024*1024)), nil)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.NewFileError(fmt.Sprintf("cannot read calendar invite %s", path), err)
+	}
+	if err := validateCalendar(content); err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("calendar invite %s is not a valid iCalendar file: %v", path, err), nil)
+	}
+
+	fileName := filepath.Base(path)
+	if !strings.EqualFold(filepath.Ext(fileName), ".ics") {
+		fileName += ".ics"
+	}
+	data := base64.StdEncoding.EncodeToString(content)
+	return []common.Attachment{
+		{
+			FileName:           fileName,
+			ContentType:        calendarInviteContentType,
+			ContentDisposition: inlineDisposition,
+			Data:               data,
+			Base64:             true,
+		},
+		{
+			FileName:           fileName,
+			ContentType:        calendarInviteContentType,
+			ContentDisposition: "attachment",
+			Data:               data,
+			Base64:             true,
+		},
+	}, nil
+}
+
+// validateCalendar checks that content is a VCALENDAR object holding at
+// least one VEVENT. A METHOD other than REQUEST is rejected, since the part
+// is sent as a request and clients ignore invites whose methods disagree.
+func validateCalendar(content []byte) error {
+	// Long lines are folded onto lines starting with a space or tab (RFC 5545)
+	text := strings.ReplaceAll(string(content), "\r\n", "\n")
+	text = strings.NewReplacer("\n ", "", "\n\t", "").Replace(text)
+
+	var lines []string
+	for _, line := range strings.Split(strings.TrimPrefix(text, "\ufeff"), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) == 0 || !strings.EqualFold(lines[0], "BEGIN:VCALENDAR") {
+		return fmt.Errorf("it must start with BEGIN:VCALENDAR")
+	}
+	if !strings.EqualFold(lines[len(lines)-1], "END:VCALENDAR") {
+		return fmt.Errorf("it must end with END:VCALENDAR")
+	}
+
+	events, open := 0, false
+	for _, line := range lines {
+		name, value, _ := strings.Cut(line, ":")
+		name, _, _ = strings.Cut(strings.ToUpper(name), ";") // drop parameters
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			open = true
+		case name == "END" && strings.EqualFold(value, "VEVENT"):
+			if !open {
+				return fmt.Errorf("END:VEVENT without BEGIN:VEVENT")
+			}
+			open = false
+			events++
+		case name == "METHOD" && !strings.EqualFold(value, "REQUEST"):
+			return fmt.Errorf("METHOD is %s; invites must use METHOD:REQUEST", value)
+		}
+	}
+	if open {
+		return fmt.Errorf("BEGIN:VEVENT without END:VEVENT")
+	}
+	if events == 0 {
+		return fmt.Errorf("it has no VEVENT")
+	}
+	return nil
+}
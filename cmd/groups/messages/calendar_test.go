@@ -0,0 +1,140 @@
+package messages
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+)
+
+const testInvite = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"PRODID:-//Example//Planner//EN\r\n" +
+	"METHOD:REQUEST\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:planning-review@example.com\r\n" +
+	"DTSTART:20261020T150000Z\r\n" +
+	"SUMMARY:Planning review with a summary long enough to be folded onto a\r\n" +
+	"  second line\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func writeInvite(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestValidateCalendar(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"invite", testInvite, ""},
+		{"lowercase LF without method", "begin:vcalendar\nbegin:vevent\nend:vevent\nend:vcalendar\n", ""},
+		{"empty", "", "must start with BEGIN:VCALENDAR"},
+		{"not a calendar", "BEGIN:VCARD\nFN:Ana\nEND:VCARD\n", "must start with BEGIN:VCALENDAR"},
+		{"truncated", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nEND:VEVENT\n", "must end with END:VCALENDAR"},
+		{"no event", "BEGIN:VCALENDAR\nBEGIN:VTODO\nEND:VTODO\nEND:VCALENDAR\n", "has no VEVENT"},
+		{"unclosed event", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nEND:VCALENDAR\n", "BEGIN:VEVENT without END:VEVENT"},
+		{"cancellation", strings.Replace(testInvite, "METHOD:REQUEST", "METHOD:CANCEL", 1), "METHOD is CANCEL"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCalendar([]byte(tt.content))
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestProcessCalendarInvite_Errors(t *testing.T) {
+	t.Run("too large", func(t *testing.T) {
+		path := writeInvite(t, "big.ics", testInvite+strings.Repeat("X", maxCalendarInviteSize))
+		_, err := processCalendarInvite(path)
+		require.Error(t, err)
+		assert.Equal(t, 4, errors.GetExitCode(err))
+		assert.Contains(t, err.Error(), "is too large (1.00 MB > 1 MB)")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		path := writeInvite(t, "notes.ics", "meeting at 3pm")
+		_, err := processCalendarInvite(path)
+		require.Error(t, err)
+		assert.Equal(t, 4, errors.GetExitCode(err))
+		assert.Contains(t, err.Error(), "is not a valid iCalendar file: it must start with BEGIN:VCALENDAR")
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		_, err := processCalendarInvite(filepath.Join(t.TempDir(), "missing.ics"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot access calendar invite")
+	})
+}
+
+func TestCreateSendJobs_CalendarInvite(t *testing.T) {
+	invite := writeInvite(t, "meeting.ics", testInvite)
+	agenda := writeInvite(t, "agenda.pdf", "%PDF-1.4")
+
+	jobs, _, err := createSendJobs(
+		"news@example.com", []string{"ana@example.com"}, nil, nil, "", false, "Planning review", "",
+		"See you Thursday", "<p>See you Thursday</p>", "",
+		"", "", "", false,
+		"", nil, false, nil,
+		nil, "", 0, false, "", nil,
+		false, false, []string{agenda}, nil, false, invite, "key",
+	)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	request := jobs[0].Request
+
+	// The text and HTML bodies are kept, with the calendar as a third alternative
+	require.NotNil(t, request.TextContent)
+	assert.Equal(t, "See you Thursday", *request.TextContent)
+	require.NotNil(t, request.HtmlContent)
+
+	attachments := request.Attachments
+	require.Len(t, attachments, 3)
+	assert.Equal(t, "agenda.pdf", attachments[0].FileName)
+	assert.Empty(t, attachments[0].ContentDisposition)
+
+	data := base64.StdEncoding.EncodeToString([]byte(testInvite))
+	alternative, attached := attachments[1], attachments[2]
+	assert.Equal(t, "text/calendar; method=REQUEST; charset=UTF-8", alternative.ContentType)
+	assert.Equal(t, "inline", alternative.ContentDisposition)
+	assert.Nil(t, alternative.ContentID)
+	assert.Equal(t, data, alternative.Data)
+	assert.True(t, alternative.Base64)
+
+	assert.Equal(t, "meeting.ics", attached.FileName)
+	assert.Equal(t, "text/calendar; method=REQUEST; charset=UTF-8", attached.ContentType)
+	assert.Equal(t, "attachment", attached.ContentDisposition)
+	assert.Equal(t, data, attached.Data)
+}
+
+func TestCreateSendJobs_CalendarInviteInvalid(t *testing.T) {
+	invite := writeInvite(t, "meeting.ics", "BEGIN:VCALENDAR\nEND:VCALENDAR\n")
+
+	_, _, err := createSendJobs(
+		"news@example.com", []string{"ana@example.com"}, nil, nil, "", false, "Planning review", "",
+		"See you Thursday", "", "",
+		"", "", "", false,
+		"", nil, false, nil,
+		nil, "", 0, false, "", nil,
+		false, false, nil, nil, false, invite, "key",
+	)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "has no VEVENT")
+}
@@ -0,0 +1,144 @@
+package messages
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AhaSend/ahasend-cli/internal/batch"
+	"github.com/AhaSend/ahasend-cli/internal/config"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+)
+
+// defaultConfirmThreshold is the recipient count above which a send needs
+// confirmation when neither the flag nor the profile sets one
+const defaultConfirmThreshold = 1000
+
+// Confirmation I/O, replaceable in tests
+var (
+	confirmInput    io.Reader = os.Stdin
+	confirmOutput   io.Writer = os.Stderr
+	stdinIsTerminal           = func() bool { return printer.IsTerminal(os.Stdin) }
+)
+
+// resolveConfirmThreshold returns the threshold from --confirm-threshold when
+// set explicitly, then the active profile's confirm_threshold, then the
+// default. A threshold of 0 disables the confirmation.
+func resolveConfirmThreshold(cmd *cobra.Command) int {
+	if cmd.Flags().Changed("confirm-threshold") {
+		return getIntFlag(cmd, "confirm-threshold")
+	}
+	if threshold := profileConfirmThreshold(cmd); threshold != nil {
+		return *threshold
+	}
+	return defaultConfirmThreshold
+}
+
+// profileConfirmThreshold reads confirm_threshold from the selected profile,
+// nil when it is not set. Configuration problems are not fatal here; the
+// default applies instead.
+func profileConfirmThreshold(cmd *cobra.Command) *int {
+	profile, _, err := loadSelectedProfile(cmd)
+	if err != nil {
+		logger.Get().WithError(err).Debug("Failed to load profile for confirm threshold")
+		return nil
+	}
+	return profile.ConfirmThreshold
+}
+
+// loadSelectedProfile returns the profile chosen with --profile, or the default profile
+func loadSelectedProfile(cmd *cobra.Command) (*config.Profile, string, error) {
+	configMgr, err := config.NewManager()
+	if err != nil {
+		return nil, "", err
+	}
+	if err := configMgr.Load(); err != nil {
+		return nil, "", err
+	}
+
+	profileName := getStringFlag(cmd, "profile")
+	if profileName == "" {
+		profileName = configMgr.GetConfig().DefaultProfile
+	}
+	profile, exists := configMgr.GetConfig().Profiles[profileName]
+	if !exists {
+		return nil, profileName, fmt.Errorf("profile '%s' not found", profileName)
+	}
+	return &profile, profileName, nil
+}
+
+// countRecipients returns the total number of recipients across all jobs
+func countRecipients(sendJobs []*batch.SendJob) int {
+	total := 0
+	for _, job := range sendJobs {
+		total += job.RecipientCount
+	}
+	return total
+}
+
+// confirmLargeSend asks for confirmation when the recipient count exceeds the
+// configured threshold. Sandbox sends are exempt unless --confirm-sandbox is set.
+func confirmLargeSend(flags *SendFlags, totalRecipients int) error {
+	if flags.ConfirmThreshold <= 0 || totalRecipients <= flags.ConfirmThreshold {
+		return nil
+	}
+	if flags.Sandbox && !flags.ConfirmSandbox {
+		return nil
+	}
+	if flags.AssumeYes {
+		return nil
+	}
+
+	if !stdinIsTerminal() {
+		return errors.NewValidationError(fmt.Sprintf(
+			"refusing to send to %d recipients (above --confirm-threshold %d) without confirmation; re-run with --yes to proceed non-interactively",
+			totalRecipients, flags.ConfirmThreshold), nil)
+	}
+
+	schedule := "immediately"
+	if flags.ScheduleTime != "" {
+		schedule = flags.ScheduleTime
+	}
+	sandbox := "no"
+	if flags.Sandbox {
+		sandbox = "yes"
+	}
+
+	fmt.Fprintf(confirmOutput, "You are about to send a large batch:\n")
+	fmt.Fprintf(confirmOutput, "  Recipients: %d (threshold %d)\n", totalRecipients, flags.ConfirmThreshold)
+	fmt.Fprintf(confirmOutput, "  From:       %s\n", flags.FromEmail)
+	if flags.SubjectField != "" {
+		fmt.Fprintf(confirmOutput, "  Subject:    %s\n", formatSubjectSummary(flags.SubjectField, flags.Subjects, flags.Batches))
+	} else {
+		fmt.Fprintf(confirmOutput, "  Subject:    %s\n", flags.Subject)
+	}
+	fmt.Fprintf(confirmOutput, "  Sandbox:    %s\n", sandbox)
+	if len(flags.ScheduleBuckets) > 0 {
+		fmt.Fprintf(confirmOutput, "  Schedule:   %d buckets\n", len(flags.ScheduleBuckets))
+		fmt.Fprintf(confirmOutput, "%s\n", formatScheduleBuckets(flags.ScheduleBuckets, "    "))
+	} else {
+		fmt.Fprintf(confirmOutput, "  Schedule:   %s\n", schedule)
+	}
+	if len(flags.Metadata) > 0 {
+		fmt.Fprintf(confirmOutput, "  Metadata:   %s\n", printer.FormatMetadata(flags.Metadata))
+	}
+	fmt.Fprint(confirmOutput, "\nDo you want to continue? (y/N): ")
+
+	reader := bufio.NewReader(confirmInput)
+	response, err := reader.ReadString('\n')
+	if err != nil && response == "" {
+		return errors.NewValidationError("send cancelled: failed to read confirmation", err)
+	}
+
+	response = strings.ToLower(strings.TrimSpace(response))
+	if response != "y" && response != "yes" {
+		return errors.NewValidationError("send cancelled", nil)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package messages
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/config"
+)
+
+// stubConfirmIO replaces the confirmation prompt's terminal and I/O for a test
+func stubConfirmIO(t *testing.T, isTTY bool, input string) *bytes.Buffer {
+	t.Helper()
+	prevInput, prevOutput, prevTTY := confirmInput, confirmOutput, stdinIsTerminal
+	t.Cleanup(func() {
+		confirmInput, confirmOutput, stdinIsTerminal = prevInput, prevOutput, prevTTY
+	})
+
+	var out bytes.Buffer
+	confirmInput = strings.NewReader(input)
+	confirmOutput = &out
+	stdinIsTerminal = func() bool { return isTTY }
+	return &out
+}
+
+func largeSendFlags() *SendFlags {
+	return &SendFlags{
+		FromEmail:        "news@example.com",
+		Subject:          "Big announcement",
+		ConfirmThreshold: 1000,
+	}
+}
+
+func TestConfirmLargeSend_ExactlyAtThreshold(t *testing.T) {
+	out := stubConfirmIO(t, false, "")
+
+	require.NoError(t, confirmLargeSend(largeSendFlags(), 1000))
+	assert.Empty(t, out.String(), "no prompt at the threshold")
+}
+
+func TestConfirmLargeSend_AboveThreshold(t *testing.T) {
+	t.Run("confirmed", func(t *testing.T) {
+		out := stubConfirmIO(t, true, "y\n")
+
+		require.NoError(t, confirmLargeSend(largeSendFlags(), 1001))
+		assert.Contains(t, out.String(), "Recipients: 1001")
+		assert.Contains(t, out.String(), "news@example.com")
+		assert.Contains(t, out.String(), "Big announcement")
+		assert.Contains(t, out.String(), "Sandbox:    no")
+		assert.Contains(t, out.String(), "Schedule:   immediately")
+	})
+
+	t.Run("declined", func(t *testing.T) {
+		stubConfirmIO(t, true, "n\n")
+
+		err := confirmLargeSend(largeSendFlags(), 1001)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "send cancelled")
+	})
+
+	t.Run("yes flag skips prompt", func(t *testing.T) {
+		out := stubConfirmIO(t, true, "")
+		flags := largeSendFlags()
+		flags.AssumeYes = true
+
+		require.NoError(t, confirmLargeSend(flags, 5000))
+		assert.Empty(t, out.String())
+	})
+}
+
+func TestConfirmLargeSend_NonTTY(t *testing.T) {
+	stubConfirmIO(t, false, "y\n")
+
+	err := confirmLargeSend(largeSendFlags(), 200000)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--yes")
+	assert.Contains(t, err.Error(), "200000")
+
+	flags := largeSendFlags()
+	flags.AssumeYes = true
+	assert.NoError(t, confirmLargeSend(flags, 200000))
+}
+
+func TestConfirmLargeSend_SandboxExemption(t *testing.T) {
+	stubConfirmIO(t, false, "")
+	flags := largeSendFlags()
+	flags.Sandbox = true
+
+	assert.NoError(t, confirmLargeSend(flags, 5000), "sandbox sends are exempt by default")
+
+	flags.ConfirmSandbox = true
+	err := confirmLargeSend(flags, 5000)
+	require.Error(t, err, "--confirm-sandbox includes sandbox sends")
+}
+
+func TestConfirmLargeSend_DisabledThreshold(t *testing.T) {
+	stubConfirmIO(t, false, "")
+	flags := largeSendFlags()
+	flags.ConfirmThreshold = 0
+
+	assert.NoError(t, confirmLargeSend(flags, 1000000))
+}
+
+func TestResolveConfirmThreshold(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	cmd := NewSendCommand()
+	assert.Equal(t, defaultConfirmThreshold, resolveConfirmThreshold(cmd))
+
+	mgr, err := config.NewManager()
+	require.NoError(t, err)
+	require.NoError(t, mgr.Load())
+	threshold := 250
+	require.NoError(t, mgr.SetProfile("default", config.Profile{APIKey: "key", AccountID: "id", ConfirmThreshold: &threshold}))
+	assert.Equal(t, 250, resolveConfirmThreshold(cmd), "profile default applies when the flag is not set")
+
+	disabled := 0
+	require.NoError(t, mgr.SetProfile("default", config.Profile{APIKey: "key", AccountID: "id", ConfirmThreshold: &disabled}))
+	assert.Equal(t, 0, resolveConfirmThreshold(cmd), "a profile threshold of 0 disables the confirmation")
+
+	require.NoError(t, cmd.Flags().Set("confirm-threshold", "50"))
+	assert.Equal(t, 50, resolveConfirmThreshold(cmd), "flag overrides the profile")
+}
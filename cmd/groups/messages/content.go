@@ -0,0 +1,284 @@
+package messages
+
+import (
+	"fmt"
+	"net/mail"
+	"net/textproto"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/spf13/cobra"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/bytesize"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+)
+
+// contentPartTypes maps the parts --part selects to their content types
+var contentPartTypes = map[string]string{
+	printer.MessagePartText: "text/plain",
+	printer.MessagePartHTML: "text/html",
+	printer.MessagePartAMP:  "text/x-amp-html",
+}
+
+// leadingHeaders are shown first, in this order; other headers follow
+// alphabetically
+var leadingHeaders = []string{"From", "To", "Cc", "Reply-To", "Subject", "Date", "Message-Id"}
+
+// NewContentCommand creates the content command
+func NewContentCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "content <message-id>",
+		Short: "Show the stored content of a message",
+		Long: `Show the content of a message as it was sent or received.
+
+Plain output prints the message headers, a blank line and one body part,
+selected with --part:
+  text  the text/plain part (the default; falls back to the HTML part when
+        the message has no text part)
+  html  the text/html part
+  amp   the AMP (text/x-amp-html) part
+  raw   the whole RFC 822 message, headers included
+
+Table output summarizes the message instead of printing its body: the size
+of each part and the attachments. JSON output has the headers, the selected
+part as "body", and the sizes of every part and attachment.
+
+--save writes the raw RFC 822 message to a file, which mail clients can open
+as an .eml file.
+
+Message content is kept for the account's data retention period (see
+'ahasend account get'). Once it has been deleted the command fails with
+exit code 11; the message metadata stays available with 'ahasend messages get'.`,
+		Example: `  # Print the headers and text body
+  ahasend messages content abcd1234-5678-90ef-abcd-1234567890ab --output plain
+
+  # Print the HTML part
+  ahasend messages content abcd1234-5678-90ef-abcd-1234567890ab --output plain --part html > message.html
+
+  # Save the raw message and show its parts
+  ahasend messages content abcd1234-5678-90ef-abcd-1234567890ab --save message.eml`,
+		Args:         cobra.ExactArgs(1),
+		RunE:         runMessagesContent,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().String("part", printer.MessagePartText, "Body part to show: text, html, amp or raw")
+	cmd.Flags().String("save", "", "File to write the raw RFC 822 message to")
+
+	return cmd
+}
+
+func runMessagesContent(cmd *cobra.Command, args []string) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+
+	messageID := args[0]
+	part, _ := cmd.Flags().GetString("part")
+	savePath, _ := cmd.Flags().GetString("save")
+
+	part = strings.ToLower(strings.TrimSpace(part))
+	if _, ok := contentPartTypes[part]; !ok && part != printer.MessagePartRaw {
+		return errors.NewValidationError(fmt.Sprintf("invalid --part %q (must be one of: text, html, amp, raw)", part), nil)
+	}
+
+	apiClient, err := auth.GetAuthenticatedClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	logger.Get().WithFields(map[string]interface{}{
+		"message_id": messageID,
+		"part":       part,
+		"save":       savePath,
+	}).Debug("Executing messages content command")
+
+	message, err := apiClient.GetMessage(messageID)
+	if err != nil {
+		return err
+	}
+	if message == nil {
+		return errors.NewNotFoundError(fmt.Sprintf("message '%s' not found", messageID), nil)
+	}
+	if err := checkContentStored(messageID, message, time.Now()); err != nil {
+		return err
+	}
+
+	content, err := buildMessageContent(messageID, message, part, cmd.Flags().Changed("part"))
+	if err != nil {
+		return err
+	}
+
+	if savePath != "" {
+		if message.Content == nil || *message.Content == "" {
+			return errors.NewNotFoundError(fmt.Sprintf("the raw message of '%s' is not stored, only its parsed parts; use --part to print them", messageID), nil)
+		}
+		if err := os.WriteFile(savePath, []byte(*message.Content), 0644); err != nil {
+			return errors.NewFileError("failed to save message to "+savePath, err)
+		}
+		content.SavedTo = savePath
+		// Reported on stderr so structured stdout stays parseable
+		fmt.Fprintf(cmd.ErrOrStderr(), "Saved raw message (%s) to %s\n", bytesize.Format(content.RawSize), savePath)
+	}
+
+	return handler.HandleMessageContent(content, printer.SingleConfig{
+		EmptyMessage: fmt.Sprintf("No content stored for message '%s'", messageID),
+	})
+}
+
+// checkContentStored fails with a content purged error when the message's
+// content is no longer stored
+func checkContentStored(messageID string, message *responses.Message, now time.Time) error {
+	if !message.RetainUntil.IsZero() && now.After(message.RetainUntil) {
+		return errors.NewContentPurgedError(fmt.Sprintf(
+			"the content of message '%s' was deleted when its retention period ended on %s; its metadata is still available with 'ahasend messages get %s'",
+			messageID, message.RetainUntil.Local().Format("2006-01-02 15:04:05"), messageID), nil)
+	}
+	if (message.Content == nil || *message.Content == "") && message.ContentParsed == nil {
+		return errors.NewContentPurgedError(fmt.Sprintf(
+			"no content is stored for message '%s'; it was deleted or never kept under the account's data retention settings (see 'ahasend account get')",
+			messageID), nil)
+	}
+	return nil
+}
+
+// buildMessageContent collects the headers, the selected part and the sizes
+// of the parts and attachments. Without an explicit --part, a message with
+// no text part shows its HTML part, or the raw message when nothing is
+// parsed.
+func buildMessageContent(messageID string, message *responses.Message, part string, explicit bool) (*printer.MessageContent, error) {
+	content := &printer.MessageContent{
+		MessageID: messageID,
+		Subject:   message.Subject,
+		Part:      part,
+	}
+	raw := ""
+	if message.Content != nil {
+		raw = *message.Content
+		content.RawSize = len(raw)
+	}
+
+	headers := map[string][]string{}
+	if message.ContentParsed != nil {
+		headers = message.ContentParsed.Headers
+		for _, p := range message.ContentParsed.Parts {
+			content.Parts = append(content.Parts, printer.MessageContentPart{
+				Part:        partName(p.ContentType),
+				ContentType: p.ContentType,
+				Size:        len(p.Content),
+			})
+		}
+		for _, attachment := range message.ContentParsed.Attachments {
+			content.Attachments = append(content.Attachments, printer.MessageContentAttachment{
+				FileName:    attachment.Filename,
+				ContentType: attachment.ContentType,
+				ContentID:   attachment.ContentID,
+				Size:        decodedSize(attachment.Content),
+			})
+		}
+	} else if parsed, err := mail.ReadMessage(strings.NewReader(raw)); err == nil {
+		headers = parsed.Header
+	}
+	content.Headers = orderHeaders(headers)
+
+	if part == printer.MessagePartRaw {
+		if raw == "" {
+			return nil, errors.NewNotFoundError(fmt.Sprintf("the raw message of '%s' is not stored, only its parsed parts; use --part text or html", messageID), nil)
+		}
+		content.Body = raw
+		return content, nil
+	}
+
+	candidates := []string{part}
+	if !explicit {
+		candidates = []string{printer.MessagePartText, printer.MessagePartHTML, printer.MessagePartAMP}
+	}
+	for _, candidate := range candidates {
+		if body, ok := findPart(message.ContentParsed, candidate); ok {
+			content.Part, content.Body = candidate, body
+			return content, nil
+		}
+	}
+	if !explicit && raw != "" {
+		content.Part, content.Body = printer.MessagePartRaw, raw
+		return content, nil
+	}
+
+	var available []string
+	for _, p := range content.Parts {
+		available = append(available, p.ContentType)
+	}
+	if len(available) == 0 {
+		available = []string{"none"}
+	}
+	return nil, errors.NewNotFoundError(fmt.Sprintf("message '%s' has no %s part (parts: %s)", messageID, part, strings.Join(available, ", ")), nil)
+}
+
+// findPart returns the content of the first part of the given kind
+func findPart(parsed *responses.ContentParsed, part string) (string, bool) {
+	if parsed == nil {
+		return "", false
+	}
+	for _, p := range parsed.Parts {
+		if partName(p.ContentType) == part {
+			return p.Content, true
+		}
+	}
+	return "", false
+}
+
+// partName returns the --part name of a content type, or "" for other types
+func partName(contentType string) string {
+	mediaType := strings.ToLower(strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0]))
+	for name, partType := range contentPartTypes {
+		if mediaType == partType {
+			return name
+		}
+	}
+	return ""
+}
+
+// orderHeaders flattens headers into lines, leadingHeaders first
+func orderHeaders(headers map[string][]string) []printer.MessageHeader {
+	canonical := make(map[string][]string, len(headers))
+	for name, values := range headers {
+		key := textproto.CanonicalMIMEHeaderKey(name)
+		canonical[key] = append(canonical[key], values...)
+	}
+
+	var names []string
+	leading := make(map[string]bool, len(leadingHeaders))
+	for _, name := range leadingHeaders {
+		leading[name] = true
+		if _, ok := canonical[name]; ok {
+			names = append(names, name)
+		}
+	}
+	var rest []string
+	for name := range canonical {
+		if !leading[name] {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+	names = append(names, rest...)
+
+	var lines []printer.MessageHeader
+	for _, name := range names {
+		for _, value := range canonical[name] {
+			lines = append(lines, printer.MessageHeader{Name: name, Value: value})
+		}
+	}
+	return lines
+}
+
+// decodedSize returns the size of base64 content once decoded
+func decodedSize(encoded string) int {
+	cleaned := strings.NewReplacer("\r", "", "\n", "", " ", "").Replace(encoded)
+	padding := len(cleaned) - len(strings.TrimRight(cleaned, "="))
+	return len(cleaned)*3/4 - padding
+}
@@ -0,0 +1,192 @@
+package messages
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+)
+
+const contentRaw = "From: Sender <sender@example.com>\r\nTo: user@example.com\r\nSubject: Welcome\r\n\r\nHello there\r\n"
+
+func contentMessage() *responses.Message {
+	raw := contentRaw
+	return &responses.Message{
+		ID:          uuid.New(),
+		CreatedAt:   time.Now().Add(-time.Hour),
+		RetainUntil: time.Now().Add(24 * time.Hour),
+		Recipient:   "user@example.com",
+		Subject:     "Welcome",
+		Direction:   "outbound",
+		Status:      "Delivered",
+		Content:     &raw,
+		ContentParsed: &responses.ContentParsed{
+			Headers: map[string][]string{
+				"x-campaign": {"spring"},
+				"subject":    {"Welcome"},
+				"from":       {"Sender <sender@example.com>"},
+				"to":         {"user@example.com"},
+			},
+			Parts: []responses.ContentPart{
+				{ContentType: "text/plain; charset=utf-8", Content: "Hello there"},
+				{ContentType: "text/html; charset=utf-8", Content: "<p>Hello there</p>"},
+			},
+			Attachments: []responses.ContentAttachment{
+				{Filename: "invoice.pdf", ContentType: "application/pdf", Content: "aGVsbG8gd29ybGQ="},
+			},
+		},
+	}
+}
+
+func executeContent(t *testing.T, mockClient *mocks.MockClient, format string, args ...string) (string, string, error) {
+	t.Helper()
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var stdout, stderr bytes.Buffer
+	cmd := NewContentCommand()
+	handler := printer.GetResponseHandler(format, false, &stdout)
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return stdout.String(), stderr.String(), err
+}
+
+func TestContentCommand_Plain(t *testing.T) {
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetMessage", "msg-1").Return(contentMessage(), nil)
+
+	out, _, err := executeContent(t, mockClient, "plain", "msg-1")
+	require.NoError(t, err)
+	assert.Equal(t, "From: Sender <sender@example.com>\nTo: user@example.com\nSubject: Welcome\nX-Campaign: spring\n\nHello there\n", out)
+
+	out, _, err = executeContent(t, mockClient, "plain", "msg-1", "--part", "html")
+	require.NoError(t, err)
+	assert.Contains(t, out, "\n\n<p>Hello there</p>\n")
+
+	out, _, err = executeContent(t, mockClient, "plain", "msg-1", "--part", "raw")
+	require.NoError(t, err)
+	assert.Equal(t, contentRaw, out, "raw output is the message as stored")
+}
+
+func TestContentCommand_PartFallback(t *testing.T) {
+	message := contentMessage()
+	message.ContentParsed.Parts = message.ContentParsed.Parts[1:]
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetMessage", "msg-1").Return(message, nil)
+
+	out, _, err := executeContent(t, mockClient, "plain", "msg-1")
+	require.NoError(t, err)
+	assert.Contains(t, out, "<p>Hello there</p>", "the default part falls back to HTML")
+
+	_, _, err = executeContent(t, mockClient, "plain", "msg-1", "--part", "text")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "has no text part (parts: text/html; charset=utf-8)")
+	assert.Equal(t, 5, errors.GetExitCode(err))
+}
+
+func TestContentCommand_Table(t *testing.T) {
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetMessage", "msg-1").Return(contentMessage(), nil)
+
+	out, _, err := executeContent(t, mockClient, "table", "msg-1")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Welcome")
+	assert.Contains(t, out, "text/html; charset=utf-8")
+	assert.Contains(t, out, "invoice.pdf")
+	assert.Contains(t, out, "11 B", "attachment sizes are decoded sizes")
+	assert.NotContains(t, out, "<p>Hello there</p>", "table output does not print the body")
+}
+
+func TestContentCommand_JSON(t *testing.T) {
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetMessage", "msg-1").Return(contentMessage(), nil)
+
+	out, _, err := executeContent(t, mockClient, "json", "msg-1")
+	require.NoError(t, err)
+
+	var content printer.MessageContent
+	require.NoError(t, json.Unmarshal([]byte(out), &content))
+	assert.Equal(t, printer.MessagePartText, content.Part)
+	assert.Equal(t, "Hello there", content.Body)
+	assert.Equal(t, len(contentRaw), content.RawSize)
+	require.Len(t, content.Parts, 2)
+	assert.Equal(t, printer.MessagePartHTML, content.Parts[1].Part)
+	require.Len(t, content.Attachments, 1)
+	assert.Equal(t, 11, content.Attachments[0].Size)
+}
+
+func TestContentCommand_Save(t *testing.T) {
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetMessage", "msg-1").Return(contentMessage(), nil)
+	path := filepath.Join(t.TempDir(), "message.eml")
+
+	out, stderr, err := executeContent(t, mockClient, "json", "msg-1", "--save", path)
+	require.NoError(t, err)
+	saved, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, contentRaw, string(saved))
+	assert.Contains(t, stderr, "Saved raw message")
+	assert.Contains(t, out, `"saved_to"`, "stdout stays parseable")
+
+	message := contentMessage()
+	message.Content = nil
+	mockClient = &mocks.MockClient{}
+	mockClient.On("GetMessage", "msg-1").Return(message, nil)
+	_, _, err = executeContent(t, mockClient, "json", "msg-1", "--save", filepath.Join(t.TempDir(), "other.eml"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "raw message of 'msg-1' is not stored")
+}
+
+func TestContentCommand_Purged(t *testing.T) {
+	t.Run("past retention", func(t *testing.T) {
+		message := contentMessage()
+		message.RetainUntil = time.Now().Add(-time.Hour)
+		mockClient := &mocks.MockClient{}
+		mockClient.On("GetMessage", "msg-1").Return(message, nil)
+
+		_, _, err := executeContent(t, mockClient, "plain", "msg-1")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "retention period ended")
+		assert.Equal(t, 11, errors.GetExitCode(err))
+	})
+
+	t.Run("nothing stored", func(t *testing.T) {
+		message := contentMessage()
+		message.Content, message.ContentParsed = nil, nil
+		mockClient := &mocks.MockClient{}
+		mockClient.On("GetMessage", "msg-1").Return(message, nil)
+
+		_, _, err := executeContent(t, mockClient, "plain", "msg-1")
+		require.Error(t, err)
+		assert.Equal(t, 11, errors.GetExitCode(err))
+	})
+}
+
+func TestContentCommand_InvalidPart(t *testing.T) {
+	mockClient := &mocks.MockClient{}
+
+	_, _, err := executeContent(t, mockClient, "plain", "msg-1", "--part", "pdf")
+	require.Error(t, err)
+	assert.Equal(t, 4, errors.GetExitCode(err))
+	mockClient.AssertNotCalled(t, "GetMessage", "msg-1")
+}
@@ -0,0 +1,80 @@
+package messages
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AhaSend/ahasend-cli/internal/config"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/validation"
+)
+
+// applyDomainDefaults applies the send defaults configured for the sender's
+// domain beneath the command line, and reports the settings it applied on
+// stderr. It runs once the sender is resolved.
+func applyDomainDefaults(cmd *cobra.Command, flags *SendFlags) error {
+	_, domain, found := strings.Cut(flags.FromEmail, "@")
+	if !found {
+		return nil
+	}
+	// Defaults are keyed by the punycode form, whichever form the sender uses
+	domain, err := config.NormalizeDomain(domain)
+	if err != nil {
+		return nil
+	}
+
+	configMgr, err := config.NewManager()
+	if err != nil {
+		return errors.NewConfigError("failed to load the domain send defaults", err)
+	}
+	if err := configMgr.Load(); err != nil {
+		return errors.NewConfigError("failed to load the domain send defaults", err)
+	}
+	defaults, ok := configMgr.GetDomainDefault(domain)
+	if !ok {
+		return nil
+	}
+
+	applied := mergeDomainDefault(flags, defaults, cmd.Flags().Changed)
+	flags.DomainDefaults = applied
+	logger.Get().WithFields(map[string]interface{}{
+		"domain":  domain,
+		"applied": applied,
+	}).Debug("Applied domain send defaults")
+	if len(applied) > 0 {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Applying send defaults for %s: %s\n", validation.FormatDomainForDisplay(domain), strings.Join(applied, " "))
+	}
+	return nil
+}
+
+// mergeDomainDefault sets the send flags a domain default covers, except
+// those given explicitly on the command line, and returns the settings it
+// applied. Default tags come before tags added otherwise, such as the
+// --to-me test tag; --tags replaces them.
+func mergeDomainDefault(flags *SendFlags, defaults config.DomainDefault, explicit func(flag string) bool) []string {
+	var applied []string
+	if defaults.TrackOpens != nil && !explicit("track-opens") {
+		flags.TrackOpens = *defaults.TrackOpens
+		applied = append(applied, "track_opens="+strconv.FormatBool(flags.TrackOpens))
+	}
+	if defaults.TrackClicks != nil && !explicit("track-clicks") {
+		flags.TrackClicks = *defaults.TrackClicks
+		applied = append(applied, "track_clicks="+strconv.FormatBool(flags.TrackClicks))
+	}
+	if len(defaults.Tags) > 0 && !explicit("tags") {
+		tags := append([]string{}, defaults.Tags...)
+		for _, tag := range flags.Tags {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+		flags.Tags = tags
+		applied = append(applied, "tags="+strings.Join(defaults.Tags, ","))
+	}
+	return applied
+}
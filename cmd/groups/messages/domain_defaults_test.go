@@ -0,0 +1,119 @@
+package messages
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/config"
+)
+
+func explicitFlags(names ...string) func(string) bool {
+	return func(flag string) bool {
+		for _, name := range names {
+			if name == flag {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func TestMergeDomainDefault(t *testing.T) {
+	off, on := false, true
+	transactional := config.DomainDefault{TrackOpens: &off, TrackClicks: &off, Tags: []string{"transactional"}}
+
+	t.Run("defaults fill in unset flags", func(t *testing.T) {
+		flags := &SendFlags{TrackOpens: true, TrackClicks: true}
+		applied := mergeDomainDefault(flags, transactional, explicitFlags())
+		assert.False(t, flags.TrackOpens)
+		assert.False(t, flags.TrackClicks)
+		assert.Equal(t, []string{"transactional"}, flags.Tags)
+		assert.Equal(t, []string{"track_opens=false", "track_clicks=false", "tags=transactional"}, applied)
+	})
+
+	t.Run("explicit flags win", func(t *testing.T) {
+		flags := &SendFlags{TrackOpens: true, TrackClicks: false, Tags: []string{"launch"}}
+		applied := mergeDomainDefault(flags, transactional, explicitFlags("track-opens", "tags"))
+		assert.True(t, flags.TrackOpens, "--track-opens given explicitly")
+		assert.False(t, flags.TrackClicks)
+		assert.Equal(t, []string{"launch"}, flags.Tags, "--tags replaces the default tags")
+		assert.Equal(t, []string{"track_clicks=false"}, applied)
+	})
+
+	t.Run("explicitly set to the flag default still wins", func(t *testing.T) {
+		flags := &SendFlags{TrackOpens: true, TrackClicks: true}
+		mergeDomainDefault(flags, transactional, explicitFlags("track-opens", "track-clicks"))
+		assert.True(t, flags.TrackOpens)
+		assert.True(t, flags.TrackClicks)
+	})
+
+	t.Run("default tags come before added tags", func(t *testing.T) {
+		flags := &SendFlags{Tags: []string{"test", "campaign"}}
+		mergeDomainDefault(flags, config.DomainDefault{Tags: []string{"campaign", "q3"}, TrackOpens: &on}, explicitFlags())
+		assert.Equal(t, []string{"campaign", "q3", "test"}, flags.Tags)
+		assert.True(t, flags.TrackOpens)
+	})
+
+	t.Run("empty default changes nothing", func(t *testing.T) {
+		flags := &SendFlags{TrackOpens: true, TrackClicks: true, Tags: []string{"x"}}
+		assert.Empty(t, mergeDomainDefault(flags, config.DomainDefault{}, explicitFlags()))
+		assert.Equal(t, &SendFlags{TrackOpens: true, TrackClicks: true, Tags: []string{"x"}}, flags)
+	})
+}
+
+func TestApplyDomainDefaults(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	configMgr, err := config.NewManager()
+	require.NoError(t, err)
+	require.NoError(t, configMgr.Load())
+	require.NoError(t, configMgr.SetDomainDefault("mail.acme.com", map[string]string{"track_opens": "false", "tags": "transactional"}))
+
+	newCommand := func(args ...string) (*cobra.Command, *bytes.Buffer) {
+		var stderr bytes.Buffer
+		cmd := NewSendCommand()
+		cmd.SetErr(&stderr)
+		require.NoError(t, cmd.ParseFlags(args))
+		return cmd, &stderr
+	}
+
+	t.Run("sender domain with defaults", func(t *testing.T) {
+		cmd, stderr := newCommand("--track-clicks=false")
+		flags := parseSendFlags(cmd)
+		flags.FromEmail = "receipts@Mail.Acme.com"
+
+		require.NoError(t, applyDomainDefaults(cmd, flags))
+		assert.False(t, flags.TrackOpens)
+		assert.False(t, flags.TrackClicks)
+		assert.Equal(t, []string{"transactional"}, flags.Tags)
+		assert.Equal(t, "Applying send defaults for mail.acme.com: track_opens=false tags=transactional\n", stderr.String())
+	})
+
+	t.Run("punycode sender matches a Unicode domain", func(t *testing.T) {
+		require.NoError(t, configMgr.SetDomainDefault("bücher.example", map[string]string{"tags": "de"}))
+		cmd, stderr := newCommand()
+		flags := parseSendFlags(cmd)
+		flags.FromEmail = "shop@xn--bcher-kva.example"
+
+		require.NoError(t, applyDomainDefaults(cmd, flags))
+		assert.Equal(t, []string{"de"}, flags.Tags)
+		assert.Equal(t, "Applying send defaults for bücher.example (xn--bcher-kva.example): tags=de\n", stderr.String())
+	})
+
+	t.Run("unknown domains are left alone", func(t *testing.T) {
+		for _, from := range []string{"news@acme.com", "ops@sub.mail.acme.com", "not-an-address"} {
+			cmd, stderr := newCommand()
+			flags := parseSendFlags(cmd)
+			flags.FromEmail = from
+
+			require.NoError(t, applyDomainDefaults(cmd, flags))
+			assert.True(t, flags.TrackOpens, from)
+			assert.True(t, flags.TrackClicks, from)
+			assert.Empty(t, flags.Tags, from)
+			assert.Empty(t, stderr.String(), from)
+		}
+	})
+}
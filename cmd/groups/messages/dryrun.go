@@ -0,0 +1,84 @@
+package messages
+
+import (
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/batch"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+)
+
+// ampMIMEType is the MIME type of AMP content
+const ampMIMEType = "text/x-amp-html"
+
+// dryRunMessage describes what the send jobs would send. The subject and
+// content sizes are those of the first recipient, rendered like the content
+// size check does.
+func dryRunMessage(flags *SendFlags, jobs []*batch.SendJob) *printer.DryRunMessage {
+	dryRun := &printer.DryRunMessage{
+		From:           flags.FromEmail,
+		Recipients:     countRecipients(jobs),
+		Cc:             flags.CcEmails,
+		Bcc:            flags.BccEmails,
+		TotalSize:      flags.ContentSize.Total(),
+		TrackOpens:     flags.TrackOpens,
+		TrackClicks:    flags.TrackClicks,
+		Tags:           flags.Tags,
+		DomainDefaults: flags.DomainDefaults,
+		Metadata:       flags.Metadata,
+		SubjectField:   flags.SubjectField,
+		Subjects:       flags.Subjects,
+		Sandbox:        flags.Sandbox,
+	}
+	if flags.ScheduleTime != "" {
+		if scheduled, err := time.Parse(time.RFC3339, flags.ScheduleTime); err == nil {
+			dryRun.Schedule = &scheduled
+		}
+	}
+	for _, bucket := range flags.ScheduleBuckets {
+		dryRun.ScheduleBuckets = append(dryRun.ScheduleBuckets, printer.DryRunScheduleBucket{SendAt: bucket.SendAt, Recipients: bucket.Recipients})
+	}
+	for _, job := range jobs {
+		dryRun.Batches = append(dryRun.Batches, printer.DryRunBatch{
+			IdempotencyKey: job.IdempotencyKey,
+			Recipients:     job.RecipientCount,
+			Request:        job.Request,
+		})
+	}
+	if len(jobs) == 0 || len(jobs[0].Recipients) == 0 {
+		return dryRun
+	}
+
+	request := jobs[0].Request
+	recipient := jobs[0].Recipients[0]
+	dryRun.FirstRecipient = recipient.Email
+	dryRun.Subject = renderSubstitutions(request.Subject, mergeSubstitutions(request.Substitutions, recipient.Substitutions, nil))
+	if request.SandboxResult != nil {
+		dryRun.SandboxResult = *request.SandboxResult
+	}
+
+	for _, part := range []struct {
+		present  bool
+		mimeType string
+		template string
+		given    string // the content given with flag
+		flag     string
+		size     int
+	}{
+		{request.HtmlContent != nil, "text/html", flags.HtmlTemplate, flags.HtmlContent, "--html", flags.ContentSize.HTML},
+		{request.TextContent != nil, "text/plain", flags.TextTemplate, flags.TextContent, "--text", flags.ContentSize.Text},
+		{request.AmpContent != nil, ampMIMEType, flags.AmpTemplate, flags.AmpContent, "--amp", flags.ContentSize.AMP},
+	} {
+		if !part.present {
+			continue
+		}
+		source := "prompt"
+		if part.template != "" {
+			source = part.template
+		} else if part.given != "" {
+			source = part.flag
+		}
+		dryRun.Content = append(dryRun.Content, printer.DryRunContent{Type: part.mimeType, Source: source, Size: part.size})
+	}
+	dryRun.Attachments = printer.SummarizeAttachments(request.Attachments)
+	return dryRun
+}
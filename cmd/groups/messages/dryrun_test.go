@@ -0,0 +1,207 @@
+package messages
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+)
+
+// executeDryRun runs 'messages send --dry-run', failing the test if the
+// command asks for an API client
+func executeDryRun(t *testing.T, format string, args ...string) (string, string, error) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		t.Fatal("a dry run must not need an API client")
+		return nil, nil
+	})
+	t.Cleanup(restore)
+
+	var stdout, stderr bytes.Buffer
+	cmd := NewSendCommand()
+	handler := printer.GetResponseHandler(format, false, &stdout)
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs(append([]string{"--dry-run"}, args...))
+	err := cmd.Execute()
+	return stdout.String(), stderr.String(), err
+}
+
+// dryRunFiles writes a recipients file, an attachment and an inline image
+func dryRunFiles(t *testing.T) (recipients, attachment, image string) {
+	t.Helper()
+	dir := t.TempDir()
+	recipients = filepath.Join(dir, "recipients.csv")
+	require.NoError(t, os.WriteFile(recipients, []byte("email,first_name\nann@example.com,Ann\nbob@example.com,Bob\n"), 0600))
+	attachment = filepath.Join(dir, "invoice.txt")
+	require.NoError(t, os.WriteFile(attachment, []byte("invoice 42"), 0600))
+	image = filepath.Join(dir, "logo.png")
+	require.NoError(t, os.WriteFile(image, []byte("\x89PNG\r\n\x1a\nlogo"), 0600))
+	return recipients, attachment, image
+}
+
+func TestSendDryRun_JSON(t *testing.T) {
+	recipients, attachment, image := dryRunFiles(t)
+
+	stdout, _, err := executeDryRun(t, "json",
+		"--from", "news@example.com", "--recipients", recipients,
+		"--subject", "Hi {{first_name}}", "--html", `<p>Hi {{first_name}}</p><img src="cid:logo">`,
+		"--attach", attachment, "--inline", image+":logo", "--idempotency-key", "campaign-1",
+		"--sandbox", "--sandbox-result", "bounce", "--track-clicks=false", "--meta", "campaign=spring")
+	require.NoError(t, err)
+
+	var output struct {
+		Object         string                     `json:"object"`
+		Recipients     int                        `json:"recipients"`
+		FirstRecipient string                     `json:"first_recipient"`
+		Subject        string                     `json:"subject"`
+		Content        []printer.DryRunContent    `json:"content"`
+		Attachments    []printer.DryRunAttachment `json:"attachments"`
+		TrackClicks    bool                       `json:"track_clicks"`
+		Sandbox        bool                       `json:"sandbox"`
+		SandboxResult  string                     `json:"sandbox_result"`
+		Metadata       map[string]string          `json:"metadata"`
+		Batches        []struct {
+			IdempotencyKey string                 `json:"idempotency_key"`
+			Recipients     int                    `json:"recipients"`
+			Request        map[string]interface{} `json:"request"`
+		} `json:"batches"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &output))
+
+	assert.Equal(t, "message_dry_run", output.Object)
+	assert.Equal(t, 2, output.Recipients)
+	assert.Equal(t, "ann@example.com", output.FirstRecipient)
+	assert.Equal(t, "Hi Ann", output.Subject)
+	assert.Equal(t, []printer.DryRunContent{{Type: "text/html", Source: "--html", Size: len(`<p>Hi Ann</p><img src="cid:logo">`)}}, output.Content)
+	assert.False(t, output.TrackClicks)
+	assert.True(t, output.Sandbox)
+	assert.Equal(t, "bounce", output.SandboxResult)
+	assert.Equal(t, map[string]string{"campaign": "spring"}, output.Metadata)
+
+	require.Len(t, output.Attachments, 2)
+	assert.Equal(t, printer.DryRunAttachment{FileName: "invoice.txt", ContentType: "text/plain; charset=utf-8", Size: 10}, output.Attachments[0])
+	assert.Equal(t, "logo", output.Attachments[1].ContentID)
+	assert.True(t, output.Attachments[1].Inline)
+
+	require.Len(t, output.Batches, 1)
+	batch := output.Batches[0]
+	assert.Equal(t, "campaign-1-batch-0", batch.IdempotencyKey)
+	assert.Equal(t, 2, batch.Recipients)
+	assert.Equal(t, "Hi {{first_name}}", batch.Request["subject"], "the request is sent unrendered")
+	assert.Len(t, batch.Request["recipients"], 2)
+
+	attachments := batch.Request["attachments"].([]interface{})
+	require.Len(t, attachments, 2)
+	for _, item := range attachments {
+		sent := item.(map[string]interface{})
+		assert.NotContains(t, sent, "data")
+		assert.Contains(t, sent, "data_bytes")
+	}
+	assert.EqualValues(t, 10, attachments[0].(map[string]interface{})["data_bytes"])
+}
+
+func TestSendDryRun_Plain(t *testing.T) {
+	recipients, attachment, image := dryRunFiles(t)
+
+	stdout, _, err := executeDryRun(t, "plain",
+		"--from", "news@example.com", "--recipients", recipients,
+		"--subject", "Hi {{first_name}}", "--html", `<img src="cid:logo">`, "--text", "Hi",
+		"--attach", attachment, "--inline", image+":logo", "--schedule", "2099-01-01T09:00:00Z",
+		"--meta", "order_id=42", "--meta", "campaign=spring")
+	require.NoError(t, err)
+
+	assert.Contains(t, stdout, "Dry run: the message was checked and not sent")
+	assert.Contains(t, stdout, "Recipients: 2\n")
+	assert.Contains(t, stdout, "Subject: Hi Ann (for ann@example.com)\n")
+	assert.Contains(t, stdout, "Content: text/html 20 B (--html), text/plain 2 B (--text)\n")
+	assert.Contains(t, stdout, "Attachments: invoice.txt (text/plain; charset=utf-8, 10 B)\n")
+	assert.Contains(t, stdout, "Inline Attachments: logo.png as cid:logo (image/png, 12 B)\n")
+	assert.Contains(t, stdout, "Metadata: campaign=spring, order_id=42\n")
+	assert.NotContains(t, stdout, "Schedule: Immediately")
+	assert.Regexp(t, `Batch 1: 2 recipients, idempotency key cli-\d+-[0-9a-f]+-batch-0`, stdout)
+}
+
+func TestSendDryRun_ValidationFails(t *testing.T) {
+	for name, args := range map[string][]string{
+		"schedule":       {"--to", "ann@example.com", "--subject", "Hi", "--text", "Hi", "--schedule", "tomorrow"},
+		"attachment":     {"--to", "ann@example.com", "--subject", "Hi", "--text", "Hi", "--attach", "missing.pdf"},
+		"recipients":     {"--recipients", "missing.csv", "--subject", "Hi", "--text", "Hi"},
+		"sandbox result": {"--to", "ann@example.com", "--subject", "Hi", "--text", "Hi", "--sandbox-result", "bounce"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			stdout, _, err := executeDryRun(t, "plain", append([]string{"--from", "news@example.com"}, args...)...)
+			require.Error(t, err)
+			assert.Empty(t, stdout)
+		})
+	}
+}
+
+func TestSendDryRun_ScheduleBuckets(t *testing.T) {
+	pinScheduleNow(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+	recipients := writeRecipientsFile(t, "recipients.csv",
+		"email,send_at\n"+
+			"a@example.com,2026-03-02T09:01:00Z\n"+
+			"b@example.com,\n"+
+			"c@example.com,2026-03-02T09:05:00Z\n")
+
+	stdout, _, err := executeDryRun(t, "json",
+		"--from", "news@example.com", "--recipients", recipients, "--subject", "Hi", "--text", "Hi")
+	require.NoError(t, err)
+
+	var output struct {
+		ScheduleBuckets []printer.DryRunScheduleBucket `json:"schedule_buckets"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &output))
+	require.Len(t, output.ScheduleBuckets, 2)
+	assert.Nil(t, output.ScheduleBuckets[0].SendAt)
+	assert.Equal(t, 1, output.ScheduleBuckets[0].Recipients)
+	assert.Equal(t, time.Date(2026, 3, 2, 9, 15, 0, 0, time.UTC), output.ScheduleBuckets[1].SendAt.UTC())
+	assert.Equal(t, 2, output.ScheduleBuckets[1].Recipients)
+
+	stdout, _, err = executeDryRun(t, "csv",
+		"--from", "news@example.com", "--recipients", recipients, "--subject", "Hi", "--text", "Hi")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "batch,recipients,send_at,subject,idempotency_key\n")
+	assert.Regexp(t, `1,1,,Hi,cli-\S+-batch-0\n2,2,2026-03-02T09:15:00Z,Hi,cli-\S+-batch-1\n`, stdout)
+}
+
+func TestSendDryRun_SubjectField(t *testing.T) {
+	recipients := writeRecipientsFile(t, "recipients.csv",
+		"email,first_name,subject_line\n"+
+			"a@example.com,Ann,Hello {{first_name}}\n"+
+			"b@example.com,Bob,News\n"+
+			"c@example.com,Cid,News\n")
+
+	stdout, _, err := executeDryRun(t, "plain",
+		"--from", "news@example.com", "--recipients", recipients, "--subject-from-field", "subject_line", "--text", "Hi")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "Subject: Hello Ann (for a@example.com)\n")
+	assert.Contains(t, stdout, "Subjects: from subject_line (2 distinct subjects in 2 batches)\n")
+	assert.Regexp(t, `Batch 1: 1 recipients, subject "Hello \{\{first_name\}\}", idempotency key cli-\S+-batch-0\n`, stdout)
+	assert.Regexp(t, `Batch 2: 2 recipients, subject "News", idempotency key cli-\S+-batch-1\n`, stdout)
+
+	stdout, _, err = executeDryRun(t, "json",
+		"--from", "news@example.com", "--recipients", recipients, "--subject-from-field", "subject_line", "--text", "Hi")
+	require.NoError(t, err)
+	var output struct {
+		SubjectField string `json:"subject_field"`
+		Subjects     int    `json:"subjects"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &output))
+	assert.Equal(t, "subject_line", output.SubjectField)
+	assert.Equal(t, 2, output.Subjects)
+}
@@ -0,0 +1,152 @@
+package messages
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AhaSend/ahasend-cli/internal/batch"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/state"
+)
+
+// defaultDuplicateWindow is how long a send is remembered to catch it being
+// repeated by accident
+const defaultDuplicateWindow = 24 * time.Hour
+
+// duplicateProfile returns the profile name recent sends are recorded under
+func duplicateProfile(cmd *cobra.Command) string {
+	_, profileName, err := loadSelectedProfile(cmd)
+	if err != nil {
+		logger.Get().WithError(err).Debug("Failed to load profile for the duplicate send check")
+	}
+	if profileName == "" {
+		profileName = "default"
+	}
+	return profileName
+}
+
+// sendFingerprint hashes the sender, the content and the recipients of a
+// send. Each recipient is hashed with its subject as the jobs are walked, and
+// the digests are added together, so the fingerprint does not depend on the
+// order of the recipients and no sorted copy of the list is kept.
+func sendFingerprint(jobs []*batch.SendJob) string {
+	if len(jobs) == 0 {
+		return ""
+	}
+	request := jobs[0].Request
+
+	h := sha256.New()
+	writeHashedField(h, "from", strings.ToLower(request.From.Email))
+	writeHashedField(h, "text", stringValue(request.TextContent))
+	writeHashedField(h, "html", stringValue(request.HtmlContent))
+	writeHashedField(h, "amp", stringValue(request.AmpContent))
+	for _, attachment := range request.Attachments {
+		writeHashedField(h, "attachment", attachment.FileName+"\x00"+attachment.Data)
+	}
+
+	var sum [4]uint64
+	var count uint64
+	for _, job := range jobs {
+		for _, recipient := range job.Recipients {
+			digest := sha256.Sum256([]byte(strings.ToLower(recipient.Email) + "\x00" + job.Request.Subject))
+			for i := range sum {
+				sum[i] += binary.BigEndian.Uint64(digest[i*8:])
+			}
+			count++
+		}
+	}
+	binary.Write(h, binary.BigEndian, sum)
+	binary.Write(h, binary.BigEndian, count)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// writeHashedField adds the hash of a named value to h
+func writeHashedField(h hash.Hash, name, value string) {
+	fmt.Fprintf(h, "%s %x\n", name, sha256.Sum256([]byte(value)))
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// checkDuplicateSend asks for confirmation when the same message was sent
+// from the profile to the same recipients within --duplicate-window. Without
+// a terminal the send is refused unless --allow-duplicate is set. Sandbox,
+// --to-me and resumed sends are not checked. It returns the fingerprint to record once
+// the send goes out, or "" when the send is not checked.
+func checkDuplicateSend(flags *SendFlags, jobs []*batch.SendJob) (string, error) {
+	if flags.Sandbox || flags.ToMe || flags.Resume || flags.DuplicateWindow <= 0 {
+		return "", nil
+	}
+	fingerprint := sendFingerprint(jobs)
+	if fingerprint == "" || flags.AllowDuplicate {
+		return fingerprint, nil
+	}
+
+	previous, err := state.FindSendFingerprint(flags.Profile, fingerprint, time.Now().Add(-flags.DuplicateWindow))
+	if err != nil {
+		logger.Get().WithError(err).Warn("Failed to read recent sends, skipping the duplicate send check")
+		return fingerprint, nil
+	}
+	if previous == nil {
+		return fingerprint, nil
+	}
+
+	description := fmt.Sprintf("the same message was sent to the same %d recipients from profile '%s' %s ago, at %s",
+		previous.Recipients, flags.Profile, time.Since(previous.SentAt).Round(time.Minute), previous.SentAt.Local().Format("2006-01-02 15:04"))
+	if !stdinIsTerminal() {
+		return "", errors.NewValidationError(fmt.Sprintf(
+			"refusing a duplicate send: %s; re-run with --allow-duplicate to send it again", description), nil)
+	}
+
+	fmt.Fprintf(confirmOutput, "⚠️  This looks like a duplicate send: %s.\n", description)
+	if previous.Subject != "" {
+		fmt.Fprintf(confirmOutput, "  Subject: %s\n", previous.Subject)
+	}
+	fmt.Fprint(confirmOutput, "\nSend it again? (y/N): ")
+
+	reader := bufio.NewReader(confirmInput)
+	response, err := reader.ReadString('\n')
+	if err != nil && response == "" {
+		return "", errors.NewValidationError("send cancelled: failed to read confirmation", err)
+	}
+	response = strings.ToLower(strings.TrimSpace(response))
+	if response != "y" && response != "yes" {
+		return "", errors.NewValidationError("send cancelled", nil)
+	}
+	return fingerprint, nil
+}
+
+// recordSend remembers a send for the duplicate send check. Failing to
+// record it does not fail the send.
+func recordSend(flags *SendFlags, fingerprint string, recipients int) {
+	if fingerprint == "" {
+		return
+	}
+	subject := flags.Subject
+	if flags.SubjectField != "" {
+		subject = formatSubjectSummary(flags.SubjectField, flags.Subjects, flags.Batches)
+	}
+	err := state.RecordSendFingerprint(flags.Profile, state.SendFingerprint{
+		Fingerprint: fingerprint,
+		Subject:     subject,
+		Recipients:  recipients,
+		SentAt:      time.Now().UTC(),
+	})
+	if err != nil {
+		logger.Get().WithError(err).Warn("Failed to record the send for the duplicate send check")
+	}
+}
@@ -0,0 +1,126 @@
+package messages
+
+import (
+	"testing"
+	"time"
+
+	ahasend "github.com/AhaSend/ahasend-go"
+	"github.com/AhaSend/ahasend-go/models/common"
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/batch"
+	"github.com/AhaSend/ahasend-cli/internal/state"
+)
+
+// fingerprintJobs splits emails into jobs of batchSize recipients sharing
+// one request
+func fingerprintJobs(html string, batchSize int, emails ...string) []*batch.SendJob {
+	request := &requests.CreateMessageRequest{
+		From:        common.SenderAddress{Email: "news@example.com"},
+		Subject:     "Weekly news",
+		HtmlContent: ahasend.String(html),
+	}
+	var jobs []*batch.SendJob
+	for i := 0; i < len(emails); i += batchSize {
+		var recipients []common.Recipient
+		for _, email := range emails[i:min(i+batchSize, len(emails))] {
+			recipients = append(recipients, common.Recipient{Email: email})
+		}
+		jobs = append(jobs, &batch.SendJob{Request: request, Recipients: recipients, RecipientCount: len(recipients)})
+	}
+	return jobs
+}
+
+func TestSendFingerprint_IgnoresRecipientOrder(t *testing.T) {
+	base := sendFingerprint(fingerprintJobs("<p>Hi</p>", 2, "a@example.com", "b@example.com", "c@example.com"))
+	require.Len(t, base, 64)
+
+	assert.Equal(t, base, sendFingerprint(fingerprintJobs("<p>Hi</p>", 2, "c@example.com", "a@example.com", "b@example.com")))
+	assert.Equal(t, base, sendFingerprint(fingerprintJobs("<p>Hi</p>", 1, "b@example.com", "C@example.com", "a@example.com")),
+		"batching and address case do not matter")
+
+	assert.NotEqual(t, base, sendFingerprint(fingerprintJobs("<p>Hello</p>", 2, "a@example.com", "b@example.com", "c@example.com")))
+	assert.NotEqual(t, base, sendFingerprint(fingerprintJobs("<p>Hi</p>", 2, "a@example.com", "b@example.com")))
+	assert.NotEqual(t, base, sendFingerprint(fingerprintJobs("<p>Hi</p>", 2, "a@example.com", "b@example.com", "c@example.com", "a@example.com")))
+	assert.Empty(t, sendFingerprint(nil))
+}
+
+func TestSendFingerprint_IncludesSubject(t *testing.T) {
+	jobs := fingerprintJobs("<p>Hi</p>", 1, "a@example.com")
+	base := sendFingerprint(jobs)
+
+	changed := *jobs[0].Request
+	changed.Subject = "Other news"
+	jobs[0].Request = &changed
+	assert.NotEqual(t, base, sendFingerprint(jobs))
+}
+
+func duplicateFlags() *SendFlags {
+	return &SendFlags{Subject: "Weekly news", DuplicateWindow: defaultDuplicateWindow, Profile: "default"}
+}
+
+func TestCheckDuplicateSend(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	jobs := fingerprintJobs("<p>Hi</p>", 100, "a@example.com", "b@example.com")
+
+	stubConfirmIO(t, false, "")
+	fingerprint, err := checkDuplicateSend(duplicateFlags(), jobs)
+	require.NoError(t, err, "a first send goes out")
+	require.NotEmpty(t, fingerprint)
+	recordSend(duplicateFlags(), fingerprint, 2)
+
+	_, err = checkDuplicateSend(duplicateFlags(), jobs)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "same 2 recipients from profile 'default'")
+	assert.Contains(t, err.Error(), "--allow-duplicate")
+
+	flags := duplicateFlags()
+	flags.AllowDuplicate = true
+	_, err = checkDuplicateSend(flags, jobs)
+	assert.NoError(t, err)
+
+	flags = duplicateFlags()
+	flags.Sandbox = true
+	fingerprint, err = checkDuplicateSend(flags, jobs)
+	assert.NoError(t, err)
+	assert.Empty(t, fingerprint, "sandbox sends are not checked or recorded")
+
+	flags = duplicateFlags()
+	flags.Profile = "staging"
+	_, err = checkDuplicateSend(flags, jobs)
+	assert.NoError(t, err, "sends are tracked per profile")
+
+	out := stubConfirmIO(t, true, "n\n")
+	_, err = checkDuplicateSend(duplicateFlags(), jobs)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "send cancelled")
+	assert.Contains(t, out.String(), "This looks like a duplicate send")
+	assert.Contains(t, out.String(), "Subject: Weekly news")
+
+	stubConfirmIO(t, true, "y\n")
+	_, err = checkDuplicateSend(duplicateFlags(), jobs)
+	assert.NoError(t, err)
+}
+
+func TestCheckDuplicateSend_OutsideWindow(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	stubConfirmIO(t, false, "")
+	jobs := fingerprintJobs("<p>Hi</p>", 100, "a@example.com")
+
+	require.NoError(t, state.RecordSendFingerprint("default", state.SendFingerprint{
+		Fingerprint: sendFingerprint(jobs),
+		Recipients:  1,
+		SentAt:      time.Now().Add(-2 * time.Hour),
+	}))
+
+	flags := duplicateFlags()
+	flags.DuplicateWindow = time.Hour
+	_, err := checkDuplicateSend(flags, jobs)
+	assert.NoError(t, err)
+
+	flags.DuplicateWindow = 3 * time.Hour
+	_, err = checkDuplicateSend(flags, jobs)
+	assert.Error(t, err)
+}
@@ -0,0 +1,297 @@
+package messages
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/output"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	ahasend "github.com/AhaSend/ahasend-go"
+	"github.com/AhaSend/ahasend-go/models/common"
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/spf13/cobra"
+)
+
+// exportPageSize is how many messages are listed per API call
+const exportPageSize = 100
+
+// NewExportCommand creates the export command
+func NewExportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export raw messages to .eml files",
+		Long: `Export the raw content of messages to .eml files, for archiving.
+
+Messages are selected with the same filters as 'messages list' and fetched
+page by page. Each message is written to --output-dir as <id>.eml, where <id>
+is its AhaSend message ID, in RFC 5322 format with CRLF line endings.
+
+Messages whose content is no longer stored, because their retention period
+has passed, are skipped with a warning. Existing files are skipped too,
+unless --overwrite is set, so an interrupted export can be run again to
+pick up where it stopped.
+
+A summary of exported and skipped messages and the total bytes written is
+printed at the end.
+
+Time values accept RFC3339, a date like 2024-06-01 (read in --timezone;
+--to-time covers the whole day) or relative durations like "24h" or "7d".`,
+		Example: `  # Archive last month's messages
+  ahasend messages export --from-time 2024-06-01 --to-time 2024-06-30 --output-dir archive/2024-06
+
+  # Export delivered messages to one recipient
+  ahasend messages export --recipient user@example.com --status delivered --output-dir user-mail
+
+  # Export at most 500 messages from the last day, replacing earlier exports
+  ahasend messages export --from-time 24h --limit 500 --output-dir today --overwrite
+
+  # Get the summary as JSON
+  ahasend messages export --on 2024-06-01 --output-dir archive --output json`,
+		Args:         cobra.NoArgs,
+		RunE:         runMessagesExport,
+		SilenceUsage: true,
+	}
+
+	// Filter parameters, as for messages list
+	cmd.Flags().String("sender", "", "Sender email address (must be from your domain)")
+	cmd.Flags().String("recipient", "", "Filter by recipient email address")
+	cmd.Flags().String("subject", "", "Filter by subject text (partial match)")
+	cmd.Flags().String("message-id", "", "Filter by message ID header")
+	cmd.Flags().StringSlice("status", []string{}, "Filter by message status (can be used multiple times)")
+	cmd.Flags().StringSlice("tags", []string{}, "Filter by tags (can be used multiple times)")
+	cmd.Flags().String("from-time", "", "Export messages created after this time (RFC3339, YYYY-MM-DD or relative like '24h', '7d')")
+	cmd.Flags().String("to-time", "", "Export messages created before this time (RFC3339, YYYY-MM-DD for the whole day, or relative)")
+	cmd.Flags().String("on", "", "Export a single day (YYYY-MM-DD); replaces --from-time and --to-time")
+	cmd.Flags().String("timezone", "", "Timezone for date-only values, e.g. 'Europe/Berlin' or 'UTC' (default: local)")
+
+	cmd.Flags().String("output-dir", "", "Directory to write the .eml files to (required)")
+	cmd.Flags().Int("limit", 0, "Maximum number of messages to export (default: all)")
+	cmd.Flags().Bool("overwrite", false, "Replace .eml files that already exist")
+	cmd.MarkFlagRequired("output-dir")
+
+	return cmd
+}
+
+func runMessagesExport(cmd *cobra.Command, args []string) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+
+	sender, _ := cmd.Flags().GetString("sender")
+	recipient, _ := cmd.Flags().GetString("recipient")
+	subject, _ := cmd.Flags().GetString("subject")
+	messageID, _ := cmd.Flags().GetString("message-id")
+	statuses, _ := cmd.Flags().GetStringSlice("status")
+	tags, _ := cmd.Flags().GetStringSlice("tags")
+	fromTimeStr, _ := cmd.Flags().GetString("from-time")
+	toTimeStr, _ := cmd.Flags().GetString("to-time")
+	on, _ := cmd.Flags().GetString("on")
+	timezone, _ := cmd.Flags().GetString("timezone")
+	outputDir, _ := cmd.Flags().GetString("output-dir")
+	limit, _ := cmd.Flags().GetInt("limit")
+	overwrite, _ := cmd.Flags().GetBool("overwrite")
+
+	if strings.TrimSpace(outputDir) == "" {
+		return errors.NewValidationError("--output-dir cannot be empty", nil)
+	}
+	if limit < 0 {
+		return errors.NewValidationError("limit cannot be negative", nil)
+	}
+	normalizedStatus, err := normalizeStatuses(statuses)
+	if err != nil {
+		return err
+	}
+	fromTime, toTime, err := output.ParseTimeRange(fromTimeStr, toTimeStr, on, timezone)
+	if err != nil {
+		return err
+	}
+
+	apiClient, err := auth.GetAuthenticatedClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return errors.NewFileError(fmt.Sprintf("failed to create output directory %s", outputDir), err)
+	}
+
+	logger.Get().WithFields(map[string]interface{}{
+		"sender":     sender,
+		"recipient":  recipient,
+		"subject":    subject,
+		"message_id": messageID,
+		"statuses":   statuses,
+		"tags":       tags,
+		"from_time":  fromTime,
+		"to_time":    toTime,
+		"output_dir": outputDir,
+		"limit":      limit,
+		"overwrite":  overwrite,
+	}).Debug("Exporting messages")
+
+	exporter := &messageExporter{
+		cmd:       cmd,
+		client:    apiClient,
+		handler:   handler,
+		overwrite: overwrite,
+		summary:   &printer.MessageExportSummary{OutputDir: outputDir},
+	}
+
+	params := requests.GetMessagesParams{
+		Status:          ahasend.String(normalizedStatus),
+		Tags:            tags,
+		Sender:          ahasend.String(sender),
+		Recipient:       ahasend.String(recipient),
+		Subject:         ahasend.String(subject),
+		MessageIDHeader: ahasend.String(messageID),
+		FromTime:        fromTime,
+		ToTime:          toTime,
+	}
+
+	seen := 0
+	var cursor *string
+	for limit == 0 || seen < limit {
+		pageSize := exportPageSize
+		if limit > 0 && limit-seen < pageSize {
+			pageSize = limit - seen
+		}
+		params.PaginationParams = common.PaginationParams{
+			Limit:  ahasend.Int32(int32(pageSize)),
+			Cursor: cursor,
+		}
+
+		page, err := apiClient.GetMessages(params)
+		if err != nil {
+			return err
+		}
+		if page == nil {
+			return errors.NewAPIError("received nil response from API", nil)
+		}
+
+		for _, message := range page.Data {
+			if limit > 0 && seen >= limit {
+				break
+			}
+			seen++
+			if err := exporter.export(message); err != nil {
+				return err
+			}
+		}
+
+		if !page.Pagination.HasMore || page.Pagination.NextCursor == nil || *page.Pagination.NextCursor == "" {
+			break
+		}
+		cursor = page.Pagination.NextCursor
+	}
+
+	return handler.HandleMessageExport(exporter.summary, printer.SimpleConfig{
+		SuccessMessage: "No messages exported",
+	})
+}
+
+// messageExporter writes listed messages to .eml files and keeps the tally
+type messageExporter struct {
+	cmd       *cobra.Command
+	client    client.AhaSendClient
+	handler   printer.ResponseHandler
+	overwrite bool
+	summary   *printer.MessageExportSummary
+}
+
+// export fetches the content of one listed message and writes it to
+// <output-dir>/<id>.eml, recording a skip when there is nothing to write
+func (e *messageExporter) export(listed responses.Message) error {
+	id := listed.ID.String()
+	path := filepath.Join(e.summary.OutputDir, id+".eml")
+
+	if !e.overwrite {
+		if _, err := os.Stat(path); err == nil {
+			e.skip(id, fmt.Sprintf("%s already exists (use --overwrite to replace it)", path))
+			return nil
+		}
+	}
+
+	message, err := e.client.GetMessage(id)
+	if err != nil {
+		return err
+	}
+	if message == nil || message.Content == nil || *message.Content == "" {
+		reason := "content is no longer stored"
+		if message != nil && !message.RetainUntil.IsZero() {
+			reason = fmt.Sprintf("content was purged after its retention period ended on %s", message.RetainUntil.Format("2006-01-02"))
+		}
+		e.skip(id, reason)
+		e.warn(fmt.Sprintf("Skipped message %s: %s", id, reason))
+		return nil
+	}
+
+	data := toCRLF(*message.Content)
+	if err := writeMessageFile(path, data, e.overwrite); err != nil {
+		return errors.NewFileError(fmt.Sprintf("failed to write %s", path), err)
+	}
+	e.summary.Exported++
+	e.summary.TotalBytes += int64(len(data))
+	return nil
+}
+
+func (e *messageExporter) skip(id, reason string) {
+	e.summary.Skipped++
+	e.summary.Skips = append(e.summary.Skips, printer.MessageExportSkip{ID: id, Reason: reason})
+}
+
+// warn reports a skipped message as it happens
+func (e *messageExporter) warn(warning string) {
+	printWarning(e.cmd, e.handler, warning)
+}
+
+// printWarning reports a warning about the command's output. JSON output
+// gets the warning in its warnings array; other formats, and output that was
+// already written when handler is nil, get a line on stderr unless --quiet
+// is set.
+func printWarning(cmd *cobra.Command, handler printer.ResponseHandler, warning string) {
+	if handler != nil {
+		if format := handler.GetFormat(); format == "json" || format == "jsonl" {
+			handler.AddWarning(warning)
+			return
+		}
+	}
+	if quiet, _ := cmd.Flags().GetBool("quiet"); quiet {
+		return
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %s\n", warning)
+}
+
+// toCRLF ends every line with CRLF, as RFC 5322 requires, whether the stored
+// content uses CRLF or bare LF
+func toCRLF(content string) []byte {
+	normalized := strings.ReplaceAll(content, "\r\n", "\n")
+	return []byte(strings.ReplaceAll(normalized, "\n", "\r\n"))
+}
+
+// writeMessageFile writes data to path. Without overwrite the file must not
+// exist yet. A partly written file is removed.
+func writeMessageFile(path string, data []byte, overwrite bool) error {
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if overwrite {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+	f, err := os.OpenFile(path, flags, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(path)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(path)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package messages
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+)
+
+func executeExport(t *testing.T, mockClient *mocks.MockClient, format string, args ...string) (string, string, error) {
+	t.Helper()
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var stdout, stderr bytes.Buffer
+	cmd := NewExportCommand()
+	cmd.Flags().Bool("quiet", false, "")
+	handler := printer.GetResponseHandler(format, false, &stdout)
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return stdout.String(), stderr.String(), err
+}
+
+func storedMessage(listed responses.Message, content string) *responses.Message {
+	message := listed
+	if content != "" {
+		message.Content = &content
+	}
+	message.RetainUntil = time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+	return &message
+}
+
+func TestExportCommand_Structure(t *testing.T) {
+	cmd := NewExportCommand()
+	assert.Equal(t, "export", cmd.Name())
+	assert.NotEmpty(t, cmd.Long)
+	assert.NotEmpty(t, cmd.Example)
+
+	for _, flag := range []string{"sender", "recipient", "status", "from-time", "to-time", "on", "output-dir", "limit", "overwrite"} {
+		assert.NotNil(t, cmd.Flags().Lookup(flag), "missing flag %s", flag)
+	}
+}
+
+func TestExportCommand_WritesPagesAndSkipsPurged(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "archive")
+	first := searchMessage("Welcome", "a@example.com")
+	purged := searchMessage("Old", "b@example.com")
+	second := searchMessage("Invoice", "c@example.com")
+
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetMessages", mock.MatchedBy(func(p requests.GetMessagesParams) bool {
+		return p.Cursor == nil && *p.Status == "Delivered" && *p.Sender == "noreply@example.com"
+	})).Return(searchPage(true, "next", first, purged), nil).Once()
+	mockClient.On("GetMessages", mock.MatchedBy(func(p requests.GetMessagesParams) bool {
+		return p.Cursor != nil && *p.Cursor == "next"
+	})).Return(searchPage(false, "", second), nil).Once()
+	mockClient.On("GetMessage", first.ID.String()).Return(storedMessage(first, "Subject: Welcome\n\nHi\n"), nil)
+	mockClient.On("GetMessage", purged.ID.String()).Return(storedMessage(purged, ""), nil)
+	mockClient.On("GetMessage", second.ID.String()).Return(storedMessage(second, "Subject: Invoice\r\n\r\nDue\r\n"), nil)
+
+	stdout, stderr, err := executeExport(t, mockClient, "table",
+		"--output-dir", dir, "--status", "delivered", "--sender", "noreply@example.com")
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+
+	data, err := os.ReadFile(filepath.Join(dir, first.ID.String()+".eml"))
+	require.NoError(t, err)
+	assert.Equal(t, "Subject: Welcome\r\n\r\nHi\r\n", string(data))
+	data, err = os.ReadFile(filepath.Join(dir, second.ID.String()+".eml"))
+	require.NoError(t, err)
+	assert.Equal(t, "Subject: Invoice\r\n\r\nDue\r\n", string(data))
+	assert.NoFileExists(t, filepath.Join(dir, purged.ID.String()+".eml"))
+
+	assert.Contains(t, stderr, "Warning: Skipped message "+purged.ID.String()+": content was purged after its retention period ended on 2024-06-30")
+	assert.Contains(t, stdout, "Exported 2 messages (49 B) to "+dir+", 1 skipped")
+}
+
+func TestExportCommand_ExistingFiles(t *testing.T) {
+	dir := t.TempDir()
+	message := searchMessage("Welcome", "a@example.com")
+	path := filepath.Join(dir, message.ID.String()+".eml")
+	require.NoError(t, os.WriteFile(path, []byte("earlier"), 0644))
+
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetMessages", mock.Anything).Return(searchPage(false, "", message), nil)
+
+	stdout, _, err := executeExport(t, mockClient, "json", "--output-dir", dir)
+	require.NoError(t, err)
+	mockClient.AssertNotCalled(t, "GetMessage", mock.Anything)
+
+	var summary printer.MessageExportSummary
+	require.NoError(t, json.Unmarshal([]byte(stdout), &summary))
+	assert.Equal(t, 0, summary.Exported)
+	assert.Equal(t, 1, summary.Skipped)
+	require.Len(t, summary.Skips, 1)
+	assert.Contains(t, summary.Skips[0].Reason, "use --overwrite")
+	data, _ := os.ReadFile(path)
+	assert.Equal(t, "earlier", string(data))
+
+	mockClient.On("GetMessage", message.ID.String()).Return(storedMessage(message, "Subject: Welcome\r\n\r\nHi\r\n"), nil)
+	stdout, _, err = executeExport(t, mockClient, "json", "--output-dir", dir, "--overwrite")
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal([]byte(stdout), &summary))
+	assert.Equal(t, 1, summary.Exported)
+	assert.EqualValues(t, 24, summary.TotalBytes)
+	data, _ = os.ReadFile(path)
+	assert.Equal(t, "Subject: Welcome\r\n\r\nHi\r\n", string(data))
+}
+
+func TestExportCommand_Limit(t *testing.T) {
+	dir := t.TempDir()
+	first := searchMessage("One", "a@example.com")
+	second := searchMessage("Two", "b@example.com")
+
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetMessages", mock.MatchedBy(func(p requests.GetMessagesParams) bool {
+		return *p.Limit == 1
+	})).Return(searchPage(true, "next", first, second), nil).Once()
+	mockClient.On("GetMessage", first.ID.String()).Return(storedMessage(first, "Subject: One\r\n\r\n"), nil)
+
+	_, _, err := executeExport(t, mockClient, "plain", "--output-dir", dir, "--limit", "1")
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+	assert.FileExists(t, filepath.Join(dir, first.ID.String()+".eml"))
+	assert.NoFileExists(t, filepath.Join(dir, second.ID.String()+".eml"))
+}
+
+func TestExportCommand_Validation(t *testing.T) {
+	mockClient := &mocks.MockClient{}
+
+	_, _, err := executeExport(t, mockClient, "table")
+	assert.Error(t, err, "--output-dir is required")
+
+	_, _, err = executeExport(t, mockClient, "table", "--output-dir", t.TempDir(), "--status", "sent")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid status 'sent'")
+
+	_, _, err = executeExport(t, mockClient, "table", "--output-dir", t.TempDir(), "--limit", "-1")
+	require.Error(t, err)
+	mockClient.AssertNotCalled(t, "GetMessages", mock.Anything)
+}
+
+func TestToCRLF(t *testing.T) {
+	assert.Equal(t, "a\r\nb\r\n", string(toCRLF("a\nb\n")))
+	assert.Equal(t, "a\r\nb\r\n", string(toCRLF("a\r\nb\r\n")))
+	assert.Equal(t, "a\r\nb", string(toCRLF("a\r\nb")))
+}
@@ -0,0 +1,86 @@
+package messages
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+)
+
+// maxIncludeDepth limits how deeply template includes may nest
+const maxIncludeDepth = 10
+
+// includeDirective matches {{include "path"}}, allowing spaces inside the braces
+var includeDirective = regexp.MustCompile(`\{\{\s*include\s+"([^"]*)"\s*\}\}`)
+
+// expandIncludes replaces every include directive in content, which was read
+// from filePath, with the content of the named file. Paths are resolved
+// relative to the directory of the including file, and included files are
+// expanded in turn. chain lists the files being expanded, outermost first.
+func expandIncludes(content, filePath string, chain []string) (string, error) {
+	chain = append(append([]string{}, chain...), filePath)
+	if len(chain) > maxIncludeDepth+1 {
+		return "", errors.NewValidationError(fmt.Sprintf("template includes are nested more than %d levels deep: %s",
+			maxIncludeDepth, strings.Join(chain, " -> ")), nil)
+	}
+
+	matches := includeDirective.FindAllStringSubmatchIndex(content, -1)
+	if len(matches) == 0 {
+		return content, nil
+	}
+
+	var expanded strings.Builder
+	last := 0
+	for _, match := range matches {
+		name := content[match[2]:match[3]]
+		if name == "" {
+			return "", errors.NewValidationError(fmt.Sprintf("empty include path in template file %s", filePath), nil)
+		}
+
+		includePath := normalizeInputPath(name)
+		if !filepath.IsAbs(includePath) {
+			includePath = filepath.Join(filepath.Dir(filePath), includePath)
+		}
+		if cycle := includeCycle(chain, includePath); cycle != "" {
+			return "", errors.NewValidationError(fmt.Sprintf("template include cycle: %s", cycle), nil)
+		}
+
+		included, err := readTemplateFile(includePath)
+		if err != nil {
+			return "", errors.NewFileError(fmt.Sprintf("cannot include %s in template file %s", name, filePath), err)
+		}
+		included, err = expandIncludes(included, includePath, chain)
+		if err != nil {
+			return "", err
+		}
+
+		expanded.WriteString(content[last:match[0]])
+		expanded.WriteString(included)
+		last = match[1]
+	}
+	expanded.WriteString(content[last:])
+	return expanded.String(), nil
+}
+
+// includeCycle returns the cycle formed by including filePath from the last
+// file of chain, e.g. "a.html -> b.html -> a.html", or "" if there is none
+func includeCycle(chain []string, filePath string) string {
+	for i, existing := range chain {
+		if sameFile(existing, filePath) {
+			return strings.Join(append(append([]string{}, chain[i:]...), filePath), " -> ")
+		}
+	}
+	return ""
+}
+
+// sameFile reports whether two paths name the same file
+func sameFile(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	if errA != nil || errB != nil {
+		return filepath.Clean(a) == filepath.Clean(b)
+	}
+	return absA == absB
+}
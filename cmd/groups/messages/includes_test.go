@@ -0,0 +1,127 @@
+package messages
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTemplates writes files, keyed by slash-separated path, below dir
+func writeTemplates(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+}
+
+func TestLoadTemplateFile_Includes(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplates(t, dir, map[string]string{
+		"email.html":             `{{include "partials/header.html"}}<p>Hi {{first_name}}</p>{{ include "partials/footer.html" }}`,
+		"partials/header.html":   `<header>{{include "logo.html"}}</header>`,
+		"partials/logo.html":     `<img alt="{{company}}">`,
+		"partials/footer.html":   `<footer>Bye</footer>`,
+		"literal.html":           `Write {{include "x.html"}} to include a file`,
+		"missing.html":           `{{include "partials/nope.html"}}`,
+		"empty.html":             `{{include ""}}`,
+		"cycle/a.html":           `{{include "b.html"}}`,
+		"cycle/b.html":           `{{include "../cycle/a.html"}}`,
+		"cycle/self.html":        `{{include "self.html"}}`,
+		"partials/twice.html":    `{{include "footer.html"}}{{include "footer.html"}}`,
+		"uses-twice.html":        `{{include "partials/twice.html"}}`,
+		"absolute-includer.html": fmt.Sprintf(`{{include %q}}`, filepath.ToSlash(filepath.Join(dir, "partials", "footer.html"))),
+	})
+	path := func(name string) string { return filepath.Join(dir, filepath.FromSlash(name)) }
+
+	t.Run("nested includes relative to each including file", func(t *testing.T) {
+		content, err := loadTemplateFile(path("email.html"), true)
+		require.NoError(t, err)
+		assert.Equal(t, `<header><img alt="{{company}}"></header><p>Hi {{first_name}}</p><footer>Bye</footer>`, content)
+	})
+
+	t.Run("the same file may be included more than once", func(t *testing.T) {
+		content, err := loadTemplateFile(path("uses-twice.html"), true)
+		require.NoError(t, err)
+		assert.Equal(t, `<footer>Bye</footer><footer>Bye</footer>`, content)
+	})
+
+	t.Run("absolute include paths", func(t *testing.T) {
+		content, err := loadTemplateFile(path("absolute-includer.html"), true)
+		require.NoError(t, err)
+		assert.Equal(t, `<footer>Bye</footer>`, content)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		content, err := loadTemplateFile(path("literal.html"), false)
+		require.NoError(t, err)
+		assert.Equal(t, `Write {{include "x.html"}} to include a file`, content)
+	})
+
+	t.Run("missing include names both files", func(t *testing.T) {
+		_, err := loadTemplateFile(path("missing.html"), true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot include partials/nope.html in template file "+path("missing.html"))
+	})
+
+	t.Run("empty include path", func(t *testing.T) {
+		_, err := loadTemplateFile(path("empty.html"), true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "empty include path")
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		_, err := loadTemplateFile(path("cycle/a.html"), true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "template include cycle: "+path("cycle/a.html")+" -> "+path("cycle/b.html")+" -> "+path("cycle/a.html"))
+
+		_, err = loadTemplateFile(path("cycle/self.html"), true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "template include cycle: "+path("cycle/self.html")+" -> "+path("cycle/self.html"))
+	})
+}
+
+func TestLoadTemplateFile_IncludeDepth(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{}
+	for i := 0; i <= maxIncludeDepth+1; i++ {
+		files[fmt.Sprintf("level%d.html", i)] = fmt.Sprintf(`{{include "level%d.html"}}`, i+1)
+	}
+	files[fmt.Sprintf("level%d.html", maxIncludeDepth+2)] = "bottom"
+	writeTemplates(t, dir, files)
+
+	// maxIncludeDepth levels below the top file are fine
+	content, err := loadTemplateFile(filepath.Join(dir, "level2.html"), true)
+	require.NoError(t, err)
+	assert.Equal(t, "bottom", content)
+
+	_, err = loadTemplateFile(filepath.Join(dir, "level0.html"), true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), fmt.Sprintf("nested more than %d levels deep", maxIncludeDepth))
+}
+
+func TestProcessEmailContent_Includes(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplates(t, dir, map[string]string{
+		"email.html":       `{{include "footer.html"}}`,
+		"footer.html":      `<footer>{{unsubscribe_url}}</footer>`,
+		"email.txt":        `{{include "footer.txt"}}`,
+		"footer.txt":       `Unsubscribe: {{unsubscribe_url}}`,
+		"literal.amp.html": `{{include "footer.html"}}`,
+	})
+
+	content, err := processEmailContent("", "", "",
+		filepath.Join(dir, "email.txt"), filepath.Join(dir, "email.html"), "", false)
+	require.NoError(t, err)
+	assert.Equal(t, "Unsubscribe: {{unsubscribe_url}}", content.TextContent)
+	assert.Equal(t, "<footer>{{unsubscribe_url}}</footer>", content.HtmlContent)
+
+	content, err = processEmailContent("", "", "", "", "", filepath.Join(dir, "literal.amp.html"), true)
+	require.NoError(t, err)
+	assert.Equal(t, `{{include "footer.html"}}`, content.AmpContent)
+}
@@ -0,0 +1,105 @@
+package messages
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-go/models/common"
+)
+
+// inlineDisposition is the Content-Disposition of inline attachments
+const inlineDisposition = "inline"
+
+// inlineWarningOutput receives the cid: reference warnings; replaced in tests
+var inlineWarningOutput io.Writer = os.Stderr
+
+// cidPattern matches a cid: URL in HTML, e.g. src="cid:logo.png"
+var cidPattern = regexp.MustCompile(`(?i)cid:([^"'\s)>]+)`)
+
+// parseInlineSpec splits an --inline value into the file path and the
+// Content-ID, which defaults to the file name. The Content-ID follows the
+// last colon, unless that colon belongs to a Windows drive letter or is
+// followed by a path separator.
+func parseInlineSpec(spec string) (path, contentID string) {
+	path = spec
+	if i := strings.LastIndex(spec, ":"); i > 1 && !strings.ContainsAny(spec[i+1:], `/\`) {
+		path, contentID = spec[:i], spec[i+1:]
+	}
+	if contentID == "" {
+		contentID = filepath.Base(normalizeInputPath(path))
+	}
+	return path, contentID
+}
+
+// processInlineAttachments reads the --inline files as inline attachments,
+// with the same size limit and MIME detection as --attach
+func processInlineAttachments(specs []string) ([]common.Attachment, error) {
+	var attachments []common.Attachment
+	seen := make(map[string]string)
+
+	for _, spec := range specs {
+		path, contentID := parseInlineSpec(spec)
+		if previous, ok := seen[contentID]; ok {
+			return nil, errors.NewValidationError(fmt.Sprintf("inline files %s and %s have the same Content-ID '%s'; set one with --inline path:content-id", previous, path, contentID), nil)
+		}
+		seen[contentID] = path
+
+		files, err := processAttachments([]string{path})
+		if err != nil {
+			return nil, err
+		}
+		attachment := files[0]
+		attachment.ContentDisposition = inlineDisposition
+		attachment.ContentID = &contentID
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments, nil
+}
+
+// checkInlineReferences compares the cid: references in the HTML with the
+// inline attachments. A reference without an attachment shows as a broken
+// image, so it prints a warning, or fails with strict. An attachment that
+// is never referenced only prints a warning.
+func checkInlineReferences(html string, inline []common.Attachment, strict bool) error {
+	attached := make(map[string]bool)
+	for _, attachment := range inline {
+		if attachment.ContentID != nil {
+			attached[*attachment.ContentID] = true
+		}
+	}
+
+	referenced := make(map[string]bool)
+	var missing []string
+	for _, match := range cidPattern.FindAllStringSubmatch(html, -1) {
+		contentID := match[1]
+		if referenced[contentID] {
+			continue
+		}
+		referenced[contentID] = true
+		if !attached[contentID] {
+			missing = append(missing, contentID)
+		}
+	}
+
+	if len(missing) > 0 {
+		message := fmt.Sprintf("HTML references cid:%s without a matching --inline attachment", strings.Join(missing, ", cid:"))
+		if strict {
+			return errors.NewValidationError(message, nil)
+		}
+		fmt.Fprintf(inlineWarningOutput, "⚠️  %s (use --strict-inline to fail instead)\n", message)
+	}
+
+	for _, attachment := range inline {
+		if attachment.ContentID != nil && !referenced[*attachment.ContentID] {
+			fmt.Fprintf(inlineWarningOutput, "⚠️  Inline attachment %s (Content-ID '%s') is not referenced by the HTML\n",
+				attachment.FileName, *attachment.ContentID)
+		}
+	}
+	return nil
+}
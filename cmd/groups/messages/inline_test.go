@@ -0,0 +1,132 @@
+package messages
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AhaSend/ahasend-go/models/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func captureInlineWarnings(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var warnings bytes.Buffer
+	prev := inlineWarningOutput
+	inlineWarningOutput = &warnings
+	t.Cleanup(func() { inlineWarningOutput = prev })
+	return &warnings
+}
+
+func inlineAttachment(fileName, contentID string) common.Attachment {
+	return common.Attachment{FileName: fileName, ContentDisposition: inlineDisposition, ContentID: &contentID}
+}
+
+func TestParseInlineSpec(t *testing.T) {
+	tests := []struct {
+		spec, path, contentID string
+	}{
+		{"logo.png", "logo.png", "logo.png"},
+		{"images/logo.png:logo", "images/logo.png", "logo"},
+		{"images/logo.png:", "images/logo.png", "logo.png"},
+		{`C:\images\logo.png`, `C:\images\logo.png`, "logo.png"},
+		{`C:\images\logo.png:logo`, `C:\images\logo.png`, "logo"},
+		{"odd:dir/logo.png", "odd:dir/logo.png", "logo.png"},
+	}
+	for _, tt := range tests {
+		path, contentID := parseInlineSpec(tt.spec)
+		assert.Equal(t, tt.path, path, tt.spec)
+		assert.Equal(t, tt.contentID, contentID, tt.spec)
+	}
+}
+
+func TestProcessInlineAttachments(t *testing.T) {
+	dir := t.TempDir()
+	logo := filepath.Join(dir, "logo.png")
+	require.NoError(t, os.WriteFile(logo, []byte("\x89PNG\r\n"), 0600))
+	banner := filepath.Join(dir, "banner.gif")
+	require.NoError(t, os.WriteFile(banner, []byte("GIF89a"), 0600))
+
+	attachments, err := processInlineAttachments([]string{logo, banner + ":hero"})
+	require.NoError(t, err)
+	require.Len(t, attachments, 2)
+
+	assert.Equal(t, "logo.png", attachments[0].FileName)
+	assert.Equal(t, "image/png", attachments[0].ContentType)
+	assert.Equal(t, "inline", attachments[0].ContentDisposition)
+	require.NotNil(t, attachments[0].ContentID)
+	assert.Equal(t, "logo.png", *attachments[0].ContentID)
+	assert.True(t, attachments[0].Base64)
+	assert.Equal(t, "hero", *attachments[1].ContentID)
+
+	_, err = processInlineAttachments([]string{logo, filepath.Join(dir, "other") + ":logo.png"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "same Content-ID 'logo.png'")
+
+	_, err = processInlineAttachments([]string{filepath.Join(dir, "missing.png")})
+	require.Error(t, err)
+}
+
+func TestCheckInlineReferences(t *testing.T) {
+	html := `<img src="cid:logo"><img src='CID:hero'><img src="cid:logo">`
+
+	t.Run("all referenced", func(t *testing.T) {
+		warnings := captureInlineWarnings(t)
+		err := checkInlineReferences(html, []common.Attachment{inlineAttachment("logo.png", "logo"), inlineAttachment("hero.gif", "hero")}, true)
+		require.NoError(t, err)
+		assert.Empty(t, warnings.String())
+	})
+
+	t.Run("missing cid warns", func(t *testing.T) {
+		warnings := captureInlineWarnings(t)
+		err := checkInlineReferences(html, []common.Attachment{inlineAttachment("logo.png", "logo")}, false)
+		require.NoError(t, err)
+		assert.Contains(t, warnings.String(), "HTML references cid:hero without a matching --inline attachment")
+		assert.Contains(t, warnings.String(), "--strict-inline")
+	})
+
+	t.Run("missing cid fails with strict", func(t *testing.T) {
+		warnings := captureInlineWarnings(t)
+		err := checkInlineReferences(html, nil, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cid:logo, cid:hero")
+		assert.Empty(t, warnings.String())
+	})
+
+	t.Run("unused inline warns", func(t *testing.T) {
+		warnings := captureInlineWarnings(t)
+		err := checkInlineReferences(`<p>No images</p>`, []common.Attachment{inlineAttachment("logo.png", "logo")}, true)
+		require.NoError(t, err)
+		assert.Contains(t, warnings.String(), "Inline attachment logo.png (Content-ID 'logo') is not referenced by the HTML")
+	})
+}
+
+func TestCreateSendJobs_InlineAttachments(t *testing.T) {
+	captureInlineWarnings(t)
+	dir := t.TempDir()
+	logo := filepath.Join(dir, "logo.png")
+	require.NoError(t, os.WriteFile(logo, []byte("\x89PNG\r\n"), 0600))
+	invoice := filepath.Join(dir, "invoice.pdf")
+	require.NoError(t, os.WriteFile(invoice, []byte("%PDF-1.4"), 0600))
+
+	jobs, _, err := createSendJobs(
+		"news@example.com", []string{"ana@example.com"}, nil, nil, "", false, "Welcome", "",
+		"", `<img src="cid:logo">`, "",
+		"", "", "", false,
+		"", nil, false, nil,
+		nil, "", 0, false, "", nil,
+		false, false, []string{invoice}, []string{logo + ":logo"}, true, "", "key",
+	)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+
+	attachments := jobs[0].Request.Attachments
+	require.Len(t, attachments, 2)
+	assert.Equal(t, "invoice.pdf", attachments[0].FileName)
+	assert.Empty(t, attachments[0].ContentDisposition)
+	assert.Nil(t, attachments[0].ContentID)
+	assert.Equal(t, "inline", attachments[1].ContentDisposition)
+	assert.Equal(t, "logo", *attachments[1].ContentID)
+}
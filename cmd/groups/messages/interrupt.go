@@ -0,0 +1,102 @@
+package messages
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/AhaSend/ahasend-cli/internal/batch"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+)
+
+// interruptExitCode is the conventional exit status for a SIGINT-terminated process
+const interruptExitCode = 130
+
+// Signal handling hooks
+var (
+	interruptSignals           = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	interruptOutput  io.Writer = os.Stderr
+	forceExit                  = os.Exit
+)
+
+// notifyInterrupts returns a context that is cancelled on the first
+// SIGINT/SIGTERM so a batch send can drain. A second signal exits the process
+// immediately. The returned stop function releases the signal handler.
+func notifyInterrupts(parent context.Context) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(parent)
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, interruptSignals...)
+	done := make(chan struct{})
+
+	go func() {
+		select {
+		case sig := <-signals:
+			logger.Get().WithField("signal", sig.String()).Debug("Received interrupt, stopping batch dispatch")
+			cancel()
+		case <-done:
+			return
+		}
+
+		select {
+		case <-signals:
+			fmt.Fprintln(interruptOutput, "\nReceived second interrupt, exiting immediately")
+			forceExit(interruptExitCode)
+		case <-done:
+		}
+	}()
+
+	return ctx, func() {
+		signal.Stop(signals)
+		close(done)
+		cancel()
+	}
+}
+
+// reportInterruptedBatch prints the summary of a cancelled batch send: the
+// message sent when only one was, or the recipient counts otherwise
+func reportInterruptedBatch(handler printer.ResponseHandler, result *batch.BatchResult, flags *SendFlags) error {
+	if len(result.SuccessfulResponses) == 1 {
+		return handler.HandleCreateMessage(result.SuccessfulResponses[0], printer.CreateConfig{
+			SuccessMessage: "Message sent before the interruption",
+			ItemName:       "message",
+			Metadata:       flags.Metadata,
+		})
+	}
+	return handler.HandleSimpleSuccess(fmt.Sprintf("⚠️  Interrupted after sending %d of %d recipients in %d messages",
+		result.SuccessfulRecipients, result.TotalRecipients, len(result.SuccessfulResponses)))
+}
+
+// interruptedBatchError summarizes a cancelled batch send, pointing at
+// --resume when the batches were recorded in stateFile
+func interruptedBatchError(result *batch.BatchResult, stateFile string) error {
+	parts := []string{
+		fmt.Sprintf("%d of %d recipients sent", result.SuccessfulRecipients, result.TotalRecipients),
+	}
+	if failed := len(result.FailedRecipients) - result.NotSentRecipients - result.AbandonedRecipients; failed > 0 {
+		parts = append(parts, fmt.Sprintf("%d failed", failed))
+	}
+	if result.NotSentRecipients > 0 {
+		parts = append(parts, fmt.Sprintf("%d not sent", result.NotSentRecipients))
+	}
+	if result.AbandonedRecipients > 0 {
+		parts = append(parts, fmt.Sprintf("%d unknown (still in flight at drain timeout)", result.AbandonedRecipients))
+	}
+	if result.SkippedRecipients > 0 {
+		parts = append(parts, fmt.Sprintf("%d skipped (already sent)", result.SkippedRecipients))
+	}
+
+	message := "batch send interrupted: " + strings.Join(parts, ", ")
+	if result.FailedRecipientsFile != "" {
+		message += fmt.Sprintf("; unsent and failed recipients saved to %s", result.FailedRecipientsFile)
+	}
+	if stateFile != "" {
+		message += fmt.Sprintf("; continue with --resume --state-file %s", stateFile)
+	}
+	return errors.NewInterruptedError(message, nil)
+}
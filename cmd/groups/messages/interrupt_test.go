@@ -0,0 +1,71 @@
+package messages
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/AhaSend/ahasend-go/models/responses"
+
+	"github.com/AhaSend/ahasend-cli/internal/batch"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterruptedBatchError(t *testing.T) {
+	result := &batch.BatchResult{
+		TotalRecipients:      10,
+		SuccessfulRecipients: 4,
+		FailedRecipients:     make([]batch.FailedRecipient, 6),
+		NotSentRecipients:    4,
+		AbandonedRecipients:  1,
+		FailedRecipientsFile: "/tmp/failed.json",
+		Interrupted:          true,
+	}
+
+	err := interruptedBatchError(result, "")
+	require.Error(t, err)
+
+	cliErr, ok := err.(*errors.CLIError)
+	require.True(t, ok)
+	assert.Equal(t, errors.ErrCodeInterrupted, cliErr.Code)
+	assert.Equal(t, interruptExitCode, errors.GetExitCode(cliErr))
+	assert.Contains(t, err.Error(), "4 of 10 recipients sent")
+	assert.Contains(t, err.Error(), "1 failed")
+	assert.Contains(t, err.Error(), "4 not sent")
+	assert.Contains(t, err.Error(), "1 unknown")
+	assert.Contains(t, err.Error(), "/tmp/failed.json")
+}
+
+func TestFormatBatchResponse_Interrupted(t *testing.T) {
+	result := &batch.BatchResult{
+		TotalRecipients:      5,
+		SuccessfulRecipients: 2,
+		SuccessfulResponses:  []*responses.CreateMessageResponse{{}, {}},
+		Interrupted:          true,
+		NotSentRecipients:    3,
+		FailedRecipientsFile: "/tmp/failed.json",
+	}
+
+	for _, format := range []string{"plain", "json"} {
+		t.Run(format, func(t *testing.T) {
+			var stdout bytes.Buffer
+			err := formatBatchResponse(printer.GetResponseHandler(format, false, &stdout), result, &SendFlags{})
+			require.Error(t, err)
+			assert.Equal(t, interruptExitCode, errors.GetExitCode(err))
+			assert.Contains(t, err.Error(), "/tmp/failed.json")
+			assert.Contains(t, stdout.String(), "Interrupted after sending 2 of 5 recipients in 2 messages",
+				"the partial summary is printed before the error")
+		})
+	}
+}
+
+func TestNotifyInterrupts_StopCancelsContext(t *testing.T) {
+	ctx, stop := notifyInterrupts(context.Background())
+	assert.NoError(t, ctx.Err())
+
+	stop()
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+}
@@ -13,10 +13,26 @@ import (
 	ahasend "github.com/AhaSend/ahasend-go"
 	"github.com/AhaSend/ahasend-go/models/common"
 	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 )
 
+// messageStatuses maps the --status values to the API's message statuses
+var messageStatuses = map[string]string{
+	"received":           "Received",
+	"delivered":          "Delivered",
+	"deferred":           "Deferred",
+	"bounced":            "Bounced",
+	"failed":             "Failed",
+	"suppressed":         "Suppressed",
+	"sandbox delivered":  "Sandbox Delivered",
+	"sandbox deferred":   "Sandbox Deferred",
+	"sandbox failed":     "Sandbox Failed",
+	"sandbox bounced":    "Sandbox Bounced",
+	"sandbox suppressed": "Sandbox Suppressed",
+}
+
 // NewListCommand creates the list command
 func NewListCommand() *cobra.Command {
 	cmd := &cobra.Command{
@@ -31,12 +47,53 @@ Status filtering supports single or multiple flags:
   - Multiple: --status delivered --status bounced --status failed
   - Valid statuses: received, delivered, deferred, bounced, failed, suppressed, sandbox delivered, sandbox deferred, sandbox failed, sandbox bounced, sandbox suppressed
 
-Date/time values should be in RFC3339 format (e.g., 2024-01-15T10:30:00Z).
+Date/time values should be in RFC3339 format (e.g., 2024-01-15T10:30:00Z)
+or a date like 2024-01-15. A date means midnight for --from-time and the end
+of that day for --to-time, in --timezone (default: local), and --on 2024-01-15
+covers that whole day.
 For relative times, you can use:
   - "1h" for 1 hour ago
   - "24h" for 24 hours ago
   - "7d" for 7 days ago
-  - "30d" for 30 days ago`,
+  - "30d" for 30 days ago
+
+--since and --until are the strict form for quick checks: a duration before
+now ("90m", "24h", "1h30m", "7d", "1d12h") or an RFC3339 timestamp, resolved
+in UTC. Anything else, such as "yesterday" or a number without a unit, is
+rejected. They replace --from-time and --to-time and cannot be combined with
+them or with --on.
+
+Filtering by metadata (--meta) is not supported: the API does not index the
+X-AhaSend-Meta-* headers that carry metadata set with 'messages send --meta'.
+Use --tags for values you need to filter on.
+
+Fetching every page:
+  --all follows the pagination cursors until the last page, using --limit as
+  the page size (and --cursor as the first page, when given). At most
+  --max-items messages are fetched (default: 10000, 0 for no limit); when the
+  list is cut short, a warning gives the --cursor to continue from. With
+  --output csv, jsonl or --ids-only each page is printed as it arrives, so
+  memory stays bounded; other formats print one list once all pages are in.
+  Ctrl-C stops after the page being fetched and prints the messages fetched
+  so far. --show-metrics reports the number of API calls made on stderr.
+
+Picking a message:
+  --pick replaces the table with an interactive list. Choose a message with
+  the arrow keys and enter, then a follow-up action: get, attempts, content
+  (the raw message) or cancel. The action runs right away with the same
+  credentials. --pick needs a terminal and table output; in scripts, pipe
+  --output json into the next command instead.
+
+Writing to a file or object storage:
+  --output-file writes the list, in the --output format, to a local file or
+  straight to Amazon S3 (s3://bucket/key) or Google Cloud Storage
+  (gs://bucket/object) instead of stdout. S3 credentials and region come from
+  the standard AWS configuration (AWS_* variables, ~/.aws, SSO or instance
+  roles); Cloud Storage uses Application Default Credentials. Large output is
+  uploaded in parts as it is written. If the command fails or is interrupted,
+  the partial file or incomplete upload is removed. --manifest writes a
+  manifest of the output, locally or to object storage, for
+  'ahasend verify-export'.`,
 		Example: `  # List all messages in account
   ahasend messages list
 
@@ -58,9 +115,19 @@ For relative times, you can use:
   # List messages from the last 24 hours
   ahasend messages list --from-time 24h
 
+  # List messages from the last 90 minutes, or from 2 to 1 days ago
+  ahasend messages list --since 90m
+  ahasend messages list --since 2d --until 1d
+
   # List messages between specific dates
   ahasend messages list --from-time 2024-01-01T00:00:00Z --to-time 2024-01-31T23:59:59Z
 
+  # List messages from all of January in UTC
+  ahasend messages list --from-time 2024-01-01 --to-time 2024-01-31 --timezone UTC
+
+  # List messages from a single day
+  ahasend messages list --on 2024-06-01
+
   # List messages with specific tags
   ahasend messages list --tags welcome --tags onboarding
 
@@ -70,8 +137,22 @@ For relative times, you can use:
   # List with pagination (limit results)
   ahasend messages list --limit 10
 
+  # Dump every delivered message from last week as CSV
+  ahasend messages list --all --status delivered --from-time 7d --output csv > delivered.csv
+
+  # Fetch up to 50000 messages and report the API calls made
+  ahasend messages list --all --max-items 50000 --output jsonl --show-metrics
+
+  # Choose a bounced message and show its delivery attempts
+  ahasend messages list --status bounced --pick
+
   # Export to JSON
-  ahasend messages list --output json`,
+  ahasend messages list --output json
+
+  # Upload yesterday's messages as CSV to S3, with a manifest
+  ahasend messages list --on 2024-06-01 --output csv \
+    --output-file s3://bucket/prefix/messages-2024-06-01.csv \
+    --manifest s3://bucket/prefix/messages-2024-06-01.manifest.json`,
 		RunE:         runMessagesList,
 		SilenceUsage: true,
 	}
@@ -83,23 +164,75 @@ For relative times, you can use:
 	cmd.Flags().String("message-id", "", "Filter by message ID header")
 	cmd.Flags().StringSlice("status", []string{}, "Filter by message status (can be used multiple times)")
 	cmd.Flags().StringSlice("tags", []string{}, "Filter by tags (can be used multiple times)")
-	cmd.Flags().String("from-time", "", "Filter messages created after this time (RFC3339 or relative like '24h', '7d')")
-	cmd.Flags().String("to-time", "", "Filter messages created before this time (RFC3339 or relative)")
+	cmd.Flags().String("from-time", "", "Filter messages created after this time (RFC3339, YYYY-MM-DD or relative like '24h', '7d')")
+	cmd.Flags().String("to-time", "", "Filter messages created before this time (RFC3339, YYYY-MM-DD for the whole day, or relative)")
+	cmd.Flags().String("on", "", "Filter to a single day (YYYY-MM-DD); replaces --from-time and --to-time")
+	cmd.Flags().String("timezone", "", "Timezone for date-only values, e.g. 'Europe/Berlin' or 'UTC' (default: local)")
+	cmd.Flags().String("since", "", "Filter messages created after this time: a duration ago like '90m', '24h' or '7d', or RFC3339")
+	cmd.Flags().String("until", "", "Filter messages created before this time: a duration ago like '1d', or RFC3339")
+	cmd.Flags().StringArray("meta", []string{}, "Filter by metadata 'key=value' (not supported by the API; see help)")
 
 	// Pagination parameters
 	cmd.Flags().Int("limit", 100, "Maximum number of messages to return (1-100)")
 	cmd.Flags().String("cursor", "", "Pagination cursor for next page")
+	cmd.Flags().Bool("all", false, "Follow pagination cursors and fetch every page, --limit messages at a time")
+	cmd.Flags().Int("max-items", defaultMaxItems, "With --all, stop after this many messages (0 for no limit)")
 
 	// Display options
 	cmd.Flags().Bool("show-details", false, "Show detailed message information")
+	cmd.Flags().Bool("pick", false, "Choose a message and a follow-up action interactively (terminal only)")
+	cmd.Flags().String("output-file", "", "Write the list to a file, s3://bucket/key or gs://bucket/object instead of stdout")
+	cmd.Flags().String("manifest", "", "Write a manifest of --output-file to this path or URL")
+	cmd.Flags().Bool("show-metrics", false, "Show the number of API calls made on stderr")
+	printer.AddIDsOnlyFlag(cmd)
+	cmd.MarkFlagsMutuallyExclusive(printer.IDsOnlyFlag, "pick")
+	cmd.MarkFlagsMutuallyExclusive(printer.IDsOnlyFlag, "output-file")
+	cmd.MarkFlagsMutuallyExclusive("all", "pick")
 
 	return cmd
 }
 
+// normalizeStatuses validates --status values and joins them into the API's
+// comma separated status filter
+func normalizeStatuses(statuses []string) (string, error) {
+	var normalizedList []string
+	for _, s := range statuses {
+		s = strings.TrimSpace(strings.ToLower(s))
+		apiStatus, valid := messageStatuses[s]
+		if !valid {
+			validInputs := make([]string, 0, len(messageStatuses))
+			for k := range messageStatuses {
+				validInputs = append(validInputs, k)
+			}
+			return "", errors.NewValidationError(fmt.Sprintf("invalid status '%s'. Valid statuses: %s", s, strings.Join(validInputs, ", ")), nil)
+		}
+		normalizedList = append(normalizedList, apiStatus)
+	}
+	return strings.Join(normalizedList, ","), nil
+}
+
 func runMessagesList(cmd *cobra.Command, args []string) error {
 	// Get response handler instance
 	handler := printer.GetResponseHandlerFromCommand(cmd)
 
+	// Refuse rather than silently returning unfiltered results
+	if meta, _ := cmd.Flags().GetStringArray("meta"); len(meta) > 0 {
+		return errors.NewValidationError("filtering messages by metadata is not supported by the AhaSend API; use --tags to filter on values set at send time", nil)
+	}
+
+	outputFile, manifestFile, err := validateOutputFile(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Fail before fetching when the picker cannot be shown
+	var prompt chooser
+	if pick, _ := cmd.Flags().GetBool("pick"); pick {
+		if prompt, err = validatePick(cmd); err != nil {
+			return err
+		}
+	}
+
 	// Get authenticated client
 	client, err := auth.GetAuthenticatedClient(cmd)
 	if err != nil {
@@ -121,65 +254,40 @@ func runMessagesList(cmd *cobra.Command, args []string) error {
 	tags, _ := cmd.Flags().GetStringSlice("tags")
 	fromTimeStr, _ := cmd.Flags().GetString("from-time")
 	toTimeStr, _ := cmd.Flags().GetString("to-time")
+	on, _ := cmd.Flags().GetString("on")
+	timezone, _ := cmd.Flags().GetString("timezone")
+	since, _ := cmd.Flags().GetString("since")
+	until, _ := cmd.Flags().GetString("until")
 	limit, _ := cmd.Flags().GetInt("limit")
 	cursor, _ := cmd.Flags().GetString("cursor")
 	showDetails, _ := cmd.Flags().GetBool("show-details")
+	all, _ := cmd.Flags().GetBool("all")
+	maxItems, _ := cmd.Flags().GetInt("max-items")
+	showMetrics, _ := cmd.Flags().GetBool("show-metrics")
 
 	// Validate limit
 	if limit < 1 || limit > 100 {
 		return errors.NewValidationError("limit must be between 1 and 100", nil)
 	}
+	if cmd.Flags().Changed("max-items") && !all {
+		return errors.NewValidationError("--max-items requires --all", nil)
+	}
+	if maxItems < 0 {
+		return errors.NewValidationError("--max-items cannot be negative", nil)
+	}
 
 	// Validate and normalize status if provided
-	var normalizedStatus string
-	if len(statuses) > 0 {
-		// Map of user-friendly input to API format
-		statusMap := map[string]string{
-			"received":           "Received",
-			"delivered":          "Delivered",
-			"deferred":           "Deferred",
-			"bounced":            "Bounced",
-			"failed":             "Failed",
-			"suppressed":         "Suppressed",
-			"sandbox delivered":  "Sandbox Delivered",
-			"sandbox deferred":   "Sandbox Deferred",
-			"sandbox failed":     "Sandbox Failed",
-			"sandbox bounced":    "Sandbox Bounced",
-			"sandbox suppressed": "Sandbox Suppressed",
-		}
-
-		var normalizedList []string
-		for _, s := range statuses {
-			s = strings.TrimSpace(strings.ToLower(s))
-			if apiStatus, valid := statusMap[s]; valid {
-				normalizedList = append(normalizedList, apiStatus)
-			} else {
-				validInputs := make([]string, 0, len(statusMap))
-				for k := range statusMap {
-					validInputs = append(validInputs, k)
-				}
-				return errors.NewValidationError(fmt.Sprintf("invalid status '%s'. Valid statuses: %s", s, strings.Join(validInputs, ", ")), nil)
-			}
-		}
-		normalizedStatus = strings.Join(normalizedList, ",")
+	normalizedStatus, err := normalizeStatuses(statuses)
+	if err != nil {
+		return err
 	}
 
 	// Parse time filters
-	var fromTime, toTime *time.Time
-	if fromTimeStr != "" {
-		t, err := output.ParseTimePast(fromTimeStr)
-		if err != nil {
-			return err
-		}
-		fromTime = &t
-	}
-
-	if toTimeStr != "" {
-		t, err := output.ParseTimePast(toTimeStr)
-		if err != nil {
-			return err
-		}
-		toTime = &t
+	fromTime, toTime, err := output.TimeRangeFlags{
+		From: fromTimeStr, To: toTimeStr, On: on, Timezone: timezone, Since: since, Until: until,
+	}.Parse(time.Now())
+	if err != nil {
+		return err
 	}
 
 	// Log the operation
@@ -195,6 +303,8 @@ func runMessagesList(cmd *cobra.Command, args []string) error {
 		"to_time":    toTime,
 		"limit":      limit,
 		"cursor":     cursor,
+		"all":        all,
+		"max_items":  maxItems,
 	}).Debug("Listing messages")
 
 	// Build parameters for the client wrapper
@@ -213,22 +323,58 @@ func runMessagesList(cmd *cobra.Command, args []string) error {
 		},
 	}
 
-	// Execute the request through our client wrapper (includes retry logic and logging)
-	response, err := client.GetMessages(params)
-	if err != nil {
-		return err
-	}
-
 	// Use the new ResponseHandler to display message list
 	fieldOrder := []string{"id", "sender", "recipient", "subject", "status", "created", "delivered", "opens", "clicks"}
 	if showDetails {
 		fieldOrder = append(fieldOrder, "message_id", "direction", "domain_id", "attempts", "tags", "bounce_class", "retain_until")
 	}
 
-	return handler.HandleMessageList(response, printer.ListConfig{
+	listConfig := printer.ListConfig{
 		SuccessMessage: "Messages retrieved successfully",
 		EmptyMessage:   "No messages found matching criteria",
 		ShowPagination: true,
 		FieldOrder:     fieldOrder,
+	}
+
+	if all {
+		return listAllMessages(cmd, handler, client, params, limit, maxItems, listConfig, outputFile, manifestFile, showMetrics)
+	}
+
+	// Execute the request through our client wrapper (includes retry logic and logging)
+	startedAt := time.Now()
+	response, err := client.GetMessages(params)
+	if err != nil {
+		return err
+	}
+	if showMetrics {
+		count := 0
+		if response != nil {
+			count = len(response.Data)
+		}
+		defer printListMetrics(cmd, 1, count, startedAt)
+	}
+
+	if prompt != nil {
+		var messages []responses.Message
+		if response != nil {
+			messages = response.Data
+		}
+		return runPick(cmd, client, messages, prompt)
+	}
+
+	if outputFile == "" {
+		return handler.HandleMessageList(response, listConfig)
+	}
+
+	err = writeOutputFile(cmd, handler, outputFile, manifestFile, func(fileHandler printer.ResponseHandler) error {
+		return fileHandler.HandleMessageList(response, listConfig)
 	})
+	if err != nil {
+		return err
+	}
+	count := 0
+	if response != nil {
+		count = len(response.Data)
+	}
+	return handler.HandleSimpleSuccess(fmt.Sprintf("Wrote %d messages to %s", count, outputFile))
 }
@@ -0,0 +1,189 @@
+package messages
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/pager"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	ahasend "github.com/AhaSend/ahasend-go"
+	"github.com/AhaSend/ahasend-go/models/common"
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/spf13/cobra"
+)
+
+// defaultMaxItems is the --max-items safeguard of 'messages list --all'
+const defaultMaxItems = 10000
+
+// messagePages follows the pagination cursors of a messages list for --all
+type messagePages struct {
+	client   client.AhaSendClient
+	params   requests.GetMessagesParams // the filters and the first page's cursor
+	pageSize int
+	maxItems int // 0 for no limit
+
+	Calls int // API calls made
+	Items int // messages fetched
+
+	// NextCursor continues the list when it was cut short by maxItems or an
+	// interrupt; nil when the last page was fetched
+	NextCursor *string
+}
+
+// Run fetches the pages one after another and calls page with each, until
+// the last page, maxItems messages or ctx is done. A page being fetched when
+// ctx is done is still passed on; no further page is requested.
+func (p *messagePages) Run(ctx context.Context, page func(*responses.PaginatedMessagesResponse) error) error {
+	cursor := p.params.Cursor
+	for {
+		pageSize := p.pageSize
+		if p.maxItems > 0 {
+			if p.Items >= p.maxItems {
+				p.NextCursor = cursor
+				return nil
+			}
+			pageSize = min(pageSize, p.maxItems-p.Items)
+		}
+
+		params := p.params
+		params.PaginationParams = common.PaginationParams{
+			Limit:  ahasend.Int32(int32(pageSize)),
+			Cursor: cursor,
+		}
+		response, err := p.client.GetMessages(params)
+		p.Calls++
+		if err != nil {
+			return err
+		}
+		if response == nil {
+			return errors.NewAPIError("received nil response from API", nil)
+		}
+		p.Items += len(response.Data)
+		logger.Get().WithFields(map[string]interface{}{
+			"page":     p.Calls,
+			"messages": len(response.Data),
+			"total":    p.Items,
+		}).Debug("Fetched messages page")
+
+		if err := page(response); err != nil {
+			return err
+		}
+
+		if !response.Pagination.HasMore || response.Pagination.NextCursor == nil || *response.Pagination.NextCursor == "" {
+			p.NextCursor = nil
+			return nil
+		}
+		cursor = response.Pagination.NextCursor
+		if ctx.Err() != nil {
+			p.NextCursor = cursor
+			return nil
+		}
+	}
+}
+
+// Pagination describes where the fetched messages end, for a list merged
+// from all pages
+func (p *messagePages) Pagination() common.PaginationInfo {
+	return common.PaginationInfo{HasMore: p.NextCursor != nil, NextCursor: p.NextCursor}
+}
+
+// listAllMessages prints every page of the list for --all. Pages are printed
+// as they arrive for csv, jsonl and --ids-only, and merged into one list for
+// the other formats. An interrupt stops after the page being fetched; the
+// messages fetched so far are printed before the interrupted error.
+func listAllMessages(cmd *cobra.Command, handler printer.ResponseHandler, apiClient client.AhaSendClient, params requests.GetMessagesParams,
+	pageSize, maxItems int, config printer.ListConfig, outputFile, manifestFile string, showMetrics bool) error {
+	parent := cmd.Context()
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, stop := notifyInterrupts(parent)
+	defer stop()
+
+	pages := &messagePages{client: apiClient, params: params, pageSize: pageSize, maxItems: maxItems}
+	format := handler.GetFormat()
+	stream := format == "csv" || format == "jsonl" || printer.IDsOnly(cmd)
+	// A merged JSON list carries the cut short warning in its warnings array
+	jsonOutput := format == "json" && !stream
+	interrupted := func() bool { return ctx.Err() != nil && parent.Err() == nil }
+	startedAt := time.Now()
+
+	// cutShort describes where a list cut short continues, or is empty
+	cutShort := func() string {
+		switch {
+		case pages.NextCursor == nil:
+			return ""
+		case interrupted():
+			return fmt.Sprintf("interrupted after %d messages; continue with --cursor %s", pages.Items, *pages.NextCursor)
+		default:
+			return fmt.Sprintf("stopped after %d messages (--max-items); continue with --cursor %s", pages.Items, *pages.NextCursor)
+		}
+	}
+
+	render := func(h printer.ResponseHandler) error {
+		if stream {
+			written := 0
+			return pages.Run(ctx, func(page *responses.PaginatedMessagesResponse) error {
+				pageConfig := config
+				pageConfig.Continuation = written > 0
+				written += len(page.Data)
+				return h.HandleMessageList(page, pageConfig)
+			})
+		}
+
+		merged := &responses.PaginatedMessagesResponse{Object: "list", Data: []responses.Message{}}
+		err := pages.Run(ctx, func(page *responses.PaginatedMessagesResponse) error {
+			merged.Data = append(merged.Data, page.Data...)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		merged.Pagination = pages.Pagination()
+		if warning := cutShort(); warning != "" && jsonOutput {
+			h.AddWarning(warning)
+		}
+		return h.HandleMessageList(merged, config)
+	}
+
+	var err error
+	switch {
+	case outputFile != "":
+		err = writeOutputFile(cmd, handler, outputFile, manifestFile, render)
+		if err == nil {
+			err = handler.HandleSimpleSuccess(fmt.Sprintf("Wrote %d messages to %s", pages.Items, outputFile))
+		}
+	case stream:
+		// Pages are printed as they arrive, so they are not held back for the pager
+		if err = pager.StopBuffering(cmd); err == nil {
+			err = render(handler)
+		}
+	default:
+		err = render(handler)
+	}
+	if showMetrics {
+		printListMetrics(cmd, pages.Calls, pages.Items, startedAt)
+	}
+	if err != nil {
+		return err
+	}
+
+	if warning := cutShort(); warning != "" && (!jsonOutput || outputFile != "") {
+		printWarning(cmd, nil, warning)
+	}
+	if interrupted() {
+		return errors.NewInterruptedError(fmt.Sprintf("interrupted after %d messages", pages.Items), nil)
+	}
+	return nil
+}
+
+// printListMetrics reports the API calls made for --show-metrics on stderr
+func printListMetrics(cmd *cobra.Command, calls, messages int, startedAt time.Time) {
+	fmt.Fprintf(cmd.ErrOrStderr(), "API calls: %d, messages: %d, duration: %s\n",
+		calls, messages, time.Since(startedAt).Round(time.Millisecond))
+}
@@ -0,0 +1,132 @@
+package messages
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+)
+
+// threePages serves three pages of two, two and one messages
+func threePages(m *mocks.MockClient) {
+	m.On("GetAccountID").Return(uuid.New().String())
+	m.On("GetMessages", mock.MatchedBy(func(p requests.GetMessagesParams) bool {
+		return p.Cursor == nil || *p.Cursor == ""
+	})).Return(searchPage(true, "page-2", searchMessage("One", "a@example.com"), searchMessage("Two", "b@example.com")), nil)
+	m.On("GetMessages", mock.MatchedBy(func(p requests.GetMessagesParams) bool {
+		return p.Cursor != nil && *p.Cursor == "page-2"
+	})).Return(searchPage(true, "page-3", searchMessage("Three", "c@example.com"), searchMessage("Four", "d@example.com")), nil)
+	m.On("GetMessages", mock.MatchedBy(func(p requests.GetMessagesParams) bool {
+		return p.Cursor != nil && *p.Cursor == "page-3"
+	})).Return(searchPage(false, "", searchMessage("Five", "e@example.com")), nil)
+}
+
+func executeListAll(t *testing.T, format string, setup func(*mocks.MockClient), args ...string) (*mocks.MockClient, string, string, error) {
+	t.Helper()
+	mockClient := &mocks.MockClient{}
+	setup(mockClient)
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var stdout, stderr bytes.Buffer
+	cmd := NewListCommand()
+	handler := printer.GetResponseHandler(format, false, &stdout)
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return mockClient, stdout.String(), stderr.String(), err
+}
+
+func TestListAll_MergesPages(t *testing.T) {
+	mockClient, stdout, stderr, err := executeListAll(t, "json", threePages, "--all", "--limit", "2", "--show-metrics")
+	require.NoError(t, err)
+	mockClient.AssertNumberOfCalls(t, "GetMessages", 3)
+	mockClient.AssertCalled(t, "GetMessages", mock.MatchedBy(func(p requests.GetMessagesParams) bool {
+		return *p.Limit == 2 && p.Cursor != nil && *p.Cursor == "page-3"
+	}))
+
+	var response struct {
+		Data       []map[string]interface{} `json:"data"`
+		Pagination struct {
+			HasMore bool `json:"has_more"`
+		} `json:"pagination"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &response))
+	assert.Len(t, response.Data, 5)
+	assert.False(t, response.Pagination.HasMore)
+	assert.Contains(t, stderr, "API calls: 3, messages: 5")
+}
+
+func TestListAll_StreamsCSVWithOneHeader(t *testing.T) {
+	_, stdout, _, err := executeListAll(t, "csv", threePages, "--all", "--limit", "2")
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	require.Len(t, lines, 6)
+	assert.True(t, strings.HasPrefix(lines[0], "id,"))
+	assert.Equal(t, 1, strings.Count(stdout, "id,sender"))
+	assert.Contains(t, lines[5], "Five")
+}
+
+func TestListAll_MaxItems(t *testing.T) {
+	mockClient, stdout, stderr, err := executeListAll(t, "jsonl", func(m *mocks.MockClient) {
+		// The second page only asks for the one message still allowed
+		m.On("GetMessages", mock.MatchedBy(func(p requests.GetMessagesParams) bool {
+			return p.Cursor != nil && *p.Cursor == "page-2" && *p.Limit == 1
+		})).Return(searchPage(true, "page-3", searchMessage("Three", "c@example.com")), nil).Once()
+		threePages(m)
+	}, "--all", "--limit", "2", "--max-items", "3")
+	require.NoError(t, err)
+
+	mockClient.AssertNumberOfCalls(t, "GetMessages", 2)
+	assert.Len(t, strings.Split(strings.TrimSpace(stdout), "\n"), 3)
+	assert.Contains(t, stderr, "Warning: stopped after 3 messages (--max-items); continue with --cursor page-3")
+}
+
+func TestListAll_Validation(t *testing.T) {
+	mockClient, _, _, err := executeListAll(t, "json", threePages, "--max-items", "10")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--max-items requires --all")
+
+	_, _, _, err = executeListAll(t, "json", threePages, "--all", "--max-items", "-1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--max-items cannot be negative")
+	mockClient.AssertNotCalled(t, "GetMessages", mock.Anything)
+}
+
+func TestMessagePages_StopsWhenInterrupted(t *testing.T) {
+	mockClient := &mocks.MockClient{}
+	threePages(mockClient)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	pages := &messagePages{client: mockClient, pageSize: 2}
+	fetched := 0
+	err := pages.Run(ctx, func(page *responses.PaginatedMessagesResponse) error {
+		fetched += len(page.Data)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, fetched)
+	assert.Equal(t, 1, pages.Calls)
+	require.NotNil(t, pages.NextCursor)
+	assert.Equal(t, "page-2", *pages.NextCursor)
+}
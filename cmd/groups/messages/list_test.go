@@ -0,0 +1,60 @@
+package messages
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+)
+
+func TestList_SinceUntil(t *testing.T) {
+	var params requests.GetMessagesParams
+	setup := func(m *mocks.MockClient) {
+		m.On("GetAccountID").Return(uuid.New().String())
+		m.On("GetMessages", mock.Anything).Run(func(args mock.Arguments) {
+			params = args.Get(0).(requests.GetMessagesParams)
+		}).Return(searchPage(false, ""), nil)
+	}
+
+	_, _, _, err := executeListAll(t, "json", setup, "--since", "1d12h", "--until", "90m")
+	require.NoError(t, err)
+	require.NotNil(t, params.FromTime)
+	require.NotNil(t, params.ToTime)
+	assert.WithinDuration(t, time.Now().Add(-36*time.Hour), *params.FromTime, time.Minute)
+	assert.WithinDuration(t, time.Now().Add(-90*time.Minute), *params.ToTime, time.Minute)
+	assert.Equal(t, time.UTC, params.FromTime.Location())
+
+	_, _, _, err = executeListAll(t, "json", setup, "--since", "2026-01-02T03:04:05+02:00")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 1, 2, 1, 4, 5, 0, time.UTC), *params.FromTime)
+	assert.Nil(t, params.ToTime)
+}
+
+func TestList_SinceUntilErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"since with from-time", []string{"--since", "24h", "--from-time", "2d"}, "--since cannot be combined with --from-time or --on"},
+		{"until with on", []string{"--until", "1h", "--on", "2026-01-02"}, "--until cannot be combined with --to-time or --on"},
+		{"ambiguous", []string{"--since", "yesterday"}, `invalid --since: invalid time "yesterday"`},
+		{"since after until", []string{"--since", "1h", "--until", "2h"}, "is after --until"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient, _, _, err := executeListAll(t, "json", func(m *mocks.MockClient) {
+				m.On("GetAccountID").Return(uuid.New().String())
+			}, tt.args...)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+			mockClient.AssertNotCalled(t, "GetMessages", mock.Anything)
+		})
+	}
+}
@@ -32,6 +32,10 @@ Common workflow:
 	cmd.AddCommand(NewListCommand())
 	cmd.AddCommand(NewGetCommand())
 	cmd.AddCommand(NewCancelCommand())
+	cmd.AddCommand(NewSearchCommand())
+	cmd.AddCommand(NewAttemptsCommand())
+	cmd.AddCommand(NewContentCommand())
+	cmd.AddCommand(NewExportCommand())
 
 	return cmd
 }
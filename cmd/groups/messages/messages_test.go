@@ -5,12 +5,14 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/AhaSend/ahasend-cli/internal/printer"
 )
 
 func TestMessagesCommandStructure(t *testing.T) {
 	// Create a fresh messages command and verify it has expected subcommands
 	messagesCmd := NewCommand()
-	expectedSubcommands := []string{"send", "list", "cancel"}
+	expectedSubcommands := []string{"send", "list", "cancel", "search"}
 
 	subcommands := make([]string, 0)
 	for _, cmd := range messagesCmd.Commands() {
@@ -49,8 +51,8 @@ func TestMessagesCommand_SubcommandCount(t *testing.T) {
 	cmd := NewCommand()
 	subcommands := cmd.Commands()
 
-	// Should have exactly 3 subcommands
-	assert.Equal(t, 4, len(subcommands), "messages command should have exactly 3 subcommands")
+	// Should have exactly 8 subcommands
+	assert.Equal(t, 8, len(subcommands), "messages command should have exactly 8 subcommands")
 }
 
 // Benchmark tests
@@ -59,3 +61,10 @@ func BenchmarkMessagesCommand_Creation(b *testing.B) {
 		_ = NewCommand()
 	}
 }
+
+func TestMessageStatusesHaveSeverity(t *testing.T) {
+	for input, status := range messageStatuses {
+		_, known := printer.StatusSeverity(status)
+		assert.True(t, known, "message status %q (--status %s) has no severity; add it in internal/printer/severity.go", status, input)
+	}
+}
@@ -0,0 +1,161 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+)
+
+const (
+	// metadataHeaderPrefix is prepended to metadata keys to form the custom
+	// headers that carry metadata, as the API has no metadata field
+	metadataHeaderPrefix = "X-AhaSend-Meta-"
+
+	// maxMetadataKeyLength is the longest metadata key accepted
+	maxMetadataKeyLength = 64
+
+	// maxMetadataSize is the combined size in bytes of all keys and values
+	maxMetadataSize = 2048
+)
+
+var metadataKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// loadMetadata merges metadata from the --meta-file JSON object and the
+// --meta key=value flags. File entries are applied first and flags override
+// file entries with the same key. Keys are compared case-insensitively because
+// they are sent as headers; a key repeated within the file or within the
+// flags is rejected.
+func loadMetadata(metaFile string, metaFlags []string) (map[string]string, error) {
+	metadata := make(map[string]string)
+	keys := make(map[string]string) // lowercased key -> key as given
+
+	if metaFile != "" {
+		fileMetadata, err := loadMetadataFile(metaFile)
+		if err != nil {
+			return nil, err
+		}
+		// Sort so validation errors are reported deterministically
+		fileKeys := make([]string, 0, len(fileMetadata))
+		for key := range fileMetadata {
+			fileKeys = append(fileKeys, key)
+		}
+		sort.Strings(fileKeys)
+		for _, key := range fileKeys {
+			if existing, ok := keys[strings.ToLower(key)]; ok {
+				return nil, errors.NewValidationError(fmt.Sprintf("metadata file %s has duplicate keys '%s' and '%s' (keys are case-insensitive)", metaFile, existing, key), nil)
+			}
+			keys[strings.ToLower(key)] = key
+			metadata[key] = fileMetadata[key]
+		}
+	}
+
+	fromFlags := make(map[string]bool)
+	for _, pair := range metaFlags {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			return nil, errors.NewValidationError(fmt.Sprintf("invalid --meta '%s', expected key=value", pair), nil)
+		}
+		key, value := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+
+		lower := strings.ToLower(key)
+		if fromFlags[lower] {
+			return nil, errors.NewValidationError(fmt.Sprintf("metadata key '%s' is given more than once with --meta", key), nil)
+		}
+		fromFlags[lower] = true
+
+		if existing, ok := keys[lower]; ok {
+			logger.Get().WithField("key", key).Debug("--meta overrides metadata file value")
+			delete(metadata, existing)
+		}
+		keys[lower] = key
+		metadata[key] = value
+	}
+
+	if err := validateMetadata(metadata); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+// loadMetadataFile reads a JSON object of string values
+func loadMetadataFile(filePath string) (map[string]string, error) {
+	filePath = normalizeInputPath(filePath)
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, errors.NewFileError(fmt.Sprintf("cannot open metadata file %s", filePath), err)
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, errors.NewFileError("failed to parse metadata JSON (expected an object of string values)", err)
+	}
+
+	metadata := make(map[string]string, len(raw))
+	for key, value := range raw {
+		str, ok := value.(string)
+		if !ok {
+			return nil, errors.NewValidationError(fmt.Sprintf("metadata value for '%s' must be a string", key), nil)
+		}
+		metadata[key] = strings.TrimSpace(str)
+	}
+	return metadata, nil
+}
+
+// validateMetadata checks the key charset and length, rejects values that
+// cannot be sent in a header and enforces the total size
+func validateMetadata(metadata map[string]string) error {
+	keys := sortedMetadataKeys(metadata)
+	size := 0
+	for _, key := range keys {
+		value := metadata[key]
+		if !metadataKeyPattern.MatchString(key) {
+			return errors.NewValidationError(fmt.Sprintf("invalid metadata key '%s': only letters, digits, '-' and '_' are allowed", key), nil)
+		}
+		if len(key) > maxMetadataKeyLength {
+			return errors.NewValidationError(fmt.Sprintf("metadata key '%s' is longer than %d characters", key, maxMetadataKeyLength), nil)
+		}
+		if strings.ContainsAny(value, "\r\n") {
+			return errors.NewValidationError(fmt.Sprintf("metadata value for '%s' must not contain line breaks", key), nil)
+		}
+		size += len(key) + len(value)
+	}
+	if size > maxMetadataSize {
+		return errors.NewValidationError(fmt.Sprintf("metadata is %d bytes, the limit is %d bytes for all keys and values combined", size, maxMetadataSize), nil)
+	}
+	return nil
+}
+
+// metadataHeaders converts metadata to 'Header-Name: value' custom headers,
+// rejecting any that collide with a --header given by the user
+func metadataHeaders(metadata map[string]string, customHeaders []string) ([]string, error) {
+	existing := make(map[string]bool, len(customHeaders))
+	for _, header := range customHeaders {
+		name := strings.TrimSpace(strings.SplitN(header, ":", 2)[0])
+		existing[strings.ToLower(name)] = true
+	}
+
+	headers := make([]string, 0, len(metadata))
+	for _, key := range sortedMetadataKeys(metadata) {
+		name := metadataHeaderPrefix + key
+		if existing[strings.ToLower(name)] {
+			return nil, errors.NewValidationError(fmt.Sprintf("--header %s conflicts with metadata key '%s'", name, key), nil)
+		}
+		headers = append(headers, fmt.Sprintf("%s: %s", name, metadata[key]))
+	}
+	return headers, nil
+}
+
+func sortedMetadataKeys(metadata map[string]string) []string {
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
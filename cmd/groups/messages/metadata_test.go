@@ -0,0 +1,107 @@
+package messages
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeMetaFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "meta.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadMetadata_MergeOrder(t *testing.T) {
+	path := writeMetaFile(t, `{"order_id": "from-file", "customer": "acme", "Region": "eu"}`)
+
+	metadata, err := loadMetadata(path, []string{"order_id=12345", "region=us", "campaign=spring=2024"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"order_id": "12345",
+		"customer": "acme",
+		"region":   "us",
+		"campaign": "spring=2024",
+	}, metadata, "flags override file entries, matching keys case-insensitively")
+}
+
+func TestLoadMetadata_Duplicates(t *testing.T) {
+	_, err := loadMetadata("", []string{"order_id=1", "ORDER_ID=2"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "more than once")
+
+	path := writeMetaFile(t, `{"Order": "1", "order": "2"}`)
+	_, err = loadMetadata(path, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "duplicate keys 'Order' and 'order'")
+}
+
+func TestLoadMetadata_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		flags   []string
+		wantErr string
+	}{
+		{name: "missing separator", flags: []string{"order_id"}, wantErr: "expected key=value"},
+		{name: "empty key", flags: []string{"=value"}, wantErr: "invalid metadata key"},
+		{name: "invalid key charset", flags: []string{"order.id=1"}, wantErr: "invalid metadata key 'order.id'"},
+		{name: "key too long", flags: []string{strings.Repeat("k", 65) + "=1"}, wantErr: "longer than 64"},
+		{name: "line break in value", file: `{"note": "a\nb"}`, wantErr: "line breaks"},
+		{name: "non-string value", file: `{"count": 3}`, wantErr: "must be a string"},
+		{name: "total size", flags: []string{"a=" + strings.Repeat("x", 1500), "b=" + strings.Repeat("x", 1500)}, wantErr: "the limit is 2048 bytes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := ""
+			if tt.file != "" {
+				path = writeMetaFile(t, tt.file)
+			}
+			_, err := loadMetadata(path, tt.flags)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestCreateSendJobs_AttachesMetadataHeaders(t *testing.T) {
+	flags := &SendFlags{
+		FromEmail:     "sender@example.com",
+		ToEmails:      []string{"user@example.com"},
+		Subject:       "Receipt",
+		TextContent:   "Thanks",
+		CustomHeaders: []string{"X-Campaign: spring"},
+		Metadata:      map[string]string{"order_id": "12345"},
+	}
+
+	jobs, err := createSendJobsFromFlags(flags)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, map[string]string{
+		"X-Campaign":              "spring",
+		"X-AhaSend-Meta-order_id": "12345",
+	}, jobs[0].Request.Headers)
+	assert.Equal(t, []string{"X-Campaign: spring"}, flags.CustomHeaders, "flag headers are not modified")
+
+	flags.CustomHeaders = []string{"x-ahasend-meta-ORDER_ID: other"}
+	_, err = createSendJobsFromFlags(flags)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "conflicts with metadata key")
+}
+
+func TestListCommand_MetaFilterUnsupported(t *testing.T) {
+	cmd := NewListCommand()
+	cmd.SetArgs([]string{"--meta", "order_id=12345"})
+	cmd.SetOut(&strings.Builder{})
+	cmd.SetErr(&strings.Builder{})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "filtering messages by metadata is not supported")
+}
@@ -0,0 +1,137 @@
+package messages
+
+import (
+	"fmt"
+	"io"
+	"os/signal"
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/manifest"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/sink"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// outputFileFlags are the flags that choose where output goes rather than
+// what it holds, left out of a manifest's filters
+var outputFileFlags = map[string]bool{"output-file": true, "manifest": true}
+
+// writeOutputFile renders output into target, a local path or an s3:// or
+// gs:// URL, in the format of handler. A manifest of the written data goes
+// to manifestTarget when it is set. On an error or an interrupt the
+// partial output, including an incomplete upload, is removed.
+func writeOutputFile(cmd *cobra.Command, handler printer.ResponseHandler, target, manifestTarget string, render func(printer.ResponseHandler) error) error {
+	ctx, stop := signal.NotifyContext(cmd.Context(), interruptSignals...)
+	defer stop()
+
+	startedAt := time.Now()
+	out, err := sink.Open(ctx, target)
+	if err != nil {
+		return err
+	}
+
+	var digester *manifest.DigestWriter
+	format := manifestFormat(handler.GetFormat())
+	var writer io.Writer = out
+	if manifestTarget != "" {
+		digester = manifest.NewDigestWriter(format)
+		writer = io.MultiWriter(out, digester)
+	}
+
+	err = render(printer.GetResponseHandler(handler.GetFormat(), false, writer))
+	var digest *manifest.Digest
+	if digester != nil {
+		var digestErr error
+		if digest, digestErr = digester.Sum(); err == nil {
+			err = digestErr
+		}
+	}
+	if err == nil {
+		err = out.Close()
+	}
+	if err != nil {
+		if abortErr := out.Abort(); abortErr != nil {
+			logger.Get().WithError(abortErr).Warn("Failed to remove the partial output")
+		}
+		if ctx.Err() != nil {
+			return errors.NewInterruptedError(fmt.Sprintf("interrupted; nothing was written to %s", target), nil)
+		}
+		return err
+	}
+
+	if manifestTarget == "" {
+		return nil
+	}
+	m := manifest.New(cmd.CommandPath(), exportFilters(cmd), startedAt)
+	m.FinalizeDigest(sink.BaseName(target), format, digest)
+	return writeManifest(cmd, m, manifestTarget)
+}
+
+// writeManifest saves a manifest to a local path or an object storage URL
+func writeManifest(cmd *cobra.Command, m *manifest.Manifest, target string) error {
+	data, err := m.Marshal()
+	if err != nil {
+		return err
+	}
+	out, err := sink.Open(cmd.Context(), target)
+	if err != nil {
+		return err
+	}
+	if _, err = out.Write(data); err == nil {
+		err = out.Close()
+	}
+	if err != nil {
+		out.Abort()
+		return errors.NewFileError(fmt.Sprintf("failed to write manifest %s", target), err)
+	}
+	return nil
+}
+
+// manifestFormat is the manifest record format of an output format
+func manifestFormat(outputFormat string) string {
+	switch outputFormat {
+	case "json":
+		return manifest.FormatJSON
+	case "jsonl":
+		return manifest.FormatJSONL
+	case "csv":
+		return manifest.FormatCSV
+	}
+	return manifest.FormatLines
+}
+
+// exportFilters records the flags the command was given, except those that
+// only choose where the output goes
+func exportFilters(cmd *cobra.Command) map[string]string {
+	filters := map[string]string{}
+	inherited := cmd.InheritedFlags()
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		if !outputFileFlags[flag.Name] && inherited.Lookup(flag.Name) == nil {
+			filters[flag.Name] = flag.Value.String()
+		}
+	})
+	if len(filters) == 0 {
+		return nil
+	}
+	return filters
+}
+
+// validateOutputFile checks the --output-file and --manifest combination
+// before anything is fetched
+func validateOutputFile(cmd *cobra.Command) (string, string, error) {
+	target, _ := cmd.Flags().GetString("output-file")
+	manifestTarget, _ := cmd.Flags().GetString("manifest")
+	if manifestTarget != "" && target == "" {
+		return "", "", errors.NewValidationError("--manifest needs --output-file", nil)
+	}
+	if target != "" && target == manifestTarget {
+		return "", "", errors.NewValidationError("--manifest must differ from --output-file", nil)
+	}
+	if pick, _ := cmd.Flags().GetBool("pick"); pick && target != "" {
+		return "", "", errors.NewValidationError("--output-file cannot be combined with --pick", nil)
+	}
+	return target, manifestTarget, nil
+}
@@ -0,0 +1,108 @@
+package messages
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/manifest"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+)
+
+func executeList(t *testing.T, format string, args ...string) (*mocks.MockClient, string, error) {
+	t.Helper()
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetAccountID").Return(uuid.New().String())
+	mockClient.On("GetMessages", mock.Anything).Return(searchPage(false, "",
+		searchMessage("Welcome", "a@example.com"),
+		searchMessage("Receipt", "b@example.com"),
+	), nil)
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var stdout bytes.Buffer
+	cmd := NewListCommand()
+	handler := printer.GetResponseHandler(format, false, &stdout)
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return mockClient, stdout.String(), err
+}
+
+func TestListOutputFile_WithManifest(t *testing.T) {
+	dir := t.TempDir()
+	dataFile := filepath.Join(dir, "messages.csv")
+	manifestFile := filepath.Join(dir, "messages.manifest.json")
+
+	_, stdout, err := executeList(t, "csv", "--status", "delivered", "--output-file", dataFile, "--manifest", manifestFile)
+	require.NoError(t, err)
+	assert.Empty(t, stdout)
+
+	data, err := os.ReadFile(dataFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "Welcome")
+
+	m, err := manifest.Load(manifestFile)
+	require.NoError(t, err)
+	assert.Equal(t, "messages.csv", m.File)
+	assert.Equal(t, int64(2), m.Records)
+	assert.Equal(t, map[string]string{"status": "[delivered]"}, m.Filters)
+	_, mismatches, err := m.Verify(dataFile)
+	require.NoError(t, err)
+	assert.Empty(t, mismatches)
+}
+
+func TestListOutputFile_Validation(t *testing.T) {
+	mockClient, _, err := executeList(t, "json", "--manifest", "manifest.json")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--manifest needs --output-file")
+	mockClient.AssertNotCalled(t, "GetMessages", mock.Anything)
+
+	_, _, err = executeList(t, "json", "--output-file", "ftp://host/messages.json")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported output URL scheme")
+}
+
+func TestListOutputFile_JSON(t *testing.T) {
+	dir := t.TempDir()
+	dataFile := filepath.Join(dir, "messages.json")
+
+	_, stdout, err := executeList(t, "json", "--output-file", dataFile, "--manifest", dataFile+".manifest")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "Wrote 2 messages to "+dataFile)
+
+	m, err := manifest.Load(dataFile + ".manifest")
+	require.NoError(t, err)
+	assert.Equal(t, manifest.FormatJSON, m.Format)
+	assert.Equal(t, int64(2), m.Records)
+
+	// No partial files are left next to the output
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestListOutputFile_MissingDirectory(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "missing", "messages.csv")
+
+	_, stdout, err := executeList(t, "csv", "--output-file", dataFile)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot create")
+	assert.Empty(t, stdout)
+	assert.NoFileExists(t, dataFile)
+}
@@ -0,0 +1,26 @@
+package messages
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// normalizeInputPath returns a file path given on the command line in the
+// platform's form, accepting both / and \ as separators. On Windows every /
+// becomes \; elsewhere a path with \ that does not exist as written is read
+// with / instead, e.g. "templates\welcome.html" copied from a Windows guide.
+func normalizeInputPath(path string) string {
+	if path == "" {
+		return path
+	}
+	if filepath.Separator == '\\' {
+		return filepath.Clean(filepath.FromSlash(path))
+	}
+	if strings.Contains(path, `\`) {
+		if _, err := os.Stat(path); err != nil {
+			return filepath.Clean(strings.ReplaceAll(path, `\`, "/"))
+		}
+	}
+	return path
+}
@@ -0,0 +1,44 @@
+//go:build !windows
+
+package messages
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeInputPath_Backslashes(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "templates"), 0o700))
+	template := filepath.Join(dir, "templates", "welcome.html")
+	require.NoError(t, os.WriteFile(template, []byte("<h1>Hi</h1>"), 0o600))
+
+	// A Windows-style path that does not exist as written uses / instead
+	assert.Equal(t, template, normalizeInputPath(dir+`\templates\welcome.html`))
+	content, err := loadTemplateFile(dir+`\templates\welcome.html`, true)
+	require.NoError(t, err)
+	assert.Equal(t, "<h1>Hi</h1>", content)
+
+	// \ is a valid file name character here, so an existing file is kept
+	literal := filepath.Join(dir, `odd\name.html`)
+	require.NoError(t, os.WriteFile(literal, []byte("x"), 0o600))
+	assert.Equal(t, literal, normalizeInputPath(literal))
+
+	assert.Equal(t, "", normalizeInputPath(""))
+	assert.Equal(t, "templates/welcome.html", normalizeInputPath("templates/welcome.html"))
+}
+
+func TestProcessAttachments_BackslashPath(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "docs"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "docs", "invoice.pdf"), []byte("%PDF-1.4"), 0o600))
+
+	attachments, err := processAttachments([]string{dir + `\docs\invoice.pdf`})
+	require.NoError(t, err)
+	require.Len(t, attachments, 1)
+	assert.Equal(t, "invoice.pdf", attachments[0].FileName)
+}
@@ -0,0 +1,29 @@
+//go:build windows
+
+package messages
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeInputPath_Windows(t *testing.T) {
+	assert.Equal(t, `C:\templates\welcome.html`, normalizeInputPath(`C:/templates/welcome.html`))
+	assert.Equal(t, `C:\templates\welcome.html`, normalizeInputPath(`C:\templates/welcome.html`))
+	assert.Equal(t, `templates\welcome.html`, normalizeInputPath(`templates\welcome.html`))
+	assert.Equal(t, `\\server\share\list.csv`, normalizeInputPath(`//server/share/list.csv`))
+}
+
+func TestProcessAttachments_ForwardSlashPath(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "invoice.pdf"), []byte("%PDF-1.4"), 0o600))
+
+	attachments, err := processAttachments([]string{filepath.ToSlash(dir) + "/invoice.pdf"})
+	require.NoError(t, err)
+	require.Len(t, attachments, 1)
+	assert.Equal(t, "invoice.pdf", attachments[0].FileName)
+}
@@ -0,0 +1,128 @@
+package messages
+
+import (
+	stderrors "errors"
+	"fmt"
+	"os"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/pager"
+	"github.com/AhaSend/ahasend-cli/internal/picker"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/spf13/cobra"
+)
+
+// chooser is the prompt messages list --pick uses to choose a message and
+// then an action. picker.Terminal implements it; tests script the choices.
+type chooser interface {
+	Choose(title string, options []string) (int, error)
+}
+
+// newChooser returns the terminal prompt, or an error when stdin or stdout
+// is not a terminal; it is replaced in tests
+var newChooser = func() (chooser, error) {
+	if !printer.IsTerminal(os.Stdin) || !printer.IsTerminal(os.Stdout) {
+		return nil, errors.NewValidationError("--pick needs an interactive terminal; in scripts, pipe JSON output instead, e.g. ahasend messages list --output json | jq -r '.data[].id' | xargs -n1 ahasend messages get", nil)
+	}
+	return &picker.Terminal{In: os.Stdin, Out: os.Stdout}, nil
+}
+
+// pickAction is a follow-up that messages list --pick can run on a message
+type pickAction struct {
+	Name        string
+	Description string
+	run         func(cmd *cobra.Command, apiClient client.AhaSendClient, messageID string) error
+}
+
+var pickActions = []pickAction{
+	{Name: "get", Description: "Show the message details", run: subcommandAction(NewGetCommand)},
+	{Name: "attempts", Description: "Show the delivery attempts", run: subcommandAction(NewAttemptsCommand)},
+	{Name: "content", Description: "Print the raw message content", run: printMessageContent},
+	{Name: "cancel", Description: "Cancel the message if it is scheduled", run: subcommandAction(NewCancelCommand)},
+}
+
+// validatePick checks that --pick can prompt before anything is fetched. The
+// picker replaces the table, so other output formats are refused, including
+// those chosen by an output override or the profile default.
+func validatePick(cmd *cobra.Command) (chooser, error) {
+	if format := printer.GetResponseHandlerFromCommand(cmd).GetFormat(); format != "table" {
+		return nil, errors.NewValidationError(fmt.Sprintf("--pick works with table output, not %s; to chain commands in scripts, pipe --output json into the next command", format), nil)
+	}
+	return newChooser()
+}
+
+// runPick lets the user choose one of the listed messages and a follow-up
+// action, then runs the action in-process with the same client
+func runPick(cmd *cobra.Command, apiClient client.AhaSendClient, messages []responses.Message, prompt chooser) error {
+	if len(messages) == 0 {
+		return printer.GetResponseHandlerFromCommand(cmd).HandleEmpty("No messages found matching criteria")
+	}
+	// The prompts draw on the terminal, and the action's output follows them
+	if err := pager.StopBuffering(cmd); err != nil {
+		return err
+	}
+
+	rows := make([]string, len(messages))
+	for i, message := range messages {
+		rows[i] = fmt.Sprintf("%s  %-18s  %-30s  %s", message.ID, message.Status, message.Recipient, message.Subject)
+	}
+	choice, err := prompt.Choose(fmt.Sprintf("Select a message (%d shown)", len(messages)), rows)
+	if err != nil {
+		return pickCancelled(cmd, err)
+	}
+	message := messages[choice]
+
+	options := make([]string, len(pickActions))
+	for i, action := range pickActions {
+		options[i] = fmt.Sprintf("%-9s %s", action.Name, action.Description)
+	}
+	choice, err = prompt.Choose(fmt.Sprintf("Message %s to %s: choose an action", message.ID, message.Recipient), options)
+	if err != nil {
+		return pickCancelled(cmd, err)
+	}
+
+	return pickActions[choice].run(cmd, apiClient, message.ID.String())
+}
+
+// pickCancelled ends --pick quietly when the user quits a prompt
+func pickCancelled(cmd *cobra.Command, err error) error {
+	if stderrors.Is(err, picker.ErrCancelled) {
+		fmt.Fprintln(cmd.ErrOrStderr(), "Nothing selected")
+		return nil
+	}
+	return err
+}
+
+// subcommandAction runs a messages subcommand on the message ID, reusing the
+// parent's client, output handler and streams
+func subcommandAction(newCommand func() *cobra.Command) func(*cobra.Command, client.AhaSendClient, string) error {
+	return func(parent *cobra.Command, apiClient client.AhaSendClient, messageID string) error {
+		sub := newCommand()
+		sub.SilenceErrors = true // the root command reports the error
+		sub.SetContext(auth.WithClient(parent.Context(), apiClient))
+		sub.SetIn(parent.InOrStdin())
+		sub.SetOut(parent.OutOrStdout())
+		sub.SetErr(parent.ErrOrStderr())
+		sub.SetArgs([]string{messageID})
+		return sub.Execute()
+	}
+}
+
+// printMessageContent writes the raw content of a message to stdout
+func printMessageContent(cmd *cobra.Command, apiClient client.AhaSendClient, messageID string) error {
+	message, err := apiClient.GetMessage(messageID)
+	if err != nil {
+		return err
+	}
+	if message == nil {
+		return errors.NewNotFoundError(fmt.Sprintf("message '%s' not found", messageID), nil)
+	}
+	if message.Content == nil || *message.Content == "" {
+		return printer.GetResponseHandlerFromCommand(cmd).HandleEmpty(fmt.Sprintf("Message '%s' has no stored content", messageID))
+	}
+	_, err = fmt.Fprintln(cmd.OutOrStdout(), *message.Content)
+	return err
+}
@@ -0,0 +1,135 @@
+package messages
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/picker"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+)
+
+// scriptedChooser answers each prompt with the next choice and records what
+// it was shown
+type scriptedChooser struct {
+	choices []int
+	err     error
+	titles  []string
+	options [][]string
+}
+
+func (s *scriptedChooser) Choose(title string, options []string) (int, error) {
+	s.titles = append(s.titles, title)
+	s.options = append(s.options, options)
+	if len(s.choices) == 0 {
+		return 0, s.err
+	}
+	choice := s.choices[0]
+	s.choices = s.choices[1:]
+	return choice, nil
+}
+
+func pickCommand(t *testing.T) (*cobra.Command, *bytes.Buffer, *bytes.Buffer) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	cmd := &cobra.Command{Use: "list"}
+	handler := printer.GetResponseHandler("plain", false, &stdout)
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	return cmd, &stdout, &stderr
+}
+
+func pickMessages() []responses.Message {
+	return []responses.Message{
+		{ID: uuid.New(), Recipient: "a@example.com", Status: "Delivered", Subject: "Hello"},
+		{ID: uuid.New(), Recipient: "b@example.com", Status: "Bounced", Subject: "Welcome"},
+	}
+}
+
+func TestRunPick_DispatchesAction(t *testing.T) {
+	messages := pickMessages()
+	target := messages[1]
+	content := "Subject: Welcome\r\n\r\nHi"
+
+	t.Run("content", func(t *testing.T) {
+		cmd, stdout, _ := pickCommand(t)
+		mockClient := &mocks.MockClient{}
+		mockClient.On("GetMessage", target.ID.String()).Return(&responses.Message{ID: target.ID, Content: &content}, nil).Once()
+		prompt := &scriptedChooser{choices: []int{1, 2}}
+
+		require.NoError(t, runPick(cmd, mockClient, messages, prompt))
+		assert.Equal(t, content+"\n", stdout.String())
+		require.Len(t, prompt.options, 2)
+		assert.Contains(t, prompt.options[0][1], "b@example.com")
+		assert.Contains(t, prompt.titles[1], target.ID.String())
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("get runs in-process with the same client", func(t *testing.T) {
+		// Any other client resolution would fail the test
+		restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+			t.Fatal("the subcommand authenticated again")
+			return nil, nil
+		})
+		t.Cleanup(restore)
+
+		cmd, stdout, _ := pickCommand(t)
+		mockClient := &mocks.MockClient{}
+		mockClient.On("GetMessage", target.ID.String()).Return(&target, nil).Once()
+
+		require.NoError(t, runPick(cmd, mockClient, messages, &scriptedChooser{choices: []int{1, 0}}))
+		assert.Contains(t, stdout.String(), "b@example.com")
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestRunPick_Cancelled(t *testing.T) {
+	cmd, stdout, stderr := pickCommand(t)
+	mockClient := &mocks.MockClient{}
+
+	err := runPick(cmd, mockClient, pickMessages(), &scriptedChooser{choices: []int{0}, err: picker.ErrCancelled})
+	require.NoError(t, err)
+	assert.Equal(t, "Nothing selected\n", stderr.String())
+	assert.Empty(t, stdout.String())
+	mockClient.AssertNotCalled(t, "GetMessage", mock.Anything)
+}
+
+func TestListPick_Validation(t *testing.T) {
+	withFormat := func(format string) *cobra.Command {
+		cmd := NewListCommand()
+		handler := printer.GetResponseHandler(format, false, &bytes.Buffer{})
+		cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+		return cmd
+	}
+
+	t.Run("other formats are refused", func(t *testing.T) {
+		// The handler's format covers --output, output overrides and the
+		// profile default alike
+		for _, format := range []string{"json", "csv", "plain"} {
+			_, err := validatePick(withFormat(format))
+			require.Error(t, err, format)
+			assert.Contains(t, err.Error(), "--pick works with table output, not "+format)
+		}
+	})
+
+	t.Run("no terminal", func(t *testing.T) {
+		if printer.IsTerminal(os.Stdin) && printer.IsTerminal(os.Stdout) {
+			t.Skip("running in a terminal")
+		}
+		_, err := validatePick(withFormat("table"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--output json")
+	})
+}
@@ -0,0 +1,295 @@
+package messages
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/validation"
+	ahasend "github.com/AhaSend/ahasend-go"
+	"github.com/AhaSend/ahasend-go/models/common"
+)
+
+// maxRecipientErrors caps how many invalid records are listed when a
+// recipients file fails validation
+const maxRecipientErrors = 50
+
+// recipientErrors collects the problems found in a recipients file so they
+// can be reported together instead of one per run
+type recipientErrors struct {
+	problems []string
+}
+
+func (e *recipientErrors) add(location, format string, args ...interface{}) {
+	e.problems = append(e.problems, location+": "+fmt.Sprintf(format, args...))
+}
+
+// err returns a validation error listing the collected problems, or nil
+func (e *recipientErrors) err() error {
+	if len(e.problems) == 0 {
+		return nil
+	}
+
+	var message strings.Builder
+	if len(e.problems) == 1 {
+		message.WriteString("recipients file has 1 invalid record:")
+	} else {
+		fmt.Fprintf(&message, "recipients file has %d invalid records:", len(e.problems))
+	}
+	for i, problem := range e.problems {
+		if i == maxRecipientErrors {
+			fmt.Fprintf(&message, "\n  ... and %d more", len(e.problems)-maxRecipientErrors)
+			break
+		}
+		message.WriteString("\n  " + problem)
+	}
+	return errors.NewValidationError(message.String(), nil)
+}
+
+// loadRecipientsFromFile loads recipients from JSON or CSV file. With strict
+// set, JSON records may only contain known fields.
+func loadRecipientsFromFile(filePath string, strict bool) ([]recipientEntry, error) {
+	filePath = normalizeInputPath(filePath)
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, errors.NewFileError(fmt.Sprintf("cannot open recipients file %s", filePath), err)
+	}
+	defer file.Close()
+
+	ext := strings.ToLower(filepath.Ext(filePath))
+	switch ext {
+	case ".json":
+		return loadRecipientsFromJSON(file, strict)
+	case ".csv":
+		return loadRecipientsFromCSV(file)
+	default:
+		return nil, errors.NewValidationError(fmt.Sprintf("unsupported recipients file format %s (supported: .json, .csv)", ext), nil)
+	}
+}
+
+// loadRecipientsFromJSON parses recipients from a JSON array. Syntax errors
+// are reported with their line and column; invalid records are collected and
+// reported together with their array index.
+func loadRecipientsFromJSON(r io.Reader, strict bool) ([]recipientEntry, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, errors.NewFileError("cannot read JSON recipients file", err)
+	}
+
+	var records []json.RawMessage
+	if err := json.Unmarshal(content, &records); err != nil {
+		var syntaxErr *json.SyntaxError
+		if stderrors.As(err, &syntaxErr) {
+			line, column := jsonPosition(content, syntaxErr.Offset)
+			return nil, errors.NewValidationError(fmt.Sprintf("invalid JSON in recipients file at line %d, column %d: %s", line, column, syntaxErr), nil)
+		}
+		return nil, errors.NewValidationError("recipients file must contain a JSON array of recipient objects", nil)
+	}
+
+	var problems recipientErrors
+	var recipients []recipientEntry
+	for i, record := range records {
+		location := fmt.Sprintf("index %d", i)
+		if !bytes.HasPrefix(bytes.TrimSpace(record), []byte("{")) {
+			problems.add(location, "recipient must be a JSON object")
+			continue
+		}
+
+		var data RecipientData
+		decoder := json.NewDecoder(bytes.NewReader(record))
+		if strict {
+			decoder.DisallowUnknownFields()
+		}
+		if err := decoder.Decode(&data); err != nil {
+			problems.add(location, "%s", describeRecipientDecodeError(err))
+			continue
+		}
+
+		email, ok := checkRecipientEmail(&problems, location, data.Email)
+		if !ok {
+			continue
+		}
+
+		recipient := common.Recipient{
+			Email: email,
+		}
+		if data.Name != "" {
+			recipient.Name = ahasend.String(data.Name)
+		}
+		if len(data.Substitutions) > 0 {
+			recipient.Substitutions = data.Substitutions
+		}
+		recipients = append(recipients, recipientEntry{
+			recipient: recipient,
+			location:  location,
+			fields:    data.Substitutions,
+			sendAt:    strings.TrimSpace(data.SendAt),
+			timezone:  strings.TrimSpace(data.Timezone),
+		})
+	}
+
+	if err := problems.err(); err != nil {
+		return nil, err
+	}
+	return recipients, nil
+}
+
+// describeRecipientDecodeError turns a record decode error into a message
+// that names the offending field
+func describeRecipientDecodeError(err error) string {
+	var typeErr *json.UnmarshalTypeError
+	if stderrors.As(err, &typeErr) {
+		if typeErr.Field == "substitutions" {
+			return "substitutions must be an object"
+		}
+		return fmt.Sprintf("%s must be a string", typeErr.Field)
+	}
+	// The decoder reports unknown fields as `json: unknown field "x"`
+	return strings.TrimPrefix(err.Error(), "json: ")
+}
+
+// jsonPosition converts the byte offset of a json.SyntaxError into a 1-based
+// line and column. The offset points just past the offending byte.
+func jsonPosition(content []byte, offset int64) (int, int) {
+	if offset > 0 {
+		offset--
+	}
+	if offset > int64(len(content)) {
+		offset = int64(len(content))
+	}
+	before := content[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := int(offset) - bytes.LastIndexByte(before, '\n')
+	return line, column
+}
+
+// checkRecipientEmail validates and normalizes a recipient email, recording
+// a problem when it is missing or invalid
+func checkRecipientEmail(problems *recipientErrors, location, email string) (string, bool) {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		problems.add(location, "missing email")
+		return "", false
+	}
+	if err := validation.ValidateEmail(email); err != nil {
+		problems.add(location, "invalid email %q: %v", email, err)
+		return "", false
+	}
+	normalized, err := validation.NormalizeEmail(email)
+	if err != nil {
+		problems.add(location, "invalid email %q: %v", email, err)
+		return "", false
+	}
+	return normalized, true
+}
+
+// loadRecipientsFromCSV parses recipients from CSV file. Malformed CSV is
+// reported with its line and column; rows with the wrong number of fields or
+// a missing or invalid email are collected and reported together.
+func loadRecipientsFromCSV(r io.Reader) ([]recipientEntry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1 // field counts are checked per row below
+	records, err := reader.ReadAll()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if stderrors.As(err, &parseErr) {
+			return nil, errors.NewValidationError(fmt.Sprintf("invalid CSV in recipients file at line %d, column %d: %v", parseErr.Line, parseErr.Column, parseErr.Err), nil)
+		}
+		return nil, errors.NewFileError("failed to parse CSV recipients file", err)
+	}
+
+	if len(records) < 2 { // Need header + at least one data row
+		return nil, errors.NewValidationError("CSV file must have at least a header row and one data row", nil)
+	}
+
+	headers := records[0]
+	var emailIndex, nameIndex, sendAtIndex, timezoneIndex = -1, -1, -1, -1
+
+	// Find required and scheduling columns
+	for i, header := range headers {
+		switch strings.ToLower(strings.TrimSpace(header)) {
+		case "email":
+			emailIndex = i
+		case "name":
+			nameIndex = i
+		case "send_at":
+			sendAtIndex = i
+		case "timezone":
+			timezoneIndex = i
+		}
+	}
+
+	if emailIndex == -1 {
+		return nil, errors.NewValidationError("CSV file must have an 'email' column", nil)
+	}
+
+	var problems recipientErrors
+	var recipients []recipientEntry
+	for i, record := range records[1:] { // Skip header row
+		location := fmt.Sprintf("row %d", i+2)
+		if len(record) != len(headers) {
+			problems.add(location, "expected %d fields, found %d", len(headers), len(record))
+			continue
+		}
+
+		email, ok := checkRecipientEmail(&problems, location, record[emailIndex])
+		if !ok {
+			continue
+		}
+
+		recipient := common.Recipient{
+			Email: email,
+		}
+
+		// Set name if column exists
+		if nameIndex >= 0 {
+			name := strings.TrimSpace(record[nameIndex])
+			if name != "" {
+				recipient.Name = ahasend.String(name)
+			}
+		}
+
+		// Create substitution data from remaining columns. Empty values are
+		// not substituted but kept in fields, e.g. for --subject-from-field.
+		substitutionData := make(map[string]interface{})
+		fields := make(map[string]interface{})
+		for j, header := range headers {
+			if j != emailIndex && j != nameIndex && j != sendAtIndex && j != timezoneIndex {
+				key := strings.TrimSpace(header)
+				value := strings.TrimSpace(record[j])
+				if key == "" {
+					continue
+				}
+				fields[key] = value
+				if value != "" {
+					substitutionData[key] = value
+				}
+			}
+		}
+
+		if len(substitutionData) > 0 {
+			recipient.Substitutions = substitutionData
+		}
+
+		entry := recipientEntry{recipient: recipient, location: location, fields: fields}
+		if sendAtIndex >= 0 {
+			entry.sendAt = strings.TrimSpace(record[sendAtIndex])
+		}
+		if timezoneIndex >= 0 {
+			entry.timezone = strings.TrimSpace(record[timezoneIndex])
+		}
+		recipients = append(recipients, entry)
+	}
+
+	if err := problems.err(); err != nil {
+		return nil, err
+	}
+	return recipients, nil
+}
@@ -0,0 +1,107 @@
+package messages
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadRecipientsFromFile_JSON(t *testing.T) {
+	path := writeRecipientsFile(t, "recipients.json", `[
+  {"email": "A@Example.com", "name": "Ada", "substitutions": {"first_name": "Ada"}},
+  {"email": "b@example.com", "send_at": "2026-03-02T09:00:00", "timezone": "Europe/Berlin"}
+]`)
+
+	entries, err := loadRecipientsFromFile(path, true)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "A@example.com", entries[0].recipient.Email)
+	assert.Equal(t, "Ada", *entries[0].recipient.Name)
+	assert.Equal(t, map[string]interface{}{"first_name": "Ada"}, entries[0].recipient.Substitutions)
+	assert.Equal(t, "Europe/Berlin", entries[1].timezone)
+}
+
+func TestLoadRecipientsFromFile_JSONSyntaxErrorPosition(t *testing.T) {
+	path := writeRecipientsFile(t, "recipients.json", "[\n  {\"email\": \"a@example.com\"},\n  {\"email\" \"b@example.com\"}\n]")
+
+	_, err := loadRecipientsFromFile(path, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid JSON in recipients file at line 3, column 12")
+}
+
+func TestLoadRecipientsFromFile_JSONNotAnArray(t *testing.T) {
+	path := writeRecipientsFile(t, "recipients.json", `{"email": "a@example.com"}`)
+
+	_, err := loadRecipientsFromFile(path, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must contain a JSON array")
+}
+
+func TestLoadRecipientsFromFile_JSONCollectsRecordErrors(t *testing.T) {
+	path := writeRecipientsFile(t, "recipients.json", `[
+  {"email": "ok@example.com"},
+  {"name": "No Email"},
+  {"email": "not-an-email"},
+  {"email": "c@example.com", "substitutions": "Ada"},
+  "d@example.com",
+  {"email": "e@example.com", "substitution_data": {"first_name": "Eve"}}
+]`)
+
+	_, err := loadRecipientsFromFile(path, false)
+	require.Error(t, err)
+	message := err.Error()
+	assert.Contains(t, message, "recipients file has 4 invalid records")
+	assert.Contains(t, message, "index 1: missing email")
+	assert.Contains(t, message, `index 2: invalid email "not-an-email"`)
+	assert.Contains(t, message, "index 3: substitutions must be an object")
+	assert.Contains(t, message, "index 4: recipient must be a JSON object")
+	assert.NotContains(t, message, "index 5", "unknown fields are allowed unless strict")
+
+	_, err = loadRecipientsFromFile(path, true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "recipients file has 5 invalid records")
+	assert.Contains(t, err.Error(), `index 5: unknown field "substitution_data"`)
+}
+
+func TestLoadRecipientsFromFile_CapsErrorList(t *testing.T) {
+	records := make([]string, maxRecipientErrors+7)
+	for i := range records {
+		records[i] = fmt.Sprintf(`{"email": "bad-%d"}`, i)
+	}
+	path := writeRecipientsFile(t, "recipients.json", "["+strings.Join(records, ",")+"]")
+
+	_, err := loadRecipientsFromFile(path, false)
+	require.Error(t, err)
+	message := err.Error()
+	assert.Contains(t, message, fmt.Sprintf("index %d:", maxRecipientErrors-1))
+	assert.NotContains(t, message, fmt.Sprintf("index %d:", maxRecipientErrors))
+	assert.True(t, strings.HasSuffix(message, "... and 7 more"))
+}
+
+func TestLoadRecipientsFromFile_CSVCollectsRowErrors(t *testing.T) {
+	path := writeRecipientsFile(t, "recipients.csv",
+		"email,name,first_name\n"+
+			"a@example.com,Ada,Ada\n"+
+			",Nobody,Nobody\n"+
+			"b@example.com,Bob\n"+
+			"not-an-email,Eve,Eve\n")
+
+	_, err := loadRecipientsFromFile(path, false)
+	require.Error(t, err)
+	message := err.Error()
+	assert.Contains(t, message, "recipients file has 3 invalid records")
+	assert.Contains(t, message, "row 3: missing email")
+	assert.Contains(t, message, "row 4: expected 3 fields, found 2")
+	assert.Contains(t, message, `row 5: invalid email "not-an-email"`)
+}
+
+func TestLoadRecipientsFromFile_CSVParseErrorPosition(t *testing.T) {
+	path := writeRecipientsFile(t, "recipients.csv", "email,name\na@example.com,\"Ada\n")
+
+	_, err := loadRecipientsFromFile(path, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid CSV in recipients file at line 2")
+}
@@ -0,0 +1,62 @@
+package messages
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/AhaSend/ahasend-cli/internal/batch"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+)
+
+// validateStateFlags checks the --state-file and --resume combination
+func validateStateFlags(flags *SendFlags) error {
+	if flags.Resume && flags.StateFile == "" {
+		return errors.NewValidationError("--resume requires --state-file", nil)
+	}
+	return nil
+}
+
+// openBatchState returns the state the batches are recorded in, or nil
+// without --state-file. With --resume the recorded state is loaded and the
+// jobs take over its idempotency keys; otherwise a new state is created,
+// refusing to replace the state file of an earlier send.
+func openBatchState(flags *SendFlags, jobs []*batch.SendJob) (*batch.State, error) {
+	if flags.StateFile == "" {
+		return nil, nil
+	}
+	if flags.Resume {
+		state, err := batch.LoadState(flags.StateFile)
+		if err != nil {
+			return nil, err
+		}
+		if err := state.Resume(jobs); err != nil {
+			return nil, err
+		}
+		return state, nil
+	}
+
+	if _, err := os.Stat(flags.StateFile); err == nil {
+		return nil, errors.NewValidationError(fmt.Sprintf(
+			"state file %s already exists; add --resume to continue that send, or remove the file to start a new one", flags.StateFile), nil)
+	}
+	return batch.NewState(flags.StateFile, jobs), nil
+}
+
+// pendingJobs returns the jobs still to send: all of them, or those state
+// does not record as sent
+func pendingJobs(state *batch.State, jobs []*batch.SendJob) []*batch.SendJob {
+	if state == nil {
+		return jobs
+	}
+	pending, _ := state.Pending(jobs)
+	return pending
+}
+
+// skippedSummary describes the batches a resumed send skipped, e.g.
+// "; skipped 3 messages already sent (300 recipients)", or "" when none were
+func skippedSummary(result *batch.BatchResult) string {
+	if result.SkippedJobs == 0 {
+		return ""
+	}
+	return fmt.Sprintf("; skipped %d messages already sent (%d recipients)", result.SkippedJobs, result.SkippedRecipients)
+}
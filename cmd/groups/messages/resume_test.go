@@ -0,0 +1,80 @@
+package messages
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/batch"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+)
+
+// stateJobs returns jobs of two recipients each, numbered and keyed like
+// createSendJobs does
+func stateJobs(key string, emails ...string) []*batch.SendJob {
+	jobs := fingerprintJobs("<p>News</p>", 2, emails...)
+	for i, job := range jobs {
+		job.BatchIndex = i
+		job.IdempotencyKey = fmt.Sprintf("%s-batch-%d", key, i)
+	}
+	return jobs
+}
+
+func TestOpenBatchState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "campaign.json")
+	emails := []string{"a@example.com", "b@example.com", "c@example.com"}
+
+	state, err := openBatchState(&SendFlags{}, stateJobs("first", emails...))
+	require.NoError(t, err)
+	assert.Nil(t, state, "no state without --state-file")
+
+	jobs := stateJobs("first", emails...)
+	state, err = openBatchState(&SendFlags{StateFile: path}, jobs)
+	require.NoError(t, err)
+	state.Record(jobs[0], batch.BatchSent, nil)
+	require.NoError(t, state.Save())
+
+	_, err = openBatchState(&SendFlags{StateFile: path}, stateJobs("second", emails...))
+	assert.ErrorContains(t, err, "already exists; add --resume")
+
+	resumed := stateJobs("second", emails...)
+	state, err = openBatchState(&SendFlags{StateFile: path, Resume: true}, resumed)
+	require.NoError(t, err)
+	assert.Equal(t, "first-batch-1", resumed[1].IdempotencyKey)
+	assert.Equal(t, []*batch.SendJob{resumed[1]}, pendingJobs(state, resumed))
+
+	_, err = openBatchState(&SendFlags{StateFile: path, Resume: true}, stateJobs("second", "a@example.com", "x@example.com", "c@example.com"))
+	assert.ErrorContains(t, err, "the recipients of batch 1 differ")
+
+	assert.ErrorContains(t, validateStateFlags(&SendFlags{Resume: true}), "--resume requires --state-file")
+}
+
+func TestFormatBatchResponse_Skipped(t *testing.T) {
+	var out bytes.Buffer
+	result := &batch.BatchResult{
+		TotalJobs:           2,
+		SuccessfulJobs:      2,
+		SuccessfulResponses: []*responses.CreateMessageResponse{recipientResults("queued"), recipientResults("queued")},
+		SkippedJobs:         3,
+		SkippedRecipients:   300,
+	}
+	require.NoError(t, formatBatchResponse(printer.GetResponseHandler("plain", false, &out), result, &SendFlags{}))
+	assert.Contains(t, out.String(), "Successfully sent all 2 messages; skipped 3 messages already sent (300 recipients)")
+
+	out.Reset()
+	result = &batch.BatchResult{SkippedJobs: 3, SkippedRecipients: 300}
+	require.NoError(t, formatBatchResponse(printer.GetResponseHandler("plain", false, &out), result, &SendFlags{}))
+	assert.Contains(t, out.String(), "Nothing left to send: all 3 messages were already sent (300 recipients)")
+
+	result = &batch.BatchResult{TotalRecipients: 200, SuccessfulRecipients: 100, SuccessfulResponses: []*responses.CreateMessageResponse{{}, {}},
+		NotSentRecipients: 100, SkippedRecipients: 300, Interrupted: true}
+	err := formatBatchResponse(printer.GetResponseHandler("plain", false, &bytes.Buffer{}), result, &SendFlags{StateFile: "campaign.json"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "300 skipped (already sent)")
+	assert.Contains(t, err.Error(), "continue with --resume --state-file campaign.json")
+}
@@ -0,0 +1,236 @@
+package messages
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/models/common"
+
+	"github.com/AhaSend/ahasend-cli/internal/batch"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+)
+
+const (
+	// defaultScheduleGranularity is the interval per-recipient send times are
+	// rounded up to, so nearby times share a batch
+	defaultScheduleGranularity = 15 * time.Minute
+
+	// maxScheduleHorizon is how far ahead the API accepts schedule.first_attempt
+	maxScheduleHorizon = 7 * 24 * time.Hour
+
+	// localTimeLayout is a send_at without a UTC offset, read in the recipient's timezone
+	localTimeLayout = "2006-01-02T15:04:05"
+)
+
+// scheduleNow is replaced in tests to pin the current time
+var scheduleNow = time.Now
+
+// recipientEntry is a parsed recipient with its optional send time override
+type recipientEntry struct {
+	recipient common.Recipient
+	location  string                 // index or row in the recipients file, for errors
+	fields    map[string]interface{} // substitution fields, including empty CSV values
+	sendAt    string
+	timezone  string
+}
+
+// scheduleBucket holds the recipients sent at one schedule time. A nil SendAt
+// sends immediately, or at --schedule when set. A non-empty Subject replaces
+// the request subject for the bucket.
+type scheduleBucket struct {
+	SendAt     *time.Time
+	Subject    string
+	Recipients []common.Recipient
+}
+
+// hasScheduleOverrides reports whether any recipient sets send_at or timezone
+func hasScheduleOverrides(entries []recipientEntry) bool {
+	for _, entry := range entries {
+		if entry.sendAt != "" || entry.timezone != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// bucketRecipients groups recipients by their effective send time. Recipient
+// times are rounded up to granularity; recipients without an override share
+// the nil bucket. Buckets are ordered by time, the nil bucket first.
+func bucketRecipients(entries []recipientEntry, scheduleTime string, granularity time.Duration) ([]scheduleBucket, error) {
+	times, err := resolveSendTimes(entries, scheduleTime, granularity)
+	if err != nil {
+		return nil, err
+	}
+	return groupBySendTime(entries, times), nil
+}
+
+// resolveSendTimes returns each recipient's send time rounded up to
+// granularity, or nil for recipients without an override. Times must be in
+// the future and within the API's scheduling limit.
+func resolveSendTimes(entries []recipientEntry, scheduleTime string, granularity time.Duration) ([]*time.Time, error) {
+	if granularity <= 0 {
+		return nil, errors.NewValidationError("--schedule-granularity must be greater than zero", nil)
+	}
+
+	var base *time.Time
+	if scheduleTime != "" {
+		parsed, err := time.Parse(time.RFC3339, scheduleTime)
+		if err != nil {
+			return nil, errors.NewValidationError("invalid schedule time format (use RFC3339)", err)
+		}
+		base = &parsed
+	}
+
+	now := scheduleNow()
+	times := make([]*time.Time, len(entries))
+	for i, entry := range entries {
+		sendAt, err := resolveSendTime(entry, base)
+		if err != nil {
+			return nil, errors.NewValidationError(fmt.Sprintf("recipient %d (%s): %s", i+1, entry.recipient.Email, err.Error()), nil)
+		}
+		if sendAt == nil {
+			continue
+		}
+
+		rounded := roundUpTime(*sendAt, granularity)
+		if !rounded.After(now) {
+			return nil, errors.NewValidationError(fmt.Sprintf("recipient %d (%s): send time %s is in the past", i+1, entry.recipient.Email, sendAt.Format(time.RFC3339)), nil)
+		}
+		if rounded.Sub(now) > maxScheduleHorizon {
+			return nil, errors.NewValidationError(fmt.Sprintf("recipient %d (%s): send time %s is more than 7 days ahead, the API's scheduling limit", i+1, entry.recipient.Email, rounded.Format(time.RFC3339)), nil)
+		}
+		times[i] = &rounded
+	}
+	return times, nil
+}
+
+// groupBySendTime groups recipients by their resolved send time, the nil
+// bucket first and the others ordered by time. A nil times slice puts every
+// recipient in the nil bucket.
+func groupBySendTime(entries []recipientEntry, times []*time.Time) []scheduleBucket {
+	byTime := make(map[int64]*scheduleBucket)
+	var immediate *scheduleBucket
+	for i, entry := range entries {
+		var sendAt *time.Time
+		if times != nil {
+			sendAt = times[i]
+		}
+
+		if sendAt == nil {
+			if immediate == nil {
+				immediate = &scheduleBucket{}
+			}
+			immediate.Recipients = append(immediate.Recipients, entry.recipient)
+			continue
+		}
+
+		bucket, ok := byTime[sendAt.Unix()]
+		if !ok {
+			bucket = &scheduleBucket{SendAt: sendAt}
+			byTime[sendAt.Unix()] = bucket
+		}
+		bucket.Recipients = append(bucket.Recipients, entry.recipient)
+	}
+
+	var buckets []scheduleBucket
+	if immediate != nil {
+		buckets = append(buckets, *immediate)
+	}
+	timed := make([]scheduleBucket, 0, len(byTime))
+	for _, bucket := range byTime {
+		timed = append(timed, *bucket)
+	}
+	sort.Slice(timed, func(i, j int) bool { return timed[i].SendAt.Before(*timed[j].SendAt) })
+	return append(buckets, timed...)
+}
+
+// resolveSendTime returns a recipient's send time, or nil when it has no
+// override. A send_at with a UTC offset is absolute; one without is read in
+// the recipient's timezone. A timezone alone applies the --schedule date and
+// clock time in that timezone, e.g. 09:00 local for every recipient.
+func resolveSendTime(entry recipientEntry, base *time.Time) (*time.Time, error) {
+	if entry.sendAt == "" && entry.timezone == "" {
+		return nil, nil
+	}
+
+	var location *time.Location
+	if entry.timezone != "" {
+		loc, err := time.LoadLocation(entry.timezone)
+		if err != nil {
+			return nil, fmt.Errorf("unknown timezone %q", entry.timezone)
+		}
+		location = loc
+	}
+
+	if entry.sendAt == "" {
+		if base == nil {
+			return nil, fmt.Errorf("timezone %s needs a send_at or --schedule to take the local time from", entry.timezone)
+		}
+		local := time.Date(base.Year(), base.Month(), base.Day(), base.Hour(), base.Minute(), base.Second(), 0, location)
+		return &local, nil
+	}
+
+	if sendAt, err := time.Parse(time.RFC3339, entry.sendAt); err == nil {
+		return &sendAt, nil
+	}
+	if location == nil {
+		return nil, fmt.Errorf("invalid send_at %q (use RFC3339, or a local time with a timezone)", entry.sendAt)
+	}
+	sendAt, err := time.ParseInLocation(localTimeLayout, entry.sendAt, location)
+	if err != nil {
+		return nil, fmt.Errorf("invalid send_at %q (use RFC3339 or %s)", entry.sendAt, localTimeLayout)
+	}
+	return &sendAt, nil
+}
+
+// roundUpTime rounds t up to the next multiple of granularity
+func roundUpTime(t time.Time, granularity time.Duration) time.Time {
+	rounded := t.Truncate(granularity)
+	if rounded.Before(t) {
+		rounded = rounded.Add(granularity)
+	}
+	return rounded.UTC()
+}
+
+// scheduleBucketCount is the number of recipients sent at one schedule time
+type scheduleBucketCount struct {
+	SendAt     *time.Time
+	Recipients int
+}
+
+// countScheduleBuckets totals the recipients of each distinct schedule time
+// across jobs, in job order
+func countScheduleBuckets(jobs []*batch.SendJob) []scheduleBucketCount {
+	var counts []scheduleBucketCount
+	index := make(map[string]int)
+	for _, job := range jobs {
+		var sendAt *time.Time
+		key := "immediate"
+		if job.Request.Schedule != nil && job.Request.Schedule.FirstAttempt != nil {
+			sendAt = job.Request.Schedule.FirstAttempt
+			key = sendAt.UTC().Format(time.RFC3339)
+		}
+		if i, ok := index[key]; ok {
+			counts[i].Recipients += job.RecipientCount
+			continue
+		}
+		index[key] = len(counts)
+		counts = append(counts, scheduleBucketCount{SendAt: sendAt, Recipients: job.RecipientCount})
+	}
+	return counts
+}
+
+// formatScheduleBuckets lists bucket times with their recipient counts, one per line
+func formatScheduleBuckets(counts []scheduleBucketCount, indent string) string {
+	var lines []string
+	for _, count := range counts {
+		when := "immediately"
+		if count.SendAt != nil {
+			when = count.SendAt.UTC().Format(time.RFC3339)
+		}
+		lines = append(lines, fmt.Sprintf("%s%-20s %d recipients", indent, when, count.Recipients))
+	}
+	return strings.Join(lines, "\n")
+}
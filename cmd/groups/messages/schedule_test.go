@@ -0,0 +1,150 @@
+package messages
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pinScheduleNow fixes the time send times are validated against
+func pinScheduleNow(t *testing.T, now time.Time) {
+	t.Helper()
+	prev := scheduleNow
+	scheduleNow = func() time.Time { return now }
+	t.Cleanup(func() { scheduleNow = prev })
+}
+
+func writeRecipientsFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func scheduledSendFlags(recipientsFile string) *SendFlags {
+	return &SendFlags{
+		FromEmail:           "news@example.com",
+		RecipientsFile:      recipientsFile,
+		Subject:             "Good morning",
+		TextContent:         "Hello",
+		ScheduleGranularity: defaultScheduleGranularity,
+	}
+}
+
+func TestCreateSendJobs_ScheduleBuckets(t *testing.T) {
+	pinScheduleNow(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+
+	recipients := writeRecipientsFile(t, "recipients.json", `[
+		{"email": "ny@example.com", "timezone": "America/New_York"},
+		{"email": "ny2@example.com", "timezone": "America/New_York"},
+		{"email": "berlin@example.com", "timezone": "Europe/Berlin"},
+		{"email": "exact@example.com", "send_at": "2026-03-02T09:07:00Z"},
+		{"email": "local@example.com", "send_at": "2026-03-02T09:00:00", "timezone": "Asia/Tokyo"},
+		{"email": "now@example.com"}
+	]`)
+	flags := scheduledSendFlags(recipients)
+	flags.ScheduleTime = "2026-03-02T09:00:00Z"
+
+	jobs, err := createSendJobsFromFlags(flags)
+	require.NoError(t, err)
+
+	// --schedule applies to recipients without an override
+	ny := time.Date(2026, 3, 2, 14, 0, 0, 0, time.UTC)
+	berlin := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+	global := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	exact := time.Date(2026, 3, 2, 9, 15, 0, 0, time.UTC) // rounded up from 09:07
+	tokyo := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	require.Len(t, jobs, 5)
+	var got []time.Time
+	for i, job := range jobs {
+		require.NotNil(t, job.Request.Schedule)
+		got = append(got, job.Request.Schedule.FirstAttempt.UTC())
+		assert.Equal(t, i, job.BatchIndex)
+	}
+	assert.Equal(t, []time.Time{global, tokyo, berlin, exact, ny}, got)
+	assert.Equal(t, "now@example.com", jobs[0].Recipients[0].Email)
+	assert.Equal(t, 2, jobs[4].RecipientCount)
+
+	require.Len(t, flags.ScheduleBuckets, 5)
+	assert.Equal(t, 2, flags.ScheduleBuckets[4].Recipients)
+	assert.Contains(t, formatScheduleBuckets(flags.ScheduleBuckets, ""), "2026-03-02T14:00:00Z 2 recipients")
+}
+
+func TestCreateSendJobs_CSVScheduleColumns(t *testing.T) {
+	pinScheduleNow(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+
+	recipients := writeRecipientsFile(t, "recipients.csv",
+		"email,first_name,send_at,timezone\n"+
+			"a@example.com,Ann,2026-03-02T09:00:00,America/Chicago\n"+
+			"b@example.com,Bob,,\n")
+
+	jobs, err := createSendJobsFromFlags(scheduledSendFlags(recipients))
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+
+	assert.Nil(t, jobs[0].Request.Schedule, "recipients without an override send immediately")
+	assert.Equal(t, map[string]interface{}{"first_name": "Bob"}, jobs[0].Recipients[0].Substitutions)
+	assert.Equal(t, time.Date(2026, 3, 2, 15, 0, 0, 0, time.UTC), jobs[1].Request.Schedule.FirstAttempt.UTC())
+	assert.Equal(t, map[string]interface{}{"first_name": "Ann"}, jobs[1].Recipients[0].Substitutions)
+}
+
+func TestCreateSendJobs_NoOverridesKeepsSingleBucket(t *testing.T) {
+	recipients := writeRecipientsFile(t, "recipients.json", `[{"email": "a@example.com"}, {"email": "b@example.com"}]`)
+	flags := scheduledSendFlags(recipients)
+
+	jobs, err := createSendJobsFromFlags(flags)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, 2, jobs[0].RecipientCount)
+	assert.Empty(t, flags.ScheduleBuckets)
+}
+
+func TestCreateSendJobs_InvalidSendTimes(t *testing.T) {
+	pinScheduleNow(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name       string
+		recipients string
+		schedule   string
+		want       string
+	}{
+		{"past", `[{"email": "a@example.com", "send_at": "2026-02-28T09:00:00Z"}]`, "", "recipient 1 (a@example.com): send time 2026-02-28T09:00:00Z is in the past"},
+		{"beyond horizon", `[{"email": "a@example.com", "send_at": "2026-03-09T09:00:00Z"}]`, "", "more than 7 days ahead"},
+		{"unknown timezone", `[{"email": "a@example.com", "timezone": "Mars/Olympus"}]`, "2026-03-02T09:00:00Z", `unknown timezone "Mars/Olympus"`},
+		{"timezone without time", `[{"email": "a@example.com", "timezone": "Europe/Paris"}]`, "", "needs a send_at or --schedule"},
+		{"local time without timezone", `[{"email": "a@example.com", "send_at": "2026-03-02T09:00:00"}]`, "", "invalid send_at"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := scheduledSendFlags(writeRecipientsFile(t, "recipients.json", tt.recipients))
+			flags.ScheduleTime = tt.schedule
+
+			_, err := createSendJobsFromFlags(flags)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestConfirmLargeSend_ListsScheduleBuckets(t *testing.T) {
+	out := stubConfirmIO(t, true, "y\n")
+	first := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+	flags := largeSendFlags()
+	flags.ScheduleBuckets = []scheduleBucketCount{
+		{Recipients: 400},
+		{SendAt: &first, Recipients: 700},
+	}
+
+	require.NoError(t, confirmLargeSend(flags, 1100))
+	assert.Contains(t, out.String(), "Schedule:   2 buckets")
+	assert.Contains(t, out.String(), "immediately")
+	assert.Contains(t, out.String(), "2026-03-02T08:00:00Z 700 recipients")
+	assert.False(t, bytes.Contains(out.Bytes(), []byte("Schedule:   immediately")))
+}
@@ -0,0 +1,347 @@
+package messages
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/output"
+	"github.com/AhaSend/ahasend-cli/internal/pager"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	ahasend "github.com/AhaSend/ahasend-go"
+	"github.com/AhaSend/ahasend-go/models/common"
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/spf13/cobra"
+)
+
+// NewSearchCommand creates the search command
+func NewSearchCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "search [query]",
+		Short: "Search messages by subject or recipient",
+		Long: `Search messages by subject or recipient text.
+
+The search is performed client-side: pages of messages in the requested time
+window are fetched from the API and matched locally. The query is matched
+case-insensitively as a substring of the subject or recipient. With --fuzzy,
+near matches (typos, transpositions) are also accepted when their similarity
+is at or above --threshold (0.0-1.0).
+
+Matches are printed page by page as they are found for table and plain
+output. JSON and CSV output are emitted once the scan completes.
+
+The scan stops after --max-scan messages. A summary of how many messages were
+scanned and matched is printed at the end (to stderr for json and csv output).
+
+Time values accept RFC3339, a date like 2024-06-01 (read in --timezone; --to
+covers the whole day) or relative durations like "24h", "7d" or "-7d".`,
+		Example: `  # Search subjects and recipients for a phrase
+  ahasend messages search "password reset"
+
+  # Only keep matches sent to a domain in the last week
+  ahasend messages search "password reset" --recipient-contains @acme.com --from -7d
+
+  # Tolerate typos in the query
+  ahasend messages search "pasword reset" --fuzzy --threshold 0.75
+
+  # Scan at most 10,000 messages
+  ahasend messages search "invoice" --max-scan 10000`,
+		Args:         cobra.MaximumNArgs(1),
+		RunE:         runMessagesSearch,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().String("recipient-contains", "", "Only match messages whose recipient contains this text")
+	cmd.Flags().String("from", "", "Search messages created after this time (RFC3339 or relative like '24h', '-7d')")
+	cmd.Flags().String("to", "", "Search messages created before this time (RFC3339 or relative)")
+	cmd.Flags().String("timezone", "", "Timezone for date-only --from/--to values, e.g. 'UTC' (default: local)")
+	cmd.Flags().Bool("fuzzy", false, "Also accept approximate matches")
+	cmd.Flags().Float64("threshold", 0.8, "Minimum similarity for fuzzy matches (0.0-1.0)")
+	cmd.Flags().Int("max-scan", 50000, "Maximum number of messages to scan")
+	cmd.Flags().Int("page-size", 100, "Number of messages fetched per page (1-100)")
+
+	return cmd
+}
+
+// messageMatcher decides whether a message matches the search criteria
+type messageMatcher struct {
+	query             string
+	recipientContains string
+	fuzzy             bool
+	threshold         float64
+}
+
+func newMessageMatcher(query, recipientContains string, fuzzy bool, threshold float64) *messageMatcher {
+	return &messageMatcher{
+		query:             strings.ToLower(strings.TrimSpace(query)),
+		recipientContains: strings.ToLower(strings.TrimSpace(recipientContains)),
+		fuzzy:             fuzzy,
+		threshold:         threshold,
+	}
+}
+
+// Match reports whether the message satisfies the query and recipient filter
+func (m *messageMatcher) Match(message responses.Message) bool {
+	recipient := strings.ToLower(message.Recipient)
+	if m.recipientContains != "" && !strings.Contains(recipient, m.recipientContains) {
+		return false
+	}
+	if m.query == "" {
+		return true
+	}
+
+	subject := strings.ToLower(message.Subject)
+	if strings.Contains(subject, m.query) || strings.Contains(recipient, m.query) {
+		return true
+	}
+	if !m.fuzzy {
+		return false
+	}
+	return fuzzySimilarity(m.query, subject) >= m.threshold ||
+		fuzzySimilarity(m.query, recipient) >= m.threshold
+}
+
+// fuzzySimilarity returns the best similarity (0.0-1.0) between the query and
+// any window of the text with a length close to the query's length
+func fuzzySimilarity(query, text string) float64 {
+	q := []rune(query)
+	t := []rune(text)
+	if len(q) == 0 {
+		return 1
+	}
+	if len(t) == 0 {
+		return 0
+	}
+
+	best := 0.0
+	for size := len(q) - 1; size <= len(q)+1; size++ {
+		if size < 1 {
+			continue
+		}
+		if size > len(t) {
+			size = len(t)
+		}
+		for start := 0; start+size <= len(t); start++ {
+			distance := levenshtein(q, t[start:start+size])
+			longest := len(q)
+			if size > longest {
+				longest = size
+			}
+			score := 1 - float64(distance)/float64(longest)
+			if score > best {
+				best = score
+				if best == 1 {
+					return best
+				}
+			}
+		}
+		if size == len(t) {
+			break
+		}
+	}
+	return best
+}
+
+// levenshtein computes the edit distance between two rune slices
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// parseSearchTime accepts the same formats as messages list plus a leading
+// "-" on relative durations (e.g. "-7d"). A date-only value means the start
+// of that day in loc, or its end when end is set.
+func parseSearchTime(value string, loc *time.Location, end bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parse := output.ParseTimeStart
+	if end {
+		parse = output.ParseTimeEnd
+	}
+	t, err := parse(strings.TrimPrefix(value, "-"), loc)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func runMessagesSearch(cmd *cobra.Command, args []string) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+
+	client, err := auth.GetAuthenticatedClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	var query string
+	if len(args) > 0 {
+		query = args[0]
+	}
+	recipientContains, _ := cmd.Flags().GetString("recipient-contains")
+	fromStr, _ := cmd.Flags().GetString("from")
+	toStr, _ := cmd.Flags().GetString("to")
+	timezone, _ := cmd.Flags().GetString("timezone")
+	fuzzy, _ := cmd.Flags().GetBool("fuzzy")
+	threshold, _ := cmd.Flags().GetFloat64("threshold")
+	maxScan, _ := cmd.Flags().GetInt("max-scan")
+	pageSize, _ := cmd.Flags().GetInt("page-size")
+
+	if strings.TrimSpace(query) == "" && strings.TrimSpace(recipientContains) == "" {
+		return errors.NewValidationError("a search query or --recipient-contains is required", nil)
+	}
+	if threshold < 0 || threshold > 1 {
+		return errors.NewValidationError("threshold must be between 0.0 and 1.0", nil)
+	}
+	if maxScan < 1 {
+		return errors.NewValidationError("max-scan must be at least 1", nil)
+	}
+	if pageSize < 1 || pageSize > 100 {
+		return errors.NewValidationError("page-size must be between 1 and 100", nil)
+	}
+
+	loc, err := output.LoadTimezone(timezone)
+	if err != nil {
+		return err
+	}
+	fromTime, err := parseSearchTime(fromStr, loc, false)
+	if err != nil {
+		return err
+	}
+	toTime, err := parseSearchTime(toStr, loc, true)
+	if err != nil {
+		return err
+	}
+
+	logger.Get().WithFields(map[string]interface{}{
+		"query":              query,
+		"recipient_contains": recipientContains,
+		"from_time":          fromTime,
+		"to_time":            toTime,
+		"fuzzy":              fuzzy,
+		"threshold":          threshold,
+		"max_scan":           maxScan,
+	}).Debug("Searching messages")
+
+	matcher := newMessageMatcher(query, recipientContains, fuzzy, threshold)
+	listConfig := printer.ListConfig{
+		SuccessMessage: "Matching messages (client-side search, not a server query)",
+		EmptyMessage:   "No messages matched the search (client-side search, not a server query)",
+		FieldOrder:     []string{"id", "sender", "recipient", "subject", "status", "created", "delivered", "opens", "clicks"},
+	}
+
+	// Table and plain output stream each page's matches as they are found;
+	// structured formats need a single document so matches are collected
+	format := handler.GetFormat()
+	stream := format == "table" || format == "plain"
+	if stream {
+		// Matches are printed page by page, so they are not held back for the pager
+		if err := pager.StopBuffering(cmd); err != nil {
+			return err
+		}
+	}
+	summaryWriter := cmd.OutOrStdout()
+	if !stream {
+		summaryWriter = cmd.ErrOrStderr()
+	}
+
+	collected := &responses.PaginatedMessagesResponse{Object: "list", Data: []responses.Message{}}
+	scanned, matched := 0, 0
+	var cursor *string
+
+	for scanned < maxScan {
+		limit := pageSize
+		if remaining := maxScan - scanned; remaining < limit {
+			limit = remaining
+		}
+
+		response, err := client.GetMessages(requests.GetMessagesParams{
+			FromTime: fromTime,
+			ToTime:   toTime,
+			PaginationParams: common.PaginationParams{
+				Limit:  ahasend.Int32(int32(limit)),
+				Cursor: cursor,
+			},
+		})
+		if err != nil {
+			return err
+		}
+		if response == nil {
+			return errors.NewAPIError("received nil response from API", nil)
+		}
+
+		written := matched
+		var pageMatches []responses.Message
+		for _, message := range response.Data {
+			if scanned >= maxScan {
+				break
+			}
+			scanned++
+			if matcher.Match(message) {
+				pageMatches = append(pageMatches, message)
+			}
+		}
+		matched += len(pageMatches)
+
+		if stream {
+			if len(pageMatches) > 0 {
+				// The success message heads the first page of matches only
+				pageConfig := listConfig
+				pageConfig.Continuation = written > 0
+				if err := handler.HandleMessageList(&responses.PaginatedMessagesResponse{
+					Object: "list",
+					Data:   pageMatches,
+				}, pageConfig); err != nil {
+					return err
+				}
+			}
+		} else {
+			collected.Data = append(collected.Data, pageMatches...)
+		}
+
+		if !response.Pagination.HasMore || response.Pagination.NextCursor == nil || *response.Pagination.NextCursor == "" {
+			break
+		}
+		cursor = response.Pagination.NextCursor
+	}
+
+	if !stream {
+		if err := handler.HandleMessageList(collected, listConfig); err != nil {
+			return err
+		}
+	} else if matched == 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s\n", listConfig.EmptyMessage)
+	}
+
+	writeSearchSummary(summaryWriter, scanned, matched, maxScan)
+	return nil
+}
+
+// writeSearchSummary reports how many messages were scanned versus matched
+func writeSearchSummary(w io.Writer, scanned, matched, maxScan int) {
+	fmt.Fprintf(w, "\nScanned %d messages, matched %d (client-side search, not a server query)", scanned, matched)
+	if scanned >= maxScan {
+		fmt.Fprintf(w, "; stopped at --max-scan %d", maxScan)
+	}
+	fmt.Fprintf(w, "\n")
+}
@@ -0,0 +1,249 @@
+package messages
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/models/common"
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/pager"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+)
+
+func executeSearch(t *testing.T, mockClient *mocks.MockClient, format string, args ...string) (string, string, error) {
+	t.Helper()
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var stdout, stderr bytes.Buffer
+	cmd := NewSearchCommand()
+	handler := printer.GetResponseHandler(format, false, &stdout)
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return stdout.String(), stderr.String(), err
+}
+
+func searchPage(hasMore bool, next string, messages ...responses.Message) *responses.PaginatedMessagesResponse {
+	page := &responses.PaginatedMessagesResponse{
+		Object:     "list",
+		Data:       messages,
+		Pagination: common.PaginationInfo{HasMore: hasMore},
+	}
+	if next != "" {
+		page.Pagination.NextCursor = &next
+	}
+	return page
+}
+
+func searchMessage(subject, recipient string) responses.Message {
+	return responses.Message{ID: uuid.New(), Subject: subject, Recipient: recipient, Sender: "noreply@example.com"}
+}
+
+func TestSearchCommand_Structure(t *testing.T) {
+	cmd := NewSearchCommand()
+	assert.Equal(t, "search", cmd.Name())
+	assert.NotEmpty(t, cmd.Long)
+	assert.NotEmpty(t, cmd.Example)
+
+	for _, flag := range []string{"recipient-contains", "from", "to", "fuzzy", "threshold", "max-scan", "page-size"} {
+		assert.NotNil(t, cmd.Flags().Lookup(flag), "missing flag %s", flag)
+	}
+	assert.Equal(t, "50000", cmd.Flags().Lookup("max-scan").DefValue)
+}
+
+func TestMessageMatcher(t *testing.T) {
+	tests := []struct {
+		name      string
+		matcher   *messageMatcher
+		message   responses.Message
+		wantMatch bool
+	}{
+		{
+			name:      "case-insensitive subject substring",
+			matcher:   newMessageMatcher("Password Reset", "", false, 0.8),
+			message:   searchMessage("Your password reset link", "a@acme.com"),
+			wantMatch: true,
+		},
+		{
+			name:      "recipient substring",
+			matcher:   newMessageMatcher("acme.com", "", false, 0.8),
+			message:   searchMessage("Hello", "a@acme.com"),
+			wantMatch: true,
+		},
+		{
+			name:      "typo without fuzzy does not match",
+			matcher:   newMessageMatcher("pasword reset", "", false, 0.8),
+			message:   searchMessage("Your password reset link", "a@acme.com"),
+			wantMatch: false,
+		},
+		{
+			name:      "typo with fuzzy matches",
+			matcher:   newMessageMatcher("pasword reset", "", true, 0.8),
+			message:   searchMessage("Your password reset link", "a@acme.com"),
+			wantMatch: true,
+		},
+		{
+			name:      "unrelated text with fuzzy does not match",
+			matcher:   newMessageMatcher("invoice overdue", "", true, 0.8),
+			message:   searchMessage("Your password reset link", "a@acme.com"),
+			wantMatch: false,
+		},
+		{
+			name:      "recipient filter excludes",
+			matcher:   newMessageMatcher("password", "@acme.com", false, 0.8),
+			message:   searchMessage("Password reset", "a@other.com"),
+			wantMatch: false,
+		},
+		{
+			name:      "recipient filter only",
+			matcher:   newMessageMatcher("", "@ACME.com", false, 0.8),
+			message:   searchMessage("Anything", "a@acme.com"),
+			wantMatch: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantMatch, tt.matcher.Match(tt.message))
+		})
+	}
+}
+
+func TestFuzzySimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, fuzzySimilarity("reset", "password reset"))
+	assert.InDelta(t, 0.8, fuzzySimilarity("rest", "reset"), 0.01)
+	assert.Equal(t, 0.0, fuzzySimilarity("abc", ""))
+	assert.Equal(t, 3, levenshtein([]rune("kitten"), []rune("sitting")))
+}
+
+func TestSearchCommand_ScansPagesAndReportsCounts(t *testing.T) {
+	for _, format := range []string{"plain", "table"} {
+		t.Run(format, func(t *testing.T) {
+			mockClient := &mocks.MockClient{}
+			mockClient.On("GetMessages", mock.MatchedBy(func(p requests.GetMessagesParams) bool {
+				return p.Cursor == nil
+			})).Return(searchPage(true, "page2",
+				searchMessage("Password reset", "a@acme.com"),
+				searchMessage("Welcome", "b@acme.com"),
+			), nil).Once()
+			mockClient.On("GetMessages", mock.MatchedBy(func(p requests.GetMessagesParams) bool {
+				return p.Cursor != nil && *p.Cursor == "page2"
+			})).Return(searchPage(false, "",
+				searchMessage("Your password reset link", "c@other.com"),
+			), nil).Once()
+
+			stdout, _, err := executeSearch(t, mockClient, format, "password reset")
+			require.NoError(t, err)
+			assert.Equal(t, 1, strings.Count(stdout, "Matching messages (client-side search, not a server query)"),
+				"the success message heads the first page only")
+			assert.Contains(t, stdout, "a@acme.com")
+			assert.Contains(t, stdout, "c@other.com")
+			assert.NotContains(t, stdout, "b@acme.com")
+			assert.Contains(t, stdout, "Scanned 3 messages, matched 2")
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSearchCommand_StreamsPastThePager(t *testing.T) {
+	restoreTerminal := pager.SetTerminalForTesting(24)
+	t.Cleanup(restoreTerminal)
+	mockClient := &mocks.MockClient{}
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var stdout bytes.Buffer
+	cmd := NewSearchCommand()
+	cmd.SetContext(context.Background())
+	pager.Attach(cmd, pager.NewWriter(&stdout, &bytes.Buffer{}, pager.ModeAlways, "cat"))
+	handler := printer.GetResponseHandler("plain", false, cmd.OutOrStdout())
+	cmd.SetContext(context.WithValue(cmd.Context(), printer.ResponseHandlerKey, handler))
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"password reset"})
+
+	mockClient.On("GetMessages", mock.MatchedBy(func(p requests.GetMessagesParams) bool {
+		return p.Cursor == nil
+	})).Return(searchPage(true, "page2", searchMessage("Password reset", "a@acme.com")), nil).Once()
+	mockClient.On("GetMessages", mock.MatchedBy(func(p requests.GetMessagesParams) bool {
+		return p.Cursor != nil
+	})).Run(func(mock.Arguments) {
+		assert.Contains(t, stdout.String(), "a@acme.com", "the first page is shown before the next is fetched")
+	}).Return(searchPage(false, ""), nil).Once()
+
+	require.NoError(t, cmd.Execute())
+	mockClient.AssertExpectations(t)
+}
+
+func TestSearchCommand_MaxScanStopsEarly(t *testing.T) {
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetMessages", mock.MatchedBy(func(p requests.GetMessagesParams) bool {
+		return p.Limit != nil && *p.Limit == 2
+	})).Return(searchPage(true, "next",
+		searchMessage("Password reset", "a@acme.com"),
+		searchMessage("Password reset", "b@acme.com"),
+	), nil).Once()
+
+	stdout, stderr, err := executeSearch(t, mockClient, "json", "password", "--max-scan", "2")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "a@acme.com")
+	assert.Contains(t, stderr, "Scanned 2 messages, matched 2")
+	assert.Contains(t, stderr, "--max-scan 2")
+	mockClient.AssertExpectations(t)
+}
+
+func TestSearchCommand_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "no query", args: []string{}, want: "search query"},
+		{name: "threshold out of range", args: []string{"x", "--threshold", "1.5"}, want: "threshold"},
+		{name: "max-scan zero", args: []string{"x", "--max-scan", "0"}, want: "max-scan"},
+		{name: "page-size too large", args: []string{"x", "--page-size", "500"}, want: "page-size"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := executeSearch(t, &mocks.MockClient{}, "plain", tt.args...)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestParseSearchTime(t *testing.T) {
+	tm, err := parseSearchTime("", time.UTC, false)
+	require.NoError(t, err)
+	assert.Nil(t, tm)
+
+	withDash, err := parseSearchTime("-7d", time.UTC, false)
+	require.NoError(t, err)
+	withoutDash, err := parseSearchTime("7d", time.UTC, false)
+	require.NoError(t, err)
+	assert.WithinDuration(t, *withoutDash, *withDash, 1e9)
+
+	end, err := parseSearchTime("2024-06-01", time.UTC, true)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 6, 1, 23, 59, 59, 999999999, time.UTC), *end)
+}
@@ -5,17 +5,18 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/base64"
-	"encoding/csv"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"mime"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/AhaSend/ahasend-cli/internal/accountstatus"
 	"github.com/AhaSend/ahasend-cli/internal/auth"
 	"github.com/AhaSend/ahasend-cli/internal/batch"
 	"github.com/AhaSend/ahasend-cli/internal/client"
@@ -23,13 +24,24 @@ import (
 	"github.com/AhaSend/ahasend-cli/internal/logger"
 	"github.com/AhaSend/ahasend-cli/internal/printer"
 	"github.com/AhaSend/ahasend-cli/internal/progress"
+	"github.com/AhaSend/ahasend-cli/internal/sender"
 	"github.com/AhaSend/ahasend-cli/internal/validation"
 	ahasend "github.com/AhaSend/ahasend-go"
 	"github.com/AhaSend/ahasend-go/models/common"
 	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
 	"github.com/spf13/cobra"
 )
 
+// rejectedOutput receives the partial failure warning; replaced in tests
+var rejectedOutput io.Writer = os.Stderr
+
+// sandboxWarningOutput receives the scheduled sandbox warning; replaced in tests
+var sandboxWarningOutput io.Writer = os.Stderr
+
+// metricsOutput receives the --show-metrics report; replaced in tests
+var metricsOutput io.Writer = os.Stderr
+
 // NewSendCommand creates the send command
 func NewSendCommand() *cobra.Command {
 	cmd := &cobra.Command{
@@ -40,19 +52,32 @@ You can send plain text, HTML, or AMP emails with templates, per-recipient subst
 custom headers, and scheduling options.
 
 The sender email address must be from a verified domain in your AhaSend account.
+When --from is omitted, the profile's default_from is used (set it with
+'ahasend config set default-from noreply@mydomain.com'), and otherwise you are
+prompted for it; without a terminal the send fails instead.
 
 RECIPIENT OPTIONS:
   --to: Use multiple times for simple recipient list (supports global substitutions only)
   --recipients: Use JSON/CSV file for recipients with per-recipient substitutions
+  --cc, --bcc: Also send a copy to these addresses (can be used multiple times)
   Note: --to and --recipients are mutually exclusive
 
+CC AND BCC:
+  With --cc or --bcc, the message is sent as one conversation message: every
+  --to address is in its To header, --cc addresses in its Cc header, and
+  --bcc addresses get a copy without being shown. --to, --cc and --bcc can
+  list 50 addresses together. An address given more than once gets a single
+  copy. Conversation messages take no substitutions, and --cc and --bcc
+  cannot be used with --recipients, where each recipient gets its own
+  message, or with --to-me.
+
 RECIPIENTS FILE FORMATS:
   JSON format:
     [
       {
         "email": "user1@example.com",
         "name": "John Doe",
-        "substitution_data": {
+        "substitutions": {
           "first_name": "John",
           "order_id": "12345"
         }
@@ -64,25 +89,189 @@ RECIPIENTS FILE FORMATS:
     user1@example.com,John Doe,John,12345
     user2@example.com,Jane Smith,Jane,12346
 
+  Every record is checked before anything is sent, and all invalid records
+  (missing or invalid emails, non-object substitutions, rows with the wrong
+  number of columns) are reported together with their index or row number.
+  JSON syntax errors are reported with their line and column. Use
+  --strict-recipients-schema to also reject unknown fields in JSON records,
+  such as a misspelled "substitutions".
+
+GLOBAL SUBSTITUTIONS:
+  --global-substitutions takes a JSON file, or an inline JSON object when the
+  value starts with '{':
+    --global-substitutions '{"company_name": "AhaSend", "year": 2026}'
+  --substitute KEY=VALUE sets one global variable (can be used multiple
+  times). Values that are JSON numbers or true/false are sent as numbers and
+  booleans; --substitute-raw-strings keeps every value a string.
+  Precedence: --substitute values override --global-substitutions, and a
+  later --substitute overrides an earlier one with the same key.
+
+SUBSTITUTION DEFAULTS:
+  --substitution-default KEY=VALUE sets a fallback for a substitution that is
+  missing or empty for a recipient, e.g. an empty first_name CSV column
+  (can be used multiple times). The global substitutions file may also hold
+  a "defaults" object; --substitution-default overrides its entries.
+  Precedence: per-recipient values, then global values, then defaults.
+    {"company_name": "AhaSend", "defaults": {"first_name": "valued customer"}}
+
 CONTENT OPTIONS:
   Direct content: --text, --html, --amp (string values)
   Template files: --text-template, --html-template, --amp-template (file paths)
   Multiple content types can be used together for multipart emails
 
+TEMPLATE INCLUDES:
+  Template files may include other files, such as a shared header and footer:
+    {{include "partials/header.html"}}
+  Paths are resolved relative to the directory of the including file, and
+  included files may include others, up to 10 levels deep. An include cycle
+  fails with an error naming the files in the cycle. Includes are expanded
+  before anything else reads the template. Use --no-includes to send
+  templates that contain the directive as literal text.
+
 ATTACHMENT OPTIONS:
   --attach: File paths to attach (can be used multiple times, max 10MB per file)
   Supports all file types with automatic MIME type detection
   Files are automatically Base64 encoded for transmission
+  --inline path[:content-id]: Attach an image inline, for <img src="cid:...">
+    in the HTML. The Content-ID defaults to the file name. Every cid:
+    reference in the HTML must have an inline file; a missing one prints a
+    warning, and so does an inline file the HTML does not reference.
+  --strict-inline: Fail instead of warning about a missing cid: reference
+  --calendar-invite FILE.ics: Send a meeting invitation. The file must be an
+    iCalendar object with at least one VEVENT, and at most 1MB. It is sent as
+    a text/calendar; method=REQUEST part next to the text and HTML bodies,
+    which mail clients show with Accept and Decline buttons, and attached as
+    a .ics file for clients that only offer a download. A METHOD other than
+    REQUEST in the file is rejected.
+
+CONTENT SIZE:
+  Before sending, the content is rendered for the first recipient (plain
+  {{name}} substitutions only) and measured with attachments as encoded in
+  the message. Gmail clips messages with more than about 102 KB of HTML, so
+  an HTML part over --max-html-size (default: 100KB) prints a warning with
+  its actual size.
+  --max-html-size: Size limit with an optional KB or MB suffix (0 disables)
+  --strict-size: Fail instead of warning
+
+PER-RECIPIENT SCHEDULING:
+  Recipients files may set "send_at" and "timezone" per recipient (JSON
+  fields or CSV columns) to deliver at each recipient's local time:
+    send_at with a UTC offset (RFC3339)  delivered at that instant
+    send_at without an offset            read in the recipient's timezone,
+                                         e.g. "2024-12-01T09:00:00"
+    timezone only                        the --schedule date and clock time in
+                                         that timezone, e.g. 09:00 local
+  Times are rounded up to --schedule-granularity (default: 15m) and recipients
+  sharing a time are sent together, one batch per schedule bucket. Recipients
+  without an override are sent immediately or at --schedule. Send times must
+  be in the future and within 7 days.
+
+PER-RECIPIENT SUBJECTS:
+  --subject-from-field NAME reads each recipient's subject from a field of the
+  recipients file: a CSV column or a JSON "substitutions" key. Recipients are
+  grouped by subject, so every batch (up to 100 recipients, and one schedule
+  time) shares a subject. Every recipient must have the field; recipients with
+  an empty value get --subject when it is set, and are rejected otherwise.
+  The large send summary and --dry-run show how many distinct subjects and
+  batches result.
+
+DOMAIN DEFAULTS:
+  Tracking and tags configured for the sender's domain with 'ahasend config
+  set-domain-default' apply when --track-opens, --track-clicks or --tags is
+  not given; flags given explicitly always win. The settings taken from the
+  domain are printed to stderr before sending.
+
+METADATA:
+  --meta key=value: Attach a metadata pair (can be used multiple times)
+  --meta-file: JSON object of string metadata values, e.g. {"order_id": "12345"}
+  File entries are applied first and --meta overrides file entries with the same
+  key. Keys may contain letters, digits, '-' and '_' and are case-insensitive;
+  all keys and values together may not exceed 2048 bytes. Metadata is sent as
+  X-AhaSend-Meta-<key> headers.
 
 IDEMPOTENCY:
   --idempotency-key: Unique key for safe retries (auto-generated if not provided)
   Keys prevent duplicate sends and expire after 24 hours
 
 BATCH OPERATIONS:
-  --progress: Show progress bar (TTY only, disabled in debug mode)
+  --progress: Show progress bar (TTY only; log lines are printed above it)
+  --progress-format json: Write a JSON progress line to stderr every --progress-interval instead
   --max-concurrency N: Send up to N messages concurrently (default: 1)
-  --max-retries N: Retry failed sends up to N times (default: 3)
-  --show-metrics: Display performance statistics after completion`,
+  --max-retries N: Retry failed sends up to N times (default: 3). Rejected
+    requests (4xx such as validation errors) are not retried, rate limited
+    ones (429) wait for the server's Retry-After, and server errors (5xx),
+    timeouts and connection errors back off exponentially with jitter.
+    --show-metrics and the failure summary count errors by these causes.
+  --show-metrics: Display performance, connection reuse and content size statistics after completion
+  --max-idle-conns N: Idle connections kept open for reuse (default: --max-concurrency)
+  --disable-http2: Use HTTP/1.1 even when the API supports HTTP/2
+  --drain-timeout D: On Ctrl-C, wait up to D for in-flight sends (default: 30s)
+
+REJECTED RECIPIENTS:
+  The API can accept a request but reject individual recipients in it, for
+  example suppressed or invalid addresses. Failed recipients are listed with
+  their errors, and the command exits non-zero when every recipient failed.
+  When only some recipients failed it prints a warning and exits zero.
+  --strict: Exit non-zero when any recipient failed
+
+INTERRUPTING A BATCH:
+  The first Ctrl-C (or SIGTERM) stops starting new batches and waits for
+  in-flight requests to finish, then prints the summary and saves unsent and
+  failed recipients to ~/.ahasend. The command exits with code 130. A second
+  Ctrl-C exits immediately.
+
+RESUMING A BATCH:
+  --state-file F: Record each batch's idempotency key and outcome in F,
+    rewriting it atomically after every batch. An existing file is not
+    replaced; remove it to start a new send.
+  --resume: Continue the send recorded in --state-file. The command must be
+    run with the same recipients and options, so that the batches are the
+    same. Batches recorded as sent are skipped; the others are sent again
+    with their recorded idempotency keys, so a batch the API accepted before
+    the interruption is not delivered twice. Idempotency keys expire after 24
+    hours, so resume within a day. The summary and --show-metrics count the
+    skipped batches apart from the newly sent ones.
+
+SANDBOX:
+  --sandbox: Accept the message without delivering it
+  --sandbox-result: Simulate deliver (default), bounce, defer, fail or
+    suppress. Any other result than deliver requires --sandbox, since the
+    message would otherwise really be sent. Scheduling a sandbox message for
+    later prints a warning.
+
+TEST SENDS:
+  --to-me: Send to the profile's default test recipient instead of --to/--recipients
+  Configure it with: ahasend config set default-test-recipient you@example.com
+  The subject is prefixed with "[TEST] ", sandbox mode is turned off and the
+  test tag (--test-tag, the profile's test_tag, or "test") is added.
+
+DRY RUN:
+  --dry-run reads and checks everything a send would (recipients file,
+  templates, substitutions, attachments, headers and schedule), then shows
+  what would be sent instead of sending it: the recipients and batches with
+  their idempotency keys, the recipients per schedule time when recipients
+  have their own send times, the content types and sizes, the attachments,
+  the metadata and the first recipient's subject with its substitutions. It
+  exits non-zero when a check fails. No API calls are made, so no
+  credentials are needed, and the confirmation and duplicate send checks are
+  skipped.
+  --output json prints every request as it would be sent, with the data of
+  each attachment replaced by its size (data_bytes).
+
+DUPLICATE SENDS:
+  Every send is fingerprinted from its sender, content and recipients, and
+  the fingerprint is kept per profile in ~/.ahasend/state.json. Sending the
+  same message to the same recipients again within --duplicate-window
+  (default: 24h) asks for confirmation; without a terminal it fails unless
+  --allow-duplicate is given. The order of the recipients does not matter.
+  Sandbox and --to-me sends are not checked.
+  --duplicate-window 0: Disable the check
+
+LARGE SEND CONFIRMATION:
+  Sends to more than --confirm-threshold recipients (default: 1000, or the
+  profile's confirm_threshold) show a summary and ask for confirmation.
+  --yes: Skip the prompt (required in non-interactive contexts)
+  --confirm-sandbox: Also confirm large sandbox sends (exempt by default)`,
 		Example: `  # Send simple text email to single recipient
   ahasend messages send --from sender@mydomain.com --to recipient@example.com --subject "Hello" --text "Hello World"
 
@@ -95,12 +284,21 @@ BATCH OPERATIONS:
   # Send with recipients file (supports per-recipient substitutions)
   ahasend messages send --from sender@mydomain.com --recipients recipients.json --subject "Order {{order_id}} Confirmation" --html-template order.html
 
+  # One-off send with inline global substitutions
+  ahasend messages send --from sender@mydomain.com --to user@example.com --subject "Hi {{name}}" --text "Your code is {{code}}" --substitute name=Ana --substitute code=0042
+
   # Send with global and per-recipient substitutions (recipients override global)
   ahasend messages send --from sender@mydomain.com --recipients recipients.csv --html-template email.html --global-substitutions defaults.json --subject "{{subject_line}}"
 
+  # Read each recipient's subject from the subject_line column, with a default for empty values
+  ahasend messages send --from sender@mydomain.com --recipients recipients.csv --html-template email.html --subject-from-field subject_line --subject "News from AhaSend"
+
   # Send multipart template email (HTML + text + AMP)
   ahasend messages send --from sender@mydomain.com --to user@example.com --subject "Multi-format" --html-template email.html --text-template email.txt --amp-template email.amp
 
+  # Send a template to yourself to preview it in your inbox
+  ahasend messages send --from sender@mydomain.com --to-me --subject "Welcome" --html-template welcome.html
+
   # Send in sandbox mode for testing
   ahasend messages send --from sender@mydomain.com --to recipient@example.com --subject "Test" --text "Test message" --sandbox
 
@@ -110,9 +308,28 @@ BATCH OPERATIONS:
   # Schedule templated email
   ahasend messages send --from sender@mydomain.com --recipients users.json --html-template welcome.html --schedule "2024-12-01T10:00:00Z"
 
+  # Deliver at 09:00 in each recipient's timezone (recipients file has a timezone column)
+  ahasend messages send --from sender@mydomain.com --recipients users.csv --html-template welcome.html --schedule "2024-12-01T09:00:00Z"
+
   # Send with attachments
   ahasend messages send --from sender@mydomain.com --to user@example.com --subject "Invoice" --text "See attached invoice" --attach invoice.pdf --attach logo.png
 
+  # Send HTML with an inline image referenced as <img src="cid:logo">
+  ahasend messages send --from sender@mydomain.com --to user@example.com --subject "Welcome" --html-template welcome.html --inline images/logo.png:logo
+
+  # Send a meeting invitation
+  ahasend messages send --from sender@mydomain.com --to user@example.com --subject "Planning review" --text "See you Thursday" --calendar-invite meeting.ics
+
+  # Send with metadata for correlating webhook events
+  ahasend messages send --from sender@mydomain.com --to user@example.com --subject "Receipt" --text "Thanks" --meta order_id=12345 --meta-file meta.json
+
+  # Check a campaign and see what would be sent, without sending it
+  ahasend messages send --from sender@mydomain.com --recipients 10000-users.csv --subject "Hi {{first_name}}" --html-template campaign.html --dry-run
+
+  # Send a large campaign that can be resumed if it is interrupted
+  ahasend messages send --from news@mydomain.com --recipients 50k.csv --html-template news.html --subject "News" --state-file campaign.json
+  ahasend messages send --from news@mydomain.com --recipients 50k.csv --html-template news.html --subject "News" --state-file campaign.json --resume
+
   # Send with custom idempotency key for safe retries
   ahasend messages send --from sender@mydomain.com --to user@example.com --subject "Important" --text "Message" --idempotency-key "my-unique-key-123"
 
@@ -120,15 +337,25 @@ BATCH OPERATIONS:
   ahasend messages send --from sender@mydomain.com --recipients large-list.csv --subject "Welcome to AhaSend" --html-template welcome.html --progress --show-metrics
 
   # High-performance batch send with concurrency
-  ahasend messages send --from sender@mydomain.com --recipients 10000-users.json --subject "Announcement" --text-template message.txt --max-concurrency 5 --progress`,
+  ahasend messages send --from sender@mydomain.com --recipients 10000-users.json --subject "Announcement" --text-template message.txt --max-concurrency 5 --progress
+
+  # Large send in a CI job (skips the confirmation prompt)
+  ahasend messages send --from sender@mydomain.com --recipients 10000-users.json --subject "Announcement" --text-template message.txt --yes
+
+  # Machine-readable progress for a wrapper script
+  ahasend messages send --from sender@mydomain.com --recipients 10000-users.json --subject "Announcement" --text-template message.txt --yes --progress-format json --progress-interval 10s`,
 		RunE:         runMessagesSend,
 		SilenceUsage: true,
+		Annotations:  map[string]string{accountstatus.SendsAnnotation: "true"},
 	}
 
 	// Required email parameters
-	cmd.Flags().String("from", "", "Sender email address (required)")
+	cmd.Flags().String("from", "", "Sender email address (defaults to the profile's default_from)")
 	cmd.Flags().StringSlice("to", []string{}, "Recipient email addresses (can be used multiple times)")
+	cmd.Flags().StringSlice("cc", []string{}, "CC recipient email addresses (can be used multiple times)")
+	cmd.Flags().StringSlice("bcc", []string{}, "BCC recipient email addresses (can be used multiple times)")
 	cmd.Flags().String("subject", "", "Email subject")
+	cmd.Flags().String("subject-from-field", "", "Recipients file field holding each recipient's subject (--subject is the fallback for empty values)")
 
 	// Content options
 	cmd.Flags().String("text", "", "Plain text content")
@@ -139,17 +366,25 @@ BATCH OPERATIONS:
 	cmd.Flags().String("text-template", "", "Plain text template file path")
 	cmd.Flags().String("html-template", "", "HTML template file path")
 	cmd.Flags().String("amp-template", "", "AMP HTML template file path")
+	cmd.Flags().Bool("no-includes", false, "Do not expand {{include \"file\"}} directives in template files")
 
 	// Recipient and substitution options
 	cmd.Flags().String("recipients", "", "Recipients file (JSON or CSV format) with per-recipient substitutions")
-	cmd.Flags().String("global-substitutions", "", "JSON file with global template variables")
+	cmd.Flags().String("global-substitutions", "", "JSON file with global template variables, or an inline JSON object starting with '{'")
+	cmd.Flags().StringArray("substitute", []string{}, "Global template variable in format 'key=value', overriding --global-substitutions (can be used multiple times)")
+	cmd.Flags().Bool("substitute-raw-strings", false, "Keep --substitute values as strings instead of reading numbers and true/false")
+	cmd.Flags().Bool("strict-recipients-schema", false, "Reject unknown fields in JSON recipients files")
+	cmd.Flags().StringArray("substitution-default", []string{}, "Fallback in format 'key=value' for substitutions missing or empty for a recipient (can be used multiple times)")
 
 	// Advanced options
 	cmd.Flags().StringSlice("header", []string{}, "Custom headers in format 'Header-Name: value' (can be used multiple times)")
 	cmd.Flags().String("schedule", "", "Schedule delivery time in RFC3339 format (e.g., '2024-12-01T10:00:00Z')")
+	cmd.Flags().Duration("schedule-granularity", defaultScheduleGranularity, "Round per-recipient send times up to this interval to limit the number of batches")
 	cmd.Flags().Bool("sandbox", false, "Send in sandbox mode (for testing)")
-	cmd.Flags().String("sandbox-result", "deliver", "Sandbox result simulation: deliver, bounce, defer, fail, or suppress (only used with --sandbox)")
+	cmd.Flags().String("sandbox-result", "deliver", "Sandbox result simulation: deliver, bounce, defer, fail, or suppress (requires --sandbox)")
 	cmd.Flags().StringSlice("tags", []string{}, "Tags for categorization (can be used multiple times)")
+	cmd.Flags().StringArray("meta", []string{}, "Metadata in format 'key=value' (can be used multiple times)")
+	cmd.Flags().String("meta-file", "", "JSON file with metadata key/value pairs (--meta overrides its entries)")
 	cmd.Flags().String("idempotency-key", "", "Idempotency key for duplicate prevention")
 
 	// Tracking options
@@ -158,15 +393,46 @@ BATCH OPERATIONS:
 
 	// Attachments
 	cmd.Flags().StringSlice("attach", []string{}, "Attachment file paths (can be used multiple times, max 10MB per file)")
+	cmd.Flags().StringArray("inline", []string{}, "Inline image in format 'path[:content-id]' for cid: references in the HTML (can be used multiple times)")
+	cmd.Flags().Bool("strict-inline", false, "Fail instead of warning when the HTML references a cid: without a matching --inline file")
+	cmd.Flags().String("calendar-invite", "", "iCalendar (.ics) file to send as a meeting invitation, max 1MB")
+
+	// Content size check
+	cmd.Flags().String("max-html-size", defaultMaxHTMLSize, "Warn when the rendered HTML is larger than this, e.g. '100KB' or '1MB' (0 disables)")
+	cmd.Flags().Bool("strict-size", false, "Fail instead of warning when the HTML is over --max-html-size")
 
 	// Batch operation enhancements
-	cmd.Flags().Bool("progress", false, "Show progress bar for batch operations (disabled in debug mode)")
+	cmd.Flags().Bool("progress", false, "Show progress bar for batch operations (TTY only)")
 	cmd.Flags().Int("max-concurrency", 1, "Maximum concurrent sends for batch operations")
-	cmd.Flags().Int("max-retries", 3, "Maximum retry attempts for failed sends")
+	cmd.Flags().Int("max-retries", 3, "Maximum retry attempts for rate limited and transient send failures")
 	cmd.Flags().Bool("show-metrics", false, "Show performance metrics after batch operations")
+	cmd.Flags().Int("max-idle-conns", 0, "Idle API connections kept open for reuse (0 matches --max-concurrency)")
+	cmd.Flags().Bool("disable-http2", false, "Use HTTP/1.1 for API requests even when HTTP/2 is available")
+	cmd.Flags().Duration("drain-timeout", batch.DefaultDrainTimeout, "How long to wait for in-flight sends after an interrupt")
+	cmd.Flags().Bool("strict", false, "Exit non-zero when any recipient is rejected, not only when all are")
+	cmd.Flags().String("state-file", "", "Record each batch's idempotency key and outcome in this file, so an interrupted send can be resumed")
+	cmd.Flags().Bool("resume", false, "Continue the send recorded in --state-file, skipping the batches already sent")
+
+	// Large send safety check
+	cmd.Flags().Int("confirm-threshold", defaultConfirmThreshold, "Require confirmation when sending to more recipients than this (0 disables; defaults to the profile's confirm_threshold)")
+	cmd.Flags().Bool("confirm-sandbox", false, "Also require confirmation for large sandbox sends")
+	cmd.Flags().BoolP("yes", "y", false, "Skip the large send confirmation prompt")
+
+	// Duplicate send check
+	cmd.Flags().Duration("duplicate-window", defaultDuplicateWindow, "Ask before repeating a send made within this window (0 disables)")
+	cmd.Flags().Bool("allow-duplicate", false, "Send even when the same message was sent to the same recipients recently")
+
+	// Dry run
+	cmd.Flags().Bool("dry-run", false, "Check everything and show what would be sent without sending it")
+
+	// Test sends
+	cmd.Flags().Bool("to-me", false, "Send to the profile's default test recipient (see 'ahasend config set default-test-recipient')")
+	cmd.Flags().String("test-tag", defaultTestTag, "Tag added to --to-me sends (defaults to the profile's test_tag)")
 
 	// Mark mutually exclusive flags
-	cmd.MarkFlagsMutuallyExclusive("to", "recipients")
+	cmd.MarkFlagsMutuallyExclusive("to", "recipients", "to-me")
+	cmd.MarkFlagsMutuallyExclusive("cc", "recipients", "to-me")
+	cmd.MarkFlagsMutuallyExclusive("bcc", "recipients", "to-me")
 
 	return cmd
 }
@@ -176,9 +442,17 @@ type SendFlags struct {
 	// Basic email parameters
 	FromEmail      string
 	ToEmails       []string
+	CcEmails       []string
+	BccEmails      []string
 	RecipientsFile string
 	Subject        string
 
+	// Recipients file field holding each recipient's subject
+	SubjectField string
+
+	// Reject unknown fields in JSON recipients files
+	StrictRecipientsSchema bool
+
 	// Content options
 	TextContent string
 	HtmlContent string
@@ -189,36 +463,96 @@ type SendFlags struct {
 	HtmlTemplate string
 	AmpTemplate  string
 
+	// Leave {{include "file"}} directives in template files as they are
+	NoIncludes bool
+
 	// Substitutions
-	GlobalSubstitutionsFile string
+	GlobalSubstitutionsFile string // a file path or an inline JSON object
+	Substitutes             []string
+	SubstituteRawStrings    bool
+	SubstitutionDefaults    []string
 
 	// Advanced options
-	CustomHeaders  []string
-	ScheduleTime   string
-	Sandbox        bool
-	SandboxResult  string
-	Tags           []string
-	IdempotencyKey string
-	TrackOpens     bool
-	TrackClicks    bool
-	Attachments    []string
+	CustomHeaders       []string
+	ScheduleTime        string
+	ScheduleGranularity time.Duration
+	Sandbox             bool
+	SandboxResult       string
+	Tags                []string
+	Meta                []string
+	MetaFile            string
+	IdempotencyKey      string
+	TrackOpens          bool
+	TrackClicks         bool
+	Attachments         []string
+	InlineAttachments   []string
+	StrictInline        bool
+	CalendarInvite      string
+
+	// Content size check
+	MaxHTMLSize string
+	StrictSize  bool
+	ContentSize contentSize // estimated for the first recipient before sending
 
 	// Batch operation options
 	ShowProgress   bool
+	Progress       progress.Options
 	MaxConcurrency int
 	MaxRetries     int
 	ShowMetrics    bool
+	MaxIdleConns   int
+	DisableHTTP2   bool
 	DebugMode      bool
+	DrainTimeout   time.Duration
+	Strict         bool
+
+	// Batch progress file, and whether to continue the send it records
+	StateFile string
+	Resume    bool
+
+	// Large send safety check
+	ConfirmThreshold int
+	ConfirmSandbox   bool
+	AssumeYes        bool
+
+	// Duplicate send check, recorded under Profile
+	DuplicateWindow time.Duration
+	AllowDuplicate  bool
+	Profile         string
+
+	// Test send to the profile's default test recipient
+	ToMe bool
+
+	// Check and show the message instead of sending it
+	DryRun bool
+
+	// Settings taken from the sender's domain defaults, e.g. track_opens=false
+	DomainDefaults []string
+
+	// Metadata resolved from --meta-file and --meta
+	Metadata map[string]string
+
+	// Recipients per schedule time, set when the recipients file has
+	// per-recipient send times
+	ScheduleBuckets []scheduleBucketCount
+
+	// Distinct subjects and batches, set with --subject-from-field
+	Subjects int
+	Batches  int
 }
 
 // parseSendFlags extracts all command flags into a structured object
 func parseSendFlags(cmd *cobra.Command) *SendFlags {
 	return &SendFlags{
 		// Basic email parameters
-		FromEmail:      getStringFlag(cmd, "from"),
-		ToEmails:       getStringSliceFlag(cmd, "to"),
-		RecipientsFile: getStringFlag(cmd, "recipients"),
-		Subject:        getStringFlag(cmd, "subject"),
+		FromEmail:              getStringFlag(cmd, "from"),
+		ToEmails:               getStringSliceFlag(cmd, "to"),
+		CcEmails:               getStringSliceFlag(cmd, "cc"),
+		BccEmails:              getStringSliceFlag(cmd, "bcc"),
+		RecipientsFile:         getStringFlag(cmd, "recipients"),
+		StrictRecipientsSchema: getBoolFlag(cmd, "strict-recipients-schema"),
+		Subject:                getStringFlag(cmd, "subject"),
+		SubjectField:           getStringFlag(cmd, "subject-from-field"),
 
 		// Content options
 		TextContent: getStringFlag(cmd, "text"),
@@ -229,27 +563,63 @@ func parseSendFlags(cmd *cobra.Command) *SendFlags {
 		TextTemplate: getStringFlag(cmd, "text-template"),
 		HtmlTemplate: getStringFlag(cmd, "html-template"),
 		AmpTemplate:  getStringFlag(cmd, "amp-template"),
+		NoIncludes:   getBoolFlag(cmd, "no-includes"),
 
 		// Substitutions
 		GlobalSubstitutionsFile: getStringFlag(cmd, "global-substitutions"),
+		Substitutes:             getStringArrayFlag(cmd, "substitute"),
+		SubstituteRawStrings:    getBoolFlag(cmd, "substitute-raw-strings"),
+		SubstitutionDefaults:    getStringArrayFlag(cmd, "substitution-default"),
 
 		// Advanced options
-		CustomHeaders:  getStringSliceFlag(cmd, "header"),
-		ScheduleTime:   getStringFlag(cmd, "schedule"),
-		Sandbox:        getBoolFlag(cmd, "sandbox"),
-		SandboxResult:  getStringFlag(cmd, "sandbox-result"),
-		Tags:           getStringSliceFlag(cmd, "tags"),
-		IdempotencyKey: getStringFlag(cmd, "idempotency-key"),
-		TrackOpens:     getBoolFlag(cmd, "track-opens"),
-		TrackClicks:    getBoolFlag(cmd, "track-clicks"),
-		Attachments:    getStringSliceFlag(cmd, "attach"),
+		CustomHeaders:       getStringSliceFlag(cmd, "header"),
+		ScheduleTime:        getStringFlag(cmd, "schedule"),
+		ScheduleGranularity: getDurationFlag(cmd, "schedule-granularity"),
+		Sandbox:             getBoolFlag(cmd, "sandbox"),
+		SandboxResult:       getStringFlag(cmd, "sandbox-result"),
+		Tags:                getStringSliceFlag(cmd, "tags"),
+		Meta:                getStringArrayFlag(cmd, "meta"),
+		MetaFile:            getStringFlag(cmd, "meta-file"),
+		IdempotencyKey:      getStringFlag(cmd, "idempotency-key"),
+		TrackOpens:          getBoolFlag(cmd, "track-opens"),
+		TrackClicks:         getBoolFlag(cmd, "track-clicks"),
+		Attachments:         getStringSliceFlag(cmd, "attach"),
+		InlineAttachments:   getStringArrayFlag(cmd, "inline"),
+		StrictInline:        getBoolFlag(cmd, "strict-inline"),
+		CalendarInvite:      getStringFlag(cmd, "calendar-invite"),
+
+		// Content size check
+		MaxHTMLSize: getStringFlag(cmd, "max-html-size"),
+		StrictSize:  getBoolFlag(cmd, "strict-size"),
 
 		// Batch operation options
 		ShowProgress:   getBoolFlag(cmd, "progress"),
+		Progress:       progress.OptionsFromCommand(cmd),
 		MaxConcurrency: getIntFlag(cmd, "max-concurrency"),
 		MaxRetries:     getIntFlag(cmd, "max-retries"),
 		ShowMetrics:    getBoolFlag(cmd, "show-metrics"),
+		MaxIdleConns:   getIntFlag(cmd, "max-idle-conns"),
+		DisableHTTP2:   getBoolFlag(cmd, "disable-http2"),
 		DebugMode:      getBoolFlag(cmd, "debug"),
+		DrainTimeout:   getDurationFlag(cmd, "drain-timeout"),
+		Strict:         getBoolFlag(cmd, "strict"),
+		StateFile:      getStringFlag(cmd, "state-file"),
+		Resume:         getBoolFlag(cmd, "resume"),
+
+		// Large send safety check
+		ConfirmThreshold: resolveConfirmThreshold(cmd),
+		ConfirmSandbox:   getBoolFlag(cmd, "confirm-sandbox"),
+		AssumeYes:        getBoolFlag(cmd, "yes"),
+
+		// Duplicate send check
+		DuplicateWindow: getDurationFlag(cmd, "duplicate-window"),
+		AllowDuplicate:  getBoolFlag(cmd, "allow-duplicate"),
+
+		// Test send
+		ToMe: getBoolFlag(cmd, "to-me"),
+
+		// Dry run
+		DryRun: getBoolFlag(cmd, "dry-run"),
 	}
 }
 
@@ -264,6 +634,11 @@ func getStringSliceFlag(cmd *cobra.Command, name string) []string {
 	return value
 }
 
+func getStringArrayFlag(cmd *cobra.Command, name string) []string {
+	value, _ := cmd.Flags().GetStringArray(name)
+	return value
+}
+
 func getBoolFlag(cmd *cobra.Command, name string) bool {
 	value, _ := cmd.Flags().GetBool(name)
 	return value
@@ -274,37 +649,135 @@ func getIntFlag(cmd *cobra.Command, name string) int {
 	return value
 }
 
+func getDurationFlag(cmd *cobra.Command, name string) time.Duration {
+	value, _ := cmd.Flags().GetDuration(name)
+	return value
+}
+
 func runMessagesSend(cmd *cobra.Command, args []string) error {
-	// Get response handler instance and authenticated client
+	// Get response handler instance and parse all flags into structured object
 	handler := printer.GetResponseHandlerFromCommand(cmd)
-	client, err := auth.GetAuthenticatedClient(cmd)
+	flags := parseSendFlags(cmd)
+
+	// A dry run makes no API calls, so it needs no credentials
+	var apiClient client.AhaSendClient
+	if !flags.DryRun {
+		var err error
+		if apiClient, err = auth.GetAuthenticatedClient(cmd); err != nil {
+			return err
+		}
+
+		// A paused or restricted account rejects every message; stop before the batch
+		if err := accountstatus.RequireSending(apiClient); err != nil {
+			return err
+		}
+	}
+
+	flags.Profile = duplicateProfile(cmd)
+	if flags.ToMe {
+		if err := applyToMe(cmd, flags); err != nil {
+			return err
+		}
+	}
+
+	from, err := sender.Resolve(cmd, promptFromEmail)
 	if err != nil {
 		return err
 	}
+	flags.FromEmail = from.Address
+	if from.Source != sender.SourceFlag {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Sending from %s (%s)\n", from.Address, from.Describe())
+	}
 
-	// Parse all flags into structured object
-	flags := parseSendFlags(cmd)
+	// Tracking and tags configured for the sender's domain, beneath the flags
+	if err := applyDomainDefaults(cmd, flags); err != nil {
+		return err
+	}
 
 	// Process the batch send operation
-	return processBatchSend(handler, client, flags)
+	if err := processBatchSend(handler, apiClient, flags); err != nil {
+		return err
+	}
+
+	if flags.ToMe && !flags.DryRun {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Test email sent to %s\n", flags.ToEmails[0])
+	}
+	return nil
 }
 
 // processBatchSend handles the main batch sending workflow
 func processBatchSend(handler printer.ResponseHandler, cl client.AhaSendClient, flags *SendFlags) error {
+	if err := validateStateFlags(flags); err != nil {
+		return err
+	}
+
+	// Resolve metadata before building the request
+	metadata, err := loadMetadata(flags.MetaFile, flags.Meta)
+	if err != nil {
+		return err
+	}
+	flags.Metadata = metadata
+
 	// Process and create send jobs
 	sendJobs, err := createSendJobsFromFlags(flags)
 	if err != nil {
 		return err
 	}
 
+	// Catch content that mail providers will clip before anything is sent
+	if flags.ContentSize, err = checkContentSize(sendJobs, flags.MaxHTMLSize, flags.StrictSize); err != nil {
+		return err
+	}
+
+	// Everything has been checked; a dry run shows the requests instead of sending them
+	if flags.DryRun {
+		return handler.HandleDryRunMessage(dryRunMessage(flags, sendJobs), printer.SimpleConfig{
+			SuccessMessage: "Dry run: the message was checked and not sent",
+		})
+	}
+
+	// Record the batches as they complete, or continue a send recorded before
+	batchState, err := openBatchState(flags, sendJobs)
+	if err != nil {
+		return err
+	}
+	pending := pendingJobs(batchState, sendJobs)
+
+	// Guard against accidentally sending to a large list
+	if err := confirmLargeSend(flags, countRecipients(pending)); err != nil {
+		return err
+	}
+
+	// Guard against repeating a recent send by accident
+	fingerprint, err := checkDuplicateSend(flags, sendJobs)
+	if err != nil {
+		return err
+	}
+
+	// Write the state file before the first batch goes out
+	if batchState != nil {
+		if err := batchState.Save(); err != nil {
+			return err
+		}
+	}
+
 	// Set up progress reporting
-	progressReporter := setupProgressReporting(sendJobs, flags)
+	progressReporter := setupProgressReporting(pending, flags)
+
+	// Process batch; the first SIGINT/SIGTERM drains in-flight sends
+	ctx, stopInterrupts := notifyInterrupts(context.Background())
+	defer stopInterrupts()
 
-	// Process batch
-	batchResult, err := executeBatchSend(cl, sendJobs, flags, progressReporter)
+	batchResult, err := executeBatchSend(ctx, cl, sendJobs, batchState, flags, progressReporter)
 	if err != nil {
 		return err
 	}
+	if len(batchResult.SuccessfulResponses) > 0 {
+		recordSend(flags, fingerprint, countRecipients(sendJobs))
+	}
+	if flags.ShowMetrics {
+		showBatchMetrics(cl, batchResult.Stats, flags.ContentSize)
+	}
 
 	// Format and return response using the new handler
 	return formatBatchResponse(handler, batchResult, flags)
@@ -312,116 +785,287 @@ func processBatchSend(handler printer.ResponseHandler, cl client.AhaSendClient,
 
 // createSendJobsFromFlags creates send jobs using the parsed flags
 func createSendJobsFromFlags(flags *SendFlags) ([]*batch.SendJob, error) {
-	return createSendJobs(
-		flags.FromEmail, flags.ToEmails, flags.RecipientsFile, flags.Subject,
+	// Metadata travels as custom headers
+	metaHeaders, err := metadataHeaders(flags.Metadata, flags.CustomHeaders)
+	if err != nil {
+		return nil, err
+	}
+	customHeaders := append(append([]string{}, flags.CustomHeaders...), metaHeaders...)
+
+	jobs, scheduled, err := createSendJobs(
+		flags.FromEmail, flags.ToEmails, flags.CcEmails, flags.BccEmails, flags.RecipientsFile, flags.StrictRecipientsSchema, flags.Subject, flags.SubjectField,
 		flags.TextContent, flags.HtmlContent, flags.AmpContent,
-		flags.TextTemplate, flags.HtmlTemplate, flags.AmpTemplate,
-		flags.GlobalSubstitutionsFile,
-		flags.CustomHeaders, flags.ScheduleTime, flags.Sandbox, flags.SandboxResult, flags.Tags,
-		flags.TrackOpens, flags.TrackClicks, flags.Attachments, flags.IdempotencyKey,
+		flags.TextTemplate, flags.HtmlTemplate, flags.AmpTemplate, flags.NoIncludes,
+		flags.GlobalSubstitutionsFile, flags.Substitutes, flags.SubstituteRawStrings, flags.SubstitutionDefaults,
+		customHeaders, flags.ScheduleTime, flags.ScheduleGranularity, flags.Sandbox, flags.SandboxResult, flags.Tags,
+		flags.TrackOpens, flags.TrackClicks, flags.Attachments, flags.InlineAttachments, flags.StrictInline, flags.CalendarInvite, flags.IdempotencyKey,
 	)
+	if err != nil {
+		return nil, err
+	}
+	if scheduled {
+		flags.ScheduleBuckets = countScheduleBuckets(jobs)
+	}
+	if flags.SubjectField != "" {
+		flags.Subjects = countSubjects(jobs)
+		flags.Batches = len(jobs)
+	}
+	return jobs, nil
 }
 
 // setupProgressReporting configures progress reporting based on job requirements
 func setupProgressReporting(sendJobs []*batch.SendJob, flags *SendFlags) *progress.Reporter {
 	// Calculate total recipients (not jobs)
-	totalRecipients := 0
-	for _, job := range sendJobs {
-		totalRecipients += job.RecipientCount
-	}
+	totalRecipients := countRecipients(sendJobs)
 
-	// Set up progress reporting if needed
-	if totalRecipients > 1 || flags.ShowProgress {
+	// Set up progress reporting if needed; it also collects the metrics.
+	// JSON progress lines were asked for explicitly, so they are always written.
+	if flags.Progress.JSON() {
+		return progress.NewJSONReporter(totalRecipients, os.Stderr, flags.Progress.Interval)
+	}
+	if totalRecipients > 1 || flags.ShowProgress || flags.ShowMetrics {
 		return progress.NewReporter(totalRecipients, flags.ShowProgress, flags.DebugMode)
 	}
 	return nil
 }
 
-// executeBatchSend performs the actual batch send operation
-func executeBatchSend(cl client.AhaSendClient, sendJobs []*batch.SendJob, flags *SendFlags, progressReporter *progress.Reporter) (*batch.BatchResult, error) {
+// executeBatchSend performs the actual batch send operation, recording it in
+// state when there is one
+func executeBatchSend(ctx context.Context, cl client.AhaSendClient, sendJobs []*batch.SendJob, state *batch.State, flags *SendFlags, progressReporter *progress.Reporter) (*batch.BatchResult, error) {
+	if tuner, ok := cl.(client.TransportTuner); ok {
+		tuner.ConfigureTransport(transportOptions(flags))
+	}
+
 	batchProcessor := batch.NewBatchProcessor(cl, flags.MaxConcurrency, flags.MaxRetries, progressReporter)
-	return batchProcessor.ProcessJobs(context.Background(), sendJobs)
+	batchProcessor.SetDrainTimeout(flags.DrainTimeout)
+	if state != nil {
+		batchProcessor.SetState(state)
+	}
+	return batchProcessor.ProcessJobs(ctx, sendJobs)
+}
+
+// transportOptions tunes the HTTP transport for the batch: by default enough
+// idle connections are kept for every concurrent worker to reuse its own,
+// instead of handshaking again once more than two are in use
+func transportOptions(flags *SendFlags) client.TransportOptions {
+	idle := flags.MaxIdleConns
+	if idle <= 0 {
+		idle = max(flags.MaxConcurrency, http.DefaultMaxIdleConnsPerHost)
+	}
+	return client.TransportOptions{
+		MaxIdleConnsPerHost: idle,
+		DisableHTTP2:        flags.DisableHTTP2,
+	}
+}
+
+// showBatchMetrics prints the --show-metrics report, including connection
+// reuse when the client tracks it and the estimated content size
+func showBatchMetrics(cl client.AhaSendClient, stats progress.Stats, size contentSize) {
+	if tuner, ok := cl.(client.TransportTuner); ok {
+		connections := tuner.ConnectionStats()
+		stats.NewConnections = connections.New
+		stats.ReusedConnections = connections.Reused
+		stats.TLSHandshakes = connections.TLSHandshakes
+	}
+	progress.ShowMetrics(stats, metricsOutput)
+	if size.Total() > 0 {
+		fmt.Fprintf(metricsOutput, "   Content size (first recipient): %s\n", size)
+	}
 }
 
 // formatBatchResponse formats the batch result using the ResponseHandler
 func formatBatchResponse(handler printer.ResponseHandler, batchResult *batch.BatchResult, flags *SendFlags) error {
+	if batchResult.Interrupted {
+		// Report what completed before the interruption, then exit 130
+		if err := reportInterruptedBatch(handler, batchResult, flags); err != nil {
+			return err
+		}
+		return interruptedBatchError(batchResult, flags.StateFile)
+	}
+
 	// Single message success - use HandleCreateMessage
-	if len(batchResult.SuccessfulResponses) == 1 && batchResult.FailedJobs == 0 {
+	if len(batchResult.SuccessfulResponses) == 1 && batchResult.FailedJobs == 0 && batchResult.SkippedJobs == 0 {
 		response := batchResult.SuccessfulResponses[0]
-		return handler.HandleCreateMessage(response, printer.CreateConfig{
+		if err := handler.HandleCreateMessage(response, printer.CreateConfig{
 			SuccessMessage: "Message sent successfully",
 			ItemName:       "message",
-		})
+			Metadata:       flags.Metadata,
+		}); err != nil {
+			return err
+		}
+		return rejectedRecipientsError(printer.SummarizeCreateMessage(response), flags.Strict)
 	}
 
 	// For batch operations or mixed results, create a summary success message
 	successCount := len(batchResult.SuccessfulResponses)
 	failedCount := batchResult.FailedJobs
 	totalCount := successCount + failedCount
+	skipped := skippedSummary(batchResult)
 
+	if totalCount == 0 && batchResult.SkippedJobs > 0 {
+		return handler.HandleSimpleSuccess(fmt.Sprintf("✅ Nothing left to send: all %d messages were already sent (%d recipients)",
+			batchResult.SkippedJobs, batchResult.SkippedRecipients))
+	}
 	if failedCount == 0 {
+		// Every call was accepted, but recipients may still have been rejected
+		rejected := summarizeResponses(batchResult.SuccessfulResponses)
+		if rejected.Failed > 0 {
+			if err := handler.HandleSimpleSuccess(fmt.Sprintf("⚠️  Sent %d of %d recipients in %d messages%s",
+				rejected.Succeeded, rejected.Total(), successCount, skipped)); err != nil {
+				return err
+			}
+			return rejectedRecipientsError(rejected, flags.Strict)
+		}
 		// All successful
-		return handler.HandleSimpleSuccess(fmt.Sprintf("✅ Successfully sent all %d messages", successCount))
+		if len(flags.ScheduleBuckets) > 0 {
+			return handler.HandleSimpleSuccess(fmt.Sprintf("✅ Successfully sent all %d messages across %d schedule buckets%s", successCount, len(flags.ScheduleBuckets), skipped))
+		}
+		return handler.HandleSimpleSuccess(fmt.Sprintf("✅ Successfully sent all %d messages%s", successCount, skipped))
 	} else if successCount == 0 {
 		// All failed
-		return fmt.Errorf("failed to send all %d messages", failedCount)
+		return fmt.Errorf("failed to send all %d messages (%s)%s", failedCount, formatErrorCounts(batchResult.ErrorCounts), skipped)
 	} else {
 		// Partial success - still return as error with details
-		return fmt.Errorf("partial success: %d succeeded, %d failed out of %d total messages", successCount, failedCount, totalCount)
+		return fmt.Errorf("partial success: %d succeeded, %d failed out of %d total messages (%s)%s",
+			successCount, failedCount, totalCount, formatErrorCounts(batchResult.ErrorCounts), skipped)
+	}
+}
+
+// formatErrorCounts lists failed calls by cause, e.g. "2 permanent, 1
+// transient", so our bad data can be told apart from API-side failures
+func formatErrorCounts(counts map[batch.ErrorCategory]int) string {
+	var parts []string
+	for _, category := range batch.ErrorCategories {
+		if counts[category] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[category], category))
+		}
+	}
+	if len(parts) == 0 {
+		return "no error details"
 	}
+	return strings.Join(parts, ", ")
 }
 
-// createSendJobs converts the send request into batch jobs
+// summarizeResponses adds up the per-recipient outcomes of several calls
+func summarizeResponses(results []*responses.CreateMessageResponse) printer.CreateMessageSummary {
+	var total printer.CreateMessageSummary
+	for _, response := range results {
+		summary := printer.SummarizeCreateMessage(response)
+		total.Succeeded += summary.Succeeded
+		total.Failed += summary.Failed
+		total.Rejected = append(total.Rejected, summary.Rejected...)
+	}
+	return total
+}
+
+// rejectedRecipientsError decides the outcome when the API accepted the
+// calls but rejected some recipients. Every recipient failing is an error;
+// a partial failure is only a warning unless strict is set.
+func rejectedRecipientsError(summary printer.CreateMessageSummary, strict bool) error {
+	switch {
+	case summary.Failed == 0:
+		return nil
+	case summary.Succeeded == 0:
+		return errors.NewAPIError(fmt.Sprintf("all %d recipients were rejected", summary.Failed), nil)
+	case strict:
+		return errors.NewAPIError(fmt.Sprintf("partial failure: %d of %d recipients were rejected", summary.Failed, summary.Total()), nil)
+	}
+	fmt.Fprintf(rejectedOutput, "⚠️  %d of %d recipients were rejected; use --strict to exit non-zero on partial failure\n",
+		summary.Failed, summary.Total())
+	return nil
+}
+
+// createSendJobs converts the send request into batch jobs. It reports
+// whether recipients were split into schedule buckets by per-recipient send
+// times. With subjectField set, each job's recipients share a subject.
 func createSendJobs(
-	fromEmail string, toEmails []string, recipientsFile, subject string,
+	fromEmail string, toEmails, ccEmails, bccEmails []string, recipientsFile string, strictRecipientsSchema bool, subject, subjectField string,
 	textContent, htmlContent, ampContent string,
-	textTemplate, htmlTemplate, ampTemplate string,
-	globalSubstitutionsFile string,
-	customHeaders []string, scheduleTime string, sandbox bool, sandboxResult string, tags []string,
-	trackOpens, trackClicks bool, attachmentPaths []string, idempotencyKey string,
-) ([]*batch.SendJob, error) {
+	textTemplate, htmlTemplate, ampTemplate string, noIncludes bool,
+	globalSubstitutionsFile string, substitutes []string, substituteRawStrings bool, substitutionDefaults []string,
+	customHeaders []string, scheduleTime string, scheduleGranularity time.Duration, sandbox bool, sandboxResult string, tags []string,
+	trackOpens, trackClicks bool, attachmentPaths, inlinePaths []string, strictInline bool, calendarInvite, idempotencyKey string,
+) ([]*batch.SendJob, bool, error) {
 	// Process the send request to get the base request
-	request, finalIdempotencyKey, err := processSendRequest(
-		fromEmail, toEmails, recipientsFile, subject,
+	request, copies, finalIdempotencyKey, buckets, err := processSendRequest(
+		fromEmail, toEmails, ccEmails, bccEmails, recipientsFile, strictRecipientsSchema, subject, subjectField,
 		textContent, htmlContent, ampContent,
-		textTemplate, htmlTemplate, ampTemplate,
-		globalSubstitutionsFile,
-		customHeaders, scheduleTime, sandbox, sandboxResult, tags,
-		trackOpens, trackClicks, attachmentPaths, idempotencyKey,
+		textTemplate, htmlTemplate, ampTemplate, noIncludes,
+		globalSubstitutionsFile, substitutes, substituteRawStrings, substitutionDefaults,
+		customHeaders, scheduleTime, scheduleGranularity, sandbox, sandboxResult, tags,
+		trackOpens, trackClicks, attachmentPaths, inlinePaths, strictInline, calendarInvite, idempotencyKey,
 	)
 	if err != nil {
-		return nil, err
+		return nil, false, err
+	}
+
+	scheduled := false
+	for _, bucket := range buckets {
+		if bucket.SendAt != nil {
+			scheduled = true
+		}
+	}
+	if buckets == nil {
+		buckets = []scheduleBucket{{Recipients: request.Recipients}}
+	}
+
+	// A message with copies is one conversation message to every address
+	if !copies.empty() {
+		job := &batch.SendJob{
+			Request:        request,
+			IdempotencyKey: fmt.Sprintf("%s-batch-0", finalIdempotencyKey),
+			Recipients:     request.Recipients,
+			RecipientCount: len(request.Recipients) + len(copies.CC) + len(copies.BCC),
+			CC:             copies.CC,
+			BCC:            copies.BCC,
+		}
+		return []*batch.SendJob{job}, scheduled, nil
 	}
 
-	// Convert to batch jobs - group recipients into batches of up to 100
+	// Convert to batch jobs - group each bucket's recipients into batches of up to 100
 	const MAX_BATCH_SIZE = 100
 	var jobs []*batch.SendJob
 
-	for i := 0; i < len(request.Recipients); i += MAX_BATCH_SIZE {
-		end := i + MAX_BATCH_SIZE
-		if end > len(request.Recipients) {
-			end = len(request.Recipients)
+	for _, bucket := range buckets {
+		bucketRequest := *request
+		if bucket.SendAt != nil {
+			bucketRequest.Schedule = &common.MessageSchedule{FirstAttempt: bucket.SendAt}
+		}
+		if bucket.Subject != "" {
+			bucketRequest.Subject = bucket.Subject
 		}
 
-		// Create batch request with up to 100 recipients
-		batchRequest := *request
-		batchRequest.Recipients = request.Recipients[i:end]
+		for i := 0; i < len(bucket.Recipients); i += MAX_BATCH_SIZE {
+			end := i + MAX_BATCH_SIZE
+			if end > len(bucket.Recipients) {
+				end = len(bucket.Recipients)
+			}
 
-		// Generate unique idempotency key for this batch
-		batchIdempotencyKey := fmt.Sprintf("%s-batch-%d", finalIdempotencyKey, i/MAX_BATCH_SIZE)
+			// Create batch request with up to 100 recipients
+			batchRequest := bucketRequest
+			batchRequest.Recipients = bucket.Recipients[i:end]
 
-		job := &batch.SendJob{
-			Request:        &batchRequest,
-			IdempotencyKey: batchIdempotencyKey,
-			BatchIndex:     i / MAX_BATCH_SIZE,
-			Recipients:     batchRequest.Recipients,
-			RecipientCount: len(batchRequest.Recipients),
+			// Generate unique idempotency key for this batch
+			batchIndex := len(jobs)
+			batchIdempotencyKey := fmt.Sprintf("%s-batch-%d", finalIdempotencyKey, batchIndex)
+
+			job := &batch.SendJob{
+				Request:        &batchRequest,
+				IdempotencyKey: batchIdempotencyKey,
+				BatchIndex:     batchIndex,
+				Recipients:     batchRequest.Recipients,
+				RecipientCount: len(batchRequest.Recipients),
+			}
+			jobs = append(jobs, job)
 		}
-		jobs = append(jobs, job)
 	}
 
-	logger.Get().WithField("total_jobs", len(jobs)).Debug("Created batch jobs")
-	return jobs, nil
+	logger.Get().WithFields(map[string]interface{}{
+		"total_jobs":       len(jobs),
+		"schedule_buckets": len(buckets),
+		"subjects":         countSubjects(jobs),
+	}).Debug("Created batch jobs")
+	return jobs, scheduled, nil
 }
 
 func promptFromEmail() (string, error) {
@@ -472,22 +1116,25 @@ func promptForContent() (content, contentType string, err error) {
 	return strings.TrimSpace(text), "text", nil
 }
 
-// RecipientData represents a single recipient with their email, name and substitution data
+// RecipientData represents a single recipient with their email, name, substitution
+// data and optional send time override
 type RecipientData struct {
 	Email         string                 `json:"email"`
 	Name          string                 `json:"name,omitempty"`
 	Substitutions map[string]interface{} `json:"substitutions,omitempty"`
+	SendAt        string                 `json:"send_at,omitempty"`  // RFC3339, or local time with Timezone
+	Timezone      string                 `json:"timezone,omitempty"` // IANA name, e.g. "America/New_York"
 }
 
 // processSendRequest handles all the validation and processing logic for the send request
 func processSendRequest(
-	fromEmail string, toEmails []string, recipientsFile, subject string,
+	fromEmail string, toEmails, ccEmails, bccEmails []string, recipientsFile string, strictRecipientsSchema bool, subject, subjectField string,
 	textContent, htmlContent, ampContent string,
-	textTemplate, htmlTemplate, ampTemplate string,
-	globalSubstitutionsFile string,
-	customHeaders []string, scheduleTime string, sandbox bool, sandboxResult string, tags []string,
-	trackOpens, trackClicks bool, attachmentPaths []string, idempotencyKey string,
-) (*requests.CreateMessageRequest, string, error) {
+	textTemplate, htmlTemplate, ampTemplate string, noIncludes bool,
+	globalSubstitutionsFile string, substitutes []string, substituteRawStrings bool, substitutionDefaults []string,
+	customHeaders []string, scheduleTime string, scheduleGranularity time.Duration, sandbox bool, sandboxResult string, tags []string,
+	trackOpens, trackClicks bool, attachmentPaths, inlinePaths []string, strictInline bool, calendarInvite, idempotencyKey string,
+) (*requests.CreateMessageRequest, messageCopies, string, []scheduleBucket, error) {
 
 	// Generate or validate idempotency key
 	finalIdempotencyKey := idempotencyKey
@@ -495,7 +1142,7 @@ func processSendRequest(
 	if finalIdempotencyKey == "" {
 		finalIdempotencyKey, err = generateIdempotencyKey()
 		if err != nil {
-			return nil, "", errors.NewAPIError("failed to generate idempotency key", err)
+			return nil, messageCopies{}, "", nil, errors.NewAPIError("failed to generate idempotency key", err)
 		}
 	}
 
@@ -503,52 +1150,126 @@ func processSendRequest(
 	if fromEmail == "" {
 		fromEmail, err = promptFromEmail()
 		if err != nil {
-			return nil, "", errors.NewValidationError("failed to get sender email", err)
+			return nil, messageCopies{}, "", nil, errors.NewValidationError("failed to get sender email", err)
 		}
 	}
 
 	// Validate sender email
 	if err := validation.ValidateEmail(fromEmail); err != nil {
-		return nil, "", err
+		return nil, messageCopies{}, "", nil, err
+	}
+	if fromEmail, err = validation.NormalizeEmail(fromEmail); err != nil {
+		return nil, messageCopies{}, "", nil, err
+	}
+
+	// Subjects read from the recipients file need the file
+	if subjectField != "" && recipientsFile == "" {
+		return nil, messageCopies{}, "", nil, errors.NewValidationError("--subject-from-field requires --recipients", nil)
 	}
 
-	// Validate subject
-	if subject == "" {
+	// Validate subject; with --subject-from-field, --subject is only the
+	// fallback for empty values
+	if subject == "" && subjectField == "" {
 		subject, err = promptSubject()
 		if err != nil {
-			return nil, "", errors.NewValidationError("failed to get email subject", err)
+			return nil, messageCopies{}, "", nil, errors.NewValidationError("failed to get email subject", err)
+		}
+	}
+
+	// Process global substitutions and the defaults for missing values
+	var globalSubstitutions, fileDefaults map[string]interface{}
+	if globalSubstitutionsFile != "" {
+		globalSubstitutions, err = loadGlobalSubstitutions(globalSubstitutionsFile)
+		if err != nil {
+			return nil, messageCopies{}, "", nil, err
+		}
+		if fileDefaults, err = splitSubstitutionDefaults(globalSubstitutions); err != nil {
+			return nil, messageCopies{}, "", nil, err
+		}
+	}
+	flagSubstitutions, err := parseSubstitutes(substitutes, substituteRawStrings)
+	if err != nil {
+		return nil, messageCopies{}, "", nil, err
+	}
+	globalSubstitutions = mergeGlobalSubstitutions(globalSubstitutions, flagSubstitutions)
+	flagDefaults, err := parseSubstitutionDefaults(substitutionDefaults)
+	if err != nil {
+		return nil, messageCopies{}, "", nil, err
+	}
+	defaults := mergeSubstitutionDefaults(fileDefaults, flagDefaults)
+
+	// Every recipient of a file gets its own message, so there is no single copy to CC
+	hasCopies := len(ccEmails)+len(bccEmails) > 0
+	if recipientsFile != "" && hasCopies {
+		return nil, messageCopies{}, "", nil, errors.NewValidationError("--cc and --bcc cannot be used with --recipients", nil)
+	}
+	// Messages with copies are sent as conversation messages, which the API
+	// sends as is, without substitutions
+	if hasCopies && (len(globalSubstitutions) > 0 || len(defaults) > 0) {
+		return nil, messageCopies{}, "", nil, errors.NewValidationError("--cc and --bcc cannot be used with substitutions", nil)
+	}
+	if len(ccEmails) > 0 {
+		for name := range parseCustomHeaders(customHeaders) {
+			if strings.EqualFold(name, "Cc") {
+				return nil, messageCopies{}, "", nil, errors.NewValidationError("--header Cc conflicts with --cc", nil)
+			}
 		}
 	}
 
 	// Process recipients (either --to or --recipients)
 	var recipients []common.Recipient
+	var copies messageCopies
+	var buckets []scheduleBucket
 	if recipientsFile != "" {
-		recipients, err = loadRecipientsFromFile(recipientsFile)
+		entries, err := loadRecipientsFromFile(recipientsFile, strictRecipientsSchema)
 		if err != nil {
-			return nil, "", err
+			return nil, messageCopies{}, "", nil, err
+		}
+		for i := range entries {
+			applySubstitutionDefaults(&entries[i].recipient, globalSubstitutions, defaults)
+			recipients = append(recipients, entries[i].recipient)
+		}
+		// Per-recipient subjects and send times split the recipients into buckets
+		if subjectField != "" {
+			buckets, err = bucketBySubject(entries, subjectField, subject, scheduleTime, scheduleGranularity)
+			if err != nil {
+				return nil, messageCopies{}, "", nil, err
+			}
+		} else if hasScheduleOverrides(entries) {
+			buckets, err = bucketRecipients(entries, scheduleTime, scheduleGranularity)
+			if err != nil {
+				return nil, messageCopies{}, "", nil, err
+			}
 		}
 	} else {
 		// Use --to flags
 		if len(toEmails) == 0 {
 			toEmail, err := promptToEmail()
 			if err != nil {
-				return nil, "", errors.NewValidationError("failed to get recipient email", err)
+				return nil, messageCopies{}, "", nil, errors.NewValidationError("failed to get recipient email", err)
 			}
 			toEmails = []string{toEmail}
 		}
 		recipients, err = createRecipientsFromEmails(toEmails)
 		if err != nil {
-			return nil, "", err
+			return nil, messageCopies{}, "", nil, err
+		}
+		copies, err = createCopyAddresses(ccEmails, bccEmails, recipients)
+		if err != nil {
+			return nil, messageCopies{}, "", nil, err
+		}
+		for i := range recipients {
+			applySubstitutionDefaults(&recipients[i], globalSubstitutions, defaults)
 		}
 	}
 
 	// Process content (direct strings or templates)
 	contentData, err := processEmailContent(
 		textContent, htmlContent, ampContent,
-		textTemplate, htmlTemplate, ampTemplate,
+		textTemplate, htmlTemplate, ampTemplate, noIncludes,
 	)
 	if err != nil {
-		return nil, "", err
+		return nil, messageCopies{}, "", nil, err
 	}
 
 	// Ensure at least one content type is provided
@@ -556,43 +1277,40 @@ func processSendRequest(
 		// Prompt for content
 		content, _, err := promptForContent()
 		if err != nil {
-			return nil, "", errors.NewValidationError("failed to get email content", err)
+			return nil, messageCopies{}, "", nil, errors.NewValidationError("failed to get email content", err)
 		}
 		contentData.TextContent = content
 	}
 
-	// Process global substitutions
-	var globalSubstitutions map[string]interface{}
-	if globalSubstitutionsFile != "" {
-		globalSubstitutions, err = loadGlobalSubstitutions(globalSubstitutionsFile)
-		if err != nil {
-			return nil, "", err
-		}
-	}
-
 	// Process attachments
 	var attachments []common.Attachment
 	if len(attachmentPaths) > 0 {
 		attachments, err = processAttachments(attachmentPaths)
 		if err != nil {
-			return nil, "", err
+			return nil, messageCopies{}, "", nil, err
 		}
 	}
 
-	// Validate sandbox result if sandbox mode is enabled
-	if sandbox && sandboxResult != "" {
-		validResults := []string{"deliver", "bounce", "defer", "fail", "suppress"}
-		isValid := false
-		for _, valid := range validResults {
-			if sandboxResult == valid {
-				isValid = true
-				break
-			}
-		}
-		if !isValid {
-			return nil, "", errors.NewValidationError(
-				fmt.Sprintf("invalid sandbox-result '%s', must be one of: deliver, bounce, defer, fail, suppress", sandboxResult), nil)
-		}
+	// Inline images are attached with their Content-ID for cid: references
+	inline, err := processInlineAttachments(inlinePaths)
+	if err != nil {
+		return nil, messageCopies{}, "", nil, err
+	}
+	if err := checkInlineReferences(contentData.HtmlContent, inline, strictInline); err != nil {
+		return nil, messageCopies{}, "", nil, err
+	}
+	attachments = append(attachments, inline...)
+
+	// A calendar invite adds its alternative part and .ics attachment last
+	invite, err := processCalendarInvite(calendarInvite)
+	if err != nil {
+		return nil, messageCopies{}, "", nil, err
+	}
+	attachments = append(attachments, invite...)
+
+	// Validate the sandbox flags, which are silently ignored in the wrong combination
+	if err := validateSandboxFlags(sandbox, sandboxResult, scheduleTime, buckets, time.Now()); err != nil {
+		return nil, messageCopies{}, "", nil, err
 	}
 
 	// Build the SDK request
@@ -602,10 +1320,55 @@ func processSendRequest(
 		trackOpens, trackClicks, attachments,
 	)
 	if err != nil {
-		return nil, "", err
+		return nil, messageCopies{}, "", nil, err
+	}
+
+	return request, copies, finalIdempotencyKey, buckets, nil
+}
+
+// sandboxResults are the outcomes --sandbox-result can simulate
+var sandboxResults = []string{"deliver", "bounce", "defer", "fail", "suppress"}
+
+// validateSandboxFlags rejects a --sandbox-result other than the default
+// "deliver" without --sandbox, since the message would really be sent, and
+// warns when a sandbox message is scheduled for later, which is rarely
+// intended. Buckets carry per-recipient send times, if any.
+func validateSandboxFlags(sandbox bool, sandboxResult, scheduleTime string, buckets []scheduleBucket, now time.Time) error {
+	if !sandbox {
+		if sandboxResult != "" && sandboxResult != "deliver" {
+			return errors.NewValidationError("--sandbox-result requires --sandbox", nil)
+		}
+		return nil
+	}
+
+	if sandboxResult != "" {
+		isValid := false
+		for _, valid := range sandboxResults {
+			if sandboxResult == valid {
+				isValid = true
+				break
+			}
+		}
+		if !isValid {
+			return errors.NewValidationError(
+				fmt.Sprintf("invalid sandbox-result '%s', must be one of: %s", sandboxResult, strings.Join(sandboxResults, ", ")), nil)
+		}
 	}
 
-	return request, finalIdempotencyKey, nil
+	// An invalid --schedule is reported when the request is built
+	scheduled := false
+	if sendAt, err := time.Parse(time.RFC3339, scheduleTime); err == nil && sendAt.After(now) {
+		scheduled = true
+	}
+	for _, bucket := range buckets {
+		if bucket.SendAt != nil && bucket.SendAt.After(now) {
+			scheduled = true
+		}
+	}
+	if scheduled {
+		fmt.Fprintln(sandboxWarningOutput, "⚠️  Scheduling a sandbox message: it will not be delivered when it is sent (remove --sandbox to deliver it)")
+	}
+	return nil
 }
 
 // ContentData holds the processed email content
@@ -618,7 +1381,7 @@ type ContentData struct {
 // processEmailContent handles both direct content and template files
 func processEmailContent(
 	textContent, htmlContent, ampContent string,
-	textTemplate, htmlTemplate, ampTemplate string,
+	textTemplate, htmlTemplate, ampTemplate string, noIncludes bool,
 ) (*ContentData, error) {
 	content := &ContentData{
 		TextContent: textContent,
@@ -628,7 +1391,7 @@ func processEmailContent(
 
 	// Load template files if provided
 	if textTemplate != "" {
-		textFromFile, err := loadTemplateFile(textTemplate)
+		textFromFile, err := loadTemplateFile(textTemplate, !noIncludes)
 		if err != nil {
 			return nil, errors.NewFileError("failed to load text template", err)
 		}
@@ -636,7 +1399,7 @@ func processEmailContent(
 	}
 
 	if htmlTemplate != "" {
-		htmlFromFile, err := loadTemplateFile(htmlTemplate)
+		htmlFromFile, err := loadTemplateFile(htmlTemplate, !noIncludes)
 		if err != nil {
 			return nil, errors.NewFileError("failed to load HTML template", err)
 		}
@@ -644,7 +1407,7 @@ func processEmailContent(
 	}
 
 	if ampTemplate != "" {
-		ampFromFile, err := loadTemplateFile(ampTemplate)
+		ampFromFile, err := loadTemplateFile(ampTemplate, !noIncludes)
 		if err != nil {
 			return nil, errors.NewFileError("failed to load AMP template", err)
 		}
@@ -654,8 +1417,19 @@ func processEmailContent(
 	return content, nil
 }
 
-// loadTemplateFile loads content from a template file
-func loadTemplateFile(filePath string) (string, error) {
+// loadTemplateFile loads content from a template file, expanding its
+// {{include "file"}} directives when includes is set
+func loadTemplateFile(filePath string, includes bool) (string, error) {
+	filePath = normalizeInputPath(filePath)
+	content, err := readTemplateFile(filePath)
+	if err != nil || !includes {
+		return content, err
+	}
+	return expandIncludes(content, filePath, nil)
+}
+
+// readTemplateFile reads a template file as is
+func readTemplateFile(filePath string) (string, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
 		return "", errors.NewFileError(fmt.Sprintf("cannot open template file %s", filePath), err)
@@ -670,144 +1444,102 @@ func loadTemplateFile(filePath string) (string, error) {
 	return string(content), nil
 }
 
-// loadRecipientsFromFile loads recipients from JSON or CSV file
-func loadRecipientsFromFile(filePath string) ([]common.Recipient, error) {
-	file, err := os.Open(filePath)
-	if err != nil {
-		return nil, errors.NewFileError(fmt.Sprintf("cannot open recipients file %s", filePath), err)
-	}
-	defer file.Close()
-
-	ext := strings.ToLower(filepath.Ext(filePath))
-	switch ext {
-	case ".json":
-		return loadRecipientsFromJSON(file)
-	case ".csv":
-		return loadRecipientsFromCSV(file)
-	default:
-		return nil, errors.NewValidationError(fmt.Sprintf("unsupported recipients file format %s (supported: .json, .csv)", ext), nil)
-	}
-}
-
-// loadRecipientsFromJSON parses recipients from JSON file
-func loadRecipientsFromJSON(file *os.File) ([]common.Recipient, error) {
-	var recipientData []RecipientData
-	decoder := json.NewDecoder(file)
-	if err := decoder.Decode(&recipientData); err != nil {
-		return nil, errors.NewFileError("failed to parse JSON recipients file", err)
-	}
-
+// createRecipientsFromEmails creates basic recipients from email addresses
+func createRecipientsFromEmails(emails []string) ([]common.Recipient, error) {
 	var recipients []common.Recipient
-	for i, data := range recipientData {
-		if err := validation.ValidateEmail(data.Email); err != nil {
-			return nil, errors.NewValidationError(fmt.Sprintf("invalid email at index %d: %v", i, err), nil)
-		}
-
-		recipient := common.Recipient{
-			Email: data.Email,
-		}
-		if data.Name != "" {
-			recipient.Name = ahasend.String(data.Name)
+	for _, email := range emails {
+		if err := validation.ValidateEmail(email); err != nil {
+			return nil, errors.NewValidationError(fmt.Sprintf("invalid recipient email %s: %v", email, err), nil)
 		}
-		if len(data.Substitutions) > 0 {
-			recipient.Substitutions = data.Substitutions
+		normalized, err := validation.NormalizeEmail(email)
+		if err != nil {
+			return nil, errors.NewValidationError(fmt.Sprintf("invalid recipient email %s: %v", email, err), nil)
 		}
-		recipients = append(recipients, recipient)
+		recipients = append(recipients, common.Recipient{
+			Email: normalized,
+		})
 	}
-
 	return recipients, nil
 }
 
-// loadRecipientsFromCSV parses recipients from CSV file
-func loadRecipientsFromCSV(file *os.File) ([]common.Recipient, error) {
-	reader := csv.NewReader(file)
-	records, err := reader.ReadAll()
-	if err != nil {
-		return nil, errors.NewFileError("failed to parse CSV recipients file", err)
-	}
-
-	if len(records) < 2 { // Need header + at least one data row
-		return nil, errors.NewValidationError("CSV file must have at least a header row and one data row", nil)
-	}
-
-	headers := records[0]
-	var emailIndex, nameIndex = -1, -1
-
-	// Find required columns
-	for i, header := range headers {
-		switch strings.ToLower(strings.TrimSpace(header)) {
-		case "email":
-			emailIndex = i
-		case "name":
-			nameIndex = i
-		}
-	}
-
-	if emailIndex == -1 {
-		return nil, errors.NewValidationError("CSV file must have an 'email' column", nil)
-	}
-
-	var recipients []common.Recipient
-	for i, record := range records[1:] { // Skip header row
-		if len(record) <= emailIndex {
-			return nil, errors.NewValidationError(fmt.Sprintf("row %d has insufficient columns", i+2), nil)
-		}
+// maxConversationAddresses is the most To, CC and BCC addresses a
+// conversation message can have together
+const maxConversationAddresses = 50
 
-		email := strings.TrimSpace(record[emailIndex])
-		if err := validation.ValidateEmail(email); err != nil {
-			return nil, errors.NewValidationError(fmt.Sprintf("invalid email at row %d: %v", i+2, err), nil)
-		}
+// messageCopies are the CC and BCC addresses of a message sent with --cc
+// and --bcc
+type messageCopies struct {
+	CC  []common.SenderAddress
+	BCC []common.SenderAddress
+}
 
-		recipient := common.Recipient{
-			Email: email,
-		}
+// empty reports whether there are no copies to send
+func (c messageCopies) empty() bool {
+	return len(c.CC) == 0 && len(c.BCC) == 0
+}
 
-		// Set name if column exists
-		if nameIndex >= 0 && len(record) > nameIndex {
-			name := strings.TrimSpace(record[nameIndex])
-			if name != "" {
-				recipient.Name = ahasend.String(name)
+// createCopyAddresses validates and normalizes the --cc and --bcc
+// addresses, leaving out addresses that already get a copy
+func createCopyAddresses(ccEmails, bccEmails []string, recipients []common.Recipient) (messageCopies, error) {
+	seen := make(map[string]bool, len(recipients))
+	for _, recipient := range recipients {
+		seen[strings.ToLower(recipient.Email)] = true
+	}
+
+	var copies messageCopies
+	for _, list := range []struct {
+		flag      string
+		emails    []string
+		addresses *[]common.SenderAddress
+	}{{"cc", ccEmails, &copies.CC}, {"bcc", bccEmails, &copies.BCC}} {
+		for _, email := range list.emails {
+			if err := validation.ValidateEmail(email); err != nil {
+				return messageCopies{}, errors.NewValidationError(fmt.Sprintf("invalid --%s email %s: %v", list.flag, email, err), nil)
 			}
-		}
-
-		// Create substitution data from remaining columns
-		substitutionData := make(map[string]interface{})
-		for j, header := range headers {
-			if j != emailIndex && j != nameIndex && j < len(record) {
-				key := strings.TrimSpace(header)
-				value := strings.TrimSpace(record[j])
-				if key != "" && value != "" {
-					substitutionData[key] = value
-				}
+			normalized, err := validation.NormalizeEmail(email)
+			if err != nil {
+				return messageCopies{}, errors.NewValidationError(fmt.Sprintf("invalid --%s email %s: %v", list.flag, email, err), nil)
 			}
+			if seen[strings.ToLower(normalized)] {
+				continue
+			}
+			seen[strings.ToLower(normalized)] = true
+			*list.addresses = append(*list.addresses, common.SenderAddress{Email: normalized})
 		}
-
-		if len(substitutionData) > 0 {
-			recipient.Substitutions = substitutionData
-		}
-
-		recipients = append(recipients, recipient)
 	}
 
-	return recipients, nil
+	if total := len(recipients) + len(copies.CC) + len(copies.BCC); !copies.empty() && total > maxConversationAddresses {
+		return messageCopies{}, errors.NewValidationError(fmt.Sprintf(
+			"--to, --cc and --bcc list %d addresses; a message with copies can have at most %d", total, maxConversationAddresses), nil)
+	}
+	return copies, nil
 }
 
-// createRecipientsFromEmails creates basic recipients from email addresses
-func createRecipientsFromEmails(emails []string) ([]common.Recipient, error) {
-	var recipients []common.Recipient
-	for _, email := range emails {
-		if err := validation.ValidateEmail(email); err != nil {
-			return nil, errors.NewValidationError(fmt.Sprintf("invalid recipient email %s: %v", email, err), nil)
+// parseCustomHeaders parses --header values in "Name: value" form. Values
+// without a colon are left out.
+func parseCustomHeaders(customHeaders []string) map[string]string {
+	headers := make(map[string]string)
+	for _, header := range customHeaders {
+		parts := strings.SplitN(header, ":", 2)
+		if len(parts) == 2 {
+			headers[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
 		}
-		recipients = append(recipients, common.Recipient{
-			Email: email,
-		})
 	}
-	return recipients, nil
+	return headers
 }
 
-// loadGlobalSubstitutions loads global substitution variables from JSON file
+// loadGlobalSubstitutions loads global substitution variables from a JSON
+// file, or from an inline JSON object when the value starts with '{'
 func loadGlobalSubstitutions(filePath string) (map[string]interface{}, error) {
+	if inline := strings.TrimSpace(filePath); strings.HasPrefix(inline, "{") {
+		var substitutions map[string]interface{}
+		if err := json.Unmarshal([]byte(inline), &substitutions); err != nil {
+			return nil, errors.NewValidationError("failed to parse inline --global-substitutions JSON", err)
+		}
+		return substitutions, nil
+	}
+
+	filePath = normalizeInputPath(filePath)
 	file, err := os.Open(filePath)
 	if err != nil {
 		return nil, errors.NewFileError(fmt.Sprintf("cannot open global substitutions file %s", filePath), err)
@@ -883,17 +1615,8 @@ func buildAdvancedMessageRequest(
 	}
 
 	// Parse and set custom headers
-	if len(customHeaders) > 0 {
-		headers := make(map[string]string)
-		for _, header := range customHeaders {
-			parts := strings.SplitN(header, ":", 2)
-			if len(parts) == 2 {
-				headers[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
-			}
-		}
-		if len(headers) > 0 {
-			request.Headers = headers
-		}
+	if headers := parseCustomHeaders(customHeaders); len(headers) > 0 {
+		request.Headers = headers
 	}
 
 	// Parse and set schedule time
@@ -935,6 +1658,8 @@ func processAttachments(filePaths []string) ([]common.Attachment, error) {
 	var attachments []common.Attachment
 
 	for _, filePath := range filePaths {
+		filePath = normalizeInputPath(filePath)
+
 		// Check if file exists and get info
 		fileInfo, err := os.Stat(filePath)
 		if err != nil {
@@ -1,10 +1,23 @@
 package messages
 
 import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/AhaSend/ahasend-cli/internal/batch"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/progress"
 	"github.com/AhaSend/ahasend-cli/internal/validation"
+	"github.com/AhaSend/ahasend-go/models/common"
+	"github.com/AhaSend/ahasend-go/models/responses"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSendCommand_Structure(t *testing.T) {
@@ -29,6 +42,12 @@ func TestSendCommand_Flags(t *testing.T) {
 	assert.NotNil(t, toFlag)
 	assert.Equal(t, "stringSlice", toFlag.Value.Type())
 
+	for _, name := range []string{"cc", "bcc"} {
+		flag := flags.Lookup(name)
+		require.NotNil(t, flag, name)
+		assert.Equal(t, "stringSlice", flag.Value.Type())
+	}
+
 	subjectFlag := flags.Lookup("subject")
 	assert.NotNil(t, subjectFlag)
 	assert.Equal(t, "string", subjectFlag.Value.Type())
@@ -54,6 +73,10 @@ func TestSendCommand_Flags(t *testing.T) {
 	assert.NotNil(t, ampTemplateFlag)
 	assert.Equal(t, "string", ampTemplateFlag.Value.Type())
 
+	noIncludesFlag := flags.Lookup("no-includes")
+	assert.NotNil(t, noIncludesFlag)
+	assert.Equal(t, "bool", noIncludesFlag.Value.Type())
+
 	ampFlag := flags.Lookup("amp")
 	assert.NotNil(t, ampFlag)
 	assert.Equal(t, "string", ampFlag.Value.Type())
@@ -74,6 +97,24 @@ func TestSendCommand_Flags(t *testing.T) {
 	scheduleFlag := flags.Lookup("schedule")
 	assert.NotNil(t, scheduleFlag)
 	assert.Equal(t, "string", scheduleFlag.Value.Type())
+
+	// Connection tuning
+	maxIdleConnsFlag := flags.Lookup("max-idle-conns")
+	assert.NotNil(t, maxIdleConnsFlag)
+	assert.Equal(t, "int", maxIdleConnsFlag.Value.Type())
+
+	disableHTTP2Flag := flags.Lookup("disable-http2")
+	assert.NotNil(t, disableHTTP2Flag)
+	assert.Equal(t, "bool", disableHTTP2Flag.Value.Type())
+
+	// Duplicate send check
+	duplicateWindowFlag := flags.Lookup("duplicate-window")
+	assert.NotNil(t, duplicateWindowFlag)
+	assert.Equal(t, "24h0m0s", duplicateWindowFlag.DefValue)
+
+	allowDuplicateFlag := flags.Lookup("allow-duplicate")
+	assert.NotNil(t, allowDuplicateFlag)
+	assert.Equal(t, "bool", allowDuplicateFlag.Value.Type())
 }
 
 func TestValidateEmail(t *testing.T) {
@@ -214,3 +255,274 @@ func TestValidateEmailRequest(t *testing.T) {
 		})
 	}
 }
+
+func recipientResults(statuses ...string) *responses.CreateMessageResponse {
+	response := &responses.CreateMessageResponse{Object: "list"}
+	for _, status := range statuses {
+		messageData := responses.CreateSingleMessageResponse{
+			Recipient: common.Recipient{Email: status + "@example.com"},
+			Status:    status,
+		}
+		if status == "failed" {
+			reason := "recipient is suppressed"
+			messageData.Error = &reason
+		}
+		response.Data = append(response.Data, messageData)
+	}
+	return response
+}
+
+func TestValidateSandboxFlags(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	later := now.Add(time.Hour)
+	earlier := now.Add(-time.Hour)
+
+	tests := []struct {
+		name          string
+		sandbox       bool
+		sandboxResult string
+		scheduleTime  string
+		buckets       []scheduleBucket
+		wantErr       string
+		wantWarning   bool
+	}{
+		{name: "default deliver without sandbox", sandboxResult: "deliver"},
+		{name: "no result without sandbox", sandboxResult: ""},
+		{name: "bounce without sandbox", sandboxResult: "bounce", wantErr: "--sandbox-result requires --sandbox"},
+		{name: "unknown result without sandbox", sandboxResult: "explode", wantErr: "--sandbox-result requires --sandbox"},
+		{name: "scheduled without sandbox", sandboxResult: "deliver", scheduleTime: later.Format(time.RFC3339)},
+		{name: "sandbox with default deliver", sandbox: true, sandboxResult: "deliver"},
+		{name: "sandbox with bounce", sandbox: true, sandboxResult: "bounce"},
+		{name: "sandbox with unknown result", sandbox: true, sandboxResult: "explode", wantErr: "invalid sandbox-result 'explode'"},
+		{name: "sandbox scheduled later", sandbox: true, sandboxResult: "deliver", scheduleTime: later.Format(time.RFC3339), wantWarning: true},
+		{name: "sandbox scheduled in the past", sandbox: true, sandboxResult: "deliver", scheduleTime: earlier.Format(time.RFC3339)},
+		{name: "sandbox with invalid schedule", sandbox: true, sandboxResult: "deliver", scheduleTime: "tomorrow"},
+		{name: "sandbox with a later recipient send time", sandbox: true, sandboxResult: "deliver", buckets: []scheduleBucket{{}, {SendAt: &later}}, wantWarning: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var warnings bytes.Buffer
+			prev := sandboxWarningOutput
+			sandboxWarningOutput = &warnings
+			t.Cleanup(func() { sandboxWarningOutput = prev })
+
+			err := validateSandboxFlags(tt.sandbox, tt.sandboxResult, tt.scheduleTime, tt.buckets, now)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			if tt.wantWarning {
+				assert.Contains(t, warnings.String(), "Scheduling a sandbox message")
+			} else {
+				assert.Empty(t, warnings.String())
+			}
+		})
+	}
+}
+
+func TestFormatBatchResponse_RejectedRecipients(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses []*responses.CreateMessageResponse
+		strict    bool
+		wantErr   string
+		wantWarn  bool
+	}{
+		{name: "all succeeded", responses: []*responses.CreateMessageResponse{recipientResults("queued", "queued")}},
+		{name: "mixed warns", responses: []*responses.CreateMessageResponse{recipientResults("queued", "failed")}, wantWarn: true},
+		{name: "mixed with strict", responses: []*responses.CreateMessageResponse{recipientResults("queued", "failed")}, strict: true, wantErr: "partial failure: 1 of 2 recipients were rejected"},
+		{name: "all failed", responses: []*responses.CreateMessageResponse{recipientResults("failed", "failed")}, wantErr: "all 2 recipients were rejected"},
+		{name: "mixed across calls", responses: []*responses.CreateMessageResponse{recipientResults("queued"), recipientResults("failed")}, wantWarn: true},
+		{name: "all failed across calls", responses: []*responses.CreateMessageResponse{recipientResults("failed"), recipientResults("failed")}, wantErr: "all 2 recipients were rejected"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out, warnings bytes.Buffer
+			prevOutput := rejectedOutput
+			rejectedOutput = &warnings
+			t.Cleanup(func() { rejectedOutput = prevOutput })
+
+			result := &batch.BatchResult{
+				TotalJobs:           len(tt.responses),
+				SuccessfulJobs:      len(tt.responses),
+				SuccessfulResponses: tt.responses,
+			}
+			err := formatBatchResponse(printer.GetResponseHandler("plain", false, &out), result, &SendFlags{Strict: tt.strict})
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			if tt.wantWarn {
+				assert.Contains(t, warnings.String(), "use --strict to exit non-zero")
+			} else {
+				assert.Empty(t, warnings.String())
+			}
+		})
+	}
+}
+
+func TestFormatBatchResponse_ErrorCategories(t *testing.T) {
+	result := &batch.BatchResult{
+		TotalJobs:           3,
+		SuccessfulJobs:      1,
+		FailedJobs:          2,
+		SuccessfulResponses: []*responses.CreateMessageResponse{recipientResults("queued")},
+		ErrorCounts:         map[batch.ErrorCategory]int{batch.ErrorPermanent: 1, batch.ErrorTransient: 1},
+	}
+	err := formatBatchResponse(printer.GetResponseHandler("plain", false, &bytes.Buffer{}), result, &SendFlags{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 succeeded, 2 failed out of 3 total messages (1 permanent, 1 transient)")
+}
+
+func TestTransportOptions(t *testing.T) {
+	assert.Equal(t, client.TransportOptions{MaxIdleConnsPerHost: 2},
+		transportOptions(&SendFlags{MaxConcurrency: 1}))
+	assert.Equal(t, client.TransportOptions{MaxIdleConnsPerHost: 20},
+		transportOptions(&SendFlags{MaxConcurrency: 20}))
+	assert.Equal(t, client.TransportOptions{MaxIdleConnsPerHost: 5, DisableHTTP2: true},
+		transportOptions(&SendFlags{MaxConcurrency: 20, MaxIdleConns: 5, DisableHTTP2: true}))
+}
+
+// tunableClient is a mock client that reports connection stats
+type tunableClient struct {
+	mocks.MockClient
+	options client.TransportOptions
+}
+
+func (c *tunableClient) ConfigureTransport(opts client.TransportOptions) { c.options = opts }
+
+func (c *tunableClient) ConnectionStats() client.ConnectionStats {
+	return client.ConnectionStats{New: 20, Reused: 180, TLSHandshakes: 20}
+}
+
+func TestShowBatchMetrics(t *testing.T) {
+	var out bytes.Buffer
+	prevOutput := metricsOutput
+	metricsOutput = &out
+	t.Cleanup(func() { metricsOutput = prevOutput })
+
+	showBatchMetrics(&mocks.MockClient{}, progress.Stats{Total: 200, Sent: 200, SuccessRate: 100}, contentSize{})
+	assert.Contains(t, out.String(), "Total messages: 200")
+	assert.NotContains(t, out.String(), "Connections")
+	assert.NotContains(t, out.String(), "Content size")
+
+	out.Reset()
+	showBatchMetrics(&tunableClient{}, progress.Stats{Total: 200, Sent: 200, SuccessRate: 100}, contentSize{Text: 2048, HTML: 100 * 1024})
+	assert.Contains(t, out.String(), "Connections: 20 new, 180 reused (90.0% reused)")
+	assert.Contains(t, out.String(), "Content size (first recipient): HTML 100.0 KB, text 2.0 KB, total 102.0 KB")
+}
+
+// ccJobs creates the send jobs for --to, --cc and --bcc addresses
+func ccJobs(t *testing.T, to, cc, bcc []string, headers []string) ([]*batch.SendJob, error) {
+	t.Helper()
+	jobs, _, err := createSendJobs(
+		"news@example.com", to, cc, bcc, "", false, "Update", "",
+		"Hello", "", "",
+		"", "", "", false,
+		"", nil, false, nil,
+		headers, "", 0, false, "", nil,
+		false, false, nil, nil, false, "", "key",
+	)
+	return jobs, err
+}
+
+func TestCreateSendJobs_CcBcc(t *testing.T) {
+	jobs, err := ccJobs(t, []string{"ana@example.com"}, []string{"boss@Example.COM"}, []string{"audit@example.com"}, nil)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+
+	// Copies make the job one conversation message with CC and BCC
+	request := jobs[0].ConversationRequest()
+	assert.Equal(t, []common.SenderAddress{{Email: "ana@example.com"}}, request.To)
+	assert.Equal(t, []common.SenderAddress{{Email: "boss@example.com"}}, request.CC)
+	assert.Equal(t, []common.SenderAddress{{Email: "audit@example.com"}}, request.BCC)
+	assert.Empty(t, request.Headers, "CC addresses are not added as a header")
+	assert.Equal(t, 3, countRecipients(jobs))
+}
+
+func TestCreateSendJobs_CcBccAddressLimit(t *testing.T) {
+	to := make([]string, 49)
+	for i := range to {
+		to[i] = fmt.Sprintf("user%d@example.com", i)
+	}
+
+	// Without copies, the recipients are sent in batches as before
+	jobs, err := ccJobs(t, append(to, "a@example.com", "b@example.com"), nil, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Empty(t, jobs[0].CC)
+
+	jobs, err = ccJobs(t, to, []string{"boss@example.com"}, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 50, jobs[0].RecipientCount)
+
+	_, err = ccJobs(t, to, []string{"boss@example.com"}, []string{"audit@example.com"}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--to, --cc and --bcc list 51 addresses; a message with copies can have at most 50")
+}
+
+func TestCreateSendJobs_CcBccDuplicates(t *testing.T) {
+	jobs, err := ccJobs(t, []string{"ana@example.com"},
+		[]string{"Ana@example.com", "boss@example.com"}, []string{"boss@example.com", "audit@example.com"}, nil)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, 3, jobs[0].RecipientCount, "each address gets a single copy")
+}
+
+func TestCreateSendJobs_CcBccValidation(t *testing.T) {
+	_, err := ccJobs(t, []string{"ana@example.com"}, []string{"not-an-email"}, nil, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --cc email not-an-email")
+
+	_, err = ccJobs(t, []string{"ana@example.com"}, nil, []string{"also bad"}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --bcc email also bad")
+
+	_, err = ccJobs(t, []string{"ana@example.com"}, []string{"boss@example.com"}, nil, []string{"cc: other@example.com"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--header Cc conflicts with --cc")
+
+	_, _, err = createSendJobs(
+		"news@example.com", []string{"ana@example.com"}, []string{"boss@example.com"}, nil, "", false, "Update", "",
+		"Hello {{name}}", "", "",
+		"", "", "", false,
+		"", []string{"name=Ana"}, false, nil,
+		nil, "", 0, false, "", nil,
+		false, false, nil, nil, false, "", "key",
+	)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--cc and --bcc cannot be used with substitutions")
+}
+
+func TestCreateSendJobs_CcBccWithRecipientsFile(t *testing.T) {
+	recipientsFile := filepath.Join(t.TempDir(), "recipients.csv")
+	require.NoError(t, os.WriteFile(recipientsFile, []byte("email,cc\nana@example.com,boss@example.com\n"), 0600))
+
+	_, _, err := createSendJobs(
+		"news@example.com", nil, []string{"boss@example.com"}, nil, recipientsFile, false, "Update", "",
+		"Hello", "", "",
+		"", "", "", false,
+		"", nil, false, nil,
+		nil, "", 0, false, "", nil,
+		false, false, nil, nil, false, "", "key",
+	)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--cc and --bcc cannot be used with --recipients")
+
+	for _, flag := range []string{"--cc", "--bcc"} {
+		cmd := NewSendCommand()
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs([]string{"--from", "news@example.com", "--recipients", recipientsFile, flag, "boss@example.com"})
+		err := cmd.Execute()
+		require.Error(t, err, flag)
+		assert.Contains(t, err.Error(), "were all set")
+	}
+}
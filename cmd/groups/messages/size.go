@@ -0,0 +1,140 @@
+package messages
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/AhaSend/ahasend-cli/internal/batch"
+	"github.com/AhaSend/ahasend-cli/internal/bytesize"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-go/models/common"
+	"github.com/AhaSend/ahasend-go/models/requests"
+)
+
+// defaultMaxHTMLSize stays under Gmail's clipping limit of about 102 KB
+const defaultMaxHTMLSize = "100KB"
+
+// mimeLineLength is the length of base64 lines in a MIME body; each line
+// ends with a CRLF
+const mimeLineLength = 76
+
+// sizeWarningOutput receives the content size warning; replaced in tests
+var sizeWarningOutput io.Writer = os.Stderr
+
+// substitutionPattern matches a plain {{ name }} substitution
+var substitutionPattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.-]+)\s*\}\}`)
+
+// contentSize is the rendered size of each part of a message in bytes
+type contentSize struct {
+	Text        int
+	HTML        int
+	AMP         int
+	Attachments int
+}
+
+// Total is the size of all parts together
+func (s contentSize) Total() int {
+	return s.Text + s.HTML + s.AMP + s.Attachments
+}
+
+// String lists the parts present and the total, e.g.
+// "HTML 98.0 KB, text 2.1 KB, total 100.1 KB"
+func (s contentSize) String() string {
+	var parts []string
+	for _, part := range []struct {
+		name string
+		size int
+	}{{"HTML", s.HTML}, {"text", s.Text}, {"AMP", s.AMP}, {"attachments", s.Attachments}} {
+		if part.size > 0 {
+			parts = append(parts, part.name+" "+bytesize.Format(part.size))
+		}
+	}
+	return strings.Join(append(parts, "total "+bytesize.Format(s.Total())), ", ")
+}
+
+// estimateContentSize computes the size of a message as rendered for one
+// recipient: the content after the recipient's substitutions (layered over
+// the global ones), plus attachments as base64 with MIME line breaks. Only
+// plain {{ name }} substitutions are rendered; other template syntax is
+// counted as written.
+func estimateContentSize(request *requests.CreateMessageRequest, recipient common.Recipient) contentSize {
+	substitutions := mergeSubstitutions(request.Substitutions, recipient.Substitutions, nil)
+
+	var size contentSize
+	if request.TextContent != nil {
+		size.Text = len(renderSubstitutions(*request.TextContent, substitutions))
+	}
+	if request.HtmlContent != nil {
+		size.HTML = len(renderSubstitutions(*request.HtmlContent, substitutions))
+	}
+	if request.AmpContent != nil {
+		size.AMP = len(renderSubstitutions(*request.AmpContent, substitutions))
+	}
+	for _, attachment := range request.Attachments {
+		size.Attachments += encodedAttachmentSize(attachment)
+	}
+	return size
+}
+
+// renderSubstitutions replaces {{ name }} placeholders that have a value,
+// leaving the others as they are
+func renderSubstitutions(content string, substitutions map[string]interface{}) string {
+	if len(substitutions) == 0 {
+		return content
+	}
+	return substitutionPattern.ReplaceAllStringFunc(content, func(match string) string {
+		name := substitutionPattern.FindStringSubmatch(match)[1]
+		if value, ok := substitutions[name]; ok && value != nil {
+			return fmt.Sprint(value)
+		}
+		return match
+	})
+}
+
+// encodedAttachmentSize is the size of an attachment in the sent message:
+// base64 with a CRLF after every 76 characters
+func encodedAttachmentSize(attachment common.Attachment) int {
+	encoded := len(attachment.Data)
+	if !attachment.Base64 {
+		encoded = (len(attachment.Data) + 2) / 3 * 4
+	}
+	return encoded + encoded/mimeLineLength*2
+}
+
+// checkContentSize estimates the content size for the first recipient of the
+// first batch and warns when the HTML is over maxHTMLSize, or fails with
+// strict set. A maxHTMLSize of "0" disables the check.
+func checkContentSize(jobs []*batch.SendJob, maxHTMLSize string, strict bool) (contentSize, error) {
+	limit, err := bytesize.Parse(maxHTMLSize)
+	if err != nil {
+		return contentSize{}, errors.NewValidationError(fmt.Sprintf("invalid --max-html-size: %v", err), nil)
+	}
+	if len(jobs) == 0 || len(jobs[0].Recipients) == 0 {
+		return contentSize{}, nil
+	}
+
+	recipient := jobs[0].Recipients[0]
+	size := estimateContentSize(jobs[0].Request, recipient)
+	logger.Get().WithFields(map[string]interface{}{
+		"recipient":   recipient.Email,
+		"text":        size.Text,
+		"html":        size.HTML,
+		"amp":         size.AMP,
+		"attachments": size.Attachments,
+	}).Debug("Estimated content size")
+
+	if limit == 0 || size.HTML <= limit {
+		return size, nil
+	}
+	message := fmt.Sprintf("HTML content is %s for %s, over the %s limit of --max-html-size; Gmail clips messages with more than about 102 KB of HTML",
+		bytesize.Format(size.HTML), recipient.Email, bytesize.Format(limit))
+	if strict {
+		return size, errors.NewValidationError(message, nil)
+	}
+	fmt.Fprintf(sizeWarningOutput, "⚠️  %s (use --strict-size to fail instead)\n", message)
+	return size, nil
+}
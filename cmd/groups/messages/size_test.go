@@ -0,0 +1,82 @@
+package messages
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/AhaSend/ahasend-cli/internal/batch"
+	ahasend "github.com/AhaSend/ahasend-go"
+	"github.com/AhaSend/ahasend-go/models/common"
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateContentSize(t *testing.T) {
+	request := &requests.CreateMessageRequest{
+		TextContent:   ahasend.String("Hi {{name}}"),
+		HtmlContent:   ahasend.String("<p>Hi {{ name }}, order {{order_id}} from {{store}}</p>"),
+		AmpContent:    ahasend.String("<p>{{unknown}}</p>"),
+		Substitutions: map[string]interface{}{"name": "Friend", "store": "Acme"},
+		Attachments: []common.Attachment{
+			{FileName: "a.bin", Data: base64.StdEncoding.EncodeToString(make([]byte, 300)), Base64: true},
+			{FileName: "b.txt", Data: "hello", Base64: false},
+		},
+	}
+	recipient := common.Recipient{
+		Email:         "jane@example.com",
+		Substitutions: map[string]interface{}{"name": "Jane", "order_id": 12345},
+	}
+
+	size := estimateContentSize(request, recipient)
+	assert.Equal(t, len("Hi Jane"), size.Text, "recipient substitutions override global ones")
+	assert.Equal(t, len("<p>Hi Jane, order 12345 from Acme</p>"), size.HTML)
+	assert.Equal(t, len("<p>{{unknown}}</p>"), size.AMP, "placeholders without a value are counted as written")
+	// 300 bytes encode to 400 base64 characters in 6 lines (5 CRLFs); "hello" to 8
+	assert.Equal(t, 400+10+8, size.Attachments)
+	assert.Equal(t, size.Text+size.HTML+size.AMP+size.Attachments, size.Total())
+}
+
+func TestCheckContentSize(t *testing.T) {
+	html := "<p>" + strings.Repeat("x", 120*1024) + "</p>"
+	jobs := []*batch.SendJob{{
+		Request:    &requests.CreateMessageRequest{HtmlContent: ahasend.String(html)},
+		Recipients: []common.Recipient{{Email: "jane@example.com"}},
+	}}
+
+	t.Run("warns over the limit", func(t *testing.T) {
+		var warnings bytes.Buffer
+		prev := sizeWarningOutput
+		sizeWarningOutput = &warnings
+		t.Cleanup(func() { sizeWarningOutput = prev })
+
+		size, err := checkContentSize(jobs, "100KB", false)
+		require.NoError(t, err)
+		assert.Equal(t, len(html), size.HTML)
+		assert.Contains(t, warnings.String(), "HTML content is 120.0 KB for jane@example.com, over the 100.0 KB limit")
+	})
+
+	t.Run("strict fails over the limit", func(t *testing.T) {
+		_, err := checkContentSize(jobs, "100KB", true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "120.0 KB")
+	})
+
+	t.Run("under the limit", func(t *testing.T) {
+		_, err := checkContentSize(jobs, "1MB", true)
+		assert.NoError(t, err)
+	})
+
+	t.Run("zero disables the check", func(t *testing.T) {
+		_, err := checkContentSize(jobs, "0", true)
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		_, err := checkContentSize(jobs, "lots", false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid --max-html-size")
+	})
+}
@@ -0,0 +1,116 @@
+package messages
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/batch"
+)
+
+// subjectGroup holds the indexes of the recipients that share a subject
+type subjectGroup struct {
+	Subject string
+	Indexes []int
+}
+
+// groupBySubject groups recipients by the value of their field, in order of
+// first appearance. Every recipient must have the field; an empty value falls
+// back to fallback, the --subject flag, when it is set. All recipients
+// without a usable subject are reported together.
+func groupBySubject(entries []recipientEntry, field, fallback string) ([]subjectGroup, error) {
+	var problems recipientErrors
+	var groups []subjectGroup
+	index := make(map[string]int)
+	for i, entry := range entries {
+		raw, ok := entry.fields[field]
+		if !ok {
+			problems.add(entry.location, "missing %s field for the subject", field)
+			continue
+		}
+		value, ok := raw.(string)
+		if !ok {
+			problems.add(entry.location, "%s must be a string to be used as the subject", field)
+			continue
+		}
+		subject := strings.TrimSpace(value)
+		if subject == "" {
+			if fallback == "" {
+				problems.add(entry.location, "empty %s and no --subject to fall back to", field)
+				continue
+			}
+			subject = fallback
+		}
+
+		g, ok := index[subject]
+		if !ok {
+			g = len(groups)
+			index[subject] = g
+			groups = append(groups, subjectGroup{Subject: subject})
+		}
+		groups[g].Indexes = append(groups[g].Indexes, i)
+	}
+
+	if err := problems.err(); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// bucketBySubject splits recipients into one bucket per subject, and per send
+// time within a subject when recipients have send time overrides. Subjects
+// keep their order of first appearance.
+func bucketBySubject(entries []recipientEntry, field, fallback, scheduleTime string, granularity time.Duration) ([]scheduleBucket, error) {
+	groups, err := groupBySubject(entries, field, fallback)
+	if err != nil {
+		return nil, err
+	}
+
+	var times []*time.Time
+	if hasScheduleOverrides(entries) {
+		times, err = resolveSendTimes(entries, scheduleTime, granularity)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var buckets []scheduleBucket
+	for _, group := range groups {
+		groupEntries := make([]recipientEntry, len(group.Indexes))
+		var groupTimes []*time.Time
+		if times != nil {
+			groupTimes = make([]*time.Time, len(group.Indexes))
+		}
+		for j, i := range group.Indexes {
+			groupEntries[j] = entries[i]
+			if times != nil {
+				groupTimes[j] = times[i]
+			}
+		}
+
+		for _, bucket := range groupBySendTime(groupEntries, groupTimes) {
+			bucket.Subject = group.Subject
+			buckets = append(buckets, bucket)
+		}
+	}
+	return buckets, nil
+}
+
+// countSubjects returns the number of distinct subjects across jobs
+func countSubjects(jobs []*batch.SendJob) int {
+	subjects := make(map[string]bool)
+	for _, job := range jobs {
+		subjects[job.Request.Subject] = true
+	}
+	return len(subjects)
+}
+
+// formatSubjectSummary describes the subjects of a --subject-from-field send,
+// e.g. "from subject_line (3 distinct subjects in 5 batches)"
+func formatSubjectSummary(field string, subjects, batches int) string {
+	noun := "subjects"
+	if subjects == 1 {
+		noun = "subject"
+	}
+	return fmt.Sprintf("from %s (%d distinct %s in %d batches)", field, subjects, noun, batches)
+}
@@ -0,0 +1,167 @@
+package messages
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/batch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func subjectSendFlags(recipientsFile string) *SendFlags {
+	flags := scheduledSendFlags(recipientsFile)
+	flags.Subject = ""
+	flags.SubjectField = "subject_line"
+	return flags
+}
+
+func TestCreateSendJobs_SubjectFromField(t *testing.T) {
+	recipients := writeRecipientsFile(t, "recipients.csv", `email,subject_line,first_name
+a@example.com,Your order shipped,Ann
+b@example.com,Your invoice,Bob
+c@example.com,Your order shipped,Cid
+`)
+	flags := subjectSendFlags(recipients)
+
+	jobs, err := createSendJobsFromFlags(flags)
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+
+	// Subjects keep their order of first appearance
+	assert.Equal(t, "Your order shipped", jobs[0].Request.Subject)
+	assert.Equal(t, []string{"a@example.com", "c@example.com"}, jobEmails(jobs[0]))
+	assert.Equal(t, "Your invoice", jobs[1].Request.Subject)
+	assert.Equal(t, []string{"b@example.com"}, jobEmails(jobs[1]))
+
+	// The field stays available as a substitution
+	assert.Equal(t, "Your invoice", jobs[1].Recipients[0].Substitutions["subject_line"])
+
+	assert.Equal(t, 2, flags.Subjects)
+	assert.Equal(t, 2, flags.Batches)
+	assert.Empty(t, flags.ScheduleBuckets)
+}
+
+func TestCreateSendJobs_SubjectFromFieldSplitsLargeGroups(t *testing.T) {
+	var csv strings.Builder
+	csv.WriteString("email,subject_line\n")
+	for i := 0; i < 150; i++ {
+		fmt.Fprintf(&csv, "a%d@example.com,Alpha\n", i)
+	}
+	for i := 0; i < 30; i++ {
+		fmt.Fprintf(&csv, "b%d@example.com,Beta\n", i)
+	}
+	flags := subjectSendFlags(writeRecipientsFile(t, "recipients.csv", csv.String()))
+
+	jobs, err := createSendJobsFromFlags(flags)
+	require.NoError(t, err)
+	require.Len(t, jobs, 3)
+
+	assert.Equal(t, []int{100, 50, 30}, []int{jobs[0].RecipientCount, jobs[1].RecipientCount, jobs[2].RecipientCount})
+	assert.Equal(t, []string{"Alpha", "Alpha", "Beta"}, []string{jobs[0].Request.Subject, jobs[1].Request.Subject, jobs[2].Request.Subject})
+	for i, job := range jobs {
+		assert.Equal(t, i, job.BatchIndex)
+	}
+	assert.Equal(t, 2, flags.Subjects)
+	assert.Equal(t, 3, flags.Batches)
+}
+
+func TestCreateSendJobs_SubjectFromFieldWithScheduleBuckets(t *testing.T) {
+	pinScheduleNow(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+
+	recipients := writeRecipientsFile(t, "recipients.json", `[
+		{"email": "ny@example.com", "timezone": "America/New_York", "substitutions": {"subject_line": "Hello"}},
+		{"email": "berlin@example.com", "timezone": "Europe/Berlin", "substitutions": {"subject_line": "Hallo"}},
+		{"email": "ny2@example.com", "timezone": "America/New_York", "substitutions": {"subject_line": "Hallo"}}
+	]`)
+	flags := subjectSendFlags(recipients)
+	flags.ScheduleTime = "2026-03-02T09:00:00Z"
+
+	jobs, err := createSendJobsFromFlags(flags)
+	require.NoError(t, err)
+	require.Len(t, jobs, 3)
+
+	ny := time.Date(2026, 3, 2, 14, 0, 0, 0, time.UTC)
+	berlin := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+	assert.Equal(t, "Hello", jobs[0].Request.Subject)
+	assert.Equal(t, ny, *jobs[0].Request.Schedule.FirstAttempt)
+	assert.Equal(t, "Hallo", jobs[1].Request.Subject)
+	assert.Equal(t, berlin, *jobs[1].Request.Schedule.FirstAttempt)
+	assert.Equal(t, "Hallo", jobs[2].Request.Subject)
+	assert.Equal(t, ny, *jobs[2].Request.Schedule.FirstAttempt)
+
+	assert.Equal(t, 2, flags.Subjects)
+	assert.Len(t, flags.ScheduleBuckets, 2)
+}
+
+func TestCreateSendJobs_SubjectFromFieldFallback(t *testing.T) {
+	recipients := writeRecipientsFile(t, "recipients.csv", `email,subject_line
+a@example.com,Custom
+b@example.com,
+`)
+
+	t.Run("falls back to --subject", func(t *testing.T) {
+		flags := subjectSendFlags(recipients)
+		flags.Subject = "Default"
+
+		jobs, err := createSendJobsFromFlags(flags)
+		require.NoError(t, err)
+		require.Len(t, jobs, 2)
+		assert.Equal(t, "Custom", jobs[0].Request.Subject)
+		assert.Equal(t, "Default", jobs[1].Request.Subject)
+	})
+
+	t.Run("empty values need --subject", func(t *testing.T) {
+		_, err := createSendJobsFromFlags(subjectSendFlags(recipients))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "row 3: empty subject_line and no --subject to fall back to")
+	})
+}
+
+func TestCreateSendJobs_SubjectFromFieldListsMissingRecords(t *testing.T) {
+	recipients := writeRecipientsFile(t, "recipients.json", `[
+		{"email": "a@example.com", "substitutions": {"subject_line": "Hi"}},
+		{"email": "b@example.com"},
+		{"email": "c@example.com", "substitutions": {"subject_line": 5}},
+		{"email": "d@example.com", "substitutions": {"other": "x"}}
+	]`)
+	flags := subjectSendFlags(recipients)
+	flags.Subject = "Default" // only covers empty values, not missing ones
+
+	_, err := createSendJobsFromFlags(flags)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "recipients file has 3 invalid records:")
+	assert.Contains(t, err.Error(), "index 1: missing subject_line field for the subject")
+	assert.Contains(t, err.Error(), "index 2: subject_line must be a string to be used as the subject")
+	assert.Contains(t, err.Error(), "index 3: missing subject_line field for the subject")
+}
+
+func TestCreateSendJobs_SubjectFromFieldNeedsRecipientsFile(t *testing.T) {
+	flags := subjectSendFlags("")
+	flags.ToEmails = []string{"a@example.com"}
+
+	_, err := createSendJobsFromFlags(flags)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--subject-from-field requires --recipients")
+}
+
+func TestConfirmLargeSend_ReportsSubjects(t *testing.T) {
+	out := stubConfirmIO(t, true, "y\n")
+	flags := largeSendFlags()
+	flags.SubjectField = "subject_line"
+	flags.Subjects = 3
+	flags.Batches = 14
+
+	require.NoError(t, confirmLargeSend(flags, 1100))
+	assert.Contains(t, out.String(), "Subject:    from subject_line (3 distinct subjects in 14 batches)")
+}
+
+func jobEmails(job *batch.SendJob) []string {
+	var emails []string
+	for _, recipient := range job.Recipients {
+		emails = append(emails, recipient.Email)
+	}
+	return emails
+}
@@ -0,0 +1,168 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/AhaSend/ahasend-go/models/common"
+
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+)
+
+// defaultsBlock is the key of the substitution defaults in a global
+// substitutions file
+const defaultsBlock = "defaults"
+
+// parseSubstitutionDefaults reads --substitution-default key=value pairs
+func parseSubstitutionDefaults(pairs []string) (map[string]interface{}, error) {
+	defaults := make(map[string]interface{}, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, errors.NewValidationError(fmt.Sprintf("invalid --substitution-default %q, expected key=value", pair), nil)
+		}
+		defaults[key] = value
+	}
+	return defaults, nil
+}
+
+// parseSubstitutes reads --substitute key=value pairs; a later pair
+// overrides an earlier one with the same key. Values that are JSON numbers
+// or true/false become numbers and booleans, unless rawStrings is set.
+func parseSubstitutes(pairs []string, rawStrings bool) (map[string]interface{}, error) {
+	substitutions := make(map[string]interface{}, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, errors.NewValidationError(fmt.Sprintf("invalid --substitute %q, expected key=value", pair), nil)
+		}
+		if rawStrings {
+			substitutions[key] = value
+		} else {
+			substitutions[key] = substituteValue(value)
+		}
+	}
+	return substitutions, nil
+}
+
+// substituteValue types a --substitute value. Numbers are kept as
+// json.Number, so they are sent and rendered exactly as given; values that
+// are not valid JSON numbers, such as a zip code with a leading zero, stay
+// strings.
+func substituteValue(value string) interface{} {
+	switch value {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	if value != strings.TrimSpace(value) {
+		return value
+	}
+	decoder := json.NewDecoder(strings.NewReader(value))
+	decoder.UseNumber()
+	var parsed interface{}
+	if err := decoder.Decode(&parsed); err != nil || decoder.More() {
+		return value
+	}
+	if number, ok := parsed.(json.Number); ok {
+		return number
+	}
+	return value
+}
+
+// mergeGlobalSubstitutions layers --substitute values over the global
+// substitutions loaded from --global-substitutions. It returns nil when
+// there are none, so the request carries no substitutions.
+func mergeGlobalSubstitutions(loaded, flagSubstitutions map[string]interface{}) map[string]interface{} {
+	if len(loaded)+len(flagSubstitutions) == 0 {
+		return nil
+	}
+	merged := make(map[string]interface{}, len(loaded)+len(flagSubstitutions))
+	for key, value := range loaded {
+		merged[key] = value
+	}
+	for key, value := range flagSubstitutions {
+		merged[key] = value
+	}
+	return merged
+}
+
+// splitSubstitutionDefaults removes the "defaults" object from global
+// substitutions loaded from a file and returns it
+func splitSubstitutionDefaults(global map[string]interface{}) (map[string]interface{}, error) {
+	block, ok := global[defaultsBlock]
+	if !ok {
+		return nil, nil
+	}
+	defaults, ok := block.(map[string]interface{})
+	if !ok {
+		return nil, errors.NewValidationError(`"defaults" in the global substitutions file must be an object`, nil)
+	}
+	delete(global, defaultsBlock)
+	return defaults, nil
+}
+
+// mergeSubstitutionDefaults layers defaults from the global substitutions
+// file beneath --substitution-default values
+func mergeSubstitutionDefaults(fileDefaults, flagDefaults map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(fileDefaults)+len(flagDefaults))
+	for key, value := range fileDefaults {
+		merged[key] = value
+	}
+	for key, value := range flagDefaults {
+		merged[key] = value
+	}
+	return merged
+}
+
+// mergeSubstitutions returns the substitutions a recipient's message is
+// rendered with: per-recipient values override global ones, and defaults
+// fill the keys that are still missing or empty
+func mergeSubstitutions(global, recipient, defaults map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(global)+len(recipient)+len(defaults))
+	for key, value := range global {
+		merged[key] = value
+	}
+	for key, value := range recipient {
+		merged[key] = value
+	}
+	for key, value := range defaults {
+		if isEmptySubstitution(merged[key]) {
+			merged[key] = value
+		}
+	}
+	return merged
+}
+
+// isEmptySubstitution reports whether a substitution value renders as nothing
+func isEmptySubstitution(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	s, ok := value.(string)
+	return ok && strings.TrimSpace(s) == ""
+}
+
+// applySubstitutionDefaults adds to the recipient's substitutions the
+// defaults for keys that are missing or empty after merging them with the
+// global substitutions. The API merges global and per-recipient values
+// itself, so a default has to travel with each recipient that needs it.
+func applySubstitutionDefaults(recipient *common.Recipient, global, defaults map[string]interface{}) {
+	if len(defaults) == 0 {
+		return
+	}
+	merged := mergeSubstitutions(global, recipient.Substitutions, nil)
+	for key, value := range defaults {
+		if !isEmptySubstitution(merged[key]) {
+			continue
+		}
+		if recipient.Substitutions == nil {
+			recipient.Substitutions = make(map[string]interface{})
+		}
+		recipient.Substitutions[key] = value
+	}
+}
@@ -0,0 +1,213 @@
+package messages
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AhaSend/ahasend-go/models/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeSubstitutions_Precedence(t *testing.T) {
+	global := map[string]interface{}{"first_name": "Friend", "company": "AhaSend", "plan": ""}
+	defaults := map[string]interface{}{"first_name": "valued customer", "plan": "free", "city": "somewhere"}
+
+	tests := []struct {
+		name      string
+		recipient map[string]interface{}
+		want      map[string]interface{}
+	}{
+		{
+			name:      "per-recipient value wins",
+			recipient: map[string]interface{}{"first_name": "Ana", "plan": "pro", "city": "Lisbon"},
+			want:      map[string]interface{}{"first_name": "Ana", "company": "AhaSend", "plan": "pro", "city": "Lisbon"},
+		},
+		{
+			name:      "global value beats the default",
+			recipient: nil,
+			want:      map[string]interface{}{"first_name": "Friend", "company": "AhaSend", "plan": "free", "city": "somewhere"},
+		},
+		{
+			name:      "empty per-recipient value falls back to the default",
+			recipient: map[string]interface{}{"first_name": " "},
+			want:      map[string]interface{}{"first_name": "valued customer", "company": "AhaSend", "plan": "free", "city": "somewhere"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, mergeSubstitutions(global, tt.recipient, defaults))
+		})
+	}
+}
+
+func TestApplySubstitutionDefaults(t *testing.T) {
+	global := map[string]interface{}{"company": "AhaSend"}
+	defaults := map[string]interface{}{"first_name": "valued customer", "company": "us"}
+
+	withName := common.Recipient{Email: "ana@example.com", Substitutions: map[string]interface{}{"first_name": "Ana"}}
+	applySubstitutionDefaults(&withName, global, defaults)
+	assert.Equal(t, map[string]interface{}{"first_name": "Ana"}, withName.Substitutions,
+		"the global company is sent once by the API, not copied to each recipient")
+
+	withoutName := common.Recipient{Email: "bo@example.com"}
+	applySubstitutionDefaults(&withoutName, global, defaults)
+	assert.Equal(t, map[string]interface{}{"first_name": "valued customer"}, withoutName.Substitutions)
+
+	untouched := common.Recipient{Email: "cy@example.com"}
+	applySubstitutionDefaults(&untouched, global, nil)
+	assert.Nil(t, untouched.Substitutions)
+}
+
+func TestParseSubstitutionDefaults(t *testing.T) {
+	defaults, err := parseSubstitutionDefaults([]string{"first_name=valued customer", "greeting=a=b"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"first_name": "valued customer", "greeting": "a=b"}, defaults)
+
+	_, err = parseSubstitutionDefaults([]string{"first_name"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expected key=value")
+}
+
+func TestSplitSubstitutionDefaults(t *testing.T) {
+	global := map[string]interface{}{"company": "AhaSend", "defaults": map[string]interface{}{"first_name": "friend"}}
+	defaults, err := splitSubstitutionDefaults(global)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"first_name": "friend"}, defaults)
+	assert.Equal(t, map[string]interface{}{"company": "AhaSend"}, global)
+
+	_, err = splitSubstitutionDefaults(map[string]interface{}{"defaults": "friend"})
+	assert.Error(t, err)
+}
+
+func TestCreateSendJobs_SubstitutionDefaults(t *testing.T) {
+	dir := t.TempDir()
+	recipientsFile := filepath.Join(dir, "recipients.csv")
+	require.NoError(t, os.WriteFile(recipientsFile, []byte("email,first_name\nana@example.com,Ana\nbo@example.com,\n"), 0600))
+	globalFile := filepath.Join(dir, "global.json")
+	require.NoError(t, os.WriteFile(globalFile, []byte(`{"company": "AhaSend", "defaults": {"first_name": "friend", "city": "your city"}}`), 0600))
+
+	jobs, _, err := createSendJobs(
+		"news@example.com", nil, nil, nil, recipientsFile, false, "Hi {{first_name}}", "",
+		"Hello {{first_name}} from {{city}}", "", "",
+		"", "", "", false,
+		globalFile, nil, false, []string{"first_name=valued customer"},
+		nil, "", 0, false, "", nil,
+		false, false, nil, nil, false, "", "key",
+	)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+
+	request := jobs[0].Request
+	assert.Equal(t, map[string]interface{}{"company": "AhaSend"}, request.Substitutions)
+	require.Len(t, request.Recipients, 2)
+	assert.Equal(t, map[string]interface{}{"first_name": "Ana", "city": "your city"}, request.Recipients[0].Substitutions)
+	assert.Equal(t, map[string]interface{}{"first_name": "valued customer", "city": "your city"}, request.Recipients[1].Substitutions)
+}
+
+func TestParseSubstitutes_Types(t *testing.T) {
+	substitutions, err := parseSubstitutes([]string{
+		"name=Ana", "count=42", "price=9.99", "vip=true", "trial=false",
+		"zip=02134", "code=12abc", "big=12345678901234567890", "quoted=\"7\"", "padded= 7", "expr=1 2", "empty=", "equation=a=b",
+	}, false)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"name":     "Ana",
+		"count":    json.Number("42"),
+		"price":    json.Number("9.99"),
+		"vip":      true,
+		"trial":    false,
+		"zip":      "02134",
+		"code":     "12abc",
+		"big":      json.Number("12345678901234567890"),
+		"quoted":   "\"7\"",
+		"padded":   " 7",
+		"expr":     "1 2",
+		"empty":    "",
+		"equation": "a=b",
+	}, substitutions)
+
+	raw, err := parseSubstitutes([]string{"count=42", "vip=true"}, true)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"count": "42", "vip": "true"}, raw)
+
+	_, err = parseSubstitutes([]string{"=value"}, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --substitute")
+}
+
+func TestLoadGlobalSubstitutions_Inline(t *testing.T) {
+	substitutions, err := loadGlobalSubstitutions(` {"company": "AhaSend", "year": 2026}`)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"company": "AhaSend", "year": float64(2026)}, substitutions)
+
+	_, err = loadGlobalSubstitutions(`{"company": `)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "inline --global-substitutions")
+
+	_, err = loadGlobalSubstitutions(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot open global substitutions file")
+}
+
+func TestCreateSendJobs_SubstitutePrecedence(t *testing.T) {
+	globalFile := filepath.Join(t.TempDir(), "global.json")
+	require.NoError(t, os.WriteFile(globalFile, []byte(`{"company": "AhaSend", "plan": "free", "defaults": {"city": "your city"}}`), 0600))
+
+	tests := []struct {
+		name        string
+		global      string
+		substitutes []string
+		raw         bool
+		want        map[string]interface{}
+	}{
+		{
+			name:   "file only",
+			global: globalFile,
+			want:   map[string]interface{}{"company": "AhaSend", "plan": "free"},
+		},
+		{
+			name:        "substitute overrides the file",
+			global:      globalFile,
+			substitutes: []string{"plan=pro", "seats=5"},
+			want:        map[string]interface{}{"company": "AhaSend", "plan": "pro", "seats": json.Number("5")},
+		},
+		{
+			name:        "substitute overrides inline JSON",
+			global:      `{"plan": "free", "seats": 1}`,
+			substitutes: []string{"seats=5"},
+			raw:         true,
+			want:        map[string]interface{}{"plan": "free", "seats": "5"},
+		},
+		{
+			name:        "later substitute wins",
+			substitutes: []string{"plan=pro", "plan=team"},
+			want:        map[string]interface{}{"plan": "team"},
+		},
+		{
+			name: "none",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobs, _, err := createSendJobs(
+				"news@example.com", []string{"ana@example.com"}, nil, nil, "", false, "Hi", "",
+				"Hello from {{company}}", "", "",
+				"", "", "", false,
+				tt.global, tt.substitutes, tt.raw, nil,
+				nil, "", 0, false, "", nil,
+				false, false, nil, nil, false, "", "key",
+			)
+			require.NoError(t, err)
+			require.Len(t, jobs, 1)
+			if tt.want == nil {
+				assert.Nil(t, jobs[0].Request.Substitutions)
+				return
+			}
+			assert.Equal(t, tt.want, jobs[0].Request.Substitutions)
+		})
+	}
+}
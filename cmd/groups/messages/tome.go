@@ -0,0 +1,68 @@
+package messages
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// testSubjectPrefix marks messages sent with --to-me
+	testSubjectPrefix = "[TEST] "
+
+	// defaultTestTag is added to --to-me sends when neither --test-tag nor
+	// the profile's test_tag is set
+	defaultTestTag = "test"
+)
+
+// applyToMe rewrites the send flags for a --to-me test send: the recipient
+// comes from the profile's default_test_recipient, the subject is prefixed
+// with [TEST], sandbox mode is turned off and the test tag is added.
+func applyToMe(cmd *cobra.Command, flags *SendFlags) error {
+	profile, profileName, err := loadSelectedProfile(cmd)
+	if err != nil {
+		return errors.NewConfigError("failed to load profile for --to-me", err)
+	}
+	if profile.DefaultTestRecipient == "" {
+		return errors.NewConfigError(fmt.Sprintf(
+			"no default test recipient configured for profile '%s'. Set one with: ahasend config set default-test-recipient you@example.com",
+			profileName), nil)
+	}
+
+	if flags.Subject == "" {
+		subject, err := promptSubject()
+		if err != nil {
+			return errors.NewValidationError("failed to get email subject", err)
+		}
+		flags.Subject = subject
+	}
+	if !strings.HasPrefix(flags.Subject, testSubjectPrefix) {
+		flags.Subject = testSubjectPrefix + flags.Subject
+	}
+
+	testTag := defaultTestTag
+	if cmd.Flags().Changed("test-tag") {
+		testTag = getStringFlag(cmd, "test-tag")
+	} else if profile.TestTag != "" {
+		testTag = profile.TestTag
+	}
+	if testTag != "" && !slices.Contains(flags.Tags, testTag) {
+		flags.Tags = append(flags.Tags, testTag)
+	}
+
+	flags.ToEmails = []string{profile.DefaultTestRecipient}
+	flags.Sandbox = false
+	flags.SandboxResult = ""
+
+	logger.Get().WithFields(map[string]interface{}{
+		"profile":   profileName,
+		"recipient": profile.DefaultTestRecipient,
+		"tag":       testTag,
+	}).Debug("Sending test message to default test recipient")
+
+	return nil
+}
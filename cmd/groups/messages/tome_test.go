@@ -0,0 +1,87 @@
+package messages
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/config"
+)
+
+// setupTestProfile stores a default profile with the given test settings
+func setupTestProfile(t *testing.T, recipient, tag string) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	mgr, err := config.NewManager()
+	require.NoError(t, err)
+	require.NoError(t, mgr.Load())
+	require.NoError(t, mgr.SetProfile("default", config.Profile{
+		APIKey:               "key",
+		AccountID:            "id",
+		DefaultTestRecipient: recipient,
+		TestTag:              tag,
+	}))
+}
+
+func TestApplyToMe(t *testing.T) {
+	setupTestProfile(t, "me@example.com", "")
+
+	cmd := NewSendCommand()
+	require.NoError(t, cmd.ParseFlags([]string{"--to-me", "--subject", "Welcome", "--sandbox", "--tags", "onboarding"}))
+	flags := parseSendFlags(cmd)
+
+	require.NoError(t, applyToMe(cmd, flags))
+	assert.Equal(t, []string{"me@example.com"}, flags.ToEmails)
+	assert.Equal(t, "[TEST] Welcome", flags.Subject)
+	assert.False(t, flags.Sandbox, "test sends go to a real inbox")
+	assert.Equal(t, []string{"onboarding", defaultTestTag}, flags.Tags)
+
+	// Applying twice does not double the prefix or tag
+	require.NoError(t, applyToMe(cmd, flags))
+	assert.Equal(t, "[TEST] Welcome", flags.Subject)
+	assert.Equal(t, []string{"onboarding", defaultTestTag}, flags.Tags)
+}
+
+func TestApplyToMe_TestTagPrecedence(t *testing.T) {
+	setupTestProfile(t, "me@example.com", "qa")
+
+	cmd := NewSendCommand()
+	require.NoError(t, cmd.ParseFlags([]string{"--to-me", "--subject", "Hi"}))
+	flags := parseSendFlags(cmd)
+	require.NoError(t, applyToMe(cmd, flags))
+	assert.Equal(t, []string{"qa"}, flags.Tags, "profile tag replaces the default")
+
+	cmd = NewSendCommand()
+	require.NoError(t, cmd.ParseFlags([]string{"--to-me", "--subject", "Hi", "--test-tag", "preview"}))
+	flags = parseSendFlags(cmd)
+	require.NoError(t, applyToMe(cmd, flags))
+	assert.Equal(t, []string{"preview"}, flags.Tags, "flag overrides the profile")
+}
+
+func TestApplyToMe_NoRecipientConfigured(t *testing.T) {
+	setupTestProfile(t, "", "")
+
+	cmd := NewSendCommand()
+	require.NoError(t, cmd.ParseFlags([]string{"--to-me", "--subject", "Hi"}))
+
+	err := applyToMe(cmd, parseSendFlags(cmd))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ahasend config set default-test-recipient")
+}
+
+func TestSendCommand_ToMeMutuallyExclusive(t *testing.T) {
+	for _, other := range [][]string{
+		{"--to", "someone@example.com"},
+		{"--recipients", "list.csv"},
+	} {
+		cmd := NewSendCommand()
+		cmd.SetArgs(append([]string{"--to-me", "--from", "a@example.com", "--subject", "x", "--text", "y"}, other...))
+		cmd.SilenceErrors = true
+
+		err := cmd.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "none of the others can be")
+	}
+}
@@ -0,0 +1,33 @@
+package reminders
+
+import (
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/state"
+	"github.com/spf13/cobra"
+)
+
+// NewDismissCommand creates the reminders dismiss command
+func NewDismissCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dismiss <reminder-id>",
+		Short: "Dismiss a reminder",
+		Long: `Remove a reminder once it has been handled. Dismissing a reminder does not
+perform the action it describes.`,
+		Example: `  # Dismiss a reminder
+  ahasend reminders dismiss 4f9c2b1e-8d3a-4c55-9e0f-1a2b3c4d5e6f`,
+		Args:         cobra.ExactArgs(1),
+		RunE:         runRemindersDismiss,
+		SilenceUsage: true,
+	}
+
+	return cmd
+}
+
+func runRemindersDismiss(cmd *cobra.Command, args []string) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+
+	if err := state.RemoveReminder(args[0]); err != nil {
+		return err
+	}
+	return handler.HandleSimpleSuccess("Reminder dismissed")
+}
@@ -0,0 +1,63 @@
+package reminders
+
+import (
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/state"
+	"github.com/spf13/cobra"
+)
+
+// NewListCommand creates the reminders list command
+func NewListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List reminders",
+		Long: `List the reminders recorded in ~/.ahasend/state.json, soonest first. Each
+reminder shows when it is due and what to do.`,
+		Example: `  # List all reminders
+  ahasend reminders list
+
+  # Only reminders that are due now
+  ahasend reminders list --due --output json`,
+		Args:         cobra.NoArgs,
+		RunE:         runRemindersList,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().Bool("due", false, "Only show reminders that are due")
+	printer.AddIDsOnlyFlag(cmd)
+
+	return cmd
+}
+
+func runRemindersList(cmd *cobra.Command, args []string) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+	dueOnly, _ := cmd.Flags().GetBool("due")
+
+	s, err := state.Load()
+	if err != nil {
+		return err
+	}
+
+	reminders := s.SortedReminders()
+	if dueOnly {
+		now := time.Now()
+		due := []state.Reminder{}
+		for _, reminder := range reminders {
+			if reminder.Due(now) {
+				due = append(due, reminder)
+			}
+		}
+		reminders = due
+	}
+
+	emptyMessage := "No reminders"
+	if dueOnly {
+		emptyMessage = "No reminders are due"
+	}
+	return handler.HandleReminderList(reminders, printer.ListConfig{
+		SuccessMessage: "Reminders",
+		EmptyMessage:   emptyMessage,
+	})
+}
@@ -0,0 +1,34 @@
+package reminders
+
+import (
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates the reminders command group
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reminders",
+		Short: "View follow-up reminders recorded by the CLI",
+		Long: `View and dismiss follow-up reminders the CLI has recorded for you, such as
+revoking an old API key after rotating it with 'ahasend apikeys clone
+--revoke-source-after'.
+
+Reminders are stored locally in ~/.ahasend/state.json. The CLI never acts on
+them; it only reminds you.`,
+		Example: `  # List reminders, soonest first
+  ahasend reminders list
+
+  # Only reminders that are due
+  ahasend reminders list --due
+
+  # Dismiss a reminder once handled
+  ahasend reminders dismiss 4f9c2b1e-8d3a-4c55-9e0f-1a2b3c4d5e6f`,
+		Annotations: map[string]string{auth.NoAuthAnnotation: "true"},
+	}
+
+	cmd.AddCommand(NewListCommand())
+	cmd.AddCommand(NewDismissCommand())
+
+	return cmd
+}
@@ -0,0 +1,78 @@
+package reminders
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func executeReminders(t *testing.T, format string, args ...string) (string, error) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	handler := printer.GetResponseHandler(format, false, &buf)
+	cmd := NewCommand()
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+func TestRemindersCommand_Structure(t *testing.T) {
+	cmd := NewCommand()
+	assert.Equal(t, "reminders", cmd.Name())
+	assert.Len(t, cmd.Commands(), 2)
+}
+
+func TestRemindersList(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	out, err := executeReminders(t, "plain", "list")
+	require.NoError(t, err)
+	assert.Contains(t, out, "No reminders")
+
+	future, err := state.AddReminder(state.Reminder{Kind: state.ReminderRevokeAPIKey, Message: "Revoke key B", DueAt: time.Now().Add(72 * time.Hour)})
+	require.NoError(t, err)
+	past, err := state.AddReminder(state.Reminder{Kind: state.ReminderRevokeAPIKey, Message: "Revoke key A", DueAt: time.Now().Add(-time.Hour)})
+	require.NoError(t, err)
+
+	out, err = executeReminders(t, "table", "list")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Revoke key A")
+	assert.Contains(t, out, "due")
+	assert.Contains(t, out, "in 2d")
+	assert.Less(t, bytes.Index([]byte(out), []byte("Revoke key A")), bytes.Index([]byte(out), []byte("Revoke key B")))
+
+	out, err = executeReminders(t, "json", "list", "--due")
+	require.NoError(t, err)
+	var decoded struct {
+		Data []state.Reminder `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &decoded))
+	require.Len(t, decoded.Data, 1)
+	assert.Equal(t, past.ID, decoded.Data[0].ID)
+
+	out, err = executeReminders(t, "table", "list", "--ids-only")
+	require.NoError(t, err)
+	assert.Equal(t, past.ID+"\n"+future.ID+"\n", out)
+
+	_, err = executeReminders(t, "plain", "dismiss", future.ID)
+	require.NoError(t, err)
+	s, err := state.Load()
+	require.NoError(t, err)
+	require.Len(t, s.Reminders, 1)
+	assert.Equal(t, past.ID, s.Reminders[0].ID)
+
+	_, err = executeReminders(t, "plain", "dismiss", "unknown")
+	require.Error(t, err)
+}
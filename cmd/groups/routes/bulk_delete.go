@@ -0,0 +1,112 @@
+package routes
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/bulk"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/fetch"
+	"github.com/AhaSend/ahasend-cli/internal/glob"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/spf13/cobra"
+)
+
+// bulkOnlyFlags only apply together with --matching
+var bulkOnlyFlags = []string{"enabled-only", "case-sensitive", "yes", "concurrency"}
+
+// deleteArgs requires a route ID unless --matching selects the routes
+func deleteArgs(cmd *cobra.Command, args []string) error {
+	if cmd.Flags().Changed("matching") {
+		if len(args) > 0 {
+			return errors.NewValidationError("a route ID cannot be combined with --matching", nil)
+		}
+		return nil
+	}
+	for _, flag := range bulkOnlyFlags {
+		if cmd.Flags().Changed(flag) {
+			return errors.NewValidationError(fmt.Sprintf("--%s requires --matching", flag), nil)
+		}
+	}
+	return cobra.ExactArgs(1)(cmd, args)
+}
+
+func runRoutesBulkDelete(cmd *cobra.Command) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+
+	matching, _ := cmd.Flags().GetString("matching")
+	enabledOnly, _ := cmd.Flags().GetBool("enabled-only")
+	caseSensitive, _ := cmd.Flags().GetBool("case-sensitive")
+	yes, _ := cmd.Flags().GetBool("yes")
+	force, _ := cmd.Flags().GetBool("force")
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+
+	if concurrency < 1 {
+		return errors.NewValidationError("--concurrency must be at least 1", nil)
+	}
+	pattern, err := glob.Compile(matching, caseSensitive)
+	if err != nil {
+		return err
+	}
+
+	apiClient, err := auth.GetAuthenticatedClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	logger.Get().WithFields(map[string]interface{}{
+		"matching":       matching,
+		"enabled_only":   enabledOnly,
+		"case_sensitive": caseSensitive,
+		"concurrency":    concurrency,
+	}).Debug("Executing routes bulk delete command")
+
+	return bulk.Run(handler, cmd.InOrStdin(), cmd.ErrOrStderr(), bulk.Selection[responses.Route]{
+		Pattern:      matching,
+		Singular:     "route",
+		Plural:       "routes",
+		EmptyMessage: fmt.Sprintf("No routes match '%s'", matching),
+		Yes:          yes || force,
+		Concurrency:  concurrency,
+		List: func() ([]responses.Route, error) {
+			all, err := fetch.AllRoutes(apiClient)
+			if err != nil {
+				return nil, err
+			}
+			return filterRoutesByName(all, pattern, enabledOnly), nil
+		},
+		Target: func(route responses.Route) bulk.Target {
+			return bulk.Target{ID: route.ID.String(), Name: route.Name}
+		},
+		Preview: func(out io.Writer, matched []responses.Route) error {
+			preview := printer.GetResponseHandler("table", false, out)
+			if err := preview.HandleRouteList(&responses.PaginatedRoutesResponse{Data: matched}, printer.ListConfig{
+				FieldOrder: []string{"id", "name", "url", "enabled", "recipient"},
+			}); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "%d routes match '%s'\n", len(matched), matching)
+			return nil
+		},
+		Delete: func(route responses.Route) error {
+			return apiClient.DeleteRoute(route.ID.String())
+		},
+	})
+}
+
+// filterRoutesByName returns the routes whose name matches the pattern
+func filterRoutesByName(routes []responses.Route, pattern *glob.Pattern, enabledOnly bool) []responses.Route {
+	var matched []responses.Route
+	for _, route := range routes {
+		if enabledOnly && !route.Enabled {
+			continue
+		}
+		if pattern.Match(route.Name) {
+			matched = append(matched, route)
+		}
+	}
+	return matched
+}
@@ -0,0 +1,60 @@
+package routes
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-go/models/common"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoutesBulkDelete(t *testing.T) {
+	mockClient := &mocks.MockClient{}
+	first := mockClient.NewMockRoute(uuid.New().String(), "test-inbound", "https://example.com/a", "", true)
+	second := mockClient.NewMockRoute(uuid.New().String(), "Test-Replies", "https://example.com/b", "", true)
+	other := mockClient.NewMockRoute(uuid.New().String(), "support", "https://example.com/c", "", true)
+
+	nextCursor := "page-2"
+	mockClient.On("ListRoutes", (*int32)(nil), (*string)(nil)).Return(&responses.PaginatedRoutesResponse{
+		Data:       []responses.Route{*first, *other},
+		Pagination: common.PaginationInfo{HasMore: true, NextCursor: &nextCursor},
+	}, nil)
+	mockClient.On("ListRoutes", (*int32)(nil), &nextCursor).Return(&responses.PaginatedRoutesResponse{
+		Data: []responses.Route{*second},
+	}, nil)
+	mockClient.On("DeleteRoute", first.ID.String()).Return(nil).Once()
+	mockClient.On("DeleteRoute", second.ID.String()).Return(errors.New("not found")).Once()
+
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var stdout, stderr bytes.Buffer
+	handler := printer.GetResponseHandler("plain", false, &stdout)
+	cmd := NewDeleteCommand()
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetIn(strings.NewReader("2\n"))
+	cmd.SetArgs([]string{"--matching", "test-*"})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to delete 1 of 2 routes")
+	assert.Contains(t, stderr.String(), "2 routes match 'test-*'")
+	assert.Contains(t, stdout.String(), "Failed Test-Replies")
+	assert.Contains(t, stdout.String(), "Deleted 1 of 2 routes matching 'test-*', 1 failed")
+	mockClient.AssertExpectations(t)
+}
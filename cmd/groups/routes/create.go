@@ -6,9 +6,13 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/AhaSend/ahasend-cli/internal/auth"
 	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/fetch"
 	"github.com/AhaSend/ahasend-cli/internal/logger"
 	"github.com/AhaSend/ahasend-cli/internal/printer"
 	"github.com/AhaSend/ahasend-go/models/requests"
@@ -35,7 +39,17 @@ Interactive mode provides step-by-step guidance for configuring:
 - Route status (enabled/disabled)
 
 Non-interactive mode allows automation and scripting by providing
-all configuration through flags.`,
+all configuration through flags.
+
+The recipient filter is an address or a pattern with at most one '*' in the
+local part and one in the domain, e.g. 'support-*@example.com' or
+'*@*.example.com'; without a filter the route matches every recipient. The
+filter is checked before the route is created, and so is --recipient in
+'routes update'.
+
+Creating a route with the name of an existing route (ignoring case) is
+refused unless --allow-duplicate-name is given. If the existing routes
+cannot be listed, a warning is shown and the route is created anyway.`,
 		Example: `  # Interactive route creation
   ahasend routes create
 
@@ -54,7 +68,7 @@ all configuration through flags.`,
   ahasend routes create \
     --name "Sales Inquiries" \
     --url "https://api.example.com/sales" \
-    --recipient "*sales*" \
+    --recipient "sales-*@*" \
     --include-headers \
     --group-by-message-id \
     --strip-replies \
@@ -73,6 +87,7 @@ all configuration through flags.`,
 	cmd.Flags().Bool("strip-replies", false, "Strip reply content from emails")
 	cmd.Flags().Bool("enabled", false, "Enable the route immediately after creation")
 	cmd.Flags().Bool("interactive", true, "Use interactive mode for route configuration")
+	cmd.Flags().Bool("allow-duplicate-name", false, "Create the route even if another route has the same name")
 
 	return cmd
 }
@@ -137,6 +152,10 @@ func runRoutesCreate(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if err := checkDuplicateRouteName(cmd, client, config.Name); err != nil {
+		return err
+	}
+
 	// Create the route
 	route, err := createRoute(client, config)
 	if err != nil {
@@ -265,6 +284,13 @@ func validateRouteConfig(config RouteCreateConfig) error {
 		return fmt.Errorf("webhook URL must include a valid host")
 	}
 
+	// An empty recipient filter matches all recipients
+	if config.Recipient != "" {
+		if err := validateRecipientPattern(config.Recipient); err != nil {
+			return err
+		}
+	}
+
 	// Security warning for HTTP URLs
 	if parsedURL.Scheme == "http" {
 		fmt.Println("⚠️  Warning: Using HTTP URL for webhook. Consider using HTTPS for production.")
@@ -273,6 +299,75 @@ func validateRouteConfig(config RouteCreateConfig) error {
 	return nil
 }
 
+// validateRecipientPattern checks a recipient filter: an address, or a
+// pattern with at most one '*' in the local part and one in the domain, such
+// as "support-*@example.com" or "*@*.example.com". Errors name the position
+// of the offending character, counted from 1.
+func validateRecipientPattern(pattern string) error {
+	index := strings.Index(pattern, "@")
+	if index < 0 {
+		return fmt.Errorf("recipient pattern must be an email pattern (e.g., *@domain.com), got %q", pattern)
+	}
+	runes := []rune(pattern)
+	at := utf8.RuneCountInString(pattern[:index]) // rune index of the first '@'
+
+	localWildcard, domainWildcard := false, false
+	for i, r := range runes {
+		position := i + 1
+		switch {
+		case unicode.IsSpace(r) || unicode.IsControl(r) || strings.ContainsRune(`,;<>"`, r):
+			return fmt.Errorf("invalid recipient pattern %q: unexpected %q at position %d", pattern, r, position)
+		case r == '@' && i != at:
+			return fmt.Errorf("invalid recipient pattern %q: unexpected second '@' at position %d", pattern, position)
+		case r == '*' && i < at:
+			if localWildcard {
+				return fmt.Errorf("recipient pattern contains too many wildcards: second '*' in the local part at position %d of %q", position, pattern)
+			}
+			localWildcard = true
+		case r == '*':
+			if domainWildcard {
+				return fmt.Errorf("recipient pattern contains too many wildcards: second '*' in the domain at position %d of %q", position, pattern)
+			}
+			domainWildcard = true
+		}
+	}
+
+	if at == 0 {
+		return fmt.Errorf("invalid recipient pattern %q: nothing before the '@' at position 1 (use '*' to match any local part)", pattern)
+	}
+	if at == len(runes)-1 {
+		return fmt.Errorf("invalid recipient pattern %q: nothing after the '@' at position %d (use '*' to match any domain)", pattern, at+1)
+	}
+	return nil
+}
+
+// checkDuplicateRouteName refuses a name that an existing route already has,
+// ignoring case, unless --allow-duplicate-name is set. The guard must never
+// block creation on its own read, so a failed lookup only warns.
+func checkDuplicateRouteName(cmd *cobra.Command, apiClient client.AhaSendClient, name string) error {
+	if allow, _ := cmd.Flags().GetBool("allow-duplicate-name"); allow {
+		return nil
+	}
+
+	existing, err := fetch.AllRoutes(apiClient)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "⚠️  Could not check for existing routes named '%s', creating anyway: %v\n", name, err)
+		return nil
+	}
+
+	var matches []string
+	for _, route := range existing {
+		if strings.EqualFold(strings.TrimSpace(route.Name), strings.TrimSpace(name)) {
+			matches = append(matches, fmt.Sprintf("'%s' %s (%s)", route.Name, route.ID, route.URL))
+		}
+	}
+	if len(matches) == 0 {
+		return nil
+	}
+	return errors.NewValidationError(fmt.Sprintf("a route with the name '%s' already exists: %s; pass --allow-duplicate-name to create another",
+		name, strings.Join(matches, ", ")), nil)
+}
+
 func createRoute(client client.AhaSendClient, config RouteCreateConfig) (*responses.Route, error) {
 	// Build create request
 	req := requests.CreateRouteRequest{
@@ -0,0 +1,89 @@
+package routes
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func executeRouteCreate(t *testing.T, listErr error, args ...string) (string, error, *mocks.MockClient) {
+	t.Helper()
+
+	mockClient := &mocks.MockClient{}
+	existing := mockClient.NewMockRoute(uuid.New().String(), "support", "https://example.com/support", "support@*", true)
+	if listErr != nil {
+		mockClient.On("ListRoutes", (*int32)(nil), (*string)(nil)).Return(nil, listErr)
+	} else {
+		mockClient.On("ListRoutes", (*int32)(nil), (*string)(nil)).Return(&responses.PaginatedRoutesResponse{
+			Data: []responses.Route{*existing},
+		}, nil)
+	}
+	created := mockClient.NewMockRoute(uuid.New().String(), "new", "https://example.com/new", "", true)
+	mockClient.On("CreateRoute", mock.AnythingOfType("requests.CreateRouteRequest")).Return(created, nil)
+
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var stdout, stderr bytes.Buffer
+	handler := printer.GetResponseHandler("plain", false, &stdout)
+	cmd := NewCreateCommand()
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs(append([]string{"--interactive=false", "--url", "https://example.com/new"}, args...))
+
+	err := cmd.Execute()
+	return stderr.String(), err, mockClient
+}
+
+func TestRoutesCreate_DuplicateName(t *testing.T) {
+	t.Run("exact collision", func(t *testing.T) {
+		_, err, mockClient := executeRouteCreate(t, nil, "--name", "support")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "a route with the name 'support' already exists")
+		assert.Contains(t, err.Error(), "https://example.com/support")
+		assert.Contains(t, err.Error(), "--allow-duplicate-name")
+		mockClient.AssertNotCalled(t, "CreateRoute", mock.Anything)
+	})
+
+	t.Run("collision in different case", func(t *testing.T) {
+		_, err, mockClient := executeRouteCreate(t, nil, "--name", "Support")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "'support'")
+		mockClient.AssertNotCalled(t, "CreateRoute", mock.Anything)
+	})
+
+	t.Run("similar name is not a collision", func(t *testing.T) {
+		_, err, mockClient := executeRouteCreate(t, nil, "--name", "support-eu")
+		require.NoError(t, err)
+		mockClient.AssertCalled(t, "CreateRoute", mock.Anything)
+	})
+
+	t.Run("override flag", func(t *testing.T) {
+		_, err, mockClient := executeRouteCreate(t, nil, "--name", "SUPPORT", "--allow-duplicate-name")
+		require.NoError(t, err)
+		mockClient.AssertNotCalled(t, "ListRoutes", mock.Anything, mock.Anything)
+		mockClient.AssertCalled(t, "CreateRoute", mock.Anything)
+	})
+
+	t.Run("list failure warns and proceeds", func(t *testing.T) {
+		stderr, err, mockClient := executeRouteCreate(t, errors.New("service unavailable"), "--name", "support")
+		require.NoError(t, err)
+		assert.Contains(t, stderr, "Could not check for existing routes named 'support'")
+		mockClient.AssertCalled(t, "CreateRoute", mock.Anything)
+	})
+}
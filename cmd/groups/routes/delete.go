@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/bulk"
 	"github.com/AhaSend/ahasend-cli/internal/client"
 	"github.com/AhaSend/ahasend-cli/internal/logger"
 	"github.com/AhaSend/ahasend-cli/internal/output"
@@ -18,7 +19,7 @@ import (
 // NewDeleteCommand creates the delete command
 func NewDeleteCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "delete <route-id>",
+		Use:   "delete [route-id]",
 		Short: "Delete an inbound email route",
 		Long: `Delete an inbound email route permanently from your account.
 
@@ -33,7 +34,16 @@ Before deletion, you'll be shown the route details and asked for confirmation
 unless you use the --force flag for automation.
 
 Consider disabling the route instead of deleting it if you might need to
-restore it later: ahasend routes update <route-id> --disabled`,
+restore it later: ahasend routes update <route-id> --disabled
+
+Bulk deletion:
+  --matching deletes every route whose name matches a glob pattern ('*'
+  matches any characters, '?' a single character). Matching is
+  case-insensitive unless --case-sensitive is given, and --enabled-only
+  restricts it to enabled routes. A preview of the matched routes is shown
+  and you must type their count to confirm, unless --yes (or --force) is
+  given. Deletions run in parallel (--concurrency); a failure does not stop
+  the others, and the command exits non-zero if any deletion failed.`,
 		Example: `  # Delete route with confirmation
   ahasend routes delete abcd1234-5678-90ef-abcd-1234567890ab
 
@@ -41,18 +51,33 @@ restore it later: ahasend routes update <route-id> --disabled`,
   ahasend routes delete abcd1234-5678-90ef-abcd-1234567890ab --force
 
   # Delete route with JSON output
-  ahasend routes delete abcd1234-5678-90ef-abcd-1234567890ab --force --output json`,
-		Args:         cobra.ExactArgs(1),
+  ahasend routes delete abcd1234-5678-90ef-abcd-1234567890ab --force --output json
+
+  # Delete all routes left over from load tests
+  ahasend routes delete --matching "test-*"
+
+  # Delete enabled matching routes without a prompt
+  ahasend routes delete --matching "test-*" --enabled-only --yes`,
+		Args:         deleteArgs,
 		RunE:         runRoutesDelete,
 		SilenceUsage: true,
 	}
 
 	cmd.Flags().Bool("force", false, "Skip confirmation prompt (for automation)")
+	cmd.Flags().String("matching", "", "Delete all routes whose name matches this glob pattern")
+	cmd.Flags().Bool("enabled-only", false, "With --matching, only delete enabled routes")
+	cmd.Flags().Bool("case-sensitive", false, "With --matching, match names case-sensitively")
+	cmd.Flags().Bool("yes", false, "With --matching, skip the typed confirmation")
+	cmd.Flags().Int("concurrency", bulk.DefaultConcurrency, "With --matching, number of deletions to run in parallel")
 
 	return cmd
 }
 
 func runRoutesDelete(cmd *cobra.Command, args []string) error {
+	if cmd.Flags().Changed("matching") {
+		return runRoutesBulkDelete(cmd)
+	}
+
 	// Get response handler instance
 	handler := printer.GetResponseHandlerFromCommand(cmd)
 
@@ -0,0 +1,92 @@
+package routes
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/AhaSend/ahasend-cli/internal/glob"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-go/models/responses"
+)
+
+// expandRoutePatterns applies the recipient filter of each route to the
+// account domains. A filter without a domain, or with a wildcard domain such
+// as "support@*", becomes one pattern per matching verified domain. A filter
+// with an explicit domain keeps it and is flagged when the domain is
+// unverified or no longer in the account. The result is never nil.
+func expandRoutePatterns(routes []responses.Route, domains []responses.Domain) []printer.ExpandedPattern {
+	sorted := append([]responses.Domain{}, domains...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return strings.ToLower(sorted[i].Domain) < strings.ToLower(sorted[j].Domain)
+	})
+
+	expanded := []printer.ExpandedPattern{}
+	for _, route := range routes {
+		expanded = append(expanded, expandRoutePattern(route, sorted)...)
+	}
+	return expanded
+}
+
+func expandRoutePattern(route responses.Route, domains []responses.Domain) []printer.ExpandedPattern {
+	local, domain := splitRecipientFilter(route.Recipient)
+
+	if !strings.ContainsAny(domain, "*?") {
+		status := printer.PatternOrphaned
+		for _, existing := range domains {
+			if strings.EqualFold(existing.Domain, domain) {
+				status = printer.PatternUnverified
+				if existing.DNSValid {
+					status = printer.PatternActive
+				}
+				break
+			}
+		}
+		return []printer.ExpandedPattern{{
+			RouteID: route.ID,
+			Pattern: local + "@" + domain,
+			Domain:  domain,
+			Status:  status,
+		}}
+	}
+
+	var expanded []printer.ExpandedPattern
+	if domainPattern, err := glob.Compile(domain, false); err == nil {
+		for _, existing := range domains {
+			if existing.DNSValid && domainPattern.Match(existing.Domain) {
+				expanded = append(expanded, printer.ExpandedPattern{
+					RouteID: route.ID,
+					Pattern: local + "@" + strings.ToLower(existing.Domain),
+					Domain:  strings.ToLower(existing.Domain),
+					Status:  printer.PatternActive,
+				})
+			}
+		}
+	}
+	if len(expanded) == 0 {
+		expanded = append(expanded, printer.ExpandedPattern{
+			RouteID: route.ID,
+			Pattern: local + "@" + domain,
+			Status:  printer.PatternNoDomain,
+		})
+	}
+	return expanded
+}
+
+// splitRecipientFilter splits a route's recipient filter into its local part
+// and domain pattern. Missing parts match anything, so "support-*@" has the
+// domain "*" and an empty filter is "*@*". A filter without @, such as
+// "*sales*", is taken as a local part.
+func splitRecipientFilter(filter string) (local, domain string) {
+	filter = strings.TrimSpace(filter)
+	local = filter
+	if at := strings.LastIndex(filter, "@"); at >= 0 {
+		local, domain = filter[:at], filter[at+1:]
+	}
+	if local == "" {
+		local = "*"
+	}
+	if domain == "" {
+		domain = "*"
+	}
+	return local, strings.ToLower(domain)
+}
@@ -0,0 +1,186 @@
+package routes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func accountDomains() []responses.Domain {
+	return []responses.Domain{
+		{Domain: "example.com", DNSValid: true},
+		{Domain: "Acme.io", DNSValid: true},
+		{Domain: "staging.example.com", DNSValid: false},
+	}
+}
+
+func TestExpandRoutePatterns(t *testing.T) {
+	tests := []struct {
+		name      string
+		recipient string
+		expected  []printer.ExpandedPattern
+	}{
+		{
+			name:      "no domain expands to every verified domain",
+			recipient: "support-*@",
+			expected: []printer.ExpandedPattern{
+				{Pattern: "support-*@acme.io", Domain: "acme.io", Status: printer.PatternActive},
+				{Pattern: "support-*@example.com", Domain: "example.com", Status: printer.PatternActive},
+			},
+		},
+		{
+			name:      "wildcard domain",
+			recipient: "support@*.com",
+			expected: []printer.ExpandedPattern{
+				{Pattern: "support@example.com", Domain: "example.com", Status: printer.PatternActive},
+			},
+		},
+		{
+			name:      "empty filter matches everything",
+			recipient: "",
+			expected: []printer.ExpandedPattern{
+				{Pattern: "*@acme.io", Domain: "acme.io", Status: printer.PatternActive},
+				{Pattern: "*@example.com", Domain: "example.com", Status: printer.PatternActive},
+			},
+		},
+		{
+			name:      "explicit domain",
+			recipient: "billing@Example.com",
+			expected: []printer.ExpandedPattern{
+				{Pattern: "billing@example.com", Domain: "example.com", Status: printer.PatternActive},
+			},
+		},
+		{
+			name:      "unverified domain",
+			recipient: "*@staging.example.com",
+			expected: []printer.ExpandedPattern{
+				{Pattern: "*@staging.example.com", Domain: "staging.example.com", Status: printer.PatternUnverified},
+			},
+		},
+		{
+			name:      "deleted domain is orphaned",
+			recipient: "help@old.example.net",
+			expected: []printer.ExpandedPattern{
+				{Pattern: "help@old.example.net", Domain: "old.example.net", Status: printer.PatternOrphaned},
+			},
+		},
+		{
+			name:      "wildcard domain matching nothing",
+			recipient: "help@*.org",
+			expected: []printer.ExpandedPattern{
+				{Pattern: "help@*.org", Status: printer.PatternNoDomain},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route := responses.Route{ID: uuid.New(), Recipient: tt.recipient}
+			for i := range tt.expected {
+				tt.expected[i].RouteID = route.ID
+			}
+			assert.Equal(t, tt.expected, expandRoutePatterns([]responses.Route{route}, accountDomains()))
+		})
+	}
+
+	assert.NotNil(t, expandRoutePatterns(nil, accountDomains()))
+}
+
+func executeRoutesExpand(t *testing.T, format string, cmd *cobra.Command, setup func(*mocks.MockClient), args ...string) (string, *mocks.MockClient) {
+	t.Helper()
+
+	mockClient := &mocks.MockClient{}
+	setup(mockClient)
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var buf bytes.Buffer
+	handler := printer.GetResponseHandler(format, false, &buf)
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs(args)
+	require.NoError(t, cmd.Execute())
+	return buf.String(), mockClient
+}
+
+func TestRoutesList_Expand(t *testing.T) {
+	setup := func(mockClient *mocks.MockClient) {
+		support := mockClient.NewMockRoute(uuid.New().String(), "support", "https://example.com/support", "support-*@", true)
+		legacy := mockClient.NewMockRoute(uuid.New().String(), "legacy", "https://example.com/legacy", "help@old.example.net", true)
+		mockClient.On("ListRoutes", &[]int32{50}[0], (*string)(nil)).Return(&responses.PaginatedRoutesResponse{
+			Data: []responses.Route{*support, *legacy},
+		}, nil)
+		mockClient.On("ListDomains", (*int32)(nil), (*string)(nil)).Return(mockClient.NewMockDomainsResponse(accountDomains(), false), nil)
+	}
+
+	t.Run("table", func(t *testing.T) {
+		out, _ := executeRoutesExpand(t, "table", NewListCommand(), setup, "--expand")
+		assert.Contains(t, out, "Expanded Patterns:")
+		assert.Contains(t, out, "support-*@acme.io")
+		assert.Contains(t, out, "support-*@example.com")
+		assert.Contains(t, out, "orphaned")
+		assert.Contains(t, out, "1 pattern points at a domain that is not in this account")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		out, _ := executeRoutesExpand(t, "json", NewListCommand(), setup, "--expand")
+		var result struct {
+			Data             []responses.Route `json:"data"`
+			ExpandedPatterns []struct {
+				Pattern string `json:"pattern"`
+				Status  string `json:"status"`
+			} `json:"expanded_patterns"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(out), &result))
+		assert.Len(t, result.Data, 2)
+		require.Len(t, result.ExpandedPatterns, 3)
+		assert.Equal(t, "help@old.example.net", result.ExpandedPatterns[2].Pattern)
+		assert.Equal(t, printer.PatternOrphaned, result.ExpandedPatterns[2].Status)
+	})
+
+	t.Run("without --expand domains are not fetched", func(t *testing.T) {
+		out, mockClient := executeRoutesExpand(t, "json", NewListCommand(), setup)
+		assert.NotContains(t, out, "expanded_patterns")
+		mockClient.AssertNotCalled(t, "ListDomains", (*int32)(nil), (*string)(nil))
+	})
+}
+
+func TestRoutesGet_Expand(t *testing.T) {
+	routeID := uuid.New().String()
+	setup := func(mockClient *mocks.MockClient) {
+		route := mockClient.NewMockRoute(routeID, "support", "https://example.com/support", "support@*", true)
+		mockClient.On("GetRoute", routeID).Return(route, nil)
+		mockClient.On("ListDomains", (*int32)(nil), (*string)(nil)).Return(mockClient.NewMockDomainsResponse(accountDomains(), false), nil)
+	}
+
+	out, _ := executeRoutesExpand(t, "json", NewGetCommand(), setup, routeID, "--expand")
+	var result struct {
+		ID               string `json:"id"`
+		Recipient        string `json:"recipient"`
+		ExpandedPatterns []struct {
+			Pattern string `json:"pattern"`
+		} `json:"expanded_patterns"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &result))
+	assert.Equal(t, routeID, result.ID)
+	assert.Equal(t, "support@*", result.Recipient)
+	require.Len(t, result.ExpandedPatterns, 2)
+	assert.Equal(t, "support@acme.io", result.ExpandedPatterns[0].Pattern)
+
+	out, _ = executeRoutesExpand(t, "plain", NewGetCommand(), setup, routeID, "--expand")
+	assert.Contains(t, out, "Expanded Patterns:\n  support@acme.io\n  support@example.com\n")
+}
@@ -0,0 +1,137 @@
+package routes
+
+import (
+	"fmt"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/spec"
+	"github.com/AhaSend/ahasend-cli/internal/webhooks"
+	"github.com/spf13/cobra"
+)
+
+// NewExplainCommand creates the explain command
+func NewExplainCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "explain [route-id]",
+		Short: "Explain what a route does to inbound email",
+		Long: `Explain what a route's processing options do to the inbound email it
+receives: which fields its message.routing payloads carry, whether emails of
+a conversation are grouped by message ID, and whether reply text is
+extracted. An example payload with the shape of a real delivery is shown.
+
+Explain an existing route by its ID, or a proposed configuration before
+creating it with --config-file. The file holds one route with the keys of
+'ahasend routes export' (name, url, recipient, attachments, headers,
+group_by_message_id, strip_replies, enabled), either on its own or as the
+only entry of an exported routes file. Unknown keys are rejected.
+
+With --output json the example payload is included as an object, for docs
+tooling to embed.`,
+		Example: `  # Explain an existing route
+  ahasend routes explain abcd1234-5678-90ef-abcd-1234567890ab
+
+  # Explain a proposed configuration
+  ahasend routes explain --config-file route.yaml
+
+  # Extract the example payload for documentation
+  ahasend routes explain --config-file route.yaml --output json | jq .example_payload`,
+		Args:         cobra.MaximumNArgs(1),
+		RunE:         runRoutesExplain,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().String("config-file", "", "YAML file with a proposed route configuration")
+
+	return cmd
+}
+
+func runRoutesExplain(cmd *cobra.Command, args []string) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+	configFile, _ := cmd.Flags().GetString("config-file")
+
+	if (len(args) == 0) == (configFile == "") {
+		return errors.NewValidationError("give either a route ID or --config-file", nil)
+	}
+
+	logger.Get().WithFields(map[string]interface{}{
+		"args":        args,
+		"config_file": configFile,
+	}).Debug("Executing routes explain command")
+
+	var route spec.Route
+	var routeID, source string
+	if configFile != "" {
+		loaded, err := spec.LoadRoute(configFile)
+		if err != nil {
+			return err
+		}
+		route, source = loaded, configFile
+	} else {
+		client, err := auth.GetAuthenticatedClient(cmd)
+		if err != nil {
+			return err
+		}
+		routeID = args[0]
+		current, err := client.GetRoute(routeID)
+		if err != nil {
+			return err
+		}
+		if current == nil {
+			return errors.NewNotFoundError(fmt.Sprintf("route %s not found", routeID), nil)
+		}
+		route, source = spec.FromRoute(current), routeID
+	}
+
+	explanation, err := explainRoute(route, routeID, source)
+	if err != nil {
+		return err
+	}
+	return handler.HandleRouteExplanation(explanation, printer.SingleConfig{
+		EmptyMessage: "No route to explain",
+	})
+}
+
+// explainRoute describes the effect of each processing option of route and
+// the payloads it posts, from the option data shared with the payload
+// examples
+func explainRoute(route spec.Route, routeID, source string) (*printer.RouteExplanation, error) {
+	settings := webhooks.RouteSettings{
+		Attachments:      route.Attachments,
+		Headers:          route.Headers,
+		GroupByMessageID: route.GroupByMessageID,
+		StripReplies:     route.StripReplies,
+	}
+
+	options := webhooks.RouteOptions()
+	explanation := &printer.RouteExplanation{
+		RouteID:   routeID,
+		Source:    source,
+		Name:      route.Name,
+		URL:       route.URL,
+		Recipient: route.Recipient,
+		Options:   make([]printer.RouteOptionExplanation, 0, len(options)),
+		Fields:    webhooks.RoutePayloadFields(settings),
+	}
+	for _, option := range options {
+		effect := option.Disabled
+		if option.On(settings) {
+			effect = option.Enabled
+		}
+		explanation.Options = append(explanation.Options, printer.RouteOptionExplanation{
+			Key:     option.Key,
+			Name:    option.Name,
+			Enabled: option.On(settings),
+			Effect:  effect,
+		})
+	}
+
+	payload, err := webhooks.ExampleRoutePayload(routeID, settings)
+	if err != nil {
+		return nil, err
+	}
+	explanation.ExamplePayload = payload
+	return explanation, nil
+}
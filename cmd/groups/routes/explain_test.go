@@ -0,0 +1,114 @@
+package routes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func executeExplain(t *testing.T, mockClient *mocks.MockClient, format string, args ...string) (string, error) {
+	t.Helper()
+
+	if mockClient != nil {
+		restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+			return mockClient, nil
+		})
+		t.Cleanup(restore)
+	}
+
+	var stdout bytes.Buffer
+	cmd := NewExplainCommand()
+	handler := printer.GetResponseHandler(format, false, &stdout)
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	return stdout.String(), err
+}
+
+func TestRoutesExplain_ConfigFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "route.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`name: Support
+url: https://example.com/inbound
+recipient: support@example.com
+strip_replies: true
+`), 0600))
+
+	out, err := executeExplain(t, nil, "plain", "--config-file", file)
+	require.NoError(t, err)
+	assert.Contains(t, out, "Support")
+	assert.Contains(t, out, "Strip replies: Yes")
+	assert.Contains(t, out, "Include attachments: No")
+	assert.Contains(t, out, "reply_from_plain_body")
+	assert.Contains(t, out, "Example message.routing payload:")
+
+	out, err = executeExplain(t, nil, "json", "--config-file", file)
+	require.NoError(t, err)
+	var explanation struct {
+		Object         string                 `json:"object"`
+		Source         string                 `json:"source"`
+		ExamplePayload map[string]interface{} `json:"example_payload"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &explanation))
+	assert.Equal(t, "route_explanation", explanation.Object)
+	assert.Equal(t, file, explanation.Source)
+	data := explanation.ExamplePayload["data"].(map[string]interface{})
+	assert.Contains(t, data, "reply_from_plain_body")
+	assert.NotContains(t, data, "attachments")
+}
+
+func TestRoutesExplain_RouteID(t *testing.T) {
+	routeID := uuid.New().String()
+	route := createTestRouteWithOptions(routeID, "Tickets", "https://example.com/tickets", true, map[string]bool{
+		"include_attachments": true,
+		"group_by_message_id": true,
+	})
+
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetRoute", routeID).Return(route, nil)
+
+	out, err := executeExplain(t, mockClient, "json", routeID)
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+
+	var explanation printer.RouteExplanation
+	require.NoError(t, json.Unmarshal([]byte(out), &explanation))
+	assert.Equal(t, routeID, explanation.RouteID)
+	enabled := make(map[string]bool)
+	for _, option := range explanation.Options {
+		enabled[option.Key] = option.Enabled
+	}
+	assert.Equal(t, map[string]bool{
+		"attachments":         true,
+		"headers":             false,
+		"group_by_message_id": true,
+		"strip_replies":       false,
+	}, enabled)
+	assert.Equal(t, routeID, explanation.ExamplePayload["route_id"])
+}
+
+func TestRoutesExplain_RequiresOneSource(t *testing.T) {
+	_, err := executeExplain(t, nil, "plain")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "either a route ID or --config-file")
+
+	_, err = executeExplain(t, nil, "plain", "route-1", "--config-file", "route.yaml")
+	require.Error(t, err)
+
+	_, err = executeExplain(t, nil, "plain", "--config-file", filepath.Join(t.TempDir(), "missing.yaml"))
+	require.Error(t, err)
+}
@@ -0,0 +1,81 @@
+package routes
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/fetch"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/spec"
+	"github.com/spf13/cobra"
+)
+
+// NewExportCommand creates the export command
+func NewExportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export routes to a YAML file",
+		Long: `Export the settings of every inbound route in the account to a declarative
+YAML file that 'ahasend routes import' can recreate in another account.
+
+Each route is written with its name, URL, recipient pattern, enabled state
+and payload options. Signing secrets are never exported: the target account
+generates new ones, so receivers must be given the new secret after importing.
+The file records the account it was exported from, the export time and the
+CLI version.
+
+Without --output-file the YAML is written to stdout.`,
+		Example: `  # Export to a file
+  ahasend routes export --output-file routes.yaml
+
+  # Copy staging routes into production
+  ahasend routes export --profile staging --output-file routes.yaml
+  ahasend routes import --file routes.yaml --profile production`,
+		Args:         cobra.NoArgs,
+		RunE:         runRoutesExport,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().String("output-file", "", "File to write the YAML to (default: stdout)")
+
+	return cmd
+}
+
+func runRoutesExport(cmd *cobra.Command, args []string) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+	outputFile, _ := cmd.Flags().GetString("output-file")
+
+	apiClient, err := auth.GetAuthenticatedClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	logger.Get().WithFields(map[string]interface{}{
+		"output_file": outputFile,
+	}).Debug("Executing routes export command")
+
+	all, err := fetch.AllRoutes(apiClient)
+	if err != nil {
+		return err
+	}
+
+	file := spec.New(spec.KindRoutes, apiClient.GetAccountID(), fetch.AccountName(apiClient), time.Now())
+	for i := range all {
+		file.Routes = append(file.Routes, spec.FromRoute(&all[i]))
+	}
+
+	if outputFile == "" {
+		data, err := file.Marshal()
+		if err != nil {
+			return err
+		}
+		_, err = cmd.OutOrStdout().Write(data)
+		return err
+	}
+	if err := file.Write(outputFile); err != nil {
+		return err
+	}
+	return handler.HandleSimpleSuccess(fmt.Sprintf("Exported %d routes to %s", len(file.Routes), outputFile))
+}
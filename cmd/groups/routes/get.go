@@ -4,8 +4,10 @@ import (
 	"fmt"
 
 	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/fetch"
 	"github.com/AhaSend/ahasend-cli/internal/logger"
 	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-go/models/responses"
 	"github.com/spf13/cobra"
 )
 
@@ -25,6 +27,12 @@ This command shows comprehensive route details including:
 - Timestamps (created, last updated)
 - Complete route configuration
 
+Use --expand to see which addresses reach the route: a filter without a
+domain (e.g. "support-*@") is listed once per verified account domain, and a
+filter with a domain is checked against the account's domains. Filters whose
+domain is no longer in the account are flagged as orphaned, because mail to
+them is dropped.
+
 The route ID can be found using the 'ahasend routes list' command.`,
 		Example: `  # Get route details
   ahasend routes get abcd1234-5678-90ef-abcd-1234567890ab
@@ -32,6 +40,9 @@ The route ID can be found using the 'ahasend routes list' command.`,
   # Get route details in JSON format
   ahasend routes get abcd1234-5678-90ef-abcd-1234567890ab --output json
 
+  # Show the concrete addresses the route receives mail for
+  ahasend routes get abcd1234-5678-90ef-abcd-1234567890ab --expand
+
   # Get route configuration for backup/restore
   ahasend routes get abcd1234-5678-90ef-abcd-1234567890ab --output json > route-backup.json`,
 		Args:         cobra.ExactArgs(1),
@@ -39,6 +50,8 @@ The route ID can be found using the 'ahasend routes list' command.`,
 		SilenceUsage: true,
 	}
 
+	cmd.Flags().Bool("expand", false, "Show the recipient filter applied to each account domain and flag missing domains")
+
 	return cmd
 }
 
@@ -52,10 +65,12 @@ func runRoutesGet(cmd *cobra.Command, args []string) error {
 	}
 
 	routeID := args[0]
+	expand, _ := cmd.Flags().GetBool("expand")
 
 	// Log command execution
 	logger.Get().WithFields(map[string]interface{}{
 		"route_id": routeID,
+		"expand":   expand,
 	}).Debug("Executing routes get command")
 
 	// Get the route
@@ -64,10 +79,19 @@ func runRoutesGet(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Use the new ResponseHandler to display route details
-	return handler.HandleSingleRoute(route, printer.SingleConfig{
+	config := printer.SingleConfig{
 		SuccessMessage: fmt.Sprintf("Route details for %s", routeID),
 		EmptyMessage:   "Route not found",
 		FieldOrder:     []string{"ID", "Name", "URL", "Enabled", "Recipient Filter", "Include Attachments", "Include Headers", "Group by Message ID", "Strip Replies", "Created at", "Updated at"},
-	})
+	}
+	if expand && route != nil {
+		domains, err := fetch.AllDomains(client)
+		if err != nil {
+			return err
+		}
+		config.ExpandedPatterns = expandRoutePatterns([]responses.Route{*route}, domains)
+	}
+
+	// Use the new ResponseHandler to display route details
+	return handler.HandleSingleRoute(route, config)
 }
@@ -0,0 +1,139 @@
+package routes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/fetch"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/spec"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/spf13/cobra"
+)
+
+// NewImportCommand creates the import command
+func NewImportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Create routes from a YAML file",
+		Long: `Create the routes described by a file written with 'ahasend routes export'
+in the current account (or the one selected with --profile).
+
+Routes are matched to existing ones by name, ignoring case:
+  created    No route has the name; it is created
+  unchanged  A route with the name has the same settings
+  skipped    A route with the name has different settings; it is left alone
+  updated    With --update-existing, a differing route is changed to match
+  failed     The route could not be created or updated, or several
+             routes in the account have the name
+
+Importing a file exported from the same account therefore changes nothing.
+New routes get new signing secrets, shown by 'ahasend routes get'. The
+command exits with an error when any route failed.`,
+		Example: `  # Recreate staging routes in production
+  ahasend routes import --file routes.yaml --profile production
+
+  # Also bring existing routes in line with the file
+  ahasend routes import --file routes.yaml --update-existing`,
+		Args:         cobra.NoArgs,
+		RunE:         runRoutesImport,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().String("file", "", "YAML file written by 'ahasend routes export' (required)")
+	cmd.Flags().Bool("update-existing", false, "Update routes that already exist with different settings")
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func runRoutesImport(cmd *cobra.Command, args []string) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+	path, _ := cmd.Flags().GetString("file")
+	updateExisting, _ := cmd.Flags().GetBool("update-existing")
+
+	file, err := spec.Load(path, spec.KindRoutes)
+	if err != nil {
+		return err
+	}
+
+	apiClient, err := auth.GetAuthenticatedClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	logger.Get().WithFields(map[string]interface{}{
+		"file":            path,
+		"routes":          len(file.Routes),
+		"source_account":  file.Source.AccountID,
+		"update_existing": updateExisting,
+	}).Debug("Executing routes import command")
+
+	existing, err := fetch.AllRoutes(apiClient)
+	if err != nil {
+		return err
+	}
+
+	items := importRoutes(apiClient, file.Routes, existing, updateExisting)
+	result := printer.NewImportResult(path, file.Source.AccountID, items)
+	if err := handler.HandleImport(result, printer.CreateConfig{ItemName: "route"}); err != nil {
+		return err
+	}
+	if result.Failed > 0 {
+		return errors.NewAPIError(fmt.Sprintf("failed to import %d of %d routes", result.Failed, len(items)), nil)
+	}
+	return nil
+}
+
+// importRoutes creates or updates each wanted route, matching existing
+// routes by name, and returns the outcome of each
+func importRoutes(apiClient client.AhaSendClient, wanted []spec.Route, existing []responses.Route, updateExisting bool) []printer.ImportItem {
+	byName := make(map[string][]*responses.Route)
+	for i := range existing {
+		key := strings.ToLower(existing[i].Name)
+		byName[key] = append(byName[key], &existing[i])
+	}
+
+	items := make([]printer.ImportItem, 0, len(wanted))
+	for _, route := range wanted {
+		item := printer.ImportItem{Name: route.Name}
+		matches := byName[strings.ToLower(route.Name)]
+
+		switch {
+		case len(matches) > 1:
+			item.Status = printer.ImportFailed
+			item.Error = fmt.Sprintf("%d routes in the account are named %q; rename all but one to import", len(matches), route.Name)
+		case len(matches) == 1:
+			current := matches[0]
+			item.ID = current.ID.String()
+			switch {
+			case route.Equal(spec.FromRoute(current)):
+				item.Status = printer.ImportUnchanged
+			case !updateExisting:
+				item.Status = printer.ImportSkipped
+			default:
+				if _, err := apiClient.UpdateRoute(item.ID, route.UpdateRequest()); err != nil {
+					item.Status, item.Error = printer.ImportFailed, err.Error()
+				} else {
+					item.Status = printer.ImportUpdated
+				}
+			}
+		default:
+			created, err := apiClient.CreateRoute(route.CreateRequest())
+			if err != nil {
+				item.Status, item.Error = printer.ImportFailed, err.Error()
+			} else {
+				item.Status = printer.ImportCreated
+				if created != nil {
+					item.ID = created.ID.String()
+				}
+			}
+		}
+		items = append(items, item)
+	}
+	return items
+}
@@ -0,0 +1,88 @@
+package routes
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func executeTransfer(t *testing.T, cmd *cobra.Command, routeList []responses.Route, setup func(*mocks.MockClient), args ...string) (string, *mocks.MockClient, error) {
+	t.Helper()
+
+	mockClient := &mocks.MockClient{}
+	mockClient.On("ListRoutes", (*int32)(nil), (*string)(nil)).Return(&responses.PaginatedRoutesResponse{
+		Object: "list",
+		Data:   routeList,
+	}, nil)
+	mockClient.On("GetAccountID").Return("acc-staging").Maybe()
+	mockClient.On("GetAccount").Return(nil, assert.AnError).Maybe()
+	if setup != nil {
+		setup(mockClient)
+	}
+
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var stdout bytes.Buffer
+	handler := printer.GetResponseHandler("plain", false, &stdout)
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	return stdout.String(), mockClient, err
+}
+
+func TestRoutesExportImport(t *testing.T) {
+	support := createTestRouteWithOptions(uuid.New().String(), "Support", "https://example.com/support", true, map[string]bool{"include_attachments": true})
+	support.Recipient = "support@example.com"
+	billing := createTestRoute(uuid.New().String(), "Billing", "https://example.com/billing", false)
+	source := []responses.Route{*support, *billing}
+
+	// Without --output-file the YAML goes to stdout, without the account name
+	// when the key cannot read the account
+	yaml, _, err := executeTransfer(t, NewExportCommand(), source, nil)
+	require.NoError(t, err)
+	assert.Contains(t, yaml, "kind: routes")
+	assert.Contains(t, yaml, "account_id: acc-staging")
+	assert.NotContains(t, yaml, "account_name")
+
+	path := filepath.Join(t.TempDir(), "routes.yaml")
+	_, _, err = executeTransfer(t, NewExportCommand(), source, nil, "--output-file", path)
+	require.NoError(t, err)
+
+	t.Run("same account", func(t *testing.T) {
+		out, mockClient, err := executeTransfer(t, NewImportCommand(), source, nil, "--file", path)
+		require.NoError(t, err)
+		assert.Contains(t, out, "0 created, 0 updated, 2 unchanged, 0 skipped")
+		mockClient.AssertNotCalled(t, "CreateRoute", mock.Anything)
+	})
+
+	t.Run("other account", func(t *testing.T) {
+		out, mockClient, err := executeTransfer(t, NewImportCommand(), []responses.Route{*billing}, func(m *mocks.MockClient) {
+			m.On("CreateRoute", mock.MatchedBy(func(req requests.CreateRouteRequest) bool {
+				return req.Name == "Support" && req.Recipient == "support@example.com" && req.Attachments
+			})).Return(support, nil).Once()
+		}, "--file", path)
+		require.NoError(t, err)
+		assert.Contains(t, out, "Created Support ("+support.ID.String()+")")
+		assert.Contains(t, out, "1 created, 0 updated, 1 unchanged, 0 skipped")
+		mockClient.AssertExpectations(t)
+	})
+}
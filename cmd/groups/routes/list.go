@@ -2,6 +2,7 @@ package routes
 
 import (
 	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/fetch"
 	"github.com/AhaSend/ahasend-cli/internal/logger"
 	"github.com/AhaSend/ahasend-cli/internal/printer"
 	"github.com/AhaSend/ahasend-go/models/responses"
@@ -25,7 +26,13 @@ This command displays:
 - Processing options (attachments, headers, etc.)
 - Creation and last update times
 
-Use --limit to control pagination and --cursor for continued navigation.`,
+Use --limit to control pagination and --cursor for continued navigation.
+
+Use --expand to see which addresses reach each route: a filter without a
+domain (e.g. "support-*@") is listed once per verified account domain, and a
+filter with a domain is checked against the account's domains. Filters whose
+domain is no longer in the account are flagged as orphaned, because mail to
+them is dropped.`,
 		Example: `  # List all routes
   ahasend routes list
 
@@ -38,6 +45,9 @@ Use --limit to control pagination and --cursor for continued navigation.`,
   # Filter by enabled status
   ahasend routes list --enabled
 
+  # Show the concrete addresses each route receives mail for
+  ahasend routes list --expand
+
   # JSON output for automation
   ahasend routes list --output json`,
 		RunE:         runRoutesList,
@@ -48,6 +58,8 @@ Use --limit to control pagination and --cursor for continued navigation.`,
 	cmd.Flags().Int32("limit", 50, "Maximum number of routes to return")
 	cmd.Flags().String("cursor", "", "Pagination cursor for continued results")
 	cmd.Flags().Bool("enabled", false, "Show only enabled routes")
+	cmd.Flags().Bool("expand", false, "Show each recipient filter applied to each account domain and flag missing domains")
+	printer.AddIDsOnlyFlag(cmd)
 
 	return cmd
 }
@@ -65,12 +77,14 @@ func runRoutesList(cmd *cobra.Command, args []string) error {
 	limit, _ := cmd.Flags().GetInt32("limit")
 	cursor, _ := cmd.Flags().GetString("cursor")
 	enabledFilter, _ := cmd.Flags().GetBool("enabled")
+	expand, _ := cmd.Flags().GetBool("expand")
 
 	// Log command execution
 	logger.Get().WithFields(map[string]interface{}{
 		"limit":          limit,
 		"cursor":         cursor,
 		"filter_enabled": enabledFilter,
+		"expand":         expand,
 	}).Debug("Executing routes list command")
 
 	// Fetch routes
@@ -107,11 +121,20 @@ func runRoutesList(cmd *cobra.Command, args []string) error {
 		emptyMessage = "No enabled routes found"
 	}
 
-	// Use the new ResponseHandler to display route list
-	return handler.HandleRouteList(routes, printer.ListConfig{
+	config := printer.ListConfig{
 		SuccessMessage: "Routes retrieved successfully",
 		EmptyMessage:   emptyMessage,
 		ShowPagination: true,
 		FieldOrder:     []string{"id", "name", "url", "enabled", "recipient", "attachments", "headers", "group_by_message_id", "strip_replies", "created_at", "updated_at"},
-	})
+	}
+	if expand && routes != nil && len(routes.Data) > 0 {
+		domains, err := fetch.AllDomains(client)
+		if err != nil {
+			return err
+		}
+		config.ExpandedPatterns = expandRoutePatterns(routes.Data, domains)
+	}
+
+	// Use the new ResponseHandler to display route list
+	return handler.HandleRouteList(routes, config)
 }
@@ -10,11 +10,16 @@ import (
 	"os"
 	"os/signal"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/bytesize"
 	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/clockskew"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
 	"github.com/AhaSend/ahasend-cli/internal/logger"
 	"github.com/AhaSend/ahasend-cli/internal/webhooks"
 	"github.com/fatih/color"
@@ -35,7 +40,44 @@ This command establishes a WebSocket connection to receive inbound email events
 - Use existing routes or create temporary routes with recipient patterns
 
 The command generates a webhook secret for signing forwarded events using
-the standard-webhooks specification.`,
+the standard-webhooks specification.
+
+SCRIPTED CAPTURE:
+  By default the command runs until interrupted. For CI and scripts,
+  --exit-after stops it after a fixed duration and --max-events stops it once
+  that many events have been received, whichever comes first. With either
+  set, the command exits non-zero when fewer than --min-events (default: 1)
+  events were received. In-flight forwards are finished before exiting, and
+  a summary of events received, replayed and forwarded is printed on exit.
+
+RECONNECTS:
+  When the connection drops, the listener reconnects with backoff and asks
+  for the events that followed the last one received, so none are missed or
+  shown twice. When the stream credentials expire (an authentication close
+  code, or a 401 on reconnect), fresh credentials are fetched through the
+  active profile before reconnecting. Reconnects and credential refreshes
+  are logged as warnings and counted in the summary.
+
+FORWARDING:
+  Each forwarded event is followed by the endpoint's status, the payload
+  size and the round-trip time. Failed forwards are categorized as timeout,
+  connection, tls, 4xx or 5xx with a hint at the fix, and payloads larger
+  than --warn-payload-kb are flagged, as inbound emails with attachments
+  easily exceed the request body limit of a receiver.
+
+  Forwarded events are signed with the local time. --timestamp-offset dates
+  the signatures earlier or later, to check how the receiver handles skewed
+  timestamps, and a warning is printed when the local clock is more than a
+  minute off from the AhaSend API server's.
+
+RECORDING:
+  --record appends each received event to a file as a JSON line, holding
+  the payload shown in full output; replayed events are tagged with
+  "replay": true. It works with --forward-to and --slim-output. With
+  --record-max-size, the file is rotated once it would grow past that many
+  MB: it is renamed FILE.1, older recordings move to FILE.2 and so on, and
+  only the newest --record-keep rotated files are kept. The file is flushed
+  to disk when the listener stops, including on Ctrl+C.`,
 		Example: `  # Listen with existing route
   ahasend routes listen --route-id abcd1234-5678-90ef-abcd-1234567890ab
 
@@ -47,7 +89,14 @@ the standard-webhooks specification.`,
     --forward-to http://localhost:3000/webhook
 
   # Slim output (minimal event display)
-  ahasend routes listen --route-id abc123 --slim-output`,
+  ahasend routes listen --route-id abc123 --slim-output
+
+  # Capture up to 5 events in CI, giving up after 2 minutes
+  ahasend routes listen --route-id abc123 --exit-after 2m --max-events 5
+
+  # Keep a history of forwarded events, rotating the file at 10 MB
+  ahasend routes listen --route-id abc123 --forward-to http://localhost:3000/inbound \
+    --record events.jsonl --record-max-size 10`,
 		Args:         cobra.NoArgs,
 		RunE:         runRoutesListen,
 		SilenceUsage: true,
@@ -58,6 +107,14 @@ the standard-webhooks specification.`,
 	cmd.Flags().String("forward-to", "", "Local endpoint to forward events to")
 	cmd.Flags().Bool("skip-verify", false, "Skip SSL certificate verification for local endpoints when forwarding events")
 	cmd.Flags().Bool("slim-output", false, "Slim down the payload for printing to the console")
+	cmd.Flags().Duration("exit-after", 0, "Stop listening after this duration (e.g. 2m)")
+	cmd.Flags().Int("max-events", 0, "Stop listening once this many events have been received")
+	cmd.Flags().Int("min-events", 1, "With --exit-after or --max-events, fail unless at least this many events were received")
+	cmd.Flags().Int("warn-payload-kb", 100, "Warn when a forwarded payload is larger than this many KB (0 disables the warning)")
+	cmd.Flags().Duration("timestamp-offset", 0, "Date forwarded signatures this far from the local clock (e.g. -10m) to test the receiver's tolerance")
+	cmd.Flags().String("record", "", "Append received events to this file as JSON lines")
+	cmd.Flags().Int("record-max-size", 0, "Rotate the --record file once it would exceed this many MB (0 never rotates)")
+	cmd.Flags().Int("record-keep", 3, "Number of rotated --record files to keep")
 
 	return cmd
 }
@@ -74,11 +131,29 @@ func runRoutesListen(cmd *cobra.Command, args []string) error {
 	forwardTo, _ := cmd.Flags().GetString("forward-to")
 	skipVerify, _ := cmd.Flags().GetBool("skip-verify")
 	slimOutput, _ := cmd.Flags().GetBool("slim-output")
+	limits := listenLimits{}
+	limits.exitAfter, _ = cmd.Flags().GetDuration("exit-after")
+	limits.maxEvents, _ = cmd.Flags().GetInt("max-events")
+	limits.minEvents, _ = cmd.Flags().GetInt("min-events")
+	warnPayloadKB, _ := cmd.Flags().GetInt("warn-payload-kb")
+	timestampOffset, _ := cmd.Flags().GetDuration("timestamp-offset")
+	recordPath, _ := cmd.Flags().GetString("record")
+	recordMaxSize, _ := cmd.Flags().GetInt("record-max-size")
+	recordKeep, _ := cmd.Flags().GetInt("record-keep")
 
 	// Validate parameters - exactly one must be provided
 	if err := validateListenParameters(routeID, recipient); err != nil {
 		return err
 	}
+	if err := limits.validate(cmd.Flags().Changed("min-events")); err != nil {
+		return err
+	}
+	if warnPayloadKB < 0 {
+		return errors.NewValidationError("--warn-payload-kb cannot be negative", nil)
+	}
+	if err := validateRecordFlags(cmd, recordPath, recordMaxSize, recordKeep); err != nil {
+		return err
+	}
 
 	// Log command execution
 	logger.Get().WithFields(map[string]interface{}{
@@ -86,8 +161,21 @@ func runRoutesListen(cmd *cobra.Command, args []string) error {
 		"recipient":   recipient,
 		"forward_to":  forwardTo,
 		"slim_output": slimOutput,
+		"exit_after":  limits.exitAfter.String(),
+		"max_events":  limits.maxEvents,
+		"record":      recordPath,
 	}).Debug("Executing routes listen command")
 
+	// Open the recording before connecting, so a bad path fails early
+	var recorder *eventRecorder
+	if recordPath != "" {
+		recorder, err = openEventRecorder(recordPath, int64(recordMaxSize)*bytesize.MB, recordKeep)
+		if err != nil {
+			return err
+		}
+		defer recorder.Close()
+	}
+
 	// Initiate route stream
 	streamResponse, err := apiClient.InitiateRouteStream(routeID, recipient)
 	if err != nil {
@@ -103,7 +191,8 @@ func runRoutesListen(cmd *cobra.Command, args []string) error {
 	// Create signer for forwarding
 	var signer *webhooks.Signer
 	if forwardTo != "" {
-		signer = webhooks.NewSigner(secret)
+		signer = webhooks.NewSigner(secret).WithTimestampOffset(timestampOffset)
+		clockskew.Warn(cmd)
 	}
 
 	// Connect to WebSocket - use the route ID from response as the connection ID
@@ -111,7 +200,23 @@ func runRoutesListen(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to connect to websocket: %w", err)
 	}
-	defer wsClient.Close()
+
+	// Reconnect when the connection drops, fetching fresh credentials and a
+	// fresh stream URL through the standard auth path when they expire
+	stream := client.NewResumableStream(wsClient,
+		resumeDialer(apiClient, streamResponse, skipVerify),
+		func() (client.StreamDialer, error) {
+			freshClient, err := auth.GetAuthenticatedClient(cmd)
+			if err != nil {
+				return nil, err
+			}
+			freshStream, err := freshClient.InitiateRouteStream(routeID, recipient)
+			if err != nil {
+				return nil, fmt.Errorf("failed to initiate route stream: %w", err)
+			}
+			return resumeDialer(freshClient, freshStream, skipVerify), nil
+		})
+	defer stream.Close()
 
 	// Print connection info
 	fmt.Println()
@@ -126,6 +231,9 @@ func runRoutesListen(cmd *cobra.Command, args []string) error {
 	if forwardTo != "" {
 		fmt.Printf("Forwarding to: %s\n", color.CyanString(forwardTo))
 	}
+	if recordPath != "" {
+		fmt.Printf("Recording to: %s\n", color.CyanString(recordPath))
+	}
 	fmt.Printf("Connected at: %s\n", color.GreenString(time.Now().Format("15:04:05")))
 	fmt.Println()
 	color.New(color.FgWhite).Println("Listening for inbound emails... (Press Ctrl+C to stop)")
@@ -150,25 +258,39 @@ func runRoutesListen(cmd *cobra.Command, args []string) error {
 
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
 
 	go func() {
-		<-sigChan
-		fmt.Println("\n\n🛑 Stopping route listener...")
-		cancel()
+		select {
+		case <-sigChan:
+			fmt.Println("\n\n🛑 Stopping route listener...")
+			cancel()
+		case <-ctx.Done():
+		}
 	}()
 
+	// Stop after --exit-after
+	var deadline <-chan time.Time
+	if limits.exitAfter > 0 {
+		timer := time.NewTimer(limits.exitAfter)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
 	// Create channels for WebSocket messages and errors
 	msgChan := make(chan *client.WebSocketMessage, 10)
 	errChan := make(chan error, 1)
+	readerDone := make(chan struct{})
 
 	// Start goroutine to read WebSocket messages without interfering with ping/pong
 	go func() {
+		defer close(readerDone)
 		defer close(msgChan)
 		defer close(errChan)
 
 		for {
 			// Read message without any artificial timeouts - let WebSocket handle its own
-			msg, err := wsClient.ReadMessage(context.Background())
+			msg, err := stream.ReadMessage(ctx)
 			if err != nil {
 				select {
 				case errChan <- err:
@@ -185,13 +307,38 @@ func runRoutesListen(cmd *cobra.Command, args []string) error {
 		}
 	}()
 
-	// Listen for messages and cancellation
+	stats := &listenStats{}
+	var forwards sync.WaitGroup
+
+	// On any exit, close the connection so the reader stops, let in-flight
+	// forwards finish and flush the recording before printing the summary
+	stop := func() {
+		cancel()
+		stream.Close()
+		<-readerDone
+		forwards.Wait()
+		stats.reconnects = stream.Reconnects()
+		stats.credentialRefreshes = stream.CredentialRefreshes()
+		if recorder != nil {
+			if err := recorder.Close(); err != nil {
+				color.New(color.FgYellow).Printf("⚠️  %s\n", err)
+			}
+			stats.recorded = recorder.recorded
+		}
+		printListenSummary(stats, forwardTo != "", recorder != nil)
+	}
+
+	// Listen for messages, cancellation and the --exit-after deadline
 	for {
 		select {
 		case <-ctx.Done():
-			// Close WebSocket connection gracefully
-			wsClient.Close()
-			return nil
+			stop()
+			return limits.check(stats)
+
+		case <-deadline:
+			fmt.Printf("\n⏱  Stopping after %s\n", limits.exitAfter)
+			stop()
+			return limits.check(stats)
 
 		case err := <-errChan:
 			if err != nil {
@@ -200,11 +347,11 @@ func runRoutesListen(cmd *cobra.Command, args []string) error {
 					strings.Contains(err.Error(), "repeated read on failed") ||
 					strings.Contains(err.Error(), "websocket connection is closed") {
 					fmt.Println("\n💔 WebSocket connection closed")
-					wsClient.Close()
-					return nil
+					stop()
+					return limits.check(stats)
 				}
 				logger.Get().WithError(err).Error("Failed to read websocket message")
-				wsClient.Close()
+				stop()
 				return fmt.Errorf("websocket error: %w", err)
 			}
 
@@ -221,12 +368,38 @@ func runRoutesListen(cmd *cobra.Command, args []string) error {
 
 			case "event", "replay":
 				if msg.Event != nil {
+					stats.record(msg)
+
 					// Display event
 					displayEvent(msg, slimOutput)
 
+					// Record event before forwarding, so a failing
+					// endpoint does not cost the history
+					if recorder != nil {
+						if err := recorder.Record(msg); err != nil {
+							stop()
+							return err
+						}
+					}
+
 					// Forward event if configured
 					if forwardTo != "" && signer != nil {
-						go forwardEvent(httpClient, forwardTo, msg.Event, signer)
+						event := msg.Event
+						forwards.Go(func() {
+							result := forwardEvent(httpClient, forwardTo, event, signer)
+							webhooks.PrintForwardResult(os.Stdout, event, result, warnPayloadKB*1024)
+							if result.Failure() == "" {
+								stats.forwarded.Add(1)
+							} else {
+								stats.forwardFailed.Add(1)
+							}
+						})
+					}
+
+					if limits.reached(stats) {
+						fmt.Printf("\n✅ Received %d events\n", stats.received)
+						stop()
+						return limits.check(stats)
 					}
 				}
 
@@ -237,6 +410,106 @@ func runRoutesListen(cmd *cobra.Command, args []string) error {
 	}
 }
 
+// listenLimits ends a scripted capture after a fixed duration or once enough
+// events have been received. The zero value listens until interrupted.
+type listenLimits struct {
+	exitAfter time.Duration
+	maxEvents int
+	minEvents int
+}
+
+// scripted reports whether the listener stops on its own
+func (l listenLimits) scripted() bool {
+	return l.exitAfter > 0 || l.maxEvents > 0
+}
+
+func (l listenLimits) validate(minEventsSet bool) error {
+	if l.exitAfter < 0 {
+		return fmt.Errorf("--exit-after must not be negative")
+	}
+	if l.maxEvents < 0 {
+		return fmt.Errorf("--max-events must not be negative")
+	}
+	if l.minEvents < 0 {
+		return fmt.Errorf("--min-events must not be negative")
+	}
+	if minEventsSet && !l.scripted() {
+		return fmt.Errorf("--min-events requires --exit-after or --max-events")
+	}
+	if l.maxEvents > 0 && l.minEvents > l.maxEvents {
+		return fmt.Errorf("--min-events (%d) cannot be greater than --max-events (%d)", l.minEvents, l.maxEvents)
+	}
+	return nil
+}
+
+// reached reports whether --max-events events have been received
+func (l listenLimits) reached(stats *listenStats) bool {
+	return l.maxEvents > 0 && stats.received >= l.maxEvents
+}
+
+// check fails a scripted capture that received fewer than --min-events events
+func (l listenLimits) check(stats *listenStats) error {
+	if l.scripted() && stats.received < l.minEvents {
+		return fmt.Errorf("received %d events, expected at least %d", stats.received, l.minEvents)
+	}
+	return nil
+}
+
+// listenStats counts events for the summary printed when the listener stops.
+// Forward results are counted from the forwarding goroutines.
+type listenStats struct {
+	received      int
+	replayed      int
+	forwarded     atomic.Int64
+	forwardFailed atomic.Int64
+	recorded      int
+
+	reconnects          int
+	credentialRefreshes int
+}
+
+func (s *listenStats) record(msg *client.WebSocketMessage) {
+	s.received++
+	if msg.Type == "replay" {
+		s.replayed++
+	}
+}
+
+func printListenSummary(stats *listenStats, forwarding, recording bool) {
+	fmt.Println(strings.Repeat("─", 60))
+	summary := fmt.Sprintf("📊 Events received: %d (%d replayed)", stats.received, stats.replayed)
+	if forwarding {
+		summary += fmt.Sprintf(", forwarded: %d, forward failures: %d", stats.forwarded.Load(), stats.forwardFailed.Load())
+	}
+	if recording {
+		summary += fmt.Sprintf(", recorded: %d", stats.recorded)
+	}
+	if stats.reconnects > 0 || stats.credentialRefreshes > 0 {
+		summary += fmt.Sprintf(", reconnects: %d (%d credential refreshes)", stats.reconnects, stats.credentialRefreshes)
+	}
+	fmt.Println(summary)
+}
+
+// validateRecordFlags checks the --record flags; the rotation flags mean
+// nothing without a file to record to
+func validateRecordFlags(cmd *cobra.Command, path string, maxSize, keep int) error {
+	if path == "" {
+		for _, flag := range []string{"record-max-size", "record-keep"} {
+			if cmd.Flags().Changed(flag) {
+				return errors.NewValidationError(fmt.Sprintf("--%s requires --record", flag), nil)
+			}
+		}
+		return nil
+	}
+	if maxSize < 0 {
+		return errors.NewValidationError("--record-max-size cannot be negative", nil)
+	}
+	if keep < 0 {
+		return errors.NewValidationError("--record-keep cannot be negative", nil)
+	}
+	return nil
+}
+
 func validateListenParameters(routeID, recipient string) error {
 	// Check that exactly one parameter is provided
 	if routeID == "" && recipient == "" {
@@ -246,18 +519,9 @@ func validateListenParameters(routeID, recipient string) error {
 		return fmt.Errorf("only one of --route-id or --recipient can be provided, not both")
 	}
 
-	// Basic validation of recipient pattern if provided
 	if recipient != "" {
-		// Check if it contains @ sign
-		if !strings.Contains(recipient, "@") {
-			return fmt.Errorf("recipient pattern must be an email pattern (e.g., *@domain.com)")
-		}
-		// Basic wildcard pattern validation
-		if strings.Count(recipient, "*") > 2 {
-			return fmt.Errorf("recipient pattern contains too many wildcards")
-		}
+		return validateRecipientPattern(recipient)
 	}
-
 	return nil
 }
 
@@ -290,6 +554,14 @@ func connectWithRetry(apiClient client.AhaSendClient, wsURL, routeID string, ski
 	return wsClient, nil
 }
 
+// resumeDialer reconnects to a route stream, replaying the events that
+// followed lastStreamID
+func resumeDialer(apiClient client.AhaSendClient, streamResponse *client.RouteStreamResponse, skipVerify bool) client.StreamDialer {
+	return func(lastStreamID string) (*client.WebSocketClient, error) {
+		return apiClient.ResumeWebSocket(streamResponse.WsURL, streamResponse.RouteID, lastStreamID, skipVerify)
+	}
+}
+
 func displayEvent(msg *client.WebSocketMessage, slimOutput bool) {
 	timestamp := time.Unix(msg.Timestamp, 0).Format("15:04:05")
 
@@ -343,17 +615,20 @@ func displayEvent(msg *client.WebSocketMessage, slimOutput bool) {
 	fmt.Println(strings.Repeat("─", 60))
 }
 
-func forwardEvent(httpClient *http.Client, forwardTo string, event *client.Event, signer *webhooks.Signer) {
+// forwardEvent signs and posts the event to forwardTo and returns the
+// endpoint's response, the payload size and the round-trip time
+func forwardEvent(httpClient *http.Client, forwardTo string, event *client.Event, signer *webhooks.Signer) webhooks.ForwardResult {
 	// Prepare payload
 	payload, err := json.Marshal(event.Data)
 	if err != nil {
 		logger.Get().WithError(err).Error("Failed to marshal event data for forwarding")
-		return
+		return webhooks.ForwardResult{Err: err}
 	}
+	result := webhooks.ForwardResult{PayloadSize: len(payload)}
 
 	// Generate message ID and timestamp
 	msgID := webhooks.GenerateMsgID()
-	timestamp := time.Now()
+	timestamp := signer.SigningTime()
 
 	logger.Get().WithFields(map[string]interface{}{
 		"msg_id":       msgID,
@@ -366,14 +641,16 @@ func forwardEvent(httpClient *http.Client, forwardTo string, event *client.Event
 	signature, err := signer.Sign(msgID, timestamp, payload)
 	if err != nil {
 		logger.Get().WithError(err).Error("Failed to sign webhook payload")
-		return
+		result.Err = err
+		return result
 	}
 
 	// Create request
 	req, err := http.NewRequest("POST", forwardTo, bytes.NewReader(payload))
 	if err != nil {
 		logger.Get().WithError(err).Error("Failed to create forward request")
-		return
+		result.Err = err
+		return result
 	}
 
 	// Set headers
@@ -397,15 +674,19 @@ func forwardEvent(httpClient *http.Client, forwardTo string, event *client.Event
 	startTime := time.Now()
 	resp, err := httpClient.Do(req)
 	duration := time.Since(startTime)
+	result.Duration = duration
 
 	if err != nil {
 		logger.Get().WithError(err).WithFields(map[string]interface{}{
 			"url":      forwardTo,
 			"duration": duration.String(),
+			"failure":  webhooks.CategorizeFailure(0, err),
 		}).Error("Failed to forward route event")
-		return
+		result.Err = err
+		return result
 	}
 	defer resp.Body.Close()
+	result.StatusCode = resp.StatusCode
 
 	logger.Get().WithFields(map[string]interface{}{
 		"url":      forwardTo,
@@ -418,10 +699,13 @@ func forwardEvent(httpClient *http.Client, forwardTo string, event *client.Event
 			"url":    forwardTo,
 			"status": resp.StatusCode,
 		}).Debug("Successfully forwarded route event")
-	} else {
-		logger.Get().WithFields(map[string]interface{}{
-			"url":    forwardTo,
-			"status": resp.StatusCode,
-		}).Warn("Route event forward returned non-2xx status")
+		return result
 	}
+
+	logger.Get().WithFields(map[string]interface{}{
+		"url":     forwardTo,
+		"status":  resp.StatusCode,
+		"failure": result.Failure(),
+	}).Warn("Route event forward returned non-2xx status")
+	return result
 }
@@ -0,0 +1,130 @@
+package routes
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+)
+
+// eventRecorder appends received events to a file as JSON lines, rotating
+// it once it would grow past maxSize bytes. Rotated files are renamed
+// FILE.1 (the newest) to FILE.<keep>; older ones are removed.
+type eventRecorder struct {
+	path    string
+	maxSize int64
+	keep    int
+
+	file     *os.File
+	size     int64
+	recorded int
+}
+
+// openEventRecorder opens path for appending, creating it if needed. A
+// maxSize of 0 never rotates the file.
+func openEventRecorder(path string, maxSize int64, keep int) (*eventRecorder, error) {
+	r := &eventRecorder{path: path, maxSize: maxSize, keep: keep}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *eventRecorder) open() error {
+	file, err := os.OpenFile(r.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return errors.NewFileError(fmt.Sprintf("cannot open %s for recording", r.path), err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return errors.NewFileError(fmt.Sprintf("cannot read %s", r.path), err)
+	}
+	r.file = file
+	r.size = info.Size()
+	return nil
+}
+
+// Record writes the event payload displayEvent shows in full mode as one
+// line, tagged with "replay": true when the event was replayed
+func (r *eventRecorder) Record(msg *client.WebSocketMessage) error {
+	line, err := recordLine(msg)
+	if err != nil {
+		return errors.NewFileError("failed to encode the event for recording", err)
+	}
+	if r.maxSize > 0 && r.size > 0 && r.size+int64(len(line)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := r.file.Write(line)
+	r.size += int64(n)
+	if err != nil {
+		return errors.NewFileError(fmt.Sprintf("failed to write to %s", r.path), err)
+	}
+	r.recorded++
+	return nil
+}
+
+// Close flushes the recorded events to disk and closes the file
+func (r *eventRecorder) Close() error {
+	if r.file == nil {
+		return nil
+	}
+	syncErr := r.file.Sync()
+	closeErr := r.file.Close()
+	r.file = nil
+	if syncErr != nil {
+		return errors.NewFileError(fmt.Sprintf("failed to flush %s", r.path), syncErr)
+	}
+	if closeErr != nil {
+		return errors.NewFileError(fmt.Sprintf("failed to close %s", r.path), closeErr)
+	}
+	return nil
+}
+
+// rotate shifts FILE.1..FILE.<keep-1> up by one, moves the current file to
+// FILE.1 and starts a new one. With keep 0 the current file is discarded.
+func (r *eventRecorder) rotate() error {
+	if err := r.Close(); err != nil {
+		return err
+	}
+	if err := os.Remove(r.rotatedPath(r.keep)); err != nil && !os.IsNotExist(err) {
+		return errors.NewFileError(fmt.Sprintf("failed to remove %s", r.rotatedPath(r.keep)), err)
+	}
+	for i := r.keep; i >= 1; i-- {
+		if err := os.Rename(r.rotatedPath(i-1), r.rotatedPath(i)); err != nil && !os.IsNotExist(err) {
+			return errors.NewFileError(fmt.Sprintf("failed to rotate %s", r.rotatedPath(i-1)), err)
+		}
+	}
+	return r.open()
+}
+
+// rotatedPath is the name of the i-th rotated file; 0 is the file itself
+func (r *eventRecorder) rotatedPath(i int) string {
+	if i == 0 {
+		return r.path
+	}
+	return fmt.Sprintf("%s.%d", r.path, i)
+}
+
+// recordLine encodes the event data as a JSON line. The data is copied
+// before tagging replays, as forwarding reads it concurrently.
+func recordLine(msg *client.WebSocketMessage) ([]byte, error) {
+	var payload interface{} = msg.Event.Data
+	if dataMap, ok := msg.Event.Data.(map[string]interface{}); ok && msg.Type == "replay" {
+		tagged := make(map[string]interface{}, len(dataMap)+1)
+		for key, value := range dataMap {
+			tagged[key] = value
+		}
+		tagged["replay"] = true
+		payload = tagged
+	}
+	line, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return append(line, '\n'), nil
+}
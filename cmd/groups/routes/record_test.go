@@ -0,0 +1,137 @@
+package routes
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/client"
+)
+
+func routingMessage(msgType, subject string) *client.WebSocketMessage {
+	return &client.WebSocketMessage{
+		Type: msgType,
+		Event: &client.Event{
+			Type: "message.routing",
+			Data: map[string]interface{}{"type": "message.routing", "subject": subject},
+		},
+	}
+}
+
+func readLines(t *testing.T, path string) []map[string]interface{} {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var lines []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var decoded map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &decoded))
+		lines = append(lines, decoded)
+	}
+	return lines
+}
+
+func TestEventRecorder_AppendsLinesAndTagsReplays(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte(`{"subject":"earlier"}`+"\n"), 0600))
+
+	recorder, err := openEventRecorder(path, 0, 3)
+	require.NoError(t, err)
+	replay := routingMessage("replay", "missed")
+	require.NoError(t, recorder.Record(routingMessage("event", "hello")))
+	require.NoError(t, recorder.Record(replay))
+	require.NoError(t, recorder.Close())
+
+	lines := readLines(t, path)
+	require.Len(t, lines, 3)
+	assert.Equal(t, "earlier", lines[0]["subject"])
+	assert.Equal(t, "hello", lines[1]["subject"])
+	assert.NotContains(t, lines[1], "replay")
+	assert.Equal(t, true, lines[2]["replay"])
+	assert.NotContains(t, replay.Event.Data, "replay", "the forwarded payload must not be tagged")
+	assert.Equal(t, 2, recorder.recorded)
+}
+
+func TestEventRecorder_RotatesKeepingNewestFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	line, err := recordLine(routingMessage("event", "0"))
+	require.NoError(t, err)
+
+	// Room for two lines per file
+	recorder, err := openEventRecorder(path, int64(2*len(line)), 2)
+	require.NoError(t, err)
+	for _, subject := range []string{"0", "1", "2", "3", "4", "5", "6"} {
+		require.NoError(t, recorder.Record(routingMessage("event", subject)))
+	}
+	require.NoError(t, recorder.Close())
+
+	subjects := func(path string) []string {
+		var got []string
+		for _, line := range readLines(t, path) {
+			got = append(got, line["subject"].(string))
+		}
+		return got
+	}
+	assert.Equal(t, []string{"6"}, subjects(path))
+	assert.Equal(t, []string{"4", "5"}, subjects(path+".1"))
+	assert.Equal(t, []string{"2", "3"}, subjects(path+".2"))
+	assert.NoFileExists(t, path+".3")
+}
+
+func TestEventRecorder_RotateWithoutKeep(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	recorder, err := openEventRecorder(path, 1, 0)
+	require.NoError(t, err)
+	require.NoError(t, recorder.Record(routingMessage("event", "old")))
+	require.NoError(t, recorder.Record(routingMessage("event", "new")))
+	require.NoError(t, recorder.Close())
+
+	lines := readLines(t, path)
+	require.Len(t, lines, 1)
+	assert.Equal(t, "new", lines[0]["subject"])
+	assert.NoFileExists(t, path+".1")
+}
+
+func TestEventRecorder_OpenFailure(t *testing.T) {
+	_, err := openEventRecorder(filepath.Join(t.TempDir(), "missing", "events.jsonl"), 0, 3)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot open")
+}
+
+func TestValidateRecordFlags(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		wantError string
+	}{
+		{name: "record with rotation", args: []string{"--record", "events.jsonl", "--record-max-size", "10", "--record-keep", "5"}},
+		{name: "no recording", args: []string{}},
+		{name: "max size without record", args: []string{"--record-max-size", "10"}, wantError: "--record-max-size requires --record"},
+		{name: "keep without record", args: []string{"--record-keep", "1"}, wantError: "--record-keep requires --record"},
+		{name: "negative max size", args: []string{"--record", "events.jsonl", "--record-max-size", "-1"}, wantError: "--record-max-size cannot be negative"},
+		{name: "negative keep", args: []string{"--record", "events.jsonl", "--record-keep", "-1"}, wantError: "--record-keep cannot be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewListenCommand()
+			require.NoError(t, cmd.ParseFlags(tt.args))
+			path, _ := cmd.Flags().GetString("record")
+			maxSize, _ := cmd.Flags().GetInt("record-max-size")
+			keep, _ := cmd.Flags().GetInt("record-keep")
+
+			err := validateRecordFlags(cmd, path, maxSize, keep)
+			if tt.wantError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantError)
+		})
+	}
+}
@@ -35,6 +35,9 @@ Common workflow:
 	cmd.AddCommand(NewDeleteCommand())
 	cmd.AddCommand(NewListenCommand())
 	cmd.AddCommand(NewTriggerCommand())
+	cmd.AddCommand(NewExportCommand())
+	cmd.AddCommand(NewImportCommand())
+	cmd.AddCommand(NewExplainCommand())
 
 	return cmd
 }
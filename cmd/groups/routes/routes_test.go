@@ -67,8 +67,8 @@ func TestRoutesCommand_SubcommandCount(t *testing.T) {
 	cmd := NewCommand()
 	subcommands := cmd.Commands()
 
-	// Should have exactly 7 subcommands (including listen and trigger)
-	assert.Equal(t, 7, len(subcommands), "routes command should have exactly 7 subcommands")
+	// Should have exactly 10 subcommands (including listen, trigger, export, import and explain)
+	assert.Equal(t, 10, len(subcommands), "routes command should have exactly 10 subcommands")
 }
 
 // Test list command structure and flags
@@ -784,6 +784,7 @@ func TestListenCommand_Flags(t *testing.T) {
 
 	expectedFlags := []string{
 		"route-id", "recipient", "forward-to", "skip-verify", "slim-output",
+		"exit-after", "max-events", "min-events", "record", "record-max-size", "record-keep",
 	}
 
 	for _, flagName := range expectedFlags {
@@ -797,6 +798,9 @@ func TestListenCommand_Flags(t *testing.T) {
 	assert.Equal(t, "string", flags.Lookup("forward-to").Value.Type())
 	assert.Equal(t, "bool", flags.Lookup("skip-verify").Value.Type())
 	assert.Equal(t, "bool", flags.Lookup("slim-output").Value.Type())
+	assert.Equal(t, "duration", flags.Lookup("exit-after").Value.Type())
+	assert.Equal(t, "int", flags.Lookup("max-events").Value.Type())
+	assert.Equal(t, "1", flags.Lookup("min-events").DefValue)
 }
 
 func TestValidateListenParameters_Success(t *testing.T) {
@@ -877,6 +881,127 @@ func TestValidateListenParameters_ValidationErrors(t *testing.T) {
 	}
 }
 
+func TestValidateRecipientPattern_Accepts(t *testing.T) {
+	for _, pattern := range []string{
+		"support@example.com",
+		"*@example.com",
+		"support-*@example.com",
+		"*@*.example.com",
+		"support@*",
+		"*@*",
+		"sales-*@*.example.co.uk",
+		"überall@bücher.example",
+	} {
+		t.Run(pattern, func(t *testing.T) {
+			assert.NoError(t, validateRecipientPattern(pattern))
+		})
+	}
+}
+
+func TestValidateRecipientPattern_Rejects(t *testing.T) {
+	tests := []struct {
+		pattern   string
+		wantError string
+	}{
+		{"support", "recipient pattern must be an email pattern"},
+		{"*sales*", "recipient pattern must be an email pattern"},
+		{"*@*@example.com", "unexpected second '@' at position 4"},
+		{"a@b@c", "unexpected second '@' at position 4"},
+		{"*-*@example.com", "second '*' in the local part at position 3"},
+		{"*@*.*.example.com", "second '*' in the domain at position 5"},
+		{"support @example.com", "unexpected ' ' at position 8"},
+		{"ü*@*.*", "second '*' in the domain at position 6"},
+		{"a,b@example.com", "unexpected ',' at position 2"},
+		{"@example.com", "nothing before the '@' at position 1"},
+		{"support@", "nothing after the '@' at position 8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			err := validateRecipientPattern(tt.pattern)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantError)
+		})
+	}
+}
+
+func TestValidateRouteConfig_Recipient(t *testing.T) {
+	config := RouteCreateConfig{Name: "Support", URL: "https://api.example.com/webhook"}
+	assert.NoError(t, validateRouteConfig(config), "an empty recipient matches all")
+
+	config.Recipient = "support-*@example.com"
+	assert.NoError(t, validateRouteConfig(config))
+
+	config.Recipient = "*@*@example.com"
+	err := validateRouteConfig(config)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "position 4")
+}
+
+func TestParseUpdateFlags_Recipient(t *testing.T) {
+	cmd := NewUpdateCommand()
+	require.NoError(t, cmd.ParseFlags([]string{"--recipient", "support-*@example.com"}))
+	config, err := parseUpdateFlags(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, "support-*@example.com", *config.Recipient)
+
+	cmd = NewUpdateCommand()
+	require.NoError(t, cmd.ParseFlags([]string{"--recipient", "**@example.com"}))
+	_, err = parseUpdateFlags(cmd)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "second '*' in the local part at position 2")
+}
+
+func TestListenLimits_Validate(t *testing.T) {
+	tests := []struct {
+		name         string
+		limits       listenLimits
+		minEventsSet bool
+		wantError    string
+	}{
+		{name: "no limits", limits: listenLimits{minEvents: 1}},
+		{name: "exit after", limits: listenLimits{exitAfter: 2 * time.Minute, minEvents: 1}},
+		{name: "min events with max events", limits: listenLimits{maxEvents: 5, minEvents: 5}, minEventsSet: true},
+		{name: "negative exit after", limits: listenLimits{exitAfter: -time.Second, minEvents: 1}, wantError: "--exit-after must not be negative"},
+		{name: "negative max events", limits: listenLimits{maxEvents: -1, minEvents: 1}, wantError: "--max-events must not be negative"},
+		{name: "min events without a limit", limits: listenLimits{minEvents: 2}, minEventsSet: true, wantError: "--min-events requires --exit-after or --max-events"},
+		{name: "min events above max events", limits: listenLimits{maxEvents: 2, minEvents: 3}, minEventsSet: true, wantError: "--min-events (3) cannot be greater than --max-events (2)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.limits.validate(tt.minEventsSet)
+			if tt.wantError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantError)
+		})
+	}
+}
+
+func TestListenLimits_ReachedAndCheck(t *testing.T) {
+	stats := &listenStats{}
+	stats.record(&client.WebSocketMessage{Type: "event"})
+	stats.record(&client.WebSocketMessage{Type: "replay"})
+	assert.Equal(t, 2, stats.received)
+	assert.Equal(t, 1, stats.replayed)
+
+	// Listening until interrupted never fails on the event count
+	assert.NoError(t, listenLimits{minEvents: 5}.check(stats))
+	assert.False(t, listenLimits{}.reached(stats))
+
+	limits := listenLimits{maxEvents: 2, minEvents: 1}
+	assert.True(t, limits.reached(stats))
+	assert.NoError(t, limits.check(stats))
+
+	limits = listenLimits{exitAfter: time.Minute, minEvents: 3}
+	err := limits.check(stats)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "received 2 events, expected at least 3")
+}
+
 func TestDisplayEvent_SlimOutput(t *testing.T) {
 	// Create test event data
 	eventData := map[string]interface{}{
@@ -1077,10 +1202,11 @@ func TestForwardEvent_Success(t *testing.T) {
 	httpClient := &http.Client{Timeout: 10 * time.Second}
 
 	// Forward the event
-	forwardEvent(httpClient, server.URL, event, signer)
-
-	// Give time for async operation
-	time.Sleep(100 * time.Millisecond)
+	result := forwardEvent(httpClient, server.URL, event, signer)
+	assert.Equal(t, webhooks.FailureCategory(""), result.Failure())
+	assert.Equal(t, http.StatusOK, result.StatusCode)
+	assert.Equal(t, len(receivedBody), result.PayloadSize)
+	assert.Positive(t, result.Duration)
 
 	// Verify headers were set correctly
 	assert.Equal(t, "application/json", receivedHeaders["Content-Type"])
@@ -1126,13 +1252,26 @@ func TestForwardEvent_ServerError(t *testing.T) {
 	signer := webhooks.NewSigner("test-secret")
 	httpClient := &http.Client{Timeout: 10 * time.Second}
 
-	// Forward the event - should not panic even with server error
-	forwardEvent(httpClient, server.URL, event, signer)
+	// Forward the event - the error is logged and categorized, not returned
+	result := forwardEvent(httpClient, server.URL, event, signer)
+	assert.Equal(t, webhooks.FailureServerError, result.Failure())
+	assert.Equal(t, http.StatusInternalServerError, result.StatusCode)
+	assert.Positive(t, result.PayloadSize)
+}
 
-	// Give time for async operation
-	time.Sleep(100 * time.Millisecond)
+func TestForwardEvent_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	event := &client.Event{Type: "message.routing", Data: map[string]interface{}{"type": "message.routing"}}
+	httpClient := &http.Client{Timeout: 20 * time.Millisecond}
 
-	// Test completes successfully even with server error (error is logged, not returned)
+	result := forwardEvent(httpClient, server.URL, event, webhooks.NewSigner("test-secret"))
+	assert.Equal(t, webhooks.FailureTimeout, result.Failure())
+	assert.Zero(t, result.StatusCode)
+	assert.GreaterOrEqual(t, result.Duration, 20*time.Millisecond)
 }
 
 func TestForwardEvent_InvalidURL(t *testing.T) {
@@ -1156,10 +1295,7 @@ func TestForwardEvent_InvalidURL(t *testing.T) {
 	httpClient := &http.Client{Timeout: 10 * time.Second}
 
 	// Forward to invalid URL - should not panic
-	forwardEvent(httpClient, "invalid-url", event, signer)
-
-	// Give time for async operation
-	time.Sleep(100 * time.Millisecond)
-
-	// Test completes successfully even with invalid URL (error is logged, not returned)
+	result := forwardEvent(httpClient, "invalid-url", event, signer)
+	require.Error(t, result.Err)
+	assert.Equal(t, webhooks.FailureOther, result.Failure())
 }
@@ -201,6 +201,9 @@ func parseUpdateFlags(cmd *cobra.Command) (RouteUpdateConfig, error) {
 		if recipient == "" {
 			return config, fmt.Errorf("recipient filter cannot be empty. Use --clear-recipient to remove filtering")
 		}
+		if err := validateRecipientPattern(recipient); err != nil {
+			return config, err
+		}
 		config.Recipient = &recipient
 	}
 
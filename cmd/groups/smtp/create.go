@@ -10,6 +10,7 @@ import (
 	"github.com/AhaSend/ahasend-cli/internal/errors"
 	"github.com/AhaSend/ahasend-cli/internal/logger"
 	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/validation"
 	"github.com/AhaSend/ahasend-go/models/requests"
 	"github.com/spf13/cobra"
 )
@@ -91,6 +92,12 @@ func runSMTPCreate(cmd *cobra.Command, args []string) error {
 		return errors.NewValidationError("domains are required for scoped credentials", nil)
 	}
 
+	// Internationalized domain restrictions are sent in punycode form
+	domains, err = validation.NormalizeDomains(domains)
+	if err != nil {
+		return err
+	}
+
 	logger.Get().WithFields(map[string]interface{}{
 		"name":    name,
 		"scope":   scope,
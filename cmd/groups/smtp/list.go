@@ -1,13 +1,29 @@
 package smtp
 
 import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
 	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
 	"github.com/AhaSend/ahasend-cli/internal/errors"
 	"github.com/AhaSend/ahasend-cli/internal/logger"
 	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-go/models/responses"
 	"github.com/spf13/cobra"
 )
 
+// smtpListFilters are the client-side filters and sort order of smtp list
+type smtpListFilters struct {
+	Scope     string
+	Domain    string
+	Sandbox   *bool
+	SortBy    string
+	hasFilter bool
+}
+
 // NewListCommand creates the smtp list command
 func NewListCommand() *cobra.Command {
 	cmd := &cobra.Command{
@@ -17,7 +33,19 @@ func NewListCommand() *cobra.Command {
 
 SMTP credentials are displayed with their name, username, scope, and creation date.
 Passwords are never shown for security reasons. Use pagination flags to navigate
-through large lists of credentials.`,
+through large lists of credentials.
+
+Filtering and sorting happen client-side over all credentials, so every page is
+fetched when any of --scope, --domain, --sandbox, --no-sandbox or --sort is given
+(and --limit and --cursor cannot be used with them):
+  --scope global|scoped   Only credentials with this scope
+  --domain example.com    Credentials that may send from the domain: global
+                          credentials and scoped ones restricted to it
+  --sandbox/--no-sandbox  Only sandbox or only live credentials
+  --sort name|created     By name (A-Z) or creation time (newest first)
+
+A warning is written to stderr for credentials restricted to a domain that no
+longer exists in the account, as sends through them fail.`,
 		Example: `  # List all SMTP credentials
   ahasend smtp list
 
@@ -27,14 +55,28 @@ through large lists of credentials.`,
   # Continue from cursor
   ahasend smtp list --cursor "next-page-token"
 
+  # Credentials that can send from a domain, newest first
+  ahasend smtp list --domain example.com --sort created
+
+  # Scoped live credentials as CSV
+  ahasend smtp list --scope scoped --no-sandbox --output csv
+
   # Export to JSON
   ahasend smtp list --output json`,
-		RunE: runSMTPList,
+		RunE:         runSMTPList,
+		SilenceUsage: true,
 	}
 
 	// Add flags
 	cmd.Flags().Int32("limit", 50, "Maximum number of credentials to return (1-100)")
 	cmd.Flags().String("cursor", "", "Pagination cursor for continued results")
+	cmd.Flags().String("scope", "", "Only show credentials with this scope: global or scoped")
+	cmd.Flags().String("domain", "", "Only show credentials that can send from this domain")
+	cmd.Flags().Bool("sandbox", false, "Only show sandbox credentials")
+	cmd.Flags().Bool("no-sandbox", false, "Only show non-sandbox credentials")
+	cmd.Flags().String("sort", "", "Sort credentials: name or created")
+	printer.AddIDsOnlyFlag(cmd)
+	cmd.MarkFlagsMutuallyExclusive("sandbox", "no-sandbox")
 
 	return cmd
 }
@@ -43,34 +85,49 @@ func runSMTPList(cmd *cobra.Command, args []string) error {
 	// Get response handler instance
 	handler := printer.GetResponseHandlerFromCommand(cmd)
 
-	client, err := auth.GetAuthenticatedClient(cmd)
-	if err != nil {
-		return err
-	}
-
 	// Get flag values
 	limit, _ := cmd.Flags().GetInt32("limit")
 	cursor, _ := cmd.Flags().GetString("cursor")
 
+	filters, err := parseSMTPListFilters(cmd)
+	if err != nil {
+		return err
+	}
+
 	// Validate limit
 	if limit < 1 || limit > 100 {
 		return errors.NewValidationError("limit must be between 1 and 100", nil)
 	}
+	if filters.hasFilter && (cmd.Flags().Changed("limit") || cursor != "") {
+		return errors.NewValidationError("--limit and --cursor cannot be combined with filters or --sort, which cover all credentials", nil)
+	}
+
+	client, err := auth.GetAuthenticatedClient(cmd)
+	if err != nil {
+		return err
+	}
 
 	logger.Get().WithFields(map[string]interface{}{
-		"limit":  limit,
-		"cursor": cursor,
+		"limit":   limit,
+		"cursor":  cursor,
+		"scope":   filters.Scope,
+		"domain":  filters.Domain,
+		"sandbox": filters.Sandbox,
+		"sort":    filters.SortBy,
 	}).Debug("Listing SMTP credentials")
 
-	// Build request parameters
-	limitPtr := &limit
-	var cursorPtr *string
-	if cursor != "" {
-		cursorPtr = &cursor
+	var response *responses.PaginatedSMTPCredentialsResponse
+	if filters.hasFilter {
+		response, err = listAllSMTPCredentials(client)
+	} else {
+		// Build request parameters
+		limitPtr := &limit
+		var cursorPtr *string
+		if cursor != "" {
+			cursorPtr = &cursor
+		}
+		response, err = client.ListSMTPCredentials(limitPtr, cursorPtr)
 	}
-
-	// List SMTP credentials
-	response, err := client.ListSMTPCredentials(limitPtr, cursorPtr)
 	if err != nil {
 		return err
 	}
@@ -79,11 +136,195 @@ func runSMTPList(cmd *cobra.Command, args []string) error {
 		return errors.NewAPIError("received nil response from API", nil)
 	}
 
+	if filters.hasFilter {
+		response.Data = filterSMTPCredentials(response.Data, filters)
+		sortSMTPCredentials(response.Data, filters.SortBy)
+	}
+
+	warnMissingCredentialDomains(client, response.Data, cmd.ErrOrStderr())
+
 	// Use the new ResponseHandler to display SMTP credentials list
+	successMessage := "SMTP credentials retrieved successfully"
+	emptyMessage := "No SMTP credentials found"
+	if description := filters.describe(); description != "" {
+		successMessage = fmt.Sprintf("Found %d SMTP credentials (%s)", len(response.Data), description)
+		emptyMessage = fmt.Sprintf("No SMTP credentials found (%s)", description)
+	}
 	return handler.HandleSMTPList(response, printer.ListConfig{
-		SuccessMessage: "SMTP credentials retrieved successfully",
-		EmptyMessage:   "No SMTP credentials found",
-		ShowPagination: true,
+		SuccessMessage: successMessage,
+		EmptyMessage:   emptyMessage,
+		ShowPagination: !filters.hasFilter,
 		FieldOrder:     []string{"id", "name", "username", "scope", "domains", "sandbox", "created_at", "updated_at"},
 	})
 }
+
+// parseSMTPListFilters reads and validates the filter and sort flags
+func parseSMTPListFilters(cmd *cobra.Command) (*smtpListFilters, error) {
+	filters := &smtpListFilters{}
+	filters.Scope, _ = cmd.Flags().GetString("scope")
+	filters.Domain, _ = cmd.Flags().GetString("domain")
+	filters.SortBy, _ = cmd.Flags().GetString("sort")
+	filters.Domain = strings.ToLower(strings.TrimSpace(filters.Domain))
+
+	if filters.Scope != "" && filters.Scope != "global" && filters.Scope != "scoped" {
+		return nil, errors.NewValidationError(fmt.Sprintf("invalid scope '%s' (must be one of: global, scoped)", filters.Scope), nil)
+	}
+	switch filters.SortBy {
+	case "", "name", "created":
+	case "last_used":
+		return nil, errors.NewValidationError("sorting by last_used is not supported: the AhaSend API does not report when SMTP credentials were last used", nil)
+	default:
+		return nil, errors.NewValidationError(fmt.Sprintf("invalid sort '%s' (must be one of: name, created)", filters.SortBy), nil)
+	}
+
+	if sandbox, _ := cmd.Flags().GetBool("sandbox"); sandbox {
+		filters.Sandbox = &sandbox
+	}
+	if noSandbox, _ := cmd.Flags().GetBool("no-sandbox"); noSandbox {
+		live := false
+		filters.Sandbox = &live
+	}
+
+	filters.hasFilter = filters.Scope != "" || filters.Domain != "" || filters.Sandbox != nil || filters.SortBy != ""
+	return filters, nil
+}
+
+// describe lists the applied filters and sort order for the success message
+func (f *smtpListFilters) describe() string {
+	var parts []string
+	if f.Scope != "" {
+		parts = append(parts, "scope "+f.Scope)
+	}
+	if f.Domain != "" {
+		parts = append(parts, "domain "+f.Domain)
+	}
+	if f.Sandbox != nil {
+		if *f.Sandbox {
+			parts = append(parts, "sandbox only")
+		} else {
+			parts = append(parts, "non-sandbox only")
+		}
+	}
+	if f.SortBy != "" {
+		parts = append(parts, "sorted by "+f.SortBy)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// listAllSMTPCredentials follows pagination cursors and returns every credential
+func listAllSMTPCredentials(apiClient client.AhaSendClient) (*responses.PaginatedSMTPCredentialsResponse, error) {
+	all := &responses.PaginatedSMTPCredentialsResponse{Object: "list", Data: []responses.SMTPCredential{}}
+	limit := int32(100)
+	var cursor *string
+	for {
+		page, err := apiClient.ListSMTPCredentials(&limit, cursor)
+		if err != nil {
+			return nil, err
+		}
+		if page == nil {
+			break
+		}
+		all.Data = append(all.Data, page.Data...)
+		if !page.Pagination.HasMore || page.Pagination.NextCursor == nil || *page.Pagination.NextCursor == "" {
+			break
+		}
+		cursor = page.Pagination.NextCursor
+	}
+	return all, nil
+}
+
+// filterSMTPCredentials keeps the credentials matching every filter
+func filterSMTPCredentials(credentials []responses.SMTPCredential, filters *smtpListFilters) []responses.SMTPCredential {
+	filtered := []responses.SMTPCredential{}
+	for _, credential := range credentials {
+		if filters.Scope != "" && credential.Scope != filters.Scope {
+			continue
+		}
+		if filters.Sandbox != nil && credential.Sandbox != *filters.Sandbox {
+			continue
+		}
+		if filters.Domain != "" && !credentialAllowsDomain(credential, filters.Domain) {
+			continue
+		}
+		filtered = append(filtered, credential)
+	}
+	return filtered
+}
+
+// credentialAllowsDomain reports whether a credential can send from domain:
+// global credentials can send from any domain
+func credentialAllowsDomain(credential responses.SMTPCredential, domain string) bool {
+	if credential.Scope == "global" {
+		return true
+	}
+	for _, allowed := range credential.Domains {
+		if strings.EqualFold(allowed, domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortSMTPCredentials orders credentials by name (A-Z) or creation time
+// (newest first)
+func sortSMTPCredentials(credentials []responses.SMTPCredential, sortBy string) {
+	sort.SliceStable(credentials, func(i, j int) bool {
+		switch sortBy {
+		case "name":
+			return strings.ToLower(credentials[i].Name) < strings.ToLower(credentials[j].Name)
+		case "created":
+			return credentials[i].CreatedAt.After(credentials[j].CreatedAt)
+		}
+		return false
+	})
+}
+
+// warnMissingCredentialDomains warns about scoped credentials restricted to
+// domains that are no longer in the account, as sends through them fail
+func warnMissingCredentialDomains(apiClient client.AhaSendClient, credentials []responses.SMTPCredential, out io.Writer) {
+	hasRestrictions := false
+	for _, credential := range credentials {
+		hasRestrictions = hasRestrictions || len(credential.Domains) > 0
+	}
+	if !hasRestrictions {
+		return
+	}
+
+	domains, err := listAllDomainNames(apiClient)
+	if err != nil {
+		logger.Get().WithError(err).Debug("Failed to list domains for SMTP credential check")
+		return
+	}
+
+	for _, credential := range credentials {
+		for _, domain := range credential.Domains {
+			if !domains[strings.ToLower(domain)] {
+				fmt.Fprintf(out, "⚠️  SMTP credential '%s' is restricted to domain '%s', which no longer exists in this account; sends through it will fail\n", credential.Name, domain)
+			}
+		}
+	}
+}
+
+// listAllDomainNames returns the lowercased names of every domain in the account
+func listAllDomainNames(apiClient client.AhaSendClient) (map[string]bool, error) {
+	names := make(map[string]bool)
+	limit := int32(100)
+	var cursor *string
+	for {
+		page, err := apiClient.ListDomains(&limit, cursor)
+		if err != nil {
+			return nil, err
+		}
+		if page == nil {
+			break
+		}
+		for _, domain := range page.Data {
+			names[strings.ToLower(domain.Domain)] = true
+		}
+		if !page.Pagination.HasMore || page.Pagination.NextCursor == nil || *page.Pagination.NextCursor == "" {
+			break
+		}
+		cursor = page.Pagination.NextCursor
+	}
+	return names, nil
+}
@@ -0,0 +1,163 @@
+package smtp
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-go/models/common"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type smtpListRun struct {
+	stdout string
+	stderr string
+	err    error
+}
+
+func testCredential(name, scope string, sandbox bool, created time.Time, domains ...string) responses.SMTPCredential {
+	return responses.SMTPCredential{
+		Object:    "smtp_credential",
+		Name:      name,
+		Username:  name + "-user",
+		Scope:     scope,
+		Sandbox:   sandbox,
+		Domains:   domains,
+		CreatedAt: created,
+		UpdatedAt: created,
+	}
+}
+
+func executeSMTPList(t *testing.T, setup func(*mocks.MockClient), args ...string) smtpListRun {
+	t.Helper()
+
+	mockClient := &mocks.MockClient{}
+	setup(mockClient)
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var stdout, stderr bytes.Buffer
+	handler := printer.GetResponseHandler("csv", false, &stdout)
+	cmd := NewListCommand()
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	return smtpListRun{stdout: stdout.String(), stderr: stderr.String(), err: err}
+}
+
+func TestSMTPList_FiltersAndSortsAcrossPages(t *testing.T) {
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	next := "page-2"
+
+	run := executeSMTPList(t, func(m *mocks.MockClient) {
+		m.On("ListSMTPCredentials", mock.Anything, (*string)(nil)).Return(&responses.PaginatedSMTPCredentialsResponse{
+			Data: []responses.SMTPCredential{
+				testCredential("zeta", "scoped", false, day, "example.com"),
+				testCredential("other", "scoped", false, day, "other.com"),
+			},
+			Pagination: common.PaginationInfo{HasMore: true, NextCursor: &next},
+		}, nil).Once()
+		m.On("ListSMTPCredentials", mock.Anything, &next).Return(&responses.PaginatedSMTPCredentialsResponse{
+			Data: []responses.SMTPCredential{
+				testCredential("Alpha", "global", false, day),
+				testCredential("beta", "scoped", true, day, "EXAMPLE.com"),
+			},
+		}, nil).Once()
+		m.On("ListDomains", mock.Anything, (*string)(nil)).Return(&responses.PaginatedDomainsResponse{
+			Data: []responses.Domain{{Domain: "example.com"}},
+		}, nil).Once()
+	}, "--domain", "example.com", "--no-sandbox", "--sort", "name")
+
+	require.NoError(t, run.err)
+	assert.Contains(t, run.stdout, "Alpha")
+	assert.Contains(t, run.stdout, "zeta")
+	assert.NotContains(t, run.stdout, "other")
+	assert.NotContains(t, run.stdout, "beta")
+	assert.Less(t, bytes.Index([]byte(run.stdout), []byte("Alpha")), bytes.Index([]byte(run.stdout), []byte("zeta")))
+	assert.Empty(t, run.stderr, "every listed domain restriction still exists")
+}
+
+func TestSMTPList_WarnsAboutMissingDomains(t *testing.T) {
+	run := executeSMTPList(t, func(m *mocks.MockClient) {
+		m.On("ListSMTPCredentials", mock.Anything, (*string)(nil)).Return(&responses.PaginatedSMTPCredentialsResponse{
+			Data: []responses.SMTPCredential{testCredential("legacy", "scoped", false, time.Now(), "gone.com", "example.com")},
+		}, nil).Once()
+		m.On("ListDomains", mock.Anything, (*string)(nil)).Return(&responses.PaginatedDomainsResponse{
+			Data: []responses.Domain{{Domain: "example.com"}},
+		}, nil).Once()
+	}, "--scope", "scoped")
+
+	require.NoError(t, run.err)
+	assert.Contains(t, run.stderr, "SMTP credential 'legacy' is restricted to domain 'gone.com'")
+	assert.NotContains(t, run.stderr, "'example.com'")
+}
+
+func TestSMTPList_SortByCreatedNewestFirst(t *testing.T) {
+	credentials := []responses.SMTPCredential{
+		testCredential("old", "global", false, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)),
+		testCredential("new", "global", false, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}
+	sortSMTPCredentials(credentials, "created")
+	assert.Equal(t, "new", credentials[0].Name)
+}
+
+func TestSMTPList_InvalidFilters(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"unknown scope", []string{"--scope", "team"}, "invalid scope 'team'"},
+		{"unknown sort", []string{"--sort", "size"}, "invalid sort 'size'"},
+		{"last used", []string{"--sort", "last_used"}, "does not report when SMTP credentials were last used"},
+		{"cursor with filter", []string{"--scope", "global", "--cursor", "abc"}, "cannot be combined"},
+		{"both sandbox flags", []string{"--sandbox", "--no-sandbox"}, "none of the others can be"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run := executeSMTPList(t, func(*mocks.MockClient) {}, tt.args...)
+			require.Error(t, run.err)
+			assert.Contains(t, run.err.Error(), tt.want)
+		})
+	}
+}
+
+func TestSMTPList_IDsOnlyWithFilter(t *testing.T) {
+	next := "page-2"
+	zeta := testCredential("zeta", "scoped", false, time.Now(), "example.com")
+	alpha := testCredential("Alpha", "global", false, time.Now())
+	alpha.ID = uuid.New()
+	zeta.ID = uuid.New()
+
+	run := executeSMTPList(t, func(m *mocks.MockClient) {
+		m.On("ListSMTPCredentials", mock.Anything, (*string)(nil)).Return(&responses.PaginatedSMTPCredentialsResponse{
+			Data:       []responses.SMTPCredential{zeta, testCredential("other", "scoped", false, time.Now(), "other.com")},
+			Pagination: common.PaginationInfo{HasMore: true, NextCursor: &next},
+		}, nil).Once()
+		m.On("ListSMTPCredentials", mock.Anything, &next).Return(&responses.PaginatedSMTPCredentialsResponse{
+			Data: []responses.SMTPCredential{alpha},
+		}, nil).Once()
+		m.On("ListDomains", mock.Anything, (*string)(nil)).Return(&responses.PaginatedDomainsResponse{
+			Data: []responses.Domain{{Domain: "example.com"}},
+		}, nil).Once()
+	}, "--domain", "example.com", "--sort", "name", "--ids-only")
+
+	require.NoError(t, run.err)
+	assert.Equal(t, alpha.ID.String()+"\n"+zeta.ID.String()+"\n", run.stdout)
+}
@@ -8,9 +8,12 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/AhaSend/ahasend-cli/internal/accountstatus"
+	"github.com/AhaSend/ahasend-cli/internal/auth"
 	"github.com/AhaSend/ahasend-cli/internal/errors"
 	"github.com/AhaSend/ahasend-cli/internal/logger"
 	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/sender"
 	"github.com/AhaSend/ahasend-cli/internal/validation"
 	"github.com/spf13/cobra"
 	"gopkg.in/gomail.v2"
@@ -29,7 +32,8 @@ the same options as the regular 'messages send' command but via SMTP protocol.
 INTERACTIVE MODE:
 When called without any arguments, the command will interactively prompt for
 all required information: sender email, recipient, subject, content, and
-SMTP credentials.
+SMTP credentials. When --from is omitted, the profile's default_from is used
+before prompting (set it with 'ahasend config set default-from ...').
 
 You can use existing SMTP credentials or provide credentials directly.
 The command supports all standard email features including attachments,
@@ -42,6 +46,13 @@ Special headers can be used to control AhaSend features:
 - AhaSend-Sandbox: true/false
 - AhaSend-Sandbox-Result: deliver/bounce/defer/fail/suppress
 
+Internationalized domains (e.g. user@bücher.example) are converted to punycode
+automatically. Use --smtputf8 to also allow UTF-8 characters in the local part
+of addresses. The flag only relaxes address validation: the SMTPUTF8 parameter
+is added to MAIL FROM when the server advertises the extension, but the CLI
+does not check for it first, so a server without SMTPUTF8 support rejects the
+addresses during the send.
+
 In test mode, the command validates the SMTP connection and message building
 without actually sending the email. It performs all SMTP steps up to DATA
 command and then closes the connection.`,
@@ -98,11 +109,12 @@ command and then closes the connection.`,
     --from sender@example.com \
     --to recipient@example.com \
     --subject "Custom Server Test"`,
-		RunE: runSMTPSend,
+		RunE:        runSMTPSend,
+		Annotations: map[string]string{accountstatus.SendsAnnotation: "true"},
 	}
 
 	// Email content flags
-	cmd.Flags().String("from", "", "From email address")
+	cmd.Flags().String("from", "", "From email address (defaults to the profile's default_from)")
 	cmd.Flags().StringSlice("to", []string{}, "Recipient email addresses (can be used multiple times)")
 	cmd.Flags().StringSlice("cc", []string{}, "CC recipients")
 	cmd.Flags().StringSlice("bcc", []string{}, "BCC recipients")
@@ -127,6 +139,9 @@ command and then closes the connection.`,
 	cmd.Flags().String("sandbox-result", "deliver", "Sandbox result simulation: deliver, bounce, defer, fail, or suppress (only used with --sandbox)")
 	cmd.Flags().StringSlice("header", []string{}, "Custom headers (format: 'Name: value')")
 
+	// Internationalized addresses
+	cmd.Flags().Bool("smtputf8", false, "Allow UTF-8 characters in address local parts (RFC 6531); only relaxes validation, the server must advertise SMTPUTF8")
+
 	// Test mode
 	cmd.Flags().Bool("test", false, "Test SMTP connection and message building (don't send email)")
 
@@ -194,6 +209,17 @@ func promptSMTPCredentials() (username, password string, err error) {
 	return username, password, nil
 }
 
+// requireSending fails when the account of the API credentials cannot send.
+// SMTP sends do not need an API key, so the check is skipped without one.
+func requireSending(cmd *cobra.Command) error {
+	apiClient, err := auth.GetAuthenticatedClient(cmd)
+	if err != nil {
+		logger.Get().WithError(err).Debug("Skipping the account status check without API credentials")
+		return nil
+	}
+	return accountstatus.RequireSending(apiClient)
+}
+
 func runSMTPSend(cmd *cobra.Command, args []string) error {
 	// Get printer instance
 	handler := printer.GetResponseHandlerFromCommand(cmd)
@@ -223,19 +249,29 @@ func runSMTPSend(cmd *cobra.Command, args []string) error {
 	headers, _ := cmd.Flags().GetStringSlice("header")
 
 	testMode, _ := cmd.Flags().GetBool("test")
+	smtpUTF8, _ := cmd.Flags().GetBool("smtputf8")
 
 	var err error
 
-	// Interactive prompts for missing required fields
-	if from == "" {
-		from, err = promptSMTPFromEmail()
-		if err != nil {
-			return errors.NewValidationError("failed to get sender email", err)
+	// A paused or restricted account rejects SMTP mail too; stop before prompting
+	if !testMode {
+		if err := requireSending(cmd); err != nil {
+			return err
 		}
 	}
 
+	// The sender comes from --from, the profile's default_from, or a prompt
+	resolved, err := sender.Resolve(cmd, promptSMTPFromEmail)
+	if err != nil {
+		return err
+	}
+	from = resolved.Address
+	if resolved.Source != sender.SourceFlag {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Sending from %s (%s)\n", from, resolved.Describe())
+	}
+
 	// Validate sender email
-	if err := validation.ValidateEmail(from); err != nil {
+	if err := validateSMTPAddress(from, smtpUTF8); err != nil {
 		return err
 	}
 
@@ -328,11 +364,25 @@ func runSMTPSend(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Internationalized domains are converted to punycode; local parts are
+	// only allowed to contain UTF-8 when --smtputf8 is set
+	if from, err = normalizeSMTPAddress(from, smtpUTF8); err != nil {
+		return err
+	}
+	for _, list := range [][]string{to, cc, bcc} {
+		for i, address := range list {
+			if list[i], err = normalizeSMTPAddress(address, smtpUTF8); err != nil {
+				return err
+			}
+		}
+	}
+
 	logger.Get().WithFields(map[string]interface{}{
 		"server":    server,
 		"from":      from,
 		"to":        to,
 		"test_mode": testMode,
+		"smtputf8":  smtpUTF8,
 	}).Debug("Sending email via SMTP with gomail")
 
 	// Parse server address
@@ -386,6 +436,23 @@ func runSMTPSend(cmd *cobra.Command, args []string) error {
 	return printSendSuccess(handler, from, to, cc, bcc, subject, server, len(attachments))
 }
 
+// validateSMTPAddress validates an address using the RFC 6531 policy when
+// SMTPUTF8 is enabled and the ASCII-only policy otherwise
+func validateSMTPAddress(address string, smtpUTF8 bool) error {
+	if smtpUTF8 {
+		return validation.ValidateEmailSMTPUTF8(address)
+	}
+	return validation.ValidateEmail(address)
+}
+
+// normalizeSMTPAddress validates an address and converts its domain to punycode
+func normalizeSMTPAddress(address string, smtpUTF8 bool) (string, error) {
+	if err := validateSMTPAddress(address, smtpUTF8); err != nil {
+		return "", err
+	}
+	return validation.NormalizeEmail(address)
+}
+
 func buildGomailMessage(
 	from string, to, cc, bcc []string,
 	subject, textContent, htmlContent string,
@@ -37,6 +37,9 @@ Examples:
   # Get details of a specific credential
   ahasend smtp get <credential-id>
 
+  # Change a credential's domains without rotating its password
+  ahasend smtp update <credential-id> --add-domain news.example.com
+
   # Test SMTP sending
   ahasend smtp send --from sender@example.com --to recipient@example.com`,
 		Example: `  # List SMTP credentials
@@ -49,15 +52,20 @@ Examples:
   ahasend smtp create --name "Notifications" --scope scoped --domains "notifications.example.com"
 
   # Test SMTP connection
-  ahasend smtp send --test --server send.ahasend.com:587`,
+  ahasend smtp send --test --server send.ahasend.com:587
+
+  # Find credentials unused for 60 days
+  ahasend smtp usage --unused-for 60d`,
 	}
 
 	// Add subcommands
 	cmd.AddCommand(NewListCommand())
 	cmd.AddCommand(NewGetCommand())
 	cmd.AddCommand(NewCreateCommand())
+	cmd.AddCommand(NewUpdateCommand())
 	cmd.AddCommand(NewDeleteCommand())
 	cmd.AddCommand(NewSendCommand())
+	cmd.AddCommand(NewUsageCommand())
 
 	return cmd
 }
@@ -54,14 +54,15 @@ func TestSMTPCommand_Help(t *testing.T) {
 	assert.Contains(t, helpOutput, "create")
 	assert.Contains(t, helpOutput, "delete")
 	assert.Contains(t, helpOutput, "send")
+	assert.Contains(t, helpOutput, "usage")
 }
 
 func TestSMTPCommand_SubcommandCount(t *testing.T) {
 	cmd := NewCommand()
 	subcommands := cmd.Commands()
 
-	// Should have exactly 5 subcommands (list, get, create, delete, send)
-	assert.Equal(t, 5, len(subcommands), "smtp command should have exactly 5 subcommands")
+	// Should have exactly 7 subcommands (list, get, create, update, delete, send, usage)
+	assert.Equal(t, 7, len(subcommands), "smtp command should have exactly 7 subcommands")
 }
 
 // Test list command structure and flags
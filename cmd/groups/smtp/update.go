@@ -0,0 +1,301 @@
+package smtp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/spf13/cobra"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/validation"
+)
+
+// updateFlags are the flags that change a credential
+var updateFlags = []string{"name", "scope", "add-domain", "remove-domain", "sandbox", "no-sandbox"}
+
+// smtpChanges are the changes requested on the command line; nil fields
+// are left as they are
+type smtpChanges struct {
+	Name    *string
+	Scope   *string
+	Sandbox *bool
+	Add     []string
+	Remove  []string
+}
+
+// NewUpdateCommand creates the smtp update command
+func NewUpdateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update <credential-id>",
+		Short: "Update an SMTP credential without changing its password",
+		Long: `Update the name, scope, domain restrictions or sandbox mode of an SMTP
+credential. The password is kept, so applications using the credential keep
+working.
+
+--add-domain and --remove-domain change the credential's current domain
+list; they can be repeated or given comma-separated lists. Removing a domain
+the credential is not restricted to, or adding and removing the same domain,
+is an error.
+
+Changing --scope to global lifts every domain restriction. Changing it to
+scoped needs at least one domain, given with --add-domain.
+
+--dry-run reads the credential and shows the domain list it would have,
+without updating it.`,
+		Example: `  # Allow a credential to send from another domain
+  ahasend smtp update 550e8400-e29b-41d4-a716-446655440000 --add-domain news.example.com
+
+  # Swap one domain for another, checking the result first
+  ahasend smtp update 550e8400-e29b-41d4-a716-446655440000 \
+    --add-domain mail.example.com --remove-domain old.example.com --dry-run
+
+  # Restrict a global credential to one domain
+  ahasend smtp update 550e8400-e29b-41d4-a716-446655440000 --scope scoped --add-domain example.com
+
+  # Rename a credential and take it out of sandbox mode
+  ahasend smtp update 550e8400-e29b-41d4-a716-446655440000 --name "Production" --no-sandbox`,
+		Args:         cobra.ExactArgs(1),
+		RunE:         runSMTPUpdate,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().String("name", "", "New credential name")
+	cmd.Flags().String("scope", "", "New credential scope (global or scoped)")
+	cmd.Flags().StringSlice("add-domain", []string{}, "Domain to allow sending from (repeatable)")
+	cmd.Flags().StringSlice("remove-domain", []string{}, "Domain to stop allowing (repeatable)")
+	cmd.Flags().Bool("sandbox", false, "Put the credential in sandbox mode")
+	cmd.Flags().Bool("no-sandbox", false, "Take the credential out of sandbox mode")
+	cmd.Flags().Bool("dry-run", false, "Show the resulting domain list without updating the credential")
+	cmd.MarkFlagsMutuallyExclusive("sandbox", "no-sandbox")
+
+	return cmd
+}
+
+func runSMTPUpdate(cmd *cobra.Command, args []string) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+
+	credentialID := args[0]
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	changes, err := parseSMTPChanges(cmd)
+	if err != nil {
+		return err
+	}
+
+	apiClient, err := auth.GetAuthenticatedClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	current, err := apiClient.GetSMTPCredential(credentialID)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		return errors.NewNotFoundError(fmt.Sprintf("SMTP credential '%s' not found", credentialID), nil)
+	}
+
+	update, req, err := planSMTPUpdate(current, changes)
+	if err != nil {
+		return err
+	}
+
+	logger.Get().WithFields(map[string]interface{}{
+		"credential_id":   credentialID,
+		"added_domains":   update.AddedDomains,
+		"removed_domains": update.RemovedDomains,
+		"dry_run":         dryRun,
+	}).Debug("Executing smtp update command")
+
+	config := printer.UpdateConfig{
+		ItemName:   "smtp_credential",
+		FieldOrder: []string{"id", "name", "username", "scope", "domains", "added_domains", "removed_domains", "sandbox", "dry_run", "updated_at"},
+	}
+	if dryRun {
+		update.DryRun = true
+		config.SuccessMessage = fmt.Sprintf("Dry run: SMTP credential %s after the update (not applied)", current.Name)
+		return handler.HandleUpdateSMTP(update, config)
+	}
+	if req == (client.UpdateSMTPCredentialRequest{}) {
+		return handler.HandleSimpleSuccess(fmt.Sprintf("No changes to SMTP credential %s; nothing was updated", current.Name))
+	}
+
+	credential, err := apiClient.UpdateSMTPCredential(credentialID, req)
+	if err != nil {
+		return err
+	}
+	if credential == nil {
+		return errors.NewAPIError("received nil response from API", nil)
+	}
+	update.Credential = credential
+
+	config.SuccessMessage = fmt.Sprintf("SMTP credential %s updated successfully", credential.Name)
+	return handler.HandleUpdateSMTP(update, config)
+}
+
+// parseSMTPChanges reads and checks the changes requested on the command
+// line, before the credential is fetched
+func parseSMTPChanges(cmd *cobra.Command) (smtpChanges, error) {
+	var changes smtpChanges
+
+	changed := false
+	for _, flag := range updateFlags {
+		changed = changed || cmd.Flags().Changed(flag)
+	}
+	if !changed {
+		return changes, errors.NewValidationError("at least one of --"+strings.Join(updateFlags, ", --")+" must be provided", nil)
+	}
+
+	if cmd.Flags().Changed("name") {
+		name, _ := cmd.Flags().GetString("name")
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return changes, errors.NewValidationError("credential name cannot be empty", nil)
+		}
+		changes.Name = &name
+	}
+	if cmd.Flags().Changed("scope") {
+		scope, _ := cmd.Flags().GetString("scope")
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		if scope != "global" && scope != "scoped" {
+			return changes, errors.NewValidationError("scope must be 'global' or 'scoped'", nil)
+		}
+		changes.Scope = &scope
+	}
+	if sandbox, _ := cmd.Flags().GetBool("sandbox"); sandbox {
+		changes.Sandbox = &sandbox
+	}
+	if noSandbox, _ := cmd.Flags().GetBool("no-sandbox"); noSandbox {
+		sandbox := false
+		changes.Sandbox = &sandbox
+	}
+
+	add, _ := cmd.Flags().GetStringSlice("add-domain")
+	remove, _ := cmd.Flags().GetStringSlice("remove-domain")
+	var err error
+	// Internationalized domains are compared and sent in punycode form
+	if changes.Add, err = normalizeDomainSet(add); err != nil {
+		return changes, err
+	}
+	if changes.Remove, err = normalizeDomainSet(remove); err != nil {
+		return changes, err
+	}
+
+	var both []string
+	for _, domain := range changes.Add {
+		if containsDomain(changes.Remove, domain) {
+			both = append(both, domain)
+		}
+	}
+	if len(both) > 0 {
+		return changes, errors.NewValidationError(fmt.Sprintf("cannot both add and remove %s", strings.Join(both, ", ")), nil)
+	}
+
+	return changes, nil
+}
+
+// planSMTPUpdate applies changes to the current credential. It returns the
+// credential as it will be after the update, and the request that makes the
+// update; the request is empty when nothing changes.
+func planSMTPUpdate(current *responses.SMTPCredential, changes smtpChanges) (*printer.SMTPCredentialUpdate, client.UpdateSMTPCredentialRequest, error) {
+	var req client.UpdateSMTPCredentialRequest
+	result := *current
+	result.Password = ""
+	update := &printer.SMTPCredentialUpdate{Credential: &result}
+
+	for _, domain := range changes.Remove {
+		if !containsDomain(current.Domains, domain) {
+			return nil, req, errors.NewValidationError(fmt.Sprintf("SMTP credential %s is not restricted to %s (domains: %s)", current.Name, domain, formatDomainList(current.Domains)), nil)
+		}
+	}
+
+	domains := []string{}
+	for _, domain := range current.Domains {
+		if containsDomain(changes.Remove, domain) {
+			update.RemovedDomains = append(update.RemovedDomains, domain)
+			continue
+		}
+		domains = append(domains, domain)
+	}
+	for _, domain := range changes.Add {
+		if !containsDomain(domains, domain) {
+			domains = append(domains, domain)
+			update.AddedDomains = append(update.AddedDomains, domain)
+		}
+	}
+
+	scope := current.Scope
+	if changes.Scope != nil {
+		scope = *changes.Scope
+	}
+	switch scope {
+	case "global":
+		if len(changes.Add) > 0 {
+			return nil, req, errors.NewValidationError("global credentials can send from any domain; use --scope scoped to restrict the credential to the added domains", nil)
+		}
+		// A global credential has no domain restrictions left
+		update.RemovedDomains = append(update.RemovedDomains, domains...)
+		domains = []string{}
+	case "scoped":
+		if len(domains) == 0 {
+			return nil, req, errors.NewValidationError(fmt.Sprintf("SMTP credential %s would be scoped to no domains; add one with --add-domain, or use --scope global", current.Name), nil)
+		}
+	}
+
+	if scope != current.Scope {
+		req.Scope = &scope
+		result.Scope = scope
+	}
+	if len(update.AddedDomains) > 0 || len(update.RemovedDomains) > 0 || req.Scope != nil {
+		req.Domains = &domains
+		result.Domains = domains
+	}
+	if changes.Name != nil && *changes.Name != current.Name {
+		req.Name = changes.Name
+		result.Name = *changes.Name
+	}
+	if changes.Sandbox != nil && *changes.Sandbox != current.Sandbox {
+		req.Sandbox = changes.Sandbox
+		result.Sandbox = *changes.Sandbox
+	}
+
+	return update, req, nil
+}
+
+// normalizeDomainSet converts domains to punycode and drops duplicates
+func normalizeDomainSet(domains []string) ([]string, error) {
+	normalized, err := validation.NormalizeDomains(domains)
+	if err != nil {
+		return nil, err
+	}
+	var set []string
+	for _, domain := range normalized {
+		if !containsDomain(set, domain) {
+			set = append(set, domain)
+		}
+	}
+	return set, nil
+}
+
+// containsDomain reports whether domains has domain, ignoring case
+func containsDomain(domains []string, domain string) bool {
+	for _, d := range domains {
+		if strings.EqualFold(d, domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatDomainList lists domains for an error message
+func formatDomainList(domains []string) string {
+	if len(domains) == 0 {
+		return "none"
+	}
+	return strings.Join(domains, ", ")
+}
@@ -0,0 +1,159 @@
+package smtp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+)
+
+func executeSMTPUpdate(t *testing.T, format string, setup func(*mocks.MockClient), args ...string) (string, *mocks.MockClient, error) {
+	t.Helper()
+
+	mockClient := &mocks.MockClient{}
+	setup(mockClient)
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var stdout bytes.Buffer
+	handler := printer.GetResponseHandler(format, false, &stdout)
+	cmd := NewUpdateCommand()
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(append([]string{"cred-1"}, args...))
+
+	err := cmd.Execute()
+	return stdout.String(), mockClient, err
+}
+
+func scopedCredential(domains ...string) *responses.SMTPCredential {
+	credential := testCredential("app", "scoped", false, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), domains...)
+	credential.Password = "secret-password"
+	return &credential
+}
+
+func TestPlanSMTPUpdate(t *testing.T) {
+	str := func(s string) *string { return &s }
+
+	t.Run("add and remove", func(t *testing.T) {
+		update, req, err := planSMTPUpdate(scopedCredential("a.com", "b.com"), smtpChanges{Add: []string{"c.com", "a.com"}, Remove: []string{"b.com"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a.com", "c.com"}, update.Credential.Domains)
+		assert.Equal(t, []string{"c.com"}, update.AddedDomains, "a domain already allowed is not added again")
+		assert.Equal(t, []string{"b.com"}, update.RemovedDomains)
+		assert.Empty(t, update.Credential.Password)
+		require.NotNil(t, req.Domains)
+		assert.Equal(t, []string{"a.com", "c.com"}, *req.Domains)
+		assert.Nil(t, req.Scope)
+		assert.Nil(t, req.Name)
+	})
+
+	t.Run("removing a domain the credential does not have", func(t *testing.T) {
+		_, _, err := planSMTPUpdate(scopedCredential("a.com"), smtpChanges{Remove: []string{"z.com"}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not restricted to z.com (domains: a.com)")
+	})
+
+	t.Run("removing the last domain", func(t *testing.T) {
+		_, _, err := planSMTPUpdate(scopedCredential("a.com"), smtpChanges{Remove: []string{"a.com"}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "scoped to no domains")
+	})
+
+	t.Run("to global lifts restrictions", func(t *testing.T) {
+		update, req, err := planSMTPUpdate(scopedCredential("a.com", "b.com"), smtpChanges{Scope: str("global")})
+		require.NoError(t, err)
+		assert.Equal(t, "global", *req.Scope)
+		assert.Empty(t, *req.Domains)
+		assert.Equal(t, []string{"a.com", "b.com"}, update.RemovedDomains)
+	})
+
+	t.Run("adding to a global credential", func(t *testing.T) {
+		global := scopedCredential()
+		global.Scope = "global"
+		_, _, err := planSMTPUpdate(global, smtpChanges{Add: []string{"a.com"}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--scope scoped")
+
+		update, req, err := planSMTPUpdate(global, smtpChanges{Scope: str("scoped"), Add: []string{"a.com"}})
+		require.NoError(t, err)
+		assert.Equal(t, "scoped", update.Credential.Scope)
+		assert.Equal(t, []string{"a.com"}, *req.Domains)
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		sandbox := false
+		_, req, err := planSMTPUpdate(scopedCredential("a.com"), smtpChanges{Name: str("app"), Sandbox: &sandbox, Add: []string{"A.com"}})
+		require.NoError(t, err)
+		assert.Equal(t, client.UpdateSMTPCredentialRequest{}, req)
+	})
+}
+
+func TestSMTPUpdate(t *testing.T) {
+	updated := scopedCredential("a.com", "c.com")
+	updated.Password = ""
+	out, mockClient, err := executeSMTPUpdate(t, "json", func(m *mocks.MockClient) {
+		m.On("GetSMTPCredential", "cred-1").Return(scopedCredential("a.com", "b.com"), nil)
+		domains := []string{"a.com", "c.com"}
+		sandbox := true
+		m.On("UpdateSMTPCredential", "cred-1", client.UpdateSMTPCredentialRequest{Domains: &domains, Sandbox: &sandbox}).Return(updated, nil)
+	}, "--add-domain", "C.com", "--remove-domain", "b.com", "--sandbox")
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+
+	var result map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(out), &result))
+	assert.Equal(t, "smtp_credential_update", result["object"])
+	assert.Equal(t, false, result["dry_run"])
+	assert.Equal(t, []interface{}{"c.com"}, result["added_domains"])
+	assert.NotContains(t, out, "password")
+}
+
+func TestSMTPUpdate_DryRun(t *testing.T) {
+	out, mockClient, err := executeSMTPUpdate(t, "plain", func(m *mocks.MockClient) {
+		m.On("GetSMTPCredential", "cred-1").Return(scopedCredential("a.com", "b.com"), nil)
+	}, "--add-domain", "c.com", "--remove-domain", "a.com", "--dry-run")
+	require.NoError(t, err)
+	mockClient.AssertNotCalled(t, "UpdateSMTPCredential")
+	assert.Contains(t, out, "Domains: b.com, c.com\n")
+	assert.Contains(t, out, "Added Domains: c.com\n")
+	assert.Contains(t, out, "Removed Domains: a.com\n")
+	assert.NotContains(t, out, "secret-password")
+}
+
+func TestSMTPUpdate_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"no changes", []string{"--dry-run"}, "at least one of"},
+		{"add and remove", []string{"--add-domain", "a.com,b.com", "--remove-domain", "B.com"}, "cannot both add and remove b.com"},
+		{"scope", []string{"--scope", "domains"}, "scope must be"},
+		{"empty name", []string{"--name", " "}, "name cannot be empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, mockClient, err := executeSMTPUpdate(t, "plain", func(*mocks.MockClient) {}, tt.args...)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+			assert.Equal(t, 4, errors.GetExitCode(err))
+			mockClient.AssertNotCalled(t, "GetSMTPCredential", "cred-1")
+		})
+	}
+}
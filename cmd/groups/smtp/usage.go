@@ -0,0 +1,319 @@
+package smtp
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/output"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/spf13/cobra"
+)
+
+// usageGroupBys are the bucket sizes smtp usage accepts
+var usageGroupBys = []string{"day", "week", "month"}
+
+// NewUsageCommand creates the smtp usage command
+func NewUsageCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "usage",
+		Short: "Show approximate usage of SMTP credentials",
+		Long: `Show how many messages each SMTP credential sent per time bucket, to find the
+credentials that are no longer used.
+
+The AhaSend API does not report usage or last-used times per SMTP credential,
+so the usage is an approximation: a scoped credential is credited with the
+messages sent from the domains it is restricted to, and a global credential
+with all of the account's messages. Credentials that share a domain are
+credited with the same messages, and messages sent through the HTTP API count
+too, so a credential showing activity may still be unused. A credential
+showing no activity did not send any messages.
+
+A credential is reported as unused when it shows no messages in the range and
+was created before it starts. --unused-for lists only these credentials for
+the given period up to now, for cleanup reviews. Use --fail-on-findings in CI
+to exit with an error when any credential is unused.`,
+		Example: `  # Usage of every credential over the last 30 days
+  ahasend smtp usage
+
+  # One credential, per day, over the last week
+  ahasend smtp usage --credential-id abcd1234-5678-90ef-abcd-1234567890ab --from-time 7d --group-by day
+
+  # Credentials without activity in the last 60 days
+  ahasend smtp usage --unused-for 60d
+
+  # Fail a CI job when a credential has been unused for 90 days
+  ahasend smtp usage --unused-for 90d --fail-on-findings --output json`,
+		RunE:         runSMTPUsage,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().String("credential-id", "", "Only report this SMTP credential")
+	cmd.Flags().String("from-time", "30d", "Start time (RFC3339, YYYY-MM-DD, or relative like '30d')")
+	cmd.Flags().String("to-time", "", "End time (RFC3339, YYYY-MM-DD, or relative like '1d'; default: now)")
+	cmd.Flags().String("on", "", "Report a single day (YYYY-MM-DD); replaces --from-time and --to-time")
+	cmd.Flags().String("timezone", "", "Timezone for date-only values, e.g. 'Europe/Berlin' or 'UTC' (default: local)")
+	cmd.Flags().String("group-by", "week", "Group messages by: day, week, month")
+	cmd.Flags().String("unused-for", "", "Only list credentials without activity in this period up to now (e.g. '60d')")
+	cmd.Flags().Bool("fail-on-findings", false, "Exit with an error when any credential is unused")
+
+	return cmd
+}
+
+func runSMTPUsage(cmd *cobra.Command, args []string) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+
+	credentialID, _ := cmd.Flags().GetString("credential-id")
+	groupBy, _ := cmd.Flags().GetString("group-by")
+	unusedFor, _ := cmd.Flags().GetString("unused-for")
+	failOnFindings, _ := cmd.Flags().GetBool("fail-on-findings")
+
+	if !slices.Contains(usageGroupBys, groupBy) {
+		return errors.NewValidationError(fmt.Sprintf("invalid group-by '%s', must be one of: %s",
+			groupBy, strings.Join(usageGroupBys, ", ")), nil)
+	}
+
+	from, to, err := usageRangeFromFlags(cmd, unusedFor)
+	if err != nil {
+		return err
+	}
+
+	apiClient, err := auth.GetAuthenticatedClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	logger.Get().WithFields(map[string]interface{}{
+		"credential_id": credentialID,
+		"from_time":     from,
+		"to_time":       to,
+		"group_by":      groupBy,
+		"unused_for":    unusedFor,
+	}).Debug("Executing SMTP usage command")
+
+	var credentials []responses.SMTPCredential
+	if credentialID != "" {
+		credential, err := apiClient.GetSMTPCredential(credentialID)
+		if err != nil {
+			return err
+		}
+		if credential == nil {
+			return errors.NewNotFoundError(fmt.Sprintf("SMTP credential %s not found", credentialID), nil)
+		}
+		credentials = []responses.SMTPCredential{*credential}
+	} else {
+		all, err := listAllSMTPCredentials(apiClient)
+		if err != nil {
+			return err
+		}
+		credentials = all.Data
+	}
+
+	stats := newUsageStats(apiClient, from, to, groupBy)
+	report := buildUsageReport(credentials, stats.fetch, from, to, groupBy)
+	if unusedFor != "" {
+		report.UnusedFor = unusedFor
+		report.Credentials = report.Unused()
+		if report.Credentials == nil {
+			report.Credentials = []printer.SMTPCredentialUsage{}
+		}
+	}
+
+	emptyMessage := "No SMTP credentials found"
+	if unusedFor != "" {
+		emptyMessage = fmt.Sprintf("No SMTP credentials unused for %s", unusedFor)
+	}
+	if err := handler.HandleSMTPUsage(report, printer.SingleConfig{
+		EmptyMessage: emptyMessage,
+	}); err != nil {
+		return err
+	}
+
+	if stats.failed > 0 && stats.failed == stats.fetched {
+		return errors.NewAPIError("deliverability statistics are unavailable for every SMTP credential", stats.lastErr)
+	}
+	if unused := report.Unused(); failOnFindings && len(unused) > 0 {
+		names := make([]string, len(unused))
+		for i, credential := range unused {
+			names[i] = credential.Name
+		}
+		return fmt.Errorf("%d unused SMTP credentials: %s", len(unused), strings.Join(names, ", "))
+	}
+	return nil
+}
+
+// usageRangeFromFlags returns the range usage is counted over: the
+// --unused-for period up to now, or the --from-time, --to-time and --on
+// range read by the shared time range parser
+func usageRangeFromFlags(cmd *cobra.Command, unusedFor string) (from, to time.Time, err error) {
+	if unusedFor != "" {
+		for _, name := range []string{"from-time", "to-time", "on"} {
+			if cmd.Flags().Changed(name) {
+				return time.Time{}, time.Time{}, errors.NewValidationError(fmt.Sprintf("--unused-for cannot be combined with --%s", name), nil)
+			}
+		}
+		to = time.Now()
+		from, err = output.ParseTimePast(unusedFor)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.NewValidationError(fmt.Sprintf("invalid unused-for: %v", err), nil)
+		}
+		if !from.Before(to) {
+			return time.Time{}, time.Time{}, errors.NewValidationError("--unused-for must be a positive period such as '60d'", nil)
+		}
+		return from, to, nil
+	}
+
+	fromTimeStr, _ := cmd.Flags().GetString("from-time")
+	toTimeStr, _ := cmd.Flags().GetString("to-time")
+	on, _ := cmd.Flags().GetString("on")
+	timezone, _ := cmd.Flags().GetString("timezone")
+	if on != "" && !cmd.Flags().Changed("from-time") {
+		fromTimeStr = ""
+	}
+
+	fromTime, toTime, err := output.ParseTimeRange(fromTimeStr, toTimeStr, on, timezone)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	to = time.Now()
+	from = to.AddDate(0, 0, -30)
+	if fromTime != nil {
+		from = *fromTime
+	}
+	if toTime != nil {
+		to = *toTime
+	}
+	return from, to, nil
+}
+
+// usageStats fetches deliverability statistics for one sender domain, or the
+// whole account for an empty domain, once per domain
+type usageStats struct {
+	apiClient client.AhaSendClient
+	params    requests.GetDeliverabilityStatisticsParams
+	cache     map[string]usageResult
+	fetched   int
+	failed    int
+	lastErr   error
+}
+
+type usageResult struct {
+	stats []responses.DeliverabilityStatistics
+	err   error
+}
+
+func newUsageStats(apiClient client.AhaSendClient, from, to time.Time, groupBy string) *usageStats {
+	return &usageStats{
+		apiClient: apiClient,
+		params: requests.GetDeliverabilityStatisticsParams{
+			FromTime: &from,
+			ToTime:   &to,
+			GroupBy:  &groupBy,
+		},
+		cache: make(map[string]usageResult),
+	}
+}
+
+func (s *usageStats) fetch(domain string) ([]responses.DeliverabilityStatistics, error) {
+	if result, ok := s.cache[domain]; ok {
+		return result.stats, result.err
+	}
+
+	params := s.params
+	if domain != "" {
+		params.SenderDomain = &domain
+	}
+	var result usageResult
+	response, err := s.apiClient.GetDeliverabilityStatistics(params)
+	if err != nil {
+		logger.Get().WithField("domain", domain).WithError(err).Debug("Failed to fetch deliverability statistics for SMTP usage")
+		result.err = err
+		s.failed++
+		s.lastErr = err
+	} else if response != nil {
+		result.stats = response.Data
+	}
+	s.fetched++
+	s.cache[domain] = result
+	return result.stats, result.err
+}
+
+// buildUsageReport credits each credential with the messages of the domains
+// it may send from. fetch returns the statistics of a sender domain, or of the
+// whole account for an empty domain. It performs no other I/O.
+func buildUsageReport(credentials []responses.SMTPCredential, fetch func(domain string) ([]responses.DeliverabilityStatistics, error), from, to time.Time, groupBy string) *printer.SMTPUsageReport {
+	report := &printer.SMTPUsageReport{
+		From:        from,
+		To:          to,
+		GroupBy:     groupBy,
+		Approximate: true,
+		Checked:     len(credentials),
+		Credentials: make([]printer.SMTPCredentialUsage, 0, len(credentials)),
+	}
+
+	for _, credential := range credentials {
+		usage := printer.SMTPCredentialUsage{
+			ID:          credential.ID.String(),
+			Name:        credential.Name,
+			Username:    credential.Username,
+			Scope:       credential.Scope,
+			Domains:     credential.Domains,
+			Sandbox:     credential.Sandbox,
+			CreatedAt:   credential.CreatedAt,
+			Attribution: printer.SMTPUsageByDomain,
+			Buckets:     []printer.SMTPUsageBucket{},
+		}
+		if usage.Domains == nil {
+			usage.Domains = []string{}
+		}
+
+		domains := credential.Domains
+		if credential.Scope == "global" || len(domains) == 0 {
+			usage.Attribution = printer.SMTPUsageByAccount
+			domains = []string{""}
+		}
+
+		buckets := make(map[time.Time]*printer.SMTPUsageBucket)
+		for _, domain := range domains {
+			stats, err := fetch(strings.ToLower(domain))
+			if err != nil {
+				usage.Error = err.Error()
+				break
+			}
+			for _, stat := range stats {
+				bucket, ok := buckets[stat.FromTimestamp]
+				if !ok {
+					bucket = &printer.SMTPUsageBucket{From: stat.FromTimestamp, To: stat.ToTimestamp}
+					buckets[stat.FromTimestamp] = bucket
+				}
+				bucket.Messages += stat.ReceptionCount
+			}
+		}
+
+		if usage.Error == "" {
+			for _, bucket := range buckets {
+				usage.Buckets = append(usage.Buckets, *bucket)
+				usage.Messages += bucket.Messages
+				if bucket.Messages > 0 && (usage.LastActivity == nil || bucket.To.After(*usage.LastActivity)) {
+					lastActivity := bucket.To
+					usage.LastActivity = &lastActivity
+				}
+			}
+			sort.Slice(usage.Buckets, func(i, j int) bool {
+				return usage.Buckets[i].From.Before(usage.Buckets[j].From)
+			})
+			usage.Unused = usage.Messages == 0 && !credential.CreatedAt.After(from)
+		}
+		report.Credentials = append(report.Credentials, usage)
+	}
+
+	return report
+}
@@ -0,0 +1,163 @@
+package smtp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func usageBucket(from time.Time, received int) responses.DeliverabilityStatistics {
+	return responses.DeliverabilityStatistics{FromTimestamp: from, ToTimestamp: from.AddDate(0, 0, 7), ReceptionCount: received}
+}
+
+func TestBuildUsageReport(t *testing.T) {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 14)
+	week2 := from.AddDate(0, 0, 7)
+	old := from.AddDate(-1, 0, 0)
+
+	stats := map[string][]responses.DeliverabilityStatistics{
+		"":            {usageBucket(from, 10), usageBucket(week2, 5)},
+		"example.com": {usageBucket(from, 3), usageBucket(week2, 0)},
+		"other.com":   {usageBucket(week2, 2)},
+		"quiet.com":   {usageBucket(from, 0)},
+	}
+	var fetched []string
+	fetch := func(domain string) ([]responses.DeliverabilityStatistics, error) {
+		fetched = append(fetched, domain)
+		if domain == "broken.com" {
+			return nil, fmt.Errorf("rate limited")
+		}
+		return stats[domain], nil
+	}
+
+	report := buildUsageReport([]responses.SMTPCredential{
+		testCredential("main", "global", false, old),
+		testCredential("shop", "scoped", false, old, "Example.com", "other.com"),
+		testCredential("legacy", "scoped", false, old, "quiet.com"),
+		testCredential("fresh", "scoped", false, from.AddDate(0, 0, 1), "quiet.com"),
+		testCredential("flaky", "scoped", false, old, "broken.com"),
+	}, fetch, from, to, "week")
+
+	require.Len(t, report.Credentials, 5)
+	assert.True(t, report.Approximate)
+	assert.Equal(t, 5, report.Checked)
+
+	main := report.Credentials[0]
+	assert.Equal(t, printer.SMTPUsageByAccount, main.Attribution)
+	assert.Equal(t, 15, main.Messages)
+
+	shop := report.Credentials[1]
+	assert.Equal(t, printer.SMTPUsageByDomain, shop.Attribution)
+	assert.Equal(t, 5, shop.Messages)
+	require.Len(t, shop.Buckets, 2)
+	assert.Equal(t, []int{3, 2}, []int{shop.Buckets[0].Messages, shop.Buckets[1].Messages})
+	require.NotNil(t, shop.LastActivity)
+	assert.Equal(t, week2.AddDate(0, 0, 7), *shop.LastActivity)
+	assert.False(t, shop.Unused)
+
+	assert.True(t, report.Credentials[2].Unused)
+	assert.Nil(t, report.Credentials[2].LastActivity)
+	assert.False(t, report.Credentials[3].Unused, "created during the range")
+	assert.False(t, report.Credentials[4].Unused, "statistics unavailable")
+	assert.Equal(t, "rate limited", report.Credentials[4].Error)
+
+	assert.Equal(t, []string{"legacy"}, []string{report.Unused()[0].Name})
+	assert.Equal(t, []string{"", "example.com", "other.com", "quiet.com", "quiet.com", "broken.com"}, fetched)
+}
+
+func executeSMTPUsage(t *testing.T, format string, setup func(*mocks.MockClient), args ...string) (string, error) {
+	t.Helper()
+
+	mockClient := &mocks.MockClient{}
+	setup(mockClient)
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var stdout bytes.Buffer
+	handler := printer.GetResponseHandler(format, false, &stdout)
+	cmd := NewUsageCommand()
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	return stdout.String(), err
+}
+
+func TestSMTPUsage_UnusedForFailOnFindings(t *testing.T) {
+	old := time.Now().AddDate(-1, 0, 0)
+	stdout, err := executeSMTPUsage(t, "table", func(m *mocks.MockClient) {
+		m.On("ListSMTPCredentials", mock.Anything, (*string)(nil)).Return(&responses.PaginatedSMTPCredentialsResponse{
+			Data: []responses.SMTPCredential{
+				testCredential("shop", "scoped", false, old, "example.com"),
+				testCredential("legacy", "scoped", false, old, "quiet.com"),
+			},
+		}, nil).Once()
+		m.On("GetDeliverabilityStatistics", mock.MatchedBy(func(p requests.GetDeliverabilityStatisticsParams) bool {
+			return *p.SenderDomain == "example.com"
+		})).Return(&responses.DeliverabilityStatisticsResponse{
+			Data: []responses.DeliverabilityStatistics{usageBucket(time.Now().AddDate(0, 0, -7), 4)},
+		}, nil).Once()
+		m.On("GetDeliverabilityStatistics", mock.MatchedBy(func(p requests.GetDeliverabilityStatisticsParams) bool {
+			return *p.SenderDomain == "quiet.com"
+		})).Return(&responses.DeliverabilityStatisticsResponse{}, nil).Once()
+	}, "--unused-for", "60d", "--fail-on-findings")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 unused SMTP credentials: legacy")
+	assert.Contains(t, stdout, "Approximation")
+	assert.Contains(t, stdout, "Unused for 60d: 1 of 2 credentials")
+	assert.Contains(t, stdout, "legacy")
+	assert.NotContains(t, stdout, "shop")
+}
+
+func TestSMTPUsage_CredentialCSV(t *testing.T) {
+	old := time.Now().AddDate(-1, 0, 0)
+	credential := testCredential("main", "global", false, old)
+	stdout, err := executeSMTPUsage(t, "csv", func(m *mocks.MockClient) {
+		m.On("GetSMTPCredential", "cred-1").Return(&credential, nil).Once()
+		m.On("GetDeliverabilityStatistics", mock.MatchedBy(func(p requests.GetDeliverabilityStatisticsParams) bool {
+			return p.SenderDomain == nil && *p.GroupBy == "day"
+		})).Return(&responses.DeliverabilityStatisticsResponse{
+			Data: []responses.DeliverabilityStatistics{usageBucket(time.Now().AddDate(0, 0, -3), 9)},
+		}, nil).Once()
+	}, "--credential-id", "cred-1", "--group-by", "day", "--from-time", "7d")
+
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "id,name,username,scope,domains,attribution,status,messages")
+	assert.Contains(t, stdout, "main,main-user,global,,account,active,9,")
+}
+
+func TestSMTPUsage_InvalidFlags(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--group-by", "hour"}, "invalid group-by 'hour'"},
+		{[]string{"--unused-for", "60d", "--from-time", "7d"}, "--unused-for cannot be combined with --from-time"},
+		{[]string{"--unused-for", "soon"}, "invalid unused-for"},
+		{[]string{"--on", "2026-03-01", "--to-time", "2026-03-02"}, "--on cannot be combined"},
+	}
+	for _, tt := range tests {
+		_, err := executeSMTPUsage(t, "json", func(*mocks.MockClient) {}, tt.args...)
+		require.Error(t, err, tt.args)
+		assert.Contains(t, err.Error(), tt.want)
+	}
+}
@@ -0,0 +1,215 @@
+package stats
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/AhaSend/ahasend-cli/internal/anomaly"
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/fetch"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/spf13/cobra"
+)
+
+// anomalyMetrics maps each --metric value to the rate it is computed from
+var anomalyMetrics = map[string]func(stat responses.DeliverabilityStatistics) *float64{
+	"bounce_rate": func(stat responses.DeliverabilityStatistics) *float64 {
+		return fetch.WeightedRate(stat.BouncedCount, stat.ReceptionCount)
+	},
+	"delivery_rate": func(stat responses.DeliverabilityStatistics) *float64 {
+		return fetch.WeightedRate(stat.DeliveredCount, stat.ReceptionCount)
+	},
+	"open_rate": func(stat responses.DeliverabilityStatistics) *float64 {
+		return fetch.WeightedRate(stat.OpenedCount, stat.DeliveredCount)
+	},
+}
+
+// NewAnomaliesCommand creates the stats anomalies command
+func NewAnomaliesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "anomalies",
+		Short: "Detect unusual bounce, delivery or open rates",
+		Long: `Flag time buckets whose bounce, delivery or open rate deviates from the
+buckets before them.
+
+Each bucket's rate is compared with the mean and standard deviation of the
+--window buckets before it. A bucket is anomalous when its z-score, the
+number of standard deviations it is away from that mean, exceeds
+--sensitivity in either direction. If the earlier buckets all have the same
+rate, any change is anomalous and the z-score is shown as "flat". A bucket
+needs at least 3 earlier buckets to be scored, and buckets without messages
+are skipped, so pick a range several windows long.
+
+Metrics:
+- bounce_rate: bounced / received
+- delivery_rate: delivered / received
+- open_rate: opened / delivered
+
+The output lists the anomalous buckets with their z-scores and ends with a
+verdict such as "2 anomalous days detected". Use --fail-on-anomaly in a
+scheduled check to exit with an error when any bucket is anomalous.`,
+		Example: `  # Check the daily bounce rate of the last 30 days
+  ahasend stats anomalies --metric bounce_rate
+
+  # Fail a scheduled check when the open rate of the last 14 days is unusual
+  ahasend stats anomalies --metric open_rate --from-time 14d --fail-on-anomaly
+
+  # Hourly delivery rate for one sender domain, flagging smaller deviations
+  ahasend stats anomalies --metric delivery_rate --from-time 3d --group-by hour \
+    --sender-domain example.com --sensitivity 2`,
+		Args:         cobra.NoArgs,
+		RunE:         runStatsAnomalies,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().String("metric", "bounce_rate", "Metric to check: bounce_rate, delivery_rate, open_rate")
+	cmd.Flags().String("from-time", "30d", "Start time (RFC3339, YYYY-MM-DD or relative like '30d', '24h')")
+	cmd.Flags().String("to-time", "", "End time (RFC3339, YYYY-MM-DD for the whole day, or relative; defaults to now)")
+	addTimeRangeFlags(cmd)
+	cmd.Flags().String("group-by", "day", "Group results by: hour, day, week, month")
+	cmd.Flags().Float64("sensitivity", anomaly.DefaultSensitivity, "Z-score beyond which a bucket is anomalous")
+	cmd.Flags().Int("window", anomaly.DefaultWindow, "Number of preceding buckets each bucket is compared with")
+	cmd.Flags().Bool("fail-on-anomaly", false, "Exit with an error when any bucket is anomalous")
+
+	// Filtering flags
+	cmd.Flags().String("sender-domain", "", "Filter by sender domain")
+	cmd.Flags().StringSlice("recipient-domain", []string{}, "Filter by recipient domains (can be used multiple times)")
+	cmd.Flags().String("tags", "", "Filter by message tags (comma-separated)")
+
+	return cmd
+}
+
+func runStatsAnomalies(cmd *cobra.Command, args []string) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+
+	metric, _ := cmd.Flags().GetString("metric")
+	groupBy, _ := cmd.Flags().GetString("group-by")
+	sensitivity, _ := cmd.Flags().GetFloat64("sensitivity")
+	window, _ := cmd.Flags().GetInt("window")
+	failOnAnomaly, _ := cmd.Flags().GetBool("fail-on-anomaly")
+	senderDomain, _ := cmd.Flags().GetString("sender-domain")
+	recipientDomains, _ := cmd.Flags().GetStringSlice("recipient-domain")
+	tags, _ := cmd.Flags().GetString("tags")
+
+	if _, ok := anomalyMetrics[metric]; !ok {
+		return errors.NewValidationError(fmt.Sprintf("invalid metric '%s', must be one of: bounce_rate, delivery_rate, open_rate", metric), nil)
+	}
+	validGroupBy := []string{"hour", "day", "week", "month"}
+	if !slices.Contains(validGroupBy, groupBy) {
+		return errors.NewValidationError(fmt.Sprintf("invalid group-by '%s', must be one of: %s",
+			groupBy, strings.Join(validGroupBy, ", ")), nil)
+	}
+	if sensitivity <= 0 {
+		return errors.NewValidationError("--sensitivity must be greater than 0", nil)
+	}
+	if window < anomaly.MinHistory {
+		return errors.NewValidationError(fmt.Sprintf("--window must be at least %d", anomaly.MinHistory), nil)
+	}
+
+	from, to, _, err := timeRangeFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	client, err := auth.GetAuthenticatedClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	logger.Get().WithFields(map[string]interface{}{
+		"metric":      metric,
+		"from_time":   from,
+		"to_time":     to,
+		"group_by":    groupBy,
+		"sensitivity": sensitivity,
+		"window":      window,
+	}).Debug("Detecting statistics anomalies")
+
+	params := requests.GetDeliverabilityStatisticsParams{
+		FromTime: &from,
+		ToTime:   &to,
+		GroupBy:  &groupBy,
+	}
+	if senderDomain != "" {
+		params.SenderDomain = &senderDomain
+	}
+	if len(recipientDomains) > 0 {
+		recipientDomainsStr := strings.Join(recipientDomains, ",")
+		params.RecipientDomains = &recipientDomainsStr
+	}
+	if tags != "" {
+		params.Tags = &tags
+	}
+
+	// Long ranges are fetched in chunks; see statsChunkSpan
+	windows := splitStatsRange(from, to, groupBy)
+	progress := newChunkProgress(cmd.ErrOrStderr(), len(windows))
+	var buckets []responses.DeliverabilityStatistics
+	err = fetchDeliverabilityWindows(client, params, windows, progress, func(_ int, chunk *responses.DeliverabilityStatisticsResponse) error {
+		buckets = append(buckets, chunk.Data...)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	report := detectAnomalies(buckets, metric, groupBy, window, sensitivity)
+	report.From = from
+	report.To = to
+	if err := handler.HandleStatsAnomalies(report, printer.StatsConfig{Title: "Statistics Anomalies"}); err != nil {
+		return err
+	}
+
+	if failOnAnomaly && len(report.Anomalies) > 0 {
+		return fmt.Errorf("%s (%s)", report.Verdict(), metric)
+	}
+	return nil
+}
+
+// detectAnomalies scores the metric of every bucket with messages against
+// the buckets before it. It performs no I/O so it can be tested in isolation
+// from fetching and rendering.
+func detectAnomalies(buckets []responses.DeliverabilityStatistics, metric, groupBy string, window int, sensitivity float64) *printer.AnomalyReport {
+	rate := anomalyMetrics[metric]
+
+	var series []float64
+	var scoredBuckets []responses.DeliverabilityStatistics
+	for _, bucket := range buckets {
+		if value := rate(bucket); value != nil {
+			series = append(series, *value)
+			scoredBuckets = append(scoredBuckets, bucket)
+		}
+	}
+
+	report := &printer.AnomalyReport{
+		Metric:      metric,
+		GroupBy:     groupBy,
+		Window:      window,
+		Sensitivity: sensitivity,
+		Buckets:     len(series),
+		Anomalies:   []printer.AnomalyBucket{},
+	}
+	for _, score := range anomaly.Detect(series, window, sensitivity) {
+		if score.Scored {
+			report.Scored++
+		}
+		if !score.Anomalous {
+			continue
+		}
+		bucket := scoredBuckets[score.Index]
+		report.Anomalies = append(report.Anomalies, printer.AnomalyBucket{
+			From:   bucket.FromTimestamp,
+			To:     bucket.ToTimestamp,
+			Value:  score.Value,
+			Mean:   score.Mean,
+			StdDev: score.StdDev,
+			ZScore: score.ZScore,
+		})
+	}
+	return report
+}
@@ -0,0 +1,136 @@
+package stats
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// dailyBounces returns one daily bucket per bounce count, 1000 messages each
+func dailyBounces(bounced ...int) []responses.DeliverabilityStatistics {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	buckets := make([]responses.DeliverabilityStatistics, len(bounced))
+	for i, count := range bounced {
+		buckets[i] = responses.DeliverabilityStatistics{
+			FromTimestamp:  from.AddDate(0, 0, i),
+			ToTimestamp:    from.AddDate(0, 0, i+1),
+			ReceptionCount: 1000,
+			DeliveredCount: 1000 - count,
+			BouncedCount:   count,
+		}
+	}
+	return buckets
+}
+
+func TestDetectAnomalies(t *testing.T) {
+	buckets := dailyBounces(10, 12, 9, 11, 10, 11, 9, 65, 10)
+	// A day without messages has no rate and is skipped
+	buckets = append(buckets[:3], append([]responses.DeliverabilityStatistics{{FromTimestamp: buckets[3].FromTimestamp}}, buckets[3:]...)...)
+
+	report := detectAnomalies(buckets, "bounce_rate", "day", 7, 2.5)
+	assert.Equal(t, 9, report.Buckets)
+	assert.Equal(t, 6, report.Scored)
+	require.Len(t, report.Anomalies, 1)
+
+	spike := report.Anomalies[0]
+	assert.Equal(t, time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC), spike.From)
+	assert.InDelta(t, 6.5, spike.Value, 1e-9)
+	require.NotNil(t, spike.ZScore)
+	assert.Greater(t, *spike.ZScore, 2.5)
+	assert.Equal(t, "1 anomalous day detected", report.Verdict())
+
+	calm := detectAnomalies(dailyBounces(10, 10, 10, 10, 10), "bounce_rate", "day", 7, 2.5)
+	assert.Empty(t, calm.Anomalies)
+	assert.Equal(t, "No anomalous days detected", calm.Verdict())
+
+	// The delivery rate drops on the spike day
+	delivery := detectAnomalies(dailyBounces(10, 12, 9, 11, 10, 11, 9, 65), "delivery_rate", "day", 7, 2.5)
+	require.Len(t, delivery.Anomalies, 1)
+	assert.Less(t, *delivery.Anomalies[0].ZScore, -2.5)
+}
+
+func runAnomalies(t *testing.T, format string, buckets []responses.DeliverabilityStatistics, args ...string) (string, error) {
+	t.Helper()
+
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetDeliverabilityStatistics", mock.Anything).Return(deliverabilityResponse(buckets...), nil)
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var buf bytes.Buffer
+	handler := printer.GetResponseHandler(format, false, &buf)
+	cmd := NewAnomaliesCommand()
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs(append([]string{"--from-time", "2026-03-01T00:00:00Z", "--to-time", "2026-03-10T00:00:00Z"}, args...))
+
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+func TestAnomaliesCommand(t *testing.T) {
+	spiky := dailyBounces(10, 12, 9, 11, 10, 11, 9, 65, 10)
+
+	t.Run("table lists the anomaly and the verdict", func(t *testing.T) {
+		out, err := runAnomalies(t, "table", spiky)
+		require.NoError(t, err)
+		assert.Contains(t, out, "6.50%")
+		assert.Contains(t, out, "1 anomalous day detected (6 of 9 days scored)")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		out, err := runAnomalies(t, "json", spiky, "--metric", "bounce_rate")
+		require.NoError(t, err)
+
+		var report struct {
+			Object    string `json:"object"`
+			Verdict   string `json:"verdict"`
+			Anomalies []struct {
+				Value  float64  `json:"value"`
+				ZScore *float64 `json:"z_score"`
+			} `json:"anomalies"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(out), &report))
+		assert.Equal(t, "stats_anomalies", report.Object)
+		assert.Equal(t, "1 anomalous day detected", report.Verdict)
+		require.Len(t, report.Anomalies, 1)
+		assert.NotNil(t, report.Anomalies[0].ZScore)
+	})
+
+	t.Run("--fail-on-anomaly", func(t *testing.T) {
+		_, err := runAnomalies(t, "plain", spiky, "--fail-on-anomaly")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "1 anomalous day detected (bounce_rate)")
+
+		out, err := runAnomalies(t, "plain", dailyBounces(10, 11, 10, 11, 10, 11), "--fail-on-anomaly")
+		require.NoError(t, err)
+		assert.Contains(t, out, "No anomalous days detected")
+	})
+
+	t.Run("invalid flags", func(t *testing.T) {
+		for _, args := range [][]string{
+			{"--metric", "click_rate"},
+			{"--group-by", "year"},
+			{"--sensitivity", "0"},
+			{"--window", "2"},
+		} {
+			_, err := runAnomalies(t, "plain", spiky, args...)
+			assert.Error(t, err, args)
+		}
+	})
+}
@@ -2,13 +2,12 @@ package stats
 
 import (
 	"fmt"
+	"slices"
 	"strings"
-	"time"
 
 	"github.com/AhaSend/ahasend-cli/internal/auth"
 	"github.com/AhaSend/ahasend-cli/internal/errors"
 	"github.com/AhaSend/ahasend-cli/internal/logger"
-	"github.com/AhaSend/ahasend-cli/internal/output"
 	"github.com/AhaSend/ahasend-cli/internal/printer"
 	"github.com/AhaSend/ahasend-go/models/requests"
 	"github.com/spf13/cobra"
@@ -38,7 +37,15 @@ Bounce Classifications:
 - QuotaIssues: The recipient's mailbox is full
 - RoutingErrors: The recipient mail server couldn't route the email
 - TransientFailure: The recipient server temporarily rejected the message
-- Uncategorized: Other bounce types not specifically categorized`,
+- Uncategorized: Other bounce types not specifically categorized
+
+Use --aggregate to collapse the time periods into a single table of bounces
+per classification over the whole range, with each classification's share
+of all bounces, most frequent first. CSV output then has exactly the columns
+classification, count and percentage.
+
+Use --explain to add the explanation and recommended action of each
+classification to the output, or 'ahasend bounces explain' to look them up.`,
 		Example: `  # View bounce trends (default view)
   ahasend stats bounces --from-time 7d
 
@@ -51,6 +58,15 @@ Bounce Classifications:
   # View trends with hourly grouping
   ahasend stats bounces --trends --from-time 24h --group-by hour
 
+  # Total bounces per classification over the last 30 days
+  ahasend stats bounces --aggregate --from-time 30d
+
+  # Feed classification totals to a dashboard
+  ahasend stats bounces --aggregate --from-time 30d --output csv > bounces.csv
+
+  # Include explanations and recommended actions
+  ahasend stats bounces --classification --explain --from-time 7d
+
   # Filter classification view by domain
   ahasend stats bounces --classification \
     --sender-domain example.com \
@@ -59,8 +75,9 @@ Bounce Classifications:
 	}
 
 	// Time range flags (inherit from deliverability pattern)
-	cmd.Flags().String("from-time", "7d", "Start time (RFC3339 format or relative like '7d', '24h')")
-	cmd.Flags().String("to-time", "", "End time (RFC3339 format or relative, defaults to now)")
+	cmd.Flags().String("from-time", "7d", "Start time (RFC3339, YYYY-MM-DD or relative like '7d', '24h')")
+	cmd.Flags().String("to-time", "", "End time (RFC3339, YYYY-MM-DD for the whole day, or relative; defaults to now)")
+	addTimeRangeFlags(cmd)
 
 	// Filtering flags
 	cmd.Flags().String("group-by", "day", "Group results by: hour, day, week, month")
@@ -72,10 +89,12 @@ Bounce Classifications:
 	cmd.Flags().Bool("raw", false, "Show raw data without interpretation (useful for CSV/JSON)")
 	cmd.Flags().Bool("classification", false, "Show classification summary breakdown")
 	cmd.Flags().Bool("trends", false, "Show time-period focused trends (default)")
+	cmd.Flags().Bool("aggregate", false, "Total the bounces per classification over the whole range")
 
 	// Additional analysis flags
 	cmd.Flags().Bool("show-domains", false, "Show top bouncing recipient domains")
 	cmd.Flags().Bool("show-totals", true, "Show summary totals")
+	cmd.Flags().Bool("explain", false, "Add the explanation and recommended action of each classification")
 
 	return cmd
 }
@@ -90,46 +109,26 @@ func runBounceStats(cmd *cobra.Command, args []string) error {
 	}
 
 	// Get flag values
-	fromTimeStr, _ := cmd.Flags().GetString("from-time")
-	toTimeStr, _ := cmd.Flags().GetString("to-time")
 	groupBy, _ := cmd.Flags().GetString("group-by")
 	senderDomain, _ := cmd.Flags().GetString("sender-domain")
 	recipientDomains, _ := cmd.Flags().GetStringSlice("recipient-domain")
 	tags, _ := cmd.Flags().GetString("tags")
+	explain, _ := cmd.Flags().GetBool("explain")
+	aggregate, _ := cmd.Flags().GetBool("aggregate")
 
 	// Note: View mode flags (raw, classification, trends) are handled by the ResponseHandler
 	// which provides consistent output across all formats
 
-	// Parse time parameters (reuse logic from deliverability)
-	var fromTime *time.Time
-	if fromTimeStr != "" {
-		parsedTime, err := output.ParseTimePast(fromTimeStr)
-		if err == nil {
-			fromTime = &parsedTime
-		}
-		if err != nil {
-			return errors.NewValidationError(fmt.Sprintf("invalid from-time: %v", err), nil)
-		}
-	} else {
-		f := time.Now().Add(-30 * 24 * time.Hour)
-		fromTime = &f
-	}
-
-	var toTime *time.Time
-	if toTimeStr != "" {
-		parsed, err := output.ParseTimePast(toTimeStr)
-		if err != nil {
-			return errors.NewValidationError(fmt.Sprintf("invalid to-time: %v", err), nil)
-		}
-		toTime = &parsed
-	} else {
-		n := time.Now()
-		toTime = &n
+	// Parse time parameters
+	from, to, _, err := timeRangeFromFlags(cmd)
+	if err != nil {
+		return err
 	}
+	fromTime, toTime := &from, &to
 
 	// Validate group-by parameter
 	validGroupBy := []string{"hour", "day", "week", "month"}
-	if !contains(validGroupBy, groupBy) {
+	if !slices.Contains(validGroupBy, groupBy) {
 		return handler.HandleError(errors.NewValidationError(fmt.Sprintf("invalid group-by '%s', must be one of: %s",
 			groupBy, strings.Join(validGroupBy, ", ")), nil))
 	}
@@ -171,6 +170,8 @@ func runBounceStats(cmd *cobra.Command, args []string) error {
 		Title:      "Bounce Statistics",
 		ShowChart:  false, // Complex bounce data doesn't work well with simple charts
 		FieldOrder: []string{"time_bucket", "classification", "count", "percentage", "description"},
+		Explain:    explain,
+		Aggregate:  aggregate,
 	})
 
 }
@@ -0,0 +1,119 @@
+package stats
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/fetch"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+)
+
+// tagFetchConcurrency caps how many tags are fetched at the same time
+const tagFetchConcurrency = 4
+
+// parseTagList splits a comma-separated tag list into trimmed tags, keeping
+// the first occurrence of each in order
+func parseTagList(tags string) []string {
+	var result []string
+	seen := make(map[string]bool)
+	for _, tag := range strings.Split(tags, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		result = append(result, tag)
+	}
+	return result
+}
+
+// fetchDeliverabilityByTag breaks deliverability down by tag. The statistics
+// API has no tag dimension, so each tag is fetched with its own filtered
+// queries, at most tagFetchConcurrency tags at a time. A tag whose queries
+// fail is reported as unavailable instead of failing the whole report.
+func fetchDeliverabilityByTag(apiClient client.AhaSendClient, params requests.GetDeliverabilityStatisticsParams, windows []statsWindow, tags []string, from, to time.Time, summaryOnly bool) *printer.DeliverabilityByTag {
+	report := &printer.DeliverabilityByTag{
+		From:        from,
+		To:          to,
+		SummaryOnly: summaryOnly,
+		Tags:        make([]printer.TagDeliverability, len(tags)),
+	}
+	if params.GroupBy != nil {
+		report.GroupBy = *params.GroupBy
+	}
+
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, tagFetchConcurrency)
+	for i, tag := range tags {
+		wg.Add(1)
+		go func(i int, tag string) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			report.Tags[i] = fetchTagDeliverability(apiClient, params, windows, tag, from, to, summaryOnly)
+		}(i, tag)
+	}
+	wg.Wait()
+
+	return report
+}
+
+// fetchTagDeliverability fetches the statistics of a single tag. Chunk
+// progress is not shown, since several tags load at once.
+func fetchTagDeliverability(apiClient client.AhaSendClient, params requests.GetDeliverabilityStatisticsParams, windows []statsWindow, tag string, from, to time.Time, summaryOnly bool) printer.TagDeliverability {
+	tagParams := params
+	tagParams.Tags = &tag
+
+	var accumulator fetch.DeliverabilityAccumulator
+	var buckets []printer.DeliverabilityBucket
+	err := fetchDeliverabilityWindows(apiClient, tagParams, windows, &chunkProgress{}, func(_ int, chunk *responses.DeliverabilityStatisticsResponse) error {
+		accumulator.Add(chunk.Data)
+		if !summaryOnly {
+			for _, stat := range chunk.Data {
+				buckets = append(buckets, newDeliverabilityBucket(stat))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Get().WithFields(map[string]interface{}{
+			"tag": tag,
+		}).WithError(err).Debug("Failed to fetch deliverability statistics for tag")
+		return printer.TagDeliverability{Tag: tag, Status: printer.TagStatusUnavailable, Error: err.Error()}
+	}
+
+	return printer.TagDeliverability{
+		Tag:     tag,
+		Status:  printer.TagStatusAvailable,
+		Summary: accumulator.Result(from, to),
+		Buckets: buckets,
+	}
+}
+
+// newDeliverabilityBucket computes the rates of one bucket from its counts
+func newDeliverabilityBucket(stat responses.DeliverabilityStatistics) printer.DeliverabilityBucket {
+	return printer.DeliverabilityBucket{
+		DeliverabilityStatistics: stat,
+		DeliveryRate:             fetch.WeightedRate(stat.DeliveredCount, stat.ReceptionCount),
+		OpenRate:                 fetch.WeightedRate(stat.OpenedCount, stat.DeliveredCount),
+	}
+}
+
+// checkTagAvailability fails when no tag could be fetched, so scripts notice
+// a report without data. Partial failures are only marked in the report.
+func checkTagAvailability(report *printer.DeliverabilityByTag) error {
+	for _, tag := range report.Tags {
+		if tag.Status == printer.TagStatusAvailable {
+			return nil
+		}
+	}
+	return errors.NewAPIError(fmt.Sprintf("deliverability statistics are unavailable for all %d tags", len(report.Tags)), nil)
+}
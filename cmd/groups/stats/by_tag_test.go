@@ -0,0 +1,202 @@
+package stats
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func tagParams(tag string) interface{} {
+	return mock.MatchedBy(func(p requests.GetDeliverabilityStatisticsParams) bool {
+		return p.Tags != nil && *p.Tags == tag
+	})
+}
+
+func runDeliverabilityByTag(t *testing.T, mockClient *mocks.MockClient, format string, args ...string) (string, error) {
+	t.Helper()
+
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var buf bytes.Buffer
+	handler := printer.GetResponseHandler(format, false, &buf)
+	cmd := NewDeliverabilityCommand()
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs(append([]string{
+		"--from-time", "2026-03-01T00:00:00Z",
+		"--to-time", "2026-03-03T00:00:00Z",
+		"--by-tag",
+	}, args...))
+
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+// newTagMock serves two daily buckets for welcome and fails for promo
+func newTagMock() *mocks.MockClient {
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetDeliverabilityStatistics", tagParams("welcome")).Return(deliverabilityResponse(
+		responses.DeliverabilityStatistics{FromTimestamp: day, ToTimestamp: day.Add(24 * time.Hour), ReceptionCount: 100, DeliveredCount: 90, OpenedCount: 45},
+		responses.DeliverabilityStatistics{FromTimestamp: day.Add(24 * time.Hour), ToTimestamp: day.Add(48 * time.Hour), ReceptionCount: 10, DeliveredCount: 10, OpenedCount: 1},
+	), nil)
+	mockClient.On("GetDeliverabilityStatistics", tagParams("digest")).Return(deliverabilityResponse(
+		responses.DeliverabilityStatistics{FromTimestamp: day, ToTimestamp: day.Add(24 * time.Hour), ReceptionCount: 50, DeliveredCount: 25, OpenedCount: 5},
+	), nil)
+	mockClient.On("GetDeliverabilityStatistics", tagParams("promo")).Return((*responses.DeliverabilityStatisticsResponse)(nil), errors.New("upstream timeout"))
+	return mockClient
+}
+
+func TestParseTagList(t *testing.T) {
+	assert.Equal(t, []string{"welcome", "digest"}, parseTagList(" welcome, digest,,welcome "))
+	assert.Empty(t, parseTagList(" , "))
+}
+
+func TestDeliverabilityCommand_ByTag(t *testing.T) {
+	t.Run("json nests buckets under each tag", func(t *testing.T) {
+		mockClient := newTagMock()
+		out, err := runDeliverabilityByTag(t, mockClient, "json", "--tags", "welcome,digest,promo")
+		require.NoError(t, err)
+
+		var report struct {
+			Object string `json:"object"`
+			Tags   []struct {
+				Tag     string `json:"tag"`
+				Status  string `json:"status"`
+				Error   string `json:"error"`
+				Summary *struct {
+					Reception    int     `json:"reception_count"`
+					DeliveryRate float64 `json:"delivery_rate"`
+					OpenRate     float64 `json:"open_rate"`
+				} `json:"summary"`
+				Buckets []struct {
+					Reception    int      `json:"reception_count"`
+					DeliveryRate *float64 `json:"delivery_rate"`
+				} `json:"buckets"`
+			} `json:"tags"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(out), &report))
+		assert.Equal(t, "deliverability_by_tag", report.Object)
+		require.Len(t, report.Tags, 3)
+
+		// Tags keep the requested order, whatever order the fetches finish in
+		welcome, digest, promo := report.Tags[0], report.Tags[1], report.Tags[2]
+		assert.Equal(t, "welcome", welcome.Tag)
+		require.Len(t, welcome.Buckets, 2)
+		assert.Equal(t, 100, welcome.Buckets[0].Reception)
+		assert.InDelta(t, 90.0, *welcome.Buckets[0].DeliveryRate, 0.001)
+		require.NotNil(t, welcome.Summary)
+		assert.Equal(t, 110, welcome.Summary.Reception)
+		assert.InDelta(t, 90.909, welcome.Summary.DeliveryRate, 0.001)
+		assert.InDelta(t, 46.0, welcome.Summary.OpenRate, 0.001)
+
+		// Rates are the tag's own, not the combined ones
+		assert.Equal(t, "digest", digest.Tag)
+		assert.InDelta(t, 50.0, digest.Summary.DeliveryRate, 0.001)
+		assert.InDelta(t, 20.0, digest.Summary.OpenRate, 0.001)
+
+		assert.Equal(t, "promo", promo.Tag)
+		assert.Equal(t, "unavailable", promo.Status)
+		assert.Contains(t, promo.Error, "upstream timeout")
+		assert.Nil(t, promo.Summary)
+		assert.Nil(t, promo.Buckets)
+
+		mockClient.AssertNumberOfCalls(t, "GetDeliverabilityStatistics", 3)
+	})
+
+	t.Run("table has a row per tag and bucket", func(t *testing.T) {
+		out, err := runDeliverabilityByTag(t, newTagMock(), "table", "--tags", "welcome,digest,promo")
+		require.NoError(t, err)
+
+		assert.Contains(t, out, "Deliverability by Tag")
+		assert.Equal(t, 2, strings.Count(out, "│ welcome"))
+		assert.Equal(t, 1, strings.Count(out, "│ digest"))
+		assert.Regexp(t, `promo\s+│ unavailable`, out)
+		assert.Contains(t, out, "Statistics for tag 'promo' are unavailable")
+	})
+
+	t.Run("summary only has a row per tag", func(t *testing.T) {
+		out, err := runDeliverabilityByTag(t, newTagMock(), "csv", "--tags", "welcome,promo", "--summary-only")
+		require.NoError(t, err)
+
+		lines := strings.Split(strings.TrimSpace(out), "\n")
+		require.Len(t, lines, 3)
+		assert.True(t, strings.HasPrefix(lines[0], "tag,status,buckets,"))
+		assert.True(t, strings.HasPrefix(lines[1], "welcome,available,2,110,100,"))
+		assert.Contains(t, lines[1], ",90.91,")
+		assert.True(t, strings.HasPrefix(lines[2], "promo,unavailable,,"))
+	})
+
+	t.Run("fails when every tag is unavailable", func(t *testing.T) {
+		out, err := runDeliverabilityByTag(t, newTagMock(), "plain", "--tags", "promo")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unavailable for all 1 tags")
+		assert.Contains(t, out, "Status: unavailable")
+	})
+}
+
+func TestDeliverabilityCommand_ByTagConcurrencyCap(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetDeliverabilityStatistics", mock.Anything).Run(func(mock.Arguments) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+	}).Return(deliverabilityResponse(), nil)
+
+	_, err := runDeliverabilityByTag(t, mockClient, "json", "--tags", "a,b,c,d,e,f,g,h,i,j")
+	require.NoError(t, err)
+	mockClient.AssertNumberOfCalls(t, "GetDeliverabilityStatistics", 10)
+	assert.LessOrEqual(t, maxInFlight, tagFetchConcurrency)
+	assert.Greater(t, maxInFlight, 1, "tags should be fetched concurrently")
+}
+
+func TestDeliverabilityCommand_ByTagConflicts(t *testing.T) {
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{nil, "--by-tag needs the tags to report"},
+		{[]string{"--tags", "welcome", "--stream"}, "cannot be combined with --stream"},
+		{[]string{"--tags", "welcome", "--chart"}, "cannot be combined with --stream or --chart"},
+		{[]string{"--tags", "welcome", "--compare-with", "previous"}, "cannot be combined with a comparison"},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			_, err := runDeliverabilityByTag(t, &mocks.MockClient{}, "json", tt.args...)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package stats
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/output"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-go/models/responses"
+)
+
+// comparePrevious is the only supported --compare-with value
+const comparePrevious = "previous"
+
+// periodLengthTolerance absorbs the drift between relative times that are
+// resolved against "now" at slightly different instants
+const periodLengthTolerance = time.Minute
+
+// deliverabilityTotals sums the counters of a deliverability response
+type deliverabilityTotals struct {
+	Reception int
+	Delivered int
+	Bounced   int
+	Opened    int
+	Clicked   int
+}
+
+// sumDeliverability adds up all time buckets of a response
+func sumDeliverability(response *responses.DeliverabilityStatisticsResponse) deliverabilityTotals {
+	var totals deliverabilityTotals
+	if response == nil {
+		return totals
+	}
+	for _, stat := range response.Data {
+		totals.Reception += stat.ReceptionCount
+		totals.Delivered += stat.DeliveredCount
+		totals.Bounced += stat.BouncedCount
+		totals.Opened += stat.OpenedCount
+		totals.Clicked += stat.ClickedCount
+	}
+	return totals
+}
+
+// percentage returns part/whole*100, or 0 when whole is zero
+func percentage(part, whole int) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return float64(part) / float64(whole) * 100
+}
+
+// compareMetric builds a MetricComparison from the two values
+func compareMetric(name string, current, previous float64, isRate, higherIsBetter bool) printer.MetricComparison {
+	metric := printer.MetricComparison{
+		Metric:         name,
+		Current:        current,
+		Previous:       previous,
+		Change:         current - previous,
+		IsRate:         isRate,
+		HigherIsBetter: higherIsBetter,
+	}
+	if previous != 0 {
+		percent := (current - previous) / previous * 100
+		metric.PercentChange = &percent
+	}
+	return metric
+}
+
+// compareRate builds a MetricComparison of part/whole in both periods. A
+// period with a zero whole has no rate, and then there is no change either.
+func compareRate(name string, current, currentWhole, previous, previousWhole int, higherIsBetter bool) printer.MetricComparison {
+	metric := compareMetric(name, percentage(current, currentWhole), percentage(previous, previousWhole), true, higherIsBetter)
+	metric.CurrentUndefined = currentWhole == 0
+	metric.PreviousUndefined = previousWhole == 0
+	if metric.Undefined() {
+		metric.Change = 0
+		metric.PercentChange = nil
+	}
+	return metric
+}
+
+// compareDeliverability diffs the totals of two deliverability responses.
+// It performs no I/O so it can be tested in isolation from fetching and rendering.
+func compareDeliverability(current, previous *responses.DeliverabilityStatisticsResponse, currentPeriod, previousPeriod printer.ComparisonPeriod) *printer.DeliverabilityComparison {
+	cur := sumDeliverability(current)
+	prev := sumDeliverability(previous)
+
+	return &printer.DeliverabilityComparison{
+		CurrentPeriod:  currentPeriod,
+		PreviousPeriod: previousPeriod,
+		Metrics: []printer.MetricComparison{
+			compareMetric("delivered", float64(cur.Delivered), float64(prev.Delivered), false, true),
+			compareMetric("bounced", float64(cur.Bounced), float64(prev.Bounced), false, false),
+			compareMetric("opened", float64(cur.Opened), float64(prev.Opened), false, true),
+			compareMetric("clicked", float64(cur.Clicked), float64(prev.Clicked), false, true),
+			compareRate("delivery_rate", cur.Delivered, cur.Reception, prev.Delivered, prev.Reception, true),
+			compareRate("open_rate", cur.Opened, cur.Delivered, prev.Opened, prev.Delivered, true),
+		},
+	}
+}
+
+// resolveComparisonPeriod determines the window to compare the current period
+// against, from either --compare-with or --compare-from/--compare-to.
+// Date-only comparison bounds are read in loc like the current period's.
+func resolveComparisonPeriod(current printer.ComparisonPeriod, compareWith, compareFrom, compareTo string, loc *time.Location, allowUnequal bool) (printer.ComparisonPeriod, error) {
+	if compareWith != "" && (compareFrom != "" || compareTo != "") {
+		return printer.ComparisonPeriod{}, errors.NewValidationError("--compare-with cannot be combined with --compare-from/--compare-to", nil)
+	}
+
+	if compareWith != "" {
+		if compareWith != comparePrevious {
+			return printer.ComparisonPeriod{}, errors.NewValidationError(fmt.Sprintf("invalid compare-with '%s', must be: %s", compareWith, comparePrevious), nil)
+		}
+		length := current.To.Sub(current.From)
+		return printer.ComparisonPeriod{From: current.From.Add(-length), To: current.From}, nil
+	}
+
+	if compareFrom == "" || compareTo == "" {
+		return printer.ComparisonPeriod{}, errors.NewValidationError("--compare-from and --compare-to must be used together", nil)
+	}
+
+	from, err := output.ParseTimeStart(compareFrom, loc)
+	if err != nil {
+		return printer.ComparisonPeriod{}, errors.NewValidationError(fmt.Sprintf("invalid compare-from: %v", err), nil)
+	}
+	to, err := output.ParseTimeEnd(compareTo, loc)
+	if err != nil {
+		return printer.ComparisonPeriod{}, errors.NewValidationError(fmt.Sprintf("invalid compare-to: %v", err), nil)
+	}
+	if !to.After(from) {
+		return printer.ComparisonPeriod{}, errors.NewValidationError("compare-to must be after compare-from", nil)
+	}
+
+	previous := printer.ComparisonPeriod{From: from, To: to}
+	if !allowUnequal {
+		currentLength := current.To.Sub(current.From)
+		previousLength := previous.To.Sub(previous.From)
+		diff := currentLength - previousLength
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff > periodLengthTolerance {
+			return printer.ComparisonPeriod{}, errors.NewValidationError(fmt.Sprintf(
+				"comparison periods differ in length (current %s, previous %s); counts would not be comparable. Adjust --compare-from/--compare-to or pass --allow-unequal",
+				formatPeriodLength(currentLength), formatPeriodLength(previousLength)), nil)
+		}
+	}
+	return previous, nil
+}
+
+// formatPeriodLength renders a period length in days or hours
+func formatPeriodLength(d time.Duration) string {
+	if d >= 24*time.Hour && d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	}
+	return d.Round(time.Minute).String()
+}
@@ -0,0 +1,256 @@
+package stats
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func deliverabilityResponse(buckets ...responses.DeliverabilityStatistics) *responses.DeliverabilityStatisticsResponse {
+	return &responses.DeliverabilityStatisticsResponse{Object: "list", Data: buckets}
+}
+
+func findMetric(t *testing.T, comparison *printer.DeliverabilityComparison, name string) printer.MetricComparison {
+	t.Helper()
+	for _, metric := range comparison.Metrics {
+		if metric.Metric == name {
+			return metric
+		}
+	}
+	t.Fatalf("metric %q not found", name)
+	return printer.MetricComparison{}
+}
+
+func TestCompareDeliverability(t *testing.T) {
+	current := deliverabilityResponse(
+		responses.DeliverabilityStatistics{ReceptionCount: 600, DeliveredCount: 570, BouncedCount: 10, OpenedCount: 285, ClickedCount: 30},
+		responses.DeliverabilityStatistics{ReceptionCount: 400, DeliveredCount: 380, BouncedCount: 10, OpenedCount: 95, ClickedCount: 10},
+	)
+	previous := deliverabilityResponse(
+		responses.DeliverabilityStatistics{ReceptionCount: 1000, DeliveredCount: 900, BouncedCount: 40, OpenedCount: 270, ClickedCount: 50},
+	)
+
+	comparison := compareDeliverability(current, previous, printer.ComparisonPeriod{}, printer.ComparisonPeriod{})
+	require.Len(t, comparison.Metrics, 6)
+
+	delivered := findMetric(t, comparison, "delivered")
+	assert.Equal(t, 950.0, delivered.Current)
+	assert.Equal(t, 900.0, delivered.Previous)
+	assert.Equal(t, 50.0, delivered.Change)
+	require.NotNil(t, delivered.PercentChange)
+	assert.InDelta(t, 5.56, *delivered.PercentChange, 0.01)
+
+	bounced := findMetric(t, comparison, "bounced")
+	assert.Equal(t, -20.0, bounced.Change)
+	assert.InDelta(t, -50.0, *bounced.PercentChange, 0.001)
+	assert.False(t, bounced.HigherIsBetter)
+
+	deliveryRate := findMetric(t, comparison, "delivery_rate")
+	assert.True(t, deliveryRate.IsRate)
+	assert.InDelta(t, 95.0, deliveryRate.Current, 0.001)
+	assert.InDelta(t, 90.0, deliveryRate.Previous, 0.001)
+	assert.InDelta(t, 5.0, deliveryRate.Change, 0.001, "rate change is in percentage points")
+
+	opened := findMetric(t, comparison, "opened")
+	assert.Equal(t, 110.0, opened.Change)
+
+	clicked := findMetric(t, comparison, "clicked")
+	assert.Equal(t, 40.0, clicked.Current)
+	assert.Equal(t, -10.0, clicked.Change)
+	assert.InDelta(t, -20.0, *clicked.PercentChange, 0.001)
+
+	openRate := findMetric(t, comparison, "open_rate")
+	assert.InDelta(t, 40.0, openRate.Current, 0.001)
+	assert.InDelta(t, 30.0, openRate.Previous, 0.001)
+	assert.False(t, openRate.Undefined())
+}
+
+func TestCompareDeliverability_EmptyPrevious(t *testing.T) {
+	current := deliverabilityResponse(responses.DeliverabilityStatistics{ReceptionCount: 10, DeliveredCount: 10})
+
+	comparison := compareDeliverability(current, deliverabilityResponse(), printer.ComparisonPeriod{}, printer.ComparisonPeriod{})
+
+	delivered := findMetric(t, comparison, "delivered")
+	assert.Equal(t, 10.0, delivered.Change)
+	assert.Nil(t, delivered.PercentChange, "no baseline means no percentage change")
+
+	deliveryRate := findMetric(t, comparison, "delivery_rate")
+	assert.False(t, deliveryRate.CurrentUndefined)
+	assert.True(t, deliveryRate.PreviousUndefined, "no receptions means no delivery rate")
+	assert.Equal(t, 0.0, deliveryRate.Change)
+	assert.Nil(t, deliveryRate.PercentChange)
+}
+
+func TestResolveComparisonPeriod(t *testing.T) {
+	current := printer.ComparisonPeriod{
+		From: time.Date(2024, 2, 8, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("previous window", func(t *testing.T) {
+		previous, err := resolveComparisonPeriod(current, "previous", "", "", time.UTC, false)
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), previous.From)
+		assert.Equal(t, current.From, previous.To)
+	})
+
+	t.Run("explicit equal window", func(t *testing.T) {
+		previous, err := resolveComparisonPeriod(current, "", "2024-01-01T00:00:00Z", "2024-01-08T00:00:00Z", time.UTC, false)
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), previous.From)
+	})
+
+	tests := []struct {
+		name         string
+		compareWith  string
+		compareFrom  string
+		compareTo    string
+		allowUnequal bool
+		wantErr      string
+	}{
+		{name: "unequal lengths", compareFrom: "2024-01-01T00:00:00Z", compareTo: "2024-01-15T00:00:00Z", wantErr: "differ in length (current 7d, previous 14d)"},
+		{name: "unknown compare-with", compareWith: "last-year", wantErr: "invalid compare-with"},
+		{name: "both modes", compareWith: "previous", compareFrom: "14d", wantErr: "cannot be combined"},
+		{name: "missing compare-to", compareFrom: "14d", wantErr: "must be used together"},
+		{name: "reversed window", compareFrom: "2024-01-08T00:00:00Z", compareTo: "2024-01-01T00:00:00Z", wantErr: "must be after"},
+		{name: "invalid time", compareFrom: "yesterday", compareTo: "2024-01-01T00:00:00Z", wantErr: "invalid compare-from"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := resolveComparisonPeriod(current, tt.compareWith, tt.compareFrom, tt.compareTo, time.UTC, tt.allowUnequal)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	t.Run("allow unequal", func(t *testing.T) {
+		_, err := resolveComparisonPeriod(current, "", "2024-01-01T00:00:00Z", "2024-01-15T00:00:00Z", time.UTC, true)
+		assert.NoError(t, err)
+	})
+}
+
+func runDeliverabilityComparison(t *testing.T, format string, args ...string) (string, *mocks.MockClient) {
+	t.Helper()
+
+	mockClient := &mocks.MockClient{}
+	currentFrom := time.Date(2024, 2, 8, 0, 0, 0, 0, time.UTC)
+	mockClient.On("GetDeliverabilityStatistics", mock.MatchedBy(func(p requests.GetDeliverabilityStatisticsParams) bool {
+		return p.FromTime.Equal(currentFrom)
+	})).Return(deliverabilityResponse(responses.DeliverabilityStatistics{ReceptionCount: 100, DeliveredCount: 95, BouncedCount: 2, OpenedCount: 38}), nil).Once()
+	mockClient.On("GetDeliverabilityStatistics", mock.MatchedBy(func(p requests.GetDeliverabilityStatisticsParams) bool {
+		return p.FromTime.Equal(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	})).Return(deliverabilityResponse(responses.DeliverabilityStatistics{ReceptionCount: 100, DeliveredCount: 90, BouncedCount: 5, OpenedCount: 27}), nil).Once()
+
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var buf bytes.Buffer
+	handler := printer.GetResponseHandler(format, false, &buf)
+	cmd := NewDeliverabilityCommand()
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs(append([]string{
+		"--from-time", "2024-02-08T00:00:00Z",
+		"--to-time", "2024-02-15T00:00:00Z",
+	}, args...))
+
+	require.NoError(t, cmd.Execute())
+	return buf.String(), mockClient
+}
+
+func TestDeliverabilityCommand_CompareWithPrevious(t *testing.T) {
+	t.Run("table", func(t *testing.T) {
+		out, mockClient := runDeliverabilityComparison(t, "table", "--compare-with", "previous")
+		assert.Contains(t, out, "Deliverability Comparison")
+		assert.Contains(t, out, "Delivery Rate")
+		assert.Contains(t, out, "+5.00 pp")
+		assert.Contains(t, out, "▲")
+		assert.Contains(t, out, "▼")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		out, _ := runDeliverabilityComparison(t, "json", "--compare-with", "previous")
+
+		var decoded struct {
+			Object  string `json:"object"`
+			Metrics []struct {
+				Metric        string   `json:"metric"`
+				Current       float64  `json:"current"`
+				Previous      float64  `json:"previous"`
+				Change        float64  `json:"change"`
+				PercentChange *float64 `json:"percent_change"`
+			} `json:"metrics"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(out), &decoded))
+		assert.Equal(t, "deliverability_comparison", decoded.Object)
+		require.Len(t, decoded.Metrics, 6)
+		assert.Equal(t, "bounced", decoded.Metrics[1].Metric)
+		assert.Equal(t, -3.0, decoded.Metrics[1].Change)
+		require.NotNil(t, decoded.Metrics[1].PercentChange)
+		assert.InDelta(t, -60.0, *decoded.Metrics[1].PercentChange, 0.001)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		out, _ := runDeliverabilityComparison(t, "csv", "--compare-from", "2024-02-01T00:00:00Z", "--compare-to", "2024-02-08T00:00:00Z")
+
+		records, err := csv.NewReader(bytes.NewBufferString(out)).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 7)
+		assert.Equal(t, []string{"metric", "current", "previous", "change", "percent_change"}, records[0][:5])
+		assert.Equal(t, []string{"delivered", "95.00", "90.00", "5.00", "5.56"}, records[1][:5])
+	})
+}
+
+func TestDeliverabilityCommand_ComparePreviousWithoutReceptions(t *testing.T) {
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetDeliverabilityStatistics", mock.MatchedBy(func(p requests.GetDeliverabilityStatisticsParams) bool {
+		return p.FromTime.Equal(time.Date(2024, 2, 8, 0, 0, 0, 0, time.UTC))
+	})).Return(deliverabilityResponse(responses.DeliverabilityStatistics{ReceptionCount: 100, DeliveredCount: 95, OpenedCount: 38}), nil).Once()
+	mockClient.On("GetDeliverabilityStatistics", mock.MatchedBy(func(p requests.GetDeliverabilityStatisticsParams) bool {
+		return p.FromTime.Equal(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	})).Return(deliverabilityResponse(), nil).Once()
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var buf bytes.Buffer
+	cmd := NewDeliverabilityCommand()
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, printer.GetResponseHandler("plain", false, &buf)))
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs([]string{"--from-time", "2024-02-08T00:00:00Z", "--to-time", "2024-02-15T00:00:00Z", "--compare-previous"})
+	require.NoError(t, cmd.Execute())
+
+	out := buf.String()
+	assert.Contains(t, out, "Delivery Rate:\n  Current: 95.00%\n  Previous: N/A\n  Change: N/A (N/A)")
+	assert.NotContains(t, out, "Inf")
+	mockClient.AssertExpectations(t)
+
+	cmd = NewDeliverabilityCommand()
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, printer.GetResponseHandler("plain", false, &buf)))
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs([]string{"--from-time", "7d", "--compare-previous", "--compare-with", "previous"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--compare-previous cannot be combined")
+}
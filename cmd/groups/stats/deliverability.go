@@ -2,15 +2,17 @@ package stats
 
 import (
 	"fmt"
+	"slices"
 	"strings"
-	"time"
 
 	"github.com/AhaSend/ahasend-cli/internal/auth"
 	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/fetch"
 	"github.com/AhaSend/ahasend-cli/internal/logger"
-	"github.com/AhaSend/ahasend-cli/internal/output"
+	"github.com/AhaSend/ahasend-cli/internal/pager"
 	"github.com/AhaSend/ahasend-cli/internal/printer"
 	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
 	"github.com/spf13/cobra"
 )
 
@@ -23,17 +25,55 @@ func NewDeliverabilityCommand() *cobra.Command {
 bounced, and rejected message counts.
 
 Statistics can be filtered by time range, domain, tags and grouped by different periods.
-The command provides ASCII charts for visual representation and supports CSV export.
+The command supports CSV and JSON export, and charts in table and plain output.
 
 Time ranges can be specified using RFC3339 format or relative formats:
 - RFC3339: "2024-01-15T00:00:00Z"
 - Relative: "1h", "24h", "7d", "30d" (from now)
 
+--since and --until take a duration before now ("90m", "1h30m", "1d12h")
+or an RFC3339 timestamp, resolved in UTC, and reject anything else. They
+replace --from-time and --to-time, and work on every stats command.
+
 Grouping options:
 - hour: Group by hour
 - day: Group by day (default)
 - week: Group by week
-- month: Group by month`,
+- month: Group by month
+
+Charts:
+--chart adds a TREND column with the delivered count of each bucket as a
+sparkline level (▁ to █), scaled from zero to the busiest bucket, and draws
+the delivery rate of each bucket as a bar chart below the table, fitted to
+the terminal width. Buckets without receptions have no rate and no bar.
+--chart only affects table and plain output; CSV and JSON are unchanged.
+
+Comparing periods:
+Use --compare-previous (or --compare-with previous) to compare the selected
+range with the window of the same length immediately before it, or
+--compare-from/--compare-to to pick the comparison window explicitly. The output
+shows current and previous values with absolute and percentage change for
+delivered, bounced, opened, clicked, delivery rate and open rate. A rate of a
+period with nothing to divide by (no receptions, or no deliveries for the open
+rate) and its change are shown as N/A, and left empty in CSV. Windows of
+different lengths are rejected unless --allow-unequal is set.
+
+Long ranges:
+The statistics API returns a range in a single response, so long hourly or
+daily ranges are fetched in consecutive chunks, with progress shown on stderr
+when it is a terminal. --stream prints each chunk's buckets as soon as it
+arrives: table rows are appended, CSV rows follow a single header, and JSON
+output becomes JSON Lines (one bucket object per line). --summary-only skips
+the per-bucket rows and prints totals for the whole range; its rates are
+computed from the summed counts, not by averaging per-bucket percentages.
+
+Per-tag breakdown:
+--by-tag reports each tag given with --tags separately, with one row per tag
+and time bucket (or one row per tag with --summary-only) and rates computed
+from that tag's counts. The statistics API has no tag dimension, so every tag
+is fetched with its own filtered query, up to 4 tags at a time. A tag whose
+query fails is shown as unavailable; the command only fails when no tag could
+be fetched. JSON output nests each tag's summary and buckets under the tag.`,
 		Example: `  # View deliverability for last 7 days
   ahasend stats deliverability --from-time 7d
 
@@ -42,37 +82,74 @@ Grouping options:
     --from-time "2024-01-15T00:00:00Z" \
     --to-time "2024-01-16T00:00:00Z"
 
+  # View a single day, midnight to midnight in UTC
+  ahasend stats deliverability --on 2024-01-15 --timezone UTC
+
   # Group by hour and filter by domain
   ahasend stats deliverability \
     --from-time 24h \
     --group-by hour \
     --sender-domain example.com
 
-  # Export to CSV with visual chart
-  ahasend stats deliverability --from-time 30d --output csv --chart
+  # Show the delivered trend and a delivery rate chart
+  ahasend stats deliverability --from-time 30d --chart
+
+  # Export to CSV
+  ahasend stats deliverability --from-time 30d --output csv
 
   # View recipient domain breakdown
   ahasend stats deliverability \
     --from-time 7d \
     --recipient-domain gmail.com \
-    --recipient-domain googlemail.com`,
+    --recipient-domain googlemail.com
+
+  # The last 90 minutes
+  ahasend stats deliverability --since 90m --group-by hour
+
+  # Compare this week with last week
+  ahasend stats deliverability --from-time 7d --compare-previous
+
+  # Compare against an explicit window
+  ahasend stats deliverability \
+    --from-time "2024-02-01T00:00:00Z" --to-time "2024-02-08T00:00:00Z" \
+    --compare-from "2024-01-01T00:00:00Z" --compare-to "2024-01-08T00:00:00Z"
+
+  # Stream 90 days of hourly buckets as JSON Lines
+  ahasend stats deliverability --from-time 90d --group-by hour --stream --output json
+
+  # Totals and rates for the last 90 days
+  ahasend stats deliverability --from-time 90d --summary-only
+
+  # Delivery and open rates per campaign tag
+  ahasend stats deliverability --from-time 30d --by-tag --tags welcome,digest,promo --summary-only`,
 		RunE: runDeliverabilityStats,
 	}
 
 	// Time range flags
-	cmd.Flags().String("from-time", "7d", "Start time (RFC3339 format or relative like '7d', '24h')")
-	cmd.Flags().String("to-time", "", "End time (RFC3339 format or relative, defaults to now)")
+	cmd.Flags().String("from-time", "7d", "Start time (RFC3339, YYYY-MM-DD or relative like '7d', '24h')")
+	cmd.Flags().String("to-time", "", "End time (RFC3339, YYYY-MM-DD for the whole day, or relative; defaults to now)")
+	addTimeRangeFlags(cmd)
 
 	// Filtering flags
 	cmd.Flags().String("group-by", "day", "Group results by: hour, day, week, month")
 	cmd.Flags().String("sender-domain", "", "Filter by sender domain")
 	cmd.Flags().StringSlice("recipient-domain", []string{}, "Filter by recipient domains (can be used multiple times)")
 	cmd.Flags().String("tags", "", "Filter by message tags (comma-separated)")
+	cmd.Flags().Bool("by-tag", false, "Report each tag in --tags separately")
 
 	// Display flags
-	cmd.Flags().Bool("chart", false, "Show ASCII chart visualization")
+	cmd.Flags().Bool("chart", false, "Add a delivered sparkline column and a delivery rate bar chart (table and plain output)")
 	cmd.Flags().Bool("raw", false, "Show raw data without interpretation (useful for CSV/JSON)")
 	cmd.Flags().Bool("show-totals", true, "Show summary totals")
+	cmd.Flags().Bool("stream", false, "Print buckets as they are fetched instead of after the whole range (JSON output becomes one bucket per line)")
+	cmd.Flags().Bool("summary-only", false, "Print only totals and volume-weighted rates for the whole range")
+
+	// Comparison flags
+	cmd.Flags().String("compare-with", "", "Compare with another period: previous")
+	cmd.Flags().Bool("compare-previous", false, "Compare with the period of the same length just before the range (same as --compare-with previous)")
+	cmd.Flags().String("compare-from", "", "Start of the comparison period (RFC3339 or relative)")
+	cmd.Flags().String("compare-to", "", "End of the comparison period (RFC3339 or relative)")
+	cmd.Flags().Bool("allow-unequal", false, "Allow comparing periods of different lengths")
 
 	return cmd
 }
@@ -87,44 +164,51 @@ func runDeliverabilityStats(cmd *cobra.Command, args []string) error {
 	}
 
 	// Get flag values
-	fromTimeStr, _ := cmd.Flags().GetString("from-time")
-	toTimeStr, _ := cmd.Flags().GetString("to-time")
 	groupBy, _ := cmd.Flags().GetString("group-by")
 	senderDomain, _ := cmd.Flags().GetString("sender-domain")
 	recipientDomains, _ := cmd.Flags().GetStringSlice("recipient-domain")
 	tags, _ := cmd.Flags().GetString("tags")
 	showChart, _ := cmd.Flags().GetBool("chart")
+	compareWith, _ := cmd.Flags().GetString("compare-with")
+	compareFrom, _ := cmd.Flags().GetString("compare-from")
+	compareTo, _ := cmd.Flags().GetString("compare-to")
+	if comparePreviousFlag, _ := cmd.Flags().GetBool("compare-previous"); comparePreviousFlag {
+		if compareWith != "" || compareFrom != "" || compareTo != "" {
+			return errors.NewValidationError("--compare-previous cannot be combined with --compare-with or --compare-from/--compare-to", nil)
+		}
+		compareWith = comparePrevious
+	}
+	allowUnequal, _ := cmd.Flags().GetBool("allow-unequal")
+	stream, _ := cmd.Flags().GetBool("stream")
+	summaryOnly, _ := cmd.Flags().GetBool("summary-only")
+	byTag, _ := cmd.Flags().GetBool("by-tag")
 
-	// Parse time parameters
-	var fromTime *time.Time
-	if fromTimeStr != "" {
-		parsedTime, err := output.ParseTimePast(fromTimeStr)
-		if err == nil {
-			fromTime = &parsedTime
+	if stream && summaryOnly {
+		return errors.NewValidationError("--stream and --summary-only cannot be used together", nil)
+	}
+	if showChart && (stream || summaryOnly) {
+		return errors.NewValidationError("--chart needs the full set of buckets and cannot be combined with --stream or --summary-only", nil)
+	}
+	var byTagList []string
+	if byTag {
+		if stream || showChart {
+			return errors.NewValidationError("--by-tag cannot be combined with --stream or --chart", nil)
 		}
-		if err != nil {
-			return errors.NewValidationError(fmt.Sprintf("invalid from-time: %v", err), nil)
+		if byTagList = parseTagList(tags); len(byTagList) == 0 {
+			return errors.NewValidationError("--by-tag needs the tags to report: pass them with --tags, e.g. --tags welcome,digest,promo", nil)
 		}
-	} else {
-		f := time.Now().Add(-30 * 24 * time.Hour)
-		fromTime = &f
 	}
 
-	var toTime *time.Time
-	if toTimeStr != "" {
-		parsed, err := output.ParseTimePast(toTimeStr)
-		if err != nil {
-			return errors.NewValidationError(fmt.Sprintf("invalid to-time: %v", err), nil)
-		}
-		toTime = &parsed
-	} else {
-		n := time.Now()
-		toTime = &n
+	// Parse time parameters
+	from, to, loc, err := timeRangeFromFlags(cmd)
+	if err != nil {
+		return err
 	}
+	fromTime, toTime := &from, &to
 
 	// Validate group-by parameter
 	validGroupBy := []string{"hour", "day", "week", "month"}
-	if !contains(validGroupBy, groupBy) {
+	if !slices.Contains(validGroupBy, groupBy) {
 		return handler.HandleError(errors.NewValidationError(fmt.Sprintf("invalid group-by '%s', must be one of: %s",
 			groupBy, strings.Join(validGroupBy, ", ")), nil))
 	}
@@ -155,16 +239,106 @@ func runDeliverabilityStats(cmd *cobra.Command, args []string) error {
 		params.Tags = &tags
 	}
 
-	// Fetch statistics
-	response, err := client.GetDeliverabilityStatistics(params)
-	if err != nil {
-		return errors.NewAPIError("failed to get deliverability statistics", err)
+	compare := compareWith != "" || compareFrom != "" || compareTo != ""
+	var previousPeriod printer.ComparisonPeriod
+	currentPeriod := printer.ComparisonPeriod{From: *fromTime, To: *toTime}
+	if compare {
+		previousPeriod, err = resolveComparisonPeriod(currentPeriod, compareWith, compareFrom, compareTo, loc, allowUnequal)
+		if err != nil {
+			return err
+		}
 	}
 
-	// Use the new ResponseHandler to display deliverability statistics
-	return handler.HandleDeliverabilityStats(response, printer.StatsConfig{
+	if compare {
+		if byTag {
+			return errors.NewValidationError("--by-tag cannot be combined with a comparison", nil)
+		}
+		if stream || summaryOnly {
+			return errors.NewValidationError("--stream and --summary-only cannot be combined with a comparison", nil)
+		}
+
+		response, err := client.GetDeliverabilityStatistics(params)
+		if err != nil {
+			return errors.NewAPIError("failed to get deliverability statistics", err)
+		}
+
+		previousParams := params
+		previousParams.FromTime = &previousPeriod.From
+		previousParams.ToTime = &previousPeriod.To
+
+		logger.Get().WithFields(map[string]interface{}{
+			"compare_from": previousPeriod.From,
+			"compare_to":   previousPeriod.To,
+		}).Debug("Fetching comparison deliverability statistics")
+
+		previous, err := client.GetDeliverabilityStatistics(previousParams)
+		if err != nil {
+			return errors.NewAPIError("failed to get comparison deliverability statistics", err)
+		}
+
+		return handler.HandleDeliverabilityComparison(
+			compareDeliverability(response, previous, currentPeriod, previousPeriod),
+			printer.StatsConfig{Title: "Deliverability Comparison"},
+		)
+	}
+
+	// Long ranges are fetched in chunks; see statsChunkSpan
+	windows := splitStatsRange(*fromTime, *toTime, groupBy)
+
+	if byTag {
+		report := fetchDeliverabilityByTag(client, params, windows, byTagList, *fromTime, *toTime, summaryOnly)
+		title := "Deliverability by Tag"
+		if summaryOnly {
+			title = "Deliverability Summary by Tag"
+		}
+		if err := handler.HandleDeliverabilityByTag(report, printer.StatsConfig{Title: title}); err != nil {
+			return err
+		}
+		return checkTagAvailability(report)
+	}
+
+	progress := newChunkProgress(cmd.ErrOrStderr(), len(windows))
+	config := printer.StatsConfig{
 		Title:      "Deliverability Statistics",
 		ShowChart:  showChart,
 		FieldOrder: []string{"time_bucket", "sent", "delivered", "bounced", "rejected", "delivery_rate"},
+	}
+
+	switch {
+	case summaryOnly:
+		var accumulator fetch.DeliverabilityAccumulator
+		err = fetchDeliverabilityWindows(client, params, windows, progress, func(_ int, chunk *responses.DeliverabilityStatisticsResponse) error {
+			accumulator.Add(chunk.Data)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return handler.HandleDeliverabilitySummary(accumulator.Result(*fromTime, *toTime), printer.StatsConfig{
+			Title: "Deliverability Summary",
+		})
+
+	case stream:
+		// Buckets are printed as they arrive, so they are not held back for the pager
+		if err := pager.StopBuffering(cmd); err != nil {
+			return err
+		}
+		return fetchDeliverabilityWindows(client, params, windows, progress, func(i int, chunk *responses.DeliverabilityStatisticsResponse) error {
+			chunkConfig := config
+			chunkConfig.Continuation = i > 0
+			return handler.HandleDeliverabilityStatsChunk(chunk, chunkConfig)
+		})
+	}
+
+	response := &responses.DeliverabilityStatisticsResponse{Object: "list"}
+	err = fetchDeliverabilityWindows(client, params, windows, progress, func(_ int, chunk *responses.DeliverabilityStatisticsResponse) error {
+		response.Data = append(response.Data, chunk.Data...)
+		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	// Use the new ResponseHandler to display deliverability statistics
+	return handler.HandleDeliverabilityStats(response, config)
 }
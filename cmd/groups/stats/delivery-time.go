@@ -2,13 +2,12 @@ package stats
 
 import (
 	"fmt"
+	"slices"
 	"strings"
-	"time"
 
 	"github.com/AhaSend/ahasend-cli/internal/auth"
 	"github.com/AhaSend/ahasend-cli/internal/errors"
 	"github.com/AhaSend/ahasend-cli/internal/logger"
-	"github.com/AhaSend/ahasend-cli/internal/output"
 	"github.com/AhaSend/ahasend-cli/internal/printer"
 	"github.com/AhaSend/ahasend-go/models/requests"
 	"github.com/spf13/cobra"
@@ -57,8 +56,9 @@ and improve overall email delivery performance.`,
 	}
 
 	// Time range flags
-	cmd.Flags().String("from-time", "7d", "Start time (RFC3339 format or relative like '7d', '24h')")
-	cmd.Flags().String("to-time", "", "End time (RFC3339 format or relative, defaults to now)")
+	cmd.Flags().String("from-time", "7d", "Start time (RFC3339, YYYY-MM-DD or relative like '7d', '24h')")
+	cmd.Flags().String("to-time", "", "End time (RFC3339, YYYY-MM-DD for the whole day, or relative; defaults to now)")
+	addTimeRangeFlags(cmd)
 
 	// Filtering flags
 	cmd.Flags().String("group-by", "day", "Group results by: hour, day, week, month")
@@ -83,32 +83,21 @@ func runDeliveryTimeStats(cmd *cobra.Command, args []string) error {
 	}
 
 	// Get flag values
-	fromTimeStr, _ := cmd.Flags().GetString("from-time")
-	toTimeStr, _ := cmd.Flags().GetString("to-time")
 	groupBy, _ := cmd.Flags().GetString("group-by")
 	senderDomain, _ := cmd.Flags().GetString("sender-domain")
 	recipientDomains, _ := cmd.Flags().GetStringSlice("recipient-domain")
 	tags, _ := cmd.Flags().GetString("tags")
 
 	// Parse time parameters
-	fromTimeValue, err := output.ParseTimePast(fromTimeStr)
+	from, to, _, err := timeRangeFromFlags(cmd)
 	if err != nil {
-		return errors.NewValidationError(fmt.Sprintf("invalid from-time: %v", err), nil)
-	}
-	fromTime := &fromTimeValue
-
-	var toTime *time.Time
-	if toTimeStr != "" {
-		parsed, err := output.ParseTimePast(toTimeStr)
-		if err != nil {
-			return errors.NewValidationError(fmt.Sprintf("invalid to-time: %v", err), nil)
-		}
-		toTime = &parsed
+		return err
 	}
+	fromTime, toTime := &from, &to
 
 	// Validate group-by parameter
 	validGroupBy := []string{"hour", "day", "week", "month"}
-	if !contains(validGroupBy, groupBy) {
+	if !slices.Contains(validGroupBy, groupBy) {
 		return handler.HandleError(errors.NewValidationError(fmt.Sprintf("invalid group-by '%s', must be one of: %s",
 			groupBy, strings.Join(validGroupBy, ", ")), nil))
 	}
@@ -152,14 +141,3 @@ func runDeliveryTimeStats(cmd *cobra.Command, args []string) error {
 		FieldOrder: []string{"time_bucket", "avg_delivery_time", "message_count"},
 	})
 }
-
-// Helper functions shared across stats commands
-
-func contains(slice []string, item string) bool {
-	for _, s := range slice {
-		if s == item {
-			return true
-		}
-	}
-	return false
-}
@@ -12,20 +12,23 @@ func NewCommand() *cobra.Command {
 		Long: `View comprehensive email statistics and reports including deliverability,
 bounce analysis, and delivery time performance metrics.
 
-Statistics can be filtered by time range, domain, and grouped by various periods
-(hour, day, week, month). Data can be exported to CSV format for further analysis.
+Statistics can be filtered by time range (--from-time/--to-time, or --since
+and --until with durations like '24h' and '7d'), domain, and grouped by
+various periods (hour, day, week, month). Data can be exported to CSV format for further analysis.
 
 Common workflow:
   1. View deliverability stats: ahasend stats deliverability
   2. Check bounce statistics: ahasend stats bounces
   3. Monitor delivery times: ahasend stats delivery-time
-  4. Export to CSV: ahasend stats deliverability --output csv > stats.csv`,
+  4. Export to CSV: ahasend stats deliverability --output csv > stats.csv
+  5. Watch for spikes: ahasend stats anomalies --metric bounce_rate --fail-on-anomaly`,
 	}
 
 	// Add subcommands
 	cmd.AddCommand(NewDeliverabilityCommand())
 	cmd.AddCommand(NewBouncesCommand())
 	cmd.AddCommand(NewDeliveryTimeCommand())
+	cmd.AddCommand(NewAnomaliesCommand())
 
 	return cmd
 }
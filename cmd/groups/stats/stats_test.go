@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"slices"
 	"testing"
 	"time"
 
@@ -52,14 +53,15 @@ func TestStatsCommand_Help(t *testing.T) {
 	assert.Contains(t, helpOutput, "deliverability")
 	assert.Contains(t, helpOutput, "bounces")
 	assert.Contains(t, helpOutput, "delivery-time")
+	assert.Contains(t, helpOutput, "anomalies")
 }
 
 func TestStatsCommand_SubcommandCount(t *testing.T) {
 	cmd := NewCommand()
 	subcommands := cmd.Commands()
 
-	// Should have exactly 3 subcommands
-	assert.Equal(t, 3, len(subcommands), "stats command should have exactly 3 subcommands")
+	// Should have exactly 4 subcommands
+	assert.Equal(t, 4, len(subcommands), "stats command should have exactly 4 subcommands")
 }
 
 // Test deliverability command structure and flags
@@ -124,7 +126,7 @@ func TestBouncesCommand_Flags(t *testing.T) {
 	expectedFlags := []string{
 		"from-time", "to-time", "group-by", "sender-domain",
 		"recipient-domain", "tags", "classification", "trends", "raw",
-		"show-domains", "show-totals",
+		"show-domains", "show-totals", "explain", "aggregate",
 	}
 
 	for _, flagName := range expectedFlags {
@@ -315,7 +317,7 @@ func TestDeliverabilityCommand_GroupByValidation(t *testing.T) {
 		t.Run(fmt.Sprintf("group-by_%s", tt.groupBy), func(t *testing.T) {
 			// Test the validation logic directly
 			validGroupBy := []string{"hour", "day", "week", "month"}
-			isValid := contains(validGroupBy, tt.groupBy)
+			isValid := slices.Contains(validGroupBy, tt.groupBy)
 
 			if tt.shouldError {
 				assert.False(t, isValid)
@@ -384,16 +386,6 @@ func TestDeliveryTimeStatistics_ResponseStructure(t *testing.T) {
 // The helper functions (calculateDeliverabilityTotals, formatTimeBucket, etc.)
 // were moved to the ResponseHandler implementation and are no longer public
 
-// Test utility functions
-func TestContains(t *testing.T) {
-	slice := []string{"apple", "banana", "orange"}
-
-	assert.True(t, contains(slice, "apple"))
-	assert.True(t, contains(slice, "banana"))
-	assert.False(t, contains(slice, "grape"))
-	assert.False(t, contains(slice, ""))
-}
-
 // Test error scenarios
 func TestStats_APIErrors(t *testing.T) {
 	tests := []struct {
@@ -450,6 +442,93 @@ func TestStats_InvalidTimeRange(t *testing.T) {
 	assert.True(t, oneHourLater.After(now))
 }
 
+func TestTimeRangeFromFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantFrom time.Time
+		wantTo   time.Time
+		wantErr  string
+	}{
+		{
+			name:     "on replaces the from-time default",
+			args:     []string{"--on", "2024-06-01", "--timezone", "UTC"},
+			wantFrom: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			wantTo:   time.Date(2024, 6, 1, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "date-only to-time is end of day",
+			args:     []string{"--from-time", "2024-06-01", "--to-time", "2024-06-07", "--timezone", "UTC"},
+			wantFrom: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			wantTo:   time.Date(2024, 6, 7, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:    "explicit from-time with on",
+			args:    []string{"--from-time", "7d", "--on", "2024-06-01"},
+			wantErr: "cannot be combined",
+		},
+		{
+			name:    "impossible date",
+			args:    []string{"--on", "2024-02-30"},
+			wantErr: "not a real calendar date",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewDeliverabilityCommand()
+			require.NoError(t, cmd.ParseFlags(tt.args))
+
+			from, to, _, err := timeRangeFromFlags(cmd)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.wantFrom.Equal(from), "from: want %s, got %s", tt.wantFrom, from)
+			assert.True(t, tt.wantTo.Equal(to), "to: want %s, got %s", tt.wantTo, to)
+		})
+	}
+}
+
+func TestTimeRangeFromFlags_SinceUntil(t *testing.T) {
+	cmd := NewDeliverabilityCommand()
+	require.NoError(t, cmd.ParseFlags([]string{"--since", "90m"}))
+	from, to, _, err := timeRangeFromFlags(cmd)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(-90*time.Minute), from, time.Minute, "--since replaces the --from-time default")
+	assert.WithinDuration(t, time.Now(), to, time.Minute)
+
+	cmd = NewDeliverabilityCommand()
+	require.NoError(t, cmd.ParseFlags([]string{"--since", "2026-01-01T00:00:00Z", "--until", "2026-01-08T00:00:00Z"}))
+	from, to, _, err = timeRangeFromFlags(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), from)
+	assert.Equal(t, time.Date(2026, 1, 8, 0, 0, 0, 0, time.UTC), to)
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"since with from-time", []string{"--since", "24h", "--from-time", "7d"}, "--since cannot be combined with --from-time or --on"},
+		{"since with on", []string{"--since", "24h", "--on", "2024-06-01"}, "--since cannot be combined"},
+		{"until with to-time", []string{"--until", "1h", "--to-time", "2h"}, "--until cannot be combined with --to-time or --on"},
+		{"since after until", []string{"--since", "1d", "--until", "2d"}, "--since ("},
+		{"ambiguous", []string{"--until", "yesterday"}, `invalid --until: invalid time "yesterday"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewDeliverabilityCommand()
+			require.NoError(t, cmd.ParseFlags(tt.args))
+			_, _, _, err := timeRangeFromFlags(cmd)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
 // Mock client integration tests
 func TestDeliverabilityStats_MockIntegration(t *testing.T) {
 	// This test demonstrates how the mock client would be used
@@ -0,0 +1,127 @@
+package stats
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+)
+
+// statsWindow is one slice of the requested range, fetched in a single request
+type statsWindow struct {
+	From time.Time
+	To   time.Time
+}
+
+// statsChunkSpan is how much of the range one request covers for a grouping.
+// The statistics API returns a range in one unpaginated response, so long
+// hourly ranges are split to keep each response small and report progress.
+// Coarser groupings produce few buckets and are never split.
+func statsChunkSpan(groupBy string) time.Duration {
+	switch groupBy {
+	case "hour":
+		return 7 * 24 * time.Hour
+	case "day":
+		return 180 * 24 * time.Hour
+	}
+	return 0
+}
+
+// statsBucketSize is the length of one bucket, used to keep chunk boundaries
+// on bucket boundaries so no bucket is split across two requests
+func statsBucketSize(groupBy string) time.Duration {
+	if groupBy == "hour" {
+		return time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// splitStatsRange splits [from, to) into consecutive windows of at most the
+// grouping's chunk span. Inner boundaries are aligned to whole buckets.
+func splitStatsRange(from, to time.Time, groupBy string) []statsWindow {
+	span := statsChunkSpan(groupBy)
+	if span == 0 || to.Sub(from) <= span {
+		return []statsWindow{{From: from, To: to}}
+	}
+
+	var windows []statsWindow
+	start := from
+	boundary := from.Truncate(statsBucketSize(groupBy)).Add(span)
+	for boundary.Before(to) {
+		windows = append(windows, statsWindow{From: start, To: boundary})
+		start = boundary
+		boundary = boundary.Add(span)
+	}
+	return append(windows, statsWindow{From: start, To: to})
+}
+
+// fetchDeliverabilityWindows requests each window in order and passes every
+// response to onChunk as soon as it arrives
+func fetchDeliverabilityWindows(apiClient client.AhaSendClient, params requests.GetDeliverabilityStatisticsParams, windows []statsWindow, progress *chunkProgress, onChunk func(index int, response *responses.DeliverabilityStatisticsResponse) error) error {
+	for i, window := range windows {
+		windowParams := params
+		windowParams.FromTime = &window.From
+		windowParams.ToTime = &window.To
+
+		logger.Get().WithFields(map[string]interface{}{
+			"chunk":     i + 1,
+			"chunks":    len(windows),
+			"from_time": window.From,
+			"to_time":   window.To,
+		}).Debug("Fetching deliverability statistics chunk")
+
+		response, err := apiClient.GetDeliverabilityStatistics(windowParams)
+		if err != nil {
+			progress.clear()
+			return errors.NewAPIError("failed to get deliverability statistics", err)
+		}
+		if response == nil {
+			response = &responses.DeliverabilityStatisticsResponse{Object: "list"}
+		}
+
+		progress.clear()
+		if err := onChunk(i, response); err != nil {
+			return err
+		}
+		progress.update(i + 1)
+	}
+	progress.clear()
+	return nil
+}
+
+// chunkProgress shows "Fetching statistics: 3/13 chunks" on stderr while a
+// multi-request range loads. It stays silent for single requests and when
+// stderr is not a terminal, so redirected output is unaffected.
+type chunkProgress struct {
+	out     io.Writer
+	total   int
+	enabled bool
+}
+
+// stderrIsTerminal is replaced in tests
+var stderrIsTerminal = func(out io.Writer) bool { return printer.IsTerminal(out) }
+
+func newChunkProgress(out io.Writer, total int) *chunkProgress {
+	progress := &chunkProgress{out: out, total: total, enabled: total > 1 && stderrIsTerminal(out)}
+	progress.update(0)
+	return progress
+}
+
+func (p *chunkProgress) update(done int) {
+	if p.enabled && done < p.total {
+		fmt.Fprintf(p.out, "\rFetching statistics: %d/%d chunks", done, p.total)
+	}
+}
+
+// clear erases the progress line so output written next starts on a clean line
+func (p *chunkProgress) clear() {
+	if p.enabled {
+		fmt.Fprint(p.out, "\r\033[K")
+	}
+}
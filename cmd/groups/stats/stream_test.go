@@ -0,0 +1,155 @@
+package stats
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitStatsRange(t *testing.T) {
+	from := time.Date(2026, 1, 1, 10, 30, 0, 0, time.UTC)
+
+	t.Run("hourly range is chunked on hour boundaries", func(t *testing.T) {
+		to := from.Add(90 * 24 * time.Hour)
+		windows := splitStatsRange(from, to, "hour")
+		require.Len(t, windows, 13)
+
+		assert.Equal(t, from, windows[0].From)
+		assert.Equal(t, time.Date(2026, 1, 8, 10, 0, 0, 0, time.UTC), windows[0].To)
+		assert.Equal(t, to, windows[len(windows)-1].To)
+		for i := 1; i < len(windows); i++ {
+			assert.Equal(t, windows[i-1].To, windows[i].From, "windows must be contiguous")
+			assert.Zero(t, windows[i].From.Minute())
+		}
+	})
+
+	t.Run("short range is one request", func(t *testing.T) {
+		windows := splitStatsRange(from, from.Add(7*24*time.Hour), "hour")
+		assert.Equal(t, []statsWindow{{From: from, To: from.Add(7 * 24 * time.Hour)}}, windows)
+	})
+
+	t.Run("coarse grouping is never split", func(t *testing.T) {
+		assert.Len(t, splitStatsRange(from, from.AddDate(2, 0, 0), "month"), 1)
+		assert.Len(t, splitStatsRange(from, from.AddDate(1, 0, 0), "day"), 3)
+	})
+}
+
+func runLongRangeDeliverability(t *testing.T, format string, args ...string) (string, error) {
+	t.Helper()
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetDeliverabilityStatistics", mock.MatchedBy(func(p requests.GetDeliverabilityStatisticsParams) bool {
+		return p.FromTime.Equal(from)
+	})).Return(deliverabilityResponse(
+		responses.DeliverabilityStatistics{FromTimestamp: from, ToTimestamp: from.Add(time.Hour), ReceptionCount: 100, DeliveredCount: 90},
+	), nil).Once()
+	mockClient.On("GetDeliverabilityStatistics", mock.MatchedBy(func(p requests.GetDeliverabilityStatisticsParams) bool {
+		return p.FromTime.Equal(from.Add(7 * 24 * time.Hour))
+	})).Return(deliverabilityResponse(
+		responses.DeliverabilityStatistics{FromTimestamp: from.Add(200 * time.Hour), ToTimestamp: from.Add(201 * time.Hour), ReceptionCount: 300, DeliveredCount: 297},
+	), nil).Once()
+
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var buf bytes.Buffer
+	handler := printer.GetResponseHandler(format, false, &buf)
+	cmd := NewDeliverabilityCommand()
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs(append([]string{
+		"--from-time", "2026-01-01T00:00:00Z",
+		"--to-time", "2026-01-11T00:00:00Z",
+		"--group-by", "hour",
+	}, args...))
+
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+func TestDeliverabilityCommand_Stream(t *testing.T) {
+	t.Run("json lines", func(t *testing.T) {
+		out, err := runLongRangeDeliverability(t, "json", "--stream")
+		require.NoError(t, err)
+
+		lines := strings.Split(strings.TrimSpace(out), "\n")
+		require.Len(t, lines, 2)
+		var bucket responses.DeliverabilityStatistics
+		require.NoError(t, json.Unmarshal([]byte(lines[1]), &bucket))
+		assert.Equal(t, 300, bucket.ReceptionCount)
+	})
+
+	t.Run("csv writes one header", func(t *testing.T) {
+		out, err := runLongRangeDeliverability(t, "csv", "--stream")
+		require.NoError(t, err)
+		assert.Equal(t, 3, strings.Count(out, "\n"))
+	})
+
+	t.Run("table appends rows", func(t *testing.T) {
+		out, err := runLongRangeDeliverability(t, "table", "--stream")
+		require.NoError(t, err)
+		assert.Equal(t, 1, strings.Count(out, "TIME BUCKET"))
+		assert.Contains(t, out, "90.00%")
+		assert.Contains(t, out, "99.00%")
+	})
+}
+
+func TestDeliverabilityCommand_SummaryOnly(t *testing.T) {
+	out, err := runLongRangeDeliverability(t, "json", "--summary-only")
+	require.NoError(t, err)
+
+	var summary struct {
+		Object       string  `json:"object"`
+		Buckets      int     `json:"buckets"`
+		Reception    int     `json:"reception_count"`
+		DeliveryRate float64 `json:"delivery_rate"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &summary))
+	assert.Equal(t, "deliverability_summary", summary.Object)
+	assert.Equal(t, 2, summary.Buckets)
+	assert.Equal(t, 400, summary.Reception)
+	assert.InDelta(t, 96.75, summary.DeliveryRate, 0.001)
+}
+
+func TestDeliverabilityCommand_StreamConflicts(t *testing.T) {
+	tests := [][]string{
+		{"--stream", "--summary-only"},
+		{"--stream", "--chart"},
+		{"--summary-only", "--compare-with", "previous"},
+	}
+	for _, args := range tests {
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			cmd := NewDeliverabilityCommand()
+			var buf bytes.Buffer
+			cmd.SetOut(&buf)
+			cmd.SetErr(&buf)
+			cmd.SetArgs(args)
+			restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+				return &mocks.MockClient{}, nil
+			})
+			defer restore()
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "cannot be")
+		})
+	}
+}
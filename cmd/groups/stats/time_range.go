@@ -0,0 +1,59 @@
+package stats
+
+import (
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// defaultStatsRange is the range used when --from-time is empty
+const defaultStatsRange = 30 * 24 * time.Hour
+
+// addTimeRangeFlags registers --on, --timezone, --since and --until
+// alongside a command's --from-time and --to-time flags
+func addTimeRangeFlags(cmd *cobra.Command) {
+	cmd.Flags().String("on", "", "Report a single day (YYYY-MM-DD); replaces --from-time and --to-time")
+	cmd.Flags().String("timezone", "", "Timezone for date-only values, e.g. 'Europe/Berlin' or 'UTC' (default: local)")
+	cmd.Flags().String("since", "", "Start time as a duration ago like '90m', '24h' or '7d', or RFC3339; replaces --from-time")
+	cmd.Flags().String("until", "", "End time as a duration ago like '1d', or RFC3339; replaces --to-time")
+}
+
+// timeRangeFromFlags parses the --from-time, --to-time, --on, --timezone,
+// --since and --until flags of the stats commands. The --from-time default
+// is ignored when --on or --since is given. The returned location is the one
+// date-only values were read in.
+func timeRangeFromFlags(cmd *cobra.Command) (from, to time.Time, loc *time.Location, err error) {
+	fromTimeStr, _ := cmd.Flags().GetString("from-time")
+	toTimeStr, _ := cmd.Flags().GetString("to-time")
+	on, _ := cmd.Flags().GetString("on")
+	timezone, _ := cmd.Flags().GetString("timezone")
+	since, _ := cmd.Flags().GetString("since")
+	until, _ := cmd.Flags().GetString("until")
+
+	// The --from-time default only applies without --on and --since
+	if (on != "" || since != "") && !cmd.Flags().Changed("from-time") {
+		fromTimeStr = ""
+	}
+	loc, err = output.LoadTimezone(timezone)
+	if err != nil {
+		return time.Time{}, time.Time{}, nil, err
+	}
+	now := time.Now()
+	fromTime, toTime, err := output.TimeRangeFlags{
+		From: fromTimeStr, To: toTimeStr, On: on, Timezone: timezone, Since: since, Until: until,
+	}.Parse(now)
+	if err != nil {
+		return time.Time{}, time.Time{}, nil, err
+	}
+
+	// An empty start means 30 days ago and an empty end means now
+	from, to = now.Add(-defaultStatsRange), now
+	if fromTime != nil {
+		from = *fromTime
+	}
+	if toTime != nil {
+		to = *toTime
+	}
+	return from, to, loc, nil
+}
@@ -34,6 +34,7 @@ The secret value of API keys is never displayed for security reasons.`,
 
 	cmd.Flags().Int32("limit", 0, "Maximum number of API keys to return")
 	cmd.Flags().String("cursor", "", "Pagination cursor for next page")
+	printer.AddIDsOnlyFlag(cmd)
 
 	return cmd
 }
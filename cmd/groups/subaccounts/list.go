@@ -31,6 +31,7 @@ The list can be paginated for large numbers of sub-accounts.`,
 
 	cmd.Flags().Int32("limit", 0, "Maximum number of sub-accounts to return")
 	cmd.Flags().String("cursor", "", "Pagination cursor for next page")
+	printer.AddIDsOnlyFlag(cmd)
 
 	return cmd
 }
@@ -0,0 +1,158 @@
+package suppressions
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/spf13/cobra"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/bulk"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/glob"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/progress"
+)
+
+// bulkDeletePreviewSize is the number of matches shown before confirming
+const bulkDeletePreviewSize = 10
+
+// bulkOnlyFlags only apply together with --match
+var bulkOnlyFlags = []string{"reason", "yes", "concurrency"}
+
+// deleteArgs requires an email address unless --match selects the suppressions
+func deleteArgs(cmd *cobra.Command, args []string) error {
+	if cmd.Flags().Changed("match") {
+		if len(args) > 0 {
+			return errors.NewValidationError("an email address cannot be combined with --match", nil)
+		}
+		return nil
+	}
+	for _, flag := range bulkOnlyFlags {
+		if cmd.Flags().Changed(flag) {
+			return errors.NewValidationError(fmt.Sprintf("--%s requires --match", flag), nil)
+		}
+	}
+	return cobra.ExactArgs(1)(cmd, args)
+}
+
+func runSuppressionsBulkDelete(cmd *cobra.Command) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+
+	match, _ := cmd.Flags().GetString("match")
+	reason, _ := cmd.Flags().GetString("reason")
+	domain, _ := cmd.Flags().GetString("domain")
+	yes, _ := cmd.Flags().GetBool("yes")
+	force, _ := cmd.Flags().GetBool("force")
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+
+	if concurrency < 1 {
+		return errors.NewValidationError("--concurrency must be at least 1", nil)
+	}
+	pattern, err := glob.Compile(match, false)
+	if err != nil {
+		return err
+	}
+
+	apiClient, err := auth.GetAuthenticatedClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	logger.Get().WithFields(map[string]interface{}{
+		"match":       match,
+		"reason":      reason,
+		"domain":      domain,
+		"concurrency": concurrency,
+	}).Debug("Executing suppressions bulk delete command")
+
+	scope := formatWipeScope(domain, match) + formatReasonScope(reason)
+	return bulk.Run(handler, cmd.InOrStdin(), cmd.ErrOrStderr(), bulk.Selection[responses.Suppression]{
+		Pattern:      match,
+		Singular:     "suppression",
+		Plural:       "suppressions",
+		EmptyMessage: fmt.Sprintf("No suppressions found%s", scope),
+		Yes:          yes || force,
+		Concurrency:  concurrency,
+		// Deleting only after the whole list was read keeps the pagination
+		// cursor valid
+		List: func() ([]responses.Suppression, error) {
+			selected, err := selectSuppressions(apiClient, domain, pattern)
+			if err != nil {
+				return nil, err
+			}
+			return filterSuppressionsByReason(selected, reason), nil
+		},
+		Target: func(suppression responses.Suppression) bulk.Target {
+			return bulk.Target{ID: suppression.ID.String(), Name: suppression.Email}
+		},
+		Preview: func(out io.Writer, matched []responses.Suppression) error {
+			return previewSuppressions(out, matched, scope)
+		},
+		Delete: func(suppression responses.Suppression) error {
+			return deleteSuppression(apiClient, suppression)
+		},
+		Progress: func(total int) *progress.Reporter {
+			return newProgressReporter(cmd, total)
+		},
+	})
+}
+
+// filterSuppressionsByReason returns the suppressions with the given reason,
+// compared case-insensitively. An empty reason keeps every suppression.
+func filterSuppressionsByReason(suppressions []responses.Suppression, reason string) []responses.Suppression {
+	if reason == "" {
+		return suppressions
+	}
+	var matched []responses.Suppression
+	for _, suppression := range suppressions {
+		if strings.EqualFold(strings.TrimSpace(suppression.Reason), strings.TrimSpace(reason)) {
+			matched = append(matched, suppression)
+		}
+	}
+	return matched
+}
+
+// formatReasonScope describes the reason filter for messages
+func formatReasonScope(reason string) string {
+	if reason == "" {
+		return ""
+	}
+	return fmt.Sprintf(" with reason '%s'", reason)
+}
+
+// previewSuppressions shows the match count and the first matches
+func previewSuppressions(out io.Writer, matched []responses.Suppression, scope string) error {
+	sample := matched
+	if len(sample) > bulkDeletePreviewSize {
+		sample = sample[:bulkDeletePreviewSize]
+	}
+
+	preview := printer.GetResponseHandler("table", false, out)
+	if err := preview.HandleSuppressionList(&responses.PaginatedSuppressionsResponse{Data: sample}, printer.ListConfig{
+		FieldOrder: []string{"email", "domain", "reason", "created_at", "expires_at"},
+	}); err != nil {
+		return err
+	}
+	if more := len(matched) - len(sample); more > 0 {
+		fmt.Fprintf(out, "... and %d more\n", more)
+	}
+	fmt.Fprintf(out, "%d suppressions found%s\n", len(matched), scope)
+	return nil
+}
+
+// deleteSuppression deletes a suppression for its domain, or for all
+// domains when it has none
+func deleteSuppression(apiClient client.AhaSendClient, suppression responses.Suppression) error {
+	var domainPtr *string
+	if suppression.Domain != "" {
+		domain := suppression.Domain
+		domainPtr = &domain
+	}
+	_, err := apiClient.DeleteSuppression(suppression.Email, domainPtr)
+	return err
+}
@@ -0,0 +1,133 @@
+package suppressions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/AhaSend/ahasend-go/models/common"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+)
+
+func executeDelete(t *testing.T, format, stdin string, setup func(*mocks.MockClient), args ...string) wipeRun {
+	t.Helper()
+
+	mockClient := &mocks.MockClient{}
+	setup(mockClient)
+
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var stdout, stderr bytes.Buffer
+	handler := printer.GetResponseHandler(format, false, &stdout)
+	cmd := NewDeleteCommand()
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	return wipeRun{stdout: stdout.String(), stderr: stderr.String(), err: err, mockClient: mockClient}
+}
+
+func TestDeleteMatch_ReasonFilterAndConfirmation(t *testing.T) {
+	run := executeDelete(t, "json", "2\n", func(m *mocks.MockClient) {
+		setupPagedSuppressions(m)
+		m.On("DeleteSuppression", mock.Anything, mock.Anything).Return(&common.SuccessResponse{}, nil)
+	}, "--match", "*@competitor.com", "--reason", "BOUNCE")
+	require.NoError(t, run.err)
+
+	// carol@COMPETITOR.com is a complaint and stays
+	run.mockClient.AssertNumberOfCalls(t, "DeleteSuppression", 2)
+	run.mockClient.AssertCalled(t, "DeleteSuppression", "alice@competitor.com", mock.Anything)
+	run.mockClient.AssertCalled(t, "DeleteSuppression", "dave@competitor.com", mock.MatchedBy(func(domain *string) bool {
+		return domain != nil && *domain == "other.com"
+	}))
+	assert.Contains(t, run.stderr, "2 suppressions found matching '*@competitor.com' with reason 'BOUNCE'")
+	assert.Contains(t, run.stderr, "Type 2 to confirm")
+	assert.Contains(t, run.stderr, "Processed 2/2 suppressions (0 failed)")
+
+	var result printer.BulkDeleteResult
+	require.NoError(t, json.Unmarshal([]byte(run.stdout), &result))
+	assert.Equal(t, 2, result.Deleted)
+	assert.Equal(t, 0, result.Failed)
+}
+
+func TestDeleteMatch_AnchoredPattern(t *testing.T) {
+	run := executeDelete(t, "table", "", func(m *mocks.MockClient) {
+		m.On("ListSuppressions", mock.Anything).Return(m.NewMockSuppressionsResponse([]responses.Suppression{
+			*m.NewMockSuppression("user@client.com", "bounce", ""),
+			*m.NewMockSuppression("user@client.com.au", "bounce", ""),
+			*m.NewMockSuppression("user@myclient.com", "bounce", ""),
+		}, false), nil).Once()
+		m.On("DeleteSuppression", mock.Anything, mock.Anything).Return(&common.SuccessResponse{}, nil)
+	}, "--match", "*@client.com", "--yes")
+	require.NoError(t, run.err)
+
+	run.mockClient.AssertNumberOfCalls(t, "DeleteSuppression", 1)
+	run.mockClient.AssertCalled(t, "DeleteSuppression", "user@client.com", (*string)(nil))
+	assert.Contains(t, run.stdout, "Deleted 1 of 1")
+}
+
+func TestDeleteMatch_PreviewSample(t *testing.T) {
+	run := executeDelete(t, "table", "0\n", func(m *mocks.MockClient) {
+		var suppressions []responses.Suppression
+		for i := 0; i < 12; i++ {
+			suppressions = append(suppressions, *m.NewMockSuppression(fmt.Sprintf("user%02d@client.com", i), "bounce", ""))
+		}
+		m.On("ListSuppressions", mock.Anything).Return(m.NewMockSuppressionsResponse(suppressions, false), nil).Once()
+	}, "--match", "*@client.com")
+	require.NoError(t, run.err)
+
+	assert.Contains(t, run.stderr, "user09@client.com")
+	assert.NotContains(t, run.stderr, "user10@client.com")
+	assert.Contains(t, run.stderr, "... and 2 more")
+	assert.Contains(t, run.stdout, "Suppression deletion cancelled")
+	run.mockClient.AssertNotCalled(t, "DeleteSuppression", mock.Anything, mock.Anything)
+}
+
+func TestDeleteMatch_PartialFailure(t *testing.T) {
+	run := executeDelete(t, "table", "", func(m *mocks.MockClient) {
+		setupPagedSuppressions(m)
+		m.On("DeleteSuppression", "alice@competitor.com", mock.Anything).Return(nil, assert.AnError)
+		m.On("DeleteSuppression", mock.Anything, mock.Anything).Return(&common.SuccessResponse{}, nil)
+	}, "--match", "*@competitor.com", "--yes", "--concurrency", "2")
+	require.Error(t, run.err)
+	assert.Contains(t, run.err.Error(), "failed to delete 1 of 3 suppressions")
+	assert.Contains(t, run.stderr, "Processed 3/3 suppressions (1 failed)")
+}
+
+func TestDeleteArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"email with match", []string{"user@example.com", "--match", "*@example.com"}, "cannot be combined with --match"},
+		{"reason without match", []string{"user@example.com", "--reason", "bounce"}, "--reason requires --match"},
+		{"no email", []string{}, "accepts 1 arg(s)"},
+		{"bad concurrency", []string{"--match", "*", "--concurrency", "0"}, "--concurrency must be at least 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run := executeDelete(t, "table", "", func(*mocks.MockClient) {}, tt.args...)
+			require.Error(t, run.err)
+			assert.Contains(t, run.err.Error(), tt.want)
+		})
+	}
+}
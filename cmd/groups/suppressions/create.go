@@ -16,7 +16,7 @@ import (
 // NewCreateCommand creates the suppressions create command
 func NewCreateCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "create <email>",
+		Use:   "create <email> | --from-bounces",
 		Short: "Create a new suppression for an email address",
 		Long: `Create a new suppression entry to prevent sending emails to an address.
 
@@ -28,7 +28,23 @@ Without --domain, creates a global suppression for all domains.
 
 The --expires flag is required and can accept:
 - Relative time: 30d, 24h, 1w, 3mo, 1y
-- Absolute time: 2024-12-31T23:59:59Z`,
+- Absolute time: 2024-12-31T23:59:59Z
+
+SUPPRESSING RECENT BOUNCES:
+--from-bounces suppresses the recipients of messages that bounced since
+--from-time (default: the last 24 hours) instead of a single address. By
+default only hard bounces are selected (BadDomain, InactiveMailbox,
+InvalidRecipient); --classifications picks others, see 'ahasend bounces
+explain'. Each address is suppressed once, and addresses that are already
+suppressed are skipped and counted separately. Without --reason, each
+suppression records the classification of its bounce.
+
+Bounced messages are read a page at a time and only the selected addresses
+are kept. The address count and a sample are shown on stderr before asking
+you to confirm by typing the count; use --yes to skip the prompt.
+
+--dry-run prints the addresses that would be suppressed to stdout, one per
+line, or writes them to --addresses-file, without creating anything.`,
 		Example: `  # Create global suppression with reason that expires in 30 days
   ahasend suppressions create user@example.com --reason "User requested unsubscribe" --expires 30d
 
@@ -39,9 +55,19 @@ The --expires flag is required and can accept:
   ahasend suppressions create user@example.com --reason "Holiday pause" --expires 2024-12-31T23:59:59Z
 
   # Create suppression with JSON output
-  ahasend suppressions create user@example.com --reason "Manually added" --expires 90d --output json`,
-		Args: cobra.ExactArgs(1),
-		RunE: runSuppressionsCreate,
+  ahasend suppressions create user@example.com --reason "Manually added" --expires 90d --output json
+
+  # List everyone who hard-bounced in the last 24 hours
+  ahasend suppressions create --from-bounces --expires 1y --dry-run > bouncers.txt
+
+  # Suppress them for a year
+  ahasend suppressions create --from-bounces --from-time -24h --reason "Bad list import" --expires 1y
+
+  # Also suppress full mailboxes, without a confirmation prompt
+  ahasend suppressions create --from-bounces --classifications InvalidRecipient,QuotaIssues --expires 30d --yes`,
+		Args:         createArgs,
+		RunE:         runSuppressionsCreate,
+		SilenceUsage: true,
 	}
 
 	// Add flags
@@ -50,6 +76,17 @@ The --expires flag is required and can accept:
 	cmd.Flags().String("expires", "", "Expiration time (e.g., '30d', '2024-12-31T23:59:59Z') [required]")
 	cmd.MarkFlagRequired("expires")
 
+	// Suppressing recent bounces
+	cmd.Flags().Bool("from-bounces", false, "Suppress the recipients of recently bounced messages instead of one address")
+	cmd.Flags().String("from-time", "24h", "With --from-bounces, messages that bounced after this time (RFC3339 or relative like '24h', '-24h', '7d')")
+	cmd.Flags().String("to-time", "", "With --from-bounces, messages that bounced before this time (RFC3339 or relative)")
+	cmd.Flags().String("on", "", "With --from-bounces, messages that bounced on this day (YYYY-MM-DD); replaces --from-time and --to-time")
+	cmd.Flags().String("timezone", "", "With --from-bounces, timezone for date-only values, e.g. 'UTC' (default: local)")
+	cmd.Flags().StringSlice("classifications", []string{}, "With --from-bounces, bounce classifications to suppress (default: BadDomain,InactiveMailbox,InvalidRecipient)")
+	cmd.Flags().Bool("dry-run", false, "With --from-bounces, list the addresses that would be suppressed without creating anything")
+	cmd.Flags().String("addresses-file", "", "With --dry-run, write the addresses to this file instead of stdout")
+	cmd.Flags().BoolP("yes", "y", false, "With --from-bounces, skip the confirmation prompt")
+
 	return cmd
 }
 
@@ -57,6 +94,10 @@ func runSuppressionsCreate(cmd *cobra.Command, args []string) error {
 	// Get response handler instance
 	handler := printer.GetResponseHandlerFromCommand(cmd)
 
+	if fromBounces, _ := cmd.Flags().GetBool("from-bounces"); fromBounces {
+		return runSuppressionsCreateFromBounces(cmd)
+	}
+
 	email := args[0]
 
 	// Validate email format
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/bulk"
 	"github.com/AhaSend/ahasend-cli/internal/errors"
 	"github.com/AhaSend/ahasend-cli/internal/logger"
 	"github.com/AhaSend/ahasend-cli/internal/printer"
@@ -16,8 +17,8 @@ import (
 // NewDeleteCommand creates the suppressions delete command
 func NewDeleteCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "delete <email>",
-		Short: "Delete an email address from the suppression list",
+		Use:   "delete [email]",
+		Short: "Delete an email address, or all matching addresses, from the suppression list",
 		Long: `Delete an email address from the suppression list to allow sending emails.
 
 This command removes a suppression entry for the specified email address.
@@ -27,7 +28,18 @@ Without --domain, deletes global suppressions.
 ⚠️  WARNING: Deleting suppressions may result in sending emails to addresses
 that previously bounced, complained, or unsubscribed. Use with caution.
 
-Use --force flag for automation and CI/CD pipelines.`,
+Use --force flag for automation and CI/CD pipelines.
+
+DELETE BY PATTERN:
+  --match deletes every suppression whose full email address matches a glob
+  pattern ('*' matches any run of characters, '?' a single character, case
+  insensitive). The pattern must match the whole address: "*@client.com"
+  matches user@client.com but not user@client.com.au. --reason further limits
+  the selection to suppressions with that reason, and --domain to one domain.
+  The number of matches and the first 10 are shown before you confirm by
+  typing the count; --yes skips the confirmation. Deletions run in parallel
+  (--concurrency) with progress on stderr, and a failure does not stop the
+  others.`,
 		Example: `  # Delete global suppression (with confirmation)
   ahasend suppressions delete user@example.com
 
@@ -38,19 +50,31 @@ Use --force flag for automation and CI/CD pipelines.`,
   ahasend suppressions delete user@example.com --force
 
   # Delete with JSON output
-  ahasend suppressions delete user@example.com --output json`,
-		Args: cobra.ExactArgs(1),
-		RunE: runSuppressionsDelete,
+  ahasend suppressions delete user@example.com --output json
+
+  # Delete every bounce suppression for a returning client
+  ahasend suppressions delete --match "*@client.com" --reason bounce`,
+		Args:         deleteArgs,
+		RunE:         runSuppressionsDelete,
+		SilenceUsage: true,
 	}
 
 	// Add flags
 	cmd.Flags().String("domain", "", "Domain for domain-specific suppression removal (optional)")
 	cmd.Flags().Bool("force", false, "Skip confirmation prompt")
+	cmd.Flags().String("match", "", "Delete all suppressions whose email matches this glob pattern (e.g. \"*@client.com\")")
+	cmd.Flags().String("reason", "", "With --match, only delete suppressions with this reason (e.g. bounce)")
+	cmd.Flags().Bool("yes", false, "With --match, skip the typed confirmation")
+	cmd.Flags().Int("concurrency", bulk.DefaultConcurrency, "With --match, number of deletions to run in parallel")
 
 	return cmd
 }
 
 func runSuppressionsDelete(cmd *cobra.Command, args []string) error {
+	if cmd.Flags().Changed("match") {
+		return runSuppressionsBulkDelete(cmd)
+	}
+
 	// Get response handler instance
 	handler := printer.GetResponseHandlerFromCommand(cmd)
 
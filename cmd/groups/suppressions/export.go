@@ -0,0 +1,342 @@
+package suppressions
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	ahasend "github.com/AhaSend/ahasend-go"
+	"github.com/AhaSend/ahasend-go/models/common"
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/spf13/cobra"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/output"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/sink"
+)
+
+// exportPageSize is the number of suppressions fetched per request
+const exportPageSize = 100
+
+// exportColumns are the CSV columns of an export, named like those of
+// 'suppressions list --output csv'. The email, domain, reason and
+// expires_at columns are the ones 'suppressions import' reads.
+var exportColumns = []string{"id", "email", "domain", "reason", "created_at", "expires_at"}
+
+// NewExportCommand creates the suppressions export command
+func NewExportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export all suppressions to a CSV or JSON file",
+		Long: `Export every suppression of the account, or those of one domain or created
+in a time range, to a CSV or JSON file or to stdout.
+
+The suppressions are fetched page by page and each page is written as it
+arrives, so exports of any size run in constant memory. CSV output has the
+columns id, email, domain, reason, created_at and expires_at, with times in
+RFC3339 and an empty expires_at for suppressions that never expire; JSON
+output is an array of objects with the same keys. Both can be read back by
+'suppressions import'. The API does not return an account ID or an update
+time for suppressions, so there are no such columns.
+
+--created-after and --created-before accept RFC3339, a date (midnight local
+time, or the end of the day for --created-before) or a relative time like
+'30d'.
+
+The file is written under a temporary name and moved into place once the
+last page has been written; an s3:// or gs:// URL uploads the export
+instead. On an error or Ctrl-C the partial file is removed. Output written
+to stdout cannot be taken back, so an interrupted export to stdout exits
+with an error saying the output is incomplete.
+
+The number of exported suppressions is reported at the end, on stderr when
+the export itself goes to stdout.`,
+		Example: `  # Export all suppressions to a CSV file
+  ahasend suppressions export --output-file suppressions.csv
+
+  # Export the suppressions of one domain created this year as JSON
+  ahasend suppressions export --domain example.com --created-after 2026-01-01 \
+    --format json --output-file suppressions.json
+
+  # Stream the export into another tool
+  ahasend suppressions export | grep bounce
+
+  # Move suppressions between accounts
+  ahasend suppressions export --output-file suppressions.csv --profile old
+  ahasend suppressions import --file suppressions.csv --profile new`,
+		Args:         cobra.NoArgs,
+		RunE:         runSuppressionsExport,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().String("format", "csv", "Export format: csv or json")
+	cmd.Flags().String("output-file", "", "Write the export to this file or s3:// or gs:// URL instead of stdout")
+	cmd.Flags().String("domain", "", "Export only the suppressions of this domain")
+	cmd.Flags().String("created-after", "", "Export suppressions created at or after this time (RFC3339, date or relative like '30d')")
+	cmd.Flags().String("created-before", "", "Export suppressions created at or before this time (RFC3339, date or relative like '1d')")
+
+	return cmd
+}
+
+func runSuppressionsExport(cmd *cobra.Command, args []string) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+
+	format, _ := cmd.Flags().GetString("format")
+	target, _ := cmd.Flags().GetString("output-file")
+	domain, _ := cmd.Flags().GetString("domain")
+	createdAfter, _ := cmd.Flags().GetString("created-after")
+	createdBefore, _ := cmd.Flags().GetString("created-before")
+
+	if format != "csv" && format != "json" {
+		return errors.NewValidationError(fmt.Sprintf("invalid --format %q (use csv or json)", format), nil)
+	}
+	params, err := exportParams(domain, createdAfter, createdBefore)
+	if err != nil {
+		return err
+	}
+
+	apiClient, err := auth.GetAuthenticatedClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	logger.Get().WithFields(map[string]interface{}{
+		"format":         format,
+		"output_file":    target,
+		"domain":         domain,
+		"created_after":  createdAfter,
+		"created_before": createdBefore,
+	}).Debug("Executing suppressions export command")
+
+	parent := cmd.Context()
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, stop := signal.NotifyContext(parent, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	interrupted := func() bool { return ctx.Err() != nil && parent.Err() == nil }
+
+	if target == "" {
+		count, err := exportSuppressions(ctx, apiClient, params, newExportWriter(format, cmd.OutOrStdout()))
+		if err != nil {
+			if interrupted() {
+				return errors.NewInterruptedError(fmt.Sprintf("export interrupted after %d suppressions; the output on stdout is incomplete", count), nil)
+			}
+			return err
+		}
+		// The export itself is on stdout
+		summary := printer.GetResponseHandler(handler.GetFormat(), false, cmd.ErrOrStderr())
+		return summary.HandleSimpleSuccess(fmt.Sprintf("Exported %d suppressions to stdout", count))
+	}
+
+	out, err := sink.Open(ctx, target)
+	if err != nil {
+		return err
+	}
+	count, err := exportSuppressions(ctx, apiClient, params, newExportWriter(format, out))
+	if err == nil {
+		err = out.Close()
+	}
+	if err != nil {
+		if abortErr := out.Abort(); abortErr != nil {
+			logger.Get().WithError(abortErr).Warn("Failed to remove the partial export")
+		}
+		if interrupted() {
+			return errors.NewInterruptedError(fmt.Sprintf("export interrupted after %d suppressions; nothing was written to %s", count, target), nil)
+		}
+		return err
+	}
+	return handler.HandleSimpleSuccess(fmt.Sprintf("Exported %d suppressions to %s", count, target))
+}
+
+// exportParams builds the list filters of an export
+func exportParams(domain, createdAfter, createdBefore string) (requests.GetSuppressionsParams, error) {
+	var params requests.GetSuppressionsParams
+	if domain != "" {
+		params.Domain = &domain
+	}
+	if createdAfter != "" {
+		after, err := output.ParseTimeStart(createdAfter, time.Local)
+		if err != nil {
+			return params, errors.NewValidationError(fmt.Sprintf("invalid --created-after: %v", err), nil)
+		}
+		params.FromTime = &after
+	}
+	if createdBefore != "" {
+		before, err := output.ParseTimeEnd(createdBefore, time.Local)
+		if err != nil {
+			return params, errors.NewValidationError(fmt.Sprintf("invalid --created-before: %v", err), nil)
+		}
+		params.ToTime = &before
+	}
+	if params.FromTime != nil && params.ToTime != nil && params.ToTime.Before(*params.FromTime) {
+		return params, errors.NewValidationError("--created-before must not be before --created-after", nil)
+	}
+	return params, nil
+}
+
+// exportSuppressions fetches the pages of the list one after another and
+// writes each suppression as it arrives. It returns the number written; an
+// interrupt stops it before the next page with the context's error.
+func exportSuppressions(ctx context.Context, apiClient client.AhaSendClient, params requests.GetSuppressionsParams, writer exportWriter) (int, error) {
+	count := 0
+	var cursor *string
+	for page := 1; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return count, err
+		}
+		params.PaginationParams = common.PaginationParams{
+			Limit:  ahasend.Int32(exportPageSize),
+			Cursor: cursor,
+		}
+		response, err := apiClient.ListSuppressions(params)
+		if err != nil {
+			return count, err
+		}
+		if response == nil {
+			return count, errors.NewAPIError("received nil response from API", nil)
+		}
+		for i := range response.Data {
+			if err := writer.Write(&response.Data[i]); err != nil {
+				return count, errors.NewFileError("failed to write the export", err)
+			}
+			count++
+		}
+		logger.Get().WithFields(map[string]interface{}{
+			"page":         page,
+			"suppressions": len(response.Data),
+			"total":        count,
+		}).Debug("Exported suppressions page")
+
+		if !response.Pagination.HasMore || response.Pagination.NextCursor == nil || *response.Pagination.NextCursor == "" {
+			break
+		}
+		cursor = response.Pagination.NextCursor
+	}
+	if err := writer.Close(); err != nil {
+		return count, errors.NewFileError("failed to write the export", err)
+	}
+	return count, nil
+}
+
+// exportRecord is a suppression as exported, keyed by exportColumns
+type exportRecord struct {
+	ID        string `json:"id"`
+	Email     string `json:"email"`
+	Domain    string `json:"domain"`
+	Reason    string `json:"reason"`
+	CreatedAt string `json:"created_at"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+func newExportRecord(suppression *responses.Suppression) exportRecord {
+	return exportRecord{
+		ID:        suppression.ID.String(),
+		Email:     suppression.Email,
+		Domain:    suppression.Domain,
+		Reason:    suppression.Reason,
+		CreatedAt: exportTime(suppression.CreatedAt),
+		ExpiresAt: exportTime(suppression.ExpiresAt),
+	}
+}
+
+// exportTime formats a time in RFC3339 UTC; the zero time, a suppression
+// that never expires, is empty
+func exportTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// exportWriter writes the suppressions of an export one at a time. Close
+// finishes the output once the last one has been written.
+type exportWriter interface {
+	Write(suppression *responses.Suppression) error
+	Close() error
+}
+
+func newExportWriter(format string, out io.Writer) exportWriter {
+	if format == "json" {
+		return &jsonExportWriter{out: out}
+	}
+	return &csvExportWriter{writer: csv.NewWriter(out)}
+}
+
+// csvExportWriter writes a header row and one row per suppression, flushing
+// after every row so that the output keeps pace with the pages
+type csvExportWriter struct {
+	writer      *csv.Writer
+	wroteHeader bool
+}
+
+func (w *csvExportWriter) writeHeader() error {
+	if w.wroteHeader {
+		return nil
+	}
+	w.wroteHeader = true
+	return w.writer.Write(exportColumns)
+}
+
+func (w *csvExportWriter) Write(suppression *responses.Suppression) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	record := newExportRecord(suppression)
+	if err := w.writer.Write([]string{record.ID, record.Email, record.Domain, record.Reason, record.CreatedAt, record.ExpiresAt}); err != nil {
+		return err
+	}
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// Close writes the header of an empty export, so the file can still be
+// imported
+func (w *csvExportWriter) Close() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// jsonExportWriter writes a JSON array one element per line, opening it
+// with the first element and closing it in Close
+type jsonExportWriter struct {
+	out     io.Writer
+	written int
+}
+
+func (w *jsonExportWriter) Write(suppression *responses.Suppression) error {
+	data, err := json.Marshal(newExportRecord(suppression))
+	if err != nil {
+		return err
+	}
+	separator := ",\n"
+	if w.written == 0 {
+		separator = "[\n"
+	}
+	w.written++
+	_, err = fmt.Fprintf(w.out, "%s  %s", separator, data)
+	return err
+}
+
+func (w *jsonExportWriter) Close() error {
+	closing := "\n]\n"
+	if w.written == 0 {
+		closing = "[]\n"
+	}
+	_, err := io.WriteString(w.out, closing)
+	return err
+}
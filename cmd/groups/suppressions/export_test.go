@@ -0,0 +1,144 @@
+package suppressions
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+)
+
+func executeExport(t *testing.T, setup func(*mocks.MockClient), args ...string) wipeRun {
+	t.Helper()
+
+	mockClient := &mocks.MockClient{}
+	setup(mockClient)
+
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var stdout, stderr bytes.Buffer
+	handler := printer.GetResponseHandler("plain", false, &stdout)
+	cmd := NewExportCommand()
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	return wipeRun{stdout: stdout.String(), stderr: stderr.String(), err: err, mockClient: mockClient}
+}
+
+func TestSuppressionsExport_CSVFile(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "suppressions.csv")
+	run := executeExport(t, setupPagedSuppressions, "--output-file", target)
+	require.NoError(t, run.err)
+	run.mockClient.AssertExpectations(t)
+	assert.Contains(t, run.stdout, "Exported 5 suppressions to "+target)
+
+	data, err := os.ReadFile(target)
+	require.NoError(t, err)
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 6)
+	assert.Equal(t, exportColumns, records[0])
+	assert.Equal(t, "alice@competitor.com", records[1][1])
+	assert.Equal(t, "example.com", records[1][2])
+	assert.Equal(t, "bounce", records[1][3])
+	assert.Regexp(t, `^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z$`, records[1][4])
+	assert.Empty(t, records[1][5], "suppressions that never expire have no expires_at")
+	assert.Equal(t, "erin@customer.com", records[5][1])
+}
+
+func TestSuppressionsExport_JSONStdout(t *testing.T) {
+	run := executeExport(t, setupPagedSuppressions, "--format", "json")
+	require.NoError(t, run.err)
+
+	var exported []map[string]string
+	require.NoError(t, json.Unmarshal([]byte(run.stdout), &exported))
+	require.Len(t, exported, 5)
+	assert.Equal(t, "carol@COMPETITOR.com", exported[2]["email"])
+	assert.Equal(t, "complaint", exported[2]["reason"])
+	assert.Contains(t, run.stderr, "Exported 5 suppressions to stdout")
+}
+
+func TestSuppressionsExport_Empty(t *testing.T) {
+	empty := func(mockClient *mocks.MockClient) {
+		mockClient.On("ListSuppressions", mock.Anything).Return(mockClient.NewMockSuppressionsResponse(nil, false), nil).Twice()
+	}
+
+	run := executeExport(t, empty, "--format", "json")
+	require.NoError(t, run.err)
+	assert.Equal(t, "[]\n", run.stdout)
+
+	run = executeExport(t, empty)
+	require.NoError(t, run.err)
+	assert.Equal(t, strings.Join(exportColumns, ",")+"\n", run.stdout)
+}
+
+func TestSuppressionsExport_Filters(t *testing.T) {
+	run := executeExport(t, func(mockClient *mocks.MockClient) {
+		mockClient.On("ListSuppressions", mock.MatchedBy(func(params requests.GetSuppressionsParams) bool {
+			return params.Domain != nil && *params.Domain == "example.com" &&
+				params.FromTime != nil && params.FromTime.Format("2006-01-02T15:04:05") == "2026-01-01T00:00:00" &&
+				params.ToTime != nil && params.ToTime.Format("2006-01-02T15:04:05") == "2026-01-31T23:59:59" &&
+				*params.Limit == exportPageSize
+		})).Return(mockClient.NewMockSuppressionsResponse([]responses.Suppression{}, false), nil).Once()
+	}, "--domain", "example.com", "--created-after", "2026-01-01", "--created-before", "2026-01-31")
+	require.NoError(t, run.err)
+	run.mockClient.AssertExpectations(t)
+}
+
+func TestSuppressionsExport_ValidationFails(t *testing.T) {
+	for name, args := range map[string][]string{
+		"format":         {"--format", "xml"},
+		"created after":  {"--created-after", "yesterday"},
+		"reversed range": {"--created-after", "2026-02-01", "--created-before", "2026-01-01"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			run := executeExport(t, func(*mocks.MockClient) {}, args...)
+			require.Error(t, run.err)
+			run.mockClient.AssertNotCalled(t, "ListSuppressions", mock.Anything)
+		})
+	}
+}
+
+func TestSuppressionsExport_FailureRemovesPartialFile(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "suppressions.csv")
+	run := executeExport(t, func(mockClient *mocks.MockClient) {
+		nextCursor := "page-2"
+		firstPage := mockClient.NewMockSuppressionsResponse([]responses.Suppression{
+			*mockClient.NewMockSuppression("alice@competitor.com", "bounce", "example.com"),
+		}, true)
+		firstPage.Pagination.NextCursor = &nextCursor
+		mockClient.On("ListSuppressions", mock.MatchedBy(func(params requests.GetSuppressionsParams) bool {
+			return params.Cursor == nil
+		})).Return(firstPage, nil).Once()
+		mockClient.On("ListSuppressions", mock.Anything).Return(nil, fmt.Errorf("connection reset")).Once()
+	}, "--output-file", target)
+	require.Error(t, run.err)
+	assert.Contains(t, run.err.Error(), "connection reset")
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "no partial export is left behind")
+}
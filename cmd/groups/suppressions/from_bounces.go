@@ -0,0 +1,370 @@
+package suppressions
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/models/common"
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/spf13/cobra"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/bounces"
+	"github.com/AhaSend/ahasend-cli/internal/bulk"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/output"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/progress"
+)
+
+const (
+	// bouncePageSize is the number of bounced messages fetched per page
+	bouncePageSize int32 = 100
+
+	// bounceStatus is the API status of bounced messages
+	bounceStatus = "Bounced"
+)
+
+// fromBouncesOnlyFlags only apply together with --from-bounces
+var fromBouncesOnlyFlags = []string{"from-time", "to-time", "on", "timezone", "classifications", "dry-run", "addresses-file", "yes"}
+
+// createArgs requires an email address unless --from-bounces selects the
+// addresses to suppress
+func createArgs(cmd *cobra.Command, args []string) error {
+	if fromBounces, _ := cmd.Flags().GetBool("from-bounces"); fromBounces {
+		if len(args) > 0 {
+			return errors.NewValidationError("an email address cannot be combined with --from-bounces", nil)
+		}
+		return nil
+	}
+	for _, flag := range fromBouncesOnlyFlags {
+		if cmd.Flags().Changed(flag) {
+			return errors.NewValidationError(fmt.Sprintf("--%s requires --from-bounces", flag), nil)
+		}
+	}
+	return cobra.ExactArgs(1)(cmd, args)
+}
+
+// bouncedAddress is a unique address selected from the bounced messages,
+// with the classification of its first matching bounce
+type bouncedAddress struct {
+	Email          string
+	Classification string
+}
+
+func runSuppressionsCreateFromBounces(cmd *cobra.Command) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+
+	reason, _ := cmd.Flags().GetString("reason")
+	domain, _ := cmd.Flags().GetString("domain")
+	expiresStr, _ := cmd.Flags().GetString("expires")
+	fromTimeStr, _ := cmd.Flags().GetString("from-time")
+	toTimeStr, _ := cmd.Flags().GetString("to-time")
+	on, _ := cmd.Flags().GetString("on")
+	timezone, _ := cmd.Flags().GetString("timezone")
+	classificationFlags, _ := cmd.Flags().GetStringSlice("classifications")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	addressesFile, _ := cmd.Flags().GetString("addresses-file")
+	yes, _ := cmd.Flags().GetBool("yes")
+
+	if len(reason) > 255 {
+		return errors.NewValidationError(fmt.Sprintf("reason exceeds maximum length of 255 characters (got %d characters)", len(reason)), nil)
+	}
+	if addressesFile != "" && !dryRun {
+		return errors.NewValidationError("--addresses-file requires --dry-run", nil)
+	}
+	expiresAt, err := output.ParseTimeFuture(expiresStr)
+	if err != nil {
+		return err
+	}
+	classifications, err := parseClassifications(classificationFlags)
+	if err != nil {
+		return err
+	}
+	// The 24h --from-time default gives way to --on
+	if on != "" && !cmd.Flags().Changed("from-time") {
+		fromTimeStr = ""
+	}
+	fromTimePtr, toTime, err := output.ParseTimeRange(fromTimeStr, toTimeStr, on, timezone)
+	if err != nil {
+		return err
+	}
+	if fromTimePtr == nil {
+		return errors.NewValidationError("--from-bounces needs a --from-time or --on", nil)
+	}
+	fromTime := *fromTimePtr
+
+	apiClient, err := auth.GetAuthenticatedClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	logger.Get().WithFields(map[string]interface{}{
+		"from_time":       fromTime,
+		"to_time":         toTime,
+		"classifications": classifications,
+		"domain":          domain,
+		"reason":          reason,
+		"expires_at":      expiresAt,
+		"dry_run":         dryRun,
+	}).Debug("Executing suppressions create --from-bounces")
+
+	summary := &printer.BounceSuppressionSummary{
+		FromTime:        fromTime,
+		ToTime:          toTime,
+		Classifications: classifications,
+		Domain:          domain,
+		DryRun:          dryRun,
+	}
+	addresses, err := selectBouncedAddresses(apiClient, fromTime, toTime, classifications, domain, summary, cmd.ErrOrStderr())
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		// The address list is the dry run's output; the summary goes to
+		// stderr unless the list went to a file
+		if addressesFile == "" {
+			for _, address := range addresses {
+				fmt.Fprintln(cmd.OutOrStdout(), address.Email)
+			}
+			fmt.Fprintln(cmd.ErrOrStderr(), formatBouncePreviewLine(summary))
+			return nil
+		}
+		if err := writeAddressesFile(addressesFile, addresses); err != nil {
+			return err
+		}
+		return handler.HandleBounceSuppressions(summary, printer.CreateConfig{ItemName: "suppression"})
+	}
+
+	if len(addresses) == 0 {
+		return handler.HandleBounceSuppressions(summary, printer.CreateConfig{ItemName: "suppression"})
+	}
+
+	// The preview goes to stderr so structured output on stdout stays parseable
+	previewBouncedAddresses(cmd.ErrOrStderr(), addresses, summary)
+
+	if !yes {
+		if !bulk.IsInteractive(cmd.InOrStdin()) {
+			return errors.NewValidationError("refusing to create suppressions without confirmation; re-run with --yes to proceed non-interactively", nil)
+		}
+		confirmed, err := bulk.ConfirmCount(cmd.InOrStdin(), cmd.ErrOrStderr(), len(addresses), "suppressions")
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return handler.HandleSimpleSuccess("Suppression creation cancelled")
+		}
+	}
+
+	createBounceSuppressions(apiClient, addresses, reason, domain, expiresAt, summary, newProgressReporter(cmd, len(addresses)))
+	if err := handler.HandleBounceSuppressions(summary, printer.CreateConfig{
+		SuccessMessage: fmt.Sprintf("Suppressed %d addresses", summary.Created),
+		ItemName:       "suppression",
+	}); err != nil {
+		return err
+	}
+	if summary.Failed > 0 {
+		return errors.NewAPIError(fmt.Sprintf("failed to create %d of %d suppressions", summary.Failed, len(addresses)), nil)
+	}
+	return nil
+}
+
+// parseClassifications validates the --classifications values and returns
+// their canonical names, defaulting to the hard bounce classifications
+func parseClassifications(values []string) ([]string, error) {
+	if len(values) == 0 {
+		return append([]string{}, bounces.Hard...), nil
+	}
+	var classifications []string
+	seen := make(map[string]bool)
+	for _, value := range values {
+		explanation, ok := bounces.Explain(value)
+		if !ok {
+			return nil, errors.NewValidationError(fmt.Sprintf("unknown bounce classification '%s', must be one of: %s",
+				value, strings.Join(bounces.Classifications, ", ")), nil)
+		}
+		if !seen[explanation.Classification] {
+			seen[explanation.Classification] = true
+			classifications = append(classifications, explanation.Classification)
+		}
+	}
+	return classifications, nil
+}
+
+// selectBouncedAddresses pages through the bounced messages in the window
+// and returns the unique recipients with one of the classifications that
+// are not suppressed yet, counting the rest in summary. Only the selected
+// addresses are kept, so memory grows with them rather than with the
+// number of bounced messages.
+func selectBouncedAddresses(apiClient client.AhaSendClient, fromTime time.Time, toTime *time.Time, classifications []string, domain string,
+	summary *printer.BounceSuppressionSummary, progress io.Writer) ([]bouncedAddress, error) {
+	wanted := make(map[string]bool, len(classifications))
+	for _, classification := range classifications {
+		wanted[classification] = true
+	}
+
+	limit := bouncePageSize
+	status := bounceStatus
+	params := requests.GetMessagesParams{
+		Status:           &status,
+		FromTime:         &fromTime,
+		ToTime:           toTime,
+		PaginationParams: common.PaginationParams{Limit: &limit},
+	}
+
+	seen := make(map[string]bool)
+	var addresses []bouncedAddress
+	for page := 1; ; page++ {
+		response, err := apiClient.GetMessages(params)
+		if err != nil {
+			return nil, err
+		}
+		if response == nil {
+			break
+		}
+		for _, message := range response.Data {
+			summary.Messages++
+			classification := messageClassification(message)
+			if message.IsBounceNotification || !wanted[classification] {
+				continue
+			}
+			email := strings.ToLower(strings.TrimSpace(message.Recipient))
+			if email == "" || seen[email] {
+				continue
+			}
+			seen[email] = true
+			summary.Matched++
+
+			suppressed, err := isSuppressed(apiClient, email, domain)
+			if err != nil {
+				return nil, err
+			}
+			if suppressed {
+				summary.AlreadySuppressed++
+				continue
+			}
+			addresses = append(addresses, bouncedAddress{Email: email, Classification: classification})
+		}
+		fmt.Fprintf(progress, "Scanned %d bounced messages (page %d): %d addresses to suppress, %d already suppressed\n",
+			summary.Messages, page, len(addresses), summary.AlreadySuppressed)
+
+		if !response.Pagination.HasMore || response.Pagination.NextCursor == nil || *response.Pagination.NextCursor == "" {
+			break
+		}
+		params.Cursor = response.Pagination.NextCursor
+	}
+	return addresses, nil
+}
+
+// messageClassification returns the canonical bounce classification of a
+// message, or "" when it has none
+func messageClassification(message responses.Message) string {
+	if message.BounceClassification == nil {
+		return ""
+	}
+	explanation, ok := bounces.Explain(*message.BounceClassification)
+	if !ok {
+		return *message.BounceClassification
+	}
+	return explanation.Classification
+}
+
+// isSuppressed reports whether email already has a suppression that covers
+// domain: a global one, or one for that domain
+func isSuppressed(apiClient client.AhaSendClient, email, domain string) (bool, error) {
+	response, err := apiClient.ListSuppressions(requests.GetSuppressionsParams{Email: &email})
+	if err != nil {
+		return false, err
+	}
+	if response == nil {
+		return false, nil
+	}
+	for _, suppression := range response.Data {
+		if !strings.EqualFold(suppression.Email, email) {
+			continue
+		}
+		if suppression.Domain == "" || strings.EqualFold(suppression.Domain, domain) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// previewBouncedAddresses shows the address count and the first addresses
+func previewBouncedAddresses(out io.Writer, addresses []bouncedAddress, summary *printer.BounceSuppressionSummary) {
+	sample := addresses
+	if len(sample) > bulkDeletePreviewSize {
+		sample = sample[:bulkDeletePreviewSize]
+	}
+	for _, address := range sample {
+		fmt.Fprintf(out, "  %s (%s)\n", address.Email, address.Classification)
+	}
+	if more := len(addresses) - len(sample); more > 0 {
+		fmt.Fprintf(out, "  ... and %d more\n", more)
+	}
+	fmt.Fprintln(out, formatBouncePreviewLine(summary))
+}
+
+// formatBouncePreviewLine describes the selected addresses
+func formatBouncePreviewLine(summary *printer.BounceSuppressionSummary) string {
+	return fmt.Sprintf("%d addresses to suppress from %d bounced messages since %s (%s); %d already suppressed",
+		summary.ToSuppress(), summary.Messages, summary.FromTime.Format(time.RFC3339),
+		strings.Join(summary.Classifications, ", "), summary.AlreadySuppressed)
+}
+
+// writeAddressesFile writes the addresses to path, one per line
+func writeAddressesFile(path string, addresses []bouncedAddress) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return errors.NewFileError(fmt.Sprintf("cannot create addresses file %s", path), err)
+	}
+	for _, address := range addresses {
+		if _, err := fmt.Fprintln(file, address.Email); err != nil {
+			file.Close()
+			return errors.NewFileError(fmt.Sprintf("cannot write addresses file %s", path), err)
+		}
+	}
+	if err := file.Close(); err != nil {
+		return errors.NewFileError(fmt.Sprintf("cannot write addresses file %s", path), err)
+	}
+	return nil
+}
+
+// createBounceSuppressions suppresses each address, recording the outcome in
+// summary and reporting progress to reporter. Without a reason, each suppression
+// records the classification of the address's bounce.
+func createBounceSuppressions(apiClient client.AhaSendClient, addresses []bouncedAddress, reason, domain string, expiresAt time.Time,
+	summary *printer.BounceSuppressionSummary, reporter *progress.Reporter) {
+	reporter.Start()
+	defer reporter.Finish()
+	for _, address := range addresses {
+		addressReason := reason
+		if addressReason == "" {
+			addressReason = fmt.Sprintf("Bounced (%s)", address.Classification)
+		}
+		req := requests.CreateSuppressionRequest{
+			Email:     address.Email,
+			ExpiresAt: expiresAt,
+			Reason:    &addressReason,
+		}
+		if domain != "" {
+			req.Domain = &domain
+		}
+
+		_, err := apiClient.CreateSuppression(req)
+		if err != nil {
+			summary.Failed++
+			summary.Failures = append(summary.Failures, printer.BounceSuppressionFailure{Email: address.Email, Error: err.Error()})
+		} else {
+			summary.Created++
+		}
+		reporter.Update(err == nil)
+	}
+}
@@ -0,0 +1,162 @@
+package suppressions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+)
+
+func bouncedMessage(recipient, classification string) responses.Message {
+	return responses.Message{Recipient: recipient, Status: "Bounced", BounceClassification: &classification}
+}
+
+// setupBounces serves two pages of bounced messages. bob is already
+// suppressed globally and erin only for another domain.
+func setupBounces(m *mocks.MockClient) {
+	cursor := "page-2"
+	first := m.NewMockMessagesResponse([]responses.Message{
+		bouncedMessage("alice@example.com", "InvalidRecipient"),
+		bouncedMessage("bob@example.com", "BadDomain"),
+		bouncedMessage("carol@example.com", "QuotaIssues"),
+	}, true)
+	first.Pagination.NextCursor = &cursor
+	m.On("GetMessages", mock.MatchedBy(func(p requests.GetMessagesParams) bool {
+		return p.Cursor == nil && p.Status != nil && *p.Status == "Bounced" && p.FromTime != nil
+	})).Return(first, nil).Once()
+	m.On("GetMessages", mock.MatchedBy(func(p requests.GetMessagesParams) bool {
+		return p.Cursor != nil && *p.Cursor == cursor
+	})).Return(m.NewMockMessagesResponse([]responses.Message{
+		bouncedMessage("ALICE@example.com", "invalid_recipient"),
+		bouncedMessage("dave@example.com", "InactiveMailbox"),
+		bouncedMessage("erin@example.com", "InvalidRecipient"),
+	}, false), nil).Once()
+
+	suppressedBy := map[string][]responses.Suppression{
+		"bob@example.com":  {*m.NewMockSuppression("bob@example.com", "bounce", "")},
+		"erin@example.com": {*m.NewMockSuppression("erin@example.com", "bounce", "other.com")},
+	}
+	for _, email := range []string{"alice@example.com", "bob@example.com", "carol@example.com", "dave@example.com", "erin@example.com"} {
+		m.On("ListSuppressions", mock.MatchedBy(func(p requests.GetSuppressionsParams) bool {
+			return p.Email != nil && *p.Email == email
+		})).Return(m.NewMockSuppressionsResponse(suppressedBy[email], false), nil)
+	}
+}
+
+func executeCreate(t *testing.T, format, stdin string, setup func(*mocks.MockClient), args ...string) wipeRun {
+	t.Helper()
+
+	mockClient := &mocks.MockClient{}
+	setup(mockClient)
+
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var stdout, stderr bytes.Buffer
+	handler := printer.GetResponseHandler(format, false, &stdout)
+	cmd := NewCreateCommand()
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	return wipeRun{stdout: stdout.String(), stderr: stderr.String(), err: err, mockClient: mockClient}
+}
+
+func TestCreateFromBounces(t *testing.T) {
+	run := executeCreate(t, "json", "3\n", func(m *mocks.MockClient) {
+		setupBounces(m)
+		m.On("CreateSuppression", mock.Anything).Return(&responses.CreateSuppressionResponse{}, nil)
+	}, "--from-bounces", "--from-time", "-24h", "--expires", "1y")
+	require.NoError(t, run.err)
+
+	// carol's QuotaIssues is not a hard bounce and bob is already suppressed
+	run.mockClient.AssertNumberOfCalls(t, "CreateSuppression", 3)
+	for _, email := range []string{"alice@example.com", "dave@example.com", "erin@example.com"} {
+		run.mockClient.AssertCalled(t, "CreateSuppression", mock.MatchedBy(func(req requests.CreateSuppressionRequest) bool {
+			return req.Email == email && req.Domain == nil && req.Reason != nil && strings.HasPrefix(*req.Reason, "Bounced (")
+		}))
+	}
+	// Each address is only looked up once
+	run.mockClient.AssertNumberOfCalls(t, "ListSuppressions", 4)
+
+	assert.Contains(t, run.stderr, "alice@example.com (InvalidRecipient)")
+	assert.Contains(t, run.stderr, "3 addresses to suppress from 6 bounced messages")
+	assert.Contains(t, run.stderr, "1 already suppressed")
+	assert.Contains(t, run.stderr, "Type 3 to confirm")
+
+	var summary printer.BounceSuppressionSummary
+	require.NoError(t, json.Unmarshal([]byte(run.stdout), &summary))
+	assert.Equal(t, 6, summary.Messages)
+	assert.Equal(t, 4, summary.Matched)
+	assert.Equal(t, 1, summary.AlreadySuppressed)
+	assert.Equal(t, 3, summary.Created)
+	assert.Equal(t, []string{"BadDomain", "InactiveMailbox", "InvalidRecipient"}, summary.Classifications)
+}
+
+func TestCreateFromBounces_DryRun(t *testing.T) {
+	setup := func(m *mocks.MockClient) { setupBounces(m) }
+
+	run := executeCreate(t, "table", "", setup,
+		"--from-bounces", "--classifications", "quota_issues,InvalidRecipient", "--domain", "other.com", "--expires", "30d", "--dry-run")
+	require.NoError(t, run.err)
+	run.mockClient.AssertNotCalled(t, "CreateSuppression", mock.Anything)
+
+	// bob's BadDomain is not selected, and erin's suppression covers other.com
+	assert.Equal(t, "alice@example.com\ncarol@example.com\n", run.stdout)
+	assert.Contains(t, run.stderr, "2 addresses to suppress")
+	assert.Contains(t, run.stderr, "(QuotaIssues, InvalidRecipient); 1 already suppressed")
+
+	path := filepath.Join(t.TempDir(), "bouncers.txt")
+	run = executeCreate(t, "plain", "", setup, "--from-bounces", "--expires", "30d", "--dry-run", "--addresses-file", path)
+	require.NoError(t, run.err)
+	written, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com\ndave@example.com\nerin@example.com\n", string(written))
+	assert.Contains(t, run.stdout, "Dry run: 3 addresses would be suppressed (6 bounced messages scanned, 1 already suppressed)")
+}
+
+func TestCreateFromBounces_Validation(t *testing.T) {
+	none := func(*mocks.MockClient) {}
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"email with from-bounces", []string{"user@example.com", "--from-bounces", "--expires", "1d"}, "cannot be combined with --from-bounces"},
+		{"bounce flag without from-bounces", []string{"user@example.com", "--dry-run", "--expires", "1d"}, "--dry-run requires --from-bounces"},
+		{"unknown classification", []string{"--from-bounces", "--classifications", "bad_mailbox", "--expires", "1d"}, "unknown bounce classification 'bad_mailbox'"},
+		{"addresses file without dry run", []string{"--from-bounces", "--addresses-file", "x.txt", "--expires", "1d"}, "--addresses-file requires --dry-run"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run := executeCreate(t, "plain", "", none, tt.args...)
+			require.Error(t, run.err)
+			assert.Contains(t, run.err.Error(), tt.wantErr)
+		})
+	}
+
+	// Without a terminal the user must confirm with --yes
+	run := executeCreate(t, "plain", "", setupBounces, "--from-bounces", "--expires", "1d")
+	require.Error(t, run.err)
+	run.mockClient.AssertNotCalled(t, "CreateSuppression", mock.Anything)
+}
@@ -0,0 +1,479 @@
+package suppressions
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/spf13/cobra"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/batch"
+	"github.com/AhaSend/ahasend-cli/internal/bulk"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/output"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/progress"
+	"github.com/AhaSend/ahasend-cli/internal/validation"
+)
+
+const (
+	// defaultImportBatchSize is the number of suppressions created per batch
+	defaultImportBatchSize = 100
+
+	// maxReasonLength is the longest reason the API accepts
+	maxReasonLength = 255
+
+	// interruptedRowError is recorded for rows not attempted after an interrupt
+	interruptedRowError = "not imported: the import was interrupted"
+)
+
+// importErrorsHeader is the header of the --errors-file CSV. It starts with
+// the import columns, so the file can be fixed and imported again.
+var importErrorsHeader = []string{"email", "domain", "reason", "expires_at", "row", "error"}
+
+// NewImportCommand creates the suppressions import command
+func NewImportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Create suppressions in bulk from a CSV or JSON file",
+		Long: `Create a suppression for every row of a CSV or JSON file, for example when
+moving suppression lists over from another provider.
+
+A CSV file needs a header row with an 'email' column and may have 'domain',
+'reason' and 'expires_at' columns; other columns are ignored. A JSON file
+holds an array of objects with the same keys. Rows without a domain create
+global suppressions. expires_at accepts RFC3339 or a relative time like
+'1y'; rows without one use --expires, and rows without a reason use
+--reason.
+
+Every row is validated first. Invalid rows, such as a malformed email or an
+expiry in the past, are not sent and do not stop the import; the same
+address and domain appearing again is skipped. The valid rows are created in
+batches of --batch-size, with up to --max-concurrency requests in flight.
+Rate limited and transient failures are retried like 'messages send' does
+(--max-retries). Progress is reported on stderr after every batch.
+
+On Ctrl-C the import stops after the current batch. Rows that were invalid,
+rejected by the API or not attempted are listed in the summary and, with
+--errors-file, written to a CSV file that can be corrected and imported
+again. The command exits with an error when any row was not imported.`,
+		Example: `  # Import a suppression list exported from another provider
+  ahasend suppressions import --file suppressions.csv --expires 1y
+
+  # Keep the rows that could not be imported for a second attempt
+  ahasend suppressions import --file suppressions.csv --errors-file rejected.csv
+
+  # Import a JSON array with more requests in flight
+  ahasend suppressions import --file suppressions.json --batch-size 500 --max-concurrency 10
+
+  # Capture the summary as JSON
+  ahasend suppressions import --file suppressions.csv --output json > import.json`,
+		Args:         cobra.NoArgs,
+		RunE:         runSuppressionsImport,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().String("file", "", "CSV or JSON file of suppressions to create (required)")
+	cmd.Flags().String("expires", "", "Expiration for rows without expires_at (e.g., '1y', '2024-12-31T23:59:59Z')")
+	cmd.Flags().String("reason", "", "Reason for rows without one (up to 255 characters)")
+	cmd.Flags().Int("batch-size", defaultImportBatchSize, "Number of suppressions created per batch")
+	cmd.Flags().Int("max-concurrency", bulk.DefaultConcurrency, "Maximum concurrent create requests")
+	cmd.Flags().Int("max-retries", 3, "Maximum retry attempts for rate limited and transient failures")
+	cmd.Flags().String("errors-file", "", "Write the rows that were not imported to this CSV file")
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+// importRow is a row of a suppressions import file
+type importRow struct {
+	Row       int    // CSV line or JSON array position, from 1
+	Email     string `json:"email"`
+	Domain    string `json:"domain"`
+	Reason    string `json:"reason"`
+	ExpiresAt string `json:"expires_at"`
+
+	err string // why the row was not imported
+}
+
+func runSuppressionsImport(cmd *cobra.Command, args []string) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+
+	path, _ := cmd.Flags().GetString("file")
+	expires, _ := cmd.Flags().GetString("expires")
+	reason, _ := cmd.Flags().GetString("reason")
+	batchSize, _ := cmd.Flags().GetInt("batch-size")
+	maxConcurrency, _ := cmd.Flags().GetInt("max-concurrency")
+	maxRetries, _ := cmd.Flags().GetInt("max-retries")
+	errorsFile, _ := cmd.Flags().GetString("errors-file")
+
+	if batchSize < 1 {
+		return errors.NewValidationError("--batch-size must be at least 1", nil)
+	}
+	if maxConcurrency < 1 {
+		return errors.NewValidationError("--max-concurrency must be at least 1", nil)
+	}
+	if maxRetries < 0 {
+		return errors.NewValidationError("--max-retries cannot be negative", nil)
+	}
+	if len(reason) > maxReasonLength {
+		return errors.NewValidationError(fmt.Sprintf("reason exceeds maximum length of %d characters (got %d characters)", maxReasonLength, len(reason)), nil)
+	}
+	if expires != "" {
+		if _, err := output.ParseTimeFuture(expires); err != nil {
+			return err
+		}
+	}
+
+	rows, err := loadImportRows(path)
+	if err != nil {
+		return err
+	}
+
+	apiClient, err := auth.GetAuthenticatedClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	logger.Get().WithFields(map[string]interface{}{
+		"file":            path,
+		"rows":            len(rows),
+		"batch_size":      batchSize,
+		"max_concurrency": maxConcurrency,
+		"max_retries":     maxRetries,
+		"errors_file":     errorsFile,
+	}).Debug("Executing suppressions import command")
+
+	summary := &printer.SuppressionImportSummary{File: path, ErrorsFile: errorsFile, Rows: len(rows)}
+	pending, reqs := prepareImportRows(rows, expires, reason, time.Now(), summary)
+
+	parent := cmd.Context()
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, stop := signal.NotifyContext(parent, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	out := cmd.ErrOrStderr()
+	reporter := progress.OptionsFromCommand(cmd).Reporter(len(pending), out,
+		progress.NewCountReporter(len(pending), out, "suppressions", batchSize))
+	importSuppressions(ctx, apiClient, pending, reqs, batchSize, maxConcurrency, maxRetries, reporter)
+	interrupted := ctx.Err() != nil && parent.Err() == nil
+
+	var notImported []*importRow
+	for _, row := range rows {
+		if row.err == "" {
+			continue
+		}
+		notImported = append(notImported, row)
+		summary.Errors = append(summary.Errors, printer.SuppressionImportError{
+			Row: row.Row, Email: row.Email, Domain: row.Domain, Error: row.err,
+		})
+	}
+	for _, row := range pending {
+		switch {
+		case row.err == "":
+			summary.Created++
+		case row.err == interruptedRowError:
+			summary.Skipped++
+		default:
+			summary.Failed++
+		}
+	}
+
+	if errorsFile != "" {
+		if err := writeImportErrors(errorsFile, notImported); err != nil {
+			return err
+		}
+	}
+
+	if err := handler.HandleSuppressionImport(summary, printer.CreateConfig{ItemName: "suppression"}); err != nil {
+		return err
+	}
+	switch {
+	case interrupted:
+		return errors.NewInterruptedError(fmt.Sprintf("import interrupted after %d of %d suppressions were created", summary.Created, len(rows)), nil)
+	case summary.Failed > 0:
+		return errors.NewAPIError(fmt.Sprintf("failed to create %d of %d suppressions", summary.Failed, len(rows)), nil)
+	case summary.Invalid > 0:
+		return errors.NewValidationError(fmt.Sprintf("%d of %d rows in %s are invalid", summary.Invalid, len(rows), path), nil)
+	}
+	return nil
+}
+
+// loadImportRows reads the rows of a CSV or JSON import file. Problems with
+// the file as a whole are returned as errors; problems with a single row are
+// recorded on the row.
+func loadImportRows(path string) ([]*importRow, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.NewFileError(fmt.Sprintf("cannot read suppressions file %s", path), err)
+	}
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		return parseImportJSON(content)
+	case ".csv":
+		return parseImportCSV(content)
+	default:
+		return nil, errors.NewValidationError(fmt.Sprintf("unsupported suppressions file format %s (supported: .csv, .json)", ext), nil)
+	}
+}
+
+// parseImportJSON parses an array of suppression objects
+func parseImportJSON(content []byte) ([]*importRow, error) {
+	var records []json.RawMessage
+	if err := json.Unmarshal(content, &records); err != nil {
+		var syntaxErr *json.SyntaxError
+		if stderrors.As(err, &syntaxErr) {
+			return nil, errors.NewValidationError(fmt.Sprintf("invalid JSON in suppressions file at offset %d: %s", syntaxErr.Offset, syntaxErr), nil)
+		}
+		return nil, errors.NewValidationError("suppressions file must contain a JSON array of suppression objects", nil)
+	}
+
+	rows := make([]*importRow, 0, len(records))
+	for i, record := range records {
+		row := &importRow{}
+		if !bytes.HasPrefix(bytes.TrimSpace(record), []byte("{")) {
+			row.err = "suppression must be a JSON object"
+		} else if err := json.Unmarshal(record, row); err != nil {
+			var typeErr *json.UnmarshalTypeError
+			if stderrors.As(err, &typeErr) {
+				row.err = fmt.Sprintf("%s must be a string", typeErr.Field)
+			} else {
+				row.err = strings.TrimPrefix(err.Error(), "json: ")
+			}
+		}
+		row.Row = i + 1
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// parseImportCSV parses a CSV file with a header row naming its columns
+func parseImportCSV(content []byte) ([]*importRow, error) {
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = -1 // field counts are checked per row below
+	records, err := reader.ReadAll()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if stderrors.As(err, &parseErr) {
+			return nil, errors.NewValidationError(fmt.Sprintf("invalid CSV in suppressions file at line %d, column %d: %v", parseErr.Line, parseErr.Column, parseErr.Err), nil)
+		}
+		return nil, errors.NewFileError("failed to parse CSV suppressions file", err)
+	}
+	if len(records) == 0 {
+		return nil, errors.NewValidationError("CSV file must have a header row", nil)
+	}
+
+	columns := map[string]int{"email": -1, "domain": -1, "reason": -1, "expires_at": -1}
+	for i, header := range records[0] {
+		name := strings.ToLower(strings.TrimSpace(header))
+		if index, ok := columns[name]; ok && index == -1 {
+			columns[name] = i
+		}
+	}
+	if columns["email"] == -1 {
+		return nil, errors.NewValidationError("CSV file must have an 'email' column", nil)
+	}
+
+	field := func(record []string, column string) string {
+		if index := columns[column]; index >= 0 {
+			return record[index]
+		}
+		return ""
+	}
+	rows := make([]*importRow, 0, len(records)-1)
+	for i, record := range records[1:] {
+		row := &importRow{Row: i + 2} // the header is line 1
+		if len(record) != len(records[0]) {
+			row.err = fmt.Sprintf("expected %d fields, found %d", len(records[0]), len(record))
+		} else {
+			row.Email = field(record, "email")
+			row.Domain = field(record, "domain")
+			row.Reason = field(record, "reason")
+			row.ExpiresAt = field(record, "expires_at")
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// prepareImportRows validates the rows and builds the create request of each
+// valid one, filling in the --expires and --reason defaults. Invalid rows are
+// counted in summary and keep their error; repeated addresses are skipped.
+func prepareImportRows(rows []*importRow, expires, reason string, now time.Time, summary *printer.SuppressionImportSummary) ([]*importRow, []requests.CreateSuppressionRequest) {
+	var pending []*importRow
+	var reqs []requests.CreateSuppressionRequest
+	seen := make(map[string]int)
+	for _, row := range rows {
+		if row.err == "" {
+			req, err := buildImportRequest(row, expires, reason, now)
+			if err != nil {
+				row.err = err.Error()
+			} else {
+				key := strings.ToLower(req.Email) + "|" + row.Domain
+				if first, ok := seen[key]; ok {
+					logger.Get().WithFields(map[string]interface{}{
+						"row":   row.Row,
+						"email": req.Email,
+						"first": first,
+					}).Debug("Skipping repeated suppression")
+					summary.Skipped++
+					continue
+				}
+				seen[key] = row.Row
+				pending = append(pending, row)
+				reqs = append(reqs, req)
+				continue
+			}
+		}
+		summary.Invalid++
+	}
+	return pending, reqs
+}
+
+// buildImportRequest validates a row and returns its create request
+func buildImportRequest(row *importRow, expires, reason string, now time.Time) (requests.CreateSuppressionRequest, error) {
+	row.Email = strings.TrimSpace(row.Email)
+	row.Domain = strings.ToLower(strings.TrimSpace(row.Domain))
+	row.Reason = strings.TrimSpace(row.Reason)
+	row.ExpiresAt = strings.TrimSpace(row.ExpiresAt)
+
+	var req requests.CreateSuppressionRequest
+	if row.Email == "" {
+		return req, fmt.Errorf("missing email")
+	}
+	if err := validation.ValidateEmail(row.Email); err != nil {
+		return req, fmt.Errorf("invalid email %q", row.Email)
+	}
+	email, err := validation.NormalizeEmail(row.Email)
+	if err != nil {
+		return req, fmt.Errorf("invalid email %q", row.Email)
+	}
+	if row.Domain != "" {
+		if err := validation.ValidateDomainName(row.Domain); err != nil {
+			return req, fmt.Errorf("invalid domain %q", row.Domain)
+		}
+	}
+
+	rowReason := row.Reason
+	if rowReason == "" {
+		rowReason = reason
+	}
+	if len(rowReason) > maxReasonLength {
+		return req, fmt.Errorf("reason exceeds maximum length of %d characters (got %d characters)", maxReasonLength, len(rowReason))
+	}
+
+	rowExpires := row.ExpiresAt
+	if rowExpires == "" {
+		rowExpires = expires
+	}
+	if rowExpires == "" {
+		return req, fmt.Errorf("missing expires_at (or set --expires)")
+	}
+	expiresAt, err := output.ParseTimeFuture(rowExpires)
+	if err != nil {
+		return req, fmt.Errorf("invalid expires_at %q", rowExpires)
+	}
+	if !expiresAt.After(now) {
+		return req, fmt.Errorf("expires_at %s is in the past", rowExpires)
+	}
+
+	req = requests.CreateSuppressionRequest{Email: email, ExpiresAt: expiresAt}
+	if rowReason != "" {
+		req.Reason = &rowReason
+	}
+	if row.Domain != "" {
+		domain := row.Domain
+		req.Domain = &domain
+	}
+	return req, nil
+}
+
+// importSuppressions creates the suppressions batchSize at a time, with at
+// most maxConcurrency requests in flight, recording failures on their rows.
+// Once ctx is done no further batch is started and the remaining rows are
+// marked as interrupted.
+func importSuppressions(ctx context.Context, apiClient client.AhaSendClient, rows []*importRow, reqs []requests.CreateSuppressionRequest,
+	batchSize, maxConcurrency, maxRetries int, reporter *progress.Reporter) {
+	reporter.Start()
+	defer reporter.Finish()
+
+	for start := 0; start < len(rows); start += batchSize {
+		end := min(start+batchSize, len(rows))
+		if ctx.Err() != nil {
+			for _, row := range rows[start:] {
+				row.err = interruptedRowError
+			}
+			return
+		}
+
+		sem := make(chan struct{}, maxConcurrency)
+		var wg sync.WaitGroup
+		for i := start; i < end; i++ {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(row *importRow, req requests.CreateSuppressionRequest) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				_, err := batch.Retry(ctx, maxRetries, func() error {
+					_, err := apiClient.CreateSuppression(req)
+					return err
+				})
+				if err != nil {
+					row.err = err.Error()
+				}
+				reporter.Update(err == nil)
+			}(rows[i], reqs[i])
+		}
+		wg.Wait()
+	}
+}
+
+// writeImportErrors writes the rows that were not imported to path as CSV
+func writeImportErrors(path string, rows []*importRow) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return errors.NewFileError(fmt.Sprintf("cannot create errors file %s", path), err)
+	}
+	if err := writeImportErrorsCSV(file, rows); err != nil {
+		file.Close()
+		return errors.NewFileError(fmt.Sprintf("cannot write errors file %s", path), err)
+	}
+	if err := file.Close(); err != nil {
+		return errors.NewFileError(fmt.Sprintf("cannot write errors file %s", path), err)
+	}
+	return nil
+}
+
+func writeImportErrorsCSV(w io.Writer, rows []*importRow) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(importErrorsHeader); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		record := []string{row.Email, row.Domain, row.Reason, row.ExpiresAt, strconv.Itoa(row.Row), row.err}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,171 @@
+package suppressions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/api"
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+)
+
+func executeImport(t *testing.T, format string, setup func(*mocks.MockClient), args ...string) wipeRun {
+	t.Helper()
+
+	mockClient := &mocks.MockClient{}
+	setup(mockClient)
+
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var stdout, stderr bytes.Buffer
+	handler := printer.GetResponseHandler(format, false, &stdout)
+	cmd := NewImportCommand()
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	return wipeRun{stdout: stdout.String(), stderr: stderr.String(), err: err, mockClient: mockClient}
+}
+
+func writeImportFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestImportCommand_CSV(t *testing.T) {
+	path := writeImportFile(t, "suppressions.csv", `Email,Domain,Reason,Expires_At,Source
+alice@example.com,,Unsubscribed,2099-01-01T00:00:00Z,old-esp
+bob@example.com,Mail.Example.com,,,old-esp
+not-an-email,,,,old-esp
+ALICE@example.com,,,1y,old-esp
+carol@example.com,,,2001-01-01T00:00:00Z,old-esp
+dave@example.com,,,1y
+`)
+	errorsFile := filepath.Join(t.TempDir(), "rejected.csv")
+
+	run := executeImport(t, "json", func(m *mocks.MockClient) {
+		m.On("CreateSuppression", mock.Anything).Return(&responses.CreateSuppressionResponse{}, nil)
+	}, "--file", path, "--expires", "30d", "--reason", "Migrated", "--errors-file", errorsFile, "--batch-size", "1")
+	require.Error(t, run.err)
+	assert.Contains(t, run.err.Error(), "3 of 6 rows")
+
+	run.mockClient.AssertNumberOfCalls(t, "CreateSuppression", 2)
+	run.mockClient.AssertCalled(t, "CreateSuppression", mock.MatchedBy(func(req requests.CreateSuppressionRequest) bool {
+		return req.Email == "alice@example.com" && req.Domain == nil && *req.Reason == "Unsubscribed" &&
+			req.ExpiresAt.Equal(time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC))
+	}))
+	run.mockClient.AssertCalled(t, "CreateSuppression", mock.MatchedBy(func(req requests.CreateSuppressionRequest) bool {
+		return req.Email == "bob@example.com" && *req.Domain == "mail.example.com" && *req.Reason == "Migrated" &&
+			req.ExpiresAt.After(time.Now().Add(29*24*time.Hour))
+	}))
+
+	var summary printer.SuppressionImportSummary
+	require.NoError(t, json.Unmarshal([]byte(run.stdout), &summary))
+	assert.Equal(t, 6, summary.Rows)
+	assert.Equal(t, 2, summary.Created)
+	assert.Equal(t, 3, summary.Invalid)
+	assert.Equal(t, 0, summary.Failed)
+	assert.Equal(t, 1, summary.Skipped)
+	require.Len(t, summary.Errors, 3)
+	assert.Equal(t, printer.SuppressionImportError{Row: 4, Email: "not-an-email", Error: `invalid email "not-an-email"`}, summary.Errors[0])
+	assert.Equal(t, "expires_at 2001-01-01T00:00:00Z is in the past", summary.Errors[1].Error)
+	assert.Equal(t, 7, summary.Errors[2].Row)
+	assert.Equal(t, "expected 5 fields, found 4", summary.Errors[2].Error)
+
+	data, err := os.ReadFile(errorsFile)
+	require.NoError(t, err)
+	assert.Equal(t, `email,domain,reason,expires_at,row,error
+not-an-email,,,,4,"invalid email ""not-an-email"""
+carol@example.com,,,2001-01-01T00:00:00Z,6,expires_at 2001-01-01T00:00:00Z is in the past
+,,,,7,"expected 5 fields, found 4"
+`, string(data))
+}
+
+func TestImportCommand_JSONWithAPIFailures(t *testing.T) {
+	path := writeImportFile(t, "suppressions.json", `[
+  {"email": "alice@example.com", "expires_at": "1y"},
+  {"email": "bob@example.com", "expires_at": "1y", "domain": "example.com"},
+  {"email": 42},
+  "carol@example.com"
+]`)
+
+	run := executeImport(t, "plain", func(m *mocks.MockClient) {
+		m.On("CreateSuppression", mock.MatchedBy(func(req requests.CreateSuppressionRequest) bool {
+			return req.Email == "alice@example.com"
+		})).Return(&responses.CreateSuppressionResponse{}, nil)
+		m.On("CreateSuppression", mock.MatchedBy(func(req requests.CreateSuppressionRequest) bool {
+			return req.Email == "bob@example.com"
+		})).Return(nil, &api.APIError{StatusCode: 422, Message: "domain not found"}).Once()
+	}, "--file", path, "--max-concurrency", "2")
+	require.Error(t, run.err)
+	assert.Contains(t, run.err.Error(), "failed to create 1 of 4 suppressions")
+
+	// Permanent errors are not retried
+	run.mockClient.AssertNumberOfCalls(t, "CreateSuppression", 2)
+	assert.Contains(t, run.stdout, "Row 2 bob@example.com: ")
+	assert.Contains(t, run.stdout, "Row 3 : email must be a string")
+	assert.Contains(t, run.stdout, "Row 4 : suppression must be a JSON object")
+	assert.Contains(t, run.stdout, "Imported 1 of 4 suppressions from "+path+", 2 invalid, 1 failed")
+	assert.Contains(t, run.stderr, "suppressions")
+}
+
+func TestImportCommand_Validation(t *testing.T) {
+	csvPath := writeImportFile(t, "suppressions.csv", "email\nalice@example.com\n")
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"missing file", []string{"--file", filepath.Join(t.TempDir(), "missing.csv")}, "cannot read suppressions file"},
+		{"unsupported format", []string{"--file", writeImportFile(t, "list.txt", "alice@example.com")}, "unsupported suppressions file format .txt"},
+		{"no email column", []string{"--file", writeImportFile(t, "bad.csv", "address\nalice@example.com\n")}, "must have an 'email' column"},
+		{"not an array", []string{"--file", writeImportFile(t, "bad.json", `{"email": "alice@example.com"}`)}, "must contain a JSON array"},
+		{"bad batch size", []string{"--file", csvPath, "--batch-size", "0"}, "--batch-size must be at least 1"},
+		{"bad concurrency", []string{"--file", csvPath, "--max-concurrency", "0"}, "--max-concurrency must be at least 1"},
+		{"bad expires", []string{"--file", csvPath, "--expires", "soon"}, "invalid format"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run := executeImport(t, "table", func(*mocks.MockClient) {}, tt.args...)
+			require.Error(t, run.err)
+			assert.Contains(t, run.err.Error(), tt.want)
+			run.mockClient.AssertNotCalled(t, "CreateSuppression", mock.Anything)
+		})
+	}
+}
+
+func TestImportSuppressions_Interrupted(t *testing.T) {
+	rows := []*importRow{{Row: 2, Email: "alice@example.com"}, {Row: 3, Email: "bob@example.com"}}
+	reqs := []requests.CreateSuppressionRequest{{Email: "alice@example.com"}, {Email: "bob@example.com"}}
+	mockClient := &mocks.MockClient{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	importSuppressions(ctx, mockClient, rows, reqs, 1, 1, 0, newProgressReporter(&cobra.Command{}, len(rows)))
+
+	mockClient.AssertNotCalled(t, "CreateSuppression", mock.Anything)
+	for _, row := range rows {
+		assert.Equal(t, interruptedRowError, row.err)
+	}
+}
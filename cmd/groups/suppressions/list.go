@@ -39,6 +39,7 @@ They can be filtered by email address, domain, creation time, and exported to JS
 	cmd.Flags().Int32("limit", 50, "Maximum number of suppressions to return")
 	cmd.Flags().String("cursor", "", "Pagination cursor for continued results")
 	cmd.Flags().String("domain", "", "Filter by specific domain")
+	printer.AddIDsOnlyFlag(cmd)
 
 	return cmd
 }
@@ -34,7 +34,10 @@ Examples:
   ahasend suppressions create user@example.com --reason unsubscribe
 
   # Delete a suppression
-  ahasend suppressions delete user@example.com`,
+  ahasend suppressions delete user@example.com
+
+  # Export all suppressions to a CSV file
+  ahasend suppressions export --output-file suppressions.csv`,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			// Call root command's PersistentPreRunE to initialize printer
 			// We need to traverse up to find the root command
@@ -44,6 +47,10 @@ Examples:
 					return err
 				}
 			}
+			// --schema prints the output shape without calling the API
+			if schema, _ := cmd.Flags().GetBool("schema"); schema {
+				return nil
+			}
 			return auth.RequireAuth(cmd)
 		},
 	}
@@ -53,6 +60,8 @@ Examples:
 	cmd.AddCommand(NewCheckCommand())
 	cmd.AddCommand(NewCreateCommand())
 	cmd.AddCommand(NewDeleteCommand())
+	cmd.AddCommand(NewImportCommand())
+	cmd.AddCommand(NewExportCommand())
 	cmd.AddCommand(NewWipeCommand())
 
 	return cmd
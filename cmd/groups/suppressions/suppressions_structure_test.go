@@ -19,7 +19,7 @@ import (
 func TestSuppressionsCommand_Structure(t *testing.T) {
 	// Create a fresh suppressions command and verify it has expected subcommands
 	suppressionsCmd := NewCommand()
-	expectedSubcommands := []string{"list", "check", "create", "delete", "wipe"}
+	expectedSubcommands := []string{"list", "check", "create", "delete", "export", "wipe"}
 
 	subcommands := make([]string, 0)
 	for _, cmd := range suppressionsCmd.Commands() {
@@ -60,8 +60,8 @@ func TestSuppressionsCommand_SubcommandCount(t *testing.T) {
 	cmd := NewCommand()
 	subcommands := cmd.Commands()
 
-	// Should have exactly 5 subcommands (list, check, create, delete, wipe)
-	assert.Equal(t, 5, len(subcommands), "suppressions command should have exactly 5 subcommands")
+	// Should have exactly 7 subcommands (list, check, create, delete, export, import, wipe)
+	assert.Equal(t, 7, len(subcommands), "suppressions command should have exactly 7 subcommands")
 }
 
 // Test list command structure and flags
@@ -414,7 +414,8 @@ func TestSuppressionsCommands_HelpOutputs(t *testing.T) {
 				"Delete an email address from the suppression list",
 				"--domain",
 				"--force",
-				"<email>",
+				"--match",
+				"[email]",
 			},
 		},
 		{
@@ -4,36 +4,66 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/bulk"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/glob"
 	"github.com/AhaSend/ahasend-cli/internal/logger"
 	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/progress"
+	"github.com/AhaSend/ahasend-go/models/common"
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
 	"github.com/spf13/cobra"
 )
 
+const (
+	// wipePageSize is the number of suppressions fetched per page when
+	// selecting suppressions for a scoped wipe
+	wipePageSize int32 = 100
+
+	// wipeProgressInterval is how often deletion progress is reported
+	wipeProgressInterval = 25
+)
+
 // NewWipeCommand creates the suppressions wipe command
 func NewWipeCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "wipe",
-		Short: "Delete all suppressions from your account",
-		Long: `Delete all suppressions from your account.
+		Short: "Delete all suppressions, or those matching a domain or pattern",
+		Long: `Delete all suppressions from your account, or only those for a domain
+or matching an email pattern.
 
-⚠️  DANGER: This command permanently deletes ALL suppressions in your account.
-This includes bounce suppressions, complaint suppressions, and manual suppressions.
-After running this command, you will be able to send emails to all previously
-suppressed addresses.
+⚠️  DANGER: Without --domain or --match, this command permanently deletes ALL
+suppressions in your account. This includes bounce suppressions, complaint
+suppressions, and manual suppressions. After running this command, you will be
+able to send emails to all previously suppressed addresses.
 
 This action:
 - Cannot be undone
-- Affects all domains in your account
+- Affects all domains in your account unless scoped
 - Removes all suppression types (bounce, complaint, unsubscribe, manual, abuse)
 - May result in sending emails to invalid addresses
 - May impact your sender reputation
 
+SCOPING:
+--domain limits the wipe to suppressions for one domain.
+--match selects suppressions whose email address matches a pattern, where *
+matches any sequence of characters and ? matches a single character (case
+insensitive). Matching suppressions are deleted one by one, with progress
+reported on stderr.
+
+Use --dry-run to see how many suppressions would be removed, broken down by
+reason, without deleting anything. Scoped wipes show the same summary before
+asking you to confirm by typing the number of suppressions.
+
 RECOMMENDED WORKFLOW:
 1. Create a backup: ahasend suppressions list --output json > backup.json
-2. Review the backup file
+2. Preview the wipe with --dry-run
 3. Run wipe command with confirmation
 4. Monitor your sending carefully after wipe
 
@@ -41,6 +71,12 @@ Use --force flag for automation (NOT recommended for production).`,
 		Example: `  # Wipe all suppressions (with confirmation)
   ahasend suppressions wipe
 
+  # See what wiping a domain's suppressions would remove
+  ahasend suppressions wipe --domain example.com --dry-run
+
+  # Wipe suppressions for addresses at one provider
+  ahasend suppressions wipe --match "*@competitor.com"
+
   # Create backup before wiping
   ahasend suppressions list --output json > suppressions-backup.json
   ahasend suppressions wipe
@@ -50,13 +86,16 @@ Use --force flag for automation (NOT recommended for production).`,
 
   # Wipe with JSON output for automation
   ahasend suppressions wipe --force --output json`,
-		Args: cobra.NoArgs,
-		RunE: runSuppressionsWipe,
+		Args:         cobra.NoArgs,
+		RunE:         runSuppressionsWipe,
+		SilenceUsage: true,
 	}
 
 	// Add flags
 	cmd.Flags().Bool("force", false, "Skip all confirmation prompts (DANGEROUS)")
-	cmd.Flags().String("domain", "", "Domain to wipe suppressions for")
+	cmd.Flags().String("domain", "", "Only wipe suppressions for this domain")
+	cmd.Flags().String("match", "", "Only wipe suppressions whose email matches this pattern (e.g. \"*@example.com\")")
+	cmd.Flags().Bool("dry-run", false, "Report what would be removed without deleting anything")
 	return cmd
 }
 
@@ -64,22 +103,63 @@ func runSuppressionsWipe(cmd *cobra.Command, args []string) error {
 	// Get response handler instance
 	handler := printer.GetResponseHandlerFromCommand(cmd)
 
+	// Get flag values
+	force, _ := cmd.Flags().GetBool("force")
+	domain, _ := cmd.Flags().GetString("domain")
+	match, _ := cmd.Flags().GetString("match")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	var pattern *glob.Pattern
+	if cmd.Flags().Changed("match") {
+		var err error
+		if pattern, err = glob.Compile(match, false); err != nil {
+			return err
+		}
+	}
+
 	client, err := auth.GetAuthenticatedClient(cmd)
 	if err != nil {
 		return err
 	}
 
-	// Get flag values
-	force, _ := cmd.Flags().GetBool("force")
-	domain, _ := cmd.Flags().GetString("domain")
 	var domainPtr *string
 	if domain != "" {
 		domainPtr = &domain
 	}
+	scoped := domain != "" || pattern != nil
+
+	logger.Get().WithFields(map[string]interface{}{
+		"force":   force,
+		"domain":  domain,
+		"match":   match,
+		"dry_run": dryRun,
+	}).Debug("Executing suppressions wipe command")
+
+	// Select the suppressions up front for dry runs, for the confirmation
+	// summary, and for pattern wipes, which delete them one by one
+	var selected []responses.Suppression
+	if dryRun || (scoped && !force) || pattern != nil {
+		if selected, err = selectSuppressions(client, domain, pattern); err != nil {
+			return err
+		}
+		if len(selected) == 0 {
+			return handler.HandleEmpty(fmt.Sprintf("No suppressions found%s", formatWipeScope(domain, match)))
+		}
+	}
+
+	wipeConfig := printer.WipeConfig{ItemName: "suppressions"}
+	if dryRun {
+		return handler.HandleSuppressionWipeSummary(newWipeSummary(domain, match, selected, true), wipeConfig)
+	}
 
 	// Show safety warning and get confirmation (unless --force is used)
 	if !force {
-		confirmed, err := confirmWipe()
+		var confirmed bool
+		if scoped {
+			confirmed, err = confirmScopedWipe(cmd, newWipeSummary(domain, match, selected, true))
+		} else {
+			confirmed, err = confirmWipe()
+		}
 		if err != nil {
 			return err
 		}
@@ -88,8 +168,28 @@ func runSuppressionsWipe(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Pattern wipes have no API of their own, so each suppression is deleted
+	if pattern != nil {
+		logger.Get().WithFields(map[string]interface{}{
+			"domain":  domain,
+			"match":   match,
+			"matched": len(selected),
+		}).Info("Deleting matching suppressions")
+
+		summary := newWipeSummary(domain, match, selected, false)
+		deleteSuppressions(client, selected, summary, newProgressReporter(cmd, len(selected)))
+		if err := handler.HandleSuppressionWipeSummary(summary, wipeConfig); err != nil {
+			return err
+		}
+		if summary.Failed > 0 {
+			return errors.NewAPIError(fmt.Sprintf("failed to delete %d of %d suppressions", summary.Failed, summary.Matched), nil)
+		}
+		return nil
+	}
+
 	logger.Get().WithFields(map[string]interface{}{
 		"force":  force,
+		"domain": domain,
 		"action": "wipe_all_suppressions",
 	}).Info("Wiping all suppressions")
 
@@ -111,6 +211,139 @@ func runSuppressionsWipe(cmd *cobra.Command, args []string) error {
 	})
 }
 
+// selectSuppressions pages through the suppression list and returns the
+// entries for the domain whose email matches the pattern. A nil pattern
+// matches every email.
+func selectSuppressions(apiClient client.AhaSendClient, domain string, pattern *glob.Pattern) ([]responses.Suppression, error) {
+	limit := wipePageSize
+	params := requests.GetSuppressionsParams{
+		PaginationParams: common.PaginationParams{Limit: &limit},
+	}
+	if domain != "" {
+		params.Domain = &domain
+	}
+
+	var selected []responses.Suppression
+	for {
+		page, err := apiClient.ListSuppressions(params)
+		if err != nil {
+			return nil, err
+		}
+		if page == nil {
+			break
+		}
+		for _, suppression := range page.Data {
+			if domain != "" && !strings.EqualFold(suppression.Domain, domain) {
+				continue
+			}
+			if pattern != nil && !pattern.Match(suppression.Email) {
+				continue
+			}
+			selected = append(selected, suppression)
+		}
+		if !page.Pagination.HasMore || page.Pagination.NextCursor == nil || *page.Pagination.NextCursor == "" {
+			break
+		}
+		params.Cursor = page.Pagination.NextCursor
+	}
+	return selected, nil
+}
+
+// deleteSuppressions deletes the selected suppressions, recording the outcome
+// in summary and reporting progress to reporter. Deleting only after the
+// whole list was read keeps the pagination cursor valid.
+func deleteSuppressions(apiClient client.AhaSendClient, selected []responses.Suppression, summary *printer.SuppressionWipeSummary, reporter *progress.Reporter) {
+	reporter.Start()
+	defer reporter.Finish()
+	for _, suppression := range selected {
+		var domainPtr *string
+		if suppression.Domain != "" {
+			domain := suppression.Domain
+			domainPtr = &domain
+		}
+
+		_, err := apiClient.DeleteSuppression(suppression.Email, domainPtr)
+		if err != nil {
+			summary.Failed++
+			summary.Failures = append(summary.Failures, printer.SuppressionWipeFailure{
+				Email:  suppression.Email,
+				Domain: suppression.Domain,
+				Error:  err.Error(),
+			})
+		} else {
+			summary.Deleted++
+		}
+		reporter.Update(err == nil)
+	}
+}
+
+// newProgressReporter reports the progress of creating or deleting total
+// suppressions on stderr: a line every wipeProgressInterval suppressions, or
+// JSON lines with --progress-format json
+func newProgressReporter(cmd *cobra.Command, total int) *progress.Reporter {
+	out := cmd.ErrOrStderr()
+	return progress.OptionsFromCommand(cmd).Reporter(total, out,
+		progress.NewCountReporter(total, out, "suppressions", wipeProgressInterval))
+}
+
+// newWipeSummary counts the selected suppressions by reason
+func newWipeSummary(domain, match string, selected []responses.Suppression, dryRun bool) *printer.SuppressionWipeSummary {
+	counts := make(map[string]int)
+	for _, suppression := range selected {
+		reason := suppression.Reason
+		if reason == "" {
+			reason = "unknown"
+		}
+		counts[reason]++
+	}
+
+	reasons := make([]printer.SuppressionReasonCount, 0, len(counts))
+	for reason, count := range counts {
+		reasons = append(reasons, printer.SuppressionReasonCount{Reason: reason, Count: count})
+	}
+	sort.Slice(reasons, func(i, j int) bool {
+		if reasons[i].Count != reasons[j].Count {
+			return reasons[i].Count > reasons[j].Count
+		}
+		return reasons[i].Reason < reasons[j].Reason
+	})
+
+	return &printer.SuppressionWipeSummary{
+		Domain:  domain,
+		Match:   match,
+		DryRun:  dryRun,
+		Matched: len(selected),
+		Reasons: reasons,
+	}
+}
+
+// formatWipeScope describes the wipe scope for messages
+func formatWipeScope(domain, match string) string {
+	scope := ""
+	if domain != "" {
+		scope += fmt.Sprintf(" for domain %s", domain)
+	}
+	if match != "" {
+		scope += fmt.Sprintf(" matching '%s'", match)
+	}
+	return scope
+}
+
+// confirmScopedWipe shows the dry-run summary on stderr and asks the user to
+// type the number of suppressions to delete
+func confirmScopedWipe(cmd *cobra.Command, summary *printer.SuppressionWipeSummary) (bool, error) {
+	if !bulk.IsInteractive(cmd.InOrStdin()) {
+		return false, errors.NewValidationError("refusing to wipe suppressions without confirmation; re-run with --force to proceed non-interactively", nil)
+	}
+
+	// The summary goes to stderr so structured output on stdout stays parseable
+	preview := printer.GetResponseHandler("table", false, cmd.ErrOrStderr())
+	if err := preview.HandleSuppressionWipeSummary(summary, printer.WipeConfig{ItemName: "suppressions"}); err != nil {
+		return false, err
+	}
+	return bulk.ConfirmCount(cmd.InOrStdin(), cmd.ErrOrStderr(), summary.Matched, "suppressions")
+}
+
 func confirmWipe() (bool, error) {
 	fmt.Println("🚨 DANGER: PERMANENT SUPPRESSION WIPE 🚨")
 	fmt.Println()
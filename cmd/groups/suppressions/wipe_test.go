@@ -0,0 +1,204 @@
+package suppressions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/AhaSend/ahasend-go/models/common"
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/progress"
+)
+
+type wipeRun struct {
+	stdout     string
+	stderr     string
+	err        error
+	mockClient *mocks.MockClient
+}
+
+// setupPagedSuppressions serves the suppressions over two pages
+func setupPagedSuppressions(mockClient *mocks.MockClient) {
+	nextCursor := "page-2"
+	firstPage := mockClient.NewMockSuppressionsResponse([]responses.Suppression{
+		*mockClient.NewMockSuppression("alice@competitor.com", "bounce", "example.com"),
+		*mockClient.NewMockSuppression("bob@customer.com", "bounce", "example.com"),
+		*mockClient.NewMockSuppression("carol@COMPETITOR.com", "complaint", "example.com"),
+	}, true)
+	firstPage.Pagination.NextCursor = &nextCursor
+	secondPage := mockClient.NewMockSuppressionsResponse([]responses.Suppression{
+		*mockClient.NewMockSuppression("dave@competitor.com", "bounce", "other.com"),
+		*mockClient.NewMockSuppression("erin@customer.com", "unsubscribe", "example.com"),
+	}, false)
+
+	mockClient.On("ListSuppressions", mock.MatchedBy(func(params requests.GetSuppressionsParams) bool {
+		return params.Cursor == nil
+	})).Return(firstPage, nil).Once()
+	mockClient.On("ListSuppressions", mock.MatchedBy(func(params requests.GetSuppressionsParams) bool {
+		return params.Cursor != nil && *params.Cursor == nextCursor
+	})).Return(secondPage, nil).Once()
+}
+
+func executeWipe(t *testing.T, format, stdin string, setup func(*mocks.MockClient), args ...string) wipeRun {
+	t.Helper()
+
+	mockClient := &mocks.MockClient{}
+	setup(mockClient)
+
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var stdout, stderr bytes.Buffer
+	handler := printer.GetResponseHandler(format, false, &stdout)
+	cmd := NewWipeCommand()
+	// The progress flags are persistent flags of the root command
+	cmd.Flags().String("progress-format", progress.FormatBar, "")
+	cmd.Flags().Duration("progress-interval", progress.DefaultInterval, "")
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	return wipeRun{stdout: stdout.String(), stderr: stderr.String(), err: err, mockClient: mockClient}
+}
+
+func TestWipe_DryRun(t *testing.T) {
+	run := executeWipe(t, "json", "", setupPagedSuppressions, "--match", "*@competitor.com", "--dry-run")
+	require.NoError(t, run.err)
+
+	var summary printer.SuppressionWipeSummary
+	require.NoError(t, json.Unmarshal([]byte(run.stdout), &summary))
+	assert.True(t, summary.DryRun)
+	assert.Equal(t, 3, summary.Matched)
+	assert.Equal(t, []printer.SuppressionReasonCount{
+		{Reason: "bounce", Count: 2},
+		{Reason: "complaint", Count: 1},
+	}, summary.Reasons)
+
+	run.mockClient.AssertNotCalled(t, "DeleteSuppression", mock.Anything, mock.Anything)
+	run.mockClient.AssertNotCalled(t, "WipeSuppressions", mock.Anything)
+	run.mockClient.AssertExpectations(t)
+}
+
+func TestWipe_MatchDeletesOnlyMatching(t *testing.T) {
+	run := executeWipe(t, "table", "", func(m *mocks.MockClient) {
+		setupPagedSuppressions(m)
+		m.On("DeleteSuppression", mock.Anything, mock.Anything).Return(&common.SuccessResponse{}, nil)
+	}, "--match", "*@competitor.com", "--domain", "example.com", "--force")
+	require.NoError(t, run.err)
+
+	run.mockClient.AssertNumberOfCalls(t, "DeleteSuppression", 2)
+	run.mockClient.AssertCalled(t, "DeleteSuppression", "alice@competitor.com", mock.MatchedBy(func(domain *string) bool {
+		return domain != nil && *domain == "example.com"
+	}))
+	run.mockClient.AssertCalled(t, "DeleteSuppression", "carol@COMPETITOR.com", mock.Anything)
+	run.mockClient.AssertNotCalled(t, "WipeSuppressions", mock.Anything)
+	assert.Contains(t, run.stdout, "Deleted 2 of 2 suppressions (domain example.com, matching '*@competitor.com')")
+	assert.Contains(t, run.stderr, "Processed 2/2 suppressions")
+}
+
+func TestWipe_JSONProgress(t *testing.T) {
+	run := executeWipe(t, "json", "", func(m *mocks.MockClient) {
+		setupPagedSuppressions(m)
+		m.On("DeleteSuppression", "alice@competitor.com", mock.Anything).Return(nil, assert.AnError)
+		m.On("DeleteSuppression", mock.Anything, mock.Anything).Return(&common.SuccessResponse{}, nil)
+	}, "--match", "*@competitor.com", "--force", "--progress-format", "json")
+	require.EqualError(t, run.err, "failed to delete 1 of 3 suppressions")
+	assert.NotContains(t, run.stderr, "Processed")
+
+	var summary printer.SuppressionWipeSummary
+	require.NoError(t, json.Unmarshal([]byte(run.stdout), &summary))
+
+	var final map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(run.stderr), "\n") {
+		if !strings.HasPrefix(line, "{") {
+			continue
+		}
+		final = nil
+		require.NoError(t, json.Unmarshal([]byte(line), &final), line)
+	}
+	require.NotNil(t, final)
+	assert.Equal(t, true, final["complete"])
+	assert.Equal(t, float64(summary.Matched), final["total"])
+	assert.Equal(t, float64(summary.Deleted), final["succeeded"])
+	assert.Equal(t, float64(summary.Failed), final["failed"])
+	assert.Equal(t, float64(summary.Deleted+summary.Failed), final["done"])
+	assert.Equal(t, 1, summary.Failed)
+}
+
+func TestWipe_ConfirmationShowsSummary(t *testing.T) {
+	t.Run("confirmed", func(t *testing.T) {
+		run := executeWipe(t, "json", "3\n", func(m *mocks.MockClient) {
+			setupPagedSuppressions(m)
+			m.On("DeleteSuppression", mock.Anything, mock.Anything).Return(&common.SuccessResponse{}, nil)
+		}, "--match", "*@competitor.com")
+		require.NoError(t, run.err)
+
+		assert.Contains(t, run.stderr, "Dry run: 3 suppressions would be removed")
+		assert.Contains(t, run.stderr, "Type 3 to confirm")
+		run.mockClient.AssertNumberOfCalls(t, "DeleteSuppression", 3)
+
+		var summary printer.SuppressionWipeSummary
+		require.NoError(t, json.Unmarshal([]byte(run.stdout), &summary))
+		assert.False(t, summary.DryRun)
+		assert.Equal(t, 3, summary.Deleted)
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		run := executeWipe(t, "table", "2\n", setupPagedSuppressions, "--match", "*@competitor.com")
+		require.NoError(t, run.err)
+		assert.Contains(t, run.stdout, "Suppression wipe cancelled")
+		run.mockClient.AssertNotCalled(t, "DeleteSuppression", mock.Anything, mock.Anything)
+	})
+
+	t.Run("domain only uses the wipe API", func(t *testing.T) {
+		run := executeWipe(t, "table", "4\n", func(m *mocks.MockClient) {
+			setupPagedSuppressions(m)
+			m.On("WipeSuppressions", mock.MatchedBy(func(domain *string) bool {
+				return domain != nil && *domain == "example.com"
+			})).Return(&common.SuccessResponse{}, nil).Once()
+		}, "--domain", "example.com")
+		require.NoError(t, run.err)
+		assert.Contains(t, run.stderr, "Type 4 to confirm")
+		run.mockClient.AssertNotCalled(t, "DeleteSuppression", mock.Anything, mock.Anything)
+		run.mockClient.AssertExpectations(t)
+	})
+}
+
+func TestWipe_PartialFailure(t *testing.T) {
+	run := executeWipe(t, "json", "", func(m *mocks.MockClient) {
+		setupPagedSuppressions(m)
+		m.On("DeleteSuppression", "alice@competitor.com", mock.Anything).Return(nil, assert.AnError)
+		m.On("DeleteSuppression", mock.Anything, mock.Anything).Return(&common.SuccessResponse{}, nil)
+	}, "--match", "*@competitor.com", "--force")
+	require.Error(t, run.err)
+	assert.Contains(t, run.err.Error(), "failed to delete 1 of 3 suppressions")
+
+	var summary printer.SuppressionWipeSummary
+	require.NoError(t, json.Unmarshal([]byte(run.stdout), &summary))
+	assert.Equal(t, 2, summary.Deleted)
+	require.Len(t, summary.Failures, 1)
+	assert.Equal(t, "alice@competitor.com", summary.Failures[0].Email)
+}
+
+func TestWipe_NoMatches(t *testing.T) {
+	run := executeWipe(t, "table", "", setupPagedSuppressions, "--match", "*@nobody.com", "--dry-run")
+	require.NoError(t, run.err)
+	assert.Contains(t, run.stdout, "No suppressions found matching '*@nobody.com'")
+}
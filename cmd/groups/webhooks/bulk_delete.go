@@ -0,0 +1,112 @@
+package webhooks
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/bulk"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/fetch"
+	"github.com/AhaSend/ahasend-cli/internal/glob"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/spf13/cobra"
+)
+
+// bulkOnlyFlags only apply together with --matching
+var bulkOnlyFlags = []string{"enabled-only", "case-sensitive", "yes", "concurrency"}
+
+// deleteArgs requires a webhook ID unless --matching selects the webhooks
+func deleteArgs(cmd *cobra.Command, args []string) error {
+	if cmd.Flags().Changed("matching") {
+		if len(args) > 0 {
+			return errors.NewValidationError("a webhook ID cannot be combined with --matching", nil)
+		}
+		return nil
+	}
+	for _, flag := range bulkOnlyFlags {
+		if cmd.Flags().Changed(flag) {
+			return errors.NewValidationError(fmt.Sprintf("--%s requires --matching", flag), nil)
+		}
+	}
+	return cobra.ExactArgs(1)(cmd, args)
+}
+
+func runWebhooksBulkDelete(cmd *cobra.Command) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+
+	matching, _ := cmd.Flags().GetString("matching")
+	enabledOnly, _ := cmd.Flags().GetBool("enabled-only")
+	caseSensitive, _ := cmd.Flags().GetBool("case-sensitive")
+	yes, _ := cmd.Flags().GetBool("yes")
+	force, _ := cmd.Flags().GetBool("force")
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+
+	if concurrency < 1 {
+		return errors.NewValidationError("--concurrency must be at least 1", nil)
+	}
+	pattern, err := glob.Compile(matching, caseSensitive)
+	if err != nil {
+		return err
+	}
+
+	apiClient, err := auth.GetAuthenticatedClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	logger.Get().WithFields(map[string]interface{}{
+		"matching":       matching,
+		"enabled_only":   enabledOnly,
+		"case_sensitive": caseSensitive,
+		"concurrency":    concurrency,
+	}).Debug("Executing webhooks bulk delete command")
+
+	return bulk.Run(handler, cmd.InOrStdin(), cmd.ErrOrStderr(), bulk.Selection[responses.Webhook]{
+		Pattern:      matching,
+		Singular:     "webhook",
+		Plural:       "webhooks",
+		EmptyMessage: fmt.Sprintf("No webhooks match '%s'", matching),
+		Yes:          yes || force,
+		Concurrency:  concurrency,
+		List: func() ([]responses.Webhook, error) {
+			all, err := fetch.AllWebhooks(apiClient)
+			if err != nil {
+				return nil, err
+			}
+			return filterWebhooksByName(all.Data, pattern, enabledOnly), nil
+		},
+		Target: func(webhook responses.Webhook) bulk.Target {
+			return bulk.Target{ID: webhook.ID.String(), Name: webhook.Name}
+		},
+		Preview: func(out io.Writer, matched []responses.Webhook) error {
+			preview := printer.GetResponseHandler("table", false, out)
+			if err := preview.HandleWebhookList(&responses.PaginatedWebhooksResponse{Data: matched}, printer.ListConfig{
+				FieldOrder: []string{"id", "name", "url", "enabled", "event_types"},
+			}); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "%d webhooks match '%s'\n", len(matched), matching)
+			return nil
+		},
+		Delete: func(webhook responses.Webhook) error {
+			return apiClient.DeleteWebhook(webhook.ID.String())
+		},
+	})
+}
+
+// filterWebhooksByName returns the webhooks whose name matches the pattern
+func filterWebhooksByName(webhookList []responses.Webhook, pattern *glob.Pattern, enabledOnly bool) []responses.Webhook {
+	var matched []responses.Webhook
+	for _, webhook := range webhookList {
+		if enabledOnly && !webhook.Enabled {
+			continue
+		}
+		if pattern.Match(webhook.Name) {
+			matched = append(matched, webhook)
+		}
+	}
+	return matched
+}
@@ -0,0 +1,164 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type bulkDeleteRun struct {
+	stdout     string
+	stderr     string
+	err        error
+	mockClient *mocks.MockClient
+}
+
+func executeBulkDelete(t *testing.T, format, stdin string, webhookList []responses.Webhook, setup func(*mocks.MockClient), args ...string) bulkDeleteRun {
+	t.Helper()
+
+	mockClient := &mocks.MockClient{}
+	mockClient.On("ListWebhooks", (*int32)(nil), (*string)(nil)).Return(&responses.PaginatedWebhooksResponse{
+		Object: "list",
+		Data:   webhookList,
+	}, nil)
+	if setup != nil {
+		setup(mockClient)
+	}
+
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var stdout, stderr bytes.Buffer
+	handler := printer.GetResponseHandler(format, false, &stdout)
+	cmd := NewDeleteCommand()
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	return bulkDeleteRun{stdout: stdout.String(), stderr: stderr.String(), err: err, mockClient: mockClient}
+}
+
+func loadTestWebhooks() []responses.Webhook {
+	return []responses.Webhook{
+		createTestWebhook(uuid.New().String(), "test-1", "https://example.com/1", true),
+		createTestWebhook(uuid.New().String(), "TEST-2", "https://example.com/2", false),
+		createTestWebhook(uuid.New().String(), "production", "https://example.com/prod", true),
+	}
+}
+
+func TestBulkDelete_TypedConfirmation(t *testing.T) {
+	webhookList := loadTestWebhooks()
+
+	t.Run("confirmed", func(t *testing.T) {
+		run := executeBulkDelete(t, "plain", "2\n", webhookList, func(m *mocks.MockClient) {
+			m.On("DeleteWebhook", webhookList[0].ID.String()).Return(nil).Once()
+			m.On("DeleteWebhook", webhookList[1].ID.String()).Return(nil).Once()
+		}, "--matching", "test-*")
+
+		require.NoError(t, run.err)
+		assert.Contains(t, run.stderr, "2 webhooks match 'test-*'")
+		assert.Contains(t, run.stderr, "Type 2 to confirm")
+		assert.NotContains(t, run.stderr, "production")
+		assert.Contains(t, run.stdout, "Deleted 2 of 2 webhooks matching 'test-*'")
+		run.mockClient.AssertExpectations(t)
+	})
+
+	t.Run("wrong count cancels", func(t *testing.T) {
+		run := executeBulkDelete(t, "plain", "y\n", webhookList, nil, "--matching", "test-*")
+
+		require.NoError(t, run.err)
+		assert.Contains(t, run.stdout, "Webhook deletion cancelled")
+		run.mockClient.AssertNotCalled(t, "DeleteWebhook", webhookList[0].ID.String())
+	})
+}
+
+func TestBulkDelete_Filters(t *testing.T) {
+	webhookList := loadTestWebhooks()
+
+	t.Run("case-sensitive", func(t *testing.T) {
+		run := executeBulkDelete(t, "plain", "", webhookList, func(m *mocks.MockClient) {
+			m.On("DeleteWebhook", webhookList[0].ID.String()).Return(nil).Once()
+		}, "--matching", "test-*", "--case-sensitive", "--yes")
+
+		require.NoError(t, run.err)
+		assert.Contains(t, run.stdout, "Deleted 1 of 1 webhooks")
+		run.mockClient.AssertExpectations(t)
+	})
+
+	t.Run("enabled only", func(t *testing.T) {
+		run := executeBulkDelete(t, "plain", "", webhookList, func(m *mocks.MockClient) {
+			m.On("DeleteWebhook", webhookList[0].ID.String()).Return(nil).Once()
+		}, "--matching", "test-*", "--enabled-only", "--yes")
+
+		require.NoError(t, run.err)
+		run.mockClient.AssertExpectations(t)
+	})
+
+	t.Run("no matches", func(t *testing.T) {
+		run := executeBulkDelete(t, "plain", "", webhookList, nil, "--matching", "staging-*", "--yes")
+
+		require.NoError(t, run.err)
+		assert.Contains(t, run.stdout, "No webhooks match 'staging-*'")
+	})
+}
+
+func TestBulkDelete_PartialFailure(t *testing.T) {
+	webhookList := loadTestWebhooks()
+
+	run := executeBulkDelete(t, "json", "", webhookList, func(m *mocks.MockClient) {
+		m.On("DeleteWebhook", webhookList[0].ID.String()).Return(errors.New("server error")).Once()
+		m.On("DeleteWebhook", webhookList[1].ID.String()).Return(nil).Once()
+	}, "--matching", "test-*", "--yes", "--concurrency", "1")
+
+	require.Error(t, run.err)
+	assert.Contains(t, run.err.Error(), "failed to delete 1 of 2 webhooks")
+	run.mockClient.AssertExpectations(t)
+
+	var decoded printer.BulkDeleteResult
+	require.NoError(t, json.Unmarshal([]byte(run.stdout), &decoded))
+	assert.Equal(t, 2, decoded.Matched)
+	assert.Equal(t, 1, decoded.Deleted)
+	assert.Equal(t, 1, decoded.Failed)
+	require.Len(t, decoded.Items, 2)
+	assert.Equal(t, "server error", decoded.Items[0].Error)
+	assert.True(t, decoded.Items[1].Deleted)
+}
+
+func TestDeleteArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"ID with matching", []string{"abc", "--matching", "test-*"}, "cannot be combined"},
+		{"bulk flag without matching", []string{"abc", "--yes"}, "--yes requires --matching"},
+		{"missing ID", []string{}, "accepts 1 arg"},
+		{"invalid concurrency", []string{"--matching", "test-*", "--concurrency", "0"}, "--concurrency must be at least 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run := executeBulkDelete(t, "plain", "", nil, nil, tt.args...)
+			require.Error(t, run.err)
+			assert.Contains(t, run.err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package webhooks
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/webhooks"
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/spf13/cobra"
+)
+
+// webhookCopy is a create request built from a source webhook, with the
+// names of the settings taken from the source and of those set by flags
+type webhookCopy struct {
+	Request    requests.CreateWebhookRequest
+	Inherited  []string
+	Overridden []string
+}
+
+// runWebhooksCopy creates a webhook with the settings of the --copy-from
+// webhook, overridden by the flags that were given. The source is fetched
+// before anything is created, so a missing source creates nothing.
+func runWebhooksCopy(cmd *cobra.Command, handler printer.ResponseHandler, apiClient client.AhaSendClient, sourceID string) error {
+	webhookURL, _ := cmd.Flags().GetString("url")
+	if webhookURL == "" {
+		return errors.NewValidationError("--url is required with --copy-from, since the copy must not deliver to the same endpoint", nil)
+	}
+	if err := validateWebhookURL(webhookURL); err != nil {
+		return err
+	}
+
+	source, err := apiClient.GetWebhook(sourceID)
+	if err != nil {
+		return err
+	}
+	if source == nil {
+		return errors.NewNotFoundError(fmt.Sprintf("webhook %s not found", sourceID), nil)
+	}
+	if strings.EqualFold(strings.TrimSpace(source.URL), strings.TrimSpace(webhookURL)) {
+		return errors.NewValidationError(fmt.Sprintf("--url must differ from the URL of webhook %s (%s)", sourceID, source.URL), nil)
+	}
+
+	copied, err := copyWebhookRequest(cmd, source)
+	if err != nil {
+		return err
+	}
+
+	logger.Get().WithFields(map[string]interface{}{
+		"source_webhook_id": sourceID,
+		"name":              copied.Request.Name,
+		"url":               copied.Request.URL,
+		"inherited":         copied.Inherited,
+		"overridden":        copied.Overridden,
+	}).Debug("Copying webhook")
+
+	if err := checkDuplicateWebhookName(cmd, apiClient, copied.Request.Name); err != nil {
+		return err
+	}
+
+	webhook, err := createWebhook(apiClient, copied.Request)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.ErrOrStderr()
+	fmt.Fprintf(out, "Copied from webhook '%s' (%s)\n", source.Name, source.ID)
+	fmt.Fprintf(out, "  Inherited: %s\n", strings.Join(copied.Inherited, ", "))
+	fmt.Fprintf(out, "  Overridden: %s\n", strings.Join(copied.Overridden, ", "))
+
+	return handler.HandleCreateWebhook(webhook, printer.CreateConfig{
+		SuccessMessage: fmt.Sprintf("Successfully created webhook: %s", webhook.Name),
+		ItemName:       "webhook",
+		FieldOrder:     []string{"id", "name", "url", "enabled", "event_types", "scope", "domains", "created_at"},
+	})
+}
+
+// copyWebhookRequest builds the create request for a copy of source. Each
+// setting comes from the source unless its flag was given; the URL always
+// comes from --url, and the name defaults to the source's with " (copy)".
+func copyWebhookRequest(cmd *cobra.Command, source *responses.Webhook) (*webhookCopy, error) {
+	flags := cmd.Flags()
+	webhookURL, _ := flags.GetString("url")
+	copied := &webhookCopy{
+		Request:    requests.CreateWebhookRequest{URL: webhookURL},
+		Overridden: []string{"url"},
+	}
+	setting := func(name string, overridden bool) {
+		if overridden {
+			copied.Overridden = append(copied.Overridden, name)
+		} else {
+			copied.Inherited = append(copied.Inherited, name)
+		}
+	}
+
+	name, _ := flags.GetString("name")
+	if name == "" {
+		name = source.Name + " (copy)"
+	}
+	copied.Request.Name = name
+	setting("name", flags.Changed("name"))
+
+	enabled := source.Enabled
+	if flags.Changed("disabled") {
+		disabled, _ := flags.GetBool("disabled")
+		enabled = !disabled
+	}
+	copied.Request.Enabled = &enabled
+	setting("enabled", flags.Changed("disabled"))
+
+	allEvents, _ := flags.GetBool("all-events")
+	events, _ := flags.GetStringSlice("events")
+	switch {
+	case allEvents:
+		webhooks.SetAllCreateEvents(&copied.Request)
+	case flags.Changed("events"):
+		validated, err := validateEventTypes(events)
+		if err != nil {
+			return nil, err
+		}
+		webhooks.SetCreateEvents(&copied.Request, validated)
+	default:
+		webhooks.SetCreateEvents(&copied.Request, webhooks.SubscribedEvents(source))
+	}
+	setting("events", allEvents || flags.Changed("events"))
+
+	copied.Request.Scope = source.Scope
+	if flags.Changed("scope") {
+		copied.Request.Scope, _ = flags.GetString("scope")
+	}
+	setting("scope", flags.Changed("scope"))
+
+	domains := append([]string{}, source.Domains...)
+	if flags.Changed("domains") {
+		domains, _ = flags.GetStringSlice("domains")
+	}
+	if len(domains) > 0 {
+		copied.Request.Domains = &domains
+	}
+	setting("domains", flags.Changed("domains"))
+
+	return copied, nil
+}
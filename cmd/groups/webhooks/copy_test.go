@@ -0,0 +1,144 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// executeWebhookCopy runs webhooks create --copy-from against a source
+// webhook subscribed to reception, delivered and bounced events
+func executeWebhookCopy(t *testing.T, args ...string) (string, error, *mocks.MockClient) {
+	t.Helper()
+
+	source := createTestWebhookWithEvents()
+	source.Name = "production"
+	source.URL = "https://example.com/prod"
+	source.Enabled = false
+
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetWebhook", source.ID.String()).Return(&source, nil)
+	mockClient.On("GetWebhook", mock.Anything).Return(nil, errors.New("webhook not found"))
+	mockClient.On("ListWebhooks", (*int32)(nil), (*string)(nil)).Return(&responses.PaginatedWebhooksResponse{
+		Data: []responses.Webhook{source},
+	}, nil)
+	created := createTestWebhook(uuid.New().String(), "copy", "https://staging.example.com/hook", true)
+	mockClient.On("CreateWebhook", mock.AnythingOfType("requests.CreateWebhookRequest")).Return(&created, nil)
+
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var stdout, stderr bytes.Buffer
+	handler := printer.GetResponseHandler("plain", false, &stdout)
+	cmd := NewCreateCommand()
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	for i, arg := range args {
+		if arg == "SOURCE" {
+			args[i] = source.ID.String()
+		}
+	}
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	return stderr.String(), err, mockClient
+}
+
+func createdWebhookRequest(t *testing.T, mockClient *mocks.MockClient) requests.CreateWebhookRequest {
+	t.Helper()
+	for _, call := range mockClient.Calls {
+		if call.Method == "CreateWebhook" {
+			return call.Arguments.Get(0).(requests.CreateWebhookRequest)
+		}
+	}
+	t.Fatal("CreateWebhook was not called")
+	return requests.CreateWebhookRequest{}
+}
+
+func TestWebhooksCreate_CopyFrom(t *testing.T) {
+	t.Run("inherits every setting but the URL", func(t *testing.T) {
+		stderr, err, mockClient := executeWebhookCopy(t, "--copy-from", "SOURCE", "--url", "https://staging.example.com/hook")
+		require.NoError(t, err)
+
+		req := createdWebhookRequest(t, mockClient)
+		assert.Equal(t, "production (copy)", req.Name)
+		assert.Equal(t, "https://staging.example.com/hook", req.URL)
+		require.NotNil(t, req.Enabled)
+		assert.False(t, *req.Enabled)
+		assert.True(t, req.OnReception)
+		assert.True(t, req.OnDelivered)
+		assert.True(t, req.OnBounced)
+		assert.False(t, req.OnOpened)
+		assert.Equal(t, "account", req.Scope)
+		require.NotNil(t, req.Domains)
+		assert.Equal(t, []string{"example.com", "test.com"}, *req.Domains)
+
+		assert.Contains(t, stderr, "Copied from webhook 'production'")
+		assert.Contains(t, stderr, "Inherited: name, enabled, events, scope, domains")
+		assert.Contains(t, stderr, "Overridden: url")
+	})
+
+	t.Run("flags override copied settings", func(t *testing.T) {
+		stderr, err, mockClient := executeWebhookCopy(t, "--copy-from", "SOURCE", "--url", "https://staging.example.com/hook",
+			"--name", "staging", "--events", "opened,clicked", "--domains", "staging.example.com", "--disabled=false")
+		require.NoError(t, err)
+
+		req := createdWebhookRequest(t, mockClient)
+		assert.Equal(t, "staging", req.Name)
+		assert.True(t, *req.Enabled)
+		assert.True(t, req.OnOpened)
+		assert.True(t, req.OnClicked)
+		assert.False(t, req.OnReception)
+		assert.Equal(t, "account", req.Scope)
+		assert.Equal(t, []string{"staging.example.com"}, *req.Domains)
+
+		assert.Contains(t, stderr, "Inherited: scope")
+		assert.Contains(t, stderr, "Overridden: url, name, enabled, events, domains")
+	})
+
+	t.Run("missing source creates nothing", func(t *testing.T) {
+		_, err, mockClient := executeWebhookCopy(t, "--copy-from", uuid.New().String(), "--url", "https://staging.example.com/hook")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "webhook not found")
+		mockClient.AssertNotCalled(t, "CreateWebhook", mock.Anything)
+	})
+
+	t.Run("URL is required and must differ", func(t *testing.T) {
+		_, err, mockClient := executeWebhookCopy(t, "--copy-from", "SOURCE")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--url is required with --copy-from")
+		mockClient.AssertNotCalled(t, "GetWebhook", mock.Anything)
+
+		_, err, mockClient = executeWebhookCopy(t, "--copy-from", "SOURCE", "--url", "https://example.com/prod")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--url must differ")
+		mockClient.AssertNotCalled(t, "CreateWebhook", mock.Anything)
+	})
+
+	t.Run("invalid combinations", func(t *testing.T) {
+		_, err, _ := executeWebhookCopy(t, "--copy-from", "SOURCE", "--url", "https://staging.example.com/hook", "--interactive")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--copy-from cannot be combined with --interactive")
+
+		_, err, mockClient := executeWebhookCopy(t, "--copy-from", "SOURCE", "--url", "https://staging.example.com/hook", "--events", "nope")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid event types: nope")
+		mockClient.AssertNotCalled(t, "CreateWebhook", mock.Anything)
+	})
+}
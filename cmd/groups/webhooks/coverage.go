@@ -0,0 +1,240 @@
+package webhooks
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/fetch"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/output"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/webhooks"
+	"github.com/AhaSend/ahasend-go/models/common"
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/spf13/cobra"
+)
+
+// suppressionCountLimit caps how many suppressions are counted for the
+// suppression.created activity; a full page means "at least this many"
+const suppressionCountLimit int32 = 100
+
+// NewCoverageCommand creates the coverage command
+func NewCoverageCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "coverage",
+		Short: "Report which events your webhooks cover",
+		Long: `Compare the events your enabled webhooks subscribe to with recent account
+activity. Every known event type is listed with the webhooks subscribed to it
+and the number of matching events since --since.
+
+Findings:
+  GAP   Activity occurred but no enabled webhook subscribes to the event
+  INFO  No enabled webhook subscribes to the event and no activity was seen
+
+Events are listed by the event type name delivered in webhook payloads.
+Activity comes from deliverability statistics. suppression.created activity is
+the number of suppressions created in the period (shown as "100+" when there
+are more), and domain.dns_error activity is not available.
+
+Use --fail-on-gaps in CI to exit with an error when any GAP is found.`,
+		Example: `  # Coverage for the last 7 days
+  ahasend webhooks coverage
+
+  # Look back 30 days
+  ahasend webhooks coverage --since 30d
+
+  # Fail a CI job when activity is not covered
+  ahasend webhooks coverage --fail-on-gaps --output json`,
+		RunE:         runWebhooksCoverage,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().String("since", "7d", "Start of the activity period (RFC3339 or relative like '24h', '7d')")
+	cmd.Flags().Bool("fail-on-gaps", false, "Exit with an error when an event has activity but no subscribed webhook")
+
+	return cmd
+}
+
+func runWebhooksCoverage(cmd *cobra.Command, args []string) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+
+	sinceStr, _ := cmd.Flags().GetString("since")
+	failOnGaps, _ := cmd.Flags().GetBool("fail-on-gaps")
+
+	since, err := output.ParseTimePast(sinceStr)
+	if err != nil {
+		return errors.NewValidationError(fmt.Sprintf("invalid since: %v", err), nil)
+	}
+
+	apiClient, err := auth.GetAuthenticatedClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	logger.Get().WithFields(map[string]interface{}{
+		"since":        since,
+		"fail_on_gaps": failOnGaps,
+	}).Debug("Executing webhooks coverage command")
+
+	webhookList, err := fetch.AllWebhooks(apiClient)
+	if err != nil {
+		return err
+	}
+
+	activity, capped, err := fetchEventActivity(apiClient, since, time.Now())
+	if err != nil {
+		return err
+	}
+
+	report := buildCoverageReport(webhookList.Data, activity, capped, since)
+	if err := handler.HandleWebhookCoverage(report, printer.SingleConfig{
+		EmptyMessage: "No coverage data available",
+	}); err != nil {
+		return err
+	}
+
+	if gaps := report.Gaps(); failOnGaps && len(gaps) > 0 {
+		events := make([]string, len(gaps))
+		for i, gap := range gaps {
+			events[i] = gap.Event
+		}
+		return errors.NewChecksFailedError(fmt.Sprintf("webhook coverage gaps found for: %s", strings.Join(events, ", ")), nil)
+	}
+	return nil
+}
+
+// fetchEventActivity returns the number of events per event key in the
+// period. Events without an activity source are absent from the map; capped
+// lists the events whose count is a lower bound.
+func fetchEventActivity(apiClient client.AhaSendClient, from, to time.Time) (map[string]int, map[string]bool, error) {
+	stats, err := apiClient.GetDeliverabilityStatistics(requests.GetDeliverabilityStatisticsParams{
+		FromTime: &from,
+		ToTime:   &to,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	activity := deliverabilityActivity(stats)
+
+	limit := suppressionCountLimit
+	suppressions, err := apiClient.ListSuppressions(requests.GetSuppressionsParams{
+		FromTime:         &from,
+		ToTime:           &to,
+		PaginationParams: common.PaginationParams{Limit: &limit},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	capped := map[string]bool{}
+	if suppressions != nil {
+		activity["suppression_created"] = len(suppressions.Data)
+		capped["suppression_created"] = suppressions.Pagination.HasMore
+	}
+	return activity, capped, nil
+}
+
+// deliverabilityActivity maps deliverability counters to the webhook events
+// they correspond to
+func deliverabilityActivity(response *responses.DeliverabilityStatisticsResponse) map[string]int {
+	activity := map[string]int{
+		"reception":       0,
+		"delivered":       0,
+		"transient_error": 0,
+		"failed":          0,
+		"bounced":         0,
+		"suppressed":      0,
+		"opened":          0,
+		"clicked":         0,
+	}
+	if response == nil {
+		return activity
+	}
+	for _, stat := range response.Data {
+		activity["reception"] += stat.ReceptionCount
+		activity["delivered"] += stat.DeliveredCount
+		activity["transient_error"] += stat.DeferredCount
+		activity["failed"] += stat.FailedCount
+		activity["bounced"] += stat.BouncedCount
+		activity["suppressed"] += stat.SuppressedCount
+		activity["opened"] += stat.OpenedCount
+		activity["clicked"] += stat.ClickedCount
+	}
+	return activity
+}
+
+// buildCoverageReport cross-references the enabled webhooks' subscriptions
+// with the activity per event key. Events are reported by their event type
+// name. It performs no I/O.
+func buildCoverageReport(webhookList []responses.Webhook, activity map[string]int, capped map[string]bool, since time.Time) *printer.WebhookCoverageReport {
+	report := &printer.WebhookCoverageReport{
+		Since:    since,
+		Webhooks: []printer.CoverageWebhook{},
+		Events:   []printer.EventCoverage{},
+		Findings: []printer.CoverageFinding{},
+	}
+
+	var enabled []responses.Webhook
+	for _, webhook := range webhookList {
+		if !webhook.Enabled {
+			continue
+		}
+		enabled = append(enabled, webhook)
+		report.Webhooks = append(report.Webhooks, printer.CoverageWebhook{
+			ID:   webhook.ID.String(),
+			Name: webhook.Name,
+			URL:  webhook.URL,
+		})
+	}
+
+	for _, event := range webhooks.EventTypes() {
+		coverage := printer.EventCoverage{
+			Event:       event.Name,
+			Description: event.Description,
+			Subscribers: []string{},
+		}
+		for i := range enabled {
+			if webhooks.IsSubscribed(&enabled[i], event.Key) {
+				coverage.Subscribers = append(coverage.Subscribers, enabled[i].ID.String())
+			}
+		}
+		if count, ok := activity[event.Key]; ok {
+			coverage.Activity = &count
+			coverage.ActivityCapped = capped[event.Key]
+		}
+		report.Events = append(report.Events, coverage)
+
+		if len(coverage.Subscribers) > 0 {
+			continue
+		}
+		if coverage.Activity != nil && *coverage.Activity > 0 {
+			count := formatActivityCount(*coverage.Activity, coverage.ActivityCapped)
+			report.Findings = append(report.Findings, printer.CoverageFinding{
+				Event:    event.Name,
+				Severity: printer.CoverageSeverityGap,
+				Message:  fmt.Sprintf("%s: %s events since %s but no enabled webhook subscribes to it", event.Name, count, since.Format(time.RFC3339)),
+			})
+		} else {
+			report.Findings = append(report.Findings, printer.CoverageFinding{
+				Event:    event.Name,
+				Severity: printer.CoverageSeverityInfo,
+				Message:  fmt.Sprintf("%s: no enabled webhook subscribes to it", event.Name),
+			})
+		}
+	}
+
+	return report
+}
+
+// formatActivityCount renders a count, marking lower bounds with "+"
+func formatActivityCount(count int, capped bool) string {
+	if capped {
+		return fmt.Sprintf("%d+", count)
+	}
+	return fmt.Sprintf("%d", count)
+}
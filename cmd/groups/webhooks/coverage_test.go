@@ -0,0 +1,180 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	clierrors "github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	internalwebhooks "github.com/AhaSend/ahasend-cli/internal/webhooks"
+	"github.com/AhaSend/ahasend-go/models/common"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func coverageWebhooks() []responses.Webhook {
+	delivery := createTestWebhook(uuid.New().String(), "Delivery", "https://example.com/delivery", true)
+	delivery.OnDelivered = true
+	delivery.OnFailed = true
+
+	disabled := createTestWebhook(uuid.New().String(), "Disabled", "https://example.com/disabled", false)
+	disabled.OnBounced = true
+	disabled.OnSuppressionCreated = true
+
+	return []responses.Webhook{delivery, disabled}
+}
+
+func findFinding(report *printer.WebhookCoverageReport, event string) *printer.CoverageFinding {
+	for i := range report.Findings {
+		if report.Findings[i].Event == event {
+			return &report.Findings[i]
+		}
+	}
+	return nil
+}
+
+func TestBuildCoverageReport(t *testing.T) {
+	since := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	activity := map[string]int{"delivered": 50, "bounced": 3, "opened": 0, "suppression_created": 100}
+	capped := map[string]bool{"suppression_created": true}
+
+	report := buildCoverageReport(coverageWebhooks(), activity, capped, since)
+
+	// Only enabled webhooks take part
+	require.Len(t, report.Webhooks, 1)
+	assert.Equal(t, "Delivery", report.Webhooks[0].Name)
+
+	// Every known event type is listed, in the shared order
+	require.Len(t, report.Events, len(internalwebhooks.EventTypes()))
+	for i, event := range internalwebhooks.EventTypes() {
+		assert.Equal(t, event.Name, report.Events[i].Event)
+	}
+
+	assert.Nil(t, findFinding(report, "message.delivered"), "covered events have no finding")
+
+	bounced := findFinding(report, "message.bounced")
+	require.NotNil(t, bounced)
+	assert.Equal(t, printer.CoverageSeverityGap, bounced.Severity)
+	assert.Contains(t, bounced.Message, "3 events")
+
+	suppressions := findFinding(report, "suppression.created")
+	require.NotNil(t, suppressions)
+	assert.Equal(t, printer.CoverageSeverityGap, suppressions.Severity)
+	assert.Contains(t, suppressions.Message, "100+ events")
+
+	opened := findFinding(report, "message.opened")
+	require.NotNil(t, opened)
+	assert.Equal(t, printer.CoverageSeverityInfo, opened.Severity)
+
+	dnsError := findFinding(report, "domain.dns_error")
+	require.NotNil(t, dnsError)
+	assert.Equal(t, printer.CoverageSeverityInfo, dnsError.Severity, "events without activity data are not gaps")
+
+	assert.Len(t, report.Gaps(), 2)
+}
+
+func TestDeliverabilityActivity_KeysAreKnownEvents(t *testing.T) {
+	activity := deliverabilityActivity(&responses.DeliverabilityStatisticsResponse{
+		Data: []responses.DeliverabilityStatistics{
+			{DeferredCount: 2, BouncedCount: 1},
+			{DeferredCount: 3},
+		},
+	})
+	for key := range activity {
+		assert.True(t, internalwebhooks.IsValidEvent(key), "unknown event key %q", key)
+	}
+	assert.Equal(t, 5, activity["transient_error"])
+	assert.Equal(t, 1, activity["bounced"])
+}
+
+func executeCoverageCommand(t *testing.T, format string, args ...string) (string, error) {
+	t.Helper()
+
+	mockClient := &mocks.MockClient{}
+	mockClient.On("ListWebhooks", (*int32)(nil), (*string)(nil)).Return(&responses.PaginatedWebhooksResponse{
+		Object: "list",
+		Data:   coverageWebhooks(),
+	}, nil)
+	mockClient.On("GetDeliverabilityStatistics", mock.Anything).Return(&responses.DeliverabilityStatisticsResponse{
+		Data: []responses.DeliverabilityStatistics{{ReceptionCount: 10, DeliveredCount: 8, FailedCount: 1}},
+	}, nil)
+	mockClient.On("ListSuppressions", mock.Anything).Return(&responses.PaginatedSuppressionsResponse{
+		Object:     "list",
+		Data:       []responses.Suppression{{Email: "a@example.com"}},
+		Pagination: common.PaginationInfo{HasMore: false},
+	}, nil)
+
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var buf bytes.Buffer
+	handler := printer.GetResponseHandler(format, false, &buf)
+	cmd := NewCoverageCommand()
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+func TestCoverageCommand(t *testing.T) {
+	t.Run("table", func(t *testing.T) {
+		out, err := executeCoverageCommand(t, "table")
+		require.NoError(t, err)
+		assert.Contains(t, out, "DELIVERY")
+		assert.Contains(t, out, "suppression.created")
+		assert.Contains(t, out, "[GAP] message.reception")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		out, err := executeCoverageCommand(t, "json")
+		require.NoError(t, err)
+
+		var decoded struct {
+			Object string `json:"object"`
+			Events []struct {
+				Event       string   `json:"event"`
+				Subscribers []string `json:"subscribers"`
+				Activity    *int     `json:"activity"`
+			} `json:"events"`
+			Gaps int `json:"gaps"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(out), &decoded))
+		assert.Equal(t, "webhook_coverage", decoded.Object)
+		require.Len(t, decoded.Events, 10)
+		assert.Equal(t, "message.reception", decoded.Events[0].Event)
+		require.NotNil(t, decoded.Events[0].Activity)
+		assert.Equal(t, 10, *decoded.Events[0].Activity)
+		assert.Nil(t, decoded.Events[9].Activity)
+		assert.Equal(t, 2, decoded.Gaps) // message.reception and suppression.created
+	})
+
+	t.Run("fail on gaps", func(t *testing.T) {
+		_, err := executeCoverageCommand(t, "plain", "--fail-on-gaps")
+		require.Error(t, err)
+		var cliErr *clierrors.CLIError
+		require.ErrorAs(t, err, &cliErr)
+		assert.Equal(t, clierrors.ErrCodeChecksFailed, cliErr.Code)
+		assert.Contains(t, err.Error(), "message.reception, suppression.created")
+	})
+
+	t.Run("invalid since", func(t *testing.T) {
+		_, err := executeCoverageCommand(t, "plain", "--since", "yesterday")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid since")
+	})
+}
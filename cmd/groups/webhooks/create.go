@@ -10,8 +10,11 @@ import (
 
 	"github.com/AhaSend/ahasend-cli/internal/auth"
 	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/fetch"
 	"github.com/AhaSend/ahasend-cli/internal/logger"
 	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/webhooks"
 	"github.com/AhaSend/ahasend-go/models/requests"
 	"github.com/AhaSend/ahasend-go/models/responses"
 	"github.com/spf13/cobra"
@@ -36,7 +39,20 @@ Required fields:
   - Name: A descriptive name for your webhook
   - URL: The endpoint URL where notifications will be sent
 
-The webhook URL must be publicly accessible and support HTTPS for production use.`,
+The webhook URL must be publicly accessible and support HTTPS for production use.
+
+Webhook names are not required to be unique, but creating a second webhook
+with the name of an existing one (ignoring case) is refused unless
+--allow-duplicate-name is given. If the existing webhooks cannot be listed,
+a warning is shown and the webhook is created anyway.
+
+Copying a webhook:
+  --copy-from <webhook-id> creates a webhook with the events, scope, domain
+  restrictions and enabled state of an existing one. --url is required, and
+  must differ from the source's URL. The name defaults to the source's name
+  with " (copy)" appended. Any of --name, --events, --all-events, --scope,
+  --domains and --disabled that are given override the copied settings. The
+  command lists which settings were inherited and which were overridden.`,
 		Example: `  # Interactive webhook creation
   ahasend webhooks create
 
@@ -49,6 +65,12 @@ The webhook URL must be publicly accessible and support HTTPS for production use
     --url "https://api.example.com/webhooks/delivery" \
     --events "delivered,bounced,failed"
 
+  # Create a staging copy of a production webhook
+  ahasend webhooks create \
+    --copy-from abcd1234-5678-90ef-abcd-1234567890ab \
+    --name "Staging Webhook" \
+    --url "https://staging.example.com/webhook"
+
   # Create disabled webhook for testing
   ahasend webhooks create \
     --name "Test Webhook" \
@@ -70,10 +92,12 @@ The webhook URL must be publicly accessible and support HTTPS for production use
 	cmd.Flags().Bool("disabled", false, "Create webhook in disabled state")
 	cmd.Flags().String("scope", "", "Webhook scope (optional)")
 	cmd.Flags().StringSlice("domains", []string{}, "Limit webhook to specific domains")
+	cmd.Flags().String("copy-from", "", "ID of a webhook whose events, scope, domains and enabled state to copy")
 
 	// Interactive mode control
 	cmd.Flags().Bool("interactive", false, "Force interactive mode even when flags are provided")
 	cmd.Flags().Bool("non-interactive", false, "Skip interactive prompts (use flag values only)")
+	cmd.Flags().Bool("allow-duplicate-name", false, "Create the webhook even if another webhook has the same name")
 
 	return cmd
 }
@@ -97,12 +121,21 @@ func runWebhooksCreate(cmd *cobra.Command, args []string) error {
 	domains, _ := cmd.Flags().GetStringSlice("domains")
 	interactive, _ := cmd.Flags().GetBool("interactive")
 	nonInteractive, _ := cmd.Flags().GetBool("non-interactive")
+	copyFrom, _ := cmd.Flags().GetString("copy-from")
 
 	// Validate conflicting flags
 	if interactive && nonInteractive {
 		return fmt.Errorf("cannot specify both --interactive and --non-interactive flags")
 	}
 
+	// Copies take their defaults from the source webhook instead of prompts
+	if copyFrom != "" {
+		if interactive {
+			return errors.NewValidationError("--copy-from cannot be combined with --interactive", nil)
+		}
+		return runWebhooksCopy(cmd, handler, client, copyFrom)
+	}
+
 	// Check if we should run in interactive mode
 	shouldInteract := interactive || (!nonInteractive && (name == "" || webhookURL == ""))
 
@@ -117,6 +150,10 @@ func runWebhooksCreate(cmd *cobra.Command, args []string) error {
 			return err
 		}
 
+		if err := checkDuplicateWebhookName(cmd, client, req.Name); err != nil {
+			return err
+		}
+
 		// Create the webhook
 		webhook, err := createWebhook(client, *req)
 		if err != nil {
@@ -159,9 +196,9 @@ func runWebhooksCreate(cmd *cobra.Command, args []string) error {
 
 	// Set event types
 	if allEvents {
-		setAllEventTypes(&req)
+		webhooks.SetAllCreateEvents(&req)
 	} else if len(validatedEvents) > 0 {
-		setEventTypes(&req, validatedEvents)
+		webhooks.SetCreateEvents(&req, validatedEvents)
 	}
 
 	// Set optional fields
@@ -183,6 +220,10 @@ func runWebhooksCreate(cmd *cobra.Command, args []string) error {
 		"domains":    domains,
 	}).Debug("Executing webhooks create command")
 
+	if err := checkDuplicateWebhookName(cmd, client, name); err != nil {
+		return err
+	}
+
 	// Create the webhook
 	webhook, err := createWebhook(client, req)
 	if err != nil {
@@ -252,7 +293,7 @@ func runInteractiveWebhookCreate() (*requests.CreateWebhookRequest, error) {
 	fmt.Println("Or enter 'all' to select all events, 'none' to select no events")
 	fmt.Println()
 
-	eventTypes := getAvailableEventTypes()
+	eventTypes := webhooks.EventTypes()
 	for i, event := range eventTypes {
 		fmt.Printf("  %d. %s - %s\n", i+1, event.Key, event.Description)
 	}
@@ -267,7 +308,7 @@ func runInteractiveWebhookCreate() (*requests.CreateWebhookRequest, error) {
 
 	switch selection {
 	case "all":
-		setAllEventTypes(&req)
+		webhooks.SetAllCreateEvents(&req)
 	case "none", "":
 		// No events selected - this is valid
 	default:
@@ -276,7 +317,7 @@ func runInteractiveWebhookCreate() (*requests.CreateWebhookRequest, error) {
 		if err != nil {
 			return nil, err
 		}
-		setEventTypes(&req, selectedEvents)
+		webhooks.SetCreateEvents(&req, selectedEvents)
 	}
 
 	// Optional: Ask about scope and domains
@@ -315,6 +356,33 @@ func createWebhook(client client.AhaSendClient, req requests.CreateWebhookReques
 	return webhook, nil
 }
 
+// checkDuplicateWebhookName refuses a name that an existing webhook already
+// has, ignoring case, unless --allow-duplicate-name is set. The guard must
+// never block creation on its own read, so a failed lookup only warns.
+func checkDuplicateWebhookName(cmd *cobra.Command, apiClient client.AhaSendClient, name string) error {
+	if allow, _ := cmd.Flags().GetBool("allow-duplicate-name"); allow {
+		return nil
+	}
+
+	existing, err := fetch.AllWebhooks(apiClient)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "⚠️  Could not check for existing webhooks named '%s', creating anyway: %v\n", name, err)
+		return nil
+	}
+
+	var matches []string
+	for _, webhook := range existing.Data {
+		if strings.EqualFold(strings.TrimSpace(webhook.Name), strings.TrimSpace(name)) {
+			matches = append(matches, fmt.Sprintf("'%s' %s (%s)", webhook.Name, webhook.ID, webhook.URL))
+		}
+	}
+	if len(matches) == 0 {
+		return nil
+	}
+	return errors.NewValidationError(fmt.Sprintf("a webhook with the name '%s' already exists: %s; pass --allow-duplicate-name to create another",
+		name, strings.Join(matches, ", ")), nil)
+}
+
 func validateWebhookURL(webhookURL string) error {
 	parsedURL, err := url.Parse(webhookURL)
 	if err != nil {
@@ -338,42 +406,17 @@ func validateWebhookURL(webhookURL string) error {
 	return nil
 }
 
-type EventType struct {
-	Key         string
-	Description string
-}
-
-func getAvailableEventTypes() []EventType {
-	return []EventType{
-		{"reception", "Message reception (inbound email received)"},
-		{"delivered", "Message delivered successfully"},
-		{"transient_error", "Temporary delivery failure (will retry)"},
-		{"failed", "Permanent delivery failure"},
-		{"bounced", "Message bounced (invalid recipient)"},
-		{"suppressed", "Message suppressed (recipient opted out)"},
-		{"opened", "Message opened by recipient"},
-		{"clicked", "Link clicked in message"},
-		{"suppression_created", "New suppression entry created"},
-		{"dns_error", "DNS configuration error for domain"},
-	}
-}
-
 func validateEventTypes(events []string) ([]string, error) {
 	if len(events) == 0 {
 		return []string{}, nil
 	}
 
-	validEventTypes := make(map[string]bool)
-	for _, event := range getAvailableEventTypes() {
-		validEventTypes[event.Key] = true
-	}
-
 	var validatedEvents []string
 	var invalidEvents []string
 
 	for _, event := range events {
 		event = strings.TrimSpace(event)
-		if validEventTypes[event] {
+		if webhooks.IsValidEvent(event) {
 			validatedEvents = append(validatedEvents, event)
 		} else {
 			invalidEvents = append(invalidEvents, event)
@@ -383,22 +426,13 @@ func validateEventTypes(events []string) ([]string, error) {
 	if len(invalidEvents) > 0 {
 		return nil, fmt.Errorf("invalid event types: %s. Valid types: %s",
 			strings.Join(invalidEvents, ", "),
-			strings.Join(getValidEventTypeKeys(), ", "))
+			strings.Join(webhooks.EventKeys(), ", "))
 	}
 
 	return validatedEvents, nil
 }
 
-func getValidEventTypeKeys() []string {
-	eventTypes := getAvailableEventTypes()
-	keys := make([]string, len(eventTypes))
-	for i, event := range eventTypes {
-		keys[i] = event.Key
-	}
-	return keys
-}
-
-func parseEventSelection(selection string, eventTypes []EventType) ([]string, error) {
+func parseEventSelection(selection string, eventTypes []webhooks.EventType) ([]string, error) {
 	parts := strings.Split(selection, ",")
 	var selectedEvents []string
 
@@ -422,43 +456,3 @@ func parseEventSelection(selection string, eventTypes []EventType) ([]string, er
 
 	return selectedEvents, nil
 }
-
-func setAllEventTypes(req *requests.CreateWebhookRequest) {
-	req.OnReception = true
-	req.OnDelivered = true
-	req.OnTransientError = true
-	req.OnFailed = true
-	req.OnBounced = true
-	req.OnSuppressed = true
-	req.OnOpened = true
-	req.OnClicked = true
-	req.OnSuppressionCreated = true
-	req.OnDnsError = true
-}
-
-func setEventTypes(req *requests.CreateWebhookRequest, events []string) {
-	for _, event := range events {
-		switch event {
-		case "reception":
-			req.OnReception = true
-		case "delivered":
-			req.OnDelivered = true
-		case "transient_error":
-			req.OnTransientError = true
-		case "failed":
-			req.OnFailed = true
-		case "bounced":
-			req.OnBounced = true
-		case "suppressed":
-			req.OnSuppressed = true
-		case "opened":
-			req.OnOpened = true
-		case "clicked":
-			req.OnClicked = true
-		case "suppression_created":
-			req.OnSuppressionCreated = true
-		case "dns_error":
-			req.OnDnsError = true
-		}
-	}
-}
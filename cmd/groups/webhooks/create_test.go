@@ -0,0 +1,89 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func executeWebhookCreate(t *testing.T, listErr error, args ...string) (string, error, *mocks.MockClient) {
+	t.Helper()
+
+	existing := createTestWebhook(uuid.New().String(), "production", "https://example.com/prod", true)
+	mockClient := &mocks.MockClient{}
+	if listErr != nil {
+		mockClient.On("ListWebhooks", (*int32)(nil), (*string)(nil)).Return(nil, listErr)
+	} else {
+		mockClient.On("ListWebhooks", (*int32)(nil), (*string)(nil)).Return(&responses.PaginatedWebhooksResponse{
+			Data: []responses.Webhook{existing},
+		}, nil)
+	}
+	created := createTestWebhook(uuid.New().String(), "new", "https://example.com/new", true)
+	mockClient.On("CreateWebhook", mock.AnythingOfType("requests.CreateWebhookRequest")).Return(&created, nil)
+
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var stdout, stderr bytes.Buffer
+	handler := printer.GetResponseHandler("plain", false, &stdout)
+	cmd := NewCreateCommand()
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs(append([]string{"--non-interactive", "--url", "https://example.com/new"}, args...))
+
+	err := cmd.Execute()
+	return stderr.String(), err, mockClient
+}
+
+func TestWebhooksCreate_DuplicateName(t *testing.T) {
+	t.Run("exact collision", func(t *testing.T) {
+		_, err, mockClient := executeWebhookCreate(t, nil, "--name", "production")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "a webhook with the name 'production' already exists")
+		assert.Contains(t, err.Error(), "https://example.com/prod")
+		assert.Contains(t, err.Error(), "--allow-duplicate-name")
+		mockClient.AssertNotCalled(t, "CreateWebhook", mock.Anything)
+	})
+
+	t.Run("collision in different case", func(t *testing.T) {
+		_, err, mockClient := executeWebhookCreate(t, nil, "--name", "Production")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "'production'")
+		mockClient.AssertNotCalled(t, "CreateWebhook", mock.Anything)
+	})
+
+	t.Run("similar name is not a collision", func(t *testing.T) {
+		_, err, mockClient := executeWebhookCreate(t, nil, "--name", "production-eu")
+		require.NoError(t, err)
+		mockClient.AssertCalled(t, "CreateWebhook", mock.Anything)
+	})
+
+	t.Run("override flag", func(t *testing.T) {
+		_, err, mockClient := executeWebhookCreate(t, nil, "--name", "PRODUCTION", "--allow-duplicate-name")
+		require.NoError(t, err)
+		mockClient.AssertNotCalled(t, "ListWebhooks", mock.Anything, mock.Anything)
+		mockClient.AssertCalled(t, "CreateWebhook", mock.Anything)
+	})
+
+	t.Run("list failure warns and proceeds", func(t *testing.T) {
+		stderr, err, mockClient := executeWebhookCreate(t, errors.New("service unavailable"), "--name", "production")
+		require.NoError(t, err)
+		assert.Contains(t, stderr, "Could not check for existing webhooks named 'production'")
+		mockClient.AssertCalled(t, "CreateWebhook", mock.Anything)
+	})
+}
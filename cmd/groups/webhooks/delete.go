@@ -7,10 +7,12 @@ import (
 	"strings"
 
 	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/bulk"
 	"github.com/AhaSend/ahasend-cli/internal/client"
 	"github.com/AhaSend/ahasend-cli/internal/logger"
 	"github.com/AhaSend/ahasend-cli/internal/output"
 	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/webhooks"
 	"github.com/AhaSend/ahasend-go/models/responses"
 	"github.com/spf13/cobra"
 )
@@ -18,7 +20,7 @@ import (
 // NewDeleteCommand creates the delete command
 func NewDeleteCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "delete <webhook-id>",
+		Use:   "delete [webhook-id]",
 		Short: "Delete a webhook",
 		Long: `Delete an existing webhook endpoint permanently.
 
@@ -28,7 +30,16 @@ receive event notifications and cannot be restored.
 By default, you will be prompted to confirm the deletion. Use the --force
 flag to skip the confirmation prompt for automated scripts.
 
-The webhook ID can be found using the 'ahasend webhooks list' command.`,
+The webhook ID can be found using the 'ahasend webhooks list' command.
+
+Bulk deletion:
+  --matching deletes every webhook whose name matches a glob pattern ('*'
+  matches any characters, '?' a single character). Matching is
+  case-insensitive unless --case-sensitive is given, and --enabled-only
+  restricts it to enabled webhooks. A preview of the matched webhooks is
+  shown and you must type their count to confirm, unless --yes (or --force)
+  is given. Deletions run in parallel (--concurrency); a failure does not
+  stop the others, and the command exits non-zero if any deletion failed.`,
 		Example: `  # Delete webhook with confirmation prompt
   ahasend webhooks delete abcd1234-5678-90ef-abcd-1234567890ab
 
@@ -36,18 +47,33 @@ The webhook ID can be found using the 'ahasend webhooks list' command.`,
   ahasend webhooks delete abcd1234-5678-90ef-abcd-1234567890ab --force
 
   # Delete webhook with JSON output
-  ahasend webhooks delete abcd1234-5678-90ef-abcd-1234567890ab --output json`,
-		Args:         cobra.ExactArgs(1),
+  ahasend webhooks delete abcd1234-5678-90ef-abcd-1234567890ab --output json
+
+  # Delete all webhooks left over from load tests
+  ahasend webhooks delete --matching "test-*"
+
+  # Delete enabled matching webhooks without a prompt
+  ahasend webhooks delete --matching "test-*" --enabled-only --yes`,
+		Args:         deleteArgs,
 		RunE:         runWebhooksDelete,
 		SilenceUsage: true,
 	}
 
 	cmd.Flags().Bool("force", false, "Skip confirmation prompt")
+	cmd.Flags().String("matching", "", "Delete all webhooks whose name matches this glob pattern")
+	cmd.Flags().Bool("enabled-only", false, "With --matching, only delete enabled webhooks")
+	cmd.Flags().Bool("case-sensitive", false, "With --matching, match names case-sensitively")
+	cmd.Flags().Bool("yes", false, "With --matching, skip the typed confirmation")
+	cmd.Flags().Int("concurrency", bulk.DefaultConcurrency, "With --matching, number of deletions to run in parallel")
 
 	return cmd
 }
 
 func runWebhooksDelete(cmd *cobra.Command, args []string) error {
+	if cmd.Flags().Changed("matching") {
+		return runWebhooksBulkDelete(cmd)
+	}
+
 	// Get response handler instance
 	handler := printer.GetResponseHandlerFromCommand(cmd)
 
@@ -150,36 +176,5 @@ func getWebhookStatus(enabled bool) string {
 }
 
 func getConfiguredEvents(webhook *responses.Webhook) []string {
-	var events []string
-	if webhook.OnReception {
-		events = append(events, "reception")
-	}
-	if webhook.OnDelivered {
-		events = append(events, "delivered")
-	}
-	if webhook.OnTransientError {
-		events = append(events, "transient_error")
-	}
-	if webhook.OnFailed {
-		events = append(events, "failed")
-	}
-	if webhook.OnBounced {
-		events = append(events, "bounced")
-	}
-	if webhook.OnSuppressed {
-		events = append(events, "suppressed")
-	}
-	if webhook.OnOpened {
-		events = append(events, "opened")
-	}
-	if webhook.OnClicked {
-		events = append(events, "clicked")
-	}
-	if webhook.OnSuppressionCreated {
-		events = append(events, "suppression_created")
-	}
-	if webhook.OnDNSError {
-		events = append(events, "dns_error")
-	}
-	return events
+	return webhooks.SubscribedEvents(webhook)
 }
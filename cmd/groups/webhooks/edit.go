@@ -0,0 +1,231 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/spec"
+	"github.com/AhaSend/ahasend-cli/internal/webhooks"
+)
+
+// defaultEditor is used when $EDITOR is not set
+const defaultEditor = "vi"
+
+// updateFlags are the flags of 'webhooks update' that --edit replaces
+var updateFlags = []string{"name", "url", "enable", "disable", "events", "all-events", "no-events", "scope", "domains", "clear-domains"}
+
+// runEditor opens path in the user's editor and waits for it to exit. The
+// editor may be given with arguments, as in EDITOR="code --wait".
+var runEditor = func(path string) error {
+	editor := strings.Fields(os.Getenv("EDITOR"))
+	if len(editor) == 0 {
+		editor = []string{defaultEditor}
+	}
+	editorCmd := exec.Command(editor[0], append(editor[1:], path)...)
+	editorCmd.Stdin = os.Stdin
+	editorCmd.Stdout = os.Stdout
+	editorCmd.Stderr = os.Stderr
+	if err := editorCmd.Run(); err != nil {
+		return errors.NewConfigError(fmt.Sprintf("editor %s failed", editor[0]), err)
+	}
+	return nil
+}
+
+// runWebhooksEdit updates a webhook with the settings edited in $EDITOR
+func runWebhooksEdit(cmd *cobra.Command, webhookID string) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	format, _ := cmd.Flags().GetString("edit-format")
+
+	for _, flag := range updateFlags {
+		if cmd.Flags().Changed(flag) {
+			return errors.NewValidationError(fmt.Sprintf("--%s cannot be combined with --edit; change the setting in the editor", flag), nil)
+		}
+	}
+	if format != "yaml" && format != "json" {
+		return errors.NewValidationError(fmt.Sprintf("invalid --edit-format %q: use yaml or json", format), nil)
+	}
+
+	client, err := auth.GetAuthenticatedClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	current, err := client.GetWebhook(webhookID)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		return errors.NewAPIError("received nil response from API", nil)
+	}
+
+	original := spec.FromWebhook(current)
+	edited, ok, err := editWebhook(original, webhookID, format)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return handler.HandleSimpleSuccess("Webhook update cancelled")
+	}
+
+	changes := original.Changes(edited)
+	logger.Get().WithFields(map[string]interface{}{
+		"webhook_id": webhookID,
+		"changes":    len(changes),
+		"dry_run":    dryRun,
+	}).Debug("Executing webhooks update --edit command")
+
+	if dryRun {
+		return handler.HandleWebhookEdit(&printer.WebhookEdit{
+			WebhookID: webhookID,
+			Name:      current.Name,
+			Changes:   changes,
+		}, printer.UpdateConfig{
+			SuccessMessage: fmt.Sprintf("Dry run: changes to webhook %s (not applied)", current.Name),
+			ItemName:       "webhook",
+		})
+	}
+	if len(changes) == 0 {
+		return handler.HandleSimpleSuccess(fmt.Sprintf("No changes to webhook %s; nothing was updated", current.Name))
+	}
+
+	webhook, err := updateWebhook(client, webhookID, original.ChangeRequest(edited))
+	if err != nil {
+		return err
+	}
+	return handler.HandleUpdateWebhook(webhook, printer.UpdateConfig{
+		SuccessMessage: fmt.Sprintf("Successfully updated webhook: %s", webhook.Name),
+		ItemName:       "webhook",
+		FieldOrder:     []string{"id", "name", "url", "enabled", "event_types", "scope", "domains", "created_at", "updated_at"},
+	})
+}
+
+// editWebhook writes the settings of a webhook to a temporary file, opens it
+// in the editor and reads the edited settings back. An invalid file is
+// reopened with the error at the top, keeping the edits; the user gives up
+// by saving it unchanged, and cancels by emptying it (ok is false).
+func editWebhook(original spec.Webhook, webhookID, format string) (spec.Webhook, bool, error) {
+	body, err := marshalEditable(original, format)
+	if err != nil {
+		return spec.Webhook{}, false, err
+	}
+
+	file, err := os.CreateTemp("", "ahasend-webhook-*."+format)
+	if err != nil {
+		return spec.Webhook{}, false, errors.NewFileError("cannot create a temporary file to edit", err)
+	}
+	path := file.Name()
+	file.Close()
+	defer os.Remove(path)
+
+	header := editHeader(original.Name, webhookID)
+	content := header + body
+	for {
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			return spec.Webhook{}, false, errors.NewFileError(fmt.Sprintf("failed to write %s", path), err)
+		}
+		if err := runEditor(path); err != nil {
+			return spec.Webhook{}, false, err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return spec.Webhook{}, false, errors.NewFileError(fmt.Sprintf("cannot read %s", path), err)
+		}
+
+		edits := stripEditHeader(string(data))
+		if isBlank(edits) {
+			return spec.Webhook{}, false, nil
+		}
+		edited, err := parseEditedWebhook(original, edits)
+		if err == nil {
+			return edited, true, nil
+		}
+		if string(data) == content {
+			return spec.Webhook{}, false, errors.NewValidationError(fmt.Sprintf("invalid webhook settings: %s", err.Error()), nil)
+		}
+		content = errorHeader(err) + header + edits
+	}
+}
+
+// parseEditedWebhook reads the edited settings, checking a changed URL
+// like 'webhooks update --url' does
+func parseEditedWebhook(original spec.Webhook, edits string) (spec.Webhook, error) {
+	edited, err := spec.ParseWebhook([]byte(edits))
+	if err != nil {
+		return spec.Webhook{}, err
+	}
+	if strings.TrimSpace(edited.URL) != strings.TrimSpace(original.URL) {
+		if err := validateWebhookURL(edited.URL); err != nil {
+			return spec.Webhook{}, err
+		}
+	}
+	return edited, nil
+}
+
+// marshalEditable encodes the editable settings in the edit format
+func marshalEditable(webhook spec.Webhook, format string) (string, error) {
+	if format == "json" {
+		if webhook.Domains == nil {
+			webhook.Domains = []string{}
+		}
+		data, err := json.MarshalIndent(webhook, "", "  ")
+		if err != nil {
+			return "", errors.NewFileError("failed to encode the webhook", err)
+		}
+		return string(data) + "\n", nil
+	}
+	data, err := yaml.Marshal(webhook)
+	if err != nil {
+		return "", errors.NewFileError("failed to encode the webhook", err)
+	}
+	return string(data), nil
+}
+
+// editHeader explains the edit file in comments, which YAML and the JSON it
+// includes both allow
+func editHeader(name, webhookID string) string {
+	return fmt.Sprintf("# Editing webhook %q (%s). Lines starting with '#' are ignored.\n", name, webhookID) +
+		"# Save and close the editor to apply the changes; empty the file to cancel.\n" +
+		fmt.Sprintf("# Valid events: %s\n", strings.Join(webhooks.EventKeys(), ", "))
+}
+
+// errorHeader reports why the edited file was rejected
+func errorHeader(err error) string {
+	var b strings.Builder
+	for _, line := range strings.Split(err.Error(), "\n") {
+		fmt.Fprintf(&b, "# ERROR: %s\n", line)
+	}
+	b.WriteString("# Fix the settings below and save again, or save unchanged to give up.\n")
+	return b.String()
+}
+
+// stripEditHeader removes the leading comment lines written by editHeader
+// and errorHeader
+func stripEditHeader(content string) string {
+	lines := strings.SplitAfter(content, "\n")
+	for len(lines) > 0 && strings.HasPrefix(strings.TrimSpace(lines[0]), "#") {
+		lines = lines[1:]
+	}
+	return strings.Join(lines, "")
+}
+
+// isBlank reports whether content holds nothing but comments and whitespace
+func isBlank(content string) bool {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			return false
+		}
+	}
+	return true
+}
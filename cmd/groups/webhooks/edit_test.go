@@ -0,0 +1,187 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+)
+
+const editWebhookID = "abcd1234-5678-90ef-abcd-1234567890ab"
+
+func editedWebhook() *responses.Webhook {
+	return &responses.Webhook{
+		ID:          uuid.MustParse(editWebhookID),
+		Name:        "Orders",
+		URL:         "https://example.com/webhook",
+		Enabled:     true,
+		OnDelivered: true,
+		OnOpened:    true,
+		Scope:       "account",
+	}
+}
+
+// fakeEditor replaces the editor with edits, one per time it is opened,
+// each given the file's content and returning the content to save. It
+// returns the contents the editor was opened with.
+func fakeEditor(t *testing.T, edits ...func(string) string) *[]string {
+	t.Helper()
+	opened := &[]string{}
+	original := runEditor
+	runEditor = func(path string) error {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		*opened = append(*opened, string(data))
+		require.Less(t, len(*opened)-1, len(edits), "editor opened too often")
+		return os.WriteFile(path, []byte(edits[len(*opened)-1](string(data))), 0600)
+	}
+	t.Cleanup(func() { runEditor = original })
+	return opened
+}
+
+func replace(old, new string) func(string) string {
+	return func(content string) string { return strings.Replace(content, old, new, 1) }
+}
+
+func executeEdit(t *testing.T, format string, setup func(*mocks.MockClient), args ...string) (*mocks.MockClient, string, error) {
+	t.Helper()
+	mockClient := &mocks.MockClient{}
+	setup(mockClient)
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var stdout bytes.Buffer
+	cmd := NewUpdateCommand()
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, printer.GetResponseHandler(format, false, &stdout)))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(append([]string{editWebhookID}, args...))
+	err := cmd.Execute()
+	return mockClient, stdout.String(), err
+}
+
+func TestUpdateEdit_AppliesOnlyChangedSettings(t *testing.T) {
+	opened := fakeEditor(t, replace("enabled: true", "enabled: false"))
+
+	mockClient, stdout, err := executeEdit(t, "plain", func(m *mocks.MockClient) {
+		m.On("GetWebhook", editWebhookID).Return(editedWebhook(), nil)
+		m.On("UpdateWebhook", editWebhookID, mock.Anything).Return(editedWebhook(), nil)
+	}, "--edit")
+	require.NoError(t, err)
+
+	require.Len(t, *opened, 1)
+	assert.Contains(t, (*opened)[0], "# Editing webhook \"Orders\"")
+	assert.Contains(t, (*opened)[0], "- delivered\n")
+	mockClient.AssertCalled(t, "UpdateWebhook", editWebhookID, mock.MatchedBy(func(req requests.UpdateWebhookRequest) bool {
+		return req.Enabled != nil && !*req.Enabled && req.Name == nil && req.URL == nil && req.OnDelivered == nil && req.Domains == nil
+	}))
+	assert.Contains(t, stdout, "Successfully updated webhook: Orders")
+}
+
+func TestUpdateEdit_ReopensInvalidFileWithError(t *testing.T) {
+	opened := fakeEditor(t,
+		replace("- opened", "- openned"),
+		replace("- openned", "- clicked"),
+	)
+
+	mockClient, _, err := executeEdit(t, "plain", func(m *mocks.MockClient) {
+		m.On("GetWebhook", editWebhookID).Return(editedWebhook(), nil)
+		m.On("UpdateWebhook", editWebhookID, mock.Anything).Return(editedWebhook(), nil)
+	}, "--edit")
+	require.NoError(t, err)
+
+	require.Len(t, *opened, 2)
+	assert.True(t, strings.HasPrefix((*opened)[1], "# ERROR: unknown events openned"))
+	assert.Equal(t, 1, strings.Count((*opened)[1], "# Editing webhook"))
+	assert.Contains(t, (*opened)[1], "- openned")
+	mockClient.AssertCalled(t, "UpdateWebhook", editWebhookID, mock.MatchedBy(func(req requests.UpdateWebhookRequest) bool {
+		return req.OnClicked != nil && *req.OnClicked && req.OnOpened != nil && !*req.OnOpened && *req.OnDelivered
+	}))
+}
+
+func TestUpdateEdit_GivesUpOnUnchangedInvalidFile(t *testing.T) {
+	unchanged := func(content string) string { return content }
+	fakeEditor(t, replace("url: https://example.com/webhook", "url: example.com"), unchanged)
+
+	mockClient, _, err := executeEdit(t, "plain", func(m *mocks.MockClient) {
+		m.On("GetWebhook", editWebhookID).Return(editedWebhook(), nil)
+	}, "--edit")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must include scheme and host")
+	mockClient.AssertNotCalled(t, "UpdateWebhook", mock.Anything, mock.Anything)
+}
+
+func TestUpdateEdit_EmptyFileCancels(t *testing.T) {
+	fakeEditor(t, func(string) string { return "# nothing left\n" })
+
+	mockClient, stdout, err := executeEdit(t, "plain", func(m *mocks.MockClient) {
+		m.On("GetWebhook", editWebhookID).Return(editedWebhook(), nil)
+	}, "--edit")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "Webhook update cancelled")
+	mockClient.AssertNotCalled(t, "UpdateWebhook", mock.Anything, mock.Anything)
+}
+
+func TestUpdateEdit_DryRunJSON(t *testing.T) {
+	opened := fakeEditor(t, replace(`"name": "Orders"`, `"name": "Orders v2"`))
+
+	mockClient, stdout, err := executeEdit(t, "json", func(m *mocks.MockClient) {
+		m.On("GetWebhook", editWebhookID).Return(editedWebhook(), nil)
+	}, "--edit", "--edit-format", "json", "--dry-run")
+	require.NoError(t, err)
+
+	require.Len(t, *opened, 1)
+	assert.Contains(t, (*opened)[0], `"domains": []`)
+	mockClient.AssertNotCalled(t, "UpdateWebhook", mock.Anything, mock.Anything)
+
+	var edit struct {
+		Object  string `json:"object"`
+		Changes []struct {
+			Path   string `json:"path"`
+			Before string `json:"before"`
+			After  string `json:"after"`
+		} `json:"changes"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &edit))
+	assert.Equal(t, "webhook_edit", edit.Object)
+	require.Len(t, edit.Changes, 1)
+	assert.Equal(t, "name", edit.Changes[0].Path)
+	assert.Equal(t, "Orders v2", edit.Changes[0].After)
+}
+
+func TestUpdateEdit_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"edit with update flags", []string{"--edit", "--disable"}, "--disable cannot be combined with --edit"},
+		{"dry run without edit", []string{"--dry-run", "--disable"}, "--dry-run requires --edit"},
+		{"bad format", []string{"--edit", "--edit-format", "toml"}, "invalid --edit-format"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient, _, err := executeEdit(t, "plain", func(*mocks.MockClient) {}, tt.args...)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+			mockClient.AssertNotCalled(t, "GetWebhook", mock.Anything)
+		})
+	}
+}
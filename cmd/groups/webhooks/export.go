@@ -0,0 +1,81 @@
+package webhooks
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/fetch"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/spec"
+	"github.com/spf13/cobra"
+)
+
+// NewExportCommand creates the export command
+func NewExportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export webhooks to a YAML file",
+		Long: `Export the settings of every webhook in the account to a declarative YAML
+file that 'ahasend webhooks import' can recreate in another account.
+
+Each webhook is written with its name, URL, enabled state, events, scope and
+domains. Signing secrets are never exported: the target account generates new
+ones, so receivers must be given the new secret after importing. The file
+records the account it was exported from, the export time and the CLI
+version.
+
+Without --output-file the YAML is written to stdout.`,
+		Example: `  # Export to a file
+  ahasend webhooks export --output-file webhooks.yaml
+
+  # Copy staging webhooks into production
+  ahasend webhooks export --profile staging --output-file webhooks.yaml
+  ahasend webhooks import --file webhooks.yaml --profile production`,
+		Args:         cobra.NoArgs,
+		RunE:         runWebhooksExport,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().String("output-file", "", "File to write the YAML to (default: stdout)")
+
+	return cmd
+}
+
+func runWebhooksExport(cmd *cobra.Command, args []string) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+	outputFile, _ := cmd.Flags().GetString("output-file")
+
+	apiClient, err := auth.GetAuthenticatedClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	logger.Get().WithFields(map[string]interface{}{
+		"output_file": outputFile,
+	}).Debug("Executing webhooks export command")
+
+	all, err := fetch.AllWebhooks(apiClient)
+	if err != nil {
+		return err
+	}
+
+	file := spec.New(spec.KindWebhooks, apiClient.GetAccountID(), fetch.AccountName(apiClient), time.Now())
+	for i := range all.Data {
+		file.Webhooks = append(file.Webhooks, spec.FromWebhook(&all.Data[i]))
+	}
+
+	if outputFile == "" {
+		data, err := file.Marshal()
+		if err != nil {
+			return err
+		}
+		_, err = cmd.OutOrStdout().Write(data)
+		return err
+	}
+	if err := file.Write(outputFile); err != nil {
+		return err
+	}
+	return handler.HandleSimpleSuccess(fmt.Sprintf("Exported %d webhooks to %s", len(file.Webhooks), outputFile))
+}
@@ -0,0 +1,139 @@
+package webhooks
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/fetch"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/spec"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/spf13/cobra"
+)
+
+// NewImportCommand creates the import command
+func NewImportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Create webhooks from a YAML file",
+		Long: `Create the webhooks described by a file written with 'ahasend webhooks export'
+in the current account (or the one selected with --profile).
+
+Webhooks are matched to existing ones by name, ignoring case:
+  created    No webhook has the name; it is created
+  unchanged  A webhook with the name has the same settings
+  skipped    A webhook with the name has different settings; it is left alone
+  updated    With --update-existing, a differing webhook is changed to match
+  failed     The webhook could not be created or updated, or several
+             webhooks in the account have the name
+
+Importing a file exported from the same account therefore changes nothing.
+New webhooks get new signing secrets, shown by 'ahasend webhooks get'.
+The command exits with an error when any webhook failed.`,
+		Example: `  # Recreate staging webhooks in production
+  ahasend webhooks import --file webhooks.yaml --profile production
+
+  # Also bring existing webhooks in line with the file
+  ahasend webhooks import --file webhooks.yaml --update-existing`,
+		Args:         cobra.NoArgs,
+		RunE:         runWebhooksImport,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().String("file", "", "YAML file written by 'ahasend webhooks export' (required)")
+	cmd.Flags().Bool("update-existing", false, "Update webhooks that already exist with different settings")
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func runWebhooksImport(cmd *cobra.Command, args []string) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+	path, _ := cmd.Flags().GetString("file")
+	updateExisting, _ := cmd.Flags().GetBool("update-existing")
+
+	file, err := spec.Load(path, spec.KindWebhooks)
+	if err != nil {
+		return err
+	}
+
+	apiClient, err := auth.GetAuthenticatedClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	logger.Get().WithFields(map[string]interface{}{
+		"file":            path,
+		"webhooks":        len(file.Webhooks),
+		"source_account":  file.Source.AccountID,
+		"update_existing": updateExisting,
+	}).Debug("Executing webhooks import command")
+
+	existing, err := fetch.AllWebhooks(apiClient)
+	if err != nil {
+		return err
+	}
+
+	items := importWebhooks(apiClient, file.Webhooks, existing.Data, updateExisting)
+	result := printer.NewImportResult(path, file.Source.AccountID, items)
+	if err := handler.HandleImport(result, printer.CreateConfig{ItemName: "webhook"}); err != nil {
+		return err
+	}
+	if result.Failed > 0 {
+		return errors.NewAPIError(fmt.Sprintf("failed to import %d of %d webhooks", result.Failed, len(items)), nil)
+	}
+	return nil
+}
+
+// importWebhooks creates or updates each wanted webhook, matching existing
+// webhooks by name, and returns the outcome of each
+func importWebhooks(apiClient client.AhaSendClient, wanted []spec.Webhook, existing []responses.Webhook, updateExisting bool) []printer.ImportItem {
+	byName := make(map[string][]*responses.Webhook)
+	for i := range existing {
+		key := strings.ToLower(existing[i].Name)
+		byName[key] = append(byName[key], &existing[i])
+	}
+
+	items := make([]printer.ImportItem, 0, len(wanted))
+	for _, webhook := range wanted {
+		item := printer.ImportItem{Name: webhook.Name}
+		matches := byName[strings.ToLower(webhook.Name)]
+
+		switch {
+		case len(matches) > 1:
+			item.Status = printer.ImportFailed
+			item.Error = fmt.Sprintf("%d webhooks in the account are named %q; rename all but one to import", len(matches), webhook.Name)
+		case len(matches) == 1:
+			current := matches[0]
+			item.ID = current.ID.String()
+			switch {
+			case webhook.Equal(spec.FromWebhook(current)):
+				item.Status = printer.ImportUnchanged
+			case !updateExisting:
+				item.Status = printer.ImportSkipped
+			default:
+				if _, err := apiClient.UpdateWebhook(item.ID, webhook.UpdateRequest()); err != nil {
+					item.Status, item.Error = printer.ImportFailed, err.Error()
+				} else {
+					item.Status = printer.ImportUpdated
+				}
+			}
+		default:
+			created, err := apiClient.CreateWebhook(webhook.CreateRequest())
+			if err != nil {
+				item.Status, item.Error = printer.ImportFailed, err.Error()
+			} else {
+				item.Status = printer.ImportCreated
+				if created != nil {
+					item.ID = created.ID.String()
+				}
+			}
+		}
+		items = append(items, item)
+	}
+	return items
+}
@@ -0,0 +1,112 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func executeTransfer(t *testing.T, cmd *cobra.Command, webhookList []responses.Webhook, setup func(*mocks.MockClient), args ...string) (string, *mocks.MockClient, error) {
+	t.Helper()
+
+	mockClient := &mocks.MockClient{}
+	mockClient.On("ListWebhooks", (*int32)(nil), (*string)(nil)).Return(&responses.PaginatedWebhooksResponse{
+		Object: "list",
+		Data:   webhookList,
+	}, nil)
+	mockClient.On("GetAccountID").Return("acc-staging").Maybe()
+	mockClient.On("GetAccount").Return(&responses.Account{Name: "Staging"}, nil).Maybe()
+	if setup != nil {
+		setup(mockClient)
+	}
+
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var stdout bytes.Buffer
+	handler := printer.GetResponseHandler("plain", false, &stdout)
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	return stdout.String(), mockClient, err
+}
+
+func exportTestWebhooks(t *testing.T, webhookList []responses.Webhook) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "webhooks.yaml")
+	out, _, err := executeTransfer(t, NewExportCommand(), webhookList, nil, "--output-file", path)
+	require.NoError(t, err)
+	assert.Contains(t, out, "Exported 2 webhooks to "+path)
+	return path
+}
+
+func TestWebhooksImport_SameAccountIsNoOp(t *testing.T) {
+	webhookList := []responses.Webhook{createTestWebhookWithEvents(), createTestWebhook(uuid.New().String(), "Second", "https://example.com/2", false)}
+	path := exportTestWebhooks(t, webhookList)
+
+	out, mockClient, err := executeTransfer(t, NewImportCommand(), webhookList, nil, "--file", path)
+	require.NoError(t, err)
+	assert.Contains(t, out, "0 created, 0 updated, 2 unchanged, 0 skipped")
+	mockClient.AssertNotCalled(t, "CreateWebhook", mock.Anything)
+	mockClient.AssertNotCalled(t, "UpdateWebhook", mock.Anything, mock.Anything)
+}
+
+func TestWebhooksImport_IntoOtherAccount(t *testing.T) {
+	source := []responses.Webhook{createTestWebhookWithEvents(), createTestWebhook(uuid.New().String(), "Second", "https://example.com/2", false)}
+	path := exportTestWebhooks(t, source)
+
+	changed := createTestWebhook(uuid.New().String(), "test webhook", "https://prod.example.com/webhook", true)
+
+	t.Run("creates missing and skips differing", func(t *testing.T) {
+		out, mockClient, err := executeTransfer(t, NewImportCommand(), []responses.Webhook{changed}, func(m *mocks.MockClient) {
+			m.On("CreateWebhook", mock.MatchedBy(func(req requests.CreateWebhookRequest) bool {
+				return req.Name == "Second" && req.Enabled != nil && !*req.Enabled
+			})).Return(&source[1], nil).Once()
+		}, "--file", path)
+		require.NoError(t, err)
+		assert.Contains(t, out, "Skipped Test Webhook ("+changed.ID.String()+")")
+		assert.Contains(t, out, "1 created, 0 updated, 0 unchanged, 1 skipped")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("update existing", func(t *testing.T) {
+		out, mockClient, err := executeTransfer(t, NewImportCommand(), []responses.Webhook{changed}, func(m *mocks.MockClient) {
+			m.On("UpdateWebhook", changed.ID.String(), mock.MatchedBy(func(req requests.UpdateWebhookRequest) bool {
+				return *req.URL == "https://example.com/webhook" && *req.OnDelivered && !*req.OnOpened
+			})).Return(&source[0], nil).Once()
+			m.On("CreateWebhook", mock.Anything).Return(nil, errors.New("quota exceeded")).Once()
+		}, "--file", path, "--update-existing")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to import 1 of 2 webhooks")
+		assert.Contains(t, out, "Failed Second: quota exceeded")
+		assert.Contains(t, out, "0 created, 1 updated, 0 unchanged, 0 skipped, 1 failed")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("ambiguous name", func(t *testing.T) {
+		duplicate := createTestWebhook(uuid.New().String(), "SECOND", "https://example.com/2", false)
+		other := createTestWebhook(uuid.New().String(), "second", "https://example.com/2", false)
+		out, _, err := executeTransfer(t, NewImportCommand(), []responses.Webhook{source[0], duplicate, other}, nil, "--file", path)
+		require.Error(t, err)
+		assert.Contains(t, out, `2 webhooks in the account are named "Second"`)
+	})
+}
@@ -1,9 +1,18 @@
 package webhooks
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/fetch"
 	"github.com/AhaSend/ahasend-cli/internal/logger"
 	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/webhooks"
+	"github.com/AhaSend/ahasend-go/api"
+	"github.com/AhaSend/ahasend-go/models/common"
 	"github.com/AhaSend/ahasend-go/models/responses"
 	"github.com/spf13/cobra"
 )
@@ -17,7 +26,21 @@ func NewListCommand() *cobra.Command {
 event types, and status information.
 
 The list shows webhook names, URLs, enabled status, and configured event types
-to help you manage your webhook endpoints effectively.`,
+to help you manage your webhook endpoints effectively.
+
+With --include-stats, Success, Errors, Error Streak, Last Request and Error
+Rate columns are added along with an account-wide totals row. Unless --limit
+or --cursor is given, all pages are fetched so the totals cover every webhook.
+Stats can be sorted with --sort errors (most errors first) or
+--sort last_request (most recent first).
+
+--enabled or --disabled keeps webhooks in that state, and --event keeps
+webhooks subscribed to the event; repeat it to keep webhooks subscribed to
+any of several events. Event names are those shown in the Events column:
+` + strings.Join(webhooks.EventKeys(), ", ") + `.
+When filtering, pages are read until --limit matching webhooks are found, or
+every page without --limit. The last page read is shown whole, so a few more
+than --limit webhooks may be listed, and --cursor continues after them.`,
 		Example: `  # List all webhooks
   ahasend webhooks list
 
@@ -28,7 +51,19 @@ to help you manage your webhook endpoints effectively.`,
   ahasend webhooks list --limit 10
 
   # List only enabled webhooks
-  ahasend webhooks list --enabled`,
+  ahasend webhooks list --enabled
+
+  # Find the webhooks that receive bounces or failures
+  ahasend webhooks list --event bounced --event failed
+
+  # Disabled webhooks, as IDs for a script
+  ahasend webhooks list --disabled --ids-only
+
+  # Weekly report with delivery stats, noisiest webhooks first
+  ahasend webhooks list --include-stats --sort errors
+
+  # Export stats as CSV
+  ahasend webhooks list --include-stats --output csv`,
 		RunE:         runWebhooksList,
 		SilenceUsage: true,
 	}
@@ -36,6 +71,12 @@ to help you manage your webhook endpoints effectively.`,
 	cmd.Flags().Int32("limit", 0, "Maximum number of webhooks to return")
 	cmd.Flags().String("cursor", "", "Pagination cursor for next page")
 	cmd.Flags().Bool("enabled", false, "Show only enabled webhooks")
+	cmd.Flags().Bool("disabled", false, "Show only disabled webhooks")
+	cmd.Flags().StringSlice("event", []string{}, "Show only webhooks subscribed to this event (repeatable; any of them matches)")
+	cmd.Flags().Bool("include-stats", false, "Include delivery stats columns and an aggregate totals row")
+	cmd.Flags().String("sort", "", "Sort webhooks when stats are included: errors or last_request")
+	printer.AddIDsOnlyFlag(cmd)
+	cmd.MarkFlagsMutuallyExclusive("enabled", "disabled")
 
 	return cmd
 }
@@ -44,15 +85,32 @@ func runWebhooksList(cmd *cobra.Command, args []string) error {
 	// Get response handler instance
 	handler := printer.GetResponseHandlerFromCommand(cmd)
 
-	client, err := auth.GetAuthenticatedClient(cmd)
-	if err != nil {
-		return err
-	}
-
 	// Get flags
 	limit, _ := cmd.Flags().GetInt32("limit")
 	cursor, _ := cmd.Flags().GetString("cursor")
 	enabledOnly, _ := cmd.Flags().GetBool("enabled")
+	disabledOnly, _ := cmd.Flags().GetBool("disabled")
+	includeStats, _ := cmd.Flags().GetBool("include-stats")
+	sortBy, _ := cmd.Flags().GetString("sort")
+
+	if sortBy != "" {
+		if !includeStats {
+			return errors.NewValidationError("--sort requires --include-stats", nil)
+		}
+		if sortBy != "errors" && sortBy != "last_request" {
+			return errors.NewValidationError("invalid sort '"+sortBy+"' (must be one of: errors, last_request)", nil)
+		}
+	}
+
+	events, err := parseEventFilter(cmd)
+	if err != nil {
+		return err
+	}
+
+	apiClient, err := auth.GetAuthenticatedClient(cmd)
+	if err != nil {
+		return err
+	}
 
 	// Handle pagination
 	var limitPtr *int32
@@ -67,37 +125,123 @@ func runWebhooksList(cmd *cobra.Command, args []string) error {
 
 	// Log command execution
 	logger.Get().WithFields(map[string]interface{}{
-		"limit":        limit,
-		"cursor":       cursor,
-		"enabled_only": enabledOnly,
+		"limit":         limit,
+		"cursor":        cursor,
+		"enabled_only":  enabledOnly,
+		"disabled_only": disabledOnly,
+		"events":        events,
+		"include_stats": includeStats,
+		"sort":          sortBy,
 	}).Debug("Executing webhooks list command")
 
-	// Fetch webhooks
-	response, err := client.ListWebhooks(limitPtr, cursorPtr)
+	// Fetch webhooks; account-wide stats need every page
+	var response *responses.PaginatedWebhooksResponse
+	switch {
+	case enabledOnly || disabledOnly || len(events) > 0:
+		response, err = fetch.MatchingWebhooks(apiClient, webhookListParams(limitPtr, cursorPtr, enabledOnly, disabledOnly, events), limit,
+			func(webhook *responses.Webhook) bool {
+				return matchesWebhookFilter(webhook, enabledOnly, disabledOnly, events)
+			})
+	case includeStats && limitPtr == nil && cursorPtr == nil:
+		response, err = fetch.AllWebhooks(apiClient)
+	default:
+		response, err = apiClient.ListWebhooks(limitPtr, cursorPtr)
+	}
 	if err != nil {
 		return err
 	}
 
-	// Filter by enabled status if specified (client-side filtering)
-	if enabledOnly && response != nil && response.Data != nil {
-		var filteredWebhooks []responses.Webhook
-		for _, webhook := range response.Data {
-			if webhook.Enabled {
-				filteredWebhooks = append(filteredWebhooks, webhook)
-			}
-		}
-		// Update response with filtered data
-		response.Data = filteredWebhooks
+	if sortBy != "" && response != nil {
+		sortWebhooks(response.Data, sortBy)
 	}
 
 	// Use the new ResponseHandler to display webhooks list
 	emptyMessage := "No webhooks found"
-	if enabledOnly {
+	switch {
+	case len(events) > 0:
+		emptyMessage = "No webhooks found subscribed to " + strings.Join(events, " or ")
+	case enabledOnly:
 		emptyMessage = "No enabled webhooks found"
+	case disabledOnly:
+		emptyMessage = "No disabled webhooks found"
 	}
 
 	return handler.HandleWebhookList(response, printer.ListConfig{
 		EmptyMessage: emptyMessage,
 		FieldOrder:   []string{"id", "name", "url", "enabled", "event_types", "scope", "domains", "created_at", "updated_at"},
+		IncludeStats: includeStats,
+	})
+}
+
+// sortWebhooks orders webhooks by error count or last request time, both
+// descending; webhooks that never received a request sort last
+func sortWebhooks(webhooks []responses.Webhook, sortBy string) {
+	sort.SliceStable(webhooks, func(i, j int) bool {
+		switch sortBy {
+		case "errors":
+			return webhooks[i].ErrorCount > webhooks[j].ErrorCount
+		case "last_request":
+			a, b := webhooks[i].LastRequestAt, webhooks[j].LastRequestAt
+			if a == nil || b == nil {
+				return a != nil && b == nil
+			}
+			return a.After(*b)
+		}
+		return false
 	})
 }
+
+// parseEventFilter reads the --event names, failing on unknown ones
+func parseEventFilter(cmd *cobra.Command) ([]string, error) {
+	names, _ := cmd.Flags().GetStringSlice("event")
+
+	var events, invalid []string
+	seen := make(map[string]bool)
+	for _, name := range names {
+		event := strings.ToLower(strings.TrimSpace(name))
+		if !webhooks.IsValidEvent(event) {
+			invalid = append(invalid, name)
+			continue
+		}
+		if !seen[event] {
+			seen[event] = true
+			events = append(events, event)
+		}
+	}
+	if len(invalid) > 0 {
+		return nil, errors.NewValidationError(fmt.Sprintf("invalid event %s (valid events: %s)",
+			strings.Join(invalid, ", "), strings.Join(webhooks.EventKeys(), ", ")), nil)
+	}
+	return events, nil
+}
+
+// webhookListParams asks the API for the filtered webhooks. Its filters must
+// all match, so several events, which match any of them, are filtered by
+// matchesWebhookFilter alone.
+func webhookListParams(limit *int32, cursor *string, enabledOnly, disabledOnly bool, events []string) api.GetWebhooksParams {
+	params := api.GetWebhooksParams{PaginationParams: common.PaginationParams{Limit: limit, Cursor: cursor}}
+	if enabledOnly || disabledOnly {
+		params.Enabled = &enabledOnly
+	}
+	if len(events) == 1 {
+		webhooks.SetListFilter(&params, events[0])
+	}
+	return params
+}
+
+// matchesWebhookFilter reports whether a webhook is in the requested state
+// and subscribed to any of events
+func matchesWebhookFilter(webhook *responses.Webhook, enabledOnly, disabledOnly bool, events []string) bool {
+	if (enabledOnly && !webhook.Enabled) || (disabledOnly && webhook.Enabled) {
+		return false
+	}
+	if len(events) == 0 {
+		return true
+	}
+	for _, event := range events {
+		if webhooks.IsSubscribed(webhook, event) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/AhaSend/ahasend-go/api"
+	"github.com/AhaSend/ahasend-go/models/common"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+)
+
+func executeWebhooksList(t *testing.T, mockClient *mocks.MockClient, args ...string) (string, error) {
+	t.Helper()
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var buf bytes.Buffer
+	cmd := NewListCommand()
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, printer.GetResponseHandler("json", false, &buf)))
+	cmd.SetOut(&buf)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(append(args, "--ids-only"))
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+func TestWebhooksList_EventFilter(t *testing.T) {
+	bounced := createTestWebhook(uuid.New().String(), "Bounces", "https://example.com/a", true)
+	bounced.OnBounced = true
+	failed := createTestWebhook(uuid.New().String(), "Failures", "https://example.com/b", false)
+	failed.OnFailed = true
+	opened := createTestWebhook(uuid.New().String(), "Opens", "https://example.com/c", true)
+	opened.OnOpened = true
+	page := &responses.PaginatedWebhooksResponse{Object: "list", Data: []responses.Webhook{bounced, failed, opened}}
+
+	t.Run("one event is filtered by the API", func(t *testing.T) {
+		subscribed := true
+		mockClient := &mocks.MockClient{}
+		mockClient.On("ListWebhooksWithParams", api.GetWebhooksParams{OnBounced: &subscribed}).Return(page, nil)
+		out, err := executeWebhooksList(t, mockClient, "--event", "Bounced")
+		require.NoError(t, err)
+		assert.Equal(t, bounced.ID.String()+"\n", out)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("several events match any of them", func(t *testing.T) {
+		mockClient := &mocks.MockClient{}
+		mockClient.On("ListWebhooksWithParams", api.GetWebhooksParams{}).Return(page, nil)
+		out, err := executeWebhooksList(t, mockClient, "--event", "bounced,failed")
+		require.NoError(t, err)
+		assert.Equal(t, bounced.ID.String()+"\n"+failed.ID.String()+"\n", out)
+	})
+
+	t.Run("with enabled state", func(t *testing.T) {
+		enabled := false
+		mockClient := &mocks.MockClient{}
+		mockClient.On("ListWebhooksWithParams", api.GetWebhooksParams{Enabled: &enabled}).Return(page, nil)
+		out, err := executeWebhooksList(t, mockClient, "--disabled", "--event", "bounced", "--event", "failed")
+		require.NoError(t, err)
+		assert.Equal(t, failed.ID.String()+"\n", out)
+	})
+
+	t.Run("invalid event", func(t *testing.T) {
+		mockClient := &mocks.MockClient{}
+		_, err := executeWebhooksList(t, mockClient, "--event", "bounced,bounce")
+		require.Error(t, err)
+		assert.Equal(t, 4, errors.GetExitCode(err))
+		assert.Contains(t, err.Error(), "invalid event bounce (valid events: reception, delivered,")
+		mockClient.AssertNotCalled(t, "ListWebhooksWithParams", mock.Anything)
+	})
+}
+
+func TestWebhooksList_FilterReadsPagesUntilLimit(t *testing.T) {
+	limit := int32(1)
+	next := "page-2"
+	disabled := createTestWebhook(uuid.New().String(), "Disabled", "https://example.com/a", false)
+	enabled := createTestWebhook(uuid.New().String(), "Enabled", "https://example.com/b", true)
+
+	mockClient := &mocks.MockClient{}
+	mockClient.On("ListWebhooksWithParams", mock.MatchedBy(func(p api.GetWebhooksParams) bool { return p.Cursor == nil })).Return(&responses.PaginatedWebhooksResponse{
+		Data:       []responses.Webhook{disabled},
+		Pagination: common.PaginationInfo{HasMore: true, NextCursor: &next},
+	}, nil).Once()
+	mockClient.On("ListWebhooksWithParams", mock.MatchedBy(func(p api.GetWebhooksParams) bool {
+		return p.Cursor != nil && *p.Cursor == next && *p.Limit == limit && *p.Enabled
+	})).Return(&responses.PaginatedWebhooksResponse{
+		Data: []responses.Webhook{enabled},
+	}, nil).Once()
+
+	out, err := executeWebhooksList(t, mockClient, "--enabled", "--limit", "1")
+	require.NoError(t, err)
+	assert.Equal(t, enabled.ID.String()+"\n", out, "a disabled webhook returned by the API is still filtered out")
+	mockClient.AssertExpectations(t)
+}
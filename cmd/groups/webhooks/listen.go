@@ -15,6 +15,8 @@ import (
 
 	"github.com/AhaSend/ahasend-cli/internal/auth"
 	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/clockskew"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
 	"github.com/AhaSend/ahasend-cli/internal/logger"
 	"github.com/AhaSend/ahasend-cli/internal/webhooks"
 	"github.com/fatih/color"
@@ -35,7 +37,17 @@ This command establishes a WebSocket connection to receive webhook events and ca
 - Handle disconnections with buffered event replay
 
 The command generates a webhook secret for signing forwarded events using
-the standard-webhooks specification.`,
+the standard-webhooks specification.
+
+Each forwarded event is followed by the endpoint's status, the payload size
+and the round-trip time. Failed forwards are categorized as timeout,
+connection, tls, 4xx or 5xx with a hint at the fix, and payloads larger than
+--warn-payload-kb are flagged, as receivers often limit the request body.
+
+Forwarded events are signed with the local time. --timestamp-offset dates
+the signatures earlier or later, to check how the receiver handles skewed
+timestamps, and a warning is printed when the local clock is more than a
+minute off from the AhaSend API server's.`,
 		Example: `  # Listen for all webhook events
   ahasend webhooks listen
 
@@ -60,6 +72,8 @@ the standard-webhooks specification.`,
 	cmd.Flags().String("forward-to", "", "Local endpoint to forward events to")
 	cmd.Flags().Bool("skip-verify", false, "Skip SSL certificate verification for local endpoints when forwarding events")
 	cmd.Flags().Bool("slim-output", false, "Slim down the payload for printing to the console")
+	cmd.Flags().Int("warn-payload-kb", 100, "Warn when a forwarded payload is larger than this many KB (0 disables the warning)")
+	cmd.Flags().Duration("timestamp-offset", 0, "Date forwarded signatures this far from the local clock (e.g. -10m) to test the receiver's tolerance")
 
 	return cmd
 }
@@ -76,11 +90,16 @@ func runWebhooksListen(cmd *cobra.Command, args []string) error {
 	forwardTo, _ := cmd.Flags().GetString("forward-to")
 	skipVerify, _ := cmd.Flags().GetBool("skip-verify")
 	slimOutput, _ := cmd.Flags().GetBool("slim-output")
+	warnPayloadKB, _ := cmd.Flags().GetInt("warn-payload-kb")
+	timestampOffset, _ := cmd.Flags().GetDuration("timestamp-offset")
 
 	// Validate event types for listening (different from webhook creation)
 	if err := validateListenEventTypes(events); err != nil {
 		return err
 	}
+	if warnPayloadKB < 0 {
+		return errors.NewValidationError("--warn-payload-kb cannot be negative", nil)
+	}
 
 	// Log command execution
 	logger.Get().WithFields(map[string]interface{}{
@@ -105,7 +124,8 @@ func runWebhooksListen(cmd *cobra.Command, args []string) error {
 	// Create signer for forwarding
 	var signer *webhooks.Signer
 	if forwardTo != "" {
-		signer = webhooks.NewSigner(secret)
+		signer = webhooks.NewSigner(secret).WithTimestampOffset(timestampOffset)
+		clockskew.Warn(cmd)
 	}
 
 	// Connect to WebSocket
@@ -229,7 +249,11 @@ func runWebhooksListen(cmd *cobra.Command, args []string) error {
 
 					// Forward event if configured
 					if forwardTo != "" && signer != nil {
-						go forwardEvent(httpClient, forwardTo, msg.Event, signer)
+						event := msg.Event
+						go func() {
+							result := forwardEvent(httpClient, forwardTo, event, signer)
+							webhooks.PrintForwardResult(os.Stdout, event, result, warnPayloadKB*1024)
+						}()
 					}
 				}
 
@@ -327,17 +351,20 @@ func displayEvent(msg *client.WebSocketMessage, slimOutput bool) {
 	fmt.Println(strings.Repeat("─", 60))
 }
 
-func forwardEvent(httpClient *http.Client, forwardTo string, event *client.Event, signer *webhooks.Signer) {
+// forwardEvent signs and posts the event to forwardTo and returns the
+// endpoint's response, the payload size and the round-trip time
+func forwardEvent(httpClient *http.Client, forwardTo string, event *client.Event, signer *webhooks.Signer) webhooks.ForwardResult {
 	// Prepare payload
 	payload, err := json.Marshal(event.Data)
 	if err != nil {
 		logger.Get().WithError(err).Error("Failed to marshal event data for forwarding")
-		return
+		return webhooks.ForwardResult{Err: err}
 	}
+	result := webhooks.ForwardResult{PayloadSize: len(payload)}
 
 	// Generate message ID and timestamp
 	msgID := webhooks.GenerateMsgID()
-	timestamp := time.Now()
+	timestamp := signer.SigningTime()
 
 	logger.Get().WithFields(map[string]interface{}{
 		"msg_id":       msgID,
@@ -350,14 +377,16 @@ func forwardEvent(httpClient *http.Client, forwardTo string, event *client.Event
 	signature, err := signer.Sign(msgID, timestamp, payload)
 	if err != nil {
 		logger.Get().WithError(err).Error("Failed to sign webhook payload")
-		return
+		result.Err = err
+		return result
 	}
 
 	// Create request
 	req, err := http.NewRequest("POST", forwardTo, bytes.NewReader(payload))
 	if err != nil {
 		logger.Get().WithError(err).Error("Failed to create forward request")
-		return
+		result.Err = err
+		return result
 	}
 
 	// Set headers
@@ -381,15 +410,19 @@ func forwardEvent(httpClient *http.Client, forwardTo string, event *client.Event
 	startTime := time.Now()
 	resp, err := httpClient.Do(req)
 	duration := time.Since(startTime)
+	result.Duration = duration
 
 	if err != nil {
 		logger.Get().WithError(err).WithFields(map[string]interface{}{
 			"url":      forwardTo,
 			"duration": duration.String(),
+			"failure":  webhooks.CategorizeFailure(0, err),
 		}).Error("Failed to forward webhook")
-		return
+		result.Err = err
+		return result
 	}
 	defer resp.Body.Close()
+	result.StatusCode = resp.StatusCode
 
 	logger.Get().WithFields(map[string]interface{}{
 		"url":      forwardTo,
@@ -404,10 +437,12 @@ func forwardEvent(httpClient *http.Client, forwardTo string, event *client.Event
 		}).Debug("Successfully forwarded webhook event")
 	} else {
 		logger.Get().WithFields(map[string]interface{}{
-			"url":    forwardTo,
-			"status": resp.StatusCode,
+			"url":     forwardTo,
+			"status":  resp.StatusCode,
+			"failure": result.Failure(),
 		}).Warn("Webhook forward returned non-2xx status")
 	}
+	return result
 }
 
 func validateListenEventTypes(events []string) error {
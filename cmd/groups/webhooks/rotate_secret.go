@@ -0,0 +1,113 @@
+package webhooks
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/bulk"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/spf13/cobra"
+)
+
+// NewRotateSecretCommand creates the rotate-secret command
+func NewRotateSecretCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rotate-secret <webhook-id>",
+		Short: "Generate a new signing secret for a webhook",
+		Long: `Generate a new signing secret for an existing webhook, for example after
+the old one was leaked.
+
+The new secret is shown once, like the secret of a newly created webhook;
+store it securely. The old secret stops working immediately: webhook
+consumers verifying signatures with it will reject every event until they
+are updated with the new secret.
+
+By default, you will be prompted to confirm the rotation. Use the --force
+flag to skip the confirmation prompt for automated scripts; without a
+terminal to confirm on, --force is required.`,
+		Example: `  # Rotate a webhook secret with confirmation prompt
+  ahasend webhooks rotate-secret abcd1234-5678-90ef-abcd-1234567890ab
+
+  # Rotate without confirmation and capture the new secret
+  ahasend webhooks rotate-secret abcd1234-5678-90ef-abcd-1234567890ab --force --output json | jq -r .secret`,
+		Args:         cobra.ExactArgs(1),
+		RunE:         runWebhooksRotateSecret,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().Bool("force", false, "Skip confirmation prompt")
+
+	return cmd
+}
+
+func runWebhooksRotateSecret(cmd *cobra.Command, args []string) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+
+	client, err := auth.GetAuthenticatedClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	webhookID := args[0]
+	force, _ := cmd.Flags().GetBool("force")
+
+	if !force {
+		if !bulk.IsInteractive(cmd.InOrStdin()) {
+			return errors.NewValidationError("refusing to rotate the webhook secret without confirmation; re-run with --force to proceed non-interactively", nil)
+		}
+
+		webhook, err := getWebhookForConfirmation(client, webhookID)
+		if err != nil {
+			return err
+		}
+		if !confirmSecretRotation(cmd, webhook) {
+			return handler.HandleSimpleSuccess("Webhook secret rotation cancelled")
+		}
+	}
+
+	logger.Get().WithFields(map[string]interface{}{
+		"webhook_id": webhookID,
+		"force":      force,
+	}).Debug("Executing webhooks rotate-secret command")
+
+	webhook, err := client.RotateWebhookSecret(webhookID)
+	if err != nil {
+		return err
+	}
+	if webhook == nil {
+		return errors.NewAPIError("received nil response from API", nil)
+	}
+
+	return handler.HandleRotateWebhookSecret(webhook, printer.UpdateConfig{
+		SuccessMessage: fmt.Sprintf("Successfully rotated the secret of webhook: %s", webhook.Name),
+		ItemName:       "webhook",
+	})
+}
+
+// confirmSecretRotation describes the webhook and its consumers' fate on
+// stderr and asks for a y/N answer
+func confirmSecretRotation(cmd *cobra.Command, webhook *responses.Webhook) bool {
+	out := cmd.ErrOrStderr()
+	fmt.Fprintf(out, "You are about to rotate the secret of the following webhook:\n\n")
+	fmt.Fprintf(out, "  Name:         %s\n", webhook.Name)
+	fmt.Fprintf(out, "  ID:           %s\n", webhook.ID)
+	fmt.Fprintf(out, "  URL:          %s\n", webhook.URL)
+	fmt.Fprintf(out, "  Status:       %s\n", getWebhookStatus(webhook.Enabled))
+	fmt.Fprintf(out, "\n")
+
+	fmt.Fprintf(out, "⚠️  The current secret stops working immediately. Existing consumers will fail\n")
+	fmt.Fprintf(out, "signature verification on every event until they are updated with the new secret.\n")
+	fmt.Fprintf(out, "\nDo you want to rotate the secret of this webhook? (y/N): ")
+
+	response, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	if err != nil && response == "" {
+		return false
+	}
+	response = strings.TrimSpace(strings.ToLower(response))
+	return response == "y" || response == "yes"
+}
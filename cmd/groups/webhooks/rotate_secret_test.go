@@ -0,0 +1,88 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+)
+
+const rotateWebhookID = "abcd1234-5678-90ef-abcd-1234567890ab"
+
+func executeRotateSecret(t *testing.T, format, input string, setup func(*mocks.MockClient), args ...string) (*mocks.MockClient, string, string, error) {
+	t.Helper()
+	mockClient := &mocks.MockClient{}
+	setup(mockClient)
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var stdout, stderr bytes.Buffer
+	cmd := NewRotateSecretCommand()
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, printer.GetResponseHandler(format, false, &stdout)))
+	cmd.SetIn(strings.NewReader(input))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return mockClient, stdout.String(), stderr.String(), err
+}
+
+func rotatedWebhook() *responses.Webhook {
+	return &responses.Webhook{
+		ID:     uuid.MustParse(rotateWebhookID),
+		Name:   "Orders",
+		URL:    "https://example.com/webhook",
+		Secret: "whsec_new",
+	}
+}
+
+func TestRotateSecretCommand_Confirmed(t *testing.T) {
+	mockClient, stdout, stderr, err := executeRotateSecret(t, "plain", "y\n", func(m *mocks.MockClient) {
+		m.On("GetWebhook", rotateWebhookID).Return(rotatedWebhook(), nil)
+		m.On("RotateWebhookSecret", rotateWebhookID).Return(rotatedWebhook(), nil)
+	}, rotateWebhookID)
+	require.NoError(t, err)
+
+	mockClient.AssertCalled(t, "RotateWebhookSecret", rotateWebhookID)
+	assert.Contains(t, stderr, "Existing consumers will fail")
+	assert.Contains(t, stdout, "Secret: whsec_new")
+	assert.Contains(t, stdout, "It will not be shown again")
+}
+
+func TestRotateSecretCommand_Declined(t *testing.T) {
+	mockClient, stdout, _, err := executeRotateSecret(t, "plain", "n\n", func(m *mocks.MockClient) {
+		m.On("GetWebhook", rotateWebhookID).Return(rotatedWebhook(), nil)
+	}, rotateWebhookID)
+	require.NoError(t, err)
+
+	mockClient.AssertNotCalled(t, "RotateWebhookSecret", mock.Anything)
+	assert.Contains(t, stdout, "Webhook secret rotation cancelled")
+}
+
+func TestRotateSecretCommand_Force(t *testing.T) {
+	mockClient, stdout, stderr, err := executeRotateSecret(t, "json", "", func(m *mocks.MockClient) {
+		m.On("RotateWebhookSecret", rotateWebhookID).Return(rotatedWebhook(), nil)
+	}, rotateWebhookID, "--force")
+	require.NoError(t, err)
+
+	mockClient.AssertNotCalled(t, "GetWebhook", mock.Anything)
+	assert.Empty(t, stderr)
+	var webhook responses.Webhook
+	require.NoError(t, json.Unmarshal([]byte(stdout), &webhook))
+	assert.Equal(t, "whsec_new", webhook.Secret)
+}
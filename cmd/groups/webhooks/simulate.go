@@ -0,0 +1,227 @@
+package webhooks
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	random "math/rand/v2"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/clockskew"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/webhooks"
+	"github.com/spf13/cobra"
+)
+
+// maxSimulatedEvents caps --count so a typo cannot flood a receiver
+const maxSimulatedEvents = 100
+
+// simulateTimeout bounds each delivery of a simulated event
+const simulateTimeout = 10 * time.Second
+
+// NewSimulateCommand creates the simulate command
+func NewSimulateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "simulate",
+		Short: "Send realistic signed sample events to a webhook",
+		Long: `Generate realistic sample events and deliver them to a webhook endpoint,
+signed with the webhook's secret exactly like real deliveries.
+
+Payloads follow the webhook event schemas of the AhaSend SDK and are filled
+with plausible data: recipient addresses, message IDs, subjects, timestamps,
+client details for opens and clicks, and bounce classifications for
+suppressions. Addresses, hosts and IPs use domains and networks reserved for
+documentation, so they never belong to real people or servers.
+
+Unlike 'ahasend webhooks trigger', the events are sent from your machine.
+That lets you target a local receiver with --url and see each HTTP response.
+Use --local-print to print the signed requests without sending them.
+
+The data is generated from --seed, so the same seed produces the same
+recipients, IDs and event details; timestamps are relative to the time of
+the run, so signatures stay within receivers' replay tolerance. Without
+--seed a random seed is used and reported, so a run can be reproduced.
+
+To test that tolerance instead, --timestamp-offset dates the signatures
+earlier or later than the local clock, e.g. -10m for a delivery that looks
+ten minutes old. A warning is printed when the local clock is more than a
+minute off from the AhaSend API server's, as receivers compare the
+timestamps with their own clocks.
+
+Valid event types: ` + strings.Join(webhooks.EventKeys(), ", "),
+		Example: `  # Send a bounced event to the webhook's URL
+  ahasend webhooks simulate --webhook-id abcd1234-5678-90ef-abcd-1234567890ab \
+    --event bounced
+
+  # Send 10 reproducible click events to a local receiver
+  ahasend webhooks simulate --webhook-id abcd1234-5678-90ef-abcd-1234567890ab \
+    --event clicked --count 10 --seed 42 --url http://localhost:3000/webhooks
+
+  # Print the signed requests without sending them
+  ahasend webhooks simulate --webhook-id abcd1234-5678-90ef-abcd-1234567890ab \
+    --event suppression_created --local-print`,
+		Args:         cobra.NoArgs,
+		RunE:         runWebhooksSimulate,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().String("webhook-id", "", "Webhook whose secret signs the events (required)")
+	cmd.Flags().String("event", "", "Event type to simulate (required)")
+	cmd.Flags().Int("count", 1, fmt.Sprintf("Number of events to send (1-%d)", maxSimulatedEvents))
+	cmd.Flags().Uint64("seed", 0, "Seed for the generated data (random when not set)")
+	cmd.Flags().String("url", "", "Send to this URL instead of the webhook's URL")
+	cmd.Flags().Bool("local-print", false, "Print the signed requests instead of sending them")
+	cmd.Flags().Duration("timestamp-offset", 0, "Date the signatures this far from the local clock (e.g. -10m) to test the receiver's tolerance")
+	cmd.MarkFlagRequired("webhook-id")
+	cmd.MarkFlagRequired("event")
+
+	return cmd
+}
+
+func runWebhooksSimulate(cmd *cobra.Command, args []string) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+
+	webhookID, _ := cmd.Flags().GetString("webhook-id")
+	event, _ := cmd.Flags().GetString("event")
+	count, _ := cmd.Flags().GetInt("count")
+	seed, _ := cmd.Flags().GetUint64("seed")
+	url, _ := cmd.Flags().GetString("url")
+	localPrint, _ := cmd.Flags().GetBool("local-print")
+	timestampOffset, _ := cmd.Flags().GetDuration("timestamp-offset")
+
+	if !webhooks.IsValidEvent(event) {
+		return errors.NewValidationError(fmt.Sprintf("invalid event type: %s\n\nValid event types are:\n%s",
+			event, strings.Join(webhooks.EventKeys(), "\n")), nil)
+	}
+	if count < 1 || count > maxSimulatedEvents {
+		return errors.NewValidationError(fmt.Sprintf("--count must be between 1 and %d", maxSimulatedEvents), nil)
+	}
+	if !cmd.Flags().Changed("seed") {
+		seed = random.Uint64()
+	}
+
+	apiClient, err := auth.GetAuthenticatedClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	webhook, err := apiClient.GetWebhook(webhookID)
+	if err != nil {
+		return err
+	}
+	if webhook == nil {
+		return errors.NewNotFoundError(fmt.Sprintf("webhook %s not found", webhookID), nil)
+	}
+	if webhook.Secret == "" {
+		return errors.NewAPIError(fmt.Sprintf("webhook %s has no signing secret to sign the events with", webhookID), nil)
+	}
+	if url == "" {
+		url = webhook.URL
+	}
+
+	logger.Get().WithFields(map[string]interface{}{
+		"webhook_id":       webhookID,
+		"event":            event,
+		"count":            count,
+		"seed":             seed,
+		"url":              url,
+		"local_print":      localPrint,
+		"timestamp_offset": timestampOffset.String(),
+	}).Debug("Executing webhooks simulate command")
+
+	simulation := &printer.WebhookSimulation{
+		WebhookID: webhookID,
+		URL:       url,
+		Seed:      seed,
+		Sent:      !localPrint,
+	}
+	simulator := webhooks.NewSimulator(seed, time.Now(), webhookID)
+	signer := webhooks.NewSigner(webhook.Secret).WithTimestampOffset(timestampOffset)
+	httpClient := &http.Client{Timeout: simulateTimeout}
+	clockskew.Warn(cmd)
+
+	for i := 0; i < count; i++ {
+		sample, err := simulator.Generate(event)
+		if err != nil {
+			return err
+		}
+		simulation.Event = sample.Type
+
+		signedAt := signer.SigningTime()
+		signature, err := signer.Sign(sample.MsgID, signedAt, sample.Payload)
+		if err != nil {
+			return fmt.Errorf("failed to sign simulated event: %w", err)
+		}
+
+		result := printer.WebhookSimulationEvent{
+			MsgID:     sample.MsgID,
+			Type:      sample.Type,
+			SignedAt:  signedAt.Unix(),
+			Signature: signature,
+			Payload:   string(sample.Payload),
+			Status:    printer.SimulationStatusSigned,
+		}
+		if !localPrint {
+			deliverSimulatedEvent(httpClient, url, &result)
+		}
+		simulation.Events = append(simulation.Events, result)
+	}
+
+	if err := handler.HandleWebhookSimulation(simulation, printer.SingleConfig{
+		EmptyMessage: "No events simulated",
+	}); err != nil {
+		return err
+	}
+	if failed := simulation.Failed(); failed > 0 {
+		return errors.NewAPIError(fmt.Sprintf("%d of %d simulated events were not accepted by %s", failed, count, url), nil)
+	}
+	return nil
+}
+
+// deliverSimulatedEvent posts a signed event with the headers of a real
+// delivery and records the receiver's response on result
+func deliverSimulatedEvent(httpClient *http.Client, url string, result *printer.WebhookSimulationEvent) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader([]byte(result.Payload)))
+	if err != nil {
+		result.Status, result.Error = printer.SimulationStatusFailed, err.Error()
+		result.Failure = webhooks.CategorizeFailure(0, err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("webhook-id", result.MsgID)
+	req.Header.Set("webhook-timestamp", fmt.Sprintf("%d", result.SignedAt))
+	req.Header.Set("webhook-signature", result.Signature)
+
+	startTime := time.Now()
+	resp, err := httpClient.Do(req)
+	result.DurationMs = time.Since(startTime).Milliseconds()
+	if err != nil {
+		result.Status, result.Error = printer.SimulationStatusFailed, err.Error()
+		result.Failure = webhooks.CategorizeFailure(0, err)
+		return
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	result.StatusCode = resp.StatusCode
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		result.Status = printer.SimulationStatusDelivered
+	} else {
+		result.Status = printer.SimulationStatusFailed
+		result.Error = resp.Status
+		result.Failure = webhooks.CategorizeFailure(resp.StatusCode, nil)
+	}
+
+	logger.Get().WithFields(map[string]interface{}{
+		"msg_id":   result.MsgID,
+		"url":      url,
+		"status":   resp.StatusCode,
+		"duration": result.DurationMs,
+	}).Debug("Delivered simulated webhook event")
+}
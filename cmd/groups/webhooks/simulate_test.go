@@ -0,0 +1,254 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	sdkwebhooks "github.com/AhaSend/ahasend-go/webhooks"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const simulateSecret = "aha-whsec-simulate"
+
+// simulateReceiver verifies deliveries with the SDK and answers with status
+type simulateReceiver struct {
+	mu     sync.Mutex
+	status int
+	events []sdkwebhooks.WebhookEvent
+}
+
+func newSimulateReceiver(t *testing.T, status int) (*simulateReceiver, *httptest.Server) {
+	t.Helper()
+	verifier, err := sdkwebhooks.NewWebhookVerifier(simulateSecret)
+	require.NoError(t, err)
+
+	receiver := &simulateReceiver{status: status}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		event, err := verifier.ParseRequest(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		receiver.mu.Lock()
+		receiver.events = append(receiver.events, event)
+		receiver.mu.Unlock()
+		w.WriteHeader(receiver.status)
+	}))
+	t.Cleanup(server.Close)
+	return receiver, server
+}
+
+func executeSimulateCommand(t *testing.T, webhookURL, format string, args ...string) (string, error) {
+	t.Helper()
+
+	webhookID := uuid.New().String()
+	webhook := createTestWebhook(webhookID, "Receiver", webhookURL, true)
+	webhook.Secret = simulateSecret
+
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetWebhook", webhookID).Return(&webhook, nil)
+
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var buf bytes.Buffer
+	handler := printer.GetResponseHandler(format, false, &buf)
+	cmd := NewSimulateCommand()
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&buf)
+	cmd.SetErr(&bytes.Buffer{}) // keep cobra's error line out of the structured output
+	cmd.SetArgs(append([]string{"--webhook-id", webhookID}, args...))
+
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+type simulationOutput struct {
+	URL    string `json:"url"`
+	Event  string `json:"event"`
+	Seed   uint64 `json:"seed"`
+	Sent   bool   `json:"sent"`
+	Failed int    `json:"failed"`
+	Events []struct {
+		MsgID      string `json:"msg_id"`
+		Signature  string `json:"webhook_signature"`
+		Payload    string `json:"payload"`
+		Status     string `json:"status"`
+		StatusCode int    `json:"status_code"`
+		Failure    string `json:"failure"`
+	} `json:"events"`
+}
+
+func TestSimulateCommand_SendsSignedEvents(t *testing.T) {
+	receiver, server := newSimulateReceiver(t, http.StatusOK)
+
+	out, err := executeSimulateCommand(t, server.URL, "json", "--event", "bounced", "--count", "3", "--seed", "42")
+	require.NoError(t, err)
+
+	var result simulationOutput
+	require.NoError(t, json.Unmarshal([]byte(out), &result))
+	assert.Equal(t, server.URL, result.URL)
+	assert.Equal(t, "message.bounced", result.Event)
+	assert.Equal(t, uint64(42), result.Seed)
+	assert.True(t, result.Sent)
+	assert.Zero(t, result.Failed)
+	require.Len(t, result.Events, 3)
+	for _, event := range result.Events {
+		assert.Equal(t, printer.SimulationStatusDelivered, event.Status)
+		assert.Equal(t, http.StatusOK, event.StatusCode)
+	}
+
+	// The receiver accepted every signature and parsed the typed events
+	require.Len(t, receiver.events, 3)
+	for _, event := range receiver.events {
+		assert.IsType(t, &sdkwebhooks.MessageBouncedEvent{}, event)
+	}
+}
+
+func TestSimulateCommand_SeedIsReproducible(t *testing.T) {
+	run := func() simulationOutput {
+		out, err := executeSimulateCommand(t, "https://example.com/hook", "json", "--event", "clicked", "--count", "2", "--seed", "7", "--local-print")
+		require.NoError(t, err)
+		var result simulationOutput
+		require.NoError(t, json.Unmarshal([]byte(out), &result))
+		return result
+	}
+
+	first, second := run(), run()
+	require.Len(t, first.Events, 2)
+	for i := range first.Events {
+		assert.Equal(t, first.Events[i].MsgID, second.Events[i].MsgID)
+
+		var a, b sdkwebhooks.MessageClickedEvent
+		require.NoError(t, json.Unmarshal([]byte(first.Events[i].Payload), &a))
+		require.NoError(t, json.Unmarshal([]byte(second.Events[i].Payload), &b))
+		assert.Equal(t, a.Data, b.Data)
+	}
+}
+
+func TestSimulateCommand_LocalPrint(t *testing.T) {
+	receiver, server := newSimulateReceiver(t, http.StatusOK)
+
+	out, err := executeSimulateCommand(t, server.URL, "table", "--event", "suppression_created", "--count", "2", "--local-print")
+	require.NoError(t, err)
+
+	assert.Empty(t, receiver.events, "--local-print sends nothing")
+	assert.Equal(t, 2, bytes.Count([]byte(out), []byte("POST "+server.URL)))
+	assert.Contains(t, out, "webhook-signature: v1,")
+	assert.Contains(t, out, `"type":"suppression.created"`)
+	assert.Contains(t, out, "Signed 2 simulated suppression.created events")
+}
+
+func TestSimulateCommand_URLOverride(t *testing.T) {
+	receiver, server := newSimulateReceiver(t, http.StatusAccepted)
+
+	_, err := executeSimulateCommand(t, "https://example.invalid/hook", "plain", "--event", "delivered", "--url", server.URL)
+	require.NoError(t, err)
+	assert.Len(t, receiver.events, 1)
+}
+
+func TestSimulateCommand_ReceiverRejects(t *testing.T) {
+	_, server := newSimulateReceiver(t, http.StatusInternalServerError)
+
+	out, err := executeSimulateCommand(t, server.URL, "json", "--event", "opened", "--count", "2")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "2 of 2 simulated events were not accepted")
+
+	var result simulationOutput
+	require.NoError(t, json.Unmarshal([]byte(out), &result))
+	assert.Equal(t, 2, result.Failed)
+	assert.Equal(t, printer.SimulationStatusFailed, result.Events[0].Status)
+	assert.Equal(t, http.StatusInternalServerError, result.Events[0].StatusCode)
+	assert.Equal(t, "5xx", result.Events[0].Failure)
+}
+
+func TestSimulateCommand_TimestampOffset(t *testing.T) {
+	receiver, server := newSimulateReceiver(t, http.StatusOK)
+
+	// Receivers reject timestamps outside their five minute window
+	out, err := executeSimulateCommand(t, server.URL, "json", "--event", "delivered", "--timestamp-offset", "-10m")
+	require.Error(t, err)
+	assert.Empty(t, receiver.events)
+
+	var result simulationOutput
+	require.NoError(t, json.Unmarshal([]byte(out), &result))
+	assert.Equal(t, http.StatusUnauthorized, result.Events[0].StatusCode)
+
+	_, err = executeSimulateCommand(t, server.URL, "json", "--event", "delivered", "--timestamp-offset", "-2m")
+	require.NoError(t, err)
+	assert.Len(t, receiver.events, 1)
+}
+
+func TestSimulateCommand_FailureCategories(t *testing.T) {
+	_, server := newSimulateReceiver(t, http.StatusRequestEntityTooLarge)
+
+	out, err := executeSimulateCommand(t, server.URL, "table", "--event", "opened", "--count", "2")
+	require.Error(t, err)
+	assert.Contains(t, out, "FAILURE")
+	assert.Contains(t, out, "failed: 2 4xx")
+	assert.Contains(t, out, "4xx: the payload is larger than the endpoint accepts")
+
+	// Nothing listens on a closed server's port
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+	out, err = executeSimulateCommand(t, closed.URL, "json", "--event", "opened")
+	require.Error(t, err)
+
+	var result simulationOutput
+	require.NoError(t, json.Unmarshal([]byte(out), &result))
+	assert.Equal(t, "connection", result.Events[0].Failure)
+}
+
+func TestSimulateCommand_Validation(t *testing.T) {
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{[]string{"--event", "message.bounced"}, "invalid event type: message.bounced"},
+		{[]string{"--event", "bounced", "--count", "0"}, "--count must be between 1 and 100"},
+		{[]string{"--event", "bounced", "--count", "101"}, "--count must be between 1 and 100"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.wantErr, func(t *testing.T) {
+			_, err := executeSimulateCommand(t, "https://example.com/hook", "json", tt.args...)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestSimulateCommand_WebhookWithoutSecret(t *testing.T) {
+	webhookID := uuid.New().String()
+	webhook := createTestWebhook(webhookID, "Receiver", "https://example.com/hook", true)
+
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetWebhook", webhookID).Return(&webhook, nil)
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	defer restore()
+
+	cmd := NewSimulateCommand()
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, printer.GetResponseHandler("json", false, &bytes.Buffer{})))
+	cmd.SetArgs([]string{"--webhook-id", webhookID, "--event", "bounced"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no signing secret")
+}
@@ -1,12 +1,22 @@
 package webhooks
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"os"
 	"strings"
+	"time"
+
+	random "math/rand/v2"
 
 	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/jsonmerge"
 	"github.com/AhaSend/ahasend-cli/internal/logger"
 	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/webhooks"
 	"github.com/spf13/cobra"
 )
 
@@ -23,6 +33,22 @@ particularly useful during development and integration testing.
 
 The webhook ID can be found using the 'ahasend webhooks list' command.
 
+To exercise specific branches of your consumer, override fields of the test
+payload. --payload-override takes a file with a partial JSON object that is
+deep-merged over the generated payload: objects merge key by key, while
+arrays and other values replace what was generated. Each --set key.path=value
+is applied after the merge; the value is parsed as JSON (numbers, booleans,
+arrays, objects, null) unless the field holds a string, and array elements
+are addressed by index, e.g. --set data.tags.0=vip. The result is checked
+against the event schema, so a misspelled field name fails before anything
+is sent.
+
+The API's trigger endpoint only sends canned payloads, so events with
+overrides are generated, signed with the webhook's secret and delivered from
+your machine, as with 'ahasend webhooks simulate'. JSON output includes the
+final payload of each event; table output summarizes what the overrides
+changed.
+
 Note: This is a development-only feature and may not be available in
 production environments.`,
 		Example: `  # Trigger a single event
@@ -35,7 +61,16 @@ production environments.`,
 
   # Trigger all available events
   ahasend webhooks trigger abcd1234-5678-90ef-abcd-1234567890ab \
-    --all-events`,
+    --all-events
+
+  # Trigger a bounce for a recipient domain your consumer handles specially
+  ahasend webhooks trigger abcd1234-5678-90ef-abcd-1234567890ab \
+    --events message.bounced --set data.recipient=ops@partner.example
+
+  # Merge a partial payload from a file, then override one field
+  ahasend webhooks trigger abcd1234-5678-90ef-abcd-1234567890ab \
+    --events message.delivered --payload-override delivered.json \
+    --set data.subject="Order shipped"`,
 		Args:         cobra.ExactArgs(1),
 		RunE:         runWebhooksTrigger,
 		SilenceUsage: true,
@@ -45,6 +80,10 @@ production environments.`,
 	cmd.Flags().StringSlice("events", []string{}, "Event types to trigger")
 	cmd.Flags().Bool("all-events", false, "Trigger all available event types")
 
+	// Payload override flags
+	cmd.Flags().String("payload-override", "", "JSON file with a partial payload deep-merged over each generated event")
+	cmd.Flags().StringArray("set", []string{}, "Set a payload field after the merge, as key.path=value (repeatable)")
+
 	return cmd
 }
 
@@ -93,6 +132,12 @@ func runWebhooksTrigger(cmd *cobra.Command, args []string) error {
 		"all_events": allEvents,
 	}).Debug("Executing webhooks trigger command")
 
+	overrideFile, _ := cmd.Flags().GetString("payload-override")
+	assignments, _ := cmd.Flags().GetStringArray("set")
+	if overrideFile != "" || len(assignments) > 0 {
+		return triggerWithOverrides(handler, client, webhookID, eventsToTrigger, overrideFile, assignments)
+	}
+
 	// Trigger the webhook
 	err = client.TriggerWebhook(webhookID, eventsToTrigger)
 	if err != nil {
@@ -108,6 +153,132 @@ func runWebhooksTrigger(cmd *cobra.Command, args []string) error {
 	})
 }
 
+// triggerWithOverrides generates the test events locally, applies the
+// payload overrides, checks the results against the event schemas and only
+// then signs and delivers them to the webhook's URL
+func triggerWithOverrides(handler printer.ResponseHandler, apiClient client.AhaSendClient, webhookID string, events []string, overrideFile string, assignments []string) error {
+	var override map[string]interface{}
+	if overrideFile != "" {
+		var err error
+		if override, err = loadPayloadOverride(overrideFile); err != nil {
+			return err
+		}
+	}
+
+	webhook, err := apiClient.GetWebhook(webhookID)
+	if err != nil {
+		return err
+	}
+	if webhook == nil {
+		return errors.NewNotFoundError(fmt.Sprintf("webhook %s not found", webhookID), nil)
+	}
+	if webhook.Secret == "" {
+		return errors.NewAPIError(fmt.Sprintf("webhook %s has no signing secret to sign the events with", webhookID), nil)
+	}
+
+	// Build and check every payload before anything is sent
+	simulator := webhooks.NewSimulator(random.Uint64(), time.Now(), webhookID)
+	trigger := &printer.WebhookTrigger{WebhookID: webhookID, URL: webhook.URL}
+	for _, name := range events {
+		event, err := overriddenEvent(simulator, name, override, assignments)
+		if err != nil {
+			return err
+		}
+		trigger.Events = append(trigger.Events, *event)
+	}
+
+	signer := webhooks.NewSigner(webhook.Secret)
+	httpClient := &http.Client{Timeout: simulateTimeout}
+	for i := range trigger.Events {
+		event := &trigger.Events[i]
+		payload, err := json.Marshal(event.Payload)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s event: %w", event.Type, err)
+		}
+		signedAt := time.Now()
+		signature, err := signer.Sign(event.MsgID, signedAt, payload)
+		if err != nil {
+			return fmt.Errorf("failed to sign %s event: %w", event.Type, err)
+		}
+
+		delivery := printer.WebhookSimulationEvent{
+			MsgID:     event.MsgID,
+			Type:      event.Type,
+			SignedAt:  signedAt.Unix(),
+			Signature: signature,
+			Payload:   string(payload),
+		}
+		deliverSimulatedEvent(httpClient, webhook.URL, &delivery)
+		event.Status, event.StatusCode, event.DurationMs = delivery.Status, delivery.StatusCode, delivery.DurationMs
+		event.Failure, event.Error = delivery.Failure, delivery.Error
+	}
+
+	if err := handler.HandleTriggerWebhookOverrides(trigger, printer.TriggerConfig{
+		SuccessMessage: fmt.Sprintf("Triggered webhook events with payload overrides: %s", strings.Join(events, ", ")),
+	}); err != nil {
+		return err
+	}
+	if failed := trigger.Failed(); failed > 0 {
+		return errors.NewAPIError(fmt.Sprintf("%d of %d events were not accepted by %s", failed, len(trigger.Events), webhook.URL), nil)
+	}
+	return nil
+}
+
+// overriddenEvent generates a test event and applies the override and the
+// --set assignments to its payload, which must still match the event schema
+func overriddenEvent(simulator *webhooks.Simulator, name string, override map[string]interface{}, assignments []string) (*printer.WebhookTriggerEvent, error) {
+	key, ok := webhooks.EventKeyForName(name)
+	if !ok {
+		return nil, errors.NewValidationError(fmt.Sprintf("invalid event type: %s", name), nil)
+	}
+	sample, err := simulator.Generate(key)
+	if err != nil {
+		return nil, err
+	}
+	var generated map[string]interface{}
+	if err := json.Unmarshal(sample.Payload, &generated); err != nil {
+		return nil, fmt.Errorf("failed to decode generated %s event: %w", name, err)
+	}
+
+	payload, err := jsonmerge.Merge(generated, override)
+	if err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("--payload-override does not fit the %s payload", name), err)
+	}
+	for _, assignment := range assignments {
+		path, value, err := jsonmerge.ParseAssignment(assignment, payload)
+		if err == nil {
+			err = jsonmerge.Set(payload, path, value)
+		}
+		if err != nil {
+			return nil, errors.NewValidationError(fmt.Sprintf("--set %s", assignment), err)
+		}
+	}
+	if err := webhooks.ValidatePayload(key, payload); err != nil {
+		return nil, errors.NewValidationError("invalid payload override", err)
+	}
+
+	return &printer.WebhookTriggerEvent{
+		MsgID:     sample.MsgID,
+		Type:      name,
+		Payload:   payload,
+		Overrides: jsonmerge.Changes(generated, payload),
+	}, nil
+}
+
+// loadPayloadOverride reads the partial payload of --payload-override,
+// which must be a JSON object
+func loadPayloadOverride(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.NewFileError("failed to read payload override "+path, err)
+	}
+	var override map[string]interface{}
+	if err := json.Unmarshal(data, &override); err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("payload override %s must be a JSON object", path), err)
+	}
+	return override, nil
+}
+
 func getAllValidTriggerEvents() []string {
 	return []string{
 		"message.reception",
@@ -0,0 +1,124 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	sdkwebhooks "github.com/AhaSend/ahasend-go/webhooks"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func executeTriggerCommand(t *testing.T, webhookURL, format string, args ...string) (string, *mocks.MockClient, error) {
+	t.Helper()
+
+	webhookID := uuid.New().String()
+	webhook := createTestWebhook(webhookID, "Receiver", webhookURL, true)
+	webhook.Secret = simulateSecret
+
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetWebhook", webhookID).Return(&webhook, nil)
+
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var buf bytes.Buffer
+	handler := printer.GetResponseHandler(format, false, &buf)
+	cmd := NewTriggerCommand()
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetOut(&buf)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(append([]string{webhookID}, args...))
+
+	err := cmd.Execute()
+	return buf.String(), mockClient, err
+}
+
+func TestTriggerWithOverrides(t *testing.T) {
+	overrideFile := filepath.Join(t.TempDir(), "override.json")
+	require.NoError(t, os.WriteFile(overrideFile, []byte(`{"data": {"recipient": "ops@partner.example", "subject": "Order shipped"}}`), 0600))
+
+	t.Run("json includes the final payload", func(t *testing.T) {
+		receiver, server := newSimulateReceiver(t, http.StatusOK)
+		out, mockClient, err := executeTriggerCommand(t, server.URL, "json",
+			"--events", "message.bounced", "--payload-override", overrideFile, "--set", "data.subject=2024")
+		require.NoError(t, err)
+		mockClient.AssertNotCalled(t, "TriggerWebhook", mock.Anything, mock.Anything)
+
+		var output struct {
+			Events []struct {
+				Type      string                 `json:"type"`
+				Status    string                 `json:"status"`
+				Payload   map[string]interface{} `json:"payload"`
+				Overrides []struct {
+					Path  string      `json:"path"`
+					After interface{} `json:"after"`
+				} `json:"overrides"`
+			} `json:"events"`
+			Failed int `json:"failed"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(out), &output))
+		require.Len(t, output.Events, 1)
+		event := output.Events[0]
+		assert.Equal(t, "message.bounced", event.Type)
+		assert.Equal(t, printer.SimulationStatusDelivered, event.Status)
+		data := event.Payload["data"].(map[string]interface{})
+		assert.Equal(t, "ops@partner.example", data["recipient"])
+		assert.Equal(t, "2024", data["subject"], "--set applies after the merge and keeps strings")
+		require.Len(t, event.Overrides, 2)
+		assert.Equal(t, "data.recipient", event.Overrides[0].Path)
+
+		// The receiver got the overridden payload, validly signed
+		require.Len(t, receiver.events, 1)
+		bounced, ok := receiver.events[0].(*sdkwebhooks.MessageBouncedEvent)
+		require.True(t, ok)
+		assert.Equal(t, "ops@partner.example", bounced.Data.Recipient)
+	})
+
+	t.Run("table summarizes the overrides", func(t *testing.T) {
+		_, server := newSimulateReceiver(t, http.StatusOK)
+		out, _, err := executeTriggerCommand(t, server.URL, "table",
+			"--events", "message.delivered,message.opened", "--set", "data.recipient=ops@partner.example")
+		require.NoError(t, err)
+		assert.Contains(t, out, "Overrides for message.delivered:\n  data.recipient: ")
+		assert.Contains(t, out, `→ "ops@partner.example"`)
+		assert.Contains(t, out, "Delivered 2 of 2 events with overrides to "+server.URL)
+	})
+
+	t.Run("typos fail before anything is sent", func(t *testing.T) {
+		receiver, server := newSimulateReceiver(t, http.StatusOK)
+		_, _, err := executeTriggerCommand(t, server.URL, "table",
+			"--events", "message.delivered", "--set", "data.recipeint=ops@partner.example")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown field data.recipeint")
+
+		_, _, err = executeTriggerCommand(t, server.URL, "table",
+			"--events", "message.delivered", "--set", "data.subject.text=x")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "data.subject is a string in the document")
+		assert.Empty(t, receiver.events)
+	})
+
+	t.Run("override file must be an object", func(t *testing.T) {
+		bad := filepath.Join(t.TempDir(), "bad.json")
+		require.NoError(t, os.WriteFile(bad, []byte(`["data"]`), 0600))
+		_, mockClient, err := executeTriggerCommand(t, "http://127.0.0.1:1", "table", "--events", "message.delivered", "--payload-override", bad)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must be a JSON object")
+		mockClient.AssertNotCalled(t, "GetWebhook", mock.Anything)
+	})
+}
@@ -5,8 +5,10 @@ import (
 
 	"github.com/AhaSend/ahasend-cli/internal/auth"
 	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
 	"github.com/AhaSend/ahasend-cli/internal/logger"
 	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/webhooks"
 	"github.com/AhaSend/ahasend-go"
 	"github.com/AhaSend/ahasend-go/models/requests"
 	"github.com/AhaSend/ahasend-go/models/responses"
@@ -25,7 +27,16 @@ You can update any combination of webhook properties by providing the
 corresponding flags. Only the specified properties will be updated;
 others will remain unchanged.
 
-The webhook ID can be found using the 'ahasend webhooks list' command.`,
+The webhook ID can be found using the 'ahasend webhooks list' command.
+
+Editing:
+  --edit opens the webhook's name, URL, enabled state, events, scope and
+  domains in $EDITOR (vi when unset) as YAML, or JSON with --edit-format
+  json. When the editor closes, only the settings you changed are updated.
+  A file that cannot be read, or names an unknown event, is reopened with
+  the error at the top so your edits are kept; saving it unchanged gives
+  up, and emptying the file cancels. With --dry-run the changes are shown
+  instead of applied.`,
 		Example: `  # Update webhook name and URL
   ahasend webhooks update abcd1234-5678-90ef-abcd-1234567890ab \
     --name "Updated Webhook" \
@@ -52,7 +63,13 @@ The webhook ID can be found using the 'ahasend webhooks list' command.`,
   # Update scope and domains
   ahasend webhooks update abcd1234-5678-90ef-abcd-1234567890ab \
     --scope "domain" \
-    --domains "example.com,test.com"`,
+    --domains "example.com,test.com"
+
+  # Edit the webhook in $EDITOR
+  ahasend webhooks update abcd1234-5678-90ef-abcd-1234567890ab --edit
+
+  # Preview the changes made in the editor without applying them
+  ahasend webhooks update abcd1234-5678-90ef-abcd-1234567890ab --edit --dry-run`,
 		Args:         cobra.ExactArgs(1),
 		RunE:         runWebhooksUpdate,
 		SilenceUsage: true,
@@ -76,10 +93,24 @@ The webhook ID can be found using the 'ahasend webhooks list' command.`,
 	cmd.Flags().StringSlice("domains", []string{}, "Set domain restrictions (replaces existing)")
 	cmd.Flags().Bool("clear-domains", false, "Clear all domain restrictions")
 
+	// Interactive editing
+	cmd.Flags().Bool("edit", false, "Edit the webhook's settings in $EDITOR")
+	cmd.Flags().String("edit-format", "yaml", "With --edit, the file format to edit: yaml or json")
+	cmd.Flags().Bool("dry-run", false, "With --edit, show the changes instead of applying them")
+
 	return cmd
 }
 
 func runWebhooksUpdate(cmd *cobra.Command, args []string) error {
+	if edit, _ := cmd.Flags().GetBool("edit"); edit {
+		return runWebhooksEdit(cmd, args[0])
+	}
+	for _, flag := range []string{"edit-format", "dry-run"} {
+		if cmd.Flags().Changed(flag) {
+			return errors.NewValidationError(fmt.Sprintf("--%s requires --edit", flag), nil)
+		}
+	}
+
 	// Get response handler instance
 	handler := printer.GetResponseHandlerFromCommand(cmd)
 
@@ -169,12 +200,12 @@ func runWebhooksUpdate(cmd *cobra.Command, args []string) error {
 
 	// Set event types
 	if allEvents {
-		setAllEventTypesUpdate(req)
+		webhooks.SetUpdateEvents(req, webhooks.EventKeys(), true)
 	} else if noEvents {
-		clearAllEventTypes(req)
+		webhooks.SetUpdateEvents(req, webhooks.EventKeys(), false)
 	} else if len(events) > 0 {
-		clearAllEventTypes(req) // Clear existing first
-		setEventTypesUpdate(req, events)
+		webhooks.SetUpdateEvents(req, webhooks.EventKeys(), false) // Clear existing first
+		webhooks.SetUpdateEvents(req, events, true)
 	}
 
 	// Set scope
@@ -226,56 +257,3 @@ func updateWebhook(client client.AhaSendClient, webhookID string, req requests.U
 	}
 	return webhook, nil
 }
-
-func setAllEventTypesUpdate(req *requests.UpdateWebhookRequest) {
-	req.OnReception = ahasend.Bool(true)
-	req.OnDelivered = ahasend.Bool(true)
-	req.OnTransientError = ahasend.Bool(true)
-	req.OnFailed = ahasend.Bool(true)
-	req.OnBounced = ahasend.Bool(true)
-	req.OnSuppressed = ahasend.Bool(true)
-	req.OnOpened = ahasend.Bool(true)
-	req.OnClicked = ahasend.Bool(true)
-	req.OnSuppressionCreated = ahasend.Bool(true)
-	req.OnDnsError = ahasend.Bool(true)
-}
-
-func clearAllEventTypes(req *requests.UpdateWebhookRequest) {
-	req.OnReception = ahasend.Bool(false)
-	req.OnDelivered = ahasend.Bool(false)
-	req.OnTransientError = ahasend.Bool(false)
-	req.OnFailed = ahasend.Bool(false)
-	req.OnBounced = ahasend.Bool(false)
-	req.OnSuppressed = ahasend.Bool(false)
-	req.OnOpened = ahasend.Bool(false)
-	req.OnClicked = ahasend.Bool(false)
-	req.OnSuppressionCreated = ahasend.Bool(false)
-	req.OnDnsError = ahasend.Bool(false)
-}
-
-func setEventTypesUpdate(req *requests.UpdateWebhookRequest, events []string) {
-	for _, event := range events {
-		switch event {
-		case "reception":
-			req.OnReception = ahasend.Bool(true)
-		case "delivered":
-			req.OnDelivered = ahasend.Bool(true)
-		case "transient_error":
-			req.OnTransientError = ahasend.Bool(true)
-		case "failed":
-			req.OnFailed = ahasend.Bool(true)
-		case "bounced":
-			req.OnBounced = ahasend.Bool(true)
-		case "suppressed":
-			req.OnSuppressed = ahasend.Bool(true)
-		case "opened":
-			req.OnOpened = ahasend.Bool(true)
-		case "clicked":
-			req.OnClicked = ahasend.Bool(true)
-		case "suppression_created":
-			req.OnSuppressionCreated = ahasend.Bool(true)
-		case "dns_error":
-			req.OnDnsError = ahasend.Bool(true)
-		}
-	}
-}
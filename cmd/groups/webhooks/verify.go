@@ -0,0 +1,234 @@
+package webhooks
+
+import (
+	"bytes"
+	stderrors "errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/clockskew"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/webhooks"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// NewVerifyCommand creates the verify command
+func NewVerifyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify the signature of a webhook delivery",
+		Long: `Verify a captured webhook delivery the way a receiver does, to tell a
+wrong secret or a modified payload apart from a clock problem.
+
+Pass the webhook-id, webhook-timestamp and webhook-signature headers of the
+delivery with --msg-id, --timestamp and --signature, and the raw request body
+with --payload-file (--id and --payload are accepted as short forms). The
+signing secret is given with --secret, or looked up from the webhook with
+--webhook-id.
+
+The signature is recomputed exactly as AhaSend and 'routes listen' sign
+deliveries, and the result states whether it matches and lists the likely
+causes when it does not:
+  - the timestamp is not within --tolerance of the local clock, either way
+    (default: 5m, the window of the standard-webhooks libraries)
+  - the payload only matches with its trailing newline stripped, as happens
+    when a captured body is saved by an editor or with echo
+  - a signature is not in the v1,<base64> format
+
+The command exits with 0 when the delivery checks out and 1 when it does
+not. With --output json the computed and provided signatures are part of the
+output, so they can be diffed.
+
+A warning is printed when the local clock itself was more than a minute off
+from the AhaSend API server's the last time the CLI talked to it, since the
+timestamp check is only as good as the local clock.`,
+		Example: `  # Verify a captured delivery with the webhook's secret
+  ahasend webhooks verify --secret aha-whsec-... --payload-file body.json \
+    --msg-id 0191e0c2-7a2b-7f3e-9c1d-2b5b1f6f7d10 --timestamp 1772446502 \
+    --signature "v1,K5oZfzN95Z9UVu1EsfQmfVNQhnkZ2pj9o9NDN/H/pI4="
+
+  # Look up the secret and allow more clock skew
+  ahasend webhooks verify --webhook-id abcd1234-5678-90ef-abcd-1234567890ab \
+    --payload-file body.json --msg-id 0191e0c2-7a2b-7f3e-9c1d-2b5b1f6f7d10 \
+    --timestamp 1772446502 --signature "v1,K5oZ..." --tolerance 10m
+
+  # Compare the computed signature with the provided one
+  ahasend webhooks verify --secret aha-whsec-... --payload body.json \
+    --id 0191e0c2-7a2b-7f3e-9c1d-2b5b1f6f7d10 --timestamp 1772446502 \
+    --signature "v1,K5oZ..." --output json`,
+		Args:         cobra.NoArgs,
+		RunE:         runWebhooksVerify,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().String("secret", "", "Webhook signing secret")
+	cmd.Flags().String("webhook-id", "", "Look up the signing secret of this webhook instead of passing --secret")
+	cmd.Flags().String("payload-file", "", "File with the raw request body (required)")
+	cmd.Flags().String("msg-id", "", "Value of the webhook-id header (required)")
+	cmd.Flags().String("timestamp", "", "Value of the webhook-timestamp header, in Unix seconds (required)")
+	cmd.Flags().String("signature", "", "Value of the webhook-signature header (required)")
+	cmd.Flags().Duration("tolerance", webhooks.DefaultTolerance, "How far the timestamp may be from the local clock, either way")
+	cmd.Flags().SetNormalizeFunc(verifyFlagAliases)
+	cmd.MarkFlagRequired("payload-file")
+	cmd.MarkFlagRequired("msg-id")
+	cmd.MarkFlagRequired("timestamp")
+	cmd.MarkFlagRequired("signature")
+
+	return cmd
+}
+
+// verifyFlagAliases accepts --payload and --id for --payload-file and
+// --msg-id
+func verifyFlagAliases(f *pflag.FlagSet, name string) pflag.NormalizedName {
+	switch name {
+	case "payload":
+		name = "payload-file"
+	case "id":
+		name = "msg-id"
+	}
+	return pflag.NormalizedName(name)
+}
+
+func runWebhooksVerify(cmd *cobra.Command, args []string) error {
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+
+	secret, _ := cmd.Flags().GetString("secret")
+	webhookID, _ := cmd.Flags().GetString("webhook-id")
+	payloadFile, _ := cmd.Flags().GetString("payload-file")
+	msgID, _ := cmd.Flags().GetString("msg-id")
+	timestamp, _ := cmd.Flags().GetString("timestamp")
+	signature, _ := cmd.Flags().GetString("signature")
+	tolerance, _ := cmd.Flags().GetDuration("tolerance")
+
+	if (secret == "") == (webhookID == "") {
+		return errors.NewValidationError("give either --secret or --webhook-id", nil)
+	}
+	if tolerance < 0 {
+		return errors.NewValidationError("--tolerance cannot be negative", nil)
+	}
+
+	signedAt, err := webhooks.ParseTimestamp(timestamp)
+	if err != nil {
+		return errors.NewValidationError(err.Error(), nil)
+	}
+
+	payload, err := os.ReadFile(payloadFile)
+	if err != nil {
+		return errors.NewFileError(fmt.Sprintf("failed to read payload file %s", payloadFile), err)
+	}
+
+	logger.Get().WithFields(map[string]interface{}{
+		"webhook_id":   webhookID,
+		"payload_file": payloadFile,
+		"msg_id":       msgID,
+		"timestamp":    timestamp,
+		"tolerance":    tolerance.String(),
+	}).Debug("Executing webhooks verify command")
+
+	if webhookID != "" {
+		apiClient, err := auth.GetAuthenticatedClient(cmd)
+		if err != nil {
+			return err
+		}
+		webhook, err := apiClient.GetWebhook(webhookID)
+		if err != nil {
+			return err
+		}
+		if webhook == nil {
+			return errors.NewNotFoundError(fmt.Sprintf("webhook %s not found", webhookID), nil)
+		}
+		if webhook.Secret == "" {
+			return errors.NewAPIError(fmt.Sprintf("webhook %s has no signing secret to verify with", webhookID), nil)
+		}
+		secret = webhook.Secret
+	}
+	clockskew.Warn(cmd)
+
+	now := time.Now()
+	verification, err := verifyDelivery(webhooks.NewSigner(secret), msgID, signedAt, signature, payload, now, tolerance)
+	if err != nil {
+		return err
+	}
+	if err := handler.HandleWebhookVerification(verification, printer.SingleConfig{}); err != nil {
+		return err
+	}
+	if !verification.SignatureMatch {
+		if verification.MatchesWithoutTrailingNewline {
+			return errors.NewSignatureMismatchError("invalid signature", errTrailingNewline)
+		}
+		return errors.NewSignatureMismatchError("invalid signature", webhooks.ErrSignatureMismatch)
+	}
+	if !verification.TimestampValid {
+		return errors.NewSignatureMismatchError("invalid signature timestamp", webhooks.CheckTimestamp(signedAt, now.Truncate(time.Second), tolerance))
+	}
+	return nil
+}
+
+// errTrailingNewline explains a signature that matches the payload only
+// without its trailing newline
+var errTrailingNewline = stderrors.New("the signature matches the payload with its trailing newline stripped; " +
+	"the payload file has a newline the signed body did not, often added by an editor or by echo")
+
+// verifyDelivery checks a delivery the way webhooks.Signer.Verify does,
+// but goes on after the first failure to find the likely causes. The
+// header has second precision, so now is compared at that precision too.
+func verifyDelivery(signer *webhooks.Signer, msgID string, signedAt time.Time, signatures string, payload []byte, now time.Time, tolerance time.Duration) (*printer.WebhookVerification, error) {
+	now = now.Truncate(time.Second)
+	computed, matched, err := signer.Match(msgID, signedAt, signatures, payload)
+	if err != nil {
+		return nil, err
+	}
+	verification := &printer.WebhookVerification{
+		SignatureMatch:     matched,
+		ComputedSignature:  computed,
+		ProvidedSignatures: strings.Fields(signatures),
+		MsgID:              msgID,
+		Timestamp:          signedAt.Unix(),
+		TimestampValid:     true,
+		SkewSeconds:        int64(signedAt.Sub(now) / time.Second),
+		ToleranceSeconds:   int64(tolerance / time.Second),
+	}
+
+	if err := webhooks.CheckTimestamp(signedAt, now, tolerance); err != nil {
+		verification.TimestampValid = false
+		verification.Problems = append(verification.Problems, err.Error())
+	}
+	if !matched {
+		verification.Problems = append(verification.Problems, signatureProblems(signer, msgID, signedAt, verification, payload)...)
+	}
+	verification.Valid = verification.SignatureMatch && verification.TimestampValid
+	return verification, nil
+}
+
+// signatureProblems names what can be detected about a signature that does
+// not match: a payload that gained a trailing newline, or signatures that
+// are not in the v1,<base64> format. When neither applies, the secret or the
+// payload itself is wrong.
+func signatureProblems(signer *webhooks.Signer, msgID string, signedAt time.Time, verification *printer.WebhookVerification, payload []byte) []string {
+	var problems []string
+	if trimmed := bytes.TrimRight(payload, "\r\n"); len(trimmed) < len(payload) {
+		_, matched, err := signer.Match(msgID, signedAt, strings.Join(verification.ProvidedSignatures, " "), trimmed)
+		if err == nil && matched {
+			verification.MatchesWithoutTrailingNewline = true
+			problems = append(problems, errTrailingNewline.Error())
+		}
+	}
+	for _, provided := range verification.ProvidedSignatures {
+		if !strings.HasPrefix(provided, "v1,") {
+			problems = append(problems, fmt.Sprintf("signature %q is not in the v1,<base64> format of the webhook-signature header", provided))
+		}
+	}
+	if len(verification.ProvidedSignatures) == 0 {
+		problems = append(problems, "--signature lists no signatures")
+	}
+	if len(problems) == 0 {
+		problems = append(problems, webhooks.ErrSignatureMismatch.Error())
+	}
+	return problems
+}
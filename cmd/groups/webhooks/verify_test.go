@@ -0,0 +1,174 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/webhooks"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signedDelivery writes a payload signed at signedAt and returns the flags
+// describing the delivery
+func signedDelivery(t *testing.T, signedAt time.Time) []string {
+	t.Helper()
+	payload := []byte(`{"type":"message.delivered","data":{}}`)
+	file := filepath.Join(t.TempDir(), "body.json")
+	require.NoError(t, os.WriteFile(file, payload, 0600))
+
+	signature, err := webhooks.NewSigner(simulateSecret).Sign("msg-1", signedAt, payload)
+	require.NoError(t, err)
+	return []string{
+		"--payload-file", file,
+		"--msg-id", "msg-1",
+		"--timestamp", fmt.Sprintf("%d", signedAt.Unix()),
+		"--signature", signature,
+	}
+}
+
+func executeVerifyCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	return executeVerifyCommandWithFormat(t, "plain", args...)
+}
+
+func executeVerifyCommandWithFormat(t *testing.T, format string, args ...string) (string, error) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	var buf bytes.Buffer
+	cmd := NewVerifyCommand()
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, printer.GetResponseHandler(format, false, &buf)))
+	cmd.SetOut(&buf)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+func TestVerifyCommand_ValidSignature(t *testing.T) {
+	out, err := executeVerifyCommand(t, append(signedDelivery(t, time.Now().Add(-90*time.Second)), "--secret", simulateSecret)...)
+	require.NoError(t, err)
+	assert.Contains(t, out, "Signature is valid; the timestamp is 1m30s behind the local clock, within ±5m0s")
+}
+
+func TestVerifyCommand_Tolerance(t *testing.T) {
+	delivery := signedDelivery(t, time.Now().Add(-7*time.Minute))
+
+	out, err := executeVerifyCommand(t, append(delivery, "--secret", simulateSecret)...)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid signature timestamp: webhook timestamp is 7m0s behind the local clock, outside the allowed window of ±5m0s")
+	assert.Equal(t, 1, errors.GetExitCode(err))
+	assert.Contains(t, out, "Signature matches, but the timestamp is 7m0s behind the local clock, outside ±5m0s")
+
+	_, err = executeVerifyCommand(t, append(delivery, "--secret", simulateSecret, "--tolerance", "10m")...)
+	require.NoError(t, err)
+}
+
+func TestVerifyCommand_WrongSecret(t *testing.T) {
+	out, err := executeVerifyCommand(t, append(signedDelivery(t, time.Now()), "--secret", "aha-whsec-other")...)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid signature: no signature matches the payload")
+	assert.Equal(t, 1, errors.GetExitCode(err))
+	assert.Contains(t, out, "Signature does not match")
+	assert.Contains(t, out, "Likely causes:\n  - no signature matches the payload")
+}
+
+func TestVerifyCommand_TrailingNewline(t *testing.T) {
+	payload := []byte(`{"type":"message.delivered","data":{}}`)
+	signedAt := time.Now()
+	signature, err := webhooks.NewSigner(simulateSecret).Sign("msg-1", signedAt, payload)
+	require.NoError(t, err)
+	file := filepath.Join(t.TempDir(), "body.json")
+	require.NoError(t, os.WriteFile(file, append(payload, '\n'), 0600))
+
+	out, err := executeVerifyCommandWithFormat(t, "json", "--secret", simulateSecret, "--payload", file, "--id", "msg-1",
+		"--timestamp", fmt.Sprintf("%d", signedAt.Unix()), "--signature", signature)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "matches the payload with its trailing newline stripped")
+	assert.Equal(t, 1, errors.GetExitCode(err))
+
+	var verification printer.WebhookVerification
+	require.NoError(t, json.Unmarshal([]byte(out), &verification))
+	assert.False(t, verification.Valid)
+	assert.False(t, verification.SignatureMatch)
+	assert.True(t, verification.MatchesWithoutTrailingNewline)
+	assert.True(t, verification.TimestampValid)
+	assert.Equal(t, []string{signature}, verification.ProvidedSignatures)
+	assert.NotEqual(t, signature, verification.ComputedSignature)
+	require.Len(t, verification.Problems, 1)
+}
+
+func TestVerifyCommand_JSON(t *testing.T) {
+	delivery := signedDelivery(t, time.Now().Add(-90*time.Second))
+
+	out, err := executeVerifyCommandWithFormat(t, "json", append(delivery, "--secret", simulateSecret)...)
+	require.NoError(t, err)
+
+	var verification printer.WebhookVerification
+	require.NoError(t, json.Unmarshal([]byte(out), &verification))
+	assert.True(t, verification.Valid)
+	assert.Equal(t, delivery[len(delivery)-1], verification.ComputedSignature)
+	assert.Equal(t, []string{verification.ComputedSignature}, verification.ProvidedSignatures)
+	assert.Equal(t, "msg-1", verification.MsgID)
+	assert.EqualValues(t, -90, verification.SkewSeconds)
+	assert.EqualValues(t, 300, verification.ToleranceSeconds)
+	assert.Empty(t, verification.Problems)
+}
+
+func TestVerifyCommand_MalformedSignature(t *testing.T) {
+	delivery := signedDelivery(t, time.Now())
+	delivery[len(delivery)-1] = "K5oZfzN95Z9UVu1EsfQmfVNQhnkZ2pj9o9NDN/H/pI4="
+
+	out, err := executeVerifyCommand(t, append(delivery, "--secret", simulateSecret)...)
+	require.Error(t, err)
+	assert.Contains(t, out, `signature "K5oZfzN95Z9UVu1EsfQmfVNQhnkZ2pj9o9NDN/H/pI4=" is not in the v1,<base64> format`)
+}
+
+func TestVerifyCommand_WebhookSecret(t *testing.T) {
+	webhookID := uuid.New().String()
+	webhook := createTestWebhook(webhookID, "Receiver", "https://example.com/hook", true)
+	webhook.Secret = simulateSecret
+
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetWebhook", webhookID).Return(&webhook, nil)
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	_, err := executeVerifyCommand(t, append(signedDelivery(t, time.Now()), "--webhook-id", webhookID)...)
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestVerifyCommand_Validation(t *testing.T) {
+	delivery := signedDelivery(t, time.Now())
+
+	_, err := executeVerifyCommand(t, delivery...)
+	assert.ErrorContains(t, err, "either --secret or --webhook-id")
+
+	_, err = executeVerifyCommand(t, append(delivery, "--secret", "s", "--webhook-id", "w")...)
+	assert.ErrorContains(t, err, "either --secret or --webhook-id")
+
+	_, err = executeVerifyCommand(t, append(delivery, "--secret", "s", "--tolerance", "-1m")...)
+	assert.ErrorContains(t, err, "--tolerance cannot be negative")
+
+	_, err = executeVerifyCommand(t, append(delivery, "--secret", "s", "--timestamp", "2026-03-02")...)
+	assert.ErrorContains(t, err, "expected Unix seconds")
+	assert.Equal(t, 4, errors.GetExitCode(err))
+}
@@ -29,8 +29,14 @@ Common workflow:
 	cmd.AddCommand(NewCreateCommand())
 	cmd.AddCommand(NewUpdateCommand())
 	cmd.AddCommand(NewDeleteCommand())
+	cmd.AddCommand(NewRotateSecretCommand())
 	cmd.AddCommand(NewListenCommand())
 	cmd.AddCommand(NewTriggerCommand())
+	cmd.AddCommand(NewSimulateCommand())
+	cmd.AddCommand(NewCoverageCommand())
+	cmd.AddCommand(NewExportCommand())
+	cmd.AddCommand(NewImportCommand())
+	cmd.AddCommand(NewVerifyCommand())
 
 	return cmd
 }
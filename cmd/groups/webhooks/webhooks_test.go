@@ -2,16 +2,22 @@ package webhooks
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
 	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
 	"github.com/AhaSend/ahasend-go/models/common"
 	"github.com/AhaSend/ahasend-go/models/responses"
 	"github.com/google/uuid"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -75,8 +81,8 @@ func TestWebhooksCommand_SubcommandCount(t *testing.T) {
 	cmd := NewCommand()
 	subcommands := cmd.Commands()
 
-	// Should have exactly 7 subcommands (list, get, create, update, delete, listen, trigger)
-	assert.Equal(t, 7, len(subcommands), "webhooks command should have exactly 7 subcommands")
+	// Should have exactly 13 subcommands (list, get, create, update, delete, rotate-secret, listen, trigger, simulate, coverage, export, import, verify)
+	assert.Equal(t, 13, len(subcommands), "webhooks command should have exactly 13 subcommands")
 }
 
 // Test list command structure and flags
@@ -278,6 +284,31 @@ func TestWebhooksList_FilterLogic(t *testing.T) {
 	assert.True(t, filteredWebhooks[0].Enabled)
 }
 
+func TestWebhooksList_IDsOnly(t *testing.T) {
+	enabled := createTestWebhook(uuid.New().String(), "Enabled", "https://example.com/a", true)
+	disabled := createTestWebhook(uuid.New().String(), "Disabled", "https://example.com/b", false)
+
+	mockClient := &mocks.MockClient{}
+	mockClient.On("ListWebhooksWithParams", mock.Anything).Return(&responses.PaginatedWebhooksResponse{
+		Object: "list",
+		Data:   []responses.Webhook{enabled, disabled},
+	}, nil)
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	var buf bytes.Buffer
+	cmd := NewListCommand()
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, printer.GetResponseHandler("json", false, &buf)))
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs([]string{"--enabled", "--ids-only"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, enabled.ID.String()+"\n", buf.String())
+}
+
 func TestWebhooksList_EventTypesDetection(t *testing.T) {
 	// Test event types detection logic from list.go
 	webhook := createTestWebhookWithEvents()
@@ -953,3 +984,51 @@ func TestWebhook_DataValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestSortWebhooks(t *testing.T) {
+	older := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	newer := time.Date(2026, 6, 2, 0, 0, 0, 0, time.UTC)
+	webhooks := []responses.Webhook{
+		{Name: "quiet", ErrorCount: 1},
+		{Name: "noisy", ErrorCount: 50, LastRequestAt: &older},
+		{Name: "recent", ErrorCount: 5, LastRequestAt: &newer},
+	}
+
+	sortWebhooks(webhooks, "errors")
+	assert.Equal(t, []string{"noisy", "recent", "quiet"}, webhookNames(webhooks))
+
+	sortWebhooks(webhooks, "last_request")
+	assert.Equal(t, []string{"recent", "noisy", "quiet"}, webhookNames(webhooks))
+}
+
+func TestListCommand_SortValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "sort without stats", args: []string{"--sort", "errors"}, wantErr: "--sort requires --include-stats"},
+		{name: "unknown sort", args: []string{"--include-stats", "--sort", "name"}, wantErr: "invalid sort"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			handler := printer.GetResponseHandler("plain", false, &buf)
+			cmd := NewListCommand()
+			cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+			require.NoError(t, cmd.ParseFlags(tt.args))
+			err := runWebhooksList(cmd, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func webhookNames(webhooks []responses.Webhook) []string {
+	names := make([]string, len(webhooks))
+	for i, webhook := range webhooks {
+		names[i] = webhook.Name
+	}
+	return names
+}
@@ -8,6 +8,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/printer"
 )
 
 // TestJSONOutputValidation tests that all commands properly support --output json flag
@@ -205,6 +207,11 @@ func TestJSONOutputFormat(t *testing.T) {
 				// Check for expected keys if JSON is valid
 				if err == nil {
 					if jsonMap, ok := jsonData.(map[string]interface{}); ok {
+						// Every document is versioned; the checks below
+						// concern the other keys
+						assert.Equal(t, float64(printer.SchemaVersion), jsonMap["schema_version"])
+						delete(jsonMap, "schema_version")
+
 						// Skip field checking if we got an error response or empty response
 						// Examples:
 						// - {"message": "Domain not found"}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+
+	"github.com/AhaSend/ahasend-cli/internal/accountstatus"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/state"
+)
+
+// TestMain keeps the tests off the real ~/.ahasend and the account status
+// API, which the default client resolver would otherwise consult
+func TestMain(m *testing.M) {
+	home, err := os.MkdirTemp("", "ahasend-test-home")
+	if err != nil {
+		panic(err)
+	}
+	os.Setenv("HOME", home)
+	restore := accountstatus.SetLookupForTesting(func(client.AhaSendClient) state.AccountStatus {
+		return state.AccountStatus{}
+	})
+
+	code := m.Run()
+
+	restore()
+	os.RemoveAll(home)
+	os.Exit(code)
+}
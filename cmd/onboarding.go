@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/AhaSend/ahasend-cli/cmd/groups/auth"
+	authn "github.com/AhaSend/ahasend-cli/internal/auth"
+	cliconfig "github.com/AhaSend/ahasend-cli/internal/config"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/spf13/cobra"
+)
+
+// These are replaced in tests to exercise the setup prompt
+var (
+	onboardingInteractive = func() bool {
+		return printer.IsTerminal(os.Stdin) && printer.IsTerminal(os.Stderr)
+	}
+
+	// runSetupLogin runs 'ahasend auth login' in-process for cmd
+	runSetupLogin = func(cmd *cobra.Command, profile string) error {
+		login := auth.NewLoginCommand()
+		login.SetContext(cmd.Context())
+		login.SetIn(cmd.InOrStdin())
+		login.SetOut(cmd.OutOrStdout())
+		login.SetErr(cmd.ErrOrStderr())
+		if profile != "" {
+			_ = login.Flags().Set("profile", profile)
+		}
+		return login.RunE(login, nil)
+	}
+)
+
+// ensureCredentials handles the first run in a terminal: a command that
+// needs credentials when none are configured offers to run the login flow
+// and then continues with the command. Otherwise the command runs and fails
+// with authn.NotAuthenticatedError, which names the exact login command and
+// the environment variable alternative.
+func ensureCredentials(cmd *cobra.Command) error {
+	if !authn.NeedsCredentials(cmd) || hasCredentials(cmd) || !onboardingInteractive() {
+		return nil
+	}
+
+	out := cmd.ErrOrStderr()
+	fmt.Fprint(out, "No credentials found. Run setup now? [Y/n]: ")
+	if !readDefaultYes(cmd.InOrStdin()) {
+		fmt.Fprintln(out)
+		return nil
+	}
+
+	profile, _ := cmd.Flags().GetString("profile")
+	logger.Get().WithField("command", cmd.CommandPath()).Debug("Starting first-run login")
+	if err := runSetupLogin(cmd, profile); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "\nContinuing with '%s'...\n\n", cmd.CommandPath())
+	return nil
+}
+
+// hasCredentials reports whether a client is provided already or the
+// flags, the environment or the configuration file provide credentials. An
+// unreadable configuration file counts as configured, so its own error is
+// reported instead.
+func hasCredentials(cmd *cobra.Command) bool {
+	if authn.HasProvidedClient(cmd) {
+		return true
+	}
+	if apiKey, _ := cmd.Flags().GetString("api-key"); apiKey != "" {
+		return true
+	}
+	if envKey, _ := authn.EnvCredentials(); envKey != "" {
+		return true
+	}
+
+	configMgr, err := cliconfig.NewManager()
+	if err != nil {
+		return true
+	}
+	if err := configMgr.Load(); err != nil {
+		return true
+	}
+	return len(configMgr.GetConfig().Profiles) > 0
+}
+
+// readDefaultYes reads a Y/n answer, where an empty answer means yes
+func readDefaultYes(in io.Reader) bool {
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && answer == "" {
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "" || answer == "y" || answer == "yes"
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	authn "github.com/AhaSend/ahasend-cli/internal/auth"
+	cliconfig "github.com/AhaSend/ahasend-cli/internal/config"
+)
+
+// executeFirstRun runs a probe command that needs credentials in a fresh
+// home directory and reports whether it got a client. setup stands in for
+// the login flow; nil means the flow must not be started.
+func executeFirstRun(t *testing.T, interactive bool, stdin string, setup func() error, args ...string) (bool, string, error) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(authn.APIKeyEnvVar, "")
+	t.Setenv(authn.AccountIDEnvVar, "")
+
+	prevInteractive, prevSetup := onboardingInteractive, runSetupLogin
+	onboardingInteractive = func() bool { return interactive }
+	runSetupLogin = func(*cobra.Command, string) error {
+		if setup == nil {
+			t.Fatal("the login flow was started")
+		}
+		return setup()
+	}
+	t.Cleanup(func() { onboardingInteractive, runSetupLogin = prevInteractive, prevSetup })
+
+	ran := false
+	root := NewRootCmdForTesting()
+	root.AddCommand(&cobra.Command{
+		Use: "probe",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if _, err := authn.GetAuthenticatedClient(cmd); err != nil {
+				return err
+			}
+			ran = true
+			return nil
+		},
+	})
+
+	var stderr bytes.Buffer
+	root.SetIn(strings.NewReader(stdin))
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&stderr)
+	root.SetArgs(append([]string{"probe"}, args...))
+	err := root.Execute()
+	return ran, stderr.String(), err
+}
+
+// saveProfile stands in for a successful login
+func saveProfile() error {
+	mgr, err := cliconfig.NewManager()
+	if err != nil {
+		return err
+	}
+	if err := mgr.Load(); err != nil {
+		return err
+	}
+	return mgr.SetProfile("default", cliconfig.Profile{APIKey: "test-key", AccountID: "11111111-1111-1111-1111-111111111111"})
+}
+
+func TestFirstRun_NonInteractiveError(t *testing.T) {
+	ran, stderr, err := executeFirstRun(t, false, "", nil)
+	require.Error(t, err)
+	assert.False(t, ran)
+	assert.NotContains(t, stderr, "Run setup now?")
+	assert.Contains(t, err.Error(), "Run 'ahasend auth login' to set up a profile")
+	assert.Contains(t, err.Error(), "set AHASEND_API_KEY and AHASEND_ACCOUNT_ID")
+
+	_, _, err = executeFirstRun(t, false, "", nil, "--profile", "staging")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "'ahasend auth login --profile staging'")
+}
+
+func TestFirstRun_SetupThenContinue(t *testing.T) {
+	ran, stderr, err := executeFirstRun(t, true, "\n", saveProfile)
+	require.NoError(t, err)
+	assert.True(t, ran)
+	assert.Contains(t, stderr, "No credentials found. Run setup now? [Y/n]")
+	assert.Contains(t, stderr, "Continuing with 'ahasend probe'")
+}
+
+func TestFirstRun_SetupDeclined(t *testing.T) {
+	ran, stderr, err := executeFirstRun(t, true, "n\n", nil)
+	require.Error(t, err)
+	assert.False(t, ran)
+	assert.Contains(t, stderr, "Run setup now?")
+	assert.Contains(t, err.Error(), "ahasend auth login")
+}
+
+func TestFirstRun_CredentialsPresent(t *testing.T) {
+	// Environment variables
+	t.Run("environment", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+		root := NewRootCmdForTesting()
+		probe := &cobra.Command{Use: "probe", RunE: func(*cobra.Command, []string) error { return nil }}
+		root.AddCommand(probe)
+		t.Setenv(authn.APIKeyEnvVar, "env-key")
+		assert.True(t, hasCredentials(probe))
+	})
+
+	// The --api-key flag
+	ran, _, err := executeFirstRun(t, true, "", nil, "--api-key", "key", "--account-id", "11111111-1111-1111-1111-111111111111")
+	require.NoError(t, err)
+	assert.True(t, ran)
+}
+
+func TestFirstRun_CommandsWithoutCredentials(t *testing.T) {
+	root := NewRootCmdForTesting()
+	for _, path := range [][]string{
+		{"help"},
+		{"completion", "bash"},
+		{"auth", "login"},
+		{"auth", "status"},
+		{"config", "get"},
+		{"reminders", "list"},
+		{"bounces", "explain"},
+		{"docs", "generate"},
+		{"verify-export"},
+	} {
+		cmd, _, err := root.Find(path)
+		require.NoError(t, err, path)
+		assert.False(t, authn.NeedsCredentials(cmd), "%v should run without credentials", path)
+	}
+
+	for _, path := range [][]string{{"messages", "send"}, {"domains", "list"}, {"ping"}} {
+		cmd, _, err := root.Find(path)
+		require.NoError(t, err, path)
+		assert.True(t, authn.NeedsCredentials(cmd), "%v should need credentials", path)
+	}
+}
+
+func TestReadDefaultYes(t *testing.T) {
+	for input, want := range map[string]bool{"\n": true, "y\n": true, "YES\n": true, "n\n": false, "no\n": false, "": false} {
+		assert.Equal(t, want, readDefaultYes(strings.NewReader(input)), "%q", input)
+	}
+}
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+)
+
+// fakeAPI answers every request with an empty list, which is enough for
+// list and get commands to render a response
+func fakeAPI(t *testing.T) string {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"object":"list","data":[],"pagination":{"has_more":false}}`))
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+// placeholderArgs fills the required <arguments> of a command's usage line
+func placeholderArgs(cmd *cobra.Command) []string {
+	var args []string
+	for _, word := range strings.Fields(cmd.Use)[1:] {
+		switch {
+		case word == "<domain>":
+			args = append(args, "example.com")
+		case word == "<key>":
+			args = append(args, "output-format")
+		case strings.HasPrefix(word, "<"):
+			args = append(args, "4f9c2b1e-8d3a-4c55-9e0f-1a2b3c4d5e6f")
+		}
+	}
+	return args
+}
+
+func listAndGetCommands(cmd *cobra.Command) []*cobra.Command {
+	var found []*cobra.Command
+	if cmd.Runnable() && (cmd.Name() == "list" || cmd.Name() == "get") {
+		found = append(found, cmd)
+	}
+	for _, sub := range cmd.Commands() {
+		found = append(found, listAndGetCommands(sub)...)
+	}
+	return found
+}
+
+func TestOutputJSON_EveryListAndGetCommand(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	apiURL := fakeAPI(t)
+
+	commands := listAndGetCommands(NewRootCmdForTesting())
+	require.NotEmpty(t, commands)
+
+	for _, command := range commands {
+		path := strings.Fields(command.CommandPath())[1:]
+		t.Run(strings.Join(path, "_"), func(t *testing.T) {
+			args := append(path, placeholderArgs(command)...)
+			args = append(args, "--output", "json",
+				"--api-key", "aha-sk-test", "--account-id", "4f9c2b1e-8d3a-4c55-9e0f-1a2b3c4d5e6f", "--api-url", apiURL)
+
+			root := NewRootCmdForTesting()
+			var out bytes.Buffer
+			root.SetOut(&out)
+			root.SetErr(&bytes.Buffer{})
+			root.SetArgs(args)
+			_ = root.Execute()
+
+			require.NotEmpty(t, out.String(), "no output")
+			assert.True(t, json.Valid(out.Bytes()), "not JSON:\n%s", out.String())
+		})
+	}
+}
+
+func runnableCommands(cmd *cobra.Command) []*cobra.Command {
+	var found []*cobra.Command
+	if cmd.Runnable() && cmd.HasParent() && cmd.Name() != "help" {
+		found = append(found, cmd)
+	}
+	for _, sub := range cmd.Commands() {
+		found = append(found, runnableCommands(sub)...)
+	}
+	return found
+}
+
+// writeConfig writes a config file with the output_format preference and an
+// output override for every command in keys
+func writeConfig(t *testing.T, preference string, overrides map[string]string) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	var config strings.Builder
+	fmt.Fprintf(&config, "preferences:\n  output_format: %s\noutput_overrides:\n", preference)
+	for key, format := range overrides {
+		fmt.Fprintf(&config, "  %s: %s\n", key, format)
+	}
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".ahasend"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(home, ".ahasend", "config.yaml"), []byte(config.String()), 0o600))
+}
+
+// resolvedFormats runs every command of a fresh tree with its RunE replaced by
+// a stub and returns the output format each command's handler was created with
+func resolvedFormats(t *testing.T, extraArgs ...string) map[string]string {
+	t.Helper()
+	formats := make(map[string]string)
+	for _, command := range runnableCommands(NewRootCmdForTesting()) {
+		key := printer.CommandKey(command)
+
+		root := NewRootCmdForTesting()
+		target, _, err := root.Find(strings.Split(key, "."))
+		require.NoError(t, err, key)
+		target.Args = cobra.ArbitraryArgs
+		target.Flags().VisitAll(func(flag *pflag.Flag) {
+			delete(flag.Annotations, cobra.BashCompOneRequiredFlag)
+		})
+		target.Run = nil
+		target.RunE = func(cmd *cobra.Command, args []string) error {
+			formats[key] = printer.GetResponseHandlerFromCommand(cmd).GetFormat()
+			return nil
+		}
+
+		root.SetOut(&bytes.Buffer{})
+		root.SetErr(&bytes.Buffer{})
+		root.SetArgs(append(strings.Split(key, "."), append([]string{
+			"--api-key", "aha-sk-test", "--account-id", "4f9c2b1e-8d3a-4c55-9e0f-1a2b3c4d5e6f"}, extraArgs...)...))
+		require.NoError(t, root.Execute(), key)
+	}
+	return formats
+}
+
+func TestOutputFormat_EveryCommandUsesResolver(t *testing.T) {
+	commands := runnableCommands(NewRootCmdForTesting())
+	overrides := make(map[string]string)
+	for _, command := range commands {
+		overrides[printer.CommandKey(command)] = "csv"
+	}
+	writeConfig(t, "plain", overrides)
+
+	t.Run("per-command override beats the preference", func(t *testing.T) {
+		formats := resolvedFormats(t)
+		require.Len(t, formats, len(commands))
+		for key, format := range formats {
+			assert.Equal(t, "csv", format, key)
+		}
+	})
+
+	t.Run("--output beats the override", func(t *testing.T) {
+		for key, format := range resolvedFormats(t, "--output", "json") {
+			assert.Equal(t, "json", format, key)
+		}
+	})
+}
+
+func TestOutputFormat_Precedence(t *testing.T) {
+	t.Run("preference applies without an override", func(t *testing.T) {
+		writeConfig(t, "plain", map[string]string{"messages.list": "csv"})
+		formats := resolvedFormats(t)
+		assert.Equal(t, "csv", formats["messages.list"])
+		assert.Equal(t, "plain", formats["messages.get"])
+	})
+
+	t.Run("table without configuration", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+		formats := resolvedFormats(t)
+		assert.Equal(t, "table", formats["domains.list"])
+	})
+}
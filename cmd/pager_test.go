@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/pager"
+)
+
+// recordingPager installs a fake $PAGER that copies its input to a record
+// file and returns the file
+func recordingPager(t *testing.T) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake pager is a shell script")
+	}
+	dir := t.TempDir()
+	record := filepath.Join(dir, "record")
+	script := filepath.Join(dir, "pager")
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\ntee '"+record+"'\n"), 0o755))
+	t.Setenv("PAGER", script)
+	return record
+}
+
+func executeWithPager(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	restore := pager.SetTerminalForTesting(24)
+	t.Cleanup(restore)
+
+	root := NewRootCmdForTesting()
+	var out bytes.Buffer
+	root.SetOut(&out)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs(append([]string{"docs", "generate", "--out", t.TempDir()}, args...))
+	err := root.Execute()
+	return out.String(), err
+}
+
+func TestPager(t *testing.T) {
+	t.Run("pages table and plain output", func(t *testing.T) {
+		for _, format := range []string{"table", "plain"} {
+			record := recordingPager(t)
+			out, err := executeWithPager(t, "--output", format, "--pager", "always")
+			require.NoError(t, err)
+
+			paged, err := os.ReadFile(record)
+			require.NoError(t, err, format)
+			assert.Contains(t, string(paged), "markdown pages to")
+			assert.Equal(t, string(paged), out, "the pager writes to stdout")
+		}
+	})
+
+	t.Run("never pages csv or json", func(t *testing.T) {
+		for _, format := range []string{"csv", "json"} {
+			record := recordingPager(t)
+			_, err := executeWithPager(t, "--output", format, "--pager", "always")
+			require.NoError(t, err)
+			assert.NoFileExists(t, record, format)
+		}
+	})
+
+	t.Run("auto leaves short output alone", func(t *testing.T) {
+		record := recordingPager(t)
+		out, err := executeWithPager(t, "--output", "plain", "--pager", "auto")
+		require.NoError(t, err)
+		assert.NoFileExists(t, record)
+		assert.Contains(t, out, "markdown pages to")
+	})
+
+	t.Run("never", func(t *testing.T) {
+		record := recordingPager(t)
+		_, err := executeWithPager(t, "--output", "plain", "--pager", "never")
+		require.NoError(t, err)
+		assert.NoFileExists(t, record)
+	})
+
+	t.Run("falls back when the pager is missing", func(t *testing.T) {
+		t.Setenv("PAGER", filepath.Join(t.TempDir(), "no-such-pager"))
+		out, err := executeWithPager(t, "--output", "plain", "--pager", "always")
+		require.NoError(t, err)
+		assert.Contains(t, out, "markdown pages to")
+	})
+
+	t.Run("rejects unknown modes", func(t *testing.T) {
+		_, err := executeWithPager(t, "--pager", "sometimes")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid pager mode: sometimes")
+	})
+}
@@ -6,10 +6,13 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var pingCmd = &cobra.Command{
-	Use:   "ping",
-	Short: "Test API connection and key validity",
-	Long: `Test the connection to AhaSend API and validate your API key.
+// newPingCommand creates the ping command. Each root command gets its own
+// instance, since a cobra command can only have one parent.
+func newPingCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "ping",
+		Short: "Test API connection and key validity",
+		Long: `Test the connection to AhaSend API and validate your API key.
 
 This command sends a ping request to the AhaSend API to verify:
 - Network connectivity to AhaSend servers
@@ -24,26 +27,33 @@ Examples:
   ahasend ping --api-key aha-sk-... --account-id <account-id>
 
   # Test with specific profile
-  ahasend ping --profile production`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		// Get client from auth helper
-		ahasendClient, err := auth.GetAuthenticatedClient(cmd)
-		if err != nil {
-			return err
-		}
-
-		// Get response handler instance
-		handler := printer.GetResponseHandlerFromCommand(cmd)
-
-		// Perform ping
-		err = ahasendClient.Ping()
-
-		if err != nil {
-			// Return the original error to let wrapper handle it
-			return err
-		}
-
-		// Success response with pong message
-		return handler.HandleSimpleSuccess("pong")
-	},
+  ahasend ping --profile production
+
+  # Test against a staging endpoint
+  ahasend ping --api-url https://staging.example.com`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Get client from auth helper
+			ahasendClient, err := auth.GetAuthenticatedClient(cmd)
+			if err != nil {
+				return err
+			}
+
+			// Get response handler instance
+			handler := printer.GetResponseHandlerFromCommand(cmd)
+
+			// Perform ping
+			err = ahasendClient.Ping()
+
+			if err != nil {
+				// Return the original error to let wrapper handle it
+				return err
+			}
+
+			// Success response with pong message and the endpoint that answered
+			return handler.HandlePing(&printer.PingResult{
+				Message: "pong",
+				APIURL:  ahasendClient.GetAPIURL(),
+			}, printer.SimpleConfig{})
+		},
+	}
 }
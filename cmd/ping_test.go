@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+)
+
+func runPing(t *testing.T, args ...string) string {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	mockClient := &mocks.MockClient{}
+	mockClient.On("Ping").Return(nil)
+	mockClient.On("GetAPIURL").Return("https://staging.example.com")
+	restore := auth.SetAuthenticatedClientResolverForTesting(func(*cobra.Command) (client.AhaSendClient, error) {
+		return mockClient, nil
+	})
+	t.Cleanup(restore)
+
+	root := NewRootCmdForTesting()
+	var out, errOut bytes.Buffer
+	root.SetOut(&out)
+	root.SetErr(&errOut)
+	root.SetArgs(append([]string{"ping"}, args...))
+	require.NoError(t, root.Execute())
+	return out.String()
+}
+
+func TestPing_JSONKeepsPongMessage(t *testing.T) {
+	var result struct {
+		Message string `json:"message"`
+		APIURL  string `json:"api_url"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(runPing(t, "--output", "json")), &result))
+	assert.Equal(t, "pong", result.Message)
+	assert.Equal(t, "https://staging.example.com", result.APIURL)
+}
+
+func TestPing_PlainNamesEndpoint(t *testing.T) {
+	assert.Contains(t, runPing(t, "--output", "plain"), "pong from https://staging.example.com")
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func executeConfigSet(t *testing.T, extra ...string) (string, string) {
+	t.Helper()
+	root := NewRootCmdForTesting()
+	var out, errOut bytes.Buffer
+	root.SetOut(&out)
+	root.SetErr(&errOut)
+	root.SetArgs(append([]string{"config", "set", "pager", "never"}, extra...))
+	require.NoError(t, root.Execute())
+	return out.String(), errOut.String()
+}
+
+func TestQuiet_SuppressesSuccessMessages(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	out, _ := executeConfigSet(t, "--output", "table")
+	assert.Contains(t, out, "Set pager")
+
+	out, _ = executeConfigSet(t, "--output", "table", "--quiet")
+	assert.Empty(t, out)
+
+	out, _ = executeConfigSet(t, "--output", "plain", "--quiet")
+	assert.Empty(t, out)
+}
+
+func TestQuiet_NoEffectOnJSON(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	loud, _ := executeConfigSet(t, "--output", "json")
+	quiet, _ := executeConfigSet(t, "--output", "json", "--quiet")
+	assert.Equal(t, loud, quiet)
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(quiet), &response))
+	assert.Equal(t, "Set pager", response["message"])
+}
@@ -3,24 +3,36 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
+	"github.com/AhaSend/ahasend-cli/cmd/groups/account"
 	"github.com/AhaSend/ahasend-cli/cmd/groups/apikeys"
 	"github.com/AhaSend/ahasend-cli/cmd/groups/auth"
+	"github.com/AhaSend/ahasend-cli/cmd/groups/bounces"
+	"github.com/AhaSend/ahasend-cli/cmd/groups/config"
 	"github.com/AhaSend/ahasend-cli/cmd/groups/domains"
+	"github.com/AhaSend/ahasend-cli/cmd/groups/inbound"
 	"github.com/AhaSend/ahasend-cli/cmd/groups/messages"
+	"github.com/AhaSend/ahasend-cli/cmd/groups/reminders"
 	"github.com/AhaSend/ahasend-cli/cmd/groups/routes"
 	"github.com/AhaSend/ahasend-cli/cmd/groups/smtp"
 	"github.com/AhaSend/ahasend-cli/cmd/groups/stats"
 	"github.com/AhaSend/ahasend-cli/cmd/groups/subaccounts"
 	"github.com/AhaSend/ahasend-cli/cmd/groups/suppressions"
 	"github.com/AhaSend/ahasend-cli/cmd/groups/webhooks"
+	authn "github.com/AhaSend/ahasend-cli/internal/auth"
+	cliconfig "github.com/AhaSend/ahasend-cli/internal/config"
 	"github.com/AhaSend/ahasend-cli/internal/errors"
 	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/pager"
 	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/progress"
+	"github.com/AhaSend/ahasend-cli/internal/validation"
 	"github.com/AhaSend/ahasend-cli/internal/version"
 	"github.com/AhaSend/ahasend-go/api"
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
@@ -35,42 +47,121 @@ suppressions, and more.
 Before using the CLI, you'll need to authenticate with your AhaSend API key:
   ahasend auth login
 
+Alternatively, set AHASEND_API_KEY and AHASEND_ACCOUNT_ID to use a key
+without a profile.
+
 For more information, visit: https://ahasend.com`,
-	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-		// Initialize logger first
-		logger.Initialize(cmd)
+	PersistentPreRunE: persistentPreRun,
+	PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+		return pager.Flush(cmd)
+	},
+	// Let Cobra handle errors and usage display normally
+}
 
-		// Initialize printer and store in context
-		if err := initializePrinter(cmd); err != nil {
-			return err
-		}
+// persistentPreRun prepares every command: output format, logging and the
+// printer, then the credentials check. Commands annotated with
+// NoAuthAnnotation skip the check.
+func persistentPreRun(cmd *cobra.Command, args []string) error {
+	// Resolve the output format before anything reads --output
+	resolveOutputFormat(cmd)
 
-		// Skip auth validation for auth commands and version/help commands
-		if cmd.Name() == "auth" || cmd.Parent().Name() == "auth" ||
-			cmd.Name() == "help" || cmd.Name() == "version" ||
-			cmd.Name() == "completion" {
-			return nil
-		}
+	// Initialize logger first
+	logger.Initialize(cmd)
 
-		return validateGlobalAuth(cmd)
-	},
-	// Let Cobra handle errors and usage display normally
+	// Initialize printer and store in context
+	if err := initializePrinter(cmd); err != nil {
+		return err
+	}
+	if err := progress.ValidateFlags(cmd); err != nil {
+		return err
+	}
+
+	// --schema prints the output shape without running the command
+	if schemaRequested(cmd) {
+		skipRequiredFlags(cmd)
+		return nil
+	}
+
+	// Cobra adds the shell completion request command at run time
+	if !authn.NeedsCredentials(cmd) || cmd.Name() == cobra.ShellCompRequestCmd || cmd.Name() == cobra.ShellCompNoDescRequestCmd {
+		return nil
+	}
+	if err := validateGlobalAuth(cmd); err != nil {
+		return err
+	}
+	return ensureCredentials(cmd)
+}
+
+// markNoAuth annotates the help and completion commands, which Cobra adds
+// itself, as working without credentials. Other commands carry the
+// annotation from their constructors.
+func markNoAuth(root *cobra.Command) {
+	root.InitDefaultHelpCmd()
+	root.InitDefaultCompletionCmd()
+	for _, cmd := range root.Commands() {
+		switch cmd.Name() {
+		case "help", "completion":
+			if cmd.Annotations == nil {
+				cmd.Annotations = map[string]string{}
+			}
+			cmd.Annotations[authn.NoAuthAnnotation] = "true"
+		}
+	}
 }
 
 // initializePrinter creates and stores the printer instance in the command context
 func initializePrinter(cmd *cobra.Command) error {
 	// Get output format and color settings
 	outputFormat, _ := cmd.Flags().GetString("output")
-	noColor, _ := cmd.Flags().GetBool("no-color")
-	colorOutput := !noColor
+	mode, err := colorMode(cmd)
+	if err != nil {
+		return err
+	}
 
 	// Validate output format
 	if err := printer.ValidateFormat(outputFormat); err != nil {
 		return err
 	}
 
+	// Capabilities are detected on stdout itself, before it is paged
+	caps := printer.DetectCapabilities(mode, cmd.OutOrStdout())
+	color.NoColor = !caps.Color
+
+	// Consoles that cannot display UTF-8 get ASCII status markers
+	out := cmd.OutOrStdout()
+	asciiOut := printer.NeedsASCII(out)
+	if asciiOut {
+		out = printer.NewASCIIWriter(out)
+	}
+	if errOut := cmd.ErrOrStderr(); printer.NeedsASCII(errOut) || !printer.DetectCapabilities(mode, errOut).Emoji {
+		cmd.SetErr(printer.NewASCIIWriter(errOut))
+	}
+
+	// Table and plain output is buffered so it can be paged
+	writer, err := newPagerWriter(cmd, outputFormat, out)
+	if err != nil {
+		return err
+	}
+	if writer != nil {
+		pager.Attach(cmd, writer)
+	} else if asciiOut {
+		cmd.SetOut(out)
+	}
+
 	// Create response handler instance
-	handler := printer.GetResponseHandler(outputFormat, colorOutput, cmd.OutOrStdout())
+	handler := printer.GetResponseHandler(outputFormat, caps.Color, cmd.OutOrStdout())
+	if field, _ := cmd.Flags().GetString(printer.FieldFlag); field != "" {
+		if outputFormat != "plain" {
+			return errors.NewValidationError(fmt.Sprintf("--field works with --output plain, not %s", outputFormat), nil)
+		}
+		if printer.IDsOnly(cmd) {
+			return errors.NewValidationError("--field cannot be used with --ids-only", nil)
+		}
+		handler = printer.NewFieldHandler(field, cmd.OutOrStdout(), cmd.ErrOrStderr())
+	}
+	handler.SetCapabilities(caps)
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	handler.SetQuiet(quiet)
 
 	// Store in command context
 	ctx := context.WithValue(cmd.Context(), printer.ResponseHandlerKey, handler)
@@ -79,6 +170,111 @@ func initializePrinter(cmd *cobra.Command) error {
 	return nil
 }
 
+// newPagerWriter returns a writer that buffers table and plain output for
+// paging to out, or nil when the output is never paged: for other formats,
+// when stdout is not a terminal, or when the pager mode is never. --pager
+// overrides the pager preference.
+func newPagerWriter(cmd *cobra.Command, outputFormat string, out io.Writer) (*pager.Writer, error) {
+	mode, _ := cmd.Flags().GetString("pager")
+	if mode != "" {
+		if err := validation.ValidatePagerMode(mode); err != nil {
+			return nil, err
+		}
+	}
+
+	if (outputFormat != "table" && outputFormat != "plain") || !pager.IsTerminal(cmd.OutOrStdout()) {
+		return nil, nil
+	}
+	if mode == "" {
+		mode = pagerPreference()
+	}
+	if mode == pager.ModeNever {
+		return nil, nil
+	}
+	return pager.NewWriter(out, cmd.ErrOrStderr(), mode, pager.Command()), nil
+}
+
+// resolveOutputFormat stores the output format of cmd in its --output flag
+// so everything that reads the flag agrees on it: --output when given, else
+// the command's output_overrides entry, else the output_format preference,
+// else table; --field always prints plain output. The flag is not marked as
+// changed, so a reused command resolves again on its next run.
+func resolveOutputFormat(cmd *cobra.Command) {
+	flag := cmd.Flags().Lookup("output")
+	if flag == nil || flag.Changed {
+		return
+	}
+	if field, _ := cmd.Flags().GetString(printer.FieldFlag); field != "" {
+		_ = flag.Value.Set("plain")
+		return
+	}
+
+	var overrides map[string]string
+	configured := ""
+	configMgr, err := cliconfig.NewManager()
+	if err == nil {
+		err = configMgr.Load()
+	}
+	if err != nil {
+		logger.Get().WithError(err).Debug("Failed to load config for the output format")
+	} else {
+		overrides = configMgr.GetConfig().OutputOverrides
+		configured = configMgr.GetConfig().Preferences.OutputFormat
+	}
+
+	_ = flag.Value.Set(printer.ResolveFormat(cmd, overrides, configured))
+}
+
+// colorMode returns the color mode: --color when given, never with
+// --no-color, else auto unless the color_output preference is false
+func colorMode(cmd *cobra.Command) (string, error) {
+	mode, _ := cmd.Flags().GetString("color")
+	noColor, _ := cmd.Flags().GetBool("no-color")
+	if mode != "" {
+		if err := validation.ValidateColorMode(mode); err != nil {
+			return "", err
+		}
+		if noColor && mode != printer.ColorNever {
+			return "", errors.NewValidationError(fmt.Sprintf("--no-color conflicts with --color %s", mode), nil)
+		}
+		return mode, nil
+	}
+	if noColor {
+		return printer.ColorNever, nil
+	}
+
+	configMgr, err := cliconfig.NewManager()
+	if err != nil {
+		return printer.ColorAuto, nil
+	}
+	if err := configMgr.Load(); err != nil {
+		logger.Get().WithError(err).Debug("Failed to load config for the color preference")
+		return printer.ColorAuto, nil
+	}
+	if !configMgr.GetConfig().Preferences.ColorOutput {
+		return printer.ColorNever, nil
+	}
+	return printer.ColorAuto, nil
+}
+
+// pagerPreference returns the configured pager mode, or auto when there is
+// no valid one
+func pagerPreference() string {
+	configMgr, err := cliconfig.NewManager()
+	if err != nil {
+		return pager.ModeAuto
+	}
+	if err := configMgr.Load(); err != nil {
+		logger.Get().WithError(err).Debug("Failed to load config for the pager preference")
+		return pager.ModeAuto
+	}
+	mode, err := configMgr.GetPreference("pager")
+	if err != nil || validation.ValidatePagerMode(mode) != nil {
+		return pager.ModeAuto
+	}
+	return mode
+}
+
 // validateGlobalAuth checks for global API key or existing profile
 func validateGlobalAuth(cmd *cobra.Command) error {
 	// Check for global --api-key and --account-id flags
@@ -120,9 +316,16 @@ func handleError(cmd *cobra.Command, err error) {
 	// Get handler from context for error formatting
 	handler := printer.GetResponseHandlerFromCommand(cmd)
 
+	// API failures are translated into actionable guidance, except raw API
+	// errors in JSON mode, which are passed through unchanged
+	rawJSON := isJSONRawAPIError(handler, err)
+	if !rawJSON {
+		err = errors.Translate(err)
+	}
+
 	// Set exit code based on error type. Raw API errors in JSON mode are
 	// pass-through API responses and intentionally keep the global exit code at 0.
-	if isJSONRawAPIError(handler, err) {
+	if rawJSON {
 		globalExitCode = 0
 	} else if cliErr, ok := err.(*errors.CLIError); ok {
 		globalExitCode = errors.GetExitCode(cliErr)
@@ -143,7 +346,8 @@ func handleError(cmd *cobra.Command, err error) {
 
 func isJSONRawAPIError(handler printer.ResponseHandler, err error) bool {
 	apiErr, ok := err.(*api.APIError)
-	return ok && handler.GetFormat() == "json" && len(apiErr.Raw) > 0
+	format := handler.GetFormat()
+	return ok && (format == "json" || format == "jsonl") && len(apiErr.Raw) > 0
 }
 
 // isUsageError determines if an error should trigger usage display
@@ -185,7 +389,9 @@ func isUsageError(errorMsg string) bool {
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() {
 	globalExitCode = 0 // Reset exit code
+	restoreConsole := printer.SetupConsole()
 	err := rootCmd.Execute()
+	restoreConsole()
 	if err != nil {
 		// Error already handled by applyJSONErrorHandling, just exit with proper code
 		if cliErr, ok := err.(*errors.CLIError); ok {
@@ -211,19 +417,36 @@ Git Commit: %s
 	rootCmd.PersistentFlags().String("api-key", "", "AhaSend API key (overrides profile)")
 	rootCmd.PersistentFlags().String("account-id", "", "AhaSend Account ID (required with --api-key)")
 	rootCmd.PersistentFlags().String("profile", "", "Profile to use (overrides default)")
-	rootCmd.PersistentFlags().String("output", "plain", "Output format (table, json, plain)")
-	rootCmd.PersistentFlags().Bool("no-color", false, "Disable colored output")
+	rootCmd.PersistentFlags().String("api-url", "", "AhaSend API base URL (overrides AHASEND_API_URL and profile api_url)")
+	rootCmd.PersistentFlags().String("output", "", "Output format: table, json, jsonl, csv or plain (default: output_overrides or output_format from config, else table)")
+	rootCmd.PersistentFlags().Bool("no-color", false, "Disable colors and emoji in table and plain output (same as --color never)")
+	rootCmd.PersistentFlags().String("color", "", "When to use colors and emoji in table and plain output: auto, always or never (default: auto, which also honors NO_COLOR; never when the color_output preference is false)")
+	rootCmd.PersistentFlags().String("pager", "", "When to page table and plain output: auto, always or never (default: the pager preference, else auto)")
 	rootCmd.PersistentFlags().Bool("verbose", false, "Enable verbose output")
 	rootCmd.PersistentFlags().Bool("debug", false, "Enable debug mode")
+	rootCmd.PersistentFlags().Bool("quiet", false, "Suppress warning banners, success messages and advisory notes, printing only data; no effect on json output")
+	rootCmd.PersistentFlags().String("progress-format", progress.FormatBar, "Progress output of long operations: bar, or json for periodic JSON lines on stderr")
+	rootCmd.PersistentFlags().Duration("progress-interval", progress.DefaultInterval, "How often --progress-format json writes a progress line")
+	rootCmd.PersistentFlags().Bool("schema", false, "Print the JSON output shape (keys and types) of the command without calling the API")
+	rootCmd.PersistentFlags().String(printer.FieldFlag, "", "Print only the value of this field of the response, one line per item for lists, with no labels (implies --output plain; field names are the keys of --output json)")
 
 	// Add utility commands
-	rootCmd.AddCommand(pingCmd)
+	rootCmd.AddCommand(newPingCommand())
+	rootCmd.AddCommand(newDoctorCommand())
+	rootCmd.AddCommand(newVerifyExportCommand())
+	rootCmd.AddCommand(newDocsCommand())
+	rootCmd.AddCommand(newDashboardCommand())
 
 	// Add command groups
+	rootCmd.AddCommand(account.NewCommand())
 	rootCmd.AddCommand(apikeys.NewCommand())
 	rootCmd.AddCommand(auth.NewCommand())
+	rootCmd.AddCommand(bounces.NewCommand())
+	rootCmd.AddCommand(config.NewCommand())
 	rootCmd.AddCommand(domains.NewCommand())
+	rootCmd.AddCommand(inbound.NewCommand())
 	rootCmd.AddCommand(messages.NewCommand())
+	rootCmd.AddCommand(reminders.NewCommand())
 	rootCmd.AddCommand(routes.NewCommand())
 	rootCmd.AddCommand(smtp.NewCommand())
 	rootCmd.AddCommand(stats.NewCommand())
@@ -233,6 +456,7 @@ Git Commit: %s
 
 	// Apply JSON error handling to all commands recursively
 	applyJSONErrorHandling(rootCmd)
+	markNoAuth(rootCmd)
 }
 
 // applyJSONErrorHandling applies JSON-aware error handling to a command and all its subcommands
@@ -240,11 +464,27 @@ func applyJSONErrorHandling(cmd *cobra.Command) {
 	// Don't silence errors/usage - let Cobra handle validation errors normally
 	// We'll only handle JSON output for actual command execution errors
 
+	// --schema needs no arguments
+	if cmd.Args != nil {
+		originalArgs := cmd.Args
+		cmd.Args = func(c *cobra.Command, args []string) error {
+			if schemaRequested(c) {
+				return nil
+			}
+			return originalArgs(c, args)
+		}
+	}
+
 	// Store the original RunE function if it exists
 	if cmd.RunE != nil {
 		originalRunE := cmd.RunE
 		cmd.RunE = func(c *cobra.Command, args []string) error {
-			err := originalRunE(c, args)
+			var err error
+			if schemaRequested(c) {
+				err = printSchema(c)
+			} else {
+				err = originalRunE(c, args)
+			}
 			if err != nil {
 				handleError(c, err)
 				// Return nil to prevent Cobra from handling the error again
@@ -288,24 +528,13 @@ suppressions, and more.
 Before using the CLI, you'll need to authenticate with your AhaSend API key:
   ahasend auth login
 
-For more information, visit: https://ahasend.com`,
-		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-			// Initialize logger first
-			logger.Initialize(cmd)
-
-			// Initialize printer and store in context
-			if err := initializePrinter(cmd); err != nil {
-				return err
-			}
-
-			// Skip auth validation for auth commands and version/help commands
-			if cmd.Name() == "auth" || cmd.Parent().Name() == "auth" ||
-				cmd.Name() == "help" || cmd.Name() == "version" ||
-				cmd.Name() == "completion" {
-				return nil
-			}
+Alternatively, set AHASEND_API_KEY and AHASEND_ACCOUNT_ID to use a key
+without a profile.
 
-			return validateGlobalAuth(cmd)
+For more information, visit: https://ahasend.com`,
+		PersistentPreRunE: persistentPreRun,
+		PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+			return pager.Flush(cmd)
 		},
 		// Let Cobra handle errors and usage display normally
 	}
@@ -314,10 +543,18 @@ For more information, visit: https://ahasend.com`,
 	root.PersistentFlags().String("api-key", "", "AhaSend API key (overrides profile)")
 	root.PersistentFlags().String("account-id", "", "AhaSend Account ID (required with --api-key)")
 	root.PersistentFlags().String("profile", "", "Profile to use (overrides default)")
-	root.PersistentFlags().String("output", "plain", "Output format (table, json, plain)")
-	root.PersistentFlags().Bool("no-color", false, "Disable colored output")
+	root.PersistentFlags().String("api-url", "", "AhaSend API base URL (overrides AHASEND_API_URL and profile api_url)")
+	root.PersistentFlags().String("output", "", "Output format: table, json, jsonl, csv or plain (default: output_overrides or output_format from config, else table)")
+	root.PersistentFlags().Bool("no-color", false, "Disable colors and emoji in table and plain output (same as --color never)")
+	root.PersistentFlags().String("color", "", "When to use colors and emoji in table and plain output: auto, always or never (default: auto, which also honors NO_COLOR; never when the color_output preference is false)")
+	root.PersistentFlags().String("pager", "", "When to page table and plain output: auto, always or never (default: the pager preference, else auto)")
 	root.PersistentFlags().Bool("verbose", false, "Enable verbose output")
 	root.PersistentFlags().Bool("debug", false, "Enable debug mode")
+	root.PersistentFlags().Bool("quiet", false, "Suppress warning banners, success messages and advisory notes, printing only data; no effect on json output")
+	root.PersistentFlags().String("progress-format", progress.FormatBar, "Progress output of long operations: bar, or json for periodic JSON lines on stderr")
+	root.PersistentFlags().Duration("progress-interval", progress.DefaultInterval, "How often --progress-format json writes a progress line")
+	root.PersistentFlags().Bool("schema", false, "Print the JSON output shape (keys and types) of the command without calling the API")
+	root.PersistentFlags().String(printer.FieldFlag, "", "Print only the value of this field of the response, one line per item for lists, with no labels (implies --output plain; field names are the keys of --output json)")
 
 	// Flattening configuration flags for complex data structures
 	root.PersistentFlags().Int("flatten-arrays", 10, "Maximum array items to show as separate columns in CSV/table output")
@@ -325,20 +562,32 @@ For more information, visit: https://ahasend.com`,
 	root.PersistentFlags().StringSlice("flatten-skip", []string{}, "Field names to skip during flattening (comma-separated)")
 
 	// Add utility commands
-	root.AddCommand(pingCmd)
+	root.AddCommand(newPingCommand())
+	root.AddCommand(newDoctorCommand())
+	root.AddCommand(newVerifyExportCommand())
+	root.AddCommand(newDocsCommand())
+	root.AddCommand(newDashboardCommand())
 
 	// Add fresh command group instances
+	root.AddCommand(account.NewCommand())
 	root.AddCommand(apikeys.NewCommand())
 	root.AddCommand(auth.NewCommand())
+	root.AddCommand(bounces.NewCommand())
+	root.AddCommand(config.NewCommand())
 	root.AddCommand(domains.NewCommand())
+	root.AddCommand(inbound.NewCommand())
 	root.AddCommand(messages.NewCommand())
+	root.AddCommand(reminders.NewCommand())
 	root.AddCommand(routes.NewCommand())
+	root.AddCommand(smtp.NewCommand())
+	root.AddCommand(stats.NewCommand())
 	root.AddCommand(subaccounts.NewCommand())
 	root.AddCommand(suppressions.NewCommand())
 	root.AddCommand(webhooks.NewCommand())
 
 	// Apply JSON error handling to all commands recursively
 	applyJSONErrorHandling(root)
+	markNoAuth(root)
 
 	return root
 }
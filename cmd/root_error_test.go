@@ -98,6 +98,20 @@ func TestHandleErrorJSONValidationErrorUsesCLIExitCode(t *testing.T) {
 
 	assert.Equal(t, clierrors.GetExitCode(err), globalExitCode)
 	assert.NotZero(t, globalExitCode)
-	assert.JSONEq(t, `{"error":true,"message":"invalid input"}`, stdout.String())
+	assert.JSONEq(t, `{"error":true,"message":"invalid input","schema_version":1}`, stdout.String())
 	assert.Empty(t, stderr.String())
 }
+
+func TestHandleErrorTranslatesAPIErrors(t *testing.T) {
+	cmd, stdout, _ := newHandleErrorTestCommand(t, "table")
+
+	handleError(cmd, &api.APIError{
+		StatusCode: 401,
+		RequestID:  "req-123",
+		Message:    `{"message":"Unauthorized"}`,
+	})
+
+	assert.Equal(t, 2, globalExitCode)
+	assert.Contains(t, stdout.String(), "your API key is invalid or revoked (HTTP 401, request ID req-123)")
+	assert.Contains(t, stdout.String(), "ahasend auth login")
+}
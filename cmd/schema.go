@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/AhaSend/ahasend-cli/internal/docs"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// commandSchema is what --schema prints: the shape of each JSON output of a
+// command, default output first
+type commandSchema struct {
+	SchemaVersion int            `json:"schema_version"`
+	Command       string         `json:"command"`
+	Outputs       []outputSchema `json:"outputs"`
+}
+
+// outputSchema is the shape of one JSON output, named after the response
+// handler method that prints it
+type outputSchema struct {
+	Handler string      `json:"handler"`
+	Shape   interface{} `json:"shape"`
+}
+
+// schemaRequested reports whether --schema was passed
+func schemaRequested(cmd *cobra.Command) bool {
+	schema, _ := cmd.Flags().GetBool("schema")
+	return schema
+}
+
+// skipRequiredFlags marks the required flags of cmd as set, so --schema
+// works without the flags the command needs to run
+func skipRequiredFlags(cmd *cobra.Command) {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if required := flag.Annotations[cobra.BashCompOneRequiredFlag]; len(required) > 0 && required[0] == "true" {
+			flag.Changed = true
+		}
+	})
+}
+
+// printSchema prints the JSON output shapes of cmd, rendered from the same
+// sample data as the printer's golden files, without calling the API
+func printSchema(cmd *cobra.Command) error {
+	methods, _ := docs.OutputSchemas(cmd)
+	if len(methods) == 0 {
+		return errors.NewValidationError(fmt.Sprintf("%s has no JSON output", cmd.CommandPath()), nil)
+	}
+
+	schema := commandSchema{
+		SchemaVersion: printer.SchemaVersion,
+		Command:       cmd.CommandPath(),
+	}
+	for _, method := range methods {
+		shape, err := printer.SampleShape(method)
+		if err != nil {
+			return fmt.Errorf("failed to render the schema of %s: %w", method, err)
+		}
+		schema.Outputs = append(schema.Outputs, outputSchema{Handler: method, Shape: shape})
+	}
+
+	encoder := json.NewEncoder(cmd.OutOrStdout())
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(schema)
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/docs"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+)
+
+func TestSchemasCoverEveryCommand(t *testing.T) {
+	var walk func(cmd *cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		for _, child := range cmd.Commands() {
+			if !child.IsAvailableCommand() || child.Name() == "help" || child.Name() == "completion" {
+				continue
+			}
+			if child.Runnable() {
+				_, ok := docs.OutputSchemas(child)
+				assert.True(t, ok, "no output schemas recorded for %q", child.CommandPath())
+			}
+			walk(child)
+		}
+	}
+	walk(GetRootCmd())
+}
+
+func executeSchema(t *testing.T, args ...string) (commandSchema, string) {
+	t.Helper()
+	root := NewRootCmdForTesting()
+	root.SetArgs(append(args, "--schema"))
+	var stdout bytes.Buffer
+	root.SetOut(&stdout)
+	require.NoError(t, root.Execute())
+
+	var schema commandSchema
+	if err := json.Unmarshal(stdout.Bytes(), &schema); err != nil {
+		return commandSchema{}, stdout.String()
+	}
+	return schema, stdout.String()
+}
+
+func TestSchemaFlag(t *testing.T) {
+	t.Run("skips arguments and the API", func(t *testing.T) {
+		schema, _ := executeSchema(t, "domains", "get")
+		assert.Equal(t, printer.SchemaVersion, schema.SchemaVersion)
+		assert.Equal(t, "ahasend domains get", schema.Command)
+		require.Len(t, schema.Outputs, 1)
+		assert.Equal(t, "HandleSingleDomain", schema.Outputs[0].Handler)
+
+		shape := schema.Outputs[0].Shape.(map[string]interface{})
+		assert.Equal(t, "number", shape["schema_version"])
+		assert.Equal(t, "boolean", shape["dns_valid"])
+		assert.IsType(t, []interface{}{}, shape["dns_records"])
+	})
+
+	t.Run("skips required flags", func(t *testing.T) {
+		schema, _ := executeSchema(t, "apikeys", "create")
+		require.Len(t, schema.Outputs, 1)
+		assert.Equal(t, "HandleCreateAPIKey", schema.Outputs[0].Handler)
+	})
+
+	t.Run("lists every output", func(t *testing.T) {
+		schema, _ := executeSchema(t, "suppressions", "create")
+		require.Len(t, schema.Outputs, 2)
+		assert.Equal(t, "HandleCreateSuppression", schema.Outputs[0].Handler)
+		assert.Equal(t, "HandleBounceSuppressions", schema.Outputs[1].Handler)
+	})
+
+	t.Run("commands without JSON output", func(t *testing.T) {
+		_, output := executeSchema(t, "webhooks", "listen")
+		assert.Contains(t, output, "ahasend webhooks listen has no JSON output")
+	})
+}
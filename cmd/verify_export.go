@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	authn "github.com/AhaSend/ahasend-cli/internal/auth"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/manifest"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/spf13/cobra"
+)
+
+// newVerifyExportCommand creates the verify-export command
+func newVerifyExportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify-export",
+		Short: "Verify an exported data file against its manifest",
+		Long: `Recompute the SHA-256 checksum and record count of an exported data file and
+compare them with the manifest written by the export's --manifest flag.
+
+The file is read in a single streaming pass, so large exports can be verified
+without loading them into memory. Records are counted by file type: CSV rows
+after the header, elements of a JSON array (or of the "data" array of a JSON
+object), or non-blank lines for JSON Lines and other files.
+
+The command exits with a validation error (exit code 4) if the file does not
+match the manifest.
+
+Examples:
+  # Verify an export before loading it into a pipeline
+  ahasend verify-export --manifest manifest.json --file data.csv`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			handler := printer.GetResponseHandlerFromCommand(cmd)
+
+			manifestPath, _ := cmd.Flags().GetString("manifest")
+			dataFile, _ := cmd.Flags().GetString("file")
+
+			m, err := manifest.Load(manifestPath)
+			if err != nil {
+				return err
+			}
+
+			digest, mismatches, err := m.Verify(dataFile)
+			if err != nil {
+				return err
+			}
+			if len(mismatches) > 0 {
+				return errors.NewValidationError(fmt.Sprintf("%s does not match manifest %s: %s",
+					dataFile, manifestPath, strings.Join(mismatches, "; ")), nil)
+			}
+
+			return handler.HandleSimpleSuccess(fmt.Sprintf("%s matches manifest %s: %d records, SHA-256 %s",
+				dataFile, manifestPath, digest.Records, digest.SHA256))
+		},
+		SilenceUsage: true,
+		Annotations:  map[string]string{authn.NoAuthAnnotation: "true"},
+	}
+
+	cmd.Flags().String("manifest", "", "Manifest written by the export (required)")
+	cmd.Flags().String("file", "", "Exported data file to verify (required)")
+	cmd.MarkFlagRequired("manifest")
+	cmd.MarkFlagRequired("file")
+
+	return cmd
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/manifest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyExport(t *testing.T) {
+	dir := t.TempDir()
+	dataFile := filepath.Join(dir, "data.csv")
+	manifestPath := filepath.Join(dir, "manifest.json")
+	require.NoError(t, os.WriteFile(dataFile, []byte("email\na@example.com\nb@example.com\n"), 0644))
+
+	m := manifest.New("suppressions export", nil, time.Now())
+	require.NoError(t, m.Finalize(dataFile))
+	require.NoError(t, m.Write(manifestPath))
+
+	run := func() string {
+		t.Cleanup(func() { globalExitCode = 0 })
+		globalExitCode = 0
+
+		var out bytes.Buffer
+		root := NewRootCmdForTesting()
+		root.SetOut(&out)
+		root.SetErr(&out)
+		root.SetArgs([]string{"verify-export", "--manifest", manifestPath, "--file", dataFile})
+		require.NoError(t, root.Execute())
+		return out.String()
+	}
+
+	t.Run("match", func(t *testing.T) {
+		output := run()
+		assert.Equal(t, 0, globalExitCode)
+		assert.Contains(t, output, "matches manifest")
+		assert.Contains(t, output, "2 records")
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(dataFile, []byte("email\na@example.com\n"), 0644))
+
+		output := run()
+		assert.Equal(t, 4, globalExitCode)
+		assert.Contains(t, output, "does not match manifest")
+		assert.Contains(t, output, "file has 1 records, manifest has 2")
+	})
+}
@@ -0,0 +1,171 @@
+// Package accountstatus reports accounts that cannot send.
+//
+// A paused or restricted account (compliance review, billing) rejects every
+// message, which otherwise surfaces as a generic API error partway through a
+// batch. The send commands call RequireSending to fail before the first
+// message, and other commands call Warn to print a one-line banner.
+//
+// The status is cached per account in the state file for TTL, so the check
+// costs at most one short request every few minutes.
+package accountstatus
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/state"
+)
+
+// TTL is how long a looked up status is reused
+const TTL = 5 * time.Minute
+
+// SendsAnnotation marks a command that sends messages. Such commands check
+// the status with RequireSending, so Warn leaves them alone.
+const SendsAnnotation = "ahasend.sends-messages"
+
+// now is replaced in tests
+var now = time.Now
+
+var (
+	lookupMu sync.RWMutex
+	lookup   = Lookup
+)
+
+// SetLookupForTesting replaces the status source of RequireSending and Warn
+// so tests neither call the API nor touch the state file. It returns a
+// function restoring the previous source.
+func SetLookupForTesting(fn func(client.AhaSendClient) state.AccountStatus) func() {
+	lookupMu.Lock()
+	previous := lookup
+	lookup = fn
+	lookupMu.Unlock()
+
+	return func() {
+		lookupMu.Lock()
+		lookup = previous
+		lookupMu.Unlock()
+	}
+}
+
+func lookupStatus(apiClient client.AhaSendClient) state.AccountStatus {
+	lookupMu.RLock()
+	fn := lookup
+	lookupMu.RUnlock()
+	return fn(apiClient)
+}
+
+// Lookup returns the status of the client's account, from the state file
+// when it was checked within TTL and from the API otherwise. A failed lookup
+// is cached as unknown for TTL too, so an API that does not report the
+// status costs one request per TTL rather than one per command. When the
+// state file cannot be read, the status is looked up without caching, so a
+// damaged file is never overwritten.
+func Lookup(apiClient client.AhaSendClient) state.AccountStatus {
+	accountID := apiClient.GetAccountID()
+
+	s, err := state.Load()
+	if err != nil {
+		logger.Get().WithError(err).Debug("Failed to read the cached account status")
+		return fetch(apiClient)
+	}
+	if cached, ok := s.AccountStatuses[accountID]; ok && now().Sub(cached.CheckedAt) < TTL {
+		return cached
+	}
+
+	status := fetch(apiClient)
+	if s.AccountStatuses == nil {
+		s.AccountStatuses = make(map[string]state.AccountStatus)
+	}
+	s.AccountStatuses[accountID] = status
+	if err := s.Save(); err != nil {
+		logger.Get().WithError(err).Debug("Failed to cache the account status")
+	}
+	return status
+}
+
+// fetch looks up the status from the API; a failed lookup is unknown
+func fetch(apiClient client.AhaSendClient) state.AccountStatus {
+	status := state.AccountStatus{CheckedAt: now().UTC()}
+	fetched, err := apiClient.GetAccountStatus()
+	if err != nil {
+		logger.Get().WithError(err).Debug("Failed to look up the account status")
+	} else if fetched != nil {
+		status.Status = strings.ToLower(fetched.Status)
+		status.Reason = fetched.StatusReason
+		status.SendingDisabled = fetched.SendingDisabled()
+	}
+	return status
+}
+
+// RequireSending fails when the account cannot send messages. An unknown
+// status lets the send go ahead; the API still has the final say.
+func RequireSending(apiClient client.AhaSendClient) error {
+	status := lookupStatus(apiClient)
+	if !status.SendingDisabled {
+		return nil
+	}
+
+	message := fmt.Sprintf("sending is disabled for account %s", apiClient.GetAccountID())
+	if status.Status != "" {
+		message += fmt.Sprintf(" because it is %s", status.Status)
+	}
+	if status.Reason != "" {
+		message += fmt.Sprintf(" (%s)", status.Reason)
+	}
+	return errors.NewPermissionError(message+"; no messages were sent. Contact AhaSend support to restore sending", nil)
+}
+
+// Warning returns the one-line warning for a status, or "" when the account
+// is active or its status is unknown
+func Warning(status state.AccountStatus) string {
+	if !status.SendingDisabled && (status.Status == "" || status.Status == client.AccountStatusActive) {
+		return ""
+	}
+
+	warning := "Sending is disabled for this account"
+	if status.Status != "" {
+		warning = "Account is " + status.Status
+		if status.SendingDisabled {
+			warning += " and cannot send"
+		}
+	}
+	if status.Reason != "" {
+		warning += ": " + status.Reason
+	}
+	return warning
+}
+
+// Warn reports a paused or restricted account for a command about to use
+// apiClient. Table output gets a banner on stderr unless --quiet is set,
+// JSON output gets the warning in its warnings array, and other formats are
+// left untouched.
+func Warn(cmd *cobra.Command, apiClient client.AhaSendClient) {
+	if _, sends := cmd.Annotations[SendsAnnotation]; sends || cmd.Context() == nil {
+		return
+	}
+
+	handler := printer.GetResponseHandlerFromCommand(cmd)
+	format := handler.GetFormat()
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	if format != "json" && (format != "table" || quiet) {
+		return
+	}
+
+	warning := Warning(lookupStatus(apiClient))
+	if warning == "" {
+		return
+	}
+	if format == "json" {
+		handler.AddWarning(warning)
+		return
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %s\n", warning)
+}
@@ -0,0 +1,148 @@
+package accountstatus
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/state"
+)
+
+func pausedClient() *mocks.MockClient {
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetAccountID").Return("acc-1")
+	mockClient.On("GetAccountStatus").Return(&client.AccountStatus{Status: "Paused", StatusReason: "Compliance review"}, nil)
+	return mockClient
+}
+
+func TestLookup_CachesForTTL(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	current := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return current }
+	t.Cleanup(func() { now = time.Now })
+
+	mockClient := pausedClient()
+	status := Lookup(mockClient)
+	assert.Equal(t, "paused", status.Status)
+	assert.True(t, status.SendingDisabled)
+
+	current = current.Add(TTL - time.Second)
+	assert.Equal(t, status, Lookup(mockClient))
+	mockClient.AssertNumberOfCalls(t, "GetAccountStatus", 1)
+
+	s, err := state.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "Compliance review", s.AccountStatuses["acc-1"].Reason)
+
+	current = current.Add(2 * time.Second)
+	Lookup(mockClient)
+	mockClient.AssertNumberOfCalls(t, "GetAccountStatus", 2)
+}
+
+func TestLookup_FailureIsCached(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetAccountID").Return("acc-1")
+	mockClient.On("GetAccountStatus").Return(nil, assert.AnError).Once()
+
+	assert.Empty(t, Lookup(mockClient).Status)
+	assert.Empty(t, Lookup(mockClient).Status, "the failure is not retried within the TTL")
+	mockClient.AssertExpectations(t)
+}
+
+func TestLookup_UnreadableStateIsKept(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	path, err := state.Path()
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	require.NoError(t, os.WriteFile(path, []byte("{not json"), 0o600))
+
+	assert.Equal(t, "paused", Lookup(pausedClient()).Status)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "{not json", string(data), "a damaged state file is not overwritten")
+}
+
+func TestRequireSending(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	err := RequireSending(pausedClient())
+	require.Error(t, err)
+	assert.Equal(t, errors.ErrCodePermission, err.(*errors.CLIError).Code)
+	assert.Contains(t, err.Error(), "sending is disabled for account acc-1 because it is paused (Compliance review); no messages were sent")
+
+	active := &mocks.MockClient{}
+	active.On("GetAccountID").Return("acc-2")
+	active.On("GetAccountStatus").Return(&client.AccountStatus{Status: "active"}, nil)
+	assert.NoError(t, RequireSending(active))
+}
+
+func TestWarning(t *testing.T) {
+	assert.Empty(t, Warning(state.AccountStatus{}))
+	assert.Empty(t, Warning(state.AccountStatus{Status: "active"}))
+	assert.Equal(t, "Account is paused and cannot send: Billing", Warning(state.AccountStatus{Status: "paused", Reason: "Billing", SendingDisabled: true}))
+	assert.Equal(t, "Account is under_review", Warning(state.AccountStatus{Status: "under_review"}))
+	assert.Equal(t, "Sending is disabled for this account", Warning(state.AccountStatus{SendingDisabled: true}))
+}
+
+func warnCommand(t *testing.T, format string, args ...string) (*cobra.Command, printer.ResponseHandler, *bytes.Buffer, *bytes.Buffer) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	cmd := &cobra.Command{Use: "list"}
+	cmd.Flags().Bool("quiet", false, "")
+	require.NoError(t, cmd.ParseFlags(args))
+	handler := printer.GetResponseHandler(format, false, &stdout)
+	cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+	cmd.SetErr(&stderr)
+	return cmd, handler, &stdout, &stderr
+}
+
+func TestWarn(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	t.Run("table banner", func(t *testing.T) {
+		cmd, _, _, stderr := warnCommand(t, "table")
+		Warn(cmd, pausedClient())
+		assert.Equal(t, "Warning: Account is paused and cannot send: Compliance review\n", stderr.String())
+	})
+
+	t.Run("quiet", func(t *testing.T) {
+		cmd, _, _, stderr := warnCommand(t, "table", "--quiet")
+		Warn(cmd, pausedClient())
+		assert.Empty(t, stderr.String())
+	})
+
+	t.Run("json warnings array", func(t *testing.T) {
+		cmd, handler, stdout, stderr := warnCommand(t, "json")
+		Warn(cmd, pausedClient())
+		require.NoError(t, handler.HandleSimpleSuccess("done"))
+		assert.Empty(t, stderr.String())
+		assert.Contains(t, stdout.String(), `"warnings": [`)
+	})
+
+	t.Run("csv and send commands are left alone", func(t *testing.T) {
+		mockClient := &mocks.MockClient{}
+		cmd, _, _, stderr := warnCommand(t, "csv")
+		Warn(cmd, mockClient)
+
+		cmd, _, _, _ = warnCommand(t, "table")
+		cmd.Annotations = map[string]string{SendsAnnotation: "true"}
+		Warn(cmd, mockClient)
+
+		assert.Empty(t, stderr.String())
+		mockClient.AssertNotCalled(t, "GetAccountStatus")
+	})
+}
@@ -0,0 +1,95 @@
+// Package anomaly flags values in a time series that deviate from the
+// recent past. Each value is compared with the mean and standard deviation
+// of the values in a trailing window before it, and flagged when its
+// z-score exceeds a sensitivity threshold. The package does no I/O.
+package anomaly
+
+import "math"
+
+// DefaultWindow is the number of preceding values each value is compared with
+const DefaultWindow = 7
+
+// DefaultSensitivity is the z-score beyond which a value is anomalous
+const DefaultSensitivity = 2.5
+
+// MinHistory is the number of preceding values a value needs before it is
+// scored; with fewer, the mean and deviation say too little
+const MinHistory = 3
+
+// Score is the result of comparing one value with the window before it
+type Score struct {
+	Index  int     // position of the value in the series
+	Value  float64 // the value itself
+	Mean   float64 // mean of the window
+	StdDev float64 // sample standard deviation of the window
+
+	// ZScore is (Value - Mean) / StdDev. It is nil when the window has no
+	// variation, in which case any change from the mean is anomalous.
+	ZScore *float64
+
+	Scored    bool // false when there were fewer than MinHistory preceding values
+	Anomalous bool
+}
+
+// Detect scores every value of series against the up to window values
+// before it. Values with fewer than MinHistory preceding values are returned
+// unscored and never anomalous.
+func Detect(series []float64, window int, sensitivity float64) []Score {
+	if window < MinHistory {
+		window = MinHistory
+	}
+
+	scores := make([]Score, len(series))
+	for i, value := range series {
+		scores[i] = Score{Index: i, Value: value}
+
+		start := i - window
+		if start < 0 {
+			start = 0
+		}
+		history := series[start:i]
+		if len(history) < MinHistory {
+			continue
+		}
+
+		mean, stdDev := meanStdDev(history)
+		scores[i].Mean = mean
+		scores[i].StdDev = stdDev
+		scores[i].Scored = true
+
+		if stdDev == 0 {
+			scores[i].Anomalous = value != mean
+			continue
+		}
+		z := (value - mean) / stdDev
+		scores[i].ZScore = &z
+		scores[i].Anomalous = math.Abs(z) > sensitivity
+	}
+	return scores
+}
+
+// Anomalies returns the anomalous scores
+func Anomalies(scores []Score) []Score {
+	var anomalies []Score
+	for _, score := range scores {
+		if score.Anomalous {
+			anomalies = append(anomalies, score)
+		}
+	}
+	return anomalies
+}
+
+// meanStdDev returns the mean and sample standard deviation of values, which
+// must hold at least two values
+func meanStdDev(values []float64) (mean, stdDev float64) {
+	for _, value := range values {
+		mean += value
+	}
+	mean /= float64(len(values))
+
+	var squares float64
+	for _, value := range values {
+		squares += (value - mean) * (value - mean)
+	}
+	return mean, math.Sqrt(squares / float64(len(values)-1))
+}
@@ -0,0 +1,112 @@
+package anomaly
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name        string
+		series      []float64
+		window      int
+		sensitivity float64
+		anomalous   []int
+	}{
+		{
+			name:        "constant series",
+			series:      []float64{2, 2, 2, 2, 2, 2, 2, 2, 2, 2},
+			window:      7,
+			sensitivity: 2.5,
+			anomalous:   nil,
+		},
+		{
+			name:        "single spike",
+			series:      []float64{1.0, 1.2, 0.9, 1.1, 1.0, 1.1, 0.9, 6.5, 1.0, 1.1},
+			window:      7,
+			sensitivity: 2.5,
+			anomalous:   []int{7},
+		},
+		{
+			name:        "single drop",
+			series:      []float64{98.1, 98.4, 97.9, 98.2, 98.0, 98.3, 91.0},
+			window:      7,
+			sensitivity: 2.5,
+			anomalous:   []int{6},
+		},
+		{
+			name:        "trending series",
+			series:      []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14},
+			window:      7,
+			sensitivity: 2.5,
+			anomalous:   nil,
+		},
+		{
+			name:        "spike after a flat window",
+			series:      []float64{0, 0, 0, 0, 3},
+			window:      7,
+			sensitivity: 2.5,
+			anomalous:   []int{4},
+		},
+		{
+			name:        "lower sensitivity flags more",
+			series:      []float64{1.0, 1.2, 0.9, 1.1, 1.0, 1.1, 0.9, 1.4},
+			window:      7,
+			sensitivity: 1.5,
+			anomalous:   []int{7},
+		},
+		{
+			name:        "too little history",
+			series:      []float64{1, 1, 50},
+			window:      7,
+			sensitivity: 2.5,
+			anomalous:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scores := Detect(tt.series, tt.window, tt.sensitivity)
+			require.Len(t, scores, len(tt.series))
+
+			var anomalous []int
+			for _, score := range Anomalies(scores) {
+				anomalous = append(anomalous, score.Index)
+			}
+			assert.Equal(t, tt.anomalous, anomalous)
+		})
+	}
+}
+
+func TestDetect_Scores(t *testing.T) {
+	scores := Detect([]float64{1, 2, 3, 4, 10}, 3, 2.5)
+
+	for _, score := range scores[:3] {
+		assert.False(t, score.Scored, "index %d has fewer than %d preceding values", score.Index, MinHistory)
+		assert.Nil(t, score.ZScore)
+	}
+
+	// The window of index 3 is 1, 2, 3
+	assert.True(t, scores[3].Scored)
+	assert.InDelta(t, 2.0, scores[3].Mean, 1e-9)
+	assert.InDelta(t, 1.0, scores[3].StdDev, 1e-9)
+	require.NotNil(t, scores[3].ZScore)
+	assert.InDelta(t, 2.0, *scores[3].ZScore, 1e-9)
+	assert.False(t, scores[3].Anomalous)
+
+	// The window of index 4 is 2, 3, 4: only the last 3 values count
+	assert.InDelta(t, 3.0, scores[4].Mean, 1e-9)
+	require.NotNil(t, scores[4].ZScore)
+	assert.InDelta(t, 7.0, *scores[4].ZScore, 1e-9)
+	assert.True(t, scores[4].Anomalous)
+}
+
+func TestDetect_FlatWindow(t *testing.T) {
+	scores := Detect([]float64{5, 5, 5, 5, 6}, 7, 2.5)
+	assert.True(t, scores[3].Scored)
+	assert.False(t, scores[3].Anomalous)
+	assert.Nil(t, scores[4].ZScore, "no variation in the window, so no z-score")
+	assert.True(t, scores[4].Anomalous)
+}
@@ -2,8 +2,12 @@
 //
 // This package handles client authentication through multiple methods:
 //   - Global API key flags (--api-key and --account-id)
+//   - The AHASEND_API_KEY and AHASEND_ACCOUNT_ID environment variables
 //   - Profile-based authentication from configuration files
+//   - API keys kept in the OS keychain or an encrypted credentials file
+//     (ProfileAPIKey)
 //   - Profile switching and validation
+//   - API endpoint resolution (--api-url, AHASEND_API_URL, profile api_url)
 //
 // The main function GetAuthenticatedClient creates authenticated clients
 // for use by CLI commands, handling the authentication precedence and
@@ -11,16 +15,48 @@
 package auth
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
 	"sync"
 
 	"github.com/spf13/cobra"
 
+	"github.com/AhaSend/ahasend-cli/internal/accountstatus"
 	"github.com/AhaSend/ahasend-cli/internal/client"
 	"github.com/AhaSend/ahasend-cli/internal/config"
 	"github.com/AhaSend/ahasend-cli/internal/errors"
 	"github.com/AhaSend/ahasend-cli/internal/logger"
 )
 
+// Environment variables that authenticate without a profile
+const (
+	APIKeyEnvVar    = "AHASEND_API_KEY"
+	AccountIDEnvVar = "AHASEND_ACCOUNT_ID"
+)
+
+// NoAuthAnnotation marks a command that works without credentials, such as
+// help, completion or the auth commands themselves. It applies to the
+// command's subcommands too.
+const NoAuthAnnotation = "ahasend.no-auth"
+
+// NeedsCredentials reports whether cmd needs credentials to run: neither it
+// nor any of its parents carries NoAuthAnnotation
+func NeedsCredentials(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if _, ok := c.Annotations[NoAuthAnnotation]; ok {
+			return false
+		}
+	}
+	return true
+}
+
+// EnvCredentials returns the API key and account ID set in the environment
+func EnvCredentials() (apiKey, accountID string) {
+	return strings.TrimSpace(os.Getenv(APIKeyEnvVar)), strings.TrimSpace(os.Getenv(AccountIDEnvVar))
+}
+
 // ClientResolver resolves an authenticated AhaSend client for a command.
 type ClientResolver func(*cobra.Command) (client.AhaSendClient, error)
 
@@ -29,9 +65,27 @@ var (
 	authenticatedClientResolver   ClientResolver = defaultAuthenticatedClientResolver
 )
 
+// clientContextKey is the context key of a client set with WithClient
+type clientContextKey struct{}
+
+// WithClient returns a context carrying an authenticated client. Commands run
+// with it use that client instead of authenticating again, so a command can
+// run another in-process with the same credentials.
+func WithClient(ctx context.Context, apiClient client.AhaSendClient) context.Context {
+	return context.WithValue(ctx, clientContextKey{}, apiClient)
+}
+
 // GetAuthenticatedClient returns an authenticated AhaSend client
-// It checks for global flags first, then falls back to profiles
+// It uses a client set with WithClient, then checks for global flags,
+// then a --profile, then AHASEND_API_KEY, then falls back to the default
+// profile
 func GetAuthenticatedClient(cmd *cobra.Command) (client.AhaSendClient, error) {
+	if ctx := cmd.Context(); ctx != nil {
+		if apiClient, ok := ctx.Value(clientContextKey{}).(client.AhaSendClient); ok {
+			return apiClient, nil
+		}
+	}
+
 	authenticatedClientResolverMu.RLock()
 	resolver := authenticatedClientResolver
 	authenticatedClientResolverMu.RUnlock()
@@ -53,11 +107,34 @@ func SetAuthenticatedClientResolverForTesting(resolver ClientResolver) func() {
 	}
 }
 
+// HasProvidedClient reports whether cmd gets its client from WithClient
+// rather than from credentials
+func HasProvidedClient(cmd *cobra.Command) bool {
+	if ctx := cmd.Context(); ctx != nil {
+		if _, ok := ctx.Value(clientContextKey{}).(client.AhaSendClient); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultAuthenticatedClientResolver resolves the client from the flags or
+// a profile and warns when its account cannot send
 func defaultAuthenticatedClientResolver(cmd *cobra.Command) (client.AhaSendClient, error) {
+	apiClient, err := resolveClient(cmd)
+	if err != nil {
+		return nil, err
+	}
+	accountstatus.Warn(cmd, apiClient)
+	return apiClient, nil
+}
+
+func resolveClient(cmd *cobra.Command) (client.AhaSendClient, error) {
 	// Check for global API key flags
 	apiKey, _ := cmd.Flags().GetString("api-key")
 	accountID, _ := cmd.Flags().GetString("account-id")
 	profileName, _ := cmd.Flags().GetString("profile")
+	apiURLFlag, _ := cmd.Flags().GetString("api-url")
 
 	if apiKey != "" {
 		if accountID == "" {
@@ -67,7 +144,19 @@ func defaultAuthenticatedClientResolver(cmd *cobra.Command) (client.AhaSendClien
 			"method":     "api-key",
 			"account_id": accountID,
 		})
-		return client.NewClient(apiKey, accountID)
+		return newClient(apiKey, accountID, client.ResolveAPIURL(apiURLFlag, ""))
+	}
+
+	// The environment authenticates unless a profile was asked for
+	if envKey, envAccount := EnvCredentials(); envKey != "" && profileName == "" {
+		if envAccount == "" {
+			return nil, errors.NewValidationError(AccountIDEnvVar+" is required when "+APIKeyEnvVar+" is set", nil)
+		}
+		logger.ConfigOperation("global_auth", "", map[string]interface{}{
+			"method":     "environment",
+			"account_id": envAccount,
+		})
+		return newClient(envKey, envAccount, client.ResolveAPIURL(apiURLFlag, ""))
 	}
 
 	// Fall back to profile-based authentication
@@ -82,6 +171,9 @@ func defaultAuthenticatedClientResolver(cmd *cobra.Command) (client.AhaSendClien
 
 	// Use specified profile or default
 	var profile *config.Profile
+	if len(configMgr.GetConfig().Profiles) == 0 {
+		return nil, NotAuthenticatedError(profileName)
+	}
 	if profileName != "" {
 		profiles := configMgr.GetConfig().Profiles
 		if p, exists := profiles[profileName]; exists {
@@ -98,12 +190,48 @@ func defaultAuthenticatedClientResolver(cmd *cobra.Command) (client.AhaSendClien
 		if err != nil {
 			return nil, errors.NewAuthError("no default profile found. Run 'ahasend auth login' to authenticate", err)
 		}
+		profileName = configMgr.GetConfig().DefaultProfile
 		logger.ConfigOperation("profile_auth", profile.Name, map[string]interface{}{
 			"method": "default_profile",
 		})
 	}
 
-	return client.NewClient(profile.APIKey, profile.AccountID, profile.APIURL)
+	// The profile's key was deleted with apikeys delete; say so instead of
+	// letting the API answer 401
+	if !profile.KeyDeletedAt.IsZero() {
+		return nil, errors.NewAuthError(fmt.Sprintf(
+			"the API key of profile '%s' was deleted on %s. Run 'ahasend auth login --profile %s' with a new key",
+			profileName, profile.KeyDeletedAt.Local().Format("2006-01-02 15:04"), profileName), nil)
+	}
+
+	profileKey, err := ProfileAPIKey(*profile)
+	if err != nil {
+		return nil, err
+	}
+	return newClient(profileKey, profile.AccountID, client.ResolveAPIURL(apiURLFlag, profile.APIURL))
+}
+
+// NotAuthenticatedError is the error of a command run before any profile
+// was set up: it names the login command for profile, if any, and the
+// environment variables that work without a profile
+func NotAuthenticatedError(profile string) error {
+	loginCommand := "ahasend auth login"
+	if profile != "" {
+		loginCommand += " --profile " + profile
+	}
+	return errors.NewAuthError(fmt.Sprintf(
+		"no AhaSend credentials found. Run '%s' to set up a profile, or set %s and %s to use an API key without one",
+		loginCommand, APIKeyEnvVar, AccountIDEnvVar), nil)
+}
+
+// newClient creates a client for the resolved endpoint, surfacing a bad URL
+// as a configuration error
+func newClient(apiKey, accountID, apiURL string) (client.AhaSendClient, error) {
+	if err := client.ValidateAPIURL(apiURL); err != nil {
+		return nil, errors.NewConfigError("invalid API URL "+apiURL, err)
+	}
+	logger.Get().WithField("api_url", apiURL).Debug("Using API endpoint")
+	return client.NewClient(apiKey, accountID, apiURL)
 }
 
 // RequireAuth validates that authentication is available
@@ -1,13 +1,17 @@
 package auth
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/AhaSend/ahasend-go/api"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/config"
 	clierrors "github.com/AhaSend/ahasend-cli/internal/errors"
 	"github.com/AhaSend/ahasend-cli/internal/mocks"
 )
@@ -30,6 +34,23 @@ func TestGetAuthenticatedClientUsesInjectedResolver(t *testing.T) {
 	assert.Same(t, cmd, resolvedCommand)
 }
 
+func TestGetAuthenticatedClientUsesContextClient(t *testing.T) {
+	cmd := newAuthTestCommand()
+	mockClient := &mocks.MockClient{}
+	cmd.SetContext(WithClient(context.Background(), mockClient))
+
+	restore := SetAuthenticatedClientResolverForTesting(func(cmd *cobra.Command) (client.AhaSendClient, error) {
+		t.Fatal("resolver called although the context carries a client")
+		return nil, nil
+	})
+	t.Cleanup(restore)
+
+	got, err := GetAuthenticatedClient(cmd)
+
+	require.NoError(t, err)
+	assert.Same(t, mockClient, got)
+}
+
 func TestSetAuthenticatedClientResolverForTestingRestoreReturnsDefaultBehavior(t *testing.T) {
 	cmd := newAuthTestCommand()
 	require.NoError(t, cmd.Flags().Set("api-key", "test-api-key"))
@@ -61,5 +82,164 @@ func newAuthTestCommand() *cobra.Command {
 	cmd.Flags().String("api-key", "", "")
 	cmd.Flags().String("account-id", "", "")
 	cmd.Flags().String("profile", "", "")
+	cmd.Flags().String("api-url", "", "")
 	return cmd
 }
+
+func TestDefaultResolverAPIURLPrecedence(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	tests := []struct {
+		name     string
+		flag     string
+		env      string
+		expected string
+	}{
+		{name: "flag over env", flag: "https://flag.example", env: "https://env.example", expected: "https://flag.example"},
+		{name: "env over default", env: "https://env.example", expected: "https://env.example"},
+		{name: "default", expected: client.DefaultAPIURL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(client.APIURLEnvVar, tt.env)
+			cmd := newAuthTestCommand()
+			require.NoError(t, cmd.Flags().Set("api-key", "test-api-key"))
+			require.NoError(t, cmd.Flags().Set("account-id", "11111111-1111-1111-1111-111111111111"))
+			require.NoError(t, cmd.Flags().Set("api-url", tt.flag))
+
+			got, err := GetAuthenticatedClient(cmd)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got.GetAPIURL())
+		})
+	}
+}
+
+func TestDefaultResolverProfileAPIURL(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(client.APIURLEnvVar, "")
+
+	mgr, err := config.NewManager()
+	require.NoError(t, err)
+	require.NoError(t, mgr.Load())
+	require.NoError(t, mgr.SetProfile("staging", config.Profile{
+		APIKey:    "test-api-key",
+		AccountID: "11111111-1111-1111-1111-111111111111",
+		APIURL:    "https://profile.example",
+	}))
+
+	cmd := newAuthTestCommand()
+	require.NoError(t, cmd.Flags().Set("profile", "staging"))
+
+	got, err := GetAuthenticatedClient(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, "https://profile.example", got.GetAPIURL())
+
+	t.Setenv(client.APIURLEnvVar, "https://env.example")
+	got, err = GetAuthenticatedClient(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, "https://env.example", got.GetAPIURL())
+
+	require.NoError(t, cmd.Flags().Set("api-url", "https://flag.example"))
+	got, err = GetAuthenticatedClient(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, "https://flag.example", got.GetAPIURL())
+}
+
+func TestDefaultResolverRejectsInvalidAPIURL(t *testing.T) {
+	cmd := newAuthTestCommand()
+	require.NoError(t, cmd.Flags().Set("api-key", "test-api-key"))
+	require.NoError(t, cmd.Flags().Set("account-id", "11111111-1111-1111-1111-111111111111"))
+	require.NoError(t, cmd.Flags().Set("api-url", "ftp://example.com"))
+
+	_, err := GetAuthenticatedClient(cmd)
+	require.Error(t, err)
+
+	var cliErr *clierrors.CLIError
+	require.ErrorAs(t, err, &cliErr)
+	assert.Equal(t, clierrors.ErrCodeConfig, cliErr.Code)
+}
+
+func TestDefaultResolverRejectsDeletedProfileKey(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	mgr, err := config.NewManager()
+	require.NoError(t, err)
+	require.NoError(t, mgr.Load())
+	require.NoError(t, mgr.SetProfile("default", config.Profile{
+		APIKey:       "test-api-key",
+		AccountID:    "11111111-1111-1111-1111-111111111111",
+		KeyDeletedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	}))
+
+	_, err = GetAuthenticatedClient(newAuthTestCommand())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "the API key of profile 'default' was deleted")
+	assert.Contains(t, err.Error(), "ahasend auth login --profile default")
+
+	var cliErr *clierrors.CLIError
+	require.ErrorAs(t, err, &cliErr)
+	assert.Equal(t, clierrors.ErrCodeAuth, cliErr.Code)
+}
+
+func TestDefaultResolverEnvironmentCredentials(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(APIKeyEnvVar, "env-api-key")
+	t.Setenv(AccountIDEnvVar, "22222222-2222-2222-2222-222222222222")
+
+	got, err := GetAuthenticatedClient(newAuthTestCommand())
+	require.NoError(t, err)
+	assert.Equal(t, "22222222-2222-2222-2222-222222222222", got.GetAccountID())
+
+	// The flags win over the environment
+	cmd := newAuthTestCommand()
+	require.NoError(t, cmd.Flags().Set("api-key", "flag-api-key"))
+	require.NoError(t, cmd.Flags().Set("account-id", "11111111-1111-1111-1111-111111111111"))
+	got, err = GetAuthenticatedClient(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, "11111111-1111-1111-1111-111111111111", got.GetAccountID())
+
+	// So does an explicitly chosen profile
+	cmd = newAuthTestCommand()
+	require.NoError(t, cmd.Flags().Set("profile", "missing"))
+	_, err = GetAuthenticatedClient(cmd)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ahasend auth login --profile missing")
+
+	t.Setenv(AccountIDEnvVar, "")
+	_, err = GetAuthenticatedClient(newAuthTestCommand())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), AccountIDEnvVar+" is required")
+}
+
+func TestNeedsCredentials(t *testing.T) {
+	root := &cobra.Command{Use: "ahasend"}
+	local := &cobra.Command{Use: "config", Annotations: map[string]string{NoAuthAnnotation: "true"}}
+	child := &cobra.Command{Use: "get"}
+	remote := &cobra.Command{Use: "domains"}
+	local.AddCommand(child)
+	root.AddCommand(local, remote)
+
+	assert.False(t, NeedsCredentials(local))
+	assert.False(t, NeedsCredentials(child))
+	assert.True(t, NeedsCredentials(remote))
+}
+
+func TestDefaultResolverWithoutProfiles(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(APIKeyEnvVar, "")
+
+	_, err := GetAuthenticatedClient(newAuthTestCommand())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Run 'ahasend auth login' to set up a profile")
+	assert.Contains(t, err.Error(), "set AHASEND_API_KEY and AHASEND_ACCOUNT_ID")
+
+	var cliErr *clierrors.CLIError
+	require.ErrorAs(t, err, &cliErr)
+	assert.Equal(t, clierrors.ErrCodeAuth, cliErr.Code)
+}
+
+func TestTranslate_UnauthorizedNamesAPIKeyEnvVar(t *testing.T) {
+	err := clierrors.Translate(&api.APIError{StatusCode: 401})
+	assert.Contains(t, err.Error(), APIKeyEnvVar)
+}
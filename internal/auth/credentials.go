@@ -0,0 +1,268 @@
+package auth
+
+import (
+	stderrors "errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/term"
+
+	"github.com/AhaSend/ahasend-cli/internal/config"
+	"github.com/AhaSend/ahasend-cli/internal/credentials"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+)
+
+// PassphraseEnv holds the passphrase of the encrypted credentials file, for
+// runs without a terminal to prompt in
+const PassphraseEnv = "AHASEND_CONFIG_PASSPHRASE"
+
+// These are replaced in tests to exercise the passphrase prompt
+var (
+	stdinIsTerminal = func() bool { return printer.IsTerminal(os.Stdin) }
+
+	readPassphrase = func(prompt string) (string, error) {
+		fmt.Fprint(os.Stderr, prompt)
+		passphrase, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Fprintln(os.Stderr)
+		return string(passphrase), err
+	}
+)
+
+// promptedPassphrase is the passphrase entered at the prompt, so a command
+// that reads several keys asks only once
+var (
+	passphraseMu       sync.Mutex
+	promptedPassphrase string
+)
+
+// ProfileAPIKey returns the API key of a profile wherever it is stored: in
+// the configuration file, the OS keychain or the encrypted credentials file.
+// Commands read profile keys only through it.
+func ProfileAPIKey(profile config.Profile) (string, error) {
+	if profile.APIKeyRef == "" {
+		return profile.APIKey, nil
+	}
+	ref, err := credentials.ParseRef(profile.APIKeyRef)
+	if err != nil {
+		return "", errors.NewConfigError("invalid API key reference in the configuration file", err)
+	}
+
+	switch ref.Store {
+	case credentials.StoreKeyring:
+		secret, err := credentials.KeyringGet(ref.Name)
+		if stderrors.Is(err, credentials.ErrNotFound) {
+			return "", errors.NewAuthError(fmt.Sprintf(
+				"the API key of profile '%s' is missing from the OS keychain. Run 'ahasend auth login --profile %s' to store it again",
+				ref.Name, ref.Name), nil)
+		}
+		if err != nil {
+			return "", errors.NewConfigError("failed to read the API key from the OS keychain", err)
+		}
+		return secret, nil
+
+	default:
+		secrets, _, err := openEncrypted(false)
+		if err != nil {
+			return "", err
+		}
+		secret, ok := secrets[ref.Name]
+		if !ok {
+			return "", errors.NewAuthError(fmt.Sprintf(
+				"the API key of profile '%s' is missing from the encrypted credentials file. Run 'ahasend auth login --profile %s' to store it again",
+				ref.Name, ref.Name), nil)
+		}
+		return secret, nil
+	}
+}
+
+// ProfileKeyStore returns where the API key of a profile is stored
+func ProfileKeyStore(profile config.Profile) credentials.Store {
+	if ref, err := credentials.ParseRef(profile.APIKeyRef); err == nil {
+		return ref.Store
+	}
+	return credentials.StorePlaintext
+}
+
+// StoreProfileAPIKey puts apiKey in store under the profile name and points
+// the profile at it. When the OS keychain is unavailable the key goes to the
+// encrypted credentials file instead; the store used is returned. The key is
+// not removed from where the profile kept it before, and the configuration
+// is not saved: see SetProfileAPIKey.
+func StoreProfileAPIKey(name string, profile *config.Profile, apiKey string, store credentials.Store) (credentials.Store, error) {
+	switch store {
+	case credentials.StorePlaintext:
+		profile.APIKey = apiKey
+		profile.APIKeyRef = ""
+		return store, nil
+
+	case credentials.StoreKeyring:
+		err := credentials.KeyringSet(name, apiKey)
+		if err == nil {
+			profile.APIKey = ""
+			profile.APIKeyRef = credentials.Ref{Store: store, Name: name}.String()
+			return store, nil
+		}
+		logger.Get().WithError(err).Warn("The OS keychain is unavailable, storing the API key in the encrypted credentials file instead")
+		store = credentials.StoreEncrypted
+	}
+
+	secrets, passphrase, err := openEncrypted(true)
+	if err != nil {
+		return "", err
+	}
+	secrets[name] = apiKey
+	if err := saveEncrypted(passphrase, secrets); err != nil {
+		return "", err
+	}
+	profile.APIKey = ""
+	profile.APIKeyRef = credentials.Ref{Store: store, Name: name}.String()
+	return store, nil
+}
+
+// DeleteProfileAPIKey removes the API key of a profile from the OS keychain
+// or the encrypted credentials file. A key kept in the configuration file
+// goes with the profile.
+func DeleteProfileAPIKey(profile config.Profile) error {
+	ref, err := credentials.ParseRef(profile.APIKeyRef)
+	if err != nil {
+		return nil
+	}
+	if ref.Store == credentials.StoreKeyring {
+		if err := credentials.KeyringDelete(ref.Name); err != nil {
+			return errors.NewConfigError("failed to remove the API key from the OS keychain", err)
+		}
+		return nil
+	}
+
+	secrets, passphrase, err := openEncrypted(false)
+	if err != nil {
+		return err
+	}
+	if _, ok := secrets[ref.Name]; !ok {
+		return nil
+	}
+	delete(secrets, ref.Name)
+	return saveEncrypted(passphrase, secrets)
+}
+
+// SetProfileAPIKey stores apiKey in store, saves the profile, and only then
+// removes the key from where the profile kept it before. If the profile
+// cannot be saved the new copy is removed again, so the configuration never
+// points at a key that is not there. The store used is returned; see
+// StoreProfileAPIKey.
+func SetProfileAPIKey(configMgr *config.Manager, name string, profile config.Profile, apiKey string, store credentials.Store) (credentials.Store, error) {
+	previous, existed := configMgr.GetConfig().Profiles[name]
+
+	used, err := StoreProfileAPIKey(name, &profile, apiKey, store)
+	if err != nil {
+		return "", err
+	}
+	if err := configMgr.SetProfile(name, profile); err != nil {
+		if !existed || previous.APIKeyRef != profile.APIKeyRef {
+			if cleanupErr := DeleteProfileAPIKey(profile); cleanupErr != nil {
+				logger.Get().WithError(cleanupErr).Warn("Failed to remove the API key stored for an unsaved profile")
+			}
+		}
+		return "", errors.NewConfigError("failed to save profile", err)
+	}
+
+	if existed && previous.APIKeyRef != profile.APIKeyRef {
+		if err := DeleteProfileAPIKey(previous); err != nil {
+			logger.Get().WithError(err).Warn("Failed to remove the API key from its previous store")
+		}
+	}
+	return used, nil
+}
+
+// openEncrypted opens the encrypted credentials file with the passphrase
+// from AHASEND_CONFIG_PASSPHRASE or the prompt. When creating is set and the
+// file does not exist yet, the passphrase is asked for twice.
+func openEncrypted(creating bool) (map[string]string, string, error) {
+	path, err := credentials.EncryptedPath()
+	if err != nil {
+		return nil, "", errors.NewConfigError("failed to locate the encrypted credentials file", err)
+	}
+	if !creating && !credentials.EncryptedExists(path) {
+		return map[string]string{}, "", nil
+	}
+
+	passphrase, err := credentialsPassphrase(path, creating && !credentials.EncryptedExists(path))
+	if err != nil {
+		return nil, "", err
+	}
+	secrets, err := credentials.LoadEncrypted(path, passphrase)
+	if stderrors.Is(err, credentials.ErrWrongPassphrase) {
+		forgetPassphrase()
+		return nil, "", errors.NewAuthError(fmt.Sprintf("wrong passphrase for %s (set %s or enter it at the prompt)", path, PassphraseEnv), nil)
+	}
+	if err != nil {
+		return nil, "", errors.NewConfigError("failed to open the encrypted credentials file", err)
+	}
+	return secrets, passphrase, nil
+}
+
+// saveEncrypted writes the encrypted credentials file
+func saveEncrypted(passphrase string, secrets map[string]string) error {
+	path, err := credentials.EncryptedPath()
+	if err != nil {
+		return errors.NewConfigError("failed to locate the encrypted credentials file", err)
+	}
+	if err := credentials.SaveEncrypted(path, passphrase, secrets); err != nil {
+		return errors.NewConfigError("failed to write the encrypted credentials file", err)
+	}
+	return nil
+}
+
+// credentialsPassphrase returns the passphrase of the credentials file at
+// path: AHASEND_CONFIG_PASSPHRASE, or the passphrase entered at a prompt.
+// Without a terminal the environment variable is required.
+func credentialsPassphrase(path string, confirm bool) (string, error) {
+	if passphrase := os.Getenv(PassphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+
+	passphraseMu.Lock()
+	defer passphraseMu.Unlock()
+	if promptedPassphrase != "" {
+		return promptedPassphrase, nil
+	}
+	if !stdinIsTerminal() {
+		return "", errors.NewConfigError(fmt.Sprintf(
+			"%s needs a passphrase; set %s or run the command in a terminal", path, PassphraseEnv), nil)
+	}
+
+	prompt := "Passphrase for " + path + ": "
+	if confirm {
+		prompt = "New passphrase for " + path + ": "
+	}
+	passphrase, err := readPassphrase(prompt)
+	if err != nil {
+		return "", errors.NewValidationError("failed to read the passphrase", err)
+	}
+	if strings.TrimSpace(passphrase) == "" {
+		return "", errors.NewValidationError("the passphrase must not be empty", nil)
+	}
+	if confirm {
+		repeated, err := readPassphrase("Repeat the passphrase: ")
+		if err != nil {
+			return "", errors.NewValidationError("failed to read the passphrase", err)
+		}
+		if repeated != passphrase {
+			return "", errors.NewValidationError("the passphrases do not match", nil)
+		}
+	}
+
+	promptedPassphrase = passphrase
+	return passphrase, nil
+}
+
+// forgetPassphrase drops a prompted passphrase that turned out to be wrong
+func forgetPassphrase() {
+	passphraseMu.Lock()
+	promptedPassphrase = ""
+	passphraseMu.Unlock()
+}
@@ -0,0 +1,159 @@
+package auth
+
+import (
+	stderrors "errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/config"
+	"github.com/AhaSend/ahasend-cli/internal/credentials"
+)
+
+// useCredentialStores isolates the keychain, the credentials file and the
+// passphrase prompt. The prompt answers with the given passphrases in turn.
+func useCredentialStores(t *testing.T, terminal bool, answers ...string) *config.Manager {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(PassphraseEnv, "")
+	credentials.MockKeyring(nil)
+	forgetPassphrase()
+	t.Cleanup(forgetPassphrase)
+
+	prevTerminal, prevRead := stdinIsTerminal, readPassphrase
+	stdinIsTerminal = func() bool { return terminal }
+	readPassphrase = func(prompt string) (string, error) {
+		if len(answers) == 0 {
+			return "", stderrors.New("unexpected prompt: " + prompt)
+		}
+		answer := answers[0]
+		answers = answers[1:]
+		return answer, nil
+	}
+	t.Cleanup(func() { stdinIsTerminal, readPassphrase = prevTerminal, prevRead })
+
+	mgr, err := config.NewManager()
+	require.NoError(t, err)
+	require.NoError(t, mgr.Load())
+	return mgr
+}
+
+func TestProfileAPIKey_Plaintext(t *testing.T) {
+	key, err := ProfileAPIKey(config.Profile{APIKey: "plain-key"})
+	require.NoError(t, err)
+	assert.Equal(t, "plain-key", key)
+	assert.Equal(t, credentials.StorePlaintext, ProfileKeyStore(config.Profile{APIKey: "plain-key"}))
+}
+
+func TestSetProfileAPIKey_Keyring(t *testing.T) {
+	mgr := useCredentialStores(t, false)
+	require.NoError(t, mgr.SetProfile("default", config.Profile{APIKey: "secret-key", AccountID: "acct"}))
+
+	used, err := SetProfileAPIKey(mgr, "default", mgr.GetConfig().Profiles["default"], "secret-key", credentials.StoreKeyring)
+	require.NoError(t, err)
+	assert.Equal(t, credentials.StoreKeyring, used)
+
+	profile := mgr.GetConfig().Profiles["default"]
+	assert.Empty(t, profile.APIKey)
+	assert.Equal(t, "keyring:default", profile.APIKeyRef)
+
+	data, err := os.ReadFile(filepath.Join(os.Getenv("HOME"), ".ahasend", "config.yaml"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "secret-key")
+
+	key, err := ProfileAPIKey(profile)
+	require.NoError(t, err)
+	assert.Equal(t, "secret-key", key)
+
+	// Rolling back puts the key in the file and clears the keychain
+	_, err = SetProfileAPIKey(mgr, "default", profile, key, credentials.StorePlaintext)
+	require.NoError(t, err)
+	assert.Equal(t, "secret-key", mgr.GetConfig().Profiles["default"].APIKey)
+	_, err = credentials.KeyringGet("default")
+	assert.ErrorIs(t, err, credentials.ErrNotFound)
+}
+
+func TestSetProfileAPIKey_KeyringUnavailableFallsBack(t *testing.T) {
+	mgr := useCredentialStores(t, false)
+	t.Setenv(PassphraseEnv, "correct horse")
+	credentials.MockKeyring(stderrors.New("no secret service"))
+
+	used, err := SetProfileAPIKey(mgr, "default", config.Profile{AccountID: "acct"}, "secret-key", credentials.StoreKeyring)
+	require.NoError(t, err)
+	assert.Equal(t, credentials.StoreEncrypted, used)
+
+	profile := mgr.GetConfig().Profiles["default"]
+	assert.Equal(t, "encrypted:default", profile.APIKeyRef)
+	key, err := ProfileAPIKey(profile)
+	require.NoError(t, err)
+	assert.Equal(t, "secret-key", key)
+}
+
+func TestProfileAPIKey_EncryptedPrompt(t *testing.T) {
+	mgr := useCredentialStores(t, true, "correct horse", "correct horse")
+
+	_, err := SetProfileAPIKey(mgr, "default", config.Profile{AccountID: "acct"}, "secret-key", credentials.StoreEncrypted)
+	require.NoError(t, err)
+
+	// The passphrase entered when creating the file is not asked for again
+	key, err := ProfileAPIKey(mgr.GetConfig().Profiles["default"])
+	require.NoError(t, err)
+	assert.Equal(t, "secret-key", key)
+
+	// A wrong passphrase is refused and forgotten
+	forgetPassphrase()
+	readPassphrase = func(string) (string, error) { return "wrong", nil }
+	_, err = ProfileAPIKey(mgr.GetConfig().Profiles["default"])
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "wrong passphrase")
+	assert.Empty(t, promptedPassphrase)
+}
+
+func TestProfileAPIKey_EncryptedNeedsPassphrase(t *testing.T) {
+	mgr := useCredentialStores(t, false)
+	t.Setenv(PassphraseEnv, "correct horse")
+	_, err := SetProfileAPIKey(mgr, "default", config.Profile{AccountID: "acct"}, "secret-key", credentials.StoreEncrypted)
+	require.NoError(t, err)
+
+	t.Setenv(PassphraseEnv, "")
+	_, err = ProfileAPIKey(mgr.GetConfig().Profiles["default"])
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), PassphraseEnv)
+}
+
+func TestCredentialsPassphrase_ConfirmMismatch(t *testing.T) {
+	mgr := useCredentialStores(t, true, "correct horse", "battery staple")
+
+	_, err := SetProfileAPIKey(mgr, "default", config.Profile{AccountID: "acct"}, "secret-key", credentials.StoreEncrypted)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "do not match")
+	_, saved := mgr.GetConfig().Profiles["default"]
+	assert.False(t, saved)
+}
+
+func TestProfileAPIKey_MissingFromKeyring(t *testing.T) {
+	useCredentialStores(t, false)
+
+	_, err := ProfileAPIKey(config.Profile{APIKeyRef: "keyring:production"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing from the OS keychain")
+	assert.Contains(t, err.Error(), "ahasend auth login --profile production")
+}
+
+func TestDefaultResolverReadsKeyringProfile(t *testing.T) {
+	mgr := useCredentialStores(t, false)
+	_, err := SetProfileAPIKey(mgr, "default", config.Profile{AccountID: "11111111-1111-1111-1111-111111111111"}, "secret-key", credentials.StoreKeyring)
+	require.NoError(t, err)
+
+	got, err := GetAuthenticatedClient(newAuthTestCommand())
+	require.NoError(t, err)
+	assert.NotNil(t, got)
+
+	require.NoError(t, credentials.KeyringDelete("default"))
+	_, err = GetAuthenticatedClient(newAuthTestCommand())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing from the OS keychain")
+}
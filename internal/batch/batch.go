@@ -5,11 +5,14 @@
 // progress reporting. Key features include:
 //
 //   - Configurable concurrency limits (up to 10 concurrent sends)
-//   - Automatic retry logic with exponential backoff
+//   - Retries by error category: permanent errors fail fast, rate limited
+//     requests honor Retry-After and transient errors back off exponentially
 //   - Progress reporting with TTY detection
 //   - Failed recipient tracking and recovery files
 //   - Performance metrics and statistics
 //   - Rate limiting integration
+//   - Graceful cancellation that drains in-flight requests
+//   - State files that let an interrupted send resume without duplicates
 //
 // The BatchProcessor is the main component that coordinates sending operations,
 // manages worker pools, and collects results for comprehensive reporting.
@@ -22,7 +25,6 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/AhaSend/ahasend-go/models/common"
@@ -41,6 +43,39 @@ type SendJob struct {
 	BatchIndex     int                // Batch number (0, 1, 2, ...)
 	Recipients     []common.Recipient // Multiple recipients in this batch (max 100)
 	RecipientCount int                // Number of recipients in this batch
+
+	// CC and BCC copies. A job with copies is sent as one conversation
+	// message, with Recipients as its To addresses.
+	CC  []common.SenderAddress
+	BCC []common.SenderAddress
+}
+
+// ConversationRequest returns the conversation message request of a job
+// with CC or BCC copies. Conversation messages take no substitutions.
+func (j *SendJob) ConversationRequest() requests.CreateConversationMessageRequest {
+	to := make([]common.SenderAddress, len(j.Recipients))
+	for i, recipient := range j.Recipients {
+		to[i] = common.SenderAddress{Email: recipient.Email, Name: recipient.Name}
+	}
+	return requests.CreateConversationMessageRequest{
+		From:          j.Request.From,
+		To:            to,
+		CC:            j.CC,
+		BCC:           j.BCC,
+		Subject:       j.Request.Subject,
+		ReplyTo:       j.Request.ReplyTo,
+		TextContent:   j.Request.TextContent,
+		HtmlContent:   j.Request.HtmlContent,
+		AmpContent:    j.Request.AmpContent,
+		Attachments:   j.Request.Attachments,
+		Headers:       j.Request.Headers,
+		Tags:          j.Request.Tags,
+		Sandbox:       j.Request.Sandbox,
+		SandboxResult: j.Request.SandboxResult,
+		Tracking:      j.Request.Tracking,
+		Retention:     j.Request.Retention,
+		Schedule:      j.Request.Schedule,
+	}
 }
 
 // SendResult represents the result of a send operation
@@ -50,6 +85,9 @@ type SendResult struct {
 	Error     error
 	Success   bool
 	Retryable bool
+	Class     ErrorClass            // Classification of Error
+	Retries   map[ErrorCategory]int // Retried attempts by the category of the error that caused them
+	NotSent   bool                  // Job was never dispatched because the batch was cancelled
 	Duration  time.Duration
 }
 
@@ -60,6 +98,7 @@ type FailedRecipient struct {
 	SubstitutionData map[string]interface{} `json:"substitution_data,omitempty" csv:"-"`
 	Error            string                 `json:"_error" csv:"error"`
 	ErrorCode        int                    `json:"_error_code" csv:"error_code"`
+	ErrorCategory    ErrorCategory          `json:"_error_category,omitempty" csv:"error_category"`
 	Retryable        bool                   `json:"_retryable" csv:"retryable"`
 
 	// CSV fields for substitution data (dynamic)
@@ -71,9 +110,21 @@ type BatchProcessor struct {
 	client           client.AhaSendClient
 	maxConcurrency   int
 	maxRetries       int
+	drainTimeout     time.Duration
 	progressReporter *progress.Reporter
+	state            *State
+	stateSaveFailed  bool // the failure to save the state was reported
 }
 
+// DefaultDrainTimeout bounds how long in-flight requests may run after cancellation
+const DefaultDrainTimeout = 30 * time.Second
+
+// Failure reasons recorded for recipients that were not delivered because of cancellation
+const (
+	notSentReason   = "not sent: batch send was interrupted"
+	abandonedReason = "unknown: request still in flight when drain timeout expired"
+)
+
 // BatchResult contains the overall batch operation results
 type BatchResult struct {
 	TotalJobs            int                                // Number of API calls made
@@ -86,6 +137,17 @@ type BatchResult struct {
 	FailedResponses      []interface{}                      // Raw API error responses for failed batch calls
 	Stats                progress.Stats
 	FailedRecipientsFile string
+	Interrupted          bool // Processing context was cancelled
+	NotSentRecipients    int  // Recipients in jobs that were never dispatched
+	AbandonedRecipients  int  // Recipients whose outcome is unknown after the drain timeout
+	SkippedJobs          int  // Jobs the state file records as already sent
+	SkippedRecipients    int  // Recipients of the skipped jobs
+
+	// ErrorCounts counts the failed API calls by error category, and
+	// RetryCounts the retried attempts, including those of calls that
+	// succeeded in the end
+	ErrorCounts map[ErrorCategory]int
+	RetryCounts map[ErrorCategory]int
 }
 
 // NewBatchProcessor creates a new batch processor
@@ -94,14 +156,44 @@ func NewBatchProcessor(client client.AhaSendClient, maxConcurrency, maxRetries i
 		client:           client,
 		maxConcurrency:   maxConcurrency,
 		maxRetries:       maxRetries,
+		drainTimeout:     DefaultDrainTimeout,
 		progressReporter: progressReporter,
 	}
 }
 
-// ProcessJobs processes a batch of send jobs with controlled concurrency
+// SetDrainTimeout sets how long in-flight requests may run once the context is
+// cancelled. A zero or negative value waits for them indefinitely.
+func (bp *BatchProcessor) SetDrainTimeout(timeout time.Duration) {
+	bp.drainTimeout = timeout
+}
+
+// SetState makes the processor skip the jobs state records as sent and
+// record the outcome of every other job in it, saving it after each
+func (bp *BatchProcessor) SetState(state *State) {
+	bp.state = state
+}
+
+// ProcessJobs processes a batch of send jobs with controlled concurrency.
+//
+// Cancelling ctx stops dispatching new jobs; requests already in flight are
+// allowed to finish, bounded by the drain timeout. Jobs that were never
+// dispatched, or whose outcome is unknown when the drain timeout expires, are
+// recorded as failed recipients so they can be retried.
 func (bp *BatchProcessor) ProcessJobs(ctx context.Context, jobs []*SendJob) (*BatchResult, error) {
+	var skipped []*SendJob
+	if bp.state != nil {
+		jobs, skipped = bp.state.Pending(jobs)
+	}
+	skippedRecipients := 0
+	for _, job := range skipped {
+		skippedRecipients += job.RecipientCount
+	}
 	if len(jobs) == 0 {
-		return &BatchResult{}, nil
+		return &BatchResult{
+			SkippedJobs:       len(skipped),
+			SkippedRecipients: skippedRecipients,
+			Stats:             progress.Stats{Skipped: skippedRecipients},
+		}, nil
 	}
 
 	// Calculate total recipients
@@ -115,6 +207,8 @@ func (bp *BatchProcessor) ProcessJobs(ctx context.Context, jobs []*SendJob) (*Ba
 		"total_recipients": totalRecipients,
 		"max_concurrency":  bp.maxConcurrency,
 		"max_retries":      bp.maxRetries,
+		"drain_timeout":    bp.drainTimeout.String(),
+		"skipped_jobs":     len(skipped),
 	}).Debug("Starting batch processing")
 
 	// Start progress reporting
@@ -122,35 +216,34 @@ func (bp *BatchProcessor) ProcessJobs(ctx context.Context, jobs []*SendJob) (*Ba
 		bp.progressReporter.Start()
 	}
 
-	// Create channels for job processing
+	// Create channels for job processing. The result channel holds one result
+	// per job so workers and the dispatcher never block on it.
 	jobChan := make(chan *SendJob, bp.maxConcurrency)
 	resultChan := make(chan *SendResult, len(jobs))
 
 	// Start worker goroutines
-	var wg sync.WaitGroup
 	for i := 0; i < bp.maxConcurrency; i++ {
-		wg.Add(1)
-		go bp.worker(ctx, &wg, jobChan, resultChan)
+		go bp.worker(ctx, jobChan, resultChan)
 	}
 
-	// Send jobs to workers
+	// Send jobs to workers; once cancelled, report the rest as not sent
 	go func() {
 		defer close(jobChan)
-		for _, job := range jobs {
-			select {
-			case jobChan <- job:
-			case <-ctx.Done():
-				return
+		for i, job := range jobs {
+			if ctx.Err() == nil {
+				select {
+				case jobChan <- job:
+					continue
+				case <-ctx.Done():
+				}
 			}
+			for _, skipped := range jobs[i:] {
+				resultChan <- &SendResult{Job: skipped, NotSent: true}
+			}
+			return
 		}
 	}()
 
-	// Wait for workers to complete and close result channel
-	go func() {
-		wg.Wait()
-		close(resultChan)
-	}()
-
 	// Collect results
 	var failedRecipients []FailedRecipient
 	var successfulResponses []*responses.CreateMessageResponse
@@ -158,10 +251,52 @@ func (bp *BatchProcessor) ProcessJobs(ctx context.Context, jobs []*SendJob) (*Ba
 	successfulJobs := 0
 	failedJobs := 0
 	successfulRecipients := 0
-	failedRecipientCount := 0
+	notSentRecipients := 0
+	abandonedRecipients := 0
+	errorCounts := make(map[ErrorCategory]int)
+	retryCounts := make(map[ErrorCategory]int)
+	completed := make(map[*SendJob]bool, len(jobs))
+
+	cancelled := ctx.Done()
+	var drainDeadline <-chan time.Time
+
+collect:
+	for len(completed) < len(jobs) {
+		var result *SendResult
+		select {
+		case result = <-resultChan:
+		case <-cancelled:
+			cancelled = nil
+			logger.Get().WithField("drain_timeout", bp.drainTimeout.String()).Debug("Batch cancelled, draining in-flight requests")
+			if bp.progressReporter != nil {
+				bp.progressReporter.Draining()
+			}
+			if bp.drainTimeout > 0 {
+				drainDeadline = time.After(bp.drainTimeout)
+			}
+			continue
+		case <-drainDeadline:
+			logger.Get().WithField("pending_jobs", len(jobs)-len(completed)).Debug("Drain timeout expired with requests still in flight")
+			bp.notify(fmt.Sprintf("Stopped waiting after %s with %d batches still in flight", bp.drainTimeout, len(jobs)-len(completed)))
+			break collect
+		}
+		completed[result.Job] = true
+
+		if result.NotSent {
+			for _, recipient := range result.Job.Recipients {
+				failedRecipients = append(failedRecipients, bp.newInterruptedRecipient(recipient, notSentReason, true))
+			}
+			notSentRecipients += result.Job.RecipientCount
+			bp.recordState(result.Job, BatchNotSent, nil)
+			continue
+		}
+
+		for category, retries := range result.Retries {
+			retryCounts[category] += retries
+		}
 
-	for result := range resultChan {
 		if result.Success {
+			bp.recordState(result.Job, BatchSent, nil)
 			successfulJobs++
 			if result.Response != nil {
 				successfulResponses = append(successfulResponses, result.Response)
@@ -171,14 +306,16 @@ func (bp *BatchProcessor) ProcessJobs(ctx context.Context, jobs []*SendJob) (*Ba
 				}
 			}
 		} else {
+			bp.recordState(result.Job, BatchFailed, result.Error)
 			failedJobs++
+			errorCounts[result.Class.Category]++
 			// Store the raw API error response for JSON output
 			failedResponses = append(failedResponses, result.Error)
 			// Create failed recipients for all recipients in the failed batch
 			for _, recipient := range result.Job.Recipients {
 				failedRecipient := bp.createFailedRecipientFromError(recipient, result.Error, result.Retryable)
+				failedRecipient.ErrorCategory = result.Class.Category
 				failedRecipients = append(failedRecipients, failedRecipient)
-				failedRecipientCount++
 			}
 		}
 
@@ -198,11 +335,27 @@ func (bp *BatchProcessor) ProcessJobs(ctx context.Context, jobs []*SendJob) (*Ba
 		}
 	}
 
+	// Requests still running after the drain timeout may or may not have been
+	// accepted by the API, so they are not safe to retry blindly
+	for _, job := range jobs {
+		if completed[job] {
+			continue
+		}
+		for _, recipient := range job.Recipients {
+			failedRecipients = append(failedRecipients, bp.newInterruptedRecipient(recipient, abandonedReason, false))
+		}
+		abandonedRecipients += job.RecipientCount
+		bp.recordState(job, BatchUnknown, nil)
+	}
+
 	// Finish progress reporting and get stats
 	var stats progress.Stats
 	if bp.progressReporter != nil {
 		stats = bp.progressReporter.Finish()
 	}
+	stats.Errors = categoryCounts(errorCounts)
+	stats.Retries = categoryCounts(retryCounts)
+	stats.Skipped = skippedRecipients
 
 	// Generate failed recipients file if there are failures
 	var failedRecipientsFile string
@@ -225,64 +378,115 @@ func (bp *BatchProcessor) ProcessJobs(ctx context.Context, jobs []*SendJob) (*Ba
 		FailedResponses:      failedResponses,
 		Stats:                stats,
 		FailedRecipientsFile: failedRecipientsFile,
+		Interrupted:          ctx.Err() != nil,
+		NotSentRecipients:    notSentRecipients,
+		AbandonedRecipients:  abandonedRecipients,
+		SkippedJobs:          len(skipped),
+		SkippedRecipients:    skippedRecipients,
+		ErrorCounts:          errorCounts,
+		RetryCounts:          retryCounts,
 	}, nil
 }
 
-// worker processes send jobs from the job channel
-func (bp *BatchProcessor) worker(ctx context.Context, wg *sync.WaitGroup, jobChan <-chan *SendJob, resultChan chan<- *SendResult) {
-	defer wg.Done()
+// recordState records the outcome of a job in the state, when there is
+// one, and saves it. A failure to save is reported once and does not stop
+// the send.
+func (bp *BatchProcessor) recordState(job *SendJob, status BatchStatus, err error) {
+	if bp.state == nil {
+		return
+	}
+	bp.state.Record(job, status, err)
+	if saveErr := bp.state.Save(); saveErr != nil {
+		logger.Get().WithError(saveErr).Debug("Failed to save the state file")
+		if !bp.stateSaveFailed {
+			bp.stateSaveFailed = true
+			bp.notify(fmt.Sprintf("Failed to update the state file %s; a resumed send may repeat batches: %v", bp.state.Path(), saveErr))
+		}
+	}
+}
 
-	for {
-		select {
-		case job, ok := <-jobChan:
-			if !ok {
-				return
-			}
-			result := bp.processSingleJob(ctx, job)
+// categoryCounts converts counts by category for progress.Stats, leaving
+// out categories without any
+func categoryCounts(counts map[ErrorCategory]int) map[string]int {
+	if len(counts) == 0 {
+		return nil
+	}
+	converted := make(map[string]int, len(counts))
+	for category, count := range counts {
+		if count > 0 {
+			converted[string(category)] = count
+		}
+	}
+	return converted
+}
 
-			select {
-			case resultChan <- result:
-			case <-ctx.Done():
-				return
-			}
-		case <-ctx.Done():
-			return
+// worker processes send jobs from the job channel. Jobs picked up after
+// cancellation are reported as not sent instead of being started.
+func (bp *BatchProcessor) worker(ctx context.Context, jobChan <-chan *SendJob, resultChan chan<- *SendResult) {
+	for job := range jobChan {
+		if ctx.Err() != nil {
+			resultChan <- &SendResult{Job: job, NotSent: true}
+			continue
 		}
+		resultChan <- bp.processSingleJob(ctx, job)
 	}
 }
 
-// processSingleJob processes a single send job with retry logic
+// notify prints a message for the user, above the progress bar when one is
+// shown. Without a reporter it is logged as a warning.
+func (bp *BatchProcessor) notify(message string) {
+	if bp.progressReporter != nil {
+		bp.progressReporter.Println(message)
+		return
+	}
+	logger.Get().Warn(message)
+}
+
+// processSingleJob processes a single send job, retrying failures by their
+// category: permanent errors are not retried, rate limited requests wait for
+// the server's Retry-After and transient errors back off exponentially with
+// jitter
 func (bp *BatchProcessor) processSingleJob(ctx context.Context, job *SendJob) *SendResult {
 	var lastErr error
+	var lastClass ErrorClass
 	var response *responses.CreateMessageResponse
+	retries := make(map[ErrorCategory]int)
 	startTime := time.Now()
 
-	ctxDone := false
+	attempts := 0
 	for attempt := 0; attempt <= bp.maxRetries; attempt++ {
 		if attempt > 0 {
-			// Wait before retry with exponential backoff
-			delay := time.Duration(attempt) * time.Second
+			delay := retryDelay(attempt, lastClass)
 			logger.Get().WithFields(map[string]interface{}{
 				"batch_index":     job.BatchIndex,
 				"recipient_count": job.RecipientCount,
 				"attempt":         attempt,
+				"category":        lastClass.Category,
 				"delay":           delay.String(),
 			}).Debug("Retrying batch send job")
+			bp.notify(fmt.Sprintf("Batch %d (%d recipients) failed: %s; retrying in %s (attempt %d of %d)",
+				job.BatchIndex+1, job.RecipientCount, extractActualErrorMessage(lastErr), delay, attempt+1, bp.maxRetries+1))
 
 			select {
 			case <-time.After(delay):
 			case <-ctx.Done():
-				lastErr = ctx.Err()
-				ctxDone = true
+				// Keep the previous attempt's error; the retry was never made
+			}
+			if ctx.Err() != nil {
 				break
 			}
-		}
-		if ctxDone {
-			break
+			retries[lastClass.Category]++
 		}
 
 		// Attempt to send
-		resp, err := bp.client.SendMessageWithIdempotencyKey(*job.Request, job.IdempotencyKey)
+		attempts++
+		var resp *responses.CreateMessageResponse
+		var err error
+		if len(job.CC) > 0 || len(job.BCC) > 0 {
+			resp, err = bp.client.SendConversationMessageWithIdempotencyKey(job.ConversationRequest(), job.IdempotencyKey)
+		} else {
+			resp, err = bp.client.SendMessageWithIdempotencyKey(*job.Request, job.IdempotencyKey)
+		}
 		if err == nil {
 			response = resp
 			lastErr = nil // Clear any previous error on success
@@ -290,32 +494,32 @@ func (bp *BatchProcessor) processSingleJob(ctx context.Context, job *SendJob) *S
 		}
 
 		lastErr = err
+		lastClass = ClassifyError(err)
 
-		// Check if error is retryable
-		if !isRetryableError(err) {
+		if !lastClass.Retryable() {
 			logger.Get().WithFields(map[string]interface{}{
 				"batch_index":     job.BatchIndex,
 				"recipient_count": job.RecipientCount,
+				"status_code":     lastClass.StatusCode,
 				"error":           err.Error(),
-			}).Debug("Non-retryable error, aborting retries")
+			}).Debug("Permanent error, not retrying")
 			break
 		}
 	}
 
 	duration := time.Since(startTime)
 	success := lastErr == nil
-	retryable := false
 
 	if lastErr != nil {
-		retryable = isRetryableError(lastErr)
 		logger.Get().WithFields(map[string]interface{}{
 			"batch_index":     job.BatchIndex,
 			"recipient_count": job.RecipientCount,
 			"error":           lastErr.Error(),
-			"retryable":       retryable,
-			"attempts":        bp.maxRetries + 1,
+			"category":        lastClass.Category,
+			"attempts":        attempts,
 		}).Debug("Batch send job failed")
 	} else {
+		lastClass = ErrorClass{}
 		logger.Get().WithFields(map[string]interface{}{
 			"batch_index":     job.BatchIndex,
 			"recipient_count": job.RecipientCount,
@@ -328,7 +532,9 @@ func (bp *BatchProcessor) processSingleJob(ctx context.Context, job *SendJob) *S
 		Response:  response,
 		Error:     lastErr,
 		Success:   success,
-		Retryable: retryable,
+		Retryable: lastErr != nil && lastClass.Retryable(),
+		Class:     lastClass,
+		Retries:   retries,
 		Duration:  duration,
 	}
 }
@@ -373,6 +579,13 @@ func (bp *BatchProcessor) createFailedRecipientFromError(recipient common.Recipi
 	return failedRecipient
 }
 
+// newInterruptedRecipient records a recipient that was not delivered because the batch was cancelled
+func (bp *BatchProcessor) newInterruptedRecipient(recipient common.Recipient, reason string, retryable bool) FailedRecipient {
+	failedRecipient := bp.createFailedRecipientFromError(recipient, nil, retryable)
+	failedRecipient.Error = reason
+	return failedRecipient
+}
+
 // saveFailedRecipients saves failed recipients to a file for retry
 func (bp *BatchProcessor) saveFailedRecipients(failedRecipients []FailedRecipient) (string, error) {
 	// Create .ahasend directory if it doesn't exist
@@ -407,34 +620,7 @@ func (bp *BatchProcessor) saveFailedRecipients(failedRecipients []FailedRecipien
 
 // isRetryableError determines if an error should be retried
 func isRetryableError(err error) bool {
-	if err == nil {
-		return false
-	}
-
-	errorStr := strings.ToLower(err.Error())
-
-	// Check for retryable conditions
-	retryableErrors := []string{
-		"rate limit",
-		"too many requests",
-		"timeout",
-		"connection",
-		"network",
-		"internal server error",
-		"bad gateway",
-		"service unavailable",
-		"gateway timeout",
-		"502", "503", "504", // HTTP status codes
-		"429", // Rate limit
-	}
-
-	for _, retryableError := range retryableErrors {
-		if strings.Contains(errorStr, retryableError) {
-			return true
-		}
-	}
-
-	return false
+	return err != nil && ClassifyError(err).Retryable()
 }
 
 // extractActualErrorMessage attempts to extract the real error message from SDK errors
@@ -463,6 +649,10 @@ func extractErrorCode(err error) int {
 		return 0
 	}
 
+	if status := ClassifyError(err).StatusCode; status != 0 {
+		return status
+	}
+
 	// Try to extract from error message
 	errMsg := err.Error()
 	if strings.Contains(errMsg, "rate limit") || strings.Contains(errMsg, "too many requests") {
@@ -491,6 +681,9 @@ func extractErrorCode(err error) int {
 // GetExitCode returns appropriate exit code based on batch results
 func (br *BatchResult) GetExitCode() int {
 	if br.TotalJobs == 0 {
+		if br.SkippedJobs > 0 {
+			return 0 // Everything was sent before
+		}
 		return 3 // Critical error
 	}
 
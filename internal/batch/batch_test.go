@@ -1,13 +1,17 @@
 package batch
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
+	ahasend "github.com/AhaSend/ahasend-go"
 	"github.com/AhaSend/ahasend-go/models/common"
 	"github.com/AhaSend/ahasend-go/models/requests"
 	"github.com/stretchr/testify/assert"
@@ -116,6 +120,45 @@ func TestBatchProcessor_ProcessJobs_SingleJobSuccess(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestBatchProcessor_ProcessJobs_ConversationJob(t *testing.T) {
+	t.Cleanup(func() {
+		os.RemoveAll(".ahasend")
+	})
+
+	mockClient := &mocks.MockClient{}
+	processor := NewBatchProcessor(mockClient, 1, 1, progress.NewReporter(1, false, false))
+
+	request := &requests.CreateMessageRequest{
+		From:        common.SenderAddress{Email: "sender@example.com"},
+		Recipients:  []common.Recipient{{Email: "to@example.com"}},
+		Subject:     "Test Subject",
+		TextContent: ahasend.String("Hello"),
+	}
+	job := &SendJob{
+		Request:        request,
+		IdempotencyKey: "key-123",
+		Recipients:     request.Recipients,
+		RecipientCount: 3,
+		CC:             []common.SenderAddress{{Email: "cc@example.com"}},
+		BCC:            []common.SenderAddress{{Email: "bcc@example.com"}},
+	}
+
+	// Jobs with copies go to the conversation endpoint
+	mockClient.On("SendConversationMessageWithIdempotencyKey", requests.CreateConversationMessageRequest{
+		From:        request.From,
+		To:          []common.SenderAddress{{Email: "to@example.com"}},
+		CC:          job.CC,
+		BCC:         job.BCC,
+		Subject:     "Test Subject",
+		TextContent: request.TextContent,
+	}, "key-123").Return(mockClient.NewMockMessageResponse("msg-123"), nil)
+
+	result, err := processor.ProcessJobs(context.Background(), []*SendJob{job})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.SuccessfulJobs)
+	mockClient.AssertExpectations(t)
+}
+
 func TestBatchProcessor_ProcessJobs_SingleJobFailure(t *testing.T) {
 	// Clean up any existing test files
 	t.Cleanup(func() {
@@ -163,6 +206,45 @@ func TestBatchProcessor_ProcessJobs_SingleJobFailure(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestBatchProcessor_JSONProgressMatchesResult(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	mockClient := &mocks.MockClient{}
+	var output bytes.Buffer
+	processor := NewBatchProcessor(mockClient, 2, 0, progress.NewJSONReporter(3, &output, time.Hour))
+
+	jobs := make([]*SendJob, 3)
+	for i := range jobs {
+		recipients := []common.Recipient{{Email: fmt.Sprintf("test%d@example.com", i+1)}}
+		request := &requests.CreateMessageRequest{
+			From:       common.SenderAddress{Email: "sender@example.com"},
+			Recipients: recipients,
+			Subject:    "Test Subject",
+		}
+		jobs[i] = &SendJob{Request: request, IdempotencyKey: fmt.Sprintf("key-%d", i+1), BatchIndex: i, Recipients: recipients, RecipientCount: 1}
+		if i == 1 {
+			mockClient.On("SendMessageWithIdempotencyKey", *request, jobs[i].IdempotencyKey).Return(nil, errors.New("invalid recipient"))
+		} else {
+			mockClient.On("SendMessageWithIdempotencyKey", *request, jobs[i].IdempotencyKey).Return(mockClient.NewMockMessageResponse("msg"), nil)
+		}
+	}
+
+	result, err := processor.ProcessJobs(context.Background(), jobs)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	var final map[string]interface{}
+	for _, line := range lines {
+		final = nil
+		require.NoError(t, json.Unmarshal([]byte(line), &final), line)
+	}
+	assert.Equal(t, true, final["complete"])
+	assert.Equal(t, float64(result.TotalRecipients), final["total"])
+	assert.Equal(t, float64(result.SuccessfulRecipients), final["succeeded"])
+	assert.Equal(t, float64(len(result.FailedRecipients)), final["failed"])
+	assert.Equal(t, float64(result.Stats.Sent+result.Stats.Failed), final["done"])
+}
+
 func TestBatchProcessor_ProcessJobs_MultipleJobs(t *testing.T) {
 	// Clean up any existing test files
 	t.Cleanup(func() {
@@ -262,8 +344,11 @@ func TestBatchProcessor_RetryLogic(t *testing.T) {
 		os.RemoveAll(".ahasend")
 	})
 
+	pinJitter(t, 1)
 	mockClient := &mocks.MockClient{}
-	processor := NewBatchProcessor(mockClient, 1, 1, nil) // 1 retry for 2 total attempts
+	var notices bytes.Buffer
+	reporter := progress.NewReporterWithOutput(1, &notices, false, false)
+	processor := NewBatchProcessor(mockClient, 1, 1, reporter) // 1 retry for 2 total attempts
 
 	request := &requests.CreateMessageRequest{
 		From:       common.SenderAddress{Email: "sender@example.com"},
@@ -294,6 +379,7 @@ func TestBatchProcessor_RetryLogic(t *testing.T) {
 	assert.Equal(t, 1, result.TotalJobs)
 	assert.Equal(t, 1, result.SuccessfulJobs)
 	assert.Equal(t, 0, result.FailedJobs)
+	assert.Equal(t, "Batch 1 (1 recipients) failed: rate limit exceeded; retrying in 1s (attempt 2 of 2)\n", notices.String())
 
 	mockClient.AssertExpectations(t)
 }
@@ -783,6 +869,105 @@ func TestBatchProcessor_ProgressReporting(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func newCancellationTestJobs(count int) []*SendJob {
+	jobs := make([]*SendJob, count)
+	for i := range jobs {
+		recipient := common.Recipient{Email: fmt.Sprintf("test%d@example.com", i+1)}
+		jobs[i] = &SendJob{
+			Request: &requests.CreateMessageRequest{
+				From:       common.SenderAddress{Email: "sender@example.com"},
+				Recipients: []common.Recipient{recipient},
+				Subject:    "Test Subject",
+			},
+			IdempotencyKey: fmt.Sprintf("key-%d", i+1),
+			BatchIndex:     i,
+			Recipients:     []common.Recipient{recipient},
+			RecipientCount: 1,
+		}
+	}
+	return jobs
+}
+
+func TestBatchProcessor_CancellationStopsDispatch(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	mockClient := &mocks.MockClient{}
+	processor := NewBatchProcessor(mockClient, 1, 0, nil)
+	jobs := newCancellationTestJobs(5)
+
+	// Only the first batch is ever in flight; it is slow enough to cancel mid-request
+	mockClient.On("SendMessageWithIdempotencyKey", mock.Anything, "key-1").
+		Return(mockClient.NewMockMessageResponse("msg-1"), nil).
+		After(100 * time.Millisecond).Once()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	result, err := processor.ProcessJobs(ctx, jobs)
+	require.NoError(t, err)
+
+	assert.True(t, result.Interrupted)
+	assert.Equal(t, 1, result.SuccessfulJobs, "in-flight request should finish")
+	assert.Equal(t, 0, result.FailedJobs)
+	assert.Equal(t, 4, result.NotSentRecipients)
+	assert.Equal(t, 0, result.AbandonedRecipients)
+	require.Len(t, result.FailedRecipients, 4)
+	for _, failed := range result.FailedRecipients {
+		assert.Equal(t, notSentReason, failed.Error)
+		assert.True(t, failed.Retryable)
+	}
+	assert.NotEmpty(t, result.FailedRecipientsFile)
+
+	mockClient.AssertNumberOfCalls(t, "SendMessageWithIdempotencyKey", 1)
+	mockClient.AssertExpectations(t)
+}
+
+func TestBatchProcessor_DrainTimeout(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	mockClient := &mocks.MockClient{}
+	processor := NewBatchProcessor(mockClient, 2, 0, nil)
+	processor.SetDrainTimeout(50 * time.Millisecond)
+	jobs := newCancellationTestJobs(2)
+
+	mockClient.On("SendMessageWithIdempotencyKey", mock.Anything, mock.Anything).
+		Return(mockClient.NewMockMessageResponse("msg"), nil).
+		After(2 * time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	start := time.Now()
+	result, err := processor.ProcessJobs(ctx, jobs)
+	require.NoError(t, err)
+
+	assert.Less(t, time.Since(start), time.Second, "drain timeout should bound the wait")
+	assert.True(t, result.Interrupted)
+	assert.Equal(t, 2, result.AbandonedRecipients)
+	assert.Equal(t, 0, result.SuccessfulJobs)
+	require.Len(t, result.FailedRecipients, 2)
+	assert.Equal(t, abandonedReason, result.FailedRecipients[0].Error)
+	assert.False(t, result.FailedRecipients[0].Retryable, "outcome is unknown so retrying could duplicate")
+}
+
+func TestBatchProcessor_NoCancellation(t *testing.T) {
+	mockClient := &mocks.MockClient{}
+	processor := NewBatchProcessor(mockClient, 2, 0, nil)
+	jobs := newCancellationTestJobs(3)
+
+	mockClient.On("SendMessageWithIdempotencyKey", mock.Anything, mock.Anything).
+		Return(mockClient.NewMockMessageResponse("msg"), nil).
+		After(5 * time.Millisecond)
+
+	result, err := processor.ProcessJobs(context.Background(), jobs)
+	require.NoError(t, err)
+
+	assert.False(t, result.Interrupted)
+	assert.Equal(t, 3, result.SuccessfulJobs)
+	assert.Zero(t, result.NotSentRecipients)
+	assert.Empty(t, result.FailedRecipients)
+}
+
 // Helper function
 func stringPtr(s string) *string {
 	return &s
@@ -0,0 +1,177 @@
+package batch
+
+import (
+	"context"
+	stderrors "errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/api"
+)
+
+// ErrorCategory says whether a failed send is worth retrying, and on whose
+// side the problem is
+type ErrorCategory string
+
+const (
+	// ErrorPermanent is a request the API rejected (4xx other than 429), such
+	// as a validation error. Retrying it fails the same way.
+	ErrorPermanent ErrorCategory = "permanent"
+	// ErrorRateLimited is a 429 response, retried after its Retry-After delay
+	ErrorRateLimited ErrorCategory = "rate_limited"
+	// ErrorTransient is a 5xx response, a timeout or a connection error,
+	// retried with exponential backoff
+	ErrorTransient ErrorCategory = "transient"
+)
+
+// ErrorCategories lists the categories in reporting order
+var ErrorCategories = []ErrorCategory{ErrorPermanent, ErrorRateLimited, ErrorTransient}
+
+// ErrorClass is the classification of a failed send
+type ErrorClass struct {
+	Category   ErrorCategory
+	StatusCode int           // HTTP status, 0 when no response was received
+	RetryAfter time.Duration // the server's Retry-After for rate limited requests
+}
+
+// Retryable reports whether the send may succeed when retried
+func (c ErrorClass) Retryable() bool {
+	return c.Category != ErrorPermanent
+}
+
+// Retry delays for transient errors: the delay doubles with every attempt,
+// up to retryMaxDelay
+const (
+	retryBaseDelay = time.Second
+	retryMaxDelay  = 30 * time.Second
+)
+
+// jitter returns a random fraction in [0, 1); replaced in tests
+var jitter = rand.Float64
+
+// transientMessages are matched against errors that carry no HTTP status or
+// network error type, such as errors wrapped as plain text
+var transientMessages = []string{
+	"timeout",
+	"connection",
+	"network",
+	"internal server error",
+	"bad gateway",
+	"service unavailable",
+	"gateway timeout",
+	"502", "503", "504",
+}
+
+// rateLimitMessages are matched like transientMessages
+var rateLimitMessages = []string{"rate limit", "too many requests", "429"}
+
+// ClassifyError classifies a failed send. API errors are classified by their
+// HTTP status: 429 is rate limited, other 4xx are permanent and 5xx are
+// transient. Timeouts and connection errors are transient. Other errors are
+// classified by their message, and are permanent when nothing matches.
+func ClassifyError(err error) ErrorClass {
+	var apiErr *api.APIError
+	if stderrors.As(err, &apiErr) {
+		return classifyAPIError(apiErr)
+	}
+	if isNetworkError(err) {
+		return ErrorClass{Category: ErrorTransient}
+	}
+
+	message := strings.ToLower(err.Error())
+	for _, match := range rateLimitMessages {
+		if strings.Contains(message, match) {
+			return ErrorClass{Category: ErrorRateLimited, StatusCode: http.StatusTooManyRequests}
+		}
+	}
+	for _, match := range transientMessages {
+		if strings.Contains(message, match) {
+			return ErrorClass{Category: ErrorTransient}
+		}
+	}
+	return ErrorClass{Category: ErrorPermanent}
+}
+
+// classifyAPIError classifies an error response by its status, falling back
+// to the SDK's error type when there is none
+func classifyAPIError(apiErr *api.APIError) ErrorClass {
+	class := ErrorClass{StatusCode: apiErr.StatusCode}
+	switch {
+	case apiErr.StatusCode == http.StatusTooManyRequests || (apiErr.StatusCode == 0 && apiErr.Type == api.ErrorTypeRateLimit):
+		class.Category = ErrorRateLimited
+		if apiErr.RetryAfter > 0 {
+			class.RetryAfter = time.Duration(apiErr.RetryAfter) * time.Second
+		}
+	case apiErr.StatusCode >= 500:
+		class.Category = ErrorTransient
+	case apiErr.StatusCode >= 400:
+		class.Category = ErrorPermanent
+	case apiErr.IsRetryable():
+		class.Category = ErrorTransient
+	default:
+		class.Category = ErrorPermanent
+	}
+	return class
+}
+
+// isNetworkError reports whether err is a timeout or a failure to reach the
+// API or to read its response
+func isNetworkError(err error) bool {
+	var sdkNetErr *api.NetworkError
+	var netErr net.Error
+	var urlErr *url.Error
+	return stderrors.As(err, &sdkNetErr) ||
+		stderrors.As(err, &netErr) ||
+		stderrors.As(err, &urlErr) ||
+		stderrors.Is(err, context.DeadlineExceeded) ||
+		stderrors.Is(err, io.ErrUnexpectedEOF) ||
+		stderrors.Is(err, syscall.ECONNRESET) ||
+		stderrors.Is(err, syscall.ECONNREFUSED)
+}
+
+// retryDelay is the wait before retry attempt (1 for the first retry). Rate
+// limited requests wait for their Retry-After when the server sent one.
+// Otherwise the delay doubles with each attempt, and half of it is random so
+// that concurrent workers do not retry in lockstep.
+func retryDelay(attempt int, class ErrorClass) time.Duration {
+	if class.Category == ErrorRateLimited && class.RetryAfter > 0 {
+		return class.RetryAfter
+	}
+
+	delay := retryMaxDelay
+	if attempt <= 5 {
+		delay = min(retryBaseDelay<<(attempt-1), retryMaxDelay)
+	}
+	half := delay / 2
+	return (half + time.Duration(jitter()*float64(half))).Round(time.Millisecond)
+}
+
+// Retry calls call until it succeeds, fails with a permanent error or has
+// been retried maxRetries times, waiting between attempts as send jobs do.
+// It stops waiting when ctx is done. The error of the last attempt is
+// returned, with its classification; both are zero on success.
+func Retry(ctx context.Context, maxRetries int, call func() error) (ErrorClass, error) {
+	var class ErrorClass
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(retryDelay(attempt, class)):
+			case <-ctx.Done():
+			}
+		}
+		err := call()
+		if err == nil {
+			return ErrorClass{}, nil
+		}
+		class = ClassifyError(err)
+		if !class.Retryable() || attempt >= maxRetries || ctx.Err() != nil {
+			return class, err
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/api"
+	"github.com/AhaSend/ahasend-go/models/common"
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+	"github.com/AhaSend/ahasend-cli/internal/progress"
+)
+
+// pinJitter makes retry delays deterministic
+func pinJitter(t *testing.T, fraction float64) {
+	t.Helper()
+	previous := jitter
+	jitter = func() float64 { return fraction }
+	t.Cleanup(func() { jitter = previous })
+}
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		category   ErrorCategory
+		status     int
+		retryAfter time.Duration
+	}{
+		{"422 validation", &api.APIError{Type: api.ErrorTypeIdempotency, StatusCode: 422, Message: "invalid recipient domain"}, ErrorPermanent, 422, 0},
+		{"400 bad request", &api.APIError{Type: api.ErrorTypeValidation, StatusCode: 400}, ErrorPermanent, 400, 0},
+		{"401 unauthorized", &api.APIError{Type: api.ErrorTypeAuthentication, StatusCode: 401}, ErrorPermanent, 401, 0},
+		{"404 with a retryable-sounding message", &api.APIError{Type: api.ErrorTypeNotFound, StatusCode: 404, Message: "connection not found"}, ErrorPermanent, 404, 0},
+		{"429 with Retry-After", &api.APIError{Type: api.ErrorTypeRateLimit, StatusCode: 429, RetryAfter: 7}, ErrorRateLimited, 429, 7 * time.Second},
+		{"429 without Retry-After", &api.APIError{Type: api.ErrorTypeRateLimit, StatusCode: 429}, ErrorRateLimited, 429, 0},
+		{"500", &api.APIError{Type: api.ErrorTypeServer, StatusCode: 500}, ErrorTransient, 500, 0},
+		{"503 wrapped", fmt.Errorf("send failed: %w", &api.APIError{Type: api.ErrorTypeServer, StatusCode: 503}), ErrorTransient, 503, 0},
+		{"SDK network error", &api.NetworkError{Op: "POST", Err: io.EOF}, ErrorTransient, 0, 0},
+		{"request timeout", &url.Error{Op: "Post", URL: "https://api.ahasend.com", Err: context.DeadlineExceeded}, ErrorTransient, 0, 0},
+		{"connection refused", &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}, ErrorTransient, 0, 0},
+		{"connection reset", fmt.Errorf("read: %w", syscall.ECONNRESET), ErrorTransient, 0, 0},
+		{"deadline exceeded", context.DeadlineExceeded, ErrorTransient, 0, 0},
+		{"rate limit text", errors.New("Rate limit exceeded"), ErrorRateLimited, 429, 0},
+		{"gateway text", errors.New("HTTP 502 Bad Gateway"), ErrorTransient, 0, 0},
+		{"unknown text", errors.New("invalid account ID format"), ErrorPermanent, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			class := ClassifyError(tt.err)
+			assert.Equal(t, tt.category, class.Category)
+			assert.Equal(t, tt.status, class.StatusCode)
+			assert.Equal(t, tt.retryAfter, class.RetryAfter)
+			assert.Equal(t, tt.category != ErrorPermanent, class.Retryable())
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	transient := ErrorClass{Category: ErrorTransient}
+
+	pinJitter(t, 0)
+	assert.Equal(t, 500*time.Millisecond, retryDelay(1, transient))
+	assert.Equal(t, time.Second, retryDelay(2, transient))
+	assert.Equal(t, 2*time.Second, retryDelay(3, transient))
+	assert.Equal(t, 15*time.Second, retryDelay(10, transient), "capped")
+
+	pinJitter(t, 0.5)
+	assert.Equal(t, 3*time.Second, retryDelay(3, transient))
+
+	// Retry-After replaces the backoff; without it rate limits back off too
+	assert.Equal(t, 7*time.Second, retryDelay(1, ErrorClass{Category: ErrorRateLimited, RetryAfter: 7 * time.Second}))
+	assert.Equal(t, 750*time.Millisecond, retryDelay(1, ErrorClass{Category: ErrorRateLimited}))
+}
+
+func newRetryJob() *SendJob {
+	request := &requests.CreateMessageRequest{
+		From:       common.SenderAddress{Email: "sender@example.com"},
+		Recipients: []common.Recipient{{Email: "test@example.com"}},
+		Subject:    "Test Subject",
+	}
+	return &SendJob{
+		Request:        request,
+		IdempotencyKey: "key-123",
+		Recipients:     request.Recipients,
+		RecipientCount: 1,
+	}
+}
+
+func TestBatchProcessor_PermanentErrorFailsFast(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	mockClient := &mocks.MockClient{}
+	validation := &api.APIError{Type: api.ErrorTypeIdempotency, StatusCode: 422, Message: "invalid recipient domain"}
+	mockClient.On("SendMessageWithIdempotencyKey", mock.Anything, "key-123").Return(nil, validation).Once()
+
+	processor := NewBatchProcessor(mockClient, 1, 3, progress.NewReporterWithOutput(1, io.Discard, false, false))
+	result, err := processor.ProcessJobs(context.Background(), []*SendJob{newRetryJob()})
+
+	require.NoError(t, err)
+	mockClient.AssertNumberOfCalls(t, "SendMessageWithIdempotencyKey", 1)
+	assert.Equal(t, map[ErrorCategory]int{ErrorPermanent: 1}, result.ErrorCounts)
+	assert.Empty(t, result.RetryCounts)
+	assert.Equal(t, map[string]int{"permanent": 1}, result.Stats.Errors)
+
+	require.Len(t, result.FailedRecipients, 1)
+	failed := result.FailedRecipients[0]
+	assert.Equal(t, ErrorPermanent, failed.ErrorCategory)
+	assert.Equal(t, 422, failed.ErrorCode)
+	assert.False(t, failed.Retryable)
+}
+
+func TestBatchProcessor_TransientErrorRetried(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	pinJitter(t, 0)
+
+	mockClient := &mocks.MockClient{}
+	mockClient.On("SendMessageWithIdempotencyKey", mock.Anything, "key-123").Return(nil, &api.APIError{Type: api.ErrorTypeServer, StatusCode: 503}).Once()
+	mockClient.On("SendMessageWithIdempotencyKey", mock.Anything, "key-123").Return(mockClient.NewMockMessageResponse("msg-1"), nil).Once()
+
+	processor := NewBatchProcessor(mockClient, 1, 3, progress.NewReporterWithOutput(1, io.Discard, false, false))
+	result, err := processor.ProcessJobs(context.Background(), []*SendJob{newRetryJob()})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.SuccessfulJobs)
+	assert.Empty(t, result.ErrorCounts)
+	assert.Equal(t, map[ErrorCategory]int{ErrorTransient: 1}, result.RetryCounts)
+	assert.Equal(t, map[string]int{"transient": 1}, result.Stats.Retries)
+	mockClient.AssertExpectations(t)
+}
+
+func TestRetry(t *testing.T) {
+	pinJitter(t, 0)
+
+	t.Run("transient errors are retried", func(t *testing.T) {
+		calls := 0
+		class, err := Retry(context.Background(), 3, func() error {
+			calls++
+			if calls < 3 {
+				return &api.APIError{Type: api.ErrorTypeServer, StatusCode: 503}
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, ErrorClass{}, class)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("permanent errors are not retried", func(t *testing.T) {
+		calls := 0
+		class, err := Retry(context.Background(), 3, func() error {
+			calls++
+			return &api.APIError{StatusCode: 422, Message: "invalid email"}
+		})
+		require.Error(t, err)
+		assert.Equal(t, ErrorPermanent, class.Category)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("gives up after maxRetries", func(t *testing.T) {
+		calls := 0
+		class, err := Retry(context.Background(), 1, func() error {
+			calls++
+			return &api.APIError{StatusCode: 429, RetryAfter: 0}
+		})
+		require.Error(t, err)
+		assert.Equal(t, ErrorRateLimited, class.Category)
+		assert.Equal(t, 2, calls)
+	})
+}
@@ -0,0 +1,190 @@
+package batch
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+)
+
+// stateVersion is the format version of state files
+const stateVersion = 1
+
+// BatchStatus is the outcome of a batch as recorded in a state file
+type BatchStatus string
+
+const (
+	BatchPending BatchStatus = "pending"  // not attempted yet
+	BatchSent    BatchStatus = "sent"     // accepted by the API
+	BatchFailed  BatchStatus = "failed"   // rejected or failed after the retries
+	BatchNotSent BatchStatus = "not_sent" // never dispatched because the send was interrupted
+	BatchUnknown BatchStatus = "unknown"  // still in flight when the drain timeout expired
+)
+
+// BatchState is the recorded progress of one batch. Fingerprint identifies
+// the batch's recipients, so a resumed send can tell that its batches are
+// the ones recorded.
+type BatchState struct {
+	Index          int         `json:"index"`
+	IdempotencyKey string      `json:"idempotency_key"`
+	Recipients     int         `json:"recipients"`
+	Fingerprint    string      `json:"fingerprint"`
+	Status         BatchStatus `json:"status"`
+	Error          string      `json:"error,omitempty"`
+	UpdatedAt      time.Time   `json:"updated_at"`
+}
+
+// State records the progress of a batch send in a state file, so that an
+// interrupted send can be resumed: batches already sent are skipped and the
+// others are sent again with their recorded idempotency keys, which lets the
+// API drop the ones it had accepted after all.
+type State struct {
+	Version   int          `json:"version"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+	Batches   []BatchState `json:"batches"`
+
+	path string
+}
+
+// NewState creates the state of a new send of jobs, to be saved at path
+func NewState(path string, jobs []*SendJob) *State {
+	now := time.Now().UTC()
+	s := &State{
+		Version:   stateVersion,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Batches:   make([]BatchState, len(jobs)),
+		path:      path,
+	}
+	for i, job := range jobs {
+		s.Batches[i] = BatchState{
+			Index:          job.BatchIndex,
+			IdempotencyKey: job.IdempotencyKey,
+			Recipients:     job.RecipientCount,
+			Fingerprint:    recipientsFingerprint(job),
+			Status:         BatchPending,
+			UpdatedAt:      now,
+		}
+	}
+	return s
+}
+
+// LoadState reads the state file at path
+func LoadState(path string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, errors.NewFileError(fmt.Sprintf("no state file at %s to resume from", path), nil)
+	}
+	if err != nil {
+		return nil, errors.NewFileError("failed to read state file "+path, err)
+	}
+
+	var s State
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, errors.NewFileError("failed to parse state file "+path, err)
+	}
+	if s.Version != stateVersion {
+		return nil, errors.NewFileError(fmt.Sprintf("state file %s has unsupported version %d", path, s.Version), nil)
+	}
+	s.path = path
+	return &s, nil
+}
+
+// Path returns the location of the state file
+func (s *State) Path() string {
+	return s.path
+}
+
+// Resume matches jobs, built again from the same input, to the recorded
+// batches and gives each job its recorded idempotency key. It fails when
+// the batches differ, as they do when the recipients or the options that
+// split them into batches changed.
+func (s *State) Resume(jobs []*SendJob) error {
+	mismatch := func(format string, args ...interface{}) error {
+		return errors.NewValidationError(fmt.Sprintf("cannot resume from %s: %s; resume with the same recipients and options as the interrupted send",
+			s.path, fmt.Sprintf(format, args...)), nil)
+	}
+	if len(jobs) != len(s.Batches) {
+		return mismatch("the send has %d batches, the state file records %d", len(jobs), len(s.Batches))
+	}
+	for i, job := range jobs {
+		recorded := s.Batches[i]
+		if job.RecipientCount != recorded.Recipients || recipientsFingerprint(job) != recorded.Fingerprint {
+			return mismatch("the recipients of batch %d differ from those recorded", i+1)
+		}
+	}
+	for i, job := range jobs {
+		job.IdempotencyKey = s.Batches[i].IdempotencyKey
+	}
+	return nil
+}
+
+// Pending splits jobs into those still to send and those the state records
+// as sent
+func (s *State) Pending(jobs []*SendJob) (pending, sent []*SendJob) {
+	for _, job := range jobs {
+		if recorded := s.batch(job); recorded != nil && recorded.Status == BatchSent {
+			sent = append(sent, job)
+			continue
+		}
+		pending = append(pending, job)
+	}
+	return pending, sent
+}
+
+// Record sets the outcome of a job's batch; err is the cause of a failure
+func (s *State) Record(job *SendJob, status BatchStatus, err error) {
+	recorded := s.batch(job)
+	if recorded == nil {
+		return
+	}
+	recorded.Status = status
+	recorded.Error = ""
+	if err != nil {
+		recorded.Error = extractActualErrorMessage(err)
+	}
+	recorded.UpdatedAt = time.Now().UTC()
+}
+
+// batch returns the recorded batch of job, or nil when there is none
+func (s *State) batch(job *SendJob) *BatchState {
+	if job.BatchIndex < 0 || job.BatchIndex >= len(s.Batches) {
+		return nil
+	}
+	return &s.Batches[job.BatchIndex]
+}
+
+// Save writes the state file, replacing it atomically so an interrupted
+// write never leaves a truncated file behind
+func (s *State) Save() error {
+	s.UpdatedAt = time.Now().UTC()
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return errors.NewFileError("failed to encode state", err)
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0600); err != nil {
+		return errors.NewFileError("failed to write state file "+tmp, err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		os.Remove(tmp)
+		return errors.NewFileError("failed to replace state file "+s.path, err)
+	}
+	return nil
+}
+
+// recipientsFingerprint hashes the addresses of a job's recipients, in order
+func recipientsFingerprint(job *SendJob) string {
+	h := sha256.New()
+	for _, recipient := range job.Recipients {
+		h.Write([]byte(recipient.Email))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
@@ -0,0 +1,141 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/models/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+)
+
+func TestState_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "campaign.json")
+	jobs := newCancellationTestJobs(3)
+
+	state := NewState(path, jobs)
+	state.Record(jobs[0], BatchSent, nil)
+	state.Record(jobs[1], BatchFailed, errors.New("service unavailable"))
+	require.NoError(t, state.Save())
+
+	_, err := os.Stat(path + ".tmp")
+	assert.True(t, os.IsNotExist(err), "the temporary file is renamed into place")
+
+	loaded, err := LoadState(path)
+	require.NoError(t, err)
+	require.Len(t, loaded.Batches, 3)
+	assert.Equal(t, "key-1", loaded.Batches[0].IdempotencyKey)
+	assert.Equal(t, BatchSent, loaded.Batches[0].Status)
+	assert.Equal(t, BatchFailed, loaded.Batches[1].Status)
+	assert.Equal(t, "service unavailable", loaded.Batches[1].Error)
+	assert.Equal(t, BatchPending, loaded.Batches[2].Status)
+
+	_, err = LoadState(filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorContains(t, err, "no state file at")
+}
+
+func TestState_Resume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "campaign.json")
+	state := NewState(path, newCancellationTestJobs(3))
+	state.Record(&SendJob{BatchIndex: 0}, BatchSent, nil)
+
+	// The same input built again gets new idempotency keys
+	jobs := newCancellationTestJobs(3)
+	for _, job := range jobs {
+		job.IdempotencyKey = "new-" + job.IdempotencyKey
+	}
+	require.NoError(t, state.Resume(jobs))
+	assert.Equal(t, "key-2", jobs[1].IdempotencyKey, "the recorded idempotency keys are reused")
+
+	pending, sent := state.Pending(jobs)
+	assert.Equal(t, []*SendJob{jobs[1], jobs[2]}, pending)
+	assert.Equal(t, []*SendJob{jobs[0]}, sent)
+
+	assert.ErrorContains(t, state.Resume(newCancellationTestJobs(2)), "the send has 2 batches, the state file records 3")
+
+	changed := newCancellationTestJobs(3)
+	changed[2].Recipients = []common.Recipient{{Email: "someone-else@example.com"}}
+	assert.ErrorContains(t, state.Resume(changed), "the recipients of batch 3 differ")
+}
+
+func TestBatchProcessor_RecordsState(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "campaign.json")
+	jobs := newCancellationTestJobs(4)
+	state := NewState(path, jobs)
+	state.Record(jobs[0], BatchSent, nil)
+
+	mockClient := &mocks.MockClient{}
+	mockClient.On("SendMessageWithIdempotencyKey", mock.Anything, "key-2").Return(mockClient.NewMockMessageResponse("msg-2"), nil).Once()
+	mockClient.On("SendMessageWithIdempotencyKey", mock.Anything, "key-3").Return(nil, errors.New("invalid recipient")).Once()
+	mockClient.On("SendMessageWithIdempotencyKey", mock.Anything, "key-4").Return(mockClient.NewMockMessageResponse("msg-4"), nil).Once()
+
+	processor := NewBatchProcessor(mockClient, 1, 0, nil)
+	processor.SetState(state)
+	result, err := processor.ProcessJobs(context.Background(), jobs)
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNotCalled(t, "SendMessageWithIdempotencyKey", mock.Anything, "key-1")
+
+	assert.Equal(t, 3, result.TotalJobs)
+	assert.Equal(t, 1, result.SkippedJobs)
+	assert.Equal(t, 1, result.SkippedRecipients)
+	assert.Equal(t, 1, result.Stats.Skipped)
+
+	saved, err := LoadState(path)
+	require.NoError(t, err)
+	var statuses []BatchStatus
+	for _, batch := range saved.Batches {
+		statuses = append(statuses, batch.Status)
+	}
+	assert.Equal(t, []BatchStatus{BatchSent, BatchSent, BatchFailed, BatchSent}, statuses)
+	assert.Equal(t, "invalid recipient", saved.Batches[2].Error)
+}
+
+func TestBatchProcessor_StateRecordsInterruption(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "campaign.json")
+	jobs := newCancellationTestJobs(3)
+
+	mockClient := &mocks.MockClient{}
+	mockClient.On("SendMessageWithIdempotencyKey", mock.Anything, "key-1").
+		Return(mockClient.NewMockMessageResponse("msg-1"), nil).
+		After(100 * time.Millisecond).Once()
+
+	processor := NewBatchProcessor(mockClient, 1, 0, nil)
+	processor.SetState(NewState(path, jobs))
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	result, err := processor.ProcessJobs(ctx, jobs)
+	require.NoError(t, err)
+	assert.True(t, result.Interrupted)
+
+	saved, err := LoadState(path)
+	require.NoError(t, err)
+	assert.Equal(t, BatchSent, saved.Batches[0].Status)
+	assert.Equal(t, BatchNotSent, saved.Batches[1].Status)
+	assert.Equal(t, BatchNotSent, saved.Batches[2].Status)
+}
+
+func TestBatchProcessor_AllSkipped(t *testing.T) {
+	jobs := newCancellationTestJobs(2)
+	state := NewState(filepath.Join(t.TempDir(), "campaign.json"), jobs)
+	for _, job := range jobs {
+		state.Record(job, BatchSent, nil)
+	}
+
+	processor := NewBatchProcessor(&mocks.MockClient{}, 1, 0, nil)
+	processor.SetState(state)
+	result, err := processor.ProcessJobs(context.Background(), jobs)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.TotalJobs)
+	assert.Equal(t, 2, result.SkippedJobs)
+	assert.Equal(t, 0, result.GetExitCode(), "a send with nothing left to do succeeds")
+}
@@ -0,0 +1,113 @@
+// Package bounces explains the bounce classifications AhaSend reports in
+// bounce statistics and message details, for readers who do not know what
+// a classification like PolicyRelated means or what to do about it.
+package bounces
+
+import "strings"
+
+// Explanation describes a bounce classification and what to do about it
+type Explanation struct {
+	Classification string `json:"classification"`
+	Description    string `json:"description"`
+	Action         string `json:"action"`
+}
+
+// Classifications lists the bounce classifications the API reports, in the
+// order they are documented
+var Classifications = []string{
+	"AuthenticationFailed",
+	"BadDomain",
+	"DNSFailure",
+	"InactiveMailbox",
+	"InvalidRecipient",
+	"PolicyRelated",
+	"ProtocolErrors",
+	"QuotaIssues",
+	"RoutingErrors",
+	"TransientFailure",
+	"Uncategorized",
+}
+
+// Hard lists the classifications of permanent failures, where the address
+// will not accept mail however often the message is retried
+var Hard = []string{
+	"BadDomain",
+	"InactiveMailbox",
+	"InvalidRecipient",
+}
+
+var explanations = map[string]Explanation{
+	"AuthenticationFailed": {
+		Description: "Message rejected due to DMARC or authentication issues",
+		Action:      "Check the SPF, DKIM and DMARC records of the sending domain with 'ahasend domains check-dns'",
+	},
+	"BadDomain": {
+		Description: "The recipient domain doesn't exist",
+		Action:      "Fix typos in the address domain or remove the recipient from your list",
+	},
+	"DNSFailure": {
+		Description: "The domain's MX record is invalid",
+		Action:      "Retry later; if it persists, the recipient's domain is misconfigured and the address should be removed",
+	},
+	"InactiveMailbox": {
+		Description: "The mailbox provider has deactivated the email address",
+		Action:      "Remove the recipient from your list; the address will not accept mail again",
+	},
+	"InvalidRecipient": {
+		Description: "The email address doesn't exist",
+		Action:      "Remove the recipient from your list and check how the address was collected",
+	},
+	"PolicyRelated": {
+		Description: "Blocked due to recipient server policies (spam/blocklists)",
+		Action:      "Review content and sending reputation, and check whether your sending IP or domain is on a blocklist",
+	},
+	"ProtocolErrors": {
+		Description: "SMTP communication issues with recipient mail server",
+		Action:      "Usually temporary; contact support if the same recipient domain keeps failing",
+	},
+	"QuotaIssues": {
+		Description: "The recipient's mailbox is full",
+		Action:      "Retry later; remove the recipient if the mailbox stays full over several sends",
+	},
+	"RoutingErrors": {
+		Description: "The recipient mail server couldn't route the email",
+		Action:      "Check the address for typos; the recipient's mail setup may be broken",
+	},
+	"TransientFailure": {
+		Description: "The recipient server temporarily rejected the message",
+		Action:      "No action needed unless it persists; delivery is retried automatically",
+	},
+	"Uncategorized": {
+		Description: "Other bounce types not specifically categorized",
+		Action:      "Inspect the delivery attempts with 'ahasend messages attempts <message-id>'",
+	},
+}
+
+// Explain returns the explanation of a classification. Matching ignores
+// case, underscores, hyphens and spaces, so "policy_related" finds
+// PolicyRelated.
+func Explain(classification string) (Explanation, bool) {
+	key := normalize(classification)
+	for _, name := range Classifications {
+		if normalize(name) == key {
+			explanation := explanations[name]
+			explanation.Classification = name
+			return explanation, true
+		}
+	}
+	return Explanation{}, false
+}
+
+// All returns the explanations of every classification in documented order
+func All() []Explanation {
+	all := make([]Explanation, 0, len(Classifications))
+	for _, name := range Classifications {
+		explanation, _ := Explain(name)
+		all = append(all, explanation)
+	}
+	return all
+}
+
+func normalize(classification string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "", " ", "").Replace(strings.TrimSpace(classification)))
+}
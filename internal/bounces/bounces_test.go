@@ -0,0 +1,46 @@
+package bounces
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestExplanationsCoverClassifications keeps the explanations in step with
+// the classification list, so a new classification cannot ship without one
+func TestExplanationsCoverClassifications(t *testing.T) {
+	known := make(map[string]bool)
+	for _, name := range Classifications {
+		require.False(t, known[name], "duplicate classification %s", name)
+		known[name] = true
+
+		explanation, ok := explanations[name]
+		require.True(t, ok, "classification %s has no explanation", name)
+		assert.NotEmpty(t, explanation.Description, "%s has no description", name)
+		assert.NotEmpty(t, explanation.Action, "%s has no recommended action", name)
+	}
+	for name := range explanations {
+		assert.True(t, known[name], "explanation for unknown classification %s", name)
+	}
+}
+
+func TestExplain(t *testing.T) {
+	for _, input := range []string{"PolicyRelated", "policy_related", "policy-related", " POLICY RELATED "} {
+		explanation, ok := Explain(input)
+		require.True(t, ok, input)
+		assert.Equal(t, "PolicyRelated", explanation.Classification)
+		assert.Contains(t, explanation.Description, "policies")
+	}
+
+	_, ok := Explain("Mystery")
+	assert.False(t, ok)
+}
+
+func TestAll(t *testing.T) {
+	all := All()
+	require.Len(t, all, len(Classifications))
+	for i, explanation := range all {
+		assert.Equal(t, Classifications[i], explanation.Classification)
+	}
+}
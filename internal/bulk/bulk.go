@@ -0,0 +1,89 @@
+package bulk
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+)
+
+// DefaultConcurrency is the number of deletions run in parallel by default
+const DefaultConcurrency = 5
+
+// Target is a resource selected for bulk deletion
+type Target struct {
+	ID   string
+	Name string
+}
+
+// Result is the outcome of deleting one target
+type Result struct {
+	Target
+	Err error
+}
+
+// Delete calls deleteFn for every target with at most concurrency calls in
+// flight. A failure does not stop the remaining deletions. Results are
+// returned in the order of targets.
+func Delete(targets []Target, concurrency int, deleteFn func(id string) error) []Result {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([]Result, len(targets))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, target := range targets {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, target Target) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = Result{Target: target, Err: deleteFn(target.ID)}
+		}(i, target)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// CountFailures returns the number of results with an error
+func CountFailures(results []Result) int {
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+		}
+	}
+	return failed
+}
+
+// IsInteractive reports whether confirmation can be read from in. Readers
+// other than files (as used in tests) are treated as interactive.
+func IsInteractive(in io.Reader) bool {
+	file, ok := in.(*os.File)
+	if !ok {
+		return true
+	}
+	return printer.IsTerminal(file)
+}
+
+// ConfirmCount asks the user to type the number of items about to be
+// deleted and reports whether they did
+func ConfirmCount(in io.Reader, out io.Writer, count int, itemName string) (bool, error) {
+	fmt.Fprintf(out, "\n⚠️  This will permanently delete %d %s. This action cannot be undone!\n", count, itemName)
+	fmt.Fprintf(out, "Type %d to confirm: ", count)
+
+	reader := bufio.NewReader(in)
+	response, err := reader.ReadString('\n')
+	if err != nil && response == "" {
+		return false, fmt.Errorf("failed to read confirmation: %w", err)
+	}
+	return strings.TrimSpace(response) == strconv.Itoa(count), nil
+}
@@ -0,0 +1,146 @@
+package bulk
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	clierrors "github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+)
+
+func makeTargets(n int) []Target {
+	targets := make([]Target, n)
+	for i := range targets {
+		targets[i] = Target{ID: fmt.Sprintf("id-%d", i), Name: fmt.Sprintf("test-%d", i)}
+	}
+	return targets
+}
+
+func TestDelete_BoundedConcurrency(t *testing.T) {
+	var inFlight, maxInFlight int32
+	results := Delete(makeTargets(20), 3, func(id string) error {
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			seen := atomic.LoadInt32(&maxInFlight)
+			if current <= seen || atomic.CompareAndSwapInt32(&maxInFlight, seen, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		return nil
+	})
+
+	require.Len(t, results, 20)
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(3))
+	assert.Equal(t, 0, CountFailures(results))
+}
+
+func TestDelete_FailuresDoNotStopOthers(t *testing.T) {
+	var attempted int32
+	results := Delete(makeTargets(5), 2, func(id string) error {
+		atomic.AddInt32(&attempted, 1)
+		if id == "id-1" || id == "id-3" {
+			return errors.New("boom")
+		}
+		return nil
+	})
+
+	assert.Equal(t, int32(5), atomic.LoadInt32(&attempted))
+	assert.Equal(t, 2, CountFailures(results))
+	for i, result := range results {
+		assert.Equal(t, fmt.Sprintf("id-%d", i), result.ID, "results keep target order")
+	}
+	assert.Error(t, results[1].Err)
+	assert.NoError(t, results[2].Err)
+}
+
+func TestConfirmCount(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"14\n", true},
+		{" 14 \n", true},
+		{"y\n", false},
+		{"13\n", false},
+		{"14", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.TrimSpace(tt.input), func(t *testing.T) {
+			var out bytes.Buffer
+			confirmed, err := ConfirmCount(strings.NewReader(tt.input), &out, 14, "webhooks")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, confirmed)
+			assert.Contains(t, out.String(), "Type 14 to confirm")
+		})
+	}
+
+	_, err := ConfirmCount(strings.NewReader(""), &bytes.Buffer{}, 3, "routes")
+	assert.Error(t, err)
+}
+
+func testSelection(deleteFn func(Target) error) Selection[Target] {
+	return Selection[Target]{
+		Pattern:      "test-*",
+		Singular:     "webhook",
+		Plural:       "webhooks",
+		EmptyMessage: "No webhooks match 'test-*'",
+		Concurrency:  2,
+		List:         func() ([]Target, error) { return makeTargets(3), nil },
+		Target:       func(target Target) Target { return target },
+		Preview: func(out io.Writer, matched []Target) error {
+			_, err := fmt.Fprintf(out, "%d webhooks match\n", len(matched))
+			return err
+		},
+		Delete: deleteFn,
+	}
+}
+
+func TestRun(t *testing.T) {
+	t.Run("confirmed with partial failure", func(t *testing.T) {
+		var out, errOut bytes.Buffer
+		s := testSelection(func(target Target) error {
+			if target.ID == "id-1" {
+				return errors.New("not found")
+			}
+			return nil
+		})
+		err := Run(printer.GetResponseHandler("json", false, &out), strings.NewReader("3\n"), &errOut, s)
+
+		var cliErr *clierrors.CLIError
+		require.ErrorAs(t, err, &cliErr)
+		assert.Equal(t, "failed to delete 1 of 3 webhooks", cliErr.Message)
+		assert.Contains(t, errOut.String(), "3 webhooks match\n")
+		assert.Contains(t, errOut.String(), "Type 3 to confirm")
+		assert.Contains(t, out.String(), `"failed": 1`)
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		var out bytes.Buffer
+		s := testSelection(func(Target) error {
+			t.Fatal("nothing is deleted when the confirmation does not match")
+			return nil
+		})
+		require.NoError(t, Run(printer.GetResponseHandler("plain", false, &out), strings.NewReader("y\n"), &bytes.Buffer{}, s))
+		assert.Contains(t, out.String(), "Webhook deletion cancelled")
+	})
+
+	t.Run("nothing matches", func(t *testing.T) {
+		var out bytes.Buffer
+		s := testSelection(nil)
+		s.List = func() ([]Target, error) { return nil, nil }
+		require.NoError(t, Run(printer.GetResponseHandler("plain", false, &out), nil, &bytes.Buffer{}, s))
+		assert.Contains(t, out.String(), "No webhooks match 'test-*'")
+	})
+}
@@ -0,0 +1,115 @@
+package bulk
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/progress"
+)
+
+// Selection is a bulk delete of resources selected by a name pattern. Run
+// does the steps every bulk delete shares; the fields describe what is
+// specific to the resource.
+type Selection[T any] struct {
+	Pattern      string // the name pattern, reported with the result
+	Singular     string // the resource name, e.g. "webhook"
+	Plural       string // the plural resource name, e.g. "webhooks"
+	EmptyMessage string // shown when nothing matches
+	Yes          bool   // skip the confirmation
+	Concurrency  int
+
+	// List returns the resources to delete
+	List func() ([]T, error)
+	// Target identifies a resource in the deletions and the report
+	Target func(T) Target
+	// Preview writes the matched resources before the confirmation
+	Preview func(out io.Writer, matched []T) error
+	// Delete deletes one resource
+	Delete func(T) error
+	// Progress, when set, returns the reporter of the deletions
+	Progress func(total int) *progress.Reporter
+}
+
+// Run lists the resources, previews them on errOut, asks for confirmation
+// on in unless Yes is set, deletes them and reports the result through
+// handler. The preview and prompt go to errOut so structured output on
+// stdout stays parseable. It returns an API error when a deletion failed.
+func Run[T any](handler printer.ResponseHandler, in io.Reader, errOut io.Writer, s Selection[T]) error {
+	matched, err := s.List()
+	if err != nil {
+		return err
+	}
+	if len(matched) == 0 {
+		return handler.HandleEmpty(s.EmptyMessage)
+	}
+
+	if err := s.Preview(errOut, matched); err != nil {
+		return err
+	}
+
+	if !s.Yes {
+		if !IsInteractive(in) {
+			return errors.NewValidationError(fmt.Sprintf("refusing to delete %s without confirmation; re-run with --yes to proceed non-interactively", s.Plural), nil)
+		}
+		confirmed, err := ConfirmCount(in, errOut, len(matched), s.Plural)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return handler.HandleSimpleSuccess(fmt.Sprintf("%s deletion cancelled", capitalize(s.Singular)))
+		}
+	}
+
+	byID := make(map[string]T, len(matched))
+	targets := make([]Target, len(matched))
+	for i, item := range matched {
+		targets[i] = s.Target(item)
+		byID[targets[i].ID] = item
+	}
+	var reporter *progress.Reporter
+	if s.Progress != nil {
+		reporter = s.Progress(len(targets))
+		reporter.Start()
+	}
+	results := Delete(targets, s.Concurrency, func(id string) error {
+		err := s.Delete(byID[id])
+		if reporter != nil {
+			reporter.Update(err == nil)
+		}
+		return err
+	})
+	if reporter != nil {
+		reporter.Finish()
+	}
+
+	result := NewDeleteResult(s.Pattern, results)
+	if err := handler.HandleBulkDelete(result, printer.DeleteConfig{ItemName: s.Singular}); err != nil {
+		return err
+	}
+	if result.Failed > 0 {
+		return errors.NewAPIError(fmt.Sprintf("failed to delete %d of %d %s", result.Failed, result.Matched, s.Plural), nil)
+	}
+	return nil
+}
+
+// NewDeleteResult converts per-item results for the response handler
+func NewDeleteResult(pattern string, results []Result) *printer.BulkDeleteResult {
+	items := make([]printer.BulkDeleteItem, len(results))
+	for i, r := range results {
+		items[i] = printer.BulkDeleteItem{ID: r.ID, Name: r.Name, Deleted: r.Err == nil}
+		if r.Err != nil {
+			items[i].Error = r.Err.Error()
+		}
+	}
+	return printer.NewBulkDeleteResult(pattern, items)
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
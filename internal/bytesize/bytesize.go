@@ -0,0 +1,63 @@
+package bytesize
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+)
+
+// Binary multiples, matching how mail providers report clipping limits
+const (
+	KB = 1024
+	MB = 1024 * KB
+)
+
+// suffixes maps the accepted unit suffixes to their multiplier, longest first
+// so that "KB" is tried before "B"
+var suffixes = []struct {
+	suffix     string
+	multiplier float64
+}{
+	{"KIB", KB}, {"MIB", MB},
+	{"KB", KB}, {"MB", MB},
+	{"K", KB}, {"M", MB},
+	{"B", 1},
+}
+
+// Parse parses a size such as "100KB", "1.5MB", "512B" or a plain number of
+// bytes. Suffixes are case-insensitive and may be separated by a space.
+func Parse(input string) (int, error) {
+	value := strings.ToUpper(strings.TrimSpace(input))
+	if value == "" {
+		return 0, errors.NewValidationError("size cannot be empty", nil)
+	}
+
+	multiplier := 1.0
+	for _, s := range suffixes {
+		if strings.HasSuffix(value, s.suffix) {
+			value = strings.TrimSpace(strings.TrimSuffix(value, s.suffix))
+			multiplier = s.multiplier
+			break
+		}
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || number < 0 {
+		return 0, errors.NewValidationError(fmt.Sprintf("invalid size %q (use bytes or a KB/MB suffix, e.g. '100KB')", input), nil)
+	}
+	return int(number * multiplier), nil
+}
+
+// Format formats a size in bytes, KB or MB
+func Format(bytes int) string {
+	switch {
+	case bytes < KB:
+		return fmt.Sprintf("%d B", bytes)
+	case bytes < MB:
+		return fmt.Sprintf("%.1f KB", float64(bytes)/KB)
+	default:
+		return fmt.Sprintf("%.1f MB", float64(bytes)/MB)
+	}
+}
@@ -0,0 +1,47 @@
+package bytesize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"0", 0},
+		{"512", 512},
+		{"512B", 512},
+		{"100KB", 100 * 1024},
+		{"100kb", 100 * 1024},
+		{"100 KB", 100 * 1024},
+		{"100K", 100 * 1024},
+		{"100KiB", 100 * 1024},
+		{"1.5MB", 1536 * 1024},
+		{"2M", 2 * 1024 * 1024},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, input := range []string{"", "KB", "ten KB", "-5KB", "100GB", "1e"} {
+		_, err := Parse(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	assert.Equal(t, "512 B", Format(512))
+	assert.Equal(t, "1.5 KB", Format(1536))
+	assert.Equal(t, "100.0 KB", Format(100*1024))
+	assert.Equal(t, "2.0 MB", Format(2*1024*1024))
+}
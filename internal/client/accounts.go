@@ -0,0 +1,169 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/api"
+	"github.com/AhaSend/ahasend-go/models/common"
+	"github.com/AhaSend/ahasend-go/models/responses"
+
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+)
+
+// AccountsResponse is returned by the accessible accounts endpoint
+type AccountsResponse struct {
+	Object string              `json:"object"`
+	Data   []responses.Account `json:"data"`
+}
+
+// NewKeyClient creates a client that is not bound to an account yet, for
+// the calls that only need the API key: Ping and ListAccounts. Account
+// scoped calls fail until the key is used with NewClient.
+func NewKeyClient(apiKey string, apiURL ...string) (*Client, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("API key is required")
+	}
+	return newClient(apiKey, "", apiURL...)
+}
+
+// ListAccounts retrieves the accounts the API key has access to. The SDK
+// does not cover this endpoint yet, so the request is made directly.
+func (c *Client) ListAccounts() ([]responses.Account, error) {
+	endpoint := "/v2/accounts"
+	fullURL := fmt.Sprintf("%s://%s%s", c.config.Scheme, c.config.Host, endpoint)
+
+	req, err := http.NewRequest("GET", fullURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	apiKey := c.auth.Value(api.ContextAccessToken).(string)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", apiKey))
+	req.Header.Set("User-Agent", c.config.UserAgent)
+
+	logger.Get().Debug("API Request")
+
+	if err := c.rateLimiter.Wait(context.Background()); err != nil {
+		return nil, err
+	}
+
+	startTime := time.Now()
+	resp, err := c.config.HTTPClient.Do(req)
+	duration := time.Since(startTime)
+	if err != nil {
+		logger.APIError("GET", endpoint, 0, err, duration)
+		return nil, fmt.Errorf("failed to list accounts: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		apiErr := errors.NewAPIError(fmt.Sprintf("list accounts failed with status %d", resp.StatusCode), nil)
+		var errorResp common.ErrorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&errorResp); err == nil && errorResp.Message != "" {
+			apiErr = errors.NewAPIError(fmt.Sprintf("list accounts failed: %s", errorResp.Message), nil)
+		}
+		logger.APIError("GET", endpoint, resp.StatusCode, apiErr, duration)
+		return nil, apiErr
+	}
+
+	var response AccountsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode accounts: %w", err)
+	}
+
+	logger.APICall("GET", endpoint, duration)
+	logger.Get().WithField("accounts_count", len(response.Data)).Debug("API Response")
+
+	return response.Data, nil
+}
+
+// Account statuses. Paused, restricted and suspended accounts cannot send.
+const (
+	AccountStatusActive     = "active"
+	AccountStatusPaused     = "paused"
+	AccountStatusRestricted = "restricted"
+	AccountStatusSuspended  = "suspended"
+)
+
+// accountStatusTimeout bounds the status lookup, which runs before other
+// commands and must not hold them up
+const accountStatusTimeout = 3 * time.Second
+
+// AccountStatus is the sending state of an account. The SDK's account object
+// does not carry these fields yet, so they are read from the raw response;
+// an API that does not return them reads as an active account.
+type AccountStatus struct {
+	Status         string `json:"status,omitempty"`
+	StatusReason   string `json:"status_reason,omitempty"`
+	SendingEnabled *bool  `json:"sending_enabled,omitempty"`
+}
+
+// SendingDisabled reports whether the account cannot send messages
+func (s AccountStatus) SendingDisabled() bool {
+	if s.SendingEnabled != nil {
+		return !*s.SendingEnabled
+	}
+	switch strings.ToLower(s.Status) {
+	case AccountStatusPaused, AccountStatusRestricted, AccountStatusSuspended:
+		return true
+	}
+	return false
+}
+
+// GetAccountStatus retrieves the status fields of the account. The request
+// is made directly, with a short timeout.
+func (c *Client) GetAccountStatus() (*AccountStatus, error) {
+	endpoint := fmt.Sprintf("/v2/accounts/%s", c.accountID)
+	fullURL := fmt.Sprintf("%s://%s%s", c.config.Scheme, c.config.Host, endpoint)
+
+	ctx, cancel := context.WithTimeout(context.Background(), accountStatusTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", fullURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	apiKey := c.auth.Value(api.ContextAccessToken).(string)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", apiKey))
+	req.Header.Set("User-Agent", c.config.UserAgent)
+
+	logger.Get().Debug("API Request")
+
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	startTime := time.Now()
+	resp, err := c.config.HTTPClient.Do(req)
+	duration := time.Since(startTime)
+	if err != nil {
+		logger.APIError("GET", endpoint, 0, err, duration)
+		return nil, fmt.Errorf("failed to get account status: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		apiErr := errors.NewAPIError(fmt.Sprintf("get account status failed with status %d", resp.StatusCode), nil)
+		logger.APIError("GET", endpoint, resp.StatusCode, apiErr, duration)
+		return nil, apiErr
+	}
+
+	var status AccountStatus
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return nil, fmt.Errorf("failed to decode account status: %w", err)
+	}
+
+	logger.APICall("GET", endpoint, duration)
+	logger.Get().WithField("status", status.Status).Debug("API Response")
+
+	return &status, nil
+}
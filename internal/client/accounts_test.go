@@ -0,0 +1,88 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/AhaSend/ahasend-go/models/common"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ListAccounts(t *testing.T) {
+	first, second := uuid.New(), uuid.New()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/v2/accounts", r.URL.Path)
+		assert.Equal(t, "Bearer test-api-key", r.Header.Get("Authorization"))
+		writeClientTestJSON(t, w, http.StatusOK, AccountsResponse{
+			Object: "list",
+			Data: []responses.Account{
+				{ID: first, Name: "Acme"},
+				{ID: second, Name: "Acme Staging"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	// Accounts are listed before one is chosen, so no account ID is needed
+	client, err := NewKeyClient("test-api-key", server.URL)
+	require.NoError(t, err)
+
+	accounts, err := client.ListAccounts()
+	require.NoError(t, err)
+	require.Len(t, accounts, 2)
+	assert.Equal(t, first, accounts[0].ID)
+	assert.Equal(t, "Acme Staging", accounts[1].Name)
+}
+
+func TestClient_ListAccounts_Error(t *testing.T) {
+	client, cleanup := newClientTestServer(t, uuid.New().String(), func(w http.ResponseWriter, r *http.Request) {
+		writeClientTestJSON(t, w, http.StatusForbidden, common.ErrorResponse{Message: "missing scope"})
+	})
+	defer cleanup()
+
+	_, err := client.ListAccounts()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "list accounts failed: missing scope")
+}
+
+func TestNewKeyClient_RequiresAPIKey(t *testing.T) {
+	_, err := NewKeyClient("")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "API key is required")
+}
+
+func TestClient_GetAccountStatus(t *testing.T) {
+	accountID := uuid.New().String()
+	client, cleanup := newClientTestServer(t, accountID, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/accounts/"+accountID, r.URL.Path)
+		writeClientTestJSON(t, w, http.StatusOK, map[string]any{
+			"id":            accountID,
+			"name":          "Acme",
+			"status":        "paused",
+			"status_reason": "Compliance review",
+		})
+	})
+	defer cleanup()
+
+	status, err := client.GetAccountStatus()
+	require.NoError(t, err)
+	assert.Equal(t, "paused", status.Status)
+	assert.Equal(t, "Compliance review", status.StatusReason)
+	assert.True(t, status.SendingDisabled())
+}
+
+func TestAccountStatus_SendingDisabled(t *testing.T) {
+	enabled, disabled := true, false
+
+	assert.False(t, AccountStatus{}.SendingDisabled(), "no status fields is an active account")
+	assert.False(t, AccountStatus{Status: "active"}.SendingDisabled())
+	assert.True(t, AccountStatus{Status: "Restricted"}.SendingDisabled())
+	assert.True(t, AccountStatus{Status: "active", SendingEnabled: &disabled}.SendingDisabled())
+	assert.False(t, AccountStatus{Status: "restricted", SendingEnabled: &enabled}.SendingDisabled(), "sending_enabled wins over the status")
+}
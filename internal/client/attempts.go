@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/api"
+	"github.com/AhaSend/ahasend-go/models/common"
+
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+)
+
+// Delivery attempt statuses
+const (
+	AttemptStatusDelivered = "delivered"
+	AttemptStatusDeferred  = "deferred"
+	AttemptStatusFailed    = "failed"
+)
+
+// MessageAttempt is a single SMTP delivery attempt of an outbound message
+type MessageAttempt struct {
+	Attempt      int       `json:"attempt"`
+	Time         time.Time `json:"time"`
+	RemoteHost   string    `json:"remote_host"`
+	Status       string    `json:"status"`
+	SMTPCode     int       `json:"smtp_code"`
+	SMTPResponse string    `json:"smtp_response"`
+}
+
+// MessageAttemptsResponse is returned by the message delivery attempts endpoint
+type MessageAttemptsResponse struct {
+	Object string           `json:"object"`
+	Data   []MessageAttempt `json:"data"`
+}
+
+// GetMessageAttempts retrieves the delivery attempts of a message. The SDK
+// does not cover this endpoint yet, so the request is made directly. A 404
+// is returned as a not found error.
+func (c *Client) GetMessageAttempts(messageID string) (*MessageAttemptsResponse, error) {
+	endpoint := fmt.Sprintf("/v2/accounts/%s/messages/%s/attempts", c.accountID, url.PathEscape(messageID))
+	fullURL := fmt.Sprintf("%s://%s%s", c.config.Scheme, c.config.Host, endpoint)
+
+	req, err := http.NewRequest("GET", fullURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	apiKey := c.auth.Value(api.ContextAccessToken).(string)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", apiKey))
+	req.Header.Set("User-Agent", c.config.UserAgent)
+
+	logger.Get().WithFields(map[string]interface{}{
+		"message_id": messageID,
+	}).Debug("API Request")
+
+	if err := c.rateLimiter.Wait(context.Background()); err != nil {
+		return nil, err
+	}
+
+	startTime := time.Now()
+	resp, err := c.config.HTTPClient.Do(req)
+	duration := time.Since(startTime)
+	if err != nil {
+		logger.APIError("GET", endpoint, 0, err, duration)
+		return nil, fmt.Errorf("failed to get message attempts: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		logger.Get().WithField("message_id", messageID).Debug("No delivery attempts available for message")
+		return nil, errors.NewNotFoundError(fmt.Sprintf("delivery attempts for message '%s' not found", messageID), nil)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		apiErr := errors.NewAPIError(fmt.Sprintf("get message attempts failed with status %d", resp.StatusCode), nil)
+		var errorResp common.ErrorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&errorResp); err == nil && errorResp.Message != "" {
+			apiErr = errors.NewAPIError(fmt.Sprintf("get message attempts failed: %s", errorResp.Message), nil)
+		}
+		logger.APIError("GET", endpoint, resp.StatusCode, apiErr, duration)
+		return nil, apiErr
+	}
+
+	var response MessageAttemptsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode message attempts: %w", err)
+	}
+
+	logger.APICall("GET", endpoint, duration)
+	logger.Get().WithFields(map[string]interface{}{
+		"message_id":     messageID,
+		"attempts_count": len(response.Data),
+	}).Debug("API Response")
+
+	return &response, nil
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/models/common"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+)
+
+func TestClient_GetMessageAttempts(t *testing.T) {
+	accountID := uuid.New().String()
+	messageID := uuid.New().String()
+	attemptTime := time.Date(2024, 3, 1, 10, 0, 5, 0, time.UTC)
+
+	client, cleanup := newClientTestServer(t, accountID, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/v2/accounts/"+accountID+"/messages/"+messageID+"/attempts", r.URL.Path)
+		assert.Equal(t, "Bearer test-api-key", r.Header.Get("Authorization"))
+		writeClientTestJSON(t, w, http.StatusOK, MessageAttemptsResponse{
+			Object: "list",
+			Data: []MessageAttempt{{
+				Attempt:      1,
+				Time:         attemptTime,
+				RemoteHost:   "mx1.example.com",
+				Status:       AttemptStatusDeferred,
+				SMTPCode:     451,
+				SMTPResponse: "451 4.7.1 Greylisted",
+			}},
+		})
+	})
+	defer cleanup()
+
+	response, err := client.GetMessageAttempts(messageID)
+	require.NoError(t, err)
+	require.Len(t, response.Data, 1)
+	assert.Equal(t, "mx1.example.com", response.Data[0].RemoteHost)
+	assert.Equal(t, 451, response.Data[0].SMTPCode)
+	assert.True(t, attemptTime.Equal(response.Data[0].Time))
+}
+
+func TestClient_GetMessageAttempts_Errors(t *testing.T) {
+	accountID := uuid.New().String()
+
+	t.Run("not found", func(t *testing.T) {
+		client, cleanup := newClientTestServer(t, accountID, func(w http.ResponseWriter, r *http.Request) {
+			writeClientTestJSON(t, w, http.StatusNotFound, common.ErrorResponse{Message: "not found"})
+		})
+		defer cleanup()
+
+		_, err := client.GetMessageAttempts("msg-1")
+		require.Error(t, err)
+		assert.True(t, errors.IsNotFoundError(err))
+	})
+
+	t.Run("api error", func(t *testing.T) {
+		client, cleanup := newClientTestServer(t, accountID, func(w http.ResponseWriter, r *http.Request) {
+			writeClientTestJSON(t, w, http.StatusForbidden, common.ErrorResponse{Message: "missing scope"})
+		})
+		defer cleanup()
+
+		_, err := client.GetMessageAttempts("msg-1")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "missing scope")
+		assert.False(t, errors.IsNotFoundError(err))
+	})
+}
@@ -41,10 +41,14 @@ type Client struct {
 	config      *api.Configuration
 	auth        context.Context
 	accountID   string
+	apiURL      string
 	rateLimiter *RateLimiter
+	connTracker *connTracker
+	transport   *http.Transport
 }
 
-// NewClient creates a new AhaSend client with rate limiting
+// NewClient creates a new AhaSend client with rate limiting. When no API URL
+// is given the client targets DefaultAPIURL.
 func NewClient(apiKey, accountID string, apiURL ...string) (*Client, error) {
 	if apiKey == "" {
 		return nil, fmt.Errorf("API key is required")
@@ -52,14 +56,22 @@ func NewClient(apiKey, accountID string, apiURL ...string) (*Client, error) {
 	if accountID == "" {
 		return nil, fmt.Errorf("account ID is required")
 	}
+	return newClient(apiKey, accountID, apiURL...)
+}
 
+func newClient(apiKey, accountID string, apiURL ...string) (*Client, error) {
 	config := api.NewConfiguration()
 
-	// Set API URL if provided
+	// Set API URL, falling back to the production endpoint
+	endpoint := DefaultAPIURL
 	if len(apiURL) > 0 && apiURL[0] != "" {
-		if err := setConfigFromURL(config, apiURL[0]); err != nil {
-			return nil, fmt.Errorf("invalid API URL: %w", err)
-		}
+		endpoint = apiURL[0]
+	}
+	if err := ValidateAPIURL(endpoint); err != nil {
+		return nil, fmt.Errorf("invalid API URL: %w", err)
+	}
+	if err := setConfigFromURL(config, endpoint); err != nil {
+		return nil, fmt.Errorf("invalid API URL: %w", err)
 	}
 
 	// Configure retry behavior using new SDK RetryConfig
@@ -76,10 +88,11 @@ func NewClient(apiKey, accountID string, apiURL ...string) (*Client, error) {
 	// Set custom user agent
 	config.UserAgent = fmt.Sprintf("ahasend-cli/1.0.0 %s", config.UserAgent)
 
-	// Add HTTP logging transport
-	httpTransport := logger.NewHTTPTransport(http.DefaultTransport, logger.Get())
+	// Add HTTP logging and connection tracking transport
+	tracker := &connTracker{}
+	transport := newHTTPTransport(TransportOptions{})
 	config.HTTPClient = &http.Client{
-		Transport: httpTransport,
+		Transport: newClientTransport(transport, tracker),
 		Timeout:   30 * time.Second,
 	}
 
@@ -94,17 +107,59 @@ func NewClient(apiKey, accountID string, apiURL ...string) (*Client, error) {
 		config:      config,
 		auth:        auth,
 		accountID:   accountID,
+		apiURL:      strings.TrimSuffix(endpoint, "/"),
 		rateLimiter: rateLimiter,
+		connTracker: tracker,
+		transport:   transport,
 	}
 
 	return client, nil
 }
 
+// newClientTransport wraps transport with connection tracking and, on top,
+// request logging
+func newClientTransport(transport http.RoundTripper, tracker *connTracker) http.RoundTripper {
+	return logger.NewHTTPTransport(&trackingTransport{transport: transport, tracker: tracker}, logger.Get())
+}
+
+// ConfigureTransport replaces the HTTP transport with one tuned by opts and
+// resets the connection stats. Idle connections of the previous transport
+// are closed, so call it before sending the requests it should apply to.
+func (c *Client) ConfigureTransport(opts TransportOptions) {
+	if c.connTracker == nil {
+		c.connTracker = &connTracker{}
+	}
+	previous := c.transport
+	c.transport = newHTTPTransport(opts)
+	c.config.HTTPClient.Transport = newClientTransport(c.transport, c.connTracker)
+	c.connTracker.reset()
+	if previous != nil {
+		previous.CloseIdleConnections()
+	}
+}
+
+// ConnectionStats returns how many requests since the client was created,
+// or since ConfigureTransport, used new or reused connections
+func (c *Client) ConnectionStats() ConnectionStats {
+	if c.connTracker == nil {
+		return ConnectionStats{}
+	}
+	return c.connTracker.stats()
+}
+
 // GetAccountID returns the configured account ID
 func (c *Client) GetAccountID() string {
 	return c.accountID
 }
 
+// GetAPIURL returns the effective API endpoint
+func (c *Client) GetAPIURL() string {
+	if c.apiURL == "" && c.config != nil && c.config.Host != "" {
+		return fmt.Sprintf("%s://%s", c.config.Scheme, c.config.Host)
+	}
+	return c.apiURL
+}
+
 // GetAuthContext returns the authenticated context
 func (c *Client) GetAuthContext() context.Context {
 	return c.auth
@@ -123,6 +178,17 @@ func (c *Client) GetAccount() (*responses.Account, error) {
 	return account, err
 }
 
+// UpdateAccount updates the account settings set in req
+func (c *Client) UpdateAccount(req requests.UpdateAccountRequest) (*responses.Account, error) {
+	accountUUID, err := uuid.Parse(c.accountID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid account ID format: %w", err)
+	}
+
+	account, _, err := c.AccountsAPI.UpdateAccount(c.auth, accountUUID, req)
+	return account, err
+}
+
 // Ping tests the connection and validates the API key
 func (c *Client) Ping() error {
 	_, _, err := c.UtilityAPI.Ping(c.auth)
@@ -146,6 +212,19 @@ func (c *Client) SendMessageWithIdempotencyKey(req requests.CreateMessageRequest
 	return response, err
 }
 
+// SendConversationMessageWithIdempotencyKey sends one message to To, CC and
+// BCC recipients, with idempotency key support
+func (c *Client) SendConversationMessageWithIdempotencyKey(req requests.CreateConversationMessageRequest, idempotencyKey string) (*responses.CreateMessageResponse, error) {
+	accountUUID, err := uuid.Parse(c.accountID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid account ID format: %w", err)
+	}
+
+	response, _, err := c.MessagesAPI.CreateConversationMessage(c.auth, accountUUID, req, api.WithIdempotencyKey(idempotencyKey))
+
+	return response, err
+}
+
 // CancelMessage cancels a scheduled message
 func (c *Client) CancelMessage(accountID, messageID string) (*common.SuccessResponse, error) {
 	accountUUID, err := uuid.Parse(accountID)
@@ -342,6 +421,20 @@ func (c *Client) ListWebhooks(limit *int32, cursor *string) (*responses.Paginate
 	return response, err
 }
 
+// ListWebhooksWithParams retrieves a page of webhooks filtered by the API
+// on their enabled state and event subscriptions
+func (c *Client) ListWebhooksWithParams(params api.GetWebhooksParams) (*responses.PaginatedWebhooksResponse, error) {
+	// Ensure we have a valid UUID for the account ID
+	accountUUID, err := uuid.Parse(c.accountID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid account ID format: %w", err)
+	}
+
+	response, _, err := c.WebhooksAPI.GetWebhooks(c.auth, accountUUID, params)
+
+	return response, err
+}
+
 // CreateWebhook creates a new webhook
 func (c *Client) CreateWebhook(req requests.CreateWebhookRequest) (*responses.Webhook, error) {
 	// Ensure we have a valid UUID for the account ID
@@ -0,0 +1,38 @@
+package client
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// serverClock holds the clock skew seen in the latest API response that
+// carried a Date header
+var serverClock struct {
+	sync.Mutex
+	skew     time.Duration
+	observed bool
+}
+
+// recordServerDate notes how far the Date header of resp, received at
+// received, is from the local clock. The header has second precision, so
+// the local time is truncated to match.
+func recordServerDate(resp *http.Response, received time.Time) {
+	date, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return
+	}
+	serverClock.Lock()
+	serverClock.skew = date.Sub(received.Truncate(time.Second))
+	serverClock.observed = true
+	serverClock.Unlock()
+}
+
+// ObservedClockSkew returns how far the API server's clock was ahead of the
+// local clock (negative when behind) in the latest response of this
+// process, and false when no response carried a Date header yet
+func ObservedClockSkew() (time.Duration, bool) {
+	serverClock.Lock()
+	defer serverClock.Unlock()
+	return serverClock.skew, serverClock.observed
+}
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+const (
+	// DefaultAPIURL is the production AhaSend API endpoint
+	DefaultAPIURL = "https://api.ahasend.com"
+
+	// APIURLEnvVar is the environment variable that overrides the API endpoint
+	APIURLEnvVar = "AHASEND_API_URL"
+)
+
+// ResolveAPIURL returns the effective API endpoint using the precedence
+// --api-url flag > AHASEND_API_URL > profile api_url > default
+func ResolveAPIURL(flagValue, profileValue string) string {
+	if v := strings.TrimSpace(flagValue); v != "" {
+		return v
+	}
+	if v := strings.TrimSpace(os.Getenv(APIURLEnvVar)); v != "" {
+		return v
+	}
+	if v := strings.TrimSpace(profileValue); v != "" {
+		return v
+	}
+	return DefaultAPIURL
+}
+
+// ValidateAPIURL checks that the endpoint is an absolute http(s) URL
+func ValidateAPIURL(apiURL string) error {
+	u, err := url.Parse(apiURL)
+	if err != nil {
+		return fmt.Errorf("invalid URL format: %w", err)
+	}
+	if !u.IsAbs() {
+		return fmt.Errorf("URL must be absolute and include scheme (http:// or https://)")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("URL scheme must be http or https, got %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("URL must include host")
+	}
+	return nil
+}
+
+// webSocketScheme maps an HTTP scheme to its WebSocket counterpart
+func webSocketScheme(scheme string) string {
+	if scheme == "http" {
+		return "ws"
+	}
+	return "wss"
+}
+
+// webSocketURL rebases a streaming URL onto the configured API endpoint so
+// WebSocket connections always target the same host as the REST API. The
+// path and query returned by the API are preserved.
+func (c *Client) webSocketURL(wsURL string) (string, error) {
+	u, err := url.Parse(wsURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid websocket URL: %w", err)
+	}
+	if c.config == nil || c.config.Host == "" {
+		return u.String(), nil
+	}
+
+	u.Scheme = webSocketScheme(c.config.Scheme)
+	u.Host = c.config.Host
+	return u.String(), nil
+}
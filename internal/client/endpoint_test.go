@@ -0,0 +1,85 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/AhaSend/ahasend-go/api"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveAPIURL_Precedence(t *testing.T) {
+	tests := []struct {
+		name     string
+		flag     string
+		env      string
+		profile  string
+		expected string
+	}{
+		{name: "flag wins over everything", flag: "https://flag.example", env: "https://env.example", profile: "https://profile.example", expected: "https://flag.example"},
+		{name: "env wins over profile", env: "https://env.example", profile: "https://profile.example", expected: "https://env.example"},
+		{name: "profile wins over default", profile: "https://profile.example", expected: "https://profile.example"},
+		{name: "default when nothing set", expected: DefaultAPIURL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(APIURLEnvVar, tt.env)
+			assert.Equal(t, tt.expected, ResolveAPIURL(tt.flag, tt.profile))
+		})
+	}
+}
+
+func TestValidateAPIURL(t *testing.T) {
+	assert.NoError(t, ValidateAPIURL("https://api.ahasend.com"))
+	assert.NoError(t, ValidateAPIURL("http://localhost:8080"))
+	assert.Error(t, ValidateAPIURL("api.ahasend.com"))
+	assert.Error(t, ValidateAPIURL("/v2"))
+	assert.Error(t, ValidateAPIURL("ftp://api.ahasend.com"))
+	assert.Error(t, ValidateAPIURL("https://"))
+}
+
+func TestNewClient_APIURL(t *testing.T) {
+	accountID := uuid.New().String()
+
+	c, err := NewClient("key", accountID)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultAPIURL, c.GetAPIURL())
+
+	c, err = NewClient("key", accountID, "http://localhost:8080/")
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:8080", c.GetAPIURL())
+	assert.Equal(t, "http", c.config.Scheme)
+	assert.Equal(t, "localhost:8080", c.config.Host)
+
+	_, err = NewClient("key", accountID, "localhost:8080")
+	assert.Error(t, err)
+}
+
+func TestWebSocketURL_DerivedFromAPIBase(t *testing.T) {
+	tests := []struct {
+		name     string
+		scheme   string
+		host     string
+		wsURL    string
+		expected string
+	}{
+		{name: "https maps to wss", scheme: "https", host: "staging.example.com", wsURL: "wss://ws.ahasend.com/v2/stream/abc?x=1", expected: "wss://staging.example.com/v2/stream/abc?x=1"},
+		{name: "http maps to ws", scheme: "http", host: "localhost:8080", wsURL: "wss://ws.ahasend.com/v2/stream/abc", expected: "ws://localhost:8080/v2/stream/abc"},
+		{name: "relative path is resolved", scheme: "https", host: "api.ahasend.com", wsURL: "/v2/stream/abc", expected: "wss://api.ahasend.com/v2/stream/abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := api.NewConfiguration()
+			config.Scheme = tt.scheme
+			config.Host = tt.host
+			c := &Client{config: config}
+
+			got, err := c.webSocketURL(tt.wsURL)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
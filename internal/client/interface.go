@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 
+	"github.com/AhaSend/ahasend-go/api"
 	"github.com/AhaSend/ahasend-go/models/common"
 	"github.com/AhaSend/ahasend-go/models/requests"
 	"github.com/AhaSend/ahasend-go/models/responses"
@@ -14,17 +15,23 @@ import (
 type AhaSendClient interface {
 	// Authentication and account info
 	GetAccountID() string
+	GetAPIURL() string
 	GetAuthContext() context.Context
 	GetAccount() (*responses.Account, error)
+	UpdateAccount(req requests.UpdateAccountRequest) (*responses.Account, error)
+	GetAccountStatus() (*AccountStatus, error)
+	ListAccounts() ([]responses.Account, error)
 	Ping() error
 	ValidateConfiguration() error
 
 	// Message operations
 	SendMessage(req requests.CreateMessageRequest) (*responses.CreateMessageResponse, error)
 	SendMessageWithIdempotencyKey(req requests.CreateMessageRequest, idempotencyKey string) (*responses.CreateMessageResponse, error)
+	SendConversationMessageWithIdempotencyKey(req requests.CreateConversationMessageRequest, idempotencyKey string) (*responses.CreateMessageResponse, error)
 	CancelMessage(accountID, messageID string) (*common.SuccessResponse, error)
 	GetMessages(params requests.GetMessagesParams) (*responses.PaginatedMessagesResponse, error)
 	GetMessage(messageID string) (*responses.Message, error)
+	GetMessageAttempts(messageID string) (*MessageAttemptsResponse, error)
 
 	// Domain operations
 	ListDomains(limit *int32, cursor *string) (*responses.PaginatedDomainsResponse, error)
@@ -37,14 +44,17 @@ type AhaSendClient interface {
 	// Webhook operations
 	CreateWebhookVerifier(secret string) (*webhooks.WebhookVerifier, error)
 	ListWebhooks(limit *int32, cursor *string) (*responses.PaginatedWebhooksResponse, error)
+	ListWebhooksWithParams(params api.GetWebhooksParams) (*responses.PaginatedWebhooksResponse, error)
 	CreateWebhook(req requests.CreateWebhookRequest) (*responses.Webhook, error)
 	GetWebhook(webhookID string) (*responses.Webhook, error)
 	UpdateWebhook(webhookID string, req requests.UpdateWebhookRequest) (*responses.Webhook, error)
 	DeleteWebhook(webhookID string) error
+	RotateWebhookSecret(webhookID string) (*responses.Webhook, error)
 
 	// Webhook streaming operations (development only)
 	InitiateWebhookStream(webhookID string) (*WebhookStreamResponse, error)
 	ConnectWebSocket(wsURL, webhookID string, forceReconnect, skipVerify bool) (*WebSocketClient, error)
+	ResumeWebSocket(wsURL, connectionID, lastStreamID string, skipVerify bool) (*WebSocketClient, error)
 	TriggerWebhook(webhookID string, events []string) error
 
 	// Route operations
@@ -68,6 +78,7 @@ type AhaSendClient interface {
 	ListSMTPCredentials(limit *int32, cursor *string) (*responses.PaginatedSMTPCredentialsResponse, error)
 	GetSMTPCredential(credentialID string) (*responses.SMTPCredential, error)
 	CreateSMTPCredential(req requests.CreateSMTPCredentialRequest) (*responses.SMTPCredential, error)
+	UpdateSMTPCredential(credentialID string, req UpdateSMTPCredentialRequest) (*responses.SMTPCredential, error)
 	DeleteSMTPCredential(credentialID string) error
 
 	// Statistics operations
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/api"
+	"github.com/AhaSend/ahasend-go/models/common"
+	"github.com/AhaSend/ahasend-go/models/responses"
+
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+)
+
+// UpdateSMTPCredentialRequest changes an SMTP credential without rotating
+// its password. Only the fields that are set are changed; Domains replaces
+// the whole domain list.
+type UpdateSMTPCredentialRequest struct {
+	Name    *string   `json:"name,omitempty"`
+	Scope   *string   `json:"scope,omitempty"`
+	Sandbox *bool     `json:"sandbox,omitempty"`
+	Domains *[]string `json:"domains,omitempty"`
+}
+
+// UpdateSMTPCredential updates an SMTP credential in place. The SDK does not
+// cover this endpoint yet, so the request is made directly. A 404 is
+// returned as a not found error.
+func (c *Client) UpdateSMTPCredential(credentialID string, req UpdateSMTPCredentialRequest) (*responses.SMTPCredential, error) {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request payload: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("/v2/accounts/%s/smtp-credentials/%s", c.accountID, url.PathEscape(credentialID))
+	fullURL := fmt.Sprintf("%s://%s%s", c.config.Scheme, c.config.Host, endpoint)
+
+	httpReq, err := http.NewRequest("PUT", fullURL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	apiKey := c.auth.Value(api.ContextAccessToken).(string)
+	httpReq.Header.Set("Accept", "application/json")
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", apiKey))
+	httpReq.Header.Set("User-Agent", c.config.UserAgent)
+
+	logger.Get().WithFields(map[string]interface{}{
+		"credential_id": credentialID,
+	}).Debug("API Request")
+
+	if err := c.rateLimiter.Wait(context.Background()); err != nil {
+		return nil, err
+	}
+
+	startTime := time.Now()
+	resp, err := c.config.HTTPClient.Do(httpReq)
+	duration := time.Since(startTime)
+	if err != nil {
+		logger.APIError("PUT", endpoint, 0, err, duration)
+		return nil, fmt.Errorf("failed to update SMTP credential: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("SMTP credential '%s' not found", credentialID), nil)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		apiErr := errors.NewAPIError(fmt.Sprintf("update SMTP credential failed with status %d", resp.StatusCode), nil)
+		var errorResp common.ErrorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&errorResp); err == nil && errorResp.Message != "" {
+			apiErr = errors.NewAPIError(fmt.Sprintf("update SMTP credential failed: %s", errorResp.Message), nil)
+		}
+		logger.APIError("PUT", endpoint, resp.StatusCode, apiErr, duration)
+		return nil, apiErr
+	}
+
+	var credential responses.SMTPCredential
+	if err := json.NewDecoder(resp.Body).Decode(&credential); err != nil {
+		return nil, fmt.Errorf("failed to decode updated SMTP credential: %w", err)
+	}
+
+	logger.APICall("PUT", endpoint, duration)
+	return &credential, nil
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/AhaSend/ahasend-go/models/common"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+)
+
+func TestClient_UpdateSMTPCredential(t *testing.T) {
+	accountID := uuid.New().String()
+	credentialID := uuid.New()
+
+	client, cleanup := newClientTestServer(t, accountID, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/v2/accounts/"+accountID+"/smtp-credentials/"+credentialID.String(), r.URL.Path)
+		assert.Equal(t, "Bearer test-api-key", r.Header.Get("Authorization"))
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]interface{}{"domains": []interface{}{"a.example.com", "b.example.com"}}, body, "unset fields are not sent")
+
+		writeClientTestJSON(t, w, http.StatusOK, responses.SMTPCredential{ID: credentialID, Name: "app", Scope: "scoped", Domains: []string{"a.example.com", "b.example.com"}})
+	})
+	defer cleanup()
+
+	domains := []string{"a.example.com", "b.example.com"}
+	credential, err := client.UpdateSMTPCredential(credentialID.String(), UpdateSMTPCredentialRequest{Domains: &domains})
+	require.NoError(t, err)
+	assert.Equal(t, credentialID, credential.ID)
+	assert.Equal(t, domains, credential.Domains)
+}
+
+func TestClient_UpdateSMTPCredential_Errors(t *testing.T) {
+	accountID := uuid.New().String()
+	name := "app"
+
+	t.Run("not found", func(t *testing.T) {
+		client, cleanup := newClientTestServer(t, accountID, func(w http.ResponseWriter, r *http.Request) {
+			writeClientTestJSON(t, w, http.StatusNotFound, common.ErrorResponse{Message: "not found"})
+		})
+		defer cleanup()
+
+		_, err := client.UpdateSMTPCredential("credential-1", UpdateSMTPCredentialRequest{Name: &name})
+		require.Error(t, err)
+		assert.True(t, errors.IsNotFoundError(err))
+	})
+
+	t.Run("api error", func(t *testing.T) {
+		client, cleanup := newClientTestServer(t, accountID, func(w http.ResponseWriter, r *http.Request) {
+			writeClientTestJSON(t, w, http.StatusBadRequest, common.ErrorResponse{Message: "domain not verified"})
+		})
+		defer cleanup()
+
+		_, err := client.UpdateSMTPCredential("credential-1", UpdateSMTPCredentialRequest{Name: &name})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "domain not verified")
+	})
+}
@@ -0,0 +1,226 @@
+package client
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+)
+
+const (
+	// defaultReconnectAttempts is how many consecutive reconnect attempts a
+	// ResumableStream makes before giving up
+	defaultReconnectAttempts = 8
+
+	// seenStreamIDs is how many delivered stream IDs are remembered to drop
+	// events replayed twice after a reconnect
+	seenStreamIDs = 1000
+)
+
+// StreamDialer connects to a stream. lastStreamID is the stream ID of the
+// last event received before the connection dropped.
+type StreamDialer func(lastStreamID string) (*WebSocketClient, error)
+
+// StreamRefresher fetches fresh credentials and a fresh stream URL, and
+// returns a dialer using them
+type StreamRefresher func() (StreamDialer, error)
+
+// ResumableStream reads a WebSocket stream and reconnects transparently when
+// the connection drops, asking the server to replay from the last event
+// received. When the server rejects the credentials, with an authentication
+// close code or a 401 on reconnect, they are refreshed before the next
+// attempt. Events delivered before the reconnect are not delivered again.
+type ResumableStream struct {
+	// MaxAttempts is how many consecutive reconnect attempts are made
+	MaxAttempts int
+	// Backoff returns the wait before reconnect attempt n, counted from 1
+	Backoff func(attempt int) time.Duration
+
+	refresh StreamRefresher
+
+	mu           sync.Mutex
+	dial         StreamDialer
+	conn         *WebSocketClient
+	closed       bool
+	lastStreamID string
+	seen         map[string]bool
+	seenOrder    []string
+	reconnects   int
+	refreshes    int
+}
+
+// NewResumableStream wraps an open connection. dial reconnects with the
+// current credentials and refresh replaces them.
+func NewResumableStream(conn *WebSocketClient, dial StreamDialer, refresh StreamRefresher) *ResumableStream {
+	return &ResumableStream{
+		MaxAttempts: defaultReconnectAttempts,
+		Backoff:     reconnectBackoff,
+		refresh:     refresh,
+		dial:        dial,
+		conn:        conn,
+		seen:        make(map[string]bool),
+	}
+}
+
+// reconnectBackoff doubles the wait from one second up to 30 seconds
+func reconnectBackoff(attempt int) time.Duration {
+	if attempt > 5 {
+		return 30 * time.Second
+	}
+	return time.Duration(1<<(attempt-1)) * time.Second
+}
+
+// ReadMessage returns the next message, reconnecting as needed. It fails
+// when the stream is closed, the server ends it with a normal closure, or
+// reconnecting fails MaxAttempts times in a row.
+func (s *ResumableStream) ReadMessage(ctx context.Context) (*WebSocketMessage, error) {
+	for {
+		s.mu.Lock()
+		conn, closed := s.conn, s.closed
+		s.mu.Unlock()
+		if closed || conn == nil {
+			return nil, fmt.Errorf("websocket connection is closed")
+		}
+
+		msg, err := conn.ReadMessage(ctx)
+		if err == nil {
+			if s.deliver(msg) {
+				return msg, nil
+			}
+			continue
+		}
+		// Errors that leave the connection open, such as a malformed
+		// message, and a deliberate end of the stream are not retried
+		if !conn.closed || s.isClosed() || ctx.Err() != nil || isNormalClosure(err) {
+			return nil, err
+		}
+		if err := s.reconnect(ctx, err); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// deliver records the stream ID of an event and reports whether it is new
+func (s *ResumableStream) deliver(msg *WebSocketMessage) bool {
+	if msg.Event == nil || msg.Event.StreamID == "" {
+		return true
+	}
+	id := msg.Event.StreamID
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.seen[id] {
+		logger.Get().WithField("stream_id", id).Debug("Dropped an event replayed twice")
+		return false
+	}
+	s.seen[id] = true
+	s.seenOrder = append(s.seenOrder, id)
+	if len(s.seenOrder) > seenStreamIDs {
+		delete(s.seen, s.seenOrder[0])
+		s.seenOrder = s.seenOrder[1:]
+	}
+	s.lastStreamID = id
+	return true
+}
+
+// reconnect replaces the dropped connection, refreshing the credentials
+// first when cause says they were rejected
+func (s *ResumableStream) reconnect(ctx context.Context, cause error) error {
+	needsRefresh := IsWebSocketAuthError(cause)
+	s.mu.Lock()
+	lastStreamID := s.lastStreamID
+	s.mu.Unlock()
+	logger.Get().WithError(cause).WithField("last_stream_id", lastStreamID).Warn("WebSocket connection lost, reconnecting")
+
+	var lastErr error
+	for attempt := 1; attempt <= s.MaxAttempts; attempt++ {
+		if needsRefresh {
+			dial, err := s.refresh()
+			if err != nil {
+				return fmt.Errorf("failed to refresh stream credentials: %w", err)
+			}
+			s.mu.Lock()
+			s.dial = dial
+			s.refreshes++
+			s.mu.Unlock()
+			logger.Get().Warn("Refreshed stream credentials")
+		} else {
+			// New credentials are tried at once; otherwise wait first
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(s.Backoff(attempt)):
+			}
+		}
+
+		s.mu.Lock()
+		dial := s.dial
+		s.mu.Unlock()
+		conn, err := dial(lastStreamID)
+		if err != nil {
+			lastErr = err
+			needsRefresh = IsWebSocketAuthError(err)
+			logger.Get().WithError(err).WithField("attempt", attempt).Debug("Reconnect attempt failed")
+			continue
+		}
+
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			conn.Close()
+			return fmt.Errorf("websocket connection is closed")
+		}
+		s.conn = conn
+		s.reconnects++
+		s.mu.Unlock()
+		logger.Get().WithFields(map[string]interface{}{
+			"attempt":        attempt,
+			"last_stream_id": lastStreamID,
+		}).Warn("Reconnected to the stream")
+		return nil
+	}
+	return fmt.Errorf("failed to reconnect after %d attempts: %w", s.MaxAttempts, lastErr)
+}
+
+// isNormalClosure reports whether the server ended the stream on purpose.
+// ReadMessage wraps close errors, so they are unwrapped here.
+func isNormalClosure(err error) bool {
+	var closeErr *websocket.CloseError
+	return stderrors.As(err, &closeErr) && closeErr.Code == websocket.CloseNormalClosure
+}
+
+func (s *ResumableStream) isClosed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
+
+// Reconnects returns how many times the stream reconnected
+func (s *ResumableStream) Reconnects() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.reconnects
+}
+
+// CredentialRefreshes returns how many times the credentials were refreshed
+func (s *ResumableStream) CredentialRefreshes() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.refreshes
+}
+
+// Close closes the current connection and stops reconnecting
+func (s *ResumableStream) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	if s.conn != nil {
+		return s.conn.Close()
+	}
+	return nil
+}
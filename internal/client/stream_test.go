@@ -0,0 +1,221 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/api"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStreamServer is a streaming endpoint that serves one scripted session
+// per accepted connection and rejects API keys other than the current one
+type fakeStreamServer struct {
+	t        *testing.T
+	server   *httptest.Server
+	sessions []func(conn *websocket.Conn)
+
+	mu       sync.Mutex
+	validKey string
+	requests []*http.Request
+}
+
+func newFakeStreamServer(t *testing.T, validKey string, sessions ...func(conn *websocket.Conn)) *fakeStreamServer {
+	f := &fakeStreamServer{t: t, validKey: validKey, sessions: sessions}
+	upgrader := websocket.Upgrader{}
+	f.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		f.requests = append(f.requests, r)
+		validKey := f.validKey
+		f.mu.Unlock()
+
+		if r.URL.Query().Get("api_secret_key") != validKey {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		f.mu.Lock()
+		if len(f.sessions) == 0 {
+			f.mu.Unlock()
+			t.Errorf("unexpected connection after the scripted sessions: %s", r.URL.RawQuery)
+			http.Error(w, "no more sessions", http.StatusServiceUnavailable)
+			return
+		}
+		session := f.sessions[0]
+		f.sessions = f.sessions[1:]
+		f.mu.Unlock()
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade failed: %v", err)
+			return
+		}
+		defer conn.Close()
+		session(conn)
+	}))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+// rotateKey makes the server reject every key but key
+func (f *fakeStreamServer) rotateKey(key string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.validKey = key
+}
+
+func (f *fakeStreamServer) request(n int) *http.Request {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	require.Greater(f.t, len(f.requests), n)
+	return f.requests[n]
+}
+
+// clientWithKey returns a client for the fake server using apiKey
+func (f *fakeStreamServer) clientWithKey(apiKey string) *Client {
+	c := createTestClient(f.t, f.server.URL)
+	c.auth = context.WithValue(context.Background(), api.ContextAccessToken, apiKey)
+	return c
+}
+
+func sendEvent(t *testing.T, conn *websocket.Conn, msgType, streamID string) {
+	assert.NoError(t, conn.WriteJSON(WebSocketMessage{Type: msgType, Event: &Event{StreamID: streamID}}))
+}
+
+func closeWith(t *testing.T, conn *websocket.Conn, code int, reason string) {
+	assert.NoError(t, conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason)))
+	// Wait for the client to answer the close frame
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	conn.ReadMessage()
+}
+
+// readStream reads stream IDs until the stream fails
+func readStream(t *testing.T, stream *ResumableStream) ([]string, error) {
+	var ids []string
+	for {
+		msg, err := stream.ReadMessage(context.Background())
+		if err != nil {
+			return ids, err
+		}
+		ids = append(ids, msg.Event.StreamID)
+	}
+}
+
+func TestResumableStream_RefreshesCredentialsOn401(t *testing.T) {
+	var server *fakeStreamServer
+	server = newFakeStreamServer(t, "old-key",
+		func(conn *websocket.Conn) {
+			sendEvent(t, conn, "event", "1")
+			// The key expires while the connection drops
+			server.rotateKey("new-key")
+			closeWith(t, conn, websocket.CloseGoingAway, "restarting")
+		},
+		func(conn *websocket.Conn) {
+			sendEvent(t, conn, "replay", "1")
+			sendEvent(t, conn, "event", "2")
+			closeWith(t, conn, websocket.CloseNormalClosure, "")
+		},
+	)
+
+	dialerFor := func(apiKey string) StreamDialer {
+		return func(lastStreamID string) (*WebSocketClient, error) {
+			return server.clientWithKey(apiKey).ResumeWebSocket("wss://ws.example.com/routes/r1", "r1", lastStreamID, false)
+		}
+	}
+	conn, err := server.clientWithKey("old-key").ConnectWebSocket("wss://ws.example.com/routes/r1", "r1", false, false)
+	require.NoError(t, err)
+
+	stream := NewResumableStream(conn, dialerFor("old-key"), func() (StreamDialer, error) {
+		return dialerFor("new-key"), nil
+	})
+	stream.Backoff = func(int) time.Duration { return 0 }
+	defer stream.Close()
+
+	ids, err := readStream(t, stream)
+	assert.True(t, isNormalClosure(err), "unexpected error: %v", err)
+	assert.Equal(t, []string{"1", "2"}, ids, "the replayed event is delivered once")
+	assert.Equal(t, 1, stream.Reconnects())
+	assert.Equal(t, 1, stream.CredentialRefreshes())
+
+	rejected := server.request(1).URL.Query()
+	assert.Equal(t, "old-key", rejected.Get("api_secret_key"))
+	resumed := server.request(2).URL.Query()
+	assert.Equal(t, "new-key", resumed.Get("api_secret_key"))
+	assert.Equal(t, "1", resumed.Get("last_stream_id"))
+	assert.Equal(t, "true", resumed.Get("force_reconnect"))
+}
+
+func TestResumableStream_RefreshesCredentialsOnAuthCloseCode(t *testing.T) {
+	var server *fakeStreamServer
+	server = newFakeStreamServer(t, "old-key",
+		func(conn *websocket.Conn) {
+			sendEvent(t, conn, "event", "7")
+			server.rotateKey("new-key")
+			closeWith(t, conn, CloseUnauthorized, "token expired")
+		},
+		func(conn *websocket.Conn) {
+			sendEvent(t, conn, "event", "8")
+			closeWith(t, conn, websocket.CloseNormalClosure, "")
+		},
+	)
+
+	var dialed []string
+	dialerFor := func(apiKey string) StreamDialer {
+		return func(lastStreamID string) (*WebSocketClient, error) {
+			dialed = append(dialed, apiKey)
+			return server.clientWithKey(apiKey).ResumeWebSocket("wss://ws.example.com/routes/r1", "r1", lastStreamID, false)
+		}
+	}
+	conn, err := server.clientWithKey("old-key").ConnectWebSocket("wss://ws.example.com/routes/r1", "r1", false, false)
+	require.NoError(t, err)
+
+	stream := NewResumableStream(conn, dialerFor("old-key"), func() (StreamDialer, error) {
+		return dialerFor("new-key"), nil
+	})
+	stream.Backoff = func(int) time.Duration { return 0 }
+	defer stream.Close()
+
+	ids, err := readStream(t, stream)
+	assert.True(t, isNormalClosure(err), "unexpected error: %v", err)
+	assert.Equal(t, []string{"7", "8"}, ids)
+	assert.Equal(t, []string{"new-key"}, dialed, "the rejected key is not retried")
+	assert.Equal(t, 1, stream.Reconnects())
+	assert.Equal(t, 1, stream.CredentialRefreshes())
+	assert.Equal(t, "7", server.request(1).URL.Query().Get("last_stream_id"))
+}
+
+func TestResumableStream_GivesUp(t *testing.T) {
+	server := newFakeStreamServer(t, "key", func(conn *websocket.Conn) {
+		closeWith(t, conn, websocket.CloseGoingAway, "")
+	})
+
+	conn, err := server.clientWithKey("key").ConnectWebSocket("wss://ws.example.com/routes/r1", "r1", false, false)
+	require.NoError(t, err)
+
+	attempts := 0
+	stream := NewResumableStream(conn, func(string) (*WebSocketClient, error) {
+		attempts++
+		return nil, assert.AnError
+	}, nil)
+	stream.MaxAttempts = 3
+	stream.Backoff = func(int) time.Duration { return 0 }
+
+	_, err = readStream(t, stream)
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, 0, stream.Reconnects())
+}
+
+func TestConnectWebSocket_RejectedCredentials(t *testing.T) {
+	server := newFakeStreamServer(t, "key")
+
+	_, err := server.clientWithKey("expired").ConnectWebSocket("wss://ws.example.com/routes/r1", "r1", false, false)
+	require.Error(t, err)
+	assert.True(t, IsWebSocketAuthError(err))
+	assert.Contains(t, err.Error(), "HTTP 401")
+}
@@ -0,0 +1,119 @@
+package client
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"sync/atomic"
+	"time"
+)
+
+// TransportOptions tunes connection reuse of the client's HTTP transport
+type TransportOptions struct {
+	// MaxIdleConnsPerHost is how many idle connections are kept open to the
+	// API host for reuse. Zero keeps the net/http default of 2.
+	MaxIdleConnsPerHost int
+
+	// DisableHTTP2 restricts the client to HTTP/1.1, which would otherwise
+	// be negotiated up to HTTP/2 when the server supports it
+	DisableHTTP2 bool
+}
+
+// TransportTuner is implemented by clients whose HTTP transport can be
+// tuned and whose connection use can be inspected
+type TransportTuner interface {
+	ConfigureTransport(opts TransportOptions)
+	ConnectionStats() ConnectionStats
+}
+
+// ConnectionStats counts how requests obtained their connections
+type ConnectionStats struct {
+	New           int64 `json:"new"`
+	Reused        int64 `json:"reused"`
+	TLSHandshakes int64 `json:"tls_handshakes"`
+}
+
+// Total returns the number of connections obtained
+func (s ConnectionStats) Total() int64 {
+	return s.New + s.Reused
+}
+
+// ReuseRate returns the percentage of requests that reused a connection
+func (s ConnectionStats) ReuseRate() float64 {
+	if s.Total() == 0 {
+		return 0
+	}
+	return float64(s.Reused) / float64(s.Total()) * 100
+}
+
+// newHTTPTransport returns a copy of the default transport tuned by opts
+func newHTTPTransport(opts TransportOptions) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if opts.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = opts.MaxIdleConnsPerHost
+		if transport.MaxIdleConns != 0 && transport.MaxIdleConns < opts.MaxIdleConnsPerHost {
+			transport.MaxIdleConns = opts.MaxIdleConnsPerHost
+		}
+	}
+	if opts.DisableHTTP2 {
+		// A non-nil, empty TLSNextProto keeps the transport from upgrading
+		transport.ForceAttemptHTTP2 = false
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	} else {
+		transport.ForceAttemptHTTP2 = true
+	}
+	return transport
+}
+
+// connTracker counts new and reused connections of the requests it traces
+type connTracker struct {
+	newConns      atomic.Int64
+	reusedConns   atomic.Int64
+	tlsHandshakes atomic.Int64
+}
+
+// stats returns a snapshot of the counts
+func (t *connTracker) stats() ConnectionStats {
+	return ConnectionStats{
+		New:           t.newConns.Load(),
+		Reused:        t.reusedConns.Load(),
+		TLSHandshakes: t.tlsHandshakes.Load(),
+	}
+}
+
+// reset zeroes the counts
+func (t *connTracker) reset() {
+	t.newConns.Store(0)
+	t.reusedConns.Store(0)
+	t.tlsHandshakes.Store(0)
+}
+
+// trackingTransport is a RoundTripper that records connection reuse of
+// every request in a connTracker, and the server's clock from the Date
+// header of every response
+type trackingTransport struct {
+	transport http.RoundTripper
+	tracker   *connTracker
+}
+
+// RoundTrip implements the RoundTripper interface
+func (t *trackingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			if info.Reused {
+				t.tracker.reusedConns.Add(1)
+			} else {
+				t.tracker.newConns.Add(1)
+			}
+		},
+		TLSHandshakeStart: func() {
+			t.tracker.tlsHandshakes.Add(1)
+		},
+	}
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+	resp, err := t.transport.RoundTrip(req)
+	if err == nil {
+		recordServerDate(resp, time.Now())
+	}
+	return resp, err
+}
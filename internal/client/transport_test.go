@@ -0,0 +1,177 @@
+package client
+
+import (
+	"crypto/tls"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTLSTestServer starts a local TLS server whose responses take long
+// enough for concurrent requests to need a connection each
+func newTLSTestServer(t testing.TB, http2 bool) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(10 * time.Millisecond)
+		w.Header().Set("X-Proto", r.Proto)
+		_, _ = io.WriteString(w, "ok")
+	}))
+	srv.EnableHTTP2 = http2
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// trustTestServer makes transport trust the test server's certificate
+func trustTestServer(transport *http.Transport, srv *httptest.Server) *http.Transport {
+	serverTLS := srv.Client().Transport.(*http.Transport).TLSClientConfig
+	transport.TLSClientConfig = &tls.Config{RootCAs: serverTLS.RootCAs}
+	return transport
+}
+
+// sendRounds sends rounds of concurrent requests, waiting for each round to
+// finish, and returns the connection stats and the protocol of the last response
+func sendRounds(t testing.TB, transport *http.Transport, url string, rounds, concurrency int) (ConnectionStats, int) {
+	t.Helper()
+	tracker := &connTracker{}
+	httpClient := &http.Client{Transport: &trackingTransport{transport: transport, tracker: tracker}}
+	defer transport.CloseIdleConnections()
+
+	var protoMajor int
+	var mu sync.Mutex
+	for range rounds {
+		var wg sync.WaitGroup
+		for range concurrency {
+			wg.Go(func() {
+				resp, err := httpClient.Get(url)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				_, _ = io.Copy(io.Discard, resp.Body)
+				resp.Body.Close()
+				mu.Lock()
+				protoMajor = resp.ProtoMajor
+				mu.Unlock()
+			})
+		}
+		wg.Wait()
+	}
+	return tracker.stats(), protoMajor
+}
+
+func TestNewHTTPTransport(t *testing.T) {
+	transport := newHTTPTransport(TransportOptions{})
+	assert.Equal(t, 0, transport.MaxIdleConnsPerHost)
+	assert.True(t, transport.ForceAttemptHTTP2)
+
+	transport = newHTTPTransport(TransportOptions{MaxIdleConnsPerHost: 250, DisableHTTP2: true})
+	assert.Equal(t, 250, transport.MaxIdleConnsPerHost)
+	assert.GreaterOrEqual(t, transport.MaxIdleConns, 250)
+	assert.False(t, transport.ForceAttemptHTTP2)
+	assert.NotNil(t, transport.TLSNextProto)
+}
+
+func TestConnectionStats_ReuseRate(t *testing.T) {
+	assert.Equal(t, 0.0, ConnectionStats{}.ReuseRate())
+	assert.Equal(t, 75.0, ConnectionStats{New: 1, Reused: 3}.ReuseRate())
+}
+
+// TestTransportReuse_HandshakeCounts measures TLS handshakes for rounds of
+// 20 concurrent requests over HTTP/1.1, before and after tuning the idle
+// connection limit to the concurrency
+func TestTransportReuse_HandshakeCounts(t *testing.T) {
+	const rounds, concurrency = 3, 20
+	srv := newTLSTestServer(t, false)
+
+	before, _ := sendRounds(t, trustTestServer(newHTTPTransport(TransportOptions{}), srv), srv.URL, rounds, concurrency)
+	after, proto := sendRounds(t, trustTestServer(newHTTPTransport(TransportOptions{MaxIdleConnsPerHost: concurrency}), srv), srv.URL, rounds, concurrency)
+	t.Logf("default transport: %d handshakes, %.1f%% reused", before.TLSHandshakes, before.ReuseRate())
+	t.Logf("tuned transport:   %d handshakes, %.1f%% reused", after.TLSHandshakes, after.ReuseRate())
+
+	assert.Equal(t, 1, proto)
+	assert.Equal(t, int64(rounds*concurrency), before.Total())
+	assert.Equal(t, int64(rounds*concurrency), after.Total())
+
+	// The default keeps two idle connections, so later rounds handshake again
+	assert.Greater(t, before.TLSHandshakes, int64(concurrency))
+	assert.LessOrEqual(t, after.TLSHandshakes, int64(concurrency))
+	assert.Greater(t, after.ReuseRate(), before.ReuseRate())
+}
+
+func TestTransportReuse_HTTP2(t *testing.T) {
+	const rounds, concurrency = 3, 20
+	srv := newTLSTestServer(t, true)
+
+	stats, proto := sendRounds(t, trustTestServer(newHTTPTransport(TransportOptions{MaxIdleConnsPerHost: concurrency}), srv), srv.URL, rounds, concurrency)
+	t.Logf("HTTP/2: %d handshakes, %.1f%% reused", stats.TLSHandshakes, stats.ReuseRate())
+	assert.Equal(t, 2, proto)
+	// Concurrent first requests may each dial, but their streams are
+	// multiplexed onto a shared connection
+	assert.Less(t, stats.New, int64(concurrency))
+	assert.Greater(t, stats.ReuseRate(), 90.0)
+
+	_, proto = sendRounds(t, trustTestServer(newHTTPTransport(TransportOptions{DisableHTTP2: true}), srv), srv.URL, 1, 1)
+	assert.Equal(t, 1, proto, "--disable-http2 must keep the client on HTTP/1.1")
+}
+
+func TestClient_ConfigureTransport(t *testing.T) {
+	c, err := NewClient("test-key", "12345678-1234-1234-1234-123456789012")
+	require.NoError(t, err)
+
+	c.connTracker.newConns.Store(3)
+	c.ConfigureTransport(TransportOptions{MaxIdleConnsPerHost: 20, DisableHTTP2: true})
+
+	assert.Equal(t, ConnectionStats{}, c.ConnectionStats())
+	assert.Equal(t, 20, c.transport.MaxIdleConnsPerHost)
+	assert.False(t, c.transport.ForceAttemptHTTP2)
+}
+
+func BenchmarkTransportReuse(b *testing.B) {
+	const concurrency = 20
+	srv := newTLSTestServer(b, false)
+	for _, bench := range []struct {
+		name string
+		opts TransportOptions
+	}{
+		{"default", TransportOptions{}},
+		{"tuned", TransportOptions{MaxIdleConnsPerHost: concurrency}},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			stats, _ := sendRounds(b, trustTestServer(newHTTPTransport(bench.opts), srv), srv.URL, b.N, concurrency)
+			b.ReportMetric(float64(stats.TLSHandshakes)/float64(b.N), "handshakes/op")
+		})
+	}
+}
+
+func TestTrackingTransport_RecordsServerClock(t *testing.T) {
+	ahead := 3 * time.Minute
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", time.Now().Add(ahead).UTC().Format(http.TimeFormat))
+	}))
+	t.Cleanup(srv.Close)
+
+	httpClient := &http.Client{Transport: &trackingTransport{transport: http.DefaultTransport, tracker: &connTracker{}}}
+	resp, err := httpClient.Get(srv.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	skew, ok := ObservedClockSkew()
+	require.True(t, ok)
+	assert.InDelta(t, ahead.Seconds(), skew.Seconds(), 1)
+}
+
+func TestRecordServerDate_IgnoresMissingDate(t *testing.T) {
+	recordServerDate(&http.Response{Header: http.Header{"Date": {time.Now().UTC().Format(http.TimeFormat)}}}, time.Now())
+	recordServerDate(&http.Response{Header: http.Header{"Date": {"yesterday"}}}, time.Now())
+
+	skew, ok := ObservedClockSkew()
+	require.True(t, ok)
+	assert.LessOrEqual(t, skew.Abs(), time.Second)
+}
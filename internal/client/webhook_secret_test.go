@@ -0,0 +1,58 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/AhaSend/ahasend-go/models/common"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+)
+
+func TestClient_RotateWebhookSecret(t *testing.T) {
+	accountID := uuid.New().String()
+	webhookID := uuid.New()
+
+	client, cleanup := newClientTestServer(t, accountID, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v2/accounts/"+accountID+"/webhooks/"+webhookID.String()+"/rotate-secret", r.URL.Path)
+		assert.Equal(t, "Bearer test-api-key", r.Header.Get("Authorization"))
+		writeClientTestJSON(t, w, http.StatusOK, responses.Webhook{ID: webhookID, Name: "Orders", Secret: "aha-whsec-new"})
+	})
+	defer cleanup()
+
+	webhook, err := client.RotateWebhookSecret(webhookID.String())
+	require.NoError(t, err)
+	assert.Equal(t, webhookID, webhook.ID)
+	assert.Equal(t, "aha-whsec-new", webhook.Secret)
+}
+
+func TestClient_RotateWebhookSecret_Errors(t *testing.T) {
+	accountID := uuid.New().String()
+
+	t.Run("not found", func(t *testing.T) {
+		client, cleanup := newClientTestServer(t, accountID, func(w http.ResponseWriter, r *http.Request) {
+			writeClientTestJSON(t, w, http.StatusNotFound, common.ErrorResponse{Message: "not found"})
+		})
+		defer cleanup()
+
+		_, err := client.RotateWebhookSecret("webhook-1")
+		require.Error(t, err)
+		assert.True(t, errors.IsNotFoundError(err))
+	})
+
+	t.Run("api error", func(t *testing.T) {
+		client, cleanup := newClientTestServer(t, accountID, func(w http.ResponseWriter, r *http.Request) {
+			writeClientTestJSON(t, w, http.StatusForbidden, common.ErrorResponse{Message: "missing scope"})
+		})
+		defer cleanup()
+
+		_, err := client.RotateWebhookSecret("webhook-1")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "missing scope")
+	})
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"crypto/tls"
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/AhaSend/ahasend-cli/internal/logger"
@@ -48,6 +51,63 @@ type WebSocketClient struct {
 	webhookID  string
 	skipVerify bool
 	closed     bool
+
+	mu           sync.Mutex
+	lastStreamID string // stream ID of the last event read
+}
+
+// WebSocket close codes the streaming service uses when the credentials of
+// a connection are rejected or have expired
+const (
+	CloseUnauthorized = 4001
+	CloseForbidden    = 4003
+)
+
+// WebSocketAuthError reports a stream connection rejected for its
+// credentials: a 401 or 403 handshake response, or an authentication close
+// code. A fresh stream URL and API key are needed before reconnecting.
+type WebSocketAuthError struct {
+	StatusCode int    // HTTP status of a rejected handshake
+	CloseCode  int    // close code of a connection closed by the server
+	Reason     string // close reason or response status
+}
+
+func (e *WebSocketAuthError) Error() string {
+	if e.StatusCode != 0 {
+		return fmt.Sprintf("websocket authentication failed: HTTP %d", e.StatusCode)
+	}
+	if e.Reason != "" {
+		return fmt.Sprintf("websocket authentication expired (close code %d: %s)", e.CloseCode, e.Reason)
+	}
+	return fmt.Sprintf("websocket authentication expired (close code %d)", e.CloseCode)
+}
+
+// IsWebSocketAuthError reports whether err is a WebSocketAuthError
+func IsWebSocketAuthError(err error) bool {
+	var authErr *WebSocketAuthError
+	return stderrors.As(err, &authErr)
+}
+
+// authCloseError returns a WebSocketAuthError for a close frame that
+// rejects the connection's credentials, or nil. Besides the dedicated
+// codes, a policy violation whose reason mentions the token counts.
+func authCloseError(err error) error {
+	var closeErr *websocket.CloseError
+	if !stderrors.As(err, &closeErr) {
+		return nil
+	}
+	switch closeErr.Code {
+	case CloseUnauthorized, CloseForbidden:
+		return &WebSocketAuthError{CloseCode: closeErr.Code, Reason: closeErr.Text}
+	case websocket.ClosePolicyViolation:
+		reason := strings.ToLower(closeErr.Text)
+		for _, hint := range []string{"auth", "token", "expired", "credential", "unauthorized"} {
+			if strings.Contains(reason, hint) {
+				return &WebSocketAuthError{CloseCode: closeErr.Code, Reason: closeErr.Text}
+			}
+		}
+	}
+	return nil
 }
 
 func (c *Client) InitiateWebhookStream(webhookID string) (*WebhookStreamResponse, error) {
@@ -157,6 +217,32 @@ func (c *Client) InitiateRouteStream(routeID, recipient string) (*RouteStreamRes
 }
 
 func (c *Client) ConnectWebSocket(wsURL, webhookID string, forceReconnect, skipVerify bool) (*WebSocketClient, error) {
+	params := url.Values{}
+	if forceReconnect {
+		params.Set("force_reconnect", "true")
+	}
+	return c.dialWebSocket(wsURL, webhookID, params, skipVerify)
+}
+
+// ResumeWebSocket reconnects a stream after it dropped. The previous
+// connection may not have been noticed as gone by the server yet, so it is
+// replaced, and with lastStreamID set the server replays the buffered events
+// that followed it.
+func (c *Client) ResumeWebSocket(wsURL, connectionID, lastStreamID string, skipVerify bool) (*WebSocketClient, error) {
+	params := url.Values{}
+	params.Set("force_reconnect", "true")
+	if lastStreamID != "" {
+		params.Set("last_stream_id", lastStreamID)
+	}
+	return c.dialWebSocket(wsURL, connectionID, params, skipVerify)
+}
+
+func (c *Client) dialWebSocket(wsURL, webhookID string, params url.Values, skipVerify bool) (*WebSocketClient, error) {
+	wsURL, err := c.webSocketURL(wsURL)
+	if err != nil {
+		return nil, err
+	}
+
 	u, err := url.Parse(wsURL)
 	if err != nil {
 		return nil, fmt.Errorf("invalid websocket URL: %w", err)
@@ -167,8 +253,10 @@ func (c *Client) ConnectWebSocket(wsURL, webhookID string, forceReconnect, skipV
 
 	q := u.Query()
 	q.Add("api_secret_key", apiKey)
-	if forceReconnect {
-		q.Add("force_reconnect", "true")
+	for key, values := range params {
+		for _, value := range values {
+			q.Add(key, value)
+		}
 	}
 	u.RawQuery = q.Encode()
 
@@ -188,6 +276,9 @@ func (c *Client) ConnectWebSocket(wsURL, webhookID string, forceReconnect, skipV
 		if resp != nil && resp.StatusCode == http.StatusConflict {
 			return nil, fmt.Errorf("another websocket connection exists for this webhook. Use force_reconnect to drop the old connection")
 		}
+		if resp != nil && (resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden) {
+			return nil, &WebSocketAuthError{StatusCode: resp.StatusCode, Reason: resp.Status}
+		}
 		return nil, fmt.Errorf("websocket connection failed: %w", err)
 	}
 
@@ -232,6 +323,10 @@ func (wsc *WebSocketClient) ReadMessage(ctx context.Context) (*WebSocketMessage,
 		// Mark connection as closed on any read error to prevent future panics
 		wsc.closed = true
 
+		if authErr := authCloseError(err); authErr != nil {
+			return nil, authErr
+		}
+
 		// Check if it's a websocket close error or connection error
 		if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
 			return nil, fmt.Errorf("websocket connection closed: %w", err)
@@ -249,9 +344,23 @@ func (wsc *WebSocketClient) ReadMessage(ctx context.Context) (*WebSocketMessage,
 		return nil, fmt.Errorf("failed to parse websocket message: %w", err)
 	}
 
+	if msg.Event != nil && msg.Event.StreamID != "" {
+		wsc.mu.Lock()
+		wsc.lastStreamID = msg.Event.StreamID
+		wsc.mu.Unlock()
+	}
+
 	return &msg, nil
 }
 
+// LastStreamID returns the stream ID of the last event read, or "" when
+// none has been
+func (wsc *WebSocketClient) LastStreamID() string {
+	wsc.mu.Lock()
+	defer wsc.mu.Unlock()
+	return wsc.lastStreamID
+}
+
 func (wsc *WebSocketClient) Close() error {
 	wsc.closed = true
 	if wsc.conn != nil {
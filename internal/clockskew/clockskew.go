@@ -0,0 +1,104 @@
+// Package clockskew reports a local clock that is off from the API server's.
+//
+// Webhook signatures carry a timestamp that receivers only accept within a
+// few minutes of their own clock, so a skewed laptop clock turns every
+// locally signed or verified delivery into an "invalid signature timestamp"
+// failure. The API client notes the server's Date header of every response;
+// Current turns that into the skew, and caches it in the state file so
+// commands that make no API call, like webhooks verify, can still warn.
+package clockskew
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/state"
+	"github.com/AhaSend/ahasend-cli/internal/webhooks"
+)
+
+// Threshold is how far the local clock may be from the server's before
+// commands warn
+const Threshold = time.Minute
+
+// TTL is how long a cached skew is trusted when this run made no API call
+const TTL = 24 * time.Hour
+
+// refreshInterval is how old a cached skew may get before a matching
+// observation is written again
+const refreshInterval = time.Hour
+
+var (
+	// now and observe are replaced in tests
+	now     = time.Now
+	observe = client.ObservedClockSkew
+)
+
+// Current returns how far the API server's clock is ahead of the local clock
+// (negative when behind), from the responses of this run or else from the
+// state file when cached within TTL. It returns false when neither is known.
+func Current() (time.Duration, bool) {
+	s, err := state.Load()
+	if err != nil {
+		logger.Get().WithError(err).Debug("Failed to read the cached clock skew")
+	}
+
+	if skew, ok := observe(); ok {
+		if s != nil && stale(s.ClockSkew, skew) {
+			s.ClockSkew = &state.ClockSkew{Skew: skew, ObservedAt: now().UTC()}
+			if err := s.Save(); err != nil {
+				logger.Get().WithError(err).Debug("Failed to cache the clock skew")
+			}
+		}
+		return skew, true
+	}
+
+	if s != nil && s.ClockSkew != nil && now().Sub(s.ClockSkew.ObservedAt) < TTL {
+		return s.ClockSkew.Skew, true
+	}
+	return 0, false
+}
+
+// stale reports whether cached should be replaced by an observed skew
+func stale(cached *state.ClockSkew, observed time.Duration) bool {
+	return cached == nil || (cached.Skew-observed).Abs() >= time.Second || now().Sub(cached.ObservedAt) >= refreshInterval
+}
+
+// Warning returns the warning for a skew, or "" when it is within Threshold
+func Warning(skew time.Duration) string {
+	if skew.Abs() <= Threshold {
+		return ""
+	}
+	// The server being ahead means the local clock is behind
+	return fmt.Sprintf("The local clock is %s the AhaSend API server's clock, so webhook timestamps signed or checked here are off by as much; synchronize the clock (e.g. enable NTP)",
+		webhooks.DescribeSkew(-skew))
+}
+
+// Warn reports a skewed local clock for a command that signs or verifies
+// webhooks. JSON output gets the warning in its warnings array; other
+// formats get a line on stderr unless --quiet is set.
+func Warn(cmd *cobra.Command) {
+	skew, ok := Current()
+	if !ok {
+		return
+	}
+	warning := Warning(skew)
+	if warning == "" {
+		return
+	}
+
+	if cmd.Context() != nil {
+		if handler := printer.GetResponseHandlerFromCommand(cmd); handler.GetFormat() == "json" {
+			handler.AddWarning(warning)
+			return
+		}
+	}
+	if quiet, _ := cmd.Flags().GetBool("quiet"); quiet {
+		return
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %s\n", warning)
+}
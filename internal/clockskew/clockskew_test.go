@@ -0,0 +1,98 @@
+package clockskew
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/state"
+)
+
+func setObserved(t *testing.T, skew time.Duration, ok bool) {
+	t.Helper()
+	previous := observe
+	observe = func() (time.Duration, bool) { return skew, ok }
+	t.Cleanup(func() { observe = previous })
+}
+
+func TestCurrent_CachesObservation(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	current := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return current }
+	t.Cleanup(func() { now = time.Now })
+
+	setObserved(t, 0, false)
+	_, ok := Current()
+	assert.False(t, ok, "nothing observed or cached")
+
+	setObserved(t, -4*time.Minute, true)
+	skew, ok := Current()
+	require.True(t, ok)
+	assert.Equal(t, -4*time.Minute, skew)
+
+	s, err := state.Load()
+	require.NoError(t, err)
+	require.NotNil(t, s.ClockSkew)
+	assert.Equal(t, -4*time.Minute, s.ClockSkew.Skew)
+
+	// A later run without API calls uses the cache until it expires
+	setObserved(t, 0, false)
+	current = current.Add(TTL - time.Second)
+	skew, ok = Current()
+	require.True(t, ok)
+	assert.Equal(t, -4*time.Minute, skew)
+
+	current = current.Add(time.Second)
+	_, ok = Current()
+	assert.False(t, ok)
+}
+
+func TestWarning_Threshold(t *testing.T) {
+	assert.Empty(t, Warning(0))
+	assert.Empty(t, Warning(Threshold))
+	assert.Empty(t, Warning(-Threshold))
+	assert.Contains(t, Warning(Threshold+time.Second), "The local clock is 1m1s behind the AhaSend API server's clock")
+	assert.Contains(t, Warning(-3*time.Minute), "The local clock is 3m0s ahead of the AhaSend API server's clock")
+}
+
+func TestWarn(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	setObserved(t, 10*time.Minute, true)
+
+	newCmd := func(format string) (*cobra.Command, *bytes.Buffer, *bytes.Buffer, printer.ResponseHandler) {
+		cmd := &cobra.Command{Use: "test"}
+		cmd.Flags().Bool("quiet", false, "")
+		var stdout, stderr bytes.Buffer
+		handler := printer.GetResponseHandler(format, false, &stdout)
+		cmd.SetContext(context.WithValue(context.Background(), printer.ResponseHandlerKey, handler))
+		cmd.SetErr(&stderr)
+		return cmd, &stdout, &stderr, handler
+	}
+
+	cmd, _, stderr, _ := newCmd("table")
+	Warn(cmd)
+	assert.Contains(t, stderr.String(), "Warning: The local clock is 10m0s behind")
+
+	cmd, _, stderr, _ = newCmd("table")
+	require.NoError(t, cmd.Flags().Set("quiet", "true"))
+	Warn(cmd)
+	assert.Empty(t, stderr.String())
+
+	cmd, stdout, stderr, handler := newCmd("json")
+	Warn(cmd)
+	assert.Empty(t, stderr.String())
+	require.NoError(t, handler.HandleSimpleSuccess("done"))
+	assert.Contains(t, stdout.String(), "The local clock is 10m0s behind")
+
+	// Skew within the threshold is not reported
+	setObserved(t, Threshold, true)
+	cmd, _, stderr, _ = newCmd("table")
+	Warn(cmd)
+	assert.Empty(t, stderr.String())
+}
@@ -18,8 +18,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/AhaSend/ahasend-cli/internal/validation"
+
 	"github.com/spf13/viper"
 )
 
@@ -28,16 +31,56 @@ type Config struct {
 	DefaultProfile string             `mapstructure:"default_profile" yaml:"default_profile"`
 	Profiles       map[string]Profile `mapstructure:"profiles" yaml:"profiles"`
 	Preferences    Preferences        `mapstructure:"preferences" yaml:"preferences"`
+
+	// OutputOverrides maps commands, keyed by their path without the root
+	// command and with dots between words (e.g. "messages.list"), to the
+	// output format they use when --output is not given. It is read
+	// separately from the rest of the file because viper splits keys at dots.
+	OutputOverrides map[string]string `mapstructure:"-" yaml:"output_overrides,omitempty"`
+
+	// DomainDefaults maps sending domains to the send settings messages send
+	// applies beneath its flags. Like OutputOverrides it is read separately,
+	// since domains contain dots.
+	DomainDefaults map[string]DomainDefault `mapstructure:"-" yaml:"domain_defaults,omitempty"`
 }
 
 // Profile represents an AhaSend account profile
 type Profile struct {
-	APIKey         string    `mapstructure:"api_key" yaml:"api_key"`
+	APIKey         string    `mapstructure:"api_key" yaml:"api_key,omitempty"`
 	APIURL         string    `mapstructure:"api_url" yaml:"api_url"`
 	AccountID      string    `mapstructure:"account_id" yaml:"account_id"`
 	Name           string    `mapstructure:"name" yaml:"name"`
 	AccountName    string    `mapstructure:"account_name" yaml:"account_name,omitempty"`
 	AccountUpdated time.Time `mapstructure:"account_updated" yaml:"account_updated,omitempty"`
+
+	// ConfirmThreshold overrides the recipient count above which
+	// messages send asks for confirmation. nil leaves the default in
+	// place; 0 turns the confirmation off.
+	ConfirmThreshold *int `mapstructure:"confirm_threshold" yaml:"confirm_threshold,omitempty"`
+
+	// DefaultTestRecipient is where messages send --to-me delivers
+	DefaultTestRecipient string `mapstructure:"default_test_recipient" yaml:"default_test_recipient,omitempty"`
+
+	// TestTag is added to messages sent with --to-me
+	TestTag string `mapstructure:"test_tag" yaml:"test_tag,omitempty"`
+
+	// DefaultFrom is the sender used by messages send and smtp send when
+	// --from is omitted
+	DefaultFrom string `mapstructure:"default_from" yaml:"default_from,omitempty"`
+
+	// KeyDeletedAt is when apikeys delete removed the API key of this
+	// profile. The profile cannot authenticate until auth login replaces it.
+	KeyDeletedAt time.Time `mapstructure:"key_deleted_at" yaml:"key_deleted_at,omitempty"`
+
+	// APIKeyID is the ID of APIKey, given to auth login with --api-key-id.
+	// apikeys delete uses it to recognize the key the profile authenticates
+	// with.
+	APIKeyID string `mapstructure:"api_key_id" yaml:"api_key_id,omitempty"`
+
+	// APIKeyRef points at the API key when it is kept in the OS keychain or
+	// the encrypted credentials file, e.g. "keyring:production"; APIKey is
+	// then empty. Read the key with auth.ProfileAPIKey.
+	APIKeyRef string `mapstructure:"api_key_ref" yaml:"api_key_ref,omitempty"`
 }
 
 // Preferences represents user preferences for the CLI
@@ -48,6 +91,7 @@ type Preferences struct {
 	LogLevel         string `mapstructure:"log_level" yaml:"log_level"`
 	DefaultDomain    string `mapstructure:"default_domain" yaml:"default_domain"`
 	BatchConcurrency int    `mapstructure:"batch_concurrency" yaml:"batch_concurrency"`
+	Pager            string `mapstructure:"pager" yaml:"pager"` // auto, always or never
 }
 
 // DefaultPreferences returns default preferences
@@ -58,6 +102,7 @@ func DefaultPreferences() Preferences {
 		WebhookTimeout:   "30s",
 		LogLevel:         "info",
 		BatchConcurrency: 5,
+		Pager:            "auto",
 	}
 }
 
@@ -107,6 +152,8 @@ func NewManager() (*Manager, error) {
 
 // Load loads configuration from file
 func (m *Manager) Load() error {
+	// Values set by an earlier Save in this process would shadow the file
+	viper.Reset()
 	viper.SetConfigFile(m.configFile)
 	viper.SetConfigType("yaml")
 
@@ -117,6 +164,7 @@ func (m *Manager) Load() error {
 	viper.SetDefault("preferences.webhook_timeout", "30s")
 	viper.SetDefault("preferences.log_level", "info")
 	viper.SetDefault("preferences.batch_concurrency", 5)
+	viper.SetDefault("preferences.pager", "auto")
 
 	// Read environment variables
 	viper.SetEnvPrefix("AHASEND")
@@ -136,6 +184,9 @@ func (m *Manager) Load() error {
 		return fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
+	m.config.OutputOverrides = flattenOutputOverrides(viper.Get("output_overrides"))
+	m.config.DomainDefaults = readDomainDefaults(viper.Get("domain_defaults"))
+
 	// Reinitialize managers with loaded config
 	m.profileManager = NewProfileManager(m.config)
 	m.preferenceManager = NewPreferenceManager(m.config)
@@ -148,6 +199,8 @@ func (m *Manager) Save() error {
 	viper.Set("default_profile", m.config.DefaultProfile)
 	viper.Set("profiles", m.config.Profiles)
 	viper.Set("preferences", m.config.Preferences)
+	viper.Set("output_overrides", m.config.OutputOverrides)
+	viper.Set("domain_defaults", m.config.DomainDefaults)
 
 	return viper.WriteConfigAs(m.configFile)
 }
@@ -203,7 +256,86 @@ func (m *Manager) SetPreference(key, value string) error {
 	return m.Save()
 }
 
+// SetProfileValue sets a per-profile setting and saves the configuration
+func (m *Manager) SetProfileValue(profileName, key, value string) error {
+	err := m.profileManager.SetProfileValue(profileName, key, value)
+	if err != nil {
+		return err
+	}
+	return m.Save()
+}
+
+// GetProfileValue gets a per-profile setting
+func (m *Manager) GetProfileValue(profileName, key string) (string, error) {
+	return m.profileManager.GetProfileValue(profileName, key)
+}
+
 // GetPreference gets a preference value
 func (m *Manager) GetPreference(key string) (string, error) {
 	return m.preferenceManager.GetPreference(key)
 }
+
+// SetOutputOverride sets the output format of a command, keyed like
+// "messages.list", and saves the configuration. An empty format removes the
+// override.
+func (m *Manager) SetOutputOverride(command, format string) error {
+	command = strings.ToLower(strings.TrimSpace(command))
+	if command == "" {
+		return fmt.Errorf("output override needs a command, e.g. output_overrides.messages.list")
+	}
+	if format == "" {
+		delete(m.config.OutputOverrides, command)
+		return m.Save()
+	}
+	if err := validation.ValidateOutputFormat(format); err != nil {
+		return err
+	}
+	if m.config.OutputOverrides == nil {
+		m.config.OutputOverrides = make(map[string]string)
+	}
+	m.config.OutputOverrides[command] = format
+	return m.Save()
+}
+
+// GetOutputOverride returns the output format override of a command, or ""
+// when it has none
+func (m *Manager) GetOutputOverride(command string) string {
+	return m.config.OutputOverrides[strings.ToLower(strings.TrimSpace(command))]
+}
+
+// flattenOutputOverrides turns the output_overrides section into a map keyed
+// by dotted command paths. Viper nests "messages.list" as messages: {list:}
+// when it writes the file, so nested maps are joined back with dots.
+func flattenOutputOverrides(section interface{}) map[string]string {
+	overrides := make(map[string]string)
+	var walk func(prefix string, value interface{})
+	walk = func(prefix string, value interface{}) {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			for key, nested := range v {
+				walk(joinCommandKey(prefix, key), nested)
+			}
+		case map[interface{}]interface{}:
+			for key, nested := range v {
+				walk(joinCommandKey(prefix, fmt.Sprint(key)), nested)
+			}
+		case map[string]string:
+			for key, format := range v {
+				overrides[strings.ToLower(joinCommandKey(prefix, key))] = format
+			}
+		case string:
+			if prefix != "" {
+				overrides[strings.ToLower(prefix)] = v
+			}
+		}
+	}
+	walk("", section)
+	return overrides
+}
+
+func joinCommandKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
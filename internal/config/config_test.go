@@ -220,3 +220,137 @@ func TestManager_SaveCreatesDirectory(t *testing.T) {
 	_, err = os.Stat(configFile)
 	assert.NoError(t, err)
 }
+
+func TestManager_SetProfileValue(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	mgr, err := NewManager()
+	require.NoError(t, err)
+	require.NoError(t, mgr.SetProfile("default", Profile{APIKey: "key", AccountID: "acct"}))
+
+	require.NoError(t, mgr.SetProfileValue("default", "default_test_recipient", "me@example.com"))
+	require.NoError(t, mgr.SetProfileValue("default", "test_tag", "qa"))
+	require.NoError(t, mgr.SetProfileValue("default", "confirm_threshold", "250"))
+	require.NoError(t, mgr.SetProfileValue("default", "default_from", "noreply@example.com"))
+
+	// Values persist across a reload
+	reloaded, err := NewManager()
+	require.NoError(t, err)
+	require.NoError(t, reloaded.Load())
+	profile := reloaded.GetConfig().Profiles["default"]
+	assert.Equal(t, "me@example.com", profile.DefaultTestRecipient)
+	assert.Equal(t, "qa", profile.TestTag)
+	require.NotNil(t, profile.ConfirmThreshold)
+	assert.Equal(t, 250, *profile.ConfirmThreshold)
+	assert.Equal(t, "noreply@example.com", profile.DefaultFrom)
+
+	value, err := reloaded.GetProfileValue("default", "default_test_recipient")
+	require.NoError(t, err)
+	assert.Equal(t, "me@example.com", value)
+
+	assert.Error(t, mgr.SetProfileValue("default", "default_test_recipient", "not-an-email"))
+	assert.Error(t, mgr.SetProfileValue("default", "default_from", "noreply@"))
+	assert.Error(t, mgr.SetProfileValue("default", "confirm_threshold", "-1"))
+
+	// 0 is kept, so the confirmation can be turned off; empty unsets it
+	require.NoError(t, mgr.SetProfileValue("default", "confirm_threshold", "0"))
+	value, err = mgr.GetProfileValue("default", "confirm_threshold")
+	require.NoError(t, err)
+	assert.Equal(t, "0", value)
+	require.NoError(t, mgr.SetProfileValue("default", "confirm_threshold", ""))
+	value, err = mgr.GetProfileValue("default", "confirm_threshold")
+	require.NoError(t, err)
+	assert.Equal(t, "", value)
+	assert.Error(t, mgr.SetProfileValue("default", "unknown_key", "x"))
+	assert.Error(t, mgr.SetProfileValue("missing", "test_tag", "x"))
+
+	assert.True(t, IsProfileSetting("test_tag"))
+	assert.False(t, IsProfileSetting("output_format"))
+}
+
+func TestManager_OutputOverrides(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	mgr, err := NewManager()
+	require.NoError(t, err)
+	require.NoError(t, mgr.Load())
+	require.NoError(t, mgr.SetOutputOverride("messages.list", "csv"))
+	require.NoError(t, mgr.SetOutputOverride("subaccounts.api-keys.get", "json"))
+	assert.Error(t, mgr.SetOutputOverride("messages.get", "xml"))
+	assert.Error(t, mgr.SetOutputOverride("", "json"))
+
+	// Dotted keys survive a reload although viper nests them when saving
+	reloaded, err := NewManager()
+	require.NoError(t, err)
+	require.NoError(t, reloaded.Load())
+	assert.Equal(t, map[string]string{"messages.list": "csv", "subaccounts.api-keys.get": "json"}, reloaded.GetConfig().OutputOverrides)
+	assert.Equal(t, "csv", reloaded.GetOutputOverride("messages.list"))
+	assert.Empty(t, reloaded.GetOutputOverride("messages.get"))
+
+	require.NoError(t, reloaded.SetOutputOverride("messages.list", ""))
+	assert.Empty(t, reloaded.GetOutputOverride("messages.list"))
+
+	// Hand-written files may use flat dotted keys
+	require.NoError(t, os.WriteFile(filepath.Join(home, ".ahasend", "config.yaml"),
+		[]byte("output_overrides:\n  messages.list: csv\n  domains:\n    check-dns: json\n"), 0o600))
+	require.NoError(t, reloaded.Load())
+	assert.Equal(t, map[string]string{"messages.list": "csv", "domains.check-dns": "json"}, reloaded.GetConfig().OutputOverrides)
+}
+
+func TestManager_DomainDefaults(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	mgr, err := NewManager()
+	require.NoError(t, err)
+	require.NoError(t, mgr.Load())
+	require.NoError(t, mgr.SetDomainDefault("Mail.Acme.com", map[string]string{"track_opens": "false"}))
+	require.NoError(t, mgr.SetDomainDefault("mail.acme.com", map[string]string{"tags": "transactional, receipts"}))
+	require.NoError(t, mgr.SetDomainDefault("acme.com", map[string]string{"track_clicks": "true"}))
+	assert.Error(t, mgr.SetDomainDefault("acme.com", map[string]string{"tags": "x", "track_opens": "sometimes"}))
+	assert.Error(t, mgr.SetDomainDefault("acme.com", map[string]string{"subject": "x"}))
+	assert.Error(t, mgr.SetDomainDefault("not a domain", map[string]string{"tags": "x"}))
+
+	reloaded, err := NewManager()
+	require.NoError(t, err)
+	require.NoError(t, reloaded.Load())
+	off, on := false, true
+	assert.Equal(t, map[string]DomainDefault{
+		"mail.acme.com": {TrackOpens: &off, Tags: []string{"transactional", "receipts"}},
+		"acme.com":      {TrackClicks: &on},
+	}, reloaded.GetConfig().DomainDefaults)
+
+	defaults, ok := reloaded.GetDomainDefault("MAIL.acme.com")
+	assert.True(t, ok)
+	assert.Equal(t, "track_opens=false tags=transactional,receipts", defaults.String())
+	_, ok = reloaded.GetDomainDefault("other.com")
+	assert.False(t, ok)
+
+	// Clearing the last setting removes the domain
+	require.NoError(t, reloaded.SetDomainDefault("acme.com", map[string]string{"track_clicks": ""}))
+	_, ok = reloaded.GetDomainDefault("acme.com")
+	assert.False(t, ok)
+
+	// Hand-written files may use flat dotted keys
+	require.NoError(t, os.WriteFile(filepath.Join(home, ".ahasend", "config.yaml"),
+		[]byte("domain_defaults:\n  mail.acme.com:\n    track_opens: false\n    tags: [transactional]\n"), 0o600))
+	require.NoError(t, reloaded.Load())
+	assert.Equal(t, map[string]DomainDefault{
+		"mail.acme.com": {TrackOpens: &off, Tags: []string{"transactional"}},
+	}, reloaded.GetConfig().DomainDefaults)
+
+	// Internationalized domains are keyed by punycode and match either form
+	require.NoError(t, reloaded.SetDomainDefault("Bücher.example", map[string]string{"tags": "de"}))
+	assert.Contains(t, reloaded.GetConfig().DomainDefaults, "xn--bcher-kva.example")
+	for _, domain := range []string{"bücher.example", "XN--BCHER-KVA.example"} {
+		defaults, ok := reloaded.GetDomainDefault(domain)
+		assert.True(t, ok, domain)
+		assert.Equal(t, []string{"de"}, defaults.Tags, domain)
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(home, ".ahasend", "config.yaml"),
+		[]byte("domain_defaults:\n  münchen.example:\n    tags: [de]\n"), 0o600))
+	require.NoError(t, reloaded.Load())
+	_, ok = reloaded.GetDomainDefault("xn--mnchen-3ya.example")
+	assert.True(t, ok, "hand-written Unicode keys match the punycode form")
+}
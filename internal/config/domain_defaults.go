@@ -0,0 +1,226 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/AhaSend/ahasend-cli/internal/validation"
+)
+
+// domainDefaultSettings lists the keys a domain default can set
+var domainDefaultSettings = []string{"track_opens", "track_clicks", "tags"}
+
+// DomainDefault holds send settings applied to messages sent from a domain.
+// A nil or empty field leaves the command's own default in place.
+type DomainDefault struct {
+	TrackOpens  *bool    `mapstructure:"track_opens" yaml:"track_opens,omitempty" json:"track_opens,omitempty"`
+	TrackClicks *bool    `mapstructure:"track_clicks" yaml:"track_clicks,omitempty" json:"track_clicks,omitempty"`
+	Tags        []string `mapstructure:"tags" yaml:"tags,omitempty" json:"tags,omitempty"`
+}
+
+// IsEmpty reports whether the default sets nothing
+func (d DomainDefault) IsEmpty() bool {
+	return d.TrackOpens == nil && d.TrackClicks == nil && len(d.Tags) == 0
+}
+
+// String lists the settings as key=value pairs, e.g.
+// "track_opens=false tags=transactional"
+func (d DomainDefault) String() string {
+	var settings []string
+	if d.TrackOpens != nil {
+		settings = append(settings, "track_opens="+strconv.FormatBool(*d.TrackOpens))
+	}
+	if d.TrackClicks != nil {
+		settings = append(settings, "track_clicks="+strconv.FormatBool(*d.TrackClicks))
+	}
+	if len(d.Tags) > 0 {
+		settings = append(settings, "tags="+strings.Join(d.Tags, ","))
+	}
+	return strings.Join(settings, " ")
+}
+
+// IsDomainDefaultSetting reports whether key can be set per domain
+func IsDomainDefaultSetting(key string) bool {
+	for _, setting := range domainDefaultSettings {
+		if setting == key {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeDomain validates a domain and returns the form domain defaults
+// are keyed by: lower-case punycode, so bücher.example and
+// xn--bcher-kva.example are the same domain
+func NormalizeDomain(domain string) (string, error) {
+	domain = strings.TrimSpace(domain)
+	if err := validation.ValidateDomainName(domain); err != nil {
+		return "", err
+	}
+	return validation.ToASCIIDomain(domain)
+}
+
+// SetDomainDefault sets send settings of a domain, keyed like track_opens,
+// and saves the configuration. Nothing is changed when a setting is
+// invalid. An empty value removes the setting, and the domain is removed
+// once it sets nothing.
+func (m *Manager) SetDomainDefault(domain string, settings map[string]string) error {
+	domain, err := NormalizeDomain(domain)
+	if err != nil {
+		return err
+	}
+
+	defaults := m.config.DomainDefaults[domain]
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := defaults.set(key, strings.TrimSpace(settings[key])); err != nil {
+			return err
+		}
+	}
+
+	if defaults.IsEmpty() {
+		delete(m.config.DomainDefaults, domain)
+		return m.Save()
+	}
+	if m.config.DomainDefaults == nil {
+		m.config.DomainDefaults = make(map[string]DomainDefault)
+	}
+	m.config.DomainDefaults[domain] = defaults
+	return m.Save()
+}
+
+// set changes one setting; an empty value removes it
+func (d *DomainDefault) set(key, value string) error {
+	switch key {
+	case "track_opens", "track_clicks":
+		var setting *bool
+		if value != "" {
+			enabled, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("%s must be true or false", key)
+			}
+			setting = &enabled
+		}
+		if key == "track_opens" {
+			d.TrackOpens = setting
+		} else {
+			d.TrackClicks = setting
+		}
+
+	case "tags":
+		d.Tags = parseTagsSetting(value)
+
+	default:
+		return fmt.Errorf("unknown domain setting: %s (expected %s)", key, strings.Join(domainDefaultSettings, ", "))
+	}
+	return nil
+}
+
+// GetDomainDefault returns the send settings of a domain, and whether it has
+// any. Domains are matched case-insensitively and exactly, in either their
+// Unicode or punycode form: mail.acme.com does not inherit the settings of
+// acme.com. An invalid domain has no settings.
+func (m *Manager) GetDomainDefault(domain string) (DomainDefault, bool) {
+	domain, err := NormalizeDomain(domain)
+	if err != nil {
+		return DomainDefault{}, false
+	}
+	defaults, ok := m.config.DomainDefaults[domain]
+	return defaults, ok
+}
+
+// readDomainDefaults turns the domain_defaults section into a map keyed by
+// domain. Viper nests "mail.acme.com" as mail: {acme: {com:}} when it writes
+// the file, so nested maps are joined back with dots; a map holding setting
+// keys is the settings of the domain named by its path.
+func readDomainDefaults(section interface{}) map[string]DomainDefault {
+	domainDefaults := make(map[string]DomainDefault)
+	var walk func(prefix string, value interface{})
+	walk = func(prefix string, value interface{}) {
+		settings := stringKeyedMap(value)
+		if settings == nil {
+			return
+		}
+		var defaults DomainDefault
+		keys := make([]string, 0, len(settings))
+		for key := range settings {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			nested := settings[key]
+			switch {
+			case key == "track_opens" && prefix != "":
+				defaults.TrackOpens = parseBoolSetting(nested)
+			case key == "track_clicks" && prefix != "":
+				defaults.TrackClicks = parseBoolSetting(nested)
+			case key == "tags" && prefix != "" && stringKeyedMap(nested) == nil:
+				defaults.Tags = parseTagsSetting(nested)
+			default:
+				walk(joinCommandKey(prefix, key), nested)
+			}
+		}
+		if !defaults.IsEmpty() {
+			// Entries written by hand may use the Unicode form
+			domain, err := validation.ToASCIIDomain(prefix)
+			if err != nil {
+				domain = strings.ToLower(prefix)
+			}
+			domainDefaults[domain] = defaults
+		}
+	}
+	walk("", section)
+	return domainDefaults
+}
+
+// stringKeyedMap returns a YAML mapping as a map with string keys, or nil
+func stringKeyedMap(value interface{}) map[string]interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(v))
+		for key, nested := range v {
+			converted[fmt.Sprint(key)] = nested
+		}
+		return converted
+	}
+	return nil
+}
+
+func parseBoolSetting(value interface{}) *bool {
+	switch v := value.(type) {
+	case bool:
+		return &v
+	case string:
+		if parsed, err := strconv.ParseBool(v); err == nil {
+			return &parsed
+		}
+	}
+	return nil
+}
+
+func parseTagsSetting(value interface{}) []string {
+	var tags []string
+	switch v := value.(type) {
+	case []interface{}:
+		for _, tag := range v {
+			tags = append(tags, fmt.Sprint(tag))
+		}
+	case []string:
+		tags = append(tags, v...)
+	case string:
+		for _, tag := range strings.Split(v, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags
+}
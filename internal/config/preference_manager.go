@@ -59,6 +59,12 @@ func (pm *PreferenceManager) SetPreference(key, value string) error {
 		concurrency, _ := strconv.Atoi(value) // Already validated above
 		pm.config.Preferences.BatchConcurrency = concurrency
 
+	case "pager":
+		if err := validation.ValidatePagerMode(value); err != nil {
+			return err
+		}
+		pm.config.Preferences.Pager = value
+
 	default:
 		return fmt.Errorf("unknown preference: %s", key)
 	}
@@ -81,6 +87,8 @@ func (pm *PreferenceManager) GetPreference(key string) (string, error) {
 		return pm.config.Preferences.DefaultDomain, nil
 	case "batch_concurrency":
 		return strconv.Itoa(pm.config.Preferences.BatchConcurrency), nil
+	case "pager":
+		return pm.config.Preferences.Pager, nil
 	default:
 		return "", fmt.Errorf("unknown preference: %s", key)
 	}
@@ -95,5 +103,6 @@ func (pm *PreferenceManager) GetAllPreferences() map[string]string {
 		"log_level":         pm.config.Preferences.LogLevel,
 		"default_domain":    pm.config.Preferences.DefaultDomain,
 		"batch_concurrency": strconv.Itoa(pm.config.Preferences.BatchConcurrency),
+		"pager":             pm.config.Preferences.Pager,
 	}
 }
@@ -2,8 +2,14 @@ package config
 
 import (
 	"fmt"
+	"strconv"
+
+	"github.com/AhaSend/ahasend-cli/internal/validation"
 )
 
+// profileSettings lists the keys that can be set per profile
+var profileSettings = []string{"default_test_recipient", "test_tag", "confirm_threshold", "default_from"}
+
 // ProfileManager handles profile-specific operations
 type ProfileManager struct {
 	config *Config
@@ -74,3 +80,83 @@ func (pm *ProfileManager) GetProfile(name string) (*Profile, error) {
 	}
 	return &profile, nil
 }
+
+// IsProfileSetting reports whether key is a per-profile setting rather than a preference
+func IsProfileSetting(key string) bool {
+	for _, setting := range profileSettings {
+		if setting == key {
+			return true
+		}
+	}
+	return false
+}
+
+// SetProfileValue sets a per-profile setting with validation
+func (pm *ProfileManager) SetProfileValue(name, key, value string) error {
+	profile, exists := pm.config.Profiles[name]
+	if !exists {
+		return fmt.Errorf("profile '%s' not found", name)
+	}
+
+	switch key {
+	case "default_test_recipient":
+		if value != "" {
+			if err := validation.ValidateEmail(value); err != nil {
+				return err
+			}
+		}
+		profile.DefaultTestRecipient = value
+
+	case "test_tag":
+		profile.TestTag = value
+
+	case "confirm_threshold":
+		if value == "" {
+			profile.ConfirmThreshold = nil
+			break
+		}
+		threshold, err := strconv.Atoi(value)
+		if err != nil || threshold < 0 {
+			return fmt.Errorf("confirm_threshold must be a non-negative integer (0 disables the confirmation)")
+		}
+		profile.ConfirmThreshold = &threshold
+
+	case "default_from":
+		if value != "" {
+			if err := validation.ValidateEmail(value); err != nil {
+				return err
+			}
+		}
+		profile.DefaultFrom = value
+
+	default:
+		return fmt.Errorf("unknown profile setting: %s", key)
+	}
+
+	pm.config.Profiles[name] = profile
+	return nil
+}
+
+// GetProfileValue gets a per-profile setting
+func (pm *ProfileManager) GetProfileValue(name, key string) (string, error) {
+	profile, exists := pm.config.Profiles[name]
+	if !exists {
+		return "", fmt.Errorf("profile '%s' not found", name)
+	}
+
+	switch key {
+	case "default_test_recipient":
+		return profile.DefaultTestRecipient, nil
+	case "test_tag":
+		return profile.TestTag, nil
+	case "confirm_threshold":
+		if profile.ConfirmThreshold == nil {
+			return "", nil
+		}
+		return strconv.Itoa(*profile.ConfirmThreshold), nil
+	case "default_from":
+		return profile.DefaultFrom, nil
+	default:
+		return "", fmt.Errorf("unknown profile setting: %s", key)
+	}
+}
@@ -75,6 +75,7 @@ func (cs *ConfigStorage) Load() (*Config, error) {
 	if err := viper.Unmarshal(config); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
+	config.OutputOverrides = flattenOutputOverrides(viper.Get("output_overrides"))
 
 	return config, nil
 }
@@ -89,6 +90,7 @@ func (cs *ConfigStorage) Save(config *Config) error {
 	viper.Set("default_profile", config.DefaultProfile)
 	viper.Set("profiles", config.Profiles)
 	viper.Set("preferences", config.Preferences)
+	viper.Set("output_overrides", config.OutputOverrides)
 
 	viper.SetConfigFile(cs.configFile)
 	viper.SetConfigType("yaml")
@@ -104,4 +106,5 @@ func (cs *ConfigStorage) setDefaults() {
 	viper.SetDefault("preferences.webhook_timeout", "30s")
 	viper.SetDefault("preferences.log_level", "info")
 	viper.SetDefault("preferences.batch_concurrency", 5)
+	viper.SetDefault("preferences.pager", "auto")
 }
@@ -0,0 +1,106 @@
+// Package credentials stores API keys outside the configuration file.
+//
+// A profile's API key lives in one of three places:
+//   - the configuration file, in plain text (the default)
+//   - the OS keychain: macOS Keychain, Windows Credential Manager or the
+//     Secret Service (libsecret) on Linux
+//   - an encrypted credentials file, ~/.ahasend/credentials.enc, sealed with
+//     a key derived from a passphrase with scrypt
+//
+// When the key is stored elsewhere, the profile keeps a reference such as
+// "keyring:production" in place of the key. Commands read keys through the
+// auth package, which resolves the reference.
+package credentials
+
+import (
+	stderrors "errors"
+	"fmt"
+	"strings"
+
+	"github.com/zalando/go-keyring"
+)
+
+// Store is where an API key is kept
+type Store string
+
+// Stores
+const (
+	StorePlaintext Store = "plaintext" // in the configuration file
+	StoreKeyring   Store = "keyring"   // in the OS keychain
+	StoreEncrypted Store = "encrypted" // in the encrypted credentials file
+)
+
+// Stores lists the valid stores
+var Stores = []Store{StorePlaintext, StoreKeyring, StoreEncrypted}
+
+// KeyringService is the service name API keys are filed under in the OS
+// keychain
+const KeyringService = "ahasend-cli"
+
+// ErrNotFound is returned when a store holds no key for a reference
+var ErrNotFound = stderrors.New("credential not found")
+
+// ParseStore validates a store name
+func ParseStore(name string) (Store, error) {
+	for _, store := range Stores {
+		if string(store) == strings.ToLower(strings.TrimSpace(name)) {
+			return store, nil
+		}
+	}
+	return "", fmt.Errorf("unknown credential store %q, expected plaintext, keyring or encrypted", name)
+}
+
+// Ref points at an API key kept outside the configuration file
+type Ref struct {
+	Store Store
+	Name  string // the entry in the store, the profile name when stored
+}
+
+// String formats the reference as it is written to the configuration file
+func (r Ref) String() string {
+	return string(r.Store) + ":" + r.Name
+}
+
+// ParseRef reads a reference written by Ref.String
+func ParseRef(value string) (Ref, error) {
+	store, name, ok := strings.Cut(value, ":")
+	if !ok || name == "" || (Store(store) != StoreKeyring && Store(store) != StoreEncrypted) {
+		return Ref{}, fmt.Errorf("invalid API key reference %q, expected keyring:<name> or encrypted:<name>", value)
+	}
+	return Ref{Store: Store(store), Name: name}, nil
+}
+
+// KeyringGet reads an API key from the OS keychain
+func KeyringGet(name string) (string, error) {
+	secret, err := keyring.Get(KeyringService, name)
+	if stderrors.Is(err, keyring.ErrNotFound) {
+		return "", ErrNotFound
+	}
+	return secret, err
+}
+
+// KeyringSet writes an API key to the OS keychain. It fails when no keychain
+// is available, e.g. on a Linux host without a Secret Service.
+func KeyringSet(name, secret string) error {
+	return keyring.Set(KeyringService, name, secret)
+}
+
+// KeyringDelete removes an API key from the OS keychain. A missing entry is
+// not an error.
+func KeyringDelete(name string) error {
+	err := keyring.Delete(KeyringService, name)
+	if stderrors.Is(err, keyring.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+// MockKeyring replaces the OS keychain with an in-memory one, or with one
+// that fails every call with err when err is not nil. It is meant for tests.
+func MockKeyring(err error) {
+	if err != nil {
+		keyring.MockInitWithError(err)
+		return
+	}
+	keyring.MockInit()
+}
@@ -0,0 +1,99 @@
+package credentials
+
+import (
+	stderrors "errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRef(t *testing.T) {
+	ref, err := ParseRef("keyring:production")
+	require.NoError(t, err)
+	assert.Equal(t, Ref{Store: StoreKeyring, Name: "production"}, ref)
+	assert.Equal(t, "keyring:production", ref.String())
+
+	for _, value := range []string{"", "keyring", "keyring:", "plaintext:default", "vault:default"} {
+		_, err := ParseRef(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestParseStore(t *testing.T) {
+	store, err := ParseStore(" Keyring ")
+	require.NoError(t, err)
+	assert.Equal(t, StoreKeyring, store)
+
+	_, err = ParseStore("vault")
+	assert.Error(t, err)
+}
+
+func TestKeyring(t *testing.T) {
+	MockKeyring(nil)
+
+	_, err := KeyringGet("default")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, KeyringSet("default", "aha-sk-secret"))
+	secret, err := KeyringGet("default")
+	require.NoError(t, err)
+	assert.Equal(t, "aha-sk-secret", secret)
+
+	require.NoError(t, KeyringDelete("default"))
+	require.NoError(t, KeyringDelete("default"))
+
+	MockKeyring(stderrors.New("no secret service"))
+	assert.Error(t, KeyringSet("default", "aha-sk-secret"))
+}
+
+func TestEncryptedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), EncryptedFileName)
+
+	secrets, err := LoadEncrypted(path, "passphrase")
+	require.NoError(t, err)
+	assert.Empty(t, secrets)
+	assert.False(t, EncryptedExists(path))
+
+	require.NoError(t, SaveEncrypted(path, "passphrase", map[string]string{"default": "aha-sk-secret"}))
+	assert.True(t, EncryptedExists(path))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "aha-sk-secret")
+
+	secrets, err = LoadEncrypted(path, "passphrase")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"default": "aha-sk-secret"}, secrets)
+
+	_, err = LoadEncrypted(path, "wrong")
+	assert.ErrorIs(t, err, ErrWrongPassphrase)
+
+	_, err = LoadEncrypted(path, "")
+	assert.Error(t, err)
+}
+
+func TestEncryptedFile_Tampered(t *testing.T) {
+	path := filepath.Join(t.TempDir(), EncryptedFileName)
+	require.NoError(t, SaveEncrypted(path, "passphrase", map[string]string{"default": "aha-sk-secret"}))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	// Flip a character of the base64 ciphertext
+	index := len(data) - 10
+	if data[index] == 'A' {
+		data[index] = 'B'
+	} else {
+		data[index] = 'A'
+	}
+	require.NoError(t, os.WriteFile(path, data, 0600))
+
+	_, err = LoadEncrypted(path, "passphrase")
+	assert.Error(t, err)
+}
@@ -0,0 +1,153 @@
+package credentials
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// EncryptedFileName is the name of the encrypted credentials file in the
+// configuration directory
+const EncryptedFileName = "credentials.enc"
+
+// scrypt parameters of newly written files. Each file records the
+// parameters it was sealed with, so they can be raised later.
+const (
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// ErrWrongPassphrase is returned when the credentials file cannot be opened
+// with the passphrase, or has been tampered with
+var ErrWrongPassphrase = stderrors.New("wrong passphrase for the encrypted credentials file")
+
+// encryptedFile is the on-disk form of the credentials file. The sealed
+// payload is a JSON object of API keys by entry name.
+type encryptedFile struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	N          int    `json:"n"`
+	R          int    `json:"r"`
+	P          int    `json:"p"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// EncryptedPath returns the location of the encrypted credentials file
+func EncryptedPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".ahasend", EncryptedFileName), nil
+}
+
+// EncryptedExists reports whether the credentials file at path exists
+func EncryptedExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// LoadEncrypted opens the credentials file at path. A missing file holds no
+// keys.
+func LoadEncrypted(path, passphrase string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials file %s: %w", path, err)
+	}
+
+	var file encryptedFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse credentials file %s: %w", path, err)
+	}
+	if file.Version != 1 || file.KDF != "scrypt" {
+		return nil, fmt.Errorf("unsupported credentials file %s (version %d, kdf %q)", path, file.Version, file.KDF)
+	}
+
+	aead, err := newAEAD(passphrase, file.Salt, file.N, file.R, file.P)
+	if err != nil {
+		return nil, err
+	}
+	if len(file.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("invalid nonce in credentials file %s", path)
+	}
+	plaintext, err := aead.Open(nil, file.Nonce, file.Ciphertext, nil)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+
+	secrets := map[string]string{}
+	if err := json.Unmarshal(plaintext, &secrets); err != nil {
+		return nil, fmt.Errorf("failed to decode credentials file %s: %w", path, err)
+	}
+	return secrets, nil
+}
+
+// SaveEncrypted seals the keys with the passphrase and writes them to path,
+// replacing the file atomically. Every write uses a fresh salt and nonce.
+func SaveEncrypted(path, passphrase string, secrets map[string]string) error {
+	plaintext, err := json.Marshal(secrets)
+	if err != nil {
+		return fmt.Errorf("failed to encode credentials: %w", err)
+	}
+
+	file := encryptedFile{Version: 1, KDF: "scrypt", N: scryptN, R: scryptR, P: scryptP, Salt: make([]byte, 16)}
+	if _, err := rand.Read(file.Salt); err != nil {
+		return fmt.Errorf("failed to generate salt: %w", err)
+	}
+	aead, err := newAEAD(passphrase, file.Salt, file.N, file.R, file.P)
+	if err != nil {
+		return err
+	}
+	file.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(file.Nonce); err != nil {
+		return fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	file.Ciphertext = aead.Seal(nil, file.Nonce, plaintext, nil)
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode credentials file: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create configuration directory: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("failed to write credentials file %s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to replace credentials file %s: %w", path, err)
+	}
+	return nil
+}
+
+// newAEAD derives the AES-256-GCM key of the credentials file from the
+// passphrase
+func newAEAD(passphrase string, salt []byte, n, r, p int) (cipher.AEAD, error) {
+	if passphrase == "" {
+		return nil, stderrors.New("the credentials file passphrase must not be empty")
+	}
+	key, err := scrypt.Key([]byte(passphrase), salt, n, r, p, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive the credentials file key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,312 @@
+// Package dashboard implements the live terminal view of 'ahasend dashboard'.
+//
+// The dashboard is split into panes that each fetch their own data on their
+// own interval. Fetching, state and rendering are kept apart so a pane can be
+// tested with a mock client and a fixed clock, without a terminal: Fetch runs
+// off the UI loop and only returns data, Apply stores it, and View renders the
+// stored data into plain lines.
+package dashboard
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/client"
+)
+
+// Pane is one section of the dashboard
+type Pane interface {
+	// Title names the pane in its header line
+	Title() string
+	// Interval is how often the pane refreshes on its own
+	Interval() time.Duration
+	// Fetch loads the pane's data. It runs in its own goroutine and must not
+	// touch the pane's state.
+	Fetch(apiClient client.AhaSendClient, now time.Time) (any, error)
+	// Apply stores data returned by a successful Fetch
+	Apply(data any)
+	// View renders the stored data in at most rows lines
+	View(rows int, now time.Time) []string
+}
+
+// Action tells the UI loop what to do after a key press
+type Action int
+
+const (
+	ActionNone Action = iota
+	ActionRedraw
+	ActionRefresh
+	ActionQuit
+)
+
+// Key is a key press read from the terminal. Named keys use bubbletea's
+// key names, so a tea.KeyMsg converts with its String method.
+type Key string
+
+// Keys the dashboard and other terminal prompts respond to besides
+// printable characters
+const (
+	KeyTab      Key = "tab"
+	KeyShiftTab Key = "shift+tab"
+	KeyLeft     Key = "left"
+	KeyRight    Key = "right"
+	KeyUp       Key = "up"
+	KeyDown     Key = "down"
+	KeyEnter    Key = "enter"
+	KeyEscape   Key = "esc"
+	KeyCtrlC    Key = "ctrl+c"
+)
+
+// ParseKeys splits raw terminal input into key presses. Unknown escape
+// sequences are dropped.
+func ParseKeys(input []byte) []Key {
+	var keys []Key
+	for i := 0; i < len(input); i++ {
+		switch b := input[i]; {
+		case b == 0x03:
+			keys = append(keys, KeyCtrlC)
+		case b == '\t':
+			keys = append(keys, KeyTab)
+		case b == '\r' || b == '\n':
+			keys = append(keys, KeyEnter)
+		case b == 0x1b:
+			if i+2 < len(input) && input[i+1] == '[' {
+				switch input[i+2] {
+				case 'A':
+					keys = append(keys, KeyUp)
+				case 'B':
+					keys = append(keys, KeyDown)
+				case 'C':
+					keys = append(keys, KeyRight)
+				case 'D':
+					keys = append(keys, KeyLeft)
+				case 'Z':
+					keys = append(keys, KeyShiftTab)
+				}
+				i += 2
+				continue
+			}
+			keys = append(keys, KeyEscape)
+		case b >= 0x20 && b < 0x7f:
+			keys = append(keys, Key(string(rune(b))))
+		}
+	}
+	return keys
+}
+
+// paneState tracks when a pane was last fetched and how that went
+type paneState struct {
+	pane      Pane
+	started   time.Time // when the last fetch started
+	updated   time.Time // when the last successful fetch finished
+	loading   bool
+	err       error
+	refreshed bool // at least one fetch has finished
+}
+
+// Model is the dashboard state: its panes, their fetch status and the
+// focused pane
+type Model struct {
+	header string
+	panes  []*paneState
+	focus  int
+}
+
+// NewModel creates a dashboard of the given panes. The header is shown on
+// the first line, e.g. the account being watched.
+func NewModel(header string, panes ...Pane) *Model {
+	m := &Model{header: header}
+	for _, pane := range panes {
+		m.panes = append(m.panes, &paneState{pane: pane})
+	}
+	return m
+}
+
+// Focus returns the index of the focused pane
+func (m *Model) Focus() int {
+	return m.focus
+}
+
+// Pane returns the pane at index i
+func (m *Model) Pane(i int) Pane {
+	return m.panes[i].pane
+}
+
+// HandleKey updates the focus for navigation keys and reports what the UI
+// loop should do next
+func (m *Model) HandleKey(key Key) Action {
+	switch key {
+	case "q", "Q", KeyEscape, KeyCtrlC:
+		return ActionQuit
+	case "r", "R":
+		return ActionRefresh
+	case KeyTab, KeyRight, KeyDown, "j", "l":
+		m.focus = (m.focus + 1) % len(m.panes)
+		return ActionRedraw
+	case KeyShiftTab, KeyLeft, KeyUp, "k", "h":
+		m.focus = (m.focus + len(m.panes) - 1) % len(m.panes)
+		return ActionRedraw
+	}
+	if len(key) == 1 && key[0] >= '1' && key[0] <= '9' {
+		if i := int(key[0] - '1'); i < len(m.panes) {
+			m.focus = i
+			return ActionRedraw
+		}
+	}
+	return ActionNone
+}
+
+// Due returns the panes whose interval has elapsed since their last fetch
+// started, and panes that were never fetched. Panes with a fetch in flight
+// are skipped.
+func (m *Model) Due(now time.Time) []int {
+	var due []int
+	for i, state := range m.panes {
+		if state.loading {
+			continue
+		}
+		if state.started.IsZero() || now.Sub(state.started) >= state.pane.Interval() {
+			due = append(due, i)
+		}
+	}
+	return due
+}
+
+// Refresh returns the focused pane for a forced refresh, unless it is
+// already loading
+func (m *Model) Refresh() []int {
+	if m.panes[m.focus].loading {
+		return nil
+	}
+	return []int{m.focus}
+}
+
+// Start marks a pane's fetch as started
+func (m *Model) Start(i int, now time.Time) {
+	m.panes[i].loading = true
+	m.panes[i].started = now
+}
+
+// Finish records the outcome of a pane's fetch. A failed fetch keeps the
+// previous data on screen and shows the error in the pane header.
+func (m *Model) Finish(i int, data any, err error, now time.Time) {
+	state := m.panes[i]
+	state.loading = false
+	state.refreshed = true
+	state.err = err
+	if err == nil {
+		state.pane.Apply(data)
+		state.updated = now
+	}
+}
+
+// minPaneRows is the body height of panes without focus
+const minPaneRows = 3
+
+// View renders the dashboard into a frame of at most height lines, each cut
+// to width columns. The focused pane gets the rows the others do not need.
+func (m *Model) View(width, height int, now time.Time) string {
+	lines := []string{m.header, ""}
+	footer := "tab/←→ switch pane · 1-" + fmt.Sprint(len(m.panes)) + " jump · r refresh · q quit"
+
+	// Title lines, the header, its blank line and the footer are fixed
+	available := height - len(lines) - 1 - 2*len(m.panes)
+	rows := make([]int, len(m.panes))
+	for i := range m.panes {
+		if i != m.focus {
+			rows[i] = minPaneRows
+			available -= minPaneRows
+		}
+	}
+	if available < minPaneRows {
+		available = minPaneRows
+	}
+	rows[m.focus] = available
+
+	for i, state := range m.panes {
+		lines = append(lines, m.paneTitle(i, state, now))
+		body := m.paneBody(state, rows[i], now)
+		for len(body) < rows[i] {
+			body = append(body, "")
+		}
+		for _, line := range body {
+			lines = append(lines, "  "+line)
+		}
+		lines = append(lines, "")
+	}
+	lines = append(lines, footer)
+
+	if height > 0 && len(lines) > height {
+		lines = lines[:height]
+	}
+	for i, line := range lines {
+		lines[i] = truncate(line, width)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// paneTitle renders the header line of a pane with its refresh status
+func (m *Model) paneTitle(i int, state *paneState, now time.Time) string {
+	marker := " "
+	if i == m.focus {
+		marker = "▶"
+	}
+	title := fmt.Sprintf("%s %d. %s", marker, i+1, state.pane.Title())
+
+	var status []string
+	switch {
+	case state.loading:
+		status = append(status, "refreshing…")
+	case !state.updated.IsZero():
+		status = append(status, "updated "+FormatAge(now.Sub(state.updated))+" ago")
+	}
+	status = append(status, "every "+state.pane.Interval().String())
+	if state.err != nil {
+		status = append(status, "error: "+state.err.Error())
+	}
+	return title + "  (" + strings.Join(status, " · ") + ")"
+}
+
+// paneBody renders a pane's data, or a placeholder before the first fetch
+func (m *Model) paneBody(state *paneState, rows int, now time.Time) []string {
+	if state.updated.IsZero() {
+		if state.refreshed && state.err != nil {
+			return []string{"No data: the last refresh failed"}
+		}
+		return []string{"Loading…"}
+	}
+	body := state.pane.View(rows, now)
+	if len(body) > rows {
+		body = body[:rows]
+	}
+	return body
+}
+
+// FormatAge renders a duration coarsely, e.g. "12s", "5m", "3h"
+func FormatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
+
+// truncate cuts text to width runes, marking the cut with an ellipsis
+func truncate(text string, width int) string {
+	if width <= 0 {
+		return text
+	}
+	runes := []rune(text)
+	if len(runes) <= width {
+		return text
+	}
+	if width == 1 {
+		return "…"
+	}
+	return string(runes[:width-1]) + "…"
+}
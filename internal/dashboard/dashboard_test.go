@@ -0,0 +1,185 @@
+package dashboard
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/client"
+)
+
+// fakePane renders the lines it was last given
+type fakePane struct {
+	title    string
+	interval time.Duration
+	lines    []string
+}
+
+func (p *fakePane) Title() string           { return p.title }
+func (p *fakePane) Interval() time.Duration { return p.interval }
+func (p *fakePane) Fetch(client.AhaSendClient, time.Time) (any, error) {
+	return nil, nil
+}
+func (p *fakePane) Apply(data any)                        { p.lines = data.([]string) }
+func (p *fakePane) View(rows int, now time.Time) []string { return p.lines }
+
+func newTestModel() *Model {
+	return NewModel("header",
+		&fakePane{title: "Stats", interval: time.Minute},
+		&fakePane{title: "Integrations", interval: 2 * time.Minute},
+		&fakePane{title: "Messages", interval: 15 * time.Second},
+	)
+}
+
+func TestParseKeys(t *testing.T) {
+	keys := ParseKeys([]byte("r\t\x1b[Z\x1b[C\x1b[D\x1b[A\x1b[B\x03q\r\x1b"))
+	assert.Equal(t, []Key{"r", KeyTab, KeyShiftTab, KeyRight, KeyLeft, KeyUp, KeyDown, KeyCtrlC, "q", KeyEnter, KeyEscape}, keys)
+
+	// Unknown escape sequences are dropped
+	assert.Empty(t, ParseKeys([]byte("\x1b[H")))
+}
+
+func TestModel_HandleKey(t *testing.T) {
+	m := newTestModel()
+
+	assert.Equal(t, ActionRedraw, m.HandleKey(KeyTab))
+	assert.Equal(t, 1, m.Focus())
+	m.HandleKey(KeyRight)
+	m.HandleKey(KeyRight)
+	assert.Equal(t, 0, m.Focus(), "focus wraps around")
+	m.HandleKey(KeyShiftTab)
+	assert.Equal(t, 2, m.Focus())
+	m.HandleKey("2")
+	assert.Equal(t, 1, m.Focus())
+	assert.Equal(t, ActionNone, m.HandleKey("9"), "no ninth pane")
+	assert.Equal(t, 1, m.Focus())
+
+	assert.Equal(t, ActionRefresh, m.HandleKey("r"))
+	assert.Equal(t, ActionQuit, m.HandleKey("q"))
+	assert.Equal(t, ActionQuit, m.HandleKey(KeyCtrlC))
+	assert.Equal(t, ActionNone, m.HandleKey("x"))
+}
+
+func TestModel_IndependentRefreshIntervals(t *testing.T) {
+	m := newTestModel()
+	start := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	// Every pane loads on startup
+	due := m.Due(start)
+	require.Equal(t, []int{0, 1, 2}, due)
+	for _, i := range due {
+		m.Start(i, start)
+	}
+	assert.Empty(t, m.Due(start.Add(time.Hour)), "panes with a fetch in flight are not started again")
+	for _, i := range due {
+		m.Finish(i, []string{"data"}, nil, start)
+	}
+
+	assert.Empty(t, m.Due(start.Add(10*time.Second)))
+	assert.Equal(t, []int{2}, m.Due(start.Add(15*time.Second)))
+	assert.Equal(t, []int{0, 2}, m.Due(start.Add(time.Minute)))
+	assert.Equal(t, []int{0, 1, 2}, m.Due(start.Add(2*time.Minute)))
+}
+
+func TestModel_RefreshFocusedPane(t *testing.T) {
+	m := newTestModel()
+	m.HandleKey("3")
+	assert.Equal(t, []int{2}, m.Refresh())
+
+	m.Start(2, time.Now())
+	assert.Empty(t, m.Refresh(), "a loading pane is not refreshed twice")
+}
+
+func TestModel_View(t *testing.T) {
+	m := newTestModel()
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	frame := m.View(80, 30, now)
+	assert.Contains(t, frame, "header")
+	assert.Contains(t, frame, "▶ 1. Stats")
+	assert.Contains(t, frame, "  2. Integrations")
+	assert.Contains(t, frame, "Loading…")
+	assert.Contains(t, frame, "q quit")
+
+	m.Finish(0, []string{"Received 10"}, nil, now.Add(-30*time.Second))
+	m.HandleKey(KeyTab)
+	frame = m.View(80, 30, now)
+	assert.Contains(t, frame, "  1. Stats  (updated 30s ago · every 1m0s)")
+	assert.Contains(t, frame, "▶ 2. Integrations")
+	assert.Contains(t, frame, "Received 10")
+
+	// A failed refresh keeps the previous data and reports the error
+	m.Finish(0, nil, errors.New("rate limited"), now)
+	frame = m.View(80, 30, now)
+	assert.Contains(t, frame, "Received 10")
+	assert.Contains(t, frame, "error: rate limited")
+
+	// A pane that never loaded says so
+	m.Finish(2, nil, errors.New("forbidden"), now)
+	assert.Contains(t, m.View(80, 30, now), "No data: the last refresh failed")
+}
+
+func TestModel_ViewFitsTheTerminal(t *testing.T) {
+	m := newTestModel()
+	now := time.Now()
+	lines := make([]string, 50)
+	for i := range lines {
+		lines[i] = strings.Repeat("x", 200)
+	}
+	m.Finish(0, lines, nil, now)
+	m.Finish(1, lines, nil, now)
+
+	frame := strings.Split(m.View(40, 24, now), "\n")
+	assert.LessOrEqual(t, len(frame), 24)
+	for _, line := range frame {
+		assert.LessOrEqual(t, len([]rune(line)), 40)
+	}
+
+	// The focused pane gets the rows the others do not need
+	dataLines := func(frame string) int {
+		n := 0
+		for _, line := range strings.Split(frame, "\n") {
+			if strings.Contains(line, "xxx") {
+				n++
+			}
+		}
+		return n
+	}
+	assert.Greater(t, dataLines(m.View(40, 24, now)), 2*minPaneRows)
+	m.HandleKey("3")
+	assert.Equal(t, 2*minPaneRows, dataLines(m.View(40, 24, now)))
+}
+
+func TestFormatAge(t *testing.T) {
+	assert.Equal(t, "5s", FormatAge(5*time.Second))
+	assert.Equal(t, "3m", FormatAge(3*time.Minute+20*time.Second))
+	assert.Equal(t, "5h", FormatAge(5*time.Hour))
+	assert.Equal(t, "3d", FormatAge(72*time.Hour))
+}
+
+func TestProgram_Update(t *testing.T) {
+	p := &program{model: newTestModel(), width: 80, height: 30}
+
+	// Named keys arrive as bubbletea key messages
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	assert.Nil(t, cmd)
+	assert.Equal(t, 2, p.model.Focus())
+
+	_, cmd = p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	require.NotNil(t, cmd)
+	assert.Equal(t, []int(nil), p.model.Refresh(), "the focused pane is loading")
+
+	p.Update(fetchResult{pane: 2, data: []string{"msg-1 delivered"}})
+	p.Update(tea.WindowSizeMsg{Width: 60, Height: 20})
+	assert.Contains(t, p.View(), "msg-1 delivered")
+	assert.Len(t, strings.Split(p.View(), "\n"), 20)
+
+	_, cmd = p.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	require.NotNil(t, cmd)
+	assert.Equal(t, tea.QuitMsg{}, cmd())
+}
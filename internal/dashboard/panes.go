@@ -0,0 +1,164 @@
+package dashboard
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/models/responses"
+
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/fetch"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+)
+
+// DeliverabilityPane shows deliverability totals and rates over a trailing
+// window, e.g. the last 24 hours
+type DeliverabilityPane struct {
+	window   time.Duration
+	interval time.Duration
+	summary  *printer.DeliverabilitySummary
+}
+
+// NewDeliverabilityPane creates a deliverability pane covering window
+func NewDeliverabilityPane(window, interval time.Duration) *DeliverabilityPane {
+	return &DeliverabilityPane{window: window, interval: interval}
+}
+
+func (p *DeliverabilityPane) Title() string {
+	return "Deliverability (last " + formatWindow(p.window) + ")"
+}
+
+func (p *DeliverabilityPane) Interval() time.Duration {
+	return p.interval
+}
+
+func (p *DeliverabilityPane) Fetch(apiClient client.AhaSendClient, now time.Time) (any, error) {
+	return fetch.DeliverabilitySummary(apiClient, now.Add(-p.window), now)
+}
+
+func (p *DeliverabilityPane) Apply(data any) {
+	p.summary = data.(*printer.DeliverabilitySummary)
+}
+
+func (p *DeliverabilityPane) View(rows int, now time.Time) []string {
+	s := p.summary
+	if s.Reception == 0 {
+		return []string{"No messages received in this window"}
+	}
+	return []string{
+		fmt.Sprintf("Received %d · Delivered %d (%s) · Bounced %d (%s)",
+			s.Reception, s.Delivered, formatRate(s.DeliveryRate), s.Bounced, formatRate(s.BounceRate)),
+		fmt.Sprintf("Deferred %d · Failed %d · Suppressed %d", s.Deferred, s.Failed, s.Suppressed),
+		fmt.Sprintf("Opened %d (%s) · Clicked %d (%s)",
+			s.Opened, formatRate(s.OpenRate), s.Clicked, formatRate(s.ClickRate)),
+	}
+}
+
+// IntegrationsPane lists webhooks and routes whose latest deliveries failed
+type IntegrationsPane struct {
+	interval time.Duration
+	failing  []fetch.FailingIntegration
+}
+
+// NewIntegrationsPane creates the failing webhooks and routes pane
+func NewIntegrationsPane(interval time.Duration) *IntegrationsPane {
+	return &IntegrationsPane{interval: interval}
+}
+
+func (p *IntegrationsPane) Title() string {
+	return "Failing webhooks and routes"
+}
+
+func (p *IntegrationsPane) Interval() time.Duration {
+	return p.interval
+}
+
+func (p *IntegrationsPane) Fetch(apiClient client.AhaSendClient, now time.Time) (any, error) {
+	return fetch.FailingIntegrations(apiClient)
+}
+
+func (p *IntegrationsPane) Apply(data any) {
+	p.failing = data.([]fetch.FailingIntegration)
+}
+
+func (p *IntegrationsPane) View(rows int, now time.Time) []string {
+	if len(p.failing) == 0 {
+		return []string{"✓ No webhook or route is failing"}
+	}
+	lines := make([]string, 0, rows)
+	for i, item := range p.failing {
+		if len(lines) == rows-1 && i < len(p.failing)-1 {
+			lines = append(lines, fmt.Sprintf("… and %d more", len(p.failing)-i))
+			break
+		}
+		last := "never"
+		if item.LastRequestAt != nil {
+			last = FormatAge(now.Sub(*item.LastRequestAt)) + " ago"
+		}
+		lines = append(lines, fmt.Sprintf("%-7s  %-24s  %4d failed in a row  last %-8s  %s",
+			item.Kind, truncate(item.Name, 24), item.ErrorStreak, last, item.URL))
+	}
+	return lines
+}
+
+// MessagesPane tails the newest messages
+type MessagesPane struct {
+	window   time.Duration
+	limit    int
+	interval time.Duration
+	messages []responses.Message
+}
+
+// NewMessagesPane creates a pane showing up to limit messages created within
+// window
+func NewMessagesPane(window time.Duration, limit int, interval time.Duration) *MessagesPane {
+	return &MessagesPane{window: window, limit: limit, interval: interval}
+}
+
+func (p *MessagesPane) Title() string {
+	return "Recent messages"
+}
+
+func (p *MessagesPane) Interval() time.Duration {
+	return p.interval
+}
+
+func (p *MessagesPane) Fetch(apiClient client.AhaSendClient, now time.Time) (any, error) {
+	return fetch.RecentMessages(apiClient, now.Add(-p.window), p.limit)
+}
+
+func (p *MessagesPane) Apply(data any) {
+	p.messages = data.([]responses.Message)
+}
+
+func (p *MessagesPane) View(rows int, now time.Time) []string {
+	if len(p.messages) == 0 {
+		return []string{"No messages in the last " + formatWindow(p.window)}
+	}
+	lines := make([]string, 0, rows)
+	for _, message := range p.messages {
+		if len(lines) == rows {
+			break
+		}
+		lines = append(lines, fmt.Sprintf("%s  %-10s  %-30s  %s",
+			message.CreatedAt.Local().Format("15:04:05"), message.Status, truncate(message.Recipient, 30), message.Subject))
+	}
+	return lines
+}
+
+// formatRate renders a percentage, or "-" when it has no denominator
+func formatRate(rate *float64) string {
+	if rate == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", *rate)
+}
+
+// formatWindow renders a window as hours or days, e.g. "24h", "7d"
+func formatWindow(window time.Duration) string {
+	if window >= 48*time.Hour && window%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", int(window.Hours()/24))
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(window.String(), "0s"), "0m")
+}
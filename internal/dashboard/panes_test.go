@@ -0,0 +1,125 @@
+package dashboard
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/fetch"
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+)
+
+var paneNow = time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func TestDeliverabilityPane(t *testing.T) {
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetDeliverabilityStatistics", mock.MatchedBy(func(params requests.GetDeliverabilityStatisticsParams) bool {
+		return params.FromTime.Equal(paneNow.Add(-24*time.Hour)) && params.ToTime.Equal(paneNow)
+	})).Return(&responses.DeliverabilityStatisticsResponse{
+		Data: []responses.DeliverabilityStatistics{
+			{ReceptionCount: 180, DeliveredCount: 171, BouncedCount: 9, OpenedCount: 57},
+			{ReceptionCount: 20, DeliveredCount: 19, BouncedCount: 1},
+		},
+	}, nil)
+
+	pane := NewDeliverabilityPane(24*time.Hour, time.Minute)
+	assert.Equal(t, "Deliverability (last 24h)", pane.Title())
+
+	data, err := pane.Fetch(mockClient, paneNow)
+	require.NoError(t, err)
+	pane.Apply(data)
+
+	view := strings.Join(pane.View(3, paneNow), "\n")
+	assert.Contains(t, view, "Received 200 · Delivered 190 (95.0%) · Bounced 10 (5.0%)")
+	assert.Contains(t, view, "Opened 57 (30.0%) · Clicked 0 (0.0%)")
+	mockClient.AssertExpectations(t)
+}
+
+func TestDeliverabilityPane_NoTraffic(t *testing.T) {
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetDeliverabilityStatistics", mock.Anything).Return(&responses.DeliverabilityStatisticsResponse{}, nil)
+
+	pane := NewDeliverabilityPane(time.Hour, time.Minute)
+	data, err := pane.Fetch(mockClient, paneNow)
+	require.NoError(t, err)
+	pane.Apply(data)
+	assert.Equal(t, []string{"No messages received in this window"}, pane.View(3, paneNow))
+}
+
+func TestIntegrationsPane(t *testing.T) {
+	lastRequest := paneNow.Add(-5 * time.Minute)
+	mockClient := &mocks.MockClient{}
+	mockClient.On("ListWebhooks", (*int32)(nil), (*string)(nil)).Return(&responses.PaginatedWebhooksResponse{
+		Data: []responses.Webhook{
+			{ID: uuid.New(), Name: "healthy", URL: "https://ok.example.com"},
+			{ID: uuid.New(), Name: "orders", URL: "https://orders.example.com", ErrorsSinceLastSuccess: 4, LastRequestAt: &lastRequest},
+		},
+	}, nil)
+	mockClient.On("ListRoutes", (*int32)(nil), (*string)(nil)).Return(&responses.PaginatedRoutesResponse{
+		Data: []responses.Route{
+			{ID: uuid.New(), Name: "support", URL: "https://support.example.com", ErrorsSinceLastSuccess: 12},
+		},
+	}, nil)
+
+	pane := NewIntegrationsPane(time.Minute)
+	data, err := pane.Fetch(mockClient, paneNow)
+	require.NoError(t, err)
+	pane.Apply(data)
+
+	view := pane.View(5, paneNow)
+	require.Len(t, view, 2)
+	assert.Contains(t, view[0], "route")
+	assert.Contains(t, view[0], "12 failed in a row")
+	assert.Contains(t, view[0], "last never")
+	assert.Contains(t, view[1], "orders")
+	assert.Contains(t, view[1], "last 5m ago")
+	assert.NotContains(t, strings.Join(view, "\n"), "healthy")
+
+	// Rows that do not fit are counted on the last line
+	view = pane.View(1, paneNow)
+	assert.Equal(t, []string{"… and 2 more"}, view)
+}
+
+func TestIntegrationsPane_NothingFailing(t *testing.T) {
+	pane := NewIntegrationsPane(time.Minute)
+	pane.Apply([]fetch.FailingIntegration(nil))
+	assert.Equal(t, []string{"✓ No webhook or route is failing"}, pane.View(3, paneNow))
+}
+
+func TestMessagesPane(t *testing.T) {
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetMessages", mock.MatchedBy(func(params requests.GetMessagesParams) bool {
+		return params.FromTime.Equal(paneNow.Add(-time.Hour)) && *params.Limit == 2
+	})).Return(&responses.PaginatedMessagesResponse{
+		Data: []responses.Message{
+			{CreatedAt: paneNow.Add(-time.Minute), Status: "Delivered", Recipient: "a@example.com", Subject: "Welcome"},
+			{CreatedAt: paneNow.Add(-2 * time.Minute), Status: "Bounced", Recipient: "b@example.com", Subject: "Receipt"},
+		},
+	}, nil)
+
+	pane := NewMessagesPane(time.Hour, 2, 15*time.Second)
+	data, err := pane.Fetch(mockClient, paneNow)
+	require.NoError(t, err)
+	pane.Apply(data)
+
+	view := pane.View(5, paneNow)
+	require.Len(t, view, 2)
+	assert.Contains(t, view[0], "Delivered")
+	assert.Contains(t, view[0], "a@example.com")
+	assert.Contains(t, view[1], "Receipt")
+	assert.Len(t, pane.View(1, paneNow), 1)
+	mockClient.AssertExpectations(t)
+}
+
+func TestMessagesPane_Empty(t *testing.T) {
+	pane := NewMessagesPane(time.Hour, 20, time.Minute)
+	pane.Apply([]responses.Message(nil))
+	assert.Equal(t, []string{"No messages in the last 1h"}, pane.View(3, paneNow))
+}
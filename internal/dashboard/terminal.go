@@ -0,0 +1,97 @@
+package dashboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/AhaSend/ahasend-cli/internal/client"
+)
+
+// tick is how often due panes are started and the frame is redrawn, which
+// also keeps the "updated ... ago" labels current
+const tick = time.Second
+
+// fetchResult is a finished pane fetch sent back to the UI loop
+type fetchResult struct {
+	pane int
+	data any
+	err  error
+}
+
+// tickMsg asks the UI loop to start due panes and redraw
+type tickMsg struct{}
+
+// program runs a Model as a bubbletea program. Fetches run as commands, so
+// they stay off the UI loop and report back with a fetchResult.
+type program struct {
+	model     *Model
+	apiClient client.AhaSendClient
+	width     int
+	height    int
+}
+
+// Run shows the dashboard on the terminal until the user quits or ctx is
+// cancelled. bubbletea puts the terminal in raw mode on the alternate
+// screen, and restores it on return, on a panic and on SIGINT.
+func Run(ctx context.Context, in io.Reader, out io.Writer, apiClient client.AhaSendClient, model *Model) error {
+	p := tea.NewProgram(&program{model: model, apiClient: apiClient, width: 100, height: 40},
+		tea.WithContext(ctx), tea.WithInput(in), tea.WithOutput(out), tea.WithAltScreen())
+	if _, err := p.Run(); err != nil && !errors.Is(err, tea.ErrProgramKilled) {
+		return fmt.Errorf("failed to run the dashboard: %w", err)
+	}
+	return nil
+}
+
+// Init starts the panes and the ticker
+func (p *program) Init() tea.Cmd {
+	return tea.Batch(p.start(p.model.Due(time.Now())), tickCmd())
+}
+
+// Update handles key presses, finished fetches, ticks and resizes
+func (p *program) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch p.model.HandleKey(Key(msg.String())) {
+		case ActionQuit:
+			return p, tea.Quit
+		case ActionRefresh:
+			return p, p.start(p.model.Refresh())
+		}
+	case fetchResult:
+		p.model.Finish(msg.pane, msg.data, msg.err, time.Now())
+	case tickMsg:
+		return p, tea.Batch(p.start(p.model.Due(time.Now())), tickCmd())
+	case tea.WindowSizeMsg:
+		p.width, p.height = msg.Width, msg.Height
+	}
+	return p, nil
+}
+
+// View renders the current frame
+func (p *program) View() string {
+	return p.model.View(p.width, p.height, time.Now())
+}
+
+// start marks the panes as loading and returns the commands fetching them
+func (p *program) start(panes []int) tea.Cmd {
+	now := time.Now()
+	cmds := make([]tea.Cmd, len(panes))
+	for n, i := range panes {
+		p.model.Start(i, now)
+		pane := p.model.Pane(i)
+		cmds[n] = func() tea.Msg {
+			data, err := pane.Fetch(p.apiClient, now)
+			return fetchResult{pane: i, data: data, err: err}
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+func tickCmd() tea.Cmd {
+	return tea.Tick(tick, func(time.Time) tea.Msg { return tickMsg{} })
+}
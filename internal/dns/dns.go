@@ -8,6 +8,7 @@
 //   - Interactive DNS configuration instructions
 //   - Provider-specific formatting and syntax
 //   - Copy-paste friendly record formats
+//   - Propagation checks of the expected records across public resolvers
 //
 // The package supports common DNS providers and infrastructure-as-code
 // tools, making it easy for users to configure their domains regardless
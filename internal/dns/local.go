@@ -0,0 +1,115 @@
+package dns
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/models/responses"
+)
+
+// SystemResolver names the resolver configured on this machine, which a
+// Lookuper queries when given an empty server
+const SystemResolver = "system"
+
+// LocalRecord is an expected record as one resolver serves it
+type LocalRecord struct {
+	Type     string
+	Host     string
+	Expected string
+	Required bool
+	Status   PropagationStatus
+	Observed []string // the values served, if any
+	Error    string   // why the resolver could not be queried, for StatusError
+}
+
+// LocalVerification is every expected record of a domain looked up by the
+// CLI on a single resolver, independently of AhaSend's own DNS check
+type LocalVerification struct {
+	Domain    string
+	Resolver  string // host or host:port, or SystemResolver
+	Records   []LocalRecord
+	CheckedAt time.Time
+}
+
+// RequiredCounts returns how many required records are found and how many
+// there are in total
+func (v *LocalVerification) RequiredCounts() (found, total int) {
+	for _, record := range v.Records {
+		if !record.Required {
+			continue
+		}
+		total++
+		if record.Status == StatusFound {
+			found++
+		}
+	}
+	return found, total
+}
+
+// State summarizes the records the resolver serves the way Verification
+// summarizes AhaSend's check
+func (v *LocalVerification) State() VerificationState {
+	found, total := v.RequiredCounts()
+	switch {
+	case found == total:
+		return StateVerified
+	case found > 0:
+		return StatePartiallyVerified
+	default:
+		return StateUnverified
+	}
+}
+
+// VerifyLocally looks up every expected record of the domain on resolver,
+// or on the system resolver when resolver is empty, and compares what is
+// served with the expected content. When the API marks no record as
+// required, all records count as required.
+func VerifyLocally(ctx context.Context, lookuper Lookuper, domain *responses.Domain, resolver string) *LocalVerification {
+	server := resolver
+	if resolver == "" || resolver == SystemResolver {
+		server, resolver = "", SystemResolver
+	}
+	verification := &LocalVerification{
+		Domain:   domain.Domain,
+		Resolver: resolver,
+		Records:  make([]LocalRecord, len(domain.DNSRecords)),
+	}
+
+	anyRequired := false
+	for _, record := range domain.DNSRecords {
+		anyRequired = anyRequired || record.Required
+	}
+
+	var wg sync.WaitGroup
+	for i, record := range domain.DNSRecords {
+		wg.Add(1)
+		go func(i int, record responses.DNSRecord) {
+			defer wg.Done()
+			expected := &RecordPropagation{
+				Type:     strings.ToUpper(record.Type),
+				Host:     qualifyHost(record.Host, domain.Domain),
+				Content:  record.Content,
+				Required: record.Required || !anyRequired,
+			}
+			values, err := lookuper.Lookup(ctx, server, expected.Type, expected.Host)
+			local := LocalRecord{
+				Type:     expected.Type,
+				Host:     expected.Host,
+				Expected: record.Content,
+				Required: expected.Required,
+				Status:   compareValues(ctx, lookuper, server, expected, values, err),
+				Observed: values,
+			}
+			if err != nil {
+				local.Error = err.Error()
+			}
+			verification.Records[i] = local
+		}(i, record)
+	}
+	wg.Wait()
+
+	verification.CheckedAt = time.Now()
+	return verification
+}
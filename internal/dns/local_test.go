@@ -0,0 +1,57 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyLocally(t *testing.T) {
+	lookuper := &fakeLookuper{
+		records: map[string][]string{
+			"1.1.1.1:53|TXT|example.com":                      {"v=spf1 include:ahasend.com ~all"},
+			"1.1.1.1:53|TXT|_dmarc.example.com":               {"v=DMARC1; p=reject;"},
+			"1.1.1.1:53|CNAME|ahasend._domainkey.example.com": {"dkim.ahasend.com."},
+		},
+		errors: map[string]error{
+			"1.1.1.1:53|CNAME|track.example.com": fmt.Errorf("i/o timeout"),
+		},
+	}
+
+	verification := VerifyLocally(context.Background(), lookuper, verificationTestDomain(), "1.1.1.1:53")
+	assert.Equal(t, "1.1.1.1:53", verification.Resolver)
+	require.Len(t, verification.Records, 4)
+
+	statuses := make([]PropagationStatus, len(verification.Records))
+	for i, record := range verification.Records {
+		statuses[i] = record.Status
+	}
+	assert.Equal(t, []PropagationStatus{StatusFound, StatusMismatched, StatusFound, StatusError}, statuses)
+	assert.Equal(t, "_dmarc.example.com", verification.Records[1].Host)
+	assert.Equal(t, []string{"v=DMARC1; p=reject;"}, verification.Records[1].Observed)
+	assert.Equal(t, "i/o timeout", verification.Records[3].Error)
+
+	found, total := verification.RequiredCounts()
+	assert.Equal(t, 2, found)
+	assert.Equal(t, 3, total, "the optional record does not count")
+	assert.Equal(t, StatePartiallyVerified, verification.State())
+}
+
+func TestVerifyLocally_SystemResolver(t *testing.T) {
+	domain := verificationTestDomain()
+	lookuper := &fakeLookuper{records: map[string][]string{}}
+	for _, record := range domain.DNSRecords {
+		lookuper.records[fmt.Sprintf("|%s|%s", record.Type, qualifyHost(record.Host, domain.Domain))] = []string{record.Content}
+	}
+
+	verification := VerifyLocally(context.Background(), lookuper, domain, "")
+	assert.Equal(t, SystemResolver, verification.Resolver)
+	assert.Equal(t, StateVerified, verification.State(), "the system resolver is queried with an empty server")
+
+	verification = VerifyLocally(context.Background(), &fakeLookuper{}, domain, "")
+	assert.Equal(t, StateUnverified, verification.State())
+	assert.Equal(t, StatusMissing, verification.Records[0].Status)
+}
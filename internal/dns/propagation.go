@@ -0,0 +1,256 @@
+package dns
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/models/responses"
+)
+
+// DefaultResolvers are the public resolvers queried when none are given
+var DefaultResolvers = []string{"8.8.8.8", "1.1.1.1", "9.9.9.9"}
+
+// PropagationStatus is the visibility of one record on one resolver
+type PropagationStatus string
+
+// Propagation statuses
+const (
+	StatusFound      PropagationStatus = "found"      // the expected value is served
+	StatusMissing    PropagationStatus = "missing"    // the record does not exist yet
+	StatusMismatched PropagationStatus = "mismatched" // the record exists with a different value
+	StatusError      PropagationStatus = "error"      // the resolver could not be queried
+)
+
+// Lookuper queries a single DNS server, or the system resolver when server
+// is empty, for the values of a record. Names that do not exist yield no
+// values and no error.
+type Lookuper interface {
+	Lookup(ctx context.Context, server, recordType, name string) ([]string, error)
+}
+
+// RecordPropagation is the visibility of one expected record on each resolver
+type RecordPropagation struct {
+	Type     string
+	Host     string
+	Content  string
+	Required bool
+	Statuses []PropagationStatus // aligned with PropagationMatrix.Resolvers
+}
+
+// PropagationMatrix is the visibility of every expected record on every resolver
+type PropagationMatrix struct {
+	Domain    string
+	Resolvers []string
+	Records   []RecordPropagation
+	CheckedAt time.Time
+}
+
+// Complete reports whether every required record is found on every resolver
+func (m *PropagationMatrix) Complete() bool {
+	visible, total := m.RequiredCounts()
+	return visible == total
+}
+
+// RequiredCounts returns how many required record/resolver checks are found
+// and how many there are in total
+func (m *PropagationMatrix) RequiredCounts() (visible, total int) {
+	for _, record := range m.Records {
+		if !record.Required {
+			continue
+		}
+		for _, status := range record.Statuses {
+			total++
+			if status == StatusFound {
+				visible++
+			}
+		}
+	}
+	return visible, total
+}
+
+// Pending lists the required record/resolver checks that are not found yet
+func (m *PropagationMatrix) Pending() []string {
+	var pending []string
+	for _, record := range m.Records {
+		if !record.Required {
+			continue
+		}
+		for i, status := range record.Statuses {
+			if status != StatusFound {
+				pending = append(pending, fmt.Sprintf("%s %s @ %s (%s)", record.Type, record.Host, m.Resolvers[i], status))
+			}
+		}
+	}
+	return pending
+}
+
+// CheckPropagation queries every expected record of the domain against every
+// resolver. When the API marks no record as required, all records are
+// treated as required.
+func CheckPropagation(ctx context.Context, lookuper Lookuper, domain *responses.Domain, resolvers []string) *PropagationMatrix {
+	matrix := &PropagationMatrix{
+		Domain:    domain.Domain,
+		Resolvers: resolvers,
+		Records:   make([]RecordPropagation, len(domain.DNSRecords)),
+	}
+
+	anyRequired := false
+	for _, record := range domain.DNSRecords {
+		anyRequired = anyRequired || record.Required
+	}
+
+	var wg sync.WaitGroup
+	for i, record := range domain.DNSRecords {
+		matrix.Records[i] = RecordPropagation{
+			Type:     strings.ToUpper(record.Type),
+			Host:     qualifyHost(record.Host, domain.Domain),
+			Content:  record.Content,
+			Required: record.Required || !anyRequired,
+			Statuses: make([]PropagationStatus, len(resolvers)),
+		}
+		for j, server := range resolvers {
+			wg.Add(1)
+			go func(rec *RecordPropagation, j int, server string) {
+				defer wg.Done()
+				rec.Statuses[j] = checkRecord(ctx, lookuper, server, rec)
+			}(&matrix.Records[i], j, server)
+		}
+	}
+	wg.Wait()
+
+	matrix.CheckedAt = time.Now()
+	return matrix
+}
+
+// checkRecord compares the values served by one resolver with the expected content
+func checkRecord(ctx context.Context, lookuper Lookuper, server string, record *RecordPropagation) PropagationStatus {
+	values, err := lookuper.Lookup(ctx, server, record.Type, record.Host)
+	return compareValues(ctx, lookuper, server, record, values, err)
+}
+
+// compareValues classifies the values one resolver served for a record
+func compareValues(ctx context.Context, lookuper Lookuper, server string, record *RecordPropagation, values []string, err error) PropagationStatus {
+	if err != nil {
+		return StatusError
+	}
+	if len(values) == 0 {
+		return StatusMissing
+	}
+
+	expected := normalizeValue(record.Type, record.Content)
+	for _, value := range values {
+		if normalizeValue(record.Type, value) == expected {
+			return StatusFound
+		}
+	}
+
+	// CNAME lookups follow the chain to the canonical name, so a target that
+	// is itself an alias matches when both resolve to the same name
+	if record.Type == "CNAME" {
+		targets, err := lookuper.Lookup(ctx, server, record.Type, record.Content)
+		if err == nil {
+			for _, target := range targets {
+				for _, value := range values {
+					if normalizeValue(record.Type, target) == normalizeValue(record.Type, value) {
+						return StatusFound
+					}
+				}
+			}
+		}
+	}
+	return StatusMismatched
+}
+
+// qualifyHost turns a record host relative to the domain into a full name
+func qualifyHost(host, domain string) string {
+	host = strings.TrimSuffix(strings.TrimSpace(host), ".")
+	if host == "" || host == "@" {
+		return domain
+	}
+	if host == domain || strings.HasSuffix(host, "."+domain) {
+		return host
+	}
+	return host + "." + domain
+}
+
+// normalizeValue makes served and expected values comparable. Names are
+// case-insensitive; TXT values keep their case but lose quoting.
+func normalizeValue(recordType, value string) string {
+	value = strings.TrimSpace(value)
+	if recordType == "TXT" {
+		return strings.ReplaceAll(strings.Trim(value, `"`), `" "`, "")
+	}
+	return strings.ToLower(strings.TrimSuffix(value, "."))
+}
+
+// NetLookuper queries resolvers with the Go DNS client
+type NetLookuper struct {
+	Timeout time.Duration // per query; defaults to 5s
+}
+
+// Lookup implements Lookuper for A, AAAA, CNAME, MX and TXT records
+func (l NetLookuper) Lookup(ctx context.Context, server, recordType, name string) ([]string, error) {
+	timeout := l.Timeout
+	if timeout == 0 {
+		timeout = 5 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// An empty server leaves the query to the system resolver
+	resolver := net.DefaultResolver
+	if server != "" {
+		address := server
+		if _, _, err := net.SplitHostPort(server); err != nil {
+			address = net.JoinHostPort(server, "53")
+		}
+		resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, network, address)
+			},
+		}
+	}
+
+	var values []string
+	var err error
+	switch recordType {
+	case "TXT":
+		values, err = resolver.LookupTXT(ctx, name)
+	case "CNAME":
+		var cname string
+		cname, err = resolver.LookupCNAME(ctx, name)
+		// A name without a CNAME resolves to itself
+		if err == nil && !strings.EqualFold(strings.TrimSuffix(cname, "."), strings.TrimSuffix(name, ".")) {
+			values = []string{cname}
+		}
+	case "MX":
+		var records []*net.MX
+		records, err = resolver.LookupMX(ctx, name)
+		for _, mx := range records {
+			values = append(values, mx.Host)
+		}
+	case "A", "AAAA":
+		var addrs []net.IPAddr
+		addrs, err = resolver.LookupIPAddr(ctx, name)
+		for _, addr := range addrs {
+			if (addr.IP.To4() != nil) == (recordType == "A") {
+				values = append(values, addr.IP.String())
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported record type %s", recordType)
+	}
+
+	var dnsErr *net.DNSError
+	if err != nil && stderrors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return nil, nil
+	}
+	return values, err
+}
@@ -0,0 +1,141 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLookuper serves records per server from a "server|type|name" map
+type fakeLookuper struct {
+	mu      sync.Mutex
+	records map[string][]string
+	errors  map[string]error
+}
+
+func (f *fakeLookuper) Lookup(ctx context.Context, server, recordType, name string) ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := fmt.Sprintf("%s|%s|%s", server, recordType, name)
+	if err := f.errors[key]; err != nil {
+		return nil, err
+	}
+	return f.records[key], nil
+}
+
+func propagationTestDomain() *responses.Domain {
+	return &responses.Domain{
+		Domain: "example.com",
+		DNSRecords: []responses.DNSRecord{
+			{Type: "txt", Host: "_dmarc", Content: "v=DMARC1; p=none;", Required: true},
+			{Type: "CNAME", Host: "ahasend._domainkey.example.com", Content: "dkim.ahasend.com", Required: true},
+			{Type: "CNAME", Host: "track", Content: "track.ahasend.com", Required: false},
+		},
+	}
+}
+
+func TestCheckPropagation(t *testing.T) {
+	lookuper := &fakeLookuper{
+		records: map[string][]string{
+			"8.8.8.8|TXT|_dmarc.example.com":               {"v=DMARC1; p=none;"},
+			"8.8.8.8|CNAME|ahasend._domainkey.example.com": {"DKIM.ahasend.com."},
+			"1.1.1.1|TXT|_dmarc.example.com":               {"v=DMARC1; p=reject;"},
+			"1.1.1.1|CNAME|ahasend._domainkey.example.com": {"dkim.ahasend.com."},
+			"1.1.1.1|CNAME|track.example.com":              {"track.ahasend.com."},
+			"9.9.9.9|TXT|_dmarc.example.com":               {`"v=DMARC1; p=none;"`},
+		},
+		errors: map[string]error{
+			"9.9.9.9|CNAME|ahasend._domainkey.example.com": fmt.Errorf("i/o timeout"),
+		},
+	}
+
+	matrix := CheckPropagation(context.Background(), lookuper, propagationTestDomain(), []string{"8.8.8.8", "1.1.1.1", "9.9.9.9"})
+
+	require.Len(t, matrix.Records, 3)
+	assert.Equal(t, "TXT", matrix.Records[0].Type)
+	assert.Equal(t, "_dmarc.example.com", matrix.Records[0].Host)
+	assert.Equal(t, []PropagationStatus{StatusFound, StatusMismatched, StatusFound}, matrix.Records[0].Statuses)
+	assert.Equal(t, []PropagationStatus{StatusFound, StatusFound, StatusError}, matrix.Records[1].Statuses)
+	assert.Equal(t, []PropagationStatus{StatusMissing, StatusFound, StatusMissing}, matrix.Records[2].Statuses)
+	assert.False(t, matrix.Records[2].Required)
+
+	visible, total := matrix.RequiredCounts()
+	assert.Equal(t, 4, visible)
+	assert.Equal(t, 6, total)
+	assert.False(t, matrix.Complete())
+	assert.Equal(t, []string{
+		"TXT _dmarc.example.com @ 1.1.1.1 (mismatched)",
+		"CNAME ahasend._domainkey.example.com @ 9.9.9.9 (error)",
+	}, matrix.Pending())
+	assert.False(t, matrix.CheckedAt.IsZero())
+}
+
+func TestCheckPropagation_Complete(t *testing.T) {
+	lookuper := &fakeLookuper{records: map[string][]string{
+		"8.8.8.8|TXT|_dmarc.example.com":               {"v=DMARC1; p=none;"},
+		"8.8.8.8|CNAME|ahasend._domainkey.example.com": {"dkim.ahasend.com"},
+	}}
+
+	matrix := CheckPropagation(context.Background(), lookuper, propagationTestDomain(), []string{"8.8.8.8"})
+
+	// The optional tracking record is missing but does not block completion
+	assert.True(t, matrix.Complete())
+	assert.Empty(t, matrix.Pending())
+}
+
+func TestCheckPropagation_NoRequiredRecords(t *testing.T) {
+	domain := &responses.Domain{
+		Domain: "example.com",
+		DNSRecords: []responses.DNSRecord{
+			{Type: "TXT", Host: "@", Content: "v=spf1 include:ahasend.com ~all"},
+		},
+	}
+
+	matrix := CheckPropagation(context.Background(), &fakeLookuper{}, domain, []string{"8.8.8.8"})
+
+	require.Len(t, matrix.Records, 1)
+	assert.Equal(t, "example.com", matrix.Records[0].Host)
+	assert.True(t, matrix.Records[0].Required)
+	assert.False(t, matrix.Complete())
+}
+
+func TestCheckPropagation_CNAMEChain(t *testing.T) {
+	// The expected target is itself an alias, so resolvers report the end of the chain
+	lookuper := &fakeLookuper{records: map[string][]string{
+		"8.8.8.8|CNAME|mail.example.com": {"edge.ahasend.net."},
+		"8.8.8.8|CNAME|mx.ahasend.com":   {"edge.ahasend.net."},
+	}}
+	domain := &responses.Domain{
+		Domain:     "example.com",
+		DNSRecords: []responses.DNSRecord{{Type: "CNAME", Host: "mail", Content: "mx.ahasend.com", Required: true}},
+	}
+
+	matrix := CheckPropagation(context.Background(), lookuper, domain, []string{"8.8.8.8"})
+
+	assert.Equal(t, []PropagationStatus{StatusFound}, matrix.Records[0].Statuses)
+}
+
+func TestQualifyHost(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{"@", "example.com"},
+		{"", "example.com"},
+		{"example.com", "example.com"},
+		{"_dmarc", "_dmarc.example.com"},
+		{"_dmarc.example.com.", "_dmarc.example.com"},
+		{"mail.example.com", "mail.example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			assert.Equal(t, tt.want, qualifyHost(tt.host, "example.com"))
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/models/responses"
+)
+
+// VerificationState summarizes the server-side DNS check of a domain
+type VerificationState string
+
+// Verification states
+const (
+	StateVerified          VerificationState = "verified"           // every required record passed
+	StatePartiallyVerified VerificationState = "partially_verified" // some required records passed
+	StateUnverified        VerificationState = "unverified"         // no required record passed
+)
+
+// FailureReason categorizes why a record failed the server-side check
+type FailureReason string
+
+// Failure reasons
+const (
+	ReasonMissing       FailureReason = "missing"        // no resolver serves the record
+	ReasonWrongValue    FailureReason = "wrong_value"    // the record is served with another value
+	ReasonConflicting   FailureReason = "conflicting"    // several records compete for the expected one
+	ReasonNotPropagated FailureReason = "not_propagated" // the expected value is served, but not everywhere or not yet seen by AhaSend
+	ReasonUnknown       FailureReason = "unknown"        // no resolver could be queried
+)
+
+// ObservedLocally labels values the CLI looked up itself. The API reports
+// only whether each record passed, not what its check saw.
+const ObservedLocally = "observed locally"
+
+// RecordFailure is a record that failed the server-side check, with what
+// the resolvers serve for it
+type RecordFailure struct {
+	Type           string
+	Host           string
+	Expected       string
+	Required       bool
+	Reason         FailureReason
+	Detail         string
+	Observed       []string
+	ObservedSource string
+}
+
+// Verification is the outcome of the server-side DNS check of a domain,
+// with the failed records explained
+type Verification struct {
+	Domain         string
+	State          VerificationState
+	RequiredPassed int
+	RequiredTotal  int
+	Resolvers      []string
+	Failures       []RecordFailure
+	CheckedAt      time.Time
+}
+
+// Verify explains the server-side DNS check of a domain: every record the
+// API did not mark as propagated is looked up on the resolvers and given a
+// reason. When the API marks no record as required, all records count as
+// required.
+func Verify(ctx context.Context, lookuper Lookuper, domain *responses.Domain, resolvers []string) *Verification {
+	verification := &Verification{Domain: domain.Domain, Resolvers: resolvers}
+
+	anyRequired := false
+	for _, record := range domain.DNSRecords {
+		anyRequired = anyRequired || record.Required
+	}
+
+	var failing []responses.DNSRecord
+	for _, record := range domain.DNSRecords {
+		required := record.Required || !anyRequired
+		if required {
+			verification.RequiredTotal++
+			if record.Propagated {
+				verification.RequiredPassed++
+			}
+		}
+		if !record.Propagated {
+			failing = append(failing, record)
+		}
+	}
+
+	switch {
+	case domain.DNSValid && verification.RequiredPassed == verification.RequiredTotal:
+		verification.State = StateVerified
+	case verification.RequiredPassed > 0:
+		verification.State = StatePartiallyVerified
+	default:
+		verification.State = StateUnverified
+	}
+
+	verification.Failures = make([]RecordFailure, len(failing))
+	var wg sync.WaitGroup
+	for i, record := range failing {
+		wg.Add(1)
+		go func(i int, record responses.DNSRecord) {
+			defer wg.Done()
+			verification.Failures[i] = diagnoseRecord(ctx, lookuper, record, domain.Domain, record.Required || !anyRequired, resolvers)
+		}(i, record)
+	}
+	wg.Wait()
+
+	verification.CheckedAt = time.Now()
+	return verification
+}
+
+// RequiredFailures returns the failed records that are required
+func (v *Verification) RequiredFailures() []RecordFailure {
+	var failures []RecordFailure
+	for _, failure := range v.Failures {
+		if failure.Required {
+			failures = append(failures, failure)
+		}
+	}
+	return failures
+}
+
+// diagnoseRecord looks up a failed record on every resolver and explains
+// the failure from what they serve
+func diagnoseRecord(ctx context.Context, lookuper Lookuper, record responses.DNSRecord, domain string, required bool, resolvers []string) RecordFailure {
+	expected := &RecordPropagation{
+		Type:     strings.ToUpper(record.Type),
+		Host:     qualifyHost(record.Host, domain),
+		Content:  record.Content,
+		Required: required,
+	}
+	failure := RecordFailure{
+		Type:           expected.Type,
+		Host:           expected.Host,
+		Expected:       record.Content,
+		Required:       required,
+		ObservedSource: ObservedLocally,
+	}
+
+	counts := make(map[PropagationStatus]int)
+	seen := make(map[string]bool)
+	conflicting := false
+	for _, server := range resolvers {
+		values, err := lookuper.Lookup(ctx, server, expected.Type, expected.Host)
+		counts[compareValues(ctx, lookuper, server, expected, values, err)]++
+		for _, value := range values {
+			if !seen[value] {
+				seen[value] = true
+				failure.Observed = append(failure.Observed, value)
+			}
+		}
+		conflicting = conflicting || conflictingCount(expected, values) > 1
+	}
+
+	switch {
+	case len(resolvers) == 0:
+		failure.Reason = ReasonUnknown
+		failure.Detail = "no resolver was queried"
+	case conflicting:
+		failure.Reason = ReasonConflicting
+		failure.Detail = fmt.Sprintf("%d %s records compete at %s; keep only the expected one", conflictingCount(expected, failure.Observed), expected.Type, expected.Host)
+	case counts[StatusFound] == len(resolvers):
+		failure.Reason = ReasonNotPropagated
+		failure.Detail = "every resolver serves the expected value, but AhaSend has not seen it yet; run 'ahasend domains check-dns' to check again"
+	case counts[StatusFound] > 0:
+		failure.Reason = ReasonNotPropagated
+		failure.Detail = fmt.Sprintf("the expected value is served by %d of %d resolvers", counts[StatusFound], len(resolvers))
+	case counts[StatusMismatched] > 0:
+		failure.Reason = ReasonWrongValue
+		failure.Detail = "the record exists with a different value"
+	case counts[StatusMissing] > 0:
+		failure.Reason = ReasonMissing
+		failure.Detail = "no resolver serves the record"
+	default:
+		failure.Reason = ReasonUnknown
+		failure.Detail = "no resolver could be queried"
+	}
+	return failure
+}
+
+// conflictingCount counts the values that compete with the expected record
+// where only one may exist: CNAMEs, or TXT records of the expected kind, such
+// as SPF records
+func conflictingCount(record *RecordPropagation, values []string) int {
+	switch record.Type {
+	case "CNAME":
+		return len(values)
+	case "TXT":
+		kind := txtKind(normalizeValue(record.Type, record.Content))
+		if kind == "" {
+			return 0
+		}
+		count := 0
+		for _, value := range values {
+			if txtKind(normalizeValue(record.Type, value)) == kind {
+				count++
+			}
+		}
+		return count
+	}
+	return 0
+}
+
+// txtKind returns the version tag a TXT record starts with, such as
+// "v=spf1" or "v=dkim1", or "" when it has none
+func txtKind(value string) string {
+	if !strings.HasPrefix(strings.ToLower(value), "v=") {
+		return ""
+	}
+	end := strings.IndexAny(value, " ;")
+	if end < 0 {
+		end = len(value)
+	}
+	return strings.ToLower(value[:end])
+}
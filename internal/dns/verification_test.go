@@ -0,0 +1,129 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func verificationTestDomain() *responses.Domain {
+	return &responses.Domain{
+		Domain: "example.com",
+		DNSRecords: []responses.DNSRecord{
+			{Type: "TXT", Host: "example.com", Content: "v=spf1 include:ahasend.com ~all", Required: true},
+			{Type: "TXT", Host: "_dmarc", Content: "v=DMARC1; p=none;", Required: true},
+			{Type: "CNAME", Host: "ahasend._domainkey", Content: "dkim.ahasend.com", Required: true},
+			{Type: "CNAME", Host: "track", Content: "track.ahasend.com", Required: false},
+		},
+	}
+}
+
+func TestVerify_States(t *testing.T) {
+	tests := []struct {
+		name       string
+		dnsValid   bool
+		propagated []bool
+		state      VerificationState
+		passed     int
+	}{
+		{"all passed", true, []bool{true, true, true, false}, StateVerified, 3},
+		{"some passed", false, []bool{true, false, true, true}, StatePartiallyVerified, 2},
+		{"none passed", false, []bool{false, false, false, true}, StateUnverified, 0},
+		{"passed but not valid", false, []bool{true, true, true, true}, StatePartiallyVerified, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			domain := verificationTestDomain()
+			domain.DNSValid = tt.dnsValid
+			for i := range domain.DNSRecords {
+				domain.DNSRecords[i].Propagated = tt.propagated[i]
+			}
+
+			verification := Verify(context.Background(), &fakeLookuper{}, domain, []string{"8.8.8.8"})
+			assert.Equal(t, tt.state, verification.State)
+			assert.Equal(t, tt.passed, verification.RequiredPassed)
+			assert.Equal(t, 3, verification.RequiredTotal)
+		})
+	}
+}
+
+func TestVerify_FailureReasons(t *testing.T) {
+	domain := verificationTestDomain()
+	lookuper := &fakeLookuper{
+		records: map[string][]string{
+			// Two SPF records compete at the apex
+			"8.8.8.8|TXT|example.com": {"v=spf1 include:ahasend.com ~all", "v=spf1 include:other.com ~all", "google-site-verification=x"},
+			"1.1.1.1|TXT|example.com": {"v=spf1 include:ahasend.com ~all", "v=spf1 include:other.com ~all"},
+			// DMARC is served with another policy
+			"8.8.8.8|TXT|_dmarc.example.com": {"v=DMARC1; p=reject;"},
+			"1.1.1.1|TXT|_dmarc.example.com": {"v=DMARC1; p=reject;"},
+			// DKIM is served by one of the two resolvers
+			"8.8.8.8|CNAME|ahasend._domainkey.example.com": {"dkim.ahasend.com."},
+		},
+	}
+
+	verification := Verify(context.Background(), lookuper, domain, []string{"8.8.8.8", "1.1.1.1"})
+	require.Len(t, verification.Failures, 4)
+	assert.Equal(t, StateUnverified, verification.State)
+
+	spf := verification.Failures[0]
+	assert.Equal(t, ReasonConflicting, spf.Reason)
+	assert.Equal(t, "TXT", spf.Type)
+	assert.Equal(t, "example.com", spf.Host)
+	assert.Contains(t, spf.Detail, "2 TXT records compete")
+	assert.Equal(t, ObservedLocally, spf.ObservedSource)
+	assert.Len(t, spf.Observed, 3)
+
+	dmarc := verification.Failures[1]
+	assert.Equal(t, ReasonWrongValue, dmarc.Reason)
+	assert.Equal(t, "v=DMARC1; p=none;", dmarc.Expected)
+	assert.Equal(t, []string{"v=DMARC1; p=reject;"}, dmarc.Observed)
+
+	dkim := verification.Failures[2]
+	assert.Equal(t, ReasonNotPropagated, dkim.Reason)
+	assert.Contains(t, dkim.Detail, "1 of 2 resolvers")
+
+	track := verification.Failures[3]
+	assert.Equal(t, ReasonMissing, track.Reason)
+	assert.False(t, track.Required)
+	assert.Empty(t, track.Observed)
+
+	assert.Len(t, verification.RequiredFailures(), 3)
+}
+
+func TestVerify_ServedEverywhere(t *testing.T) {
+	domain := &responses.Domain{
+		Domain:     "example.com",
+		DNSRecords: []responses.DNSRecord{{Type: "TXT", Host: "_dmarc", Content: "v=DMARC1; p=none;", Required: true}},
+	}
+	lookuper := &fakeLookuper{
+		records: map[string][]string{"8.8.8.8|TXT|_dmarc.example.com": {`"v=DMARC1; p=none;"`}},
+	}
+
+	verification := Verify(context.Background(), lookuper, domain, []string{"8.8.8.8"})
+	require.Len(t, verification.Failures, 1)
+	assert.Equal(t, ReasonNotPropagated, verification.Failures[0].Reason)
+	assert.Contains(t, verification.Failures[0].Detail, "check-dns")
+}
+
+func TestVerify_ResolverErrors(t *testing.T) {
+	domain := &responses.Domain{
+		Domain:     "example.com",
+		DNSRecords: []responses.DNSRecord{{Type: "TXT", Host: "_dmarc", Content: "v=DMARC1; p=none;", Required: true}},
+	}
+	lookuper := &fakeLookuper{
+		errors: map[string]error{"8.8.8.8|TXT|_dmarc.example.com": fmt.Errorf("i/o timeout")},
+	}
+
+	verification := Verify(context.Background(), lookuper, domain, []string{"8.8.8.8"})
+	require.Len(t, verification.Failures, 1)
+	assert.Equal(t, ReasonUnknown, verification.Failures[0].Reason)
+
+	verification = Verify(context.Background(), lookuper, domain, nil)
+	assert.Equal(t, ReasonUnknown, verification.Failures[0].Reason)
+	assert.Equal(t, "no resolver was queried", verification.Failures[0].Detail)
+}
@@ -0,0 +1,197 @@
+// Package docs generates the command reference from the cobra command tree.
+//
+// One page is written per visible command in markdown, man (roff) or
+// reStructuredText. Next to the usual usage, flags and examples, each page of
+// a runnable command lists the output formats it supports and the API key
+// scopes it needs. Pages carry no timestamps or version information, so the
+// same command tree always produces byte-identical files that can be
+// committed and diffed.
+package docs
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+)
+
+// Supported reference formats
+const (
+	FormatMarkdown = "markdown"
+	FormatMan      = "man"
+	FormatReST     = "rest"
+)
+
+// GetSupportedFormats returns all supported reference formats
+func GetSupportedFormats() []string {
+	return []string{FormatMarkdown, FormatMan, FormatReST}
+}
+
+// renderer writes pages in one reference format
+type renderer interface {
+	fileName(cmd *cobra.Command) string
+	render(w io.Writer, p *page) error
+}
+
+func newRenderer(format string) (renderer, error) {
+	switch format {
+	case FormatMarkdown:
+		return markdownRenderer{}, nil
+	case FormatMan:
+		return manRenderer{}, nil
+	case FormatReST:
+		return restRenderer{}, nil
+	}
+	return nil, errors.NewValidationError(fmt.Sprintf("unsupported docs format '%s'. Supported formats: %s",
+		format, strings.Join(GetSupportedFormats(), ", ")), nil)
+}
+
+// page is the format-independent content of one command's reference page
+type page struct {
+	Path     string // full command path, e.g. "ahasend messages send"
+	Short    string
+	Long     string
+	UseLine  string
+	Aliases  []string
+	Example  string
+	Flags    string
+	Global   string
+	Runnable bool
+	Formats  []string
+	Scopes   []string
+	Parent   *cobra.Command
+	Children []*cobra.Command
+}
+
+// Generate writes one page per visible command of root into dir, creating
+// dir if needed, and returns the number of pages written
+func Generate(root *cobra.Command, format, dir string) (int, error) {
+	r, err := newRenderer(format)
+	if err != nil {
+		return 0, err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return 0, errors.NewFileError(fmt.Sprintf("failed to create %s", dir), err)
+	}
+	return generateTree(root, r, dir)
+}
+
+func generateTree(cmd *cobra.Command, r renderer, dir string) (int, error) {
+	p := newPage(cmd)
+	path := filepath.Join(dir, r.fileName(cmd))
+
+	f, err := os.Create(path)
+	if err != nil {
+		return 0, errors.NewFileError(fmt.Sprintf("failed to create %s", path), err)
+	}
+	w := bufio.NewWriter(f)
+	if err := r.render(w, p); err != nil {
+		f.Close()
+		return 0, err
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return 0, errors.NewFileError(fmt.Sprintf("failed to write %s", path), err)
+	}
+	if err := f.Close(); err != nil {
+		return 0, errors.NewFileError(fmt.Sprintf("failed to write %s", path), err)
+	}
+
+	written := 1
+	for _, child := range p.Children {
+		n, err := generateTree(child, r, dir)
+		if err != nil {
+			return 0, err
+		}
+		written += n
+	}
+	return written, nil
+}
+
+func newPage(cmd *cobra.Command) *page {
+	// Execute adds the help and version flags lazily; add them here so the
+	// pages are the same whether or not the tree was executed first
+	cmd.InitDefaultHelpFlag()
+	cmd.InitDefaultVersionFlag()
+
+	p := &page{
+		Path:     cmd.CommandPath(),
+		Short:    cmd.Short,
+		Long:     cmd.Long,
+		UseLine:  cmd.UseLine(),
+		Aliases:  cmd.Aliases,
+		Example:  cmd.Example,
+		Flags:    cmd.NonInheritedFlags().FlagUsages(),
+		Global:   cmd.InheritedFlags().FlagUsages(),
+		Runnable: cmd.Runnable(),
+		Children: visibleChildren(cmd),
+	}
+	if cmd.HasParent() {
+		p.Parent = cmd.Parent()
+	}
+	if p.Runnable && cmd.HasParent() {
+		p.Formats = OutputFormats(cmd)
+		p.Scopes, _ = Scopes(cmd)
+	}
+	return p
+}
+
+// visibleChildren returns the documented subcommands of cmd. The help and
+// completion commands are added by cobra at execution time and are left out
+// so the output does not depend on whether the tree was executed.
+func visibleChildren(cmd *cobra.Command) []*cobra.Command {
+	var children []*cobra.Command
+	for _, child := range cmd.Commands() {
+		if !child.IsAvailableCommand() || child.IsAdditionalHelpTopicCommand() {
+			continue
+		}
+		if child.Name() == "help" || child.Name() == "completion" {
+			continue
+		}
+		children = append(children, child)
+	}
+	return children
+}
+
+// baseName joins the words of a command path with sep
+func baseName(cmd *cobra.Command, sep string) string {
+	return strings.ReplaceAll(cmd.CommandPath(), " ", sep)
+}
+
+// paragraphs splits text into its non-empty, blank-line separated paragraphs
+func paragraphs(text string) []string {
+	var result []string
+	for _, paragraph := range strings.Split(strings.TrimSpace(text), "\n\n") {
+		if paragraph = strings.Trim(paragraph, "\n"); paragraph != "" {
+			result = append(result, paragraph)
+		}
+	}
+	return result
+}
+
+// isPreformatted reports whether a paragraph relies on its layout
+func isPreformatted(paragraph string) bool {
+	for _, line := range strings.Split(paragraph, "\n") {
+		if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") || strings.HasPrefix(line, "- ") {
+			return true
+		}
+	}
+	return false
+}
+
+// describeFormats renders the supported output formats for display
+func describeFormats(formats []string) string {
+	if len(formats) == 0 {
+		return "Interactive output only; --output is ignored."
+	}
+	return strings.Join(formats, ", ")
+}
+
+// scopeNote is shown instead of a scope list for commands that need none
+const scopeNote = "No specific scope required."
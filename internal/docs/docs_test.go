@@ -0,0 +1,151 @@
+package docs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/validation"
+)
+
+func newTestTree() *cobra.Command {
+	root := &cobra.Command{Use: "ahasend", Short: "Root"}
+	root.PersistentFlags().String("output", "plain", "Output format")
+
+	group := &cobra.Command{Use: "webhooks", Short: "Manage webhooks"}
+	list := &cobra.Command{
+		Use:     "list",
+		Short:   "List webhooks",
+		Long:    "List webhooks.\n\n.hidden lines and \\backslashes stay literal:\n  ahasend webhooks list",
+		Example: "  ahasend webhooks list --output json",
+		RunE:    func(cmd *cobra.Command, args []string) error { return nil },
+	}
+	list.Flags().Int32("limit", 0, "Maximum number of webhooks")
+	listen := &cobra.Command{Use: "listen", Short: "Listen", RunE: list.RunE}
+	hidden := &cobra.Command{Use: "secret", Short: "Hidden", Hidden: true, RunE: list.RunE}
+	group.AddCommand(list, listen, hidden)
+
+	root.AddCommand(group)
+	root.InitDefaultCompletionCmd()
+	root.InitDefaultHelpCmd()
+	return root
+}
+
+func TestCommandScopesAreValid(t *testing.T) {
+	for command, scopes := range commandScopes {
+		for _, scope := range scopes {
+			assert.NoError(t, validation.ValidateScope(scope), "scope of %q", command)
+		}
+	}
+}
+
+func TestGenerate_Pages(t *testing.T) {
+	tests := []struct {
+		format string
+		files  []string
+	}{
+		{FormatMarkdown, []string{"ahasend.md", "ahasend_webhooks.md", "ahasend_webhooks_list.md", "ahasend_webhooks_listen.md"}},
+		{FormatMan, []string{"ahasend.1", "ahasend-webhooks.1", "ahasend-webhooks-list.1", "ahasend-webhooks-listen.1"}},
+		{FormatReST, []string{"ahasend.rst", "ahasend_webhooks.rst", "ahasend_webhooks_list.rst", "ahasend_webhooks_listen.rst"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			dir := t.TempDir()
+			pages, err := Generate(newTestTree(), tt.format, dir)
+			require.NoError(t, err)
+			assert.Equal(t, len(tt.files), pages)
+
+			entries, err := os.ReadDir(dir)
+			require.NoError(t, err)
+			var names []string
+			for _, entry := range entries {
+				names = append(names, entry.Name())
+			}
+			// Hidden commands and cobra's help and completion are left out
+			assert.ElementsMatch(t, tt.files, names)
+		})
+	}
+}
+
+func TestGenerate_Deterministic(t *testing.T) {
+	for _, format := range GetSupportedFormats() {
+		t.Run(format, func(t *testing.T) {
+			first, second := t.TempDir(), t.TempDir()
+			_, err := Generate(newTestTree(), format, first)
+			require.NoError(t, err)
+			_, err = Generate(newTestTree(), format, second)
+			require.NoError(t, err)
+
+			entries, err := os.ReadDir(first)
+			require.NoError(t, err)
+			for _, entry := range entries {
+				a, err := os.ReadFile(filepath.Join(first, entry.Name()))
+				require.NoError(t, err)
+				b, err := os.ReadFile(filepath.Join(second, entry.Name()))
+				require.NoError(t, err)
+				assert.Equal(t, string(a), string(b), entry.Name())
+			}
+		})
+	}
+}
+
+func TestMarkdown_CustomSections(t *testing.T) {
+	dir := t.TempDir()
+	_, err := Generate(newTestTree(), FormatMarkdown, dir)
+	require.NoError(t, err)
+
+	list, err := os.ReadFile(filepath.Join(dir, "ahasend_webhooks_list.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(list), "### Output formats\n\njson, jsonl, table, plain, csv\n")
+	assert.Contains(t, string(list), "### Required API scopes\n\n* `webhooks:read:all`\n")
+	assert.Contains(t, string(list), "### Examples\n\n```\n  ahasend webhooks list --output json\n```")
+	assert.Contains(t, string(list), "* [ahasend webhooks](ahasend_webhooks.md)\t - Manage webhooks")
+
+	listen, err := os.ReadFile(filepath.Join(dir, "ahasend_webhooks_listen.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(listen), "Interactive output only; --output is ignored.")
+
+	// Group commands only link to their subcommands
+	group, err := os.ReadFile(filepath.Join(dir, "ahasend_webhooks.md"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(group), "### Required API scopes")
+	assert.Contains(t, string(group), "* [ahasend webhooks list](ahasend_webhooks_list.md)\t - List webhooks")
+}
+
+func TestMan_Escaping(t *testing.T) {
+	dir := t.TempDir()
+	_, err := Generate(newTestTree(), FormatMan, dir)
+	require.NoError(t, err)
+
+	page, err := os.ReadFile(filepath.Join(dir, "ahasend-webhooks-list.1"))
+	require.NoError(t, err)
+	content := string(page)
+	assert.Contains(t, content, ".TH \"AHASEND-WEBHOOKS-LIST\" \"1\" \"\" \"ahasend\" \"AhaSend CLI\"\n")
+	assert.Contains(t, content, "\\&.hidden lines and \\ebackslashes stay literal:")
+	assert.Contains(t, content, ".SH REQUIRED API SCOPES\n\\fBwebhooks:read:all\\fP\n")
+	assert.Contains(t, content, ".SH SEE ALSO\n\\fBahasend-webhooks(1)\\fP\n")
+}
+
+func TestGenerate_UnsupportedFormat(t *testing.T) {
+	_, err := Generate(newTestTree(), "html", t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Supported formats: markdown, man, rest")
+}
+
+func TestCommandSchemasAreValid(t *testing.T) {
+	methods := make(map[string]bool)
+	for _, method := range printer.SchemaMethods() {
+		methods[method] = true
+	}
+	for command, schemas := range commandSchemas {
+		for _, method := range schemas {
+			assert.True(t, methods[method], "schema %q of %q is not a response handler method", method, command)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package docs
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// manRenderer writes section 1 man pages in roff. The header leaves the date
+// empty to keep the output deterministic.
+type manRenderer struct{}
+
+func (manRenderer) fileName(cmd *cobra.Command) string {
+	return manName(cmd) + ".1"
+}
+
+// manName is the page name of cmd, e.g. ahasend-messages-send
+func manName(cmd *cobra.Command) string {
+	return baseName(cmd, "-")
+}
+
+func (manRenderer) render(w io.Writer, p *page) error {
+	var b strings.Builder
+	name := strings.ReplaceAll(p.Path, " ", "-")
+
+	fmt.Fprintf(&b, ".TH \"%s\" \"1\" \"\" \"ahasend\" \"AhaSend CLI\"\n", strings.ToUpper(name))
+	b.WriteString(".SH NAME\n")
+	fmt.Fprintf(&b, "%s \\- %s\n", name, roffEscape(p.Short))
+
+	if p.Runnable {
+		b.WriteString(".SH SYNOPSIS\n")
+		fmt.Fprintf(&b, "\\fB%s\\fP\n", roffEscape(p.UseLine))
+	}
+
+	b.WriteString(".SH DESCRIPTION\n")
+	description := p.Long
+	if description == "" {
+		description = p.Short
+	}
+	writeRoffText(&b, description)
+
+	if len(p.Aliases) > 0 {
+		b.WriteString(".SH ALIASES\n")
+		fmt.Fprintf(&b, "%s\n", roffEscape(strings.Join(p.Aliases, ", ")))
+	}
+	if p.Flags != "" {
+		b.WriteString(".SH OPTIONS\n")
+		writeRoffPreformatted(&b, p.Flags)
+	}
+	if p.Global != "" {
+		b.WriteString(".SH OPTIONS INHERITED FROM PARENT COMMANDS\n")
+		writeRoffPreformatted(&b, p.Global)
+	}
+	if p.Example != "" {
+		b.WriteString(".SH EXAMPLES\n")
+		writeRoffPreformatted(&b, p.Example)
+	}
+
+	if p.Formats != nil {
+		b.WriteString(".SH OUTPUT FORMATS\n")
+		fmt.Fprintf(&b, "%s\n", roffEscape(describeFormats(p.Formats)))
+		b.WriteString(".SH REQUIRED API SCOPES\n")
+		if len(p.Scopes) == 0 {
+			fmt.Fprintf(&b, "%s\n", scopeNote)
+		}
+		for i, scope := range p.Scopes {
+			if i > 0 {
+				b.WriteString(".br\n")
+			}
+			fmt.Fprintf(&b, "\\fB%s\\fP\n", roffEscape(scope))
+		}
+	}
+
+	if p.Parent != nil || len(p.Children) > 0 {
+		var refs []string
+		if p.Parent != nil {
+			refs = append(refs, fmt.Sprintf("\\fB%s(1)\\fP", manName(p.Parent)))
+		}
+		for _, child := range p.Children {
+			refs = append(refs, fmt.Sprintf("\\fB%s(1)\\fP", manName(child)))
+		}
+		b.WriteString(".SH SEE ALSO\n")
+		fmt.Fprintf(&b, "%s\n", strings.Join(refs, ", "))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// roffEscape escapes backslashes and control characters at the start of a
+// line so text is printed literally
+func roffEscape(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, `\`, `\e`), "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			lines[i] = `\&` + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// writeRoffText writes paragraphs separated by blank lines. Paragraphs with
+// indented lines or list items keep their line breaks; others are filled.
+func writeRoffText(b *strings.Builder, text string) {
+	for _, paragraph := range paragraphs(text) {
+		if isPreformatted(paragraph) {
+			b.WriteString(".PP\n")
+			writeRoffPreformatted(b, paragraph)
+			continue
+		}
+		fmt.Fprintf(b, ".PP\n%s\n", roffEscape(paragraph))
+	}
+}
+
+// writeRoffPreformatted writes text without filling or adjusting
+func writeRoffPreformatted(b *strings.Builder, text string) {
+	fmt.Fprintf(b, ".nf\n%s\n.fi\n", roffEscape(strings.Trim(text, "\n")))
+}
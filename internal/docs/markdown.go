@@ -0,0 +1,87 @@
+package docs
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// markdownRenderer writes GitHub-flavored markdown pages that link to each
+// other by file name
+type markdownRenderer struct{}
+
+func (markdownRenderer) fileName(cmd *cobra.Command) string {
+	return baseName(cmd, "_") + ".md"
+}
+
+func (r markdownRenderer) render(w io.Writer, p *page) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "## %s\n\n", p.Path)
+	fmt.Fprintf(&b, "%s\n\n", p.Short)
+
+	if p.Long != "" {
+		b.WriteString("### Synopsis\n\n")
+		for _, paragraph := range paragraphs(p.Long) {
+			if isPreformatted(paragraph) {
+				writeMarkdownBlock(&b, "", paragraph)
+				continue
+			}
+			fmt.Fprintf(&b, "%s\n\n", paragraph)
+		}
+	}
+	if p.Runnable {
+		writeMarkdownBlock(&b, "", p.UseLine)
+	}
+	if len(p.Aliases) > 0 {
+		fmt.Fprintf(&b, "Aliases: `%s`\n\n", strings.Join(p.Aliases, "`, `"))
+	}
+	if p.Example != "" {
+		writeMarkdownBlock(&b, "### Examples", p.Example)
+	}
+	if p.Flags != "" {
+		writeMarkdownBlock(&b, "### Options", p.Flags)
+	}
+	if p.Global != "" {
+		writeMarkdownBlock(&b, "### Options inherited from parent commands", p.Global)
+	}
+
+	if p.Formats != nil {
+		b.WriteString("### Output formats\n\n")
+		fmt.Fprintf(&b, "%s\n\n", describeFormats(p.Formats))
+		b.WriteString("### Required API scopes\n\n")
+		if len(p.Scopes) == 0 {
+			fmt.Fprintf(&b, "%s\n\n", scopeNote)
+		}
+		for _, scope := range p.Scopes {
+			fmt.Fprintf(&b, "* `%s`\n", scope)
+		}
+		if len(p.Scopes) > 0 {
+			b.WriteString("\n")
+		}
+	}
+
+	if p.Parent != nil || len(p.Children) > 0 {
+		b.WriteString("### SEE ALSO\n\n")
+		if p.Parent != nil {
+			fmt.Fprintf(&b, "* [%s](%s)\t - %s\n", p.Parent.CommandPath(), r.fileName(p.Parent), p.Parent.Short)
+		}
+		for _, child := range p.Children {
+			fmt.Fprintf(&b, "* [%s](%s)\t - %s\n", child.CommandPath(), r.fileName(child), child.Short)
+		}
+	}
+
+	_, err := io.WriteString(w, strings.TrimRight(b.String(), "\n")+"\n")
+	return err
+}
+
+// writeMarkdownBlock writes an optional heading followed by text in a fenced
+// code block
+func writeMarkdownBlock(b *strings.Builder, heading, text string) {
+	if heading != "" {
+		fmt.Fprintf(b, "%s\n\n", heading)
+	}
+	fmt.Fprintf(b, "```\n%s\n```\n\n", strings.Trim(text, "\n"))
+}
@@ -0,0 +1,287 @@
+package docs
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+)
+
+// commandScopes maps each runnable command, keyed by its path without the
+// root command name, to the API key scopes it needs. Commands that only touch
+// local state or authenticate without an API key map to an empty list. Every
+// runnable command must have an entry (the tests check this), so adding a
+// command means deciding its scopes here.
+var commandScopes = map[string][]string{
+	"dashboard":     {"statistics-transactional:read:all", "webhooks:read:all", "routes:read:all", "messages:read:all"},
+	"doctor":        {"api-keys:read", "domains:read"},
+	"ping":          {},
+	"verify-export": {},
+
+	"account get":    {"accounts:read"},
+	"account update": {"accounts:write"},
+
+	"apikeys clone":  {"api-keys:read", "api-keys:write", "domains:read"},
+	"apikeys create": {"api-keys:write"},
+	"apikeys delete": {"api-keys:read", "api-keys:delete"},
+	"apikeys get":    {"api-keys:read"},
+	"apikeys list":   {"api-keys:read"},
+	"apikeys rotate": {"api-keys:read", "api-keys:write", "api-keys:delete", "domains:read"},
+	"apikeys update": {"api-keys:write"},
+
+	"auth login":          {"accounts:read"},
+	"auth logout":         {},
+	"auth migrate-keys":   {},
+	"auth status":         {"accounts:read"},
+	"auth switch":         {},
+	"auth switch-account": {"accounts:read"},
+
+	"bounces explain": {},
+
+	"config get":                {},
+	"config set":                {},
+	"config set-domain-default": {},
+
+	"domains check-dns": {"domains:read"},
+	"domains create":    {"domains:write"},
+	"domains delete":    {"domains:read", "domains:delete:all"},
+	"domains dns-watch": {"domains:read"},
+	"domains edit":      {"domains:write"},
+	"domains get":       {"domains:read"},
+	"domains list":      {"domains:read"},
+	"domains verify":    {"domains:read"},
+
+	"inbound get":  {"messages:read:all"},
+	"inbound list": {"messages:read:all"},
+
+	"messages attempts": {"messages:read:all"},
+	"messages content":  {"messages:read:all"},
+	"messages cancel":   {"messages:cancel:all"},
+	"messages export":   {"messages:read:all"},
+	"messages get":      {"messages:read:all"},
+	"messages list":     {"messages:read:all"},
+	"messages search":   {"messages:read:all"},
+	"messages send":     {"messages:send:all"},
+
+	"reminders dismiss": {},
+	"reminders list":    {},
+
+	"routes create":  {"routes:read:all", "routes:write:all"},
+	"routes delete":  {"routes:read:all", "routes:delete:all"},
+	"routes explain": {"routes:read:all"},
+	"routes export":  {"routes:read:all"},
+	"routes get":     {"routes:read:all", "domains:read"},
+	"routes import":  {"routes:read:all", "routes:write:all"},
+	"routes list":    {"routes:read:all", "domains:read"},
+	"routes listen":  {"routes:write:all"},
+	"routes trigger": {"routes:write:all"},
+	"routes update":  {"routes:write:all"},
+
+	"smtp create": {"smtp-credentials:write:all"},
+	"smtp delete": {"smtp-credentials:read:all", "smtp-credentials:delete:all"},
+	"smtp get":    {"smtp-credentials:read:all"},
+	"smtp list":   {"smtp-credentials:read:all", "domains:read"},
+	"smtp send":   {}, // authenticates with SMTP credentials
+	"smtp update": {"smtp-credentials:read:all", "smtp-credentials:write:all"},
+	"smtp usage":  {"smtp-credentials:read:all", "statistics-transactional:read:all"},
+
+	"stats anomalies":      {"statistics-transactional:read:all"},
+	"stats bounces":        {"statistics-transactional:read:all"},
+	"stats deliverability": {"statistics-transactional:read:all"},
+	"stats delivery-time":  {"statistics-transactional:read:all"},
+
+	"subaccounts api-keys create": {"sub-account-api-keys:write"},
+	"subaccounts api-keys delete": {"sub-account-api-keys:delete"},
+	"subaccounts api-keys get":    {"sub-account-api-keys:read"},
+	"subaccounts api-keys list":   {"sub-account-api-keys:read"},
+	"subaccounts api-keys update": {"sub-account-api-keys:write"},
+	"subaccounts create":          {"sub-accounts:write"},
+	"subaccounts delete":          {"sub-accounts:delete"},
+	"subaccounts get":             {"sub-accounts:read"},
+	"subaccounts list":            {"sub-accounts:read"},
+	"subaccounts suspend":         {"sub-accounts:suspend"},
+	"subaccounts unsuspend":       {"sub-accounts:suspend"},
+	"subaccounts update":          {"sub-accounts:write"},
+	"subaccounts usage":           {"sub-accounts:usage"},
+
+	"suppressions check":  {"suppressions:read"},
+	"suppressions create": {"messages:read:all", "suppressions:read", "suppressions:write"},
+	"suppressions delete": {"suppressions:read", "suppressions:delete"},
+	"suppressions export": {"suppressions:read"},
+	"suppressions import": {"suppressions:write"},
+	"suppressions list":   {"suppressions:read"},
+	"suppressions wipe":   {"suppressions:read", "suppressions:delete", "suppressions:wipe"},
+
+	"webhooks coverage":      {"statistics-transactional:read:all", "suppressions:read"},
+	"webhooks create":        {"webhooks:read:all", "webhooks:write:all"},
+	"webhooks delete":        {"webhooks:read:all", "webhooks:delete:all"},
+	"webhooks export":        {"webhooks:read:all"},
+	"webhooks get":           {"webhooks:read:all"},
+	"webhooks import":        {"webhooks:read:all", "webhooks:write:all"},
+	"webhooks list":          {"webhooks:read:all"},
+	"webhooks listen":        {"webhooks:write:all"},
+	"webhooks rotate-secret": {"webhooks:read:all", "webhooks:write:all"},
+	"webhooks simulate":      {"webhooks:read:all"},
+	"webhooks trigger":       {"webhooks:write:all"},
+	"webhooks update":        {"webhooks:read:all", "webhooks:write:all"},
+	"webhooks verify":        {"webhooks:read:all"},
+}
+
+// commandFormats lists the output formats of commands that do not support
+// every format. Listeners print an interactive event stream and the
+// dashboard draws on the terminal, so they ignore --output.
+var commandFormats = map[string][]string{
+	"dashboard":       {},
+	"routes listen":   {},
+	"webhooks listen": {},
+}
+
+// commandSchemas maps each runnable command to the ResponseHandler methods
+// that print its JSON output, default output first; --schema prints their
+// shapes. Like commandScopes, every runnable command must have an entry, and
+// commands without JSON output map to an empty list.
+var commandSchemas = map[string][]string{
+	"dashboard":     {},
+	"doctor":        {"HandleDoctorReport"},
+	"ping":          {"HandlePing"},
+	"verify-export": {"HandleSimpleSuccess"},
+
+	"account get":    {"HandleAccount"},
+	"account update": {"HandleAccount"},
+
+	"apikeys clone":  {"HandleCreateAPIKey"},
+	"apikeys create": {"HandleCreateAPIKey"},
+	"apikeys delete": {"HandleDeleteAPIKey"},
+	"apikeys get":    {"HandleSingleAPIKey"},
+	"apikeys list":   {"HandleAPIKeyList"},
+	"apikeys rotate": {"HandleCreateAPIKey"},
+	"apikeys update": {"HandleUpdateAPIKey"},
+
+	"auth login":          {"HandleAuthLogin"},
+	"auth logout":         {"HandleAuthLogout"},
+	"auth migrate-keys":   {"HandleSimpleSuccess"},
+	"auth status":         {"HandleAuthStatus"},
+	"auth switch":         {"HandleAuthSwitch"},
+	"auth switch-account": {"HandleSimpleSuccess"},
+
+	"bounces explain": {"HandleBounceExplanations"},
+
+	"config get":                {"HandleSimpleSuccess"},
+	"config set":                {"HandleSimpleSuccess"},
+	"config set-domain-default": {"HandleSimpleSuccess"},
+
+	"domains check-dns": {"HandleSingleDomain"},
+	"domains create":    {"HandleSingleDomain"},
+	"domains delete":    {"HandleSimpleSuccess"},
+	"domains dns-watch": {"HandleDNSPropagation"},
+	"domains edit":      {"HandleSingleDomain"},
+	"domains get":       {"HandleSingleDomain"},
+	"domains list":      {"HandleDomainList"},
+	"domains verify":    {"HandleDNSVerification", "HandleDomainVerification"},
+
+	"inbound get":  {"HandleSingleMessage"},
+	"inbound list": {"HandleMessageList"},
+
+	"messages attempts": {"HandleMessageAttempts"},
+	"messages content":  {"HandleMessageContent"},
+	"messages cancel":   {"HandleSimpleSuccess"},
+	"messages export":   {"HandleMessageExport"},
+	"messages get":      {"HandleSingleMessage"},
+	"messages list":     {"HandleMessageList"},
+	"messages search":   {"HandleMessageList"},
+	"messages send":     {"HandleCreateMessage", "HandleDryRunMessage"},
+
+	"reminders dismiss": {"HandleSimpleSuccess"},
+	"reminders list":    {"HandleReminderList"},
+
+	"routes create":  {"HandleCreateRoute"},
+	"routes delete":  {"HandleDeleteRoute", "HandleBulkDelete"},
+	"routes explain": {"HandleRouteExplanation"},
+	"routes export":  {"HandleSimpleSuccess"},
+	"routes get":     {"HandleSingleRoute"},
+	"routes import":  {"HandleImport"},
+	"routes list":    {"HandleRouteList"},
+	"routes listen":  {},
+	"routes trigger": {"HandleTriggerRoute"},
+	"routes update":  {"HandleUpdateRoute"},
+
+	"smtp create": {"HandleCreateSMTP"},
+	"smtp delete": {"HandleDeleteSMTP"},
+	"smtp get":    {"HandleSingleSMTP"},
+	"smtp list":   {"HandleSMTPList"},
+	"smtp send":   {"HandleSMTPSend"},
+	"smtp update": {"HandleUpdateSMTP", "HandleSimpleSuccess"},
+	"smtp usage":  {"HandleSMTPUsage"},
+
+	"stats anomalies":      {"HandleStatsAnomalies"},
+	"stats bounces":        {"HandleBounceStats"},
+	"stats deliverability": {"HandleDeliverabilityStats", "HandleDeliverabilitySummary", "HandleDeliverabilityComparison", "HandleDeliverabilityByTag", "HandleDeliverabilityStatsChunk"},
+	"stats delivery-time":  {"HandleDeliveryTimeStats"},
+
+	"subaccounts api-keys create": {"HandleCreateAPIKey"},
+	"subaccounts api-keys delete": {"HandleDeleteAPIKey"},
+	"subaccounts api-keys get":    {"HandleSingleAPIKey"},
+	"subaccounts api-keys list":   {"HandleAPIKeyList"},
+	"subaccounts api-keys update": {"HandleUpdateAPIKey"},
+	"subaccounts create":          {"HandleSingleSubAccount"},
+	"subaccounts delete":          {"HandleSimpleSuccess"},
+	"subaccounts get":             {"HandleSingleSubAccount"},
+	"subaccounts list":            {"HandleSubAccountList"},
+	"subaccounts suspend":         {"HandleSingleSubAccount"},
+	"subaccounts unsuspend":       {"HandleSingleSubAccount"},
+	"subaccounts update":          {"HandleSingleSubAccount"},
+	"subaccounts usage":           {"HandleSubAccountUsage"},
+
+	"suppressions check":  {"HandleCheckSuppression"},
+	"suppressions create": {"HandleCreateSuppression", "HandleBounceSuppressions"},
+	"suppressions delete": {"HandleDeleteSuppression", "HandleBulkDelete"},
+	"suppressions export": {"HandleSimpleSuccess"},
+	"suppressions import": {"HandleSuppressionImport"},
+	"suppressions list":   {"HandleSuppressionList"},
+	"suppressions wipe":   {"HandleSuppressionWipeSummary", "HandleDeleteSuppression"},
+
+	"webhooks coverage":      {"HandleWebhookCoverage"},
+	"webhooks create":        {"HandleCreateWebhook"},
+	"webhooks delete":        {"HandleDeleteWebhook", "HandleBulkDelete"},
+	"webhooks export":        {"HandleSimpleSuccess"},
+	"webhooks get":           {"HandleSingleWebhook"},
+	"webhooks import":        {"HandleImport"},
+	"webhooks list":          {"HandleWebhookList"},
+	"webhooks listen":        {},
+	"webhooks rotate-secret": {"HandleRotateWebhookSecret", "HandleSimpleSuccess"},
+	"webhooks simulate":      {"HandleWebhookSimulation"},
+	"webhooks trigger":       {"HandleTriggerWebhook", "HandleTriggerWebhookOverrides"},
+	"webhooks update":        {"HandleUpdateWebhook", "HandleWebhookEdit", "HandleSimpleSuccess"},
+	"webhooks verify":        {"HandleWebhookVerification"},
+}
+
+// commandKey returns the path of cmd without the root command name
+func commandKey(cmd *cobra.Command) string {
+	path := cmd.CommandPath()
+	if cmd.HasParent() {
+		return strings.TrimPrefix(path, cmd.Root().Name()+" ")
+	}
+	return ""
+}
+
+// Scopes returns the API key scopes cmd needs and whether they are known
+func Scopes(cmd *cobra.Command) ([]string, bool) {
+	scopes, ok := commandScopes[commandKey(cmd)]
+	return scopes, ok
+}
+
+// OutputFormats returns the --output formats cmd supports
+func OutputFormats(cmd *cobra.Command) []string {
+	if formats, ok := commandFormats[commandKey(cmd)]; ok {
+		return formats
+	}
+	return printer.GetSupportedFormats()
+}
+
+// OutputSchemas returns the ResponseHandler methods that print the JSON
+// output of cmd and whether they are known
+func OutputSchemas(cmd *cobra.Command) ([]string, bool) {
+	methods, ok := commandSchemas[commandKey(cmd)]
+	return methods, ok
+}
@@ -0,0 +1,105 @@
+package docs
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// restRenderer writes reStructuredText pages that link to each other through
+// :ref: labels, for inclusion in a Sphinx toctree
+type restRenderer struct{}
+
+func (restRenderer) fileName(cmd *cobra.Command) string {
+	return restLabel(cmd) + ".rst"
+}
+
+// restLabel is the cross-reference label of cmd, e.g. ahasend_messages_send
+func restLabel(cmd *cobra.Command) string {
+	return baseName(cmd, "_")
+}
+
+func (restRenderer) render(w io.Writer, p *page) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, ".. _%s:\n\n", strings.ReplaceAll(p.Path, " ", "_"))
+	writeRestHeading(&b, p.Path, "-")
+	fmt.Fprintf(&b, "%s\n\n", p.Short)
+
+	if p.Long != "" {
+		writeRestHeading(&b, "Synopsis", "~")
+		for _, paragraph := range paragraphs(p.Long) {
+			if isPreformatted(paragraph) {
+				writeRestLiteral(&b, paragraph)
+				continue
+			}
+			fmt.Fprintf(&b, "%s\n\n", paragraph)
+		}
+	}
+	if p.Runnable {
+		writeRestLiteral(&b, p.UseLine)
+	}
+	if len(p.Aliases) > 0 {
+		fmt.Fprintf(&b, "Aliases: ``%s``\n\n", strings.Join(p.Aliases, "``, ``"))
+	}
+	if p.Example != "" {
+		writeRestHeading(&b, "Examples", "~")
+		writeRestLiteral(&b, p.Example)
+	}
+	if p.Flags != "" {
+		writeRestHeading(&b, "Options", "~")
+		writeRestLiteral(&b, p.Flags)
+	}
+	if p.Global != "" {
+		writeRestHeading(&b, "Options inherited from parent commands", "~")
+		writeRestLiteral(&b, p.Global)
+	}
+
+	if p.Formats != nil {
+		writeRestHeading(&b, "Output formats", "~")
+		fmt.Fprintf(&b, "%s\n\n", describeFormats(p.Formats))
+		writeRestHeading(&b, "Required API scopes", "~")
+		if len(p.Scopes) == 0 {
+			fmt.Fprintf(&b, "%s\n\n", scopeNote)
+		}
+		for _, scope := range p.Scopes {
+			fmt.Fprintf(&b, "* ``%s``\n", scope)
+		}
+		if len(p.Scopes) > 0 {
+			b.WriteString("\n")
+		}
+	}
+
+	if p.Parent != nil || len(p.Children) > 0 {
+		writeRestHeading(&b, "SEE ALSO", "~")
+		if p.Parent != nil {
+			fmt.Fprintf(&b, "* :ref:`%s <%s>` \t - %s\n", p.Parent.CommandPath(), restLabel(p.Parent), p.Parent.Short)
+		}
+		for _, child := range p.Children {
+			fmt.Fprintf(&b, "* :ref:`%s <%s>` \t - %s\n", child.CommandPath(), restLabel(child), child.Short)
+		}
+	}
+
+	_, err := io.WriteString(w, strings.TrimRight(b.String(), "\n")+"\n")
+	return err
+}
+
+// writeRestHeading writes title underlined with char
+func writeRestHeading(b *strings.Builder, title, char string) {
+	fmt.Fprintf(b, "%s\n%s\n\n", title, strings.Repeat(char, len(title)))
+}
+
+// writeRestLiteral writes text as an indented literal block
+func writeRestLiteral(b *strings.Builder, text string) {
+	b.WriteString("::\n\n")
+	for _, line := range strings.Split(strings.Trim(text, "\n"), "\n") {
+		if strings.TrimSpace(line) == "" {
+			b.WriteString("\n")
+			continue
+		}
+		fmt.Fprintf(b, "  %s\n", line)
+	}
+	b.WriteString("\n")
+}
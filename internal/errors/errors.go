@@ -38,6 +38,17 @@ const (
 	ErrCodeRateLimit     = "RATE_LIMIT_ERROR"
 	ErrCodeNotFound      = "NOT_FOUND"
 	ErrCodePermission    = "PERMISSION_ERROR"
+	ErrCodeInterrupted   = "INTERRUPTED"
+
+	ErrCodeDNSPartiallyVerified = "DNS_PARTIALLY_VERIFIED"
+	ErrCodeDNSUnverified        = "DNS_UNVERIFIED"
+
+	ErrCodeSignatureMismatch = "SIGNATURE_MISMATCH"
+
+	ErrCodeContentPurged = "CONTENT_PURGED"
+
+	ErrCodeChecksWarned = "CHECKS_WARNED"
+	ErrCodeChecksFailed = "CHECKS_FAILED"
 )
 
 // NewCLIError creates a new CLI error
@@ -99,6 +110,47 @@ func NewPermissionError(message string, cause error) *CLIError {
 	return NewCLIError(ErrCodePermission, message, cause)
 }
 
+// NewInterruptedError creates an error for an operation stopped by a signal
+func NewInterruptedError(message string, cause error) *CLIError {
+	return NewCLIError(ErrCodeInterrupted, message, cause)
+}
+
+// NewDNSPartiallyVerifiedError creates an error for a domain whose DNS
+// check passed for some required records but not all
+func NewDNSPartiallyVerifiedError(message string, cause error) *CLIError {
+	return NewCLIError(ErrCodeDNSPartiallyVerified, message, cause)
+}
+
+// NewDNSUnverifiedError creates an error for a domain whose DNS check
+// passed for none of the required records
+func NewDNSUnverifiedError(message string, cause error) *CLIError {
+	return NewCLIError(ErrCodeDNSUnverified, message, cause)
+}
+
+// NewSignatureMismatchError creates an error for a webhook delivery whose
+// signature or timestamp did not check out
+func NewSignatureMismatchError(message string, cause error) *CLIError {
+	return NewCLIError(ErrCodeSignatureMismatch, message, cause)
+}
+
+// NewContentPurgedError creates an error for a message whose content is no
+// longer stored because its retention period has passed
+func NewContentPurgedError(message string, cause error) *CLIError {
+	return NewCLIError(ErrCodeContentPurged, message, cause)
+}
+
+// NewChecksWarnedError creates an error for a diagnosis whose worst finding
+// is a warning
+func NewChecksWarnedError(message string, cause error) *CLIError {
+	return NewCLIError(ErrCodeChecksWarned, message, cause)
+}
+
+// NewChecksFailedError creates an error for a diagnosis with at least one
+// failed check
+func NewChecksFailedError(message string, cause error) *CLIError {
+	return NewCLIError(ErrCodeChecksFailed, message, cause)
+}
+
 // ExitWithError prints an error message and exits with code 1
 func ExitWithError(err error) {
 	if cliErr, ok := err.(*CLIError); ok {
@@ -168,6 +220,20 @@ func GetExitCode(err error) int {
 			return 7
 		case ErrCodeRateLimit:
 			return 8
+		case ErrCodeDNSPartiallyVerified:
+			return 9
+		case ErrCodeDNSUnverified:
+			return 10
+		case ErrCodeSignatureMismatch:
+			return 1
+		case ErrCodeContentPurged:
+			return 11
+		case ErrCodeChecksWarned:
+			return 12
+		case ErrCodeChecksFailed:
+			return 13
+		case ErrCodeInterrupted:
+			return 130
 		default:
 			return 1
 		}
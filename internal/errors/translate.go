@@ -0,0 +1,103 @@
+package errors
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/api"
+)
+
+// scopePattern finds an API key scope such as "messages:send:all" or
+// "webhooks:read:{example.com}" in an API error message
+var scopePattern = regexp.MustCompile(`[a-z][a-z-]*:[a-z]+(?::(?:[a-z]+|\{[^}\s]+\}))?`)
+
+// apiKeyEnvVar is auth.APIKeyEnvVar, which this package cannot import as
+// auth depends on it
+const apiKeyEnvVar = "AHASEND_API_KEY"
+
+// now is replaced in tests to pin rate limit reset times
+var now = time.Now
+
+// Translate maps common API failures to errors with actionable next steps:
+// rejected keys (401), missing scopes (403), account mismatches (404 on the
+// account) and rate limiting (429). The HTTP status and request ID stay in
+// the message. Other errors are returned unchanged.
+func Translate(err error) error {
+	var apiErr *api.APIError
+	if err == nil || !stderrors.As(err, &apiErr) {
+		return err
+	}
+
+	detail := apiErrorDetail(apiErr)
+	status := formatAPIStatus(apiErr)
+
+	switch apiErr.StatusCode {
+	case http.StatusUnauthorized:
+		return NewAuthError(fmt.Sprintf(
+			"your API key is invalid or revoked (%s)\nRun 'ahasend auth login' to store a new key, or check the key passed with --api-key or set in %s",
+			status, apiKeyEnvVar), nil)
+
+	case http.StatusForbidden:
+		if scope := scopePattern.FindString(detail); scope != "" {
+			return NewPermissionError(fmt.Sprintf(
+				"your API key is missing the '%s' scope (%s)\nGrant it with 'ahasend apikeys update <key-id> --scope %s' (list the key's existing scopes too, as --scope replaces them) or use a key that has it",
+				scope, status, scope), nil)
+		}
+		return NewPermissionError(fmt.Sprintf(
+			"your API key is not allowed to perform this request (%s): %s\nCheck the key's scopes with 'ahasend apikeys list'",
+			status, detail), nil)
+
+	case http.StatusNotFound:
+		if strings.Contains(strings.ToLower(detail), "account") {
+			return NewConfigError(fmt.Sprintf(
+				"the account was not found (%s): %s\nThe account ID may not match the API key; check it with 'ahasend auth status' or pass --account-id",
+				status, detail), nil)
+		}
+		return err
+
+	case http.StatusTooManyRequests:
+		retry := "Wait a moment and try again"
+		if apiErr.RetryAfter > 0 {
+			reset := now().Add(time.Duration(apiErr.RetryAfter) * time.Second)
+			retry = fmt.Sprintf("The limit resets in %ds (at %s); try again then", apiErr.RetryAfter, reset.Local().Format("15:04:05"))
+		}
+		return NewRateLimitError(fmt.Sprintf("rate limit exceeded (%s)\n%s", status, retry), nil)
+	}
+
+	return err
+}
+
+// formatAPIStatus describes the HTTP status and, when known, the request ID
+func formatAPIStatus(apiErr *api.APIError) string {
+	status := fmt.Sprintf("HTTP %d", apiErr.StatusCode)
+	if apiErr.RequestID != "" {
+		status += fmt.Sprintf(", request ID %s", apiErr.RequestID)
+	}
+	return status
+}
+
+// apiErrorDetail extracts the message from a JSON error body, falling back
+// to the SDK's message
+func apiErrorDetail(apiErr *api.APIError) string {
+	var body struct {
+		Message string `json:"message"`
+		Error   string `json:"error"`
+	}
+	if len(apiErr.Raw) > 0 && json.Unmarshal(apiErr.Raw, &body) == nil {
+		if body.Message != "" {
+			return body.Message
+		}
+		if body.Error != "" {
+			return body.Error
+		}
+	}
+	if apiErr.Message != "" {
+		return apiErr.Message
+	}
+	return http.StatusText(apiErr.StatusCode)
+}
@@ -0,0 +1,125 @@
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslate(t *testing.T) {
+	reset := time.Date(2026, 3, 1, 12, 0, 0, 0, time.Local)
+	now = func() time.Time { return reset }
+	t.Cleanup(func() { now = time.Now })
+
+	tests := []struct {
+		name         string
+		err          *api.APIError
+		wantCode     string
+		wantContains []string
+	}{
+		{
+			name:     "unauthorized",
+			err:      &api.APIError{StatusCode: 401, RequestID: "req-401", Raw: []byte(`{"message":"Unauthorized"}`)},
+			wantCode: ErrCodeAuth,
+			wantContains: []string{
+				"your API key is invalid or revoked",
+				"HTTP 401, request ID req-401",
+				"ahasend auth login",
+				"--api-key",
+				"AHASEND_API_KEY",
+			},
+		},
+		{
+			name:     "forbidden with scope",
+			err:      &api.APIError{StatusCode: 403, RequestID: "req-403", Raw: []byte(`{"message":"API key does not have the required scope: messages:send:all"}`)},
+			wantCode: ErrCodePermission,
+			wantContains: []string{
+				"missing the 'messages:send:all' scope",
+				"HTTP 403, request ID req-403",
+				"ahasend apikeys update <key-id> --scope messages:send:all",
+			},
+		},
+		{
+			name:     "forbidden with domain scope",
+			err:      &api.APIError{StatusCode: 403, Message: "missing scope webhooks:read:{example.com}"},
+			wantCode: ErrCodePermission,
+			wantContains: []string{
+				"missing the 'webhooks:read:{example.com}' scope",
+				"HTTP 403)",
+			},
+		},
+		{
+			name:     "forbidden without scope",
+			err:      &api.APIError{StatusCode: 403, Raw: []byte(`{"message":"Forbidden"}`)},
+			wantCode: ErrCodePermission,
+			wantContains: []string{
+				"not allowed to perform this request (HTTP 403): Forbidden",
+				"ahasend apikeys list",
+			},
+		},
+		{
+			name:     "account not found",
+			err:      &api.APIError{StatusCode: 404, RequestID: "req-404", Raw: []byte(`{"message":"account not found"}`)},
+			wantCode: ErrCodeConfig,
+			wantContains: []string{
+				"HTTP 404, request ID req-404",
+				"account ID may not match the API key",
+				"ahasend auth status",
+			},
+		},
+		{
+			name:     "rate limited with retry after",
+			err:      &api.APIError{StatusCode: 429, RequestID: "req-429", RetryAfter: 30},
+			wantCode: ErrCodeRateLimit,
+			wantContains: []string{
+				"rate limit exceeded (HTTP 429, request ID req-429)",
+				"resets in 30s (at 12:00:30)",
+			},
+		},
+		{
+			name:         "rate limited without retry after",
+			err:          &api.APIError{StatusCode: 429},
+			wantCode:     ErrCodeRateLimit,
+			wantContains: []string{"rate limit exceeded (HTTP 429)", "try again"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			translated := Translate(tt.err)
+
+			var cliErr *CLIError
+			require.True(t, stderrors.As(translated, &cliErr), "got %T", translated)
+			assert.Equal(t, tt.wantCode, cliErr.Code)
+			for _, want := range tt.wantContains {
+				assert.Contains(t, translated.Error(), want)
+			}
+		})
+	}
+}
+
+func TestTranslate_Unchanged(t *testing.T) {
+	resourceNotFound := &api.APIError{StatusCode: 404, Raw: []byte(`{"message":"domain not found"}`)}
+	assert.Same(t, resourceNotFound, Translate(resourceNotFound))
+
+	serverErr := &api.APIError{StatusCode: 500, Message: "Internal Server Error"}
+	assert.Same(t, serverErr, Translate(serverErr))
+
+	plain := fmt.Errorf("boom")
+	assert.Equal(t, plain, Translate(plain))
+	assert.Nil(t, Translate(nil))
+}
+
+func TestTranslate_WrappedAPIError(t *testing.T) {
+	wrapped := NewAPIError("failed to list domains", &api.APIError{StatusCode: 401})
+
+	var cliErr *CLIError
+	require.True(t, stderrors.As(Translate(wrapped), &cliErr))
+	assert.Equal(t, ErrCodeAuth, cliErr.Code)
+	assert.Equal(t, 2, GetExitCode(cliErr))
+}
@@ -0,0 +1,76 @@
+package fetch
+
+import (
+	"time"
+
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+)
+
+// DeliverabilityAccumulator sums buckets into a range-wide summary without
+// keeping the buckets themselves
+type DeliverabilityAccumulator struct {
+	summary printer.DeliverabilitySummary
+}
+
+// Add folds the counts of the given buckets into the summary
+func (a *DeliverabilityAccumulator) Add(buckets []responses.DeliverabilityStatistics) {
+	for _, stat := range buckets {
+		a.summary.Buckets++
+		a.summary.Reception += stat.ReceptionCount
+		a.summary.Delivered += stat.DeliveredCount
+		a.summary.Deferred += stat.DeferredCount
+		a.summary.Bounced += stat.BouncedCount
+		a.summary.Failed += stat.FailedCount
+		a.summary.Suppressed += stat.SuppressedCount
+		a.summary.Opened += stat.OpenedCount
+		a.summary.Clicked += stat.ClickedCount
+	}
+}
+
+// Result returns the summary for the range with rates computed from the
+// summed counts. Averaging per-bucket rates would weight a bucket with 10
+// messages the same as one with 10,000.
+func (a *DeliverabilityAccumulator) Result(from, to time.Time) *printer.DeliverabilitySummary {
+	summary := a.summary
+	summary.From = from
+	summary.To = to
+	summary.DeliveryRate = WeightedRate(summary.Delivered, summary.Reception)
+	summary.BounceRate = WeightedRate(summary.Bounced, summary.Reception)
+	summary.OpenRate = WeightedRate(summary.Opened, summary.Delivered)
+	summary.ClickRate = WeightedRate(summary.Clicked, summary.Delivered)
+	return &summary
+}
+
+// WeightedRate returns part/whole as a percentage, or nil when whole is zero
+func WeightedRate(part, whole int) *float64 {
+	if whole == 0 {
+		return nil
+	}
+	rate := float64(part) / float64(whole) * 100
+	return &rate
+}
+
+// DeliverabilitySummary fetches hourly statistics for a range short enough
+// to be returned in one response and sums them
+func DeliverabilitySummary(apiClient client.AhaSendClient, from, to time.Time) (*printer.DeliverabilitySummary, error) {
+	groupBy := "hour"
+	response, err := apiClient.GetDeliverabilityStatistics(requests.GetDeliverabilityStatisticsParams{
+		FromTime: &from,
+		ToTime:   &to,
+		GroupBy:  &groupBy,
+	})
+	if err != nil {
+		return nil, errors.NewAPIError("failed to get deliverability statistics", err)
+	}
+
+	var accumulator DeliverabilityAccumulator
+	if response != nil {
+		accumulator.Add(response.Data)
+	}
+	return accumulator.Result(from, to), nil
+}
@@ -0,0 +1,90 @@
+package fetch
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+)
+
+func TestDeliverabilityAccumulator_WeightedRates(t *testing.T) {
+	var accumulator DeliverabilityAccumulator
+	accumulator.Add([]responses.DeliverabilityStatistics{
+		{ReceptionCount: 1000, DeliveredCount: 990, BouncedCount: 5, OpenedCount: 495, ClickedCount: 99},
+		{ReceptionCount: 10, DeliveredCount: 5, BouncedCount: 5, OpenedCount: 5, ClickedCount: 5},
+	})
+	accumulator.Add([]responses.DeliverabilityStatistics{
+		{ReceptionCount: 0, DeliveredCount: 0}, // an idle hour still counts as a bucket
+	})
+
+	summary := accumulator.Result(time.Time{}, time.Time{})
+	assert.Equal(t, 3, summary.Buckets)
+	assert.Equal(t, 1010, summary.Reception)
+	assert.Equal(t, 995, summary.Delivered)
+	assert.Equal(t, 10, summary.Bounced)
+
+	// Averaging the per-bucket delivery rates (99% and 50%) would give 74.5%
+	require.NotNil(t, summary.DeliveryRate)
+	assert.InDelta(t, 98.515, *summary.DeliveryRate, 0.001)
+	assert.InDelta(t, 0.990, *summary.BounceRate, 0.001)
+
+	// Engagement rates are relative to delivered messages, not received ones
+	assert.InDelta(t, 50.251, *summary.OpenRate, 0.001)
+	assert.InDelta(t, 10.452, *summary.ClickRate, 0.001)
+}
+
+func TestDeliverabilityAccumulator_ZeroDenominators(t *testing.T) {
+	var accumulator DeliverabilityAccumulator
+	summary := accumulator.Result(time.Time{}, time.Time{})
+	assert.Equal(t, 0, summary.Buckets)
+	assert.Nil(t, summary.DeliveryRate)
+	assert.Nil(t, summary.OpenRate)
+
+	// Everything bounced: delivery rate is 0%, but open and click rates have
+	// no delivered messages to be a rate of
+	accumulator.Add([]responses.DeliverabilityStatistics{{ReceptionCount: 4, BouncedCount: 4}})
+	summary = accumulator.Result(time.Time{}, time.Time{})
+	require.NotNil(t, summary.DeliveryRate)
+	assert.Equal(t, 0.0, *summary.DeliveryRate)
+	assert.Equal(t, 100.0, *summary.BounceRate)
+	assert.Nil(t, summary.OpenRate)
+	assert.Nil(t, summary.ClickRate)
+}
+
+func TestDeliverabilitySummary(t *testing.T) {
+	to := time.Date(2026, 6, 2, 12, 0, 0, 0, time.UTC)
+	from := to.Add(-24 * time.Hour)
+
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetDeliverabilityStatistics", mock.MatchedBy(func(params requests.GetDeliverabilityStatisticsParams) bool {
+		return params.FromTime.Equal(from) && params.ToTime.Equal(to) && *params.GroupBy == "hour"
+	})).Return(&responses.DeliverabilityStatisticsResponse{
+		Data: []responses.DeliverabilityStatistics{
+			{ReceptionCount: 90, DeliveredCount: 81},
+			{ReceptionCount: 10, DeliveredCount: 9},
+		},
+	}, nil)
+
+	summary, err := DeliverabilitySummary(mockClient, from, to)
+	require.NoError(t, err)
+	assert.Equal(t, 2, summary.Buckets)
+	assert.Equal(t, 100, summary.Reception)
+	assert.InDelta(t, 90.0, *summary.DeliveryRate, 0.001)
+	assert.Equal(t, from, summary.From)
+}
+
+func TestDeliverabilitySummary_Error(t *testing.T) {
+	mockClient := &mocks.MockClient{}
+	mockClient.On("GetDeliverabilityStatistics", mock.Anything).Return((*responses.DeliverabilityStatisticsResponse)(nil), errors.New("boom"))
+
+	_, err := DeliverabilitySummary(mockClient, time.Now().Add(-time.Hour), time.Now())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get deliverability statistics")
+}
@@ -0,0 +1,196 @@
+// Package fetch holds the API reads shared by several commands and the
+// dashboard, so each resource is paged and summarized in one place.
+package fetch
+
+import (
+	"sort"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/api"
+	"github.com/AhaSend/ahasend-go/models/common"
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+
+	"github.com/AhaSend/ahasend-cli/internal/client"
+)
+
+// AllWebhooks follows pagination cursors and returns every webhook
+func AllWebhooks(apiClient client.AhaSendClient) (*responses.PaginatedWebhooksResponse, error) {
+	all := &responses.PaginatedWebhooksResponse{Object: "list", Data: []responses.Webhook{}}
+	var cursor *string
+	for {
+		page, err := apiClient.ListWebhooks(nil, cursor)
+		if err != nil {
+			return nil, err
+		}
+		if page == nil {
+			break
+		}
+		all.Data = append(all.Data, page.Data...)
+		if !page.Pagination.HasMore || page.Pagination.NextCursor == nil || *page.Pagination.NextCursor == "" {
+			break
+		}
+		cursor = page.Pagination.NextCursor
+	}
+	return all, nil
+}
+
+// MatchingWebhooks pages through the webhooks params selects, from
+// params.Cursor on, keeping those match accepts until limit of them are found
+// or there are no more pages; a limit of 0 reads every page. The last page
+// read is kept whole, so the result can hold a few more than limit webhooks
+// and its pagination continues right after them.
+func MatchingWebhooks(apiClient client.AhaSendClient, params api.GetWebhooksParams, limit int32, match func(*responses.Webhook) bool) (*responses.PaginatedWebhooksResponse, error) {
+	matching := &responses.PaginatedWebhooksResponse{Object: "list", Data: []responses.Webhook{}}
+	for {
+		page, err := apiClient.ListWebhooksWithParams(params)
+		if err != nil {
+			return nil, err
+		}
+		if page == nil {
+			break
+		}
+		for i := range page.Data {
+			if match(&page.Data[i]) {
+				matching.Data = append(matching.Data, page.Data[i])
+			}
+		}
+		matching.Pagination = page.Pagination
+		if limit > 0 && int32(len(matching.Data)) >= limit {
+			break
+		}
+		if !page.Pagination.HasMore || page.Pagination.NextCursor == nil || *page.Pagination.NextCursor == "" {
+			break
+		}
+		params.Cursor = page.Pagination.NextCursor
+	}
+	return matching, nil
+}
+
+// AllRoutes follows pagination cursors and returns every route
+func AllRoutes(apiClient client.AhaSendClient) ([]responses.Route, error) {
+	var routes []responses.Route
+	var cursor *string
+	for {
+		page, err := apiClient.ListRoutes(nil, cursor)
+		if err != nil {
+			return nil, err
+		}
+		if page == nil {
+			break
+		}
+		routes = append(routes, page.Data...)
+		if !page.Pagination.HasMore || page.Pagination.NextCursor == nil || *page.Pagination.NextCursor == "" {
+			break
+		}
+		cursor = page.Pagination.NextCursor
+	}
+	return routes, nil
+}
+
+// AllDomains follows pagination cursors and returns every domain
+func AllDomains(apiClient client.AhaSendClient) ([]responses.Domain, error) {
+	var domains []responses.Domain
+	var cursor *string
+	for {
+		page, err := apiClient.ListDomains(nil, cursor)
+		if err != nil {
+			return nil, err
+		}
+		if page == nil {
+			break
+		}
+		domains = append(domains, page.Data...)
+		if !page.Pagination.HasMore || page.Pagination.NextCursor == nil || *page.Pagination.NextCursor == "" {
+			break
+		}
+		cursor = page.Pagination.NextCursor
+	}
+	return domains, nil
+}
+
+// FailingIntegration is a webhook or route whose most recent deliveries failed
+type FailingIntegration struct {
+	Kind          string // "webhook" or "route"
+	ID            string
+	Name          string
+	URL           string
+	ErrorStreak   int // errors since the last successful delivery
+	ErrorCount    uint64
+	LastRequestAt *time.Time
+}
+
+// FailingIntegrations returns the webhooks and routes with an error streak,
+// longest streak first
+func FailingIntegrations(apiClient client.AhaSendClient) ([]FailingIntegration, error) {
+	webhooks, err := AllWebhooks(apiClient)
+	if err != nil {
+		return nil, err
+	}
+	routes, err := AllRoutes(apiClient)
+	if err != nil {
+		return nil, err
+	}
+
+	var failing []FailingIntegration
+	for _, webhook := range webhooks.Data {
+		if webhook.ErrorsSinceLastSuccess > 0 {
+			failing = append(failing, FailingIntegration{
+				Kind:          "webhook",
+				ID:            webhook.ID.String(),
+				Name:          webhook.Name,
+				URL:           webhook.URL,
+				ErrorStreak:   webhook.ErrorsSinceLastSuccess,
+				ErrorCount:    webhook.ErrorCount,
+				LastRequestAt: webhook.LastRequestAt,
+			})
+		}
+	}
+	for _, route := range routes {
+		if route.ErrorsSinceLastSuccess > 0 {
+			failing = append(failing, FailingIntegration{
+				Kind:          "route",
+				ID:            route.ID.String(),
+				Name:          route.Name,
+				URL:           route.URL,
+				ErrorStreak:   route.ErrorsSinceLastSuccess,
+				ErrorCount:    route.ErrorCount,
+				LastRequestAt: route.LastRequestAt,
+			})
+		}
+	}
+
+	sort.SliceStable(failing, func(i, j int) bool {
+		return failing[i].ErrorStreak > failing[j].ErrorStreak
+	})
+	return failing, nil
+}
+
+// RecentMessages returns up to limit of the newest messages created since
+// the given time
+func RecentMessages(apiClient client.AhaSendClient, since time.Time, limit int) ([]responses.Message, error) {
+	pageSize := int32(limit)
+	response, err := apiClient.GetMessages(requests.GetMessagesParams{
+		FromTime: &since,
+		PaginationParams: common.PaginationParams{
+			Limit: &pageSize,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if response == nil {
+		return nil, nil
+	}
+	return response.Data, nil
+}
+
+// AccountName returns the name of the authenticated account, or "" when the
+// key cannot read it; callers use it only to annotate output
+func AccountName(apiClient client.AhaSendClient) string {
+	account, err := apiClient.GetAccount()
+	if err != nil || account == nil {
+		return ""
+	}
+	return account.Name
+}
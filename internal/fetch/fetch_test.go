@@ -0,0 +1,110 @@
+package fetch
+
+import (
+	"testing"
+
+	"github.com/AhaSend/ahasend-go/api"
+	"github.com/AhaSend/ahasend-go/models/common"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/mocks"
+)
+
+func TestAllWebhooks_FollowsCursor(t *testing.T) {
+	next := "page-2"
+	mockClient := &mocks.MockClient{}
+	mockClient.On("ListWebhooks", (*int32)(nil), (*string)(nil)).Return(&responses.PaginatedWebhooksResponse{
+		Data:       []responses.Webhook{{Name: "a"}},
+		Pagination: common.PaginationInfo{HasMore: true, NextCursor: &next},
+	}, nil).Once()
+	mockClient.On("ListWebhooks", (*int32)(nil), &next).Return(&responses.PaginatedWebhooksResponse{
+		Data: []responses.Webhook{{Name: "b"}},
+	}, nil).Once()
+
+	all, err := AllWebhooks(mockClient)
+	require.NoError(t, err)
+	require.Len(t, all.Data, 2)
+	assert.Equal(t, "a", all.Data[0].Name)
+	assert.Equal(t, "b", all.Data[1].Name)
+	mockClient.AssertExpectations(t)
+}
+
+func TestMatchingWebhooks_StopsAtLimit(t *testing.T) {
+	limit := int32(2)
+	second, third := "page-2", "page-3"
+	enabled := func(w *responses.Webhook) bool { return w.Enabled }
+	mockClient := &mocks.MockClient{}
+	mockClient.On("ListWebhooksWithParams", api.GetWebhooksParams{PaginationParams: common.PaginationParams{Limit: &limit}}).Return(&responses.PaginatedWebhooksResponse{
+		Data:       []responses.Webhook{{Name: "a", Enabled: true}, {Name: "b"}},
+		Pagination: common.PaginationInfo{HasMore: true, NextCursor: &second},
+	}, nil).Once()
+	mockClient.On("ListWebhooksWithParams", api.GetWebhooksParams{PaginationParams: common.PaginationParams{Limit: &limit, Cursor: &second}}).Return(&responses.PaginatedWebhooksResponse{
+		Data:       []responses.Webhook{{Name: "c", Enabled: true}, {Name: "d", Enabled: true}},
+		Pagination: common.PaginationInfo{HasMore: true, NextCursor: &third},
+	}, nil).Once()
+
+	matching, err := MatchingWebhooks(mockClient, api.GetWebhooksParams{PaginationParams: common.PaginationParams{Limit: &limit}}, limit, enabled)
+	require.NoError(t, err)
+	require.Len(t, matching.Data, 3, "the last page is kept whole")
+	assert.Equal(t, []string{"a", "c", "d"}, []string{matching.Data[0].Name, matching.Data[1].Name, matching.Data[2].Name})
+	assert.True(t, matching.Pagination.HasMore)
+	assert.Equal(t, &third, matching.Pagination.NextCursor)
+	mockClient.AssertExpectations(t)
+}
+
+func TestMatchingWebhooks_ReadsEveryPageWithoutLimit(t *testing.T) {
+	next := "page-2"
+	mockClient := &mocks.MockClient{}
+	mockClient.On("ListWebhooksWithParams", api.GetWebhooksParams{}).Return(&responses.PaginatedWebhooksResponse{
+		Data:       []responses.Webhook{{Name: "a"}},
+		Pagination: common.PaginationInfo{HasMore: true, NextCursor: &next},
+	}, nil).Once()
+	mockClient.On("ListWebhooksWithParams", api.GetWebhooksParams{PaginationParams: common.PaginationParams{Cursor: &next}}).Return(&responses.PaginatedWebhooksResponse{
+		Data: []responses.Webhook{{Name: "b", Enabled: true}},
+	}, nil).Once()
+
+	matching, err := MatchingWebhooks(mockClient, api.GetWebhooksParams{}, 0, func(w *responses.Webhook) bool { return w.Enabled })
+	require.NoError(t, err)
+	require.Len(t, matching.Data, 1)
+	assert.Equal(t, "b", matching.Data[0].Name)
+	assert.False(t, matching.Pagination.HasMore)
+	mockClient.AssertExpectations(t)
+}
+
+func TestAllRoutes_FollowsCursor(t *testing.T) {
+	next := "page-2"
+	mockClient := &mocks.MockClient{}
+	mockClient.On("ListRoutes", (*int32)(nil), (*string)(nil)).Return(&responses.PaginatedRoutesResponse{
+		Data:       []responses.Route{{Name: "a"}},
+		Pagination: common.PaginationInfo{HasMore: true, NextCursor: &next},
+	}, nil).Once()
+	mockClient.On("ListRoutes", (*int32)(nil), &next).Return(&responses.PaginatedRoutesResponse{
+		Data: []responses.Route{{Name: "b"}},
+	}, nil).Once()
+
+	routes, err := AllRoutes(mockClient)
+	require.NoError(t, err)
+	require.Len(t, routes, 2)
+	assert.Equal(t, "b", routes[1].Name)
+	mockClient.AssertExpectations(t)
+}
+
+func TestAllDomains_FollowsCursor(t *testing.T) {
+	next := "page-2"
+	mockClient := &mocks.MockClient{}
+	mockClient.On("ListDomains", (*int32)(nil), (*string)(nil)).Return(&responses.PaginatedDomainsResponse{
+		Data:       []responses.Domain{{Domain: "a.example"}},
+		Pagination: common.PaginationInfo{HasMore: true, NextCursor: &next},
+	}, nil).Once()
+	mockClient.On("ListDomains", (*int32)(nil), &next).Return(&responses.PaginatedDomainsResponse{
+		Data: []responses.Domain{{Domain: "b.example"}},
+	}, nil).Once()
+
+	domains, err := AllDomains(mockClient)
+	require.NoError(t, err)
+	require.Len(t, domains, 2)
+	assert.Equal(t, "b.example", domains[1].Domain)
+	mockClient.AssertExpectations(t)
+}
@@ -0,0 +1,58 @@
+package glob
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+)
+
+// Pattern is a compiled shell-style name glob. '*' matches any run of
+// characters (including none) and '?' matches exactly one character; all
+// other characters match literally. Unlike path.Match, '/' is not special,
+// so patterns work on free-form resource names.
+type Pattern struct {
+	raw string
+	re  *regexp.Regexp
+}
+
+// Compile parses a glob pattern. Matching is case-insensitive unless
+// caseSensitive is set.
+func Compile(pattern string, caseSensitive bool) (*Pattern, error) {
+	if strings.TrimSpace(pattern) == "" {
+		return nil, errors.NewValidationError("glob pattern cannot be empty", nil)
+	}
+
+	var expr strings.Builder
+	if !caseSensitive {
+		expr.WriteString("(?i)")
+	}
+	expr.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			expr.WriteString("(?s:.*)")
+		case '?':
+			expr.WriteString("(?s:.)")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	expr.WriteString("$")
+
+	re, err := regexp.Compile(expr.String())
+	if err != nil {
+		return nil, errors.NewValidationError("invalid glob pattern '"+pattern+"'", err)
+	}
+	return &Pattern{raw: pattern, re: re}, nil
+}
+
+// Match reports whether name matches the pattern
+func (p *Pattern) Match(name string) bool {
+	return p.re.MatchString(name)
+}
+
+// String returns the pattern as given to Compile
+func (p *Pattern) String() string {
+	return p.raw
+}
@@ -0,0 +1,43 @@
+package glob
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPattern_Match(t *testing.T) {
+	tests := []struct {
+		name          string
+		pattern       string
+		caseSensitive bool
+		input         string
+		want          bool
+	}{
+		{"prefix", "test-*", false, "test-123", true},
+		{"prefix no match", "test-*", false, "prod-123", false},
+		{"case-insensitive by default", "test-*", false, "TEST-load", true},
+		{"case-sensitive", "test-*", true, "TEST-load", false},
+		{"single character", "hook-?", false, "hook-1", true},
+		{"single character too long", "hook-?", false, "hook-12", false},
+		{"literal regex characters", "a.b+(c)", false, "a.b+(c)", true},
+		{"dot is literal", "a.b", false, "axb", false},
+		{"slash is not special", "team/*", false, "team/alerts/prod", true},
+		{"star matches empty", "*", false, "", true},
+		{"whole name must match", "test", false, "my-test-hook", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pattern, err := Compile(tt.pattern, tt.caseSensitive)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, pattern.Match(tt.input))
+		})
+	}
+}
+
+func TestCompile_Empty(t *testing.T) {
+	_, err := Compile("  ", false)
+	assert.Error(t, err)
+}
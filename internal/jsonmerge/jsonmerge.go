@@ -0,0 +1,299 @@
+// Package jsonmerge edits decoded JSON documents: deep-merging a partial
+// object over a document, setting values by dotted path, and listing what
+// changed between two documents.
+//
+// Documents are the values encoding/json decodes into an interface{}:
+// map[string]interface{}, []interface{}, string, float64, bool and nil.
+// Objects merge key by key; arrays and scalars are replaced as a whole. An
+// object or array is never silently replaced by a value of another kind,
+// since that is almost always a mistake in the override; a ConflictError
+// is returned instead.
+package jsonmerge
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ConflictError reports an override that would replace an object or array
+// with a value of another kind, or descend into a scalar
+type ConflictError struct {
+	Path     string
+	Existing string // the kind in the document
+	Value    string // the kind of the override
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s is %s in the document and cannot be replaced with %s", e.Path, e.Existing, e.Value)
+}
+
+// Kind names the JSON kind of a decoded value
+func Kind(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "an object"
+	case []interface{}:
+		return "an array"
+	case string:
+		return "a string"
+	case float64, int, int64, json.Number:
+		return "a number"
+	case bool:
+		return "a boolean"
+	}
+	return fmt.Sprintf("a %T", value)
+}
+
+// isContainer reports whether a value is an object or array
+func isContainer(value interface{}) bool {
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		return true
+	}
+	return false
+}
+
+// Merge returns a copy of base with patch deep-merged over it. Objects are
+// merged recursively, keys missing from base are added, and arrays, scalars
+// and nulls in patch replace the value in base.
+func Merge(base, patch map[string]interface{}) (map[string]interface{}, error) {
+	merged, _ := Copy(base).(map[string]interface{})
+	if merged == nil {
+		merged = map[string]interface{}{}
+	}
+	if err := mergeInto(merged, patch, ""); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+func mergeInto(target, patch map[string]interface{}, prefix string) error {
+	for key, value := range patch {
+		path := joinPath(prefix, key)
+		existing, exists := target[key]
+		if patchObject, ok := value.(map[string]interface{}); ok {
+			if targetObject, ok := existing.(map[string]interface{}); ok {
+				if err := mergeInto(targetObject, patchObject, path); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if exists && conflicts(existing, value) {
+			return &ConflictError{Path: path, Existing: Kind(existing), Value: Kind(value)}
+		}
+		target[key] = Copy(value)
+	}
+	return nil
+}
+
+// conflicts reports whether replacing existing with value changes an object
+// or array into something else, or a scalar into an object. Null may
+// replace anything, and anything may replace null.
+func conflicts(existing, value interface{}) bool {
+	if existing == nil || value == nil {
+		return false
+	}
+	_, existingObject := existing.(map[string]interface{})
+	_, valueObject := value.(map[string]interface{})
+	_, existingArray := existing.([]interface{})
+	_, valueArray := value.([]interface{})
+	return existingObject != valueObject || existingArray != valueArray
+}
+
+// Get returns the value at a dotted path, and whether it exists. Array
+// elements are addressed by index, e.g. "data.attachments.0.filename".
+func Get(doc map[string]interface{}, path string) (interface{}, bool) {
+	var current interface{} = doc
+	for _, segment := range strings.Split(path, ".") {
+		switch container := current.(type) {
+		case map[string]interface{}:
+			value, ok := container[segment]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(container) {
+				return nil, false
+			}
+			current = container[index]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// Set sets the value at a dotted path, creating missing objects along the
+// way. An array element is addressed by index; the index one past the end
+// appends. Descending into a scalar, or replacing an object or array with
+// another kind of value, is a ConflictError.
+func Set(doc map[string]interface{}, path string, value interface{}) error {
+	segments := strings.Split(path, ".")
+	for _, segment := range segments {
+		if segment == "" {
+			return fmt.Errorf("invalid path %q: empty segment", path)
+		}
+	}
+	_, err := setIn(doc, segments, value, "")
+	return err
+}
+
+// setIn sets value below container and returns the container, which is a
+// new slice when an array was appended to
+func setIn(container interface{}, segments []string, value interface{}, prefix string) (interface{}, error) {
+	segment := segments[0]
+	path := joinPath(prefix, segment)
+	last := len(segments) == 1
+
+	switch c := container.(type) {
+	case map[string]interface{}:
+		existing, exists := c[segment]
+		if last {
+			if exists && conflicts(existing, value) {
+				return nil, &ConflictError{Path: path, Existing: Kind(existing), Value: Kind(value)}
+			}
+			c[segment] = Copy(value)
+			return c, nil
+		}
+		if !exists || existing == nil {
+			existing = map[string]interface{}{}
+		}
+		if !isContainer(existing) {
+			return nil, &ConflictError{Path: path, Existing: Kind(existing), Value: "an object"}
+		}
+		updated, err := setIn(existing, segments[1:], value, path)
+		if err != nil {
+			return nil, err
+		}
+		c[segment] = updated
+		return c, nil
+
+	case []interface{}:
+		index, err := strconv.Atoi(segment)
+		if err != nil || index < 0 {
+			return nil, fmt.Errorf("%s is an array; address its elements by index, e.g. %s", prefix, joinPath(prefix, "0"))
+		}
+		if index > len(c) {
+			return nil, fmt.Errorf("index %d of %s is out of range: the array has %d elements", index, prefix, len(c))
+		}
+		if index == len(c) {
+			c = append(c, nil)
+		}
+		if last {
+			if conflicts(c[index], value) {
+				return nil, &ConflictError{Path: path, Existing: Kind(c[index]), Value: Kind(value)}
+			}
+			c[index] = Copy(value)
+			return c, nil
+		}
+		element := c[index]
+		if element == nil {
+			element = map[string]interface{}{}
+		}
+		if !isContainer(element) {
+			return nil, &ConflictError{Path: path, Existing: Kind(element), Value: "an object"}
+		}
+		updated, err := setIn(element, segments[1:], value, path)
+		if err != nil {
+			return nil, err
+		}
+		c[index] = updated
+		return c, nil
+	}
+	return nil, &ConflictError{Path: prefix, Existing: Kind(container), Value: "an object"}
+}
+
+// ParseAssignment parses a key.path=value assignment against doc. The value
+// is kept as a string when the path holds a string, so --set subject=2024
+// stays a string; otherwise it is parsed as JSON when it is valid JSON and
+// taken as a string when it is not.
+func ParseAssignment(assignment string, doc map[string]interface{}) (string, interface{}, error) {
+	path, raw, ok := strings.Cut(assignment, "=")
+	if !ok || strings.TrimSpace(path) == "" {
+		return "", nil, fmt.Errorf("invalid assignment %q: expected key.path=value", assignment)
+	}
+	path = strings.TrimSpace(path)
+
+	if existing, exists := Get(doc, path); exists {
+		if _, isString := existing.(string); isString {
+			return path, raw, nil
+		}
+	}
+	var value interface{}
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		return path, raw, nil
+	}
+	return path, value, nil
+}
+
+// Change is a difference between two documents. Before is nil when the
+// path was added and After is nil when it was removed or set to null.
+type Change struct {
+	Path   string      `json:"path"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// Changes lists the differences between two documents, ordered by path.
+// Objects are compared key by key; arrays and scalars are compared whole.
+func Changes(before, after map[string]interface{}) []Change {
+	var changes []Change
+	diff(before, after, "", &changes)
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+func diff(before, after map[string]interface{}, prefix string, changes *[]Change) {
+	for key, afterValue := range after {
+		path := joinPath(prefix, key)
+		beforeValue, exists := before[key]
+		beforeObject, beforeIsObject := beforeValue.(map[string]interface{})
+		afterObject, afterIsObject := afterValue.(map[string]interface{})
+		switch {
+		case beforeIsObject && afterIsObject:
+			diff(beforeObject, afterObject, path, changes)
+		case !exists || !reflect.DeepEqual(beforeValue, afterValue):
+			*changes = append(*changes, Change{Path: path, Before: beforeValue, After: afterValue})
+		}
+	}
+	for key, beforeValue := range before {
+		if _, exists := after[key]; !exists {
+			*changes = append(*changes, Change{Path: joinPath(prefix, key), Before: beforeValue})
+		}
+	}
+}
+
+// Copy returns a deep copy of a decoded JSON value
+func Copy(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			copied[key] = Copy(item)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, item := range v {
+			copied[i] = Copy(item)
+		}
+		return copied
+	}
+	return value
+}
+
+func joinPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
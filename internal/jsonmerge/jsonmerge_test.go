@@ -0,0 +1,186 @@
+package jsonmerge
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decode(t *testing.T, document string) map[string]interface{} {
+	t.Helper()
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(document), &doc))
+	return doc
+}
+
+func TestMerge(t *testing.T) {
+	base := decode(t, `{
+		"type": "message.delivered",
+		"data": {"recipient": "ada@example.com", "subject": "Hi", "tags": ["a", "b"], "ip": "192.0.2.1"}
+	}`)
+
+	t.Run("nested objects merge key by key", func(t *testing.T) {
+		merged, err := Merge(base, decode(t, `{"data": {"recipient": "ops@corp.example", "extra": {"x": 1}}}`))
+		require.NoError(t, err)
+		assert.Equal(t, decode(t, `{
+			"type": "message.delivered",
+			"data": {"recipient": "ops@corp.example", "subject": "Hi", "tags": ["a", "b"], "ip": "192.0.2.1", "extra": {"x": 1}}
+		}`), merged)
+		assert.Equal(t, "ada@example.com", base["data"].(map[string]interface{})["recipient"], "base is not modified")
+	})
+
+	t.Run("arrays are replaced whole", func(t *testing.T) {
+		merged, err := Merge(base, decode(t, `{"data": {"tags": ["c"]}}`))
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{"c"}, merged["data"].(map[string]interface{})["tags"])
+	})
+
+	t.Run("null and scalar kinds replace", func(t *testing.T) {
+		merged, err := Merge(base, decode(t, `{"data": {"ip": null, "subject": 42}}`))
+		require.NoError(t, err)
+		data := merged["data"].(map[string]interface{})
+		assert.Nil(t, data["ip"])
+		assert.Contains(t, data, "ip")
+		assert.Equal(t, float64(42), data["subject"])
+	})
+
+	t.Run("type conflicts", func(t *testing.T) {
+		for patch, message := range map[string]string{
+			`{"data": "none"}`:                "data is an object in the document and cannot be replaced with a string",
+			`{"data": {"tags": {"0": "x"}}}`:  "data.tags is an array in the document and cannot be replaced with an object",
+			`{"data": {"subject": {"a": 1}}}`: "data.subject is a string in the document and cannot be replaced with an object",
+			`{"data": {"tags": "a,b"}}`:       "data.tags is an array in the document and cannot be replaced with a string",
+			`{"type": ["message.delivered"]}`: "type is a string in the document and cannot be replaced with an array",
+		} {
+			_, err := Merge(base, decode(t, patch))
+			var conflict *ConflictError
+			require.ErrorAs(t, err, &conflict, patch)
+			assert.EqualError(t, err, message)
+		}
+	})
+
+	t.Run("nil base", func(t *testing.T) {
+		merged, err := Merge(nil, decode(t, `{"a": {"b": true}}`))
+		require.NoError(t, err)
+		assert.Equal(t, decode(t, `{"a": {"b": true}}`), merged)
+	})
+}
+
+func TestSet(t *testing.T) {
+	t.Run("creates nested objects", func(t *testing.T) {
+		doc := decode(t, `{"data": {}}`)
+		require.NoError(t, Set(doc, "data.client.device.os", "ios"))
+		require.NoError(t, Set(doc, "meta", map[string]interface{}{"a": 1.0}))
+		assert.Equal(t, decode(t, `{"data": {"client": {"device": {"os": "ios"}}}, "meta": {"a": 1}}`), doc)
+	})
+
+	t.Run("replaces null with an object", func(t *testing.T) {
+		doc := decode(t, `{"data": null}`)
+		require.NoError(t, Set(doc, "data.subject", "Hi"))
+		assert.Equal(t, decode(t, `{"data": {"subject": "Hi"}}`), doc)
+	})
+
+	t.Run("array elements", func(t *testing.T) {
+		doc := decode(t, `{"tags": ["a", "b"], "attachments": [{"filename": "a.pdf"}]}`)
+		require.NoError(t, Set(doc, "tags.1", "x"))
+		require.NoError(t, Set(doc, "tags.2", "appended"))
+		require.NoError(t, Set(doc, "attachments.0.filename", "b.pdf"))
+		require.NoError(t, Set(doc, "attachments.1.filename", "c.pdf"))
+		assert.Equal(t, decode(t, `{"tags": ["a", "x", "appended"], "attachments": [{"filename": "b.pdf"}, {"filename": "c.pdf"}]}`), doc)
+
+		err := Set(doc, "tags.5", "x")
+		assert.EqualError(t, err, "index 5 of tags is out of range: the array has 3 elements")
+		err = Set(doc, "tags.first", "x")
+		assert.EqualError(t, err, "tags is an array; address its elements by index, e.g. tags.0")
+		err = Set(doc, "tags.-1", "x")
+		assert.Error(t, err)
+	})
+
+	t.Run("type conflicts", func(t *testing.T) {
+		doc := decode(t, `{"data": {"subject": "Hi", "tags": ["a"]}}`)
+		var conflict *ConflictError
+
+		err := Set(doc, "data.subject.text", "x")
+		require.ErrorAs(t, err, &conflict)
+		assert.EqualError(t, err, "data.subject is a string in the document and cannot be replaced with an object")
+
+		err = Set(doc, "data", "x")
+		require.ErrorAs(t, err, &conflict)
+		err = Set(doc, "data.tags", "a")
+		require.ErrorAs(t, err, &conflict)
+		err = Set(doc, "data.tags.0.name", "a")
+		require.ErrorAs(t, err, &conflict)
+		assert.Equal(t, "data.tags.0", conflict.Path)
+
+		require.NoError(t, Set(doc, "data.tags", []interface{}{"b", "c"}), "an array may replace an array")
+	})
+
+	t.Run("invalid paths", func(t *testing.T) {
+		doc := map[string]interface{}{}
+		assert.EqualError(t, Set(doc, "data..subject", "x"), `invalid path "data..subject": empty segment`)
+		assert.Error(t, Set(doc, "", "x"))
+	})
+}
+
+func TestGet(t *testing.T) {
+	doc := decode(t, `{"data": {"tags": ["a", {"b": 2}], "ip": null}}`)
+
+	value, ok := Get(doc, "data.tags.1.b")
+	assert.True(t, ok)
+	assert.Equal(t, float64(2), value)
+
+	value, ok = Get(doc, "data.ip")
+	assert.True(t, ok)
+	assert.Nil(t, value)
+
+	for _, path := range []string{"data.missing", "data.tags.2", "data.tags.x", "data.ip.x"} {
+		_, ok := Get(doc, path)
+		assert.False(t, ok, path)
+	}
+}
+
+func TestParseAssignment(t *testing.T) {
+	doc := decode(t, `{"data": {"subject": "Hi", "is_bot": false, "size": 10}}`)
+
+	tests := []struct {
+		assignment string
+		path       string
+		value      interface{}
+	}{
+		{"data.subject=2024", "data.subject", "2024"},
+		{"data.subject=a=b", "data.subject", "a=b"},
+		{"data.is_bot=true", "data.is_bot", true},
+		{"data.size=12", "data.size", float64(12)},
+		{`data.tags=["a","b"]`, "data.tags", []interface{}{"a", "b"}},
+		{"data.note=hello there", "data.note", "hello there"},
+		{`data.note="quoted"`, "data.note", "quoted"},
+		{"data.ip=null", "data.ip", nil},
+	}
+	for _, tt := range tests {
+		path, value, err := ParseAssignment(tt.assignment, doc)
+		require.NoError(t, err, tt.assignment)
+		assert.Equal(t, tt.path, path, tt.assignment)
+		assert.Equal(t, tt.value, value, tt.assignment)
+	}
+
+	for _, assignment := range []string{"data.subject", "=x", " =x"} {
+		_, _, err := ParseAssignment(assignment, doc)
+		assert.Error(t, err, assignment)
+	}
+}
+
+func TestChanges(t *testing.T) {
+	before := decode(t, `{"type": "message.delivered", "data": {"recipient": "a@example.com", "tags": ["a"], "ip": "192.0.2.1", "subject": "Hi"}}`)
+	after := decode(t, `{"type": "message.delivered", "data": {"recipient": "b@example.com", "tags": ["a", "b"], "subject": "Hi", "note": "new"}}`)
+
+	assert.Equal(t, []Change{
+		{Path: "data.ip", Before: "192.0.2.1"},
+		{Path: "data.note", After: "new"},
+		{Path: "data.recipient", Before: "a@example.com", After: "b@example.com"},
+		{Path: "data.tags", Before: []interface{}{"a"}, After: []interface{}{"a", "b"}},
+	}, Changes(before, after))
+
+	assert.Empty(t, Changes(before, Copy(before).(map[string]interface{})))
+}
@@ -45,7 +45,7 @@ func Initialize(cmd *cobra.Command) {
 
 	// Check if we're in JSON output mode
 	outputFormat, _ := cmd.Flags().GetString("output")
-	isJSONMode := outputFormat == "json"
+	isJSONMode := outputFormat == "json" || outputFormat == "jsonl"
 
 	defaultLogger = NewLoggerWithFormat(debug, verbose, noColor, isJSONMode)
 }
@@ -119,6 +119,18 @@ func Get() *Logger {
 	return defaultLogger
 }
 
+// RedirectOutput sends log output to w until the returned function is
+// called, e.g. so a progress bar can print log lines above itself. Output
+// that is discarded (JSON mode) stays discarded.
+func (l *Logger) RedirectOutput(w io.Writer) (restore func()) {
+	previous := l.Out
+	if previous == io.Discard {
+		return func() {}
+	}
+	l.SetOutput(w)
+	return func() { l.SetOutput(previous) }
+}
+
 // IsDebugEnabled returns true if debug mode is enabled
 func (l *Logger) IsDebugEnabled() bool {
 	return l.debugMode
@@ -0,0 +1,371 @@
+// Package manifest records and verifies the integrity of exported data files.
+//
+// An export writes a JSON manifest next to its data file holding the record
+// count, the SHA-256 of the file, the filters used and the CLI version. The
+// manifest carries a schema version: readers accept any manifest up to the
+// version they know and ignore fields they do not, so fields may be added
+// without breaking older manifests.
+package manifest
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/version"
+)
+
+// SchemaVersion is the manifest schema written by this CLI
+const SchemaVersion = 1
+
+// Data file formats, which determine how records are counted
+const (
+	FormatCSV   = "csv"   // one record per row after the header
+	FormatJSON  = "json"  // elements of a top-level array or of an object's "data" array
+	FormatJSONL = "jsonl" // one record per non-blank line
+	FormatLines = "lines" // one record per non-blank line
+)
+
+// Manifest describes an exported data file
+type Manifest struct {
+	SchemaVersion int               `json:"schema_version"`
+	File          string            `json:"file"` // base name of the data file
+	Format        string            `json:"format"`
+	Records       int64             `json:"records"`
+	Bytes         int64             `json:"bytes"`
+	SHA256        string            `json:"sha256"`
+	Command       string            `json:"command"`
+	Filters       map[string]string `json:"filters,omitempty"` // including the time range, if any
+	CLIVersion    string            `json:"cli_version"`
+	StartedAt     time.Time         `json:"started_at"`
+	CompletedAt   time.Time         `json:"completed_at"`
+}
+
+// Digest is the computed identity of a data file
+type Digest struct {
+	Records int64
+	Bytes   int64
+	SHA256  string
+}
+
+// New starts a manifest for an export begun at startedAt
+func New(command string, filters map[string]string, startedAt time.Time) *Manifest {
+	return &Manifest{
+		SchemaVersion: SchemaVersion,
+		Command:       command,
+		Filters:       filters,
+		CLIVersion:    version.Version,
+		StartedAt:     startedAt.UTC(),
+	}
+}
+
+// Finalize digests the written data file into the manifest
+func (m *Manifest) Finalize(dataFile string) error {
+	format := FormatFromPath(dataFile)
+	digest, err := DigestFile(dataFile, format)
+	if err != nil {
+		return err
+	}
+	m.FinalizeDigest(filepath.Base(dataFile), format, digest)
+	return nil
+}
+
+// FinalizeDigest records a digest computed while the data was written, for
+// exports that never exist as a local file, such as uploads to object storage
+func (m *Manifest) FinalizeDigest(file, format string, digest *Digest) {
+	m.File = file
+	m.Format = format
+	m.Records = digest.Records
+	m.Bytes = digest.Bytes
+	m.SHA256 = digest.SHA256
+	m.CompletedAt = time.Now().UTC()
+}
+
+// Marshal encodes the manifest as indented JSON
+func (m *Manifest) Marshal() ([]byte, error) {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, errors.NewFileError("failed to encode manifest", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// Write saves the manifest as indented JSON
+func (m *Manifest) Write(path string) error {
+	data, err := m.Marshal()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return errors.NewFileError(fmt.Sprintf("failed to write manifest %s", path), err)
+	}
+	return nil
+}
+
+// Load reads a manifest, rejecting schema versions newer than this CLI knows
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.NewFileError(fmt.Sprintf("cannot open manifest %s", path), err)
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, errors.NewFileError(fmt.Sprintf("failed to parse manifest %s", path), err)
+	}
+	if m.SchemaVersion < 1 {
+		return nil, errors.NewValidationError(fmt.Sprintf("manifest %s has no schema_version", path), nil)
+	}
+	if m.SchemaVersion > SchemaVersion {
+		return nil, errors.NewValidationError(fmt.Sprintf("manifest %s uses schema version %d, this CLI supports up to %d; upgrade the CLI", path, m.SchemaVersion, SchemaVersion), nil)
+	}
+	if m.SHA256 == "" {
+		return nil, errors.NewValidationError(fmt.Sprintf("manifest %s has no sha256", path), nil)
+	}
+	return &m, nil
+}
+
+// Verify recomputes the digest of dataFile and returns a description of each
+// difference from the manifest; none means the file matches
+func (m *Manifest) Verify(dataFile string) (*Digest, []string, error) {
+	format := m.Format
+	if format == "" {
+		format = FormatFromPath(dataFile)
+	}
+	digest, err := DigestFile(dataFile, format)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var mismatches []string
+	if !strings.EqualFold(digest.SHA256, m.SHA256) {
+		mismatches = append(mismatches, fmt.Sprintf("SHA-256 is %s, manifest has %s", digest.SHA256, m.SHA256))
+	}
+	if digest.Records != m.Records {
+		mismatches = append(mismatches, fmt.Sprintf("file has %d records, manifest has %d", digest.Records, m.Records))
+	}
+	return digest, mismatches, nil
+}
+
+// FormatFromPath picks the record format from a data file's extension
+func FormatFromPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return FormatCSV
+	case ".json":
+		return FormatJSON
+	case ".jsonl", ".ndjson":
+		return FormatJSONL
+	}
+	return FormatLines
+}
+
+// DigestFile hashes and counts the records of a data file in a single
+// streaming pass, so files of any size can be digested
+func DigestFile(path, format string) (*Digest, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.NewFileError(fmt.Sprintf("cannot open data file %s", path), err)
+	}
+	defer file.Close()
+
+	d, err := digest(file, format)
+	if err != nil {
+		return nil, errors.NewFileError(fmt.Sprintf("failed to read %s as %s", path, format), err)
+	}
+	return d, nil
+}
+
+// DigestWriter digests data as it is written, for exports streamed to a
+// destination that cannot be read back
+type DigestWriter struct {
+	format string
+	pipe   *io.PipeWriter
+	done   chan struct{}
+	digest *Digest
+	err    error
+}
+
+// NewDigestWriter starts digesting records of the given format
+func NewDigestWriter(format string) *DigestWriter {
+	reader, writer := io.Pipe()
+	w := &DigestWriter{format: format, pipe: writer, done: make(chan struct{})}
+	go func() {
+		defer close(w.done)
+		w.digest, w.err = digest(reader, format)
+		// Unblock writes if the data stopped parsing early
+		reader.CloseWithError(w.err)
+	}()
+	return w
+}
+
+// Write feeds data to the digest; it fails if the data does not parse as
+// the format
+func (w *DigestWriter) Write(p []byte) (int, error) {
+	return w.pipe.Write(p)
+}
+
+// Sum ends the data and returns its digest
+func (w *DigestWriter) Sum() (*Digest, error) {
+	w.pipe.Close()
+	<-w.done
+	if w.err != nil {
+		return nil, errors.NewFileError(fmt.Sprintf("failed to read the export as %s", w.format), w.err)
+	}
+	return w.digest, nil
+}
+
+// digest hashes and counts the records of r in a single pass
+func digest(r io.Reader, format string) (*Digest, error) {
+	hasher := sha256.New()
+	counter := &countingWriter{}
+	reader := io.TeeReader(r, io.MultiWriter(hasher, counter))
+
+	records, err := countRecords(reader, format)
+	if err != nil {
+		return nil, err
+	}
+	// Hash anything the record counter did not consume
+	if _, err := io.Copy(io.Discard, reader); err != nil {
+		return nil, err
+	}
+
+	return &Digest{
+		Records: records,
+		Bytes:   counter.n,
+		SHA256:  hex.EncodeToString(hasher.Sum(nil)),
+	}, nil
+}
+
+func countRecords(r io.Reader, format string) (int64, error) {
+	switch format {
+	case FormatCSV:
+		return countCSVRecords(r)
+	case FormatJSON:
+		return countJSONRecords(r)
+	case FormatJSONL, FormatLines:
+		return countLines(r)
+	}
+	return 0, fmt.Errorf("unknown format %q", format)
+}
+
+// countCSVRecords counts rows after the header
+func countCSVRecords(r io.Reader) (int64, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	var rows int64
+	for {
+		_, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+		rows++
+	}
+	if rows == 0 {
+		return 0, nil
+	}
+	return rows - 1, nil
+}
+
+// countJSONRecords counts the elements of a top-level array, or of the
+// "data" array of a top-level object as in the CLI's list output. Any other
+// document counts as one record. Elements are decoded one at a time.
+func countJSONRecords(r io.Reader) (int64, error) {
+	decoder := json.NewDecoder(r)
+	token, err := decoder.Token()
+	if err == io.EOF {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	switch token {
+	case json.Delim('['):
+		return countJSONArray(decoder)
+	case json.Delim('{'):
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return 0, err
+			}
+			if key == "data" {
+				if next, err := decoder.Token(); err != nil {
+					return 0, err
+				} else if next == json.Delim('[') {
+					return countJSONArray(decoder)
+				}
+				return 1, nil
+			}
+			var skip json.RawMessage
+			if err := decoder.Decode(&skip); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return 1, nil
+}
+
+func countJSONArray(decoder *json.Decoder) (int64, error) {
+	var count int64
+	for decoder.More() {
+		var element json.RawMessage
+		if err := decoder.Decode(&element); err != nil {
+			return 0, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// countLines counts non-blank lines, byte by byte so long lines need no buffer
+func countLines(r io.Reader) (int64, error) {
+	reader := bufio.NewReader(r)
+	var count int64
+	blank := true
+	for {
+		b, err := reader.ReadByte()
+		if err == io.EOF {
+			if !blank {
+				count++
+			}
+			return count, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		switch b {
+		case '\n':
+			if !blank {
+				count++
+			}
+			blank = true
+		case ' ', '\t', '\r':
+		default:
+			blank = false
+		}
+	}
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,165 @@
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	clierrors "github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestDigestFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		records int64
+	}{
+		{"csv", "data.csv", "email,reason\na@example.com,bounce\n\"b@example.com\",\"multi\nline\"\n", 2},
+		{"csv header only", "data.csv", "email,reason\n", 0},
+		{"empty csv", "data.csv", "", 0},
+		{"json array", "data.json", `[{"id":1},{"id":2},{"id":3}]`, 3},
+		{"json list object", "data.json", `{"object":"list","pagination":{"has_more":false},"data":[{"id":1},{"id":2}]}`, 2},
+		{"json single object", "data.json", `{"id":1}`, 1},
+		{"jsonl", "data.jsonl", "{\"id\":1}\n\n{\"id\":2}\n{\"id\":3}", 3},
+		{"other", "data.txt", "one\ntwo\n", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeTestFile(t, tt.file, tt.content)
+
+			digest, err := DigestFile(path, FormatFromPath(path))
+			require.NoError(t, err)
+
+			sum := sha256.Sum256([]byte(tt.content))
+			assert.Equal(t, hex.EncodeToString(sum[:]), digest.SHA256)
+			assert.Equal(t, int64(len(tt.content)), digest.Bytes)
+			assert.Equal(t, tt.records, digest.Records)
+		})
+	}
+}
+
+func TestDigestFile_LongLines(t *testing.T) {
+	// Lines longer than any read buffer are still one record
+	line := strings.Repeat("x", 200*1024)
+	path := writeTestFile(t, "data.jsonl", line+"\n"+line+"\n")
+
+	digest, err := DigestFile(path, FormatJSONL)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), digest.Records)
+}
+
+func TestManifest_RoundTrip(t *testing.T) {
+	dataFile := writeTestFile(t, "suppressions.csv", "email\na@example.com\nb@example.com\n")
+	manifestPath := filepath.Join(t.TempDir(), "manifest.json")
+
+	started := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	m := New("suppressions export", map[string]string{"from": "2026-02-01T00:00:00Z", "domain": "example.com"}, started)
+	require.NoError(t, m.Finalize(dataFile))
+	require.NoError(t, m.Write(manifestPath))
+
+	loaded, err := Load(manifestPath)
+	require.NoError(t, err)
+	assert.Equal(t, SchemaVersion, loaded.SchemaVersion)
+	assert.Equal(t, "suppressions.csv", loaded.File)
+	assert.Equal(t, FormatCSV, loaded.Format)
+	assert.Equal(t, int64(2), loaded.Records)
+	assert.Equal(t, "example.com", loaded.Filters["domain"])
+	assert.Equal(t, started, loaded.StartedAt)
+	assert.False(t, loaded.CompletedAt.IsZero())
+	assert.NotEmpty(t, loaded.CLIVersion)
+
+	_, mismatches, err := loaded.Verify(dataFile)
+	require.NoError(t, err)
+	assert.Empty(t, mismatches)
+
+	// Dropping a row changes both the checksum and the count
+	require.NoError(t, os.WriteFile(dataFile, []byte("email\na@example.com\n"), 0644))
+	_, mismatches, err = loaded.Verify(dataFile)
+	require.NoError(t, err)
+	require.Len(t, mismatches, 2)
+	assert.Contains(t, mismatches[0], "SHA-256 is")
+	assert.Equal(t, "file has 1 records, manifest has 2", mismatches[1])
+}
+
+func TestLoad_SchemaVersions(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"current with unknown fields", `{"schema_version":1,"sha256":"abc","records":1,"added_later":true}`, ""},
+		{"newer", `{"schema_version":2,"sha256":"abc"}`, "uses schema version 2, this CLI supports up to 1"},
+		{"missing version", `{"sha256":"abc"}`, "has no schema_version"},
+		{"missing checksum", `{"schema_version":1}`, "has no sha256"},
+		{"not json", `records: 1`, "failed to parse manifest"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writeTestFile(t, "manifest.json", tt.content))
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestDigestFile_Missing(t *testing.T) {
+	_, err := DigestFile(filepath.Join(t.TempDir(), "missing.csv"), FormatCSV)
+	require.Error(t, err)
+	cliErr, ok := err.(*clierrors.CLIError)
+	require.True(t, ok)
+	assert.Equal(t, clierrors.ErrCodeFileOperation, cliErr.Code)
+}
+
+func TestDigestWriter(t *testing.T) {
+	data := "id,email\n1,a@example.com\n2,b@example.com\n"
+	path := writeTestFile(t, "data.csv", data)
+	want, err := DigestFile(path, FormatCSV)
+	require.NoError(t, err)
+
+	w := NewDigestWriter(FormatCSV)
+	for _, chunk := range []string{data[:5], data[5:30], data[30:]} {
+		_, err := w.Write([]byte(chunk))
+		require.NoError(t, err)
+	}
+	got, err := w.Sum()
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	m := New("messages list", nil, time.Now())
+	m.FinalizeDigest("data.csv", FormatCSV, got)
+	encoded, err := m.Marshal()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path+".manifest.json", encoded, 0644))
+	loaded, err := Load(path + ".manifest.json")
+	require.NoError(t, err)
+	_, mismatches, err := loaded.Verify(path)
+	require.NoError(t, err)
+	assert.Empty(t, mismatches)
+}
+
+func TestDigestWriter_InvalidData(t *testing.T) {
+	w := NewDigestWriter(FormatJSON)
+	w.Write([]byte("{not json"))
+	_, err := w.Sum()
+	assert.Error(t, err)
+}
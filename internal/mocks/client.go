@@ -19,6 +19,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/AhaSend/ahasend-go/api"
 	"github.com/AhaSend/ahasend-go/models/common"
 	"github.com/AhaSend/ahasend-go/models/requests"
 	"github.com/AhaSend/ahasend-go/models/responses"
@@ -44,6 +45,11 @@ func (m *MockClient) GetAccountID() string {
 	return args.String(0)
 }
 
+func (m *MockClient) GetAPIURL() string {
+	args := m.Called()
+	return args.String(0)
+}
+
 func (m *MockClient) GetAuthContext() context.Context {
 	args := m.Called()
 	if args.Get(0) == nil {
@@ -60,6 +66,30 @@ func (m *MockClient) GetAccount() (*responses.Account, error) {
 	return args.Get(0).(*responses.Account), args.Error(1)
 }
 
+func (m *MockClient) UpdateAccount(req requests.UpdateAccountRequest) (*responses.Account, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*responses.Account), args.Error(1)
+}
+
+func (m *MockClient) GetAccountStatus() (*client.AccountStatus, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*client.AccountStatus), args.Error(1)
+}
+
+func (m *MockClient) ListAccounts() ([]responses.Account, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]responses.Account), args.Error(1)
+}
+
 func (m *MockClient) Ping() error {
 	args := m.Called()
 	return args.Error(0)
@@ -88,6 +118,14 @@ func (m *MockClient) SendMessageWithIdempotencyKey(req requests.CreateMessageReq
 	return args.Get(0).(*responses.CreateMessageResponse), args.Error(1)
 }
 
+func (m *MockClient) SendConversationMessageWithIdempotencyKey(req requests.CreateConversationMessageRequest, idempotencyKey string) (*responses.CreateMessageResponse, error) {
+	args := m.Called(req, idempotencyKey)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*responses.CreateMessageResponse), args.Error(1)
+}
+
 func (m *MockClient) CancelMessage(accountID, messageID string) (*common.SuccessResponse, error) {
 	args := m.Called(accountID, messageID)
 	if args.Get(0) == nil {
@@ -112,6 +150,14 @@ func (m *MockClient) GetMessage(messageID string) (*responses.Message, error) {
 	return args.Get(0).(*responses.Message), args.Error(1)
 }
 
+func (m *MockClient) GetMessageAttempts(messageID string) (*client.MessageAttemptsResponse, error) {
+	args := m.Called(messageID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*client.MessageAttemptsResponse), args.Error(1)
+}
+
 // Domain operations methods
 
 func (m *MockClient) ListDomains(limit *int32, cursor *string) (*responses.PaginatedDomainsResponse, error) {
@@ -180,6 +226,14 @@ func (m *MockClient) ListWebhooks(limit *int32, cursor *string) (*responses.Pagi
 	return args.Get(0).(*responses.PaginatedWebhooksResponse), args.Error(1)
 }
 
+func (m *MockClient) ListWebhooksWithParams(params api.GetWebhooksParams) (*responses.PaginatedWebhooksResponse, error) {
+	args := m.Called(params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*responses.PaginatedWebhooksResponse), args.Error(1)
+}
+
 func (m *MockClient) CreateWebhook(req requests.CreateWebhookRequest) (*responses.Webhook, error) {
 	args := m.Called(req)
 	if args.Get(0) == nil {
@@ -209,6 +263,14 @@ func (m *MockClient) DeleteWebhook(webhookID string) error {
 	return args.Error(0)
 }
 
+func (m *MockClient) RotateWebhookSecret(webhookID string) (*responses.Webhook, error) {
+	args := m.Called(webhookID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*responses.Webhook), args.Error(1)
+}
+
 func (m *MockClient) TriggerWebhook(webhookID string, events []string) error {
 	args := m.Called(webhookID, events)
 	return args.Error(0)
@@ -440,6 +502,14 @@ func (m *MockClient) CreateSMTPCredential(req requests.CreateSMTPCredentialReque
 	return args.Get(0).(*responses.SMTPCredential), args.Error(1)
 }
 
+func (m *MockClient) UpdateSMTPCredential(credentialID string, req client.UpdateSMTPCredentialRequest) (*responses.SMTPCredential, error) {
+	args := m.Called(credentialID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*responses.SMTPCredential), args.Error(1)
+}
+
 func (m *MockClient) DeleteSMTPCredential(credentialID string) error {
 	args := m.Called(credentialID)
 	return args.Error(0)
@@ -868,3 +938,11 @@ func (m *MockClient) ConnectWebSocket(wsURL, webhookID string, forceReconnect, s
 	}
 	return args.Get(0).(*client.WebSocketClient), args.Error(1)
 }
+
+func (m *MockClient) ResumeWebSocket(wsURL, connectionID, lastStreamID string, skipVerify bool) (*client.WebSocketClient, error) {
+	args := m.Called(wsURL, connectionID, lastStreamID, skipVerify)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*client.WebSocketClient), args.Error(1)
+}
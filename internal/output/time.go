@@ -2,6 +2,7 @@ package output
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 	"time"
 
@@ -28,39 +29,234 @@ func FormatTimeLocalValue(t time.Time) string {
 	return localTime.Format("2006-01-02 15:04:05")
 }
 
-// ParseTimePast parses a time string that can be RFC3339 or relative time in the past
-// (e.g., "24h" means 24 hours ago)
+// dateOnlyLayout is a calendar date without a time of day
+const dateOnlyLayout = "2006-01-02"
+
+// dateLikePattern matches values meant as a date, so that impossible or
+// badly padded dates get a date error instead of a generic format error
+var dateLikePattern = regexp.MustCompile(`^\d{4}-\d{1,2}-\d{1,2}$`)
+
+// ParseTimePast parses a time string that can be RFC3339, a date-only value
+// (midnight local time) or relative time in the past (e.g., "24h" means 24
+// hours ago)
 func ParseTimePast(input string) (time.Time, error) {
-	// Try parsing as RFC3339 first
+	return ParseTimeStart(input, time.Local)
+}
+
+// ParseTimeStart parses the start of a time range. A date-only value such
+// as 2024-06-01 means midnight of that day in loc; everything else is parsed
+// as by ParseTimePast.
+func ParseTimeStart(input string, loc *time.Location) (time.Time, error) {
+	if day, ok, err := parseDate(input, loc); ok {
+		return day, err
+	}
+	return parseTimePast(input)
+}
+
+// ParseTimeEnd parses the end of a time range. A date-only value means the
+// last instant of that day in loc, so a single date covers the whole day.
+func ParseTimeEnd(input string, loc *time.Location) (time.Time, error) {
+	if day, ok, err := parseDate(input, loc); ok {
+		if err != nil {
+			return time.Time{}, err
+		}
+		return endOfDay(day), nil
+	}
+	return parseTimePast(input)
+}
+
+// ParseTimeRange parses the --from-time, --to-time and --on flags of the
+// commands that take a time range, with date-only values read in the
+// timezone named by tz (empty means local time). --on is shorthand for the
+// whole of one day and cannot be combined with the other two. Unset ends of
+// the range are returned as nil.
+func ParseTimeRange(fromStr, toStr, on, tz string) (from, to *time.Time, err error) {
+	loc, err := LoadTimezone(tz)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if on != "" {
+		if fromStr != "" || toStr != "" {
+			return nil, nil, errors.NewValidationError("--on cannot be combined with --from-time or --to-time", nil)
+		}
+		day, ok, err := parseDate(on, loc)
+		if !ok {
+			return nil, nil, errors.NewValidationError(fmt.Sprintf("invalid --on date %q (use YYYY-MM-DD)", on), nil)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		end := endOfDay(day)
+		return &day, &end, nil
+	}
+
+	if fromStr != "" {
+		t, err := ParseTimeStart(fromStr, loc)
+		if err != nil {
+			return nil, nil, errors.NewValidationError(fmt.Sprintf("invalid from-time: %v", err), nil)
+		}
+		from = &t
+	}
+	if toStr != "" {
+		t, err := ParseTimeEnd(toStr, loc)
+		if err != nil {
+			return nil, nil, errors.NewValidationError(fmt.Sprintf("invalid to-time: %v", err), nil)
+		}
+		to = &t
+	}
+	if from != nil && to != nil && to.Before(*from) {
+		return nil, nil, errors.NewValidationError("to-time must not be before from-time", nil)
+	}
+	return from, to, nil
+}
+
+// LoadTimezone resolves a --timezone value: an IANA name such as
+// "Europe/Berlin", "UTC", or empty for the local timezone
+func LoadTimezone(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.EqualFold(name, "local") {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("unknown timezone %q (use an IANA name like 'Europe/Berlin' or 'UTC')", name), nil)
+	}
+	return loc, nil
+}
+
+// parseDate parses a date-only value as midnight in loc. ok reports whether
+// the input looks like a date at all; err is set for dates that do not
+// exist, such as 2024-02-30.
+func parseDate(input string, loc *time.Location) (day time.Time, ok bool, err error) {
+	input = strings.TrimSpace(input)
+	if !dateLikePattern.MatchString(input) {
+		return time.Time{}, false, nil
+	}
+	day, err = time.ParseInLocation(dateOnlyLayout, input, loc)
+	if err != nil {
+		return time.Time{}, true, errors.NewValidationError(fmt.Sprintf("invalid date %q: not a real calendar date in YYYY-MM-DD form", input), nil)
+	}
+	return day, true, nil
+}
+
+// endOfDay returns the last instant of the day starting at midnight. Adding
+// a calendar day rather than 24h keeps daylight saving transitions right.
+func endOfDay(midnight time.Time) time.Time {
+	return midnight.AddDate(0, 0, 1).Add(-time.Nanosecond)
+}
+
+// parseTimePast parses RFC3339 or relative times in the past, as read by
+// parseTimeAgo
+func parseTimePast(input string) (time.Time, error) {
+	return parseTimeAgo(input, time.Now())
+}
+
+// dayDurationPattern matches a duration led by a number of days, such as
+// "7d" or "1d12h"; the rest is a Go duration
+var dayDurationPattern = regexp.MustCompile(`^(\d+)d(.*)$`)
+
+// ParseRelativeTime resolves a --since or --until value against now, in
+// UTC, as read by parseTimeAgo
+func ParseRelativeTime(input string, now time.Time) (time.Time, error) {
+	t, err := parseTimeAgo(input, now)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.UTC(), nil
+}
+
+// parseTimeAgo is the one grammar of relative times in the past: an RFC3339
+// timestamp, or a duration before now written as a Go duration ("90m",
+// "24h", "1h30m") led by an optional number of calendar days ("7d",
+// "1d12h"). A leading "-" ("-24h") also means ago. Words like "yesterday"
+// and numbers without a unit are ambiguous and rejected.
+func parseTimeAgo(input string, now time.Time) (time.Time, error) {
+	input = strings.TrimSpace(input)
 	if t, err := time.Parse(time.RFC3339, input); err == nil {
 		return t, nil
 	}
-	// Try parsing as relative time
-	input = strings.ToLower(strings.TrimSpace(input))
-	now := time.Now()
-	// Parse relative time formats like "1h", "24h", "7d", "30d"
-	if strings.HasSuffix(input, "h") {
-		hours := strings.TrimSuffix(input, "h")
-		var h int
-		if _, err := fmt.Sscanf(hours, "%d", &h); err == nil {
-			return now.Add(-time.Duration(h) * time.Hour), nil
+	days, rest, err := parseDurationAgo(input)
+	if err != nil {
+		return time.Time{}, errors.NewValidationError(fmt.Sprintf(
+			"invalid time %q: use a duration before now like '90m', '24h' or '7d', or an RFC3339 timestamp like 2024-01-15T10:30:00Z", input), nil)
+	}
+	return now.AddDate(0, 0, -days).Add(-rest), nil
+}
+
+// parseDurationAgo splits a duration into its leading day count and the rest
+func parseDurationAgo(input string) (days int, rest time.Duration, err error) {
+	value := strings.TrimPrefix(strings.ToLower(input), "-")
+
+	if match := dayDurationPattern.FindStringSubmatch(value); match != nil {
+		if _, err := fmt.Sscanf(match[1], "%d", &days); err != nil {
+			return 0, 0, err
 		}
+		if value = match[2]; value == "" {
+			return days, 0, nil
+		}
+	}
+	// time.ParseDuration takes a sign, and "0" without a unit; signs are
+	// not durations before now and any bare number is ambiguous
+	if value == "" || value == "0" || strings.ContainsAny(value[:1], "+-") {
+		return 0, 0, fmt.Errorf("invalid duration %q", input)
 	}
-	if strings.HasSuffix(input, "d") {
-		days := strings.TrimSuffix(input, "d")
-		var d int
-		if _, err := fmt.Sscanf(days, "%d", &d); err == nil {
-			return now.AddDate(0, 0, -d), nil
+	rest, err = time.ParseDuration(value)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid duration %q", input)
+	}
+	return days, rest, nil
+}
+
+// TimeRangeFlags are the values of the --from-time, --to-time, --on,
+// --timezone, --since and --until flags of the commands that take a time
+// range
+type TimeRangeFlags struct {
+	From, To, On, Timezone, Since, Until string
+}
+
+// Parse resolves the flags against now. --since and --until replace
+// --from-time and --to-time and cannot be combined with them or with --on.
+// Unset ends of the range are returned as nil.
+func (f TimeRangeFlags) Parse(now time.Time) (from, to *time.Time, err error) {
+	if f.Since != "" && (f.From != "" || f.On != "") {
+		return nil, nil, errors.NewValidationError("--since cannot be combined with --from-time or --on", nil)
+	}
+	if f.Until != "" && (f.To != "" || f.On != "") {
+		return nil, nil, errors.NewValidationError("--until cannot be combined with --to-time or --on", nil)
+	}
+	from, to, err = ParseTimeRange(f.From, f.To, f.On, f.Timezone)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ApplySinceUntil(from, to, f.Since, f.Until, now)
+}
+
+// ApplySinceUntil resolves the --since and --until flags against now and
+// puts them in place of from and to, the parsed --from-time and --to-time
+// (commands reject combining the two). Unset values leave from and to as
+// they are. It fails when the resulting start is after the end.
+func ApplySinceUntil(from, to *time.Time, since, until string, now time.Time) (*time.Time, *time.Time, error) {
+	startFlag, endFlag := "--from-time", "--to-time"
+	if since != "" {
+		t, err := ParseRelativeTime(since, now)
+		if err != nil {
+			return nil, nil, errors.NewValidationError(fmt.Sprintf("invalid --since: %v", err), nil)
 		}
+		from, startFlag = &t, "--since"
 	}
-	if strings.HasSuffix(input, "m") {
-		minutes := strings.TrimSuffix(input, "m")
-		var m int
-		if _, err := fmt.Sscanf(minutes, "%d", &m); err == nil {
-			return now.Add(-time.Duration(m) * time.Minute), nil
+	if until != "" {
+		t, err := ParseRelativeTime(until, now)
+		if err != nil {
+			return nil, nil, errors.NewValidationError(fmt.Sprintf("invalid --until: %v", err), nil)
 		}
+		to, endFlag = &t, "--until"
+	}
+	if from != nil && to != nil && from.After(*to) {
+		return nil, nil, errors.NewValidationError(fmt.Sprintf("%s (%s) is after %s (%s)",
+			startFlag, from.UTC().Format(time.RFC3339), endFlag, to.UTC().Format(time.RFC3339)), nil)
 	}
-	return time.Time{}, errors.NewValidationError(fmt.Sprintf("invalid time format: %s (use RFC3339 or relative like '24h', '7d')", input), nil)
+	return from, to, nil
 }
 
 // ParseTimeFuture parses a time string that can be RFC3339 or relative time in the future
@@ -5,6 +5,9 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/errors"
 )
 
 func TestFormatTimeLocal(t *testing.T) {
@@ -237,3 +240,285 @@ func BenchmarkFormatTimeLocal_ZeroValue(b *testing.B) {
 func timePtrTime(t time.Time) *time.Time {
 	return &t
 }
+
+func TestParseTimePast_Relative(t *testing.T) {
+	for _, input := range []string{"24h", "-24h", " -24H "} {
+		parsed, err := ParseTimePast(input)
+		assert.NoError(t, err, input)
+		assert.WithinDuration(t, time.Now().Add(-24*time.Hour), parsed, time.Minute, input)
+	}
+
+	// --from-time reads durations like --since does
+	parsed, err := ParseTimePast("1h30m")
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(-90*time.Minute), parsed, time.Minute)
+
+	_, err = ParseTimePast("yesterday")
+	assert.Error(t, err)
+}
+
+func TestParseTimeRange_DateOnly(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		from     string
+		to       string
+		on       string
+		timezone string
+		wantFrom time.Time
+		wantTo   time.Time
+	}{
+		{
+			name:     "date range in UTC",
+			from:     "2024-06-01",
+			to:       "2024-06-03",
+			timezone: "UTC",
+			wantFrom: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			wantTo:   time.Date(2024, 6, 3, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "same date on both sides covers the whole day",
+			from:     "2024-06-01",
+			to:       "2024-06-01",
+			timezone: "UTC",
+			wantFrom: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			wantTo:   time.Date(2024, 6, 1, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "date range in a named timezone",
+			from:     "2024-06-01",
+			to:       "2024-06-01",
+			timezone: "Europe/Berlin",
+			wantFrom: time.Date(2024, 6, 1, 0, 0, 0, 0, berlin),
+			wantTo:   time.Date(2024, 6, 1, 23, 59, 59, 999999999, berlin),
+		},
+		{
+			name:     "on is a one-day window",
+			on:       "2024-06-01",
+			timezone: "America/New_York",
+			wantFrom: time.Date(2024, 6, 1, 4, 0, 0, 0, time.UTC),
+			wantTo:   time.Date(2024, 6, 2, 3, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "on a daylight saving change day is 23 hours long",
+			on:       "2024-03-10",
+			timezone: "America/New_York",
+			wantFrom: time.Date(2024, 3, 10, 0, 0, 0, 0, newYork),
+			wantTo:   time.Date(2024, 3, 10, 23, 59, 59, 999999999, newYork),
+		},
+		{
+			name:     "leap day",
+			on:       "2024-02-29",
+			timezone: "UTC",
+			wantFrom: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+			wantTo:   time.Date(2024, 2, 29, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "RFC3339 values ignore the timezone",
+			from:     "2024-06-01T10:00:00Z",
+			to:       "2024-06-01T12:00:00Z",
+			timezone: "Europe/Berlin",
+			wantFrom: time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC),
+			wantTo:   time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "date start with RFC3339 end",
+			from:     "2024-06-01",
+			to:       "2024-06-02T12:00:00Z",
+			timezone: "UTC",
+			wantFrom: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			wantTo:   time.Date(2024, 6, 2, 12, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, err := ParseTimeRange(tt.from, tt.to, tt.on, tt.timezone)
+			require.NoError(t, err)
+			require.NotNil(t, from)
+			require.NotNil(t, to)
+			assert.True(t, tt.wantFrom.Equal(*from), "from: want %s, got %s", tt.wantFrom, *from)
+			assert.True(t, tt.wantTo.Equal(*to), "to: want %s, got %s", tt.wantTo, *to)
+		})
+	}
+}
+
+func TestParseTimeRange_DaylightSavingLength(t *testing.T) {
+	from, to, err := ParseTimeRange("", "", "2024-03-10", "America/New_York")
+	require.NoError(t, err)
+	assert.Equal(t, 23*time.Hour, to.Sub(*from)+time.Nanosecond)
+}
+
+func TestParseTimeRange_Unset(t *testing.T) {
+	from, to, err := ParseTimeRange("", "", "", "")
+	require.NoError(t, err)
+	assert.Nil(t, from)
+	assert.Nil(t, to)
+
+	from, to, err = ParseTimeRange("24h", "", "", "")
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(-24*time.Hour), *from, time.Minute)
+	assert.Nil(t, to)
+}
+
+func TestParseTimeRange_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     string
+		to       string
+		on       string
+		timezone string
+		want     string
+	}{
+		{name: "impossible day", from: "2024-02-30", want: "not a real calendar date"},
+		{name: "not a leap year", to: "2023-02-29", want: "not a real calendar date"},
+		{name: "impossible month", on: "2024-13-01", want: "not a real calendar date"},
+		{name: "unpadded date", from: "2024-6-1", want: "YYYY-MM-DD"},
+		{name: "on is not a date", on: "24h", want: "invalid --on date"},
+		{name: "on with from-time", from: "2024-06-01", on: "2024-06-01", want: "cannot be combined"},
+		{name: "on with to-time", to: "2024-06-01", on: "2024-06-01", want: "cannot be combined"},
+		{name: "unknown timezone", from: "2024-06-01", timezone: "Mars/Olympus", want: "unknown timezone"},
+		{name: "end before start", from: "2024-06-02", to: "2024-06-01", want: "must not be before"},
+		{name: "garbage", from: "yesterday", want: "invalid from-time"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ParseTimeRange(tt.from, tt.to, tt.on, tt.timezone)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestParseTimePast_DateOnlyIsLocalMidnight(t *testing.T) {
+	parsed, err := ParseTimePast("2024-06-01")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 6, 1, 0, 0, 0, 0, time.Local), parsed)
+}
+
+func TestParseRelativeTime(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+	utcNow := now.UTC()
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"90m", utcNow.Add(-90 * time.Minute)},
+		{"24h", utcNow.Add(-24 * time.Hour)},
+		{"1h30m", utcNow.Add(-90 * time.Minute)},
+		{"7d", utcNow.Add(-7 * 24 * time.Hour)},
+		{"1d12h", utcNow.Add(-36 * time.Hour)},
+		{"2D", utcNow.Add(-48 * time.Hour)},
+		{" -24h ", utcNow.Add(-24 * time.Hour)},
+		{"0s", utcNow},
+		{"2026-03-01T08:00:00Z", time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)},
+		{"2026-03-01T09:00:00+01:00", time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseRelativeTime(tt.input, now)
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "want %s, got %s", tt.want, got)
+			assert.Equal(t, time.UTC, got.Location())
+		})
+	}
+}
+
+func TestParseRelativeTime_Rejected(t *testing.T) {
+	now := time.Now()
+	for _, input := range []string{
+		"", "yesterday", "24", "0", "7", "d", "1w", "12h1d", "+24h", "--24h", "1d-2h", "1d+2h",
+		"2024-06-01", "2024-06-01 10:00", "last week",
+	} {
+		_, err := ParseRelativeTime(input, now)
+		require.Error(t, err, input)
+		assert.Contains(t, err.Error(), "use a duration before now like '90m', '24h' or '7d', or an RFC3339 timestamp", input)
+	}
+}
+
+func TestApplySinceUntil(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	fromTime := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	toTime := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+
+	from, to, err := ApplySinceUntil(nil, nil, "24h", "90m", now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-24*time.Hour), *from)
+	assert.Equal(t, now.Add(-90*time.Minute), *to)
+
+	from, to, err = ApplySinceUntil(&fromTime, &toTime, "", "", now)
+	require.NoError(t, err)
+	assert.Equal(t, fromTime, *from)
+	assert.Equal(t, toTime, *to)
+
+	// Mixed forms: a duration until a timestamp, and --since with --to-time
+	from, to, err = ApplySinceUntil(nil, nil, "30d", "2026-03-05T00:00:00Z", now)
+	require.NoError(t, err)
+	assert.Equal(t, now.AddDate(0, 0, -30), *from)
+	assert.Equal(t, time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC), *to)
+
+	from, to, err = ApplySinceUntil(nil, &toTime, "2d", "", now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-48*time.Hour), *from)
+	assert.Equal(t, toTime, *to)
+
+	from, to, err = ApplySinceUntil(nil, nil, "", "1h", now)
+	require.NoError(t, err)
+	assert.Nil(t, from)
+	assert.Equal(t, now.Add(-time.Hour), *to)
+}
+
+func TestApplySinceUntil_Errors(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	fromTime := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		from  *time.Time
+		since string
+		until string
+		want  string
+	}{
+		{name: "since after until", since: "1d", until: "2d", want: "--since (2026-03-09T12:00:00Z) is after --until (2026-03-08T12:00:00Z)"},
+		{name: "since after until timestamp", since: "1h", until: "2026-03-01T00:00:00Z", want: "--since (2026-03-10T11:00:00Z) is after --until (2026-03-01T00:00:00Z)"},
+		{name: "from-time after until", from: &fromTime, until: "2d", want: "--from-time (2026-03-10T00:00:00Z) is after --until"},
+		{name: "ambiguous since", since: "yesterday", want: `invalid --since: invalid time "yesterday"`},
+		{name: "bare number until", until: "24", want: `invalid --until: invalid time "24"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ApplySinceUntil(tt.from, nil, tt.since, tt.until, now)
+			require.Error(t, err)
+			assert.Equal(t, 4, errors.GetExitCode(err))
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestTimeRangeFlags_Parse(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	from, to, err := TimeRangeFlags{Since: "1h30m", To: "2026-03-10T11:00:00Z"}.Parse(now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 10, 10, 30, 0, 0, time.UTC), *from)
+	assert.Equal(t, time.Date(2026, 3, 10, 11, 0, 0, 0, time.UTC), *to)
+
+	for _, tt := range []struct {
+		flags TimeRangeFlags
+		want  string
+	}{
+		{TimeRangeFlags{Since: "1d", From: "2d"}, "--since cannot be combined with --from-time or --on"},
+		{TimeRangeFlags{Since: "1d", On: "2026-03-01"}, "--since cannot be combined with --from-time or --on"},
+		{TimeRangeFlags{Until: "1d", To: "2h"}, "--until cannot be combined with --to-time or --on"},
+	} {
+		_, _, err := tt.flags.Parse(now)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), tt.want)
+	}
+}
@@ -0,0 +1,204 @@
+package pager
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+)
+
+// Pager modes
+const (
+	ModeAuto   = "auto"   // page when stdout is a terminal and the output is taller than it
+	ModeAlways = "always" // page whenever stdout is a terminal
+	ModeNever  = "never"  // never page
+)
+
+// DefaultCommand is the pager used when $PAGER is not set. -R passes color
+// escape sequences through.
+const DefaultCommand = "less -R"
+
+// GetSupportedModes returns the valid pager modes
+func GetSupportedModes() []string {
+	return []string{ModeAuto, ModeAlways, ModeNever}
+}
+
+// Command returns the pager command line: $PAGER, or DefaultCommand
+func Command() string {
+	if command := strings.TrimSpace(os.Getenv("PAGER")); command != "" {
+		return command
+	}
+	return DefaultCommand
+}
+
+// isTerminal and terminalHeight are replaced by SetTerminalForTesting
+var (
+	isTerminal     = func(out io.Writer) bool { return printer.IsTerminal(out) }
+	terminalHeight = func(out io.Writer) (int, bool) {
+		file, ok := out.(*os.File)
+		if !ok {
+			return 0, false
+		}
+		_, height, err := term.GetSize(int(file.Fd()))
+		return height, err == nil && height > 0
+	}
+)
+
+// SetTerminalForTesting makes every writer look like a terminal of the given
+// height. It returns a function that restores terminal detection.
+func SetTerminalForTesting(height int) func() {
+	previousIsTerminal, previousHeight := isTerminal, terminalHeight
+	isTerminal = func(io.Writer) bool { return true }
+	terminalHeight = func(io.Writer) (int, bool) { return height, true }
+	return func() {
+		isTerminal, terminalHeight = previousIsTerminal, previousHeight
+	}
+}
+
+// IsTerminal reports whether out is a terminal, the only kind of output that
+// is ever paged
+func IsTerminal(out io.Writer) bool {
+	return isTerminal(out)
+}
+
+// Writer buffers everything a command renders. When the command finishes,
+// Flush either writes the buffer to stdout or pipes it through a pager such
+// as less, depending on the mode, whether stdout is a terminal and how tall
+// the output is compared to the terminal.
+type Writer struct {
+	out         io.Writer
+	errOut      io.Writer
+	mode        string
+	command     string
+	buf         bytes.Buffer
+	passthrough bool
+}
+
+// NewWriter creates a Writer that pages to out with the pager command
+// line. The pager's own errors go to errOut.
+func NewWriter(out, errOut io.Writer, mode, command string) *Writer {
+	return &Writer{out: out, errOut: errOut, mode: mode, command: command}
+}
+
+// Write buffers p, or writes it straight to the output once buffering has
+// stopped
+func (w *Writer) Write(p []byte) (int, error) {
+	if w.passthrough {
+		return w.out.Write(p)
+	}
+	return w.buf.Write(p)
+}
+
+// StopBuffering writes what has been buffered and sends further output
+// straight to stdout. Commands that print incrementally call it, so their
+// output is not held back until they finish.
+func (w *Writer) StopBuffering() error {
+	if w.passthrough {
+		return nil
+	}
+	w.passthrough = true
+	_, err := w.out.Write(w.buf.Bytes())
+	w.buf.Reset()
+	return err
+}
+
+// Flush shows the buffered output, through the pager when the mode calls
+// for it. When the pager cannot be started or exits with an error the
+// output is written directly instead.
+func (w *Writer) Flush() error {
+	if w.passthrough {
+		return nil
+	}
+	defer w.buf.Reset()
+	w.passthrough = true
+
+	if !w.shouldPage() {
+		_, err := w.out.Write(w.buf.Bytes())
+		return err
+	}
+
+	if err := w.page(); err != nil {
+		logger.Get().WithError(err).WithFields(map[string]interface{}{
+			"pager": w.command,
+		}).Debug("Pager failed, writing output directly")
+		_, err := w.out.Write(w.buf.Bytes())
+		return err
+	}
+	return nil
+}
+
+func (w *Writer) shouldPage() bool {
+	if w.mode == ModeNever || w.buf.Len() == 0 || !isTerminal(w.out) {
+		return false
+	}
+	if w.mode == ModeAlways {
+		return true
+	}
+	height, ok := terminalHeight(w.out)
+	return ok && bytes.Count(w.buf.Bytes(), []byte("\n")) >= height
+}
+
+// page pipes the buffer through the pager. The command line is split on
+// whitespace; shell syntax is not supported.
+func (w *Writer) page() error {
+	args := strings.Fields(w.command)
+	if len(args) == 0 {
+		return fmt.Errorf("no pager command")
+	}
+	path, err := exec.LookPath(args[0])
+	if err != nil {
+		return err
+	}
+
+	pager := exec.Command(path, args[1:]...)
+	pager.Stdin = bytes.NewReader(w.buf.Bytes())
+	pager.Stdout = w.out
+	pager.Stderr = w.errOut
+	return pager.Run()
+}
+
+type contextKey struct{}
+
+// Attach sends cmd's output through w: it becomes cmd's stdout and is stored
+// in the command context for Flush and StopBuffering
+func Attach(cmd *cobra.Command, w *Writer) {
+	cmd.SetOut(w)
+	cmd.SetContext(context.WithValue(cmd.Context(), contextKey{}, w))
+}
+
+// Flush flushes the Writer attached to cmd, if any
+func Flush(cmd *cobra.Command) error {
+	if w := fromCommand(cmd); w != nil {
+		return w.Flush()
+	}
+	return nil
+}
+
+// StopBuffering stops buffering cmd's output, if it is buffered, and
+// restores the real stdout as cmd's output. Commands that print
+// incrementally or redraw the terminal call it before they start.
+func StopBuffering(cmd *cobra.Command) error {
+	w := fromCommand(cmd)
+	if w == nil {
+		return nil
+	}
+	cmd.SetOut(w.out)
+	return w.StopBuffering()
+}
+
+func fromCommand(cmd *cobra.Command) *Writer {
+	if cmd.Context() == nil {
+		return nil
+	}
+	w, _ := cmd.Context().Value(contextKey{}).(*Writer)
+	return w
+}
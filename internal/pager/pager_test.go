@@ -0,0 +1,142 @@
+package pager
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePager writes a pager script that records its arguments and input to a
+// file and echoes the input prefixed with PAGED, then exits with exitCode.
+// It returns the command line and the record file.
+func fakePager(t *testing.T, exitCode int) (string, string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake pager is a shell script")
+	}
+	dir := t.TempDir()
+	record := filepath.Join(dir, "record")
+	script := filepath.Join(dir, "pager")
+	content := "#!/bin/sh\n" +
+		"echo \"args: $*\" > '" + record + "'\n" +
+		"tee -a '" + record + "' | sed 's/^/PAGED /'\n" +
+		"exit " + strconv.Itoa(exitCode) + "\n"
+	require.NoError(t, os.WriteFile(script, []byte(content), 0o755))
+	return script + " -R", record
+}
+
+func lines(n int) string {
+	return strings.Repeat("row\n", n)
+}
+
+func TestWriter_Modes(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     string
+		output   string
+		terminal bool
+		paged    bool
+	}{
+		{"auto pages output taller than the terminal", ModeAuto, lines(30), true, true},
+		{"auto pages output as tall as the terminal", ModeAuto, lines(24), true, true},
+		{"auto leaves short output alone", ModeAuto, lines(5), true, false},
+		{"always pages short output", ModeAlways, lines(1), true, true},
+		{"never pages", ModeNever, lines(100), true, false},
+		{"output that is not a terminal is never paged", ModeAlways, lines(100), false, false},
+		{"empty output is never paged", ModeAlways, "", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			command, record := fakePager(t, 0)
+			restore := SetTerminalForTesting(24)
+			defer restore()
+			if !tt.terminal {
+				isTerminal = func(io.Writer) bool { return false }
+			}
+
+			var out, errOut bytes.Buffer
+			w := NewWriter(&out, &errOut, tt.mode, command)
+			_, err := w.Write([]byte(tt.output))
+			require.NoError(t, err)
+			assert.Empty(t, out.String(), "output is buffered until Flush")
+			require.NoError(t, w.Flush())
+
+			if tt.paged {
+				recorded, err := os.ReadFile(record)
+				require.NoError(t, err)
+				assert.Equal(t, "args: -R\n"+tt.output, string(recorded))
+				assert.True(t, strings.HasPrefix(out.String(), "PAGED row\n"))
+			} else {
+				assert.NoFileExists(t, record)
+				assert.Equal(t, tt.output, out.String())
+			}
+		})
+	}
+}
+
+func TestWriter_FallsBackWhenPagerFails(t *testing.T) {
+	restore := SetTerminalForTesting(24)
+	defer restore()
+
+	t.Run("missing binary", func(t *testing.T) {
+		var out bytes.Buffer
+		w := NewWriter(&out, &bytes.Buffer{}, ModeAlways, filepath.Join(t.TempDir(), "no-such-pager"))
+		w.Write([]byte(lines(3)))
+		require.NoError(t, w.Flush())
+		assert.Equal(t, lines(3), out.String())
+	})
+
+	t.Run("non-zero exit", func(t *testing.T) {
+		command, record := fakePager(t, 1)
+		var out bytes.Buffer
+		w := NewWriter(&out, &bytes.Buffer{}, ModeAlways, command)
+		w.Write([]byte(lines(3)))
+		require.NoError(t, w.Flush())
+		assert.FileExists(t, record)
+		assert.True(t, strings.HasSuffix(out.String(), lines(3)), "the output is written directly after the pager fails")
+	})
+
+	t.Run("empty command", func(t *testing.T) {
+		var out bytes.Buffer
+		w := NewWriter(&out, &bytes.Buffer{}, ModeAlways, "  ")
+		w.Write([]byte(lines(3)))
+		require.NoError(t, w.Flush())
+		assert.Equal(t, lines(3), out.String())
+	})
+}
+
+func TestWriter_StopBuffering(t *testing.T) {
+	command, record := fakePager(t, 0)
+	restore := SetTerminalForTesting(2)
+	defer restore()
+
+	var out bytes.Buffer
+	w := NewWriter(&out, &bytes.Buffer{}, ModeAlways, command)
+	w.Write([]byte("header\n"))
+	require.NoError(t, w.StopBuffering())
+	assert.Equal(t, "header\n", out.String())
+
+	w.Write([]byte(lines(10)))
+	assert.Equal(t, "header\n"+lines(10), out.String(), "writes go straight through")
+
+	require.NoError(t, w.Flush())
+	assert.NoFileExists(t, record)
+	assert.Equal(t, "header\n"+lines(10), out.String())
+}
+
+func TestCommand(t *testing.T) {
+	t.Setenv("PAGER", "")
+	assert.Equal(t, DefaultCommand, Command())
+
+	t.Setenv("PAGER", " more ")
+	assert.Equal(t, "more", Command())
+}
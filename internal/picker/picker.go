@@ -0,0 +1,119 @@
+// Package picker lets the user choose one of a list of options with the arrow
+// keys on a terminal.
+//
+// List holds the state of a choice and renders it without touching the
+// terminal, so it can be tested on its own; Terminal drives a List from raw
+// keyboard input.
+package picker
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/AhaSend/ahasend-cli/internal/dashboard"
+)
+
+// ErrCancelled is returned when the user quits without choosing
+var ErrCancelled = errors.New("selection cancelled")
+
+// List is a choice among options with a highlighted cursor
+type List struct {
+	Title   string
+	Options []string
+	Cursor  int
+	offset  int // first option shown when the list is taller than the screen
+}
+
+// HandleKey applies a key press. It reports whether the choice is finished
+// and, if so, whether an option was chosen rather than cancelled.
+func (l *List) HandleKey(key dashboard.Key) (done, chosen bool) {
+	switch key {
+	case dashboard.KeyUp, "k":
+		if l.Cursor > 0 {
+			l.Cursor--
+		}
+	case dashboard.KeyDown, "j":
+		if l.Cursor < len(l.Options)-1 {
+			l.Cursor++
+		}
+	case dashboard.KeyEnter:
+		return true, len(l.Options) > 0
+	case "q", "Q", dashboard.KeyEscape, dashboard.KeyCtrlC:
+		return true, false
+	}
+	return false, false
+}
+
+// View renders the title, the options that fit in height lines with the
+// cursor marked, and a key help line
+func (l *List) View(height int) string {
+	visible := height - 3 // title, blank line and help
+	if visible < 1 {
+		visible = 1
+	}
+	if l.Cursor < l.offset {
+		l.offset = l.Cursor
+	}
+	if l.Cursor >= l.offset+visible {
+		l.offset = l.Cursor - visible + 1
+	}
+
+	var b strings.Builder
+	b.WriteString(l.Title + "\n\n")
+	for i := l.offset; i < len(l.Options) && i < l.offset+visible; i++ {
+		marker := "  "
+		if i == l.Cursor {
+			marker = "> "
+		}
+		b.WriteString(marker + l.Options[i] + "\n")
+	}
+	b.WriteString("↑/↓ move · enter select · q cancel")
+	return b.String()
+}
+
+// Terminal chooses options on a terminal. In and Out must be terminals;
+// In is put in raw mode while choosing and restored afterwards.
+type Terminal struct {
+	In  *os.File
+	Out *os.File
+}
+
+// Choose shows options under title and returns the index of the one chosen,
+// or ErrCancelled
+func (t *Terminal) Choose(title string, options []string) (int, error) {
+	state, err := term.MakeRaw(int(t.In.Fd()))
+	if err != nil {
+		return 0, fmt.Errorf("failed to set up the terminal: %w", err)
+	}
+	defer term.Restore(int(t.In.Fd()), state)
+
+	fmt.Fprint(t.Out, "\033[?1049h\033[?25l")
+	defer fmt.Fprint(t.Out, "\033[?25h\033[?1049l")
+
+	list := &List{Title: title, Options: options}
+	buf := make([]byte, 64)
+	for {
+		_, height, err := term.GetSize(int(t.Out.Fd()))
+		if err != nil {
+			height = 24
+		}
+		fmt.Fprint(t.Out, "\033[H\033[2J"+strings.ReplaceAll(list.View(height), "\n", "\r\n"))
+
+		n, err := t.In.Read(buf)
+		if err != nil {
+			return 0, ErrCancelled
+		}
+		for _, key := range dashboard.ParseKeys(buf[:n]) {
+			if done, chosen := list.HandleKey(key); done {
+				if !chosen {
+					return 0, ErrCancelled
+				}
+				return list.Cursor, nil
+			}
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package picker
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AhaSend/ahasend-cli/internal/dashboard"
+)
+
+func TestListHandleKey(t *testing.T) {
+	list := &List{Options: []string{"a", "b", "c"}}
+
+	list.HandleKey(dashboard.KeyUp)
+	assert.Equal(t, 0, list.Cursor, "cursor stops at the top")
+	list.HandleKey(dashboard.KeyDown)
+	list.HandleKey("j")
+	list.HandleKey(dashboard.KeyDown)
+	assert.Equal(t, 2, list.Cursor, "cursor stops at the bottom")
+	list.HandleKey("k")
+	assert.Equal(t, 1, list.Cursor)
+
+	done, chosen := list.HandleKey(dashboard.KeyEnter)
+	assert.True(t, done)
+	assert.True(t, chosen)
+
+	done, chosen = list.HandleKey(dashboard.KeyEscape)
+	assert.True(t, done)
+	assert.False(t, chosen)
+
+	done, chosen = (&List{}).HandleKey(dashboard.KeyEnter)
+	assert.True(t, done)
+	assert.False(t, chosen, "an empty list cannot be chosen from")
+}
+
+func TestListViewScrolls(t *testing.T) {
+	list := &List{Title: "Pick", Options: []string{"one", "two", "three", "four", "five"}}
+
+	view := list.View(5)
+	assert.True(t, strings.HasPrefix(view, "Pick\n\n> one\n  two\n"))
+	assert.NotContains(t, view, "three")
+
+	list.Cursor = 3
+	view = list.View(5)
+	assert.Contains(t, view, "  three\n> four\n")
+	assert.NotContains(t, view, "two")
+	assert.Contains(t, view, "enter select")
+}
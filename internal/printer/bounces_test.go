@@ -0,0 +1,254 @@
+package printer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/bounces"
+)
+
+func bounceStatsResponse() *responses.BounceStatisticsResponse {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	return &responses.BounceStatisticsResponse{
+		Object: "list",
+		Data: []responses.BounceStatistics{{
+			FromTimestamp: from,
+			ToTimestamp:   from.Add(24 * time.Hour),
+			Bounces: []responses.Bounce{
+				{Classification: "PolicyRelated", Count: 3},
+				{Classification: "SomethingNew", Count: 1},
+			},
+		}},
+	}
+}
+
+func TestHandleBounceStats_Explain(t *testing.T) {
+	policy, _ := bounces.Explain("PolicyRelated")
+
+	for _, format := range []string{"table", "plain", "csv", "json"} {
+		t.Run(format+" without --explain", func(t *testing.T) {
+			var buf bytes.Buffer
+			handler := GetResponseHandler(format, false, &buf)
+			require.NoError(t, handler.HandleBounceStats(bounceStatsResponse(), StatsConfig{Title: "Bounce Statistics"}))
+			assert.NotContains(t, buf.String(), policy.Action)
+			assert.NotContains(t, buf.String(), "explanation")
+		})
+	}
+
+	t.Run("table", func(t *testing.T) {
+		var buf bytes.Buffer
+		handler := GetResponseHandler("table", false, &buf)
+		require.NoError(t, handler.HandleBounceStats(bounceStatsResponse(), StatsConfig{Title: "Bounce Statistics", Explain: true}))
+		assert.Contains(t, buf.String(), "RECOMMENDED ACTION")
+		assert.Contains(t, buf.String(), "Blocked due to recipient server policies")
+	})
+
+	t.Run("plain", func(t *testing.T) {
+		var buf bytes.Buffer
+		handler := GetResponseHandler("plain", false, &buf)
+		require.NoError(t, handler.HandleBounceStats(bounceStatsResponse(), StatsConfig{Title: "Bounce Statistics", Explain: true}))
+		assert.Contains(t, buf.String(), "      "+policy.Description+"\n      Action: "+policy.Action+"\n")
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		handler := GetResponseHandler("csv", false, &buf)
+		require.NoError(t, handler.HandleBounceStats(bounceStatsResponse(), StatsConfig{
+			FieldOrder: []string{"classification", "count"},
+			Explain:    true,
+		}))
+		records, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 3)
+		assert.Equal(t, []string{"classification", "count", "explanation", "action"}, records[0])
+		assert.Equal(t, []string{"PolicyRelated", "3", policy.Description, policy.Action}, records[1])
+		assert.Equal(t, []string{"SomethingNew", "1", "", ""}, records[2])
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		handler := GetResponseHandler("json", false, &buf)
+		require.NoError(t, handler.HandleBounceStats(bounceStatsResponse(), StatsConfig{Explain: true}))
+
+		var decoded struct {
+			Data []struct {
+				Bounces []struct {
+					Classification string             `json:"classification"`
+					Explanation    *bounceExplanation `json:"explanation"`
+				} `json:"bounces"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		require.Len(t, decoded.Data, 1)
+		require.Len(t, decoded.Data[0].Bounces, 2)
+		assert.Equal(t, &bounceExplanation{Description: policy.Description, Action: policy.Action}, decoded.Data[0].Bounces[0].Explanation)
+		assert.Nil(t, decoded.Data[0].Bounces[1].Explanation)
+	})
+}
+
+// multiDayBounceStats has two daily buckets whose classifications overlap
+func multiDayBounceStats() *responses.BounceStatisticsResponse {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	return &responses.BounceStatisticsResponse{
+		Object: "list",
+		Data: []responses.BounceStatistics{{
+			FromTimestamp: from,
+			ToTimestamp:   from.Add(24 * time.Hour),
+			Bounces: []responses.Bounce{
+				{Classification: "QuotaIssues", Count: 2},
+				{Classification: "PolicyRelated", Count: 1},
+			},
+		}, {
+			FromTimestamp: from.Add(24 * time.Hour),
+			ToTimestamp:   from.Add(48 * time.Hour),
+			Bounces: []responses.Bounce{
+				{Classification: "PolicyRelated", Count: 4},
+				{Classification: "BadDomain", Count: 1},
+			},
+		}},
+	}
+}
+
+func TestHandleBounceStats_Aggregate(t *testing.T) {
+	config := StatsConfig{Title: "Bounce Statistics", Aggregate: true}
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		handler := GetResponseHandler("csv", false, &buf)
+		require.NoError(t, handler.HandleBounceStats(multiDayBounceStats(), StatsConfig{
+			FieldOrder: []string{"time_bucket", "classification"},
+			Aggregate:  true,
+		}))
+		records, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{
+			{"classification", "count", "percentage"},
+			{"PolicyRelated", "5", "62.5"},
+			{"QuotaIssues", "2", "25.0"},
+			{"BadDomain", "1", "12.5"},
+		}, records, "fixed columns, sorted by count")
+	})
+
+	t.Run("table", func(t *testing.T) {
+		var buf bytes.Buffer
+		handler := GetResponseHandler("table", false, &buf)
+		require.NoError(t, handler.HandleBounceStats(multiDayBounceStats(), config))
+		output := buf.String()
+		assert.Contains(t, output, "(8 bounces)")
+		assert.Contains(t, output, "62.5%")
+		assert.Less(t, strings.Index(output, "PolicyRelated"), strings.Index(output, "QuotaIssues"))
+		assert.Equal(t, 1, strings.Count(output, "PolicyRelated"), "one row per classification")
+	})
+
+	t.Run("plain", func(t *testing.T) {
+		var buf bytes.Buffer
+		handler := GetResponseHandler("plain", false, &buf)
+		require.NoError(t, handler.HandleBounceStats(multiDayBounceStats(), config))
+		assert.Contains(t, buf.String(), "  Total Bounces: 8\n  Bounce Classifications:\n    PolicyRelated: 5 (62.5%)\n    QuotaIssues: 2 (25.0%)\n    BadDomain: 1 (12.5%)\n")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		handler := GetResponseHandler("json", false, &buf)
+		require.NoError(t, handler.HandleBounceStats(multiDayBounceStats(), config))
+
+		var decoded struct {
+			Object string `json:"object"`
+			Total  int    `json:"total"`
+			Data   []struct {
+				Classification string  `json:"classification"`
+				Count          int     `json:"count"`
+				Percentage     float64 `json:"percentage"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		assert.Equal(t, "bounce_totals", decoded.Object)
+		assert.Equal(t, 8, decoded.Total)
+		require.Len(t, decoded.Data, 3)
+		assert.Equal(t, "PolicyRelated", decoded.Data[0].Classification)
+		assert.Equal(t, 62.5, decoded.Data[0].Percentage)
+		assert.NotContains(t, buf.String(), "explanation")
+	})
+
+	t.Run("empty range", func(t *testing.T) {
+		empty := &responses.BounceStatisticsResponse{Data: []responses.BounceStatistics{{
+			FromTimestamp: time.Now().Add(-time.Hour),
+			ToTimestamp:   time.Now(),
+		}}}
+		for _, format := range []string{"table", "plain"} {
+			var buf bytes.Buffer
+			handler := GetResponseHandler(format, false, &buf)
+			require.NoError(t, handler.HandleBounceStats(empty, config))
+			assert.Equal(t, "No bounce statistics found\n", buf.String(), format)
+
+			buf.Reset()
+			require.NoError(t, handler.HandleBounceStats(&responses.BounceStatisticsResponse{}, config))
+			assert.Equal(t, "No bounce statistics found\n", buf.String(), format)
+		}
+	})
+}
+
+func TestHandleSingleMessage_BounceExplanation(t *testing.T) {
+	classification := "QuotaIssues"
+	message := &responses.Message{
+		Sender:               "sender@example.com",
+		Recipient:            "user@example.com",
+		Status:               "Bounced",
+		BounceClassification: &classification,
+	}
+	quota, _ := bounces.Explain(classification)
+	line := quota.Description + ". " + quota.Action + "."
+
+	var table bytes.Buffer
+	require.NoError(t, GetResponseHandler("table", false, &table).HandleSingleMessage(message, SingleConfig{}))
+	assert.Contains(t, table.String(), "Bounce explanation: "+line)
+
+	var plain bytes.Buffer
+	require.NoError(t, GetResponseHandler("plain", false, &plain).HandleSingleMessage(message, SingleConfig{}))
+	assert.Contains(t, plain.String(), "Bounce Explanation: "+line)
+
+	unknown := "SomethingNew"
+	message.BounceClassification = &unknown
+	table.Reset()
+	require.NoError(t, GetResponseHandler("table", false, &table).HandleSingleMessage(message, SingleConfig{}))
+	assert.NotContains(t, table.String(), "Bounce explanation")
+}
+
+func TestHandleBounceExplanations(t *testing.T) {
+	all := bounces.All()
+
+	var table bytes.Buffer
+	require.NoError(t, GetResponseHandler("table", false, &table).HandleBounceExplanations(all, ListConfig{}))
+	for _, explanation := range all {
+		assert.Contains(t, table.String(), explanation.Classification)
+	}
+
+	var plain bytes.Buffer
+	require.NoError(t, GetResponseHandler("plain", false, &plain).HandleBounceExplanations(all[:1], ListConfig{}))
+	assert.Equal(t, "Classification: "+all[0].Classification+"\nExplanation: "+all[0].Description+"\nAction: "+all[0].Action+"\n", plain.String())
+
+	var csvOut bytes.Buffer
+	require.NoError(t, GetResponseHandler("csv", false, &csvOut).HandleBounceExplanations(all, ListConfig{}))
+	records, err := csv.NewReader(&csvOut).ReadAll()
+	require.NoError(t, err)
+	assert.Len(t, records, len(all)+1)
+	assert.Equal(t, []string{"classification", "explanation", "action"}, records[0])
+
+	var jsonOut bytes.Buffer
+	require.NoError(t, GetResponseHandler("json", false, &jsonOut).HandleBounceExplanations(all, ListConfig{}))
+	var decoded struct {
+		Object string                `json:"object"`
+		Data   []bounces.Explanation `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(jsonOut.Bytes(), &decoded))
+	assert.Equal(t, "list", decoded.Object)
+	assert.Equal(t, all, decoded.Data)
+}
@@ -0,0 +1,58 @@
+package printer
+
+import (
+	"io"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// Color modes (--color)
+const (
+	ColorAuto   = "auto"   // decorate when the output is a terminal and NO_COLOR is not set
+	ColorAlways = "always" // always decorate
+	ColorNever  = "never"  // never decorate
+)
+
+// GetSupportedColorModes returns the valid color modes
+func GetSupportedColorModes() []string {
+	return []string{ColorAuto, ColorAlways, ColorNever}
+}
+
+// Capabilities are the decorations table and plain output may use. Without
+// Emoji, status markers are written as ASCII, e.g. "✅" as "[OK]", and
+// tables get ASCII borders. JSON and CSV output is never decorated.
+type Capabilities struct {
+	Color bool // ANSI colors
+	Emoji bool // emoji and other non-ASCII status markers
+}
+
+// DetectCapabilities resolves a color mode for output written to out. In
+// auto mode the output is decorated only when out is a terminal and the
+// NO_COLOR environment variable (https://no-color.org) is unset or empty;
+// a console that cannot display UTF-8 keeps its colors but not its emoji.
+func DetectCapabilities(mode string, out io.Writer) Capabilities {
+	switch mode {
+	case ColorAlways:
+		return Capabilities{Color: true, Emoji: true}
+	case ColorNever:
+		return Capabilities{}
+	}
+	if os.Getenv("NO_COLOR") != "" || !isTerminal(out) {
+		return Capabilities{}
+	}
+	return Capabilities{Color: true, Emoji: !NeedsASCII(out)}
+}
+
+// IsTerminal reports whether stream, an input or output such as os.Stdin or
+// a command's writer, is a terminal. Streams other than files never are.
+// Every terminal check of the CLI goes through here.
+func IsTerminal(stream any) bool {
+	return isTerminal(stream)
+}
+
+// isTerminal is replaced in tests
+var isTerminal = func(stream any) bool {
+	file, ok := stream.(*os.File)
+	return ok && term.IsTerminal(int(file.Fd()))
+}
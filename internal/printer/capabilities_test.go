@@ -0,0 +1,130 @@
+package printer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/fatih/color"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withColors enables fatih/color, which is off when stdout is not a terminal
+func withColors(t *testing.T) {
+	t.Helper()
+	prev := color.NoColor
+	color.NoColor = false
+	t.Cleanup(func() { color.NoColor = prev })
+}
+
+// renderWith renders through a handler of format with caps
+func renderWith(t *testing.T, format string, caps Capabilities, render func(ResponseHandler) error) string {
+	t.Helper()
+	var buf bytes.Buffer
+	handler := GetResponseHandler(format, true, &buf)
+	handler.SetCapabilities(caps)
+	require.NoError(t, render(handler))
+	return buf.String()
+}
+
+// requireASCII fails when output has a non-ASCII character or an escape
+// sequence
+func requireASCII(t *testing.T, output string) {
+	t.Helper()
+	for _, r := range output {
+		require.True(t, r < 128 && r != '\x1b', "undecorated output has %q:\n%s", r, output)
+	}
+}
+
+func TestDetectCapabilities(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	prev := isTerminal
+	t.Cleanup(func() { isTerminal = prev })
+	isTerminal = func(any) bool { return true }
+
+	full := Capabilities{Color: true, Emoji: true}
+	assert.Equal(t, full, DetectCapabilities(ColorAuto, &bytes.Buffer{}))
+	assert.Equal(t, full, DetectCapabilities(ColorAlways, &bytes.Buffer{}))
+	assert.Equal(t, Capabilities{}, DetectCapabilities(ColorNever, &bytes.Buffer{}))
+
+	t.Setenv("NO_COLOR", "1")
+	assert.Equal(t, Capabilities{}, DetectCapabilities(ColorAuto, &bytes.Buffer{}), "auto honors NO_COLOR")
+	assert.Equal(t, full, DetectCapabilities(ColorAlways, &bytes.Buffer{}), "always overrides NO_COLOR")
+
+	t.Setenv("NO_COLOR", "")
+	isTerminal = func(any) bool { return false }
+	assert.Equal(t, Capabilities{}, DetectCapabilities(ColorAuto, &bytes.Buffer{}), "auto leaves pipes and files undecorated")
+}
+
+func TestIsTerminal_NonFileStreams(t *testing.T) {
+	assert.False(t, IsTerminal(&bytes.Buffer{}))
+	assert.False(t, IsTerminal(strings.NewReader("y\n")))
+	assert.False(t, IsTerminal(nil))
+}
+
+func TestCapabilities_DomainList(t *testing.T) {
+	withColors(t)
+	response := &responses.PaginatedDomainsResponse{Data: []responses.Domain{
+		{ID: uuid.New(), Domain: "example.com", DNSValid: true, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+		{ID: uuid.New(), Domain: "pending.example.com", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	}}
+	render := func(handler ResponseHandler) error {
+		return handler.HandleDomainList(response, ListConfig{SuccessMessage: "✅ Domains retrieved successfully"})
+	}
+
+	decorated := renderWith(t, "table", Capabilities{Color: true, Emoji: true}, render)
+	assert.Contains(t, decorated, "✅ Domains retrieved successfully")
+	assert.Contains(t, decorated, color.GreenString("Valid"))
+	assert.Contains(t, decorated, color.RedString("Invalid"))
+	assert.Contains(t, decorated, "│")
+
+	undecorated := renderWith(t, "table", Capabilities{}, render)
+	requireASCII(t, undecorated)
+	assert.Contains(t, undecorated, "[OK] Domains retrieved successfully")
+	assert.Contains(t, undecorated, "| example.com")
+	assert.Contains(t, undecorated, "Invalid")
+}
+
+func TestCapabilities_WebhookCreate(t *testing.T) {
+	webhook := &responses.Webhook{ID: uuid.New(), Name: "orders", URL: "https://example.com/hooks", Enabled: true, Secret: "whsec_abc", CreatedAt: time.Now()}
+	render := func(handler ResponseHandler) error {
+		return handler.HandleCreateWebhook(webhook, CreateConfig{SuccessMessage: "✅ Successfully created webhook: orders"})
+	}
+
+	decorated := renderWith(t, "table", Capabilities{Color: true, Emoji: true}, render)
+	assert.Contains(t, decorated, "✅ Successfully created webhook: orders")
+	assert.Contains(t, decorated, "🔐 Security Note")
+
+	undecorated := renderWith(t, "table", Capabilities{}, render)
+	requireASCII(t, undecorated)
+	assert.Contains(t, undecorated, "[OK] Successfully created webhook: orders")
+	assert.Contains(t, undecorated, "\nSecurity Note")
+	assert.Contains(t, undecorated, "whsec_abc")
+
+	decorated = renderWith(t, "plain", Capabilities{Color: true, Emoji: true}, render)
+	assert.Contains(t, decorated, "⚠️  Store this secret securely")
+
+	undecorated = renderWith(t, "plain", Capabilities{}, render)
+	requireASCII(t, undecorated)
+	assert.Contains(t, undecorated, "[!]  Store this secret securely")
+}
+
+func TestCapabilities_DataFormatsUndecorated(t *testing.T) {
+	// JSON output carries API data, which ASCII conversion would alter
+	output := renderWith(t, "json", Capabilities{}, func(handler ResponseHandler) error {
+		return handler.HandleSimpleSuccess("✅ done → next")
+	})
+	assert.Contains(t, output, "✅ done → next")
+
+	// A writer set later is converted too
+	var buf bytes.Buffer
+	handler := GetResponseHandler("plain", false, &bytes.Buffer{})
+	handler.SetCapabilities(Capabilities{})
+	handler.SetWriter(&buf)
+	require.NoError(t, handler.HandleSimpleSuccess("✅ done"))
+	assert.Equal(t, "[OK] done\n", buf.String())
+}
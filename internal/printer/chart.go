@@ -0,0 +1,116 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+// sparkTicks are the levels of a sparkline, lowest first
+var sparkTicks = []rune("▁▂▃▄▅▆▇█")
+
+// barBlock draws the bars of a bar chart
+const barBlock = "█"
+
+// defaultChartWidth is used when the output is not a terminal and $COLUMNS
+// is not set
+const defaultChartWidth = 80
+
+// minBarWidth keeps bars readable on narrow terminals; the line overflows
+// rather than shrinking them further
+const minBarWidth = 10
+
+// terminalWidth returns the width charts written to out are fitted to. Table
+// output is usually buffered for the pager, so the width of stdout is used
+// when out is not a terminal itself. Replaced in tests.
+var terminalWidth = func(out io.Writer) int {
+	for _, w := range []io.Writer{out, os.Stdout} {
+		if file, ok := w.(*os.File); ok && IsTerminal(file) {
+			if width, _, err := term.GetSize(int(file.Fd())); err == nil && width > 0 {
+				return width
+			}
+		}
+	}
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	return defaultChartWidth
+}
+
+// sparkTick returns the sparkline level of value on a scale from zero to
+// top. With a top of zero, as when every value is zero, it is the lowest.
+func sparkTick(value, top float64) string {
+	if top <= 0 || value <= 0 {
+		return string(sparkTicks[0])
+	}
+	level := int(math.Round(value / top * float64(len(sparkTicks)-1)))
+	level = min(level, len(sparkTicks)-1)
+	return string(sparkTicks[level])
+}
+
+// sparkline draws values as one sparkline level each, scaled from zero to
+// the largest value so that equal values share a level
+func sparkline(values []float64) string {
+	var b strings.Builder
+	top := maxValue(values)
+	for _, value := range values {
+		b.WriteString(sparkTick(value, top))
+	}
+	return b.String()
+}
+
+// chartBar is one line of a bar chart. Missing bars, such as a rate with
+// nothing to divide by, get their text but no bar.
+type chartBar struct {
+	Label   string
+	Value   float64
+	Text    string
+	Missing bool
+}
+
+// barChart draws one horizontal bar per line, scaled so that scaleMax fills
+// the space left by the labels and texts within width. A scaleMax of zero
+// scales to the largest value; when that is zero too, no bar is drawn.
+func barChart(bars []chartBar, scaleMax float64, width int) []string {
+	labelWidth, textWidth := 0, 0
+	values := make([]float64, 0, len(bars))
+	for _, bar := range bars {
+		labelWidth = max(labelWidth, utf8.RuneCountInString(bar.Label))
+		textWidth = max(textWidth, utf8.RuneCountInString(bar.Text))
+		if !bar.Missing {
+			values = append(values, bar.Value)
+		}
+	}
+	if scaleMax <= 0 {
+		scaleMax = maxValue(values)
+	}
+
+	// label, space, bar, space, right-aligned text
+	barWidth := max(width-labelWidth-textWidth-2, minBarWidth)
+
+	lines := make([]string, len(bars))
+	for i, bar := range bars {
+		length := 0
+		if !bar.Missing && scaleMax > 0 && bar.Value > 0 {
+			length = int(math.Round(min(bar.Value, scaleMax) / scaleMax * float64(barWidth)))
+		}
+		line := fmt.Sprintf("%-*s %s%s %*s", labelWidth, bar.Label,
+			strings.Repeat(barBlock, length), strings.Repeat(" ", barWidth-length), textWidth, bar.Text)
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return lines
+}
+
+func maxValue(values []float64) float64 {
+	result := 0.0
+	for _, value := range values {
+		result = max(result, value)
+	}
+	return result
+}
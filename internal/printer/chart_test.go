@@ -0,0 +1,115 @@
+package printer
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setTerminalWidth(t *testing.T, width int) {
+	t.Helper()
+	previous := terminalWidth
+	terminalWidth = func(io.Writer) int { return width }
+	t.Cleanup(func() { terminalWidth = previous })
+}
+
+func TestSparkline(t *testing.T) {
+	assert.Equal(t, "▁▃▅▆█", sparkline([]float64{0, 25, 50, 75, 100}))
+	assert.Equal(t, "▁▁▁", sparkline([]float64{0, 0, 0}), "all zero")
+	assert.Equal(t, "███", sparkline([]float64{7, 7, 7}), "all equal")
+	assert.Equal(t, "", sparkline(nil))
+}
+
+func TestBarChart(t *testing.T) {
+	bars := []chartBar{
+		{Label: "a", Value: 100, Text: "100%"},
+		{Label: "bb", Value: 50, Text: "50%"},
+		{Label: "c", Text: "N/A", Missing: true},
+	}
+	lines := barChart(bars, 100, 30)
+	require.Len(t, lines, 3)
+	// 30 columns less the label, the text and two spaces leave 22 for bars
+	assert.Equal(t, "a  "+strings.Repeat("█", 22)+" 100%", lines[0])
+	assert.Equal(t, "bb "+strings.Repeat("█", 11)+strings.Repeat(" ", 11)+"  50%", lines[1])
+	assert.Equal(t, "c  "+strings.Repeat(" ", 22)+"  N/A", lines[2])
+
+	t.Run("all zero", func(t *testing.T) {
+		lines := barChart([]chartBar{{Label: "a", Text: "0"}, {Label: "b", Text: "0"}}, 0, 20)
+		for _, line := range lines {
+			assert.NotContains(t, line, "█")
+		}
+	})
+
+	t.Run("equal values fill the width", func(t *testing.T) {
+		lines := barChart([]chartBar{{Label: "a", Value: 3, Text: "3"}, {Label: "b", Value: 3, Text: "3"}}, 0, 20)
+		assert.Equal(t, lines[0][1:], lines[1][1:])
+		assert.Equal(t, 20, utf8.RuneCountInString(lines[0]))
+	})
+
+	t.Run("narrow terminal keeps a minimum bar", func(t *testing.T) {
+		lines := barChart([]chartBar{{Label: "a long label", Value: 1, Text: "1"}}, 1, 5)
+		assert.Equal(t, minBarWidth, strings.Count(lines[0], "█"))
+	})
+}
+
+func deliverabilityChartResponse() *responses.DeliverabilityStatisticsResponse {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	return &responses.DeliverabilityStatisticsResponse{
+		Object: "list",
+		Data: []responses.DeliverabilityStatistics{
+			{FromTimestamp: from, ToTimestamp: from.Add(day), ReceptionCount: 100, DeliveredCount: 100},
+			{FromTimestamp: from.Add(day), ToTimestamp: from.Add(2 * day), ReceptionCount: 100, DeliveredCount: 50},
+			{FromTimestamp: from.Add(2 * day), ToTimestamp: from.Add(3 * day)},
+		},
+	}
+}
+
+func TestHandleDeliverabilityStats_Chart(t *testing.T) {
+	setTerminalWidth(t, 60)
+	config := StatsConfig{Title: "Deliverability Statistics", ShowChart: true}
+
+	t.Run("table", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, GetResponseHandler("table", false, &buf).HandleDeliverabilityStats(deliverabilityChartResponse(), config))
+		out := buf.String()
+		assert.Contains(t, out, "TREND")
+		assert.Contains(t, out, "Delivered: █▅▁ (max 100)")
+		assert.Contains(t, out, "Delivery Rate\n")
+		for _, line := range strings.Split(out[strings.Index(out, "Delivery Rate\n"):], "\n") {
+			assert.LessOrEqual(t, utf8.RuneCountInString(line), 60, line)
+		}
+		assert.Regexp(t, `█+ 100\.00%`, out)
+		assert.Regexp(t, `\s+N/A\n$`, out)
+	})
+
+	t.Run("plain", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, GetResponseHandler("plain", false, &buf).HandleDeliverabilityStats(deliverabilityChartResponse(), config))
+		assert.Contains(t, buf.String(), "  Delivered Trend: ▅\n")
+		assert.Contains(t, buf.String(), "Delivered: █▅▁ (max 100)")
+	})
+
+	for _, format := range []string{"csv", "json"} {
+		t.Run(format+" ignores the chart", func(t *testing.T) {
+			var with, without bytes.Buffer
+			require.NoError(t, GetResponseHandler(format, false, &with).HandleDeliverabilityStats(deliverabilityChartResponse(), config))
+			require.NoError(t, GetResponseHandler(format, false, &without).HandleDeliverabilityStats(deliverabilityChartResponse(), StatsConfig{Title: config.Title}))
+			assert.Equal(t, without.String(), with.String())
+		})
+	}
+
+	t.Run("no chart without --chart", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, GetResponseHandler("table", false, &buf).HandleDeliverabilityStats(deliverabilityChartResponse(), StatsConfig{}))
+		assert.NotContains(t, buf.String(), "TREND")
+		assert.NotContains(t, buf.String(), "Delivery Rate\n")
+	})
+}
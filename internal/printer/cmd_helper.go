@@ -1,6 +1,8 @@
 package printer
 
 import (
+	"strings"
+
 	"github.com/spf13/cobra"
 )
 
@@ -10,20 +12,65 @@ type responseHandlerKeyType string
 const ResponseHandlerKey = responseHandlerKeyType("responseHandler")
 
 // GetResponseHandlerFromCommand retrieves the response handler instance from the command context
-// This is the main function commands should use to get their response handler
+// This is the main function commands should use to get their response handler.
+// List commands run with --ids-only get a handler that prints only IDs.
 func GetResponseHandlerFromCommand(cmd *cobra.Command) ResponseHandler {
+	handler := responseHandlerFromContext(cmd)
+	if IDsOnly(cmd) {
+		return NewIDsOnlyHandler(handler, cmd.OutOrStdout())
+	}
+	return handler
+}
+
+// responseHandlerFromContext returns the handler stored by the root command
+func responseHandlerFromContext(cmd *cobra.Command) ResponseHandler {
 	// Context key for the response handler instance - must match root.go
 	// Using the same type as defined in root.go
-
 	if h := cmd.Context().Value(ResponseHandlerKey); h != nil {
 		if handlerInstance, ok := h.(ResponseHandler); ok {
 			return handlerInstance
 		}
 	}
 
-	// Fallback to table handler if context is not available
+	// Fallback to the --output flag if context is not available
 	// This shouldn't happen in normal execution since root command sets it up
-	return GetResponseHandler("table", true, cmd.OutOrStdout())
+	format, _ := cmd.Flags().GetString("output")
+	if format == "" {
+		format = DefaultFormat
+	}
+	return GetResponseHandler(format, true, cmd.OutOrStdout())
+}
+
+// DefaultFormat is the output format used when neither --output nor the
+// configuration chooses one
+const DefaultFormat = "table"
+
+// CommandKey returns the key of cmd in the output_overrides configuration:
+// its path without the root command, with dots between words, e.g.
+// "messages.list" or "subaccounts.apikeys.create"
+func CommandKey(cmd *cobra.Command) string {
+	var words []string
+	for c := cmd; c.HasParent(); c = c.Parent() {
+		words = append([]string{c.Name()}, words...)
+	}
+	return strings.Join(words, ".")
+}
+
+// ResolveFormat returns the output format of cmd. An explicit --output flag
+// wins, then the command's entry in overrides, then configured (the
+// output_format preference), then DefaultFormat. Every command gets its
+// format from here, so no command default can override --output.
+func ResolveFormat(cmd *cobra.Command, overrides map[string]string, configured string) string {
+	if flag := cmd.Flags().Lookup("output"); flag != nil && flag.Changed {
+		return flag.Value.String()
+	}
+	if format := overrides[CommandKey(cmd)]; format != "" {
+		return format
+	}
+	if configured != "" {
+		return configured
+	}
+	return DefaultFormat
 }
 
 // ValidateOutputFormat validates the --output flag value
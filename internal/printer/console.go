@@ -0,0 +1,136 @@
+package printer
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/tw"
+)
+
+// asciiMarkers maps the status markers and symbols the CLI prints to ASCII
+// for consoles that cannot display UTF-8. Longer sequences come first so a
+// marker with its variation selector is replaced as a whole.
+var asciiMarkers = []string{
+	"⚠️", "[!]",
+	"⚠", "[!]",
+	"✅", "[OK]",
+	"✓", "[OK]",
+	"❌", "[X]",
+	"✗", "[X]",
+	"•", "*",
+	"·", "-",
+	"─", "-",
+	"—", "-",
+	"…", "...",
+	"←→", "<->",
+	"→", "->",
+	"←", "<-",
+	"↑", "^",
+	"↓", "v",
+	"▲", "^",
+	"▼", "v",
+	"▶", ">",
+	"█", "#",
+	"░", ".",
+}
+
+// decorativeEmoji only decorate the text after them and are dropped,
+// together with the spacing that follows them
+var decorativeEmoji = []string{
+	"🔑", "📧", "📋", "🚨", "🛑", "🔧", "🔄", "📨", "📊", "💡", "💔", "⏱️", "⏱", "🔐", "🔌", "📅",
+}
+
+var asciiReplacer = newASCIIReplacer()
+
+// asciiOutput is set by SetupConsole when stdout is a console that cannot
+// display UTF-8; tables then use ASCII borders and cell text
+var asciiOutput bool
+
+func newASCIIReplacer() *strings.Replacer {
+	var pairs []string
+	for _, emoji := range decorativeEmoji {
+		pairs = append(pairs, emoji+"  ", "", emoji+" ", "", emoji, "")
+	}
+	pairs = append(pairs, asciiMarkers...)
+	// Any variation selector left over would show up as a stray character
+	pairs = append(pairs, "\uFE0F", "")
+	return strings.NewReplacer(pairs...)
+}
+
+// ASCII replaces the CLI's status markers and symbols in text with ASCII
+// equivalents. Other characters, such as names in API data, are kept.
+func ASCII(text string) string {
+	return asciiReplacer.Replace(text)
+}
+
+// asciiWriter writes everything through ASCII
+type asciiWriter struct {
+	out io.Writer
+}
+
+func (w *asciiWriter) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(w.out, ASCII(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// NewASCIIWriter returns a writer that replaces status markers with ASCII
+// before writing to w
+func NewASCIIWriter(w io.Writer) io.Writer {
+	return &asciiWriter{out: w}
+}
+
+// NeedsASCII reports whether w is a console that cannot display UTF-8, such
+// as a legacy Windows console. Other writers, including files and pipes,
+// get UTF-8 unchanged.
+func NeedsASCII(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	return ok && needsASCII(file)
+}
+
+// SetupConsole prepares the process's console for UTF-8 and color output
+// where the platform needs it, e.g. by switching a Windows console to the
+// UTF-8 code page and enabling virtual terminal processing. When stdout
+// still cannot display UTF-8, tables fall back to ASCII. The returned
+// function restores the previous console settings.
+func SetupConsole() (restore func()) {
+	restore = setupConsole()
+	asciiOutput = needsASCII(os.Stdout)
+	return restore
+}
+
+// SetASCIIOutputForTesting makes tables render as on a console without
+// UTF-8 support. The returned function restores the previous setting.
+func SetASCIIOutputForTesting(ascii bool) func() {
+	previous := asciiOutput
+	asciiOutput = ascii
+	return func() { asciiOutput = previous }
+}
+
+// newTable creates a table writer for w. On consoles without UTF-8 support,
+// or when ascii is set, it uses ASCII borders and converts cell text before
+// column widths are measured, so markers like ✓ keep the columns aligned.
+func newTable(w io.Writer, ascii bool) *tablewriter.Table {
+	table := tablewriter.NewWriter(w)
+	if ascii || asciiOutput {
+		filter := tw.CellFilter{Global: asciiCells}
+		table.Options(
+			tablewriter.WithSymbols(tw.NewSymbols(tw.StyleASCII)),
+			tablewriter.WithHeaderFilter(filter),
+			tablewriter.WithRowFilter(filter),
+			tablewriter.WithFooterFilter(filter),
+		)
+	}
+	return table
+}
+
+func asciiCells(cells []string) []string {
+	converted := make([]string, len(cells))
+	for i, cell := range cells {
+		converted[i] = ASCII(cell)
+	}
+	return converted
+}
@@ -0,0 +1,16 @@
+//go:build !windows
+
+package printer
+
+import "os"
+
+// Terminals on other platforms decode UTF-8 and interpret escape sequences
+// without any setup
+
+func setupConsole() func() {
+	return func() {}
+}
+
+func needsASCII(file *os.File) bool {
+	return false
+}
@@ -0,0 +1,78 @@
+package printer
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestASCII(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"✅ Successfully sent all 3 messages", "[OK] Successfully sent all 3 messages"},
+		{"⚠️  Sent 2 of 3 recipients", "[!]  Sent 2 of 3 recipients"},
+		{"✓ Valid · ✗ Invalid", "[OK] Valid - [X] Invalid"},
+		{"🔑 API key created", "API key created"},
+		{"⏱️  Timed out after 30s…", "Timed out after 30s..."},
+		{"tab/←→ switch pane", "tab/<-> switch pane"},
+		{"Müller", "Müller"}, // data is kept
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, ASCII(tt.in), tt.in)
+	}
+}
+
+func TestNewASCIIWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewASCIIWriter(&buf)
+
+	n, err := w.Write([]byte("✓ done\n"))
+	require.NoError(t, err)
+	assert.Equal(t, len("✓ done\n"), n, "reports the bytes it was given")
+	assert.Equal(t, "[OK] done\n", buf.String())
+}
+
+func TestNeedsASCII_NonConsoleWriters(t *testing.T) {
+	assert.False(t, NeedsASCII(&bytes.Buffer{}))
+
+	file, err := os.Create(filepath.Join(t.TempDir(), "out.txt"))
+	require.NoError(t, err)
+	defer file.Close()
+	assert.False(t, NeedsASCII(file), "files always get UTF-8")
+}
+
+func TestNewTable_ASCIIOutput(t *testing.T) {
+	render := func() string {
+		var buf bytes.Buffer
+		table := newTable(&buf, false)
+		table.Header("Name", "Status")
+		addTableRow(table, []string{"alpha", "✓ Valid"})
+		addTableRow(table, []string{"beta", "⚠️ Pending"})
+		renderTable(table)
+		return buf.String()
+	}
+
+	unicode := render()
+	assert.Contains(t, unicode, "│")
+	assert.Contains(t, unicode, "✓ Valid")
+
+	defer SetASCIIOutputForTesting(true)()
+	ascii := render()
+	for _, r := range ascii {
+		require.Less(t, r, rune(128), "non-ASCII output:\n%s", ascii)
+	}
+	assert.Contains(t, ascii, "[OK] Valid")
+	assert.Contains(t, ascii, "[!] Pending")
+
+	// Cells are converted before the columns are sized, so rows line up
+	lines := strings.Split(strings.TrimSpace(ascii), "\n")
+	for _, line := range lines[1:] {
+		assert.Equal(t, len(lines[0]), len(line), "misaligned table:\n%s", ascii)
+	}
+}
@@ -0,0 +1,66 @@
+//go:build windows
+
+package printer
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// utf8CodePage is the Windows code page for UTF-8
+const utf8CodePage = 65001
+
+// setupConsole enables virtual terminal processing on stdout and stderr, so
+// colors and cursor sequences are interpreted instead of printed, and
+// switches the console output code page to UTF-8. Either may fail on
+// consoles older than Windows 10; output then falls back to ASCII.
+func setupConsole() func() {
+	var restores []func()
+	for _, file := range []*os.File{os.Stdout, os.Stderr} {
+		handle := windows.Handle(file.Fd())
+		var mode uint32
+		if windows.GetConsoleMode(handle, &mode) != nil {
+			continue // redirected to a file or pipe
+		}
+		if mode&windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING == 0 &&
+			windows.SetConsoleMode(handle, mode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING) == nil {
+			restores = append(restores, func() { windows.SetConsoleMode(handle, mode) })
+		}
+	}
+
+	// The code page belongs to the console, not the process, so cmd.exe
+	// keeps it after we exit unless it is restored
+	if codePage, err := windows.GetConsoleOutputCP(); err == nil && codePage != utf8CodePage &&
+		windows.SetConsoleOutputCP(utf8CodePage) == nil {
+		restores = append(restores, func() { windows.SetConsoleOutputCP(codePage) })
+	}
+
+	return func() {
+		for i := len(restores) - 1; i >= 0; i-- {
+			restores[i]()
+		}
+	}
+}
+
+// needsASCII reports whether file is a console that cannot display UTF-8.
+// Files and pipes get UTF-8 unchanged.
+func needsASCII(file *os.File) bool {
+	var mode uint32
+	if windows.GetConsoleMode(windows.Handle(file.Fd()), &mode) != nil {
+		return false
+	}
+	codePage, err := windows.GetConsoleOutputCP()
+	if err != nil {
+		return true
+	}
+	return asciiFallback(mode, codePage)
+}
+
+// asciiFallback decides from a console's mode and output code page whether
+// it needs ASCII. Consoles without virtual terminal processing are the
+// legacy console host, whose raster fonts lack the markers and box drawing
+// characters even with the UTF-8 code page.
+func asciiFallback(mode, codePage uint32) bool {
+	return codePage != utf8CodePage || mode&windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING == 0
+}
@@ -0,0 +1,30 @@
+//go:build windows
+
+package printer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sys/windows"
+)
+
+func TestASCIIFallback(t *testing.T) {
+	const vt = windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING
+	tests := []struct {
+		name     string
+		mode     uint32
+		codePage uint32
+		want     bool
+	}{
+		{"modern console with UTF-8", vt | windows.ENABLE_PROCESSED_OUTPUT, utf8CodePage, false},
+		{"legacy code page", vt, 437, true},
+		{"western code page", vt, 1252, true},
+		{"legacy console host", windows.ENABLE_PROCESSED_OUTPUT, utf8CodePage, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, asciiFallback(tt.mode, tt.codePage))
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package printer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/AhaSend/ahasend-go/models/common"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createMessageResponse(queued int, rejected ...string) *responses.CreateMessageResponse {
+	response := &responses.CreateMessageResponse{Object: "list"}
+	for i := 0; i < queued; i++ {
+		id := fmt.Sprintf("msg-%d", i)
+		response.Data = append(response.Data, responses.CreateSingleMessageResponse{
+			ID:        &id,
+			Recipient: common.Recipient{Email: fmt.Sprintf("ok%d@example.com", i)},
+			Status:    "queued",
+		})
+	}
+	for _, email := range rejected {
+		reason := "recipient is suppressed"
+		response.Data = append(response.Data, responses.CreateSingleMessageResponse{
+			Recipient: common.Recipient{Email: email},
+			Status:    "failed",
+			Error:     &reason,
+		})
+	}
+	return response
+}
+
+func rejectedEmails(n int) []string {
+	emails := make([]string, n)
+	for i := range emails {
+		emails[i] = fmt.Sprintf("bad%d@example.com", i)
+	}
+	return emails
+}
+
+func TestSummarizeCreateMessage(t *testing.T) {
+	summary := SummarizeCreateMessage(createMessageResponse(2, "bad@example.com"))
+	assert.Equal(t, 2, summary.Succeeded)
+	assert.Equal(t, 1, summary.Failed)
+	assert.Equal(t, 3, summary.Total())
+	require.Len(t, summary.Rejected, 1)
+	assert.Equal(t, "bad@example.com", summary.Rejected[0].Recipient.Email)
+
+	// An error message marks the recipient as failed whatever the status
+	reason := "invalid address"
+	summary = SummarizeCreateMessage(&responses.CreateMessageResponse{
+		Data: []responses.CreateSingleMessageResponse{{Status: "queued", Error: &reason}},
+	})
+	assert.Equal(t, 1, summary.Failed)
+
+	assert.Equal(t, CreateMessageSummary{}, SummarizeCreateMessage(nil))
+}
+
+func TestHandleCreateMessage_RecipientOutcomes(t *testing.T) {
+	config := CreateConfig{SuccessMessage: "Message sent successfully", ItemName: "message"}
+
+	tests := []struct {
+		name     string
+		response *responses.CreateMessageResponse
+		table    []string
+		plain    []string
+		absent   []string
+	}{
+		{
+			name:     "all succeeded",
+			response: createMessageResponse(2),
+			table:    []string{"Message sent successfully", "Successfully sent 2 messages"},
+			plain:    []string{"Message sent successfully", "Successfully sent 2 messages"},
+			absent:   []string{"Failed recipients"},
+		},
+		{
+			name:     "mixed",
+			response: createMessageResponse(2, "bad@example.com"),
+			table:    []string{"Partially sent: 2 succeeded, 1 failed", "Sent 2 of 3 messages", "Failed recipients (1):", "bad@example.com: recipient is suppressed"},
+			plain:    []string{"Partially sent: 2 succeeded, 1 failed", "Sent 2 of 3 messages", "Failed recipients (1):", "bad@example.com: recipient is suppressed"},
+			absent:   []string{"Message sent successfully", "Successfully sent"},
+		},
+		{
+			name:     "all failed",
+			response: createMessageResponse(0, "bad1@example.com", "bad2@example.com"),
+			table:    []string{"No messages were sent: all 2 recipients failed", "Sent 0 of 2 messages", "Failed recipients (2):"},
+			plain:    []string{"No messages were sent: all 2 recipients failed", "Failed recipients (2):", "bad2@example.com: recipient is suppressed"},
+			absent:   []string{"Message sent successfully", "Successfully sent"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for format, expected := range map[string][]string{"table": tt.table, "plain": tt.plain} {
+				var buf bytes.Buffer
+				require.NoError(t, GetResponseHandler(format, false, &buf).HandleCreateMessage(tt.response, config))
+				for _, text := range expected {
+					assert.Contains(t, buf.String(), text, format)
+				}
+				for _, text := range tt.absent {
+					assert.NotContains(t, buf.String(), text, format)
+				}
+			}
+
+			var jsonBuf bytes.Buffer
+			require.NoError(t, GetResponseHandler("json", false, &jsonBuf).HandleCreateMessage(tt.response, config))
+			var result struct {
+				Data    []map[string]interface{} `json:"data"`
+				Summary map[string]interface{}   `json:"summary"`
+			}
+			require.NoError(t, json.Unmarshal(jsonBuf.Bytes(), &result))
+			summary := SummarizeCreateMessage(tt.response)
+			assert.Len(t, result.Data, summary.Total())
+			assert.Equal(t, map[string]interface{}{
+				"succeeded": float64(summary.Succeeded),
+				"failed":    float64(summary.Failed),
+			}, result.Summary)
+
+			var csvBuf bytes.Buffer
+			require.NoError(t, GetResponseHandler("csv", false, &csvBuf).HandleCreateMessage(tt.response, config))
+			records, err := csv.NewReader(&csvBuf).ReadAll()
+			require.NoError(t, err)
+			require.Len(t, records, summary.Total()+1)
+			assert.Equal(t, []string{"message_id", "recipient", "status", "error", "failed"}, records[0])
+			failed := 0
+			for _, record := range records[1:] {
+				if record[4] == "true" {
+					failed++
+				}
+			}
+			assert.Equal(t, summary.Failed, failed)
+		})
+	}
+}
+
+func TestHandleCreateMessage_TableCapsFailedRecipients(t *testing.T) {
+	var buf bytes.Buffer
+	response := createMessageResponse(1, rejectedEmails(maxFailedRecipientsShown+3)...)
+	require.NoError(t, GetResponseHandler("table", false, &buf).HandleCreateMessage(response, CreateConfig{}))
+
+	output := buf.String()
+	assert.Contains(t, output, fmt.Sprintf("Failed recipients (%d):", maxFailedRecipientsShown+3))
+	assert.Contains(t, output, "... and 3 more; see --output json for full details")
+}
@@ -2,9 +2,16 @@ package printer
 
 import (
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/AhaSend/ahasend-cli/internal/bounces"
+	"github.com/AhaSend/ahasend-cli/internal/dns"
+	"github.com/AhaSend/ahasend-cli/internal/state"
 	"github.com/AhaSend/ahasend-go/models/responses"
 )
 
@@ -126,6 +133,104 @@ func (h *csvHandler) HandleSingleDomain(domain *responses.Domain, config SingleC
 	return nil
 }
 
+func (h *csvHandler) HandleDNSPropagation(matrix *dns.PropagationMatrix, config SingleConfig) error {
+	if matrix == nil || len(matrix.Records) == 0 {
+		return nil // No CSV output for empty data
+	}
+
+	writer := h.createCSVWriter()
+	defer flushCSVWriter(writer)
+
+	headers := append([]string{"type", "host", "content", "required"}, matrix.Resolvers...)
+	if err := writeCSVHeaders(writer, headers); err != nil {
+		return err
+	}
+
+	for _, record := range matrix.Records {
+		row := []string{record.Type, record.Host, record.Content, fmt.Sprintf("%t", record.Required)}
+		for _, status := range record.Statuses {
+			row = append(row, string(status))
+		}
+		if err := writeCSVRow(writer, row); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (h *csvHandler) HandleDNSVerification(domain *responses.Domain, verification *dns.Verification, config SingleConfig) error {
+	if domain == nil || verification == nil {
+		return nil // No CSV output for empty data
+	}
+
+	writer := h.createCSVWriter()
+	defer flushCSVWriter(writer)
+
+	headers := []string{"domain", "state", "type", "host", "required", "reason", "expected", "observed", "observed_source", "detail"}
+	if err := writeCSVHeaders(writer, headers); err != nil {
+		return err
+	}
+
+	// A verified domain has no failed records; one row still reports its state
+	if len(verification.Failures) == 0 {
+		return writeCSVRow(writer, []string{verification.Domain, string(verification.State), "", "", "", "", "", "", "", ""})
+	}
+
+	for _, failure := range verification.Failures {
+		row := []string{
+			verification.Domain,
+			string(verification.State),
+			failure.Type,
+			failure.Host,
+			fmt.Sprintf("%t", failure.Required),
+			string(failure.Reason),
+			failure.Expected,
+			strings.Join(failure.Observed, ";"),
+			failure.ObservedSource,
+			failure.Detail,
+		}
+		if err := writeCSVRow(writer, row); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (h *csvHandler) HandleDomainVerification(verification *dns.LocalVerification, config SingleConfig) error {
+	if verification == nil || len(verification.Records) == 0 {
+		return nil // No CSV output for empty data
+	}
+
+	writer := h.createCSVWriter()
+	defer flushCSVWriter(writer)
+
+	headers := []string{"domain", "resolver", "type", "host", "required", "status", "expected", "observed", "error"}
+	if err := writeCSVHeaders(writer, headers); err != nil {
+		return err
+	}
+
+	for _, record := range verification.Records {
+		row := []string{
+			verification.Domain,
+			verification.Resolver,
+			record.Type,
+			record.Host,
+			fmt.Sprintf("%t", record.Required),
+			string(record.Status),
+			record.Expected,
+			strings.Join(record.Observed, ";"),
+			record.Error,
+		}
+		if err := writeCSVRow(writer, row); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Message responses
 func (h *csvHandler) HandleMessageList(response *responses.PaginatedMessagesResponse, config ListConfig) error {
 	if len(response.Data) == 0 {
@@ -154,6 +259,7 @@ func (h *csvHandler) HandleMessageList(response *responses.PaginatedMessagesResp
 		"tags":         formatStringSlice(firstMessage.Tags),
 		"bounce_class": formatOptionalString(firstMessage.BounceClassification),
 		"retain_until": formatTime(firstMessage.RetainUntil),
+		"received":     formatTime(firstMessage.CreatedAt),
 	}
 
 	// Get headers respecting field order
@@ -165,9 +271,11 @@ func (h *csvHandler) HandleMessageList(response *responses.PaginatedMessagesResp
 		headers = []string{"id", "sender", "recipient", "subject", "status", "created", "delivered", "opens", "clicks"}
 	}
 
-	// Write headers
-	if err := writeCSVHeaders(writer, headers); err != nil {
-		return err
+	// Write headers, unless an earlier page of the stream wrote them
+	if !config.Continuation {
+		if err := writeCSVHeaders(writer, headers); err != nil {
+			return err
+		}
 	}
 
 	// Write data rows
@@ -189,6 +297,7 @@ func (h *csvHandler) HandleMessageList(response *responses.PaginatedMessagesResp
 			"tags":         formatStringSlice(message.Tags),
 			"bounce_class": formatOptionalString(message.BounceClassification),
 			"retain_until": formatTime(message.RetainUntil),
+			"received":     formatTime(message.CreatedAt),
 		}
 
 		row := convertToCSVRow(messageFieldMap, headers)
@@ -228,6 +337,8 @@ func (h *csvHandler) HandleSingleMessage(message *responses.Message, config Sing
 		"domain_id":    formatUUID(message.DomainID),
 		"tags":         formatStringSlice(message.Tags),
 		"retain_until": formatTime(message.RetainUntil),
+		"received":     formatTime(message.CreatedAt),
+		"attachments":  formatAttachmentCount(*message),
 	}
 
 	// Add content fields if content exists
@@ -271,7 +382,7 @@ func (h *csvHandler) HandleCreateMessage(response *responses.CreateMessageRespon
 	defer flushCSVWriter(writer)
 
 	// Write headers
-	headers := []string{"message_id", "recipient", "status", "error"}
+	headers := []string{"message_id", "recipient", "status", "error", "failed"}
 	if err := writeCSVHeaders(writer, headers); err != nil {
 		return err
 	}
@@ -288,6 +399,7 @@ func (h *csvHandler) HandleCreateMessage(response *responses.CreateMessageRespon
 			messageData.Recipient.Email,
 			messageData.Status,
 			errorMsg,
+			fmt.Sprintf("%t", recipientFailed(messageData)),
 		}
 
 		if err := writeCSVRow(writer, row); err != nil {
@@ -326,6 +438,103 @@ func (h *csvHandler) HandleCancelMessage(response *CancelMessageResponse, config
 	return nil
 }
 
+func (h *csvHandler) HandleMessageExport(summary *MessageExportSummary, config SimpleConfig) error {
+	if summary == nil {
+		return nil // No CSV output for empty data
+	}
+
+	writer := h.createCSVWriter()
+	defer flushCSVWriter(writer)
+
+	fieldOrder := []string{"output_dir", "exported", "skipped", "total_bytes"}
+	writeCSVHeaders(writer, fieldOrder)
+	writeCSVRow(writer, convertToCSVRow(map[string]string{
+		"output_dir":  summary.OutputDir,
+		"exported":    fmt.Sprintf("%d", summary.Exported),
+		"skipped":     fmt.Sprintf("%d", summary.Skipped),
+		"total_bytes": fmt.Sprintf("%d", summary.TotalBytes),
+	}, fieldOrder))
+
+	return nil
+}
+
+func (h *csvHandler) HandleDryRunMessage(dryRun *DryRunMessage, config SimpleConfig) error {
+	if dryRun == nil {
+		return nil // No CSV output for empty data
+	}
+
+	writer := h.createCSVWriter()
+	defer flushCSVWriter(writer)
+
+	if err := writeCSVHeaders(writer, []string{"batch", "recipients", "send_at", "subject", "idempotency_key"}); err != nil {
+		return err
+	}
+	for i, batch := range dryRun.Batches {
+		sendAt := ""
+		if batch.Request != nil && batch.Request.Schedule != nil && batch.Request.Schedule.FirstAttempt != nil {
+			sendAt = batch.Request.Schedule.FirstAttempt.UTC().Format(time.RFC3339)
+		}
+		if err := writeCSVRow(writer, []string{fmt.Sprintf("%d", i+1), fmt.Sprintf("%d", batch.Recipients), sendAt, formatDryRunBatchSubject(batch), batch.IdempotencyKey}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (h *csvHandler) HandleMessageAttempts(report *MessageAttemptsReport, config SingleConfig) error {
+	if report == nil || len(report.Attempts) == 0 {
+		return nil // No CSV output for empty data
+	}
+
+	writer := h.createCSVWriter()
+	defer flushCSVWriter(writer)
+
+	fieldOrder := []string{"attempt", "time", "remote_host", "status", "smtp_code", "interval_seconds", "smtp_response"}
+	writeCSVHeaders(writer, fieldOrder)
+
+	for _, attempt := range report.Attempts {
+		fieldMap := map[string]string{
+			"attempt":          formatInt(attempt.Attempt),
+			"time":             formatTime(attempt.Time),
+			"remote_host":      attempt.RemoteHost,
+			"status":           attempt.Status,
+			"smtp_code":        formatSMTPCode(attempt.SMTPCode),
+			"interval_seconds": fmt.Sprintf("%.0f", attempt.SincePrevious.Seconds()),
+			"smtp_response":    attempt.SMTPResponse,
+		}
+		writeCSVRow(writer, convertToCSVRow(fieldMap, fieldOrder))
+	}
+
+	return nil
+}
+
+// HandleMessageContent lists the parts and attachments with their sizes,
+// one per row
+func (h *csvHandler) HandleMessageContent(content *MessageContent, config SingleConfig) error {
+	if content == nil {
+		return nil // No CSV output for empty data
+	}
+
+	writer := h.createCSVWriter()
+	defer flushCSVWriter(writer)
+
+	if err := writeCSVHeaders(writer, []string{"kind", "name", "content_type", "content_id", "size"}); err != nil {
+		return err
+	}
+	for _, part := range content.Parts {
+		if err := writeCSVRow(writer, []string{"part", part.Part, part.ContentType, "", formatInt(part.Size)}); err != nil {
+			return err
+		}
+	}
+	for _, attachment := range content.Attachments {
+		if err := writeCSVRow(writer, []string{"attachment", attachment.FileName, attachment.ContentType, attachment.ContentID, formatInt(attachment.Size)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Webhook responses
 func (h *csvHandler) HandleWebhookList(response *responses.PaginatedWebhooksResponse, config ListConfig) error {
 	if len(response.Data) == 0 {
@@ -349,6 +558,11 @@ func (h *csvHandler) HandleWebhookList(response *responses.PaginatedWebhooksResp
 		"created_at": formatTime(firstWebhook.CreatedAt),
 		"updated_at": formatTime(firstWebhook.UpdatedAt),
 	}
+	if config.IncludeStats {
+		for key, value := range webhookStatsFieldMap(firstWebhook) {
+			fieldMap[key] = value
+		}
+	}
 
 	// Get headers respecting field order
 	var headers []string
@@ -359,6 +573,12 @@ func (h *csvHandler) HandleWebhookList(response *responses.PaginatedWebhooksResp
 		headers = []string{"name", "id", "url", "enabled", "events", "created_at", "updated_at"}
 	}
 
+	// Stats columns are only added on request so existing consumers keep
+	// the same shape
+	if config.IncludeStats {
+		headers = withWebhookStatsFields(headers)
+	}
+
 	// Write headers
 	if err := writeCSVHeaders(writer, headers); err != nil {
 		return err
@@ -378,6 +598,11 @@ func (h *csvHandler) HandleWebhookList(response *responses.PaginatedWebhooksResp
 			"created_at": formatTime(webhook.CreatedAt),
 			"updated_at": formatTime(webhook.UpdatedAt),
 		}
+		if config.IncludeStats {
+			for key, value := range webhookStatsFieldMap(webhook) {
+				webhookFieldMap[key] = value
+			}
+		}
 
 		row := convertToCSVRow(webhookFieldMap, headers)
 		if err := writeCSVRow(writer, row); err != nil {
@@ -385,6 +610,14 @@ func (h *csvHandler) HandleWebhookList(response *responses.PaginatedWebhooksResp
 		}
 	}
 
+	// Aggregate totals row
+	if config.IncludeStats {
+		row := convertToCSVRow(webhookSummaryFieldMap(summarizeWebhookStats(response.Data)), headers)
+		if err := writeCSVRow(writer, row); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -522,6 +755,64 @@ func (h *csvHandler) HandleUpdateWebhook(webhook *responses.Webhook, config Upda
 	return nil
 }
 
+func (h *csvHandler) HandleRotateWebhookSecret(webhook *responses.Webhook, config UpdateConfig) error {
+	if webhook == nil {
+		return nil // No CSV output for empty data
+	}
+
+	writer := h.createCSVWriter()
+	defer flushCSVWriter(writer)
+
+	// Create field map - the new secret is shown this once
+	fieldMap := map[string]string{
+		"id":     formatUUID(webhook.ID),
+		"name":   webhook.Name,
+		"url":    webhook.URL,
+		"secret": formatWebhookSecretCreation(webhook.Secret),
+	}
+
+	// Get headers respecting field order
+	var headers []string
+	if len(config.FieldOrder) > 0 {
+		headers = getCSVHeaders(fieldMap, config.FieldOrder)
+	} else {
+		headers = []string{"id", "name", "url", "secret"}
+	}
+
+	// Write headers
+	if err := writeCSVHeaders(writer, headers); err != nil {
+		return err
+	}
+
+	// Write data row
+	row := convertToCSVRow(fieldMap, headers)
+	if err := writeCSVRow(writer, row); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (h *csvHandler) HandleWebhookEdit(edit *WebhookEdit, config UpdateConfig) error {
+	if edit == nil {
+		return nil // No CSV output for empty data
+	}
+
+	writer := h.createCSVWriter()
+	defer flushCSVWriter(writer)
+
+	if err := writeCSVHeaders(writer, []string{"webhook_id", "field", "before", "after"}); err != nil {
+		return err
+	}
+	for _, change := range edit.Changes {
+		if err := writeCSVRow(writer, []string{edit.WebhookID, change.Path, formatOverrideValue(change.Before), formatOverrideValue(change.After)}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (h *csvHandler) HandleDeleteWebhook(success bool, config DeleteConfig) error {
 	// CSV format doesn't output data for delete operations
 	// Success/failure is handled via exit codes and error messages
@@ -534,6 +825,126 @@ func (h *csvHandler) HandleTriggerWebhook(webhookID string, events []string, con
 	return nil
 }
 
+func (h *csvHandler) HandleTriggerWebhookOverrides(trigger *WebhookTrigger, config TriggerConfig) error {
+	if trigger == nil {
+		return nil // No CSV output for empty data
+	}
+
+	writer := h.createCSVWriter()
+	defer flushCSVWriter(writer)
+
+	writeCSVHeaders(writer, []string{"msg_id", "type", "status", "failure", "status_code", "duration_ms", "error", "overrides", "payload"})
+	for _, event := range trigger.Events {
+		overrides := make([]string, len(event.Overrides))
+		for i, change := range event.Overrides {
+			overrides[i] = formatOverride(change)
+		}
+		payload, _ := json.Marshal(event.Payload)
+		writeCSVRow(writer, []string{
+			event.MsgID,
+			event.Type,
+			event.Status,
+			string(event.Failure),
+			formatSimulationStatusCode(event.StatusCode),
+			strconv.FormatInt(event.DurationMs, 10),
+			event.Error,
+			strings.Join(overrides, "; "),
+			string(payload),
+		})
+	}
+
+	return nil
+}
+
+func (h *csvHandler) HandleWebhookCoverage(report *WebhookCoverageReport, config SingleConfig) error {
+	if report == nil {
+		return nil // No CSV output for empty data
+	}
+
+	writer := h.createCSVWriter()
+	defer flushCSVWriter(writer)
+
+	fieldOrder := []string{"event"}
+	for _, webhook := range report.Webhooks {
+		fieldOrder = append(fieldOrder, webhook.Name)
+	}
+	fieldOrder = append(fieldOrder, "activity", "finding")
+	writeCSVHeaders(writer, fieldOrder)
+
+	findings := make(map[string]string, len(report.Findings))
+	for _, finding := range report.Findings {
+		findings[finding.Event] = finding.Severity
+	}
+
+	for _, event := range report.Events {
+		row := []string{event.Event}
+		for _, webhook := range report.Webhooks {
+			row = append(row, formatBooleanStatus(slices.Contains(event.Subscribers, webhook.ID)))
+		}
+		activity := ""
+		if event.Activity != nil {
+			activity = formatInt(*event.Activity)
+		}
+		row = append(row, activity, findings[event.Event])
+		writeCSVRow(writer, row)
+	}
+
+	return nil
+}
+
+func (h *csvHandler) HandleWebhookVerification(verification *WebhookVerification, config SingleConfig) error {
+	if verification == nil {
+		return nil // No CSV output for empty data
+	}
+
+	writer := h.createCSVWriter()
+	defer flushCSVWriter(writer)
+
+	var headers, row []string
+	for _, field := range webhookVerificationFields(verification) {
+		headers = append(headers, field.Key)
+		row = append(row, field.Value)
+	}
+	headers = append(headers, "problems")
+	row = append(row, strings.Join(verification.Problems, "; "))
+	if err := writeCSVHeaders(writer, headers); err != nil {
+		return err
+	}
+	return writeCSVRow(writer, row)
+}
+
+func (h *csvHandler) HandleWebhookSimulation(simulation *WebhookSimulation, config SingleConfig) error {
+	if simulation == nil {
+		return nil // No CSV output for empty data
+	}
+
+	writer := h.createCSVWriter()
+	defer flushCSVWriter(writer)
+
+	writeCSVHeaders(writer, []string{"msg_id", "type", "webhook_timestamp", "webhook_signature", "status", "failure", "status_code", "duration_ms", "error", "payload"})
+	for _, event := range simulation.Events {
+		statusCode, duration := "", ""
+		if simulation.Sent {
+			statusCode = formatSimulationStatusCode(event.StatusCode)
+			duration = strconv.FormatInt(event.DurationMs, 10)
+		}
+		writeCSVRow(writer, []string{
+			event.MsgID,
+			event.Type,
+			strconv.FormatInt(event.SignedAt, 10),
+			event.Signature,
+			event.Status,
+			string(event.Failure),
+			statusCode,
+			duration,
+			event.Error,
+			event.Payload,
+		})
+	}
+
+	return nil
+}
+
 // Route responses
 func (h *csvHandler) HandleRouteList(response *responses.PaginatedRoutesResponse, config ListConfig) error {
 	if response == nil || len(response.Data) == 0 {
@@ -549,6 +960,9 @@ func (h *csvHandler) HandleRouteList(response *responses.PaginatedRoutesResponse
 	if len(fieldOrder) == 0 {
 		fieldOrder = defaultFields
 	}
+	if config.ExpandedPatterns != nil {
+		fieldOrder = append(append([]string{}, fieldOrder...), "expanded_patterns")
+	}
 
 	// Create header row
 	headers := []string{}
@@ -576,6 +990,8 @@ func (h *csvHandler) HandleRouteList(response *responses.PaginatedRoutesResponse
 			headers = append(headers, "created_at")
 		case "updated_at":
 			headers = append(headers, "updated_at")
+		case "expanded_patterns":
+			headers = append(headers, "expanded_patterns")
 		}
 	}
 	if err := writeCSVHeaders(writer, headers); err != nil {
@@ -596,6 +1012,7 @@ func (h *csvHandler) HandleRouteList(response *responses.PaginatedRoutesResponse
 			"strip_replies":       fmt.Sprintf("%t", route.StripReplies),
 			"created_at":          route.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 			"updated_at":          route.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+			"expanded_patterns":   formatExpandedPatterns(patternsForRoute(config.ExpandedPatterns, route.ID)),
 		}
 
 		row := convertToCSVRow(fieldMap, fieldOrder)
@@ -629,13 +1046,20 @@ func (h *csvHandler) HandleSingleRoute(route *responses.Route, config SingleConf
 		"created_at":          route.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		"updated_at":          route.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
+	fieldOrder := config.FieldOrder
+	if config.ExpandedPatterns != nil {
+		fieldMap["expanded_patterns"] = formatExpandedPatterns(config.ExpandedPatterns)
+		if len(fieldOrder) > 0 {
+			fieldOrder = append(append([]string{}, fieldOrder...), "expanded_patterns")
+		}
+	}
 
-	headers := getCSVHeaders(fieldMap, config.FieldOrder)
+	headers := getCSVHeaders(fieldMap, fieldOrder)
 	if err := writeCSVHeaders(writer, headers); err != nil {
 		return err
 	}
 
-	row := convertToCSVRow(fieldMap, config.FieldOrder)
+	row := convertToCSVRow(fieldMap, fieldOrder)
 	if err := writeCSVRow(writer, row); err != nil {
 		return err
 	}
@@ -741,6 +1165,25 @@ func (h *csvHandler) HandleTriggerRoute(routeID string, config TriggerConfig) er
 	return nil
 }
 
+func (h *csvHandler) HandleRouteExplanation(explanation *RouteExplanation, config SingleConfig) error {
+	if explanation == nil {
+		return nil // No CSV output for empty data
+	}
+
+	writer := h.createCSVWriter()
+	defer flushCSVWriter(writer)
+
+	// One row per option, then one per payload field
+	writeCSVHeaders(writer, []string{"kind", "name", "enabled", "option", "effect"})
+	for _, option := range explanation.Options {
+		writeCSVRow(writer, []string{"option", option.Key, formatBooleanStatus(option.Enabled), "", option.Effect})
+	}
+	for _, field := range explanation.Fields {
+		writeCSVRow(writer, []string{"field", field.Name, formatBooleanStatus(field.Present), field.Option, ""})
+	}
+	return nil
+}
+
 // Suppression responses
 func (h *csvHandler) HandleSuppressionList(response *responses.PaginatedSuppressionsResponse, config ListConfig) error {
 	if len(response.Data) == 0 {
@@ -858,17 +1301,83 @@ func (h *csvHandler) HandleCreateSuppression(response *responses.CreateSuppressi
 		}
 	}
 
-	return nil
-}
+	return nil
+}
+
+func (h *csvHandler) HandleDeleteSuppression(success bool, config DeleteConfig) error {
+	h.printMessage("%s\n", config.SuccessMessage)
+	return nil
+}
+
+func (h *csvHandler) HandleWipeSuppression(count int, config WipeConfig) error {
+	h.printMessage("%s\n", config.SuccessMessage)
+	fmt.Fprintf(h.writer, "Wiped %d suppressions\n", count)
+	return nil
+}
+
+func (h *csvHandler) HandleSuppressionWipeSummary(summary *SuppressionWipeSummary, config WipeConfig) error {
+	if summary == nil || len(summary.Reasons) == 0 {
+		return nil // No CSV output for empty data
+	}
+
+	writer := h.createCSVWriter()
+	defer flushCSVWriter(writer)
+
+	fieldOrder := []string{"reason", "count"}
+	writeCSVHeaders(writer, fieldOrder)
+
+	for _, reason := range summary.Reasons {
+		fieldMap := map[string]string{
+			"reason": reason.Reason,
+			"count":  fmt.Sprintf("%d", reason.Count),
+		}
+		writeCSVRow(writer, convertToCSVRow(fieldMap, fieldOrder))
+	}
+
+	return nil
+}
+
+func (h *csvHandler) HandleBounceSuppressions(summary *BounceSuppressionSummary, config CreateConfig) error {
+	if summary == nil {
+		return nil // No CSV output for empty data
+	}
+
+	writer := h.createCSVWriter()
+	defer flushCSVWriter(writer)
+
+	fieldOrder := []string{"dry_run", "messages", "matched", "already_suppressed", "created", "failed"}
+	writeCSVHeaders(writer, fieldOrder)
+	writeCSVRow(writer, convertToCSVRow(map[string]string{
+		"dry_run":            fmt.Sprintf("%t", summary.DryRun),
+		"messages":           fmt.Sprintf("%d", summary.Messages),
+		"matched":            fmt.Sprintf("%d", summary.Matched),
+		"already_suppressed": fmt.Sprintf("%d", summary.AlreadySuppressed),
+		"created":            fmt.Sprintf("%d", summary.Created),
+		"failed":             fmt.Sprintf("%d", summary.Failed),
+	}, fieldOrder))
 
-func (h *csvHandler) HandleDeleteSuppression(success bool, config DeleteConfig) error {
-	fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
 	return nil
 }
 
-func (h *csvHandler) HandleWipeSuppression(count int, config WipeConfig) error {
-	fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
-	fmt.Fprintf(h.writer, "Wiped %d suppressions\n", count)
+func (h *csvHandler) HandleSuppressionImport(summary *SuppressionImportSummary, config CreateConfig) error {
+	if summary == nil {
+		return nil // No CSV output for empty data
+	}
+
+	writer := h.createCSVWriter()
+	defer flushCSVWriter(writer)
+
+	fieldOrder := []string{"file", "rows", "created", "invalid", "failed", "skipped"}
+	writeCSVHeaders(writer, fieldOrder)
+	writeCSVRow(writer, convertToCSVRow(map[string]string{
+		"file":    summary.File,
+		"rows":    fmt.Sprintf("%d", summary.Rows),
+		"created": fmt.Sprintf("%d", summary.Created),
+		"invalid": fmt.Sprintf("%d", summary.Invalid),
+		"failed":  fmt.Sprintf("%d", summary.Failed),
+		"skipped": fmt.Sprintf("%d", summary.Skipped),
+	}, fieldOrder))
+
 	return nil
 }
 
@@ -1020,6 +1529,41 @@ func (h *csvHandler) HandleCreateSMTP(credential *responses.SMTPCredential, conf
 	return nil
 }
 
+func (h *csvHandler) HandleUpdateSMTP(update *SMTPCredentialUpdate, config UpdateConfig) error {
+	if update == nil || update.Credential == nil {
+		return nil
+	}
+	credential := update.Credential
+
+	writer := h.createCSVWriter()
+	defer flushCSVWriter(writer)
+
+	fieldMap := map[string]string{
+		"id":              formatUUID(credential.ID),
+		"name":            credential.Name,
+		"username":        credential.Username,
+		"scope":           credential.Scope,
+		"domains":         formatStringSlice(credential.Domains),
+		"added_domains":   formatStringSlice(update.AddedDomains),
+		"removed_domains": formatStringSlice(update.RemovedDomains),
+		"sandbox":         fmt.Sprintf("%t", credential.Sandbox),
+		"dry_run":         fmt.Sprintf("%t", update.DryRun),
+		"updated_at":      formatTime(credential.UpdatedAt),
+	}
+
+	headers := getCSVHeaders(fieldMap, config.FieldOrder)
+	if err := writeCSVHeaders(writer, headers); err != nil {
+		return err
+	}
+
+	row := convertToCSVRow(fieldMap, headers)
+	if err := writeCSVRow(writer, row); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (h *csvHandler) HandleDeleteSMTP(success bool, config DeleteConfig) error {
 	writer := h.createCSVWriter()
 	defer flushCSVWriter(writer)
@@ -1088,6 +1632,62 @@ func (h *csvHandler) HandleSMTPSend(result *SMTPSendResult, config SMTPSendConfi
 	return nil
 }
 
+func (h *csvHandler) HandleSMTPUsage(report *SMTPUsageReport, config SingleConfig) error {
+	if report == nil {
+		return nil // No CSV output for empty data
+	}
+
+	writer := h.createCSVWriter()
+	defer flushCSVWriter(writer)
+
+	if err := writeCSVHeaders(writer, []string{
+		"id", "name", "username", "scope", "domains", "attribution", "status", "messages",
+		"last_activity", "bucket_from", "bucket_to", "bucket_messages", "error",
+	}); err != nil {
+		return err
+	}
+
+	for _, credential := range report.Credentials {
+		lastActivity := ""
+		if credential.LastActivity != nil {
+			lastActivity = credential.LastActivity.Format(time.RFC3339)
+		}
+		row := []string{
+			credential.ID,
+			credential.Name,
+			credential.Username,
+			credential.Scope,
+			formatStringSlice(credential.Domains),
+			credential.Attribution,
+			smtpUsageStatus(credential),
+			formatInt(credential.Messages),
+			lastActivity,
+		}
+
+		// One row per bucket, and a single row without bucket columns for
+		// credentials without any
+		if len(credential.Buckets) == 0 {
+			if err := writeCSVRow(writer, append(row, "", "", "", credential.Error)); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, bucket := range credential.Buckets {
+			bucketRow := append(append([]string{}, row...),
+				bucket.From.Format(time.RFC3339),
+				bucket.To.Format(time.RFC3339),
+				formatInt(bucket.Messages),
+				credential.Error,
+			)
+			if err := writeCSVRow(writer, bucketRow); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 // API Key responses
 func (h *csvHandler) HandleAPIKeyList(response *responses.PaginatedAPIKeysResponse, config ListConfig) error {
 	if len(response.Data) == 0 {
@@ -1442,23 +2042,168 @@ func (h *csvHandler) HandleDeliverabilityStats(response *responses.Deliverabilit
 	writer := h.createCSVWriter()
 	defer flushCSVWriter(writer)
 
-	// Define field mapping
-	defaultFields := []string{
-		"from_timestamp", "to_timestamp", "reception_count", "delivered_count",
+	fieldOrder := deliverabilityCSVFields(config)
+
+	// Write headers
+	writeCSVHeaders(writer, fieldOrder)
+	writeDeliverabilityCSVRows(writer, response.Data, fieldOrder)
+
+	return nil
+}
+
+// HandleDeliverabilityStatsChunk appends the rows of one streamed chunk,
+// writing the header row only for the first chunk
+func (h *csvHandler) HandleDeliverabilityStatsChunk(response *responses.DeliverabilityStatisticsResponse, config StatsConfig) error {
+	writer := h.createCSVWriter()
+	defer flushCSVWriter(writer)
+
+	fieldOrder := deliverabilityCSVFields(config)
+	if !config.Continuation {
+		writeCSVHeaders(writer, fieldOrder)
+	}
+	if response != nil {
+		writeDeliverabilityCSVRows(writer, response.Data, fieldOrder)
+	}
+	return nil
+}
+
+func (h *csvHandler) HandleDeliverabilitySummary(summary *DeliverabilitySummary, config StatsConfig) error {
+	if summary == nil {
+		return nil // No CSV output for empty data
+	}
+
+	writer := h.createCSVWriter()
+	defer flushCSVWriter(writer)
+
+	rate := func(rate *float64) string {
+		if rate == nil {
+			return ""
+		}
+		return fmt.Sprintf("%.2f", *rate)
+	}
+
+	writeCSVHeaders(writer, []string{
+		"from_time", "to_time", "buckets", "reception_count", "delivered_count",
 		"deferred_count", "bounced_count", "failed_count", "suppressed_count",
-		"opened_count", "clicked_count", "delivery_rate", "open_rate",
+		"opened_count", "clicked_count", "delivery_rate", "bounce_rate", "open_rate", "click_rate",
+	})
+	writeCSVRow(writer, []string{
+		formatTime(summary.From),
+		formatTime(summary.To),
+		formatInt(summary.Buckets),
+		formatInt(summary.Reception),
+		formatInt(summary.Delivered),
+		formatInt(summary.Deferred),
+		formatInt(summary.Bounced),
+		formatInt(summary.Failed),
+		formatInt(summary.Suppressed),
+		formatInt(summary.Opened),
+		formatInt(summary.Clicked),
+		rate(summary.DeliveryRate),
+		rate(summary.BounceRate),
+		rate(summary.OpenRate),
+		rate(summary.ClickRate),
+	})
+	return nil
+}
+
+// HandleDeliverabilityByTag writes one row per tag and time bucket, or one
+// row per tag for a summary-only report. Unavailable tags get a single row
+// with their status and error and empty counts.
+func (h *csvHandler) HandleDeliverabilityByTag(report *DeliverabilityByTag, config StatsConfig) error {
+	if report == nil {
+		return nil // No CSV output for empty data
 	}
 
-	fieldOrder := defaultFields
-	if len(config.FieldOrder) > 0 {
-		fieldOrder = config.FieldOrder
+	writer := h.createCSVWriter()
+	defer flushCSVWriter(writer)
+
+	rate := func(rate *float64) string {
+		if rate == nil {
+			return ""
+		}
+		return fmt.Sprintf("%.2f", *rate)
 	}
 
-	// Write headers
-	writeCSVHeaders(writer, fieldOrder)
+	if report.SummaryOnly {
+		headers := []string{
+			"tag", "status", "buckets", "reception_count", "delivered_count", "deferred_count",
+			"bounced_count", "failed_count", "suppressed_count", "opened_count", "clicked_count",
+			"delivery_rate", "bounce_rate", "open_rate", "click_rate", "error",
+		}
+		writeCSVHeaders(writer, headers)
+		for _, tag := range report.Tags {
+			fieldMap := map[string]string{"tag": tag.Tag, "status": tag.Status, "error": tag.Error}
+			if summary := tag.Summary; summary != nil {
+				fieldMap["buckets"] = formatInt(summary.Buckets)
+				fieldMap["reception_count"] = formatInt(summary.Reception)
+				fieldMap["delivered_count"] = formatInt(summary.Delivered)
+				fieldMap["deferred_count"] = formatInt(summary.Deferred)
+				fieldMap["bounced_count"] = formatInt(summary.Bounced)
+				fieldMap["failed_count"] = formatInt(summary.Failed)
+				fieldMap["suppressed_count"] = formatInt(summary.Suppressed)
+				fieldMap["opened_count"] = formatInt(summary.Opened)
+				fieldMap["clicked_count"] = formatInt(summary.Clicked)
+				fieldMap["delivery_rate"] = rate(summary.DeliveryRate)
+				fieldMap["bounce_rate"] = rate(summary.BounceRate)
+				fieldMap["open_rate"] = rate(summary.OpenRate)
+				fieldMap["click_rate"] = rate(summary.ClickRate)
+			}
+			writeCSVRow(writer, convertToCSVRow(fieldMap, headers))
+		}
+		return nil
+	}
 
-	// Write data rows
-	for _, stat := range response.Data {
+	headers := []string{
+		"tag", "status", "from_timestamp", "to_timestamp", "reception_count", "delivered_count",
+		"deferred_count", "bounced_count", "failed_count", "suppressed_count", "opened_count",
+		"clicked_count", "delivery_rate", "open_rate", "error",
+	}
+	writeCSVHeaders(writer, headers)
+	for _, tag := range report.Tags {
+		if tag.Status == TagStatusUnavailable {
+			writeCSVRow(writer, convertToCSVRow(map[string]string{
+				"tag": tag.Tag, "status": tag.Status, "error": tag.Error,
+			}, headers))
+			continue
+		}
+		for _, bucket := range tag.Buckets {
+			writeCSVRow(writer, convertToCSVRow(map[string]string{
+				"tag":              tag.Tag,
+				"status":           tag.Status,
+				"from_timestamp":   formatTime(bucket.FromTimestamp),
+				"to_timestamp":     formatTime(bucket.ToTimestamp),
+				"reception_count":  formatInt(bucket.ReceptionCount),
+				"delivered_count":  formatInt(bucket.DeliveredCount),
+				"deferred_count":   formatInt(bucket.DeferredCount),
+				"bounced_count":    formatInt(bucket.BouncedCount),
+				"failed_count":     formatInt(bucket.FailedCount),
+				"suppressed_count": formatInt(bucket.SuppressedCount),
+				"opened_count":     formatInt(bucket.OpenedCount),
+				"clicked_count":    formatInt(bucket.ClickedCount),
+				"delivery_rate":    rate(bucket.DeliveryRate),
+				"open_rate":        rate(bucket.OpenRate),
+			}, headers))
+		}
+	}
+	return nil
+}
+
+// deliverabilityCSVFields returns the deliverability columns, honoring the field order
+func deliverabilityCSVFields(config StatsConfig) []string {
+	if len(config.FieldOrder) > 0 {
+		return config.FieldOrder
+	}
+	return []string{
+		"from_timestamp", "to_timestamp", "reception_count", "delivered_count",
+		"deferred_count", "bounced_count", "failed_count", "suppressed_count",
+		"opened_count", "clicked_count", "delivery_rate", "open_rate",
+	}
+}
+
+// writeDeliverabilityCSVRows writes one row per time bucket
+func writeDeliverabilityCSVRows(writer *csv.Writer, data []responses.DeliverabilityStatistics, fieldOrder []string) {
+	for _, stat := range data {
 		// Calculate rates
 		deliveryRate := ""
 		if stat.ReceptionCount > 0 {
@@ -1490,6 +2235,106 @@ func (h *csvHandler) HandleDeliverabilityStats(response *responses.Deliverabilit
 		row := convertToCSVRow(fieldMap, fieldOrder)
 		writeCSVRow(writer, row)
 	}
+}
+
+func (h *csvHandler) HandleBounceExplanations(explanations []bounces.Explanation, config ListConfig) error {
+	writer := h.createCSVWriter()
+	defer flushCSVWriter(writer)
+
+	writeCSVHeaders(writer, []string{"classification", "explanation", "action"})
+	for _, explanation := range explanations {
+		writeCSVRow(writer, []string{explanation.Classification, explanation.Description, explanation.Action})
+	}
+	return nil
+}
+
+func (h *csvHandler) HandleReminderList(reminders []state.Reminder, config ListConfig) error {
+	writer := h.createCSVWriter()
+	defer flushCSVWriter(writer)
+
+	writeCSVHeaders(writer, []string{"id", "kind", "subject", "message", "due_at", "created_at", "due"})
+	now := time.Now()
+	for _, reminder := range reminders {
+		writeCSVRow(writer, []string{
+			reminder.ID,
+			reminder.Kind,
+			reminder.Subject,
+			reminder.Message,
+			reminder.DueAt.UTC().Format(time.RFC3339),
+			reminder.CreatedAt.UTC().Format(time.RFC3339),
+			strconv.FormatBool(reminder.Due(now)),
+		})
+	}
+	return nil
+}
+
+func (h *csvHandler) HandleStatsAnomalies(report *AnomalyReport, config StatsConfig) error {
+	writer := h.createCSVWriter()
+	defer flushCSVWriter(writer)
+
+	writeCSVHeaders(writer, []string{"from_timestamp", "to_timestamp", "metric", "value", "mean", "stddev", "z_score"})
+	for _, bucket := range report.Anomalies {
+		z := ""
+		if bucket.ZScore != nil {
+			z = formatFloat64(*bucket.ZScore)
+		}
+		writeCSVRow(writer, []string{
+			bucket.From.Format(time.RFC3339),
+			bucket.To.Format(time.RFC3339),
+			report.Metric,
+			formatFloat64(bucket.Value),
+			formatFloat64(bucket.Mean),
+			formatFloat64(bucket.StdDev),
+			z,
+		})
+	}
+	return nil
+}
+
+func (h *csvHandler) HandleDeliverabilityComparison(comparison *DeliverabilityComparison, config StatsConfig) error {
+	if comparison == nil || len(comparison.Metrics) == 0 {
+		return nil // No CSV output for empty data
+	}
+
+	writer := h.createCSVWriter()
+	defer flushCSVWriter(writer)
+
+	fieldOrder := []string{
+		"metric", "current", "previous", "change", "percent_change",
+		"current_from", "current_to", "previous_from", "previous_to",
+	}
+	writeCSVHeaders(writer, fieldOrder)
+
+	for _, metric := range comparison.Metrics {
+		// Undefined rates and changes are left empty, like a percent change
+		// without a baseline
+		current, previous, change, percentChange := "", "", "", ""
+		if !metric.CurrentUndefined {
+			current = formatFloat64(metric.Current)
+		}
+		if !metric.PreviousUndefined {
+			previous = formatFloat64(metric.Previous)
+		}
+		if !metric.Undefined() {
+			change = formatFloat64(metric.Change)
+		}
+		if metric.PercentChange != nil {
+			percentChange = formatFloat64(*metric.PercentChange)
+		}
+
+		fieldMap := map[string]string{
+			"metric":         metric.Metric,
+			"current":        current,
+			"previous":       previous,
+			"change":         change,
+			"percent_change": percentChange,
+			"current_from":   formatTime(comparison.CurrentPeriod.From),
+			"current_to":     formatTime(comparison.CurrentPeriod.To),
+			"previous_from":  formatTime(comparison.PreviousPeriod.From),
+			"previous_to":    formatTime(comparison.PreviousPeriod.To),
+		}
+		writeCSVRow(writer, convertToCSVRow(fieldMap, fieldOrder))
+	}
 
 	return nil
 }
@@ -1498,6 +2343,9 @@ func (h *csvHandler) HandleBounceStats(response *responses.BounceStatisticsRespo
 	if len(response.Data) == 0 {
 		return nil // No CSV output for empty data
 	}
+	if config.Aggregate {
+		return h.writeBounceTotals(response, config)
+	}
 
 	writer := h.createCSVWriter()
 	defer flushCSVWriter(writer)
@@ -1508,6 +2356,9 @@ func (h *csvHandler) HandleBounceStats(response *responses.BounceStatisticsRespo
 	if len(config.FieldOrder) > 0 {
 		fieldOrder = config.FieldOrder
 	}
+	if config.Explain {
+		fieldOrder = append(append([]string{}, fieldOrder...), "explanation", "action")
+	}
 
 	// Write headers
 	writeCSVHeaders(writer, fieldOrder)
@@ -1532,6 +2383,9 @@ func (h *csvHandler) HandleBounceStats(response *responses.BounceStatisticsRespo
 				"count":          formatInt(bounce.Count),
 				"percentage":     fmt.Sprintf("%.1f", percentage),
 			}
+			if config.Explain {
+				fieldMap["explanation"], fieldMap["action"] = explainBounce(bounce.Classification)
+			}
 
 			row := convertToCSVRow(fieldMap, fieldOrder)
 			writeCSVRow(writer, row)
@@ -1541,6 +2395,43 @@ func (h *csvHandler) HandleBounceStats(response *responses.BounceStatisticsRespo
 	return nil
 }
 
+// writeBounceTotals writes one row per classification over the whole
+// range, for --aggregate. The columns are fixed so the output can feed
+// dashboards; --explain appends explanation and action.
+func (h *csvHandler) writeBounceTotals(response *responses.BounceStatisticsResponse, config StatsConfig) error {
+	totals, _, _, total := aggregateBounces(response.Data)
+	if total == 0 {
+		return nil // No CSV output for empty data
+	}
+
+	writer := h.createCSVWriter()
+	defer flushCSVWriter(writer)
+
+	fieldOrder := []string{"classification", "count", "percentage"}
+	if config.Explain {
+		fieldOrder = append(fieldOrder, "explanation", "action")
+	}
+	if err := writeCSVHeaders(writer, fieldOrder); err != nil {
+		return err
+	}
+
+	for _, bounce := range totals {
+		fieldMap := map[string]string{
+			"classification": bounce.Classification,
+			"count":          formatInt(bounce.Count),
+			"percentage":     fmt.Sprintf("%.1f", bounce.Percentage),
+		}
+		if config.Explain {
+			fieldMap["explanation"], fieldMap["action"] = explainBounce(bounce.Classification)
+		}
+		if err := writeCSVRow(writer, convertToCSVRow(fieldMap, fieldOrder)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (h *csvHandler) HandleDeliveryTimeStats(response *responses.DeliveryTimeStatisticsResponse, config StatsConfig) error {
 	if len(response.Data) == 0 {
 		return nil // No CSV output for empty data
@@ -1651,6 +2542,29 @@ func (h *csvHandler) HandleAuthLogout(success bool, config AuthConfig) error {
 	return nil
 }
 
+func (h *csvHandler) HandleAccount(account *responses.Account, config SingleConfig) error {
+	if account == nil {
+		return nil // No CSV output for empty data
+	}
+
+	writer := h.createCSVWriter()
+	defer flushCSVWriter(writer)
+
+	fields := accountFields(account)
+	fieldMap := make(map[string]string, len(fields))
+	headers := config.FieldOrder
+	for _, field := range fields {
+		fieldMap[field.Key] = field.Value
+		if len(config.FieldOrder) == 0 {
+			headers = append(headers, field.Key)
+		}
+	}
+	if err := writeCSVHeaders(writer, headers); err != nil {
+		return err
+	}
+	return writeCSVRow(writer, convertToCSVRow(fieldMap, headers))
+}
+
 func (h *csvHandler) HandleAuthStatus(status *AuthStatus, config AuthConfig) error {
 	if status == nil {
 		return nil // No CSV output for empty status
@@ -1660,9 +2574,16 @@ func (h *csvHandler) HandleAuthStatus(status *AuthStatus, config AuthConfig) err
 	fieldMap := map[string]string{
 		"profile": status.Profile,
 		"api_key": status.APIKey,
+		"api_url": status.APIURL,
 		"valid":   formatBooleanStatus(status.Valid),
 	}
 
+	// The profile's account is shown even when the API cannot be reached
+	if status.AccountID != "" {
+		fieldMap["account_id"] = status.AccountID
+		fieldMap["account_name"] = status.AccountName
+	}
+
 	// Add account fields if available
 	if status.Account != nil {
 		fieldMap["account_id"] = formatUUID(status.Account.ID)
@@ -1737,6 +2658,91 @@ func (h *csvHandler) HandleAuthSwitch(newProfile string, config AuthConfig) erro
 	return nil
 }
 
+// Setup diagnosis
+func (h *csvHandler) HandleDoctorReport(report *DoctorReport, config SingleConfig) error {
+	if report == nil {
+		return nil // No CSV output for empty data
+	}
+
+	writer := h.createCSVWriter()
+	defer flushCSVWriter(writer)
+
+	writeCSVHeaders(writer, []string{"id", "name", "status", "message", "hint"})
+	for _, check := range report.Checks {
+		writeCSVRow(writer, []string{check.ID, check.Name, check.Status, check.Message, check.Hint})
+	}
+
+	return nil
+}
+
+func (h *csvHandler) HandlePing(result *PingResult, config SimpleConfig) error {
+	if result == nil {
+		return nil // No CSV output for empty data
+	}
+
+	writer := h.createCSVWriter()
+	defer flushCSVWriter(writer)
+
+	writeCSVHeaders(writer, []string{"message", "api_url"})
+	writeCSVRow(writer, []string{result.Message, result.APIURL})
+
+	return nil
+}
+
+// Bulk delete results
+func (h *csvHandler) HandleBulkDelete(result *BulkDeleteResult, config DeleteConfig) error {
+	if result == nil || len(result.Items) == 0 {
+		return nil // No CSV output for empty data
+	}
+
+	writer := h.createCSVWriter()
+	defer flushCSVWriter(writer)
+
+	fieldOrder := []string{"id", "name", "status", "error"}
+	writeCSVHeaders(writer, fieldOrder)
+
+	for _, item := range result.Items {
+		status := "deleted"
+		if !item.Deleted {
+			status = "failed"
+		}
+		fieldMap := map[string]string{
+			"id":     item.ID,
+			"name":   item.Name,
+			"status": status,
+			"error":  item.Error,
+		}
+		writeCSVRow(writer, convertToCSVRow(fieldMap, fieldOrder))
+	}
+
+	return nil
+}
+
+// Import results
+func (h *csvHandler) HandleImport(result *ImportResult, config CreateConfig) error {
+	if result == nil || len(result.Items) == 0 {
+		return nil // No CSV output for empty data
+	}
+
+	writer := h.createCSVWriter()
+	defer flushCSVWriter(writer)
+
+	fieldOrder := []string{"name", "id", "status", "error"}
+	writeCSVHeaders(writer, fieldOrder)
+
+	for _, item := range result.Items {
+		fieldMap := map[string]string{
+			"name":   item.Name,
+			"id":     item.ID,
+			"status": item.Status,
+			"error":  item.Error,
+		}
+		writeCSVRow(writer, convertToCSVRow(fieldMap, fieldOrder))
+	}
+
+	return nil
+}
+
 // Simple success and empty responses
 func (h *csvHandler) HandleSimpleSuccess(message string) error {
 	// CSV format doesn't typically output success messages
@@ -0,0 +1,179 @@
+package printer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+)
+
+// FieldFlag is the global flag that prints a single field of the response
+const FieldFlag = "field"
+
+// fieldHandler prints the raw value of one field of a response, with no
+// labels or decoration: one line for a single object, one line per item for
+// a list. Responses are rendered by the JSON handler first, so the field
+// names are the keys of --output json and every response type supports
+// them. Messages and empty responses print nothing, and errors go to
+// errWriter so they never end up in a captured value.
+type fieldHandler struct {
+	jsonHandler
+	errWriter io.Writer
+}
+
+// NewFieldHandler returns a handler that prints only field of each response
+// to writer. A dotted name selects a nested field, e.g. "schedule.expires".
+func NewFieldHandler(field string, writer, errWriter io.Writer) ResponseHandler {
+	return &fieldHandler{
+		jsonHandler: jsonHandler{handlerBase: handlerBase{writer: writer, out: writer}, field: field},
+		errWriter:   errWriter,
+	}
+}
+
+// GetFormat returns the format name; --field is a mode of plain output
+func (h *fieldHandler) GetFormat() string {
+	return "plain"
+}
+
+func (h *fieldHandler) HandleError(err error) error {
+	if err == nil {
+		return nil
+	}
+	fmt.Fprintf(h.errWriter, "Error: %v\n", err)
+	return err
+}
+
+// writeField prints the field of a cleaned response, or of each item when
+// the response is a list
+func (h *jsonHandler) writeField(cleaned interface{}) error {
+	data, err := json.Marshal(cleaned)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var response interface{}
+	if err := decoder.Decode(&response); err != nil {
+		return fmt.Errorf("failed to decode JSON: %w", err)
+	}
+
+	items := []interface{}{response}
+	if object, ok := response.(map[string]interface{}); ok && object["object"] == "list" {
+		if data, ok := object["data"].([]interface{}); ok {
+			items = data
+		}
+	} else if list, ok := response.([]interface{}); ok {
+		items = list
+	}
+
+	for _, item := range items {
+		value, err := lookupField(item, h.field)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(h.writer, formatFieldValue(value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lookupField returns the value of a dotted field name in a decoded JSON
+// object. Names match keys exactly, through jsonlFieldAliases, or ignoring
+// case and underscores, so "dns_valid", "DNSValid" and "dnsvalid" are the
+// same field.
+func lookupField(item interface{}, field string) (interface{}, error) {
+	value := item
+	path := ""
+	for _, name := range strings.Split(field, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.NewValidationError(fmt.Sprintf("unknown field %q: %s has no fields", field, path), nil)
+		}
+		key, ok := matchFieldKey(object, name)
+		if !ok {
+			return nil, errors.NewValidationError(fmt.Sprintf("unknown field %q; valid fields: %s", field, strings.Join(fieldNames(object, path), ", ")), nil)
+		}
+		value = object[key]
+		path += key + "."
+	}
+	return value, nil
+}
+
+// matchFieldKey returns the key of object that name selects
+func matchFieldKey(object map[string]interface{}, name string) (string, bool) {
+	if _, ok := object[name]; ok {
+		return name, true
+	}
+	if alias, ok := jsonlFieldAliases[name]; ok {
+		if _, ok := object[alias]; ok {
+			return alias, true
+		}
+	}
+	normalized := normalizeFieldName(name)
+	for key := range object {
+		if normalizeFieldName(key) == normalized {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+func normalizeFieldName(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))
+}
+
+// fieldNames lists the keys of object, prefixed with path, alphabetically
+func fieldNames(object map[string]interface{}, path string) []string {
+	names := make([]string, 0, len(object))
+	for key := range object {
+		if path == "" && (key == "schema_version" || key == "warnings") {
+			continue
+		}
+		names = append(names, path+key)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// formatFieldValue formats a decoded JSON value for the shell: strings and
+// numbers as they are, null as an empty line, lists of scalars joined with
+// commas and anything else as compact JSON
+func formatFieldValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return fmt.Sprintf("%t", v)
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, element := range v {
+			switch element.(type) {
+			case map[string]interface{}, []interface{}:
+				return compactJSON(v)
+			}
+			parts = append(parts, formatFieldValue(element))
+		}
+		return strings.Join(parts, ",")
+	default:
+		return compactJSON(v)
+	}
+}
+
+func compactJSON(value interface{}) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
@@ -0,0 +1,104 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+)
+
+func TestFieldHandler_SingleObject(t *testing.T) {
+	domainID := uuid.New()
+	domain := &responses.Domain{
+		ID:        domainID,
+		Domain:    "example.com",
+		DNSValid:  true,
+		CreatedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		DNSRecords: []responses.DNSRecord{
+			{Type: "TXT", Host: "example.com", Content: "v=spf1 include:ahasend.com ~all"},
+		},
+	}
+
+	tests := []struct {
+		field string
+		want  string
+	}{
+		{"id", domainID.String() + "\n"},
+		{"domain", "example.com\n"},
+		{"dns_valid", "true\n"},
+		{"DNSValid", "true\n"},
+		{"created", "2026-01-02T03:04:05Z\n"},
+		{"dns_records", `[{"content":"v=spf1 include:ahasend.com ~all","host":"example.com","label":null,"propagated":false,"required":false,"type":"TXT"}]` + "\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			var buf bytes.Buffer
+			handler := NewFieldHandler(tt.field, &buf, &bytes.Buffer{})
+			require.NoError(t, handler.HandleSingleDomain(domain, SingleConfig{}))
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestFieldHandler_List(t *testing.T) {
+	var buf bytes.Buffer
+	handler := NewFieldHandler("domain", &buf, &bytes.Buffer{})
+	require.NoError(t, handler.HandleDomainList(&responses.PaginatedDomainsResponse{
+		Object: "list",
+		Data:   []responses.Domain{{ID: uuid.New(), Domain: "example.com"}, {ID: uuid.New(), Domain: "mail.example.org"}},
+	}, ListConfig{ShowPagination: true}))
+	assert.Equal(t, "example.com\nmail.example.org\n", buf.String())
+}
+
+func TestFieldHandler_NestedAndArrays(t *testing.T) {
+	var buf bytes.Buffer
+	handler := NewFieldHandler("scopes", &buf, &bytes.Buffer{})
+	require.NoError(t, handler.HandleSingleAPIKey(&responses.APIKey{
+		ID:     uuid.New(),
+		Label:  "ci",
+		Scopes: []responses.APIKeyScope{{Scope: "messages:send:all"}},
+	}, SingleConfig{}))
+	assert.Contains(t, buf.String(), `"scope":"messages:send:all"`)
+
+	buf.Reset()
+	handler = NewFieldHandler("domains", &buf, &bytes.Buffer{})
+	require.NoError(t, handler.HandleSingleSMTP(&responses.SMTPCredential{ID: uuid.New(), Domains: []string{"a.com", "b.com"}}, SingleConfig{}))
+	assert.Equal(t, "a.com,b.com\n", buf.String())
+}
+
+func TestFieldHandler_UnknownField(t *testing.T) {
+	var buf bytes.Buffer
+	handler := NewFieldHandler("nope", &buf, &bytes.Buffer{})
+	err := handler.HandleSingleDomain(&responses.Domain{ID: uuid.New(), Domain: "example.com"}, SingleConfig{})
+	require.Error(t, err)
+	assert.Equal(t, 4, errors.GetExitCode(err))
+	assert.Contains(t, err.Error(), `unknown field "nope"; valid fields:`)
+	assert.Contains(t, err.Error(), "dns_valid")
+	assert.NotContains(t, err.Error(), "schema_version")
+	assert.Empty(t, buf.String())
+
+	handler = NewFieldHandler("domain.name", &buf, &bytes.Buffer{})
+	err = handler.HandleSingleDomain(&responses.Domain{ID: uuid.New(), Domain: "example.com"}, SingleConfig{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "domain. has no fields")
+}
+
+func TestFieldHandler_MessagesAndErrors(t *testing.T) {
+	var out, errOut bytes.Buffer
+	handler := NewFieldHandler("id", &out, &errOut)
+	require.NoError(t, handler.HandleSimpleSuccess("Domain deleted"))
+	require.NoError(t, handler.HandleEmpty("No domains found"))
+	assert.Empty(t, out.String())
+	assert.Equal(t, "plain", handler.GetFormat())
+
+	err := handler.HandleError(errors.NewNotFoundError("domain not found", nil))
+	require.Error(t, err)
+	assert.Empty(t, out.String())
+	assert.Contains(t, errOut.String(), "domain not found")
+}
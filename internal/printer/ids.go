@@ -0,0 +1,158 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/spf13/cobra"
+
+	"github.com/AhaSend/ahasend-cli/internal/state"
+)
+
+// IDsOnlyFlag is the list command flag that prints only primary identifiers
+const IDsOnlyFlag = "ids-only"
+
+// AddIDsOnlyFlag registers --ids-only on a list command
+func AddIDsOnlyFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool(IDsOnlyFlag, false, "Print only the ID of each item, one per line, for use in shell pipelines")
+}
+
+// IDsOnly reports whether --ids-only is set on cmd
+func IDsOnly(cmd *cobra.Command) bool {
+	idsOnly, _ := cmd.Flags().GetBool(IDsOnlyFlag)
+	return idsOnly
+}
+
+// idsHandler prints the primary identifier of every listed item, one per
+// line, with no headers, pagination or messages; an empty list prints
+// nothing. Errors and all other responses go to the wrapped handler.
+type idsHandler struct {
+	ResponseHandler
+	writer io.Writer
+}
+
+// NewIDsOnlyHandler wraps handler so that list responses print only the
+// primary identifier of each item to writer
+func NewIDsOnlyHandler(handler ResponseHandler, writer io.Writer) ResponseHandler {
+	return &idsHandler{ResponseHandler: handler, writer: writer}
+}
+
+func (h *idsHandler) writeIDs(ids []string) error {
+	for _, id := range ids {
+		if _, err := fmt.Fprintln(h.writer, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Primary identifiers are what the other commands of a group take as their
+// argument: domain names for domains, email addresses for suppressions and
+// IDs for everything else.
+
+func (h *idsHandler) HandleDomainList(response *responses.PaginatedDomainsResponse, config ListConfig) error {
+	if response == nil {
+		return nil
+	}
+	ids := make([]string, len(response.Data))
+	for i, domain := range response.Data {
+		ids[i] = domain.Domain
+	}
+	return h.writeIDs(ids)
+}
+
+func (h *idsHandler) HandleMessageList(response *responses.PaginatedMessagesResponse, config ListConfig) error {
+	if response == nil {
+		return nil
+	}
+	ids := make([]string, len(response.Data))
+	for i, message := range response.Data {
+		ids[i] = message.ID.String()
+	}
+	return h.writeIDs(ids)
+}
+
+func (h *idsHandler) HandleWebhookList(response *responses.PaginatedWebhooksResponse, config ListConfig) error {
+	if response == nil {
+		return nil
+	}
+	ids := make([]string, len(response.Data))
+	for i, webhook := range response.Data {
+		ids[i] = webhook.ID.String()
+	}
+	return h.writeIDs(ids)
+}
+
+func (h *idsHandler) HandleRouteList(response *responses.PaginatedRoutesResponse, config ListConfig) error {
+	if response == nil {
+		return nil
+	}
+	ids := make([]string, len(response.Data))
+	for i, route := range response.Data {
+		ids[i] = route.ID.String()
+	}
+	return h.writeIDs(ids)
+}
+
+func (h *idsHandler) HandleSuppressionList(response *responses.PaginatedSuppressionsResponse, config ListConfig) error {
+	if response == nil {
+		return nil
+	}
+	ids := make([]string, len(response.Data))
+	for i, suppression := range response.Data {
+		ids[i] = suppression.Email
+	}
+	return h.writeIDs(ids)
+}
+
+func (h *idsHandler) HandleSMTPList(response *responses.PaginatedSMTPCredentialsResponse, config ListConfig) error {
+	if response == nil {
+		return nil
+	}
+	ids := make([]string, len(response.Data))
+	for i, credential := range response.Data {
+		ids[i] = credential.ID.String()
+	}
+	return h.writeIDs(ids)
+}
+
+func (h *idsHandler) HandleAPIKeyList(response *responses.PaginatedAPIKeysResponse, config ListConfig) error {
+	if response == nil {
+		return nil
+	}
+	ids := make([]string, len(response.Data))
+	for i, key := range response.Data {
+		ids[i] = key.ID.String()
+	}
+	return h.writeIDs(ids)
+}
+
+func (h *idsHandler) HandleSubAccountList(response *responses.PaginatedSubAccountsResponse, config ListConfig) error {
+	if response == nil {
+		return nil
+	}
+	ids := make([]string, len(response.Data))
+	for i, subAccount := range response.Data {
+		ids[i] = subAccount.ID.String()
+	}
+	return h.writeIDs(ids)
+}
+
+func (h *idsHandler) HandleReminderList(reminders []state.Reminder, config ListConfig) error {
+	ids := make([]string, len(reminders))
+	for i, reminder := range reminders {
+		ids[i] = reminder.ID
+	}
+	return h.writeIDs(ids)
+}
+
+// Messages are left out so pipelines only receive identifiers
+
+func (h *idsHandler) HandleSimpleSuccess(message string) error {
+	return nil
+}
+
+func (h *idsHandler) HandleEmpty(message string) error {
+	return nil
+}
@@ -0,0 +1,111 @@
+package printer
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/state"
+)
+
+func TestIDsOnlyHandler_PrintsPrimaryIdentifiers(t *testing.T) {
+	webhookA, webhookB := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name   string
+		handle func(ResponseHandler) error
+		want   string
+	}{
+		{
+			name: "domains by name",
+			handle: func(h ResponseHandler) error {
+				return h.HandleDomainList(&responses.PaginatedDomainsResponse{
+					Data: []responses.Domain{{ID: uuid.New(), Domain: "example.com"}, {ID: uuid.New(), Domain: "mail.example.org"}},
+				}, ListConfig{ShowPagination: true})
+			},
+			want: "example.com\nmail.example.org\n",
+		},
+		{
+			name: "suppressions by email",
+			handle: func(h ResponseHandler) error {
+				return h.HandleSuppressionList(&responses.PaginatedSuppressionsResponse{
+					Data: []responses.Suppression{{Email: "a@example.com"}, {Email: "b@example.com"}},
+				}, ListConfig{})
+			},
+			want: "a@example.com\nb@example.com\n",
+		},
+		{
+			name: "webhooks by ID",
+			handle: func(h ResponseHandler) error {
+				return h.HandleWebhookList(&responses.PaginatedWebhooksResponse{
+					Data: []responses.Webhook{{ID: webhookA, Name: "First"}, {ID: webhookB, Name: "Second"}},
+				}, ListConfig{IncludeStats: true})
+			},
+			want: webhookA.String() + "\n" + webhookB.String() + "\n",
+		},
+		{
+			name: "reminders by ID",
+			handle: func(h ResponseHandler) error {
+				return h.HandleReminderList([]state.Reminder{{ID: "r1", Message: "Revoke"}}, ListConfig{})
+			},
+			want: "r1\n",
+		},
+		{
+			name: "empty list",
+			handle: func(h ResponseHandler) error {
+				return h.HandleAPIKeyList(&responses.PaginatedAPIKeysResponse{}, ListConfig{EmptyMessage: "No API keys found"})
+			},
+			want: "",
+		},
+		{
+			name: "nil response",
+			handle: func(h ResponseHandler) error {
+				return h.HandleSMTPList(nil, ListConfig{EmptyMessage: "No SMTP credentials found"})
+			},
+			want: "",
+		},
+		{
+			name: "messages are suppressed",
+			handle: func(h ResponseHandler) error {
+				if err := h.HandleEmpty("No routes found"); err != nil {
+					return err
+				}
+				return h.HandleSimpleSuccess("Done")
+			},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			handler := NewIDsOnlyHandler(GetResponseHandler("table", false, &buf), &buf)
+			require.NoError(t, tt.handle(handler))
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestGetResponseHandlerFromCommand_IDsOnly(t *testing.T) {
+	cmd := &cobra.Command{Use: "list", Run: func(*cobra.Command, []string) {}}
+	AddIDsOnlyFlag(cmd)
+	require.NoError(t, cmd.ParseFlags([]string{"--ids-only"}))
+
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetContext(context.WithValue(context.Background(), ResponseHandlerKey, GetResponseHandler("json", false, &buf)))
+	handler := GetResponseHandlerFromCommand(cmd)
+	_, ok := handler.(*idsHandler)
+	assert.True(t, ok)
+
+	plain := &cobra.Command{Use: "get"}
+	plain.SetContext(cmd.Context())
+	_, ok = GetResponseHandlerFromCommand(plain).(*idsHandler)
+	assert.False(t, ok, "commands without the flag keep the regular handler")
+}
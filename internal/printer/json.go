@@ -4,16 +4,27 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"math"
 	"reflect"
 	"strings"
+	"time"
 
+	"github.com/AhaSend/ahasend-cli/internal/bounces"
+	"github.com/AhaSend/ahasend-cli/internal/dns"
+	"github.com/AhaSend/ahasend-cli/internal/jsonmerge"
+	"github.com/AhaSend/ahasend-cli/internal/state"
 	"github.com/AhaSend/ahasend-go/api"
+	"github.com/AhaSend/ahasend-go/models/common"
+	"github.com/AhaSend/ahasend-go/models/requests"
 	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/google/uuid"
 )
 
 // jsonHandler handles JSON output formatting with complete type safety
 type jsonHandler struct {
 	handlerBase
+	lines bool   // write JSON Lines, see jsonlHandler
+	field string // print only this field, see fieldHandler
 }
 
 // GetFormat returns the format name
@@ -39,8 +50,9 @@ func (h *jsonHandler) HandleError(err error) error {
 			var rawData interface{}
 			if json.Unmarshal(apiErr.Raw, &rawData) == nil {
 				// Output the raw API response and return nil (exit code 0)
-				// This is intentional - in JSON mode we faithfully output API responses
-				return h.printJSON(rawData)
+				// This is intentional - in JSON mode we faithfully output API
+				// responses, so they carry no schema_version either
+				return h.writeJSON(h.removeEmptyAdditionalProperties(rawData))
 			}
 		}
 		// If no raw response, return the APIError fields as JSON
@@ -83,7 +95,7 @@ func (h *jsonHandler) HandleDomainList(response *responses.PaginatedDomainsRespo
 	if response == nil {
 		return h.HandleEmpty(config.EmptyMessage)
 	}
-	return h.printJSON(response)
+	return h.printList(response, config)
 }
 
 func (h *jsonHandler) HandleSingleDomain(domain *responses.Domain, config SingleConfig) error {
@@ -93,12 +105,167 @@ func (h *jsonHandler) HandleSingleDomain(domain *responses.Domain, config Single
 	return h.printJSON(domain)
 }
 
+func (h *jsonHandler) HandleDNSPropagation(matrix *dns.PropagationMatrix, config SingleConfig) error {
+	if matrix == nil {
+		return h.HandleEmpty(config.EmptyMessage)
+	}
+
+	type recordJSON struct {
+		Type     string            `json:"type"`
+		Host     string            `json:"host"`
+		Content  string            `json:"content"`
+		Required bool              `json:"required"`
+		Statuses map[string]string `json:"statuses"` // resolver -> status
+	}
+	records := make([]recordJSON, len(matrix.Records))
+	for i, record := range matrix.Records {
+		statuses := make(map[string]string, len(matrix.Resolvers))
+		for j, resolver := range matrix.Resolvers {
+			statuses[resolver] = string(record.Statuses[j])
+		}
+		records[i] = recordJSON{
+			Type:     record.Type,
+			Host:     record.Host,
+			Content:  record.Content,
+			Required: record.Required,
+			Statuses: statuses,
+		}
+	}
+
+	return h.printJSON(struct {
+		Object    string       `json:"object"`
+		Domain    string       `json:"domain"`
+		Resolvers []string     `json:"resolvers"`
+		Complete  bool         `json:"complete"`
+		CheckedAt time.Time    `json:"checked_at"`
+		Records   []recordJSON `json:"records"`
+	}{
+		Object:    "dns_propagation",
+		Domain:    matrix.Domain,
+		Resolvers: matrix.Resolvers,
+		Complete:  matrix.Complete(),
+		CheckedAt: matrix.CheckedAt,
+		Records:   records,
+	})
+}
+
+func (h *jsonHandler) HandleDNSVerification(domain *responses.Domain, verification *dns.Verification, config SingleConfig) error {
+	if domain == nil || verification == nil {
+		return h.HandleEmpty(config.EmptyMessage)
+	}
+
+	type failureJSON struct {
+		Type           string   `json:"type"`
+		Host           string   `json:"host"`
+		Required       bool     `json:"required"`
+		Reason         string   `json:"reason"`
+		Detail         string   `json:"detail"`
+		Expected       string   `json:"expected"`
+		Observed       []string `json:"observed"`
+		ObservedSource string   `json:"observed_source"`
+	}
+	failures := make([]failureJSON, len(verification.Failures))
+	for i, failure := range verification.Failures {
+		observed := failure.Observed
+		if observed == nil {
+			observed = []string{}
+		}
+		failures[i] = failureJSON{
+			Type:           failure.Type,
+			Host:           failure.Host,
+			Required:       failure.Required,
+			Reason:         string(failure.Reason),
+			Detail:         failure.Detail,
+			Expected:       failure.Expected,
+			Observed:       observed,
+			ObservedSource: failure.ObservedSource,
+		}
+	}
+
+	return h.printJSON(struct {
+		Object         string        `json:"object"`
+		Domain         string        `json:"domain"`
+		State          string        `json:"state"`
+		DNSValid       bool          `json:"dns_valid"`
+		RequiredPassed int           `json:"required_passed"`
+		RequiredTotal  int           `json:"required_total"`
+		Resolvers      []string      `json:"resolvers"`
+		Failures       []failureJSON `json:"failures"`
+		LastDNSCheckAt *time.Time    `json:"last_dns_check_at"`
+		CheckedAt      time.Time     `json:"checked_at"`
+	}{
+		Object:         "dns_verification",
+		Domain:         verification.Domain,
+		State:          string(verification.State),
+		DNSValid:       domain.DNSValid,
+		RequiredPassed: verification.RequiredPassed,
+		RequiredTotal:  verification.RequiredTotal,
+		Resolvers:      verification.Resolvers,
+		Failures:       failures,
+		LastDNSCheckAt: domain.LastDNSCheckAt,
+		CheckedAt:      verification.CheckedAt,
+	})
+}
+
+func (h *jsonHandler) HandleDomainVerification(verification *dns.LocalVerification, config SingleConfig) error {
+	if verification == nil {
+		return h.HandleEmpty(config.EmptyMessage)
+	}
+
+	type recordJSON struct {
+		Type     string   `json:"type"`
+		Host     string   `json:"host"`
+		Required bool     `json:"required"`
+		Status   string   `json:"status"`
+		Expected string   `json:"expected"`
+		Observed []string `json:"observed"`
+		Error    string   `json:"error,omitempty"`
+	}
+	records := make([]recordJSON, len(verification.Records))
+	for i, record := range verification.Records {
+		observed := record.Observed
+		if observed == nil {
+			observed = []string{}
+		}
+		records[i] = recordJSON{
+			Type:     record.Type,
+			Host:     record.Host,
+			Required: record.Required,
+			Status:   string(record.Status),
+			Expected: record.Expected,
+			Observed: observed,
+			Error:    record.Error,
+		}
+	}
+
+	found, total := verification.RequiredCounts()
+	return h.printJSON(struct {
+		Object        string       `json:"object"`
+		Domain        string       `json:"domain"`
+		Resolver      string       `json:"resolver"`
+		State         string       `json:"state"`
+		RequiredFound int          `json:"required_found"`
+		RequiredTotal int          `json:"required_total"`
+		Records       []recordJSON `json:"records"`
+		CheckedAt     time.Time    `json:"checked_at"`
+	}{
+		Object:        "domain_verification",
+		Domain:        verification.Domain,
+		Resolver:      verification.Resolver,
+		State:         string(verification.State()),
+		RequiredFound: found,
+		RequiredTotal: total,
+		Records:       records,
+		CheckedAt:     verification.CheckedAt,
+	})
+}
+
 // Message responses
 func (h *jsonHandler) HandleMessageList(response *responses.PaginatedMessagesResponse, config ListConfig) error {
 	if response == nil {
 		return h.HandleEmpty(config.EmptyMessage)
 	}
-	return h.printJSON(response)
+	return h.printList(response, config)
 }
 
 func (h *jsonHandler) HandleSingleMessage(message *responses.Message, config SingleConfig) error {
@@ -112,7 +279,29 @@ func (h *jsonHandler) HandleCreateMessage(response *responses.CreateMessageRespo
 	if response == nil {
 		return h.HandleEmpty("No response received")
 	}
-	return h.printJSON(response)
+	summary := SummarizeCreateMessage(response)
+	if len(config.Metadata) == 0 {
+		return h.printJSON(struct {
+			Object  string                                  `json:"object"`
+			Data    []responses.CreateSingleMessageResponse `json:"data"`
+			Summary CreateMessageSummary                    `json:"summary"`
+		}{
+			Object:  response.Object,
+			Data:    response.Data,
+			Summary: summary,
+		})
+	}
+	return h.printJSON(struct {
+		Object   string                                  `json:"object"`
+		Data     []responses.CreateSingleMessageResponse `json:"data"`
+		Summary  CreateMessageSummary                    `json:"summary"`
+		Metadata map[string]string                       `json:"metadata"`
+	}{
+		Object:   response.Object,
+		Data:     response.Data,
+		Summary:  summary,
+		Metadata: config.Metadata,
+	})
 }
 
 func (h *jsonHandler) HandleCancelMessage(response *CancelMessageResponse, config SimpleConfig) error {
@@ -122,12 +311,188 @@ func (h *jsonHandler) HandleCancelMessage(response *CancelMessageResponse, confi
 	return h.printJSON(response)
 }
 
+func (h *jsonHandler) HandleMessageExport(summary *MessageExportSummary, config SimpleConfig) error {
+	if summary == nil {
+		return h.HandleEmpty(config.SuccessMessage)
+	}
+	skips := summary.Skips
+	if skips == nil {
+		skips = []MessageExportSkip{}
+	}
+	return h.printJSON(struct {
+		Object string `json:"object"`
+		MessageExportSummary
+		Skips []MessageExportSkip `json:"skips"`
+	}{
+		Object:               "message_export",
+		MessageExportSummary: *summary,
+		Skips:                skips,
+	})
+}
+
+func (h *jsonHandler) HandleDryRunMessage(dryRun *DryRunMessage, config SimpleConfig) error {
+	if dryRun == nil {
+		return h.HandleEmpty(config.SuccessMessage)
+	}
+
+	type batchJSON struct {
+		IdempotencyKey string                 `json:"idempotency_key"`
+		Recipients     int                    `json:"recipients"`
+		Request        map[string]interface{} `json:"request"`
+	}
+	batches := make([]batchJSON, len(dryRun.Batches))
+	for i, batch := range dryRun.Batches {
+		request, err := dryRunRequestJSON(batch.Request)
+		if err != nil {
+			return err
+		}
+		batches[i] = batchJSON{batch.IdempotencyKey, batch.Recipients, request}
+	}
+
+	summary := *dryRun
+	summary.Cc = nonNilStrings(summary.Cc)
+	summary.Bcc = nonNilStrings(summary.Bcc)
+	summary.Tags = nonNilStrings(summary.Tags)
+	summary.DomainDefaults = nonNilStrings(summary.DomainDefaults)
+	if summary.Metadata == nil {
+		summary.Metadata = map[string]string{}
+	}
+	if summary.Content == nil {
+		summary.Content = []DryRunContent{}
+	}
+	if summary.Attachments == nil {
+		summary.Attachments = []DryRunAttachment{}
+	}
+	return h.printJSON(struct {
+		Object string `json:"object"`
+		DryRunMessage
+		Batches []batchJSON `json:"batches"`
+	}{
+		Object:        "message_dry_run",
+		DryRunMessage: summary,
+		Batches:       batches,
+	})
+}
+
+// dryRunRequestJSON is a create message request as sent, except that the
+// data of each attachment is replaced with its size as data_bytes
+func dryRunRequestJSON(request *requests.CreateMessageRequest) (map[string]interface{}, error) {
+	if request == nil {
+		return nil, nil
+	}
+	encoded, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(encoded, &result); err != nil {
+		return nil, err
+	}
+	if attachments, ok := result["attachments"].([]interface{}); ok {
+		for i, item := range attachments {
+			if attachment, ok := item.(map[string]interface{}); ok && i < len(request.Attachments) {
+				delete(attachment, "data")
+				attachment["data_bytes"] = attachmentSize(request.Attachments[i])
+			}
+		}
+	}
+	return result, nil
+}
+
+// nonNilStrings returns values, or an empty slice instead of nil so that it
+// is encoded as [] rather than null
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+func (h *jsonHandler) HandleMessageAttempts(report *MessageAttemptsReport, config SingleConfig) error {
+	if report == nil {
+		return h.HandleEmpty(config.EmptyMessage)
+	}
+
+	type attemptJSON struct {
+		Attempt         int       `json:"attempt"`
+		Time            time.Time `json:"time"`
+		RemoteHost      string    `json:"remote_host"`
+		Status          string    `json:"status"`
+		SMTPCode        int       `json:"smtp_code"`
+		SMTPResponse    string    `json:"smtp_response"`
+		IntervalSeconds float64   `json:"interval_seconds"`
+	}
+	attempts := make([]attemptJSON, len(report.Attempts))
+	for i, attempt := range report.Attempts {
+		attempts[i] = attemptJSON{
+			Attempt:         attempt.Attempt,
+			Time:            attempt.Time,
+			RemoteHost:      attempt.RemoteHost,
+			Status:          attempt.Status,
+			SMTPCode:        attempt.SMTPCode,
+			SMTPResponse:    attempt.SMTPResponse,
+			IntervalSeconds: attempt.SincePrevious.Seconds(),
+		}
+	}
+
+	return h.printJSON(struct {
+		Object               string        `json:"object"`
+		MessageID            string        `json:"message_id"`
+		Recipient            string        `json:"recipient"`
+		Status               string        `json:"status"`
+		CreatedAt            time.Time     `json:"created_at"`
+		Final                bool          `json:"final"`
+		TotalDurationSeconds float64       `json:"total_duration_seconds"`
+		Attempts             []attemptJSON `json:"attempts"`
+	}{
+		Object:               "message_attempts",
+		MessageID:            report.MessageID,
+		Recipient:            report.Recipient,
+		Status:               report.Status,
+		CreatedAt:            report.CreatedAt,
+		Final:                report.Final,
+		TotalDurationSeconds: report.TotalDuration.Seconds(),
+		Attempts:             attempts,
+	})
+}
+
+func (h *jsonHandler) HandleMessageContent(content *MessageContent, config SingleConfig) error {
+	if content == nil {
+		return h.HandleEmpty(config.EmptyMessage)
+	}
+	result := *content
+	if result.Headers == nil {
+		result.Headers = []MessageHeader{}
+	}
+	if result.Parts == nil {
+		result.Parts = []MessageContentPart{}
+	}
+	if result.Attachments == nil {
+		result.Attachments = []MessageContentAttachment{}
+	}
+	return h.printJSON(struct {
+		Object string `json:"object"`
+		MessageContent
+	}{
+		Object:         "message_content",
+		MessageContent: result,
+	})
+}
+
 // Webhook responses
 func (h *jsonHandler) HandleWebhookList(response *responses.PaginatedWebhooksResponse, config ListConfig) error {
 	if response == nil {
 		return h.HandleEmpty(config.EmptyMessage)
 	}
-	return h.printJSON(response)
+	if config.IncludeStats {
+		return h.printList(struct {
+			Object     string                `json:"object"`
+			Data       []responses.Webhook   `json:"data"`
+			Pagination common.PaginationInfo `json:"pagination"`
+			Summary    WebhookStatsSummary   `json:"summary"`
+		}{response.Object, response.Data, response.Pagination, summarizeWebhookStats(response.Data)}, config)
+	}
+	return h.printList(response, config)
 }
 
 func (h *jsonHandler) HandleSingleWebhook(webhook *responses.Webhook, config SingleConfig) error {
@@ -151,6 +516,29 @@ func (h *jsonHandler) HandleUpdateWebhook(webhook *responses.Webhook, config Upd
 	return h.printJSON(webhook)
 }
 
+func (h *jsonHandler) HandleRotateWebhookSecret(webhook *responses.Webhook, config UpdateConfig) error {
+	if webhook == nil {
+		return h.HandleEmpty("No webhook secret rotated")
+	}
+	return h.printJSON(webhook)
+}
+
+func (h *jsonHandler) HandleWebhookEdit(edit *WebhookEdit, config UpdateConfig) error {
+	if edit == nil {
+		return h.HandleEmpty("No webhook edited")
+	}
+	changes := edit.Changes
+	if changes == nil {
+		changes = []jsonmerge.Change{}
+	}
+	return h.printJSON(struct {
+		Object    string             `json:"object"`
+		WebhookID string             `json:"webhook_id"`
+		Name      string             `json:"name"`
+		Changes   []jsonmerge.Change `json:"changes"`
+	}{"webhook_edit", edit.WebhookID, edit.Name, changes})
+}
+
 func (h *jsonHandler) HandleDeleteWebhook(success bool, config DeleteConfig) error {
 	result := map[string]interface{}{
 		"success": success,
@@ -169,21 +557,137 @@ func (h *jsonHandler) HandleTriggerWebhook(webhookID string, events []string, co
 	return h.printJSON(result)
 }
 
+func (h *jsonHandler) HandleTriggerWebhookOverrides(trigger *WebhookTrigger, config TriggerConfig) error {
+	if trigger == nil {
+		return h.HandleEmpty("No events triggered")
+	}
+	return h.printJSON(struct {
+		Object    string                `json:"object"`
+		WebhookID string                `json:"webhook_id"`
+		URL       string                `json:"url"`
+		Events    []WebhookTriggerEvent `json:"events"`
+		Failed    int                   `json:"failed"`
+		Message   string                `json:"message"`
+	}{
+		Object:    "webhook_trigger",
+		WebhookID: trigger.WebhookID,
+		URL:       trigger.URL,
+		Events:    trigger.Events,
+		Failed:    trigger.Failed(),
+		Message:   config.SuccessMessage,
+	})
+}
+
+func (h *jsonHandler) HandleWebhookCoverage(report *WebhookCoverageReport, config SingleConfig) error {
+	if report == nil {
+		return h.HandleEmpty(config.EmptyMessage)
+	}
+	return h.printJSON(struct {
+		Object   string            `json:"object"`
+		Since    time.Time         `json:"since"`
+		Webhooks []CoverageWebhook `json:"webhooks"`
+		Events   []EventCoverage   `json:"events"`
+		Findings []CoverageFinding `json:"findings"`
+		Gaps     int               `json:"gaps"`
+	}{
+		Object:   "webhook_coverage",
+		Since:    report.Since,
+		Webhooks: report.Webhooks,
+		Events:   report.Events,
+		Findings: report.Findings,
+		Gaps:     len(report.Gaps()),
+	})
+}
+
+func (h *jsonHandler) HandleWebhookVerification(verification *WebhookVerification, config SingleConfig) error {
+	if verification == nil {
+		return h.HandleEmpty(config.EmptyMessage)
+	}
+	problems := verification.Problems
+	if problems == nil {
+		problems = []string{}
+	}
+	return h.printJSON(struct {
+		Object string `json:"object"`
+		WebhookVerification
+		Problems []string `json:"problems"`
+	}{
+		Object:              "webhook_verification",
+		WebhookVerification: *verification,
+		Problems:            problems,
+	})
+}
+
+func (h *jsonHandler) HandleWebhookSimulation(simulation *WebhookSimulation, config SingleConfig) error {
+	if simulation == nil {
+		return h.HandleEmpty(config.EmptyMessage)
+	}
+	return h.printJSON(struct {
+		Object    string                   `json:"object"`
+		WebhookID string                   `json:"webhook_id"`
+		URL       string                   `json:"url"`
+		Event     string                   `json:"event"`
+		Seed      uint64                   `json:"seed"`
+		Sent      bool                     `json:"sent"`
+		Events    []WebhookSimulationEvent `json:"events"`
+		Failed    int                      `json:"failed"`
+	}{
+		Object:    "webhook_simulation",
+		WebhookID: simulation.WebhookID,
+		URL:       simulation.URL,
+		Event:     simulation.Event,
+		Seed:      simulation.Seed,
+		Sent:      simulation.Sent,
+		Events:    simulation.Events,
+		Failed:    simulation.Failed(),
+	})
+}
+
 // Route responses
 func (h *jsonHandler) HandleRouteList(response *responses.PaginatedRoutesResponse, config ListConfig) error {
 	if response == nil {
 		return h.HandleEmpty(config.EmptyMessage)
 	}
-	return h.printJSON(response)
+	if config.ExpandedPatterns != nil {
+		return h.printList(struct {
+			Object           string                `json:"object"`
+			Data             []responses.Route     `json:"data"`
+			Pagination       common.PaginationInfo `json:"pagination"`
+			ExpandedPatterns []expandedPatternJSON `json:"expanded_patterns"`
+		}{response.Object, response.Data, response.Pagination, expandedPatternsJSON(config.ExpandedPatterns)}, config)
+	}
+	return h.printList(response, config)
 }
 
 func (h *jsonHandler) HandleSingleRoute(route *responses.Route, config SingleConfig) error {
 	if route == nil {
 		return h.HandleEmpty(config.EmptyMessage)
 	}
+	if config.ExpandedPatterns != nil {
+		return h.printJSON(struct {
+			*responses.Route
+			ExpandedPatterns []expandedPatternJSON `json:"expanded_patterns"`
+		}{route, expandedPatternsJSON(config.ExpandedPatterns)})
+	}
 	return h.printJSON(route)
 }
 
+// expandedPatternJSON is the JSON form of an ExpandedPattern
+type expandedPatternJSON struct {
+	RouteID uuid.UUID `json:"route_id"`
+	Pattern string    `json:"pattern"`
+	Domain  string    `json:"domain,omitempty"`
+	Status  string    `json:"status"`
+}
+
+func expandedPatternsJSON(patterns []ExpandedPattern) []expandedPatternJSON {
+	result := make([]expandedPatternJSON, len(patterns))
+	for i, pattern := range patterns {
+		result[i] = expandedPatternJSON(pattern)
+	}
+	return result
+}
+
 func (h *jsonHandler) HandleCreateRoute(route *responses.Route, config CreateConfig) error {
 	if route == nil {
 		return h.HandleEmpty("No route created")
@@ -216,12 +720,25 @@ func (h *jsonHandler) HandleTriggerRoute(routeID string, config TriggerConfig) e
 	return h.printJSON(result)
 }
 
+func (h *jsonHandler) HandleRouteExplanation(explanation *RouteExplanation, config SingleConfig) error {
+	if explanation == nil {
+		return h.HandleEmpty(config.EmptyMessage)
+	}
+	return h.printJSON(struct {
+		Object string `json:"object"`
+		*RouteExplanation
+	}{
+		Object:           "route_explanation",
+		RouteExplanation: explanation,
+	})
+}
+
 // Suppression responses
 func (h *jsonHandler) HandleSuppressionList(response *responses.PaginatedSuppressionsResponse, config ListConfig) error {
 	if response == nil {
 		return h.HandleEmpty(config.EmptyMessage)
 	}
-	return h.printJSON(response)
+	return h.printList(response, config)
 }
 
 func (h *jsonHandler) HandleSingleSuppression(suppression *responses.Suppression, config SingleConfig) error {
@@ -255,6 +772,75 @@ func (h *jsonHandler) HandleWipeSuppression(count int, config WipeConfig) error
 	return h.printJSON(result)
 }
 
+func (h *jsonHandler) HandleSuppressionWipeSummary(summary *SuppressionWipeSummary, config WipeConfig) error {
+	if summary == nil {
+		return h.HandleEmpty(config.SuccessMessage)
+	}
+	reasons := summary.Reasons
+	if reasons == nil {
+		reasons = []SuppressionReasonCount{}
+	}
+	return h.printJSON(struct {
+		Object   string                   `json:"object"`
+		Domain   string                   `json:"domain,omitempty"`
+		Match    string                   `json:"match,omitempty"`
+		DryRun   bool                     `json:"dry_run"`
+		Matched  int                      `json:"matched"`
+		Reasons  []SuppressionReasonCount `json:"reasons"`
+		Deleted  int                      `json:"deleted"`
+		Failed   int                      `json:"failed"`
+		Failures []SuppressionWipeFailure `json:"failures,omitempty"`
+	}{
+		Object:   "suppression_wipe",
+		Domain:   summary.Domain,
+		Match:    summary.Match,
+		DryRun:   summary.DryRun,
+		Matched:  summary.Matched,
+		Reasons:  reasons,
+		Deleted:  summary.Deleted,
+		Failed:   summary.Failed,
+		Failures: summary.Failures,
+	})
+}
+
+func (h *jsonHandler) HandleBounceSuppressions(summary *BounceSuppressionSummary, config CreateConfig) error {
+	if summary == nil {
+		return h.HandleEmpty(config.SuccessMessage)
+	}
+	classifications := summary.Classifications
+	if classifications == nil {
+		classifications = []string{}
+	}
+	return h.printJSON(struct {
+		Object string `json:"object"`
+		BounceSuppressionSummary
+		Classifications []string `json:"classifications"`
+	}{
+		Object:                   "suppression_bounce_import",
+		BounceSuppressionSummary: *summary,
+		Classifications:          classifications,
+	})
+}
+
+func (h *jsonHandler) HandleSuppressionImport(summary *SuppressionImportSummary, config CreateConfig) error {
+	if summary == nil {
+		return h.HandleEmpty(config.SuccessMessage)
+	}
+	errors := summary.Errors
+	if errors == nil {
+		errors = []SuppressionImportError{}
+	}
+	return h.printJSON(struct {
+		Object string `json:"object"`
+		SuppressionImportSummary
+		Errors []SuppressionImportError `json:"errors"`
+	}{
+		Object:                   "suppression_import",
+		SuppressionImportSummary: *summary,
+		Errors:                   errors,
+	})
+}
+
 func (h *jsonHandler) HandleCheckSuppression(suppression *responses.Suppression, found bool, config CheckConfig) error {
 	result := map[string]interface{}{
 		"found": found,
@@ -275,7 +861,7 @@ func (h *jsonHandler) HandleSMTPList(response *responses.PaginatedSMTPCredential
 	if response == nil {
 		return h.HandleEmpty(config.EmptyMessage)
 	}
-	return h.printJSON(response)
+	return h.printList(response, config)
 }
 
 func (h *jsonHandler) HandleSingleSMTP(credential *responses.SMTPCredential, config SingleConfig) error {
@@ -292,6 +878,56 @@ func (h *jsonHandler) HandleCreateSMTP(credential *responses.SMTPCredential, con
 	return h.printJSON(credential)
 }
 
+func (h *jsonHandler) HandleUpdateSMTP(update *SMTPCredentialUpdate, config UpdateConfig) error {
+	if update == nil || update.Credential == nil {
+		return h.HandleEmpty("No credential updated")
+	}
+	credential := update.Credential
+	added, removed := update.AddedDomains, update.RemovedDomains
+	if added == nil {
+		added = []string{}
+	}
+	if removed == nil {
+		removed = []string{}
+	}
+	// The credential without its password, which is only ever shown on
+	// creation
+	type updatedCredential struct {
+		Object    string    `json:"object"`
+		ID        uuid.UUID `json:"id"`
+		CreatedAt time.Time `json:"created_at"`
+		UpdatedAt time.Time `json:"updated_at"`
+		Name      string    `json:"name"`
+		Username  string    `json:"username"`
+		Sandbox   bool      `json:"sandbox"`
+		Scope     string    `json:"scope"`
+		Domains   []string  `json:"domains"`
+	}
+	return h.printJSON(struct {
+		Object         string            `json:"object"`
+		Credential     updatedCredential `json:"credential"`
+		DryRun         bool              `json:"dry_run"`
+		AddedDomains   []string          `json:"added_domains"`
+		RemovedDomains []string          `json:"removed_domains"`
+	}{
+		Object: "smtp_credential_update",
+		Credential: updatedCredential{
+			Object:    credential.Object,
+			ID:        credential.ID,
+			CreatedAt: credential.CreatedAt,
+			UpdatedAt: credential.UpdatedAt,
+			Name:      credential.Name,
+			Username:  credential.Username,
+			Sandbox:   credential.Sandbox,
+			Scope:     credential.Scope,
+			Domains:   credential.Domains,
+		},
+		DryRun:         update.DryRun,
+		AddedDomains:   added,
+		RemovedDomains: removed,
+	})
+}
+
 func (h *jsonHandler) HandleDeleteSMTP(success bool, config DeleteConfig) error {
 	result := map[string]interface{}{
 		"success": success,
@@ -307,12 +943,27 @@ func (h *jsonHandler) HandleSMTPSend(result *SMTPSendResult, config SMTPSendConf
 	return h.printJSON(result)
 }
 
+func (h *jsonHandler) HandleSMTPUsage(report *SMTPUsageReport, config SingleConfig) error {
+	if report == nil {
+		return h.HandleEmpty(config.EmptyMessage)
+	}
+	return h.printJSON(struct {
+		Object string `json:"object"`
+		*SMTPUsageReport
+		Unused int `json:"unused"`
+	}{
+		Object:          "smtp_usage",
+		SMTPUsageReport: report,
+		Unused:          len(report.Unused()),
+	})
+}
+
 // API Key responses
 func (h *jsonHandler) HandleAPIKeyList(response *responses.PaginatedAPIKeysResponse, config ListConfig) error {
 	if response == nil {
 		return h.HandleEmpty(config.EmptyMessage)
 	}
-	return h.printJSON(response)
+	return h.printList(response, config)
 }
 
 func (h *jsonHandler) HandleSingleAPIKey(key *responses.APIKey, config SingleConfig) error {
@@ -349,7 +1000,7 @@ func (h *jsonHandler) HandleSubAccountList(response *responses.PaginatedSubAccou
 	if response == nil {
 		return h.HandleEmpty(config.EmptyMessage)
 	}
-	return h.printJSON(response)
+	return h.printList(response, config)
 }
 
 func (h *jsonHandler) HandleSingleSubAccount(subAccount *responses.SubAccount, config SingleConfig) error {
@@ -381,11 +1032,292 @@ func (h *jsonHandler) HandleDeliverabilityStats(response *responses.Deliverabili
 	return h.printJSON(response)
 }
 
+// HandleDeliverabilityStatsChunk writes each bucket as one compact JSON line
+// (JSONL), so streamed output can be consumed before the range completes
+func (h *jsonHandler) HandleDeliverabilityStatsChunk(response *responses.DeliverabilityStatisticsResponse, config StatsConfig) error {
+	if response == nil {
+		return nil
+	}
+	encoder := json.NewEncoder(h.writer)
+	encoder.SetEscapeHTML(false)
+	for _, stat := range response.Data {
+		if h.field != "" {
+			if err := h.writeField(h.removeEmptyAdditionalProperties(stat)); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := encoder.Encode(withSchemaVersion(h.removeEmptyAdditionalProperties(stat))); err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+	}
+	return nil
+}
+
+func (h *jsonHandler) HandleDeliverabilitySummary(summary *DeliverabilitySummary, config StatsConfig) error {
+	if summary == nil {
+		return h.HandleEmpty("No statistics available")
+	}
+	return h.printJSON(struct {
+		Object       string    `json:"object"`
+		From         time.Time `json:"from"`
+		To           time.Time `json:"to"`
+		Buckets      int       `json:"buckets"`
+		Reception    int       `json:"reception_count"`
+		Delivered    int       `json:"delivered_count"`
+		Deferred     int       `json:"deferred_count"`
+		Bounced      int       `json:"bounced_count"`
+		Failed       int       `json:"failed_count"`
+		Suppressed   int       `json:"suppressed_count"`
+		Opened       int       `json:"opened_count"`
+		Clicked      int       `json:"clicked_count"`
+		DeliveryRate *float64  `json:"delivery_rate"`
+		BounceRate   *float64  `json:"bounce_rate"`
+		OpenRate     *float64  `json:"open_rate"`
+		ClickRate    *float64  `json:"click_rate"`
+	}{
+		Object:       "deliverability_summary",
+		From:         summary.From,
+		To:           summary.To,
+		Buckets:      summary.Buckets,
+		Reception:    summary.Reception,
+		Delivered:    summary.Delivered,
+		Deferred:     summary.Deferred,
+		Bounced:      summary.Bounced,
+		Failed:       summary.Failed,
+		Suppressed:   summary.Suppressed,
+		Opened:       summary.Opened,
+		Clicked:      summary.Clicked,
+		DeliveryRate: summary.DeliveryRate,
+		BounceRate:   summary.BounceRate,
+		OpenRate:     summary.OpenRate,
+		ClickRate:    summary.ClickRate,
+	})
+}
+
+// HandleDeliverabilityByTag nests each tag's summary and, unless the report
+// is summary-only, its buckets under the tag
+func (h *jsonHandler) HandleDeliverabilityByTag(report *DeliverabilityByTag, config StatsConfig) error {
+	if report == nil {
+		return h.HandleEmpty("No statistics available")
+	}
+
+	type tagJSON struct {
+		Tag     string                  `json:"tag"`
+		Status  string                  `json:"status"`
+		Error   string                  `json:"error,omitempty"`
+		Summary *DeliverabilitySummary  `json:"summary,omitempty"`
+		Buckets *[]DeliverabilityBucket `json:"buckets,omitempty"`
+	}
+	tags := make([]tagJSON, len(report.Tags))
+	for i, tag := range report.Tags {
+		tags[i] = tagJSON{Tag: tag.Tag, Status: tag.Status, Error: tag.Error, Summary: tag.Summary}
+		if tag.Status == TagStatusAvailable && !report.SummaryOnly {
+			buckets := tag.Buckets
+			if buckets == nil {
+				buckets = []DeliverabilityBucket{}
+			}
+			tags[i].Buckets = &buckets
+		}
+	}
+
+	return h.printJSON(struct {
+		Object  string    `json:"object"`
+		From    time.Time `json:"from"`
+		To      time.Time `json:"to"`
+		GroupBy string    `json:"group_by"`
+		Tags    []tagJSON `json:"tags"`
+	}{
+		Object:  "deliverability_by_tag",
+		From:    report.From,
+		To:      report.To,
+		GroupBy: report.GroupBy,
+		Tags:    tags,
+	})
+}
+
+func (h *jsonHandler) HandleBounceExplanations(explanations []bounces.Explanation, config ListConfig) error {
+	if explanations == nil {
+		explanations = []bounces.Explanation{}
+	}
+	return h.printList(struct {
+		Object string                `json:"object"`
+		Data   []bounces.Explanation `json:"data"`
+	}{
+		Object: "list",
+		Data:   explanations,
+	}, config)
+}
+
+func (h *jsonHandler) HandleReminderList(reminders []state.Reminder, config ListConfig) error {
+	if reminders == nil {
+		reminders = []state.Reminder{}
+	}
+	return h.printList(struct {
+		Object string           `json:"object"`
+		Data   []state.Reminder `json:"data"`
+	}{
+		Object: "list",
+		Data:   reminders,
+	}, config)
+}
+
+func (h *jsonHandler) HandleStatsAnomalies(report *AnomalyReport, config StatsConfig) error {
+	anomalies := report.Anomalies
+	if anomalies == nil {
+		anomalies = []AnomalyBucket{}
+	}
+	return h.printJSON(struct {
+		Object      string          `json:"object"`
+		Metric      string          `json:"metric"`
+		GroupBy     string          `json:"group_by"`
+		From        time.Time       `json:"from"`
+		To          time.Time       `json:"to"`
+		Window      int             `json:"window"`
+		Sensitivity float64         `json:"sensitivity"`
+		Buckets     int             `json:"buckets"`
+		Scored      int             `json:"scored"`
+		Anomalies   []AnomalyBucket `json:"anomalies"`
+		Verdict     string          `json:"verdict"`
+	}{
+		Object:      "stats_anomalies",
+		Metric:      report.Metric,
+		GroupBy:     report.GroupBy,
+		From:        report.From,
+		To:          report.To,
+		Window:      report.Window,
+		Sensitivity: report.Sensitivity,
+		Buckets:     report.Buckets,
+		Scored:      report.Scored,
+		Anomalies:   anomalies,
+		Verdict:     report.Verdict(),
+	})
+}
+
+func (h *jsonHandler) HandleDeliverabilityComparison(comparison *DeliverabilityComparison, config StatsConfig) error {
+	if comparison == nil {
+		return h.HandleEmpty("No statistics available")
+	}
+	return h.printJSON(struct {
+		Object         string             `json:"object"`
+		CurrentPeriod  ComparisonPeriod   `json:"current_period"`
+		PreviousPeriod ComparisonPeriod   `json:"previous_period"`
+		Metrics        []MetricComparison `json:"metrics"`
+	}{
+		Object:         "deliverability_comparison",
+		CurrentPeriod:  comparison.CurrentPeriod,
+		PreviousPeriod: comparison.PreviousPeriod,
+		Metrics:        comparison.Metrics,
+	})
+}
+
 func (h *jsonHandler) HandleBounceStats(response *responses.BounceStatisticsResponse, config StatsConfig) error {
 	if response == nil {
 		return h.HandleEmpty("No statistics available")
 	}
-	return h.printJSON(response)
+	if config.Aggregate {
+		return h.printBounceTotals(response, config)
+	}
+	if !config.Explain {
+		return h.printJSON(response)
+	}
+
+	explained := explainedBounceStats{Object: response.Object, Data: []explainedBounceBucket{}}
+	for _, stat := range response.Data {
+		bucket := explainedBounceBucket{
+			FromTimestamp: stat.FromTimestamp,
+			ToTimestamp:   stat.ToTimestamp,
+			Bounces:       []explainedBounce{},
+		}
+		for _, bounce := range stat.Bounces {
+			item := explainedBounce{Classification: bounce.Classification, Count: bounce.Count}
+			if description, action := explainBounce(bounce.Classification); description != "" {
+				item.Explanation = &bounceExplanation{Description: description, Action: action}
+			}
+			bucket.Bounces = append(bucket.Bounces, item)
+		}
+		explained.Data = append(explained.Data, bucket)
+	}
+	return h.printJSON(explained)
+}
+
+// explainedBounceStats is a bounce statistics response whose bounces carry
+// their explanation, for --explain
+type explainedBounceStats struct {
+	Object string                  `json:"object"`
+	Data   []explainedBounceBucket `json:"data"`
+}
+
+type explainedBounceBucket struct {
+	FromTimestamp time.Time         `json:"from_timestamp"`
+	ToTimestamp   time.Time         `json:"to_timestamp"`
+	Bounces       []explainedBounce `json:"bounces"`
+}
+
+// explainedBounce has a null explanation for classifications the CLI does
+// not know yet
+type explainedBounce struct {
+	Classification string             `json:"classification"`
+	Count          int                `json:"count"`
+	Explanation    *bounceExplanation `json:"explanation"`
+}
+
+type bounceExplanation struct {
+	Description string `json:"description"`
+	Action      string `json:"action"`
+}
+
+// printBounceTotals prints the bounces per classification over the whole
+// range, for --aggregate
+func (h *jsonHandler) printBounceTotals(response *responses.BounceStatisticsResponse, config StatsConfig) error {
+	totals, from, to, total := aggregateBounces(response.Data)
+	result := bounceTotals{
+		Object:        "bounce_totals",
+		FromTimestamp: from,
+		ToTimestamp:   to,
+		Total:         total,
+		Data:          []interface{}{},
+	}
+	for _, bounce := range totals {
+		item := bounceTotalItem{
+			Classification: bounce.Classification,
+			Count:          bounce.Count,
+			Percentage:     math.Round(bounce.Percentage*10) / 10,
+		}
+		if !config.Explain {
+			result.Data = append(result.Data, item)
+			continue
+		}
+		explained := explainedBounceTotal{Classification: item.Classification, Count: item.Count, Percentage: item.Percentage}
+		if description, action := explainBounce(bounce.Classification); description != "" {
+			explained.Explanation = &bounceExplanation{Description: description, Action: action}
+		}
+		result.Data = append(result.Data, explained)
+	}
+	return h.printJSON(result)
+}
+
+// bounceTotals is the bounces per classification over a whole range, most
+// frequent first
+type bounceTotals struct {
+	Object        string        `json:"object"`
+	FromTimestamp time.Time     `json:"from_timestamp"`
+	ToTimestamp   time.Time     `json:"to_timestamp"`
+	Total         int           `json:"total"`
+	Data          []interface{} `json:"data"` // bounceTotalItem, or explainedBounceTotal for --explain
+}
+
+type bounceTotalItem struct {
+	Classification string  `json:"classification"`
+	Count          int     `json:"count"`
+	Percentage     float64 `json:"percentage"`
+}
+
+type explainedBounceTotal struct {
+	Classification string             `json:"classification"`
+	Count          int                `json:"count"`
+	Percentage     float64            `json:"percentage"`
+	Explanation    *bounceExplanation `json:"explanation"`
 }
 
 func (h *jsonHandler) HandleDeliveryTimeStats(response *responses.DeliveryTimeStatisticsResponse, config StatsConfig) error {
@@ -413,6 +1345,13 @@ func (h *jsonHandler) HandleAuthLogout(success bool, config AuthConfig) error {
 	return h.printJSON(result)
 }
 
+func (h *jsonHandler) HandleAccount(account *responses.Account, config SingleConfig) error {
+	if account == nil {
+		return h.HandleEmpty(config.EmptyMessage)
+	}
+	return h.printJSON(account)
+}
+
 func (h *jsonHandler) HandleAuthStatus(status *AuthStatus, config AuthConfig) error {
 	if status == nil {
 		return h.HandleEmpty("No authentication status available")
@@ -429,8 +1368,82 @@ func (h *jsonHandler) HandleAuthSwitch(newProfile string, config AuthConfig) err
 	return h.printJSON(result)
 }
 
+// Setup diagnosis
+func (h *jsonHandler) HandleDoctorReport(report *DoctorReport, config SingleConfig) error {
+	if report == nil {
+		return h.HandleEmpty(config.EmptyMessage)
+	}
+	checks := report.Checks
+	if checks == nil {
+		checks = []DoctorCheck{}
+	}
+	return h.printJSON(struct {
+		Object   string        `json:"object"`
+		Status   string        `json:"status"`
+		Checks   []DoctorCheck `json:"checks"`
+		Passed   int           `json:"passed"`
+		Warnings int           `json:"warnings"`
+		Failed   int           `json:"failed"`
+		Skipped  int           `json:"skipped"`
+	}{
+		Object:   "doctor_report",
+		Status:   report.Status(),
+		Checks:   checks,
+		Passed:   report.Count(DoctorStatusPass),
+		Warnings: report.Count(DoctorStatusWarn),
+		Failed:   report.Count(DoctorStatusFail),
+		Skipped:  report.Count(DoctorStatusSkip),
+	})
+}
+
+func (h *jsonHandler) HandlePing(result *PingResult, config SimpleConfig) error {
+	if result == nil {
+		return h.HandleEmpty(config.SuccessMessage)
+	}
+	return h.printJSON(result)
+}
+
+// Bulk delete results
+func (h *jsonHandler) HandleBulkDelete(result *BulkDeleteResult, config DeleteConfig) error {
+	if result == nil {
+		return h.HandleEmpty(config.SuccessMessage)
+	}
+	return h.printJSON(struct {
+		Object  string           `json:"object"`
+		Pattern string           `json:"pattern"`
+		Matched int              `json:"matched"`
+		Deleted int              `json:"deleted"`
+		Failed  int              `json:"failed"`
+		Items   []BulkDeleteItem `json:"items"`
+	}{
+		Object:  "bulk_delete",
+		Pattern: result.Pattern,
+		Matched: result.Matched,
+		Deleted: result.Deleted,
+		Failed:  result.Failed,
+		Items:   result.Items,
+	})
+}
+
+// Import results
+func (h *jsonHandler) HandleImport(result *ImportResult, config CreateConfig) error {
+	if result == nil {
+		return h.HandleEmpty(config.SuccessMessage)
+	}
+	return h.printJSON(struct {
+		Object string `json:"object"`
+		*ImportResult
+	}{
+		Object:       "import",
+		ImportResult: result,
+	})
+}
+
 // Simple success and empty responses
 func (h *jsonHandler) HandleSimpleSuccess(message string) error {
+	if h.field != "" {
+		return nil
+	}
 	result := map[string]interface{}{
 		"message": message,
 	}
@@ -438,6 +1451,9 @@ func (h *jsonHandler) HandleSimpleSuccess(message string) error {
 }
 
 func (h *jsonHandler) HandleEmpty(message string) error {
+	if h.field != "" {
+		return nil
+	}
 	result := map[string]interface{}{
 		"empty":   true,
 		"message": message,
@@ -447,20 +1463,73 @@ func (h *jsonHandler) HandleEmpty(message string) error {
 
 // printJSON is the core JSON formatting method
 func (h *jsonHandler) printJSON(data interface{}) error {
+	return h.printList(data, ListConfig{})
+}
+
+// printList prints a response that may be a list. JSON output prints it as
+// one document; JSON Lines output prints each item of a list on its own
+// line, keeping only the config's FieldOrder fields and the schema_version.
+func (h *jsonHandler) printList(data interface{}, config ListConfig) error {
 	// Clean data by removing empty additionalproperties fields
-	cleanedData := h.removeEmptyAdditionalProperties(data)
+	cleaned := h.removeEmptyAdditionalProperties(data)
+	if h.field != "" {
+		return h.writeField(cleaned)
+	}
+	if h.lines {
+		if object, ok := cleaned.(map[string]interface{}); ok && object["object"] == "list" {
+			if items, ok := object["data"].([]interface{}); ok {
+				for _, item := range items {
+					if err := h.writeJSON(itemWithSchemaVersion(projectFields(item, config.FieldOrder))); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+		}
+	}
+	return h.writeJSON(h.withWarnings(withSchemaVersion(cleaned)))
+}
 
+// writeJSON writes cleaned data as indented JSON, or as a single line for
+// JSON Lines output. Lines are flushed as they are written, so a consumer
+// reading the pipe gets each one right away.
+func (h *jsonHandler) writeJSON(cleanedData interface{}) error {
 	buf := bytes.NewBuffer([]byte{})
 	encoder := json.NewEncoder(buf)
 	encoder.SetEscapeHTML(false)
-	encoder.SetIndent("", "  ")
+	if !h.lines {
+		encoder.SetIndent("", "  ")
+	}
 
 	if err := encoder.Encode(cleanedData); err != nil {
 		return fmt.Errorf("failed to marshal JSON: %w", err)
 	}
 
-	_, err := h.writer.Write(buf.Bytes())
-	return err
+	if _, err := h.writer.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	if flusher, ok := h.writer.(interface{ Flush() error }); ok && h.lines {
+		return flusher.Flush()
+	}
+	return nil
+}
+
+// withSchemaVersion adds the schema_version to a cleaned top-level object.
+// Other values are returned unchanged.
+func withSchemaVersion(data interface{}) interface{} {
+	if object, ok := data.(map[string]interface{}); ok {
+		object["schema_version"] = SchemaVersion
+	}
+	return data
+}
+
+// withWarnings adds the recorded warnings to a cleaned top-level object.
+// The field is left out when there are none.
+func (h *jsonHandler) withWarnings(data interface{}) interface{} {
+	if object, ok := data.(map[string]interface{}); ok && len(h.warnings) > 0 {
+		object["warnings"] = h.warnings
+	}
+	return data
 }
 
 // removeEmptyAdditionalProperties recursively removes empty additionalproperties fields
@@ -521,6 +1590,7 @@ func (h *jsonHandler) removeEmptyAdditionalProperties(data interface{}) interfac
 // cleanStruct cleans a struct by removing empty additionalproperties fields
 func (h *jsonHandler) cleanStruct(v reflect.Value) interface{} {
 	result := make(map[string]interface{})
+	promoted := make(map[string]interface{})
 	t := v.Type()
 
 	for i := 0; i < v.NumField(); i++ {
@@ -547,6 +1617,17 @@ func (h *jsonHandler) cleanStruct(v reflect.Value) interface{} {
 			continue
 		}
 
+		// Promote the fields of untagged embedded structs, as encoding/json
+		// does; fields of the outer struct take precedence
+		if field.Anonymous && field.Tag.Get("json") == "" {
+			if embedded, ok := h.removeEmptyAdditionalProperties(fieldValue.Interface()).(map[string]interface{}); ok {
+				for key, value := range embedded {
+					promoted[key] = value
+				}
+				continue
+			}
+		}
+
 		// Skip empty additionalproperties fields
 		if fieldName == "additionalproperties" || fieldName == "AdditionalProperties" {
 			if h.isEmptyValue(fieldValue.Interface()) {
@@ -558,6 +1639,11 @@ func (h *jsonHandler) cleanStruct(v reflect.Value) interface{} {
 		result[fieldName] = cleanedValue
 	}
 
+	for key, value := range promoted {
+		if _, exists := result[key]; !exists {
+			result[key] = value
+		}
+	}
 	return result
 }
 
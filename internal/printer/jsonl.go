@@ -0,0 +1,104 @@
+package printer
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// jsonlHandler writes JSON Lines for line-oriented tools like jq, grep and
+// xargs. A list response becomes one compact object per item, without the
+// list envelope or pagination, and any other response a single line. It
+// shares the JSON handler's encoding, so each line has the shape of the
+// matching element of --output json.
+type jsonlHandler struct {
+	jsonHandler
+}
+
+// GetFormat returns the format name
+func (h *jsonlHandler) GetFormat() string {
+	return "jsonl"
+}
+
+// jsonlFieldAliases maps the column names some commands use in FieldOrder
+// to the JSON keys they display
+var jsonlFieldAliases = map[string]string{
+	"created":      "created_at",
+	"updated":      "updated_at",
+	"delivered":    "delivered_at",
+	"received":     "created_at",
+	"opens":        "open_count",
+	"clicks":       "click_count",
+	"attempts":     "num_attempts",
+	"bounce_class": "bounce_classification",
+}
+
+// projectFields keeps the fieldOrder fields of a list item, in that order.
+// Fields the item does not have are left out, as with omitempty; when none
+// match, the item is kept whole rather than printed as an empty object.
+func projectFields(item interface{}, fieldOrder []string) interface{} {
+	object, ok := item.(map[string]interface{})
+	if !ok || len(fieldOrder) == 0 {
+		return item
+	}
+
+	var projected orderedObject
+	seen := make(map[string]bool)
+	for _, field := range fieldOrder {
+		key := field
+		if _, ok := object[key]; !ok {
+			key = jsonlFieldAliases[field]
+		}
+		value, ok := object[key]
+		if !ok || seen[key] {
+			continue
+		}
+		seen[key] = true
+		projected = append(projected, orderedField{key: key, value: value})
+	}
+	if len(projected) == 0 {
+		return item
+	}
+	return projected
+}
+
+// itemWithSchemaVersion adds the schema_version to a list item printed on
+// its own line, as withSchemaVersion does for whole documents
+func itemWithSchemaVersion(item interface{}) interface{} {
+	if object, ok := item.(orderedObject); ok {
+		return append(object, orderedField{key: "schema_version", value: SchemaVersion})
+	}
+	return withSchemaVersion(item)
+}
+
+// orderedObject is a JSON object whose keys keep their order
+type orderedObject []orderedField
+
+type orderedField struct {
+	key   string
+	value interface{}
+}
+
+// MarshalJSON implements json.Marshaler
+func (o orderedObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+
+	buf.WriteByte('{')
+	for i, field := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := encoder.Encode(field.key); err != nil {
+			return nil, err
+		}
+		buf.Truncate(buf.Len() - 1) // Encode ends with a newline
+		buf.WriteByte(':')
+		if err := encoder.Encode(field.value); err != nil {
+			return nil, err
+		}
+		buf.Truncate(buf.Len() - 1)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
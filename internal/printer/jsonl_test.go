@@ -0,0 +1,155 @@
+package printer
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// jsonLines splits JSON Lines output and decodes every line
+func jsonLines(t *testing.T, output string) []map[string]interface{} {
+	t.Helper()
+	var objects []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSuffix(output, "\n"), "\n") {
+		var object map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &object), line)
+		objects = append(objects, object)
+	}
+	return objects
+}
+
+func TestJSONL_MessageList(t *testing.T) {
+	var buf bytes.Buffer
+	handler := GetResponseHandler("jsonl", false, &buf)
+	assert.Equal(t, "jsonl", handler.GetFormat())
+
+	created := time.Date(2026, 3, 2, 10, 15, 0, 0, time.UTC)
+	response := &responses.PaginatedMessagesResponse{
+		Object: "list",
+		Data: []responses.Message{
+			{ID: uuid.New(), Sender: "news@example.com", Recipient: "ana@example.com", Subject: "<Welcome>", Status: "Delivered", CreatedAt: created, OpenCount: 2},
+			{ID: uuid.New(), Sender: "news@example.com", Recipient: "ben@example.com", Subject: "Welcome", Status: "Bounced", CreatedAt: created},
+		},
+	}
+	require.NoError(t, handler.HandleMessageList(response, ListConfig{
+		FieldOrder: []string{"id", "recipient", "subject", "status", "created", "delivered", "opens"},
+	}))
+
+	output := buf.String()
+	lines := jsonLines(t, output)
+	require.Len(t, lines, 2, "one line per message, without the list envelope")
+	assert.Equal(t, "ana@example.com", lines[0]["recipient"])
+	assert.Equal(t, float64(2), lines[0]["open_count"], "column names map to JSON keys")
+	assert.Equal(t, "2026-03-02T10:15:00Z", lines[0]["created_at"])
+	assert.NotContains(t, lines[0], "sender", "only FieldOrder fields are kept")
+	assert.Nil(t, lines[0]["delivered_at"], "unset fields are null, as with --output json")
+	assert.NotContains(t, output, "pagination")
+
+	// Keys follow FieldOrder and HTML is not escaped
+	first := strings.SplitN(output, "\n", 2)[0]
+	assert.True(t, strings.HasPrefix(first, `{"id":"`+response.Data[0].ID.String()+`","recipient":"ana@example.com","subject":"<Welcome>"`), first)
+}
+
+func TestJSONL_DomainList(t *testing.T) {
+	var buf bytes.Buffer
+	handler := GetResponseHandler("jsonl", false, &buf)
+
+	require.NoError(t, handler.HandleDomainList(&responses.PaginatedDomainsResponse{
+		Object: "list",
+		Data:   []responses.Domain{{Domain: "example.com", DNSValid: true}, {Domain: "example.org"}},
+	}, ListConfig{}))
+
+	lines := jsonLines(t, buf.String())
+	require.Len(t, lines, 2)
+	assert.Equal(t, "example.com", lines[0]["domain"])
+	assert.Equal(t, true, lines[0]["dns_valid"])
+	assert.Contains(t, lines[1], "id", "without FieldOrder every field is kept")
+	assert.Equal(t, float64(SchemaVersion), lines[1]["schema_version"])
+
+	// An empty list prints nothing, so line-oriented consumers see no records
+	buf.Reset()
+	require.NoError(t, handler.HandleDomainList(&responses.PaginatedDomainsResponse{Object: "list"}, ListConfig{}))
+	assert.Empty(t, buf.String())
+}
+
+func TestJSONL_SuppressionList(t *testing.T) {
+	var buf bytes.Buffer
+	handler := GetResponseHandler("jsonl", false, &buf)
+
+	require.NoError(t, handler.HandleSuppressionList(&responses.PaginatedSuppressionsResponse{
+		Object: "list",
+		Data: []responses.Suppression{
+			{Email: "a@example.com", Domain: "example.com", Reason: "Bounced"},
+			{Email: "b@example.com", Reason: "Unsubscribed"},
+		},
+	}, ListConfig{FieldOrder: []string{"email", "domain", "reason", "created_at", "expires_at"}}))
+
+	lines := jsonLines(t, buf.String())
+	require.Len(t, lines, 2)
+	assert.Equal(t, map[string]interface{}{
+		"email": "a@example.com", "domain": "example.com", "reason": "Bounced",
+		"created_at": "0001-01-01T00:00:00Z", "expires_at": "0001-01-01T00:00:00Z",
+		"schema_version": float64(SchemaVersion),
+	}, lines[0])
+	assert.Equal(t, "b@example.com", lines[1]["email"])
+}
+
+func TestJSONL_SingleObject(t *testing.T) {
+	var buf bytes.Buffer
+	handler := GetResponseHandler("jsonl", false, &buf)
+	handler.AddWarning("Account is paused")
+
+	require.NoError(t, handler.HandleSingleDomain(&responses.Domain{Domain: "example.com"}, SingleConfig{}))
+	assert.Equal(t, 1, strings.Count(buf.String(), "\n"), "a single object is one line")
+
+	lines := jsonLines(t, buf.String())
+	assert.Equal(t, "example.com", lines[0]["domain"])
+	assert.Equal(t, float64(SchemaVersion), lines[0]["schema_version"])
+	assert.Equal(t, []interface{}{"Account is paused"}, lines[0]["warnings"])
+}
+
+// flushRecorder records what had been flushed when each line was written
+type flushRecorder struct {
+	bytes.Buffer
+	flushes int
+}
+
+func (f *flushRecorder) Flush() error {
+	f.flushes++
+	return nil
+}
+
+func TestJSONL_FlushesEachLine(t *testing.T) {
+	var out flushRecorder
+	handler := GetResponseHandler("jsonl", false, &out)
+
+	require.NoError(t, handler.HandleSuppressionList(&responses.PaginatedSuppressionsResponse{
+		Object: "list",
+		Data:   []responses.Suppression{{Email: "a@example.com"}, {Email: "b@example.com"}, {Email: "c@example.com"}},
+	}, ListConfig{}))
+	assert.Equal(t, 3, out.flushes)
+
+	// Indented JSON output is not flushed line by line
+	var indented flushRecorder
+	require.NoError(t, GetResponseHandler("json", false, &indented).HandleSimpleSuccess("done"))
+	assert.Zero(t, indented.flushes)
+}
+
+func TestProjectFields(t *testing.T) {
+	item := map[string]interface{}{"id": "1", "created_at": "now", "open_count": 3}
+
+	projected, err := json.Marshal(projectFields(item, []string{"opens", "id", "missing", "created", "created_at"}))
+	require.NoError(t, err)
+	assert.Equal(t, `{"open_count":3,"id":"1","created_at":"now"}`, string(projected))
+
+	assert.Equal(t, item, projectFields(item, nil))
+	assert.Equal(t, item, projectFields(item, []string{"route"}), "no matching field keeps the item whole")
+	assert.Equal(t, "scalar", projectFields("scalar", []string{"id"}))
+}
@@ -2,8 +2,15 @@ package printer
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/AhaSend/ahasend-go/models/responses"
+
+	"github.com/AhaSend/ahasend-cli/internal/bounces"
+	"github.com/AhaSend/ahasend-cli/internal/dns"
+	"github.com/AhaSend/ahasend-cli/internal/state"
+	"github.com/AhaSend/ahasend-cli/internal/webhooks"
 )
 
 // plainHandler handles plain text output formatting with complete type safety
@@ -30,14 +37,14 @@ func (h *plainHandler) HandleDomainList(response *responses.PaginatedDomainsResp
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
+	h.printMessage("%s\n", config.SuccessMessage)
 
 	for i, domain := range response.Data {
 		if i > 0 {
 			fmt.Fprintf(h.writer, "\n")
 		}
 
-		fmt.Fprintf(h.writer, "Domain: %s\n", domain.Domain)
+		fmt.Fprintf(h.writer, "Domain: %s\n", formatDomainName(domain.Domain))
 		fmt.Fprintf(h.writer, "  ID: %s\n", formatUUID(domain.ID))
 		fmt.Fprintf(h.writer, "  Status: %s\n", formatDNSStatus(domain.DNSValid))
 		fmt.Fprintf(h.writer, "  Created: %s\n", formatTime(domain.CreatedAt))
@@ -65,9 +72,9 @@ func (h *plainHandler) HandleSingleDomain(domain *responses.Domain, config Singl
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
+	h.printMessage("%s\n", config.SuccessMessage)
 
-	fmt.Fprintf(h.writer, "Domain: %s\n", domain.Domain)
+	fmt.Fprintf(h.writer, "Domain: %s\n", formatDomainName(domain.Domain))
 	fmt.Fprintf(h.writer, "ID: %s\n", formatUUID(domain.ID))
 	fmt.Fprintf(h.writer, "Account ID: %s\n", formatUUID(domain.AccountID))
 	fmt.Fprintf(h.writer, "DNS Status: %s\n", formatDNSStatus(domain.DNSValid))
@@ -93,6 +100,96 @@ func (h *plainHandler) HandleSingleDomain(domain *responses.Domain, config Singl
 	return nil
 }
 
+func (h *plainHandler) HandleDNSPropagation(matrix *dns.PropagationMatrix, config SingleConfig) error {
+	if matrix == nil || len(matrix.Records) == 0 {
+		fmt.Fprintf(h.writer, "%s\n", config.EmptyMessage)
+		return nil
+	}
+
+	if config.SuccessMessage != "" {
+		h.printMessage("%s\n", config.SuccessMessage)
+	}
+
+	for _, record := range matrix.Records {
+		statuses := make([]string, len(matrix.Resolvers))
+		for i, resolver := range matrix.Resolvers {
+			statuses[i] = fmt.Sprintf("%s=%s", resolver, record.Statuses[i])
+		}
+		fmt.Fprintf(h.writer, "%s %s: %s\n", record.Type, record.Host, strings.Join(statuses, " "))
+	}
+
+	fmt.Fprintf(h.writer, "%s\n", formatPropagationSummary(matrix))
+	return nil
+}
+
+func (h *plainHandler) HandleDNSVerification(domain *responses.Domain, verification *dns.Verification, config SingleConfig) error {
+	if domain == nil || verification == nil {
+		fmt.Fprintf(h.writer, "%s\n", config.EmptyMessage)
+		return nil
+	}
+
+	if config.SuccessMessage != "" {
+		h.printMessage("%s\n", config.SuccessMessage)
+	}
+
+	fmt.Fprintf(h.writer, "Domain: %s\n", formatDomainName(verification.Domain))
+	fmt.Fprintf(h.writer, "State: %s\n", formatVerificationState(verification.State))
+	fmt.Fprintf(h.writer, "%s\n", formatVerificationSummary(verification))
+
+	for _, failure := range verification.Failures {
+		fmt.Fprintf(h.writer, "\nFailed: %s %s (required: %s)\n", failure.Type, failure.Host, formatBooleanStatus(failure.Required))
+		fmt.Fprintf(h.writer, "  Reason: %s\n", formatFailureReason(failure.Reason))
+		fmt.Fprintf(h.writer, "  Expected: %s\n", failure.Expected)
+		fmt.Fprintf(h.writer, "  Observed (%s): %s\n", failure.ObservedSource, formatObservedValues(failure.Observed))
+		fmt.Fprintf(h.writer, "  Detail: %s\n", failure.Detail)
+	}
+
+	if config.ShowRecords && len(domain.DNSRecords) > 0 {
+		fmt.Fprintf(h.writer, "\nDNS Records:\n")
+		for i, record := range domain.DNSRecords {
+			fmt.Fprintf(h.writer, "  %d. Type: %s, Host: %s, Content: %s, Required: %s, Propagated: %s\n",
+				i+1, record.Type, record.Host, record.Content,
+				formatBooleanStatus(record.Required), formatBooleanStatus(record.Propagated))
+		}
+	}
+
+	if verification.State != dns.StateVerified {
+		fmt.Fprintf(h.writer, "\nTroubleshooting tips:\n")
+		for _, tip := range verificationTips(verification.Domain) {
+			fmt.Fprintf(h.writer, "- %s\n", tip)
+		}
+	}
+
+	return nil
+}
+
+func (h *plainHandler) HandleDomainVerification(verification *dns.LocalVerification, config SingleConfig) error {
+	if verification == nil {
+		fmt.Fprintf(h.writer, "%s\n", config.EmptyMessage)
+		return nil
+	}
+
+	if config.SuccessMessage != "" {
+		h.printMessage("%s\n", config.SuccessMessage)
+	}
+
+	fmt.Fprintf(h.writer, "Domain: %s\n", formatDomainName(verification.Domain))
+	fmt.Fprintf(h.writer, "Resolver: %s\n", verification.Resolver)
+	fmt.Fprintf(h.writer, "%s\n", formatLocalVerificationSummary(verification))
+
+	for _, record := range verification.Records {
+		fmt.Fprintf(h.writer, "\n%s %s (required: %s): %s\n", record.Type, record.Host, formatBooleanStatus(record.Required), record.Status)
+		fmt.Fprintf(h.writer, "  Expected: %s\n", record.Expected)
+		if record.Status == dns.StatusError {
+			fmt.Fprintf(h.writer, "  Error: %s\n", record.Error)
+		} else {
+			fmt.Fprintf(h.writer, "  Observed: %s\n", formatObservedValues(record.Observed))
+		}
+	}
+
+	return nil
+}
+
 // Message responses
 func (h *plainHandler) HandleMessageList(response *responses.PaginatedMessagesResponse, config ListConfig) error {
 	if len(response.Data) == 0 {
@@ -100,7 +197,11 @@ func (h *plainHandler) HandleMessageList(response *responses.PaginatedMessagesRe
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
+	if config.Continuation {
+		fmt.Fprintf(h.writer, "\n")
+	} else {
+		h.printMessage("%s\n", config.SuccessMessage)
+	}
 
 	for i, message := range response.Data {
 		if i > 0 {
@@ -116,8 +217,10 @@ func (h *plainHandler) HandleMessageList(response *responses.PaginatedMessagesRe
 		if message.DeliveredAt != nil {
 			fmt.Fprintf(h.writer, "  Delivered: %s\n", formatTimePtr(message.DeliveredAt))
 		}
-		fmt.Fprintf(h.writer, "  Opens: %d\n", message.OpenCount)
-		fmt.Fprintf(h.writer, "  Clicks: %d\n", message.ClickCount)
+		if message.Direction != "inbound" {
+			fmt.Fprintf(h.writer, "  Opens: %d\n", message.OpenCount)
+			fmt.Fprintf(h.writer, "  Clicks: %d\n", message.ClickCount)
+		}
 	}
 
 	// Show pagination info if enabled
@@ -138,7 +241,7 @@ func (h *plainHandler) HandleSingleMessage(message *responses.Message, config Si
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
+	h.printMessage("%s\n", config.SuccessMessage)
 
 	fmt.Fprintf(h.writer, "ID: %s\n", formatUUID(message.ID))
 	fmt.Fprintf(h.writer, "Account ID: %s\n", formatUUID(message.AccountID))
@@ -147,6 +250,9 @@ func (h *plainHandler) HandleSingleMessage(message *responses.Message, config Si
 	fmt.Fprintf(h.writer, "Subject: %s\n", message.Subject)
 	fmt.Fprintf(h.writer, "Status: %s\n", message.Status)
 	fmt.Fprintf(h.writer, "Direction: %s\n", message.Direction)
+	if message.Direction == "inbound" {
+		fmt.Fprintf(h.writer, "Attachments: %s\n", formatAttachmentCount(*message))
+	}
 	fmt.Fprintf(h.writer, "Created: %s\n", formatTime(message.CreatedAt))
 	fmt.Fprintf(h.writer, "Updated: %s\n", formatTime(message.UpdatedAt))
 
@@ -161,6 +267,9 @@ func (h *plainHandler) HandleSingleMessage(message *responses.Message, config Si
 	fmt.Fprintf(h.writer, "Attempts: %d\n", message.NumAttempts)
 	if message.BounceClassification != nil {
 		fmt.Fprintf(h.writer, "Bounce Class: %s\n", formatOptionalString(message.BounceClassification))
+		if explanation := formatBounceExplanation(message.BounceClassification); explanation != "" {
+			fmt.Fprintf(h.writer, "Bounce Explanation: %s\n", explanation)
+		}
 	}
 	fmt.Fprintf(h.writer, "Message ID: %s\n", message.MessageID)
 	fmt.Fprintf(h.writer, "Domain ID: %s\n", formatUUID(message.DomainID))
@@ -187,10 +296,24 @@ func (h *plainHandler) HandleCreateMessage(response *responses.CreateMessageResp
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
+	summary := SummarizeCreateMessage(response)
+	fmt.Fprintf(h.writer, "%s\n", formatCreateMessageHeadline(summary, config))
 
 	// Show summary first
-	fmt.Fprintf(h.writer, "Successfully sent %d messages\n", len(response.Data))
+	if summary.Failed == 0 {
+		fmt.Fprintf(h.writer, "Successfully sent %d messages\n", summary.Succeeded)
+	} else {
+		fmt.Fprintf(h.writer, "Sent %d of %d messages\n", summary.Succeeded, summary.Total())
+	}
+	if len(config.Metadata) > 0 {
+		fmt.Fprintf(h.writer, "Metadata: %s\n", FormatMetadata(config.Metadata))
+	}
+	if summary.Failed > 0 {
+		fmt.Fprintf(h.writer, "Failed recipients (%d):\n", summary.Failed)
+		for _, messageData := range summary.Rejected {
+			fmt.Fprintf(h.writer, "  %s\n", formatRejectedRecipient(messageData))
+		}
+	}
 
 	// Show details for each message
 	for i, messageData := range response.Data {
@@ -217,7 +340,7 @@ func (h *plainHandler) HandleCancelMessage(response *CancelMessageResponse, conf
 	}
 
 	if response.Success {
-		fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
+		h.printMessage("%s\n", config.SuccessMessage)
 		fmt.Fprintf(h.writer, "Message ID: %s\n", response.MessageID)
 	} else {
 		fmt.Fprintf(h.writer, "Message cancellation failed\n")
@@ -230,6 +353,94 @@ func (h *plainHandler) HandleCancelMessage(response *CancelMessageResponse, conf
 	return nil
 }
 
+func (h *plainHandler) HandleMessageExport(summary *MessageExportSummary, config SimpleConfig) error {
+	if summary == nil {
+		return nil
+	}
+
+	for _, skip := range summary.Skips {
+		fmt.Fprintf(h.writer, "Skipped %s: %s\n", skip.ID, skip.Reason)
+	}
+	fmt.Fprintf(h.writer, "%s\n", formatMessageExportSummary(summary))
+	return nil
+}
+
+func (h *plainHandler) HandleDryRunMessage(dryRun *DryRunMessage, config SimpleConfig) error {
+	if dryRun == nil {
+		fmt.Fprintf(h.writer, "No message to send\n")
+		return nil
+	}
+
+	h.printMessage("%s\n", config.SuccessMessage)
+	for _, field := range dryRunFields(dryRun) {
+		fmt.Fprintf(h.writer, "%s: %s\n", field[0], field[1])
+	}
+	for i, batch := range dryRun.Batches {
+		schedule := ""
+		if when := formatDryRunBatchSchedule(batch); len(dryRun.ScheduleBuckets) > 0 && when == "Immediately" {
+			schedule = " immediately"
+		} else if len(dryRun.ScheduleBuckets) > 0 {
+			schedule = " at " + when
+		}
+		if dryRun.SubjectField != "" {
+			schedule += fmt.Sprintf(", subject %q", formatDryRunBatchSubject(batch))
+		}
+		fmt.Fprintf(h.writer, "Batch %d: %d recipients%s, idempotency key %s\n", i+1, batch.Recipients, schedule, batch.IdempotencyKey)
+	}
+	return nil
+}
+
+func (h *plainHandler) HandleMessageAttempts(report *MessageAttemptsReport, config SingleConfig) error {
+	if report == nil || len(report.Attempts) == 0 {
+		fmt.Fprintf(h.writer, "%s\n", config.EmptyMessage)
+		return nil
+	}
+
+	if config.SuccessMessage != "" {
+		h.printMessage("%s\n", config.SuccessMessage)
+	}
+	fmt.Fprintf(h.writer, "Recipient: %s\n", report.Recipient)
+	fmt.Fprintf(h.writer, "Status: %s\n", report.Status)
+	fmt.Fprintf(h.writer, "Created: %s\n", formatTime(report.CreatedAt))
+
+	for _, attempt := range report.Attempts {
+		fmt.Fprintf(h.writer, "\nAttempt %d:\n", attempt.Attempt)
+		fmt.Fprintf(h.writer, "  Time: %s (+%s)\n", formatTime(attempt.Time), formatElapsed(attempt.SincePrevious))
+		fmt.Fprintf(h.writer, "  Remote Host: %s\n", attempt.RemoteHost)
+		fmt.Fprintf(h.writer, "  Status: %s\n", attempt.Status)
+		fmt.Fprintf(h.writer, "  SMTP Code: %s\n", formatSMTPCode(attempt.SMTPCode))
+		fmt.Fprintf(h.writer, "  Response: %s\n", attempt.SMTPResponse)
+	}
+
+	fmt.Fprintf(h.writer, "\n%s\n", formatAttemptsTotal(report))
+	return nil
+}
+
+// HandleMessageContent prints the headers, a blank line and the selected
+// part, like the message itself, so the output can be piped; a raw part
+// already starts with its headers
+func (h *plainHandler) HandleMessageContent(content *MessageContent, config SingleConfig) error {
+	if content == nil {
+		fmt.Fprintf(h.writer, "%s\n", config.EmptyMessage)
+		return nil
+	}
+
+	if config.SuccessMessage != "" {
+		h.printMessage("%s\n", config.SuccessMessage)
+	}
+	if content.Part != MessagePartRaw {
+		for _, header := range content.Headers {
+			fmt.Fprintf(h.writer, "%s: %s\n", header.Name, header.Value)
+		}
+		fmt.Fprintln(h.writer)
+	}
+	fmt.Fprint(h.writer, content.Body)
+	if !strings.HasSuffix(content.Body, "\n") {
+		fmt.Fprintln(h.writer)
+	}
+	return nil
+}
+
 // Webhook responses
 func (h *plainHandler) HandleWebhookList(response *responses.PaginatedWebhooksResponse, config ListConfig) error {
 	if len(response.Data) == 0 {
@@ -237,7 +448,7 @@ func (h *plainHandler) HandleWebhookList(response *responses.PaginatedWebhooksRe
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
+	h.printMessage("%s\n", config.SuccessMessage)
 
 	for i, webhook := range response.Data {
 		if i > 0 {
@@ -253,6 +464,10 @@ func (h *plainHandler) HandleWebhookList(response *responses.PaginatedWebhooksRe
 		fmt.Fprintf(h.writer, "  Updated: %s\n", formatTime(webhook.UpdatedAt))
 		fmt.Fprintf(h.writer, "  Success Count: %d\n", webhook.SuccessCount)
 		fmt.Fprintf(h.writer, "  Error Count: %d\n", webhook.ErrorCount)
+		if config.IncludeStats {
+			fmt.Fprintf(h.writer, "  Error Streak: %d\n", webhook.ErrorsSinceLastSuccess)
+			fmt.Fprintf(h.writer, "  Error Rate: %s\n", formatErrorRate(webhookErrorRate(webhook.SuccessCount, webhook.ErrorCount)))
+		}
 		if webhook.LastRequestAt != nil {
 			fmt.Fprintf(h.writer, "  Last Request: %s\n", formatTimePtr(webhook.LastRequestAt))
 		}
@@ -261,6 +476,16 @@ func (h *plainHandler) HandleWebhookList(response *responses.PaginatedWebhooksRe
 		}
 	}
 
+	// Aggregate footer
+	if config.IncludeStats {
+		summary := summarizeWebhookStats(response.Data)
+		fmt.Fprintf(h.writer, "\nTotals (%d webhooks):\n", summary.Webhooks)
+		fmt.Fprintf(h.writer, "  Success Count: %d\n", summary.Success)
+		fmt.Fprintf(h.writer, "  Error Count: %d\n", summary.Errors)
+		fmt.Fprintf(h.writer, "  Error Rate: %s\n", formatErrorRate(summary.ErrorRate))
+		fmt.Fprintf(h.writer, "  Last Request: %s\n", formatTimePtr(summary.LastRequest))
+	}
+
 	// Show pagination info if enabled
 	if config.ShowPagination {
 		fmt.Fprintf(h.writer, "\nShowing %d webhooks", len(response.Data))
@@ -279,7 +504,7 @@ func (h *plainHandler) HandleSingleWebhook(webhook *responses.Webhook, config Si
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
+	h.printMessage("%s\n", config.SuccessMessage)
 
 	fmt.Fprintf(h.writer, "Webhook ID: %s\n", formatUUID(webhook.ID))
 	fmt.Fprintf(h.writer, "Name: %s\n", webhook.Name)
@@ -335,7 +560,7 @@ func (h *plainHandler) HandleCreateWebhook(webhook *responses.Webhook, config Cr
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
+	h.printMessage("%s\n", config.SuccessMessage)
 
 	fmt.Fprintf(h.writer, "Webhook ID: %s\n", formatUUID(webhook.ID))
 	fmt.Fprintf(h.writer, "Name: %s\n", webhook.Name)
@@ -344,37 +569,7 @@ func (h *plainHandler) HandleCreateWebhook(webhook *responses.Webhook, config Cr
 	fmt.Fprintf(h.writer, "Scope: %s\n", webhook.Scope)
 
 	// Show configured events
-	events := []string{}
-	if webhook.OnReception {
-		events = append(events, "reception")
-	}
-	if webhook.OnDelivered {
-		events = append(events, "delivered")
-	}
-	if webhook.OnTransientError {
-		events = append(events, "transient_error")
-	}
-	if webhook.OnFailed {
-		events = append(events, "failed")
-	}
-	if webhook.OnBounced {
-		events = append(events, "bounced")
-	}
-	if webhook.OnSuppressed {
-		events = append(events, "suppressed")
-	}
-	if webhook.OnOpened {
-		events = append(events, "opened")
-	}
-	if webhook.OnClicked {
-		events = append(events, "clicked")
-	}
-	if webhook.OnSuppressionCreated {
-		events = append(events, "suppression_created")
-	}
-	if webhook.OnDNSError {
-		events = append(events, "dns_error")
-	}
+	events := webhooks.SubscribedEvents(webhook)
 
 	if len(events) > 0 {
 		fmt.Fprintf(h.writer, "Events: %s\n", formatStringSlice(events))
@@ -389,7 +584,7 @@ func (h *plainHandler) HandleCreateWebhook(webhook *responses.Webhook, config Cr
 	// Security info (show actual secret for creation)
 	if webhook.Secret != "" {
 		fmt.Fprintf(h.writer, "Secret: %s\n", webhook.Secret)
-		fmt.Fprintf(h.writer, "\n⚠️  Store this secret securely. It will not be shown again.\n")
+		h.printMessage("\n⚠️  Store this secret securely. It will not be shown again.\n")
 	}
 
 	return nil
@@ -401,7 +596,7 @@ func (h *plainHandler) HandleUpdateWebhook(webhook *responses.Webhook, config Up
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
+	h.printMessage("%s\n", config.SuccessMessage)
 
 	fmt.Fprintf(h.writer, "Webhook ID: %s\n", formatUUID(webhook.ID))
 	fmt.Fprintf(h.writer, "Name: %s\n", webhook.Name)
@@ -411,58 +606,173 @@ func (h *plainHandler) HandleUpdateWebhook(webhook *responses.Webhook, config Up
 	fmt.Fprintf(h.writer, "Updated: %s\n", formatTime(webhook.UpdatedAt))
 
 	// Show configured events
-	events := []string{}
-	if webhook.OnReception {
-		events = append(events, "reception")
+	events := webhooks.SubscribedEvents(webhook)
+
+	if len(events) > 0 {
+		fmt.Fprintf(h.writer, "Events: %s\n", formatStringSlice(events))
+	} else {
+		fmt.Fprintf(h.writer, "Events: None configured\n")
 	}
-	if webhook.OnDelivered {
-		events = append(events, "delivered")
+
+	if len(webhook.Domains) > 0 {
+		fmt.Fprintf(h.writer, "Domains: %s\n", formatStringSlice(webhook.Domains))
 	}
-	if webhook.OnTransientError {
-		events = append(events, "transient_error")
+
+	return nil
+}
+
+func (h *plainHandler) HandleRotateWebhookSecret(webhook *responses.Webhook, config UpdateConfig) error {
+	if webhook == nil {
+		fmt.Fprintf(h.writer, "No webhook data received\n")
+		return nil
 	}
-	if webhook.OnFailed {
-		events = append(events, "failed")
+
+	h.printMessage("%s\n", config.SuccessMessage)
+
+	fmt.Fprintf(h.writer, "Webhook ID: %s\n", formatUUID(webhook.ID))
+	fmt.Fprintf(h.writer, "Name: %s\n", webhook.Name)
+	fmt.Fprintf(h.writer, "URL: %s\n", webhook.URL)
+	fmt.Fprintf(h.writer, "Secret: %s\n", formatWebhookSecretCreation(webhook.Secret))
+	h.printMessage("\n⚠️  Store this secret securely. It will not be shown again.\n")
+
+	return nil
+}
+
+func (h *plainHandler) HandleWebhookEdit(edit *WebhookEdit, config UpdateConfig) error {
+	if edit == nil {
+		fmt.Fprintf(h.writer, "No webhook data received\n")
+		return nil
 	}
-	if webhook.OnBounced {
-		events = append(events, "bounced")
+
+	h.printMessage("%s\n", config.SuccessMessage)
+	if len(edit.Changes) == 0 {
+		fmt.Fprintf(h.writer, "No changes to webhook %s\n", edit.Name)
+		return nil
 	}
-	if webhook.OnSuppressed {
-		events = append(events, "suppressed")
+	for _, change := range edit.Changes {
+		fmt.Fprintf(h.writer, "%s\n", formatOverride(change))
 	}
-	if webhook.OnOpened {
-		events = append(events, "opened")
+
+	return nil
+}
+
+func (h *plainHandler) HandleDeleteWebhook(success bool, config DeleteConfig) error {
+	h.printMessage("%s\n", config.SuccessMessage)
+	return nil
+}
+
+func (h *plainHandler) HandleTriggerWebhook(webhookID string, events []string, config TriggerConfig) error {
+	h.printMessage("%s\n", config.SuccessMessage)
+	return nil
+}
+
+func (h *plainHandler) HandleTriggerWebhookOverrides(trigger *WebhookTrigger, config TriggerConfig) error {
+	if trigger == nil {
+		fmt.Fprintf(h.writer, "No events triggered\n")
+		return nil
 	}
-	if webhook.OnClicked {
-		events = append(events, "clicked")
+
+	h.printMessage("%s\n\n", config.SuccessMessage)
+	writeTriggerOverrides(h.writer, trigger)
+	for _, event := range trigger.Events {
+		fmt.Fprintf(h.writer, "%s (%s):\n", event.Type, event.MsgID)
+		fmt.Fprintf(h.writer, "  Status: %s\n", event.Status)
+		if event.Failure != "" {
+			fmt.Fprintf(h.writer, "  Failure: %s\n", event.Failure)
+		}
+		fmt.Fprintf(h.writer, "  HTTP: %s\n", formatSimulationStatusCode(event.StatusCode))
+		fmt.Fprintf(h.writer, "  Duration: %s\n", formatSimulationDuration(event.DurationMs))
+		if event.Error != "" {
+			fmt.Fprintf(h.writer, "  Error: %s\n", event.Error)
+		}
+		fmt.Fprintf(h.writer, "\n")
 	}
-	if webhook.OnSuppressionCreated {
-		events = append(events, "suppression_created")
+	fmt.Fprintf(h.writer, "%s\n", formatTriggerSummary(trigger))
+	return nil
+}
+
+func (h *plainHandler) HandleWebhookCoverage(report *WebhookCoverageReport, config SingleConfig) error {
+	if report == nil {
+		fmt.Fprintf(h.writer, "%s\n", config.EmptyMessage)
+		return nil
 	}
-	if webhook.OnDNSError {
-		events = append(events, "dns_error")
+
+	if config.SuccessMessage != "" {
+		h.printMessage("%s\n", config.SuccessMessage)
 	}
+	fmt.Fprintf(h.writer, "Activity Since: %s\n", formatTime(report.Since))
 
-	if len(events) > 0 {
-		fmt.Fprintf(h.writer, "Events: %s\n", formatStringSlice(events))
-	} else {
-		fmt.Fprintf(h.writer, "Events: None configured\n")
+	names := make(map[string]string, len(report.Webhooks))
+	for _, webhook := range report.Webhooks {
+		names[webhook.ID] = webhook.Name
 	}
 
-	if len(webhook.Domains) > 0 {
-		fmt.Fprintf(h.writer, "Domains: %s\n", formatStringSlice(webhook.Domains))
+	for _, event := range report.Events {
+		subscribers := make([]string, len(event.Subscribers))
+		for i, id := range event.Subscribers {
+			subscribers[i] = names[id]
+		}
+		fmt.Fprintf(h.writer, "\n%s:\n", event.Event)
+		if len(subscribers) > 0 {
+			fmt.Fprintf(h.writer, "  Webhooks: %s\n", formatStringSlice(subscribers))
+		} else {
+			fmt.Fprintf(h.writer, "  Webhooks: None\n")
+		}
+		fmt.Fprintf(h.writer, "  Activity: %s\n", formatCoverageActivity(event))
 	}
 
+	fmt.Fprintf(h.writer, "\nFindings:\n")
+	if len(report.Findings) == 0 {
+		fmt.Fprintf(h.writer, "  None\n")
+	}
+	for _, finding := range report.Findings {
+		fmt.Fprintf(h.writer, "  %s %s\n", formatCoverageSeverity(finding.Severity), finding.Message)
+	}
 	return nil
 }
 
-func (h *plainHandler) HandleDeleteWebhook(success bool, config DeleteConfig) error {
-	fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
+func (h *plainHandler) HandleWebhookSimulation(simulation *WebhookSimulation, config SingleConfig) error {
+	if simulation == nil {
+		fmt.Fprintf(h.writer, "%s\n", config.EmptyMessage)
+		return nil
+	}
+
+	if !simulation.Sent {
+		writeSimulatedRequests(h.writer, simulation)
+		fmt.Fprintf(h.writer, "%s\n", formatSimulationSummary(simulation))
+		return nil
+	}
+
+	for i, event := range simulation.Events {
+		fmt.Fprintf(h.writer, "Event %d:\n", i+1)
+		fmt.Fprintf(h.writer, "  Msg ID: %s\n", event.MsgID)
+		fmt.Fprintf(h.writer, "  Status: %s\n", event.Status)
+		if event.Failure != "" {
+			fmt.Fprintf(h.writer, "  Failure: %s\n", event.Failure)
+		}
+		fmt.Fprintf(h.writer, "  HTTP: %s\n", formatSimulationStatusCode(event.StatusCode))
+		fmt.Fprintf(h.writer, "  Duration: %s\n", formatSimulationDuration(event.DurationMs))
+		if event.Error != "" {
+			fmt.Fprintf(h.writer, "  Error: %s\n", event.Error)
+		}
+		fmt.Fprintf(h.writer, "\n")
+	}
+	fmt.Fprintf(h.writer, "%s\n", formatSimulationSummary(simulation))
+	writeSimulationFailureHints(h.writer, simulation)
 	return nil
 }
 
-func (h *plainHandler) HandleTriggerWebhook(webhookID string, events []string, config TriggerConfig) error {
-	fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
+func (h *plainHandler) HandleWebhookVerification(verification *WebhookVerification, config SingleConfig) error {
+	if verification == nil {
+		fmt.Fprintf(h.writer, "%s\n", config.EmptyMessage)
+		return nil
+	}
+
+	fmt.Fprintf(h.writer, "%s\n\n", formatWebhookVerificationSummary(verification))
+	for _, field := range webhookVerificationFields(verification) {
+		fmt.Fprintf(h.writer, "%s: %s\n", field.Label, field.Value)
+	}
+	writeWebhookVerificationProblems(h.writer, verification)
 	return nil
 }
 
@@ -473,7 +783,7 @@ func (h *plainHandler) HandleRouteList(response *responses.PaginatedRoutesRespon
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
+	h.printMessage("%s\n", config.SuccessMessage)
 
 	for _, route := range response.Data {
 		fmt.Fprintf(h.writer, "Route ID: %s\n", formatUUID(route.ID))
@@ -495,6 +805,12 @@ func (h *plainHandler) HandleRouteList(response *responses.PaginatedRoutesRespon
 		}
 		fmt.Fprintf(h.writer, "  Created: %s\n", formatTime(route.CreatedAt))
 		fmt.Fprintf(h.writer, "  Updated: %s\n", formatTime(route.UpdatedAt))
+		if config.ExpandedPatterns != nil {
+			fmt.Fprintf(h.writer, "  Expanded Patterns:\n")
+			for _, pattern := range patternsForRoute(config.ExpandedPatterns, route.ID) {
+				fmt.Fprintf(h.writer, "    %s\n", formatExpandedPattern(pattern))
+			}
+		}
 		fmt.Fprintf(h.writer, "\n")
 	}
 
@@ -507,6 +823,10 @@ func (h *plainHandler) HandleRouteList(response *responses.PaginatedRoutesRespon
 		fmt.Fprintf(h.writer, "\n")
 	}
 
+	if warning := orphanedPatternsWarning(config.ExpandedPatterns); warning != "" {
+		fmt.Fprintf(h.writer, "\n%s\n", warning)
+	}
+
 	return nil
 }
 
@@ -516,7 +836,7 @@ func (h *plainHandler) HandleSingleRoute(route *responses.Route, config SingleCo
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
+	h.printMessage("%s\n", config.SuccessMessage)
 
 	fmt.Fprintf(h.writer, "Route ID: %s\n", formatUUID(route.ID))
 	fmt.Fprintf(h.writer, "Name: %s\n", route.Name)
@@ -534,6 +854,16 @@ func (h *plainHandler) HandleSingleRoute(route *responses.Route, config SingleCo
 	fmt.Fprintf(h.writer, "  Created: %s\n", formatTime(route.CreatedAt))
 	fmt.Fprintf(h.writer, "  Updated: %s\n", formatTime(route.UpdatedAt))
 
+	if config.ExpandedPatterns != nil {
+		fmt.Fprintf(h.writer, "\nExpanded Patterns:\n")
+		for _, pattern := range config.ExpandedPatterns {
+			fmt.Fprintf(h.writer, "  %s\n", formatExpandedPattern(pattern))
+		}
+		if warning := orphanedPatternsWarning(config.ExpandedPatterns); warning != "" {
+			fmt.Fprintf(h.writer, "\n%s\n", warning)
+		}
+	}
+
 	return nil
 }
 
@@ -543,7 +873,7 @@ func (h *plainHandler) HandleCreateRoute(route *responses.Route, config CreateCo
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
+	h.printMessage("%s\n", config.SuccessMessage)
 
 	fmt.Fprintf(h.writer, "Route ID: %s\n", formatUUID(route.ID))
 	fmt.Fprintf(h.writer, "Name: %s\n", route.Name)
@@ -566,7 +896,7 @@ func (h *plainHandler) HandleUpdateRoute(route *responses.Route, config UpdateCo
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
+	h.printMessage("%s\n", config.SuccessMessage)
 
 	fmt.Fprintf(h.writer, "Updated Route ID: %s\n", formatUUID(route.ID))
 	fmt.Fprintf(h.writer, "Name: %s\n", route.Name)
@@ -591,15 +921,30 @@ func (h *plainHandler) HandleDeleteRoute(success bool, config DeleteConfig) erro
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
+	h.printMessage("%s\n", config.SuccessMessage)
 	return nil
 }
 
 func (h *plainHandler) HandleTriggerRoute(routeID string, config TriggerConfig) error {
-	fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
+	h.printMessage("%s\n", config.SuccessMessage)
 	return nil
 }
 
+func (h *plainHandler) HandleRouteExplanation(explanation *RouteExplanation, config SingleConfig) error {
+	if explanation == nil {
+		fmt.Fprintf(h.writer, "%s\n", config.EmptyMessage)
+		return nil
+	}
+
+	writeRouteExplanationHeader(h.writer, explanation)
+	for _, option := range explanation.Options {
+		fmt.Fprintf(h.writer, "\n%s: %s\n", option.Name, formatBooleanStatus(option.Enabled))
+		fmt.Fprintf(h.writer, "  %s\n", option.Effect)
+	}
+	fmt.Fprintf(h.writer, "\n")
+	return writeRoutePayload(h.writer, explanation)
+}
+
 // Suppression responses
 func (h *plainHandler) HandleSuppressionList(response *responses.PaginatedSuppressionsResponse, config ListConfig) error {
 	if len(response.Data) == 0 {
@@ -607,7 +952,7 @@ func (h *plainHandler) HandleSuppressionList(response *responses.PaginatedSuppre
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
+	h.printMessage("%s\n", config.SuccessMessage)
 
 	for i, suppression := range response.Data {
 		if i > 0 {
@@ -644,7 +989,7 @@ func (h *plainHandler) HandleSingleSuppression(suppression *responses.Suppressio
 		return h.HandleEmpty(config.EmptyMessage)
 	}
 
-	fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
+	h.printMessage("%s\n", config.SuccessMessage)
 	fmt.Fprintf(h.writer, "Email: %s\n", suppression.Email)
 	fmt.Fprintf(h.writer, "ID: %s\n", formatUUID(suppression.ID))
 	if suppression.Domain != "" {
@@ -663,7 +1008,7 @@ func (h *plainHandler) HandleCreateSuppression(response *responses.CreateSuppres
 		return h.HandleEmpty("No suppressions created")
 	}
 
-	fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
+	h.printMessage("%s\n", config.SuccessMessage)
 
 	for i, suppression := range response.Data {
 		if i > 0 {
@@ -685,16 +1030,55 @@ func (h *plainHandler) HandleCreateSuppression(response *responses.CreateSuppres
 }
 
 func (h *plainHandler) HandleDeleteSuppression(success bool, config DeleteConfig) error {
-	fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
+	h.printMessage("%s\n", config.SuccessMessage)
 	return nil
 }
 
 func (h *plainHandler) HandleWipeSuppression(count int, config WipeConfig) error {
-	fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
+	h.printMessage("%s\n", config.SuccessMessage)
 	fmt.Fprintf(h.writer, "Wiped %d suppressions\n", count)
 	return nil
 }
 
+func (h *plainHandler) HandleSuppressionWipeSummary(summary *SuppressionWipeSummary, config WipeConfig) error {
+	if summary == nil {
+		return nil
+	}
+
+	for _, reason := range summary.Reasons {
+		fmt.Fprintf(h.writer, "%s: %d\n", reason.Reason, reason.Count)
+	}
+	for _, failure := range summary.Failures {
+		fmt.Fprintf(h.writer, "Failed %s: %s\n", failure.Email, failure.Error)
+	}
+	fmt.Fprintf(h.writer, "%s\n", formatSuppressionWipeSummary(summary))
+	return nil
+}
+
+func (h *plainHandler) HandleBounceSuppressions(summary *BounceSuppressionSummary, config CreateConfig) error {
+	if summary == nil {
+		return nil
+	}
+
+	for _, failure := range summary.Failures {
+		fmt.Fprintf(h.writer, "Failed %s: %s\n", failure.Email, failure.Error)
+	}
+	fmt.Fprintf(h.writer, "%s\n", formatBounceSuppressionSummary(summary))
+	return nil
+}
+
+func (h *plainHandler) HandleSuppressionImport(summary *SuppressionImportSummary, config CreateConfig) error {
+	if summary == nil {
+		return nil
+	}
+
+	for _, rowErr := range summary.Errors {
+		fmt.Fprintf(h.writer, "Row %d %s: %s\n", rowErr.Row, rowErr.Email, rowErr.Error)
+	}
+	fmt.Fprintf(h.writer, "%s\n", formatSuppressionImportSummary(summary))
+	return nil
+}
+
 func (h *plainHandler) HandleCheckSuppression(suppression *responses.Suppression, found bool, config CheckConfig) error {
 	if found {
 		fmt.Fprintf(h.writer, "%s\n", config.FoundMessage)
@@ -723,7 +1107,7 @@ func (h *plainHandler) HandleSMTPList(response *responses.PaginatedSMTPCredentia
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
+	h.printMessage("%s\n", config.SuccessMessage)
 
 	for i, credential := range response.Data {
 		if i > 0 {
@@ -735,7 +1119,7 @@ func (h *plainHandler) HandleSMTPList(response *responses.PaginatedSMTPCredentia
 		fmt.Fprintf(h.writer, "  Username: %s\n", credential.Username)
 		fmt.Fprintf(h.writer, "  Scope: %s\n", credential.Scope)
 		if len(credential.Domains) > 0 {
-			fmt.Fprintf(h.writer, "  Domains: %s\n", formatStringSlice(credential.Domains))
+			fmt.Fprintf(h.writer, "  Domains: %s\n", formatDomainNames(credential.Domains))
 		}
 		fmt.Fprintf(h.writer, "  Sandbox: %s\n", formatBooleanStatus(credential.Sandbox))
 		fmt.Fprintf(h.writer, "  Created: %s\n", formatTime(credential.CreatedAt))
@@ -761,7 +1145,7 @@ func (h *plainHandler) HandleSingleSMTP(credential *responses.SMTPCredential, co
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	h.printMessage("%s\n\n", config.SuccessMessage)
 
 	fmt.Fprintf(h.writer, "Name: %s\n", credential.Name)
 	fmt.Fprintf(h.writer, "ID: %s\n", formatUUID(credential.ID))
@@ -769,7 +1153,7 @@ func (h *plainHandler) HandleSingleSMTP(credential *responses.SMTPCredential, co
 	fmt.Fprintf(h.writer, "Password: %s\n", "[HIDDEN]") // Never show password
 	fmt.Fprintf(h.writer, "Scope: %s\n", credential.Scope)
 	if len(credential.Domains) > 0 {
-		fmt.Fprintf(h.writer, "Domains: %s\n", formatStringSlice(credential.Domains))
+		fmt.Fprintf(h.writer, "Domains: %s\n", formatDomainNames(credential.Domains))
 	}
 	fmt.Fprintf(h.writer, "Sandbox: %s\n", formatBooleanStatus(credential.Sandbox))
 	fmt.Fprintf(h.writer, "Created: %s\n", formatTime(credential.CreatedAt))
@@ -790,7 +1174,7 @@ func (h *plainHandler) HandleCreateSMTP(credential *responses.SMTPCredential, co
 		return h.HandleEmpty(config.SuccessMessage)
 	}
 
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	h.printMessage("%s\n\n", config.SuccessMessage)
 
 	fmt.Fprintf(h.writer, "Created SMTP Credential:\n")
 	fmt.Fprintf(h.writer, "  Name: %s\n", credential.Name)
@@ -799,11 +1183,11 @@ func (h *plainHandler) HandleCreateSMTP(credential *responses.SMTPCredential, co
 	// Show password only on creation
 	if credential.Password != "" {
 		fmt.Fprintf(h.writer, "  Password: %s\n", credential.Password)
-		fmt.Fprintf(h.writer, "\n⚠️  IMPORTANT: Save this password now! It won't be shown again.\n")
+		h.printMessage("\n⚠️  IMPORTANT: Save this password now! It won't be shown again.\n")
 	}
 	fmt.Fprintf(h.writer, "  Scope: %s\n", credential.Scope)
 	if len(credential.Domains) > 0 {
-		fmt.Fprintf(h.writer, "  Domains: %s\n", formatStringSlice(credential.Domains))
+		fmt.Fprintf(h.writer, "  Domains: %s\n", formatDomainNames(credential.Domains))
 	}
 	fmt.Fprintf(h.writer, "  Sandbox: %s\n", formatBooleanStatus(credential.Sandbox))
 	fmt.Fprintf(h.writer, "  Created: %s\n", formatTime(credential.CreatedAt))
@@ -818,9 +1202,38 @@ func (h *plainHandler) HandleCreateSMTP(credential *responses.SMTPCredential, co
 	return nil
 }
 
+func (h *plainHandler) HandleUpdateSMTP(update *SMTPCredentialUpdate, config UpdateConfig) error {
+	if update == nil || update.Credential == nil {
+		return h.HandleEmpty("No credential updated")
+	}
+	credential := update.Credential
+
+	h.printMessage("%s\n\n", config.SuccessMessage)
+
+	fmt.Fprintf(h.writer, "Name: %s\n", credential.Name)
+	fmt.Fprintf(h.writer, "ID: %s\n", formatUUID(credential.ID))
+	fmt.Fprintf(h.writer, "Username: %s\n", credential.Username)
+	fmt.Fprintf(h.writer, "Scope: %s\n", credential.Scope)
+	if len(credential.Domains) > 0 {
+		fmt.Fprintf(h.writer, "Domains: %s\n", formatDomainNames(credential.Domains))
+	}
+	if len(update.AddedDomains) > 0 {
+		fmt.Fprintf(h.writer, "Added Domains: %s\n", formatDomainNames(update.AddedDomains))
+	}
+	if len(update.RemovedDomains) > 0 {
+		fmt.Fprintf(h.writer, "Removed Domains: %s\n", formatDomainNames(update.RemovedDomains))
+	}
+	fmt.Fprintf(h.writer, "Sandbox: %s\n", formatBooleanStatus(credential.Sandbox))
+	if !update.DryRun {
+		fmt.Fprintf(h.writer, "Updated: %s\n", formatTime(credential.UpdatedAt))
+	}
+
+	return nil
+}
+
 func (h *plainHandler) HandleDeleteSMTP(success bool, config DeleteConfig) error {
 	if success {
-		fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
+		h.printMessage("%s\n", config.SuccessMessage)
 	} else {
 		fmt.Fprintf(h.writer, "Failed to delete %s\n", config.ItemName)
 	}
@@ -845,7 +1258,7 @@ func (h *plainHandler) HandleSMTPSend(result *SMTPSendResult, config SMTPSendCon
 		}
 	} else {
 		if result.Success {
-			fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
+			h.printMessage("%s\n", config.SuccessMessage)
 			if result.MessageID != "" {
 				fmt.Fprintf(h.writer, "Message ID: %s\n", result.MessageID)
 			}
@@ -860,6 +1273,49 @@ func (h *plainHandler) HandleSMTPSend(result *SMTPSendResult, config SMTPSendCon
 	return nil
 }
 
+func (h *plainHandler) HandleSMTPUsage(report *SMTPUsageReport, config SingleConfig) error {
+	if report == nil {
+		fmt.Fprintf(h.writer, "%s\n", config.EmptyMessage)
+		return nil
+	}
+
+	if config.SuccessMessage != "" {
+		h.printMessage("%s\n", config.SuccessMessage)
+	}
+	fmt.Fprintf(h.writer, "From: %s\n", formatTime(report.From))
+	fmt.Fprintf(h.writer, "To: %s\n", formatTime(report.To))
+	fmt.Fprintf(h.writer, "Group By: %s\n", report.GroupBy)
+	if report.Approximate {
+		fmt.Fprintf(h.writer, "%s\n", smtpUsageApproximationNote)
+	}
+	if report.UnusedFor != "" {
+		fmt.Fprintf(h.writer, "Unused For %s: %d of %d credentials\n", report.UnusedFor, len(report.Credentials), report.Checked)
+	}
+	if len(report.Credentials) == 0 {
+		fmt.Fprintf(h.writer, "%s\n", config.EmptyMessage)
+		return nil
+	}
+
+	for _, credential := range report.Credentials {
+		fmt.Fprintf(h.writer, "\n%s (%s):\n", credential.Name, credential.ID)
+		fmt.Fprintf(h.writer, "  Username: %s\n", credential.Username)
+		fmt.Fprintf(h.writer, "  Scope: %s\n", credential.Scope)
+		fmt.Fprintf(h.writer, "  Domains: %s\n", formatSMTPUsageDomains(credential))
+		fmt.Fprintf(h.writer, "  Messages: %d\n", credential.Messages)
+		fmt.Fprintf(h.writer, "  Last Activity: %s\n", formatTimePtr(credential.LastActivity))
+		fmt.Fprintf(h.writer, "  Status: %s\n", smtpUsageStatus(credential))
+		if credential.Error != "" {
+			fmt.Fprintf(h.writer, "  Error: %s\n", credential.Error)
+		}
+		for _, bucket := range credential.Buckets {
+			if bucket.Messages > 0 {
+				fmt.Fprintf(h.writer, "  %s: %d\n", formatTime(bucket.From), bucket.Messages)
+			}
+		}
+	}
+	return nil
+}
+
 // API Key responses
 func (h *plainHandler) HandleAPIKeyList(response *responses.PaginatedAPIKeysResponse, config ListConfig) error {
 	if len(response.Data) == 0 {
@@ -867,7 +1323,7 @@ func (h *plainHandler) HandleAPIKeyList(response *responses.PaginatedAPIKeysResp
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
+	h.printMessage("%s\n", config.SuccessMessage)
 
 	for i, key := range response.Data {
 		if i > 0 {
@@ -915,7 +1371,7 @@ func (h *plainHandler) HandleSingleAPIKey(key *responses.APIKey, config SingleCo
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	h.printMessage("%s\n\n", config.SuccessMessage)
 
 	fmt.Fprintf(h.writer, "Label: %s\n", key.Label)
 	fmt.Fprintf(h.writer, "ID: %s\n", formatUUID(key.ID))
@@ -953,7 +1409,7 @@ func (h *plainHandler) HandleCreateAPIKey(key *responses.APIKey, config CreateCo
 		return h.HandleEmpty(config.SuccessMessage)
 	}
 
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	h.printMessage("%s\n\n", config.SuccessMessage)
 
 	fmt.Fprintf(h.writer, "Created API Key:\n")
 	fmt.Fprintf(h.writer, "  Label: %s\n", key.Label)
@@ -963,7 +1419,7 @@ func (h *plainHandler) HandleCreateAPIKey(key *responses.APIKey, config CreateCo
 	// Show secret key only on creation
 	if key.SecretKey != nil && *key.SecretKey != "" {
 		fmt.Fprintf(h.writer, "  Secret Key: %s\n", *key.SecretKey)
-		fmt.Fprintf(h.writer, "\n⚠️  IMPORTANT: Save this secret key now! It won't be shown again.\n")
+		h.printMessage("\n⚠️  IMPORTANT: Save this secret key now! It won't be shown again.\n")
 	} else {
 		fmt.Fprintf(h.writer, "  Secret Key: [Not provided in response]\n")
 	}
@@ -991,7 +1447,7 @@ func (h *plainHandler) HandleUpdateAPIKey(key *responses.APIKey, config UpdateCo
 		return h.HandleEmpty(config.SuccessMessage)
 	}
 
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	h.printMessage("%s\n\n", config.SuccessMessage)
 
 	fmt.Fprintf(h.writer, "Updated API Key:\n")
 	fmt.Fprintf(h.writer, "  Label: %s\n", key.Label)
@@ -1018,7 +1474,7 @@ func (h *plainHandler) HandleUpdateAPIKey(key *responses.APIKey, config UpdateCo
 
 func (h *plainHandler) HandleDeleteAPIKey(success bool, config DeleteConfig) error {
 	if success {
-		fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
+		h.printMessage("%s\n", config.SuccessMessage)
 	} else {
 		fmt.Fprintf(h.writer, "Failed to delete %s\n", config.ItemName)
 	}
@@ -1032,7 +1488,7 @@ func (h *plainHandler) HandleSubAccountList(response *responses.PaginatedSubAcco
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
+	h.printMessage("%s\n", config.SuccessMessage)
 
 	for i, subAccount := range response.Data {
 		if i > 0 {
@@ -1069,7 +1525,7 @@ func (h *plainHandler) HandleSingleSubAccount(subAccount *responses.SubAccount,
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	h.printMessage("%s\n\n", config.SuccessMessage)
 	h.printSubAccountDetails(subAccount)
 	return nil
 }
@@ -1079,7 +1535,7 @@ func (h *plainHandler) HandleCreateSubAccount(subAccount *responses.SubAccount,
 		return h.HandleEmpty(config.SuccessMessage)
 	}
 
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	h.printMessage("%s\n\n", config.SuccessMessage)
 	h.printSubAccountDetails(subAccount)
 	return nil
 }
@@ -1112,7 +1568,7 @@ func (h *plainHandler) HandleSubAccountUsage(response *responses.SubAccountUsage
 	}
 
 	if config.SuccessMessage != "" {
-		fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
+		h.printMessage("%s\n", config.SuccessMessage)
 	}
 
 	fmt.Fprintf(h.writer, "Billing Period: %s\n", formatSubAccountBillingPeriod(response.BillingPeriod))
@@ -1145,43 +1601,212 @@ func (h *plainHandler) HandleDeliverabilityStats(response *responses.Deliverabil
 		fmt.Fprintf(h.writer, "%s\n\n", config.Title)
 	}
 
+	var trend []string
+	if config.ShowChart {
+		trend = deliveredTrend(response.Data)
+	}
 	for i, stat := range response.Data {
 		if i > 0 {
 			fmt.Fprintf(h.writer, "\n")
 		}
+		h.writeDeliverabilityBucket(stat)
+		if config.ShowChart {
+			fmt.Fprintf(h.writer, "  Delivered Trend: %s\n", trend[i])
+		}
+	}
+	if config.ShowChart {
+		writeDeliverabilityChart(h.writer, response.Data, terminalWidth(h.writer))
+	}
+	return nil
+}
 
-		fmt.Fprintf(h.writer, "Time Period: %s to %s\n",
-			formatTime(stat.FromTimestamp), formatTime(stat.ToTimestamp))
+// HandleDeliverabilityStatsChunk appends the buckets of one streamed chunk
+func (h *plainHandler) HandleDeliverabilityStatsChunk(response *responses.DeliverabilityStatisticsResponse, config StatsConfig) error {
+	if !config.Continuation && config.Title != "" {
+		fmt.Fprintf(h.writer, "%s\n\n", config.Title)
+	}
+	if response == nil {
+		return nil
+	}
+	for _, stat := range response.Data {
+		h.writeDeliverabilityBucket(stat)
+		fmt.Fprintf(h.writer, "\n")
+	}
+	return nil
+}
 
-		fmt.Fprintf(h.writer, "  Reception Count: %s\n", formatInt(stat.ReceptionCount))
-		fmt.Fprintf(h.writer, "  Delivered Count: %s\n", formatInt(stat.DeliveredCount))
-		fmt.Fprintf(h.writer, "  Deferred Count: %s\n", formatInt(stat.DeferredCount))
-		fmt.Fprintf(h.writer, "  Bounced Count: %s\n", formatInt(stat.BouncedCount))
-		fmt.Fprintf(h.writer, "  Failed Count: %s\n", formatInt(stat.FailedCount))
-		fmt.Fprintf(h.writer, "  Suppressed Count: %s\n", formatInt(stat.SuppressedCount))
-		fmt.Fprintf(h.writer, "  Opened Count: %s\n", formatInt(stat.OpenedCount))
-		fmt.Fprintf(h.writer, "  Clicked Count: %s\n", formatInt(stat.ClickedCount))
+func (h *plainHandler) HandleDeliverabilitySummary(summary *DeliverabilitySummary, config StatsConfig) error {
+	if summary == nil || summary.Buckets == 0 {
+		fmt.Fprintf(h.writer, "No deliverability statistics found\n")
+		return nil
+	}
+
+	if config.Title != "" {
+		fmt.Fprintf(h.writer, "%s\n\n", config.Title)
+	}
+
+	fmt.Fprintf(h.writer, "Period: %s to %s\n", formatTime(summary.From), formatTime(summary.To))
+	fmt.Fprintf(h.writer, "Buckets: %s\n", formatInt(summary.Buckets))
+	for _, row := range deliverabilitySummaryRows(summary) {
+		fmt.Fprintf(h.writer, "%s: %s\n", row[0], row[1])
+	}
+	return nil
+}
+
+func (h *plainHandler) HandleDeliverabilityByTag(report *DeliverabilityByTag, config StatsConfig) error {
+	if report == nil || len(report.Tags) == 0 {
+		fmt.Fprintf(h.writer, "No deliverability statistics found\n")
+		return nil
+	}
+
+	if config.Title != "" {
+		fmt.Fprintf(h.writer, "%s\n\n", config.Title)
+	}
+	fmt.Fprintf(h.writer, "Period: %s to %s\n", formatTime(report.From), formatTime(report.To))
+
+	for _, tag := range report.Tags {
+		fmt.Fprintf(h.writer, "\nTag: %s\n", tag.Tag)
+		if tag.Status == TagStatusUnavailable {
+			fmt.Fprintf(h.writer, "Status: %s (%s)\n", TagStatusUnavailable, tag.Error)
+			continue
+		}
+
+		if report.SummaryOnly {
+			fmt.Fprintf(h.writer, "Buckets: %s\n", formatInt(tag.Summary.Buckets))
+			for _, row := range deliverabilitySummaryRows(tag.Summary) {
+				fmt.Fprintf(h.writer, "%s: %s\n", row[0], row[1])
+			}
+			continue
+		}
+
+		if len(tag.Buckets) == 0 {
+			fmt.Fprintf(h.writer, "No deliverability statistics found\n")
+		}
+		for _, bucket := range tag.Buckets {
+			h.writeDeliverabilityBucket(bucket.DeliverabilityStatistics)
+		}
+	}
+	return nil
+}
+
+// writeDeliverabilityBucket writes the counts and rates of one time bucket
+func (h *plainHandler) writeDeliverabilityBucket(stat responses.DeliverabilityStatistics) {
+	fmt.Fprintf(h.writer, "Time Period: %s to %s\n",
+		formatTime(stat.FromTimestamp), formatTime(stat.ToTimestamp))
+
+	fmt.Fprintf(h.writer, "  Reception Count: %s\n", formatInt(stat.ReceptionCount))
+	fmt.Fprintf(h.writer, "  Delivered Count: %s\n", formatInt(stat.DeliveredCount))
+	fmt.Fprintf(h.writer, "  Deferred Count: %s\n", formatInt(stat.DeferredCount))
+	fmt.Fprintf(h.writer, "  Bounced Count: %s\n", formatInt(stat.BouncedCount))
+	fmt.Fprintf(h.writer, "  Failed Count: %s\n", formatInt(stat.FailedCount))
+	fmt.Fprintf(h.writer, "  Suppressed Count: %s\n", formatInt(stat.SuppressedCount))
+	fmt.Fprintf(h.writer, "  Opened Count: %s\n", formatInt(stat.OpenedCount))
+	fmt.Fprintf(h.writer, "  Clicked Count: %s\n", formatInt(stat.ClickedCount))
+
+	// Calculate delivery rate if we have reception count
+	if stat.ReceptionCount > 0 {
+		deliveryRate := (float64(stat.DeliveredCount) / float64(stat.ReceptionCount)) * 100
+		fmt.Fprintf(h.writer, "  Delivery Rate: %.2f%%\n", deliveryRate)
+	}
+
+	// Calculate open rate if we have delivered count
+	if stat.DeliveredCount > 0 {
+		openRate := (float64(stat.OpenedCount) / float64(stat.DeliveredCount)) * 100
+		fmt.Fprintf(h.writer, "  Open Rate: %.2f%%\n", openRate)
+	}
+}
+
+func (h *plainHandler) HandleBounceExplanations(explanations []bounces.Explanation, config ListConfig) error {
+	if len(explanations) == 0 {
+		return h.HandleEmpty(config.EmptyMessage)
+	}
 
-		// Calculate delivery rate if we have reception count
-		if stat.ReceptionCount > 0 {
-			deliveryRate := (float64(stat.DeliveredCount) / float64(stat.ReceptionCount)) * 100
-			fmt.Fprintf(h.writer, "  Delivery Rate: %.2f%%\n", deliveryRate)
+	for i, explanation := range explanations {
+		if i > 0 {
+			fmt.Fprintf(h.writer, "\n")
 		}
+		fmt.Fprintf(h.writer, "Classification: %s\n", explanation.Classification)
+		fmt.Fprintf(h.writer, "Explanation: %s\n", explanation.Description)
+		fmt.Fprintf(h.writer, "Action: %s\n", explanation.Action)
+	}
+	return nil
+}
 
-		// Calculate open rate if we have delivered count
-		if stat.DeliveredCount > 0 {
-			openRate := (float64(stat.OpenedCount) / float64(stat.DeliveredCount)) * 100
-			fmt.Fprintf(h.writer, "  Open Rate: %.2f%%\n", openRate)
+func (h *plainHandler) HandleReminderList(reminders []state.Reminder, config ListConfig) error {
+	if len(reminders) == 0 {
+		return h.HandleEmpty(config.EmptyMessage)
+	}
+
+	now := time.Now()
+	for i, reminder := range reminders {
+		if i > 0 {
+			fmt.Fprintf(h.writer, "\n")
 		}
+		fmt.Fprintf(h.writer, "ID: %s\n", reminder.ID)
+		fmt.Fprintf(h.writer, "Due: %s (%s)\n", formatTime(reminder.DueAt), formatReminderStatus(reminder, now))
+		fmt.Fprintf(h.writer, "Reminder: %s\n", reminder.Message)
+	}
+	return nil
+}
+
+func (h *plainHandler) HandleDeliverabilityComparison(comparison *DeliverabilityComparison, config StatsConfig) error {
+	if comparison == nil || len(comparison.Metrics) == 0 {
+		fmt.Fprintf(h.writer, "No deliverability statistics to compare\n")
+		return nil
+	}
+
+	if config.Title != "" {
+		fmt.Fprintf(h.writer, "%s\n\n", config.Title)
+	}
+
+	fmt.Fprintf(h.writer, "Current Period: %s to %s\n",
+		formatTime(comparison.CurrentPeriod.From), formatTime(comparison.CurrentPeriod.To))
+	fmt.Fprintf(h.writer, "Previous Period: %s to %s\n",
+		formatTime(comparison.PreviousPeriod.From), formatTime(comparison.PreviousPeriod.To))
+
+	for _, metric := range comparison.Metrics {
+		arrow, _ := comparisonTrend(metric)
+		fmt.Fprintf(h.writer, "\n%s:\n", formatComparisonMetricName(metric.Metric))
+		fmt.Fprintf(h.writer, "  Current: %s\n", formatComparisonValue(metric, metric.Current, metric.CurrentUndefined))
+		fmt.Fprintf(h.writer, "  Previous: %s\n", formatComparisonValue(metric, metric.Previous, metric.PreviousUndefined))
+		fmt.Fprintf(h.writer, "  Change: %s (%s) %s\n", formatComparisonChange(metric), formatPercentChange(metric.PercentChange), arrow)
 	}
 	return nil
 }
 
+func (h *plainHandler) HandleStatsAnomalies(report *AnomalyReport, config StatsConfig) error {
+	if config.Title != "" {
+		fmt.Fprintf(h.writer, "%s\n\n", config.Title)
+	}
+
+	fmt.Fprintf(h.writer, "Metric: %s\n", report.Metric)
+	fmt.Fprintf(h.writer, "Period: %s to %s\n", formatTime(report.From), formatTime(report.To))
+	fmt.Fprintf(h.writer, "Group By: %s\n", report.GroupBy)
+	fmt.Fprintf(h.writer, "Window: %d\n", report.Window)
+	fmt.Fprintf(h.writer, "Sensitivity: %.1f\n", report.Sensitivity)
+	fmt.Fprintf(h.writer, "Buckets: %d\n", report.Buckets)
+	fmt.Fprintf(h.writer, "Scored: %d\n", report.Scored)
+
+	for _, bucket := range report.Anomalies {
+		fmt.Fprintf(h.writer, "\n%s:\n", formatTime(bucket.From))
+		fmt.Fprintf(h.writer, "  Value: %.2f%%\n", bucket.Value)
+		fmt.Fprintf(h.writer, "  Mean: %.2f%%\n", bucket.Mean)
+		fmt.Fprintf(h.writer, "  StdDev: %s\n", formatFloat64(bucket.StdDev))
+		fmt.Fprintf(h.writer, "  Z-Score: %s\n", formatZScore(bucket.ZScore))
+	}
+
+	fmt.Fprintf(h.writer, "\n%s\n", report.Verdict())
+	return nil
+}
+
 func (h *plainHandler) HandleBounceStats(response *responses.BounceStatisticsResponse, config StatsConfig) error {
 	if len(response.Data) == 0 {
 		fmt.Fprintf(h.writer, "No bounce statistics found\n")
 		return nil
 	}
+	if config.Aggregate {
+		return h.writeBounceTotals(response, config)
+	}
 
 	if config.Title != "" {
 		fmt.Fprintf(h.writer, "%s\n\n", config.Title)
@@ -1213,6 +1838,37 @@ func (h *plainHandler) HandleBounceStats(response *responses.BounceStatisticsRes
 				}
 				fmt.Fprintf(h.writer, "    %s: %s (%.1f%%)\n",
 					bounce.Classification, formatInt(bounce.Count), percentage)
+				if config.Explain {
+					if description, action := explainBounce(bounce.Classification); description != "" {
+						fmt.Fprintf(h.writer, "      %s\n      Action: %s\n", description, action)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// writeBounceTotals writes the bounces per classification over the whole
+// range, for --aggregate
+func (h *plainHandler) writeBounceTotals(response *responses.BounceStatisticsResponse, config StatsConfig) error {
+	totals, from, to, total := aggregateBounces(response.Data)
+	if total == 0 {
+		fmt.Fprintf(h.writer, "No bounce statistics found\n")
+		return nil
+	}
+
+	if config.Title != "" {
+		fmt.Fprintf(h.writer, "%s\n\n", config.Title)
+	}
+	fmt.Fprintf(h.writer, "Time Period: %s to %s\n", formatTime(from), formatTime(to))
+	fmt.Fprintf(h.writer, "  Total Bounces: %s\n", formatInt(total))
+	fmt.Fprintf(h.writer, "  Bounce Classifications:\n")
+	for _, bounce := range totals {
+		fmt.Fprintf(h.writer, "    %s: %s (%.1f%%)\n", bounce.Classification, formatInt(bounce.Count), bounce.Percentage)
+		if config.Explain {
+			if description, action := explainBounce(bounce.Classification); description != "" {
+				fmt.Fprintf(h.writer, "      %s\n      Action: %s\n", description, action)
 			}
 		}
 	}
@@ -1283,13 +1939,30 @@ func (h *plainHandler) HandleDeliveryTimeStats(response *responses.DeliveryTimeS
 
 // Auth responses
 func (h *plainHandler) HandleAuthLogin(success bool, profile string, config AuthConfig) error {
-	fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
+	h.printMessage("%s\n", config.SuccessMessage)
 	fmt.Fprintf(h.writer, "Profile: %s\n", profile)
 	return nil
 }
 
 func (h *plainHandler) HandleAuthLogout(success bool, config AuthConfig) error {
-	fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
+	h.printMessage("%s\n", config.SuccessMessage)
+	return nil
+}
+
+func (h *plainHandler) HandleAccount(account *responses.Account, config SingleConfig) error {
+	if account == nil {
+		fmt.Fprintf(h.writer, "%s\n", config.EmptyMessage)
+		return nil
+	}
+
+	if config.SuccessMessage != "" {
+		h.printMessage("%s\n\n", config.SuccessMessage)
+	}
+	for _, field := range accountFields(account) {
+		if field.Value != "" {
+			fmt.Fprintf(h.writer, "%s: %s\n", field.Label, field.Value)
+		}
+	}
 	return nil
 }
 
@@ -1302,6 +1975,12 @@ func (h *plainHandler) HandleAuthStatus(status *AuthStatus, config AuthConfig) e
 	fmt.Fprintf(h.writer, "Authentication Status\n")
 	fmt.Fprintf(h.writer, "Profile: %s\n", status.Profile)
 	fmt.Fprintf(h.writer, "API Key: %s\n", status.APIKey)
+	if status.APIURL != "" {
+		fmt.Fprintf(h.writer, "API URL: %s\n", status.APIURL)
+	}
+	if status.AccountID != "" {
+		fmt.Fprintf(h.writer, "Account: %s\n", formatProfileAccount(status))
+	}
 	fmt.Fprintf(h.writer, "Valid: %s\n", formatBooleanStatus(status.Valid))
 
 	if status.Account != nil {
@@ -1354,14 +2033,77 @@ func (h *plainHandler) HandleAuthStatus(status *AuthStatus, config AuthConfig) e
 }
 
 func (h *plainHandler) HandleAuthSwitch(newProfile string, config AuthConfig) error {
-	fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
+	h.printMessage("%s\n", config.SuccessMessage)
 	fmt.Fprintf(h.writer, "Switched to profile: %s\n", newProfile)
 	return nil
 }
 
+// Setup diagnosis
+func (h *plainHandler) HandleDoctorReport(report *DoctorReport, config SingleConfig) error {
+	if report == nil {
+		fmt.Fprintf(h.writer, "%s\n", config.EmptyMessage)
+		return nil
+	}
+
+	for _, check := range report.Checks {
+		fmt.Fprintf(h.writer, "%s %s: %s\n", formatDoctorStatus(check.Status), check.Name, check.Message)
+		if check.Hint != "" {
+			fmt.Fprintf(h.writer, "  Hint: %s\n", check.Hint)
+		}
+	}
+	fmt.Fprintf(h.writer, "\n%s\n", formatDoctorSummary(report))
+	return nil
+}
+
+func (h *plainHandler) HandlePing(result *PingResult, config SimpleConfig) error {
+	if result == nil {
+		return nil
+	}
+	h.printMessage("%s from %s\n", result.Message, result.APIURL)
+	return nil
+}
+
+// Bulk delete results
+func (h *plainHandler) HandleBulkDelete(result *BulkDeleteResult, config DeleteConfig) error {
+	if result == nil {
+		return nil
+	}
+
+	for _, item := range result.Items {
+		if item.Deleted {
+			fmt.Fprintf(h.writer, "Deleted %s (%s)\n", item.Name, item.ID)
+		} else {
+			fmt.Fprintf(h.writer, "Failed %s (%s): %s\n", item.Name, item.ID, item.Error)
+		}
+	}
+	fmt.Fprintf(h.writer, "%s\n", formatBulkDeleteSummary(result, config.ItemName))
+	return nil
+}
+
+// Import results
+func (h *plainHandler) HandleImport(result *ImportResult, config CreateConfig) error {
+	if result == nil {
+		return nil
+	}
+
+	for _, item := range result.Items {
+		label := strings.ToUpper(item.Status[:1]) + item.Status[1:]
+		switch {
+		case item.Error != "":
+			fmt.Fprintf(h.writer, "%s %s: %s\n", label, item.Name, item.Error)
+		case item.ID != "":
+			fmt.Fprintf(h.writer, "%s %s (%s)\n", label, item.Name, item.ID)
+		default:
+			fmt.Fprintf(h.writer, "%s %s\n", label, item.Name)
+		}
+	}
+	fmt.Fprintf(h.writer, "%s\n", formatImportSummary(result, config.ItemName))
+	return nil
+}
+
 // Simple success and empty responses
 func (h *plainHandler) HandleSimpleSuccess(message string) error {
-	fmt.Fprintf(h.writer, "%s\n", message)
+	h.printMessage("%s\n", message)
 	return nil
 }
 
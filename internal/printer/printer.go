@@ -28,8 +28,16 @@ import (
 	"fmt"
 	"io"
 	"os"
-
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/bounces"
+	"github.com/AhaSend/ahasend-cli/internal/dns"
+	"github.com/AhaSend/ahasend-cli/internal/jsonmerge"
+	"github.com/AhaSend/ahasend-cli/internal/state"
+	"github.com/AhaSend/ahasend-cli/internal/webhooks"
+	"github.com/AhaSend/ahasend-go/models/requests"
 	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/google/uuid"
 )
 
 // ResponseHandler defines the interface for type-safe output formatting with concrete types.
@@ -41,20 +49,33 @@ type ResponseHandler interface {
 	// Domain responses
 	HandleDomainList(response *responses.PaginatedDomainsResponse, config ListConfig) error
 	HandleSingleDomain(domain *responses.Domain, config SingleConfig) error
+	HandleDNSPropagation(matrix *dns.PropagationMatrix, config SingleConfig) error
+	HandleDNSVerification(domain *responses.Domain, verification *dns.Verification, config SingleConfig) error
+	HandleDomainVerification(verification *dns.LocalVerification, config SingleConfig) error
 
 	// Message responses
 	HandleMessageList(response *responses.PaginatedMessagesResponse, config ListConfig) error
 	HandleSingleMessage(message *responses.Message, config SingleConfig) error
 	HandleCreateMessage(response *responses.CreateMessageResponse, config CreateConfig) error
 	HandleCancelMessage(response *CancelMessageResponse, config SimpleConfig) error
+	HandleMessageAttempts(report *MessageAttemptsReport, config SingleConfig) error
+	HandleMessageContent(content *MessageContent, config SingleConfig) error
+	HandleMessageExport(summary *MessageExportSummary, config SimpleConfig) error
+	HandleDryRunMessage(dryRun *DryRunMessage, config SimpleConfig) error
 
 	// Webhook responses
 	HandleWebhookList(response *responses.PaginatedWebhooksResponse, config ListConfig) error
 	HandleSingleWebhook(webhook *responses.Webhook, config SingleConfig) error
 	HandleCreateWebhook(webhook *responses.Webhook, config CreateConfig) error
 	HandleUpdateWebhook(webhook *responses.Webhook, config UpdateConfig) error
+	HandleRotateWebhookSecret(webhook *responses.Webhook, config UpdateConfig) error
+	HandleWebhookEdit(edit *WebhookEdit, config UpdateConfig) error
 	HandleDeleteWebhook(success bool, config DeleteConfig) error
 	HandleTriggerWebhook(webhookID string, events []string, config TriggerConfig) error
+	HandleWebhookCoverage(report *WebhookCoverageReport, config SingleConfig) error
+	HandleWebhookSimulation(simulation *WebhookSimulation, config SingleConfig) error
+	HandleWebhookVerification(verification *WebhookVerification, config SingleConfig) error
+	HandleTriggerWebhookOverrides(trigger *WebhookTrigger, config TriggerConfig) error
 
 	// Route responses
 	HandleRouteList(response *responses.PaginatedRoutesResponse, config ListConfig) error
@@ -63,6 +84,7 @@ type ResponseHandler interface {
 	HandleUpdateRoute(route *responses.Route, config UpdateConfig) error
 	HandleDeleteRoute(success bool, config DeleteConfig) error
 	HandleTriggerRoute(routeID string, config TriggerConfig) error
+	HandleRouteExplanation(explanation *RouteExplanation, config SingleConfig) error
 
 	// Suppression responses
 	HandleSuppressionList(response *responses.PaginatedSuppressionsResponse, config ListConfig) error
@@ -70,14 +92,19 @@ type ResponseHandler interface {
 	HandleCreateSuppression(response *responses.CreateSuppressionResponse, config CreateConfig) error
 	HandleDeleteSuppression(success bool, config DeleteConfig) error
 	HandleWipeSuppression(count int, config WipeConfig) error
+	HandleSuppressionWipeSummary(summary *SuppressionWipeSummary, config WipeConfig) error
+	HandleBounceSuppressions(summary *BounceSuppressionSummary, config CreateConfig) error
+	HandleSuppressionImport(summary *SuppressionImportSummary, config CreateConfig) error
 	HandleCheckSuppression(suppression *responses.Suppression, found bool, config CheckConfig) error
 
 	// SMTP responses
 	HandleSMTPList(response *responses.PaginatedSMTPCredentialsResponse, config ListConfig) error
 	HandleSingleSMTP(credential *responses.SMTPCredential, config SingleConfig) error
 	HandleCreateSMTP(credential *responses.SMTPCredential, config CreateConfig) error
+	HandleUpdateSMTP(update *SMTPCredentialUpdate, config UpdateConfig) error
 	HandleDeleteSMTP(success bool, config DeleteConfig) error
 	HandleSMTPSend(result *SMTPSendResult, config SMTPSendConfig) error
+	HandleSMTPUsage(report *SMTPUsageReport, config SingleConfig) error
 
 	// API Key responses
 	HandleAPIKeyList(response *responses.PaginatedAPIKeysResponse, config ListConfig) error
@@ -95,7 +122,19 @@ type ResponseHandler interface {
 	// Statistics responses
 	HandleDeliverabilityStats(response *responses.DeliverabilityStatisticsResponse, config StatsConfig) error
 	HandleBounceStats(response *responses.BounceStatisticsResponse, config StatsConfig) error
+	HandleBounceExplanations(explanations []bounces.Explanation, config ListConfig) error
 	HandleDeliveryTimeStats(response *responses.DeliveryTimeStatisticsResponse, config StatsConfig) error
+	HandleDeliverabilityComparison(comparison *DeliverabilityComparison, config StatsConfig) error
+	HandleStatsAnomalies(report *AnomalyReport, config StatsConfig) error
+	HandleDeliverabilityStatsChunk(response *responses.DeliverabilityStatisticsResponse, config StatsConfig) error
+	HandleDeliverabilitySummary(summary *DeliverabilitySummary, config StatsConfig) error
+	HandleDeliverabilityByTag(report *DeliverabilityByTag, config StatsConfig) error
+
+	// Local reminders
+	HandleReminderList(reminders []state.Reminder, config ListConfig) error
+
+	// Account responses
+	HandleAccount(account *responses.Account, config SingleConfig) error
 
 	// Auth responses
 	HandleAuthLogin(success bool, profile string, config AuthConfig) error
@@ -103,6 +142,16 @@ type ResponseHandler interface {
 	HandleAuthStatus(status *AuthStatus, config AuthConfig) error
 	HandleAuthSwitch(newProfile string, config AuthConfig) error
 
+	// Setup diagnosis
+	HandleDoctorReport(report *DoctorReport, config SingleConfig) error
+	HandlePing(result *PingResult, config SimpleConfig) error
+
+	// Bulk delete results
+	HandleBulkDelete(result *BulkDeleteResult, config DeleteConfig) error
+
+	// Import results
+	HandleImport(result *ImportResult, config CreateConfig) error
+
 	// Simple success without data
 	HandleSimpleSuccess(message string) error
 
@@ -112,6 +161,21 @@ type ResponseHandler interface {
 	// Internal methods for format detection and output writing
 	GetFormat() string
 	SetWriter(w io.Writer)
+
+	// SetCapabilities sets the colors and emoji table and plain output may
+	// use (--color); GetCapabilities returns them
+	SetCapabilities(caps Capabilities)
+	GetCapabilities() Capabilities
+
+	// SetQuiet suppresses success messages and advisory notes in table,
+	// plain and csv output, leaving only the data (--quiet). JSON output
+	// is unchanged.
+	SetQuiet(quiet bool)
+
+	// AddWarning records a warning about the command's context, such as a
+	// paused account. JSON output includes it in a warnings array; other
+	// formats ignore it.
+	AddWarning(message string)
 }
 
 // Configuration types for different response scenarios
@@ -122,6 +186,16 @@ type ListConfig struct {
 	EmptyMessage   string   // Message to show when list is empty
 	ShowPagination bool     // Whether to show pagination information
 	FieldOrder     []string // Optional field ordering for table display
+	IncludeStats   bool     // Whether to add per-item stats and an aggregate summary (webhooks)
+
+	// ExpandedPatterns are the routes' recipient filters applied to the
+	// account domains (routes list --expand); shown when not nil
+	ExpandedPatterns []ExpandedPattern
+
+	// Continuation marks a streamed page after rows were already written,
+	// which omits the success message and CSV column headers (messages list
+	// --all, messages search)
+	Continuation bool
 }
 
 // SingleConfig configures how single item responses are displayed
@@ -129,13 +203,22 @@ type SingleConfig struct {
 	SuccessMessage string   // Message to show on successful retrieval
 	EmptyMessage   string   // Message to show when item is nil
 	FieldOrder     []string // Optional field ordering for table display
+
+	// ExpandedPatterns are the route's recipient filter applied to the
+	// account domains (routes get --expand); shown when not nil
+	ExpandedPatterns []ExpandedPattern
+
+	// ShowRecords lists every DNS record of a verified domain, not only the
+	// failed ones (domains verify --verbose)
+	ShowRecords bool
 }
 
 // CreateConfig configures how creation responses are displayed
 type CreateConfig struct {
-	SuccessMessage string   // Message to show on successful creation
-	ItemName       string   // Name of the item being created (e.g., "domain", "webhook")
-	FieldOrder     []string // Optional field ordering for table display
+	SuccessMessage string            // Message to show on successful creation
+	ItemName       string            // Name of the item being created (e.g., "domain", "webhook")
+	FieldOrder     []string          // Optional field ordering for table display
+	Metadata       map[string]string // Optional metadata attached to a created message
 }
 
 // UpdateConfig configures how update responses are displayed
@@ -156,6 +239,18 @@ type StatsConfig struct {
 	Title      string   // Title for the statistics display
 	ShowChart  bool     // Whether to show ASCII charts for data
 	FieldOrder []string // Optional field ordering for table display
+
+	// Continuation marks a streamed chunk after the first, which omits the
+	// title and column headers already written
+	Continuation bool
+
+	// Explain adds the explanation and recommended action of each bounce
+	// classification
+	Explain bool
+
+	// Aggregate collapses the time buckets of bounce statistics into one
+	// total per classification over the whole range
+	Aggregate bool
 }
 
 // AuthConfig configures how authentication responses are displayed
@@ -198,8 +293,65 @@ type TriggerConfig struct {
 type AuthStatus struct {
 	Profile string             // Currently active profile name
 	APIKey  string             // Masked API key (showing only account ID)
+	APIURL  string             // Effective API endpoint
 	Account *responses.Account // Full account information
 	Valid   bool               // Whether the authentication is valid
+
+	AccountID   string // Account the profile is bound to
+	AccountName string // Account name stored with the profile
+}
+
+// Doctor check statuses, from best to worst except skip
+const (
+	DoctorStatusPass = "pass"
+	DoctorStatusWarn = "warn"
+	DoctorStatusFail = "fail"
+	DoctorStatusSkip = "skip" // not run because a check it depends on failed
+)
+
+// DoctorCheck is the outcome of one doctor check. Hint says how to fix a
+// warning or failure.
+type DoctorCheck struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	Hint    string `json:"hint"`
+}
+
+// PingResult is the answer to 'ping'. Message is always "pong"; APIURL is
+// the endpoint that answered.
+type PingResult struct {
+	Message string `json:"message"`
+	APIURL  string `json:"api_url"`
+}
+
+// DoctorReport is the outcome of every doctor check, in the order they ran
+type DoctorReport struct {
+	Checks []DoctorCheck `json:"checks"`
+}
+
+// Count returns the number of checks with status
+func (r *DoctorReport) Count(status string) int {
+	count := 0
+	for _, check := range r.Checks {
+		if check.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// Status returns the worst status of the checks: fail, warn or pass.
+// Skipped checks do not count, since the check they depend on failed.
+func (r *DoctorReport) Status() string {
+	switch {
+	case r.Count(DoctorStatusFail) > 0:
+		return DoctorStatusFail
+	case r.Count(DoctorStatusWarn) > 0:
+		return DoctorStatusWarn
+	}
+	return DoctorStatusPass
 }
 
 // SMTPSendResult represents the result of an SMTP send operation
@@ -210,6 +362,72 @@ type SMTPSendResult struct {
 	TestMode  bool   // Whether this was a test send
 }
 
+// SMTP credential usage attributions
+const (
+	SMTPUsageByDomain  = "domains" // counted from the sender domains the credential is restricted to
+	SMTPUsageByAccount = "account" // a global credential, counted from all of the account's messages
+)
+
+// SMTPUsageBucket is the number of messages of one time bucket
+type SMTPUsageBucket struct {
+	From     time.Time `json:"from_timestamp"`
+	To       time.Time `json:"to_timestamp"`
+	Messages int       `json:"messages"`
+}
+
+// SMTPCredentialUsage is the approximate usage of one SMTP credential
+type SMTPCredentialUsage struct {
+	ID           string            `json:"id"`
+	Name         string            `json:"name"`
+	Username     string            `json:"username"`
+	Scope        string            `json:"scope"`
+	Domains      []string          `json:"domains"`
+	Sandbox      bool              `json:"sandbox"`
+	CreatedAt    time.Time         `json:"created_at"`
+	Attribution  string            `json:"attribution"`
+	Messages     int               `json:"messages"`
+	LastActivity *time.Time        `json:"last_activity"` // end of the latest bucket with messages
+	Unused       bool              `json:"unused"`        // no messages in the range, and created before it
+	Buckets      []SMTPUsageBucket `json:"buckets"`
+	Error        string            `json:"error,omitempty"` // statistics could not be fetched
+}
+
+// SMTPUsageReport is the approximate usage of SMTP credentials over a time
+// range. The API does not report usage per credential, so the counts come
+// from deliverability statistics of the sender domains each credential may
+// send from.
+type SMTPUsageReport struct {
+	From        time.Time             `json:"from_time"`
+	To          time.Time             `json:"to_time"`
+	GroupBy     string                `json:"group_by"`
+	Approximate bool                  `json:"approximate"`
+	UnusedFor   string                `json:"unused_for,omitempty"` // set when only unused credentials are listed
+	Checked     int                   `json:"checked"`              // credentials checked, including unlisted ones
+	Credentials []SMTPCredentialUsage `json:"credentials"`
+}
+
+// Unused returns the credentials without messages in the range
+func (r *SMTPUsageReport) Unused() []SMTPCredentialUsage {
+	var unused []SMTPCredentialUsage
+	for _, credential := range r.Credentials {
+		if credential.Unused {
+			unused = append(unused, credential)
+		}
+	}
+	return unused
+}
+
+// SMTPCredentialUpdate is an SMTP credential changed by 'smtp update' and
+// the domains added to and removed from its restrictions. In a dry run the
+// credential is the result the update would have and nothing is changed.
+// The password is never part of an update.
+type SMTPCredentialUpdate struct {
+	Credential     *responses.SMTPCredential `json:"credential"`
+	DryRun         bool                      `json:"dry_run"`
+	AddedDomains   []string                  `json:"added_domains"`
+	RemovedDomains []string                  `json:"removed_domains"`
+}
+
 // CancelMessageResponse represents a message cancellation result
 type CancelMessageResponse struct {
 	MessageID string // ID of the cancelled message
@@ -217,15 +435,670 @@ type CancelMessageResponse struct {
 	Error     string // Error message if failed
 }
 
+// MessageAttemptEntry is one SMTP delivery attempt of a message
+type MessageAttemptEntry struct {
+	Attempt       int           `json:"attempt"`
+	Time          time.Time     `json:"time"`
+	RemoteHost    string        `json:"remote_host"`
+	Status        string        `json:"status"`
+	SMTPCode      int           `json:"smtp_code"`
+	SMTPResponse  string        `json:"smtp_response"`
+	SincePrevious time.Duration `json:"-"` // since the previous attempt, or since creation for the first
+}
+
+// MessageAttemptsReport is the chronological delivery attempt history of a message
+type MessageAttemptsReport struct {
+	MessageID     string                `json:"message_id"`
+	Recipient     string                `json:"recipient"`
+	Status        string                `json:"status"`
+	CreatedAt     time.Time             `json:"created_at"`
+	Final         bool                  `json:"final"` // whether delivery reached a final state
+	TotalDuration time.Duration         `json:"-"`     // creation to final state, or to now while pending
+	Attempts      []MessageAttemptEntry `json:"attempts"`
+}
+
+// MessageContent is the stored content of a message. Body is the part
+// selected with --part; table output summarizes the parts and attachments
+// instead of printing it.
+type MessageContent struct {
+	MessageID   string                     `json:"message_id"`
+	Subject     string                     `json:"subject"`
+	Headers     []MessageHeader            `json:"headers"` // in display order
+	Part        string                     `json:"part"`    // text, html, amp or raw
+	Body        string                     `json:"body"`
+	RawSize     int                        `json:"raw_size"` // of the RFC 822 message; 0 when only the parsed content is stored
+	Parts       []MessageContentPart       `json:"parts"`
+	Attachments []MessageContentAttachment `json:"attachments"`
+	SavedTo     string                     `json:"saved_to,omitempty"` // where --save wrote the raw message
+}
+
+// Message parts 'messages content --part' selects
+const (
+	MessagePartText = "text"
+	MessagePartHTML = "html"
+	MessagePartAMP  = "amp"
+	MessagePartRaw  = "raw" // the whole RFC 822 message
+)
+
+// MessageHeader is one header line of a message
+type MessageHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// MessageContentPart is a body part of a message
+type MessageContentPart struct {
+	Part        string `json:"part"` // text, html or amp; empty for other content types
+	ContentType string `json:"content_type"`
+	Size        int    `json:"size"`
+}
+
+// MessageContentAttachment is an attachment of a message
+type MessageContentAttachment struct {
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	ContentID   string `json:"content_id,omitempty"`
+	Size        int    `json:"size"` // decoded
+}
+
+// ComparisonPeriod is the time window of one side of a statistics comparison
+type ComparisonPeriod struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// MetricComparison holds one metric's values across two periods. Rates are
+// percentages and their Change is in percentage points.
+type MetricComparison struct {
+	Metric         string   `json:"metric"`
+	Current        float64  `json:"current"`
+	Previous       float64  `json:"previous"`
+	Change         float64  `json:"change"`
+	PercentChange  *float64 `json:"percent_change"` // nil when the previous value is zero
+	IsRate         bool     `json:"is_rate"`
+	HigherIsBetter bool     `json:"higher_is_better"`
+
+	// CurrentUndefined and PreviousUndefined mark a rate with nothing to
+	// divide by in that period, such as a delivery rate without receptions.
+	// The value, Change and PercentChange are then zero or nil, shown as N/A.
+	CurrentUndefined  bool `json:"current_undefined,omitempty"`
+	PreviousUndefined bool `json:"previous_undefined,omitempty"`
+}
+
+// Undefined reports whether the metric cannot be compared because its rate
+// is undefined in either period
+func (m MetricComparison) Undefined() bool {
+	return m.CurrentUndefined || m.PreviousUndefined
+}
+
+// DeliverabilityComparison compares deliverability metrics between two periods
+type DeliverabilityComparison struct {
+	CurrentPeriod  ComparisonPeriod   `json:"current_period"`
+	PreviousPeriod ComparisonPeriod   `json:"previous_period"`
+	Metrics        []MetricComparison `json:"metrics"`
+}
+
+// AnomalyBucket is a time bucket whose metric deviates from the buckets
+// before it. Value, Mean and StdDev are percentages.
+type AnomalyBucket struct {
+	From   time.Time `json:"from_timestamp"`
+	To     time.Time `json:"to_timestamp"`
+	Value  float64   `json:"value"`
+	Mean   float64   `json:"mean"`
+	StdDev float64   `json:"stddev"`
+	ZScore *float64  `json:"z_score"` // nil when the preceding buckets did not vary
+}
+
+// AnomalyReport lists the anomalous buckets of a statistics metric
+type AnomalyReport struct {
+	Metric      string          `json:"metric"`
+	GroupBy     string          `json:"group_by"`
+	From        time.Time       `json:"from"`
+	To          time.Time       `json:"to"`
+	Window      int             `json:"window"`
+	Sensitivity float64         `json:"sensitivity"`
+	Buckets     int             `json:"buckets"` // buckets with volume for the metric
+	Scored      int             `json:"scored"`  // buckets with enough history to be scored
+	Anomalies   []AnomalyBucket `json:"anomalies"`
+}
+
+// Verdict summarizes the report in one line, e.g. "2 anomalous days detected"
+func (r *AnomalyReport) Verdict() string {
+	unit := r.GroupBy
+	if len(r.Anomalies) != 1 {
+		unit += "s"
+	}
+	if len(r.Anomalies) == 0 {
+		return fmt.Sprintf("No anomalous %s detected", unit)
+	}
+	return fmt.Sprintf("%d anomalous %s detected", len(r.Anomalies), unit)
+}
+
+// DeliverabilitySummary aggregates deliverability counts over a whole range.
+// Rates are weighted by volume, i.e. summed counts over summed denominators
+// rather than an average of per-bucket rates, and are nil when the
+// denominator is zero.
+type DeliverabilitySummary struct {
+	From         time.Time `json:"from"`
+	To           time.Time `json:"to"`
+	Buckets      int       `json:"buckets"`
+	Reception    int       `json:"reception_count"`
+	Delivered    int       `json:"delivered_count"`
+	Deferred     int       `json:"deferred_count"`
+	Bounced      int       `json:"bounced_count"`
+	Failed       int       `json:"failed_count"`
+	Suppressed   int       `json:"suppressed_count"`
+	Opened       int       `json:"opened_count"`
+	Clicked      int       `json:"clicked_count"`
+	DeliveryRate *float64  `json:"delivery_rate"` // delivered / reception
+	BounceRate   *float64  `json:"bounce_rate"`   // bounced / reception
+	OpenRate     *float64  `json:"open_rate"`     // opened / delivered
+	ClickRate    *float64  `json:"click_rate"`    // clicked / delivered
+}
+
+// DeliverabilityBucket is one time bucket of deliverability counts with its
+// rates, which are nil when their denominator is zero
+type DeliverabilityBucket struct {
+	responses.DeliverabilityStatistics
+	DeliveryRate *float64 `json:"delivery_rate"` // delivered / reception
+	OpenRate     *float64 `json:"open_rate"`     // opened / delivered
+}
+
+// Tag statistics availability
+const (
+	TagStatusAvailable   = "available"
+	TagStatusUnavailable = "unavailable"
+)
+
+// TagDeliverability is the deliverability of the messages with one tag. Its
+// rates are computed from that tag's counts only. When the tag's statistics
+// could not be fetched, Status is TagStatusUnavailable, Error says why and
+// there are no counts.
+type TagDeliverability struct {
+	Tag     string
+	Status  string
+	Error   string
+	Summary *DeliverabilitySummary
+	Buckets []DeliverabilityBucket
+}
+
+// DeliverabilityByTag breaks deliverability down by message tag, with the
+// tags in the order they were requested. Buckets are only filled in when
+// SummaryOnly is false.
+type DeliverabilityByTag struct {
+	From        time.Time
+	To          time.Time
+	GroupBy     string
+	SummaryOnly bool
+	Tags        []TagDeliverability
+}
+
+// Statuses of an ExpandedPattern
+const (
+	PatternActive     = "active"     // the domain is verified
+	PatternUnverified = "unverified" // the domain is in the account but its DNS is not verified
+	PatternOrphaned   = "orphaned"   // the domain is not in the account, so mail to it is dropped
+	PatternNoDomain   = "no_domain"  // no verified account domain matches the filter
+)
+
+// ExpandedPattern is a route's recipient filter applied to one account
+// domain, e.g. "support-*@" becomes "support-*@example.com"
+type ExpandedPattern struct {
+	RouteID uuid.UUID
+	Pattern string // the concrete pattern; the filter as is for PatternNoDomain
+	Domain  string // empty for PatternNoDomain
+	Status  string
+}
+
+// BulkDeleteItem is the outcome of deleting one resource in a bulk delete
+type BulkDeleteItem struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Deleted bool   `json:"deleted"`
+	Error   string `json:"error,omitempty"`
+}
+
+// BulkDeleteResult summarizes a bulk delete by name pattern
+type BulkDeleteResult struct {
+	Pattern string           `json:"pattern"`
+	Matched int              `json:"matched"`
+	Deleted int              `json:"deleted"`
+	Failed  int              `json:"failed"`
+	Items   []BulkDeleteItem `json:"items"`
+}
+
+// NewBulkDeleteResult builds a BulkDeleteResult and its counts from the items
+func NewBulkDeleteResult(pattern string, items []BulkDeleteItem) *BulkDeleteResult {
+	result := &BulkDeleteResult{Pattern: pattern, Matched: len(items), Items: items}
+	for _, item := range items {
+		if item.Deleted {
+			result.Deleted++
+		} else {
+			result.Failed++
+		}
+	}
+	return result
+}
+
+// Import item statuses
+const (
+	ImportCreated   = "created"
+	ImportUpdated   = "updated"
+	ImportUnchanged = "unchanged" // exists with the same settings
+	ImportSkipped   = "skipped"   // exists, and --update-existing was not given
+	ImportFailed    = "failed"
+)
+
+// ImportItem is the outcome of importing one resource from a file
+type ImportItem struct {
+	Name   string `json:"name"`
+	ID     string `json:"id,omitempty"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ImportResult summarizes an import of resources from a file
+type ImportResult struct {
+	File          string       `json:"file"`
+	SourceAccount string       `json:"source_account,omitempty"`
+	Created       int          `json:"created"`
+	Updated       int          `json:"updated"`
+	Unchanged     int          `json:"unchanged"`
+	Skipped       int          `json:"skipped"`
+	Failed        int          `json:"failed"`
+	Items         []ImportItem `json:"items"`
+}
+
+// NewImportResult builds an ImportResult and its counts from the items
+func NewImportResult(file, sourceAccount string, items []ImportItem) *ImportResult {
+	result := &ImportResult{File: file, SourceAccount: sourceAccount, Items: items}
+	for _, item := range items {
+		switch item.Status {
+		case ImportCreated:
+			result.Created++
+		case ImportUpdated:
+			result.Updated++
+		case ImportUnchanged:
+			result.Unchanged++
+		case ImportSkipped:
+			result.Skipped++
+		default:
+			result.Failed++
+		}
+	}
+	return result
+}
+
+// SuppressionReasonCount is the number of selected suppressions with one reason
+type SuppressionReasonCount struct {
+	Reason string `json:"reason"`
+	Count  int    `json:"count"`
+}
+
+// SuppressionWipeFailure is a suppression that could not be deleted
+type SuppressionWipeFailure struct {
+	Email  string `json:"email"`
+	Domain string `json:"domain,omitempty"`
+	Error  string `json:"error"`
+}
+
+// SuppressionWipeSummary describes the suppressions selected by a scoped
+// wipe and, unless it is a dry run, how many of them were deleted
+type SuppressionWipeSummary struct {
+	Domain   string                   `json:"domain,omitempty"`
+	Match    string                   `json:"match,omitempty"`
+	DryRun   bool                     `json:"dry_run"`
+	Matched  int                      `json:"matched"`
+	Reasons  []SuppressionReasonCount `json:"reasons"`
+	Deleted  int                      `json:"deleted"`
+	Failed   int                      `json:"failed"`
+	Failures []SuppressionWipeFailure `json:"failures,omitempty"`
+}
+
+// BounceSuppressionFailure is a bounced address that could not be suppressed
+type BounceSuppressionFailure struct {
+	Email string `json:"email"`
+	Error string `json:"error"`
+}
+
+// BounceSuppressionSummary describes the addresses selected from recent
+// bounces by 'suppressions create --from-bounces' and, unless it is a dry
+// run, how many of them were suppressed
+type BounceSuppressionSummary struct {
+	FromTime          time.Time                  `json:"from_time"`
+	ToTime            *time.Time                 `json:"to_time,omitempty"`
+	Classifications   []string                   `json:"classifications"`
+	Domain            string                     `json:"domain,omitempty"`
+	DryRun            bool                       `json:"dry_run"`
+	Messages          int                        `json:"messages"`           // bounced messages scanned
+	Matched           int                        `json:"matched"`            // unique addresses with a selected classification
+	AlreadySuppressed int                        `json:"already_suppressed"` // matched addresses skipped as already suppressed
+	Created           int                        `json:"created"`
+	Failed            int                        `json:"failed"`
+	Failures          []BounceSuppressionFailure `json:"failures,omitempty"`
+}
+
+// ToSuppress returns the number of matched addresses not yet suppressed
+func (s *BounceSuppressionSummary) ToSuppress() int {
+	return s.Matched - s.AlreadySuppressed
+}
+
+// SuppressionImportError is a row of a suppressions import file that was
+// not suppressed, because it is invalid or the API rejected it
+type SuppressionImportError struct {
+	Row    int    `json:"row"` // CSV line or JSON array position, from 1
+	Email  string `json:"email"`
+	Domain string `json:"domain,omitempty"`
+	Error  string `json:"error"`
+}
+
+// SuppressionImportSummary describes the outcome of 'suppressions import'
+type SuppressionImportSummary struct {
+	File       string                   `json:"file"`
+	ErrorsFile string                   `json:"errors_file,omitempty"`
+	Rows       int                      `json:"rows"`
+	Created    int                      `json:"created"`
+	Invalid    int                      `json:"invalid"` // rows that failed validation and were not sent
+	Failed     int                      `json:"failed"`  // rows the API rejected
+	Skipped    int                      `json:"skipped"` // duplicate rows, and rows not attempted after an interrupt
+	Errors     []SuppressionImportError `json:"errors,omitempty"`
+}
+
+// MessageExportSkip is a message that 'messages export' did not write
+type MessageExportSkip struct {
+	ID     string `json:"id"`
+	Reason string `json:"reason"`
+}
+
+// MessageExportSummary describes the .eml files written by 'messages export'
+type MessageExportSummary struct {
+	OutputDir  string              `json:"output_dir"`
+	Exported   int                 `json:"exported"`
+	Skipped    int                 `json:"skipped"`
+	TotalBytes int64               `json:"total_bytes"`
+	Skips      []MessageExportSkip `json:"skips,omitempty"`
+}
+
+// DryRunContent is a content part of a message previewed by 'messages send
+// --dry-run', sized as rendered for the first recipient
+type DryRunContent struct {
+	Type   string `json:"type"`   // MIME type, e.g. text/html
+	Source string `json:"source"` // the template file or the flag it was given with
+	Size   int    `json:"size"`
+}
+
+// DryRunAttachment is a file attached to a message previewed by 'messages
+// send --dry-run'
+type DryRunAttachment struct {
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	ContentID   string `json:"content_id,omitempty"`
+	Inline      bool   `json:"inline"`
+	Size        int    `json:"size"` // of the file, before encoding
+}
+
+// DryRunBatch is one request a send would make
+type DryRunBatch struct {
+	IdempotencyKey string                         `json:"idempotency_key"`
+	Recipients     int                            `json:"recipients"`
+	Request        *requests.CreateMessageRequest `json:"request"`
+}
+
+// DryRunScheduleBucket is the number of recipients a send would deliver at
+// one schedule time
+type DryRunScheduleBucket struct {
+	SendAt     *time.Time `json:"send_at"` // nil when sent immediately
+	Recipients int        `json:"recipients"`
+}
+
+// DryRunMessage is what 'messages send --dry-run' checked and would have
+// sent. Recipients counts every copy, CC and BCC addresses included.
+type DryRunMessage struct {
+	From           string             `json:"from"`
+	Recipients     int                `json:"recipients"`
+	Cc             []string           `json:"cc"`
+	Bcc            []string           `json:"bcc"`
+	FirstRecipient string             `json:"first_recipient"`
+	Subject        string             `json:"subject"`                 // with the first recipient's substitutions
+	SubjectField   string             `json:"subject_field,omitempty"` // recipients file field holding each recipient's subject
+	Subjects       int                `json:"subjects,omitempty"`      // distinct subjects, with SubjectField
+	Content        []DryRunContent    `json:"content"`
+	Attachments    []DryRunAttachment `json:"attachments"`
+	TotalSize      int                `json:"total_size"` // content and encoded attachments
+	TrackOpens     bool               `json:"track_opens"`
+	TrackClicks    bool               `json:"track_clicks"`
+	Tags           []string           `json:"tags"`
+	DomainDefaults []string           `json:"domain_defaults"` // settings taken from the sender's domain, e.g. track_opens=false
+	Metadata       map[string]string  `json:"metadata"`        // sent as X-AhaSend-Meta-<key> headers
+	Sandbox        bool               `json:"sandbox"`
+	SandboxResult  string             `json:"sandbox_result,omitempty"`
+	Schedule       *time.Time         `json:"schedule,omitempty"`
+	// Recipients per schedule time, when the recipients file has
+	// per-recipient send times
+	ScheduleBuckets []DryRunScheduleBucket `json:"schedule_buckets,omitempty"`
+	Batches         []DryRunBatch          `json:"batches"`
+}
+
+// Webhook coverage finding severities
+const (
+	CoverageSeverityGap  = "gap"  // activity occurred but no enabled webhook subscribes to the event
+	CoverageSeverityInfo = "info" // no enabled webhook subscribes to the event and no activity was seen
+)
+
+// CoverageWebhook identifies an enabled webhook in a coverage report
+type CoverageWebhook struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// EventCoverage describes which webhooks subscribe to an event and how much
+// recent activity the account had for it
+type EventCoverage struct {
+	Event          string   `json:"event"`
+	Description    string   `json:"description"`
+	Subscribers    []string `json:"subscribers"`               // IDs of the subscribed webhooks
+	Activity       *int     `json:"activity"`                  // nil when activity is not available for the event
+	ActivityCapped bool     `json:"activity_capped,omitempty"` // Activity is a lower bound
+}
+
+// CoverageFinding is a coverage problem worth reporting
+type CoverageFinding struct {
+	Event    string `json:"event"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// WebhookCoverageReport compares webhook event subscriptions to account activity
+type WebhookCoverageReport struct {
+	Since    time.Time         `json:"since"`
+	Webhooks []CoverageWebhook `json:"webhooks"`
+	Events   []EventCoverage   `json:"events"`
+	Findings []CoverageFinding `json:"findings"`
+}
+
+// Gaps returns the findings where activity occurred without coverage
+func (r *WebhookCoverageReport) Gaps() []CoverageFinding {
+	var gaps []CoverageFinding
+	for _, finding := range r.Findings {
+		if finding.Severity == CoverageSeverityGap {
+			gaps = append(gaps, finding)
+		}
+	}
+	return gaps
+}
+
+// Simulated webhook event statuses
+const (
+	SimulationStatusSigned    = "signed"    // printed only, not sent
+	SimulationStatusDelivered = "delivered" // the receiver answered with a 2xx status
+	SimulationStatusFailed    = "failed"    // the request failed or the receiver answered with another status
+)
+
+// WebhookSimulationEvent is a signed simulated event and, when it was sent,
+// the receiver's response. Payload holds the exact signed body; Failure
+// categorizes a failed delivery.
+type WebhookSimulationEvent struct {
+	MsgID      string                   `json:"msg_id"`
+	Type       string                   `json:"type"`
+	SignedAt   int64                    `json:"webhook_timestamp"`
+	Signature  string                   `json:"webhook_signature"`
+	Payload    string                   `json:"payload"`
+	Status     string                   `json:"status"`
+	StatusCode int                      `json:"status_code,omitempty"`
+	DurationMs int64                    `json:"duration_ms,omitempty"`
+	Failure    webhooks.FailureCategory `json:"failure,omitempty"`
+	Error      string                   `json:"error,omitempty"`
+}
+
+// WebhookSimulation is the result of sending simulated events to a webhook
+type WebhookSimulation struct {
+	WebhookID string                   `json:"webhook_id"`
+	URL       string                   `json:"url"`
+	Event     string                   `json:"event"`
+	Seed      uint64                   `json:"seed"`
+	Sent      bool                     `json:"sent"`
+	Events    []WebhookSimulationEvent `json:"events"`
+}
+
+// Failed returns the number of events the receiver did not accept
+func (s *WebhookSimulation) Failed() int {
+	failed := 0
+	for _, event := range s.Events {
+		if event.Status == SimulationStatusFailed {
+			failed++
+		}
+	}
+	return failed
+}
+
+// WebhookVerification is the result of checking the signature of a webhook
+// delivery locally. ComputedSignature is the signature of the payload as
+// given; Problems lists the likely causes of a failed check.
+type WebhookVerification struct {
+	Valid                         bool     `json:"valid"`
+	SignatureMatch                bool     `json:"signature_match"`
+	ComputedSignature             string   `json:"computed_signature"`
+	ProvidedSignatures            []string `json:"provided_signatures"`
+	MatchesWithoutTrailingNewline bool     `json:"matches_without_trailing_newline"` // a signature matches the payload with its trailing newline stripped
+	MsgID                         string   `json:"msg_id"`
+	Timestamp                     int64    `json:"webhook_timestamp"`
+	TimestampValid                bool     `json:"timestamp_valid"`
+	SkewSeconds                   int64    `json:"skew_seconds"` // how far the timestamp is ahead of the local clock; negative when behind
+	ToleranceSeconds              int64    `json:"tolerance_seconds"`
+	Problems                      []string `json:"problems"`
+}
+
+// WebhookTriggerEvent is a test event triggered with payload overrides
+type WebhookTriggerEvent struct {
+	MsgID      string                   `json:"msg_id"`
+	Type       string                   `json:"type"`
+	Payload    map[string]interface{}   `json:"payload"`   // the final payload, after the overrides
+	Overrides  []jsonmerge.Change       `json:"overrides"` // what the overrides changed in the generated payload
+	Status     string                   `json:"status"`
+	StatusCode int                      `json:"status_code,omitempty"`
+	DurationMs int64                    `json:"duration_ms,omitempty"`
+	Failure    webhooks.FailureCategory `json:"failure,omitempty"`
+	Error      string                   `json:"error,omitempty"`
+}
+
+// WebhookTrigger is the result of triggering webhook events with payload
+// overrides, which the CLI generates, signs and delivers itself
+type WebhookTrigger struct {
+	WebhookID string                `json:"webhook_id"`
+	URL       string                `json:"url"`
+	Events    []WebhookTriggerEvent `json:"events"`
+}
+
+// Failed returns the number of events the receiver did not accept
+func (t *WebhookTrigger) Failed() int {
+	failed := 0
+	for _, event := range t.Events {
+		if event.Status == SimulationStatusFailed {
+			failed++
+		}
+	}
+	return failed
+}
+
+// WebhookEdit is what editing a webhook's settings changes, previewed by
+// 'webhooks update --edit --dry-run' instead of being applied
+type WebhookEdit struct {
+	WebhookID string             `json:"webhook_id"`
+	Name      string             `json:"name"`
+	Changes   []jsonmerge.Change `json:"changes"`
+}
+
+// RouteOptionExplanation is the state of a route processing option and what
+// it does to inbound email
+type RouteOptionExplanation struct {
+	Key     string `json:"key"`
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	Effect  string `json:"effect"`
+}
+
+// RouteExplanation describes what a route configuration does to inbound
+// email: the effect of each processing option, the fields its payloads
+// carry and an example payload
+type RouteExplanation struct {
+	RouteID        string                       `json:"route_id,omitempty"`
+	Source         string                       `json:"source"` // the route ID or the configuration file
+	Name           string                       `json:"name"`
+	URL            string                       `json:"url"`
+	Recipient      string                       `json:"recipient"`
+	Options        []RouteOptionExplanation     `json:"options"`
+	Fields         []webhooks.RoutePayloadField `json:"fields"`
+	ExamplePayload map[string]interface{}       `json:"example_payload"`
+}
+
 // handlerBase provides common functionality for all response handlers
 type handlerBase struct {
-	writer      io.Writer
-	colorOutput bool
+	writer    io.Writer // out, converted to ASCII when emoji are disabled
+	out       io.Writer
+	caps      Capabilities
+	decorated bool // whether the format carries colors and emoji: table and plain
+	quiet     bool
+	warnings  []string
 }
 
 // SetWriter sets the output writer
 func (h *handlerBase) SetWriter(w io.Writer) {
+	h.out = w
 	h.writer = w
+	if h.decorated && !h.caps.Emoji {
+		h.writer = NewASCIIWriter(w)
+	}
+}
+
+// SetCapabilities sets the colors and emoji the output may use
+func (h *handlerBase) SetCapabilities(caps Capabilities) {
+	h.caps = caps
+	h.SetWriter(h.out)
+}
+
+// GetCapabilities returns the colors and emoji the output may use
+func (h *handlerBase) GetCapabilities() Capabilities {
+	return h.caps
+}
+
+// SetQuiet sets whether success messages and advisory notes are suppressed
+func (h *handlerBase) SetQuiet(quiet bool) {
+	h.quiet = quiet
+}
+
+// printMessage writes a success message or advisory note, which --quiet
+// suppresses
+func (h *handlerBase) printMessage(format string, args ...interface{}) {
+	if h.quiet {
+		return
+	}
+	fmt.Fprintf(h.writer, format, args...)
+}
+
+// AddWarning records a warning for formats that include them
+func (h *handlerBase) AddWarning(message string) {
+	h.warnings = append(h.warnings, message)
 }
 
 // GetResponseHandler creates a new response handler based on the format string
@@ -235,16 +1108,21 @@ func GetResponseHandler(format string, colorOutput bool, writer io.Writer) Respo
 	}
 
 	base := handlerBase{
-		writer:      writer,
-		colorOutput: colorOutput,
+		writer: writer,
+		out:    writer,
+		caps:   Capabilities{Color: colorOutput, Emoji: true},
 	}
 
 	switch format {
 	case "json":
 		return &jsonHandler{handlerBase: base}
+	case "jsonl":
+		return &jsonlHandler{jsonHandler{handlerBase: base, lines: true}}
 	case "table":
+		base.decorated = true
 		return &tableHandler{handlerBase: base}
 	case "plain":
+		base.decorated = true
 		return &plainHandler{handlerBase: base}
 	case "csv":
 		return &csvHandler{handlerBase: base}
@@ -256,7 +1134,7 @@ func GetResponseHandler(format string, colorOutput bool, writer io.Writer) Respo
 
 // GetSupportedFormats returns the list of supported output formats
 func GetSupportedFormats() []string {
-	return []string{"json", "table", "plain", "csv"}
+	return []string{"json", "jsonl", "table", "plain", "csv"}
 }
 
 // ValidateFormat validates that a format is supported
@@ -294,6 +1172,18 @@ func (h *unsupportedHandler) HandleSingleDomain(domain *responses.Domain, config
 	return fmt.Errorf("unsupported output format: %s", h.format)
 }
 
+func (h *unsupportedHandler) HandleDNSPropagation(matrix *dns.PropagationMatrix, config SingleConfig) error {
+	return fmt.Errorf("unsupported output format: %s", h.format)
+}
+
+func (h *unsupportedHandler) HandleDNSVerification(domain *responses.Domain, verification *dns.Verification, config SingleConfig) error {
+	return fmt.Errorf("unsupported output format: %s", h.format)
+}
+
+func (h *unsupportedHandler) HandleDomainVerification(verification *dns.LocalVerification, config SingleConfig) error {
+	return fmt.Errorf("unsupported output format: %s", h.format)
+}
+
 func (h *unsupportedHandler) HandleMessageList(response *responses.PaginatedMessagesResponse, config ListConfig) error {
 	return fmt.Errorf("unsupported output format: %s", h.format)
 }
@@ -310,6 +1200,18 @@ func (h *unsupportedHandler) HandleCancelMessage(response *CancelMessageResponse
 	return fmt.Errorf("unsupported output format: %s", h.format)
 }
 
+func (h *unsupportedHandler) HandleDryRunMessage(dryRun *DryRunMessage, config SimpleConfig) error {
+	return fmt.Errorf("unsupported output format: %s", h.format)
+}
+
+func (h *unsupportedHandler) HandleMessageAttempts(report *MessageAttemptsReport, config SingleConfig) error {
+	return fmt.Errorf("unsupported output format: %s", h.format)
+}
+
+func (h *unsupportedHandler) HandleMessageContent(content *MessageContent, config SingleConfig) error {
+	return fmt.Errorf("unsupported output format: %s", h.format)
+}
+
 func (h *unsupportedHandler) HandleWebhookList(response *responses.PaginatedWebhooksResponse, config ListConfig) error {
 	return fmt.Errorf("unsupported output format: %s", h.format)
 }
@@ -326,6 +1228,14 @@ func (h *unsupportedHandler) HandleUpdateWebhook(webhook *responses.Webhook, con
 	return fmt.Errorf("unsupported output format: %s", h.format)
 }
 
+func (h *unsupportedHandler) HandleRotateWebhookSecret(webhook *responses.Webhook, config UpdateConfig) error {
+	return fmt.Errorf("unsupported output format: %s", h.format)
+}
+
+func (h *unsupportedHandler) HandleWebhookEdit(edit *WebhookEdit, config UpdateConfig) error {
+	return fmt.Errorf("unsupported output format: %s", h.format)
+}
+
 func (h *unsupportedHandler) HandleDeleteWebhook(success bool, config DeleteConfig) error {
 	return fmt.Errorf("unsupported output format: %s", h.format)
 }
@@ -334,6 +1244,22 @@ func (h *unsupportedHandler) HandleTriggerWebhook(webhookID string, events []str
 	return fmt.Errorf("unsupported output format: %s", h.format)
 }
 
+func (h *unsupportedHandler) HandleTriggerWebhookOverrides(trigger *WebhookTrigger, config TriggerConfig) error {
+	return fmt.Errorf("unsupported output format: %s", h.format)
+}
+
+func (h *unsupportedHandler) HandleWebhookCoverage(report *WebhookCoverageReport, config SingleConfig) error {
+	return fmt.Errorf("unsupported output format: %s", h.format)
+}
+
+func (h *unsupportedHandler) HandleWebhookSimulation(simulation *WebhookSimulation, config SingleConfig) error {
+	return fmt.Errorf("unsupported output format: %s", h.format)
+}
+
+func (h *unsupportedHandler) HandleWebhookVerification(verification *WebhookVerification, config SingleConfig) error {
+	return fmt.Errorf("unsupported output format: %s", h.format)
+}
+
 func (h *unsupportedHandler) HandleRouteList(response *responses.PaginatedRoutesResponse, config ListConfig) error {
 	return fmt.Errorf("unsupported output format: %s", h.format)
 }
@@ -356,6 +1282,9 @@ func (h *unsupportedHandler) HandleDeleteRoute(success bool, config DeleteConfig
 func (h *unsupportedHandler) HandleTriggerRoute(routeID string, config TriggerConfig) error {
 	return fmt.Errorf("unsupported output format: %s", h.format)
 }
+func (h *unsupportedHandler) HandleRouteExplanation(explanation *RouteExplanation, config SingleConfig) error {
+	return fmt.Errorf("unsupported output format: %s", h.format)
+}
 
 func (h *unsupportedHandler) HandleSuppressionList(response *responses.PaginatedSuppressionsResponse, config ListConfig) error {
 	return fmt.Errorf("unsupported output format: %s", h.format)
@@ -377,6 +1306,22 @@ func (h *unsupportedHandler) HandleWipeSuppression(count int, config WipeConfig)
 	return fmt.Errorf("unsupported output format: %s", h.format)
 }
 
+func (h *unsupportedHandler) HandleMessageExport(summary *MessageExportSummary, config SimpleConfig) error {
+	return fmt.Errorf("unsupported output format: %s", h.format)
+}
+
+func (h *unsupportedHandler) HandleSuppressionWipeSummary(summary *SuppressionWipeSummary, config WipeConfig) error {
+	return fmt.Errorf("unsupported output format: %s", h.format)
+}
+
+func (h *unsupportedHandler) HandleBounceSuppressions(summary *BounceSuppressionSummary, config CreateConfig) error {
+	return fmt.Errorf("unsupported output format: %s", h.format)
+}
+
+func (h *unsupportedHandler) HandleSuppressionImport(summary *SuppressionImportSummary, config CreateConfig) error {
+	return fmt.Errorf("unsupported output format: %s", h.format)
+}
+
 func (h *unsupportedHandler) HandleCheckSuppression(suppression *responses.Suppression, found bool, config CheckConfig) error {
 	return fmt.Errorf("unsupported output format: %s", h.format)
 }
@@ -393,6 +1338,10 @@ func (h *unsupportedHandler) HandleCreateSMTP(credential *responses.SMTPCredenti
 	return fmt.Errorf("unsupported output format: %s", h.format)
 }
 
+func (h *unsupportedHandler) HandleUpdateSMTP(update *SMTPCredentialUpdate, config UpdateConfig) error {
+	return fmt.Errorf("unsupported output format: %s", h.format)
+}
+
 func (h *unsupportedHandler) HandleDeleteSMTP(success bool, config DeleteConfig) error {
 	return fmt.Errorf("unsupported output format: %s", h.format)
 }
@@ -401,6 +1350,10 @@ func (h *unsupportedHandler) HandleSMTPSend(result *SMTPSendResult, config SMTPS
 	return fmt.Errorf("unsupported output format: %s", h.format)
 }
 
+func (h *unsupportedHandler) HandleSMTPUsage(report *SMTPUsageReport, config SingleConfig) error {
+	return fmt.Errorf("unsupported output format: %s", h.format)
+}
+
 func (h *unsupportedHandler) HandleAPIKeyList(response *responses.PaginatedAPIKeysResponse, config ListConfig) error {
 	return fmt.Errorf("unsupported output format: %s", h.format)
 }
@@ -437,6 +1390,26 @@ func (h *unsupportedHandler) HandleSubAccountUsage(response *responses.SubAccoun
 	return fmt.Errorf("unsupported output format: %s", h.format)
 }
 
+func (h *unsupportedHandler) HandleStatsAnomalies(report *AnomalyReport, config StatsConfig) error {
+	return fmt.Errorf("unsupported output format: %s", h.format)
+}
+
+func (h *unsupportedHandler) HandleDeliverabilityComparison(comparison *DeliverabilityComparison, config StatsConfig) error {
+	return fmt.Errorf("unsupported output format: %s", h.format)
+}
+
+func (h *unsupportedHandler) HandleDeliverabilityStatsChunk(response *responses.DeliverabilityStatisticsResponse, config StatsConfig) error {
+	return fmt.Errorf("unsupported output format: %s", h.format)
+}
+
+func (h *unsupportedHandler) HandleDeliverabilitySummary(summary *DeliverabilitySummary, config StatsConfig) error {
+	return fmt.Errorf("unsupported output format: %s", h.format)
+}
+
+func (h *unsupportedHandler) HandleDeliverabilityByTag(report *DeliverabilityByTag, config StatsConfig) error {
+	return fmt.Errorf("unsupported output format: %s", h.format)
+}
+
 func (h *unsupportedHandler) HandleDeliverabilityStats(response *responses.DeliverabilityStatisticsResponse, config StatsConfig) error {
 	return fmt.Errorf("unsupported output format: %s", h.format)
 }
@@ -449,6 +1422,14 @@ func (h *unsupportedHandler) HandleDeliveryTimeStats(response *responses.Deliver
 	return fmt.Errorf("unsupported output format: %s", h.format)
 }
 
+func (h *unsupportedHandler) HandleBounceExplanations(explanations []bounces.Explanation, config ListConfig) error {
+	return fmt.Errorf("unsupported output format: %s", h.format)
+}
+
+func (h *unsupportedHandler) HandleReminderList(reminders []state.Reminder, config ListConfig) error {
+	return fmt.Errorf("unsupported output format: %s", h.format)
+}
+
 func (h *unsupportedHandler) HandleAuthLogin(success bool, profile string, config AuthConfig) error {
 	return fmt.Errorf("unsupported output format: %s", h.format)
 }
@@ -457,6 +1438,10 @@ func (h *unsupportedHandler) HandleAuthLogout(success bool, config AuthConfig) e
 	return fmt.Errorf("unsupported output format: %s", h.format)
 }
 
+func (h *unsupportedHandler) HandleAccount(account *responses.Account, config SingleConfig) error {
+	return fmt.Errorf("unsupported output format: %s", h.format)
+}
+
 func (h *unsupportedHandler) HandleAuthStatus(status *AuthStatus, config AuthConfig) error {
 	return fmt.Errorf("unsupported output format: %s", h.format)
 }
@@ -465,6 +1450,22 @@ func (h *unsupportedHandler) HandleAuthSwitch(newProfile string, config AuthConf
 	return fmt.Errorf("unsupported output format: %s", h.format)
 }
 
+func (h *unsupportedHandler) HandleDoctorReport(report *DoctorReport, config SingleConfig) error {
+	return fmt.Errorf("unsupported output format: %s", h.format)
+}
+
+func (h *unsupportedHandler) HandlePing(result *PingResult, config SimpleConfig) error {
+	return fmt.Errorf("unsupported output format: %s", h.format)
+}
+
+func (h *unsupportedHandler) HandleBulkDelete(result *BulkDeleteResult, config DeleteConfig) error {
+	return fmt.Errorf("unsupported output format: %s", h.format)
+}
+
+func (h *unsupportedHandler) HandleImport(result *ImportResult, config CreateConfig) error {
+	return fmt.Errorf("unsupported output format: %s", h.format)
+}
+
 func (h *unsupportedHandler) HandleSimpleSuccess(message string) error {
 	return fmt.Errorf("unsupported output format: %s", h.format)
 }
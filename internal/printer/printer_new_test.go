@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/AhaSend/ahasend-go/models/responses"
 	"github.com/stretchr/testify/assert"
@@ -17,6 +18,7 @@ func TestNewResponseHandlerTypes(t *testing.T) {
 		expectError bool
 	}{
 		{"json handler", "json", false},
+		{"jsonl handler", "jsonl", false},
 		{"table handler", "table", false},
 		{"plain handler", "plain", false},
 		{"csv handler", "csv", false},
@@ -35,7 +37,7 @@ func TestNewResponseHandlerTypes(t *testing.T) {
 }
 
 func TestResponseHandlerInterfaceMethods(t *testing.T) {
-	formats := []string{"json", "table", "plain", "csv"}
+	formats := []string{"json", "jsonl", "table", "plain", "csv"}
 
 	for _, format := range formats {
 		t.Run(format, func(t *testing.T) {
@@ -113,6 +115,54 @@ func TestJSONResponseHandler(t *testing.T) {
 		assert.Contains(t, result, "data")
 	})
 
+	t.Run("Handle created message with metadata", func(t *testing.T) {
+		buf.Reset()
+		response := &responses.CreateMessageResponse{
+			Object: "list",
+			Data:   []responses.CreateSingleMessageResponse{{Status: "queued"}},
+		}
+		err := handler.HandleCreateMessage(response, CreateConfig{
+			SuccessMessage: "Message sent successfully",
+			Metadata:       map[string]string{"order_id": "12345"},
+		})
+		require.NoError(t, err)
+
+		var result map[string]interface{}
+		err = json.Unmarshal(buf.Bytes(), &result)
+		assert.NoError(t, err)
+		assert.Contains(t, result, "data")
+		assert.Equal(t, map[string]interface{}{"order_id": "12345"}, result["metadata"])
+	})
+
+	t.Run("Embedded structs are flattened", func(t *testing.T) {
+		buf.Reset()
+		rate := 90.0
+		err := handler.HandleDeliverabilityByTag(&DeliverabilityByTag{
+			Tags: []TagDeliverability{{
+				Tag:    "welcome",
+				Status: TagStatusAvailable,
+				Buckets: []DeliverabilityBucket{{
+					DeliverabilityStatistics: responses.DeliverabilityStatistics{ReceptionCount: 100, DeliveredCount: 90},
+					DeliveryRate:             &rate,
+				}},
+			}},
+		}, StatsConfig{})
+		require.NoError(t, err)
+
+		var result struct {
+			Tags []struct {
+				Buckets []map[string]interface{} `json:"buckets"`
+			} `json:"tags"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+		require.Len(t, result.Tags, 1)
+		require.Len(t, result.Tags[0].Buckets, 1)
+		bucket := result.Tags[0].Buckets[0]
+		assert.Equal(t, 100.0, bucket["reception_count"])
+		assert.Equal(t, 90.0, bucket["delivery_rate"])
+		assert.NotContains(t, bucket, "DeliverabilityStatistics")
+	})
+
 	t.Run("Handle simple success", func(t *testing.T) {
 		buf.Reset()
 		err := handler.HandleSimpleSuccess("Operation completed")
@@ -165,7 +215,7 @@ func TestResponseHandlerMessages(t *testing.T) {
 }
 
 func TestResponseHandlerEmptyData(t *testing.T) {
-	formats := []string{"json", "table", "plain", "csv"}
+	formats := []string{"json", "jsonl", "table", "plain", "csv"}
 
 	for _, format := range formats {
 		t.Run(format, func(t *testing.T) {
@@ -201,3 +251,17 @@ func TestUnsupportedResponseHandler(t *testing.T) {
 		assert.Contains(t, err.Error(), "unsupported output format: xml")
 	})
 }
+
+func TestSMTPListTruncatesUnicodeDomains(t *testing.T) {
+	response := &responses.PaginatedSMTPCredentialsResponse{
+		Data: []responses.SMTPCredential{{
+			Name:    "idn",
+			Domains: []string{"xn--bcher-kva.example", "xn--mnchen-3ya.example", "xn--zrich-kva.example"},
+		}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, GetResponseHandler("table", false, &buf).HandleSMTPList(response, ListConfig{}))
+	assert.True(t, utf8.Valid(buf.Bytes()), "truncation must not split a character")
+	assert.Contains(t, buf.String(), "bücher.example (xn--bcher-k...")
+}
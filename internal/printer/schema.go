@@ -0,0 +1,346 @@
+package printer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+)
+
+// SchemaVersion is the version of the JSON output schema, written as the
+// top-level schema_version of every JSON document (and of every JSONL line)
+// the JSON handler prints. Scripts can check it to detect output they were
+// not written for.
+//
+// The version follows the SchemaChange policy below: additive changes keep
+// it, breaking changes bump it. The golden files in testdata/golden enforce
+// this, so a field rename or removal cannot ship without a bump.
+const SchemaVersion = 1
+
+// SchemaChange classifies a change to the JSON output of a handler and
+// decides whether it needs a SchemaVersion bump
+type SchemaChange int
+
+const (
+	// SchemaUnchanged means every key keeps its name and type; only the
+	// sample values differ
+	SchemaUnchanged SchemaChange = iota
+
+	// SchemaAdditive means keys were added and none were removed or
+	// retyped. Consumers that ignore unknown keys keep working, so the
+	// SchemaVersion stays.
+	SchemaAdditive
+
+	// SchemaBreaking means a key was removed or renamed, or its value
+	// changed type (e.g. a string became an object). Consumers may break,
+	// so the SchemaVersion must be bumped.
+	SchemaBreaking
+)
+
+// String returns the name of the change
+func (c SchemaChange) String() string {
+	switch c {
+	case SchemaUnchanged:
+		return "unchanged"
+	case SchemaAdditive:
+		return "additive"
+	case SchemaBreaking:
+		return "breaking"
+	default:
+		return fmt.Sprintf("SchemaChange(%d)", int(c))
+	}
+}
+
+// RequiresVersionBump reports whether the change needs a new SchemaVersion
+func (c SchemaChange) RequiresVersionBump() bool {
+	return c == SchemaBreaking
+}
+
+// Shape type names of JSON scalars
+const (
+	shapeString  = "string"
+	shapeNumber  = "number"
+	shapeBoolean = "boolean"
+	shapeNull    = "null"
+)
+
+// ShapeOf returns the shape of a decoded JSON value: objects map their keys
+// to the shapes of their values, arrays hold the merged shape of their
+// elements (or nothing when empty) and scalars are replaced by their type
+// name: string, number, boolean or null
+func ShapeOf(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		shape := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			shape[key] = ShapeOf(item)
+		}
+		return shape
+	case []interface{}:
+		var element interface{}
+		for _, item := range v {
+			element = mergeShapes(element, ShapeOf(item))
+		}
+		if element == nil {
+			return []interface{}{}
+		}
+		return []interface{}{element}
+	case string:
+		return shapeString
+	case float64, json.Number:
+		return shapeNumber
+	case bool:
+		return shapeBoolean
+	default:
+		return shapeNull
+	}
+}
+
+// mergeShapes combines the shapes of two array elements, so the element
+// shape lists every key any element has
+func mergeShapes(a, b interface{}) interface{} {
+	if a == nil || a == shapeNull {
+		return b
+	}
+	if b == nil || b == shapeNull {
+		return a
+	}
+	aObject, aOK := a.(map[string]interface{})
+	bObject, bOK := b.(map[string]interface{})
+	if aOK && bOK {
+		for key, shape := range bObject {
+			aObject[key] = mergeShapes(aObject[key], shape)
+		}
+		return aObject
+	}
+	return a
+}
+
+// CompareShapes classifies the change from the old to the new shape and
+// describes each difference by the path of the key. Null values and empty
+// arrays carry no type, so they are compatible with any shape.
+func CompareShapes(old, new interface{}) (SchemaChange, []string) {
+	var diffs []string
+	change := compareShapes("", old, new, &diffs)
+	sort.Strings(diffs)
+	return change, diffs
+}
+
+func compareShapes(path string, old, new interface{}, diffs *[]string) SchemaChange {
+	if old == shapeNull || new == shapeNull {
+		return SchemaUnchanged
+	}
+
+	switch oldShape := old.(type) {
+	case map[string]interface{}:
+		newShape, ok := new.(map[string]interface{})
+		if !ok {
+			return retyped(path, old, new, diffs)
+		}
+		change := SchemaUnchanged
+		for key, oldValue := range oldShape {
+			newValue, ok := newShape[key]
+			if !ok {
+				*diffs = append(*diffs, "removed "+joinShapePath(path, key))
+				change = SchemaBreaking
+				continue
+			}
+			change = max(change, compareShapes(joinShapePath(path, key), oldValue, newValue, diffs))
+		}
+		for key := range newShape {
+			if _, ok := oldShape[key]; !ok {
+				*diffs = append(*diffs, "added "+joinShapePath(path, key))
+				change = max(change, SchemaAdditive)
+			}
+		}
+		return change
+
+	case []interface{}:
+		newShape, ok := new.([]interface{})
+		if !ok {
+			return retyped(path, old, new, diffs)
+		}
+		if len(oldShape) == 0 || len(newShape) == 0 {
+			return SchemaUnchanged
+		}
+		return compareShapes(path+"[]", oldShape[0], newShape[0], diffs)
+
+	default:
+		if old != new {
+			return retyped(path, old, new, diffs)
+		}
+		return SchemaUnchanged
+	}
+}
+
+// retyped records a changed type, which is always breaking
+func retyped(path string, old, new interface{}, diffs *[]string) SchemaChange {
+	if path == "" {
+		path = "(root)"
+	}
+	*diffs = append(*diffs, fmt.Sprintf("changed %s from %s to %s", path, shapeKind(old), shapeKind(new)))
+	return SchemaBreaking
+}
+
+// shapeKind names the JSON type of a shape
+func shapeKind(shape interface{}) string {
+	switch shape := shape.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return shape
+	default:
+		return fmt.Sprint(shape)
+	}
+}
+
+func joinShapePath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// SchemaMethods returns the names of the ResponseHandler methods that print
+// output, each of which has a schema sample
+func SchemaMethods() []string {
+	handlerType := reflect.TypeOf((*ResponseHandler)(nil)).Elem()
+	var methods []string
+	for i := 0; i < handlerType.NumMethod(); i++ {
+		if name := handlerType.Method(i).Name; strings.HasPrefix(name, "Handle") {
+			methods = append(methods, name)
+		}
+	}
+	return methods
+}
+
+// schemaSampleTime is the time of every time value in the samples, so the
+// sample output is deterministic
+var schemaSampleTime = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+// schemaSampleAdjustments complete the generated arguments of handlers whose
+// output depends on particular values. They receive a pointer to each
+// argument.
+var schemaSampleAdjustments = map[string]func(args []interface{}){
+	"HandleError": func(args []interface{}) {
+		*args[0].(*error) = errors.NewValidationError("example", nil)
+	},
+	"HandleDeliverabilityByTag": func(args []interface{}) {
+		report := *args[0].(**DeliverabilityByTag)
+		report.SummaryOnly = false
+		report.Tags[0].Status = TagStatusAvailable
+	},
+}
+
+// SampleJSON renders the JSON output of a ResponseHandler method for sample
+// arguments generated from its parameter types: every exported field is
+// set, pointers are allocated and slices and maps hold one element, so the
+// output has every key the method can print for default settings. Options
+// (the *Config argument) keep their zero values.
+func SampleJSON(method string) ([]byte, error) {
+	var buf bytes.Buffer
+	handler := reflect.ValueOf(GetResponseHandler("json", false, &buf))
+	fn := handler.MethodByName(method)
+	if !fn.IsValid() || !strings.HasPrefix(method, "Handle") {
+		return nil, fmt.Errorf("no response handler method %q", method)
+	}
+
+	fnType := fn.Type()
+	args := make([]reflect.Value, fnType.NumIn())
+	pointers := make([]interface{}, len(args))
+	for i := range args {
+		args[i] = reflect.New(fnType.In(i)).Elem()
+		pointers[i] = args[i].Addr().Interface()
+		if !strings.HasSuffix(fnType.In(i).Name(), "Config") {
+			fillSample(args[i], 0)
+		}
+	}
+	if adjust, ok := schemaSampleAdjustments[method]; ok {
+		adjust(pointers)
+	}
+
+	results := fn.Call(args)
+	// HandleError returns the error it printed for a non-zero exit code
+	if err, _ := results[0].Interface().(error); err != nil && method != "HandleError" {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// SampleShape returns the shape of the JSON output of a ResponseHandler
+// method; for JSONL output, the shape of one line
+func SampleShape(method string) (interface{}, error) {
+	output, err := SampleJSON(method)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeShape(output)
+}
+
+// DecodeShape returns the shape of the first JSON document in data
+func DecodeShape(data []byte) (interface{}, error) {
+	var value interface{}
+	if err := json.NewDecoder(bytes.NewReader(data)).Decode(&value); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON: %w", err)
+	}
+	return ShapeOf(value), nil
+}
+
+// maxSampleDepth stops filling recursive types
+const maxSampleDepth = 8
+
+// fillSample sets v to a deterministic, non-empty sample value
+func fillSample(v reflect.Value, depth int) {
+	if depth > maxSampleDepth || !v.CanSet() {
+		return
+	}
+	switch v.Type() {
+	case reflect.TypeOf(time.Time{}):
+		v.Set(reflect.ValueOf(schemaSampleTime))
+		return
+	case reflect.TypeOf(time.Duration(0)):
+		v.Set(reflect.ValueOf(time.Second))
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		v.Set(reflect.New(v.Type().Elem()))
+		fillSample(v.Elem(), depth+1)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			fillSample(v.Field(i), depth+1)
+		}
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+		fillSample(v.Index(0), depth+1)
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			fillSample(v.Index(i), depth+1)
+		}
+	case reflect.Map:
+		key := reflect.New(v.Type().Key()).Elem()
+		value := reflect.New(v.Type().Elem()).Elem()
+		fillSample(key, depth+1)
+		fillSample(value, depth+1)
+		v.Set(reflect.MakeMap(v.Type()))
+		v.SetMapIndex(key, value)
+	case reflect.String:
+		v.SetString("example")
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(1)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(1.5)
+	}
+}
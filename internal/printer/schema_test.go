@@ -0,0 +1,184 @@
+package printer
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateGolden = flag.Bool("update", false, "update the JSON golden files in testdata/golden")
+
+// TestJSONGolden compares the JSON output of every handler method with its
+// golden file. After an intended change, regenerate the files with
+//
+//	go test ./internal/printer -run TestJSONGolden -update
+//
+// A breaking change (see SchemaChange) is refused, even with -update, until
+// SchemaVersion is bumped.
+func TestJSONGolden(t *testing.T) {
+	for _, method := range SchemaMethods() {
+		t.Run(method, func(t *testing.T) {
+			output, err := SampleJSON(method)
+			require.NoError(t, err)
+			path := filepath.Join("testdata", "golden", method+".json")
+
+			golden, err := os.ReadFile(path)
+			if os.IsNotExist(err) {
+				if *updateGolden {
+					require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+					require.NoError(t, os.WriteFile(path, output, 0o644))
+					return
+				}
+				t.Fatalf("%s is missing; run go test ./internal/printer -run TestJSONGolden -update", path)
+			}
+			require.NoError(t, err)
+			if bytes.Equal(golden, output) {
+				return
+			}
+
+			goldenShape, err := DecodeShape(golden)
+			require.NoError(t, err)
+			outputShape, err := DecodeShape(output)
+			require.NoError(t, err)
+			change, diffs := CompareShapes(goldenShape, outputShape)
+
+			if change.RequiresVersionBump() && goldenSchemaVersion(t, golden) == SchemaVersion {
+				t.Fatalf("breaking change to the JSON output of %s needs a SchemaVersion bump: %v", method, diffs)
+			}
+			if *updateGolden {
+				require.NoError(t, os.WriteFile(path, output, 0o644))
+				return
+			}
+			t.Errorf("the JSON output of %s changed (%s: %v); review it and run go test ./internal/printer -run TestJSONGolden -update\n%s",
+				method, change, diffs, output)
+		})
+	}
+}
+
+func goldenSchemaVersion(t *testing.T, golden []byte) int {
+	t.Helper()
+	var document struct {
+		SchemaVersion int `json:"schema_version"`
+	}
+	require.NoError(t, json.NewDecoder(bytes.NewReader(golden)).Decode(&document))
+	return document.SchemaVersion
+}
+
+func TestGoldenFilesMatchHandlers(t *testing.T) {
+	methods := make(map[string]bool)
+	for _, method := range SchemaMethods() {
+		methods[method+".json"] = true
+	}
+	entries, err := os.ReadDir(filepath.Join("testdata", "golden"))
+	require.NoError(t, err)
+	for _, entry := range entries {
+		assert.True(t, methods[entry.Name()], "%s does not match a handler method; remove it", entry.Name())
+	}
+}
+
+func TestCompareShapes(t *testing.T) {
+	old := map[string]interface{}{
+		"id":    "string",
+		"count": "number",
+		"tags":  []interface{}{"string"},
+		"items": []interface{}{map[string]interface{}{"email": "string"}},
+		"next":  "null",
+	}
+	tests := []struct {
+		name   string
+		new    map[string]interface{}
+		change SchemaChange
+		diffs  []string
+	}{
+		{"unchanged", map[string]interface{}{}, SchemaUnchanged, nil},
+		{"added key", map[string]interface{}{"items": []interface{}{map[string]interface{}{"email": "string", "reason": "string"}}}, SchemaAdditive, []string{"added items[].reason"}},
+		{"null becomes typed", map[string]interface{}{"next": "string"}, SchemaUnchanged, nil},
+		{"empty array", map[string]interface{}{"tags": []interface{}{}}, SchemaUnchanged, nil},
+		{"retyped scalar", map[string]interface{}{"count": "string"}, SchemaBreaking, []string{"changed count from number to string"}},
+		{"scalar becomes object", map[string]interface{}{"id": map[string]interface{}{"value": "string"}}, SchemaBreaking, []string{"changed id from string to object"}},
+		{"removed key", map[string]interface{}{"id": nil}, SchemaBreaking, []string{"removed id"}},
+		{"renamed key", map[string]interface{}{"id": nil, "uuid": "string"}, SchemaBreaking, []string{"added uuid", "removed id"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			new := make(map[string]interface{})
+			for key, value := range old {
+				new[key] = value
+			}
+			for key, value := range tt.new {
+				if value == nil {
+					delete(new, key)
+				} else {
+					new[key] = value
+				}
+			}
+
+			change, diffs := CompareShapes(old, new)
+			assert.Equal(t, tt.change, change)
+			assert.Equal(t, tt.diffs, diffs)
+			assert.Equal(t, tt.change == SchemaBreaking, change.RequiresVersionBump())
+		})
+	}
+}
+
+func TestShapeOf(t *testing.T) {
+	var value interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"a": [{"x": 1}, {"y": true}], "b": null, "c": []}`), &value))
+	assert.Equal(t, map[string]interface{}{
+		"a": []interface{}{map[string]interface{}{"x": "number", "y": "boolean"}},
+		"b": "null",
+		"c": []interface{}{},
+	}, ShapeOf(value))
+}
+
+func TestJSONHandler_SchemaVersion(t *testing.T) {
+	var buf bytes.Buffer
+	handler := GetResponseHandler("json", false, &buf)
+
+	require.NoError(t, handler.HandleSimpleSuccess("done"))
+	var document map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &document))
+	assert.Equal(t, float64(SchemaVersion), document["schema_version"])
+
+	// Every JSONL line is versioned
+	buf.Reset()
+	require.NoError(t, handler.HandleDeliverabilityStatsChunk(&responses.DeliverabilityStatisticsResponse{
+		Data: []responses.DeliverabilityStatistics{{DeliveredCount: 1}, {DeliveredCount: 2}},
+	}, StatsConfig{}))
+	decoder := json.NewDecoder(&buf)
+	for decoder.More() {
+		var line map[string]interface{}
+		require.NoError(t, decoder.Decode(&line))
+		assert.Equal(t, float64(SchemaVersion), line["schema_version"])
+	}
+}
+
+func TestJSONHandler_Warnings(t *testing.T) {
+	var buf bytes.Buffer
+	handler := GetResponseHandler("json", false, &buf)
+
+	require.NoError(t, handler.HandleSimpleSuccess("done"))
+	assert.NotContains(t, buf.String(), "warnings")
+
+	buf.Reset()
+	handler.AddWarning("Account is paused")
+	require.NoError(t, handler.HandleSimpleSuccess("done"))
+	var document map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &document))
+	assert.Equal(t, []interface{}{"Account is paused"}, document["warnings"])
+
+	// Table output leaves warnings to the caller's banner
+	buf.Reset()
+	table := GetResponseHandler("table", false, &buf)
+	table.AddWarning("Account is paused")
+	require.NoError(t, table.HandleSimpleSuccess("done"))
+	assert.NotContains(t, buf.String(), "paused")
+}
@@ -0,0 +1,117 @@
+package printer
+
+import (
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// Severity classifies a status value for display
+type Severity int
+
+const (
+	SeverityNeutral Severity = iota
+	SeveritySuccess
+	SeverityWarning
+	SeverityError
+)
+
+// statusSeverities maps every status value shown in table output to its
+// severity. Keys are lowercase; values are looked up case-insensitively.
+// A status missing here is printed uncolored, so new status values must be
+// added here (the tests check the known sets).
+var statusSeverities = map[string]Severity{
+	// Message statuses
+	"received":           SeverityWarning,
+	"queued":             SeverityWarning,
+	"delivered":          SeveritySuccess,
+	"deferred":           SeverityWarning,
+	"bounced":            SeverityError,
+	"failed":             SeverityError,
+	"suppressed":         SeverityWarning,
+	"sandbox delivered":  SeveritySuccess,
+	"sandbox deferred":   SeverityWarning,
+	"sandbox failed":     SeverityError,
+	"sandbox bounced":    SeverityError,
+	"sandbox suppressed": SeverityWarning,
+
+	// Stats columns that count messages by outcome
+	"rejected": SeverityError,
+
+	// Domain DNS status
+	"valid":   SeveritySuccess,
+	"invalid": SeverityError,
+	"pending": SeverityWarning,
+
+	// Webhook, route and SMTP credential state
+	"enabled":  SeveritySuccess,
+	"disabled": SeverityError,
+
+	// Command outcomes
+	"success": SeveritySuccess,
+
+	// Per-tag statistics that could not be fetched
+	"unavailable": SeverityError,
+
+	// SMTP credential usage
+	"active": SeveritySuccess,
+	"unused": SeverityWarning,
+	"new":    SeverityNeutral,
+
+	// DNS propagation
+	"found":      SeveritySuccess,
+	"missing":    SeverityWarning,
+	"mismatched": SeverityError,
+	"error":      SeverityError,
+
+	// DNS verification states and failure reasons
+	"verified":           SeveritySuccess,
+	"partially_verified": SeverityWarning,
+	"unverified":         SeverityError,
+	"wrong_value":        SeverityError,
+	"conflicting":        SeverityError,
+	"not_propagated":     SeverityWarning,
+	"unknown":            SeverityWarning,
+
+	// Doctor check statuses
+	"pass": SeveritySuccess,
+	"warn": SeverityWarning,
+	"fail": SeverityError,
+	"skip": SeverityNeutral,
+}
+
+// StatusSeverity returns the severity of a status value and whether the
+// status is known
+func StatusSeverity(status string) (Severity, bool) {
+	severity, ok := statusSeverities[strings.ToLower(strings.TrimSpace(status))]
+	return severity, ok
+}
+
+// colorStatus colors a status value by its severity
+func colorStatus(status string) string {
+	return colorStatusAs(status, status)
+}
+
+// colorStatusAs colors text by the severity of status, for cells whose text
+// differs from the status it represents (e.g. "Yes" for enabled)
+func colorStatusAs(text, status string) string {
+	severity, _ := StatusSeverity(status)
+	switch severity {
+	case SeveritySuccess:
+		return color.GreenString(text)
+	case SeverityWarning:
+		return color.YellowString(text)
+	case SeverityError:
+		return color.RedString(text)
+	}
+	return text
+}
+
+// statusCell colors a table cell by the severity of status when the output
+// may use colors
+func (h *tableHandler) statusCell(text, status string) string {
+	if !h.caps.Color {
+		return text
+	}
+	return colorStatusAs(text, status)
+}
@@ -0,0 +1,90 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/client"
+	"github.com/AhaSend/ahasend-cli/internal/dns"
+)
+
+// forceColor enables ANSI output even though tests do not run on a terminal
+func forceColor(t *testing.T) {
+	t.Helper()
+	prev := color.NoColor
+	color.NoColor = false
+	t.Cleanup(func() { color.NoColor = prev })
+}
+
+func TestStatusSeverity_KnownStatuses(t *testing.T) {
+	statuses := []string{
+		client.AttemptStatusDelivered, client.AttemptStatusDeferred, client.AttemptStatusFailed,
+		string(dns.StatusFound), string(dns.StatusMissing), string(dns.StatusMismatched), string(dns.StatusError),
+		string(dns.StateVerified), string(dns.StatePartiallyVerified), string(dns.StateUnverified),
+		string(dns.ReasonMissing), string(dns.ReasonWrongValue), string(dns.ReasonConflicting),
+		string(dns.ReasonNotPropagated), string(dns.ReasonUnknown),
+		formatDNSStatus(true), formatDNSStatus(false),
+		formatEnabledStatus(true), formatEnabledStatus(false),
+		smtpUsageActive, smtpUsageUnused, smtpUsageNew, smtpUsageUnavailable,
+		DoctorStatusPass, DoctorStatusWarn, DoctorStatusFail, DoctorStatusSkip,
+	}
+	for _, status := range statuses {
+		_, known := StatusSeverity(status)
+		assert.True(t, known, "status %q has no severity", status)
+	}
+}
+
+func TestStatusSeverity(t *testing.T) {
+	tests := map[string]Severity{
+		"Delivered":       SeveritySuccess,
+		" valid ":         SeveritySuccess,
+		"Queued":          SeverityWarning,
+		"Sandbox Bounced": SeverityError,
+		"disabled":        SeverityError,
+	}
+	for status, want := range tests {
+		got, known := StatusSeverity(status)
+		assert.True(t, known, status)
+		assert.Equal(t, want, got, status)
+	}
+
+	_, known := StatusSeverity("opened")
+	assert.False(t, known)
+}
+
+func TestStatusCell(t *testing.T) {
+	forceColor(t)
+
+	colored := &tableHandler{handlerBase{caps: Capabilities{Color: true}}}
+	assert.Equal(t, color.GreenString("Yes"), colored.statusCell("Yes", "enabled"))
+	assert.Equal(t, color.YellowString("Deferred"), colored.statusCell("Deferred", "Deferred"))
+	assert.Equal(t, color.RedString("Bounced"), colored.statusCell("Bounced", "Bounced"))
+	assert.Equal(t, "Opened", colored.statusCell("Opened", "Opened"))
+
+	plain := &tableHandler{handlerBase{}}
+	assert.Equal(t, "Bounced", plain.statusCell("Bounced", "Bounced"))
+}
+
+func TestDeliverabilityStatusCells(t *testing.T) {
+	forceColor(t)
+	h := &tableHandler{handlerBase{caps: Capabilities{Color: true}}}
+
+	row := h.deliverabilityStatusCells(
+		[]string{"2026-01-01", "10", "9", "0", "1"},
+		[]string{"time_bucket", "sent", "delivered", "deferred", "bounced_count"})
+	assert.Equal(t, []string{"2026-01-01", "10", color.GreenString("9"), "0", color.RedString("1")}, row)
+}
+
+func TestTableHandler_NoColorFlag(t *testing.T) {
+	forceColor(t)
+
+	var buf bytes.Buffer
+	handler := GetResponseHandler("table", false, &buf)
+	require.NoError(t, handler.HandleSMTPSend(&SMTPSendResult{Success: true}, SMTPSendConfig{SuccessMessage: "sent"}))
+	assert.NotContains(t, buf.String(), "\x1b[")
+	assert.Contains(t, buf.String(), "Sent successfully")
+}
@@ -2,9 +2,17 @@ package printer
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/AhaSend/ahasend-cli/internal/bounces"
+	"github.com/AhaSend/ahasend-cli/internal/bytesize"
+	"github.com/AhaSend/ahasend-cli/internal/dns"
+	"github.com/AhaSend/ahasend-cli/internal/state"
 	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/fatih/color"
+	"github.com/google/uuid"
 	"github.com/olekukonko/tablewriter"
 )
 
@@ -32,7 +40,7 @@ func (h *tableHandler) HandleDomainList(response *responses.PaginatedDomainsResp
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	h.printMessage("%s\n\n", config.SuccessMessage)
 
 	table := h.createTable()
 
@@ -75,9 +83,9 @@ func (h *tableHandler) HandleDomainList(response *responses.PaginatedDomainsResp
 		if len(config.FieldOrder) > 0 {
 			// Build row according to field order
 			fieldMap := map[string]string{
-				"domain":            domain.Domain,
-				"dns_valid":         formatDNSStatus(domain.DNSValid),
-				"status":            formatDNSStatus(domain.DNSValid),
+				"domain":            formatDomainName(domain.Domain),
+				"dns_valid":         h.statusCell(formatDNSStatus(domain.DNSValid), formatDNSStatus(domain.DNSValid)),
+				"status":            h.statusCell(formatDNSStatus(domain.DNSValid), formatDNSStatus(domain.DNSValid)),
 				"created_at":        formatTime(domain.CreatedAt),
 				"updated_at":        formatTime(domain.UpdatedAt),
 				"last_dns_check_at": formatTimePtr(domain.LastDNSCheckAt),
@@ -92,8 +100,8 @@ func (h *tableHandler) HandleDomainList(response *responses.PaginatedDomainsResp
 		} else {
 			// Default order
 			row = []string{
-				domain.Domain,
-				formatDNSStatus(domain.DNSValid),
+				formatDomainName(domain.Domain),
+				h.statusCell(formatDNSStatus(domain.DNSValid), formatDNSStatus(domain.DNSValid)),
 				formatTime(domain.CreatedAt),
 				formatTime(domain.UpdatedAt),
 				formatTimePtr(domain.LastDNSCheckAt),
@@ -123,14 +131,14 @@ func (h *tableHandler) HandleSingleDomain(domain *responses.Domain, config Singl
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	h.printMessage("%s\n\n", config.SuccessMessage)
 
 	table := h.createBorderedTable()
 	table.Header("Field", "Value")
 
 	// Create field map for ordering
 	fieldMap := map[string]string{
-		"domain":            domain.Domain,
+		"domain":            formatDomainName(domain.Domain),
 		"id":                formatUUID(domain.ID),
 		"account_id":        formatUUID(domain.AccountID),
 		"dns_valid":         formatDNSStatus(domain.DNSValid),
@@ -170,7 +178,7 @@ func (h *tableHandler) HandleSingleDomain(domain *responses.Domain, config Singl
 	} else {
 		// Default order
 		rows = [][]string{
-			{"Domain", domain.Domain},
+			{"Domain", formatDomainName(domain.Domain)},
 			{"ID", formatUUID(domain.ID)},
 			{"Account ID", formatUUID(domain.AccountID)},
 			{"DNS Status", formatDNSStatus(domain.DNSValid)},
@@ -219,6 +227,138 @@ func (h *tableHandler) HandleSingleDomain(domain *responses.Domain, config Singl
 	return nil
 }
 
+func (h *tableHandler) HandleDNSPropagation(matrix *dns.PropagationMatrix, config SingleConfig) error {
+	if matrix == nil || len(matrix.Records) == 0 {
+		fmt.Fprintf(h.writer, "%s\n", config.EmptyMessage)
+		return nil
+	}
+
+	if config.SuccessMessage != "" {
+		h.printMessage("%s\n\n", config.SuccessMessage)
+	}
+
+	table := h.createTable()
+	headerArgs := []any{"Type", "Host", "Required"}
+	for _, resolver := range matrix.Resolvers {
+		headerArgs = append(headerArgs, resolver)
+	}
+	table.Header(headerArgs...)
+	for _, record := range matrix.Records {
+		row := []string{record.Type, record.Host, formatBooleanStatus(record.Required)}
+		for _, status := range record.Statuses {
+			row = append(row, h.statusCell(string(status), string(status)))
+		}
+		addTableRow(table, row)
+	}
+	renderTable(table)
+
+	fmt.Fprintf(h.writer, "\n%s\n", formatPropagationSummary(matrix))
+	return nil
+}
+
+func (h *tableHandler) HandleDNSVerification(domain *responses.Domain, verification *dns.Verification, config SingleConfig) error {
+	if domain == nil || verification == nil {
+		fmt.Fprintf(h.writer, "%s\n", config.EmptyMessage)
+		return nil
+	}
+
+	if config.SuccessMessage != "" {
+		h.printMessage("%s\n\n", config.SuccessMessage)
+	}
+
+	summary := h.createBorderedTable()
+	summary.Header("Field", "Value")
+	addTableRow(summary, []string{"Domain", formatDomainName(verification.Domain)})
+	addTableRow(summary, []string{"State", h.statusCell(formatVerificationState(verification.State), string(verification.State))})
+	addTableRow(summary, []string{"Required Records", fmt.Sprintf("%d/%d passed", verification.RequiredPassed, verification.RequiredTotal)})
+	lastCheck := "Never"
+	if domain.LastDNSCheckAt != nil {
+		lastCheck = formatTimePtr(domain.LastDNSCheckAt)
+	}
+	addTableRow(summary, []string{"Last DNS Check", lastCheck})
+	renderTable(summary)
+
+	if len(verification.Failures) > 0 {
+		fmt.Fprintf(h.writer, "\nFailed Records:\n")
+		table := h.createTable()
+		table.Header("Type", "Host", "Required", "Reason", "Expected", "Observed Locally")
+		for _, failure := range verification.Failures {
+			addTableRow(table, []string{
+				failure.Type,
+				failure.Host,
+				formatBooleanStatus(failure.Required),
+				h.statusCell(formatFailureReason(failure.Reason), string(failure.Reason)),
+				failure.Expected,
+				formatObservedValues(failure.Observed),
+			})
+		}
+		renderTable(table)
+
+		fmt.Fprintln(h.writer)
+		for _, failure := range verification.Failures {
+			fmt.Fprintf(h.writer, "• %s %s: %s\n", failure.Type, failure.Host, failure.Detail)
+		}
+	}
+
+	if config.ShowRecords && len(domain.DNSRecords) > 0 {
+		fmt.Fprintf(h.writer, "\nDNS Records:\n")
+		table := h.createTable()
+		table.Header("Type", "Host", "Content", "Required", "Propagated")
+		for _, record := range domain.DNSRecords {
+			addTableRow(table, []string{
+				record.Type,
+				record.Host,
+				record.Content,
+				formatBooleanStatus(record.Required),
+				formatBooleanStatus(record.Propagated),
+			})
+		}
+		renderTable(table)
+	}
+
+	fmt.Fprintf(h.writer, "\n%s\n", formatVerificationSummary(verification))
+	if verification.State != dns.StateVerified {
+		fmt.Fprintf(h.writer, "\n💡 Troubleshooting tips:\n")
+		for _, tip := range verificationTips(verification.Domain) {
+			fmt.Fprintf(h.writer, "• %s\n", tip)
+		}
+	}
+
+	return nil
+}
+
+func (h *tableHandler) HandleDomainVerification(verification *dns.LocalVerification, config SingleConfig) error {
+	if verification == nil {
+		fmt.Fprintf(h.writer, "%s\n", config.EmptyMessage)
+		return nil
+	}
+
+	if config.SuccessMessage != "" {
+		h.printMessage("%s\n\n", config.SuccessMessage)
+	}
+
+	table := h.createTable()
+	table.Header("Type", "Host", "Required", "Status", "Expected", "Observed")
+	for _, record := range verification.Records {
+		observed := formatObservedValues(record.Observed)
+		if record.Status == dns.StatusError {
+			observed = record.Error
+		}
+		addTableRow(table, []string{
+			record.Type,
+			record.Host,
+			formatBooleanStatus(record.Required),
+			h.statusCell(string(record.Status), string(record.Status)),
+			record.Expected,
+			observed,
+		})
+	}
+	renderTable(table)
+
+	fmt.Fprintf(h.writer, "\n%s on resolver %s\n", formatLocalVerificationSummary(verification), verification.Resolver)
+	return nil
+}
+
 // Message responses
 func (h *tableHandler) HandleMessageList(response *responses.PaginatedMessagesResponse, config ListConfig) error {
 	if len(response.Data) == 0 {
@@ -226,7 +366,9 @@ func (h *tableHandler) HandleMessageList(response *responses.PaginatedMessagesRe
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	if !config.Continuation {
+		h.printMessage("%s\n\n", config.SuccessMessage)
+	}
 
 	table := h.createTable()
 
@@ -268,6 +410,8 @@ func (h *tableHandler) HandleMessageList(response *responses.PaginatedMessagesRe
 				headers = append(headers, "Bounce Class")
 			case "retain_until":
 				headers = append(headers, "Retain Until")
+			case "received":
+				headers = append(headers, "Received")
 			}
 		}
 	}
@@ -293,7 +437,7 @@ func (h *tableHandler) HandleMessageList(response *responses.PaginatedMessagesRe
 			case "subject":
 				row = append(row, message.Subject)
 			case "status":
-				row = append(row, message.Status)
+				row = append(row, h.statusCell(message.Status, message.Status))
 			case "created":
 				row = append(row, formatTime(message.CreatedAt))
 			case "delivered":
@@ -316,6 +460,8 @@ func (h *tableHandler) HandleMessageList(response *responses.PaginatedMessagesRe
 				row = append(row, formatOptionalString(message.BounceClassification))
 			case "retain_until":
 				row = append(row, formatTime(message.RetainUntil))
+			case "received":
+				row = append(row, formatTime(message.CreatedAt))
 			}
 		}
 
@@ -326,7 +472,7 @@ func (h *tableHandler) HandleMessageList(response *responses.PaginatedMessagesRe
 				message.Sender,
 				message.Recipient,
 				message.Subject,
-				message.Status,
+				h.statusCell(message.Status, message.Status),
 				formatTime(message.CreatedAt),
 				formatTimePtr(message.DeliveredAt),
 				formatInt(int(message.OpenCount)),
@@ -357,7 +503,7 @@ func (h *tableHandler) HandleSingleMessage(message *responses.Message, config Si
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	h.printMessage("%s\n\n", config.SuccessMessage)
 
 	table := h.createBorderedTable()
 	headerArgs := []any{"Field", "Value"}
@@ -371,6 +517,9 @@ func (h *tableHandler) HandleSingleMessage(message *responses.Message, config Si
 	addTableRow(table, []string{"Subject", message.Subject})
 	addTableRow(table, []string{"Status", message.Status})
 	addTableRow(table, []string{"Direction", message.Direction})
+	if message.Direction == "inbound" {
+		addTableRow(table, []string{"Attachments", formatAttachmentCount(*message)})
+	}
 	addTableRow(table, []string{"Created", formatTime(message.CreatedAt)})
 	addTableRow(table, []string{"Updated", formatTime(message.UpdatedAt)})
 
@@ -405,10 +554,7 @@ func (h *tableHandler) HandleSingleMessage(message *responses.Message, config Si
 	if message.Content != nil {
 		content := *message.Content
 		if content != "" {
-			contentPreview := content
-			if len(contentPreview) > 100 {
-				contentPreview = contentPreview[:97] + "..."
-			}
+			contentPreview := truncateText(content, 100)
 			// Replace newlines with spaces for table display
 			contentPreview = strings.ReplaceAll(contentPreview, "\n", " ")
 			contentPreview = strings.ReplaceAll(contentPreview, "\r", "")
@@ -419,6 +565,10 @@ func (h *tableHandler) HandleSingleMessage(message *responses.Message, config Si
 
 	renderTable(table)
 
+	if explanation := formatBounceExplanation(message.BounceClassification); explanation != "" {
+		fmt.Fprintf(h.writer, "\nBounce explanation: %s\n", explanation)
+	}
+
 	return nil
 }
 
@@ -428,10 +578,31 @@ func (h *tableHandler) HandleCreateMessage(response *responses.CreateMessageResp
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	summary := SummarizeCreateMessage(response)
+	fmt.Fprintf(h.writer, "%s\n\n", formatCreateMessageHeadline(summary, config))
 
 	// Show summary
-	fmt.Fprintf(h.writer, "Successfully sent %d messages\n\n", len(response.Data))
+	if summary.Failed == 0 {
+		fmt.Fprintf(h.writer, "Successfully sent %d messages\n\n", summary.Succeeded)
+	} else {
+		fmt.Fprintf(h.writer, "Sent %d of %d messages\n\n", summary.Succeeded, summary.Total())
+	}
+	if len(config.Metadata) > 0 {
+		fmt.Fprintf(h.writer, "Metadata: %s\n\n", FormatMetadata(config.Metadata))
+	}
+
+	// List failed recipients before the full table so they are not missed
+	if summary.Failed > 0 {
+		fmt.Fprintf(h.writer, "Failed recipients (%d):\n", summary.Failed)
+		for i, messageData := range summary.Rejected {
+			if i == maxFailedRecipientsShown {
+				fmt.Fprintf(h.writer, "  ... and %d more; see --output json for full details\n", summary.Failed-maxFailedRecipientsShown)
+				break
+			}
+			fmt.Fprintf(h.writer, "  %s\n", formatRejectedRecipient(messageData))
+		}
+		fmt.Fprintln(h.writer)
+	}
 
 	// Create table for message details
 	table := h.createTable()
@@ -465,7 +636,7 @@ func (h *tableHandler) HandleCancelMessage(response *CancelMessageResponse, conf
 	}
 
 	if response.Success {
-		fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+		h.printMessage("%s\n\n", config.SuccessMessage)
 	} else {
 		fmt.Fprintf(h.writer, "Message cancellation failed\n\n")
 	}
@@ -486,6 +657,150 @@ func (h *tableHandler) HandleCancelMessage(response *CancelMessageResponse, conf
 	return nil
 }
 
+func (h *tableHandler) HandleMessageExport(summary *MessageExportSummary, config SimpleConfig) error {
+	if summary == nil {
+		return nil
+	}
+
+	if len(summary.Skips) > 0 {
+		table := h.createTable()
+		table.Header("Skipped Message", "Reason")
+		for _, skip := range summary.Skips {
+			addTableRow(table, []string{skip.ID, skip.Reason})
+		}
+		renderTable(table)
+		fmt.Fprintln(h.writer)
+	}
+
+	line := formatMessageExportSummary(summary)
+	if summary.Exported > 0 && h.caps.Color {
+		line = color.GreenString(line)
+	}
+	fmt.Fprintf(h.writer, "%s\n", line)
+	return nil
+}
+
+func (h *tableHandler) HandleDryRunMessage(dryRun *DryRunMessage, config SimpleConfig) error {
+	if dryRun == nil {
+		fmt.Fprintf(h.writer, "No message to send\n")
+		return nil
+	}
+
+	h.printMessage("%s\n\n", config.SuccessMessage)
+
+	table := h.createBorderedTable()
+	table.Header("Field", "Value")
+	for _, field := range dryRunFields(dryRun) {
+		addTableRow(table, field[:])
+	}
+	renderTable(table)
+
+	fmt.Fprintln(h.writer)
+	// Batches of per-recipient schedules and subjects differ in them
+	bucketed := len(dryRun.ScheduleBuckets) > 0
+	header := []any{"Batch", "Recipients"}
+	if bucketed {
+		header = append(header, "Schedule")
+	}
+	if dryRun.SubjectField != "" {
+		header = append(header, "Subject")
+	}
+	batches := h.createTable()
+	batches.Header(append(header, "Idempotency Key")...)
+	for i, batch := range dryRun.Batches {
+		row := []string{fmt.Sprintf("%d", i+1), fmt.Sprintf("%d", batch.Recipients)}
+		if bucketed {
+			row = append(row, formatDryRunBatchSchedule(batch))
+		}
+		if dryRun.SubjectField != "" {
+			row = append(row, formatDryRunBatchSubject(batch))
+		}
+		addTableRow(batches, append(row, batch.IdempotencyKey))
+	}
+	renderTable(batches)
+
+	return nil
+}
+
+func (h *tableHandler) HandleMessageAttempts(report *MessageAttemptsReport, config SingleConfig) error {
+	if report == nil || len(report.Attempts) == 0 {
+		fmt.Fprintf(h.writer, "%s\n", config.EmptyMessage)
+		return nil
+	}
+
+	if config.SuccessMessage != "" {
+		h.printMessage("%s\n\n", config.SuccessMessage)
+	}
+	fmt.Fprintf(h.writer, "Recipient: %s\n", report.Recipient)
+	fmt.Fprintf(h.writer, "Status:    %s\n", report.Status)
+	fmt.Fprintf(h.writer, "Created:   %s\n\n", formatTime(report.CreatedAt))
+
+	table := h.createTable()
+	table.Header("#", "Time", "Remote Host", "Status", "Code", "Interval", "Response")
+	for _, attempt := range report.Attempts {
+		status := h.statusCell(attempt.Status, attempt.Status)
+		addTableRow(table, []string{
+			formatInt(attempt.Attempt),
+			formatTime(attempt.Time),
+			attempt.RemoteHost,
+			status,
+			formatSMTPCode(attempt.SMTPCode),
+			"+" + formatElapsed(attempt.SincePrevious),
+			truncateText(attempt.SMTPResponse, attemptResponseWidth),
+		})
+	}
+	renderTable(table)
+
+	fmt.Fprintf(h.writer, "\n%s\n", formatAttemptsTotal(report))
+	return nil
+}
+
+// HandleMessageContent summarizes the parts and attachments; bodies can be
+// megabytes, so they are left to plain and JSON output
+func (h *tableHandler) HandleMessageContent(content *MessageContent, config SingleConfig) error {
+	if content == nil {
+		fmt.Fprintf(h.writer, "%s\n", config.EmptyMessage)
+		return nil
+	}
+
+	if config.SuccessMessage != "" {
+		h.printMessage("%s\n\n", config.SuccessMessage)
+	}
+
+	summary := h.createBorderedTable()
+	summary.Header("Field", "Value")
+	addTableRow(summary, []string{"Message ID", content.MessageID})
+	addTableRow(summary, []string{"Subject", content.Subject})
+	addTableRow(summary, []string{"Headers", formatInt(len(content.Headers))})
+	raw := "Not stored"
+	if content.RawSize > 0 {
+		raw = bytesize.Format(content.RawSize)
+	}
+	addTableRow(summary, []string{"Raw Size", raw})
+	renderTable(summary)
+
+	fmt.Fprintf(h.writer, "\nParts:\n")
+	parts := h.createTable()
+	parts.Header("Part", "Content Type", "Size")
+	for _, part := range content.Parts {
+		addTableRow(parts, []string{part.Part, part.ContentType, bytesize.Format(part.Size)})
+	}
+	renderTable(parts)
+
+	if len(content.Attachments) > 0 {
+		fmt.Fprintf(h.writer, "\nAttachments:\n")
+		attachments := h.createTable()
+		attachments.Header("File Name", "Content Type", "Content-ID", "Size")
+		for _, attachment := range content.Attachments {
+			addTableRow(attachments, []string{attachment.FileName, attachment.ContentType, attachment.ContentID, bytesize.Format(attachment.Size)})
+		}
+		renderTable(attachments)
+	}
+
+	h.printMessage("\n💡 Use --output plain to print the %s part, or --save FILE to write the raw message\n", content.Part)
+	return nil
+}
+
 // Webhook responses
 func (h *tableHandler) HandleWebhookList(response *responses.PaginatedWebhooksResponse, config ListConfig) error {
 	if len(response.Data) == 0 {
@@ -493,27 +808,39 @@ func (h *tableHandler) HandleWebhookList(response *responses.PaginatedWebhooksRe
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	h.printMessage("%s\n\n", config.SuccessMessage)
+
+	if config.IncludeStats {
+		if len(config.FieldOrder) == 0 {
+			config.FieldOrder = []string{"name", "url", "enabled", "events", "created_at", "updated_at"}
+		}
+		config.FieldOrder = withWebhookStatsFields(config.FieldOrder)
+	}
 
 	table := h.createTable()
 
 	// Define headers - respect FieldOrder if provided
+	headerMap := map[string]string{
+		"name":       "Name",
+		"url":        "URL",
+		"enabled":    "Enabled",
+		"events":     "Events",
+		"created_at": "Created",
+		"updated_at": "Updated",
+		"id":         "ID",
+		"secret":     "Secret",
+		"domains":    "Domains",
+		"scope":      "Scope",
+		// Stats columns (--include-stats)
+		"success":      "Success",
+		"errors":       "Errors",
+		"error_streak": "Error Streak",
+		"last_request": "Last Request",
+		"error_rate":   "Error Rate",
+	}
 	headers := []string{"Name", "URL", "Enabled", "Events", "Created", "Updated"}
 	if len(config.FieldOrder) > 0 {
 		// Use custom field order if specified
-		headerMap := map[string]string{
-			"name":       "Name",
-			"url":        "URL",
-			"enabled":    "Enabled",
-			"events":     "Events",
-			"created_at": "Created",
-			"updated_at": "Updated",
-			"id":         "ID",
-			"secret":     "Secret",
-			"domains":    "Domains",
-			"scope":      "Scope",
-		}
-
 		var orderedHeaders []string
 		for _, field := range config.FieldOrder {
 			if header, exists := headerMap[field]; exists {
@@ -541,7 +868,7 @@ func (h *tableHandler) HandleWebhookList(response *responses.PaginatedWebhooksRe
 			fieldMap := map[string]string{
 				"name":       webhook.Name,
 				"url":        webhook.URL,
-				"enabled":    formatBooleanStatus(webhook.Enabled),
+				"enabled":    h.statusCell(formatBooleanStatus(webhook.Enabled), formatEnabledStatus(webhook.Enabled)),
 				"events":     formatWebhookEvents(&webhook),
 				"created_at": formatTime(webhook.CreatedAt),
 				"updated_at": formatTime(webhook.UpdatedAt),
@@ -550,6 +877,11 @@ func (h *tableHandler) HandleWebhookList(response *responses.PaginatedWebhooksRe
 				"domains":    formatStringSlice(webhook.Domains),
 				"scope":      webhook.Scope,
 			}
+			if config.IncludeStats {
+				for key, value := range webhookStatsFieldMap(webhook) {
+					fieldMap[key] = value
+				}
+			}
 
 			for _, field := range config.FieldOrder {
 				if value, exists := fieldMap[field]; exists {
@@ -561,7 +893,7 @@ func (h *tableHandler) HandleWebhookList(response *responses.PaginatedWebhooksRe
 			row = []string{
 				webhook.Name,
 				webhook.URL,
-				formatBooleanStatus(webhook.Enabled),
+				h.statusCell(formatBooleanStatus(webhook.Enabled), formatEnabledStatus(webhook.Enabled)),
 				formatWebhookEvents(&webhook),
 				formatTime(webhook.CreatedAt),
 				formatTime(webhook.UpdatedAt),
@@ -571,6 +903,18 @@ func (h *tableHandler) HandleWebhookList(response *responses.PaginatedWebhooksRe
 		addTableRow(table, row)
 	}
 
+	// Aggregate totals row
+	if config.IncludeStats {
+		totals := webhookSummaryFieldMap(summarizeWebhookStats(response.Data))
+		var row []string
+		for _, field := range config.FieldOrder {
+			if _, exists := headerMap[field]; exists {
+				row = append(row, totals[field])
+			}
+		}
+		addTableRow(table, row)
+	}
+
 	renderTable(table)
 
 	// Show pagination info if enabled
@@ -591,7 +935,7 @@ func (h *tableHandler) HandleSingleWebhook(webhook *responses.Webhook, config Si
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	h.printMessage("%s\n\n", config.SuccessMessage)
 
 	table := h.createBorderedTable()
 	table.Header("Field", "Value")
@@ -691,7 +1035,7 @@ func (h *tableHandler) HandleCreateWebhook(webhook *responses.Webhook, config Cr
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	h.printMessage("%s\n\n", config.SuccessMessage)
 
 	// Show main webhook details
 	table := h.createBorderedTable()
@@ -716,8 +1060,8 @@ func (h *tableHandler) HandleCreateWebhook(webhook *responses.Webhook, config Cr
 	renderTable(table)
 
 	// Show security note
-	fmt.Fprintf(h.writer, "\n🔐 Security Note: Save the webhook secret above - it won't be shown again.\n")
-	fmt.Fprintf(h.writer, "Use this secret to verify webhook signatures for security.\n")
+	h.printMessage("\n🔐 Security Note: Save the webhook secret above - it won't be shown again.\n")
+	h.printMessage("Use this secret to verify webhook signatures for security.\n")
 
 	return nil
 }
@@ -728,7 +1072,7 @@ func (h *tableHandler) HandleUpdateWebhook(webhook *responses.Webhook, config Up
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	h.printMessage("%s\n\n", config.SuccessMessage)
 
 	// Show updated webhook details
 	table := h.createBorderedTable()
@@ -773,9 +1117,56 @@ func (h *tableHandler) HandleUpdateWebhook(webhook *responses.Webhook, config Up
 	return nil
 }
 
+func (h *tableHandler) HandleRotateWebhookSecret(webhook *responses.Webhook, config UpdateConfig) error {
+	if webhook == nil {
+		fmt.Fprintf(h.writer, "No webhook data received\n")
+		return nil
+	}
+
+	h.printMessage("%s\n\n", config.SuccessMessage)
+
+	table := h.createBorderedTable()
+	table.Header("Field", "Value")
+
+	addTableRow(table, []string{"Name", webhook.Name})
+	addTableRow(table, []string{"ID", formatUUID(webhook.ID)})
+	addTableRow(table, []string{"URL", webhook.URL})
+	addTableRow(table, []string{"Secret", formatWebhookSecretCreation(webhook.Secret)})
+
+	renderTable(table)
+
+	// Show security note
+	h.printMessage("\n🔐 Security Note: Save the new webhook secret above - it won't be shown again.\n")
+	h.printMessage("Update your webhook consumers with it; signatures made with the old secret no longer verify.\n")
+
+	return nil
+}
+
+func (h *tableHandler) HandleWebhookEdit(edit *WebhookEdit, config UpdateConfig) error {
+	if edit == nil {
+		fmt.Fprintf(h.writer, "No webhook data received\n")
+		return nil
+	}
+
+	h.printMessage("%s\n\n", config.SuccessMessage)
+	if len(edit.Changes) == 0 {
+		fmt.Fprintf(h.writer, "No changes to webhook %s\n", edit.Name)
+		return nil
+	}
+
+	table := h.createBorderedTable()
+	table.Header("Field", "Before", "After")
+	for _, change := range edit.Changes {
+		addTableRow(table, []string{change.Path, formatOverrideValue(change.Before), formatOverrideValue(change.After)})
+	}
+	renderTable(table)
+
+	return nil
+}
+
 func (h *tableHandler) HandleDeleteWebhook(success bool, config DeleteConfig) error {
 	if success {
-		fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+		h.printMessage("%s\n\n", config.SuccessMessage)
 	} else {
 		fmt.Fprintf(h.writer, "Webhook deletion failed\n\n")
 	}
@@ -798,7 +1189,7 @@ func (h *tableHandler) HandleDeleteWebhook(success bool, config DeleteConfig) er
 }
 
 func (h *tableHandler) HandleTriggerWebhook(webhookID string, events []string, config TriggerConfig) error {
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	h.printMessage("%s\n\n", config.SuccessMessage)
 
 	// Show trigger summary
 	table := h.createBorderedTable()
@@ -814,6 +1205,136 @@ func (h *tableHandler) HandleTriggerWebhook(webhookID string, events []string, c
 	return nil
 }
 
+func (h *tableHandler) HandleTriggerWebhookOverrides(trigger *WebhookTrigger, config TriggerConfig) error {
+	if trigger == nil {
+		fmt.Fprintf(h.writer, "No events triggered\n")
+		return nil
+	}
+
+	h.printMessage("%s\n\n", config.SuccessMessage)
+	writeTriggerOverrides(h.writer, trigger)
+
+	table := h.createTable()
+	table.Header("Event", "Msg ID", "Status", "Failure", "HTTP", "Duration", "Error")
+	for _, event := range trigger.Events {
+		addTableRow(table, []string{
+			event.Type,
+			event.MsgID,
+			h.statusCell(event.Status, event.Status),
+			string(event.Failure),
+			formatSimulationStatusCode(event.StatusCode),
+			formatSimulationDuration(event.DurationMs),
+			event.Error,
+		})
+	}
+	renderTable(table)
+
+	fmt.Fprintf(h.writer, "\n%s\n", formatTriggerSummary(trigger))
+	return nil
+}
+
+func (h *tableHandler) HandleWebhookCoverage(report *WebhookCoverageReport, config SingleConfig) error {
+	if report == nil {
+		fmt.Fprintf(h.writer, "%s\n", config.EmptyMessage)
+		return nil
+	}
+
+	if config.SuccessMessage != "" {
+		h.printMessage("%s\n\n", config.SuccessMessage)
+	}
+	fmt.Fprintf(h.writer, "Activity since: %s\n", formatTime(report.Since))
+	if len(report.Webhooks) == 0 {
+		fmt.Fprintf(h.writer, "No enabled webhooks\n")
+	}
+	fmt.Fprintf(h.writer, "\n")
+
+	headerArgs := []any{"Event"}
+	for _, webhook := range report.Webhooks {
+		headerArgs = append(headerArgs, webhook.Name)
+	}
+	headerArgs = append(headerArgs, "Activity")
+
+	table := h.createTable()
+	table.Header(headerArgs...)
+	for _, event := range report.Events {
+		row := []string{event.Event}
+		for _, webhook := range report.Webhooks {
+			if slices.Contains(event.Subscribers, webhook.ID) {
+				row = append(row, "✓")
+			} else {
+				row = append(row, "")
+			}
+		}
+		row = append(row, formatCoverageActivity(event))
+		addTableRow(table, row)
+	}
+	renderTable(table)
+
+	fmt.Fprintf(h.writer, "\n")
+	if len(report.Findings) == 0 {
+		fmt.Fprintf(h.writer, "Findings: none, every event with activity is covered\n")
+		return nil
+	}
+	fmt.Fprintf(h.writer, "Findings:\n")
+	for _, finding := range report.Findings {
+		severity := formatCoverageSeverity(finding.Severity)
+		if h.caps.Color && finding.Severity == CoverageSeverityGap {
+			severity = color.RedString(severity)
+		}
+		fmt.Fprintf(h.writer, "  %s %s\n", severity, finding.Message)
+	}
+	return nil
+}
+
+func (h *tableHandler) HandleWebhookSimulation(simulation *WebhookSimulation, config SingleConfig) error {
+	if simulation == nil {
+		fmt.Fprintf(h.writer, "%s\n", config.EmptyMessage)
+		return nil
+	}
+
+	if !simulation.Sent {
+		writeSimulatedRequests(h.writer, simulation)
+		fmt.Fprintf(h.writer, "%s\n", formatSimulationSummary(simulation))
+		return nil
+	}
+
+	table := h.createTable()
+	table.Header("#", "Msg ID", "Status", "Failure", "HTTP", "Duration", "Error")
+	for i, event := range simulation.Events {
+		addTableRow(table, []string{
+			formatInt(i + 1),
+			event.MsgID,
+			h.statusCell(event.Status, event.Status),
+			string(event.Failure),
+			formatSimulationStatusCode(event.StatusCode),
+			formatSimulationDuration(event.DurationMs),
+			event.Error,
+		})
+	}
+	renderTable(table)
+
+	fmt.Fprintf(h.writer, "\n%s\n", formatSimulationSummary(simulation))
+	writeSimulationFailureHints(h.writer, simulation)
+	return nil
+}
+
+func (h *tableHandler) HandleWebhookVerification(verification *WebhookVerification, config SingleConfig) error {
+	if verification == nil {
+		fmt.Fprintf(h.writer, "%s\n", config.EmptyMessage)
+		return nil
+	}
+
+	fmt.Fprintf(h.writer, "%s\n\n", formatWebhookVerificationSummary(verification))
+	table := h.createBorderedTable()
+	table.Header("Field", "Value")
+	for _, field := range webhookVerificationFields(verification) {
+		addTableRow(table, []string{field.Label, field.Value})
+	}
+	renderTable(table)
+	writeWebhookVerificationProblems(h.writer, verification)
+	return nil
+}
+
 // Route responses
 func (h *tableHandler) HandleRouteList(response *responses.PaginatedRoutesResponse, config ListConfig) error {
 	if response == nil || len(response.Data) == 0 {
@@ -821,7 +1342,7 @@ func (h *tableHandler) HandleRouteList(response *responses.PaginatedRoutesRespon
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	h.printMessage("%s\n\n", config.SuccessMessage)
 
 	table := h.createTable()
 	table.Header("ID", "Name", "URL", "Enabled", "Recipient", "Attachments", "Headers", "Group Messages", "Strip Replies", "Created", "Updated")
@@ -829,9 +1350,7 @@ func (h *tableHandler) HandleRouteList(response *responses.PaginatedRoutesRespon
 	for _, route := range response.Data {
 		// Truncate URL for better table display
 		url := route.URL
-		if len(url) > 50 {
-			url = url[:47] + "..."
-		}
+		url = truncateText(url, 50)
 
 		row := []string{
 			formatUUID(route.ID)[:8] + "...", // Show short ID
@@ -860,6 +1379,25 @@ func (h *tableHandler) HandleRouteList(response *responses.PaginatedRoutesRespon
 		fmt.Fprintf(h.writer, "\n")
 	}
 
+	if config.ExpandedPatterns != nil {
+		names := make(map[uuid.UUID]string)
+		for _, route := range response.Data {
+			names[route.ID] = route.Name
+		}
+
+		fmt.Fprintf(h.writer, "\nExpanded Patterns:\n")
+		patternsTable := h.createTable()
+		patternsTable.Header("Route", "Pattern", "Domain", "Status")
+		for _, pattern := range config.ExpandedPatterns {
+			addTableRow(patternsTable, []string{names[pattern.RouteID], pattern.Pattern, pattern.Domain, pattern.Status})
+		}
+		renderTable(patternsTable)
+
+		if warning := orphanedPatternsWarning(config.ExpandedPatterns); warning != "" {
+			fmt.Fprintf(h.writer, "\n%s\n", warning)
+		}
+	}
+
 	return nil
 }
 
@@ -869,7 +1407,7 @@ func (h *tableHandler) HandleSingleRoute(route *responses.Route, config SingleCo
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	h.printMessage("%s\n\n", config.SuccessMessage)
 
 	table := h.createBorderedTable()
 	table.Header("Field", "Value")
@@ -922,6 +1460,20 @@ func (h *tableHandler) HandleSingleRoute(route *responses.Route, config SingleCo
 
 	renderTable(statsTable)
 
+	if config.ExpandedPatterns != nil {
+		fmt.Fprintf(h.writer, "\nExpanded Patterns:\n")
+		patternsTable := h.createTable()
+		patternsTable.Header("Pattern", "Domain", "Status")
+		for _, pattern := range config.ExpandedPatterns {
+			addTableRow(patternsTable, []string{pattern.Pattern, pattern.Domain, pattern.Status})
+		}
+		renderTable(patternsTable)
+
+		if warning := orphanedPatternsWarning(config.ExpandedPatterns); warning != "" {
+			fmt.Fprintf(h.writer, "\n%s\n", warning)
+		}
+	}
+
 	return nil
 }
 
@@ -931,7 +1483,7 @@ func (h *tableHandler) HandleCreateRoute(route *responses.Route, config CreateCo
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	h.printMessage("%s\n\n", config.SuccessMessage)
 
 	table := h.createBorderedTable()
 	table.Header("Field", "Value")
@@ -955,9 +1507,9 @@ func (h *tableHandler) HandleCreateRoute(route *responses.Route, config CreateCo
 
 	renderTable(table)
 
-	fmt.Fprintf(h.writer, "\n📋 Your route has been created and is ready to receive inbound emails.\n")
+	h.printMessage("\n📋 Your route has been created and is ready to receive inbound emails.\n")
 	if !route.Enabled {
-		fmt.Fprintf(h.writer, "⚠️  Note: The route is currently disabled. Enable it to start processing emails.\n")
+		h.printMessage("⚠️  Note: The route is currently disabled. Enable it to start processing emails.\n")
 	}
 
 	return nil
@@ -969,7 +1521,7 @@ func (h *tableHandler) HandleUpdateRoute(route *responses.Route, config UpdateCo
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	h.printMessage("%s\n\n", config.SuccessMessage)
 
 	table := h.createBorderedTable()
 	table.Header("Field", "Value")
@@ -1004,7 +1556,7 @@ func (h *tableHandler) HandleDeleteRoute(success bool, config DeleteConfig) erro
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
+	h.printMessage("%s\n", config.SuccessMessage)
 
 	table := h.createBorderedTable()
 	table.Header("Status", "Action")
@@ -1013,13 +1565,13 @@ func (h *tableHandler) HandleDeleteRoute(success bool, config DeleteConfig) erro
 
 	renderTable(table)
 
-	fmt.Fprintf(h.writer, "\n⚠️  This action cannot be undone. Inbound emails will no longer be processed by this route.\n")
+	h.printMessage("\n⚠️  This action cannot be undone. Inbound emails will no longer be processed by this route.\n")
 
 	return nil
 }
 
 func (h *tableHandler) HandleTriggerRoute(routeID string, config TriggerConfig) error {
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	h.printMessage("%s\n\n", config.SuccessMessage)
 
 	// Show trigger summary
 	table := h.createBorderedTable()
@@ -1035,6 +1587,26 @@ func (h *tableHandler) HandleTriggerRoute(routeID string, config TriggerConfig)
 	return nil
 }
 
+func (h *tableHandler) HandleRouteExplanation(explanation *RouteExplanation, config SingleConfig) error {
+	if explanation == nil {
+		fmt.Fprintf(h.writer, "%s\n", config.EmptyMessage)
+		return nil
+	}
+
+	writeRouteExplanationHeader(h.writer, explanation)
+	fmt.Fprintf(h.writer, "\n")
+
+	table := h.createTable()
+	table.Header("Option", "Setting", "Effect")
+	for _, option := range explanation.Options {
+		addTableRow(table, []string{option.Name, formatBooleanStatus(option.Enabled), option.Effect})
+	}
+	renderTable(table)
+
+	fmt.Fprintf(h.writer, "\n")
+	return writeRoutePayload(h.writer, explanation)
+}
+
 // Suppression responses
 func (h *tableHandler) HandleSuppressionList(response *responses.PaginatedSuppressionsResponse, config ListConfig) error {
 	if len(response.Data) == 0 {
@@ -1042,7 +1614,7 @@ func (h *tableHandler) HandleSuppressionList(response *responses.PaginatedSuppre
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	h.printMessage("%s\n\n", config.SuccessMessage)
 
 	table := h.createTable()
 
@@ -1139,7 +1711,7 @@ func (h *tableHandler) HandleSingleSuppression(suppression *responses.Suppressio
 		return h.HandleEmpty(config.EmptyMessage)
 	}
 
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	h.printMessage("%s\n\n", config.SuccessMessage)
 
 	table := h.createBorderedTable()
 	table.Header("Field", "Value")
@@ -1166,7 +1738,7 @@ func (h *tableHandler) HandleCreateSuppression(response *responses.CreateSuppres
 		return h.HandleEmpty("No suppressions created")
 	}
 
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	h.printMessage("%s\n\n", config.SuccessMessage)
 
 	table := h.createTable()
 	table.Header("Email", "ID", "Domain", "Reason", "Expires")
@@ -1197,16 +1769,95 @@ func (h *tableHandler) HandleCreateSuppression(response *responses.CreateSuppres
 }
 
 func (h *tableHandler) HandleDeleteSuppression(success bool, config DeleteConfig) error {
-	fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
+	h.printMessage("%s\n", config.SuccessMessage)
 	return nil
 }
 
 func (h *tableHandler) HandleWipeSuppression(count int, config WipeConfig) error {
-	fmt.Fprintf(h.writer, "%s\n", config.SuccessMessage)
+	h.printMessage("%s\n", config.SuccessMessage)
 	fmt.Fprintf(h.writer, "Wiped %d suppressions\n", count)
 	return nil
 }
 
+func (h *tableHandler) HandleSuppressionWipeSummary(summary *SuppressionWipeSummary, config WipeConfig) error {
+	if summary == nil {
+		return nil
+	}
+
+	if len(summary.Reasons) > 0 {
+		table := h.createTable()
+		table.Header("Reason", "Count")
+		for _, reason := range summary.Reasons {
+			addTableRow(table, []string{reason.Reason, fmt.Sprintf("%d", reason.Count)})
+		}
+		renderTable(table)
+		fmt.Fprintln(h.writer)
+	}
+
+	if len(summary.Failures) > 0 {
+		table := h.createTable()
+		table.Header("Email", "Domain", "Error")
+		for _, failure := range summary.Failures {
+			addTableRow(table, []string{failure.Email, failure.Domain, failure.Error})
+		}
+		renderTable(table)
+		fmt.Fprintln(h.writer)
+	}
+
+	line := formatSuppressionWipeSummary(summary)
+	if summary.Failed > 0 && h.caps.Color {
+		line = color.YellowString(line)
+	}
+	fmt.Fprintf(h.writer, "%s\n", line)
+	return nil
+}
+
+func (h *tableHandler) HandleBounceSuppressions(summary *BounceSuppressionSummary, config CreateConfig) error {
+	if summary == nil {
+		return nil
+	}
+
+	if len(summary.Failures) > 0 {
+		table := h.createTable()
+		table.Header("Email", "Error")
+		for _, failure := range summary.Failures {
+			addTableRow(table, []string{failure.Email, failure.Error})
+		}
+		renderTable(table)
+		fmt.Fprintln(h.writer)
+	}
+
+	line := formatBounceSuppressionSummary(summary)
+	if summary.Failed > 0 && h.caps.Color {
+		line = color.YellowString(line)
+	}
+	fmt.Fprintf(h.writer, "%s\n", line)
+	return nil
+}
+
+func (h *tableHandler) HandleSuppressionImport(summary *SuppressionImportSummary, config CreateConfig) error {
+	if summary == nil {
+		return nil
+	}
+
+	if len(summary.Errors) > 0 {
+		table := h.createTable()
+		table.Header("Row", "Email", "Domain", "Error")
+		for _, rowErr := range summary.Errors {
+			addTableRow(table, []string{fmt.Sprintf("%d", rowErr.Row), rowErr.Email, rowErr.Domain, rowErr.Error})
+		}
+		renderTable(table)
+		fmt.Fprintln(h.writer)
+	}
+
+	line := formatSuppressionImportSummary(summary)
+	if summary.Invalid+summary.Failed > 0 && h.caps.Color {
+		line = color.YellowString(line)
+	}
+	fmt.Fprintf(h.writer, "%s\n", line)
+	return nil
+}
+
 func (h *tableHandler) HandleCheckSuppression(suppression *responses.Suppression, found bool, config CheckConfig) error {
 	if found {
 		fmt.Fprintf(h.writer, "%s\n\n", config.FoundMessage)
@@ -1242,7 +1893,7 @@ func (h *tableHandler) HandleSMTPList(response *responses.PaginatedSMTPCredentia
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	h.printMessage("%s\n\n", config.SuccessMessage)
 
 	table := h.createTable()
 
@@ -1259,11 +1910,10 @@ func (h *tableHandler) HandleSMTPList(response *responses.PaginatedSMTPCredentia
 	for _, credential := range response.Data {
 		domains := ""
 		if len(credential.Domains) > 0 {
-			domains = formatStringSlice(credential.Domains)
-			// Truncate long domain lists
-			if len(domains) > 30 {
-				domains = domains[:27] + "..."
-			}
+			domains = formatDomainNames(credential.Domains)
+			// Truncate long domain lists by character, as Unicode domain
+			// names would otherwise be cut mid-character
+			domains = truncateText(domains, 30)
 		} else {
 			domains = "-"
 		}
@@ -1297,7 +1947,7 @@ func (h *tableHandler) HandleSingleSMTP(credential *responses.SMTPCredential, co
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	h.printMessage("%s\n\n", config.SuccessMessage)
 
 	// Create bordered table for detailed view
 	table := h.createBorderedTable()
@@ -1314,7 +1964,7 @@ func (h *tableHandler) HandleSingleSMTP(credential *responses.SMTPCredential, co
 	addTableRow(table, []string{"Scope", credential.Scope})
 
 	if len(credential.Domains) > 0 {
-		addTableRow(table, []string{"Domains", formatStringSlice(credential.Domains)})
+		addTableRow(table, []string{"Domains", formatDomainNames(credential.Domains)})
 	} else {
 		addTableRow(table, []string{"Domains", "-"})
 	}
@@ -1349,7 +1999,7 @@ func (h *tableHandler) HandleCreateSMTP(credential *responses.SMTPCredential, co
 		return h.HandleEmpty(config.SuccessMessage)
 	}
 
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	h.printMessage("%s\n\n", config.SuccessMessage)
 
 	// Create bordered table for new credential
 	table := h.createBorderedTable()
@@ -1371,7 +2021,7 @@ func (h *tableHandler) HandleCreateSMTP(credential *responses.SMTPCredential, co
 	addTableRow(table, []string{"Scope", credential.Scope})
 
 	if len(credential.Domains) > 0 {
-		addTableRow(table, []string{"Domains", formatStringSlice(credential.Domains)})
+		addTableRow(table, []string{"Domains", formatDomainNames(credential.Domains)})
 	} else {
 		addTableRow(table, []string{"Domains", "-"})
 	}
@@ -1383,7 +2033,7 @@ func (h *tableHandler) HandleCreateSMTP(credential *responses.SMTPCredential, co
 
 	// Show important password warning if password was provided
 	if credential.Password != "" {
-		fmt.Fprintf(h.writer, "\n⚠️  IMPORTANT: Save the password shown above! It won't be displayed again.\n")
+		h.printMessage("\n⚠️  IMPORTANT: Save the password shown above! It won't be displayed again.\n")
 	}
 
 	// Show SMTP connection settings
@@ -1408,9 +2058,51 @@ func (h *tableHandler) HandleCreateSMTP(credential *responses.SMTPCredential, co
 	return nil
 }
 
+func (h *tableHandler) HandleUpdateSMTP(update *SMTPCredentialUpdate, config UpdateConfig) error {
+	if update == nil || update.Credential == nil {
+		return h.HandleEmpty("No credential updated")
+	}
+	credential := update.Credential
+
+	h.printMessage("%s\n\n", config.SuccessMessage)
+
+	table := h.createBorderedTable()
+	table.Header("Field", "Value")
+
+	addTableRow(table, []string{"Name", credential.Name})
+	addTableRow(table, []string{"ID", formatUUID(credential.ID)})
+	addTableRow(table, []string{"Username", credential.Username})
+	addTableRow(table, []string{"Scope", credential.Scope})
+	if len(credential.Domains) > 0 {
+		addTableRow(table, []string{"Domains", formatDomainNames(credential.Domains)})
+	} else {
+		addTableRow(table, []string{"Domains", "-"})
+	}
+	if len(update.AddedDomains) > 0 {
+		addTableRow(table, []string{"Added Domains", formatDomainNames(update.AddedDomains)})
+	}
+	if len(update.RemovedDomains) > 0 {
+		addTableRow(table, []string{"Removed Domains", formatDomainNames(update.RemovedDomains)})
+	}
+	addTableRow(table, []string{"Sandbox Mode", formatBooleanStatus(credential.Sandbox)})
+	if !update.DryRun {
+		addTableRow(table, []string{"Updated", formatTime(credential.UpdatedAt)})
+	}
+
+	renderTable(table)
+
+	if update.DryRun {
+		h.printMessage("\n💡 Dry run: nothing was changed. Run again without --dry-run to apply.\n")
+	} else {
+		h.printMessage("\n✅ The password is unchanged; clients using this credential keep working.\n")
+	}
+
+	return nil
+}
+
 func (h *tableHandler) HandleDeleteSMTP(success bool, config DeleteConfig) error {
 	if success {
-		fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+		h.printMessage("%s\n\n", config.SuccessMessage)
 	} else {
 		fmt.Fprintf(h.writer, "SMTP credential deletion failed\n\n")
 	}
@@ -1448,12 +2140,12 @@ func (h *tableHandler) HandleSMTPSend(result *SMTPSendResult, config SMTPSendCon
 		table.Header(headerArgs...)
 
 		if result.Success {
-			addTableRow(table, []string{"Connection", "✓ Successful"})
-			addTableRow(table, []string{"Authentication", "✓ Valid"})
+			addTableRow(table, []string{"Connection", h.statusCell("✓ Successful", "success")})
+			addTableRow(table, []string{"Authentication", h.statusCell("✓ Valid", "valid")})
 			addTableRow(table, []string{"Server Response", "Ready to accept messages"})
 			addTableRow(table, []string{"Status", "SMTP configuration is working correctly"})
 		} else {
-			addTableRow(table, []string{"Connection", "✗ Failed"})
+			addTableRow(table, []string{"Connection", h.statusCell("✗ Failed", "failed")})
 			if result.Error != "" {
 				addTableRow(table, []string{"Error", result.Error})
 			}
@@ -1463,13 +2155,13 @@ func (h *tableHandler) HandleSMTPSend(result *SMTPSendResult, config SMTPSendCon
 		renderTable(table)
 	} else {
 		if result.Success {
-			fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+			h.printMessage("%s\n\n", config.SuccessMessage)
 
 			table := h.createBorderedTable()
 			headerArgs := []any{"Field", "Value"}
 			table.Header(headerArgs...)
 
-			addTableRow(table, []string{"Status", "✓ Sent successfully"})
+			addTableRow(table, []string{"Status", h.statusCell("✓ Sent successfully", "success")})
 			if result.MessageID != "" {
 				addTableRow(table, []string{"Message ID", result.MessageID})
 			}
@@ -1483,16 +2175,77 @@ func (h *tableHandler) HandleSMTPSend(result *SMTPSendResult, config SMTPSendCon
 			headerArgs := []any{"Field", "Value"}
 			table.Header(headerArgs...)
 
-			addTableRow(table, []string{"Status", "✗ Send failed"})
-			if result.Error != "" {
-				addTableRow(table, []string{"Error", result.Error})
+			addTableRow(table, []string{"Status", h.statusCell("✗ Send failed", "failed")})
+			if result.Error != "" {
+				addTableRow(table, []string{"Error", result.Error})
+			}
+			addTableRow(table, []string{"Action", "Check SMTP settings and try again"})
+
+			renderTable(table)
+		}
+	}
+
+	return nil
+}
+
+func (h *tableHandler) HandleSMTPUsage(report *SMTPUsageReport, config SingleConfig) error {
+	if report == nil {
+		fmt.Fprintf(h.writer, "%s\n", config.EmptyMessage)
+		return nil
+	}
+
+	if config.SuccessMessage != "" {
+		h.printMessage("%s\n\n", config.SuccessMessage)
+	}
+	fmt.Fprintf(h.writer, "Period: %s to %s (by %s)\n", formatTime(report.From), formatTime(report.To), report.GroupBy)
+	if report.Approximate {
+		fmt.Fprintf(h.writer, "%s\n", smtpUsageApproximationNote)
+	}
+	if report.UnusedFor != "" {
+		fmt.Fprintf(h.writer, "Unused for %s: %d of %d credentials\n", report.UnusedFor, len(report.Credentials), report.Checked)
+	}
+	fmt.Fprintf(h.writer, "\n")
+
+	if len(report.Credentials) == 0 {
+		fmt.Fprintf(h.writer, "%s\n", config.EmptyMessage)
+		return nil
+	}
+
+	table := h.createTable()
+	table.Header("Name", "Username", "Scope", "Domains", "Messages", "Last Activity", "Status")
+	for _, credential := range report.Credentials {
+		status := smtpUsageStatus(credential)
+		addTableRow(table, []string{
+			credential.Name,
+			credential.Username,
+			credential.Scope,
+			formatSMTPUsageDomains(credential),
+			formatInt(credential.Messages),
+			formatTimePtr(credential.LastActivity),
+			h.statusCell(status, status),
+		})
+	}
+	renderTable(table)
+
+	var buckets [][]string
+	for _, credential := range report.Credentials {
+		for _, bucket := range credential.Buckets {
+			if bucket.Messages > 0 {
+				buckets = append(buckets, []string{credential.Name, formatTime(bucket.From), formatTime(bucket.To), formatInt(bucket.Messages)})
 			}
-			addTableRow(table, []string{"Action", "Check SMTP settings and try again"})
-
-			renderTable(table)
 		}
 	}
+	if len(buckets) > 0 {
+		fmt.Fprintf(h.writer, "\nMessages per %s:\n", report.GroupBy)
+		bucketTable := h.createTable()
+		bucketTable.Header("Credential", "From", "To", "Messages")
+		for _, row := range buckets {
+			addTableRow(bucketTable, row)
+		}
+		renderTable(bucketTable)
+	}
 
+	writeSMTPUsageErrors(h.writer, report)
 	return nil
 }
 
@@ -1503,7 +2256,7 @@ func (h *tableHandler) HandleAPIKeyList(response *responses.PaginatedAPIKeysResp
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	h.printMessage("%s\n\n", config.SuccessMessage)
 
 	table := h.createTable()
 
@@ -1574,7 +2327,7 @@ func (h *tableHandler) HandleSingleAPIKey(key *responses.APIKey, config SingleCo
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	h.printMessage("%s\n\n", config.SuccessMessage)
 
 	// Create bordered table for detailed view
 	table := h.createBorderedTable()
@@ -1634,7 +2387,7 @@ func (h *tableHandler) HandleCreateAPIKey(key *responses.APIKey, config CreateCo
 		return h.HandleEmpty(config.SuccessMessage)
 	}
 
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	h.printMessage("%s\n\n", config.SuccessMessage)
 
 	// Create bordered table for new API key
 	table := h.createBorderedTable()
@@ -1660,7 +2413,7 @@ func (h *tableHandler) HandleCreateAPIKey(key *responses.APIKey, config CreateCo
 
 	// Show important secret key warning if provided
 	if key.SecretKey != nil && *key.SecretKey != "" {
-		fmt.Fprintf(h.writer, "\n⚠️  IMPORTANT: Save the secret key shown above! It won't be displayed again.\n")
+		h.printMessage("\n⚠️  IMPORTANT: Save the secret key shown above! It won't be displayed again.\n")
 	}
 
 	// Show scopes if any
@@ -1694,7 +2447,7 @@ func (h *tableHandler) HandleUpdateAPIKey(key *responses.APIKey, config UpdateCo
 		return h.HandleEmpty(config.SuccessMessage)
 	}
 
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	h.printMessage("%s\n\n", config.SuccessMessage)
 
 	// Create bordered table for updated API key
 	table := h.createBorderedTable()
@@ -1741,7 +2494,7 @@ func (h *tableHandler) HandleUpdateAPIKey(key *responses.APIKey, config UpdateCo
 
 func (h *tableHandler) HandleDeleteAPIKey(success bool, config DeleteConfig) error {
 	if success {
-		fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+		h.printMessage("%s\n\n", config.SuccessMessage)
 	} else {
 		fmt.Fprintf(h.writer, "API key deletion failed\n\n")
 	}
@@ -1773,7 +2526,7 @@ func (h *tableHandler) HandleSubAccountList(response *responses.PaginatedSubAcco
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	h.printMessage("%s\n\n", config.SuccessMessage)
 
 	table := h.createTable()
 	table.Header("Name", "ID", "Status", "Domains", "Members", "Monthly Credit", "Created")
@@ -1805,7 +2558,7 @@ func (h *tableHandler) HandleSingleSubAccount(subAccount *responses.SubAccount,
 		return nil
 	}
 
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	h.printMessage("%s\n\n", config.SuccessMessage)
 
 	renderTable(h.subAccountDetailTable(subAccount))
 	return nil
@@ -1816,7 +2569,7 @@ func (h *tableHandler) HandleCreateSubAccount(subAccount *responses.SubAccount,
 		return h.HandleEmpty(config.SuccessMessage)
 	}
 
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	h.printMessage("%s\n\n", config.SuccessMessage)
 
 	renderTable(h.subAccountDetailTable(subAccount))
 	return nil
@@ -1853,7 +2606,7 @@ func (h *tableHandler) HandleSubAccountUsage(response *responses.SubAccountUsage
 	}
 
 	if config.SuccessMessage != "" {
-		fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+		h.printMessage("%s\n\n", config.SuccessMessage)
 	}
 
 	fmt.Fprintf(h.writer, "Billing Period: %s\n", formatSubAccountBillingPeriod(response.BillingPeriod))
@@ -1934,6 +2687,13 @@ func (h *tableHandler) HandleDeliverabilityStats(response *responses.Deliverabil
 		}
 	}
 
+	// --chart appends the delivered trend of each bucket
+	var trend []string
+	if config.ShowChart {
+		headers = append(headers, "TREND")
+		trend = deliveredTrend(response.Data)
+	}
+
 	// Convert to []any for tablewriter
 	headerAny := make([]any, len(headers))
 	for i, header := range headers {
@@ -1941,7 +2701,7 @@ func (h *tableHandler) HandleDeliverabilityStats(response *responses.Deliverabil
 	}
 	table.Header(headerAny...)
 
-	for _, stat := range response.Data {
+	for bucket, stat := range response.Data {
 		timePeriod := fmt.Sprintf("%s to %s",
 			formatTime(stat.FromTimestamp), formatTime(stat.ToTimestamp))
 
@@ -2023,18 +2783,54 @@ func (h *tableHandler) HandleDeliverabilityStats(response *responses.Deliverabil
 			row = orderedRow
 		}
 
+		row = h.deliverabilityStatusCells(row, config.FieldOrder)
+		if config.ShowChart {
+			row = append(row, trend[bucket])
+		}
 		addTableRow(table, row)
 	}
 
 	renderTable(table)
+	if config.ShowChart {
+		writeDeliverabilityChart(h.writer, response.Data, terminalWidth(h.writer))
+	}
 	return nil
 }
 
+// deliverabilityDefaultColumns are the columns of a deliverability row
+// without a field order, named for their status severity
+var deliverabilityDefaultColumns = []string{
+	"time_period", "reception", "delivered", "deferred", "bounced", "failed",
+	"suppressed", "opened", "clicked", "delivery_rate", "open_rate",
+}
+
+// deliverabilityStatusCells colors the non-zero counts of outcome columns
+// (delivered, deferred, bounced, ...) by the severity of that outcome
+func (h *tableHandler) deliverabilityStatusCells(row, fieldOrder []string) []string {
+	columns := fieldOrder
+	if len(columns) == 0 {
+		columns = deliverabilityDefaultColumns
+	}
+	for i, column := range columns {
+		if i >= len(row) || row[i] == "0" || row[i] == "-" {
+			continue
+		}
+		status := strings.TrimSuffix(strings.ToLower(column), "_count")
+		if _, known := StatusSeverity(status); known {
+			row[i] = h.statusCell(row[i], status)
+		}
+	}
+	return row
+}
+
 func (h *tableHandler) HandleBounceStats(response *responses.BounceStatisticsResponse, config StatsConfig) error {
 	if len(response.Data) == 0 {
 		fmt.Fprintf(h.writer, "No bounce statistics found\n")
 		return nil
 	}
+	if config.Aggregate {
+		return h.writeBounceTotals(response, config)
+	}
 
 	if config.Title != "" {
 		fmt.Fprintf(h.writer, "%s\n\n", config.Title)
@@ -2084,7 +2880,11 @@ func (h *tableHandler) HandleBounceStats(response *responses.BounceStatisticsRes
 		fmt.Fprintf(h.writer, "Bounce Classifications - %s:\n\n", timePeriod)
 
 		bounceTable := h.createBorderedTable()
-		bounceTable.Header("Classification", "Count", "Percentage")
+		if config.Explain {
+			bounceTable.Header("Classification", "Count", "Percentage", "Explanation", "Recommended Action")
+		} else {
+			bounceTable.Header("Classification", "Count", "Percentage")
+		}
 
 		totalBounces := 0
 		for _, bounce := range stat.Bounces {
@@ -2098,11 +2898,16 @@ func (h *tableHandler) HandleBounceStats(response *responses.BounceStatisticsRes
 				percentage = fmt.Sprintf("%.1f%%", pct)
 			}
 
-			addTableRow(bounceTable, []string{
+			row := []string{
 				bounce.Classification,
 				formatInt(bounce.Count),
 				percentage,
-			})
+			}
+			if config.Explain {
+				description, action := explainBounce(bounce.Classification)
+				row = append(row, description, action)
+			}
+			addTableRow(bounceTable, row)
 		}
 
 		renderTable(bounceTable)
@@ -2111,6 +2916,43 @@ func (h *tableHandler) HandleBounceStats(response *responses.BounceStatisticsRes
 	return nil
 }
 
+// writeBounceTotals writes one table of bounces per classification over
+// the whole range, for --aggregate
+func (h *tableHandler) writeBounceTotals(response *responses.BounceStatisticsResponse, config StatsConfig) error {
+	totals, from, to, total := aggregateBounces(response.Data)
+	if total == 0 {
+		fmt.Fprintf(h.writer, "No bounce statistics found\n")
+		return nil
+	}
+
+	if config.Title != "" {
+		fmt.Fprintf(h.writer, "%s\n\n", config.Title)
+	}
+	fmt.Fprintf(h.writer, "Bounce Classifications - %s to %s (%s bounces):\n\n", formatTime(from), formatTime(to), formatInt(total))
+
+	table := h.createBorderedTable()
+	if config.Explain {
+		table.Header("Classification", "Count", "Percentage", "Explanation", "Recommended Action")
+	} else {
+		table.Header("Classification", "Count", "Percentage")
+	}
+	for _, bounce := range totals {
+		row := []string{
+			bounce.Classification,
+			formatInt(bounce.Count),
+			fmt.Sprintf("%.1f%%", bounce.Percentage),
+		}
+		if config.Explain {
+			description, action := explainBounce(bounce.Classification)
+			row = append(row, description, action)
+		}
+		addTableRow(table, row)
+	}
+	renderTable(table)
+
+	return nil
+}
+
 func (h *tableHandler) HandleDeliveryTimeStats(response *responses.DeliveryTimeStatisticsResponse, config StatsConfig) error {
 	if len(response.Data) == 0 {
 		fmt.Fprintf(h.writer, "No delivery time statistics found\n")
@@ -2265,7 +3107,7 @@ func (h *tableHandler) HandleDeliveryTimeStats(response *responses.DeliveryTimeS
 
 // Auth responses
 func (h *tableHandler) HandleAuthLogin(success bool, profile string, config AuthConfig) error {
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	h.printMessage("%s\n\n", config.SuccessMessage)
 
 	table := h.createBorderedTable()
 	table.Header("Field", "Value")
@@ -2278,7 +3120,7 @@ func (h *tableHandler) HandleAuthLogin(success bool, profile string, config Auth
 }
 
 func (h *tableHandler) HandleAuthLogout(success bool, config AuthConfig) error {
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	h.printMessage("%s\n\n", config.SuccessMessage)
 
 	table := h.createBorderedTable()
 	table.Header("Field", "Value")
@@ -2289,6 +3131,27 @@ func (h *tableHandler) HandleAuthLogout(success bool, config AuthConfig) error {
 	return nil
 }
 
+func (h *tableHandler) HandleAccount(account *responses.Account, config SingleConfig) error {
+	if account == nil {
+		fmt.Fprintf(h.writer, "%s\n", config.EmptyMessage)
+		return nil
+	}
+
+	if config.SuccessMessage != "" {
+		h.printMessage("%s\n\n", config.SuccessMessage)
+	}
+
+	table := h.createBorderedTable()
+	table.Header("Field", "Value")
+	for _, field := range accountFields(account) {
+		if field.Value != "" {
+			addTableRow(table, []string{field.Label, field.Value})
+		}
+	}
+	renderTable(table)
+	return nil
+}
+
 func (h *tableHandler) HandleAuthStatus(status *AuthStatus, config AuthConfig) error {
 	if status == nil {
 		fmt.Fprintf(h.writer, "No authentication status available\n")
@@ -2303,6 +3166,12 @@ func (h *tableHandler) HandleAuthStatus(status *AuthStatus, config AuthConfig) e
 
 	addTableRow(table, []string{"Profile", status.Profile})
 	addTableRow(table, []string{"API Key", status.APIKey})
+	if status.APIURL != "" {
+		addTableRow(table, []string{"API URL", status.APIURL})
+	}
+	if status.AccountID != "" {
+		addTableRow(table, []string{"Account", formatProfileAccount(status)})
+	}
 	addTableRow(table, []string{"Valid", formatBooleanStatus(status.Valid)})
 
 	renderTable(table)
@@ -2376,7 +3245,7 @@ func (h *tableHandler) HandleAuthStatus(status *AuthStatus, config AuthConfig) e
 }
 
 func (h *tableHandler) HandleAuthSwitch(newProfile string, config AuthConfig) error {
-	fmt.Fprintf(h.writer, "%s\n\n", config.SuccessMessage)
+	h.printMessage("%s\n\n", config.SuccessMessage)
 
 	table := h.createBorderedTable()
 	table.Header("Field", "Value")
@@ -2388,9 +3257,96 @@ func (h *tableHandler) HandleAuthSwitch(newProfile string, config AuthConfig) er
 	return nil
 }
 
+// Setup diagnosis
+func (h *tableHandler) HandleDoctorReport(report *DoctorReport, config SingleConfig) error {
+	if report == nil {
+		fmt.Fprintf(h.writer, "%s\n", config.EmptyMessage)
+		return nil
+	}
+
+	table := h.createTable()
+	table.Header("Check", "Status", "Details")
+	for _, check := range report.Checks {
+		addTableRow(table, []string{
+			check.Name,
+			h.statusCell(formatDoctorStatus(check.Status), check.Status),
+			check.Message,
+		})
+	}
+	renderTable(table)
+
+	var hints []DoctorCheck
+	for _, check := range report.Checks {
+		if check.Hint != "" {
+			hints = append(hints, check)
+		}
+	}
+	if len(hints) > 0 {
+		fmt.Fprintf(h.writer, "\nHow to fix:\n")
+		for _, check := range hints {
+			fmt.Fprintf(h.writer, "  %s: %s\n", check.Name, check.Hint)
+		}
+	}
+	fmt.Fprintf(h.writer, "\n%s\n", formatDoctorSummary(report))
+	return nil
+}
+
+func (h *tableHandler) HandlePing(result *PingResult, config SimpleConfig) error {
+	if result == nil {
+		return nil
+	}
+	h.printMessage("%s from %s\n", result.Message, result.APIURL)
+	return nil
+}
+
+// Bulk delete results
+func (h *tableHandler) HandleBulkDelete(result *BulkDeleteResult, config DeleteConfig) error {
+	if result == nil {
+		return nil
+	}
+
+	table := h.createTable()
+	table.Header("Name", "ID", "Status", "Error")
+	for _, item := range result.Items {
+		status := "Deleted"
+		if !item.Deleted {
+			status = "Failed"
+			if h.caps.Color {
+				status = color.RedString(status)
+			}
+		}
+		addTableRow(table, []string{item.Name, item.ID, status, item.Error})
+	}
+	renderTable(table)
+
+	fmt.Fprintf(h.writer, "\n%s\n", formatBulkDeleteSummary(result, config.ItemName))
+	return nil
+}
+
+// Import results
+func (h *tableHandler) HandleImport(result *ImportResult, config CreateConfig) error {
+	if result == nil {
+		return nil
+	}
+
+	table := h.createTable()
+	table.Header("Name", "ID", "Status", "Error")
+	for _, item := range result.Items {
+		status := item.Status
+		if status == ImportFailed && h.caps.Color {
+			status = color.RedString(status)
+		}
+		addTableRow(table, []string{item.Name, item.ID, status, item.Error})
+	}
+	renderTable(table)
+
+	fmt.Fprintf(h.writer, "\n%s\n", formatImportSummary(result, config.ItemName))
+	return nil
+}
+
 // Simple success and empty responses
 func (h *tableHandler) HandleSimpleSuccess(message string) error {
-	fmt.Fprintf(h.writer, "%s\n", message)
+	h.printMessage("%s\n", message)
 	return nil
 }
 
@@ -2399,16 +3355,286 @@ func (h *tableHandler) HandleEmpty(message string) error {
 	return nil
 }
 
+// deliverabilityStreamRow is the fixed-width layout of streamed deliverability
+// rows; tablewriter sizes columns from the full data set, which a stream never has
+const deliverabilityStreamRow = "%-41s  %10s  %10s  %10s  %10s  %10s  %10s  %13s  %9s\n"
+
+// HandleDeliverabilityStatsChunk appends the rows of one streamed chunk,
+// writing the title and header only for the first chunk
+func (h *tableHandler) HandleDeliverabilityStatsChunk(response *responses.DeliverabilityStatisticsResponse, config StatsConfig) error {
+	if !config.Continuation {
+		if config.Title != "" {
+			fmt.Fprintf(h.writer, "%s\n\n", config.Title)
+		}
+		fmt.Fprintf(h.writer, deliverabilityStreamRow, "TIME BUCKET", "SENT", "DELIVERED", "DEFERRED",
+			"BOUNCED", "REJECTED", "OPENED", "DELIVERY RATE", "OPEN RATE")
+	}
+	if response == nil {
+		return nil
+	}
+
+	for _, stat := range response.Data {
+		deliveryRate := "N/A"
+		if stat.ReceptionCount > 0 {
+			deliveryRate = fmt.Sprintf("%.2f%%", float64(stat.DeliveredCount)/float64(stat.ReceptionCount)*100)
+		}
+		openRate := "N/A"
+		if stat.DeliveredCount > 0 {
+			openRate = fmt.Sprintf("%.2f%%", float64(stat.OpenedCount)/float64(stat.DeliveredCount)*100)
+		}
+
+		fmt.Fprintf(h.writer, deliverabilityStreamRow,
+			fmt.Sprintf("%s to %s", formatTime(stat.FromTimestamp), formatTime(stat.ToTimestamp)),
+			formatInt(stat.ReceptionCount),
+			formatInt(stat.DeliveredCount),
+			formatInt(stat.DeferredCount),
+			formatInt(stat.BouncedCount),
+			formatInt(stat.FailedCount),
+			formatInt(stat.OpenedCount),
+			deliveryRate,
+			openRate,
+		)
+	}
+	return nil
+}
+
+func (h *tableHandler) HandleDeliverabilitySummary(summary *DeliverabilitySummary, config StatsConfig) error {
+	if summary == nil || summary.Buckets == 0 {
+		fmt.Fprintf(h.writer, "No deliverability statistics found\n")
+		return nil
+	}
+
+	if config.Title != "" {
+		fmt.Fprintf(h.writer, "%s\n\n", config.Title)
+	}
+
+	fmt.Fprintf(h.writer, "Period: %s to %s (%s buckets)\n\n",
+		formatTime(summary.From), formatTime(summary.To), formatInt(summary.Buckets))
+
+	table := h.createTable()
+	table.Header("METRIC", "TOTAL")
+	for _, row := range deliverabilitySummaryRows(summary) {
+		addTableRow(table, []string{row[0], row[1]})
+	}
+
+	table.Render()
+	return nil
+}
+
+// HandleDeliverabilityByTag prints one row per tag and time bucket, or one
+// row per tag with --summary-only. Unavailable tags get a single marked row
+// and their errors are listed below the table.
+func (h *tableHandler) HandleDeliverabilityByTag(report *DeliverabilityByTag, config StatsConfig) error {
+	if report == nil || len(report.Tags) == 0 {
+		fmt.Fprintf(h.writer, "No deliverability statistics found\n")
+		return nil
+	}
+
+	if config.Title != "" {
+		fmt.Fprintf(h.writer, "%s\n\n", config.Title)
+	}
+	fmt.Fprintf(h.writer, "Period: %s to %s\n\n", formatTime(report.From), formatTime(report.To))
+
+	table := h.createTable()
+	if report.SummaryOnly {
+		table.Header("TAG", "SENT", "DELIVERED", "BOUNCED", "REJECTED", "OPENED", "CLICKED",
+			"DELIVERY RATE", "BOUNCE RATE", "OPEN RATE", "CLICK RATE")
+	} else {
+		table.Header("TAG", "TIME BUCKET", "SENT", "DELIVERED", "BOUNCED", "REJECTED", "OPENED",
+			"DELIVERY RATE", "OPEN RATE")
+	}
+
+	for _, tag := range report.Tags {
+		if tag.Status == TagStatusUnavailable {
+			row := []string{tag.Tag, h.statusCell(TagStatusUnavailable, TagStatusUnavailable)}
+			for len(row) < len(tagDeliverabilityColumns(report.SummaryOnly)) {
+				row = append(row, "-")
+			}
+			addTableRow(table, row)
+			continue
+		}
+
+		if report.SummaryOnly {
+			summary := tag.Summary
+			addTableRow(table, h.deliverabilityStatusCells([]string{
+				tag.Tag,
+				formatInt(summary.Reception),
+				formatInt(summary.Delivered),
+				formatInt(summary.Bounced),
+				formatInt(summary.Failed),
+				formatInt(summary.Opened),
+				formatInt(summary.Clicked),
+				formatSummaryRate(summary.DeliveryRate),
+				formatSummaryRate(summary.BounceRate),
+				formatSummaryRate(summary.OpenRate),
+				formatSummaryRate(summary.ClickRate),
+			}, tagDeliverabilityColumns(true)))
+			continue
+		}
+
+		for _, bucket := range tag.Buckets {
+			addTableRow(table, h.deliverabilityStatusCells([]string{
+				tag.Tag,
+				fmt.Sprintf("%s to %s", formatTime(bucket.FromTimestamp), formatTime(bucket.ToTimestamp)),
+				formatInt(bucket.ReceptionCount),
+				formatInt(bucket.DeliveredCount),
+				formatInt(bucket.BouncedCount),
+				formatInt(bucket.FailedCount),
+				formatInt(bucket.OpenedCount),
+				formatSummaryRate(bucket.DeliveryRate),
+				formatSummaryRate(bucket.OpenRate),
+			}, tagDeliverabilityColumns(false)))
+		}
+	}
+	renderTable(table)
+
+	writeUnavailableTags(h.writer, report)
+	return nil
+}
+
+// tagDeliverabilityColumns names the columns of a per-tag row, for status coloring
+func tagDeliverabilityColumns(summaryOnly bool) []string {
+	if summaryOnly {
+		return []string{"tag", "reception", "delivered", "bounced", "failed", "opened", "clicked",
+			"delivery_rate", "bounce_rate", "open_rate", "click_rate"}
+	}
+	return []string{"tag", "time_bucket", "reception", "delivered", "bounced", "failed", "opened",
+		"delivery_rate", "open_rate"}
+}
+
+func (h *tableHandler) HandleBounceExplanations(explanations []bounces.Explanation, config ListConfig) error {
+	if len(explanations) == 0 {
+		return h.HandleEmpty(config.EmptyMessage)
+	}
+
+	if config.SuccessMessage != "" {
+		h.printMessage("%s\n\n", config.SuccessMessage)
+	}
+
+	table := h.createBorderedTable()
+	table.Header("Classification", "Explanation", "Recommended Action")
+	for _, explanation := range explanations {
+		addTableRow(table, []string{explanation.Classification, explanation.Description, explanation.Action})
+	}
+
+	renderTable(table)
+	return nil
+}
+
+func (h *tableHandler) HandleReminderList(reminders []state.Reminder, config ListConfig) error {
+	if len(reminders) == 0 {
+		return h.HandleEmpty(config.EmptyMessage)
+	}
+
+	if config.SuccessMessage != "" {
+		h.printMessage("%s\n\n", config.SuccessMessage)
+	}
+
+	now := time.Now()
+	table := h.createTable()
+	table.Header("ID", "DUE", "STATUS", "REMINDER")
+	for _, reminder := range reminders {
+		status := formatReminderStatus(reminder, now)
+		if h.caps.Color && reminder.Due(now) {
+			status = color.YellowString(status)
+		}
+		addTableRow(table, []string{reminder.ID, formatTime(reminder.DueAt), status, reminder.Message})
+	}
+
+	renderTable(table)
+	return nil
+}
+
+func (h *tableHandler) HandleDeliverabilityComparison(comparison *DeliverabilityComparison, config StatsConfig) error {
+	if comparison == nil || len(comparison.Metrics) == 0 {
+		fmt.Fprintf(h.writer, "No deliverability statistics to compare\n")
+		return nil
+	}
+
+	if config.Title != "" {
+		fmt.Fprintf(h.writer, "%s\n\n", config.Title)
+	}
+
+	fmt.Fprintf(h.writer, "Current:  %s to %s\n",
+		formatTime(comparison.CurrentPeriod.From), formatTime(comparison.CurrentPeriod.To))
+	fmt.Fprintf(h.writer, "Previous: %s to %s\n\n",
+		formatTime(comparison.PreviousPeriod.From), formatTime(comparison.PreviousPeriod.To))
+
+	table := h.createTable()
+	table.Header("METRIC", "CURRENT", "PREVIOUS", "CHANGE", "% CHANGE", "")
+
+	for _, metric := range comparison.Metrics {
+		arrow, improved := comparisonTrend(metric)
+		if h.caps.Color && metric.Change != 0 {
+			if improved {
+				arrow = color.GreenString(arrow)
+			} else {
+				arrow = color.RedString(arrow)
+			}
+		}
+
+		addTableRow(table, []string{
+			formatComparisonMetricName(metric.Metric),
+			formatComparisonValue(metric, metric.Current, metric.CurrentUndefined),
+			formatComparisonValue(metric, metric.Previous, metric.PreviousUndefined),
+			formatComparisonChange(metric),
+			formatPercentChange(metric.PercentChange),
+			arrow,
+		})
+	}
+
+	table.Render()
+	return nil
+}
+
+func (h *tableHandler) HandleStatsAnomalies(report *AnomalyReport, config StatsConfig) error {
+	if config.Title != "" {
+		fmt.Fprintf(h.writer, "%s\n\n", config.Title)
+	}
+
+	fmt.Fprintf(h.writer, "Metric: %s, %s to %s\n", formatComparisonMetricName(report.Metric),
+		formatTime(report.From), formatTime(report.To))
+	fmt.Fprintf(h.writer, "Each %s is compared with the %d before it; sensitivity %.1f\n\n",
+		report.GroupBy, report.Window, report.Sensitivity)
+
+	if len(report.Anomalies) > 0 {
+		table := h.createTable()
+		table.Header("TIME BUCKET", strings.ToUpper(formatComparisonMetricName(report.Metric)), "MEAN", "STDDEV", "Z-SCORE")
+		for _, bucket := range report.Anomalies {
+			addTableRow(table, []string{
+				formatTime(bucket.From),
+				fmt.Sprintf("%.2f%%", bucket.Value),
+				fmt.Sprintf("%.2f%%", bucket.Mean),
+				formatFloat64(bucket.StdDev),
+				formatZScore(bucket.ZScore),
+			})
+		}
+		renderTable(table)
+		fmt.Fprintln(h.writer)
+	}
+
+	verdict := report.Verdict()
+	if h.caps.Color {
+		if len(report.Anomalies) > 0 {
+			verdict = color.RedString(verdict)
+		} else {
+			verdict = color.GreenString(verdict)
+		}
+	}
+	fmt.Fprintf(h.writer, "%s (%d of %d %ss scored)\n", verdict, report.Scored, report.Buckets, report.GroupBy)
+	return nil
+}
+
 // Table-specific utility functions
 
 // createTable creates a properly configured table writer
 func (h *tableHandler) createTable() *tablewriter.Table {
-	return tablewriter.NewWriter(h.writer)
+	return newTable(h.writer, !h.caps.Emoji)
 }
 
 // createBorderedTable creates a table with borders for detailed views
 func (h *tableHandler) createBorderedTable() *tablewriter.Table {
-	table := tablewriter.NewWriter(h.writer)
+	table := newTable(h.writer, !h.caps.Emoji)
 	return table
 }
 
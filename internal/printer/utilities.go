@@ -1,12 +1,26 @@
 package printer
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"io"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/AhaSend/ahasend-go/models/common"
 	"github.com/AhaSend/ahasend-go/models/responses"
 	"github.com/google/uuid"
+
+	"github.com/AhaSend/ahasend-cli/internal/bounces"
+	"github.com/AhaSend/ahasend-cli/internal/bytesize"
+	"github.com/AhaSend/ahasend-cli/internal/dns"
+	"github.com/AhaSend/ahasend-cli/internal/jsonmerge"
+	"github.com/AhaSend/ahasend-cli/internal/state"
+	"github.com/AhaSend/ahasend-cli/internal/validation"
+	"github.com/AhaSend/ahasend-cli/internal/webhooks"
 )
 
 // Common formatting utilities for all output formats
@@ -35,6 +49,35 @@ func formatBooleanStatus(b bool) string {
 	return "No"
 }
 
+// FormatDomainWaitStatus summarizes one poll of 'domains get --wait' on a
+// single line, with the propagation of every DNS record, e.g.
+// "[15:04:05] DNS Invalid, 1/2 records propagated: TXT example.com Yes, CNAME em.example.com No"
+func FormatDomainWaitStatus(domain *responses.Domain, checkedAt time.Time) string {
+	propagated := 0
+	records := make([]string, 0, len(domain.DNSRecords))
+	for _, record := range domain.DNSRecords {
+		if record.Propagated {
+			propagated++
+		}
+		records = append(records, fmt.Sprintf("%s %s %s", record.Type, record.Host, formatBooleanStatus(record.Propagated)))
+	}
+
+	status := fmt.Sprintf("[%s] DNS %s, %d/%d records propagated", checkedAt.Local().Format("15:04:05"),
+		formatDNSStatus(domain.DNSValid), propagated, len(domain.DNSRecords))
+	if len(records) > 0 {
+		status += ": " + strings.Join(records, ", ")
+	}
+	return status
+}
+
+// formatEnabledStatus names the enabled state of a webhook, route or credential
+func formatEnabledStatus(enabled bool) string {
+	if enabled {
+		return "enabled"
+	}
+	return "disabled"
+}
+
 // formatDNSStatus formats DNS validation status
 func formatDNSStatus(valid bool) string {
 	if valid {
@@ -43,6 +86,15 @@ func formatDNSStatus(valid bool) string {
 	return "Invalid"
 }
 
+// formatProfileAccount shows the account a profile is bound to, with its
+// name when known
+func formatProfileAccount(status *AuthStatus) string {
+	if status.AccountName == "" {
+		return status.AccountID
+	}
+	return fmt.Sprintf("%s (%s)", status.AccountName, status.AccountID)
+}
+
 // formatOptionalString handles nil string pointers safely
 func formatOptionalString(s *string) string {
 	if s == nil {
@@ -59,6 +111,30 @@ func formatStringSlice(slice []string) string {
 	return strings.Join(slice, ", ")
 }
 
+// formatDomainName shows internationalized domains in Unicode with the
+// punycode form in parentheses
+func formatDomainName(domain string) string {
+	return validation.FormatDomainForDisplay(domain)
+}
+
+// formatDomainNames formats a list of domains for display
+func formatDomainNames(domains []string) string {
+	formatted := make([]string, len(domains))
+	for i, domain := range domains {
+		formatted[i] = formatDomainName(domain)
+	}
+	return formatStringSlice(formatted)
+}
+
+// formatAttachmentCount returns the number of stored attachments, or N/A
+// when the parsed content is not available
+func formatAttachmentCount(message responses.Message) string {
+	if message.ContentParsed == nil {
+		return "N/A"
+	}
+	return formatInt(len(message.ContentParsed.Attachments))
+}
+
 // formatUUID formats UUID values consistently
 func formatUUID(id uuid.UUID) string {
 	return id.String()
@@ -79,6 +155,103 @@ func formatFloat64(f float64) string {
 	return fmt.Sprintf("%.2f", f)
 }
 
+// attemptResponseWidth is the SMTP response length shown in tables
+const attemptResponseWidth = 60
+
+// truncateText shortens text to at most width characters, marking the cut with "..."
+func truncateText(text string, width int) string {
+	runes := []rune(text)
+	if len(runes) <= width {
+		return text
+	}
+	return string(runes[:width-3]) + "..."
+}
+
+// formatSMTPCode formats an SMTP status code, or N/A when none was received
+func formatSMTPCode(code int) string {
+	if code == 0 {
+		return "N/A"
+	}
+	return formatInt(code)
+}
+
+// formatElapsed formats a duration with its two most significant units
+func formatElapsed(d time.Duration) string {
+	d = d.Round(time.Second)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm %ds", int(d/time.Minute), int(d%time.Minute/time.Second))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh %dm", int(d/time.Hour), int(d%time.Hour/time.Minute))
+	default:
+		return fmt.Sprintf("%dd %dh", int(d/(24*time.Hour)), int(d%(24*time.Hour)/time.Hour))
+	}
+}
+
+// formatAttemptsTotal describes the time from creation to the final state
+func formatAttemptsTotal(report *MessageAttemptsReport) string {
+	if report.Final {
+		return fmt.Sprintf("Total time: %s from creation to %s", formatElapsed(report.TotalDuration), report.Status)
+	}
+	return fmt.Sprintf("Elapsed: %s since creation, not yet in a final state", formatElapsed(report.TotalDuration))
+}
+
+// formatPropagationSummary reports how many required record checks are visible
+func formatPropagationSummary(matrix *dns.PropagationMatrix) string {
+	visible, total := matrix.RequiredCounts()
+	return fmt.Sprintf("%d/%d required records visible across %d resolvers (checked %s)",
+		visible, total, len(matrix.Resolvers), matrix.CheckedAt.Local().Format("15:04:05"))
+}
+
+// formatVerificationSummary reports how many required records passed the
+// server-side DNS check and how many records failed it
+func formatVerificationSummary(verification *dns.Verification) string {
+	summary := fmt.Sprintf("%d/%d required records passed the AhaSend DNS check",
+		verification.RequiredPassed, verification.RequiredTotal)
+	if optional := len(verification.Failures) - len(verification.RequiredFailures()); optional == 1 {
+		summary += " (1 optional record failed)"
+	} else if optional > 1 {
+		summary += fmt.Sprintf(" (%d optional records failed)", optional)
+	}
+	return summary
+}
+
+// formatLocalVerificationSummary reports how many required records a
+// resolver serves as expected, e.g. "2/3 required records found"
+func formatLocalVerificationSummary(verification *dns.LocalVerification) string {
+	found, total := verification.RequiredCounts()
+	return fmt.Sprintf("%d/%d required records found", found, total)
+}
+
+// formatVerificationState names a DNS verification state for display
+func formatVerificationState(state dns.VerificationState) string {
+	return strings.ReplaceAll(string(state), "_", " ")
+}
+
+// formatFailureReason names the reason a DNS record failed for display
+func formatFailureReason(reason dns.FailureReason) string {
+	return strings.ReplaceAll(string(reason), "_", " ")
+}
+
+// formatObservedValues lists the values resolvers serve for a record
+func formatObservedValues(values []string) string {
+	if len(values) == 0 {
+		return "(none)"
+	}
+	return strings.Join(values, ", ")
+}
+
+// verificationTips suggests next steps for a domain whose DNS is not verified
+func verificationTips(domain string) []string {
+	return []string{
+		"DNS propagation can take up to 48 hours",
+		fmt.Sprintf("Run 'ahasend domains dns-watch %s' to follow propagation across resolvers", domain),
+		fmt.Sprintf("Run 'ahasend domains check-dns %s' to have AhaSend check the records again", domain),
+	}
+}
+
 // Field ordering and selection utilities
 
 // orderFields reorders fields according to the specified order
@@ -122,43 +295,374 @@ func orderFields(fieldMap map[string]string, fieldOrder []string) [][]string {
 
 // formatWebhookEvents formats webhook event subscriptions as a comma-separated list
 func formatWebhookEvents(webhook *responses.Webhook) string {
-	var events []string
+	events := webhooks.SubscribedEvents(webhook)
+	if len(events) == 0 {
+		return "none"
+	}
+	return strings.Join(events, ", ")
+}
 
-	if webhook.OnReception {
-		events = append(events, "reception")
+// formatCoverageActivity formats an event's activity count for a coverage report
+func formatCoverageActivity(event EventCoverage) string {
+	if event.Activity == nil {
+		return "N/A"
 	}
-	if webhook.OnDelivered {
-		events = append(events, "delivered")
+	if event.ActivityCapped {
+		return fmt.Sprintf("%d+", *event.Activity)
 	}
-	if webhook.OnTransientError {
-		events = append(events, "transient_error")
+	return formatInt(*event.Activity)
+}
+
+// formatCoverageSeverity formats a coverage finding severity as a label
+func formatCoverageSeverity(severity string) string {
+	return "[" + strings.ToUpper(severity) + "]"
+}
+
+// formatDoctorStatus formats a doctor check status as a label
+func formatDoctorStatus(status string) string {
+	return "[" + strings.ToUpper(status) + "]"
+}
+
+// formatDoctorSummary counts the doctor checks by status, e.g. "5 passed,
+// 1 warning, 1 failed"
+func formatDoctorSummary(report *DoctorReport) string {
+	parts := []string{fmt.Sprintf("%d passed", report.Count(DoctorStatusPass))}
+	switch warnings := report.Count(DoctorStatusWarn); {
+	case warnings == 1:
+		parts = append(parts, "1 warning")
+	case warnings > 1:
+		parts = append(parts, fmt.Sprintf("%d warnings", warnings))
 	}
-	if webhook.OnFailed {
-		events = append(events, "failed")
+	if failed := report.Count(DoctorStatusFail); failed > 0 {
+		parts = append(parts, fmt.Sprintf("%d failed", failed))
 	}
-	if webhook.OnBounced {
-		events = append(events, "bounced")
+	if skipped := report.Count(DoctorStatusSkip); skipped > 0 {
+		parts = append(parts, fmt.Sprintf("%d skipped", skipped))
 	}
-	if webhook.OnSuppressed {
-		events = append(events, "suppressed")
+	return strings.Join(parts, ", ")
+}
+
+// webhookStatsFields are the extra columns shown with --include-stats
+var webhookStatsFields = []string{"success", "errors", "error_streak", "last_request", "error_rate"}
+
+// WebhookStatsSummary aggregates delivery stats across webhooks
+type WebhookStatsSummary struct {
+	Webhooks    int        `json:"webhooks"`
+	Success     uint64     `json:"success_count"`
+	Errors      uint64     `json:"error_count"`
+	ErrorRate   float64    `json:"error_rate"`
+	LastRequest *time.Time `json:"last_request_at"`
+}
+
+// summarizeWebhookStats computes account-wide totals for a list of webhooks
+func summarizeWebhookStats(webhooks []responses.Webhook) WebhookStatsSummary {
+	summary := WebhookStatsSummary{Webhooks: len(webhooks)}
+	for _, webhook := range webhooks {
+		summary.Success += webhook.SuccessCount
+		summary.Errors += webhook.ErrorCount
+		if webhook.LastRequestAt != nil && (summary.LastRequest == nil || webhook.LastRequestAt.After(*summary.LastRequest)) {
+			summary.LastRequest = webhook.LastRequestAt
+		}
 	}
-	if webhook.OnOpened {
-		events = append(events, "opened")
+	summary.ErrorRate = webhookErrorRate(summary.Success, summary.Errors)
+	return summary
+}
+
+// webhookErrorRate returns errors as a percentage of all requests
+func webhookErrorRate(success, errors uint64) float64 {
+	total := success + errors
+	if total == 0 {
+		return 0
 	}
-	if webhook.OnClicked {
-		events = append(events, "clicked")
+	return float64(errors) / float64(total) * 100
+}
+
+// formatErrorRate formats an error rate percentage
+func formatErrorRate(rate float64) string {
+	return fmt.Sprintf("%.2f%%", rate)
+}
+
+// webhookStatsFieldMap returns the stats columns for a single webhook
+func webhookStatsFieldMap(webhook responses.Webhook) map[string]string {
+	return map[string]string{
+		"success":      formatUint64(webhook.SuccessCount),
+		"errors":       formatUint64(webhook.ErrorCount),
+		"error_streak": formatInt(webhook.ErrorsSinceLastSuccess),
+		"last_request": formatTimePtr(webhook.LastRequestAt),
+		"error_rate":   formatErrorRate(webhookErrorRate(webhook.SuccessCount, webhook.ErrorCount)),
 	}
-	if webhook.OnSuppressionCreated {
-		events = append(events, "suppression_created")
+}
+
+// webhookSummaryFieldMap returns the totals row for the stats summary
+func webhookSummaryFieldMap(summary WebhookStatsSummary) map[string]string {
+	return map[string]string{
+		"name":         "TOTAL",
+		"success":      formatUint64(summary.Success),
+		"errors":       formatUint64(summary.Errors),
+		"last_request": formatTimePtr(summary.LastRequest),
+		"error_rate":   formatErrorRate(summary.ErrorRate),
 	}
-	if webhook.OnDNSError {
-		events = append(events, "dns_error")
+}
+
+// withWebhookStatsFields appends the stats columns to a field order
+func withWebhookStatsFields(fieldOrder []string) []string {
+	fields := append([]string{}, fieldOrder...)
+	for _, field := range webhookStatsFields {
+		if !slices.Contains(fields, field) {
+			fields = append(fields, field)
+		}
 	}
+	return fields
+}
 
-	if len(events) == 0 {
-		return "none"
+// formatBulkDeleteSummary describes how many matched items were deleted
+func formatBulkDeleteSummary(result *BulkDeleteResult, itemName string) string {
+	summary := fmt.Sprintf("Deleted %d of %d %ss matching '%s'", result.Deleted, result.Matched, itemName, result.Pattern)
+	if result.Failed > 0 {
+		summary += fmt.Sprintf(", %d failed", result.Failed)
 	}
-	return strings.Join(events, ", ")
+	return summary
+}
+
+// formatImportSummary counts the outcomes of an import
+func formatImportSummary(result *ImportResult, itemName string) string {
+	summary := fmt.Sprintf("Imported %ss from %s: %d created, %d updated, %d unchanged, %d skipped",
+		itemName, result.File, result.Created, result.Updated, result.Unchanged, result.Skipped)
+	if result.Failed > 0 {
+		summary += fmt.Sprintf(", %d failed", result.Failed)
+	}
+	return summary
+}
+
+// formatMessageExportSummary counts the .eml files an export wrote
+func formatMessageExportSummary(summary *MessageExportSummary) string {
+	return fmt.Sprintf("Exported %d messages (%s) to %s, %d skipped",
+		summary.Exported, bytesize.Format(int(summary.TotalBytes)), summary.OutputDir, summary.Skipped)
+}
+
+// SummarizeAttachments describes attachments without their data
+func SummarizeAttachments(attachments []common.Attachment) []DryRunAttachment {
+	summaries := make([]DryRunAttachment, len(attachments))
+	for i, attachment := range attachments {
+		summaries[i] = DryRunAttachment{
+			FileName:    attachment.FileName,
+			ContentType: attachment.ContentType,
+			Inline:      attachment.ContentDisposition == "inline",
+			Size:        attachmentSize(attachment),
+		}
+		if attachment.ContentID != nil {
+			summaries[i].ContentID = *attachment.ContentID
+		}
+	}
+	return summaries
+}
+
+// attachmentSize is the size of an attachment's file: its data, decoded
+// when it is base64
+func attachmentSize(attachment common.Attachment) int {
+	if !attachment.Base64 {
+		return len(attachment.Data)
+	}
+	data, err := base64.StdEncoding.DecodeString(attachment.Data)
+	if err != nil {
+		return base64.StdEncoding.DecodedLen(len(attachment.Data))
+	}
+	return len(data)
+}
+
+// dryRunFields lists what a dry run would send as field and value pairs,
+// leaving out the settings that are not used
+func dryRunFields(dryRun *DryRunMessage) [][2]string {
+	fields := [][2]string{
+		{"From", dryRun.From},
+		{"Recipients", fmt.Sprintf("%d", dryRun.Recipients)},
+		{"Batches", fmt.Sprintf("%d", len(dryRun.Batches))},
+	}
+	if len(dryRun.Cc) > 0 {
+		fields = append(fields, [2]string{"Cc", strings.Join(dryRun.Cc, ", ")})
+	}
+	if len(dryRun.Bcc) > 0 {
+		fields = append(fields, [2]string{"Bcc", strings.Join(dryRun.Bcc, ", ")})
+	}
+	fields = append(fields,
+		[2]string{"Subject", fmt.Sprintf("%s (for %s)", dryRun.Subject, dryRun.FirstRecipient)},
+	)
+	if dryRun.SubjectField != "" {
+		noun := "subjects"
+		if dryRun.Subjects == 1 {
+			noun = "subject"
+		}
+		fields = append(fields, [2]string{"Subjects", fmt.Sprintf("from %s (%d distinct %s in %d batches)",
+			dryRun.SubjectField, dryRun.Subjects, noun, len(dryRun.Batches))})
+	}
+	fields = append(fields, [2]string{"Content", formatDryRunContent(dryRun.Content)})
+	if attachments := formatDryRunAttachments(dryRun.Attachments, false); attachments != "" {
+		fields = append(fields, [2]string{"Attachments", attachments})
+	}
+	if inline := formatDryRunAttachments(dryRun.Attachments, true); inline != "" {
+		fields = append(fields, [2]string{"Inline Attachments", inline})
+	}
+	fields = append(fields,
+		[2]string{"Total Size", bytesize.Format(dryRun.TotalSize)},
+		[2]string{"Track Opens", formatBooleanStatus(dryRun.TrackOpens)},
+		[2]string{"Track Clicks", formatBooleanStatus(dryRun.TrackClicks)},
+	)
+	if len(dryRun.Tags) > 0 {
+		fields = append(fields, [2]string{"Tags", strings.Join(dryRun.Tags, ", ")})
+	}
+	if len(dryRun.DomainDefaults) > 0 {
+		fields = append(fields, [2]string{"Domain Defaults", strings.Join(dryRun.DomainDefaults, " ")})
+	}
+	if len(dryRun.Metadata) > 0 {
+		fields = append(fields, [2]string{"Metadata", FormatMetadata(dryRun.Metadata)})
+	}
+	sandbox := formatBooleanStatus(dryRun.Sandbox)
+	if dryRun.Sandbox {
+		sandbox += fmt.Sprintf(" (result: %s)", dryRun.SandboxResult)
+	}
+	schedule := "Immediately"
+	if len(dryRun.ScheduleBuckets) > 0 {
+		schedule = formatDryRunScheduleBuckets(dryRun.ScheduleBuckets)
+	} else if dryRun.Schedule != nil {
+		schedule = formatTimePtr(dryRun.Schedule)
+	}
+	return append(fields, [2]string{"Sandbox", sandbox}, [2]string{"Schedule", schedule})
+}
+
+// formatDryRunScheduleBuckets lists schedule times with their recipient
+// counts, e.g. "2024-12-01 09:00:00 (120 recipients), Immediately (3 recipients)"
+func formatDryRunScheduleBuckets(buckets []DryRunScheduleBucket) string {
+	parts := make([]string, len(buckets))
+	for i, bucket := range buckets {
+		when := "Immediately"
+		if bucket.SendAt != nil {
+			when = formatTimePtr(bucket.SendAt)
+		}
+		parts[i] = fmt.Sprintf("%s (%d recipients)", when, bucket.Recipients)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatDryRunBatchSubject is the subject of a batch's request, before the
+// recipients' substitutions
+func formatDryRunBatchSubject(batch DryRunBatch) string {
+	if batch.Request == nil {
+		return ""
+	}
+	return batch.Request.Subject
+}
+
+// formatDryRunBatchSchedule is the schedule time of a batch's request, or
+// "Immediately"
+func formatDryRunBatchSchedule(batch DryRunBatch) string {
+	if batch.Request == nil || batch.Request.Schedule == nil || batch.Request.Schedule.FirstAttempt == nil {
+		return "Immediately"
+	}
+	return formatTimePtr(batch.Request.Schedule.FirstAttempt)
+}
+
+// formatDryRunContent lists content parts with their sizes and sources, e.g.
+// "text/html 98.0 KB (welcome.html), text/plain 2.1 KB (--text)"
+func formatDryRunContent(content []DryRunContent) string {
+	parts := make([]string, len(content))
+	for i, part := range content {
+		parts[i] = fmt.Sprintf("%s %s (%s)", part.Type, bytesize.Format(part.Size), part.Source)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatDryRunAttachments lists either the inline or the other attachments
+// with their types and sizes; inline ones show their Content-ID
+func formatDryRunAttachments(attachments []DryRunAttachment, inline bool) string {
+	var parts []string
+	for _, attachment := range attachments {
+		if attachment.Inline != inline {
+			continue
+		}
+		name := attachment.FileName
+		if inline {
+			name += " as cid:" + attachment.ContentID
+		}
+		parts = append(parts, fmt.Sprintf("%s (%s, %s)", name, attachment.ContentType, bytesize.Format(attachment.Size)))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// FormatMetadata renders message metadata as key=value pairs sorted by key
+func FormatMetadata(metadata map[string]string) string {
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = fmt.Sprintf("%s=%s", key, metadata[key])
+	}
+	return strings.Join(pairs, ", ")
+}
+
+// formatSuppressionWipeSummary describes how many suppressions a scoped wipe
+// selects or deleted
+func formatSuppressionWipeSummary(summary *SuppressionWipeSummary) string {
+	var scope []string
+	if summary.Domain != "" {
+		scope = append(scope, fmt.Sprintf("domain %s", summary.Domain))
+	}
+	if summary.Match != "" {
+		scope = append(scope, fmt.Sprintf("matching '%s'", summary.Match))
+	}
+	scopeText := ""
+	if len(scope) > 0 {
+		scopeText = fmt.Sprintf(" (%s)", strings.Join(scope, ", "))
+	}
+
+	if summary.DryRun {
+		return fmt.Sprintf("Dry run: %d suppressions would be removed%s", summary.Matched, scopeText)
+	}
+	text := fmt.Sprintf("Deleted %d of %d suppressions%s", summary.Deleted, summary.Matched, scopeText)
+	if summary.Failed > 0 {
+		text += fmt.Sprintf(", %d failed", summary.Failed)
+	}
+	return text
+}
+
+// formatBounceSuppressionSummary describes how many bounced addresses
+// would be or were suppressed
+func formatBounceSuppressionSummary(summary *BounceSuppressionSummary) string {
+	skipped := ""
+	if summary.AlreadySuppressed > 0 {
+		skipped = fmt.Sprintf(", %d already suppressed", summary.AlreadySuppressed)
+	}
+	if summary.DryRun {
+		return fmt.Sprintf("Dry run: %d addresses would be suppressed (%d bounced messages scanned%s)",
+			summary.ToSuppress(), summary.Messages, skipped)
+	}
+	text := fmt.Sprintf("Suppressed %d of %d addresses (%d bounced messages scanned%s)",
+		summary.Created, summary.ToSuppress(), summary.Messages, skipped)
+	if summary.Failed > 0 {
+		text += fmt.Sprintf(", %d failed", summary.Failed)
+	}
+	return text
+}
+
+// formatSuppressionImportSummary describes how many rows of an import file
+// were suppressed and what happened to the rest
+func formatSuppressionImportSummary(summary *SuppressionImportSummary) string {
+	text := fmt.Sprintf("Imported %d of %d suppressions from %s", summary.Created, summary.Rows, summary.File)
+	for _, count := range []struct {
+		n     int
+		label string
+	}{{summary.Invalid, "invalid"}, {summary.Failed, "failed"}, {summary.Skipped, "skipped"}} {
+		if count.n > 0 {
+			text += fmt.Sprintf(", %d %s", count.n, count.label)
+		}
+	}
+	if summary.ErrorsFile != "" && len(summary.Errors) > 0 {
+		text += fmt.Sprintf("; rows not imported written to %s", summary.ErrorsFile)
+	}
+	return text
 }
 
 // formatWebhookSecret formats webhook secret for display (masked)
@@ -176,3 +680,638 @@ func formatWebhookSecretCreation(secret string) string {
 	}
 	return secret
 }
+
+// formatComparisonValue formats a compared metric value (rates as
+// percentages), or N/A for an undefined rate
+func formatComparisonValue(metric MetricComparison, value float64, undefined bool) string {
+	if undefined {
+		return "N/A"
+	}
+	if metric.IsRate {
+		return fmt.Sprintf("%.2f%%", value)
+	}
+	return fmt.Sprintf("%.0f", value)
+}
+
+// formatComparisonChange formats the absolute change with an explicit sign
+func formatComparisonChange(metric MetricComparison) string {
+	if metric.Undefined() {
+		return "N/A"
+	}
+	if metric.IsRate {
+		return fmt.Sprintf("%+.2f pp", metric.Change)
+	}
+	return fmt.Sprintf("%+.0f", metric.Change)
+}
+
+// formatPercentChange formats a relative change, or N/A when there is no baseline
+func formatPercentChange(percent *float64) string {
+	if percent == nil {
+		return "N/A"
+	}
+	return fmt.Sprintf("%+.2f%%", *percent)
+}
+
+// comparisonTrend returns an arrow for the direction of change and whether the
+// change is an improvement for that metric
+func comparisonTrend(metric MetricComparison) (string, bool) {
+	switch {
+	case metric.Undefined():
+		return "", true
+	case metric.Change > 0:
+		return "▲", metric.HigherIsBetter
+	case metric.Change < 0:
+		return "▼", !metric.HigherIsBetter
+	default:
+		return "=", true
+	}
+}
+
+// formatComparisonMetricName converts a metric key to a display label
+func formatComparisonMetricName(metric string) string {
+	words := strings.Split(metric, "_")
+	for i, word := range words {
+		if word != "" {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// formatZScore formats a z-score with its sign, or "flat" when the preceding
+// buckets did not vary
+func formatZScore(z *float64) string {
+	if z == nil {
+		return "flat"
+	}
+	return fmt.Sprintf("%+.2f", *z)
+}
+
+// formatSummaryRate formats a weighted rate, or N/A when it has no denominator
+func formatSummaryRate(rate *float64) string {
+	if rate == nil {
+		return "N/A"
+	}
+	return fmt.Sprintf("%.2f%%", *rate)
+}
+
+// deliverabilitySummaryRows lists the metrics of a summary as label/value pairs
+func deliverabilitySummaryRows(summary *DeliverabilitySummary) [][2]string {
+	return [][2]string{
+		{"Reception", formatInt(summary.Reception)},
+		{"Delivered", formatInt(summary.Delivered)},
+		{"Deferred", formatInt(summary.Deferred)},
+		{"Bounced", formatInt(summary.Bounced)},
+		{"Failed", formatInt(summary.Failed)},
+		{"Suppressed", formatInt(summary.Suppressed)},
+		{"Opened", formatInt(summary.Opened)},
+		{"Clicked", formatInt(summary.Clicked)},
+		{"Delivery Rate", formatSummaryRate(summary.DeliveryRate)},
+		{"Bounce Rate", formatSummaryRate(summary.BounceRate)},
+		{"Open Rate", formatSummaryRate(summary.OpenRate)},
+		{"Click Rate", formatSummaryRate(summary.ClickRate)},
+	}
+}
+
+// deliveredTrend returns the sparkline level of each bucket's delivered
+// count, scaled across all buckets
+func deliveredTrend(data []responses.DeliverabilityStatistics) []string {
+	delivered := make([]float64, len(data))
+	for i, stat := range data {
+		delivered[i] = float64(stat.DeliveredCount)
+	}
+	top := maxValue(delivered)
+	ticks := make([]string, len(data))
+	for i, value := range delivered {
+		ticks[i] = sparkTick(value, top)
+	}
+	return ticks
+}
+
+// writeDeliverabilityChart writes the delivered counts as a sparkline and
+// the delivery rate of each bucket as a bar chart fitted to width. Buckets
+// without receptions have no rate and no bar.
+func writeDeliverabilityChart(w io.Writer, data []responses.DeliverabilityStatistics, width int) {
+	delivered := make([]float64, len(data))
+	top := 0
+	bars := make([]chartBar, len(data))
+	for i, stat := range data {
+		delivered[i] = float64(stat.DeliveredCount)
+		top = max(top, stat.DeliveredCount)
+		bars[i] = chartBar{Label: formatTime(stat.FromTimestamp), Text: "N/A", Missing: true}
+		if stat.ReceptionCount > 0 {
+			rate := float64(stat.DeliveredCount) / float64(stat.ReceptionCount) * 100
+			bars[i] = chartBar{Label: bars[i].Label, Value: rate, Text: fmt.Sprintf("%.2f%%", rate)}
+		}
+	}
+
+	fmt.Fprintf(w, "\nDelivered: %s (max %s)\n", sparkline(delivered), formatInt(top))
+	fmt.Fprintf(w, "\nDelivery Rate\n")
+	for _, line := range barChart(bars, 100, width) {
+		fmt.Fprintln(w, line)
+	}
+}
+
+// writeUnavailableTags explains below a per-tag report why tags are unavailable
+func writeUnavailableTags(w io.Writer, report *DeliverabilityByTag) {
+	separated := false
+	for _, tag := range report.Tags {
+		if tag.Status != TagStatusUnavailable {
+			continue
+		}
+		if !separated {
+			fmt.Fprintln(w)
+			separated = true
+		}
+		fmt.Fprintf(w, "Statistics for tag '%s' are unavailable: %s\n", tag.Tag, tag.Error)
+	}
+}
+
+// formatSimulationSummary describes how many simulated events were delivered
+func formatSimulationSummary(simulation *WebhookSimulation) string {
+	if !simulation.Sent {
+		return fmt.Sprintf("Signed %d simulated %s events (seed %d); nothing was sent", len(simulation.Events), simulation.Event, simulation.Seed)
+	}
+	delivered := len(simulation.Events) - simulation.Failed()
+	summary := fmt.Sprintf("Delivered %d of %d simulated %s events to %s (seed %d)", delivered, len(simulation.Events), simulation.Event, simulation.URL, simulation.Seed)
+	if failures := simulationFailures(simulation); len(failures) > 0 {
+		counts := make([]string, len(failures))
+		for i, failure := range failures {
+			counts[i] = fmt.Sprintf("%d %s", failure.count, failure.category)
+		}
+		summary += "; failed: " + strings.Join(counts, ", ")
+	}
+	return summary
+}
+
+// formatWebhookVerificationSummary states whether a delivery checked out
+// and how far its timestamp is from the local clock
+func formatWebhookVerificationSummary(verification *WebhookVerification) string {
+	window := "within"
+	if !verification.TimestampValid {
+		window = "outside"
+	}
+	timestamp := fmt.Sprintf("the timestamp is %s the local clock, %s ±%s",
+		webhooks.DescribeSkew(time.Duration(verification.SkewSeconds)*time.Second), window,
+		time.Duration(verification.ToleranceSeconds)*time.Second)
+
+	switch {
+	case verification.Valid:
+		return "Signature is valid; " + timestamp
+	case verification.SignatureMatch:
+		return "Signature matches, but " + timestamp
+	default:
+		return "Signature does not match; " + timestamp
+	}
+}
+
+// webhookVerificationFields lists the details of a verification in display
+// order, reusing the key, label and value triple of accountField
+func webhookVerificationFields(verification *WebhookVerification) []accountField {
+	return []accountField{
+		{"valid", "Valid", formatBooleanStatus(verification.Valid)},
+		{"signature_match", "Signature Match", formatBooleanStatus(verification.SignatureMatch)},
+		{"computed_signature", "Computed Signature", verification.ComputedSignature},
+		{"provided_signatures", "Provided Signatures", strings.Join(verification.ProvidedSignatures, " ")},
+		{"msg_id", "Msg ID", verification.MsgID},
+		{"webhook_timestamp", "Timestamp", fmt.Sprintf("%d", verification.Timestamp)},
+		{"skew_seconds", "Skew (seconds)", fmt.Sprintf("%d", verification.SkewSeconds)},
+		{"tolerance_seconds", "Tolerance (seconds)", fmt.Sprintf("%d", verification.ToleranceSeconds)},
+	}
+}
+
+// writeWebhookVerificationProblems lists the likely causes of a failed
+// verification
+func writeWebhookVerificationProblems(w io.Writer, verification *WebhookVerification) {
+	if len(verification.Problems) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\nLikely causes:\n")
+	for _, problem := range verification.Problems {
+		fmt.Fprintf(w, "  - %s\n", problem)
+	}
+}
+
+// simulationFailure counts the failed deliveries of one category, keeping
+// the status code of the first for the hint
+type simulationFailure struct {
+	category   webhooks.FailureCategory
+	count      int
+	statusCode int
+}
+
+// simulationFailures groups the failed deliveries by category, in the order
+// the categories first occurred
+func simulationFailures(simulation *WebhookSimulation) []simulationFailure {
+	var failures []simulationFailure
+	index := make(map[webhooks.FailureCategory]int)
+	for _, event := range simulation.Events {
+		if event.Failure == "" {
+			continue
+		}
+		i, ok := index[event.Failure]
+		if !ok {
+			i = len(failures)
+			index[event.Failure] = i
+			failures = append(failures, simulationFailure{category: event.Failure, statusCode: event.StatusCode})
+		}
+		failures[i].count++
+	}
+	return failures
+}
+
+// writeSimulationFailureHints suggests a fix for each category of failed
+// deliveries
+func writeSimulationFailureHints(w io.Writer, simulation *WebhookSimulation) {
+	for _, failure := range simulationFailures(simulation) {
+		fmt.Fprintf(w, "  %s: %s\n", failure.category, failure.category.Hint(failure.statusCode))
+	}
+}
+
+// formatSimulationStatusCode formats the HTTP status of a simulated delivery,
+// which is zero when no response was received
+func formatSimulationStatusCode(code int) string {
+	if code == 0 {
+		return "-"
+	}
+	return formatInt(code)
+}
+
+// formatSimulationDuration formats the round trip of a simulated delivery
+func formatSimulationDuration(ms int64) string {
+	return fmt.Sprintf("%dms", ms)
+}
+
+// maxOverrideValueLength caps each value in the override summary; the full
+// payload is in the JSON output
+const maxOverrideValueLength = 60
+
+// formatOverride summarizes one change made by a payload override
+func formatOverride(change jsonmerge.Change) string {
+	return fmt.Sprintf("%s: %s → %s", change.Path, formatOverrideValue(change.Before), formatOverrideValue(change.After))
+}
+
+// formatOverrideValue writes a value of an override summary as JSON,
+// shortened when long
+func formatOverrideValue(value interface{}) string {
+	if value == nil {
+		return "unset"
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return truncateText(string(encoded), maxOverrideValueLength)
+}
+
+// writeTriggerOverrides lists what the overrides changed in each event
+func writeTriggerOverrides(w io.Writer, trigger *WebhookTrigger) {
+	for _, event := range trigger.Events {
+		if len(event.Overrides) == 0 {
+			fmt.Fprintf(w, "Overrides for %s: none changed the generated payload\n\n", event.Type)
+			continue
+		}
+		fmt.Fprintf(w, "Overrides for %s:\n", event.Type)
+		for _, change := range event.Overrides {
+			fmt.Fprintf(w, "  %s\n", formatOverride(change))
+		}
+		fmt.Fprintf(w, "\n")
+	}
+}
+
+// formatTriggerSummary reports how many triggered events were accepted
+func formatTriggerSummary(trigger *WebhookTrigger) string {
+	delivered := len(trigger.Events) - trigger.Failed()
+	return fmt.Sprintf("Delivered %d of %d events with overrides to %s", delivered, len(trigger.Events), trigger.URL)
+}
+
+// writeSimulatedRequests writes signed simulated events as the HTTP requests
+// that would deliver them, so they can be replayed against a receiver
+func writeSimulatedRequests(w io.Writer, simulation *WebhookSimulation) {
+	for _, event := range simulation.Events {
+		fmt.Fprintf(w, "POST %s\n", simulation.URL)
+		fmt.Fprintf(w, "Content-Type: application/json\n")
+		fmt.Fprintf(w, "webhook-id: %s\n", event.MsgID)
+		fmt.Fprintf(w, "webhook-timestamp: %d\n", event.SignedAt)
+		fmt.Fprintf(w, "webhook-signature: %s\n", event.Signature)
+		fmt.Fprintf(w, "\n%s\n\n", event.Payload)
+	}
+}
+
+// formatReminderStatus describes whether a reminder is due or how long until it is
+func formatReminderStatus(reminder state.Reminder, now time.Time) string {
+	if reminder.Due(now) {
+		return "due"
+	}
+	remaining := reminder.DueAt.Sub(now)
+	if remaining >= 24*time.Hour {
+		return fmt.Sprintf("in %dd", int(remaining/(24*time.Hour)))
+	}
+	return "in " + remaining.Round(time.Minute).String()
+}
+
+// maxFailedRecipientsShown caps the failed recipients listed above the
+// table; the full list is in the JSON output
+const maxFailedRecipientsShown = 10
+
+// CreateMessageSummary counts the per-recipient outcomes of a create
+// message call. The API accepts the call as a whole even when some
+// recipients are rejected, so each entry has to be checked.
+type CreateMessageSummary struct {
+	Succeeded int                                     `json:"succeeded"`
+	Failed    int                                     `json:"failed"`
+	Rejected  []responses.CreateSingleMessageResponse `json:"-"`
+}
+
+// Total returns the number of recipients in the call
+func (s CreateMessageSummary) Total() int {
+	return s.Succeeded + s.Failed
+}
+
+// SummarizeCreateMessage counts the succeeded and failed recipients of a
+// create message response
+func SummarizeCreateMessage(response *responses.CreateMessageResponse) CreateMessageSummary {
+	var summary CreateMessageSummary
+	if response == nil {
+		return summary
+	}
+	for _, messageData := range response.Data {
+		if recipientFailed(messageData) {
+			summary.Failed++
+			summary.Rejected = append(summary.Rejected, messageData)
+		} else {
+			summary.Succeeded++
+		}
+	}
+	return summary
+}
+
+// recipientFailed reports whether the API rejected a single recipient
+func recipientFailed(messageData responses.CreateSingleMessageResponse) bool {
+	if messageData.Error != nil && *messageData.Error != "" {
+		return true
+	}
+	return strings.EqualFold(messageData.Status, "failed")
+}
+
+// formatCreateMessageHeadline describes the outcome of a create message call,
+// using the configured success message only when every recipient succeeded
+func formatCreateMessageHeadline(summary CreateMessageSummary, config CreateConfig) string {
+	switch {
+	case summary.Failed == 0:
+		return config.SuccessMessage
+	case summary.Succeeded == 0:
+		return fmt.Sprintf("No messages were sent: all %d recipients failed", summary.Failed)
+	default:
+		return fmt.Sprintf("Partially sent: %d succeeded, %d failed", summary.Succeeded, summary.Failed)
+	}
+}
+
+// formatRejectedRecipient describes a failed recipient on one line
+func formatRejectedRecipient(messageData responses.CreateSingleMessageResponse) string {
+	reason := messageData.Status
+	if messageData.Error != nil && *messageData.Error != "" {
+		reason = *messageData.Error
+	}
+	return fmt.Sprintf("%s: %s", messageData.Recipient.Email, reason)
+}
+
+// formatBounceExplanation renders a classification's explanation and
+// recommended action on one line, or "" when the classification is unknown
+func formatBounceExplanation(classification *string) string {
+	if classification == nil {
+		return ""
+	}
+	description, action := explainBounce(*classification)
+	if description == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s. %s.", description, action)
+}
+
+// bounceTotal is the number of bounces of one classification over a whole
+// range, with its share of all bounces in percent
+type bounceTotal struct {
+	Classification string
+	Count          int
+	Percentage     float64
+}
+
+// aggregateBounces sums the bounces of every time bucket per
+// classification, most frequent first. It also returns the range the
+// buckets cover and the total number of bounces.
+func aggregateBounces(data []responses.BounceStatistics) (totals []bounceTotal, from, to time.Time, total int) {
+	counts := make(map[string]int)
+	for i, stat := range data {
+		if i == 0 || stat.FromTimestamp.Before(from) {
+			from = stat.FromTimestamp
+		}
+		if stat.ToTimestamp.After(to) {
+			to = stat.ToTimestamp
+		}
+		for _, bounce := range stat.Bounces {
+			counts[bounce.Classification] += bounce.Count
+			total += bounce.Count
+		}
+	}
+
+	for classification, count := range counts {
+		if count == 0 {
+			continue
+		}
+		totals = append(totals, bounceTotal{
+			Classification: classification,
+			Count:          count,
+			Percentage:     float64(count) / float64(total) * 100,
+		})
+	}
+	sort.Slice(totals, func(i, j int) bool {
+		if totals[i].Count != totals[j].Count {
+			return totals[i].Count > totals[j].Count
+		}
+		return totals[i].Classification < totals[j].Classification
+	})
+	return totals, from, to, total
+}
+
+// explainBounce returns a classification's description and recommended
+// action, empty when the classification is unknown
+func explainBounce(classification string) (description, action string) {
+	explanation, ok := bounces.Explain(classification)
+	if !ok {
+		return "", ""
+	}
+	return explanation.Description, explanation.Action
+}
+
+// patternsForRoute returns the expanded patterns of one route
+func patternsForRoute(patterns []ExpandedPattern, routeID uuid.UUID) []ExpandedPattern {
+	var matching []ExpandedPattern
+	for _, pattern := range patterns {
+		if pattern.RouteID == routeID {
+			matching = append(matching, pattern)
+		}
+	}
+	return matching
+}
+
+// formatExpandedPattern renders a pattern with its status unless it is
+// active, e.g. "support@old.example.com (orphaned)"
+func formatExpandedPattern(pattern ExpandedPattern) string {
+	if pattern.Status == PatternActive {
+		return pattern.Pattern
+	}
+	return fmt.Sprintf("%s (%s)", pattern.Pattern, pattern.Status)
+}
+
+// orphanedPatternsWarning returns a warning about patterns whose domain is
+// not in the account, or "" when there are none
+func orphanedPatternsWarning(patterns []ExpandedPattern) string {
+	orphaned := 0
+	for _, pattern := range patterns {
+		if pattern.Status == PatternOrphaned {
+			orphaned++
+		}
+	}
+	switch orphaned {
+	case 0:
+		return ""
+	case 1:
+		return "⚠️  1 pattern points at a domain that is not in this account; mail to it is dropped"
+	default:
+		return fmt.Sprintf("⚠️  %d patterns point at domains that are not in this account; mail to them is dropped", orphaned)
+	}
+}
+
+// formatExpandedPatterns joins patterns into one value, e.g. for a CSV cell
+func formatExpandedPatterns(patterns []ExpandedPattern) string {
+	formatted := make([]string, len(patterns))
+	for i, pattern := range patterns {
+		formatted[i] = formatExpandedPattern(pattern)
+	}
+	return strings.Join(formatted, "; ")
+}
+
+// SMTP credential usage states
+const (
+	smtpUsageActive      = "active"      // messages were sent in the range
+	smtpUsageUnused      = "unused"      // no messages in the range
+	smtpUsageNew         = "new"         // no messages, but created during the range
+	smtpUsageUnavailable = "unavailable" // statistics could not be fetched
+)
+
+// smtpUsageApproximationNote labels SMTP usage counts as approximations
+const smtpUsageApproximationNote = "Approximation: the API does not report usage per SMTP credential. Counts are messages sent from each credential's domains; global credentials show all account messages."
+
+// smtpUsageStatus returns the usage state of a credential
+func smtpUsageStatus(credential SMTPCredentialUsage) string {
+	switch {
+	case credential.Error != "":
+		return smtpUsageUnavailable
+	case credential.Unused:
+		return smtpUsageUnused
+	case credential.Messages == 0:
+		return smtpUsageNew
+	}
+	return smtpUsageActive
+}
+
+// formatSMTPUsageDomains shows the domains usage was counted from
+func formatSMTPUsageDomains(credential SMTPCredentialUsage) string {
+	if credential.Attribution == SMTPUsageByAccount {
+		return "(all)"
+	}
+	return formatDomainNames(credential.Domains)
+}
+
+// writeSMTPUsageErrors lists the credentials whose statistics could not be
+// fetched
+func writeSMTPUsageErrors(w io.Writer, report *SMTPUsageReport) {
+	for _, credential := range report.Credentials {
+		if credential.Error != "" {
+			fmt.Fprintf(w, "\n⚠️  Usage of '%s' is unavailable: %s\n", credential.Name, credential.Error)
+		}
+	}
+}
+
+// writeRouteExplanationHeader names the explained route
+func writeRouteExplanationHeader(w io.Writer, explanation *RouteExplanation) {
+	fmt.Fprintf(w, "Route: %s (%s)\n", explanation.Name, explanation.Source)
+	fmt.Fprintf(w, "URL: %s\n", explanation.URL)
+	recipient := explanation.Recipient
+	if recipient == "" {
+		recipient = "(any)"
+	}
+	fmt.Fprintf(w, "Recipient Filter: %s\n", recipient)
+}
+
+// writeRoutePayload lists the payload fields a route includes and leaves
+// out, followed by the example payload
+func writeRoutePayload(w io.Writer, explanation *RouteExplanation) error {
+	var present, absent []string
+	for _, field := range explanation.Fields {
+		if field.Present {
+			present = append(present, field.Name)
+		} else {
+			absent = append(absent, fmt.Sprintf("%s (needs %s)", field.Name, field.Option))
+		}
+	}
+	fmt.Fprintf(w, "Payload fields: %s\n", strings.Join(present, ", "))
+	if len(absent) > 0 {
+		fmt.Fprintf(w, "Left out: %s\n", strings.Join(absent, ", "))
+	}
+
+	fmt.Fprintf(w, "\nExample %s payload:\n", webhooks.RouteEventName)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(explanation.ExamplePayload); err != nil {
+		return fmt.Errorf("failed to encode example payload: %w", err)
+	}
+	return nil
+}
+
+// accountField is one setting of an account: its CSV column, its label in
+// table and plain output, and its value, empty when the API did not return it
+type accountField struct {
+	Key   string
+	Label string
+	Value string
+}
+
+// accountFields lists the settings of an account in display order
+func accountFields(account *responses.Account) []accountField {
+	optionalBool := func(b *bool) string {
+		if b == nil {
+			return ""
+		}
+		return formatBooleanStatus(*b)
+	}
+	optionalDays := func(days *int32) string {
+		if days == nil {
+			return ""
+		}
+		return formatInt(int(*days))
+	}
+	parentAccountID := ""
+	if account.ParentAccountID != nil {
+		parentAccountID = formatUUID(*account.ParentAccountID)
+	}
+
+	return []accountField{
+		{"id", "ID", formatUUID(account.ID)},
+		{"name", "Name", account.Name},
+		{"parent_account_id", "Parent Account ID", parentAccountID},
+		{"website", "Website", formatOptionalString(account.Website)},
+		{"about", "About", formatOptionalString(account.About)},
+		{"track_opens", "Track Opens", optionalBool(account.TrackOpens)},
+		{"track_clicks", "Track Clicks", optionalBool(account.TrackClicks)},
+		{"reject_bad_recipients", "Reject Bad Recipients", optionalBool(account.RejectBadRecipients)},
+		{"reject_mistyped_recipients", "Reject Mistyped Recipients", optionalBool(account.RejectMistypedRecipients)},
+		{"message_metadata_retention", "Message Metadata Retention (days)", optionalDays(account.MessageMetadataRetention)},
+		{"message_data_retention", "Message Data Retention (days)", optionalDays(account.MessageDataRetention)},
+		{"owner_id", "Owner ID", formatUUID(account.OwnerID)},
+		{"created_at", "Created", formatTime(account.CreatedAt)},
+		{"updated_at", "Updated", formatTime(account.UpdatedAt)},
+	}
+}
@@ -0,0 +1,131 @@
+package printer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestWebhookStatsResponse() *responses.PaginatedWebhooksResponse {
+	recent := time.Date(2026, 6, 2, 12, 0, 0, 0, time.UTC)
+	older := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	return &responses.PaginatedWebhooksResponse{
+		Object: "list",
+		Data: []responses.Webhook{
+			{ID: uuid.New(), Name: "orders", URL: "https://example.com/orders", SuccessCount: 90, ErrorCount: 10, ErrorsSinceLastSuccess: 2, LastRequestAt: &older},
+			{ID: uuid.New(), Name: "billing", URL: "https://example.com/billing", SuccessCount: 10, ErrorCount: 0, LastRequestAt: &recent},
+		},
+	}
+}
+
+func TestSummarizeWebhookStats(t *testing.T) {
+	summary := summarizeWebhookStats(newTestWebhookStatsResponse().Data)
+
+	assert.Equal(t, 2, summary.Webhooks)
+	assert.Equal(t, uint64(100), summary.Success)
+	assert.Equal(t, uint64(10), summary.Errors)
+	assert.InDelta(t, 9.09, summary.ErrorRate, 0.01)
+	require.NotNil(t, summary.LastRequest)
+	assert.Equal(t, 2, summary.LastRequest.Day())
+
+	assert.Equal(t, 0.0, summarizeWebhookStats(nil).ErrorRate)
+}
+
+func TestWebhookList_IncludeStats(t *testing.T) {
+	config := ListConfig{SuccessMessage: "Webhooks", IncludeStats: true}
+
+	t.Run("table", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, GetResponseHandler("table", false, &buf).HandleWebhookList(newTestWebhookStatsResponse(), config))
+		out := buf.String()
+		for _, header := range []string{"SUCCESS", "ERRORS", "ERROR STREAK", "LAST REQUEST"} {
+			assert.Contains(t, strings.ToUpper(out), header)
+		}
+		assert.Equal(t, 1, strings.Count(out, "TOTAL"), "totals are shown once, as the last row")
+		assert.Contains(t, out, "9.09%")
+		assert.NotContains(t, out, "Totals across")
+	})
+
+	t.Run("plain", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, GetResponseHandler("plain", false, &buf).HandleWebhookList(newTestWebhookStatsResponse(), config))
+		out := buf.String()
+		assert.Contains(t, out, "Error Streak: 2")
+		assert.Contains(t, out, "Totals (2 webhooks):")
+		assert.Contains(t, out, "Error Rate: 9.09%")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, GetResponseHandler("json", false, &buf).HandleWebhookList(newTestWebhookStatsResponse(), config))
+
+		var decoded map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		assert.Len(t, decoded["data"], 2)
+		summary := decoded["summary"].(map[string]interface{})
+		assert.Equal(t, float64(100), summary["success_count"])
+		assert.Equal(t, float64(10), summary["error_count"])
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, GetResponseHandler("csv", false, &buf).HandleWebhookList(newTestWebhookStatsResponse(), config))
+
+		records, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 4, "header, two webhooks, totals")
+		assert.Contains(t, records[0], "success")
+		assert.Contains(t, records[0], "error_streak")
+		assert.Equal(t, "TOTAL", records[3][0])
+	})
+}
+
+func TestWebhookList_CSVWithoutStatsKeepsColumns(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, GetResponseHandler("csv", false, &buf).HandleWebhookList(newTestWebhookStatsResponse(), ListConfig{}))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"name", "id", "url", "enabled", "events", "created_at", "updated_at"}, records[0])
+	assert.Len(t, records, 3)
+}
+
+func TestCreateWebhook_Quiet(t *testing.T) {
+	webhook := &responses.Webhook{ID: uuid.New(), Name: "orders", URL: "https://example.com/orders", Secret: "whsec_quiet"}
+	config := CreateConfig{SuccessMessage: "Successfully created webhook: orders"}
+
+	for _, format := range []string{"table", "plain"} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			handler := GetResponseHandler(format, false, &buf)
+			handler.SetQuiet(true)
+			require.NoError(t, handler.HandleCreateWebhook(webhook, config))
+			out := buf.String()
+			assert.Contains(t, out, "whsec_quiet")
+			assert.NotContains(t, out, "Successfully created")
+			assert.NotContains(t, out, "⚠️")
+			assert.NotContains(t, out, "Security Note")
+
+			buf.Reset()
+			require.NoError(t, handler.HandleSimpleSuccess("Webhook deletion cancelled"))
+			assert.Empty(t, buf.String())
+		})
+	}
+
+	t.Run("json", func(t *testing.T) {
+		var quiet, loud bytes.Buffer
+		handler := GetResponseHandler("json", false, &quiet)
+		handler.SetQuiet(true)
+		require.NoError(t, handler.HandleSimpleSuccess("Done"))
+		require.NoError(t, GetResponseHandler("json", false, &loud).HandleSimpleSuccess("Done"))
+		assert.Equal(t, loud.String(), quiet.String())
+	})
+}
@@ -3,31 +3,48 @@
 // This package implements intelligent progress reporting with:
 //
 //   - TTY detection for appropriate progress bar display
-//   - Debug mode compatibility (text updates instead of progress bars)
+//   - Log output printed above the progress bar instead of through it
+//   - Debug mode text updates when no progress bar is shown
+//   - Periodic JSON lines for scripts (--progress-format json)
 //   - Real-time performance metrics (emails/second, success rate, ETA)
 //   - Automatic fallback for non-interactive environments
 //   - Performance statistics collection and reporting
 //   - Integration with logging system for debug information
 //
 // The Reporter automatically adapts its output based on the environment,
-// showing progress bars in interactive terminals and periodic text updates
-// in non-interactive or debug modes.
+// showing progress bars in interactive terminals and, in debug mode,
+// periodic text updates elsewhere. With --progress-format json it writes a
+// JSON object per line instead, every --progress-interval, ending with a line
+// marked "complete": true that carries the final totals.
 package progress
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"os"
+	"sort"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
-	"golang.org/x/term"
+	"github.com/spf13/cobra"
+
+	"github.com/AhaSend/ahasend-cli/internal/errors"
 
 	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
 )
 
-// Reporter handles progress reporting for batch operations
+// Reporter handles progress reporting for batch operations.
+//
+// While the progress bar is shown the reporter owns the terminal line: log
+// output is routed through it and, like Println, printed above the bar,
+// which is then redrawn. Its methods are safe for concurrent use.
 type Reporter struct {
+	mu         sync.Mutex
 	enabled    bool
 	debugMode  bool
 	total      int
@@ -35,7 +52,73 @@ type Reporter struct {
 	failed     int
 	startTime  time.Time
 	lastUpdate time.Time
+	draining   bool
 	output     io.Writer
+
+	barWidth   int    // width of the bar on the current line, 0 when none is drawn
+	pending    []byte // log output written since the last complete line
+	restoreLog func() // restores the log output redirected by Start
+
+	jsonInterval time.Duration // interval between JSON lines, 0 unless rendering JSON
+	stopJSON     chan struct{} // closed by Finish to stop the JSON ticker
+	jsonDone     chan struct{} // closed when the JSON ticker has stopped
+
+	countNoun  string // what is counted in the lines printed every countEvery results
+	countEvery int
+}
+
+// Progress formats accepted by --progress-format
+const (
+	FormatBar  = "bar"
+	FormatJSON = "json"
+)
+
+// DefaultInterval is how often JSON progress lines are written by default
+const DefaultInterval = 5 * time.Second
+
+// Options are the progress flags of a command
+type Options struct {
+	Format   string
+	Interval time.Duration
+}
+
+// OptionsFromCommand reads --progress-format and --progress-interval,
+// falling back to the defaults for commands without them
+func OptionsFromCommand(cmd *cobra.Command) Options {
+	options := Options{Format: FormatBar, Interval: DefaultInterval}
+	if format, err := cmd.Flags().GetString("progress-format"); err == nil && format != "" {
+		options.Format = strings.ToLower(format)
+	}
+	if interval, err := cmd.Flags().GetDuration("progress-interval"); err == nil {
+		options.Interval = interval
+	}
+	return options
+}
+
+// ValidateFlags checks the progress flags of a command
+func ValidateFlags(cmd *cobra.Command) error {
+	options := OptionsFromCommand(cmd)
+	if options.Format != FormatBar && options.Format != FormatJSON {
+		return errors.NewValidationError(fmt.Sprintf("invalid --progress-format %q: expected bar or json", options.Format), nil)
+	}
+	if options.Interval <= 0 {
+		return errors.NewValidationError(fmt.Sprintf("invalid --progress-interval %s: must be positive", options.Interval), nil)
+	}
+	return nil
+}
+
+// JSON reports whether JSON progress lines were asked for
+func (o Options) JSON() bool {
+	return o.Format == FormatJSON
+}
+
+// Reporter returns a JSON reporter writing to output when the options ask
+// for JSON progress lines, and fallback otherwise
+func (o Options) Reporter(total int, output io.Writer, fallback *Reporter) *Reporter {
+	if o.JSON() {
+		return NewJSONReporter(total, output, o.Interval)
+	}
+	return fallback
 }
 
 // Stats holds performance metrics
@@ -43,39 +126,100 @@ type Stats struct {
 	Total        int           `json:"total"`
 	Sent         int           `json:"sent"`
 	Failed       int           `json:"failed"`
+	Skipped      int           `json:"skipped,omitempty"` // recipients of batches sent before a resume
 	SuccessRate  float64       `json:"success_rate"`
 	Duration     time.Duration `json:"duration"`
 	EmailsPerSec float64       `json:"emails_per_sec"`
+
+	// Connection reuse, filled in by callers that track it
+	NewConnections    int64 `json:"new_connections,omitempty"`
+	ReusedConnections int64 `json:"reused_connections,omitempty"`
+	TLSHandshakes     int64 `json:"tls_handshakes,omitempty"`
+
+	// Failed API calls and retried attempts by error category (permanent,
+	// rate_limited or transient), filled in by the batch processor
+	Errors  map[string]int `json:"errors,omitempty"`
+	Retries map[string]int `json:"retries,omitempty"`
 }
 
-// NewReporter creates a new progress reporter
+// NewReporter creates a new progress reporter writing to stderr. The
+// progress bar is shown when showProgress is set and stderr is a terminal;
+// otherwise debug mode logs periodic updates instead.
 func NewReporter(total int, showProgress, debugMode bool) *Reporter {
-	enabled := showProgress && isTerminal() && !debugMode
-
-	if showProgress && debugMode {
-		logger.Debug("Progress bar disabled in debug mode, using periodic updates")
-	}
+	return NewReporterWithOutput(total, os.Stderr, showProgress && isTerminal(), debugMode)
+}
 
+// NewReporterWithOutput creates a progress reporter writing to output,
+// showing the progress bar when showBar is set
+func NewReporterWithOutput(total int, output io.Writer, showBar, debugMode bool) *Reporter {
 	return &Reporter{
-		enabled:   enabled,
+		enabled:   showBar,
 		debugMode: debugMode,
 		total:     total,
 		startTime: time.Now(),
-		output:    os.Stderr,
+		output:    output,
+	}
+}
+
+// NewJSONReporter creates a progress reporter writing a JSON object per line
+// to output every interval, instead of drawing a bar
+func NewJSONReporter(total int, output io.Writer, interval time.Duration) *Reporter {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return &Reporter{
+		total:        total,
+		startTime:    time.Now(),
+		output:       output,
+		jsonInterval: interval,
 	}
 }
 
-// Start initializes the progress reporter
+// NewCountReporter creates a progress reporter printing "Processed n/total
+// noun (f failed)" to output every every results and after the last one
+func NewCountReporter(total int, output io.Writer, noun string, every int) *Reporter {
+	return &Reporter{
+		total:      total,
+		startTime:  time.Now(),
+		output:     output,
+		countNoun:  noun,
+		countEvery: max(every, 1),
+	}
+}
+
+// Start initializes the progress reporter. With the progress bar shown or
+// JSON lines written, log output is routed through the reporter until
+// Finish.
 func (r *Reporter) Start() {
-	if r.enabled {
-		fmt.Fprintf(r.output, "Sending %d messages...\n", r.total)
-	} else if r.debugMode {
-		logger.Get().WithField("total_messages", r.total).Debug("Starting batch send operation")
+	if r.jsonInterval > 0 {
+		r.startJSON()
+		return
+	}
+	if !r.enabled {
+		if r.debugMode {
+			logger.Get().WithField("total_messages", r.total).Debug("Starting batch send operation")
+		}
+		return
 	}
+
+	r.mu.Lock()
+	fmt.Fprintf(r.output, "Sending %d messages...\n", r.total)
+	r.mu.Unlock()
+
+	r.restoreLog = logger.Get().RedirectOutput(logWriter{r})
+}
+
+// Println prints a message on its own line. While the progress bar is
+// drawn the message goes above it and the bar is redrawn below.
+func (r *Reporter) Println(message string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.printLine(message)
 }
 
 // Update reports progress for a single message result
 func (r *Reporter) Update(success bool) {
+	r.mu.Lock()
 	if success {
 		r.sent++
 	} else {
@@ -83,28 +227,87 @@ func (r *Reporter) Update(success bool) {
 	}
 
 	now := time.Now()
+	completed := r.sent + r.failed
+
+	if r.jsonInterval > 0 {
+		r.mu.Unlock()
+		return
+	}
+	if r.countEvery > 0 {
+		if completed%r.countEvery == 0 || completed == r.total {
+			fmt.Fprintf(r.output, "Processed %d/%d %s (%d failed)\n", completed, r.total, r.countNoun, r.failed)
+		}
+		r.mu.Unlock()
+		return
+	}
 
 	if r.enabled {
 		// Update progress bar
 		r.updateProgressBar(now)
-	} else if r.debugMode {
-		// Periodic debug updates (every 10 messages or every 5 seconds)
-		completed := r.sent + r.failed
-		if completed%10 == 0 || now.Sub(r.lastUpdate) > 5*time.Second {
-			logger.Get().WithFields(map[string]interface{}{
-				"completed":  completed,
-				"total":      r.total,
-				"sent":       r.sent,
-				"failed":     r.failed,
-				"percentage": int(float64(completed) / float64(r.total) * 100),
-			}).Debug("Batch progress update")
-			r.lastUpdate = now
-		}
+		r.mu.Unlock()
+		return
+	}
+
+	// Periodic debug updates (every 10 messages or every 5 seconds)
+	logUpdate := r.debugMode && (completed%10 == 0 || now.Sub(r.lastUpdate) > 5*time.Second)
+	if logUpdate {
+		r.lastUpdate = now
+	}
+	sent, failed := r.sent, r.failed
+	r.mu.Unlock()
+
+	if logUpdate {
+		logger.Get().WithFields(map[string]interface{}{
+			"completed":  completed,
+			"total":      r.total,
+			"sent":       sent,
+			"failed":     failed,
+			"percentage": int(float64(completed) / float64(r.total) * 100),
+		}).Debug("Batch progress update")
+	}
+}
+
+// Draining switches the reporter into the draining state after the operation
+// was cancelled and only in-flight requests are still being awaited
+func (r *Reporter) Draining() {
+	r.mu.Lock()
+	if r.draining {
+		r.mu.Unlock()
+		return
+	}
+	r.draining = true
+	completed := r.sent + r.failed
+
+	if r.jsonInterval > 0 {
+		r.writeJSONLine(time.Now(), false)
+	}
+	if r.enabled {
+		r.clearProgressBar()
+		fmt.Fprintf(r.output, "Interrupted: waiting for in-flight requests to finish (press Ctrl-C again to exit immediately)\n")
+		r.lastUpdate = time.Time{}
+	}
+	r.mu.Unlock()
+
+	if !r.enabled && r.debugMode {
+		logger.Get().WithFields(map[string]interface{}{
+			"completed": completed,
+			"total":     r.total,
+		}).Debug("Batch interrupted, draining in-flight requests")
 	}
 }
 
 // Finish completes the progress reporting and returns stats
 func (r *Reporter) Finish() Stats {
+	// Hand the log output back before the final line
+	if r.restoreLog != nil {
+		r.restoreLog()
+		r.restoreLog = nil
+	}
+	r.stopJSONTicker()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	duration := time.Since(r.startTime)
 	completed := r.sent + r.failed
 	successRate := 0.0
@@ -127,10 +330,24 @@ func (r *Reporter) Finish() Stats {
 		EmailsPerSec: emailsPerSec,
 	}
 
+	if r.jsonInterval > 0 {
+		if len(r.pending) > 0 {
+			r.writeJSON(jsonMessage{Message: string(r.pending)})
+			r.pending = nil
+		}
+		r.writeJSONLine(r.startTime.Add(duration), true)
+	}
 	if r.enabled {
 		// Clear progress bar and show final result
 		r.clearProgressBar()
-		if r.failed == 0 {
+		if len(r.pending) > 0 {
+			fmt.Fprintf(r.output, "%s\n", r.pending)
+			r.pending = nil
+		}
+		if r.draining {
+			fmt.Fprintf(r.output, "⚠ Interrupted after sending %d/%d messages (%d failed) (%.1fs)\n",
+				r.sent, r.total, r.failed, duration.Seconds())
+		} else if r.failed == 0 {
 			fmt.Fprintf(r.output, "✓ Successfully sent %d/%d messages (%.1fs)\n",
 				r.sent, r.total, duration.Seconds())
 		} else {
@@ -142,14 +359,156 @@ func (r *Reporter) Finish() Stats {
 	return stats
 }
 
-// updateProgressBar renders the progress bar
+// logWriter routes log output through the reporter a line at a time
+type logWriter struct {
+	reporter *Reporter
+}
+
+func (w logWriter) Write(p []byte) (int, error) {
+	r := w.reporter
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pending = append(r.pending, p...)
+	for {
+		newline := strings.IndexByte(string(r.pending), '\n')
+		if newline < 0 {
+			break
+		}
+		r.printLine(string(r.pending[:newline]))
+		r.pending = r.pending[newline+1:]
+	}
+	return len(p), nil
+}
+
+// printLine prints a line above the progress bar, if one is drawn, and
+// redraws the bar. When writing JSON lines it is written as a message
+// object instead, so every line stays parseable. The caller must hold r.mu.
+func (r *Reporter) printLine(line string) {
+	if r.jsonInterval > 0 {
+		r.writeJSON(jsonMessage{Message: strings.TrimSuffix(line, "\n")})
+		return
+	}
+	redraw := r.barWidth > 0
+	r.clearProgressBar()
+	fmt.Fprintf(r.output, "%s\n", strings.TrimSuffix(line, "\n"))
+	if redraw {
+		r.drawProgressBar(time.Now())
+	}
+}
+
+// jsonProgress is a JSON progress line. The final line is marked Complete
+// and adds the elapsed time and the number of successes.
+type jsonProgress struct {
+	Done       int     `json:"done"`
+	Total      int     `json:"total"`
+	Rate       float64 `json:"rate"`
+	ETASeconds *int    `json:"eta_seconds"`
+	Failed     int     `json:"failed"`
+	Draining   bool    `json:"draining,omitempty"`
+
+	Succeeded      *int     `json:"succeeded,omitempty"`
+	ElapsedSeconds *float64 `json:"elapsed_seconds,omitempty"`
+	Interrupted    bool     `json:"interrupted,omitempty"`
+	Complete       bool     `json:"complete,omitempty"`
+}
+
+// jsonMessage carries a message or log line while JSON lines are written
+type jsonMessage struct {
+	Message string `json:"message"`
+}
+
+// startJSON writes the first JSON line and starts the ticker writing the
+// others
+func (r *Reporter) startJSON() {
+	r.mu.Lock()
+	r.writeJSONLine(time.Now(), false)
+	r.stopJSON = make(chan struct{})
+	r.jsonDone = make(chan struct{})
+	r.mu.Unlock()
+
+	r.restoreLog = logger.Get().RedirectOutput(logWriter{r})
+
+	go func() {
+		defer close(r.jsonDone)
+		ticker := time.NewTicker(r.jsonInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-r.stopJSON:
+				return
+			case now := <-ticker.C:
+				r.mu.Lock()
+				r.writeJSONLine(now, false)
+				r.mu.Unlock()
+			}
+		}
+	}()
+}
+
+// stopJSONTicker stops the JSON ticker, if one runs, and waits for it
+func (r *Reporter) stopJSONTicker() {
+	if r.stopJSON == nil {
+		return
+	}
+	close(r.stopJSON)
+	<-r.jsonDone
+	r.stopJSON = nil
+}
+
+// writeJSONLine writes the progress as of now. The caller must hold r.mu.
+func (r *Reporter) writeJSONLine(now time.Time, final bool) {
+	completed := r.sent + r.failed
+	elapsed := now.Sub(r.startTime).Seconds()
+	line := jsonProgress{
+		Done:     completed,
+		Total:    r.total,
+		Failed:   r.failed,
+		Draining: r.draining && !final,
+	}
+	if elapsed > 0 {
+		line.Rate = math.Round(float64(completed)/elapsed*10) / 10
+	}
+	if completed > 0 && elapsed > 0 {
+		eta := 0
+		if completed < r.total {
+			eta = int(math.Round(float64(r.total-completed) * elapsed / float64(completed)))
+		}
+		line.ETASeconds = &eta
+	}
+	if final {
+		sent := r.sent
+		elapsed = math.Round(elapsed*1000) / 1000
+		line.Succeeded = &sent
+		line.ElapsedSeconds = &elapsed
+		line.Interrupted = r.draining
+		line.Complete = true
+	}
+	r.writeJSON(line)
+}
+
+// writeJSON writes value as a line of JSON. The caller must hold r.mu.
+func (r *Reporter) writeJSON(value interface{}) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(r.output, "%s\n", encoded)
+}
+
+// updateProgressBar renders the progress bar. The caller must hold r.mu.
 func (r *Reporter) updateProgressBar(now time.Time) {
 	// Only update every 100ms to avoid flicker
 	if now.Sub(r.lastUpdate) < 100*time.Millisecond {
 		return
 	}
 	r.lastUpdate = now
+	r.drawProgressBar(now)
+}
 
+// drawProgressBar draws the progress bar over the current line. The caller
+// must hold r.mu.
+func (r *Reporter) drawProgressBar(now time.Time) {
 	completed := r.sent + r.failed
 	percentage := float64(completed) / float64(r.total) * 100
 
@@ -173,25 +532,40 @@ func (r *Reporter) updateProgressBar(now time.Time) {
 	if r.failed > 0 {
 		stats = fmt.Sprintf(" (%d sent, %d failed)", r.sent, r.failed)
 	}
+	if r.draining {
+		eta = " draining…"
+	}
 
-	// Clear line and write progress
-	fmt.Fprintf(r.output, "\r[%s] %.1f%% (%d/%d)%s%s",
-		bar, percentage, completed, r.total, stats, eta)
+	// Overwrite the line, padding over what is left of a longer previous bar
+	line := fmt.Sprintf("[%s] %.1f%% (%d/%d)%s%s", bar, percentage, completed, r.total, stats, eta)
+	width := utf8.RuneCountInString(line)
+	padding := ""
+	if r.barWidth > width {
+		padding = strings.Repeat(" ", r.barWidth-width)
+	}
+	fmt.Fprintf(r.output, "\r%s%s", line, padding)
+	r.barWidth = width
 
 	// Add newline if complete
 	if completed >= r.total {
 		fmt.Fprintf(r.output, "\n")
+		r.barWidth = 0
 	}
 }
 
-// clearProgressBar clears the current progress bar line
+// clearProgressBar clears the progress bar line, if one is drawn. The
+// caller must hold r.mu.
 func (r *Reporter) clearProgressBar() {
-	fmt.Fprintf(r.output, "\r%s\r", strings.Repeat(" ", 80))
+	if r.barWidth == 0 {
+		return
+	}
+	fmt.Fprintf(r.output, "\r%s\r", strings.Repeat(" ", r.barWidth))
+	r.barWidth = 0
 }
 
 // isTerminal checks if we're running in an interactive terminal
 func isTerminal() bool {
-	return term.IsTerminal(int(os.Stderr.Fd()))
+	return printer.IsTerminal(os.Stderr)
 }
 
 // formatDuration formats a duration for display
@@ -217,7 +591,37 @@ func ShowMetrics(stats Stats, output io.Writer) {
 	if stats.Failed > 0 {
 		fmt.Fprintf(output, "   Failed: %d\n", stats.Failed)
 	}
+	if stats.Skipped > 0 {
+		fmt.Fprintf(output, "   Skipped (already sent): %d\n", stats.Skipped)
+	}
+	if len(stats.Errors) > 0 {
+		fmt.Fprintf(output, "   Failed calls by cause: %s\n", formatCategoryCounts(stats.Errors))
+	}
+	if len(stats.Retries) > 0 {
+		fmt.Fprintf(output, "   Retries by cause: %s\n", formatCategoryCounts(stats.Retries))
+	}
 	fmt.Fprintf(output, "   Success rate: %.1f%%\n", stats.SuccessRate)
 	fmt.Fprintf(output, "   Duration: %s\n", formatDuration(stats.Duration))
 	fmt.Fprintf(output, "   Performance: %.1f emails/sec\n", stats.EmailsPerSec)
+	if connections := stats.NewConnections + stats.ReusedConnections; connections > 0 {
+		fmt.Fprintf(output, "   Connections: %d new, %d reused (%.1f%% reused)\n", stats.NewConnections, stats.ReusedConnections,
+			float64(stats.ReusedConnections)/float64(connections)*100)
+		fmt.Fprintf(output, "   TLS handshakes: %d\n", stats.TLSHandshakes)
+	}
+}
+
+// formatCategoryCounts lists counts by category in name order, e.g.
+// "2 permanent, 1 transient"
+func formatCategoryCounts(counts map[string]int) string {
+	categories := make([]string, 0, len(counts))
+	for category := range counts {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	parts := make([]string, len(categories))
+	for i, category := range categories {
+		parts[i] = fmt.Sprintf("%d %s", counts[category], category)
+	}
+	return strings.Join(parts, ", ")
 }
@@ -0,0 +1,361 @@
+package progress
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+)
+
+// fakeTerminal keeps the lines a terminal would show for what is written to
+// it, applying \r and \n the way a terminal does
+type fakeTerminal struct {
+	mu    sync.Mutex
+	lines [][]rune
+	col   int
+}
+
+func (t *fakeTerminal) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.lines) == 0 {
+		t.lines = [][]rune{{}}
+	}
+	for _, r := range string(p) {
+		switch r {
+		case '\r':
+			t.col = 0
+		case '\n':
+			t.lines = append(t.lines, []rune{})
+			t.col = 0
+		default:
+			line := &t.lines[len(t.lines)-1]
+			if t.col < len(*line) {
+				(*line)[t.col] = r
+			} else {
+				*line = append(*line, r)
+			}
+			t.col++
+		}
+	}
+	return len(p), nil
+}
+
+// Screen returns the visible lines without trailing blanks
+func (t *fakeTerminal) Screen() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var screen []string
+	for _, line := range t.lines {
+		screen = append(screen, strings.TrimRight(string(line), " "))
+	}
+	for len(screen) > 0 && screen[len(screen)-1] == "" {
+		screen = screen[:len(screen)-1]
+	}
+	return screen
+}
+
+var barLine = regexp.MustCompile(`^\[[█░]{40}\] \d+\.\d%`)
+
+func TestReporter_PrintlnAboveBar(t *testing.T) {
+	terminal := &fakeTerminal{}
+	reporter := NewReporterWithOutput(3, terminal, true, false)
+	reporter.Start()
+	reporter.Update(true)
+
+	reporter.Println("Batch 2 (1 recipients) failed: timeout; retrying in 1s (attempt 2 of 4)")
+
+	screen := terminal.Screen()
+	require.Len(t, screen, 3)
+	assert.Equal(t, "Sending 3 messages...", screen[0])
+	assert.Equal(t, "Batch 2 (1 recipients) failed: timeout; retrying in 1s (attempt 2 of 4)", screen[1])
+	assert.Regexp(t, barLine, screen[2])
+	assert.Contains(t, screen[2], "(1/3)")
+
+	reporter.Update(true)
+	reporter.lastUpdate = time.Time{}
+	reporter.Update(true)
+	stats := reporter.Finish()
+	assert.Equal(t, 3, stats.Sent)
+
+	screen = terminal.Screen()
+	assert.Equal(t, "Batch 2 (1 recipients) failed: timeout; retrying in 1s (attempt 2 of 4)", screen[1])
+	assert.Contains(t, screen[2], "(3/3)")
+	assert.True(t, strings.HasPrefix(screen[len(screen)-1], "✓ Successfully sent 3/3 messages"))
+}
+
+func TestReporter_RoutesLogOutput(t *testing.T) {
+	terminal := &fakeTerminal{}
+	reporter := NewReporterWithOutput(10, terminal, true, false)
+	reporter.Start()
+	reporter.Update(true)
+
+	logger.Get().Warn("rate limit close")
+
+	screen := terminal.Screen()
+	require.Len(t, screen, 3)
+	assert.Contains(t, screen[1], "rate limit close")
+	assert.Regexp(t, barLine, screen[2])
+
+	reporter.Finish()
+	_, routed := logger.Get().Out.(logWriter)
+	assert.False(t, routed, "Finish hands the log output back")
+}
+
+func TestReporter_PartialLogWrites(t *testing.T) {
+	terminal := &fakeTerminal{}
+	reporter := NewReporterWithOutput(2, terminal, true, false)
+	reporter.Update(true)
+
+	writer := logWriter{reporter}
+	_, _ = writer.Write([]byte("first ha"))
+	_, _ = writer.Write([]byte("lf\nsecond\nunfinished"))
+
+	screen := terminal.Screen()
+	require.Len(t, screen, 3)
+	assert.Equal(t, []string{"first half", "second"}, screen[:2])
+	assert.Regexp(t, barLine, screen[2])
+
+	// What is left is printed when the reporter finishes
+	reporter.Finish()
+	assert.Contains(t, terminal.Screen(), "unfinished")
+}
+
+func TestReporter_ConcurrentOutput(t *testing.T) {
+	terminal := &fakeTerminal{}
+	const messages = 50
+	reporter := NewReporterWithOutput(messages, terminal, true, false)
+	reporter.Start()
+
+	var wg sync.WaitGroup
+	for i := 0; i < messages; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			reporter.Println(fmt.Sprintf("message %d", i))
+			logger.Get().Warn(fmt.Sprintf("log %d", i))
+			reporter.Update(true)
+		}(i)
+	}
+	wg.Wait()
+	reporter.Finish()
+
+	message := regexp.MustCompile(`^message \d+$`)
+	logLine := regexp.MustCompile(`log \d+`)
+	printed := 0
+	for _, line := range terminal.Screen() {
+		switch {
+		case message.MatchString(line):
+			printed++
+		case logLine.MatchString(line) && !strings.Contains(line, "█") && !strings.Contains(line, "message"):
+		case barLine.MatchString(line) && !strings.Contains(line, "message") && !strings.Contains(line, "log "):
+		case line == fmt.Sprintf("Sending %d messages...", messages), strings.HasPrefix(line, "✓ Successfully sent"):
+		default:
+			t.Errorf("interleaved output: %q", line)
+		}
+	}
+	assert.Equal(t, messages, printed)
+}
+
+func TestReporter_WithoutBar(t *testing.T) {
+	terminal := &fakeTerminal{}
+	reporter := NewReporterWithOutput(2, terminal, false, false)
+	reporter.Start()
+	reporter.Update(true)
+	reporter.Println("retrying")
+	reporter.Update(false)
+	stats := reporter.Finish()
+
+	assert.Equal(t, []string{"retrying"}, terminal.Screen())
+	assert.Equal(t, 1, stats.Failed)
+	assert.InDelta(t, 50.0, stats.SuccessRate, 0.01)
+}
+
+func TestShowMetrics_Connections(t *testing.T) {
+	var out bytes.Buffer
+	ShowMetrics(Stats{Total: 2, Sent: 2, SuccessRate: 100}, &out)
+	assert.NotContains(t, out.String(), "Connections")
+
+	out.Reset()
+	ShowMetrics(Stats{Total: 40, Sent: 40, SuccessRate: 100, NewConnections: 4, ReusedConnections: 36, TLSHandshakes: 4}, &out)
+	assert.Contains(t, out.String(), "Connections: 4 new, 36 reused (90.0% reused)")
+	assert.Contains(t, out.String(), "TLS handshakes: 4")
+}
+
+func TestShowMetrics_ErrorCategories(t *testing.T) {
+	var out bytes.Buffer
+	ShowMetrics(Stats{Total: 2, Sent: 2, SuccessRate: 100}, &out)
+	assert.NotContains(t, out.String(), "by cause")
+
+	out.Reset()
+	ShowMetrics(Stats{
+		Total: 40, Sent: 37, Failed: 3, SuccessRate: 92.5,
+		Errors:  map[string]int{"transient": 1, "permanent": 2},
+		Retries: map[string]int{"rate_limited": 4},
+	}, &out)
+	assert.Contains(t, out.String(), "Failed calls by cause: 2 permanent, 1 transient")
+	assert.Contains(t, out.String(), "Retries by cause: 4 rate_limited")
+}
+
+func TestShowMetrics_Skipped(t *testing.T) {
+	var out bytes.Buffer
+	ShowMetrics(Stats{Total: 2, Sent: 2, SuccessRate: 100}, &out)
+	assert.NotContains(t, out.String(), "Skipped")
+
+	out.Reset()
+	ShowMetrics(Stats{Total: 200, Sent: 200, Skipped: 300, SuccessRate: 100}, &out)
+	assert.Contains(t, out.String(), "Successfully sent: 200\n   Skipped (already sent): 300\n")
+}
+
+// lockedBuffer is a bytes.Buffer safe for the JSON ticker to write to
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// Lines parses every line written as a JSON object
+func (b *lockedBuffer) Lines(t *testing.T) []map[string]interface{} {
+	t.Helper()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var lines []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSuffix(b.buf.String(), "\n"), "\n") {
+		var decoded map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &decoded), line)
+		lines = append(lines, decoded)
+	}
+	return lines
+}
+
+func TestJSONReporter_PeriodicLines(t *testing.T) {
+	output := &lockedBuffer{}
+	reporter := NewJSONReporter(5, output, 10*time.Millisecond)
+	reporter.Start()
+	reporter.Update(true)
+	reporter.Update(false)
+	time.Sleep(35 * time.Millisecond)
+	reporter.Println("Batch 3 failed: timeout; retrying")
+	logger.Get().Warn("rate limit close")
+	reporter.Update(true)
+	reporter.Update(true)
+	reporter.Update(true)
+	stats := reporter.Finish()
+
+	lines := output.Lines(t)
+	require.GreaterOrEqual(t, len(lines), 5, "the first line, ticks, messages and the final line")
+
+	first := lines[0]
+	assert.Equal(t, map[string]interface{}{"done": 0.0, "total": 5.0, "rate": 0.0, "eta_seconds": nil, "failed": 0.0}, first)
+
+	var ticks, messages int
+	for _, line := range lines[1 : len(lines)-1] {
+		if message, ok := line["message"]; ok {
+			messages++
+			assert.NotContains(t, line, "done")
+			assert.True(t, message == "Batch 3 failed: timeout; retrying" || strings.Contains(message.(string), "rate limit close"), message)
+			continue
+		}
+		ticks++
+		assert.Equal(t, 5.0, line["total"])
+		assert.Equal(t, 1.0, line["failed"])
+		assert.GreaterOrEqual(t, line["done"], 2.0)
+		assert.Greater(t, line["rate"], 0.0)
+		assert.NotNil(t, line["eta_seconds"])
+		assert.NotContains(t, line, "complete")
+	}
+	assert.GreaterOrEqual(t, ticks, 2)
+	assert.Equal(t, 2, messages)
+
+	final := lines[len(lines)-1]
+	assert.Equal(t, true, final["complete"])
+	assert.Equal(t, float64(stats.Total), final["total"])
+	assert.Equal(t, float64(stats.Sent+stats.Failed), final["done"])
+	assert.Equal(t, float64(stats.Sent), final["succeeded"])
+	assert.Equal(t, float64(stats.Failed), final["failed"])
+	assert.Equal(t, 0.0, final["eta_seconds"])
+	assert.NotContains(t, final, "interrupted")
+
+	_, routed := logger.Get().Out.(logWriter)
+	assert.False(t, routed, "Finish hands the log output back")
+
+	size := len(output.Lines(t))
+	time.Sleep(30 * time.Millisecond)
+	assert.Len(t, output.Lines(t), size, "the ticker stops with Finish")
+}
+
+func TestJSONReporter_Interrupted(t *testing.T) {
+	output := &lockedBuffer{}
+	reporter := NewJSONReporter(4, output, time.Hour)
+	reporter.Start()
+	reporter.Update(true)
+	reporter.Draining()
+	stats := reporter.Finish()
+
+	lines := output.Lines(t)
+	require.Len(t, lines, 3)
+	assert.Equal(t, true, lines[1]["draining"])
+	assert.Equal(t, 1.0, lines[1]["done"])
+
+	final := lines[2]
+	assert.Equal(t, true, final["complete"])
+	assert.Equal(t, true, final["interrupted"])
+	assert.Equal(t, 1.0, final["done"])
+	assert.Equal(t, float64(stats.Sent), final["succeeded"])
+	assert.Equal(t, 4.0, final["total"])
+	assert.NotContains(t, final, "draining")
+}
+
+func TestCountReporter(t *testing.T) {
+	var output bytes.Buffer
+	reporter := NewCountReporter(5, &output, "suppressions", 2)
+	reporter.Start()
+	for i := 0; i < 5; i++ {
+		reporter.Update(i != 2)
+	}
+	stats := reporter.Finish()
+
+	assert.Equal(t, "Processed 2/5 suppressions (0 failed)\nProcessed 4/5 suppressions (1 failed)\nProcessed 5/5 suppressions (1 failed)\n", output.String())
+	assert.Equal(t, 4, stats.Sent)
+}
+
+func TestOptionsFromCommand(t *testing.T) {
+	newCommand := func(args ...string) *cobra.Command {
+		cmd := &cobra.Command{Use: "send"}
+		cmd.Flags().String("progress-format", FormatBar, "")
+		cmd.Flags().Duration("progress-interval", DefaultInterval, "")
+		require.NoError(t, cmd.ParseFlags(args))
+		return cmd
+	}
+
+	options := OptionsFromCommand(newCommand("--progress-format", "JSON", "--progress-interval", "2s"))
+	assert.Equal(t, Options{Format: FormatJSON, Interval: 2 * time.Second}, options)
+	assert.True(t, options.JSON())
+	assert.Equal(t, Options{Format: FormatBar, Interval: DefaultInterval}, OptionsFromCommand(&cobra.Command{}))
+
+	fallback := NewCountReporter(1, nil, "suppressions", 1)
+	assert.Same(t, fallback, OptionsFromCommand(newCommand()).Reporter(1, nil, fallback))
+	assert.Equal(t, 2*time.Second, options.Reporter(1, nil, fallback).jsonInterval)
+
+	assert.NoError(t, ValidateFlags(newCommand("--progress-format", "json")))
+	assert.ErrorContains(t, ValidateFlags(newCommand("--progress-format", "dots")), `invalid --progress-format "dots"`)
+	assert.ErrorContains(t, ValidateFlags(newCommand("--progress-interval", "0s")), "must be positive")
+}
@@ -0,0 +1,106 @@
+// Package sender resolves the From address for commands that send email.
+//
+// The address comes from the first of these that supplies one: the --from
+// flag, the selected profile's default_from, or an interactive prompt when
+// stdin is a terminal. Without any of them the send fails instead of
+// blocking on a prompt nobody can answer.
+package sender
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AhaSend/ahasend-cli/internal/config"
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+	"github.com/AhaSend/ahasend-cli/internal/printer"
+	"github.com/AhaSend/ahasend-cli/internal/validation"
+)
+
+// Source identifies where a sender address came from
+type Source string
+
+// Sender address sources, in resolution order
+const (
+	SourceFlag    Source = "flag"
+	SourceProfile Source = "profile"
+	SourcePrompt  Source = "prompt"
+)
+
+// stdinIsTerminal is replaced in tests
+var stdinIsTerminal = func() bool { return printer.IsTerminal(os.Stdin) }
+
+// Sender is a resolved sender address
+type Sender struct {
+	Address string
+	Source  Source
+	Profile string // profile that supplied the address, for SourceProfile
+}
+
+// Describe names the source of the address for display
+func (s Sender) Describe() string {
+	switch s.Source {
+	case SourceFlag:
+		return "from --from"
+	case SourceProfile:
+		return fmt.Sprintf("default_from of profile '%s'", s.Profile)
+	default:
+		return "entered at prompt"
+	}
+}
+
+// Resolve returns the sender for cmd, which must have a --from flag. prompt
+// asks for the address and is only called when stdin is a terminal.
+func Resolve(cmd *cobra.Command, prompt func() (string, error)) (*Sender, error) {
+	if from, _ := cmd.Flags().GetString("from"); from != "" {
+		return &Sender{Address: from, Source: SourceFlag}, nil
+	}
+
+	if address, profileName := profileDefaultFrom(cmd); address != "" {
+		// The value was validated by config set, but the file may have
+		// been edited by hand since
+		if err := validation.ValidateEmail(address); err != nil {
+			return nil, errors.NewConfigError(fmt.Sprintf(
+				"default_from of profile '%s' is not a valid email address. Fix it with: ahasend config set default-from you@yourdomain.com",
+				profileName), err)
+		}
+		return &Sender{Address: address, Source: SourceProfile, Profile: profileName}, nil
+	}
+
+	if !stdinIsTerminal() {
+		return nil, errors.NewValidationError(
+			"sender email is required: pass --from or set a default with: ahasend config set default-from you@yourdomain.com", nil)
+	}
+
+	address, err := prompt()
+	if err != nil {
+		return nil, errors.NewValidationError("failed to get sender email", err)
+	}
+	return &Sender{Address: address, Source: SourcePrompt}, nil
+}
+
+// profileDefaultFrom returns the default_from of the profile selected with
+// --profile, or of the default profile. A missing configuration or profile
+// means there is no default; the send then falls through to the prompt.
+func profileDefaultFrom(cmd *cobra.Command) (string, string) {
+	configMgr, err := config.NewManager()
+	if err != nil {
+		return "", ""
+	}
+	if err := configMgr.Load(); err != nil {
+		logger.Get().WithError(err).Debug("Could not load configuration for default_from")
+		return "", ""
+	}
+
+	profileName, _ := cmd.Flags().GetString("profile")
+	if profileName == "" {
+		profileName = configMgr.GetConfig().DefaultProfile
+	}
+	profile, exists := configMgr.GetConfig().Profiles[profileName]
+	if !exists {
+		return "", ""
+	}
+	return profile.DefaultFrom, profileName
+}
@@ -0,0 +1,101 @@
+package sender
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/config"
+)
+
+func newSendCommand(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{Use: "send"}
+	cmd.Flags().String("from", "", "")
+	cmd.Flags().String("profile", "", "")
+	require.NoError(t, cmd.ParseFlags(args))
+	return cmd
+}
+
+func setupProfiles(t *testing.T, defaultFrom, stagingFrom string) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	mgr, err := config.NewManager()
+	require.NoError(t, err)
+	require.NoError(t, mgr.Load())
+	require.NoError(t, mgr.SetProfile("default", config.Profile{APIKey: "key", AccountID: "id", DefaultFrom: defaultFrom}))
+	require.NoError(t, mgr.SetProfile("staging", config.Profile{APIKey: "key", AccountID: "id", DefaultFrom: stagingFrom}))
+}
+
+func withTerminal(t *testing.T, terminal bool) {
+	t.Helper()
+	prev := stdinIsTerminal
+	stdinIsTerminal = func() bool { return terminal }
+	t.Cleanup(func() { stdinIsTerminal = prev })
+}
+
+func noPrompt(t *testing.T) func() (string, error) {
+	return func() (string, error) {
+		t.Fatal("unexpected prompt")
+		return "", nil
+	}
+}
+
+func TestResolve_Precedence(t *testing.T) {
+	setupProfiles(t, "noreply@example.com", "staging@example.com")
+	withTerminal(t, true)
+
+	s, err := Resolve(newSendCommand(t, "--from", "me@example.com"), noPrompt(t))
+	require.NoError(t, err)
+	assert.Equal(t, Sender{Address: "me@example.com", Source: SourceFlag}, *s)
+
+	s, err = Resolve(newSendCommand(t), noPrompt(t))
+	require.NoError(t, err)
+	assert.Equal(t, "noreply@example.com", s.Address)
+	assert.Equal(t, SourceProfile, s.Source)
+	assert.Equal(t, "default_from of profile 'default'", s.Describe())
+
+	s, err = Resolve(newSendCommand(t, "--profile", "staging"), noPrompt(t))
+	require.NoError(t, err)
+	assert.Equal(t, "staging@example.com", s.Address)
+	assert.Equal(t, "staging", s.Profile)
+}
+
+func TestResolve_Prompt(t *testing.T) {
+	setupProfiles(t, "", "")
+
+	t.Run("terminal", func(t *testing.T) {
+		withTerminal(t, true)
+		s, err := Resolve(newSendCommand(t), func() (string, error) { return "typed@example.com", nil })
+		require.NoError(t, err)
+		assert.Equal(t, Sender{Address: "typed@example.com", Source: SourcePrompt}, *s)
+	})
+
+	t.Run("prompt failure", func(t *testing.T) {
+		withTerminal(t, true)
+		_, err := Resolve(newSendCommand(t), func() (string, error) { return "", errors.New("EOF") })
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get sender email")
+	})
+
+	t.Run("no terminal", func(t *testing.T) {
+		withTerminal(t, false)
+		_, err := Resolve(newSendCommand(t), noPrompt(t))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "ahasend config set default-from")
+	})
+}
+
+func TestResolve_InvalidConfiguredAddress(t *testing.T) {
+	// SetProfile stores the value as is, like a hand-edited config file
+	setupProfiles(t, "noreply@", "")
+	withTerminal(t, false)
+
+	_, err := Resolve(newSendCommand(t), noPrompt(t))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "default_from of profile 'default' is not a valid email address")
+}
@@ -0,0 +1,210 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/oauth2/google"
+
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+)
+
+// GCSAPI is the resumable upload protocol of Cloud Storage as a sink uses it
+type GCSAPI interface {
+	// StartUpload opens a resumable upload session and returns its URI
+	StartUpload(ctx context.Context, bucket, object, contentType string) (string, error)
+	// UploadChunk sends the bytes starting at offset; the final chunk
+	// completes the object
+	UploadChunk(ctx context.Context, session string, chunk []byte, offset int64, final bool) error
+	// CancelUpload discards a session and everything uploaded to it
+	CancelUpload(ctx context.Context, session string) error
+}
+
+// gcsScope is the OAuth scope needed to create objects
+const gcsScope = "https://www.googleapis.com/auth/devstorage.read_write"
+
+// newGCSClient builds a Cloud Storage client from Application Default
+// Credentials: GOOGLE_APPLICATION_CREDENTIALS, the gcloud user credentials
+// or the metadata server. Replaced in tests.
+var newGCSClient = func(ctx context.Context) (GCSAPI, error) {
+	client, err := google.DefaultClient(ctx, gcsScope)
+	if err != nil {
+		return nil, err
+	}
+	return &gcsHTTPClient{client: client, endpoint: "https://storage.googleapis.com"}, nil
+}
+
+// gcsSink uploads an object to Cloud Storage through a resumable upload
+// session, sending the output in chunks as it is written
+type gcsSink struct {
+	ctx       context.Context
+	client    GCSAPI
+	target    string
+	bucket    string
+	object    string
+	chunkSize int
+
+	buf     bytes.Buffer
+	session string
+	offset  int64
+	done    bool
+}
+
+func newGCSSink(ctx context.Context, client GCSAPI, target, bucket, object string, chunkSize int) *gcsSink {
+	return &gcsSink{ctx: ctx, client: client, target: target, bucket: bucket, object: object, chunkSize: chunkSize}
+}
+
+func (s *gcsSink) Write(p []byte) (int, error) {
+	if err := s.ctx.Err(); err != nil {
+		return 0, err
+	}
+	s.buf.Write(p)
+	// Keep a chunk back so the final request is never empty
+	for s.buf.Len() > s.chunkSize {
+		if err := s.upload(s.buf.Next(s.chunkSize), false); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// upload sends a chunk, opening the session first if needed
+func (s *gcsSink) upload(chunk []byte, final bool) error {
+	if s.session == "" {
+		session, err := s.client.StartUpload(s.ctx, s.bucket, s.object, contentType(s.object))
+		if err != nil {
+			return errors.NewNetworkError("failed to start the upload to "+s.target, err)
+		}
+		s.session = session
+	}
+	if err := s.client.UploadChunk(s.ctx, s.session, chunk, s.offset, final); err != nil {
+		return errors.NewNetworkError("failed to upload to "+s.target, err)
+	}
+	s.offset += int64(len(chunk))
+	return nil
+}
+
+func (s *gcsSink) Close() error {
+	if s.done {
+		return nil
+	}
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+	if err := s.upload(s.buf.Next(s.buf.Len()), true); err != nil {
+		return err
+	}
+	s.done = true
+	return nil
+}
+
+// Abort cancels the upload session, which discards the chunks sent so far
+func (s *gcsSink) Abort() error {
+	if s.done {
+		return nil
+	}
+	s.done = true
+	if s.session == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), abortTimeout)
+	defer cancel()
+	if err := s.client.CancelUpload(ctx, s.session); err != nil {
+		return errors.NewNetworkError("failed to cancel the upload to "+s.target+"; the incomplete upload expires after a week", err)
+	}
+	logger.Get().WithField("target", s.target).Debug("Cancelled incomplete resumable upload")
+	return nil
+}
+
+func (s *gcsSink) Location() string {
+	return s.target
+}
+
+// gcsHTTPClient speaks the Cloud Storage JSON API's resumable upload
+// protocol over an authorized HTTP client
+type gcsHTTPClient struct {
+	client   *http.Client
+	endpoint string
+}
+
+func (c *gcsHTTPClient) StartUpload(ctx context.Context, bucket, object, contentType string) (string, error) {
+	uploadURL := fmt.Sprintf("%s/upload/storage/v1/b/%s/o?uploadType=resumable&name=%s",
+		c.endpoint, url.PathEscape(bucket), url.QueryEscape(object))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uploadURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Upload-Content-Type", contentType)
+
+	resp, err := c.do(req, http.StatusOK)
+	if err != nil {
+		return "", err
+	}
+	session := resp.Header.Get("Location")
+	if session == "" {
+		return "", fmt.Errorf("no upload session in the response")
+	}
+	return session, nil
+}
+
+func (c *gcsHTTPClient) UploadChunk(ctx context.Context, session string, chunk []byte, offset int64, final bool) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, session, bytes.NewReader(chunk))
+	if err != nil {
+		return err
+	}
+	req.ContentLength = int64(len(chunk))
+
+	end := offset + int64(len(chunk))
+	total := "*"
+	if final {
+		total = fmt.Sprint(end)
+	}
+	if len(chunk) == 0 {
+		req.Header.Set("Content-Range", "bytes */"+total)
+	} else {
+		req.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%s", offset, end-1, total))
+	}
+
+	// 308 means the chunk was stored and more is expected
+	expected := http.StatusPermanentRedirect
+	if final {
+		expected = http.StatusOK
+	}
+	_, err = c.do(req, expected, http.StatusCreated)
+	return err
+}
+
+func (c *gcsHTTPClient) CancelUpload(ctx context.Context, session string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, session, nil)
+	if err != nil {
+		return err
+	}
+	// Cloud Storage answers a cancelled session with 499
+	_, err = c.do(req, 499, http.StatusNoContent)
+	return err
+}
+
+// do sends req and fails unless the response has one of the expected
+// status codes
+func (c *gcsHTTPClient) do(req *http.Request, expected ...int) (*http.Response, error) {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	for _, status := range expected {
+		if resp.StatusCode == status {
+			io.Copy(io.Discard, resp.Body)
+			return resp, nil
+		}
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return nil, fmt.Errorf("%s %s: %s: %s", req.Method, req.URL.Redacted(), resp.Status, bytes.TrimSpace(body))
+}
@@ -0,0 +1,172 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/logger"
+)
+
+// PartSize is how much of an export is buffered before it is uploaded as
+// one part. Exports smaller than this are uploaded in a single request.
+// S3 requires parts of at least 5 MiB and GCS chunks in multiples of 256 KiB.
+const PartSize = 8 << 20
+
+// S3API is the part of the S3 client a sink uses; *s3.Client implements it
+type S3API interface {
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+	CreateMultipartUpload(ctx context.Context, params *s3.CreateMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CreateMultipartUploadOutput, error)
+	UploadPart(ctx context.Context, params *s3.UploadPartInput, optFns ...func(*s3.Options)) (*s3.UploadPartOutput, error)
+	CompleteMultipartUpload(ctx context.Context, params *s3.CompleteMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CompleteMultipartUploadOutput, error)
+	AbortMultipartUpload(ctx context.Context, params *s3.AbortMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.AbortMultipartUploadOutput, error)
+}
+
+// newS3Client builds an S3 client from the standard AWS configuration
+// chain: environment variables, shared config and credentials files, SSO
+// and instance roles. Replaced in tests.
+var newS3Client = func(ctx context.Context) (S3API, error) {
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s3.NewFromConfig(cfg), nil
+}
+
+// s3Sink uploads an object to S3, switching to a multipart upload once
+// the output outgrows a single part
+type s3Sink struct {
+	ctx      context.Context
+	client   S3API
+	target   string
+	bucket   string
+	key      string
+	partSize int
+
+	buf      bytes.Buffer
+	uploadID string
+	parts    []types.CompletedPart
+	done     bool
+}
+
+func newS3Sink(ctx context.Context, client S3API, target, bucket, key string, partSize int) *s3Sink {
+	return &s3Sink{ctx: ctx, client: client, target: target, bucket: bucket, key: key, partSize: partSize}
+}
+
+func (s *s3Sink) Write(p []byte) (int, error) {
+	if err := s.ctx.Err(); err != nil {
+		return 0, err
+	}
+	s.buf.Write(p)
+	for s.buf.Len() >= s.partSize {
+		if err := s.uploadPart(s.buf.Next(s.partSize)); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// uploadPart uploads the next part, starting the multipart upload first
+// if this is the first one
+func (s *s3Sink) uploadPart(data []byte) error {
+	if s.uploadID == "" {
+		out, err := s.client.CreateMultipartUpload(s.ctx, &s3.CreateMultipartUploadInput{
+			Bucket:      aws.String(s.bucket),
+			Key:         aws.String(s.key),
+			ContentType: aws.String(contentType(s.key)),
+		})
+		if err != nil {
+			return errors.NewNetworkError("failed to start the upload to "+s.target, err)
+		}
+		s.uploadID = aws.ToString(out.UploadId)
+	}
+
+	number := int32(len(s.parts) + 1)
+	out, err := s.client.UploadPart(s.ctx, &s3.UploadPartInput{
+		Bucket:     aws.String(s.bucket),
+		Key:        aws.String(s.key),
+		UploadId:   aws.String(s.uploadID),
+		PartNumber: aws.Int32(number),
+		Body:       bytes.NewReader(data),
+	})
+	if err != nil {
+		return errors.NewNetworkError(fmt.Sprintf("failed to upload part %d to %s", number, s.target), err)
+	}
+	s.parts = append(s.parts, types.CompletedPart{ETag: out.ETag, PartNumber: aws.Int32(number)})
+	return nil
+}
+
+func (s *s3Sink) Close() error {
+	if s.done {
+		return nil
+	}
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+
+	if s.uploadID == "" {
+		_, err := s.client.PutObject(s.ctx, &s3.PutObjectInput{
+			Bucket:      aws.String(s.bucket),
+			Key:         aws.String(s.key),
+			ContentType: aws.String(contentType(s.key)),
+			Body:        bytes.NewReader(s.buf.Bytes()),
+		})
+		if err != nil {
+			return errors.NewNetworkError("failed to upload "+s.target, err)
+		}
+		s.done = true
+		return nil
+	}
+
+	if s.buf.Len() > 0 {
+		if err := s.uploadPart(s.buf.Next(s.buf.Len())); err != nil {
+			return err
+		}
+	}
+	_, err := s.client.CompleteMultipartUpload(s.ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(s.bucket),
+		Key:             aws.String(s.key),
+		UploadId:        aws.String(s.uploadID),
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: s.parts},
+	})
+	if err != nil {
+		return errors.NewNetworkError("failed to complete the upload to "+s.target, err)
+	}
+	s.done = true
+	return nil
+}
+
+// Abort removes the parts of an unfinished multipart upload. A single-part
+// upload has nothing stored until it completes.
+func (s *s3Sink) Abort() error {
+	if s.done {
+		return nil
+	}
+	s.done = true
+	if s.uploadID == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), abortTimeout)
+	defer cancel()
+	_, err := s.client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(s.bucket),
+		Key:      aws.String(s.key),
+		UploadId: aws.String(s.uploadID),
+	})
+	if err != nil {
+		return errors.NewNetworkError(fmt.Sprintf("failed to abort the upload to %s; remove upload %s by hand or with a bucket lifecycle rule", s.target, s.uploadID), err)
+	}
+	logger.Get().WithField("target", s.target).Debug("Aborted incomplete multipart upload")
+	return nil
+}
+
+func (s *s3Sink) Location() string {
+	return s.target
+}
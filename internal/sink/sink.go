@@ -0,0 +1,182 @@
+// Package sink writes command output to a local file or straight to object
+// storage, chosen by the scheme of the target:
+//
+//	messages.csv                  a local file
+//	s3://bucket/prefix/file.csv   an Amazon S3 object
+//	gs://bucket/prefix/file.csv   a Google Cloud Storage object
+//
+// A sink is written to like any io.Writer. Close makes the output visible at
+// its target; Abort discards it, including the parts of an incomplete
+// multipart or resumable upload, so a failed or interrupted export leaves
+// nothing behind. Remote sinks reach their storage through small client
+// interfaces, which tests replace with fakes.
+package sink
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+)
+
+// Sink is the destination of an export
+type Sink interface {
+	Write(p []byte) (int, error)
+	// Close finishes the output and makes it visible at its target
+	Close() error
+	// Abort discards everything written; it is safe to call after a failed
+	// Close and does nothing after a successful one
+	Abort() error
+	// Location is the target as given to Open
+	Location() string
+}
+
+// abortTimeout bounds the cleanup of an upload, which runs after the export's
+// own context may have been cancelled
+const abortTimeout = 30 * time.Second
+
+// Open creates the sink for target. Remote uploads run under ctx, so
+// cancelling it (on Ctrl-C, for example) fails the next write; the caller
+// then calls Abort.
+func Open(ctx context.Context, target string) (Sink, error) {
+	scheme, bucket, key, err := parseTarget(target)
+	if err != nil {
+		return nil, err
+	}
+
+	switch scheme {
+	case "s3":
+		client, err := newS3Client(ctx)
+		if err != nil {
+			return nil, errors.NewConfigError("failed to load AWS credentials for "+target, err)
+		}
+		return newS3Sink(ctx, client, target, bucket, key, PartSize), nil
+
+	case "gs":
+		client, err := newGCSClient(ctx)
+		if err != nil {
+			return nil, errors.NewConfigError("failed to load Google Cloud credentials for "+target, err)
+		}
+		return newGCSSink(ctx, client, target, bucket, key, PartSize), nil
+	}
+	return openFile(target)
+}
+
+// IsRemote reports whether target names object storage rather than a file
+func IsRemote(target string) bool {
+	scheme, _, _, err := parseTarget(target)
+	return err == nil && scheme != ""
+}
+
+// BaseName returns the last element of target's path: the file or object
+// name without its directory or prefix
+func BaseName(target string) string {
+	if scheme, _, key, err := parseTarget(target); err == nil && scheme != "" {
+		return path.Base(key)
+	}
+	return filepath.Base(target)
+}
+
+// parseTarget splits an s3:// or gs:// URL into bucket and key. Anything
+// else is a local path and comes back with an empty scheme.
+func parseTarget(target string) (scheme, bucket, key string, err error) {
+	if target == "" {
+		return "", "", "", errors.NewValidationError("output file must not be empty", nil)
+	}
+	index := strings.Index(target, "://")
+	if index < 0 {
+		return "", "", "", nil
+	}
+
+	scheme = strings.ToLower(target[:index])
+	switch scheme {
+	case "s3", "gs":
+	case "file":
+		return "", "", target[index+3:], nil
+	default:
+		return "", "", "", errors.NewValidationError(fmt.Sprintf(
+			"unsupported output URL scheme '%s://'; use a local path, s3:// or gs://", scheme), nil)
+	}
+
+	u, err := url.Parse(target)
+	if err != nil {
+		return "", "", "", errors.NewValidationError("invalid output URL "+target, err)
+	}
+	bucket = u.Host
+	key = strings.TrimPrefix(u.Path, "/")
+	if bucket == "" || key == "" || strings.HasSuffix(key, "/") {
+		return "", "", "", errors.NewValidationError(fmt.Sprintf(
+			"output URL %s must name a bucket and an object, e.g. %s://bucket/prefix/messages.csv", target, scheme), nil)
+	}
+	return scheme, bucket, key, nil
+}
+
+// contentType guesses the MIME type of an object from its name
+func contentType(key string) string {
+	if t := mime.TypeByExtension(path.Ext(key)); t != "" {
+		return t
+	}
+	return "application/octet-stream"
+}
+
+// fileSink writes to a temporary file next to the target and renames it
+// into place on Close, so the target never holds a partial export
+type fileSink struct {
+	target string
+	path   string
+	file   *os.File
+	done   bool
+}
+
+func openFile(target string) (*fileSink, error) {
+	path := strings.TrimPrefix(target, "file://")
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".partial-*")
+	if err != nil {
+		return nil, errors.NewFileError(fmt.Sprintf("cannot create %s", path), err)
+	}
+	return &fileSink{target: target, path: path, file: file}, nil
+}
+
+func (s *fileSink) Write(p []byte) (int, error) {
+	return s.file.Write(p)
+}
+
+func (s *fileSink) Close() error {
+	if s.done {
+		return nil
+	}
+	if err := s.file.Chmod(0644); err != nil {
+		return errors.NewFileError(fmt.Sprintf("failed to write %s", s.path), err)
+	}
+	if err := s.file.Close(); err != nil {
+		return errors.NewFileError(fmt.Sprintf("failed to write %s", s.path), err)
+	}
+	if err := os.Rename(s.file.Name(), s.path); err != nil {
+		return errors.NewFileError(fmt.Sprintf("failed to write %s", s.path), err)
+	}
+	s.done = true
+	return nil
+}
+
+func (s *fileSink) Abort() error {
+	if s.done {
+		return nil
+	}
+	s.done = true
+	s.file.Close()
+	if err := os.Remove(s.file.Name()); err != nil && !os.IsNotExist(err) {
+		return errors.NewFileError(fmt.Sprintf("failed to remove the partial file %s", s.file.Name()), err)
+	}
+	return nil
+}
+
+func (s *fileSink) Location() string {
+	return s.target
+}
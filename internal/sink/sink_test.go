@@ -0,0 +1,320 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	stderrors "errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 records the calls a sink makes and keeps completed objects
+type fakeS3 struct {
+	objects   map[string][]byte
+	parts     map[int32][]byte
+	uploads   int
+	aborted   []string
+	failPart  int32
+	completed bool
+}
+
+func newFakeS3() *fakeS3 {
+	return &fakeS3{objects: map[string][]byte{}, parts: map[int32][]byte{}}
+}
+
+func (f *fakeS3) PutObject(ctx context.Context, in *s3.PutObjectInput, _ ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	data, _ := io.ReadAll(in.Body)
+	f.objects[aws.ToString(in.Bucket)+"/"+aws.ToString(in.Key)] = data
+	return &s3.PutObjectOutput{}, nil
+}
+
+func (f *fakeS3) CreateMultipartUpload(ctx context.Context, in *s3.CreateMultipartUploadInput, _ ...func(*s3.Options)) (*s3.CreateMultipartUploadOutput, error) {
+	f.uploads++
+	return &s3.CreateMultipartUploadOutput{UploadId: aws.String("upload-1")}, nil
+}
+
+func (f *fakeS3) UploadPart(ctx context.Context, in *s3.UploadPartInput, _ ...func(*s3.Options)) (*s3.UploadPartOutput, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	number := aws.ToInt32(in.PartNumber)
+	if number == f.failPart {
+		return nil, stderrors.New("connection reset")
+	}
+	f.parts[number], _ = io.ReadAll(in.Body)
+	return &s3.UploadPartOutput{ETag: aws.String("etag")}, nil
+}
+
+func (f *fakeS3) CompleteMultipartUpload(ctx context.Context, in *s3.CompleteMultipartUploadInput, _ ...func(*s3.Options)) (*s3.CompleteMultipartUploadOutput, error) {
+	var data []byte
+	for _, part := range in.MultipartUpload.Parts {
+		data = append(data, f.parts[aws.ToInt32(part.PartNumber)]...)
+	}
+	f.objects[aws.ToString(in.Bucket)+"/"+aws.ToString(in.Key)] = data
+	f.completed = true
+	return &s3.CompleteMultipartUploadOutput{}, nil
+}
+
+func (f *fakeS3) AbortMultipartUpload(ctx context.Context, in *s3.AbortMultipartUploadInput, _ ...func(*s3.Options)) (*s3.AbortMultipartUploadOutput, error) {
+	f.aborted = append(f.aborted, aws.ToString(in.UploadId))
+	return &s3.AbortMultipartUploadOutput{}, nil
+}
+
+// fakeGCS keeps the chunks of one resumable upload
+type fakeGCS struct {
+	session   string
+	data      []byte
+	finished  bool
+	cancelled bool
+}
+
+func (f *fakeGCS) StartUpload(ctx context.Context, bucket, object, contentType string) (string, error) {
+	f.session = "session:" + bucket + "/" + object
+	return f.session, nil
+}
+
+func (f *fakeGCS) UploadChunk(ctx context.Context, session string, chunk []byte, offset int64, final bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if offset != int64(len(f.data)) {
+		return stderrors.New("chunk out of order")
+	}
+	f.data = append(f.data, chunk...)
+	f.finished = final
+	return nil
+}
+
+func (f *fakeGCS) CancelUpload(ctx context.Context, session string) error {
+	f.cancelled = true
+	return nil
+}
+
+func TestParseTarget(t *testing.T) {
+	scheme, bucket, key, err := parseTarget("s3://exports/2024/messages.csv")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"s3", "exports", "2024/messages.csv"}, []string{scheme, bucket, key})
+
+	scheme, _, key, err = parseTarget("messages.csv")
+	require.NoError(t, err)
+	assert.Empty(t, scheme)
+	assert.Equal(t, "", key)
+
+	for _, target := range []string{"", "s3://bucket", "gs://bucket/prefix/", "s3:///key", "ftp://host/file.csv"} {
+		_, _, _, err := parseTarget(target)
+		assert.Error(t, err, target)
+	}
+
+	assert.True(t, IsRemote("gs://bucket/messages.json"))
+	assert.False(t, IsRemote("out/messages.json"))
+	assert.Equal(t, "messages.csv", BaseName("s3://exports/2024/messages.csv"))
+	assert.Equal(t, "messages.csv", BaseName(filepath.Join("out", "messages.csv")))
+}
+
+func TestFileSink(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "messages.csv")
+
+	out, err := Open(context.Background(), target)
+	require.NoError(t, err)
+	_, err = out.Write([]byte("id\n1\n"))
+	require.NoError(t, err)
+
+	// Nothing is at the target until the sink is closed
+	_, err = os.Stat(target)
+	assert.True(t, os.IsNotExist(err))
+
+	require.NoError(t, out.Close())
+	data, err := os.ReadFile(target)
+	require.NoError(t, err)
+	assert.Equal(t, "id\n1\n", string(data))
+	require.NoError(t, out.Abort())
+	assert.FileExists(t, target)
+}
+
+func TestFileSink_Abort(t *testing.T) {
+	dir := t.TempDir()
+	out, err := Open(context.Background(), filepath.Join(dir, "messages.csv"))
+	require.NoError(t, err)
+	_, err = out.Write([]byte("id\n"))
+	require.NoError(t, err)
+
+	require.NoError(t, out.Abort())
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestS3Sink_SmallOutputIsOnePut(t *testing.T) {
+	client := newFakeS3()
+	out := newS3Sink(context.Background(), client, "s3://exports/messages.csv", "exports", "messages.csv", 8)
+
+	_, err := out.Write([]byte("id\n1\n"))
+	require.NoError(t, err)
+	require.NoError(t, out.Close())
+
+	assert.Equal(t, "id\n1\n", string(client.objects["exports/messages.csv"]))
+	assert.Zero(t, client.uploads)
+}
+
+func TestS3Sink_Multipart(t *testing.T) {
+	client := newFakeS3()
+	out := newS3Sink(context.Background(), client, "s3://exports/messages.csv", "exports", "messages.csv", 4)
+
+	for _, chunk := range []string{"abc", "defgh", "ij"} {
+		_, err := out.Write([]byte(chunk))
+		require.NoError(t, err)
+	}
+	require.NoError(t, out.Close())
+
+	assert.Equal(t, 1, client.uploads)
+	assert.Len(t, client.parts, 3)
+	assert.Equal(t, "abcd", string(client.parts[1]))
+	assert.True(t, client.completed)
+	assert.Equal(t, "abcdefghij", string(client.objects["exports/messages.csv"]))
+	assert.Empty(t, client.aborted)
+}
+
+func TestS3Sink_FailedPartAbortsUpload(t *testing.T) {
+	client := newFakeS3()
+	client.failPart = 2
+	out := newS3Sink(context.Background(), client, "s3://exports/messages.csv", "exports", "messages.csv", 4)
+
+	_, err := out.Write([]byte("abcdefgh"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to upload part 2")
+
+	require.NoError(t, out.Abort())
+	assert.Equal(t, []string{"upload-1"}, client.aborted)
+	assert.Empty(t, client.objects)
+}
+
+func TestS3Sink_CancelledContext(t *testing.T) {
+	client := newFakeS3()
+	ctx, cancel := context.WithCancel(context.Background())
+	out := newS3Sink(ctx, client, "s3://exports/messages.csv", "exports", "messages.csv", 4)
+
+	_, err := out.Write([]byte("abcd"))
+	require.NoError(t, err)
+	cancel()
+
+	_, err = out.Write([]byte("efgh"))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, out.Close(), context.Canceled)
+
+	// The cleanup runs even though the export's context is gone
+	require.NoError(t, out.Abort())
+	assert.Equal(t, []string{"upload-1"}, client.aborted)
+	assert.False(t, client.completed)
+}
+
+func TestGCSSink(t *testing.T) {
+	client := &fakeGCS{}
+	out := newGCSSink(context.Background(), client, "gs://exports/messages.csv", "exports", "messages.csv", 4)
+
+	_, err := out.Write([]byte("abcdefghij"))
+	require.NoError(t, err)
+	assert.False(t, client.finished)
+	require.NoError(t, out.Close())
+
+	assert.Equal(t, "abcdefghij", string(client.data))
+	assert.True(t, client.finished)
+	require.NoError(t, out.Abort())
+	assert.False(t, client.cancelled)
+}
+
+func TestGCSSink_AbortCancelsSession(t *testing.T) {
+	client := &fakeGCS{}
+	out := newGCSSink(context.Background(), client, "gs://exports/messages.csv", "exports", "messages.csv", 4)
+
+	_, err := out.Write([]byte("abcdefghij"))
+	require.NoError(t, err)
+	require.NoError(t, out.Abort())
+	assert.True(t, client.cancelled)
+	assert.False(t, client.finished)
+
+	// Nothing was started for an empty, aborted sink
+	empty := &fakeGCS{}
+	require.NoError(t, newGCSSink(context.Background(), empty, "gs://b/o", "b", "o", 4).Abort())
+	assert.Empty(t, empty.session)
+}
+
+func TestOpen_RemoteSchemes(t *testing.T) {
+	s3Client, gcsClient := newFakeS3(), &fakeGCS{}
+	prevS3, prevGCS := newS3Client, newGCSClient
+	newS3Client = func(context.Context) (S3API, error) { return s3Client, nil }
+	newGCSClient = func(context.Context) (GCSAPI, error) { return gcsClient, nil }
+	t.Cleanup(func() { newS3Client, newGCSClient = prevS3, prevGCS })
+
+	for _, target := range []string{"s3://exports/a.json", "gs://exports/a.json"} {
+		out, err := Open(context.Background(), target)
+		require.NoError(t, err)
+		assert.Equal(t, target, out.Location())
+		_, err = out.Write([]byte("[]"))
+		require.NoError(t, err)
+		require.NoError(t, out.Close())
+	}
+	assert.Equal(t, "[]", string(s3Client.objects["exports/a.json"]))
+	assert.Equal(t, "[]", string(gcsClient.data))
+
+	newS3Client = func(context.Context) (S3API, error) { return nil, stderrors.New("no region") }
+	_, err := Open(context.Background(), "s3://exports/a.json")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to load AWS credentials")
+}
+
+func TestGCSHTTPClient(t *testing.T) {
+	var received bytes.Buffer
+	var ranges []string
+	cancelled := false
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost:
+			assert.Equal(t, "/upload/storage/v1/b/exports/o", r.URL.Path)
+			assert.Equal(t, "resumable", r.URL.Query().Get("uploadType"))
+			assert.Equal(t, "2024/messages.csv", r.URL.Query().Get("name"))
+			w.Header().Set("Location", server.URL+"/session/1")
+		case r.Method == http.MethodPut:
+			ranges = append(ranges, r.Header.Get("Content-Range"))
+			io.Copy(&received, r.Body)
+			if strings.HasSuffix(r.Header.Get("Content-Range"), "/*") {
+				w.WriteHeader(http.StatusPermanentRedirect)
+			}
+		case r.Method == http.MethodDelete:
+			cancelled = true
+			w.WriteHeader(499)
+		}
+	}))
+	defer server.Close()
+
+	client := &gcsHTTPClient{client: server.Client(), endpoint: server.URL}
+	session, err := client.StartUpload(context.Background(), "exports", "2024/messages.csv", "text/csv")
+	require.NoError(t, err)
+	require.NoError(t, client.UploadChunk(context.Background(), session, []byte("abcd"), 0, false))
+	require.NoError(t, client.UploadChunk(context.Background(), session, []byte("ef"), 4, true))
+	assert.Equal(t, []string{"bytes 0-3/*", "bytes 4-5/6"}, ranges)
+	assert.Equal(t, "abcdef", received.String())
+
+	require.NoError(t, client.CancelUpload(context.Background(), session))
+	assert.True(t, cancelled)
+
+	// Storage errors carry the response body
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("access denied"))
+	})
+	err = client.UploadChunk(context.Background(), session, []byte("gh"), 6, true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "access denied")
+}
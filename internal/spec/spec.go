@@ -0,0 +1,456 @@
+// Package spec reads and writes the declarative YAML files that describe
+// webhooks and routes, so they can be exported from one account and imported
+// into another.
+//
+// A file holds the settings of each resource without its ID, statistics or
+// signing secret, and is annotated with the account it was exported from.
+// Resources are identified by name: importing matches them against the
+// target account's resources case-insensitively. Like export manifests, the
+// file carries a schema version and readers reject versions newer than they
+// know.
+package spec
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/AhaSend/ahasend-cli/internal/jsonmerge"
+	"github.com/AhaSend/ahasend-cli/internal/version"
+	"github.com/AhaSend/ahasend-cli/internal/webhooks"
+)
+
+// SchemaVersion is the file schema written by this CLI
+const SchemaVersion = 1
+
+// Resource kinds, one per file
+const (
+	KindWebhooks = "webhooks"
+	KindRoutes   = "routes"
+)
+
+// File is a declarative description of the webhooks or routes of an account
+type File struct {
+	SchemaVersion int       `yaml:"schema_version"`
+	Kind          string    `yaml:"kind"`
+	Source        Source    `yaml:"source,omitempty"`
+	Webhooks      []Webhook `yaml:"webhooks,omitempty"`
+	Routes        []Route   `yaml:"routes,omitempty"`
+}
+
+// Source records where and when a file was exported
+type Source struct {
+	AccountID   string    `yaml:"account_id,omitempty"`
+	AccountName string    `yaml:"account_name,omitempty"`
+	ExportedAt  time.Time `yaml:"exported_at,omitempty"`
+	CLIVersion  string    `yaml:"cli_version,omitempty"`
+}
+
+// Webhook holds the settings of a webhook. The signing secret is never
+// exported; the target account generates its own.
+type Webhook struct {
+	Name    string   `yaml:"name" json:"name"`
+	URL     string   `yaml:"url" json:"url"`
+	Enabled bool     `yaml:"enabled" json:"enabled"`
+	Events  []string `yaml:"events" json:"events"`
+	Scope   string   `yaml:"scope,omitempty" json:"scope"`
+	Domains []string `yaml:"domains,omitempty" json:"domains"`
+}
+
+// Route holds the settings of an inbound route
+type Route struct {
+	Name             string `yaml:"name"`
+	URL              string `yaml:"url"`
+	Recipient        string `yaml:"recipient"`
+	Attachments      bool   `yaml:"attachments"`
+	Headers          bool   `yaml:"headers"`
+	GroupByMessageID bool   `yaml:"group_by_message_id"`
+	StripReplies     bool   `yaml:"strip_replies"`
+	Enabled          bool   `yaml:"enabled"`
+}
+
+// New starts a file of the given kind exported from accountID at exportedAt
+func New(kind, accountID, accountName string, exportedAt time.Time) *File {
+	return &File{
+		SchemaVersion: SchemaVersion,
+		Kind:          kind,
+		Source: Source{
+			AccountID:   accountID,
+			AccountName: accountName,
+			ExportedAt:  exportedAt.UTC().Truncate(time.Second),
+			CLIVersion:  version.Version,
+		},
+	}
+}
+
+// FromWebhook describes an existing webhook
+func FromWebhook(webhook *responses.Webhook) Webhook {
+	events := webhooks.SubscribedEvents(webhook)
+	if events == nil {
+		events = []string{}
+	}
+	return Webhook{
+		Name:    webhook.Name,
+		URL:     webhook.URL,
+		Enabled: webhook.Enabled,
+		Events:  events,
+		Scope:   webhook.Scope,
+		Domains: append([]string(nil), webhook.Domains...),
+	}
+}
+
+// FromRoute describes an existing route
+func FromRoute(route *responses.Route) Route {
+	return Route{
+		Name:             route.Name,
+		URL:              route.URL,
+		Recipient:        route.Recipient,
+		Attachments:      route.Attachments,
+		Headers:          route.Headers,
+		GroupByMessageID: route.GroupByMessageID,
+		StripReplies:     route.StripReplies,
+		Enabled:          route.Enabled,
+	}
+}
+
+// CreateRequest builds the request that creates the webhook
+func (w Webhook) CreateRequest() requests.CreateWebhookRequest {
+	enabled := w.Enabled
+	req := requests.CreateWebhookRequest{
+		Name:    w.Name,
+		URL:     w.URL,
+		Enabled: &enabled,
+		Scope:   w.Scope,
+	}
+	webhooks.SetCreateEvents(&req, w.Events)
+	if len(w.Domains) > 0 {
+		domains := append([]string(nil), w.Domains...)
+		req.Domains = &domains
+	}
+	return req
+}
+
+// UpdateRequest builds the request that sets every setting of an existing
+// webhook to those of w, unsubscribing from events w does not list
+func (w Webhook) UpdateRequest() requests.UpdateWebhookRequest {
+	name, url, enabled, scope := w.Name, w.URL, w.Enabled, w.Scope
+	domains := append([]string{}, w.Domains...)
+	req := requests.UpdateWebhookRequest{
+		Name:    &name,
+		URL:     &url,
+		Enabled: &enabled,
+		Scope:   &scope,
+		Domains: &domains,
+	}
+	webhooks.SetUpdateEvents(&req, webhooks.EventKeys(), false)
+	webhooks.SetUpdateEvents(&req, w.Events, true)
+	return req
+}
+
+// Equal reports whether two webhooks have the same settings. Names are
+// compared case-insensitively, and events and domains in any order.
+func (w Webhook) Equal(other Webhook) bool {
+	return strings.EqualFold(w.Name, other.Name) &&
+		strings.TrimSpace(w.URL) == strings.TrimSpace(other.URL) &&
+		w.Enabled == other.Enabled &&
+		w.Scope == other.Scope &&
+		sameSet(w.Events, other.Events) &&
+		sameSet(w.Domains, other.Domains)
+}
+
+// Changes lists the settings edited changes, ordered like the fields of
+// Webhook. Events and domains are compared in any order.
+func (w Webhook) Changes(edited Webhook) []jsonmerge.Change {
+	var changes []jsonmerge.Change
+	add := func(path string, before, after interface{}) {
+		changes = append(changes, jsonmerge.Change{Path: path, Before: before, After: after})
+	}
+	if w.Name != edited.Name {
+		add("name", w.Name, edited.Name)
+	}
+	if strings.TrimSpace(w.URL) != strings.TrimSpace(edited.URL) {
+		add("url", w.URL, edited.URL)
+	}
+	if w.Enabled != edited.Enabled {
+		add("enabled", w.Enabled, edited.Enabled)
+	}
+	if !sameSet(w.Events, edited.Events) {
+		add("events", nonNil(w.Events), nonNil(edited.Events))
+	}
+	if w.Scope != edited.Scope {
+		add("scope", w.Scope, edited.Scope)
+	}
+	if !sameSet(w.Domains, edited.Domains) {
+		add("domains", nonNil(w.Domains), nonNil(edited.Domains))
+	}
+	return changes
+}
+
+// ChangeRequest builds the request that applies the settings edited changes
+// to the webhook w describes, leaving the others alone
+func (w Webhook) ChangeRequest(edited Webhook) requests.UpdateWebhookRequest {
+	var req requests.UpdateWebhookRequest
+	full := edited.UpdateRequest()
+	for _, change := range w.Changes(edited) {
+		switch change.Path {
+		case "name":
+			req.Name = full.Name
+		case "url":
+			req.URL = full.URL
+		case "enabled":
+			req.Enabled = full.Enabled
+		case "events":
+			webhooks.SetUpdateEvents(&req, webhooks.EventKeys(), false)
+			webhooks.SetUpdateEvents(&req, edited.Events, true)
+		case "scope":
+			req.Scope = full.Scope
+		case "domains":
+			req.Domains = full.Domains
+		}
+	}
+	return req
+}
+
+// CreateRequest builds the request that creates the route
+func (r Route) CreateRequest() requests.CreateRouteRequest {
+	enabled := r.Enabled
+	return requests.CreateRouteRequest{
+		Name:             r.Name,
+		URL:              r.URL,
+		Recipient:        r.Recipient,
+		Attachments:      r.Attachments,
+		Headers:          r.Headers,
+		GroupByMessageId: r.GroupByMessageID,
+		StripReplies:     r.StripReplies,
+		Enabled:          &enabled,
+	}
+}
+
+// UpdateRequest builds the request that sets every setting of an existing
+// route to those of r
+func (r Route) UpdateRequest() requests.UpdateRouteRequest {
+	route := r
+	return requests.UpdateRouteRequest{
+		Name:             &route.Name,
+		URL:              &route.URL,
+		Recipient:        &route.Recipient,
+		Attachments:      &route.Attachments,
+		Headers:          &route.Headers,
+		GroupByMessageId: &route.GroupByMessageID,
+		StripReplies:     &route.StripReplies,
+		Enabled:          &route.Enabled,
+	}
+}
+
+// Equal reports whether two routes have the same settings, comparing names
+// and recipients case-insensitively
+func (r Route) Equal(other Route) bool {
+	return strings.EqualFold(r.Name, other.Name) &&
+		strings.TrimSpace(r.URL) == strings.TrimSpace(other.URL) &&
+		strings.EqualFold(r.Recipient, other.Recipient) &&
+		r.Attachments == other.Attachments &&
+		r.Headers == other.Headers &&
+		r.GroupByMessageID == other.GroupByMessageID &&
+		r.StripReplies == other.StripReplies &&
+		r.Enabled == other.Enabled
+}
+
+// Write saves the file as YAML to path
+func (f *File) Write(path string) error {
+	data, err := f.Marshal()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return errors.NewFileError(fmt.Sprintf("failed to write %s", path), err)
+	}
+	return nil
+}
+
+// Marshal encodes the file as YAML
+func (f *File) Marshal() ([]byte, error) {
+	data, err := yaml.Marshal(f)
+	if err != nil {
+		return nil, errors.NewFileError("failed to encode "+f.Kind, err)
+	}
+	return data, nil
+}
+
+// Load reads and validates a file of the given kind
+func Load(path, kind string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.NewFileError(fmt.Sprintf("cannot open %s", path), err)
+	}
+	f, err := Parse(data, kind)
+	if err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("%s: %s", path, err.Error()), nil)
+	}
+	return f, nil
+}
+
+// Parse decodes and validates a file of the given kind. Names must be
+// present and unique ignoring case, since import matches resources by name.
+func Parse(data []byte, kind string) (*File, error) {
+	var f File
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("not a valid %s file: %w", kind, err)
+	}
+	if f.SchemaVersion < 1 {
+		return nil, fmt.Errorf("no schema_version")
+	}
+	if f.SchemaVersion > SchemaVersion {
+		return nil, fmt.Errorf("uses schema version %d, this CLI supports up to %d; upgrade the CLI", f.SchemaVersion, SchemaVersion)
+	}
+	if f.Kind != kind {
+		return nil, fmt.Errorf("holds %q, expected %q", f.Kind, kind)
+	}
+
+	var names []string
+	switch kind {
+	case KindWebhooks:
+		if len(f.Routes) > 0 {
+			return nil, fmt.Errorf("a webhooks file cannot list routes")
+		}
+		for i, webhook := range f.Webhooks {
+			if webhook.URL == "" {
+				return nil, fmt.Errorf("webhook %d (%q) has no url", i+1, webhook.Name)
+			}
+			for _, event := range webhook.Events {
+				if !webhooks.IsValidEvent(event) {
+					return nil, fmt.Errorf("webhook %q has unknown event %q (valid: %s)", webhook.Name, event, strings.Join(webhooks.EventKeys(), ", "))
+				}
+			}
+			names = append(names, webhook.Name)
+		}
+	case KindRoutes:
+		if len(f.Webhooks) > 0 {
+			return nil, fmt.Errorf("a routes file cannot list webhooks")
+		}
+		for i, route := range f.Routes {
+			if route.URL == "" {
+				return nil, fmt.Errorf("route %d (%q) has no url", i+1, route.Name)
+			}
+			names = append(names, route.Name)
+		}
+	}
+
+	seen := make(map[string]bool, len(names))
+	for i, name := range names {
+		if strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("%s %d has no name", strings.TrimSuffix(kind, "s"), i+1)
+		}
+		key := strings.ToLower(name)
+		if seen[key] {
+			return nil, fmt.Errorf("name %q appears more than once", name)
+		}
+		seen[key] = true
+	}
+	return &f, nil
+}
+
+// LoadRoute reads the settings of one route: either a bare route mapping
+// with the keys of a routes file entry, or a routes file holding a single
+// route. Unknown keys are rejected, so a misspelled option is not silently
+// read as false.
+func LoadRoute(path string) (Route, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Route{}, errors.NewFileError(fmt.Sprintf("cannot open %s", path), err)
+	}
+	route, err := ParseRoute(data)
+	if err != nil {
+		return Route{}, errors.NewValidationError(fmt.Sprintf("%s: %s", path, err.Error()), nil)
+	}
+	return route, nil
+}
+
+// ParseRoute decodes the settings of one route, see LoadRoute
+func ParseRoute(data []byte) (Route, error) {
+	var probe struct {
+		Kind string `yaml:"kind"`
+	}
+	if err := yaml.Unmarshal(data, &probe); err != nil {
+		return Route{}, fmt.Errorf("not a valid route file: %w", err)
+	}
+
+	if probe.Kind != "" {
+		f, err := Parse(data, KindRoutes)
+		if err != nil {
+			return Route{}, err
+		}
+		if len(f.Routes) != 1 {
+			return Route{}, fmt.Errorf("holds %d routes; a route file must hold exactly one", len(f.Routes))
+		}
+		return f.Routes[0], nil
+	}
+
+	var route Route
+	decoder := yaml.NewDecoder(strings.NewReader(string(data)))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&route); err != nil {
+		return Route{}, fmt.Errorf("not a valid route file: %w", err)
+	}
+	return route, nil
+}
+
+// ParseWebhook decodes the settings of one webhook from YAML or JSON, which
+// YAML includes. Unknown keys are rejected, and the name, URL and events are
+// checked like those of a webhooks file.
+func ParseWebhook(data []byte) (Webhook, error) {
+	var webhook Webhook
+	decoder := yaml.NewDecoder(strings.NewReader(string(data)))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&webhook); err != nil {
+		return Webhook{}, fmt.Errorf("not a valid webhook: %w", err)
+	}
+	if strings.TrimSpace(webhook.Name) == "" {
+		return Webhook{}, fmt.Errorf("the webhook has no name")
+	}
+	if strings.TrimSpace(webhook.URL) == "" {
+		return Webhook{}, fmt.Errorf("the webhook has no url")
+	}
+	var unknown []string
+	for _, event := range webhook.Events {
+		if !webhooks.IsValidEvent(event) {
+			unknown = append(unknown, event)
+		}
+	}
+	if len(unknown) > 0 {
+		return Webhook{}, fmt.Errorf("unknown events %s (valid: %s)", strings.Join(unknown, ", "), strings.Join(webhooks.EventKeys(), ", "))
+	}
+	return webhook, nil
+}
+
+// nonNil returns values, or an empty slice for nil so it encodes as []
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+// sameSet reports whether a and b hold the same strings in any order
+func sameSet(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = append([]string(nil), a...)
+	b = append([]string(nil), b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,166 @@
+package spec
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookRoundTrip(t *testing.T) {
+	source := responses.Webhook{
+		Name:        "Deliveries",
+		URL:         "https://example.com/hook",
+		Enabled:     true,
+		Secret:      "whsec_do_not_export",
+		OnDelivered: true,
+		OnBounced:   true,
+		Scope:       "account",
+		Domains:     []string{"example.com"},
+	}
+
+	file := New(KindWebhooks, "acc-1", "Staging", time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	file.Webhooks = append(file.Webhooks, FromWebhook(&source))
+	path := filepath.Join(t.TempDir(), "webhooks.yaml")
+	require.NoError(t, file.Write(path))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "whsec_do_not_export")
+	assert.Contains(t, string(data), "account_id: acc-1")
+
+	loaded, err := Load(path, KindWebhooks)
+	require.NoError(t, err)
+	require.Len(t, loaded.Webhooks, 1)
+	assert.True(t, loaded.Webhooks[0].Equal(FromWebhook(&source)))
+	assert.Equal(t, "Staging", loaded.Source.AccountName)
+
+	req := loaded.Webhooks[0].CreateRequest()
+	assert.True(t, req.OnDelivered)
+	assert.True(t, req.OnBounced)
+	assert.False(t, req.OnOpened)
+	require.NotNil(t, req.Domains)
+	assert.Equal(t, []string{"example.com"}, *req.Domains)
+}
+
+func TestWebhookUpdateRequestClearsUnlistedEvents(t *testing.T) {
+	req := Webhook{Name: "a", URL: "https://example.com", Events: []string{"opened"}}.UpdateRequest()
+	require.NotNil(t, req.OnOpened)
+	assert.True(t, *req.OnOpened)
+	require.NotNil(t, req.OnDelivered)
+	assert.False(t, *req.OnDelivered)
+	require.NotNil(t, req.Domains)
+	assert.Empty(t, *req.Domains)
+}
+
+func TestEqual(t *testing.T) {
+	a := Webhook{Name: "Hook", URL: "https://example.com", Events: []string{"opened", "clicked"}}
+	b := Webhook{Name: "hook", URL: "https://example.com", Events: []string{"clicked", "opened"}}
+	assert.True(t, a.Equal(b))
+	b.Enabled = true
+	assert.False(t, a.Equal(b))
+
+	r := Route{Name: "Support", URL: "https://example.com", Recipient: "Support@example.com"}
+	assert.True(t, r.Equal(Route{Name: "support", URL: "https://example.com", Recipient: "support@example.com"}))
+	assert.False(t, r.Equal(Route{Name: "support", URL: "https://example.com", Recipient: "support@example.com", StripReplies: true}))
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    string
+		data    string
+		wantErr string
+	}{
+		{name: "valid routes", kind: KindRoutes, data: "schema_version: 1\nkind: routes\nroutes:\n  - name: a\n    url: https://example.com\n"},
+		{name: "no version", kind: KindRoutes, data: "kind: routes\n", wantErr: "no schema_version"},
+		{name: "newer version", kind: KindRoutes, data: "schema_version: 9\nkind: routes\n", wantErr: "upgrade the CLI"},
+		{name: "wrong kind", kind: KindWebhooks, data: "schema_version: 1\nkind: routes\n", wantErr: `expected "webhooks"`},
+		{name: "missing name", kind: KindRoutes, data: "schema_version: 1\nkind: routes\nroutes:\n  - url: https://example.com\n", wantErr: "route 1 has no name"},
+		{name: "duplicate name", kind: KindWebhooks, data: "schema_version: 1\nkind: webhooks\nwebhooks:\n  - name: A\n    url: https://a\n  - name: a\n    url: https://b\n", wantErr: "appears more than once"},
+		{name: "unknown event", kind: KindWebhooks, data: "schema_version: 1\nkind: webhooks\nwebhooks:\n  - name: A\n    url: https://a\n    events: [sent]\n", wantErr: `unknown event "sent"`},
+		{name: "not yaml", kind: KindWebhooks, data: "{", wantErr: "not a valid webhooks file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.data), tt.kind)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestParseRoute(t *testing.T) {
+	route, err := ParseRoute([]byte("name: support\nurl: https://example.com/inbound\nattachments: true\nstrip_replies: true\n"))
+	require.NoError(t, err)
+	assert.Equal(t, Route{Name: "support", URL: "https://example.com/inbound", Attachments: true, StripReplies: true}, route)
+
+	route, err = ParseRoute([]byte("schema_version: 1\nkind: routes\nroutes:\n  - name: a\n    url: https://example.com\n    headers: true\n"))
+	require.NoError(t, err)
+	assert.True(t, route.Headers)
+
+	_, err = ParseRoute([]byte("name: a\nstrip_reply: true\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "strip_reply")
+
+	_, err = ParseRoute([]byte("schema_version: 1\nkind: routes\nroutes:\n  - name: a\n    url: https://a\n  - name: b\n    url: https://b\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "holds 2 routes")
+}
+
+func TestParseWebhook(t *testing.T) {
+	webhook, err := ParseWebhook([]byte("# edited\nname: Deliveries\nurl: https://example.com/hook\nenabled: true\nevents: [delivered, opened]\n"))
+	require.NoError(t, err)
+	assert.Equal(t, Webhook{Name: "Deliveries", URL: "https://example.com/hook", Enabled: true, Events: []string{"delivered", "opened"}}, webhook)
+
+	webhook, err = ParseWebhook([]byte(`{"name": "Deliveries", "url": "https://example.com/hook", "domains": ["example.com"]}`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"example.com"}, webhook.Domains)
+
+	_, err = ParseWebhook([]byte("name: a\nurl: https://example.com\nevents: [delivered, openned]\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown events openned")
+
+	_, err = ParseWebhook([]byte("name: a\nurl: https://example.com\nenable: true\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "enable")
+
+	_, err = ParseWebhook([]byte("name: a\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no url")
+}
+
+func TestWebhookChanges(t *testing.T) {
+	before := Webhook{Name: "Hook", URL: "https://example.com", Enabled: true, Events: []string{"opened", "clicked"}, Scope: "account"}
+	after := before
+	after.Events = []string{"clicked", "opened"}
+	assert.Empty(t, before.Changes(after))
+
+	after.Enabled = false
+	after.Events = []string{"clicked"}
+	changes := before.Changes(after)
+	require.Len(t, changes, 2)
+	assert.Equal(t, "enabled", changes[0].Path)
+	assert.Equal(t, "events", changes[1].Path)
+	assert.Equal(t, []string{"clicked"}, changes[1].After)
+
+	req := before.ChangeRequest(after)
+	assert.Nil(t, req.Name)
+	assert.Nil(t, req.URL)
+	assert.Nil(t, req.Domains)
+	require.NotNil(t, req.Enabled)
+	assert.False(t, *req.Enabled)
+	require.NotNil(t, req.OnClicked)
+	assert.True(t, *req.OnClicked)
+	require.NotNil(t, req.OnOpened)
+	assert.False(t, *req.OnOpened)
+}
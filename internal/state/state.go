@@ -0,0 +1,222 @@
+// Package state persists CLI bookkeeping that is not configuration.
+//
+// State lives in ~/.ahasend/state.json, next to config.yaml, and holds
+// reminders: follow-up actions the CLI records for the user, such as
+// revoking an API key after it has been rotated. The CLI never acts on a
+// reminder itself; `ahasend reminders list` surfaces them.
+//
+// It also caches the last known sending status of each account, so commands
+// can warn about a paused account without asking the API every time.
+//
+// It keeps fingerprints of recent sends per profile, so messages send can ask
+// before sending the same message to the same recipients again.
+//
+// Finally it caches how far the local clock was from the API server's, so
+// webhook signing and verification can warn about a skewed clock without an
+// API call.
+package state
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+)
+
+// Reminder kinds
+const (
+	ReminderRevokeAPIKey = "revoke_api_key"
+)
+
+// Reminder is a follow-up action due at a given time
+type Reminder struct {
+	ID        string    `json:"id"`
+	Kind      string    `json:"kind"`
+	Subject   string    `json:"subject"` // what the reminder is about, e.g. an API key ID
+	Message   string    `json:"message"`
+	DueAt     time.Time `json:"due_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Due reports whether the reminder's time has come
+func (r Reminder) Due(now time.Time) bool {
+	return !now.Before(r.DueAt)
+}
+
+// AccountStatus is the last known sending status of an account. An empty
+// Status means it could not be determined when it was checked.
+type AccountStatus struct {
+	Status          string    `json:"status,omitempty"`
+	Reason          string    `json:"reason,omitempty"`
+	SendingDisabled bool      `json:"sending_disabled,omitempty"`
+	CheckedAt       time.Time `json:"checked_at"`
+}
+
+// ClockSkew is how far the API server's clock was ahead of the local clock
+// (negative when behind) when it was last observed
+type ClockSkew struct {
+	Skew       time.Duration `json:"skew_ns"`
+	ObservedAt time.Time     `json:"observed_at"`
+}
+
+// State is the content of the state file
+type State struct {
+	Reminders        []Reminder                   `json:"reminders"`
+	AccountStatuses  map[string]AccountStatus     `json:"account_statuses,omitempty"`  // by account ID
+	SendFingerprints map[string][]SendFingerprint `json:"send_fingerprints,omitempty"` // by profile name
+	ClockSkew        *ClockSkew                   `json:"clock_skew,omitempty"`
+}
+
+// Path returns the location of the state file
+func Path() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", errors.NewFileError("failed to get home directory", err)
+	}
+	return filepath.Join(homeDir, ".ahasend", "state.json"), nil
+}
+
+// Load reads the state file. A missing file is an empty state.
+func Load() (*State, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &State{}, nil
+	}
+	if err != nil {
+		return nil, errors.NewFileError("failed to read state file "+path, err)
+	}
+
+	var s State
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, errors.NewFileError("failed to parse state file "+path, err)
+	}
+	return &s, nil
+}
+
+// Save writes the state file, replacing it atomically so an interrupted
+// write never leaves a truncated file behind
+func (s *State) Save() error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.NewFileError("failed to create state directory", err)
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return errors.NewFileError("failed to encode state", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0600); err != nil {
+		return errors.NewFileError("failed to write state file "+tmp, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return errors.NewFileError("failed to replace state file "+path, err)
+	}
+	return nil
+}
+
+// AddReminder records a reminder, filling in its ID and creation time
+func AddReminder(reminder Reminder) (*Reminder, error) {
+	s, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	reminder.ID = uuid.New().String()
+	reminder.CreatedAt = time.Now().UTC()
+	s.Reminders = append(s.Reminders, reminder)
+	if err := s.Save(); err != nil {
+		return nil, err
+	}
+	return &reminder, nil
+}
+
+// RemoveReminder deletes the reminder with the given ID
+func RemoveReminder(id string) error {
+	s, err := Load()
+	if err != nil {
+		return err
+	}
+
+	for i, reminder := range s.Reminders {
+		if reminder.ID == id {
+			s.Reminders = append(s.Reminders[:i], s.Reminders[i+1:]...)
+			return s.Save()
+		}
+	}
+	return errors.NewNotFoundError("no reminder with ID "+id, nil)
+}
+
+// SortedReminders returns the reminders ordered by due time, soonest first
+func (s *State) SortedReminders() []Reminder {
+	reminders := append([]Reminder(nil), s.Reminders...)
+	sort.SliceStable(reminders, func(i, j int) bool {
+		return reminders[i].DueAt.Before(reminders[j].DueAt)
+	})
+	return reminders
+}
+
+// SendFingerprint identifies a send by its sender, content and recipients,
+// so a repeated send can be caught before it goes out again
+type SendFingerprint struct {
+	Fingerprint string    `json:"fingerprint"`
+	Subject     string    `json:"subject,omitempty"`
+	Recipients  int       `json:"recipients"`
+	SentAt      time.Time `json:"sent_at"`
+}
+
+// sendFingerprintRetention is how long send fingerprints are kept
+const sendFingerprintRetention = 7 * 24 * time.Hour
+
+// FindSendFingerprint returns the most recent send of profile with the
+// given fingerprint made after since, or nil
+func FindSendFingerprint(profile, fingerprint string, since time.Time) (*SendFingerprint, error) {
+	s, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	var found *SendFingerprint
+	for i, sent := range s.SendFingerprints[profile] {
+		if sent.Fingerprint == fingerprint && sent.SentAt.After(since) && (found == nil || sent.SentAt.After(found.SentAt)) {
+			found = &s.SendFingerprints[profile][i]
+		}
+	}
+	return found, nil
+}
+
+// RecordSendFingerprint records a send of profile, dropping fingerprints
+// older than a week
+func RecordSendFingerprint(profile string, sent SendFingerprint) error {
+	s, err := Load()
+	if err != nil {
+		return err
+	}
+	if s.SendFingerprints == nil {
+		s.SendFingerprints = make(map[string][]SendFingerprint)
+	}
+
+	cutoff := time.Now().Add(-sendFingerprintRetention)
+	var kept []SendFingerprint
+	for _, previous := range s.SendFingerprints[profile] {
+		if previous.SentAt.After(cutoff) {
+			kept = append(kept, previous)
+		}
+	}
+	s.SendFingerprints[profile] = append(kept, sent)
+	return s.Save()
+}
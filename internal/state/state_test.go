@@ -0,0 +1,85 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	clierrors "github.com/AhaSend/ahasend-cli/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReminders_RoundTrip(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	s, err := Load()
+	require.NoError(t, err)
+	assert.Empty(t, s.Reminders, "a missing state file is an empty state")
+
+	now := time.Now().UTC()
+	later, err := AddReminder(Reminder{Kind: ReminderRevokeAPIKey, Subject: "key-2", DueAt: now.Add(48 * time.Hour)})
+	require.NoError(t, err)
+	sooner, err := AddReminder(Reminder{Kind: ReminderRevokeAPIKey, Subject: "key-1", DueAt: now.Add(-time.Hour)})
+	require.NoError(t, err)
+	assert.NotEmpty(t, later.ID)
+	assert.False(t, later.CreatedAt.IsZero())
+
+	info, err := os.Stat(filepath.Join(home, ".ahasend", "state.json"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	s, err = Load()
+	require.NoError(t, err)
+	reminders := s.SortedReminders()
+	require.Len(t, reminders, 2)
+	assert.Equal(t, "key-1", reminders[0].Subject)
+	assert.True(t, reminders[0].Due(now))
+	assert.False(t, reminders[1].Due(now))
+
+	require.NoError(t, RemoveReminder(sooner.ID))
+	s, err = Load()
+	require.NoError(t, err)
+	require.Len(t, s.Reminders, 1)
+	assert.Equal(t, later.ID, s.Reminders[0].ID)
+
+	err = RemoveReminder("missing")
+	require.Error(t, err)
+	assert.Equal(t, clierrors.ErrCodeNotFound, err.(*clierrors.CLIError).Code)
+}
+
+func TestLoad_Corrupt(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".ahasend"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(home, ".ahasend", "state.json"), []byte("{"), 0600))
+
+	_, err := Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse state file")
+}
+
+func TestSendFingerprints(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	now := time.Now().UTC()
+
+	require.NoError(t, RecordSendFingerprint("default", SendFingerprint{Fingerprint: "old", SentAt: now.Add(-8 * 24 * time.Hour)}))
+	require.NoError(t, RecordSendFingerprint("default", SendFingerprint{Fingerprint: "abc", Recipients: 3, SentAt: now.Add(-time.Hour)}))
+	require.NoError(t, RecordSendFingerprint("staging", SendFingerprint{Fingerprint: "abc", SentAt: now}))
+
+	found, err := FindSendFingerprint("default", "abc", now.Add(-2*time.Hour))
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, 3, found.Recipients)
+
+	found, err = FindSendFingerprint("default", "abc", now.Add(-30*time.Minute))
+	require.NoError(t, err)
+	assert.Nil(t, found, "sends before since are ignored")
+
+	s, err := Load()
+	require.NoError(t, err)
+	require.Len(t, s.SendFingerprints["default"], 1, "fingerprints older than a week are dropped")
+	assert.Equal(t, "abc", s.SendFingerprints["default"][0].Fingerprint)
+}
@@ -0,0 +1,153 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+
+	"github.com/AhaSend/ahasend-cli/internal/errors"
+)
+
+// IDN (internationalized domain name) handling.
+//
+// The AhaSend API only accepts ASCII hostnames, so Unicode domains such as
+// "bücher.example" must be converted to their punycode form
+// ("xn--bcher-kva.example") before they are sent. Output should show the
+// Unicode form with the punycode alongside it so users can match what they
+// typed with what is stored.
+
+// acePrefix is the ASCII Compatible Encoding prefix used by IDNA labels
+const acePrefix = "xn--"
+
+// ToASCIIDomain converts a domain name to its ASCII (punycode) form with
+// the IDNA lookup profile, which maps and NFC-normalizes labels, checks the
+// RFC 5893 bidi rule and rejects malformed xn-- labels. Already-ASCII input
+// is lowercased, so calling it on a domain that is already punycoded is
+// safe.
+func ToASCIIDomain(domain string) (string, error) {
+	domain = strings.TrimSuffix(strings.TrimSpace(domain), ".")
+	if domain == "" {
+		return "", errors.NewValidationError("domain name cannot be empty", nil)
+	}
+	for _, label := range strings.Split(domain, ".") {
+		if label == "" {
+			return "", errors.NewValidationError("invalid domain name format: empty label in "+domain, nil)
+		}
+	}
+
+	ascii, err := idna.Lookup.ToASCII(domain)
+	if err != nil {
+		return "", errors.NewValidationError("invalid domain name format: "+domain, err)
+	}
+
+	// The lookup profile allows any mix of scripts
+	unicodeDomain, _ := idna.Lookup.ToUnicode(ascii)
+	for _, label := range strings.Split(unicodeDomain, ".") {
+		if isMixedScript(label) {
+			return "", errors.NewValidationError("domain label mixes Latin, Greek or Cyrillic letters: "+label, nil)
+		}
+	}
+	return ascii, nil
+}
+
+// ToUnicodeDomain converts a punycoded domain name to its Unicode form.
+// Labels that cannot be decoded are returned unchanged.
+func ToUnicodeDomain(domain string) string {
+	labels := strings.Split(domain, ".")
+	for i, label := range labels {
+		if !strings.HasPrefix(strings.ToLower(label), acePrefix) {
+			continue
+		}
+		if decoded, err := idna.Lookup.ToUnicode(label); err == nil {
+			labels[i] = decoded
+		}
+	}
+	return strings.Join(labels, ".")
+}
+
+// IsIDN reports whether a domain contains non-ASCII or punycoded labels
+func IsIDN(domain string) bool {
+	if !isASCII(domain) {
+		return true
+	}
+	for _, label := range strings.Split(strings.ToLower(domain), ".") {
+		if strings.HasPrefix(label, acePrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatDomainForDisplay returns the Unicode form of a domain followed by its
+// punycode in parentheses, e.g. "bücher.example (xn--bcher-kva.example)".
+// Plain ASCII domains are returned unchanged.
+func FormatDomainForDisplay(domain string) string {
+	if !IsIDN(domain) {
+		return domain
+	}
+	ascii, err := ToASCIIDomain(domain)
+	if err != nil {
+		return domain
+	}
+	display := ToUnicodeDomain(ascii)
+	if display == ascii {
+		return ascii
+	}
+	return fmt.Sprintf("%s (%s)", display, ascii)
+}
+
+// NormalizeEmail converts the domain part of an email address to punycode.
+// The local part is left untouched.
+func NormalizeEmail(email string) (string, error) {
+	at := strings.LastIndex(email, "@")
+	if at <= 0 || at == len(email)-1 {
+		return "", errors.NewValidationError("invalid email address format: "+email, nil)
+	}
+	domain, err := ToASCIIDomain(email[at+1:])
+	if err != nil {
+		return "", err
+	}
+	return email[:at+1] + domain, nil
+}
+
+// NormalizeDomains converts a list of domains to punycode
+func NormalizeDomains(domains []string) ([]string, error) {
+	normalized := make([]string, 0, len(domains))
+	for _, domain := range domains {
+		ascii, err := ToASCIIDomain(domain)
+		if err != nil {
+			return nil, err
+		}
+		normalized = append(normalized, ascii)
+	}
+	return normalized, nil
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// isMixedScript reports whether a label combines letters from more than one of
+// the Latin, Greek and Cyrillic scripts, which is the classic homograph trick
+// (e.g. a Cyrillic "а" inside "pаypal"). Other script combinations such as
+// Han with Latin are common in legitimate domains and are allowed.
+func isMixedScript(label string) bool {
+	seen := 0
+	for _, table := range []*unicode.RangeTable{unicode.Latin, unicode.Greek, unicode.Cyrillic} {
+		for _, r := range label {
+			if unicode.Is(table, r) {
+				seen++
+				break
+			}
+		}
+	}
+	return seen > 1
+}
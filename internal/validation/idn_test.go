@@ -0,0 +1,177 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToASCIIDomain(t *testing.T) {
+	tests := []struct {
+		name     string
+		domain   string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "plain ascii domain",
+			domain:   "example.com",
+			expected: "example.com",
+		},
+		{
+			name:     "uppercase ascii is lowercased",
+			domain:   "Example.COM",
+			expected: "example.com",
+		},
+		{
+			name:     "german umlaut",
+			domain:   "bücher.example",
+			expected: "xn--bcher-kva.example",
+		},
+		{
+			name:     "already punycoded input is kept",
+			domain:   "xn--bcher-kva.example",
+			expected: "xn--bcher-kva.example",
+		},
+		{
+			name:     "uppercase punycode prefix",
+			domain:   "XN--BCHER-KVA.example",
+			expected: "xn--bcher-kva.example",
+		},
+		{
+			name:     "japanese label",
+			domain:   "日本語.jp",
+			expected: "xn--wgv71a119e.jp",
+		},
+		{
+			name:     "cyrillic domain and tld",
+			domain:   "пример.рф",
+			expected: "xn--e1afmkfd.xn--p1ai",
+		},
+		{
+			name:     "han mixed with latin is allowed",
+			domain:   "abc日本.example",
+			expected: "xn--abc-v08fl0d.example",
+		},
+		{
+			name:     "trailing dot is trimmed",
+			domain:   "bücher.example.",
+			expected: "xn--bcher-kva.example",
+		},
+		{
+			name:    "latin and cyrillic homograph is rejected",
+			domain:  "pаypal.com", // Cyrillic "а"
+			wantErr: true,
+		},
+		{
+			name:    "latin and greek mix is rejected",
+			domain:  "αbc.example",
+			wantErr: true,
+		},
+		{
+			name:    "right-to-left label starting with a digit is rejected",
+			domain:  "1שלום.example",
+			wantErr: true,
+		},
+		{
+			name:    "mixed direction label is rejected",
+			domain:  "שלוםabc.example",
+			wantErr: true,
+		},
+		{
+			name:     "valid right-to-left label",
+			domain:   "שלום.example",
+			expected: "xn--9dbne9b.example",
+		},
+		{
+			name:    "malformed punycode",
+			domain:  "xn--!!!.example",
+			wantErr: true,
+		},
+		{
+			name:    "empty label",
+			domain:  "bücher..example",
+			wantErr: true,
+		},
+		{
+			name:    "empty domain",
+			domain:  "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ToASCIIDomain(tt.domain)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestToUnicodeDomain(t *testing.T) {
+	assert.Equal(t, "bücher.example", ToUnicodeDomain("xn--bcher-kva.example"))
+	assert.Equal(t, "пример.рф", ToUnicodeDomain("xn--e1afmkfd.xn--p1ai"))
+	assert.Equal(t, "example.com", ToUnicodeDomain("example.com"))
+	assert.Equal(t, "xn--!!!.example", ToUnicodeDomain("xn--!!!.example"))
+}
+
+func TestFormatDomainForDisplay(t *testing.T) {
+	assert.Equal(t, "example.com", FormatDomainForDisplay("example.com"))
+	assert.Equal(t, "bücher.example (xn--bcher-kva.example)", FormatDomainForDisplay("xn--bcher-kva.example"))
+	assert.Equal(t, "bücher.example (xn--bcher-kva.example)", FormatDomainForDisplay("bücher.example"))
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	email, err := NormalizeEmail("user@bücher.example")
+	require.NoError(t, err)
+	assert.Equal(t, "user@xn--bcher-kva.example", email)
+
+	_, err = NormalizeEmail("no-at-sign")
+	assert.Error(t, err)
+}
+
+func TestValidateEmail_IDN(t *testing.T) {
+	tests := []struct {
+		name    string
+		email   string
+		wantErr bool
+		utf8Err bool
+	}{
+		{name: "unicode domain", email: "user@bücher.example"},
+		{name: "punycode domain", email: "user@xn--bcher-kva.example"},
+		{name: "cyrillic tld", email: "info@пример.рф"},
+		{name: "utf8 local part", email: "用户@example.com", wantErr: true},
+		{name: "homograph domain", email: "user@pаypal.com", wantErr: true, utf8Err: true},
+		{name: "space in utf8 local part", email: "jö rg@example.com", wantErr: true, utf8Err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateEmail(tt.email)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			err = ValidateEmailSMTPUTF8(tt.email)
+			if tt.utf8Err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateDomainName_IDN(t *testing.T) {
+	assert.NoError(t, ValidateDomainName("bücher.example"))
+	assert.NoError(t, ValidateDomainName("xn--bcher-kva.example"))
+	assert.Error(t, ValidateDomainName("pаypal.com"))
+}
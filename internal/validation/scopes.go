@@ -129,3 +129,15 @@ func ValidateScope(scope string) error {
 
 	return errors.NewValidationError("invalid scope: "+scope, nil)
 }
+
+// ScopeDomain returns the domain of a domain-restricted scope such as
+// messages:send:{example.com}, and false for scopes without a restriction.
+func ScopeDomain(scope string) (string, bool) {
+	for _, prefix := range validDynamicPrefixes {
+		if strings.HasPrefix(scope, prefix) && strings.HasSuffix(scope, "}") {
+			domain := strings.TrimSuffix(strings.TrimPrefix(scope, prefix), "}")
+			return domain, domain != ""
+		}
+	}
+	return "", false
+}
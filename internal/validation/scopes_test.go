@@ -91,3 +91,14 @@ func TestValidateScope(t *testing.T) {
 		})
 	}
 }
+
+func TestScopeDomain(t *testing.T) {
+	domain, ok := ScopeDomain("messages:send:{example.com}")
+	assert.True(t, ok)
+	assert.Equal(t, "example.com", domain)
+
+	for _, scope := range []string{"messages:send:all", "domains:read", "messages:send:{}", "accounts:read:{example.com}"} {
+		_, ok := ScopeDomain(scope)
+		assert.False(t, ok, "scope %q has no domain restriction", scope)
+	}
+}
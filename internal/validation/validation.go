@@ -5,6 +5,7 @@
 //   - Email address format validation with comprehensive regex
 //   - UUID format validation for account IDs and message IDs
 //   - Domain name validation following DNS standards
+//   - Internationalized domain (IDN) to punycode conversion
 //   - Output format validation (table, json, csv, plain)
 //   - Log level validation (debug, info, warn, error)
 //   - Preference value validation with type checking
@@ -17,24 +18,51 @@ package validation
 import (
 	"regexp"
 	"strconv"
+	"strings"
+	"unicode"
 
 	"github.com/google/uuid"
 
 	"github.com/AhaSend/ahasend-cli/internal/errors"
 )
 
-// Email validation regex pattern
-var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+// Email validation regex pattern (the TLD may be punycoded, e.g. xn--p1ai)
+var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.([a-zA-Z]{2,}|xn--[a-zA-Z0-9-]+)$`)
 
 // Domain validation regex pattern
 var domainRegex = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9\-]{0,61}[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9\-]{0,61}[a-zA-Z0-9])?)*$`)
 
-// ValidateEmail validates an email address format
+// ValidateEmail validates an email address format. Internationalized domains
+// are accepted and checked in their punycode form; the local part must be ASCII.
 func ValidateEmail(email string) error {
 	if email == "" {
 		return errors.NewValidationError("email address cannot be empty", nil)
 	}
-	if !emailRegex.MatchString(email) {
+	normalized, err := NormalizeEmail(email)
+	if err != nil || !emailRegex.MatchString(normalized) {
+		return errors.NewValidationError("invalid email address format: "+email, nil)
+	}
+	return nil
+}
+
+// ValidateEmailSMTPUTF8 validates an email address allowing a UTF-8 local part
+// as permitted by RFC 6531. Only use this for paths that negotiate SMTPUTF8.
+func ValidateEmailSMTPUTF8(email string) error {
+	if email == "" {
+		return errors.NewValidationError("email address cannot be empty", nil)
+	}
+	normalized, err := NormalizeEmail(email)
+	if err != nil {
+		return errors.NewValidationError("invalid email address format: "+email, nil)
+	}
+	at := strings.LastIndex(normalized, "@")
+	local, domain := normalized[:at], normalized[at+1:]
+	for _, r := range local {
+		if r == '@' || unicode.IsSpace(r) || unicode.IsControl(r) {
+			return errors.NewValidationError("invalid email address format: "+email, nil)
+		}
+	}
+	if !emailRegex.MatchString("x@" + domain) {
 		return errors.NewValidationError("invalid email address format: "+email, nil)
 	}
 	return nil
@@ -84,12 +112,19 @@ func ValidatePageLimit(limit int32) error {
 	return nil
 }
 
-// ValidateDomainName validates a domain name format
+// ValidateDomainName validates a domain name format. Internationalized
+// domains are validated in their punycode form.
 func ValidateDomainName(domain string) error {
 	if domain == "" {
 		return errors.NewValidationError("domain name cannot be empty", nil)
 	}
 
+	ascii, err := ToASCIIDomain(domain)
+	if err != nil {
+		return err
+	}
+	domain = ascii
+
 	if len(domain) > 253 {
 		return errors.NewValidationError("domain name too long (max 253 characters)", nil)
 	}
@@ -103,13 +138,13 @@ func ValidateDomainName(domain string) error {
 
 // ValidateOutputFormat validates output format values
 func ValidateOutputFormat(value string) error {
-	validFormats := []string{"table", "json", "csv", "plain"}
+	validFormats := []string{"table", "json", "jsonl", "csv", "plain"}
 	for _, format := range validFormats {
 		if value == format {
 			return nil
 		}
 	}
-	return errors.NewValidationError("invalid output format: "+value+" (must be one of: table, json, csv, plain)", nil)
+	return errors.NewValidationError("invalid output format: "+value+" (must be one of: table, json, jsonl, csv, plain)", nil)
 }
 
 // ValidateLogLevel validates log level values
@@ -123,6 +158,28 @@ func ValidateLogLevel(value string) error {
 	return errors.NewValidationError("invalid log level: "+value+" (must be one of: debug, info, warn, error)", nil)
 }
 
+// ValidatePagerMode validates pager mode values
+func ValidatePagerMode(value string) error {
+	validModes := []string{"auto", "always", "never"}
+	for _, mode := range validModes {
+		if value == mode {
+			return nil
+		}
+	}
+	return errors.NewValidationError("invalid pager mode: "+value+" (must be one of: auto, always, never)", nil)
+}
+
+// ValidateColorMode validates the --color mode
+func ValidateColorMode(value string) error {
+	validModes := []string{"auto", "always", "never"}
+	for _, mode := range validModes {
+		if value == mode {
+			return nil
+		}
+	}
+	return errors.NewValidationError("invalid color mode: "+value+" (must be one of: auto, always, never)", nil)
+}
+
 // ValidateBooleanString validates boolean string values
 func ValidateBooleanString(value string) error {
 	if value != "true" && value != "false" {
@@ -0,0 +1,207 @@
+package webhooks
+
+import (
+	"github.com/AhaSend/ahasend-go/api"
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+)
+
+// EventType describes an event a webhook can subscribe to. The list below is
+// the single mapping between event keys and the On* subscription fields of the
+// SDK models, so create, update, display and coverage reporting stay in sync.
+type EventType struct {
+	Key         string
+	Name        string // the event type name in delivered payloads
+	Description string
+
+	webhookField func(*responses.Webhook) bool
+	createField  func(*requests.CreateWebhookRequest) *bool
+	updateField  func(*requests.UpdateWebhookRequest) **bool
+	listFilter   func(*api.GetWebhooksParams) **bool
+}
+
+var eventTypes = []EventType{
+	{
+		Key:          "reception",
+		Name:         "message.reception",
+		Description:  "Message reception (inbound email received)",
+		webhookField: func(w *responses.Webhook) bool { return w.OnReception },
+		createField:  func(r *requests.CreateWebhookRequest) *bool { return &r.OnReception },
+		updateField:  func(r *requests.UpdateWebhookRequest) **bool { return &r.OnReception },
+		listFilter:   func(p *api.GetWebhooksParams) **bool { return &p.OnReception },
+	},
+	{
+		Key:          "delivered",
+		Name:         "message.delivered",
+		Description:  "Message delivered successfully",
+		webhookField: func(w *responses.Webhook) bool { return w.OnDelivered },
+		createField:  func(r *requests.CreateWebhookRequest) *bool { return &r.OnDelivered },
+		updateField:  func(r *requests.UpdateWebhookRequest) **bool { return &r.OnDelivered },
+		listFilter:   func(p *api.GetWebhooksParams) **bool { return &p.OnDelivered },
+	},
+	{
+		Key:          "transient_error",
+		Name:         "message.transient_error",
+		Description:  "Temporary delivery failure (will retry)",
+		webhookField: func(w *responses.Webhook) bool { return w.OnTransientError },
+		createField:  func(r *requests.CreateWebhookRequest) *bool { return &r.OnTransientError },
+		updateField:  func(r *requests.UpdateWebhookRequest) **bool { return &r.OnTransientError },
+		listFilter:   func(p *api.GetWebhooksParams) **bool { return &p.OnTransientError },
+	},
+	{
+		Key:          "failed",
+		Name:         "message.failed",
+		Description:  "Permanent delivery failure",
+		webhookField: func(w *responses.Webhook) bool { return w.OnFailed },
+		createField:  func(r *requests.CreateWebhookRequest) *bool { return &r.OnFailed },
+		updateField:  func(r *requests.UpdateWebhookRequest) **bool { return &r.OnFailed },
+		listFilter:   func(p *api.GetWebhooksParams) **bool { return &p.OnFailed },
+	},
+	{
+		Key:          "bounced",
+		Name:         "message.bounced",
+		Description:  "Message bounced (invalid recipient)",
+		webhookField: func(w *responses.Webhook) bool { return w.OnBounced },
+		createField:  func(r *requests.CreateWebhookRequest) *bool { return &r.OnBounced },
+		updateField:  func(r *requests.UpdateWebhookRequest) **bool { return &r.OnBounced },
+		listFilter:   func(p *api.GetWebhooksParams) **bool { return &p.OnBounced },
+	},
+	{
+		Key:          "suppressed",
+		Name:         "message.suppressed",
+		Description:  "Message suppressed (recipient opted out)",
+		webhookField: func(w *responses.Webhook) bool { return w.OnSuppressed },
+		createField:  func(r *requests.CreateWebhookRequest) *bool { return &r.OnSuppressed },
+		updateField:  func(r *requests.UpdateWebhookRequest) **bool { return &r.OnSuppressed },
+		listFilter:   func(p *api.GetWebhooksParams) **bool { return &p.OnSuppressed },
+	},
+	{
+		Key:          "opened",
+		Name:         "message.opened",
+		Description:  "Message opened by recipient",
+		webhookField: func(w *responses.Webhook) bool { return w.OnOpened },
+		createField:  func(r *requests.CreateWebhookRequest) *bool { return &r.OnOpened },
+		updateField:  func(r *requests.UpdateWebhookRequest) **bool { return &r.OnOpened },
+		listFilter:   func(p *api.GetWebhooksParams) **bool { return &p.OnOpened },
+	},
+	{
+		Key:          "clicked",
+		Name:         "message.clicked",
+		Description:  "Link clicked in message",
+		webhookField: func(w *responses.Webhook) bool { return w.OnClicked },
+		createField:  func(r *requests.CreateWebhookRequest) *bool { return &r.OnClicked },
+		updateField:  func(r *requests.UpdateWebhookRequest) **bool { return &r.OnClicked },
+		listFilter:   func(p *api.GetWebhooksParams) **bool { return &p.OnClicked },
+	},
+	{
+		Key:          "suppression_created",
+		Name:         "suppression.created",
+		Description:  "New suppression entry created",
+		webhookField: func(w *responses.Webhook) bool { return w.OnSuppressionCreated },
+		createField:  func(r *requests.CreateWebhookRequest) *bool { return &r.OnSuppressionCreated },
+		updateField:  func(r *requests.UpdateWebhookRequest) **bool { return &r.OnSuppressionCreated },
+		listFilter:   func(p *api.GetWebhooksParams) **bool { return &p.OnSuppressionCreated },
+	},
+	{
+		Key:          "dns_error",
+		Name:         "domain.dns_error",
+		Description:  "DNS configuration error for domain",
+		webhookField: func(w *responses.Webhook) bool { return w.OnDNSError },
+		createField:  func(r *requests.CreateWebhookRequest) *bool { return &r.OnDnsError },
+		updateField:  func(r *requests.UpdateWebhookRequest) **bool { return &r.OnDnsError },
+		listFilter:   func(p *api.GetWebhooksParams) **bool { return &p.OnDnsError },
+	},
+}
+
+// EventTypes returns all webhook event types in display order
+func EventTypes() []EventType {
+	return append([]EventType(nil), eventTypes...)
+}
+
+// EventKeys returns the keys of all webhook event types
+func EventKeys() []string {
+	keys := make([]string, len(eventTypes))
+	for i, event := range eventTypes {
+		keys[i] = event.Key
+	}
+	return keys
+}
+
+// lookupEvent finds an event type by key
+func lookupEvent(key string) (EventType, bool) {
+	for _, event := range eventTypes {
+		if event.Key == key {
+			return event, true
+		}
+	}
+	return EventType{}, false
+}
+
+// IsValidEvent reports whether key names a known event type
+func IsValidEvent(key string) bool {
+	_, ok := lookupEvent(key)
+	return ok
+}
+
+// IsSubscribed reports whether the webhook subscribes to the event
+func IsSubscribed(webhook *responses.Webhook, key string) bool {
+	event, ok := lookupEvent(key)
+	return ok && event.webhookField(webhook)
+}
+
+// SubscribedEvents returns the keys of the events a webhook subscribes to
+func SubscribedEvents(webhook *responses.Webhook) []string {
+	var events []string
+	for _, event := range eventTypes {
+		if event.webhookField(webhook) {
+			events = append(events, event.Key)
+		}
+	}
+	return events
+}
+
+// SetCreateEvents subscribes a create request to the given events. Unknown
+// keys are ignored; validate them first with IsValidEvent.
+func SetCreateEvents(req *requests.CreateWebhookRequest, keys []string) {
+	for _, key := range keys {
+		if event, ok := lookupEvent(key); ok {
+			*event.createField(req) = true
+		}
+	}
+}
+
+// SetAllCreateEvents subscribes a create request to every event
+func SetAllCreateEvents(req *requests.CreateWebhookRequest) {
+	SetCreateEvents(req, EventKeys())
+}
+
+// SetUpdateEvents sets the given events on an update request to enabled.
+// Unknown keys are ignored; validate them first with IsValidEvent.
+func SetUpdateEvents(req *requests.UpdateWebhookRequest, keys []string, enabled bool) {
+	for _, key := range keys {
+		if event, ok := lookupEvent(key); ok {
+			value := enabled
+			*event.updateField(req) = &value
+		}
+	}
+}
+
+// SetListFilter restricts a webhook list request to webhooks subscribed to
+// the event. Unknown keys are ignored; validate them first with IsValidEvent.
+func SetListFilter(params *api.GetWebhooksParams, key string) {
+	if event, ok := lookupEvent(key); ok {
+		subscribed := true
+		*event.listFilter(params) = &subscribed
+	}
+}
+
+// EventKeyForName returns the key of the event type with a payload name,
+// e.g. "delivered" for "message.delivered"
+func EventKeyForName(name string) (string, bool) {
+	for _, event := range eventTypes {
+		if event.Name == name {
+			return event.Key, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,95 @@
+package webhooks
+
+import (
+	"testing"
+
+	"github.com/AhaSend/ahasend-go/api"
+	"github.com/AhaSend/ahasend-go/models/requests"
+	"github.com/AhaSend/ahasend-go/models/responses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventTypes_MappingRoundTrips(t *testing.T) {
+	keys := EventKeys()
+	require.Len(t, keys, 10)
+
+	for _, key := range keys {
+		t.Run(key, func(t *testing.T) {
+			var create requests.CreateWebhookRequest
+			SetCreateEvents(&create, []string{key})
+
+			var update requests.UpdateWebhookRequest
+			SetUpdateEvents(&update, []string{key}, true)
+
+			// Each event must map to exactly one distinct field on every model
+			webhook := responses.Webhook{
+				OnReception:          create.OnReception,
+				OnDelivered:          create.OnDelivered,
+				OnTransientError:     create.OnTransientError,
+				OnFailed:             create.OnFailed,
+				OnBounced:            create.OnBounced,
+				OnSuppressed:         create.OnSuppressed,
+				OnOpened:             create.OnOpened,
+				OnClicked:            create.OnClicked,
+				OnSuppressionCreated: create.OnSuppressionCreated,
+				OnDNSError:           create.OnDnsError,
+			}
+			assert.Equal(t, []string{key}, SubscribedEvents(&webhook))
+			assert.True(t, IsSubscribed(&webhook, key))
+
+			set := 0
+			for _, field := range []*bool{update.OnReception, update.OnDelivered, update.OnTransientError,
+				update.OnFailed, update.OnBounced, update.OnSuppressed, update.OnOpened, update.OnClicked,
+				update.OnSuppressionCreated, update.OnDnsError} {
+				if field != nil {
+					assert.True(t, *field)
+					set++
+				}
+			}
+			assert.Equal(t, 1, set)
+
+			var params api.GetWebhooksParams
+			SetListFilter(&params, key)
+			filters := []*bool{params.OnReception, params.OnDelivered, params.OnTransientError,
+				params.OnFailed, params.OnBounced, params.OnSuppressed, params.OnOpened, params.OnClicked,
+				params.OnSuppressionCreated, params.OnDnsError}
+			for i, field := range filters {
+				if i == eventIndex(key) {
+					require.NotNil(t, field)
+					assert.True(t, *field)
+				} else {
+					assert.Nil(t, field)
+				}
+			}
+		})
+	}
+}
+
+// eventIndex returns the position of key in display order, which is also
+// the order of the On* fields of the SDK models
+func eventIndex(key string) int {
+	for i, k := range EventKeys() {
+		if k == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestSetUpdateEvents_Clear(t *testing.T) {
+	var req requests.UpdateWebhookRequest
+	SetUpdateEvents(&req, EventKeys(), false)
+	SetUpdateEvents(&req, []string{"bounced"}, true)
+
+	require.NotNil(t, req.OnBounced)
+	assert.True(t, *req.OnBounced)
+	require.NotNil(t, req.OnDnsError)
+	assert.False(t, *req.OnDnsError)
+}
+
+func TestIsValidEvent(t *testing.T) {
+	assert.True(t, IsValidEvent("suppression_created"))
+	assert.False(t, IsValidEvent("unknown"))
+	assert.False(t, IsSubscribed(&responses.Webhook{}, "unknown"))
+}
@@ -0,0 +1,196 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+
+	"github.com/AhaSend/ahasend-cli/internal/bytesize"
+	"github.com/AhaSend/ahasend-cli/internal/client"
+)
+
+// FailureCategory buckets a failed delivery by what went wrong. A timeout,
+// a refused connection and a 413 need different fixes, so the category is
+// shown wherever a delivery failure is reported.
+type FailureCategory string
+
+const (
+	FailureTimeout     FailureCategory = "timeout"    // no response in time
+	FailureConnection  FailureCategory = "connection" // the endpoint could not be reached
+	FailureTLS         FailureCategory = "tls"        // the TLS handshake or certificate check failed
+	FailureClientError FailureCategory = "4xx"        // the endpoint rejected the request
+	FailureServerError FailureCategory = "5xx"        // the endpoint failed handling the request
+	FailureOther       FailureCategory = "other"      // anything else, e.g. an unexpected 3xx
+)
+
+// Message fragments of errors that do not keep their type, such as errors
+// recorded as strings. Timeouts are checked first, as a TLS handshake
+// timeout is fixed like any other timeout.
+var (
+	timeoutFragments    = []string{"timeout", "timed out", "deadline exceeded"}
+	tlsFragments        = []string{"x509:", "tls:", "certificate", "handshake failure"}
+	connectionFragments = []string{
+		"connection refused", "connection reset", "no such host", "no route to host",
+		"network is unreachable", "broken pipe", "dial tcp", "eof",
+	}
+)
+
+// CategorizeFailure returns the category of a delivery that answered with
+// statusCode (zero when no response was received) or failed with err. It
+// returns an empty category for a delivery that succeeded.
+func CategorizeFailure(statusCode int, err error) FailureCategory {
+	if err == nil {
+		return categorizeStatus(statusCode)
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return FailureTimeout
+	}
+
+	var (
+		recordErr    tls.RecordHeaderError
+		verifyErr    *tls.CertificateVerificationError
+		authorityErr x509.UnknownAuthorityError
+		hostnameErr  x509.HostnameError
+		invalidErr   x509.CertificateInvalidError
+	)
+	if errors.As(err, &recordErr) || errors.As(err, &verifyErr) || errors.As(err, &authorityErr) ||
+		errors.As(err, &hostnameErr) || errors.As(err, &invalidErr) {
+		return FailureTLS
+	}
+
+	return CategorizeFailureMessage(statusCode, err.Error())
+}
+
+// CategorizeFailureMessage categorizes a failure from its error message, for
+// failures that were recorded as text
+func CategorizeFailureMessage(statusCode int, message string) FailureCategory {
+	if message == "" {
+		return categorizeStatus(statusCode)
+	}
+
+	message = strings.ToLower(message)
+	switch {
+	case containsAny(message, timeoutFragments):
+		return FailureTimeout
+	case containsAny(message, tlsFragments):
+		return FailureTLS
+	case containsAny(message, connectionFragments):
+		return FailureConnection
+	case statusCode != 0:
+		return categorizeStatus(statusCode)
+	default:
+		return FailureOther
+	}
+}
+
+func categorizeStatus(statusCode int) FailureCategory {
+	switch {
+	case statusCode == 0 || (statusCode >= 200 && statusCode < 300):
+		return ""
+	case statusCode >= 400 && statusCode < 500:
+		return FailureClientError
+	case statusCode >= 500 && statusCode < 600:
+		return FailureServerError
+	default:
+		return FailureOther
+	}
+}
+
+func containsAny(s string, fragments []string) bool {
+	for _, fragment := range fragments {
+		if strings.Contains(s, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// Hint suggests where to look for the fix of a failure in the category.
+// statusCode refines the hint for client errors.
+func (c FailureCategory) Hint(statusCode int) string {
+	switch c {
+	case FailureTimeout:
+		return "the endpoint did not answer in time; acknowledge events before doing slow work"
+	case FailureConnection:
+		return "the endpoint could not be reached; check the URL and that the server is running"
+	case FailureTLS:
+		return "the TLS handshake failed; check the endpoint's certificate"
+	case FailureClientError:
+		switch statusCode {
+		case http.StatusRequestEntityTooLarge:
+			return "the payload is larger than the endpoint accepts; raise its request body limit"
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return "the endpoint refused the request; check its signature verification and secret"
+		case http.StatusNotFound, http.StatusMethodNotAllowed:
+			return "the endpoint does not accept POST requests at this URL; check the path"
+		default:
+			return "the endpoint rejected the request; check its validation of the payload"
+		}
+	case FailureServerError:
+		return "the endpoint failed while handling the event; check its logs"
+	case FailureOther:
+		return "the delivery failed; see the error for details"
+	default:
+		return ""
+	}
+}
+
+// ForwardResult is the outcome of forwarding one event to a local endpoint
+type ForwardResult struct {
+	PayloadSize int
+	Duration    time.Duration
+	StatusCode  int // zero when no response was received
+	Err         error
+}
+
+// Failure returns the failure category of the forward, or an empty category
+// when the endpoint accepted the event
+func (r ForwardResult) Failure() FailureCategory {
+	return CategorizeFailure(r.StatusCode, r.Err)
+}
+
+// PrintForwardResult writes the outcome of forwarding an event to w: the
+// status, payload size and round-trip time, and for a failure its category
+// and a hint at the fix. Payloads over warnPayloadSize bytes are flagged.
+func PrintForwardResult(w io.Writer, event *client.Event, result ForwardResult, warnPayloadSize int) {
+	var eventType string
+	if dataMap, ok := event.Data.(map[string]interface{}); ok {
+		eventType, _ = dataMap["type"].(string)
+	}
+	size := FormatSize(result.PayloadSize)
+	roundTrip := result.Duration.Round(time.Millisecond)
+
+	// Forwards finish concurrently, so each result is written at once
+	var out strings.Builder
+	if failure := result.Failure(); failure != "" {
+		detail := fmt.Sprintf("HTTP %d", result.StatusCode)
+		if result.Err != nil {
+			detail = result.Err.Error()
+		}
+		out.WriteString(color.RedString("  ↳ %s forward failed [%s]: %s · %s · %s\n", eventType, failure, detail, size, roundTrip))
+		out.WriteString(color.YellowString("    %s\n", failure.Hint(result.StatusCode)))
+	} else {
+		out.WriteString(color.GreenString("  ↳ %s forwarded: HTTP %d · %s · %s\n", eventType, result.StatusCode, size, roundTrip))
+	}
+	if warnPayloadSize > 0 && result.PayloadSize > warnPayloadSize {
+		out.WriteString(color.YellowString("  ⚠️  Payload is %s, larger than %s; make sure the endpoint accepts bodies this large\n",
+			size, FormatSize(warnPayloadSize)))
+	}
+	fmt.Fprint(w, out.String())
+}
+
+// FormatSize formats a payload size in bytes, KB or MB
+func FormatSize(bytes int) string {
+	return bytesize.Format(bytes)
+}
@@ -0,0 +1,152 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AhaSend/ahasend-cli/internal/client"
+)
+
+func TestCategorizeFailureMessage(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		message    string
+		want       FailureCategory
+	}{
+		{"delivered", 200, "", ""},
+		{"no response and no error", 0, "", ""},
+		{"client timeout", 0, `Post "http://localhost:3000/webhook": context deadline exceeded (Client.Timeout exceeded while awaiting headers)`, FailureTimeout},
+		{"dial timeout", 0, "dial tcp 10.0.0.1:443: i/o timeout", FailureTimeout},
+		{"TLS handshake timeout", 0, "net/http: TLS handshake timeout", FailureTimeout},
+		{"refused", 0, `Post "http://localhost:3000/webhook": dial tcp [::1]:3000: connect: connection refused`, FailureConnection},
+		{"unknown host", 0, "dial tcp: lookup hooks.invalid: no such host", FailureConnection},
+		{"reset", 0, "read tcp 127.0.0.1:5000->127.0.0.1:3000: read: connection reset by peer", FailureConnection},
+		{"closed early", 0, `Post "http://localhost:3000/webhook": EOF`, FailureConnection},
+		{"unknown authority", 0, "tls: failed to verify certificate: x509: certificate signed by unknown authority", FailureTLS},
+		{"expired", 0, "x509: certificate has expired or is not yet valid", FailureTLS},
+		{"plain HTTP to TLS", 0, "http: server gave HTTP response to HTTPS client", FailureOther},
+		{"payload too large", 413, "413 Request Entity Too Large", FailureClientError},
+		{"unauthorized", 401, "401 Unauthorized", FailureClientError},
+		{"bad gateway", 502, "502 Bad Gateway", FailureServerError},
+		{"redirect", 302, "302 Found", FailureOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, CategorizeFailureMessage(tt.statusCode, tt.message))
+		})
+	}
+}
+
+func TestCategorizeFailure(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		err        error
+		want       FailureCategory
+	}{
+		{"accepted", 204, nil, ""},
+		{"status only", 500, nil, FailureServerError},
+		{"deadline", 0, fmt.Errorf("forward: %w", context.DeadlineExceeded), FailureTimeout},
+		{"net timeout", 0, &net.OpError{Op: "dial", Net: "tcp", Err: timeoutError{}}, FailureTimeout},
+		{"unknown authority", 0, fmt.Errorf("forward: %w", x509.UnknownAuthorityError{}), FailureTLS},
+		{"hostname mismatch", 0, x509.HostnameError{Certificate: &x509.Certificate{}, Host: "localhost"}, FailureTLS},
+		{"untyped", 0, errors.New("connect: connection refused"), FailureConnection},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, CategorizeFailure(tt.statusCode, tt.err))
+		})
+	}
+}
+
+func TestCategorizeFailure_RealErrors(t *testing.T) {
+	t.Run("timeout", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(200 * time.Millisecond)
+		}))
+		defer server.Close()
+
+		_, err := (&http.Client{Timeout: 20 * time.Millisecond}).Post(server.URL, "application/json", nil)
+		require.Error(t, err)
+		assert.Equal(t, FailureTimeout, CategorizeFailure(0, err))
+	})
+
+	t.Run("connection refused", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		url := server.URL
+		server.Close()
+
+		_, err := http.Post(url, "application/json", nil)
+		require.Error(t, err)
+		assert.Equal(t, FailureConnection, CategorizeFailure(0, err))
+	})
+
+	t.Run("untrusted certificate", func(t *testing.T) {
+		server := httptest.NewUnstartedServer(http.NotFoundHandler())
+		server.Config.ErrorLog = log.New(io.Discard, "", 0)
+		server.StartTLS()
+		defer server.Close()
+
+		_, err := http.Post(server.URL, "application/json", nil)
+		require.Error(t, err)
+		assert.Equal(t, FailureTLS, CategorizeFailure(0, err))
+	})
+}
+
+func TestFailureCategory_Hint(t *testing.T) {
+	assert.Contains(t, FailureClientError.Hint(413), "request body limit")
+	assert.Contains(t, FailureClientError.Hint(401), "signature verification")
+	assert.Contains(t, FailureClientError.Hint(422), "rejected the request")
+	for _, category := range []FailureCategory{FailureTimeout, FailureConnection, FailureTLS, FailureServerError, FailureOther} {
+		assert.NotEmpty(t, category.Hint(0), category)
+	}
+	assert.Empty(t, FailureCategory("").Hint(0))
+}
+
+func TestFormatSize(t *testing.T) {
+	assert.Equal(t, "512 B", FormatSize(512))
+	assert.Equal(t, "1.5 KB", FormatSize(1536))
+	assert.Equal(t, "2.0 MB", FormatSize(2*1024*1024))
+}
+
+func TestPrintForwardResult(t *testing.T) {
+	prev := color.NoColor
+	color.NoColor = true
+	t.Cleanup(func() { color.NoColor = prev })
+
+	event := &client.Event{Data: map[string]interface{}{"type": "message.delivered"}}
+
+	var out bytes.Buffer
+	PrintForwardResult(&out, event, ForwardResult{PayloadSize: 512, Duration: 12 * time.Millisecond, StatusCode: 200}, 0)
+	assert.Equal(t, "  ↳ message.delivered forwarded: HTTP 200 · 512 B · 12ms\n", out.String())
+
+	out.Reset()
+	PrintForwardResult(&out, event, ForwardResult{PayloadSize: 2048, Duration: time.Second, StatusCode: 413}, 1024)
+	assert.Equal(t, "  ↳ message.delivered forward failed [4xx]: HTTP 413 · 2.0 KB · 1s\n"+
+		"    the payload is larger than the endpoint accepts; raise its request body limit\n"+
+		"  ⚠️  Payload is 2.0 KB, larger than 1.0 KB; make sure the endpoint accepts bodies this large\n", out.String())
+}
+
+// timeoutError is a net.Error that timed out
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "operation timed out" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
@@ -0,0 +1,202 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	sdkwebhooks "github.com/AhaSend/ahasend-go/webhooks"
+)
+
+// RouteEventName is the event type of the payloads routes post for inbound
+// email
+const RouteEventName = "message.routing"
+
+// RouteSettings are the processing options of an inbound route
+type RouteSettings struct {
+	Attachments      bool
+	Headers          bool
+	GroupByMessageID bool
+	StripReplies     bool
+}
+
+// RouteOption describes a processing option of inbound routes and what it
+// does to the payloads the route posts. The list below is the single source
+// for route explanations and example payloads, so both stay in sync.
+type RouteOption struct {
+	Key      string // the route setting, e.g. "strip_replies"
+	Name     string
+	Enabled  string // what the route does with the option on
+	Disabled string // and with it off
+	Fields   []string
+
+	setting func(RouteSettings) bool
+	example func(*sdkwebhooks.RouteEventData) // applied to the example payload when on
+}
+
+var routeOptions = []RouteOption{
+	{
+		Key:      "attachments",
+		Name:     "Include attachments",
+		Enabled:  "Attachments are included in the payload with their file name, content type, Content-ID and base64 encoded data.",
+		Disabled: "Attachments are dropped; the payload has no attachments field, so the files cannot be recovered from it.",
+		Fields:   []string{"attachments"},
+		setting:  func(s RouteSettings) bool { return s.Attachments },
+	},
+	{
+		Key:      "headers",
+		Name:     "Include headers",
+		Enabled:  "Every header of the email is included in the headers object, keyed by header name.",
+		Disabled: "Only the parsed fields are included (from, to, cc, subject, message_id, in_reply_to, references...); the headers field is absent.",
+		Fields:   []string{"headers"},
+		setting:  func(s RouteSettings) bool { return s.Headers },
+	},
+	{
+		Key:      "group_by_message_id",
+		Name:     "Group by message ID",
+		Enabled:  "Emails of one conversation, linked by their In-Reply-To and References headers, are grouped: message_id is the Message-ID of the thread's first email, so it identifies the thread rather than the single email.",
+		Disabled: "Every email stands alone: message_id is the email's own Message-ID, and threads have to be rebuilt from in_reply_to and references.",
+		setting:  func(s RouteSettings) bool { return s.GroupByMessageID },
+		example: func(data *sdkwebhooks.RouteEventData) {
+			data.MessageID = *data.InReplyTo
+		},
+	},
+	{
+		Key:      "strip_replies",
+		Name:     "Strip replies",
+		Enabled:  "The text of a reply without the quoted earlier messages is extracted into reply_from_plain_body; plain_body and html_body still hold the full email.",
+		Disabled: "No reply text is extracted; plain_body and html_body include the quoted thread and reply_from_plain_body is absent.",
+		Fields:   []string{"reply_from_plain_body"},
+		setting:  func(s RouteSettings) bool { return s.StripReplies },
+	},
+}
+
+// RouteOptions returns the route processing options in display order
+func RouteOptions() []RouteOption {
+	return routeOptions
+}
+
+// On reports whether the option is enabled in settings
+func (o RouteOption) On(settings RouteSettings) bool {
+	return o.setting(settings)
+}
+
+// RoutePayloadField is a data field of route payloads and whether a route
+// with given settings includes it
+type RoutePayloadField struct {
+	Name    string `json:"name"`
+	Present bool   `json:"present"`
+	Option  string `json:"option,omitempty"` // the option the field depends on
+}
+
+// RoutePayloadFields lists the data fields of route payloads in schema order
+func RoutePayloadFields(settings RouteSettings) []RoutePayloadField {
+	controlled := make(map[string]RouteOption)
+	for _, option := range routeOptions {
+		for _, field := range option.Fields {
+			controlled[field] = option
+		}
+	}
+
+	var fields []RoutePayloadField
+	for _, name := range orderedJSONFields(reflect.TypeOf(sdkwebhooks.RouteEventData{})) {
+		field := RoutePayloadField{Name: name, Present: true}
+		if option, ok := controlled[name]; ok {
+			field.Option = option.Key
+			field.Present = option.On(settings)
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// ExampleRoutePayload returns the skeleton of a payload posted by a route
+// with the given settings, built from the SDK's route event type. Fields the
+// settings leave out are removed, so the example has the shape of a real
+// delivery. Values are placeholders using documentation domains.
+func ExampleRoutePayload(routeID string, settings RouteSettings) (map[string]interface{}, error) {
+	replyTo := "ada.lovelace@example.com"
+	cc := "grace.hopper@example.org"
+	date := "Mon, 02 Mar 2026 10:15:00 +0000"
+	inReplyTo := "<2f6c1e0a@mail.example.com>"
+	autoSubmitted := "no"
+	reply := "Thanks, that fixed it."
+	contentID := "logo"
+	spamScore := float32(0.4)
+
+	data := sdkwebhooks.RouteEventData{
+		ID:                 "0c4a7a3e-9a57-4f5c-8a8e-2b5b1f6f7d10",
+		From:               "Ada Lovelace <ada.lovelace@example.com>",
+		ReplyTo:            &replyTo,
+		To:                 "support@inbound.example.com",
+		Subject:            "Re: Your ticket #12345",
+		MessageID:          "<7d1f3b2c@example.com>",
+		Size:               48213,
+		SpamScore:          &spamScore,
+		CC:                 &cc,
+		Date:               &date,
+		InReplyTo:          &inReplyTo,
+		References:         &inReplyTo,
+		AutoSubmitted:      &autoSubmitted,
+		HTMLBody:           "<p>Thanks, that fixed it.</p><blockquote>...</blockquote>",
+		PlainBody:          "Thanks, that fixed it.\n\nOn Mon, 2 Mar 2026, Support wrote:\n> ...",
+		ReplyFromPlainBody: &reply,
+		Attachments: []sdkwebhooks.RouteAttachment{{
+			Filename:    "screenshot.png",
+			ContentType: "image/png",
+			ContentID:   &contentID,
+			Data:        "<base64 encoded data>",
+		}},
+		Headers: map[string]string{
+			"Message-ID":   "<7d1f3b2c@example.com>",
+			"X-Mailer":     "Example Mail 1.0",
+			"Content-Type": "multipart/mixed; boundary=\"b1\"",
+		},
+	}
+	for _, option := range routeOptions {
+		if option.example != nil && option.On(settings) {
+			option.example(&data)
+		}
+	}
+
+	event := sdkwebhooks.RouteMessageEvent{
+		Type:      RouteEventName,
+		Timestamp: time.Date(2026, 3, 2, 10, 15, 2, 0, time.UTC),
+		Data:      data,
+	}
+	if routeID != "" {
+		event.RouteID = &routeID
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode example %s payload: %w", RouteEventName, err)
+	}
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("failed to decode example %s payload: %w", RouteEventName, err)
+	}
+
+	fields := payload["data"].(map[string]interface{})
+	for _, field := range RoutePayloadFields(settings) {
+		if !field.Present {
+			delete(fields, field.Name)
+		}
+	}
+	return payload, nil
+}
+
+// orderedJSONFields returns the JSON names of the fields of struct type t in
+// declaration order
+func orderedJSONFields(t reflect.Type) []string {
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
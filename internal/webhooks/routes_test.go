@@ -0,0 +1,100 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	sdkwebhooks "github.com/AhaSend/ahasend-go/webhooks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouteOptions_MatchSchema(t *testing.T) {
+	fields := jsonFields(reflect.TypeOf(sdkwebhooks.RouteEventData{}))
+	settingsType := reflect.TypeOf(RouteSettings{})
+
+	// Every option controls real payload fields and has one setting
+	require.Len(t, RouteOptions(), settingsType.NumField())
+	for _, option := range RouteOptions() {
+		assert.NotEmpty(t, option.Enabled, option.Key)
+		assert.NotEmpty(t, option.Disabled, option.Key)
+		for _, field := range option.Fields {
+			assert.Contains(t, fields, field, "%s controls an unknown payload field", option.Key)
+		}
+	}
+}
+
+func TestRoutePayloadFields(t *testing.T) {
+	present := func(settings RouteSettings) map[string]bool {
+		result := make(map[string]bool)
+		for _, field := range RoutePayloadFields(settings) {
+			result[field.Name] = field.Present
+		}
+		return result
+	}
+
+	none := present(RouteSettings{})
+	assert.False(t, none["attachments"])
+	assert.False(t, none["headers"])
+	assert.False(t, none["reply_from_plain_body"])
+	assert.True(t, none["plain_body"])
+	assert.True(t, none["message_id"])
+
+	all := present(RouteSettings{Attachments: true, Headers: true, GroupByMessageID: true, StripReplies: true})
+	for name, ok := range all {
+		assert.True(t, ok, name)
+	}
+
+	fields := RoutePayloadFields(RouteSettings{})
+	assert.Equal(t, "id", fields[0].Name, "schema order")
+	for _, field := range fields {
+		if field.Name == "headers" {
+			assert.Equal(t, "headers", field.Option)
+		}
+	}
+}
+
+func TestExampleRoutePayload(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings RouteSettings
+		present  []string
+		absent   []string
+	}{
+		{"defaults", RouteSettings{}, []string{"plain_body", "html_body", "message_id"}, []string{"attachments", "headers", "reply_from_plain_body"}},
+		{"attachments", RouteSettings{Attachments: true}, []string{"attachments"}, []string{"headers"}},
+		{"headers", RouteSettings{Headers: true}, []string{"headers"}, []string{"attachments"}},
+		{"strip replies", RouteSettings{StripReplies: true}, []string{"reply_from_plain_body", "plain_body"}, []string{"attachments"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, err := ExampleRoutePayload("route-1", tt.settings)
+			require.NoError(t, err)
+			assert.Equal(t, RouteEventName, payload["type"])
+			assert.Equal(t, "route-1", payload["route_id"])
+
+			data := payload["data"].(map[string]interface{})
+			for _, field := range tt.present {
+				assert.Contains(t, data, field)
+			}
+			for _, field := range tt.absent {
+				assert.NotContains(t, data, field)
+			}
+
+			// The example decodes into the SDK's route event without unknown fields
+			require.NoError(t, checkFields(payload, reflect.TypeOf(sdkwebhooks.RouteMessageEvent{}), ""))
+			body, err := json.Marshal(payload)
+			require.NoError(t, err)
+			var event sdkwebhooks.RouteMessageEvent
+			require.NoError(t, json.Unmarshal(body, &event))
+		})
+	}
+
+	grouped, err := ExampleRoutePayload("", RouteSettings{GroupByMessageID: true})
+	require.NoError(t, err)
+	assert.NotContains(t, grouped, "route_id")
+	data := grouped["data"].(map[string]interface{})
+	assert.Equal(t, data["in_reply_to"], data["message_id"], "grouped payloads carry the thread's Message-ID")
+}
@@ -0,0 +1,166 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	sdkwebhooks "github.com/AhaSend/ahasend-go/webhooks"
+)
+
+// eventPayloadTypes maps event keys to the SDK types their payloads decode
+// into
+var eventPayloadTypes = map[string]reflect.Type{
+	"reception":           reflect.TypeOf(sdkwebhooks.MessageReceptionEvent{}),
+	"delivered":           reflect.TypeOf(sdkwebhooks.MessageDeliveredEvent{}),
+	"transient_error":     reflect.TypeOf(sdkwebhooks.MessageTransientErrorEvent{}),
+	"failed":              reflect.TypeOf(sdkwebhooks.MessageFailedEvent{}),
+	"bounced":             reflect.TypeOf(sdkwebhooks.MessageBouncedEvent{}),
+	"suppressed":          reflect.TypeOf(sdkwebhooks.MessageSuppressedEvent{}),
+	"opened":              reflect.TypeOf(sdkwebhooks.MessageOpenedEvent{}),
+	"clicked":             reflect.TypeOf(sdkwebhooks.MessageClickedEvent{}),
+	"suppression_created": reflect.TypeOf(sdkwebhooks.SuppressionCreatedEvent{}),
+	"dns_error":           reflect.TypeOf(sdkwebhooks.DomainDNSErrorEvent{}),
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// ValidatePayload checks a decoded payload against the SDK event type of an
+// event key: every field must be part of the event's schema and hold a value
+// of the right type. It catches typos in payload overrides before anything
+// is delivered.
+func ValidatePayload(key string, payload map[string]interface{}) error {
+	event, ok := lookupEvent(key)
+	if !ok {
+		return fmt.Errorf("unknown event type: %s", key)
+	}
+	payloadType, ok := eventPayloadTypes[key]
+	if !ok {
+		return fmt.Errorf("no schema for %s events", event.Name)
+	}
+
+	if err := checkFields(payload, payloadType, ""); err != nil {
+		return fmt.Errorf("payload does not match the %s event schema: %w", event.Name, err)
+	}
+
+	// Decoding into the SDK type checks the value types
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s payload: %w", event.Name, err)
+	}
+	if err := json.Unmarshal(body, reflect.New(payloadType).Interface()); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return fmt.Errorf("payload does not match the %s event schema: %s must be %s, not a %s",
+				event.Name, typeErr.Field, describeType(typeErr.Type), typeErr.Value)
+		}
+		return fmt.Errorf("payload does not match the %s event schema: %w", event.Name, err)
+	}
+	return nil
+}
+
+// checkFields reports the first field of object that the struct type t does
+// not have, descending into nested objects and arrays of objects
+func checkFields(object map[string]interface{}, t reflect.Type, prefix string) error {
+	fields := jsonFields(t)
+	for _, key := range sortedKeys(object) {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		fieldType, ok := fields[key]
+		if !ok {
+			return fmt.Errorf("unknown field %s; valid fields%s are: %s", path, inPrefix(prefix), strings.Join(sortedKeys(fields), ", "))
+		}
+		switch value := object[key].(type) {
+		case map[string]interface{}:
+			if structType(fieldType) != nil {
+				if err := checkFields(value, structType(fieldType), path); err != nil {
+					return err
+				}
+			}
+		case []interface{}:
+			if fieldType.Kind() != reflect.Slice || structType(fieldType.Elem()) == nil {
+				continue
+			}
+			for i, item := range value {
+				if element, ok := item.(map[string]interface{}); ok {
+					if err := checkFields(element, structType(fieldType.Elem()), path+"."+strconv.Itoa(i)); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// jsonFields returns the JSON field names of a struct type and their types
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+// structType returns the struct type behind t, or nil when t is not an
+// object in JSON
+func structType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t == timeType {
+		return nil
+	}
+	return t
+}
+
+// describeType names the JSON kind a Go type decodes from
+func describeType(t reflect.Type) string {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch {
+	case t == timeType:
+		return "an RFC 3339 timestamp string"
+	case t.Kind() == reflect.String:
+		return "a string"
+	case t.Kind() == reflect.Bool:
+		return "a boolean"
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Float64:
+		return "a number"
+	case t.Kind() == reflect.Slice:
+		return "an array"
+	}
+	return "an object"
+}
+
+func inPrefix(prefix string) string {
+	if prefix == "" {
+		return ""
+	}
+	return " in " + prefix
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
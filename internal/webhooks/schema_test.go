@@ -0,0 +1,60 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePayload_GeneratedEvents(t *testing.T) {
+	for _, key := range EventKeys() {
+		event := generateAll(t, 7, key, 1)[0]
+		var payload map[string]interface{}
+		require.NoError(t, json.Unmarshal(event.Payload, &payload))
+		assert.NoError(t, ValidatePayload(key, payload), key)
+	}
+}
+
+func TestValidatePayload_Errors(t *testing.T) {
+	event := generateAll(t, 7, "delivered", 1)[0]
+	decode := func() map[string]interface{} {
+		var payload map[string]interface{}
+		require.NoError(t, json.Unmarshal(event.Payload, &payload))
+		return payload
+	}
+
+	payload := decode()
+	payload["data"].(map[string]interface{})["recipent"] = "x@example.com"
+	err := ValidatePayload("delivered", payload)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "payload does not match the message.delivered event schema: unknown field data.recipent; valid fields in data are: account_id, event, from, id")
+
+	payload = decode()
+	payload["typ"] = "x"
+	err = ValidatePayload("delivered", payload)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown field typ; valid fields are: data, timestamp, type, webhook_id")
+
+	payload = decode()
+	payload["data"].(map[string]interface{})["subject"] = 42.0
+	err = ValidatePayload("delivered", payload)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "data.subject must be a string, not a number")
+
+	payload = decode()
+	payload["timestamp"] = "yesterday"
+	assert.Error(t, ValidatePayload("delivered", payload))
+
+	assert.Error(t, ValidatePayload("unknown", payload))
+}
+
+func TestEventKeyForName(t *testing.T) {
+	key, ok := EventKeyForName("message.delivered")
+	assert.True(t, ok)
+	assert.Equal(t, "delivered", key)
+
+	_, ok = EventKeyForName("delivered")
+	assert.False(t, ok)
+}
@@ -14,6 +14,7 @@ import (
 
 type Signer struct {
 	secret []byte
+	offset time.Duration // added to the signing time by SigningTime
 }
 
 func NewSigner(secret string) *Signer {
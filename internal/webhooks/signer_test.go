@@ -2,6 +2,7 @@ package webhooks
 
 import (
 	"encoding/base64"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -268,3 +269,87 @@ func TestGenerateRandomString(t *testing.T) {
 func isValidRandomChar(c rune) bool {
 	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
 }
+
+func TestCheckTimestamp_Boundaries(t *testing.T) {
+	now := time.Unix(1772446502, 0)
+	tolerance := 5 * time.Minute
+
+	tests := []struct {
+		name   string
+		offset time.Duration
+		valid  bool
+	}{
+		{"in step", 0, true},
+		{"exactly tolerance behind", -tolerance, true},
+		{"exactly tolerance ahead", tolerance, true},
+		{"a second past tolerance behind", -tolerance - time.Second, false},
+		{"a second past tolerance ahead", tolerance + time.Second, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckTimestamp(now.Add(tt.offset), now, tolerance)
+			if tt.valid {
+				assert.NoError(t, err)
+				return
+			}
+			var timestampErr *TimestampError
+			require.ErrorAs(t, err, &timestampErr)
+			assert.Equal(t, tt.offset, timestampErr.Skew)
+			assert.Equal(t, tolerance, timestampErr.Tolerance)
+		})
+	}
+
+	err := CheckTimestamp(now.Add(-7*time.Minute), now, tolerance)
+	assert.EqualError(t, err, "webhook timestamp is 7m0s behind the local clock, outside the allowed window of ±5m0s; "+
+		"check that both clocks are synchronized, or widen the window with --tolerance")
+	err = CheckTimestamp(now.Add(90*time.Second), now, time.Minute)
+	assert.Contains(t, err.Error(), "1m30s ahead of the local clock, outside the allowed window of ±1m0s")
+}
+
+func TestSigner_Verify(t *testing.T) {
+	signer := NewSigner("aha-whsec-test1234567890")
+	now := time.Unix(1772446502, 0)
+	payload := []byte(`{"type":"message.delivered"}`)
+	signedAt := now.Add(-2 * time.Minute)
+	signature, err := signer.Sign("msg-1", signedAt, payload)
+	require.NoError(t, err)
+	timestamp := fmt.Sprintf("%d", signedAt.Unix())
+
+	assert.NoError(t, signer.Verify("msg-1", timestamp, signature, payload, now, DefaultTolerance))
+	assert.NoError(t, signer.Verify("msg-1", timestamp, "v1,b3RoZXI= "+signature, payload, now, DefaultTolerance), "any listed signature may match")
+
+	var timestampErr *TimestampError
+	require.ErrorAs(t, signer.Verify("msg-1", timestamp, signature, payload, now, time.Minute), &timestampErr)
+	assert.Equal(t, -2*time.Minute, timestampErr.Skew)
+
+	err = signer.Verify("msg-1", timestamp, signature, []byte(`{}`), now, DefaultTolerance)
+	assert.ErrorIs(t, err, ErrSignatureMismatch)
+	err = NewSigner("other").Verify("msg-1", timestamp, signature, payload, now, DefaultTolerance)
+	assert.ErrorContains(t, err, "no signature matches")
+	err = signer.Verify("msg-1", "2026-03-02", signature, payload, now, DefaultTolerance)
+	assert.ErrorContains(t, err, "expected Unix seconds")
+}
+
+func TestSigner_Match(t *testing.T) {
+	signer := NewSigner("aha-whsec-test1234567890")
+	signedAt := time.Unix(1772446502, 0)
+	payload := []byte(`{"type":"message.delivered"}`)
+	signature, err := signer.Sign("msg-1", signedAt, payload)
+	require.NoError(t, err)
+
+	expected, matched, err := signer.Match("msg-1", signedAt, "v1,b3RoZXI= "+signature, payload)
+	require.NoError(t, err)
+	assert.True(t, matched)
+	assert.Equal(t, signature, expected)
+
+	expected, matched, err = signer.Match("msg-1", signedAt, signature, append(payload, '\n'))
+	require.NoError(t, err)
+	assert.False(t, matched)
+	assert.NotEqual(t, signature, expected, "the computed signature is returned on a mismatch too")
+}
+
+func TestSigner_WithTimestampOffset(t *testing.T) {
+	signer := NewSigner("secret").WithTimestampOffset(-10 * time.Minute)
+	assert.InDelta(t, float64(time.Now().Add(-10*time.Minute).Unix()), float64(signer.SigningTime().Unix()), 1)
+	assert.InDelta(t, float64(time.Now().Unix()), float64(NewSigner("secret").SigningTime().Unix()), 1)
+}
@@ -0,0 +1,264 @@
+package webhooks
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	random "math/rand/v2"
+
+	sdkwebhooks "github.com/AhaSend/ahasend-go/webhooks"
+	"github.com/google/uuid"
+)
+
+// Sample data for simulated events. Addresses, hosts and IPs use the
+// domains and networks reserved for documentation (RFC 2606 and RFC 5737),
+// so simulated events never point at real people or servers.
+var (
+	sampleFirstNames = []string{"ada", "grace", "alan", "linus", "margaret", "dennis", "barbara", "ken", "frances", "edsger"}
+	sampleLastNames  = []string{"lovelace", "hopper", "turing", "torvalds", "hamilton", "ritchie", "liskov", "thompson", "allen", "dijkstra"}
+	sampleDomains    = []string{"example.com", "example.org", "example.net"}
+	sampleSenders    = []string{"notifications@mail.example.com", "billing@mail.example.com", "hello@news.example.org"}
+	sampleSubjects   = []string{
+		"Welcome to Example",
+		"Your receipt for order #%d",
+		"Reset your password",
+		"Your weekly digest",
+		"Invoice %d is ready",
+		"Confirm your email address",
+	}
+	sampleLinks = []string{
+		"https://example.com/account",
+		"https://example.com/orders/%d",
+		"https://example.org/blog/whats-new",
+		"https://example.net/unsubscribe",
+	}
+	sampleUserAgents = []string{
+		"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Safari/605.1.15",
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36",
+		"Mozilla/5.0 (iPhone; CPU iPhone OS 17_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Mobile/15E148",
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:125.0) Gecko/20100101 Firefox/125.0",
+	}
+	sampleNetworks = []string{"192.0.2", "198.51.100", "203.0.113"}
+
+	// sampleBounceClassifications are the suppression reasons of bounced
+	// and complained recipients
+	sampleBounceClassifications = []string{
+		"Hard bounce: 550 5.1.1 The email account that you tried to reach does not exist",
+		"Hard bounce: 550 5.1.2 Host or domain name not found",
+		"Hard bounce: 554 5.7.1 Message rejected due to content restrictions",
+		"Soft bounce: 452 4.2.2 The email account that you tried to reach is over quota",
+		"Soft bounce: 421 4.7.0 Try again later, closing connection",
+		"Spam complaint",
+		"Unsubscribed",
+	}
+	sampleSuppressionDurations = []time.Duration{30 * 24 * time.Hour, 90 * 24 * time.Hour, 365 * 24 * time.Hour}
+)
+
+// SampleEvent is a simulated webhook event, ready to be signed and delivered
+type SampleEvent struct {
+	MsgID     string // the webhook-id header of the delivery
+	Type      string
+	Timestamp time.Time
+	Payload   []byte
+}
+
+// Simulator generates plausible webhook events for testing receivers. The
+// payloads are the event types of the SDK's webhooks package, so they parse
+// like real deliveries. Everything is derived from the seed: simulators with
+// the same seed and base time generate identical events.
+type Simulator struct {
+	source    *random.ChaCha8
+	rng       *random.Rand
+	now       time.Time
+	accountID string
+	webhookID string
+}
+
+// NewSimulator creates a simulator. Event timestamps fall in the five
+// minutes before now, and message events carry webhookID when it is set.
+func NewSimulator(seed uint64, now time.Time, webhookID string) *Simulator {
+	var key [32]byte
+	binary.LittleEndian.PutUint64(key[:], seed)
+	source := random.NewChaCha8(key)
+
+	s := &Simulator{
+		source:    source,
+		rng:       random.New(source),
+		now:       now.UTC().Truncate(time.Second),
+		webhookID: webhookID,
+	}
+	s.accountID = s.uuid()
+	return s
+}
+
+// Generate creates a sample event for an event key (see EventKeys)
+func (s *Simulator) Generate(key string) (*SampleEvent, error) {
+	event, ok := lookupEvent(key)
+	if !ok {
+		return nil, fmt.Errorf("unknown event type: %s", key)
+	}
+
+	msgID := s.uuid()
+	timestamp := s.now.Add(-time.Duration(s.rng.IntN(300)) * time.Second)
+
+	var payload interface{}
+	switch event.Key {
+	case "reception", "delivered", "transient_error", "failed", "bounced", "suppressed", "opened":
+		payload = s.messageEvent(event.Name, timestamp)
+	case "clicked":
+		payload = s.clickedEvent(event.Name, timestamp)
+	case "suppression_created":
+		payload = s.suppressionEvent(event.Name, timestamp)
+	case "dns_error":
+		payload = s.dnsErrorEvent(event.Name, timestamp)
+	default:
+		return nil, fmt.Errorf("simulating %s events is not supported", key)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s event: %w", event.Name, err)
+	}
+	return &SampleEvent{MsgID: msgID, Type: event.Name, Timestamp: timestamp, Payload: body}, nil
+}
+
+// messageEvent builds the payload shared by the message.* events. All of
+// them have the same shape in the SDK, so the reception event type is used
+// for each; only opens carry the client details.
+func (s *Simulator) messageEvent(name string, timestamp time.Time) *sdkwebhooks.MessageReceptionEvent {
+	from := pick(s.rng, sampleSenders)
+	data := sdkwebhooks.MessageEventData{
+		AccountID:       s.accountID,
+		Event:           name,
+		From:            from,
+		Recipient:       s.address(),
+		Subject:         s.subject(),
+		MessageIDHeader: s.messageIDHeader(from),
+		ID:              s.uuid(),
+	}
+	if name == "message.opened" {
+		userAgent, ip, isBot := pick(s.rng, sampleUserAgents), s.ip(), fmt.Sprintf("%t", s.rng.IntN(10) == 0)
+		data.UserAgent, data.IP, data.IsBot = &userAgent, &ip, &isBot
+	}
+	return &sdkwebhooks.MessageReceptionEvent{
+		Type:      name,
+		WebhookID: s.webhookIDRef(),
+		Timestamp: timestamp,
+		Data:      data,
+	}
+}
+
+func (s *Simulator) clickedEvent(name string, timestamp time.Time) *sdkwebhooks.MessageClickedEvent {
+	from := pick(s.rng, sampleSenders)
+	link := pick(s.rng, sampleLinks)
+	if strings.Contains(link, "%d") {
+		link = fmt.Sprintf(link, 10000+s.rng.IntN(90000))
+	}
+	return &sdkwebhooks.MessageClickedEvent{
+		Type:      name,
+		Timestamp: timestamp,
+		Data: sdkwebhooks.MessageClickedEventData{
+			AccountID:       s.accountID,
+			Event:           name,
+			From:            from,
+			Recipient:       s.address(),
+			Subject:         s.subject(),
+			MessageIDHeader: s.messageIDHeader(from),
+			URL:             link,
+			UserAgent:       pick(s.rng, sampleUserAgents),
+			IP:              s.ip(),
+			ID:              s.uuid(),
+			IsBot:           s.rng.IntN(10) == 0,
+		},
+	}
+}
+
+func (s *Simulator) suppressionEvent(name string, timestamp time.Time) *sdkwebhooks.SuppressionCreatedEvent {
+	return &sdkwebhooks.SuppressionCreatedEvent{
+		Type:      name,
+		Timestamp: timestamp,
+		Data: sdkwebhooks.SuppressionEventData{
+			AccountID:     s.accountID,
+			Recipient:     s.address(),
+			CreatedAt:     timestamp,
+			ExpiresAt:     timestamp.Add(pick(s.rng, sampleSuppressionDurations)),
+			Reason:        pick(s.rng, sampleBounceClassifications),
+			SendingDomain: senderDomain(pick(s.rng, sampleSenders)),
+		},
+	}
+}
+
+// dnsErrorEvent reports a domain with at least one failing record
+func (s *Simulator) dnsErrorEvent(name string, timestamp time.Time) *sdkwebhooks.DomainDNSErrorEvent {
+	valid := [3]bool{s.rng.IntN(2) == 0, s.rng.IntN(2) == 0, s.rng.IntN(2) == 0}
+	valid[s.rng.IntN(len(valid))] = false
+	return &sdkwebhooks.DomainDNSErrorEvent{
+		Type:      name,
+		WebhookID: s.webhookIDRef(),
+		Timestamp: timestamp,
+		Data: sdkwebhooks.DomainEventData{
+			Domain:           senderDomain(pick(s.rng, sampleSenders)),
+			AccountID:        s.accountID,
+			SPFValid:         valid[0],
+			DKIMValid:        valid[1],
+			DMARCValid:       valid[2],
+			DNSLastCheckedAt: timestamp,
+		},
+	}
+}
+
+func (s *Simulator) uuid() string {
+	return uuid.Must(uuid.NewRandomFromReader(s.source)).String()
+}
+
+func (s *Simulator) address() string {
+	first, last := pick(s.rng, sampleFirstNames), pick(s.rng, sampleLastNames)
+	var local string
+	switch s.rng.IntN(3) {
+	case 0:
+		local = first + "." + last
+	case 1:
+		local = first[:1] + last
+	default:
+		local = fmt.Sprintf("%s%d", first, s.rng.IntN(100))
+	}
+	return local + "@" + pick(s.rng, sampleDomains)
+}
+
+func (s *Simulator) subject() string {
+	subject := pick(s.rng, sampleSubjects)
+	if strings.Contains(subject, "%d") {
+		subject = fmt.Sprintf(subject, 10000+s.rng.IntN(90000))
+	}
+	return subject
+}
+
+func (s *Simulator) messageIDHeader(from string) string {
+	id := make([]byte, 16)
+	s.source.Read(id)
+	return fmt.Sprintf("<%s@%s>", hex.EncodeToString(id), senderDomain(from))
+}
+
+func (s *Simulator) ip() string {
+	return fmt.Sprintf("%s.%d", pick(s.rng, sampleNetworks), 1+s.rng.IntN(254))
+}
+
+func (s *Simulator) webhookIDRef() *string {
+	if s.webhookID == "" {
+		return nil
+	}
+	webhookID := s.webhookID
+	return &webhookID
+}
+
+func senderDomain(address string) string {
+	return address[strings.LastIndex(address, "@")+1:]
+}
+
+func pick[T any](rng *random.Rand, values []T) T {
+	return values[rng.IntN(len(values))]
+}
@@ -0,0 +1,104 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	sdkwebhooks "github.com/AhaSend/ahasend-go/webhooks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var simulateNow = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func generateAll(t *testing.T, seed uint64, key string, count int) []*SampleEvent {
+	t.Helper()
+	simulator := NewSimulator(seed, simulateNow, "f4c0a7d2-0000-4000-8000-000000000001")
+	events := make([]*SampleEvent, count)
+	for i := range events {
+		event, err := simulator.Generate(key)
+		require.NoError(t, err)
+		events[i] = event
+	}
+	return events
+}
+
+func TestSimulator_Deterministic(t *testing.T) {
+	first := generateAll(t, 42, "bounced", 5)
+	second := generateAll(t, 42, "bounced", 5)
+	other := generateAll(t, 43, "bounced", 5)
+
+	for i := range first {
+		assert.Equal(t, first[i].MsgID, second[i].MsgID)
+		assert.Equal(t, string(first[i].Payload), string(second[i].Payload))
+		assert.NotEqual(t, string(first[i].Payload), string(other[i].Payload))
+	}
+	assert.NotEqual(t, first[0].MsgID, first[1].MsgID, "events of a run differ from each other")
+}
+
+// TestSimulator_EventsParseWithSDK signs every event type and checks that
+// the SDK's verifier accepts the signature and parses the payload
+func TestSimulator_EventsParseWithSDK(t *testing.T) {
+	signer := NewSigner("aha-whsec-test")
+	verifier, err := sdkwebhooks.NewWebhookVerifier("aha-whsec-test")
+	require.NoError(t, err)
+
+	for _, key := range EventKeys() {
+		t.Run(key, func(t *testing.T) {
+			event := generateAll(t, 7, key, 1)[0]
+			name, _ := lookupEvent(key)
+			assert.Equal(t, name.Name, event.Type)
+			assert.False(t, event.Timestamp.After(simulateNow))
+			assert.True(t, event.Timestamp.After(simulateNow.Add(-5*time.Minute)))
+
+			signedAt := time.Now()
+			signature, err := signer.Sign(event.MsgID, signedAt, event.Payload)
+			require.NoError(t, err)
+			headers := http.Header{}
+			headers.Set(sdkwebhooks.HeaderWebhookID, event.MsgID)
+			headers.Set(sdkwebhooks.HeaderWebhookTimestamp, strconv.FormatInt(signedAt.Unix(), 10))
+			headers.Set(sdkwebhooks.HeaderWebhookSignature, signature)
+
+			parsed, err := verifier.Parse(event.Payload, headers)
+			require.NoError(t, err)
+			assert.Equal(t, event.Type, parsed.GetType())
+			assert.True(t, event.Timestamp.Equal(parsed.GetTimestamp()))
+		})
+	}
+}
+
+func TestSimulator_PlausibleData(t *testing.T) {
+	for _, event := range generateAll(t, 1, "opened", 20) {
+		var parsed sdkwebhooks.MessageOpenedEvent
+		require.NoError(t, json.Unmarshal(event.Payload, &parsed))
+		assert.Equal(t, "message.opened", parsed.Data.Event)
+		assert.Regexp(t, `@example\.(com|org|net)$`, parsed.Data.Recipient)
+		assert.Regexp(t, `^<[0-9a-f]{32}@[a-z.]+example\.(com|org)>$`, parsed.Data.MessageIDHeader)
+		require.NotNil(t, parsed.Data.IP)
+		assert.Regexp(t, `^(192\.0\.2|198\.51\.100|203\.0\.113)\.\d+$`, *parsed.Data.IP)
+		require.NotNil(t, parsed.WebhookID)
+	}
+
+	for _, event := range generateAll(t, 1, "suppression_created", 20) {
+		var parsed sdkwebhooks.SuppressionCreatedEvent
+		require.NoError(t, json.Unmarshal(event.Payload, &parsed))
+		assert.Contains(t, sampleBounceClassifications, parsed.Data.Reason)
+		assert.True(t, parsed.Data.ExpiresAt.After(parsed.Data.CreatedAt))
+	}
+
+	for _, event := range generateAll(t, 1, "dns_error", 20) {
+		var parsed sdkwebhooks.DomainDNSErrorEvent
+		require.NoError(t, json.Unmarshal(event.Payload, &parsed))
+		assert.False(t, parsed.Data.SPFValid && parsed.Data.DKIMValid && parsed.Data.DMARCValid,
+			"a DNS error has at least one failing record")
+	}
+}
+
+func TestSimulator_UnknownEvent(t *testing.T) {
+	_, err := NewSimulator(1, simulateNow, "").Generate("message.bounced")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown event type")
+}
@@ -0,0 +1,114 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultTolerance is how far a webhook-timestamp may be from the local
+// clock, either way, before verification rejects it. It matches the replay
+// window of the standard-webhooks libraries.
+const DefaultTolerance = 5 * time.Minute
+
+// ErrSignatureMismatch is returned by Verify when none of the signatures
+// matches the payload
+var ErrSignatureMismatch = errors.New("no signature matches the payload; check the secret and that the payload is byte-for-byte what was signed")
+
+// TimestampError reports a webhook-timestamp outside the allowed window
+type TimestampError struct {
+	Skew      time.Duration // how far the timestamp is ahead of the local clock; negative when behind
+	Tolerance time.Duration
+}
+
+func (e *TimestampError) Error() string {
+	return fmt.Sprintf("webhook timestamp is %s the local clock, outside the allowed window of ±%s; "+
+		"check that both clocks are synchronized, or widen the window with --tolerance", DescribeSkew(e.Skew), e.Tolerance)
+}
+
+// DescribeSkew phrases how far a time is from the local clock, e.g.
+// "3m12s behind"
+func DescribeSkew(skew time.Duration) string {
+	switch {
+	case skew > 0:
+		return skew.String() + " ahead of"
+	case skew < 0:
+		return (-skew).String() + " behind"
+	default:
+		return "in step with"
+	}
+}
+
+// CheckTimestamp returns a *TimestampError when timestamp is more than
+// tolerance away from now. A timestamp exactly tolerance away is accepted.
+func CheckTimestamp(timestamp, now time.Time, tolerance time.Duration) error {
+	skew := timestamp.Sub(now)
+	if skew > tolerance || skew < -tolerance {
+		return &TimestampError{Skew: skew, Tolerance: tolerance}
+	}
+	return nil
+}
+
+// ParseTimestamp parses a webhook-timestamp header, in Unix seconds
+func ParseTimestamp(header string) (time.Time, error) {
+	seconds, err := strconv.ParseInt(strings.TrimSpace(header), 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid webhook timestamp %q: expected Unix seconds", header)
+	}
+	return time.Unix(seconds, 0), nil
+}
+
+// Verify checks a delivery the way a receiver does: the webhook-timestamp
+// must be within tolerance of now, and one of the space separated
+// signatures of the webhook-signature header must match the payload. The
+// header has second precision, so now is compared at that precision too.
+func (s *Signer) Verify(msgID, timestamp, signatures string, payload []byte, now time.Time, tolerance time.Duration) error {
+	signedAt, err := ParseTimestamp(timestamp)
+	if err != nil {
+		return err
+	}
+	if err := CheckTimestamp(signedAt, now.Truncate(time.Second), tolerance); err != nil {
+		return err
+	}
+
+	_, matched, err := s.Match(msgID, signedAt, signatures, payload)
+	if err != nil {
+		return err
+	}
+	if !matched {
+		return ErrSignatureMismatch
+	}
+	return nil
+}
+
+// Match signs payload and reports whether one of the space separated
+// signatures of a webhook-signature header equals the result, which it
+// returns as well
+func (s *Signer) Match(msgID string, signedAt time.Time, signatures string, payload []byte) (string, bool, error) {
+	expected, err := s.Sign(msgID, signedAt, payload)
+	if err != nil {
+		return "", false, err
+	}
+	for _, signature := range strings.Fields(signatures) {
+		if hmac.Equal([]byte(signature), []byte(expected)) {
+			return expected, true, nil
+		}
+	}
+	return expected, false, nil
+}
+
+// SigningTime returns the time to sign a delivery with: now, moved by the
+// signer's timestamp offset
+func (s *Signer) SigningTime() time.Time {
+	return time.Now().Add(s.offset)
+}
+
+// WithTimestampOffset makes the signer date its signatures offset from the
+// local clock, to test how receivers handle skewed timestamps
+func (s *Signer) WithTimestampOffset(offset time.Duration) *Signer {
+	s.offset = offset
+	return s
+}